@@ -67,13 +67,163 @@ func (x *AgentId) GetValue() string {
 	return ""
 }
 
+// AgentList is a paginated list of agents.
+type AgentList struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Total number of pages available.
+	TotalPages int32 `protobuf:"varint,1,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	// Agents in the current page, ordered by creation time.
+	Entries []*Agent `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Token for the next page, passed as page_token in the next request.
+	//
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentList) Reset() {
+	*x = AgentList{}
+	mi := &file_ai_stigmer_agentic_agent_v1_io_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentList) ProtoMessage() {}
+
+func (x *AgentList) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_agent_v1_io_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentList.ProtoReflect.Descriptor instead.
+func (*AgentList) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_agent_v1_io_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AgentList) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *AgentList) GetEntries() []*Agent {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *AgentList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ListAgentsRequest specifies parameters for listing agents.
+type ListAgentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of agents to return per page.
+	//
+	// Default: 100 (if not specified or 0). Maximum: 100.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token for pagination, obtained from previous response.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Filter by labels (optional).
+	//
+	// Limits results to agents whose metadata.labels contain ALL of the
+	// key-value pairs (AND logic). Keys and values must match exactly.
+	//
+	// Example: label_selector: {"team": "data", "env": "prod"}
+	LabelSelector map[string]string `protobuf:"bytes,3,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_ai_stigmer_agentic_agent_v1_io_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsRequest) ProtoMessage() {}
+
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_agent_v1_io_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_agent_v1_io_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListAgentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAgentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAgentsRequest) GetLabelSelector() map[string]string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return nil
+}
+
 var File_ai_stigmer_agentic_agent_v1_io_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_agent_v1_io_proto_rawDesc = "" +
 	"\n" +
-	"$ai/stigmer/agentic/agent/v1/io.proto\x12\x1bai.stigmer.agentic.agent.v1\x1a\x1bbuf/validate/validate.proto\"'\n" +
+	"$ai/stigmer/agentic/agent/v1/io.proto\x12\x1bai.stigmer.agentic.agent.v1\x1a%ai/stigmer/agentic/agent/v1/api.proto\x1a\x1bbuf/validate/validate.proto\"'\n" +
 	"\aAgentId\x12\x1c\n" +
-	"\x05value\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05valueB\x89\x02\n" +
+	"\x05value\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05value\"\x92\x01\n" +
+	"\tAgentList\x12\x1f\n" +
+	"\vtotal_pages\x18\x01 \x01(\x05R\n" +
+	"totalPages\x12<\n" +
+	"\aentries\x18\x02 \x03(\v2\".ai.stigmer.agentic.agent.v1.AgentR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\xfb\x01\n" +
+	"\x11ListAgentsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12h\n" +
+	"\x0elabel_selector\x18\x03 \x03(\v2A.ai.stigmer.agentic.agent.v1.ListAgentsRequest.LabelSelectorEntryR\rlabelSelector\x1a@\n" +
+	"\x12LabelSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x89\x02\n" +
 	"\x1fcom.ai.stigmer.agentic.agent.v1B\aIoProtoP\x01ZLgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1;agentv1\xa2\x02\x04ASAA\xaa\x02\x1bAi.Stigmer.Agentic.Agent.V1\xca\x02\x1bAi\\Stigmer\\Agentic\\Agent\\V1\xe2\x02'Ai\\Stigmer\\Agentic\\Agent\\V1\\GPBMetadata\xea\x02\x1fAi::Stigmer::Agentic::Agent::V1b\x06proto3"
 
 var (
@@ -88,16 +238,22 @@ func file_ai_stigmer_agentic_agent_v1_io_proto_rawDescGZIP() []byte {
 	return file_ai_stigmer_agentic_agent_v1_io_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_agent_v1_io_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_ai_stigmer_agentic_agent_v1_io_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_ai_stigmer_agentic_agent_v1_io_proto_goTypes = []any{
-	(*AgentId)(nil), // 0: ai.stigmer.agentic.agent.v1.AgentId
+	(*AgentId)(nil),           // 0: ai.stigmer.agentic.agent.v1.AgentId
+	(*AgentList)(nil),         // 1: ai.stigmer.agentic.agent.v1.AgentList
+	(*ListAgentsRequest)(nil), // 2: ai.stigmer.agentic.agent.v1.ListAgentsRequest
+	nil,                       // 3: ai.stigmer.agentic.agent.v1.ListAgentsRequest.LabelSelectorEntry
+	(*Agent)(nil),             // 4: ai.stigmer.agentic.agent.v1.Agent
 }
 var file_ai_stigmer_agentic_agent_v1_io_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	4, // 0: ai.stigmer.agentic.agent.v1.AgentList.entries:type_name -> ai.stigmer.agentic.agent.v1.Agent
+	3, // 1: ai.stigmer.agentic.agent.v1.ListAgentsRequest.label_selector:type_name -> ai.stigmer.agentic.agent.v1.ListAgentsRequest.LabelSelectorEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_agentic_agent_v1_io_proto_init() }
@@ -105,13 +261,14 @@ func file_ai_stigmer_agentic_agent_v1_io_proto_init() {
 	if File_ai_stigmer_agentic_agent_v1_io_proto != nil {
 		return
 	}
+	file_ai_stigmer_agentic_agent_v1_api_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_agent_v1_io_proto_rawDesc), len(file_ai_stigmer_agentic_agent_v1_io_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	AgentQueryController_Get_FullMethodName            = "/ai.stigmer.agentic.agent.v1.AgentQueryController/get"
 	AgentQueryController_GetByReference_FullMethodName = "/ai.stigmer.agentic.agent.v1.AgentQueryController/getByReference"
+	AgentQueryController_List_FullMethodName           = "/ai.stigmer.agentic.agent.v1.AgentQueryController/list"
 )
 
 // AgentQueryControllerClient is the client API for AgentQueryController service.
@@ -34,6 +35,11 @@ type AgentQueryControllerClient interface {
 	Get(ctx context.Context, in *AgentId, opts ...grpc.CallOption) (*Agent, error)
 	// Custom authorization in handler
 	GetByReference(ctx context.Context, in *apiresource.ApiResourceReference, opts ...grpc.CallOption) (*Agent, error)
+	// List agents with pagination, optionally filtered by label selector.
+	//
+	// Example: all agents where team=data
+	// { page_size: 50, label_selector: { "team": "data" } }
+	List(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*AgentList, error)
 }
 
 type agentQueryControllerClient struct {
@@ -64,6 +70,16 @@ func (c *agentQueryControllerClient) GetByReference(ctx context.Context, in *api
 	return out, nil
 }
 
+func (c *agentQueryControllerClient) List(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*AgentList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AgentList)
+	err := c.cc.Invoke(ctx, AgentQueryController_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentQueryControllerServer is the server API for AgentQueryController service.
 // All implementations should embed UnimplementedAgentQueryControllerServer
 // for forward compatibility.
@@ -74,6 +90,11 @@ type AgentQueryControllerServer interface {
 	Get(context.Context, *AgentId) (*Agent, error)
 	// Custom authorization in handler
 	GetByReference(context.Context, *apiresource.ApiResourceReference) (*Agent, error)
+	// List agents with pagination, optionally filtered by label selector.
+	//
+	// Example: all agents where team=data
+	// { page_size: 50, label_selector: { "team": "data" } }
+	List(context.Context, *ListAgentsRequest) (*AgentList, error)
 }
 
 // UnimplementedAgentQueryControllerServer should be embedded to have
@@ -89,6 +110,9 @@ func (UnimplementedAgentQueryControllerServer) Get(context.Context, *AgentId) (*
 func (UnimplementedAgentQueryControllerServer) GetByReference(context.Context, *apiresource.ApiResourceReference) (*Agent, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByReference not implemented")
 }
+func (UnimplementedAgentQueryControllerServer) List(context.Context, *ListAgentsRequest) (*AgentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
 func (UnimplementedAgentQueryControllerServer) testEmbeddedByValue() {}
 
 // UnsafeAgentQueryControllerServer may be embedded to opt out of forward compatibility for this service.
@@ -145,6 +169,24 @@ func _AgentQueryController_GetByReference_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentQueryController_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentQueryControllerServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentQueryController_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentQueryControllerServer).List(ctx, req.(*ListAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AgentQueryController_ServiceDesc is the grpc.ServiceDesc for AgentQueryController service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -160,6 +202,10 @@ var AgentQueryController_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "getByReference",
 			Handler:    _AgentQueryController_GetByReference_Handler,
 		},
+		{
+			MethodName: "list",
+			Handler:    _AgentQueryController_List_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ai/stigmer/agentic/agent/v1/query.proto",
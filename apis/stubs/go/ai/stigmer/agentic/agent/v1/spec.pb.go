@@ -12,6 +12,7 @@ import (
 	apiresource "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,6 +25,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// MemoryStrategy defines how an agent retains conversation history between turns.
+type MemoryStrategy int32
+
+const (
+	MemoryStrategy_MEMORY_STRATEGY_UNSPECIFIED MemoryStrategy = 0 // Full session history (default)
+	MemoryStrategy_MEMORY_NONE                 MemoryStrategy = 1 // Stateless: every execution starts from an empty history
+	MemoryStrategy_MEMORY_WINDOW               MemoryStrategy = 2 // Keep only the most recent window_turns turns
+	MemoryStrategy_MEMORY_SUMMARIZING          MemoryStrategy = 3 // Summarize older history once max_tokens is exceeded
+)
+
+// Enum value maps for MemoryStrategy.
+var (
+	MemoryStrategy_name = map[int32]string{
+		0: "MEMORY_STRATEGY_UNSPECIFIED",
+		1: "MEMORY_NONE",
+		2: "MEMORY_WINDOW",
+		3: "MEMORY_SUMMARIZING",
+	}
+	MemoryStrategy_value = map[string]int32{
+		"MEMORY_STRATEGY_UNSPECIFIED": 0,
+		"MEMORY_NONE":                 1,
+		"MEMORY_WINDOW":               2,
+		"MEMORY_SUMMARIZING":          3,
+	}
+)
+
+func (x MemoryStrategy) Enum() *MemoryStrategy {
+	p := new(MemoryStrategy)
+	*p = x
+	return p
+}
+
+func (x MemoryStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MemoryStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_enumTypes[0].Descriptor()
+}
+
+func (MemoryStrategy) Type() protoreflect.EnumType {
+	return &file_ai_stigmer_agentic_agent_v1_spec_proto_enumTypes[0]
+}
+
+func (x MemoryStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MemoryStrategy.Descriptor instead.
+func (MemoryStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{0}
+}
+
 // AgentSpec defines the configurable properties of an AI agent.
 // This is the "Template" layer - immutable logic that declares requirements.
 type AgentSpec struct {
@@ -43,7 +97,26 @@ type AgentSpec struct {
 	SubAgents []*SubAgent `protobuf:"bytes,6,rep,name=sub_agents,json=subAgents,proto3" json:"sub_agents,omitempty"`
 	// Environment variables required by the agent.
 	// Uses the shared EnvironmentSpec for consistent env var handling.
-	EnvSpec       *v1.EnvironmentSpec `protobuf:"bytes,7,opt,name=env_spec,json=envSpec,proto3" json:"env_spec,omitempty"`
+	EnvSpec *v1.EnvironmentSpec `protobuf:"bytes,7,opt,name=env_spec,json=envSpec,proto3" json:"env_spec,omitempty"`
+	// Conversation memory configuration (optional).
+	// Controls how much history the agent keeps between turns of a session.
+	// When unset, the full session history is kept.
+	Memory *MemoryConfig `protobuf:"bytes,8,opt,name=memory,proto3" json:"memory,omitempty"`
+	// JSON Schema for the agent's final response (optional).
+	// When set, the agent is asked to answer with a JSON object conforming to
+	// the schema, and workflow AGENT_CALL tasks validate the response and
+	// expose the parsed object as the task output.
+	OutputSchema *structpb.Struct `protobuf:"bytes,9,opt,name=output_schema,json=outputSchema,proto3" json:"output_schema,omitempty"`
+	// Agent-wide tool policy (optional).
+	// Applies to every tool the agent can call, whichever MCP server provides it.
+	ToolPolicy *ToolPolicy `protobuf:"bytes,10,opt,name=tool_policy,json=toolPolicy,proto3" json:"tool_policy,omitempty"`
+	// Localized display texts keyed by BCP-47 language tag (e.g. "de", "fr-CA").
+	// description remains the default, used for languages without an entry.
+	Localizations map[string]*AgentLocalization `protobuf:"bytes,11,rep,name=localizations,proto3" json:"localizations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Resource limits of each execution of the agent (optional).
+	// An execution exceeding a limit is stopped with phase
+	// EXECUTION_LIMIT_EXCEEDED.
+	Limits        *AgentLimits `protobuf:"bytes,12,opt,name=limits,proto3" json:"limits,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -127,8 +200,212 @@ func (x *AgentSpec) GetEnvSpec() *v1.EnvironmentSpec {
 	return nil
 }
 
+func (x *AgentSpec) GetMemory() *MemoryConfig {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *AgentSpec) GetOutputSchema() *structpb.Struct {
+	if x != nil {
+		return x.OutputSchema
+	}
+	return nil
+}
+
+func (x *AgentSpec) GetToolPolicy() *ToolPolicy {
+	if x != nil {
+		return x.ToolPolicy
+	}
+	return nil
+}
+
+func (x *AgentSpec) GetLocalizations() map[string]*AgentLocalization {
+	if x != nil {
+		return x.Localizations
+	}
+	return nil
+}
+
+func (x *AgentSpec) GetLimits() *AgentLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+// AgentLocalization holds the display texts of an agent in one language.
+type AgentLocalization struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Localized description, with the same limit as the base description.
+	Description   string `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentLocalization) Reset() {
+	*x = AgentLocalization{}
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentLocalization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentLocalization) ProtoMessage() {}
+
+func (x *AgentLocalization) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentLocalization.ProtoReflect.Descriptor instead.
+func (*AgentLocalization) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AgentLocalization) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// ToolPolicy restricts the tools an agent may call by name.
+//
+// Patterns are tool names with "*" (any characters) and "?" (one character)
+// wildcards, e.g. "delete_*". Denied tools win over confirmed ones.
+type ToolPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tools removed from the agent's tool list.
+	Deny []string `protobuf:"bytes,1,rep,name=deny,proto3" json:"deny,omitempty"`
+	// Tools that pause the execution for human approval before each call.
+	Confirm       []string `protobuf:"bytes,2,rep,name=confirm,proto3" json:"confirm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolPolicy) Reset() {
+	*x = ToolPolicy{}
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolPolicy) ProtoMessage() {}
+
+func (x *ToolPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolPolicy.ProtoReflect.Descriptor instead.
+func (*ToolPolicy) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ToolPolicy) GetDeny() []string {
+	if x != nil {
+		return x.Deny
+	}
+	return nil
+}
+
+func (x *ToolPolicy) GetConfirm() []string {
+	if x != nil {
+		return x.Confirm
+	}
+	return nil
+}
+
+// MemoryConfig configures conversation memory for an agent.
+type MemoryConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Memory strategy to apply.
+	Strategy MemoryStrategy `protobuf:"varint,1,opt,name=strategy,proto3,enum=ai.stigmer.agentic.agent.v1.MemoryStrategy" json:"strategy,omitempty"`
+	// Number of most recent turns to keep (MEMORY_WINDOW only).
+	WindowTurns int32 `protobuf:"varint,2,opt,name=window_turns,json=windowTurns,proto3" json:"window_turns,omitempty"`
+	// Token budget before older history is summarized (MEMORY_SUMMARIZING only).
+	MaxTokens     int32 `protobuf:"varint,3,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemoryConfig) Reset() {
+	*x = MemoryConfig{}
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemoryConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryConfig) ProtoMessage() {}
+
+func (x *MemoryConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryConfig.ProtoReflect.Descriptor instead.
+func (*MemoryConfig) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MemoryConfig) GetStrategy() MemoryStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return MemoryStrategy_MEMORY_STRATEGY_UNSPECIFIED
+}
+
+func (x *MemoryConfig) GetWindowTurns() int32 {
+	if x != nil {
+		return x.WindowTurns
+	}
+	return 0
+}
+
+func (x *MemoryConfig) GetMaxTokens() int32 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
 // SubAgent defines a sub-agent that can be delegated to.
-// Sub-agents are defined inline within the parent agent spec.
+// Sub-agents are either defined inline within the parent agent spec or
+// reference a deployed AgentInstance through agent_instance_ref.
 type SubAgent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Name of the sub-agent.
@@ -136,20 +413,31 @@ type SubAgent struct {
 	// Description of what this sub-agent does.
 	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
 	// Behavior instructions for this sub-agent.
+	// Required (at least 10 characters) unless agent_instance_ref is set.
 	Instructions string `protobuf:"bytes,3,opt,name=instructions,proto3" json:"instructions,omitempty"`
 	// MCP server names this sub-agent can use (references McpServerDefinition.name).
 	McpServers []string `protobuf:"bytes,4,rep,name=mcp_servers,json=mcpServers,proto3" json:"mcp_servers,omitempty"`
 	// Tool selections for each MCP server.
 	McpToolSelections map[string]*McpToolSelection `protobuf:"bytes,5,rep,name=mcp_tool_selections,json=mcpToolSelections,proto3" json:"mcp_tool_selections,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// References to Skill resources for this sub-agent's knowledge.
-	SkillRefs     []*apiresource.ApiResourceReference `protobuf:"bytes,6,rep,name=skill_refs,json=skillRefs,proto3" json:"skill_refs,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	SkillRefs []*apiresource.ApiResourceReference `protobuf:"bytes,6,rep,name=skill_refs,json=skillRefs,proto3" json:"skill_refs,omitempty"`
+	// MCP servers defined locally on this sub-agent.
+	// Unlike mcp_servers (which references servers defined on the parent agent),
+	// these definitions are owned by the sub-agent. Names must not collide with
+	// referenced parent servers.
+	McpServerDefinitions []*McpServerDefinition `protobuf:"bytes,7,rep,name=mcp_server_definitions,json=mcpServerDefinitions,proto3" json:"mcp_server_definitions,omitempty"`
+	// Deployed AgentInstance this sub-agent delegates to, instead of an inline
+	// definition. The version pins the instance: empty or "latest" for the
+	// current version, a tag such as "stable", or an exact version hash.
+	// Resolved when the parent agent executes.
+	AgentInstanceRef *apiresource.ApiResourceReference `protobuf:"bytes,8,opt,name=agent_instance_ref,json=agentInstanceRef,proto3" json:"agent_instance_ref,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *SubAgent) Reset() {
 	*x = SubAgent{}
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[1]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -161,7 +449,7 @@ func (x *SubAgent) String() string {
 func (*SubAgent) ProtoMessage() {}
 
 func (x *SubAgent) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[1]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -174,7 +462,7 @@ func (x *SubAgent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubAgent.ProtoReflect.Descriptor instead.
 func (*SubAgent) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{1}
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SubAgent) GetName() string {
@@ -219,6 +507,20 @@ func (x *SubAgent) GetSkillRefs() []*apiresource.ApiResourceReference {
 	return nil
 }
 
+func (x *SubAgent) GetMcpServerDefinitions() []*McpServerDefinition {
+	if x != nil {
+		return x.McpServerDefinitions
+	}
+	return nil
+}
+
+func (x *SubAgent) GetAgentInstanceRef() *apiresource.ApiResourceReference {
+	if x != nil {
+		return x.AgentInstanceRef
+	}
+	return nil
+}
+
 // McpToolSelection defines which tools from an MCP server are enabled.
 type McpToolSelection struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -230,7 +532,7 @@ type McpToolSelection struct {
 
 func (x *McpToolSelection) Reset() {
 	*x = McpToolSelection{}
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[2]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -242,7 +544,7 @@ func (x *McpToolSelection) String() string {
 func (*McpToolSelection) ProtoMessage() {}
 
 func (x *McpToolSelection) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[2]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -255,7 +557,7 @@ func (x *McpToolSelection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use McpToolSelection.ProtoReflect.Descriptor instead.
 func (*McpToolSelection) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{2}
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *McpToolSelection) GetEnabledTools() []string {
@@ -303,7 +605,7 @@ type McpServerDefinition struct {
 
 func (x *McpServerDefinition) Reset() {
 	*x = McpServerDefinition{}
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[3]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -315,7 +617,7 @@ func (x *McpServerDefinition) String() string {
 func (*McpServerDefinition) ProtoMessage() {}
 
 func (x *McpServerDefinition) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[3]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,7 +630,7 @@ func (x *McpServerDefinition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use McpServerDefinition.ProtoReflect.Descriptor instead.
 func (*McpServerDefinition) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{3}
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *McpServerDefinition) GetName() string {
@@ -426,7 +728,7 @@ type StdioServer struct {
 
 func (x *StdioServer) Reset() {
 	*x = StdioServer{}
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[4]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -438,7 +740,7 @@ func (x *StdioServer) String() string {
 func (*StdioServer) ProtoMessage() {}
 
 func (x *StdioServer) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[4]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,7 +753,7 @@ func (x *StdioServer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StdioServer.ProtoReflect.Descriptor instead.
 func (*StdioServer) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{4}
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *StdioServer) GetCommand() string {
@@ -498,13 +800,17 @@ type HttpServer struct {
 	QueryParams map[string]string `protobuf:"bytes,3,rep,name=query_params,json=queryParams,proto3" json:"query_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Timeout for HTTP requests in seconds (default: 30).
 	TimeoutSeconds int32 `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// OAuth2 authentication (optional).
+	// When set, the runtime fetches an access token before connecting and
+	// refreshes it before it expires, sending it as "Authorization: Bearer".
+	Auth          *OAuth2ClientCredentials `protobuf:"bytes,5,opt,name=auth,proto3" json:"auth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *HttpServer) Reset() {
 	*x = HttpServer{}
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[5]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -516,7 +822,7 @@ func (x *HttpServer) String() string {
 func (*HttpServer) ProtoMessage() {}
 
 func (x *HttpServer) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[5]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -529,7 +835,7 @@ func (x *HttpServer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HttpServer.ProtoReflect.Descriptor instead.
 func (*HttpServer) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{5}
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *HttpServer) GetUrl() string {
@@ -560,6 +866,95 @@ func (x *HttpServer) GetTimeoutSeconds() int32 {
 	return 0
 }
 
+func (x *HttpServer) GetAuth() *OAuth2ClientCredentials {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+// OAuth2ClientCredentials configures the OAuth2 client-credentials grant for
+// an HTTP MCP server.
+//
+// The client ID and secret are never stored in the spec. Both fields name
+// environment variables that are resolved at runtime from the AgentInstance's
+// environment, like ${VAR_NAME} header placeholders.
+type OAuth2ClientCredentials struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Token endpoint of the authorization server.
+	// Example: "https://auth.example.com/oauth2/token"
+	TokenUrl string `protobuf:"bytes,1,opt,name=token_url,json=tokenUrl,proto3" json:"token_url,omitempty"`
+	// Environment variable holding the client ID.
+	// Example: "MCP_CLIENT_ID"
+	ClientIdEnv string `protobuf:"bytes,2,opt,name=client_id_env,json=clientIdEnv,proto3" json:"client_id_env,omitempty"`
+	// Environment variable holding the client secret.
+	// Example: "MCP_CLIENT_SECRET"
+	ClientSecretEnv string `protobuf:"bytes,3,opt,name=client_secret_env,json=clientSecretEnv,proto3" json:"client_secret_env,omitempty"`
+	// Scopes to request (optional).
+	// Example: ["mcp.read", "mcp.write"]
+	Scopes        []string `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuth2ClientCredentials) Reset() {
+	*x = OAuth2ClientCredentials{}
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuth2ClientCredentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuth2ClientCredentials) ProtoMessage() {}
+
+func (x *OAuth2ClientCredentials) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuth2ClientCredentials.ProtoReflect.Descriptor instead.
+func (*OAuth2ClientCredentials) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OAuth2ClientCredentials) GetTokenUrl() string {
+	if x != nil {
+		return x.TokenUrl
+	}
+	return ""
+}
+
+func (x *OAuth2ClientCredentials) GetClientIdEnv() string {
+	if x != nil {
+		return x.ClientIdEnv
+	}
+	return ""
+}
+
+func (x *OAuth2ClientCredentials) GetClientSecretEnv() string {
+	if x != nil {
+		return x.ClientSecretEnv
+	}
+	return ""
+}
+
+func (x *OAuth2ClientCredentials) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
 // DockerServer defines an MCP server that runs in a Docker container.
 type DockerServer struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -586,7 +981,7 @@ type DockerServer struct {
 
 func (x *DockerServer) Reset() {
 	*x = DockerServer{}
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[6]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -598,7 +993,7 @@ func (x *DockerServer) String() string {
 func (*DockerServer) ProtoMessage() {}
 
 func (x *DockerServer) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[6]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,7 +1006,7 @@ func (x *DockerServer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DockerServer.ProtoReflect.Descriptor instead.
 func (*DockerServer) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{6}
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DockerServer) GetImage() string {
@@ -678,7 +1073,7 @@ type VolumeMount struct {
 
 func (x *VolumeMount) Reset() {
 	*x = VolumeMount{}
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[7]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -690,7 +1085,7 @@ func (x *VolumeMount) String() string {
 func (*VolumeMount) ProtoMessage() {}
 
 func (x *VolumeMount) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[7]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -703,7 +1098,7 @@ func (x *VolumeMount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VolumeMount.ProtoReflect.Descriptor instead.
 func (*VolumeMount) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{7}
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *VolumeMount) GetHostPath() string {
@@ -742,7 +1137,7 @@ type PortMapping struct {
 
 func (x *PortMapping) Reset() {
 	*x = PortMapping{}
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[8]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -754,7 +1149,7 @@ func (x *PortMapping) String() string {
 func (*PortMapping) ProtoMessage() {}
 
 func (x *PortMapping) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[8]
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -767,7 +1162,7 @@ func (x *PortMapping) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PortMapping.ProtoReflect.Descriptor instead.
 func (*PortMapping) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{8}
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *PortMapping) GetHostPort() int32 {
@@ -791,11 +1186,76 @@ func (x *PortMapping) GetProtocol() string {
 	return ""
 }
 
+// AgentLimits bounds the resources a single agent execution may consume.
+// Each limit is optional; 0 means no limit.
+type AgentLimits struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum model tokens (input and output, across all model calls) per execution.
+	MaxTokensPerRun int32 `protobuf:"varint,1,opt,name=max_tokens_per_run,json=maxTokensPerRun,proto3" json:"max_tokens_per_run,omitempty"`
+	// Maximum number of tool calls per execution.
+	MaxToolCalls int32 `protobuf:"varint,2,opt,name=max_tool_calls,json=maxToolCalls,proto3" json:"max_tool_calls,omitempty"`
+	// Maximum wall-clock duration of an execution, in seconds.
+	MaxDurationSeconds int32 `protobuf:"varint,3,opt,name=max_duration_seconds,json=maxDurationSeconds,proto3" json:"max_duration_seconds,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AgentLimits) Reset() {
+	*x = AgentLimits{}
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentLimits) ProtoMessage() {}
+
+func (x *AgentLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentLimits.ProtoReflect.Descriptor instead.
+func (*AgentLimits) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AgentLimits) GetMaxTokensPerRun() int32 {
+	if x != nil {
+		return x.MaxTokensPerRun
+	}
+	return 0
+}
+
+func (x *AgentLimits) GetMaxToolCalls() int32 {
+	if x != nil {
+		return x.MaxToolCalls
+	}
+	return 0
+}
+
+func (x *AgentLimits) GetMaxDurationSeconds() int32 {
+	if x != nil {
+		return x.MaxDurationSeconds
+	}
+	return 0
+}
+
 var File_ai_stigmer_agentic_agent_v1_spec_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_agent_v1_spec_proto_rawDesc = "" +
 	"\n" +
-	"&ai/stigmer/agentic/agent/v1/spec.proto\x12\x1bai.stigmer.agentic.agent.v1\x1a,ai/stigmer/agentic/environment/v1/spec.proto\x1a'ai/stigmer/commons/apiresource/io.proto\x1a\x1bbuf/validate/validate.proto\"\x97\x04\n" +
+	"&ai/stigmer/agentic/agent/v1/spec.proto\x12\x1bai.stigmer.agentic.agent.v1\x1a,ai/stigmer/agentic/environment/v1/spec.proto\x1a'ai/stigmer/commons/apiresource/io.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xa9\b\n" +
 	"\tAgentSpec\x12 \n" +
 	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x19\n" +
 	"\bicon_url\x18\x02 \x01(\tR\aiconUrl\x12+\n" +
@@ -808,21 +1268,46 @@ const file_ai_stigmer_agentic_agent_v1_spec_proto_rawDesc = "" +
 	"\x0fskill_refs.kind\x123skill_refs must reference resources with kind=skill\x1a\x0fthis.kind == 43R\tskillRefs\x12D\n" +
 	"\n" +
 	"sub_agents\x18\x06 \x03(\v2%.ai.stigmer.agentic.agent.v1.SubAgentR\tsubAgents\x12M\n" +
-	"\benv_spec\x18\a \x01(\v22.ai.stigmer.agentic.environment.v1.EnvironmentSpecR\aenvSpec\"\xb3\x04\n" +
+	"\benv_spec\x18\a \x01(\v22.ai.stigmer.agentic.environment.v1.EnvironmentSpecR\aenvSpec\x12A\n" +
+	"\x06memory\x18\b \x01(\v2).ai.stigmer.agentic.agent.v1.MemoryConfigR\x06memory\x12<\n" +
+	"\routput_schema\x18\t \x01(\v2\x17.google.protobuf.StructR\foutputSchema\x12H\n" +
+	"\vtool_policy\x18\n" +
+	" \x01(\v2'.ai.stigmer.agentic.agent.v1.ToolPolicyR\n" +
+	"toolPolicy\x12\x90\x01\n" +
+	"\rlocalizations\x18\v \x03(\v29.ai.stigmer.agentic.agent.v1.AgentSpec.LocalizationsEntryB/\xbaH,\x9a\x01)\"'r%2#^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$R\rlocalizations\x12@\n" +
+	"\x06limits\x18\f \x01(\v2(.ai.stigmer.agentic.agent.v1.AgentLimitsR\x06limits\x1ap\n" +
+	"\x12LocalizationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12D\n" +
+	"\x05value\x18\x02 \x01(\v2..ai.stigmer.agentic.agent.v1.AgentLocalizationR\x05value:\x028\x01\"?\n" +
+	"\x11AgentLocalization\x12*\n" +
+	"\vdescription\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\xf4\x03R\vdescription\"|\n" +
+	"\n" +
+	"ToolPolicy\x123\n" +
+	"\x04deny\x18\x01 \x03(\tB\x1f\xbaH\x1c\x92\x01\x19\"\x17r\x152\x13^[A-Za-z0-9_.*?-]+$R\x04deny\x129\n" +
+	"\aconfirm\x18\x02 \x03(\tB\x1f\xbaH\x1c\x92\x01\x19\"\x17r\x152\x13^[A-Za-z0-9_.*?-]+$R\aconfirm\"\xbc\x01\n" +
+	"\fMemoryConfig\x12Q\n" +
+	"\bstrategy\x18\x01 \x01(\x0e2+.ai.stigmer.agentic.agent.v1.MemoryStrategyB\b\xbaH\x05\x82\x01\x02\x10\x01R\bstrategy\x12-\n" +
+	"\fwindow_turns\x18\x02 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\vwindowTurns\x12*\n" +
+	"\n" +
+	"max_tokens\x18\x03 \x01(\x05B\v\xbaH\b\x1a\x06\x18\xc0\x84=(\x00R\tmaxTokens\"\xc8\b\n" +
 	"\bSubAgent\x12\x1a\n" +
 	"\x04name\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04name\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12+\n" +
-	"\finstructions\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\n" +
-	"R\finstructions\x12\x1f\n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\"\n" +
+	"\finstructions\x18\x03 \x01(\tR\finstructions\x12\x1f\n" +
 	"\vmcp_servers\x18\x04 \x03(\tR\n" +
 	"mcpServers\x12l\n" +
 	"\x13mcp_tool_selections\x18\x05 \x03(\v2<.ai.stigmer.agentic.agent.v1.SubAgent.McpToolSelectionsEntryR\x11mcpToolSelections\x12\xb7\x01\n" +
 	"\n" +
 	"skill_refs\x18\x06 \x03(\v24.ai.stigmer.commons.apiresource.ApiResourceReferenceBb\xbaH_\x92\x01\\\"Z\xba\x01W\n" +
-	"\x0fskill_refs.kind\x123skill_refs must reference resources with kind=skill\x1a\x0fthis.kind == 43R\tskillRefs\x1as\n" +
+	"\x0fskill_refs.kind\x123skill_refs must reference resources with kind=skill\x1a\x0fthis.kind == 43R\tskillRefs\x12f\n" +
+	"\x16mcp_server_definitions\x18\a \x03(\v20.ai.stigmer.agentic.agent.v1.McpServerDefinitionR\x14mcpServerDefinitions\x12b\n" +
+	"\x12agent_instance_ref\x18\b \x01(\v24.ai.stigmer.commons.apiresource.ApiResourceReferenceR\x10agentInstanceRef\x1as\n" +
 	"\x16McpToolSelectionsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12C\n" +
-	"\x05value\x18\x02 \x01(\v2-.ai.stigmer.agentic.agent.v1.McpToolSelectionR\x05value:\x028\x01\"7\n" +
+	"\x05value\x18\x02 \x01(\v2-.ai.stigmer.agentic.agent.v1.McpToolSelectionR\x05value:\x028\x01:\xcf\x02\xbaH\xcb\x02\x1a\x96\x01\n" +
+	"\x16sub_agent.instructions\x12=inline sub-agents need instructions of at least 10 characters\x1a=has(this.agent_instance_ref) || size(this.instructions) >= 10\x1a\xaf\x01\n" +
+	"!sub_agent.agent_instance_ref.kind\x12Eagent_instance_ref must reference a resource with kind=agent_instance\x1aC!has(this.agent_instance_ref) || this.agent_instance_ref.kind == 45\"7\n" +
 	"\x10McpToolSelection\x12#\n" +
 	"\renabled_tools\x18\x01 \x03(\tR\fenabledTools\"\xab\x02\n" +
 	"\x13McpServerDefinition\x12\x1a\n" +
@@ -840,19 +1325,25 @@ const file_ai_stigmer_agentic_agent_v1_spec_proto_rawDesc = "" +
 	"workingDir\x1aB\n" +
 	"\x14EnvPlaceholdersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf8\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc2\x03\n" +
 	"\n" +
 	"HttpServer\x12\x18\n" +
 	"\x03url\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03url\x12N\n" +
 	"\aheaders\x18\x02 \x03(\v24.ai.stigmer.agentic.agent.v1.HttpServer.HeadersEntryR\aheaders\x12[\n" +
 	"\fquery_params\x18\x03 \x03(\v28.ai.stigmer.agentic.agent.v1.HttpServer.QueryParamsEntryR\vqueryParams\x12'\n" +
-	"\x0ftimeout_seconds\x18\x04 \x01(\x05R\x0etimeoutSeconds\x1a:\n" +
+	"\x0ftimeout_seconds\x18\x04 \x01(\x05R\x0etimeoutSeconds\x12H\n" +
+	"\x04auth\x18\x05 \x01(\v24.ai.stigmer.agentic.agent.v1.OAuth2ClientCredentialsR\x04auth\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
 	"\x10QueryParamsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb4\x03\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x81\x02\n" +
+	"\x17OAuth2ClientCredentials\x12(\n" +
+	"\ttoken_url\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x88\x01\x01R\btokenUrl\x12F\n" +
+	"\rclient_id_env\x18\x02 \x01(\tB\"\xbaH\x1f\xc8\x01\x01r\x1a2\x18^[A-Za-z_][A-Za-z0-9_]*$R\vclientIdEnv\x12N\n" +
+	"\x11client_secret_env\x18\x03 \x01(\tB\"\xbaH\x1f\xc8\x01\x01r\x1a2\x18^[A-Za-z_][A-Za-z0-9_]*$R\x0fclientSecretEnv\x12$\n" +
+	"\x06scopes\x18\x04 \x03(\tB\f\xbaH\t\x92\x01\x06\"\x04r\x02\x10\x01R\x06scopes\"\xb4\x03\n" +
 	"\fDockerServer\x12\x1c\n" +
 	"\x05image\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05image\x12\x12\n" +
 	"\x04args\x18\x02 \x03(\tR\x04args\x12i\n" +
@@ -871,7 +1362,16 @@ const file_ai_stigmer_agentic_agent_v1_spec_proto_rawDesc = "" +
 	"\vPortMapping\x12$\n" +
 	"\thost_port\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x01R\bhostPort\x12.\n" +
 	"\x0econtainer_port\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x01R\rcontainerPort\x12\x1a\n" +
-	"\bprotocol\x18\x03 \x01(\tR\bprotocolB\x8b\x02\n" +
+	"\bprotocol\x18\x03 \x01(\tR\bprotocol\"\xad\x01\n" +
+	"\vAgentLimits\x124\n" +
+	"\x12max_tokens_per_run\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x0fmaxTokensPerRun\x12-\n" +
+	"\x0emax_tool_calls\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\fmaxToolCalls\x129\n" +
+	"\x14max_duration_seconds\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x12maxDurationSeconds*m\n" +
+	"\x0eMemoryStrategy\x12\x1f\n" +
+	"\x1bMEMORY_STRATEGY_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vMEMORY_NONE\x10\x01\x12\x11\n" +
+	"\rMEMORY_WINDOW\x10\x02\x12\x16\n" +
+	"\x12MEMORY_SUMMARIZING\x10\x03B\x8b\x02\n" +
 	"\x1fcom.ai.stigmer.agentic.agent.v1B\tSpecProtoP\x01ZLgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1;agentv1\xa2\x02\x04ASAA\xaa\x02\x1bAi.Stigmer.Agentic.Agent.V1\xca\x02\x1bAi\\Stigmer\\Agentic\\Agent\\V1\xe2\x02'Ai\\Stigmer\\Agentic\\Agent\\V1\\GPBMetadata\xea\x02\x1fAi::Stigmer::Agentic::Agent::V1b\x06proto3"
 
 var (
@@ -886,47 +1386,66 @@ func file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescGZIP() []byte {
 	return file_ai_stigmer_agentic_agent_v1_spec_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_ai_stigmer_agentic_agent_v1_spec_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_ai_stigmer_agentic_agent_v1_spec_proto_goTypes = []any{
-	(*AgentSpec)(nil),                        // 0: ai.stigmer.agentic.agent.v1.AgentSpec
-	(*SubAgent)(nil),                         // 1: ai.stigmer.agentic.agent.v1.SubAgent
-	(*McpToolSelection)(nil),                 // 2: ai.stigmer.agentic.agent.v1.McpToolSelection
-	(*McpServerDefinition)(nil),              // 3: ai.stigmer.agentic.agent.v1.McpServerDefinition
-	(*StdioServer)(nil),                      // 4: ai.stigmer.agentic.agent.v1.StdioServer
-	(*HttpServer)(nil),                       // 5: ai.stigmer.agentic.agent.v1.HttpServer
-	(*DockerServer)(nil),                     // 6: ai.stigmer.agentic.agent.v1.DockerServer
-	(*VolumeMount)(nil),                      // 7: ai.stigmer.agentic.agent.v1.VolumeMount
-	(*PortMapping)(nil),                      // 8: ai.stigmer.agentic.agent.v1.PortMapping
-	nil,                                      // 9: ai.stigmer.agentic.agent.v1.SubAgent.McpToolSelectionsEntry
-	nil,                                      // 10: ai.stigmer.agentic.agent.v1.StdioServer.EnvPlaceholdersEntry
-	nil,                                      // 11: ai.stigmer.agentic.agent.v1.HttpServer.HeadersEntry
-	nil,                                      // 12: ai.stigmer.agentic.agent.v1.HttpServer.QueryParamsEntry
-	nil,                                      // 13: ai.stigmer.agentic.agent.v1.DockerServer.EnvPlaceholdersEntry
-	(*apiresource.ApiResourceReference)(nil), // 14: ai.stigmer.commons.apiresource.ApiResourceReference
-	(*v1.EnvironmentSpec)(nil),               // 15: ai.stigmer.agentic.environment.v1.EnvironmentSpec
+	(MemoryStrategy)(0),                      // 0: ai.stigmer.agentic.agent.v1.MemoryStrategy
+	(*AgentSpec)(nil),                        // 1: ai.stigmer.agentic.agent.v1.AgentSpec
+	(*AgentLocalization)(nil),                // 2: ai.stigmer.agentic.agent.v1.AgentLocalization
+	(*ToolPolicy)(nil),                       // 3: ai.stigmer.agentic.agent.v1.ToolPolicy
+	(*MemoryConfig)(nil),                     // 4: ai.stigmer.agentic.agent.v1.MemoryConfig
+	(*SubAgent)(nil),                         // 5: ai.stigmer.agentic.agent.v1.SubAgent
+	(*McpToolSelection)(nil),                 // 6: ai.stigmer.agentic.agent.v1.McpToolSelection
+	(*McpServerDefinition)(nil),              // 7: ai.stigmer.agentic.agent.v1.McpServerDefinition
+	(*StdioServer)(nil),                      // 8: ai.stigmer.agentic.agent.v1.StdioServer
+	(*HttpServer)(nil),                       // 9: ai.stigmer.agentic.agent.v1.HttpServer
+	(*OAuth2ClientCredentials)(nil),          // 10: ai.stigmer.agentic.agent.v1.OAuth2ClientCredentials
+	(*DockerServer)(nil),                     // 11: ai.stigmer.agentic.agent.v1.DockerServer
+	(*VolumeMount)(nil),                      // 12: ai.stigmer.agentic.agent.v1.VolumeMount
+	(*PortMapping)(nil),                      // 13: ai.stigmer.agentic.agent.v1.PortMapping
+	(*AgentLimits)(nil),                      // 14: ai.stigmer.agentic.agent.v1.AgentLimits
+	nil,                                      // 15: ai.stigmer.agentic.agent.v1.AgentSpec.LocalizationsEntry
+	nil,                                      // 16: ai.stigmer.agentic.agent.v1.SubAgent.McpToolSelectionsEntry
+	nil,                                      // 17: ai.stigmer.agentic.agent.v1.StdioServer.EnvPlaceholdersEntry
+	nil,                                      // 18: ai.stigmer.agentic.agent.v1.HttpServer.HeadersEntry
+	nil,                                      // 19: ai.stigmer.agentic.agent.v1.HttpServer.QueryParamsEntry
+	nil,                                      // 20: ai.stigmer.agentic.agent.v1.DockerServer.EnvPlaceholdersEntry
+	(*apiresource.ApiResourceReference)(nil), // 21: ai.stigmer.commons.apiresource.ApiResourceReference
+	(*v1.EnvironmentSpec)(nil),               // 22: ai.stigmer.agentic.environment.v1.EnvironmentSpec
+	(*structpb.Struct)(nil),                  // 23: google.protobuf.Struct
 }
 var file_ai_stigmer_agentic_agent_v1_spec_proto_depIdxs = []int32{
-	3,  // 0: ai.stigmer.agentic.agent.v1.AgentSpec.mcp_servers:type_name -> ai.stigmer.agentic.agent.v1.McpServerDefinition
-	14, // 1: ai.stigmer.agentic.agent.v1.AgentSpec.skill_refs:type_name -> ai.stigmer.commons.apiresource.ApiResourceReference
-	1,  // 2: ai.stigmer.agentic.agent.v1.AgentSpec.sub_agents:type_name -> ai.stigmer.agentic.agent.v1.SubAgent
-	15, // 3: ai.stigmer.agentic.agent.v1.AgentSpec.env_spec:type_name -> ai.stigmer.agentic.environment.v1.EnvironmentSpec
-	9,  // 4: ai.stigmer.agentic.agent.v1.SubAgent.mcp_tool_selections:type_name -> ai.stigmer.agentic.agent.v1.SubAgent.McpToolSelectionsEntry
-	14, // 5: ai.stigmer.agentic.agent.v1.SubAgent.skill_refs:type_name -> ai.stigmer.commons.apiresource.ApiResourceReference
-	4,  // 6: ai.stigmer.agentic.agent.v1.McpServerDefinition.stdio:type_name -> ai.stigmer.agentic.agent.v1.StdioServer
-	5,  // 7: ai.stigmer.agentic.agent.v1.McpServerDefinition.http:type_name -> ai.stigmer.agentic.agent.v1.HttpServer
-	6,  // 8: ai.stigmer.agentic.agent.v1.McpServerDefinition.docker:type_name -> ai.stigmer.agentic.agent.v1.DockerServer
-	10, // 9: ai.stigmer.agentic.agent.v1.StdioServer.env_placeholders:type_name -> ai.stigmer.agentic.agent.v1.StdioServer.EnvPlaceholdersEntry
-	11, // 10: ai.stigmer.agentic.agent.v1.HttpServer.headers:type_name -> ai.stigmer.agentic.agent.v1.HttpServer.HeadersEntry
-	12, // 11: ai.stigmer.agentic.agent.v1.HttpServer.query_params:type_name -> ai.stigmer.agentic.agent.v1.HttpServer.QueryParamsEntry
-	13, // 12: ai.stigmer.agentic.agent.v1.DockerServer.env_placeholders:type_name -> ai.stigmer.agentic.agent.v1.DockerServer.EnvPlaceholdersEntry
-	7,  // 13: ai.stigmer.agentic.agent.v1.DockerServer.volumes:type_name -> ai.stigmer.agentic.agent.v1.VolumeMount
-	8,  // 14: ai.stigmer.agentic.agent.v1.DockerServer.ports:type_name -> ai.stigmer.agentic.agent.v1.PortMapping
-	2,  // 15: ai.stigmer.agentic.agent.v1.SubAgent.McpToolSelectionsEntry.value:type_name -> ai.stigmer.agentic.agent.v1.McpToolSelection
-	16, // [16:16] is the sub-list for method output_type
-	16, // [16:16] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	7,  // 0: ai.stigmer.agentic.agent.v1.AgentSpec.mcp_servers:type_name -> ai.stigmer.agentic.agent.v1.McpServerDefinition
+	21, // 1: ai.stigmer.agentic.agent.v1.AgentSpec.skill_refs:type_name -> ai.stigmer.commons.apiresource.ApiResourceReference
+	5,  // 2: ai.stigmer.agentic.agent.v1.AgentSpec.sub_agents:type_name -> ai.stigmer.agentic.agent.v1.SubAgent
+	22, // 3: ai.stigmer.agentic.agent.v1.AgentSpec.env_spec:type_name -> ai.stigmer.agentic.environment.v1.EnvironmentSpec
+	4,  // 4: ai.stigmer.agentic.agent.v1.AgentSpec.memory:type_name -> ai.stigmer.agentic.agent.v1.MemoryConfig
+	23, // 5: ai.stigmer.agentic.agent.v1.AgentSpec.output_schema:type_name -> google.protobuf.Struct
+	3,  // 6: ai.stigmer.agentic.agent.v1.AgentSpec.tool_policy:type_name -> ai.stigmer.agentic.agent.v1.ToolPolicy
+	15, // 7: ai.stigmer.agentic.agent.v1.AgentSpec.localizations:type_name -> ai.stigmer.agentic.agent.v1.AgentSpec.LocalizationsEntry
+	14, // 8: ai.stigmer.agentic.agent.v1.AgentSpec.limits:type_name -> ai.stigmer.agentic.agent.v1.AgentLimits
+	0,  // 9: ai.stigmer.agentic.agent.v1.MemoryConfig.strategy:type_name -> ai.stigmer.agentic.agent.v1.MemoryStrategy
+	16, // 10: ai.stigmer.agentic.agent.v1.SubAgent.mcp_tool_selections:type_name -> ai.stigmer.agentic.agent.v1.SubAgent.McpToolSelectionsEntry
+	21, // 11: ai.stigmer.agentic.agent.v1.SubAgent.skill_refs:type_name -> ai.stigmer.commons.apiresource.ApiResourceReference
+	7,  // 12: ai.stigmer.agentic.agent.v1.SubAgent.mcp_server_definitions:type_name -> ai.stigmer.agentic.agent.v1.McpServerDefinition
+	21, // 13: ai.stigmer.agentic.agent.v1.SubAgent.agent_instance_ref:type_name -> ai.stigmer.commons.apiresource.ApiResourceReference
+	8,  // 14: ai.stigmer.agentic.agent.v1.McpServerDefinition.stdio:type_name -> ai.stigmer.agentic.agent.v1.StdioServer
+	9,  // 15: ai.stigmer.agentic.agent.v1.McpServerDefinition.http:type_name -> ai.stigmer.agentic.agent.v1.HttpServer
+	11, // 16: ai.stigmer.agentic.agent.v1.McpServerDefinition.docker:type_name -> ai.stigmer.agentic.agent.v1.DockerServer
+	17, // 17: ai.stigmer.agentic.agent.v1.StdioServer.env_placeholders:type_name -> ai.stigmer.agentic.agent.v1.StdioServer.EnvPlaceholdersEntry
+	18, // 18: ai.stigmer.agentic.agent.v1.HttpServer.headers:type_name -> ai.stigmer.agentic.agent.v1.HttpServer.HeadersEntry
+	19, // 19: ai.stigmer.agentic.agent.v1.HttpServer.query_params:type_name -> ai.stigmer.agentic.agent.v1.HttpServer.QueryParamsEntry
+	10, // 20: ai.stigmer.agentic.agent.v1.HttpServer.auth:type_name -> ai.stigmer.agentic.agent.v1.OAuth2ClientCredentials
+	20, // 21: ai.stigmer.agentic.agent.v1.DockerServer.env_placeholders:type_name -> ai.stigmer.agentic.agent.v1.DockerServer.EnvPlaceholdersEntry
+	12, // 22: ai.stigmer.agentic.agent.v1.DockerServer.volumes:type_name -> ai.stigmer.agentic.agent.v1.VolumeMount
+	13, // 23: ai.stigmer.agentic.agent.v1.DockerServer.ports:type_name -> ai.stigmer.agentic.agent.v1.PortMapping
+	2,  // 24: ai.stigmer.agentic.agent.v1.AgentSpec.LocalizationsEntry.value:type_name -> ai.stigmer.agentic.agent.v1.AgentLocalization
+	6,  // 25: ai.stigmer.agentic.agent.v1.SubAgent.McpToolSelectionsEntry.value:type_name -> ai.stigmer.agentic.agent.v1.McpToolSelection
+	26, // [26:26] is the sub-list for method output_type
+	26, // [26:26] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_agentic_agent_v1_spec_proto_init() }
@@ -934,7 +1453,7 @@ func file_ai_stigmer_agentic_agent_v1_spec_proto_init() {
 	if File_ai_stigmer_agentic_agent_v1_spec_proto != nil {
 		return
 	}
-	file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[3].OneofWrappers = []any{
+	file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes[6].OneofWrappers = []any{
 		(*McpServerDefinition_Stdio)(nil),
 		(*McpServerDefinition_Http)(nil),
 		(*McpServerDefinition_Docker)(nil),
@@ -944,13 +1463,14 @@ func file_ai_stigmer_agentic_agent_v1_spec_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_agent_v1_spec_proto_rawDesc), len(file_ai_stigmer_agentic_agent_v1_spec_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      1,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_ai_stigmer_agentic_agent_v1_spec_proto_goTypes,
 		DependencyIndexes: file_ai_stigmer_agentic_agent_v1_spec_proto_depIdxs,
+		EnumInfos:         file_ai_stigmer_agentic_agent_v1_spec_proto_enumTypes,
 		MessageInfos:      file_ai_stigmer_agentic_agent_v1_spec_proto_msgTypes,
 	}.Build()
 	File_ai_stigmer_agentic_agent_v1_spec_proto = out.File
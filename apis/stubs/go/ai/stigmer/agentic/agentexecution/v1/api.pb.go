@@ -121,7 +121,7 @@ type AgentExecutionStatus struct {
 	Messages []*AgentMessage `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
 	// Current execution lifecycle phase.
 	// Tracks the execution state from creation (PENDING) through active processing (IN_PROGRESS)
-	// to terminal states (COMPLETED/FAILED/CANCELLED).
+	// to terminal states (COMPLETED/FAILED/CANCELLED/LIMIT_EXCEEDED).
 	Phase ExecutionPhase `protobuf:"varint,2,opt,name=phase,proto3,enum=ai.stigmer.agentic.agentexecution.v1.ExecutionPhase" json:"phase,omitempty"`
 	// Tool calls made during this execution.
 	// Tracked separately for easier querying and display.
@@ -132,7 +132,7 @@ type AgentExecutionStatus struct {
 	// Ordered chronologically by invocation time.
 	SubAgentExecutions []*SubAgentExecution `protobuf:"bytes,4,rep,name=sub_agent_executions,json=subAgentExecutions,proto3" json:"sub_agent_executions,omitempty"`
 	// Error message if execution failed.
-	// Only populated when phase == EXECUTION_FAILED.
+	// Only populated when phase == EXECUTION_FAILED or EXECUTION_LIMIT_EXCEEDED.
 	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 	// ISO 8601 timestamp when execution started.
 	// Example: "2025-01-10T10:30:00Z"
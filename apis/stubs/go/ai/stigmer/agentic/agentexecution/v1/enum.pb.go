@@ -31,6 +31,7 @@ const (
 	ExecutionPhase_EXECUTION_COMPLETED         ExecutionPhase = 3 // Successfully completed
 	ExecutionPhase_EXECUTION_FAILED            ExecutionPhase = 4 // Failed with error
 	ExecutionPhase_EXECUTION_CANCELLED         ExecutionPhase = 5 // Cancelled by user
+	ExecutionPhase_EXECUTION_LIMIT_EXCEEDED    ExecutionPhase = 6 // Stopped after exceeding one of the agent's limits (AgentSpec.limits)
 )
 
 // Enum value maps for ExecutionPhase.
@@ -42,6 +43,7 @@ var (
 		3: "EXECUTION_COMPLETED",
 		4: "EXECUTION_FAILED",
 		5: "EXECUTION_CANCELLED",
+		6: "EXECUTION_LIMIT_EXCEEDED",
 	}
 	ExecutionPhase_value = map[string]int32{
 		"EXECUTION_PHASE_UNSPECIFIED": 0,
@@ -50,6 +52,7 @@ var (
 		"EXECUTION_COMPLETED":         3,
 		"EXECUTION_FAILED":            4,
 		"EXECUTION_CANCELLED":         5,
+		"EXECUTION_LIMIT_EXCEEDED":    6,
 	}
 )
 
@@ -145,6 +148,7 @@ const (
 	ToolCallStatus_TOOL_CALL_RUNNING            ToolCallStatus = 2 // Currently executing
 	ToolCallStatus_TOOL_CALL_COMPLETED          ToolCallStatus = 3 // Successfully completed
 	ToolCallStatus_TOOL_CALL_FAILED             ToolCallStatus = 4 // Failed with error
+	ToolCallStatus_TOOL_CALL_AWAITING_APPROVAL  ToolCallStatus = 5 // Paused by the agent's tool policy until approved or rejected
 )
 
 // Enum value maps for ToolCallStatus.
@@ -155,6 +159,7 @@ var (
 		2: "TOOL_CALL_RUNNING",
 		3: "TOOL_CALL_COMPLETED",
 		4: "TOOL_CALL_FAILED",
+		5: "TOOL_CALL_AWAITING_APPROVAL",
 	}
 	ToolCallStatus_value = map[string]int32{
 		"TOOL_CALL_STATUS_UNSPECIFIED": 0,
@@ -162,6 +167,7 @@ var (
 		"TOOL_CALL_RUNNING":            2,
 		"TOOL_CALL_COMPLETED":          3,
 		"TOOL_CALL_FAILED":             4,
+		"TOOL_CALL_AWAITING_APPROVAL":  5,
 	}
 )
 
@@ -304,31 +310,84 @@ func (SubAgentStatus) EnumDescriptor() ([]byte, []int) {
 	return file_ai_stigmer_agentic_agentexecution_v1_enum_proto_rawDescGZIP(), []int{4}
 }
 
+// CallbackOutputMode defines the result an execution created with a callback
+// token completes its caller with.
+type CallbackOutputMode int32
+
+const (
+	CallbackOutputMode_CALLBACK_OUTPUT_MODE_UNSPECIFIED  CallbackOutputMode = 0 // The full AgentExecution
+	CallbackOutputMode_CALLBACK_OUTPUT_FINAL_ONLY        CallbackOutputMode = 1 // {"final": "<last AI message>"}
+	CallbackOutputMode_CALLBACK_OUTPUT_STREAM_TO_CONTEXT CallbackOutputMode = 2 // The final message plus every AI message as {"text", "timestamp"} chunks
+)
+
+// Enum value maps for CallbackOutputMode.
+var (
+	CallbackOutputMode_name = map[int32]string{
+		0: "CALLBACK_OUTPUT_MODE_UNSPECIFIED",
+		1: "CALLBACK_OUTPUT_FINAL_ONLY",
+		2: "CALLBACK_OUTPUT_STREAM_TO_CONTEXT",
+	}
+	CallbackOutputMode_value = map[string]int32{
+		"CALLBACK_OUTPUT_MODE_UNSPECIFIED":  0,
+		"CALLBACK_OUTPUT_FINAL_ONLY":        1,
+		"CALLBACK_OUTPUT_STREAM_TO_CONTEXT": 2,
+	}
+)
+
+func (x CallbackOutputMode) Enum() *CallbackOutputMode {
+	p := new(CallbackOutputMode)
+	*p = x
+	return p
+}
+
+func (x CallbackOutputMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CallbackOutputMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_ai_stigmer_agentic_agentexecution_v1_enum_proto_enumTypes[5].Descriptor()
+}
+
+func (CallbackOutputMode) Type() protoreflect.EnumType {
+	return &file_ai_stigmer_agentic_agentexecution_v1_enum_proto_enumTypes[5]
+}
+
+func (x CallbackOutputMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CallbackOutputMode.Descriptor instead.
+func (CallbackOutputMode) EnumDescriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_agentexecution_v1_enum_proto_rawDescGZIP(), []int{5}
+}
+
 var File_ai_stigmer_agentic_agentexecution_v1_enum_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_agentexecution_v1_enum_proto_rawDesc = "" +
 	"\n" +
-	"/ai/stigmer/agentic/agentexecution/v1/enum.proto\x12$ai.stigmer.agentic.agentexecution.v1*\xab\x01\n" +
+	"/ai/stigmer/agentic/agentexecution/v1/enum.proto\x12$ai.stigmer.agentic.agentexecution.v1*\xc9\x01\n" +
 	"\x0eExecutionPhase\x12\x1f\n" +
 	"\x1bEXECUTION_PHASE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11EXECUTION_PENDING\x10\x01\x12\x19\n" +
 	"\x15EXECUTION_IN_PROGRESS\x10\x02\x12\x17\n" +
 	"\x13EXECUTION_COMPLETED\x10\x03\x12\x14\n" +
 	"\x10EXECUTION_FAILED\x10\x04\x12\x17\n" +
-	"\x13EXECUTION_CANCELLED\x10\x05*t\n" +
+	"\x13EXECUTION_CANCELLED\x10\x05\x12\x1c\n" +
+	"\x18EXECUTION_LIMIT_EXCEEDED\x10\x06*t\n" +
 	"\vMessageType\x12\x1c\n" +
 	"\x18MESSAGE_TYPE_UNSPECIFIED\x10\x00\x12\x11\n" +
 	"\rMESSAGE_HUMAN\x10\x01\x12\x0e\n" +
 	"\n" +
 	"MESSAGE_AI\x10\x02\x12\x10\n" +
 	"\fMESSAGE_TOOL\x10\x03\x12\x12\n" +
-	"\x0eMESSAGE_SYSTEM\x10\x04*\x8f\x01\n" +
+	"\x0eMESSAGE_SYSTEM\x10\x04*\xb0\x01\n" +
 	"\x0eToolCallStatus\x12 \n" +
 	"\x1cTOOL_CALL_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11TOOL_CALL_PENDING\x10\x01\x12\x15\n" +
 	"\x11TOOL_CALL_RUNNING\x10\x02\x12\x17\n" +
 	"\x13TOOL_CALL_COMPLETED\x10\x03\x12\x14\n" +
-	"\x10TOOL_CALL_FAILED\x10\x04*y\n" +
+	"\x10TOOL_CALL_FAILED\x10\x04\x12\x1f\n" +
+	"\x1bTOOL_CALL_AWAITING_APPROVAL\x10\x05*y\n" +
 	"\n" +
 	"TodoStatus\x12\x1b\n" +
 	"\x17TODO_STATUS_UNSPECIFIED\x10\x00\x12\x10\n" +
@@ -341,7 +400,11 @@ const file_ai_stigmer_agentic_agentexecution_v1_enum_proto_rawDesc = "" +
 	"\x11SUB_AGENT_PENDING\x10\x01\x12\x19\n" +
 	"\x15SUB_AGENT_IN_PROGRESS\x10\x02\x12\x17\n" +
 	"\x13SUB_AGENT_COMPLETED\x10\x03\x12\x14\n" +
-	"\x10SUB_AGENT_FAILED\x10\x04B\xca\x02\n" +
+	"\x10SUB_AGENT_FAILED\x10\x04*\x81\x01\n" +
+	"\x12CallbackOutputMode\x12$\n" +
+	" CALLBACK_OUTPUT_MODE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aCALLBACK_OUTPUT_FINAL_ONLY\x10\x01\x12%\n" +
+	"!CALLBACK_OUTPUT_STREAM_TO_CONTEXT\x10\x02B\xca\x02\n" +
 	"(com.ai.stigmer.agentic.agentexecution.v1B\tEnumProtoP\x01Z^github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1;agentexecutionv1\xa2\x02\x04ASAA\xaa\x02$Ai.Stigmer.Agentic.Agentexecution.V1\xca\x02$Ai\\Stigmer\\Agentic\\Agentexecution\\V1\xe2\x020Ai\\Stigmer\\Agentic\\Agentexecution\\V1\\GPBMetadata\xea\x02(Ai::Stigmer::Agentic::Agentexecution::V1b\x06proto3"
 
 var (
@@ -356,13 +419,14 @@ func file_ai_stigmer_agentic_agentexecution_v1_enum_proto_rawDescGZIP() []byte {
 	return file_ai_stigmer_agentic_agentexecution_v1_enum_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_agentexecution_v1_enum_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_ai_stigmer_agentic_agentexecution_v1_enum_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
 var file_ai_stigmer_agentic_agentexecution_v1_enum_proto_goTypes = []any{
-	(ExecutionPhase)(0), // 0: ai.stigmer.agentic.agentexecution.v1.ExecutionPhase
-	(MessageType)(0),    // 1: ai.stigmer.agentic.agentexecution.v1.MessageType
-	(ToolCallStatus)(0), // 2: ai.stigmer.agentic.agentexecution.v1.ToolCallStatus
-	(TodoStatus)(0),     // 3: ai.stigmer.agentic.agentexecution.v1.TodoStatus
-	(SubAgentStatus)(0), // 4: ai.stigmer.agentic.agentexecution.v1.SubAgentStatus
+	(ExecutionPhase)(0),     // 0: ai.stigmer.agentic.agentexecution.v1.ExecutionPhase
+	(MessageType)(0),        // 1: ai.stigmer.agentic.agentexecution.v1.MessageType
+	(ToolCallStatus)(0),     // 2: ai.stigmer.agentic.agentexecution.v1.ToolCallStatus
+	(TodoStatus)(0),         // 3: ai.stigmer.agentic.agentexecution.v1.TodoStatus
+	(SubAgentStatus)(0),     // 4: ai.stigmer.agentic.agentexecution.v1.SubAgentStatus
+	(CallbackOutputMode)(0), // 5: ai.stigmer.agentic.agentexecution.v1.CallbackOutputMode
 }
 var file_ai_stigmer_agentic_agentexecution_v1_enum_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -382,7 +446,7 @@ func file_ai_stigmer_agentic_agentexecution_v1_enum_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_agentexecution_v1_enum_proto_rawDesc), len(file_ai_stigmer_agentic_agentexecution_v1_enum_proto_rawDesc)),
-			NumEnums:      5,
+			NumEnums:      6,
 			NumMessages:   0,
 			NumExtensions: 0,
 			NumServices:   0,
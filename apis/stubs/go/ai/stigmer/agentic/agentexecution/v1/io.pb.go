@@ -118,7 +118,10 @@ type AgentExecutionList struct {
 	// Total number of pages available.
 	TotalPages int32 `protobuf:"varint,1,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
 	// Agent executions in the current page.
-	Entries       []*AgentExecution `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	Entries []*AgentExecution `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Token for the next page, passed as page_token in the next request.
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -167,10 +170,18 @@ func (x *AgentExecutionList) GetEntries() []*AgentExecution {
 	return nil
 }
 
+func (x *AgentExecutionList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 // ListAgentExecutionsRequest specifies parameters for listing executions.
 type ListAgentExecutionsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Maximum number of executions to return per page.
+	// Maximum number of executions to return per page (max 100).
+	// If 0, all executions are returned; unpaginated lists are deprecated.
 	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	// Token for pagination, obtained from previous response.
 	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
@@ -245,7 +256,8 @@ type ListAgentExecutionsBySessionRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Session ID to filter by.
 	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	// Maximum number of executions to return per page.
+	// Maximum number of executions to return per page (max 100).
+	// If 0, all executions are returned; unpaginated lists are deprecated.
 	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	// Token for pagination, obtained from previous response.
 	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
@@ -312,11 +324,12 @@ const file_ai_stigmer_agentic_agentexecution_v1_io_proto_rawDesc = "" +
 	"\x10AgentExecutionId\x12\x1c\n" +
 	"\x05value\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05value\")\n" +
 	"\tSessionId\x12\x1c\n" +
-	"\x05value\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05value\"\x85\x01\n" +
+	"\x05value\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05value\"\xad\x01\n" +
 	"\x12AgentExecutionList\x12\x1f\n" +
 	"\vtotal_pages\x18\x01 \x01(\x05R\n" +
 	"totalPages\x12N\n" +
-	"\aentries\x18\x02 \x03(\v24.ai.stigmer.agentic.agentexecution.v1.AgentExecutionR\aentries\"\xb8\x01\n" +
+	"\aentries\x18\x02 \x03(\v24.ai.stigmer.agentic.agentexecution.v1.AgentExecutionR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\xb8\x01\n" +
 	"\x1aListAgentExecutionsRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
@@ -8,9 +8,11 @@ package agentexecutionv1
 
 import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	v11 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
 	v1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/executioncontext/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -103,40 +105,36 @@ type AgentExecutionSpec struct {
 	// **Example Usage (Go)**:
 	// ```go
 	// // In Zigflow workflow activity
+	// func CallAgentActivity(ctx context.Context, config *AgentCallTaskConfig) {
+	//     // Extract task token
+	//     taskToken := activity.GetInfo(ctx).TaskToken
 	//
-	//	func CallAgentActivity(ctx context.Context, config *AgentCallTaskConfig) {
-	//	    // Extract task token
-	//	    taskToken := activity.GetInfo(ctx).TaskToken
+	//     // Create agent execution with token
+	//     execution := &AgentExecution{
+	//         Spec: &AgentExecutionSpec{
+	//             AgentId: config.Agent,
+	//             Message: config.Message,
+	//             CallbackToken: taskToken,  // 👈 Pass token here
+	//         },
+	//     }
 	//
-	//	    // Create agent execution with token
-	//	    execution := &AgentExecution{
-	//	        Spec: &AgentExecutionSpec{
-	//	            AgentId: config.Agent,
-	//	            Message: config.Message,
-	//	            CallbackToken: taskToken,  // 👈 Pass token here
-	//	        },
-	//	    }
-	//
-	//	    client.Create(ctx, execution)
-	//
-	//	    // Return pending - activity paused, thread released
-	//	    return nil, activity.ErrResultPending
-	//	}
+	//     client.Create(ctx, execution)
 	//
+	//     // Return pending - activity paused, thread released
+	//     return nil, activity.ErrResultPending
+	// }
 	// ```
 	//
 	// **Example Usage (Java Completion)**:
 	// ```java
 	// // In agent workflow (after completion)
-	//
-	//	if (spec.getCallbackToken() != null && !spec.getCallbackToken().isEmpty()) {
-	//	    // Complete the external activity
-	//	    systemActivities.completeZigflowToken(
-	//	        spec.getCallbackToken(),
-	//	        result
-	//	    );
-	//	}
-	//
+	// if (spec.getCallbackToken() != null && !spec.getCallbackToken().isEmpty()) {
+	//     // Complete the external activity
+	//     systemActivities.completeZigflowToken(
+	//         spec.getCallbackToken(),
+	//         result
+	//     );
+	// }
 	// ```
 	//
 	// **References**:
@@ -147,6 +145,10 @@ type AgentExecutionSpec struct {
 	//
 	// @since 2026-01-22 (Phase 2: Async Agent Execution Integration)
 	CallbackToken []byte `protobuf:"bytes,6,opt,name=callback_token,json=callbackToken,proto3" json:"callback_token,omitempty"`
+	// Files attached to the message (e.g., by a workflow AGENT_CALL task).
+	// The agent runner writes them to /attachments/{name} in the sandbox so
+	// the agent's tools can read them.
+	Attachments   []*ExecutionAttachment `protobuf:"bytes,7,rep,name=attachments,proto3" json:"attachments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -223,14 +225,36 @@ func (x *AgentExecutionSpec) GetCallbackToken() []byte {
 	return nil
 }
 
+func (x *AgentExecutionSpec) GetAttachments() []*ExecutionAttachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
 // Configuration that can be applied at execution time.
 type ExecutionConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The model to use for this execution.
 	// Example: "claude-sonnet-4-20250514"
-	ModelName     string `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ModelName string `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	// Conversation memory configuration for this execution.
+	// Resolved from the agent's spec at creation time when not set explicitly.
+	Memory *v11.MemoryConfig `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	// JSON Schema the agent's final response must conform to.
+	// Resolved from the agent's spec at creation time when not set explicitly.
+	OutputSchema *structpb.Struct `protobuf:"bytes,3,opt,name=output_schema,json=outputSchema,proto3" json:"output_schema,omitempty"`
+	// Decisions on tool calls awaiting approval in the session.
+	// When set, the execution resumes the interrupted conversation with these
+	// decisions instead of starting a new turn.
+	ToolApprovals []*ToolApproval `protobuf:"bytes,4,rep,name=tool_approvals,json=toolApprovals,proto3" json:"tool_approvals,omitempty"`
+	// Shape of the result the caller is completed with when the execution was
+	// created with a callback_token (e.g. by a workflow AGENT_CALL task).
+	// Ignored when output_schema is set: the caller needs the messages to
+	// validate the structured response.
+	CallbackOutput CallbackOutputMode `protobuf:"varint,5,opt,name=callback_output,json=callbackOutput,proto3,enum=ai.stigmer.agentic.agentexecution.v1.CallbackOutputMode" json:"callback_output,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ExecutionConfig) Reset() {
@@ -270,11 +294,168 @@ func (x *ExecutionConfig) GetModelName() string {
 	return ""
 }
 
+func (x *ExecutionConfig) GetMemory() *v11.MemoryConfig {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *ExecutionConfig) GetOutputSchema() *structpb.Struct {
+	if x != nil {
+		return x.OutputSchema
+	}
+	return nil
+}
+
+func (x *ExecutionConfig) GetToolApprovals() []*ToolApproval {
+	if x != nil {
+		return x.ToolApprovals
+	}
+	return nil
+}
+
+func (x *ExecutionConfig) GetCallbackOutput() CallbackOutputMode {
+	if x != nil {
+		return x.CallbackOutput
+	}
+	return CallbackOutputMode_CALLBACK_OUTPUT_MODE_UNSPECIFIED
+}
+
+// A decision on a tool call paused by the agent's tool policy
+// (status TOOL_CALL_AWAITING_APPROVAL).
+type ToolApproval struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the tool call awaiting approval.
+	ToolCallId string `protobuf:"bytes,1,opt,name=tool_call_id,json=toolCallId,proto3" json:"tool_call_id,omitempty"`
+	// Whether the tool call may run.
+	Approved bool `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	// Reason given to the agent when the call is rejected (optional).
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ToolApproval) Reset() {
+	*x = ToolApproval{}
+	mi := &file_ai_stigmer_agentic_agentexecution_v1_spec_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ToolApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToolApproval) ProtoMessage() {}
+
+func (x *ToolApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_agentexecution_v1_spec_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToolApproval.ProtoReflect.Descriptor instead.
+func (*ToolApproval) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_agentexecution_v1_spec_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ToolApproval) GetToolCallId() string {
+	if x != nil {
+		return x.ToolCallId
+	}
+	return ""
+}
+
+func (x *ToolApproval) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ToolApproval) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// A file attached to an agent execution.
+type ExecutionAttachment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// File name (e.g., "diff.patch").
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// File content.
+	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// MIME type of the content (e.g., "text/x-patch").
+	MimeType      string `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecutionAttachment) Reset() {
+	*x = ExecutionAttachment{}
+	mi := &file_ai_stigmer_agentic_agentexecution_v1_spec_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionAttachment) ProtoMessage() {}
+
+func (x *ExecutionAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_agentexecution_v1_spec_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionAttachment.ProtoReflect.Descriptor instead.
+func (*ExecutionAttachment) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_agentexecution_v1_spec_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExecutionAttachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExecutionAttachment) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ExecutionAttachment) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
 var File_ai_stigmer_agentic_agentexecution_v1_spec_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_agentexecution_v1_spec_proto_rawDesc = "" +
 	"\n" +
-	"/ai/stigmer/agentic/agentexecution/v1/spec.proto\x12$ai.stigmer.agentic.agentexecution.v1\x1a1ai/stigmer/agentic/executioncontext/v1/spec.proto\x1a\x1bbuf/validate/validate.proto\"\xdc\x03\n" +
+	"/ai/stigmer/agentic/agentexecution/v1/spec.proto\x12$ai.stigmer.agentic.agentexecution.v1\x1a&ai/stigmer/agentic/agent/v1/spec.proto\x1a/ai/stigmer/agentic/agentexecution/v1/enum.proto\x1a1ai/stigmer/agentic/executioncontext/v1/spec.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xc3\x04\n" +
 	"\x12AgentExecutionSpec\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x19\n" +
@@ -283,13 +464,28 @@ const file_ai_stigmer_agentic_agentexecution_v1_spec_proto_rawDesc = "" +
 	"\x10execution_config\x18\x04 \x01(\v25.ai.stigmer.agentic.agentexecution.v1.ExecutionConfigR\x0fexecutionConfig\x12i\n" +
 	"\vruntime_env\x18\x05 \x03(\v2H.ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec.RuntimeEnvEntryR\n" +
 	"runtimeEnv\x12%\n" +
-	"\x0ecallback_token\x18\x06 \x01(\fR\rcallbackToken\x1au\n" +
+	"\x0ecallback_token\x18\x06 \x01(\fR\rcallbackToken\x12e\n" +
+	"\vattachments\x18\a \x03(\v29.ai.stigmer.agentic.agentexecution.v1.ExecutionAttachmentB\b\xbaH\x05\x92\x01\x02\x10\x14R\vattachments\x1au\n" +
 	"\x0fRuntimeEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12L\n" +
-	"\x05value\x18\x02 \x01(\v26.ai.stigmer.agentic.executioncontext.v1.ExecutionValueR\x05value:\x028\x01\"0\n" +
+	"\x05value\x18\x02 \x01(\v26.ai.stigmer.agentic.executioncontext.v1.ExecutionValueR\x05value:\x028\x01\"\xef\x02\n" +
 	"\x0fExecutionConfig\x12\x1d\n" +
 	"\n" +
-	"model_name\x18\x01 \x01(\tR\tmodelNameB\xca\x02\n" +
+	"model_name\x18\x01 \x01(\tR\tmodelName\x12A\n" +
+	"\x06memory\x18\x02 \x01(\v2).ai.stigmer.agentic.agent.v1.MemoryConfigR\x06memory\x12<\n" +
+	"\routput_schema\x18\x03 \x01(\v2\x17.google.protobuf.StructR\foutputSchema\x12Y\n" +
+	"\x0etool_approvals\x18\x04 \x03(\v22.ai.stigmer.agentic.agentexecution.v1.ToolApprovalR\rtoolApprovals\x12a\n" +
+	"\x0fcallback_output\x18\x05 \x01(\x0e28.ai.stigmer.agentic.agentexecution.v1.CallbackOutputModeR\x0ecallbackOutput\"w\n" +
+	"\fToolApproval\x12)\n" +
+	"\ftool_call_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
+	"toolCallId\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\bR\bapproved\x12 \n" +
+	"\x06reason\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xe8\aR\x06reason\"\x99\x01\n" +
+	"\x13ExecutionAttachment\x12?\n" +
+	"\x04name\x18\x01 \x01(\tB+\xbaH(\xc8\x01\x01r#\x18\xff\x012\x1e^[A-Za-z0-9_-][A-Za-z0-9._-]*$R\x04name\x12$\n" +
+	"\acontent\x18\x02 \x01(\fB\n" +
+	"\xbaH\az\x05\x18\x80\x80\x80\x02R\acontent\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeTypeB\xca\x02\n" +
 	"(com.ai.stigmer.agentic.agentexecution.v1B\tSpecProtoP\x01Z^github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1;agentexecutionv1\xa2\x02\x04ASAA\xaa\x02$Ai.Stigmer.Agentic.Agentexecution.V1\xca\x02$Ai\\Stigmer\\Agentic\\Agentexecution\\V1\xe2\x020Ai\\Stigmer\\Agentic\\Agentexecution\\V1\\GPBMetadata\xea\x02(Ai::Stigmer::Agentic::Agentexecution::V1b\x06proto3"
 
 var (
@@ -304,22 +500,32 @@ func file_ai_stigmer_agentic_agentexecution_v1_spec_proto_rawDescGZIP() []byte {
 	return file_ai_stigmer_agentic_agentexecution_v1_spec_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_agentexecution_v1_spec_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_ai_stigmer_agentic_agentexecution_v1_spec_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_ai_stigmer_agentic_agentexecution_v1_spec_proto_goTypes = []any{
-	(*AgentExecutionSpec)(nil), // 0: ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec
-	(*ExecutionConfig)(nil),    // 1: ai.stigmer.agentic.agentexecution.v1.ExecutionConfig
-	nil,                        // 2: ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec.RuntimeEnvEntry
-	(*v1.ExecutionValue)(nil),  // 3: ai.stigmer.agentic.executioncontext.v1.ExecutionValue
+	(*AgentExecutionSpec)(nil),  // 0: ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec
+	(*ExecutionConfig)(nil),     // 1: ai.stigmer.agentic.agentexecution.v1.ExecutionConfig
+	(*ToolApproval)(nil),        // 2: ai.stigmer.agentic.agentexecution.v1.ToolApproval
+	(*ExecutionAttachment)(nil), // 3: ai.stigmer.agentic.agentexecution.v1.ExecutionAttachment
+	nil,                         // 4: ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec.RuntimeEnvEntry
+	(*v11.MemoryConfig)(nil),    // 5: ai.stigmer.agentic.agent.v1.MemoryConfig
+	(*structpb.Struct)(nil),     // 6: google.protobuf.Struct
+	(CallbackOutputMode)(0),     // 7: ai.stigmer.agentic.agentexecution.v1.CallbackOutputMode
+	(*v1.ExecutionValue)(nil),   // 8: ai.stigmer.agentic.executioncontext.v1.ExecutionValue
 }
 var file_ai_stigmer_agentic_agentexecution_v1_spec_proto_depIdxs = []int32{
 	1, // 0: ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec.execution_config:type_name -> ai.stigmer.agentic.agentexecution.v1.ExecutionConfig
-	2, // 1: ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec.runtime_env:type_name -> ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec.RuntimeEnvEntry
-	3, // 2: ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec.RuntimeEnvEntry.value:type_name -> ai.stigmer.agentic.executioncontext.v1.ExecutionValue
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 1: ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec.runtime_env:type_name -> ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec.RuntimeEnvEntry
+	3, // 2: ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec.attachments:type_name -> ai.stigmer.agentic.agentexecution.v1.ExecutionAttachment
+	5, // 3: ai.stigmer.agentic.agentexecution.v1.ExecutionConfig.memory:type_name -> ai.stigmer.agentic.agent.v1.MemoryConfig
+	6, // 4: ai.stigmer.agentic.agentexecution.v1.ExecutionConfig.output_schema:type_name -> google.protobuf.Struct
+	2, // 5: ai.stigmer.agentic.agentexecution.v1.ExecutionConfig.tool_approvals:type_name -> ai.stigmer.agentic.agentexecution.v1.ToolApproval
+	7, // 6: ai.stigmer.agentic.agentexecution.v1.ExecutionConfig.callback_output:type_name -> ai.stigmer.agentic.agentexecution.v1.CallbackOutputMode
+	8, // 7: ai.stigmer.agentic.agentexecution.v1.AgentExecutionSpec.RuntimeEnvEntry.value:type_name -> ai.stigmer.agentic.executioncontext.v1.ExecutionValue
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_agentic_agentexecution_v1_spec_proto_init() }
@@ -327,13 +533,14 @@ func file_ai_stigmer_agentic_agentexecution_v1_spec_proto_init() {
 	if File_ai_stigmer_agentic_agentexecution_v1_spec_proto != nil {
 		return
 	}
+	file_ai_stigmer_agentic_agentexecution_v1_enum_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_agentexecution_v1_spec_proto_rawDesc), len(file_ai_stigmer_agentic_agentexecution_v1_spec_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -21,6 +21,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// EnvironmentValueKind is the kind of value an environment variable holds.
+type EnvironmentValueKind int32
+
+const (
+	EnvironmentValueKind_ENVIRONMENT_VALUE_KIND_UNSPECIFIED EnvironmentValueKind = 0 // Any string (same as ENV_VALUE_STRING)
+	EnvironmentValueKind_ENV_VALUE_STRING                   EnvironmentValueKind = 1 // Any string
+	EnvironmentValueKind_ENV_VALUE_INT                      EnvironmentValueKind = 2 // Base-10 integer, optionally bounded by min and max
+	EnvironmentValueKind_ENV_VALUE_BOOL                     EnvironmentValueKind = 3 // "true" or "false"
+	EnvironmentValueKind_ENV_VALUE_ENUM                     EnvironmentValueKind = 4 // One of allowed_values
+)
+
+// Enum value maps for EnvironmentValueKind.
+var (
+	EnvironmentValueKind_name = map[int32]string{
+		0: "ENVIRONMENT_VALUE_KIND_UNSPECIFIED",
+		1: "ENV_VALUE_STRING",
+		2: "ENV_VALUE_INT",
+		3: "ENV_VALUE_BOOL",
+		4: "ENV_VALUE_ENUM",
+	}
+	EnvironmentValueKind_value = map[string]int32{
+		"ENVIRONMENT_VALUE_KIND_UNSPECIFIED": 0,
+		"ENV_VALUE_STRING":                   1,
+		"ENV_VALUE_INT":                      2,
+		"ENV_VALUE_BOOL":                     3,
+		"ENV_VALUE_ENUM":                     4,
+	}
+)
+
+func (x EnvironmentValueKind) Enum() *EnvironmentValueKind {
+	p := new(EnvironmentValueKind)
+	*p = x
+	return p
+}
+
+func (x EnvironmentValueKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EnvironmentValueKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_ai_stigmer_agentic_environment_v1_spec_proto_enumTypes[0].Descriptor()
+}
+
+func (EnvironmentValueKind) Type() protoreflect.EnumType {
+	return &file_ai_stigmer_agentic_environment_v1_spec_proto_enumTypes[0]
+}
+
+func (x EnvironmentValueKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EnvironmentValueKind.Descriptor instead.
+func (EnvironmentValueKind) EnumDescriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_environment_v1_spec_proto_rawDescGZIP(), []int{0}
+}
+
 // EnvironmentSpec defines a collection of configuration and secrets.
 // Created before AgentInstance or WorkflowInstance, referenced during instance creation.
 type EnvironmentSpec struct {
@@ -31,8 +87,7 @@ type EnvironmentSpec struct {
 	// Key-value pairs containing both configuration and secrets.
 	// Each value includes a flag indicating whether it's a secret.
 	// Example: {"AWS_REGION": {value: "us-west-2", is_secret: false},
-	//
-	//	"AWS_ACCESS_KEY_ID": {value: "AKIA...", is_secret: true}}
+	//           "AWS_ACCESS_KEY_ID": {value: "AKIA...", is_secret: true}}
 	Data          map[string]*EnvironmentValue `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -89,8 +144,7 @@ type EnvironmentValue struct {
 	// - If is_secret=true: This value is encrypted at rest and redacted in logs
 	// - If is_secret=false: This value is stored as plaintext
 	// Note: Value can be empty when defining environment variables in specs.
-	//
-	//	Actual values are typically provided at runtime during execution.
+	//       Actual values are typically provided at runtime during execution.
 	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
 	// Whether this value should be treated as a secret.
 	// When true:
@@ -103,7 +157,12 @@ type EnvironmentValue struct {
 	IsSecret bool `protobuf:"varint,2,opt,name=is_secret,json=isSecret,proto3" json:"is_secret,omitempty"`
 	// Optional description for documentation.
 	// Example: "AWS access key for S3 bucket access"
-	Description   string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Optional type and constraints of the value.
+	// Set on variables declared by an Agent or Workflow; values supplied by the
+	// environments referenced at instance creation are validated against it.
+	// Unset means any string is accepted.
+	Type          *EnvironmentValueType `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -159,6 +218,87 @@ func (x *EnvironmentValue) GetDescription() string {
 	return ""
 }
 
+func (x *EnvironmentValue) GetType() *EnvironmentValueType {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+// EnvironmentValueType declares the kind and constraints of an environment variable.
+type EnvironmentValueType struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The kind of value.
+	Kind EnvironmentValueKind `protobuf:"varint,1,opt,name=kind,proto3,enum=ai.stigmer.agentic.environment.v1.EnvironmentValueKind" json:"kind,omitempty"`
+	// Inclusive lower bound for ENV_VALUE_INT values.
+	Min *int64 `protobuf:"varint,2,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	// Inclusive upper bound for ENV_VALUE_INT values.
+	Max *int64 `protobuf:"varint,3,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	// Allowed values for ENV_VALUE_ENUM values.
+	// Example: ["fast", "thorough"]
+	AllowedValues []string `protobuf:"bytes,4,rep,name=allowed_values,json=allowedValues,proto3" json:"allowed_values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnvironmentValueType) Reset() {
+	*x = EnvironmentValueType{}
+	mi := &file_ai_stigmer_agentic_environment_v1_spec_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvironmentValueType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvironmentValueType) ProtoMessage() {}
+
+func (x *EnvironmentValueType) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_environment_v1_spec_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvironmentValueType.ProtoReflect.Descriptor instead.
+func (*EnvironmentValueType) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_environment_v1_spec_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EnvironmentValueType) GetKind() EnvironmentValueKind {
+	if x != nil {
+		return x.Kind
+	}
+	return EnvironmentValueKind_ENVIRONMENT_VALUE_KIND_UNSPECIFIED
+}
+
+func (x *EnvironmentValueType) GetMin() int64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *EnvironmentValueType) GetMax() int64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *EnvironmentValueType) GetAllowedValues() []string {
+	if x != nil {
+		return x.AllowedValues
+	}
+	return nil
+}
+
 var File_ai_stigmer_agentic_environment_v1_spec_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_environment_v1_spec_proto_rawDesc = "" +
@@ -169,11 +309,25 @@ const file_ai_stigmer_agentic_environment_v1_spec_proto_rawDesc = "" +
 	"\x04data\x18\x02 \x03(\v2<.ai.stigmer.agentic.environment.v1.EnvironmentSpec.DataEntryR\x04data\x1al\n" +
 	"\tDataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12I\n" +
-	"\x05value\x18\x02 \x01(\v23.ai.stigmer.agentic.environment.v1.EnvironmentValueR\x05value:\x028\x01\"g\n" +
+	"\x05value\x18\x02 \x01(\v23.ai.stigmer.agentic.environment.v1.EnvironmentValueR\x05value:\x028\x01\"\xb4\x01\n" +
 	"\x10EnvironmentValue\x12\x14\n" +
 	"\x05value\x18\x01 \x01(\tR\x05value\x12\x1b\n" +
 	"\tis_secret\x18\x02 \x01(\bR\bisSecret\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescriptionB\xb5\x02\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12K\n" +
+	"\x04type\x18\x04 \x01(\v27.ai.stigmer.agentic.environment.v1.EnvironmentValueTypeR\x04type\"\xc8\x01\n" +
+	"\x14EnvironmentValueType\x12K\n" +
+	"\x04kind\x18\x01 \x01(\x0e27.ai.stigmer.agentic.environment.v1.EnvironmentValueKindR\x04kind\x12\x15\n" +
+	"\x03min\x18\x02 \x01(\x03H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x03 \x01(\x03H\x01R\x03max\x88\x01\x01\x12%\n" +
+	"\x0eallowed_values\x18\x04 \x03(\tR\rallowedValuesB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max*\x8f\x01\n" +
+	"\x14EnvironmentValueKind\x12&\n" +
+	"\"ENVIRONMENT_VALUE_KIND_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10ENV_VALUE_STRING\x10\x01\x12\x11\n" +
+	"\rENV_VALUE_INT\x10\x02\x12\x12\n" +
+	"\x0eENV_VALUE_BOOL\x10\x03\x12\x12\n" +
+	"\x0eENV_VALUE_ENUM\x10\x04B\xb5\x02\n" +
 	"%com.ai.stigmer.agentic.environment.v1B\tSpecProtoP\x01ZXgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1;environmentv1\xa2\x02\x04ASAE\xaa\x02!Ai.Stigmer.Agentic.Environment.V1\xca\x02!Ai\\Stigmer\\Agentic\\Environment\\V1\xe2\x02-Ai\\Stigmer\\Agentic\\Environment\\V1\\GPBMetadata\xea\x02%Ai::Stigmer::Agentic::Environment::V1b\x06proto3"
 
 var (
@@ -188,20 +342,25 @@ func file_ai_stigmer_agentic_environment_v1_spec_proto_rawDescGZIP() []byte {
 	return file_ai_stigmer_agentic_environment_v1_spec_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_environment_v1_spec_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_ai_stigmer_agentic_environment_v1_spec_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_ai_stigmer_agentic_environment_v1_spec_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_ai_stigmer_agentic_environment_v1_spec_proto_goTypes = []any{
-	(*EnvironmentSpec)(nil),  // 0: ai.stigmer.agentic.environment.v1.EnvironmentSpec
-	(*EnvironmentValue)(nil), // 1: ai.stigmer.agentic.environment.v1.EnvironmentValue
-	nil,                      // 2: ai.stigmer.agentic.environment.v1.EnvironmentSpec.DataEntry
+	(EnvironmentValueKind)(0),    // 0: ai.stigmer.agentic.environment.v1.EnvironmentValueKind
+	(*EnvironmentSpec)(nil),      // 1: ai.stigmer.agentic.environment.v1.EnvironmentSpec
+	(*EnvironmentValue)(nil),     // 2: ai.stigmer.agentic.environment.v1.EnvironmentValue
+	(*EnvironmentValueType)(nil), // 3: ai.stigmer.agentic.environment.v1.EnvironmentValueType
+	nil,                          // 4: ai.stigmer.agentic.environment.v1.EnvironmentSpec.DataEntry
 }
 var file_ai_stigmer_agentic_environment_v1_spec_proto_depIdxs = []int32{
-	2, // 0: ai.stigmer.agentic.environment.v1.EnvironmentSpec.data:type_name -> ai.stigmer.agentic.environment.v1.EnvironmentSpec.DataEntry
-	1, // 1: ai.stigmer.agentic.environment.v1.EnvironmentSpec.DataEntry.value:type_name -> ai.stigmer.agentic.environment.v1.EnvironmentValue
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4, // 0: ai.stigmer.agentic.environment.v1.EnvironmentSpec.data:type_name -> ai.stigmer.agentic.environment.v1.EnvironmentSpec.DataEntry
+	3, // 1: ai.stigmer.agentic.environment.v1.EnvironmentValue.type:type_name -> ai.stigmer.agentic.environment.v1.EnvironmentValueType
+	0, // 2: ai.stigmer.agentic.environment.v1.EnvironmentValueType.kind:type_name -> ai.stigmer.agentic.environment.v1.EnvironmentValueKind
+	2, // 3: ai.stigmer.agentic.environment.v1.EnvironmentSpec.DataEntry.value:type_name -> ai.stigmer.agentic.environment.v1.EnvironmentValue
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_agentic_environment_v1_spec_proto_init() }
@@ -209,18 +368,20 @@ func file_ai_stigmer_agentic_environment_v1_spec_proto_init() {
 	if File_ai_stigmer_agentic_environment_v1_spec_proto != nil {
 		return
 	}
+	file_ai_stigmer_agentic_environment_v1_spec_proto_msgTypes[2].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_environment_v1_spec_proto_rawDesc), len(file_ai_stigmer_agentic_environment_v1_spec_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_ai_stigmer_agentic_environment_v1_spec_proto_goTypes,
 		DependencyIndexes: file_ai_stigmer_agentic_environment_v1_spec_proto_depIdxs,
+		EnumInfos:         file_ai_stigmer_agentic_environment_v1_spec_proto_enumTypes,
 		MessageInfos:      file_ai_stigmer_agentic_environment_v1_spec_proto_msgTypes,
 	}.Build()
 	File_ai_stigmer_agentic_environment_v1_spec_proto = out.File
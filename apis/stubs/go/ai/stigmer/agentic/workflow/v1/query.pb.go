@@ -26,25 +26,30 @@ var File_ai_stigmer_agentic_workflow_v1_query_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflow_v1_query_proto_rawDesc = "" +
 	"\n" +
-	"*ai/stigmer/agentic/workflow/v1/query.proto\x12\x1eai.stigmer.agentic.workflow.v1\x1a(ai/stigmer/agentic/workflow/v1/api.proto\x1a'ai/stigmer/agentic/workflow/v1/io.proto\x1a'ai/stigmer/commons/apiresource/io.proto\x1a8ai/stigmer/commons/apiresource/rpc_service_options.proto\x1aAai/stigmer/iam/iampolicy/v1/rpcauthorization/method_options.proto2\x9e\x02\n" +
+	"*ai/stigmer/agentic/workflow/v1/query.proto\x12\x1eai.stigmer.agentic.workflow.v1\x1a(ai/stigmer/agentic/workflow/v1/api.proto\x1a'ai/stigmer/agentic/workflow/v1/io.proto\x1a'ai/stigmer/commons/apiresource/io.proto\x1a8ai/stigmer/commons/apiresource/rpc_service_options.proto\x1aAai/stigmer/iam/iampolicy/v1/rpcauthorization/method_options.proto2\x90\x03\n" +
 	"\x17WorkflowQueryController\x12\x8a\x01\n" +
 	"\x03get\x12*.ai.stigmer.agentic.workflow.v1.WorkflowId\x1a(.ai.stigmer.agentic.workflow.v1.Workflow\"-¸\x18)\b\x03\x102\"\x05value*\x1cunauthorized to get workflow\x12p\n" +
-	"\x0egetByReference\x124.ai.stigmer.commons.apiresource.ApiResourceReference\x1a(.ai.stigmer.agentic.workflow.v1.Workflow\x1a\x04\xa0\xff+2B\xa1\x02\n" +
+	"\x0egetByReference\x124.ai.stigmer.commons.apiresource.ApiResourceReference\x1a(.ai.stigmer.agentic.workflow.v1.Workflow\x12p\n" +
+	"\x04list\x124.ai.stigmer.agentic.workflow.v1.ListWorkflowsRequest\x1a,.ai.stigmer.agentic.workflow.v1.WorkflowList\"\x04и\x18\x01\x1a\x04\xa0\xff+2B\xa1\x02\n" +
 	"\"com.ai.stigmer.agentic.workflow.v1B\n" +
 	"QueryProtoP\x01ZRgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1;workflowv1\xa2\x02\x04ASAW\xaa\x02\x1eAi.Stigmer.Agentic.Workflow.V1\xca\x02\x1eAi\\Stigmer\\Agentic\\Workflow\\V1\xe2\x02*Ai\\Stigmer\\Agentic\\Workflow\\V1\\GPBMetadata\xea\x02\"Ai::Stigmer::Agentic::Workflow::V1b\x06proto3"
 
 var file_ai_stigmer_agentic_workflow_v1_query_proto_goTypes = []any{
 	(*WorkflowId)(nil),                       // 0: ai.stigmer.agentic.workflow.v1.WorkflowId
 	(*apiresource.ApiResourceReference)(nil), // 1: ai.stigmer.commons.apiresource.ApiResourceReference
-	(*Workflow)(nil),                         // 2: ai.stigmer.agentic.workflow.v1.Workflow
+	(*ListWorkflowsRequest)(nil),             // 2: ai.stigmer.agentic.workflow.v1.ListWorkflowsRequest
+	(*Workflow)(nil),                         // 3: ai.stigmer.agentic.workflow.v1.Workflow
+	(*WorkflowList)(nil),                     // 4: ai.stigmer.agentic.workflow.v1.WorkflowList
 }
 var file_ai_stigmer_agentic_workflow_v1_query_proto_depIdxs = []int32{
 	0, // 0: ai.stigmer.agentic.workflow.v1.WorkflowQueryController.get:input_type -> ai.stigmer.agentic.workflow.v1.WorkflowId
 	1, // 1: ai.stigmer.agentic.workflow.v1.WorkflowQueryController.getByReference:input_type -> ai.stigmer.commons.apiresource.ApiResourceReference
-	2, // 2: ai.stigmer.agentic.workflow.v1.WorkflowQueryController.get:output_type -> ai.stigmer.agentic.workflow.v1.Workflow
-	2, // 3: ai.stigmer.agentic.workflow.v1.WorkflowQueryController.getByReference:output_type -> ai.stigmer.agentic.workflow.v1.Workflow
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
+	2, // 2: ai.stigmer.agentic.workflow.v1.WorkflowQueryController.list:input_type -> ai.stigmer.agentic.workflow.v1.ListWorkflowsRequest
+	3, // 3: ai.stigmer.agentic.workflow.v1.WorkflowQueryController.get:output_type -> ai.stigmer.agentic.workflow.v1.Workflow
+	3, // 4: ai.stigmer.agentic.workflow.v1.WorkflowQueryController.getByReference:output_type -> ai.stigmer.agentic.workflow.v1.Workflow
+	4, // 5: ai.stigmer.agentic.workflow.v1.WorkflowQueryController.list:output_type -> ai.stigmer.agentic.workflow.v1.WorkflowList
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	WorkflowQueryController_Get_FullMethodName            = "/ai.stigmer.agentic.workflow.v1.WorkflowQueryController/get"
 	WorkflowQueryController_GetByReference_FullMethodName = "/ai.stigmer.agentic.workflow.v1.WorkflowQueryController/getByReference"
+	WorkflowQueryController_List_FullMethodName           = "/ai.stigmer.agentic.workflow.v1.WorkflowQueryController/list"
 )
 
 // WorkflowQueryControllerClient is the client API for WorkflowQueryController service.
@@ -34,6 +35,11 @@ type WorkflowQueryControllerClient interface {
 	Get(ctx context.Context, in *WorkflowId, opts ...grpc.CallOption) (*Workflow, error)
 	// Custom authorization in handler
 	GetByReference(ctx context.Context, in *apiresource.ApiResourceReference, opts ...grpc.CallOption) (*Workflow, error)
+	// List workflows with pagination, optionally filtered by label selector.
+	//
+	// Example: all workflows where team=data
+	// { page_size: 50, label_selector: { "team": "data" } }
+	List(ctx context.Context, in *ListWorkflowsRequest, opts ...grpc.CallOption) (*WorkflowList, error)
 }
 
 type workflowQueryControllerClient struct {
@@ -64,6 +70,16 @@ func (c *workflowQueryControllerClient) GetByReference(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *workflowQueryControllerClient) List(ctx context.Context, in *ListWorkflowsRequest, opts ...grpc.CallOption) (*WorkflowList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkflowList)
+	err := c.cc.Invoke(ctx, WorkflowQueryController_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkflowQueryControllerServer is the server API for WorkflowQueryController service.
 // All implementations should embed UnimplementedWorkflowQueryControllerServer
 // for forward compatibility.
@@ -74,6 +90,11 @@ type WorkflowQueryControllerServer interface {
 	Get(context.Context, *WorkflowId) (*Workflow, error)
 	// Custom authorization in handler
 	GetByReference(context.Context, *apiresource.ApiResourceReference) (*Workflow, error)
+	// List workflows with pagination, optionally filtered by label selector.
+	//
+	// Example: all workflows where team=data
+	// { page_size: 50, label_selector: { "team": "data" } }
+	List(context.Context, *ListWorkflowsRequest) (*WorkflowList, error)
 }
 
 // UnimplementedWorkflowQueryControllerServer should be embedded to have
@@ -89,6 +110,9 @@ func (UnimplementedWorkflowQueryControllerServer) Get(context.Context, *Workflow
 func (UnimplementedWorkflowQueryControllerServer) GetByReference(context.Context, *apiresource.ApiResourceReference) (*Workflow, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByReference not implemented")
 }
+func (UnimplementedWorkflowQueryControllerServer) List(context.Context, *ListWorkflowsRequest) (*WorkflowList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
 func (UnimplementedWorkflowQueryControllerServer) testEmbeddedByValue() {}
 
 // UnsafeWorkflowQueryControllerServer may be embedded to opt out of forward compatibility for this service.
@@ -145,6 +169,24 @@ func _WorkflowQueryController_GetByReference_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowQueryController_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkflowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowQueryControllerServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkflowQueryController_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowQueryControllerServer).List(ctx, req.(*ListWorkflowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkflowQueryController_ServiceDesc is the grpc.ServiceDesc for WorkflowQueryController service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -160,6 +202,10 @@ var WorkflowQueryController_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "getByReference",
 			Handler:    _WorkflowQueryController_GetByReference_Handler,
 		},
+		{
+			MethodName: "list",
+			Handler:    _WorkflowQueryController_List_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ai/stigmer/agentic/workflow/v1/query.proto",
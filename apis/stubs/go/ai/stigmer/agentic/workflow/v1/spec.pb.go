@@ -25,6 +25,109 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ScheduleCatchUpPolicy defines how missed schedule fire times are handled.
+type ScheduleCatchUpPolicy int32
+
+const (
+	ScheduleCatchUpPolicy_SCHEDULE_CATCH_UP_POLICY_UNSPECIFIED ScheduleCatchUpPolicy = 0 // Treated as SCHEDULE_SKIP_MISSED
+	ScheduleCatchUpPolicy_SCHEDULE_SKIP_MISSED                 ScheduleCatchUpPolicy = 1 // Missed fire times are dropped
+	ScheduleCatchUpPolicy_SCHEDULE_RUN_MISSED                  ScheduleCatchUpPolicy = 2 // Missed fire times run once the scheduler is available again
+)
+
+// Enum value maps for ScheduleCatchUpPolicy.
+var (
+	ScheduleCatchUpPolicy_name = map[int32]string{
+		0: "SCHEDULE_CATCH_UP_POLICY_UNSPECIFIED",
+		1: "SCHEDULE_SKIP_MISSED",
+		2: "SCHEDULE_RUN_MISSED",
+	}
+	ScheduleCatchUpPolicy_value = map[string]int32{
+		"SCHEDULE_CATCH_UP_POLICY_UNSPECIFIED": 0,
+		"SCHEDULE_SKIP_MISSED":                 1,
+		"SCHEDULE_RUN_MISSED":                  2,
+	}
+)
+
+func (x ScheduleCatchUpPolicy) Enum() *ScheduleCatchUpPolicy {
+	p := new(ScheduleCatchUpPolicy)
+	*p = x
+	return p
+}
+
+func (x ScheduleCatchUpPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScheduleCatchUpPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_ai_stigmer_agentic_workflow_v1_spec_proto_enumTypes[0].Descriptor()
+}
+
+func (ScheduleCatchUpPolicy) Type() protoreflect.EnumType {
+	return &file_ai_stigmer_agentic_workflow_v1_spec_proto_enumTypes[0]
+}
+
+func (x ScheduleCatchUpPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScheduleCatchUpPolicy.Descriptor instead.
+func (ScheduleCatchUpPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{0}
+}
+
+// ConcurrencyConflictAction defines how a conflicting execution is handled.
+type ConcurrencyConflictAction int32
+
+const (
+	ConcurrencyConflictAction_CONCURRENCY_CONFLICT_ACTION_UNSPECIFIED ConcurrencyConflictAction = 0 // Treated as CONCURRENCY_QUEUE
+	ConcurrencyConflictAction_CONCURRENCY_QUEUE                       ConcurrencyConflictAction = 1 // Wait until in-flight executions finish, then start
+	ConcurrencyConflictAction_CONCURRENCY_SKIP                        ConcurrencyConflictAction = 2 // Do not run the new execution
+	ConcurrencyConflictAction_CONCURRENCY_CANCEL_EXISTING             ConcurrencyConflictAction = 3 // Cancel in-flight executions and start the new one
+)
+
+// Enum value maps for ConcurrencyConflictAction.
+var (
+	ConcurrencyConflictAction_name = map[int32]string{
+		0: "CONCURRENCY_CONFLICT_ACTION_UNSPECIFIED",
+		1: "CONCURRENCY_QUEUE",
+		2: "CONCURRENCY_SKIP",
+		3: "CONCURRENCY_CANCEL_EXISTING",
+	}
+	ConcurrencyConflictAction_value = map[string]int32{
+		"CONCURRENCY_CONFLICT_ACTION_UNSPECIFIED": 0,
+		"CONCURRENCY_QUEUE":                       1,
+		"CONCURRENCY_SKIP":                        2,
+		"CONCURRENCY_CANCEL_EXISTING":             3,
+	}
+)
+
+func (x ConcurrencyConflictAction) Enum() *ConcurrencyConflictAction {
+	p := new(ConcurrencyConflictAction)
+	*p = x
+	return p
+}
+
+func (x ConcurrencyConflictAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConcurrencyConflictAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_ai_stigmer_agentic_workflow_v1_spec_proto_enumTypes[1].Descriptor()
+}
+
+func (ConcurrencyConflictAction) Type() protoreflect.EnumType {
+	return &file_ai_stigmer_agentic_workflow_v1_spec_proto_enumTypes[1]
+}
+
+func (x ConcurrencyConflictAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConcurrencyConflictAction.Descriptor instead.
+func (ConcurrencyConflictAction) EnumDescriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{1}
+}
+
 // WorkflowSpec defines the complete specification of a workflow.
 // Follows the "kind + Struct" pattern from CloudResource (Planton Cloud).
 //
@@ -42,9 +145,30 @@ type WorkflowSpec struct {
 	Tasks []*WorkflowTask `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"`
 	// Environment variables required by the workflow.
 	// Uses the shared EnvironmentSpec for consistent env var handling.
-	EnvSpec       *v1.EnvironmentSpec `protobuf:"bytes,4,opt,name=env_spec,json=envSpec,proto3" json:"env_spec,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	EnvSpec *v1.EnvironmentSpec `protobuf:"bytes,4,opt,name=env_spec,json=envSpec,proto3" json:"env_spec,omitempty"`
+	// Concurrency policy for executions of this workflow (optional).
+	// When set, executions that share the same key never run at the same time.
+	ConcurrencyPolicy *ConcurrencyPolicy `protobuf:"bytes,5,opt,name=concurrency_policy,json=concurrencyPolicy,proto3" json:"concurrency_policy,omitempty"`
+	// Cron schedule that triggers executions of the workflow (optional).
+	// When set, stigmer-server maintains a Temporal schedule for each instance
+	// of the workflow, updated when the spec changes and removed with the instance.
+	Schedule *WorkflowSchedule `protobuf:"bytes,6,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// Tasks run when the workflow fails (optional).
+	// They run in order after the main tasks fail, with the failure available
+	// to expressions as $data.error (message, type, classification, task).
+	// They do not run when the workflow succeeds, and a failing handler task
+	// does not replace the original error in the execution status.
+	// Task names must be distinct from the main task names.
+	OnFailure []*WorkflowTask `protobuf:"bytes,7,rep,name=on_failure,json=onFailure,proto3" json:"on_failure,omitempty"`
+	// Maximum duration of an execution, in seconds (optional, 0 = no maximum).
+	// Once exceeded, the runner stops the tasks and the execution fails with
+	// classification WORKFLOW_TIMEOUT. The on_failure tasks still run, with
+	// $data.error.type "WorkflowTimeout". The Temporal run timeout of the
+	// execution is this duration plus a grace period for them.
+	// At least 10 seconds when set.
+	MaxDurationSeconds int32 `protobuf:"varint,8,opt,name=max_duration_seconds,json=maxDurationSeconds,proto3" json:"max_duration_seconds,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *WorkflowSpec) Reset() {
@@ -105,6 +229,164 @@ func (x *WorkflowSpec) GetEnvSpec() *v1.EnvironmentSpec {
 	return nil
 }
 
+func (x *WorkflowSpec) GetConcurrencyPolicy() *ConcurrencyPolicy {
+	if x != nil {
+		return x.ConcurrencyPolicy
+	}
+	return nil
+}
+
+func (x *WorkflowSpec) GetSchedule() *WorkflowSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *WorkflowSpec) GetOnFailure() []*WorkflowTask {
+	if x != nil {
+		return x.OnFailure
+	}
+	return nil
+}
+
+func (x *WorkflowSpec) GetMaxDurationSeconds() int32 {
+	if x != nil {
+		return x.MaxDurationSeconds
+	}
+	return 0
+}
+
+// WorkflowSchedule triggers executions of a workflow on a cron schedule.
+type WorkflowSchedule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Standard 5-field cron expression (minute hour day-of-month month day-of-week)
+	// or a descriptor such as "@daily".
+	// Example: "0 2 * * *" (every day at 02:00)
+	Cron string `protobuf:"bytes,1,opt,name=cron,proto3" json:"cron,omitempty"`
+	// IANA time zone the cron expression is evaluated in.
+	// Defaults to "UTC" when empty.
+	// Example: "Europe/Berlin"
+	Timezone string `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// What to do with fire times missed while the scheduler was unavailable.
+	CatchUpPolicy ScheduleCatchUpPolicy `protobuf:"varint,3,opt,name=catch_up_policy,json=catchUpPolicy,proto3,enum=ai.stigmer.agentic.workflow.v1.ScheduleCatchUpPolicy" json:"catch_up_policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowSchedule) Reset() {
+	*x = WorkflowSchedule{}
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowSchedule) ProtoMessage() {}
+
+func (x *WorkflowSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowSchedule.ProtoReflect.Descriptor instead.
+func (*WorkflowSchedule) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WorkflowSchedule) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *WorkflowSchedule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *WorkflowSchedule) GetCatchUpPolicy() ScheduleCatchUpPolicy {
+	if x != nil {
+		return x.CatchUpPolicy
+	}
+	return ScheduleCatchUpPolicy_SCHEDULE_CATCH_UP_POLICY_UNSPECIFIED
+}
+
+// ConcurrencyPolicy serializes executions of a workflow that share a key.
+//
+// Enforced by the WorkflowExecution controller when executions are created:
+// a new execution whose key matches an in-flight (pending or in-progress)
+// execution of the same workflow is handled according to on_conflict.
+type ConcurrencyPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key executions are serialized on.
+	// "org" uses the execution's organization, "workflow" serializes all
+	// executions of the workflow, and any other value is looked up in the
+	// execution's trigger_metadata.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// What to do when an in-flight execution with the same key exists.
+	OnConflict    ConcurrencyConflictAction `protobuf:"varint,2,opt,name=on_conflict,json=onConflict,proto3,enum=ai.stigmer.agentic.workflow.v1.ConcurrencyConflictAction" json:"on_conflict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConcurrencyPolicy) Reset() {
+	*x = ConcurrencyPolicy{}
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConcurrencyPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConcurrencyPolicy) ProtoMessage() {}
+
+func (x *ConcurrencyPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConcurrencyPolicy.ProtoReflect.Descriptor instead.
+func (*ConcurrencyPolicy) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ConcurrencyPolicy) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConcurrencyPolicy) GetOnConflict() ConcurrencyConflictAction {
+	if x != nil {
+		return x.OnConflict
+	}
+	return ConcurrencyConflictAction_CONCURRENCY_CONFLICT_ACTION_UNSPECIFIED
+}
+
 // WorkflowDocument contains workflow metadata.
 // Maps to the `document:` block in Zigflow DSL YAML.
 type WorkflowDocument struct {
@@ -125,7 +407,7 @@ type WorkflowDocument struct {
 
 func (x *WorkflowDocument) Reset() {
 	*x = WorkflowDocument{}
-	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[1]
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -137,7 +419,7 @@ func (x *WorkflowDocument) String() string {
 func (*WorkflowDocument) ProtoMessage() {}
 
 func (x *WorkflowDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[1]
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -150,7 +432,7 @@ func (x *WorkflowDocument) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WorkflowDocument.ProtoReflect.Descriptor instead.
 func (*WorkflowDocument) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{1}
+	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *WorkflowDocument) GetDsl() string {
@@ -229,6 +511,7 @@ type WorkflowTask struct {
 	// - CALL_ACTIVITY: ai.stigmer.agentic.workflow.v1.tasks.CallActivityTaskConfig
 	// - RAISE: ai.stigmer.agentic.workflow.v1.tasks.RaiseTaskConfig
 	// - RUN: ai.stigmer.agentic.workflow.v1.tasks.RunTaskConfig
+	// - CUSTOM: defined by the runner extension registered for custom_kind
 	//
 	// See: apis/ai/stigmer/agentic/workflow/v1/tasks/*.proto for detailed schemas.
 	TaskConfig *structpb.Struct `protobuf:"bytes,3,opt,name=task_config,json=taskConfig,proto3" json:"task_config,omitempty"`
@@ -237,14 +520,44 @@ type WorkflowTask struct {
 	Export *Export `protobuf:"bytes,4,opt,name=export,proto3" json:"export,omitempty"`
 	// Flow control (which task executes next).
 	// Optional - if not set, continues to next task in sequence.
-	Flow          *FlowControl `protobuf:"bytes,5,opt,name=flow,proto3" json:"flow,omitempty"`
+	Flow *FlowControl `protobuf:"bytes,5,opt,name=flow,proto3" json:"flow,omitempty"`
+	// Wall-clock execution bound for this task, in seconds.
+	// Applies to every task kind: activity-backed tasks get it as their activity
+	// timeout (overriding the workflow/queue default) and tasks that run in
+	// workflow code are cancelled by a workflow timer.
+	// Distinct from HTTP_CALL timeout_seconds, which only bounds the HTTP request.
+	// When exceeded, the task fails with error type "ExecutionTimeout".
+	// Optional - 0 means the runner default applies.
+	ExecutionTimeoutSeconds int32 `protobuf:"varint,6,opt,name=execution_timeout_seconds,json=executionTimeoutSeconds,proto3" json:"execution_timeout_seconds,omitempty"`
+	// Top-level output fields that carry sensitive data (tokens, credentials).
+	// The runner keeps these values available to later tasks in the same
+	// execution but replaces them with a redaction marker in exported context,
+	// task output and execution history.
+	// Optional - empty means the output is recorded as-is.
+	SensitiveOutputFields []string `protobuf:"bytes,7,rep,name=sensitive_output_fields,json=sensitiveOutputFields,proto3" json:"sensitive_output_fields,omitempty"`
+	// Guard expression deciding whether this task runs.
+	// Maps to the `if:` directive in Zigflow DSL.
+	// Example: ${ $context["analyze"].severity | . == "high" }
+	// When it evaluates to false the task is skipped; a skipped task that
+	// exports records {"skipped": true} in the context under its name.
+	// Optional - empty means the task always runs.
+	If string `protobuf:"bytes,8,opt,name=if,proto3" json:"if,omitempty"`
+	// Human-readable description of what the task does, for handover notes
+	// and generated documentation. Not interpreted by the runner.
+	// Example: "Fetches the user's open PRs from GitHub, paginated"
+	// Optional - at most 500 characters.
+	Description string `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	// Name of the runner extension task kind of a CUSTOM task, in upper snake
+	// case (e.g. "SNOWFLAKE_QUERY"). Its task_config is extension-defined.
+	// Required for CUSTOM tasks, empty otherwise.
+	CustomKind    string `protobuf:"bytes,10,opt,name=custom_kind,json=customKind,proto3" json:"custom_kind,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WorkflowTask) Reset() {
 	*x = WorkflowTask{}
-	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[2]
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -256,7 +569,7 @@ func (x *WorkflowTask) String() string {
 func (*WorkflowTask) ProtoMessage() {}
 
 func (x *WorkflowTask) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[2]
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -269,7 +582,7 @@ func (x *WorkflowTask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WorkflowTask.ProtoReflect.Descriptor instead.
 func (*WorkflowTask) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{2}
+	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *WorkflowTask) GetName() string {
@@ -307,6 +620,41 @@ func (x *WorkflowTask) GetFlow() *FlowControl {
 	return nil
 }
 
+func (x *WorkflowTask) GetExecutionTimeoutSeconds() int32 {
+	if x != nil {
+		return x.ExecutionTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *WorkflowTask) GetSensitiveOutputFields() []string {
+	if x != nil {
+		return x.SensitiveOutputFields
+	}
+	return nil
+}
+
+func (x *WorkflowTask) GetIf() string {
+	if x != nil {
+		return x.If
+	}
+	return ""
+}
+
+func (x *WorkflowTask) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WorkflowTask) GetCustomKind() string {
+	if x != nil {
+		return x.CustomKind
+	}
+	return ""
+}
+
 // Export defines how to save task output to context.
 // Maps to the `export:` block in Zigflow DSL.
 //
@@ -325,7 +673,7 @@ type Export struct {
 
 func (x *Export) Reset() {
 	*x = Export{}
-	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[3]
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -337,7 +685,7 @@ func (x *Export) String() string {
 func (*Export) ProtoMessage() {}
 
 func (x *Export) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[3]
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +698,7 @@ func (x *Export) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Export.ProtoReflect.Descriptor instead.
 func (*Export) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{3}
+	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Export) GetAs() string {
@@ -377,7 +725,7 @@ type FlowControl struct {
 
 func (x *FlowControl) Reset() {
 	*x = FlowControl{}
-	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[4]
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -389,7 +737,7 @@ func (x *FlowControl) String() string {
 func (*FlowControl) ProtoMessage() {}
 
 func (x *FlowControl) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[4]
+	mi := &file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -402,7 +750,7 @@ func (x *FlowControl) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlowControl.ProtoReflect.Descriptor instead.
 func (*FlowControl) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{4}
+	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *FlowControl) GetThen() string {
@@ -416,29 +764,60 @@ var File_ai_stigmer_agentic_workflow_v1_spec_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDesc = "" +
 	"\n" +
-	")ai/stigmer/agentic/workflow/v1/spec.proto\x12\x1eai.stigmer.agentic.workflow.v1\x1a,ai/stigmer/agentic/environment/v1/spec.proto\x1a)ai/stigmer/commons/apiresource/enum.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xa3\x02\n" +
+	")ai/stigmer/agentic/workflow/v1/spec.proto\x12\x1eai.stigmer.agentic.workflow.v1\x1a,ai/stigmer/agentic/environment/v1/spec.proto\x1a)ai/stigmer/commons/apiresource/enum.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xc3\x05\n" +
 	"\fWorkflowSpec\x12 \n" +
 	"\vdescription\x18\x01 \x01(\tR\vdescription\x12T\n" +
 	"\bdocument\x18\x02 \x01(\v20.ai.stigmer.agentic.workflow.v1.WorkflowDocumentB\x06\xbaH\x03\xc8\x01\x01R\bdocument\x12L\n" +
 	"\x05tasks\x18\x03 \x03(\v2,.ai.stigmer.agentic.workflow.v1.WorkflowTaskB\b\xbaH\x05\x92\x01\x02\b\x01R\x05tasks\x12M\n" +
-	"\benv_spec\x18\x04 \x01(\v22.ai.stigmer.agentic.environment.v1.EnvironmentSpecR\aenvSpec\"\xbc\x01\n" +
+	"\benv_spec\x18\x04 \x01(\v22.ai.stigmer.agentic.environment.v1.EnvironmentSpecR\aenvSpec\x12`\n" +
+	"\x12concurrency_policy\x18\x05 \x01(\v21.ai.stigmer.agentic.workflow.v1.ConcurrencyPolicyR\x11concurrencyPolicy\x12L\n" +
+	"\bschedule\x18\x06 \x01(\v20.ai.stigmer.agentic.workflow.v1.WorkflowScheduleR\bschedule\x12K\n" +
+	"\n" +
+	"on_failure\x18\a \x03(\v2,.ai.stigmer.agentic.workflow.v1.WorkflowTaskR\tonFailure\x12\xa0\x01\n" +
+	"\x14max_duration_seconds\x18\b \x01(\x05Bn\xbaHk\xba\x01h\n" +
+	"\x1amax_duration_seconds.floor\x121max_duration_seconds must be at least 10 when set\x1a\x17this == 0 || this >= 10R\x12maxDurationSeconds\"\xb4\x01\n" +
+	"\x10WorkflowSchedule\x12\x1b\n" +
+	"\x04cron\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04cron\x12\x1a\n" +
+	"\btimezone\x18\x02 \x01(\tR\btimezone\x12g\n" +
+	"\x0fcatch_up_policy\x18\x03 \x01(\x0e25.ai.stigmer.agentic.workflow.v1.ScheduleCatchUpPolicyB\b\xbaH\x05\x82\x01\x02\x10\x01R\rcatchUpPolicy\"\x94\x01\n" +
+	"\x11ConcurrencyPolicy\x12\x19\n" +
+	"\x03key\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x03key\x12d\n" +
+	"\von_conflict\x18\x02 \x01(\x0e29.ai.stigmer.agentic.workflow.v1.ConcurrencyConflictActionB\b\xbaH\x05\x82\x01\x02\x10\x01R\n" +
+	"onConflict\"\xbc\x01\n" +
 	"\x10WorkflowDocument\x12\"\n" +
 	"\x03dsl\x18\x01 \x01(\tB\x10\xbaH\rr\v2\t^1\\.0\\.0$R\x03dsl\x12$\n" +
 	"\tnamespace\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\tnamespace\x12\x1a\n" +
 	"\x04name\x18\x03 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04name\x12 \n" +
 	"\aversion\x18\x04 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\aversion\x12 \n" +
-	"\vdescription\x18\x05 \x01(\tR\vdescription\"\xbb\x02\n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\xdd\x05\n" +
 	"\fWorkflowTask\x12\x1a\n" +
 	"\x04name\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04name\x12L\n" +
 	"\x04kind\x18\x02 \x01(\x0e20.ai.stigmer.commons.apiresource.WorkflowTaskKindB\x06\xbaH\x03\xc8\x01\x01R\x04kind\x12@\n" +
 	"\vtask_config\x18\x03 \x01(\v2\x17.google.protobuf.StructB\x06\xbaH\x03\xc8\x01\x01R\n" +
 	"taskConfig\x12>\n" +
 	"\x06export\x18\x04 \x01(\v2&.ai.stigmer.agentic.workflow.v1.ExportR\x06export\x12?\n" +
-	"\x04flow\x18\x05 \x01(\v2+.ai.stigmer.agentic.workflow.v1.FlowControlR\x04flow\"!\n" +
+	"\x04flow\x18\x05 \x01(\v2+.ai.stigmer.agentic.workflow.v1.FlowControlR\x04flow\x12C\n" +
+	"\x19execution_timeout_seconds\x18\x06 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x17executionTimeoutSeconds\x12D\n" +
+	"\x17sensitive_output_fields\x18\a \x03(\tB\f\xbaH\t\x92\x01\x06\"\x04r\x02\x10\x01R\x15sensitiveOutputFields\x12\x0e\n" +
+	"\x02if\x18\b \x01(\tR\x02if\x12*\n" +
+	"\vdescription\x18\t \x01(\tB\b\xbaH\x05r\x03\x18\xf4\x03R\vdescription\x12H\n" +
+	"\vcustom_kind\x18\n" +
+	" \x01(\tB'\xbaH$r\"2 ^([A-Z][A-Z0-9]*(_[A-Z0-9]+)*)?$R\n" +
+	"customKind:\x8e\x01\xbaH\x8a\x01\x1a\x87\x01\n" +
+	"\x19workflow_task.custom_kind\x12;custom_kind must be set for CUSTOM tasks, and only for them\x1a-(this.kind == 14) == (this.custom_kind != '')\"!\n" +
 	"\x06Export\x12\x17\n" +
 	"\x02as\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02as\"!\n" +
 	"\vFlowControl\x12\x12\n" +
-	"\x04then\x18\x01 \x01(\tR\x04thenB\xa0\x02\n" +
+	"\x04then\x18\x01 \x01(\tR\x04then*t\n" +
+	"\x15ScheduleCatchUpPolicy\x12(\n" +
+	"$SCHEDULE_CATCH_UP_POLICY_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14SCHEDULE_SKIP_MISSED\x10\x01\x12\x17\n" +
+	"\x13SCHEDULE_RUN_MISSED\x10\x02*\x96\x01\n" +
+	"\x19ConcurrencyConflictAction\x12+\n" +
+	"'CONCURRENCY_CONFLICT_ACTION_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11CONCURRENCY_QUEUE\x10\x01\x12\x14\n" +
+	"\x10CONCURRENCY_SKIP\x10\x02\x12\x1f\n" +
+	"\x1bCONCURRENCY_CANCEL_EXISTING\x10\x03B\xa0\x02\n" +
 	"\"com.ai.stigmer.agentic.workflow.v1B\tSpecProtoP\x01ZRgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1;workflowv1\xa2\x02\x04ASAW\xaa\x02\x1eAi.Stigmer.Agentic.Workflow.V1\xca\x02\x1eAi\\Stigmer\\Agentic\\Workflow\\V1\xe2\x02*Ai\\Stigmer\\Agentic\\Workflow\\V1\\GPBMetadata\xea\x02\"Ai::Stigmer::Agentic::Workflow::V1b\x06proto3"
 
 var (
@@ -453,30 +832,40 @@ func file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescGZIP() []byte {
 	return file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_ai_stigmer_agentic_workflow_v1_spec_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_ai_stigmer_agentic_workflow_v1_spec_proto_goTypes = []any{
-	(*WorkflowSpec)(nil),              // 0: ai.stigmer.agentic.workflow.v1.WorkflowSpec
-	(*WorkflowDocument)(nil),          // 1: ai.stigmer.agentic.workflow.v1.WorkflowDocument
-	(*WorkflowTask)(nil),              // 2: ai.stigmer.agentic.workflow.v1.WorkflowTask
-	(*Export)(nil),                    // 3: ai.stigmer.agentic.workflow.v1.Export
-	(*FlowControl)(nil),               // 4: ai.stigmer.agentic.workflow.v1.FlowControl
-	(*v1.EnvironmentSpec)(nil),        // 5: ai.stigmer.agentic.environment.v1.EnvironmentSpec
-	(apiresource.WorkflowTaskKind)(0), // 6: ai.stigmer.commons.apiresource.WorkflowTaskKind
-	(*structpb.Struct)(nil),           // 7: google.protobuf.Struct
+	(ScheduleCatchUpPolicy)(0),        // 0: ai.stigmer.agentic.workflow.v1.ScheduleCatchUpPolicy
+	(ConcurrencyConflictAction)(0),    // 1: ai.stigmer.agentic.workflow.v1.ConcurrencyConflictAction
+	(*WorkflowSpec)(nil),              // 2: ai.stigmer.agentic.workflow.v1.WorkflowSpec
+	(*WorkflowSchedule)(nil),          // 3: ai.stigmer.agentic.workflow.v1.WorkflowSchedule
+	(*ConcurrencyPolicy)(nil),         // 4: ai.stigmer.agentic.workflow.v1.ConcurrencyPolicy
+	(*WorkflowDocument)(nil),          // 5: ai.stigmer.agentic.workflow.v1.WorkflowDocument
+	(*WorkflowTask)(nil),              // 6: ai.stigmer.agentic.workflow.v1.WorkflowTask
+	(*Export)(nil),                    // 7: ai.stigmer.agentic.workflow.v1.Export
+	(*FlowControl)(nil),               // 8: ai.stigmer.agentic.workflow.v1.FlowControl
+	(*v1.EnvironmentSpec)(nil),        // 9: ai.stigmer.agentic.environment.v1.EnvironmentSpec
+	(apiresource.WorkflowTaskKind)(0), // 10: ai.stigmer.commons.apiresource.WorkflowTaskKind
+	(*structpb.Struct)(nil),           // 11: google.protobuf.Struct
 }
 var file_ai_stigmer_agentic_workflow_v1_spec_proto_depIdxs = []int32{
-	1, // 0: ai.stigmer.agentic.workflow.v1.WorkflowSpec.document:type_name -> ai.stigmer.agentic.workflow.v1.WorkflowDocument
-	2, // 1: ai.stigmer.agentic.workflow.v1.WorkflowSpec.tasks:type_name -> ai.stigmer.agentic.workflow.v1.WorkflowTask
-	5, // 2: ai.stigmer.agentic.workflow.v1.WorkflowSpec.env_spec:type_name -> ai.stigmer.agentic.environment.v1.EnvironmentSpec
-	6, // 3: ai.stigmer.agentic.workflow.v1.WorkflowTask.kind:type_name -> ai.stigmer.commons.apiresource.WorkflowTaskKind
-	7, // 4: ai.stigmer.agentic.workflow.v1.WorkflowTask.task_config:type_name -> google.protobuf.Struct
-	3, // 5: ai.stigmer.agentic.workflow.v1.WorkflowTask.export:type_name -> ai.stigmer.agentic.workflow.v1.Export
-	4, // 6: ai.stigmer.agentic.workflow.v1.WorkflowTask.flow:type_name -> ai.stigmer.agentic.workflow.v1.FlowControl
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	5,  // 0: ai.stigmer.agentic.workflow.v1.WorkflowSpec.document:type_name -> ai.stigmer.agentic.workflow.v1.WorkflowDocument
+	6,  // 1: ai.stigmer.agentic.workflow.v1.WorkflowSpec.tasks:type_name -> ai.stigmer.agentic.workflow.v1.WorkflowTask
+	9,  // 2: ai.stigmer.agentic.workflow.v1.WorkflowSpec.env_spec:type_name -> ai.stigmer.agentic.environment.v1.EnvironmentSpec
+	4,  // 3: ai.stigmer.agentic.workflow.v1.WorkflowSpec.concurrency_policy:type_name -> ai.stigmer.agentic.workflow.v1.ConcurrencyPolicy
+	3,  // 4: ai.stigmer.agentic.workflow.v1.WorkflowSpec.schedule:type_name -> ai.stigmer.agentic.workflow.v1.WorkflowSchedule
+	6,  // 5: ai.stigmer.agentic.workflow.v1.WorkflowSpec.on_failure:type_name -> ai.stigmer.agentic.workflow.v1.WorkflowTask
+	0,  // 6: ai.stigmer.agentic.workflow.v1.WorkflowSchedule.catch_up_policy:type_name -> ai.stigmer.agentic.workflow.v1.ScheduleCatchUpPolicy
+	1,  // 7: ai.stigmer.agentic.workflow.v1.ConcurrencyPolicy.on_conflict:type_name -> ai.stigmer.agentic.workflow.v1.ConcurrencyConflictAction
+	10, // 8: ai.stigmer.agentic.workflow.v1.WorkflowTask.kind:type_name -> ai.stigmer.commons.apiresource.WorkflowTaskKind
+	11, // 9: ai.stigmer.agentic.workflow.v1.WorkflowTask.task_config:type_name -> google.protobuf.Struct
+	7,  // 10: ai.stigmer.agentic.workflow.v1.WorkflowTask.export:type_name -> ai.stigmer.agentic.workflow.v1.Export
+	8,  // 11: ai.stigmer.agentic.workflow.v1.WorkflowTask.flow:type_name -> ai.stigmer.agentic.workflow.v1.FlowControl
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_agentic_workflow_v1_spec_proto_init() }
@@ -489,13 +878,14 @@ func file_ai_stigmer_agentic_workflow_v1_spec_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDesc), len(file_ai_stigmer_agentic_workflow_v1_spec_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_ai_stigmer_agentic_workflow_v1_spec_proto_goTypes,
 		DependencyIndexes: file_ai_stigmer_agentic_workflow_v1_spec_proto_depIdxs,
+		EnumInfos:         file_ai_stigmer_agentic_workflow_v1_spec_proto_enumTypes,
 		MessageInfos:      file_ai_stigmer_agentic_workflow_v1_spec_proto_msgTypes,
 	}.Build()
 	File_ai_stigmer_agentic_workflow_v1_spec_proto = out.File
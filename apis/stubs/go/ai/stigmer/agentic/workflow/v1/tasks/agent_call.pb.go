@@ -11,6 +11,7 @@ import (
 	apiresource "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -34,6 +35,8 @@ const (
 // 2. If scope is ORGANIZATION: look only in org agents
 // 3. If scope is UNSPECIFIED: defaults to ORGANIZATION scope
 // 4. Before explicit scope lookup, check manifest (current deployment)
+// When org is set, the agent is looked up in that organization instead of
+// the one running the workflow.
 //
 // The workflow's execution context (environment variables, secrets) is
 // passed to the agent invocation, allowing agents to access workflow state.
@@ -50,6 +53,10 @@ const (
 //     config:
 //     model: "claude-3-5-sonnet"
 //     timeout: 300
+//     attachments:
+//   - name: "diff.patch"
+//     content: "${ $context.fetchDiff.body }"
+//     mime_type: "text/x-patch"
 //
 // Reference: design doc at stigmer/_cursor/add-agent-config-to-workflow.md
 type AgentCallTaskConfig struct {
@@ -76,7 +83,33 @@ type AgentCallTaskConfig struct {
 	Env map[string]string `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Execution configuration for the agent invocation.
 	// Optional - defaults are applied if not specified.
-	Config        *AgentExecutionConfig `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`
+	Config *AgentExecutionConfig `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`
+	// Record the agent's output as an array of chunks with timestamps.
+	// When true, the task output includes "chunks": [{"text": ..., "timestamp": ...}]
+	// alongside "final", so progress can be persisted for audit logs.
+	// Ignored when final_output_only is true.
+	// Optional (default: false).
+	StreamToContext bool `protobuf:"varint,6,opt,name=stream_to_context,json=streamToContext,proto3" json:"stream_to_context,omitempty"`
+	// Record only the agent's final message in the task output ({"final": ...}).
+	// Use this for long-running agents to keep Temporal history small.
+	// Takes precedence over stream_to_context.
+	// Optional (default: false).
+	FinalOutputOnly bool `protobuf:"varint,7,opt,name=final_output_only,json=finalOutputOnly,proto3" json:"final_output_only,omitempty"`
+	// Files passed to the agent alongside the message, such as a diff fetched
+	// by a previous task. Use attachments instead of inlining large content
+	// into the message; the agent runner writes them into the agent's sandbox.
+	// Optional.
+	Attachments []*AgentAttachment `protobuf:"bytes,8,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	// Organization that owns the agent, for agents deployed outside the
+	// workflow's project (possibly by another organization).
+	// Empty resolves the agent in the organization running the workflow.
+	// Optional.
+	Org string `protobuf:"bytes,9,opt,name=org,proto3" json:"org,omitempty"`
+	// Version of the agent to invoke: empty or "latest" for the current
+	// version, otherwise a tag (e.g., "stable") or version hash the resolved
+	// agent must carry. The call fails when the agent doesn't match.
+	// Optional.
+	Version       string `protobuf:"bytes,10,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -146,6 +179,123 @@ func (x *AgentCallTaskConfig) GetConfig() *AgentExecutionConfig {
 	return nil
 }
 
+func (x *AgentCallTaskConfig) GetStreamToContext() bool {
+	if x != nil {
+		return x.StreamToContext
+	}
+	return false
+}
+
+func (x *AgentCallTaskConfig) GetFinalOutputOnly() bool {
+	if x != nil {
+		return x.FinalOutputOnly
+	}
+	return false
+}
+
+func (x *AgentCallTaskConfig) GetAttachments() []*AgentAttachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *AgentCallTaskConfig) GetOrg() string {
+	if x != nil {
+		return x.Org
+	}
+	return ""
+}
+
+func (x *AgentCallTaskConfig) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// AgentAttachment defines a file passed to an agent call.
+type AgentAttachment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// File name in the agent's attachments directory (e.g., "diff.patch").
+	// Required field.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// File content. Usually an expression referencing a previous task output.
+	// Non-string expression results are encoded as JSON.
+	// Example: "${ $context.fetchDiff.body }"
+	// Required field.
+	Content string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// MIME type of the content.
+	// Default: "text/plain"
+	// Optional.
+	MimeType string `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	// Maximum size in bytes of the evaluated content.
+	// The task fails when the content is larger.
+	// Default: 1048576 (1 MiB)
+	// Optional.
+	MaxSizeBytes  int32 `protobuf:"varint,4,opt,name=max_size_bytes,json=maxSizeBytes,proto3" json:"max_size_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentAttachment) Reset() {
+	*x = AgentAttachment{}
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentAttachment) ProtoMessage() {}
+
+func (x *AgentAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentAttachment.ProtoReflect.Descriptor instead.
+func (*AgentAttachment) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AgentAttachment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AgentAttachment) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *AgentAttachment) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *AgentAttachment) GetMaxSizeBytes() int32 {
+	if x != nil {
+		return x.MaxSizeBytes
+	}
+	return 0
+}
+
 // AgentExecutionConfig defines optional execution parameters for agent calls.
 // These settings override the agent's default configuration for this specific invocation.
 type AgentExecutionConfig struct {
@@ -162,14 +312,20 @@ type AgentExecutionConfig struct {
 	// Lower = more deterministic, Higher = more creative
 	// Default: 0.7
 	// Optional.
-	Temperature   float32 `protobuf:"fixed32,3,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	Temperature float32 `protobuf:"fixed32,3,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	// JSON Schema the agent's final response must conform to.
+	// Overrides the agent's output_schema for this invocation.
+	// The runner validates the response (retrying on mismatch) and the task
+	// output becomes the parsed JSON object.
+	// Optional.
+	OutputSchema  *structpb.Struct `protobuf:"bytes,4,opt,name=output_schema,json=outputSchema,proto3" json:"output_schema,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AgentExecutionConfig) Reset() {
 	*x = AgentExecutionConfig{}
-	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_msgTypes[1]
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -181,7 +337,7 @@ func (x *AgentExecutionConfig) String() string {
 func (*AgentExecutionConfig) ProtoMessage() {}
 
 func (x *AgentExecutionConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_msgTypes[1]
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -194,7 +350,7 @@ func (x *AgentExecutionConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AgentExecutionConfig.ProtoReflect.Descriptor instead.
 func (*AgentExecutionConfig) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_rawDescGZIP(), []int{1}
+	return file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *AgentExecutionConfig) GetModel() string {
@@ -218,27 +374,47 @@ func (x *AgentExecutionConfig) GetTemperature() float32 {
 	return 0
 }
 
+func (x *AgentExecutionConfig) GetOutputSchema() *structpb.Struct {
+	if x != nil {
+		return x.OutputSchema
+	}
+	return nil
+}
+
 var File_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_rawDesc = "" +
 	"\n" +
-	"5ai/stigmer/agentic/workflow/v1/tasks/agent_call.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a)ai/stigmer/commons/apiresource/enum.proto\x1a2ai/stigmer/commons/apiresource/field_options.proto\x1a\x1bbuf/validate/validate.proto\"\x92\x03\n" +
+	"5ai/stigmer/agentic/workflow/v1/tasks/agent_call.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a)ai/stigmer/commons/apiresource/enum.proto\x1a2ai/stigmer/commons/apiresource/field_options.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xae\x05\n" +
 	"\x13AgentCallTaskConfig\x12\"\n" +
 	"\x05agent\x18\x01 \x01(\tB\f\xbaH\t\xc8\x01\x01r\x04\x10\x01\x18?R\x05agent\x12K\n" +
 	"\x05scope\x18\x02 \x01(\x0e25.ai.stigmer.commons.apiresource.ApiResourceOwnerScopeR\x05scope\x12(\n" +
 	"\amessage\x18\x03 \x01(\tB\x0e\xbaH\a\xc8\x01\x01r\x02\x10\x01\u0605,\x01R\amessage\x12T\n" +
 	"\x03env\x18\x04 \x03(\v2B.ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.EnvEntryR\x03env\x12R\n" +
-	"\x06config\x18\x05 \x01(\v2:.ai.stigmer.agentic.workflow.v1.tasks.AgentExecutionConfigR\x06config\x1a6\n" +
+	"\x06config\x18\x05 \x01(\v2:.ai.stigmer.agentic.workflow.v1.tasks.AgentExecutionConfigR\x06config\x12*\n" +
+	"\x11stream_to_context\x18\x06 \x01(\bR\x0fstreamToContext\x12*\n" +
+	"\x11final_output_only\x18\a \x01(\bR\x0ffinalOutputOnly\x12a\n" +
+	"\vattachments\x18\b \x03(\v25.ai.stigmer.agentic.workflow.v1.tasks.AgentAttachmentB\b\xbaH\x05\x92\x01\x02\x10\x14R\vattachments\x12\x10\n" +
+	"\x03org\x18\t \x01(\tR\x03org\x12M\n" +
+	"\aversion\x18\n" +
+	" \x01(\tB3\xbaH0r.2,^$|^latest$|^[a-zA-Z0-9._-]+$|^[a-f0-9]{64}$R\aversion\x1a6\n" +
 	"\bEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x85\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc9\x01\n" +
+	"\x0fAgentAttachment\x12?\n" +
+	"\x04name\x18\x01 \x01(\tB+\xbaH(\xc8\x01\x01r#\x18\xff\x012\x1e^[A-Za-z0-9_-][A-Za-z0-9._-]*$R\x04name\x12$\n" +
+	"\acontent\x18\x02 \x01(\tB\n" +
+	"\xbaH\x03\xc8\x01\x01\u0605,\x01R\acontent\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\x122\n" +
+	"\x0emax_size_bytes\x18\x04 \x01(\x05B\f\xbaH\t\x1a\a\x18\x80\x80\x80\x02(\x00R\fmaxSizeBytes\"\xc3\x01\n" +
 	"\x14AgentExecutionConfig\x12\x14\n" +
 	"\x05model\x18\x01 \x01(\tR\x05model\x12$\n" +
 	"\atimeout\x18\x02 \x01(\x05B\n" +
 	"\xbaH\a\x1a\x05\x18\x90\x1c(\x01R\atimeout\x121\n" +
 	"\vtemperature\x18\x03 \x01(\x02B\x0f\xbaH\f\n" +
 	"\n" +
-	"\x1d\x00\x00\x80?-\x00\x00\x00\x00R\vtemperatureB\xc1\x02\n" +
+	"\x1d\x00\x00\x80?-\x00\x00\x00\x00R\vtemperature\x12<\n" +
+	"\routput_schema\x18\x04 \x01(\v2\x17.google.protobuf.StructR\foutputSchemaB\xc1\x02\n" +
 	"(com.ai.stigmer.agentic.workflow.v1.tasksB\x0eAgentCallProtoP\x01ZMgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks\xa2\x02\x06ASAWVT\xaa\x02$Ai.Stigmer.Agentic.Workflow.V1.Tasks\xca\x02$Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\xe2\x020Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\\GPBMetadata\xea\x02)Ai::Stigmer::Agentic::Workflow::V1::Tasksb\x06proto3"
 
 var (
@@ -253,22 +429,26 @@ func file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_rawDescGZIP() []
 	return file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_goTypes = []any{
 	(*AgentCallTaskConfig)(nil),            // 0: ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig
-	(*AgentExecutionConfig)(nil),           // 1: ai.stigmer.agentic.workflow.v1.tasks.AgentExecutionConfig
-	nil,                                    // 2: ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.EnvEntry
-	(apiresource.ApiResourceOwnerScope)(0), // 3: ai.stigmer.commons.apiresource.ApiResourceOwnerScope
+	(*AgentAttachment)(nil),                // 1: ai.stigmer.agentic.workflow.v1.tasks.AgentAttachment
+	(*AgentExecutionConfig)(nil),           // 2: ai.stigmer.agentic.workflow.v1.tasks.AgentExecutionConfig
+	nil,                                    // 3: ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.EnvEntry
+	(apiresource.ApiResourceOwnerScope)(0), // 4: ai.stigmer.commons.apiresource.ApiResourceOwnerScope
+	(*structpb.Struct)(nil),                // 5: google.protobuf.Struct
 }
 var file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_depIdxs = []int32{
-	3, // 0: ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.scope:type_name -> ai.stigmer.commons.apiresource.ApiResourceOwnerScope
-	2, // 1: ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.env:type_name -> ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.EnvEntry
-	1, // 2: ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.config:type_name -> ai.stigmer.agentic.workflow.v1.tasks.AgentExecutionConfig
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 0: ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.scope:type_name -> ai.stigmer.commons.apiresource.ApiResourceOwnerScope
+	3, // 1: ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.env:type_name -> ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.EnvEntry
+	2, // 2: ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.config:type_name -> ai.stigmer.agentic.workflow.v1.tasks.AgentExecutionConfig
+	1, // 3: ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig.attachments:type_name -> ai.stigmer.agentic.workflow.v1.tasks.AgentAttachment
+	5, // 4: ai.stigmer.agentic.workflow.v1.tasks.AgentExecutionConfig.output_schema:type_name -> google.protobuf.Struct
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_init() }
@@ -282,7 +462,7 @@ func file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_rawDesc), len(file_ai_stigmer_agentic_workflow_v1_tasks_agent_call_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -25,15 +25,18 @@ const (
 
 // CallActivityTaskConfig defines the configuration for CALL_ACTIVITY tasks.
 //
-// CALL_ACTIVITY tasks execute Temporal activities.
+// CALL_ACTIVITY tasks execute Temporal activities registered on a worker,
+// such as custom activities registered on the workflow runner.
 //
 // YAML Example:
 //   - taskName:
 //     call: activity
 //     with:
-//     activity: "ProcessDataActivity"
+//     activity: "transform.v1.Normalize"
 //     input:
 //     data: ${ .data }
+//     task_queue: "custom-q"
+//     timeout_seconds: 120
 //
 // Reference: zigflow-dsl-pattern-catalog.md - Task Type 10
 type CallActivityTaskConfig struct {
@@ -44,9 +47,15 @@ type CallActivityTaskConfig struct {
 	// Activity input (optional).
 	// Can be any JSON structure.
 	// Supports expressions in string values.
-	Input         *structpb.Struct `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Input *structpb.Struct `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	// Task queue of the worker that registered the activity (optional).
+	// Defaults to the workflow runner's execution queue.
+	TaskQueue string `protobuf:"bytes,3,opt,name=task_queue,json=taskQueue,proto3" json:"task_queue,omitempty"`
+	// Activity start-to-close timeout in seconds (optional).
+	// 0 uses the runner's default activity timeout.
+	TimeoutSeconds int32 `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CallActivityTaskConfig) Reset() {
@@ -93,15 +102,32 @@ func (x *CallActivityTaskConfig) GetInput() *structpb.Struct {
 	return nil
 }
 
+func (x *CallActivityTaskConfig) GetTaskQueue() string {
+	if x != nil {
+		return x.TaskQueue
+	}
+	return ""
+}
+
+func (x *CallActivityTaskConfig) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
 var File_ai_stigmer_agentic_workflow_v1_tasks_call_activity_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflow_v1_tasks_call_activity_proto_rawDesc = "" +
 	"\n" +
-	"8ai/stigmer/agentic/workflow/v1/tasks/call_activity.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\"o\n" +
+	"8ai/stigmer/agentic/workflow/v1/tasks/call_activity.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xc4\x01\n" +
 	"\x16CallActivityTaskConfig\x12&\n" +
 	"\bactivity\x18\x01 \x01(\tB\n" +
 	"\xbaH\a\xc8\x01\x01r\x02\x10\x01R\bactivity\x12-\n" +
-	"\x05input\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05inputB\xc4\x02\n" +
+	"\x05input\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05input\x12\x1d\n" +
+	"\n" +
+	"task_queue\x18\x03 \x01(\tR\ttaskQueue\x124\n" +
+	"\x0ftimeout_seconds\x18\x04 \x01(\x05B\v\xbaH\b\x1a\x06\x18\x80\xa3\x05(\x00R\x0etimeoutSecondsB\xc4\x02\n" +
 	"(com.ai.stigmer.agentic.workflow.v1.tasksB\x11CallActivityProtoP\x01ZMgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks\xa2\x02\x06ASAWVT\xaa\x02$Ai.Stigmer.Agentic.Workflow.V1.Tasks\xca\x02$Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\xe2\x020Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\\GPBMetadata\xea\x02)Ai::Stigmer::Agentic::Workflow::V1::Tasksb\x06proto3"
 
 var (
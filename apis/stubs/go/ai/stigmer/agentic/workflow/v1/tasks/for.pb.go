@@ -53,9 +53,22 @@ type ForTaskConfig struct {
 	In string `protobuf:"bytes,2,opt,name=in,proto3" json:"in,omitempty"`
 	// Tasks to execute for each iteration.
 	// Loop variables available: ${ $data.item }, ${ $data.index }
-	Do            []*v1.WorkflowTask `protobuf:"bytes,3,rep,name=do,proto3" json:"do,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Do []*v1.WorkflowTask `protobuf:"bytes,3,rep,name=do,proto3" json:"do,omitempty"`
+	// Expression evaluated after each iteration; the loop stops early when it is true.
+	// Evaluated against the iteration's state, so it can read tasks of the body.
+	// Example: ${ $context["poll"].status == "done" }
+	// Optional (default: run every iteration).
+	Until string `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`
+	// Maximum number of iterations the task may run.
+	// The task fails instead of starting an iteration beyond the cap.
+	// Optional (default: 0, no cap).
+	MaxIterations int32 `protobuf:"varint,5,opt,name=max_iterations,json=maxIterations,proto3" json:"max_iterations,omitempty"`
+	// If true, the task output is {"iterations": <count>, "results": [...]}
+	// instead of the list of iteration results.
+	// Optional (default: false).
+	ReportIterations bool `protobuf:"varint,6,opt,name=report_iterations,json=reportIterations,proto3" json:"report_iterations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *ForTaskConfig) Reset() {
@@ -109,16 +122,40 @@ func (x *ForTaskConfig) GetDo() []*v1.WorkflowTask {
 	return nil
 }
 
+func (x *ForTaskConfig) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *ForTaskConfig) GetMaxIterations() int32 {
+	if x != nil {
+		return x.MaxIterations
+	}
+	return 0
+}
+
+func (x *ForTaskConfig) GetReportIterations() bool {
+	if x != nil {
+		return x.ReportIterations
+	}
+	return false
+}
+
 var File_ai_stigmer_agentic_workflow_v1_tasks_for_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflow_v1_tasks_for_proto_rawDesc = "" +
 	"\n" +
-	".ai/stigmer/agentic/workflow/v1/tasks/for.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a)ai/stigmer/agentic/workflow/v1/spec.proto\x1a2ai/stigmer/commons/apiresource/field_options.proto\x1a\x1bbuf/validate/validate.proto\"\x97\x01\n" +
+	".ai/stigmer/agentic/workflow/v1/tasks/for.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a)ai/stigmer/agentic/workflow/v1/spec.proto\x1a2ai/stigmer/commons/apiresource/field_options.proto\x1a\x1bbuf/validate/validate.proto\"\x90\x02\n" +
 	"\rForTaskConfig\x12\x1e\n" +
 	"\x04each\x18\x01 \x01(\tB\n" +
 	"\xbaH\a\xc8\x01\x01r\x02\x10\x01R\x04each\x12\x1e\n" +
 	"\x02in\x18\x02 \x01(\tB\x0e\xbaH\a\xc8\x01\x01r\x02\x10\x01\u0605,\x01R\x02in\x12F\n" +
-	"\x02do\x18\x03 \x03(\v2,.ai.stigmer.agentic.workflow.v1.WorkflowTaskB\b\xbaH\x05\x92\x01\x02\b\x01R\x02doB\xbb\x02\n" +
+	"\x02do\x18\x03 \x03(\v2,.ai.stigmer.agentic.workflow.v1.WorkflowTaskB\b\xbaH\x05\x92\x01\x02\b\x01R\x02do\x12\x1a\n" +
+	"\x05until\x18\x04 \x01(\tB\x04\u0605,\x01R\x05until\x12.\n" +
+	"\x0emax_iterations\x18\x05 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\rmaxIterations\x12+\n" +
+	"\x11report_iterations\x18\x06 \x01(\bR\x10reportIterationsB\xbb\x02\n" +
 	"(com.ai.stigmer.agentic.workflow.v1.tasksB\bForProtoP\x01ZMgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks\xa2\x02\x06ASAWVT\xaa\x02$Ai.Stigmer.Agentic.Workflow.V1.Tasks\xca\x02$Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\xe2\x020Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\\GPBMetadata\xea\x02)Ai::Stigmer::Agentic::Workflow::V1::Tasksb\x06proto3"
 
 var (
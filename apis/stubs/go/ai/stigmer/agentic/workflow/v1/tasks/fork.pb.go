@@ -51,9 +51,15 @@ type ForkTaskConfig struct {
 	Branches []*ForkBranch `protobuf:"bytes,1,rep,name=branches,proto3" json:"branches,omitempty"`
 	// If true, first branch to complete wins (race mode).
 	// If false, all branches must complete (default).
-	Compete       bool `protobuf:"varint,2,opt,name=compete,proto3" json:"compete,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Compete bool `protobuf:"varint,2,opt,name=compete,proto3" json:"compete,omitempty"`
+	// If true, a failing branch records an error object instead of failing the fork.
+	// The branch output becomes {"succeeded": false, "error": {"message": ...}},
+	// so downstream tasks can branch on each outcome.
+	// Ignored in race mode (compete).
+	// Optional (default: false).
+	ContinueOnBranchError bool `protobuf:"varint,3,opt,name=continue_on_branch_error,json=continueOnBranchError,proto3" json:"continue_on_branch_error,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *ForkTaskConfig) Reset() {
@@ -100,6 +106,13 @@ func (x *ForkTaskConfig) GetCompete() bool {
 	return false
 }
 
+func (x *ForkTaskConfig) GetContinueOnBranchError() bool {
+	if x != nil {
+		return x.ContinueOnBranchError
+	}
+	return false
+}
+
 // ForkBranch defines a single branch in parallel execution.
 type ForkBranch struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -159,10 +172,11 @@ var File_ai_stigmer_agentic_workflow_v1_tasks_fork_proto protoreflect.FileDescri
 
 const file_ai_stigmer_agentic_workflow_v1_tasks_fork_proto_rawDesc = "" +
 	"\n" +
-	"/ai/stigmer/agentic/workflow/v1/tasks/fork.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a)ai/stigmer/agentic/workflow/v1/spec.proto\x1a\x1bbuf/validate/validate.proto\"\x82\x01\n" +
+	"/ai/stigmer/agentic/workflow/v1/tasks/fork.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a)ai/stigmer/agentic/workflow/v1/spec.proto\x1a\x1bbuf/validate/validate.proto\"\xbb\x01\n" +
 	"\x0eForkTaskConfig\x12V\n" +
 	"\bbranches\x18\x01 \x03(\v20.ai.stigmer.agentic.workflow.v1.tasks.ForkBranchB\b\xbaH\x05\x92\x01\x02\b\x02R\bbranches\x12\x18\n" +
-	"\acompete\x18\x02 \x01(\bR\acompete\"t\n" +
+	"\acompete\x18\x02 \x01(\bR\acompete\x127\n" +
+	"\x18continue_on_branch_error\x18\x03 \x01(\bR\x15continueOnBranchError\"t\n" +
 	"\n" +
 	"ForkBranch\x12\x1e\n" +
 	"\x04name\x18\x01 \x01(\tB\n" +
@@ -26,7 +26,8 @@ const (
 
 // HttpCallTaskConfig defines the configuration for HTTP_CALL tasks.
 //
-// HTTP_CALL tasks make HTTP requests (GET, POST, PUT, DELETE, PATCH).
+// HTTP_CALL tasks make HTTP requests (GET, POST, PUT, DELETE, PATCH, HEAD,
+// OPTIONS).
 //
 // YAML Example:
 //   - taskName:
@@ -39,11 +40,25 @@ const (
 //     Authorization: "Bearer ${TOKEN}"
 //     body:
 //     field1: value
+//     tls:
+//     client_cert: "${.secrets.CLIENT_CERT}"
+//     client_key: "${.secrets.CLIENT_KEY}"
+//     ca_bundle: "${.secrets.INTERNAL_CA}"
+//     cache:
+//     ttl_seconds: 600
+//     key: uri
+//     proxy:
+//     url: http://proxy.corp:3128
+//     response_format: text
+//     body_encoding: form
+//     expect_status: [200, 201]
 //
 // Reference: zigflow-dsl-pattern-catalog.md - Task Type 2
 type HttpCallTaskConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// HTTP method (GET, POST, PUT, DELETE, PATCH).
+	// HTTP method (GET, POST, PUT, DELETE, PATCH, HEAD, OPTIONS).
+	// HEAD and OPTIONS tasks output only the status code and the response
+	// headers (lowercased names): {"statusCode": 200, "headers": {"etag": ...}}.
 	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
 	// HTTP endpoint configuration.
 	Endpoint *HttpEndpoint `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
@@ -55,6 +70,41 @@ type HttpCallTaskConfig struct {
 	Body *structpb.Struct `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
 	// Request timeout in seconds (optional, default: 30).
 	TimeoutSeconds int32 `protobuf:"varint,5,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// TLS configuration for the connection (optional).
+	// Used for endpoints that require client certificates or a private CA.
+	Tls *HttpTls `protobuf:"bytes,6,opt,name=tls,proto3" json:"tls,omitempty"`
+	// Response caching for idempotent GET requests (optional).
+	Cache *HttpCache `protobuf:"bytes,7,opt,name=cache,proto3" json:"cache,omitempty"`
+	// Proxy used for the request (optional).
+	// Overrides the proxy configured on the workflow runner.
+	Proxy *HttpProxy `protobuf:"bytes,8,opt,name=proxy,proto3" json:"proxy,omitempty"`
+	// How the response body is stored in the task output (optional).
+	// "": parsed as JSON when it is a JSON object or array, otherwise a string.
+	// "json": parsed as JSON; the task fails if the body is not JSON.
+	// "text": stored as a string.
+	// "bytes_base64": stored base64-encoded, for binary payloads up to 1 MiB.
+	// The format used is recorded in the task output metadata.
+	ResponseFormat string `protobuf:"bytes,9,opt,name=response_format,json=responseFormat,proto3" json:"response_format,omitempty"`
+	// How the request body is encoded (optional).
+	// "" or "json": the body is sent as JSON.
+	// "form": the body is sent as application/x-www-form-urlencoded; nested
+	//   objects and arrays are flattened with bracket notation (a[b]=1, c[0]=2).
+	// "multipart": each body field is sent as a part of a multipart/form-data
+	//   body, configured by multipart_parts.
+	// The runner sets the Content-Type header to match the encoding.
+	BodyEncoding string `protobuf:"bytes,10,opt,name=body_encoding,json=bodyEncoding,proto3" json:"body_encoding,omitempty"`
+	// Parts of a multipart body, in the order they are sent (optional).
+	// Body fields without an entry are sent after them, sorted by name.
+	MultipartParts []*HttpMultipartPart `protobuf:"bytes,11,rep,name=multipart_parts,json=multipartParts,proto3" json:"multipart_parts,omitempty"`
+	// Response statuses that complete the task (optional).
+	// By default, the task fails on 3xx, 4xx and 5xx statuses. With
+	// expect_status, it fails on any status not listed, including 2xx ones.
+	// Failures carry a snippet of the response body and are classified
+	// UPSTREAM_4XX or UPSTREAM_5XX by status class.
+	ExpectStatus []int32 `protobuf:"varint,12,rep,packed,name=expect_status,json=expectStatus,proto3" json:"expect_status,omitempty"`
+	// The task completes whatever the response status (optional), for tasks
+	// that branch on the status themselves. Exclusive with expect_status.
+	AllowAnyStatus bool `protobuf:"varint,13,opt,name=allow_any_status,json=allowAnyStatus,proto3" json:"allow_any_status,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -124,6 +174,368 @@ func (x *HttpCallTaskConfig) GetTimeoutSeconds() int32 {
 	return 0
 }
 
+func (x *HttpCallTaskConfig) GetTls() *HttpTls {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+func (x *HttpCallTaskConfig) GetCache() *HttpCache {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+func (x *HttpCallTaskConfig) GetProxy() *HttpProxy {
+	if x != nil {
+		return x.Proxy
+	}
+	return nil
+}
+
+func (x *HttpCallTaskConfig) GetResponseFormat() string {
+	if x != nil {
+		return x.ResponseFormat
+	}
+	return ""
+}
+
+func (x *HttpCallTaskConfig) GetBodyEncoding() string {
+	if x != nil {
+		return x.BodyEncoding
+	}
+	return ""
+}
+
+func (x *HttpCallTaskConfig) GetMultipartParts() []*HttpMultipartPart {
+	if x != nil {
+		return x.MultipartParts
+	}
+	return nil
+}
+
+func (x *HttpCallTaskConfig) GetExpectStatus() []int32 {
+	if x != nil {
+		return x.ExpectStatus
+	}
+	return nil
+}
+
+func (x *HttpCallTaskConfig) GetAllowAnyStatus() bool {
+	if x != nil {
+		return x.AllowAnyStatus
+	}
+	return false
+}
+
+// HttpMultipartPart configures a part of a multipart/form-data request body.
+//
+// The value of the part is the body field of the same name, so it can use
+// expressions and runtime references like any other body value. String values
+// are sent as-is; other values are sent as JSON.
+type HttpMultipartPart struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the part, and of the body field holding its value.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// File name sent with the part, making it a file upload (optional).
+	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Content type of the part (optional).
+	// Default: "application/octet-stream" for files, "application/json" for
+	// non-string values, none otherwise.
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// The value is base64-encoded binary content, decoded before it is sent,
+	// such as the output of an HTTP_CALL with response_format "bytes_base64".
+	Base64        bool `protobuf:"varint,4,opt,name=base64,proto3" json:"base64,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HttpMultipartPart) Reset() {
+	*x = HttpMultipartPart{}
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpMultipartPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpMultipartPart) ProtoMessage() {}
+
+func (x *HttpMultipartPart) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpMultipartPart.ProtoReflect.Descriptor instead.
+func (*HttpMultipartPart) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HttpMultipartPart) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HttpMultipartPart) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *HttpMultipartPart) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *HttpMultipartPart) GetBase64() bool {
+	if x != nil {
+		return x.Base64
+	}
+	return false
+}
+
+// HttpProxy overrides the proxy of an HTTP_CALL task.
+//
+// Without it, the workflow runner uses its own proxy configuration
+// (HTTP_PROXY, HTTPS_PROXY and NO_PROXY).
+type HttpProxy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// URL of the proxy, such as "http://proxy.corp:3128".
+	// Supported schemes: http, https and socks5.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Connect directly, bypassing the runner's proxy.
+	Disabled      bool `protobuf:"varint,2,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HttpProxy) Reset() {
+	*x = HttpProxy{}
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpProxy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpProxy) ProtoMessage() {}
+
+func (x *HttpProxy) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpProxy.ProtoReflect.Descriptor instead.
+func (*HttpProxy) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HttpProxy) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *HttpProxy) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+// HttpCache marks an HTTP_CALL task as cacheable.
+//
+// The workflow runner keeps successful responses in a local cache keyed by the
+// resolved request and skips the network call while a cached response is
+// fresh. The task output metadata records whether the call was a cache hit.
+//
+// Requests whose headers reference runtime secrets (${.secrets.KEY}) are never
+// cached unless include_auth is set, so responses fetched with one caller's
+// credentials are not served to another by default.
+type HttpCache struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How long a cached response is reused, in seconds.
+	TtlSeconds int32 `protobuf:"varint,1,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	// Parts of the resolved request that make up the cache key (optional, default: "uri").
+	// "uri": method and URI, including the query string.
+	// "request": method, URI and request headers.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// Cache requests whose headers reference runtime secrets.
+	// Cached responses are then shared by every caller of the same cache key.
+	IncludeAuth   bool `protobuf:"varint,3,opt,name=include_auth,json=includeAuth,proto3" json:"include_auth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HttpCache) Reset() {
+	*x = HttpCache{}
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpCache) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpCache) ProtoMessage() {}
+
+func (x *HttpCache) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpCache.ProtoReflect.Descriptor instead.
+func (*HttpCache) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HttpCache) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *HttpCache) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HttpCache) GetIncludeAuth() bool {
+	if x != nil {
+		return x.IncludeAuth
+	}
+	return false
+}
+
+// HttpTls defines the TLS configuration of an HTTP_CALL task.
+//
+// Certificates and keys are PEM-encoded. Key material must be supplied as
+// runtime secret references (${.secrets.KEY}), which the workflow runner
+// resolves just-in-time, so it never appears in manifests or workflow history.
+type HttpTls struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Client certificate presented to the server (PEM).
+	// Runtime reference or literal PEM. Requires client_key.
+	ClientCert string `protobuf:"bytes,1,opt,name=client_cert,json=clientCert,proto3" json:"client_cert,omitempty"`
+	// Private key of the client certificate (PEM).
+	// Must be a runtime secret reference: "${.secrets.CLIENT_KEY}".
+	// Requires client_cert.
+	ClientKey string `protobuf:"bytes,2,opt,name=client_key,json=clientKey,proto3" json:"client_key,omitempty"`
+	// CA certificates used to verify the server (PEM), replacing the system roots.
+	// Runtime reference or literal PEM.
+	CaBundle string `protobuf:"bytes,3,opt,name=ca_bundle,json=caBundle,proto3" json:"ca_bundle,omitempty"`
+	// Path of a CA bundle file on the workflow runner (PEM).
+	// Alternative to ca_bundle for CAs provisioned on the runner host.
+	CaBundleFile string `protobuf:"bytes,4,opt,name=ca_bundle_file,json=caBundleFile,proto3" json:"ca_bundle_file,omitempty"`
+	// Disable verification of the server certificate.
+	// Only for testing: the connection is open to interception.
+	InsecureSkipVerify bool `protobuf:"varint,5,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *HttpTls) Reset() {
+	*x = HttpTls{}
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpTls) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpTls) ProtoMessage() {}
+
+func (x *HttpTls) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpTls.ProtoReflect.Descriptor instead.
+func (*HttpTls) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HttpTls) GetClientCert() string {
+	if x != nil {
+		return x.ClientCert
+	}
+	return ""
+}
+
+func (x *HttpTls) GetClientKey() string {
+	if x != nil {
+		return x.ClientKey
+	}
+	return ""
+}
+
+func (x *HttpTls) GetCaBundle() string {
+	if x != nil {
+		return x.CaBundle
+	}
+	return ""
+}
+
+func (x *HttpTls) GetCaBundleFile() string {
+	if x != nil {
+		return x.CaBundleFile
+	}
+	return ""
+}
+
+func (x *HttpTls) GetInsecureSkipVerify() bool {
+	if x != nil {
+		return x.InsecureSkipVerify
+	}
+	return false
+}
+
 // HttpEndpoint defines the HTTP endpoint to call.
 type HttpEndpoint struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -136,7 +548,7 @@ type HttpEndpoint struct {
 
 func (x *HttpEndpoint) Reset() {
 	*x = HttpEndpoint{}
-	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[1]
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -148,7 +560,7 @@ func (x *HttpEndpoint) String() string {
 func (*HttpEndpoint) ProtoMessage() {}
 
 func (x *HttpEndpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[1]
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,7 +573,7 @@ func (x *HttpEndpoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HttpEndpoint.ProtoReflect.Descriptor instead.
 func (*HttpEndpoint) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_rawDescGZIP(), []int{1}
+	return file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *HttpEndpoint) GetUri() string {
@@ -175,17 +587,50 @@ var File_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto protoreflect.FileD
 
 const file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_rawDesc = "" +
 	"\n" +
-	"4ai/stigmer/agentic/workflow/v1/tasks/http_call.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a2ai/stigmer/commons/apiresource/field_options.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xac\x03\n" +
-	"\x12HttpCallTaskConfig\x12?\n" +
-	"\x06method\x18\x01 \x01(\tB'\xbaH$\xc8\x01\x01r\x1fR\x03GETR\x04POSTR\x03PUTR\x06DELETER\x05PATCHR\x06method\x12V\n" +
+	"4ai/stigmer/agentic/workflow/v1/tasks/http_call.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a2ai/stigmer/commons/apiresource/field_options.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xf9\b\n" +
+	"\x12HttpCallTaskConfig\x12N\n" +
+	"\x06method\x18\x01 \x01(\tB6\xbaH3\xc8\x01\x01r.R\x03GETR\x04POSTR\x03PUTR\x06DELETER\x05PATCHR\x04HEADR\aOPTIONSR\x06method\x12V\n" +
 	"\bendpoint\x18\x02 \x01(\v22.ai.stigmer.agentic.workflow.v1.tasks.HttpEndpointB\x06\xbaH\x03\xc8\x01\x01R\bendpoint\x12_\n" +
 	"\aheaders\x18\x03 \x03(\v2E.ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.HeadersEntryR\aheaders\x12+\n" +
 	"\x04body\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x04body\x123\n" +
 	"\x0ftimeout_seconds\x18\x05 \x01(\x05B\n" +
-	"\xbaH\a\x1a\x05\x18\xac\x02(\x01R\x0etimeoutSeconds\x1a:\n" +
+	"\xbaH\a\x1a\x05\x18\xac\x02(\x01R\x0etimeoutSeconds\x12?\n" +
+	"\x03tls\x18\x06 \x01(\v2-.ai.stigmer.agentic.workflow.v1.tasks.HttpTlsR\x03tls\x12E\n" +
+	"\x05cache\x18\a \x01(\v2/.ai.stigmer.agentic.workflow.v1.tasks.HttpCacheR\x05cache\x12E\n" +
+	"\x05proxy\x18\b \x01(\v2/.ai.stigmer.agentic.workflow.v1.tasks.HttpProxyR\x05proxy\x12J\n" +
+	"\x0fresponse_format\x18\t \x01(\tB!\xbaH\x1er\x1cR\x00R\x04jsonR\x04textR\fbytes_base64R\x0eresponseFormat\x12C\n" +
+	"\rbody_encoding\x18\n" +
+	" \x01(\tB\x1e\xbaH\x1br\x19R\x00R\x04jsonR\x04formR\tmultipartR\fbodyEncoding\x12`\n" +
+	"\x0fmultipart_parts\x18\v \x03(\v27.ai.stigmer.agentic.workflow.v1.tasks.HttpMultipartPartR\x0emultipartParts\x126\n" +
+	"\rexpect_status\x18\f \x03(\x05B\x11\xbaH\x0e\x92\x01\v\x18\x01\"\a\x1a\x05\x18\xd7\x04(dR\fexpectStatus\x12(\n" +
+	"\x10allow_any_status\x18\r \x01(\bR\x0eallowAnyStatus\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"0\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01:\x97\x01\xbaH\x93\x01\x1a\x90\x01\n" +
+	"\x1aallow_any_status.exclusive\x129allow_any_status and expect_status are mutually exclusive\x1a7!this.allow_any_status || size(this.expect_status) == 0\"\x8a\x01\n" +
+	"\x11HttpMultipartPart\x12\x1e\n" +
+	"\x04name\x18\x01 \x01(\tB\n" +
+	"\xbaH\a\xc8\x01\x01r\x02\x10\x01R\x04name\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x16\n" +
+	"\x06base64\x18\x04 \x01(\bR\x06base64\"\xa7\x01\n" +
+	"\tHttpProxy\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1a\n" +
+	"\bdisabled\x18\x02 \x01(\bR\bdisabled:l\xbaHi\x1ag\n" +
+	"\x14http_proxy.exclusive\x12'url and disabled are mutually exclusive\x1a&!(size(this.url) > 0 && this.disabled)\"\x81\x01\n" +
+	"\tHttpCache\x12(\n" +
+	"\vttl_seconds\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\n" +
+	"ttlSeconds\x12'\n" +
+	"\x03key\x18\x02 \x01(\tB\x15\xbaH\x12r\x10R\x00R\x03uriR\arequestR\x03key\x12!\n" +
+	"\finclude_auth\x18\x03 \x01(\bR\vincludeAuth\"\xbe\x01\n" +
+	"\aHttpTls\x12\x1f\n" +
+	"\vclient_cert\x18\x01 \x01(\tR\n" +
+	"clientCert\x12\x1d\n" +
+	"\n" +
+	"client_key\x18\x02 \x01(\tR\tclientKey\x12\x1b\n" +
+	"\tca_bundle\x18\x03 \x01(\tR\bcaBundle\x12$\n" +
+	"\x0eca_bundle_file\x18\x04 \x01(\tR\fcaBundleFile\x120\n" +
+	"\x14insecure_skip_verify\x18\x05 \x01(\bR\x12insecureSkipVerify\"0\n" +
 	"\fHttpEndpoint\x12 \n" +
 	"\x03uri\x18\x01 \x01(\tB\x0e\xbaH\a\xc8\x01\x01r\x02\x10\x01\u0605,\x01R\x03uriB\xc0\x02\n" +
 	"(com.ai.stigmer.agentic.workflow.v1.tasksB\rHttpCallProtoP\x01ZMgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks\xa2\x02\x06ASAWVT\xaa\x02$Ai.Stigmer.Agentic.Workflow.V1.Tasks\xca\x02$Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\xe2\x020Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\\GPBMetadata\xea\x02)Ai::Stigmer::Agentic::Workflow::V1::Tasksb\x06proto3"
@@ -202,22 +647,30 @@ func file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_rawDescGZIP() []b
 	return file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_goTypes = []any{
 	(*HttpCallTaskConfig)(nil), // 0: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig
-	(*HttpEndpoint)(nil),       // 1: ai.stigmer.agentic.workflow.v1.tasks.HttpEndpoint
-	nil,                        // 2: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.HeadersEntry
-	(*structpb.Struct)(nil),    // 3: google.protobuf.Struct
+	(*HttpMultipartPart)(nil),  // 1: ai.stigmer.agentic.workflow.v1.tasks.HttpMultipartPart
+	(*HttpProxy)(nil),          // 2: ai.stigmer.agentic.workflow.v1.tasks.HttpProxy
+	(*HttpCache)(nil),          // 3: ai.stigmer.agentic.workflow.v1.tasks.HttpCache
+	(*HttpTls)(nil),            // 4: ai.stigmer.agentic.workflow.v1.tasks.HttpTls
+	(*HttpEndpoint)(nil),       // 5: ai.stigmer.agentic.workflow.v1.tasks.HttpEndpoint
+	nil,                        // 6: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.HeadersEntry
+	(*structpb.Struct)(nil),    // 7: google.protobuf.Struct
 }
 var file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_depIdxs = []int32{
-	1, // 0: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.endpoint:type_name -> ai.stigmer.agentic.workflow.v1.tasks.HttpEndpoint
-	2, // 1: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.headers:type_name -> ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.HeadersEntry
-	3, // 2: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.body:type_name -> google.protobuf.Struct
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	5, // 0: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.endpoint:type_name -> ai.stigmer.agentic.workflow.v1.tasks.HttpEndpoint
+	6, // 1: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.headers:type_name -> ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.HeadersEntry
+	7, // 2: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.body:type_name -> google.protobuf.Struct
+	4, // 3: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.tls:type_name -> ai.stigmer.agentic.workflow.v1.tasks.HttpTls
+	3, // 4: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.cache:type_name -> ai.stigmer.agentic.workflow.v1.tasks.HttpCache
+	2, // 5: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.proxy:type_name -> ai.stigmer.agentic.workflow.v1.tasks.HttpProxy
+	1, // 6: ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig.multipart_parts:type_name -> ai.stigmer.agentic.workflow.v1.tasks.HttpMultipartPart
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_init() }
@@ -231,7 +684,7 @@ func file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_rawDesc), len(file_ai_stigmer_agentic_workflow_v1_tasks_http_call_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -8,6 +8,7 @@ package tasks
 
 import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -39,9 +40,26 @@ const (
 type ListenTaskConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Signal listening configuration.
-	To            *ListenTo `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	To *ListenTo `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"`
+	// Human approval gate (optional).
+	//
+	// When set, the task pauses the workflow until an approval decision is sent
+	// through WorkflowExecutionCommandController.approve. While waiting, the
+	// execution is in phase EXECUTION_AWAITING_APPROVAL and lists the task in
+	// status.pending_approvals.
+	//
+	// Task output once approved:
+	//   {approved: true, approved_by: "...", approved_at: "...", comment: "..."}
+	//
+	// A rejection fails the task with error type "ApprovalRejected".
+	Approval *ListenApproval `protobuf:"bytes,2,opt,name=approval,proto3" json:"approval,omitempty"`
+	// How long to wait for the signals, in seconds (0 = 1 minute).
+	// The task fails with error type "ListenTimeout" when no accepted signal
+	// arrives in time. Ignored for approval gates, which use
+	// approval.timeout_seconds.
+	TimeoutSeconds int32 `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ListenTaskConfig) Reset() {
@@ -81,6 +99,101 @@ func (x *ListenTaskConfig) GetTo() *ListenTo {
 	return nil
 }
 
+func (x *ListenTaskConfig) GetApproval() *ListenApproval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+func (x *ListenTaskConfig) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+// ListenApproval configures a LISTEN task as a human approval gate.
+//
+// YAML Example:
+//   - approveDeploy:
+//     listen:
+//     to:
+//     one:
+//     with:
+//     id: approval
+//     type: signal
+//     metadata:
+//     approval:
+//     approvers: [ops-team]
+//     timeout: 48h
+//     onTimeout: fail
+type ListenApproval struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identities or groups allowed to decide on the approval.
+	// Empty means anyone with edit permission on the execution.
+	Approvers []string `protobuf:"bytes,1,rep,name=approvers,proto3" json:"approvers,omitempty"`
+	// How long to wait for a decision, in seconds (0 = 24 hours).
+	TimeoutSeconds int32 `protobuf:"varint,2,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// What happens when no decision arrives before the timeout:
+	// - "fail": Fail the task with error type "ApprovalTimeout" (default)
+	// - "approve": Approve automatically (approved_by is "timeout")
+	OnTimeout     string `protobuf:"bytes,3,opt,name=on_timeout,json=onTimeout,proto3" json:"on_timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenApproval) Reset() {
+	*x = ListenApproval{}
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenApproval) ProtoMessage() {}
+
+func (x *ListenApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenApproval.ProtoReflect.Descriptor instead.
+func (*ListenApproval) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListenApproval) GetApprovers() []string {
+	if x != nil {
+		return x.Approvers
+	}
+	return nil
+}
+
+func (x *ListenApproval) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ListenApproval) GetOnTimeout() string {
+	if x != nil {
+		return x.OnTimeout
+	}
+	return ""
+}
+
 // ListenTo defines what signals to listen for.
 type ListenTo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -96,7 +209,7 @@ type ListenTo struct {
 
 func (x *ListenTo) Reset() {
 	*x = ListenTo{}
-	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes[1]
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -108,7 +221,7 @@ func (x *ListenTo) String() string {
 func (*ListenTo) ProtoMessage() {}
 
 func (x *ListenTo) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes[1]
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -121,7 +234,7 @@ func (x *ListenTo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListenTo.ProtoReflect.Descriptor instead.
 func (*ListenTo) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_rawDescGZIP(), []int{1}
+	return file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ListenTo) GetMode() string {
@@ -147,14 +260,23 @@ type SignalSpec struct {
 	// - "signal": Temporal signal
 	// - "query": Temporal query
 	// - "update": Temporal update
-	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Correlation filter (optional).
+	//
+	// A boolean expression evaluated against each received payload, available
+	// as ".". Payloads for which it is false are ignored and the task keeps
+	// waiting, so a signal meant for another order or request cannot complete
+	// the task.
+	//
+	// Example: "${ .orderId == $context.createOrder.id }"
+	AcceptIf      string `protobuf:"bytes,3,opt,name=accept_if,json=acceptIf,proto3" json:"accept_if,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SignalSpec) Reset() {
 	*x = SignalSpec{}
-	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes[2]
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -166,7 +288,7 @@ func (x *SignalSpec) String() string {
 func (*SignalSpec) ProtoMessage() {}
 
 func (x *SignalSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes[2]
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -179,7 +301,7 @@ func (x *SignalSpec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignalSpec.ProtoReflect.Descriptor instead.
 func (*SignalSpec) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_rawDescGZIP(), []int{2}
+	return file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SignalSpec) GetId() string {
@@ -196,22 +318,37 @@ func (x *SignalSpec) GetType() string {
 	return ""
 }
 
+func (x *SignalSpec) GetAcceptIf() string {
+	if x != nil {
+		return x.AcceptIf
+	}
+	return ""
+}
+
 var File_ai_stigmer_agentic_workflow_v1_tasks_listen_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_rawDesc = "" +
 	"\n" +
-	"1ai/stigmer/agentic/workflow/v1/tasks/listen.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a\x1bbuf/validate/validate.proto\"Z\n" +
+	"1ai/stigmer/agentic/workflow/v1/tasks/listen.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a2ai/stigmer/commons/apiresource/field_options.proto\x1a\x1bbuf/validate/validate.proto\"\xde\x01\n" +
 	"\x10ListenTaskConfig\x12F\n" +
-	"\x02to\x18\x01 \x01(\v2..ai.stigmer.agentic.workflow.v1.tasks.ListenToB\x06\xbaH\x03\xc8\x01\x01R\x02to\"\x88\x01\n" +
+	"\x02to\x18\x01 \x01(\v2..ai.stigmer.agentic.workflow.v1.tasks.ListenToB\x06\xbaH\x03\xc8\x01\x01R\x02to\x12P\n" +
+	"\bapproval\x18\x02 \x01(\v24.ai.stigmer.agentic.workflow.v1.tasks.ListenApprovalR\bapproval\x120\n" +
+	"\x0ftimeout_seconds\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x0etimeoutSeconds\"\x97\x01\n" +
+	"\x0eListenApproval\x12\x1c\n" +
+	"\tapprovers\x18\x01 \x03(\tR\tapprovers\x120\n" +
+	"\x0ftimeout_seconds\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x0etimeoutSeconds\x125\n" +
+	"\n" +
+	"on_timeout\x18\x03 \x01(\tB\x16\xbaH\x13r\x11R\x00R\x04failR\aapproveR\tonTimeout\"\x88\x01\n" +
 	"\bListenTo\x12&\n" +
 	"\x04mode\x18\x01 \x01(\tB\x12\xbaH\x0f\xc8\x01\x01r\n" +
 	"R\x03oneR\x03allR\x04mode\x12T\n" +
-	"\asignals\x18\x02 \x03(\v20.ai.stigmer.agentic.workflow.v1.tasks.SignalSpecB\b\xbaH\x05\x92\x01\x02\b\x01R\asignals\"]\n" +
+	"\asignals\x18\x02 \x03(\v20.ai.stigmer.agentic.workflow.v1.tasks.SignalSpecB\b\xbaH\x05\x92\x01\x02\b\x01R\asignals\"\x80\x01\n" +
 	"\n" +
 	"SignalSpec\x12\x1a\n" +
 	"\x02id\x18\x01 \x01(\tB\n" +
 	"\xbaH\a\xc8\x01\x01r\x02\x10\x01R\x02id\x123\n" +
-	"\x04type\x18\x02 \x01(\tB\x1f\xbaH\x1c\xc8\x01\x01r\x17R\x06signalR\x05queryR\x06updateR\x04typeB\xbe\x02\n" +
+	"\x04type\x18\x02 \x01(\tB\x1f\xbaH\x1c\xc8\x01\x01r\x17R\x06signalR\x05queryR\x06updateR\x04type\x12!\n" +
+	"\taccept_if\x18\x03 \x01(\tB\x04\u0605,\x01R\bacceptIfB\xbe\x02\n" +
 	"(com.ai.stigmer.agentic.workflow.v1.tasksB\vListenProtoP\x01ZMgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks\xa2\x02\x06ASAWVT\xaa\x02$Ai.Stigmer.Agentic.Workflow.V1.Tasks\xca\x02$Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\xe2\x020Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\\GPBMetadata\xea\x02)Ai::Stigmer::Agentic::Workflow::V1::Tasksb\x06proto3"
 
 var (
@@ -226,20 +363,22 @@ func file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_rawDescGZIP() []byte
 	return file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_goTypes = []any{
 	(*ListenTaskConfig)(nil), // 0: ai.stigmer.agentic.workflow.v1.tasks.ListenTaskConfig
-	(*ListenTo)(nil),         // 1: ai.stigmer.agentic.workflow.v1.tasks.ListenTo
-	(*SignalSpec)(nil),       // 2: ai.stigmer.agentic.workflow.v1.tasks.SignalSpec
+	(*ListenApproval)(nil),   // 1: ai.stigmer.agentic.workflow.v1.tasks.ListenApproval
+	(*ListenTo)(nil),         // 2: ai.stigmer.agentic.workflow.v1.tasks.ListenTo
+	(*SignalSpec)(nil),       // 3: ai.stigmer.agentic.workflow.v1.tasks.SignalSpec
 }
 var file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_depIdxs = []int32{
-	1, // 0: ai.stigmer.agentic.workflow.v1.tasks.ListenTaskConfig.to:type_name -> ai.stigmer.agentic.workflow.v1.tasks.ListenTo
-	2, // 1: ai.stigmer.agentic.workflow.v1.tasks.ListenTo.signals:type_name -> ai.stigmer.agentic.workflow.v1.tasks.SignalSpec
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2, // 0: ai.stigmer.agentic.workflow.v1.tasks.ListenTaskConfig.to:type_name -> ai.stigmer.agentic.workflow.v1.tasks.ListenTo
+	1, // 1: ai.stigmer.agentic.workflow.v1.tasks.ListenTaskConfig.approval:type_name -> ai.stigmer.agentic.workflow.v1.tasks.ListenApproval
+	3, // 2: ai.stigmer.agentic.workflow.v1.tasks.ListenTo.signals:type_name -> ai.stigmer.agentic.workflow.v1.tasks.SignalSpec
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_init() }
@@ -253,7 +392,7 @@ func file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_rawDesc), len(file_ai_stigmer_agentic_workflow_v1_tasks_listen_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -10,6 +10,7 @@ import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -32,14 +33,22 @@ const (
 //     variable1: value
 //     variable2: ${ expression }
 //     computed: ${ .a + .b }
+//     tempToken: null  # unset
 //
 // Reference: zigflow-dsl-pattern-catalog.md - Task Type 1
 type SetTaskConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Variables to set in workflow state.
 	// Keys are variable names, values can be literals or expressions.
+	// Literals keep their JSON type: numbers, booleans, objects and arrays are
+	// set as such, not as strings.
 	// Expressions use ${...} syntax, e.g., "${.a + .b}" or "${now}"
-	Variables     map[string]string `protobuf:"bytes,1,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Variables *structpb.Struct `protobuf:"bytes,1,opt,name=variables,proto3" json:"variables,omitempty"`
+	// Variables to remove from workflow state.
+	// The runner deletes each key from the workflow data and from task exports
+	// in $context, rather than setting it to an empty value. A variable cannot
+	// be both set and unset by the same task.
+	Unset         []string `protobuf:"bytes,2,rep,name=unset,proto3" json:"unset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,23 +83,30 @@ func (*SetTaskConfig) Descriptor() ([]byte, []int) {
 	return file_ai_stigmer_agentic_workflow_v1_tasks_set_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *SetTaskConfig) GetVariables() map[string]string {
+func (x *SetTaskConfig) GetVariables() *structpb.Struct {
 	if x != nil {
 		return x.Variables
 	}
 	return nil
 }
 
+func (x *SetTaskConfig) GetUnset() []string {
+	if x != nil {
+		return x.Unset
+	}
+	return nil
+}
+
 var File_ai_stigmer_agentic_workflow_v1_tasks_set_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflow_v1_tasks_set_proto_rawDesc = "" +
 	"\n" +
-	".ai/stigmer/agentic/workflow/v1/tasks/set.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a\x1bbuf/validate/validate.proto\"\xb7\x01\n" +
-	"\rSetTaskConfig\x12h\n" +
-	"\tvariables\x18\x01 \x03(\v2B.ai.stigmer.agentic.workflow.v1.tasks.SetTaskConfig.VariablesEntryB\x06\xbaH\x03\xc8\x01\x01R\tvariables\x1a<\n" +
-	"\x0eVariablesEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\xbb\x02\n" +
+	".ai/stigmer/agentic/workflow/v1/tasks/set.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xe0\x02\n" +
+	"\rSetTaskConfig\x125\n" +
+	"\tvariables\x18\x01 \x01(\v2\x17.google.protobuf.StructR\tvariables\x12$\n" +
+	"\x05unset\x18\x02 \x03(\tB\x0e\xbaH\v\x92\x01\b\x18\x01\"\x04r\x02\x10\x01R\x05unset:\xf1\x01\xbaH\xed\x01\x1a|\n" +
+	"\x16set.variables_or_unset\x120set task must set or unset at least one variable\x1a0size(this.variables) > 0 || size(this.unset) > 0\x1am\n" +
+	"\x11set.unset_not_set\x12'a variable cannot be both set and unset\x1a/this.unset.all(name, !(name in this.variables))B\xbb\x02\n" +
 	"(com.ai.stigmer.agentic.workflow.v1.tasksB\bSetProtoP\x01ZMgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks\xa2\x02\x06ASAWVT\xaa\x02$Ai.Stigmer.Agentic.Workflow.V1.Tasks\xca\x02$Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\xe2\x020Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\\GPBMetadata\xea\x02)Ai::Stigmer::Agentic::Workflow::V1::Tasksb\x06proto3"
 
 var (
@@ -105,13 +121,13 @@ func file_ai_stigmer_agentic_workflow_v1_tasks_set_proto_rawDescGZIP() []byte {
 	return file_ai_stigmer_agentic_workflow_v1_tasks_set_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_workflow_v1_tasks_set_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_ai_stigmer_agentic_workflow_v1_tasks_set_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_ai_stigmer_agentic_workflow_v1_tasks_set_proto_goTypes = []any{
-	(*SetTaskConfig)(nil), // 0: ai.stigmer.agentic.workflow.v1.tasks.SetTaskConfig
-	nil,                   // 1: ai.stigmer.agentic.workflow.v1.tasks.SetTaskConfig.VariablesEntry
+	(*SetTaskConfig)(nil),   // 0: ai.stigmer.agentic.workflow.v1.tasks.SetTaskConfig
+	(*structpb.Struct)(nil), // 1: google.protobuf.Struct
 }
 var file_ai_stigmer_agentic_workflow_v1_tasks_set_proto_depIdxs = []int32{
-	1, // 0: ai.stigmer.agentic.workflow.v1.tasks.SetTaskConfig.variables:type_name -> ai.stigmer.agentic.workflow.v1.tasks.SetTaskConfig.VariablesEntry
+	1, // 0: ai.stigmer.agentic.workflow.v1.tasks.SetTaskConfig.variables:type_name -> google.protobuf.Struct
 	1, // [1:1] is the sub-list for method output_type
 	1, // [1:1] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
@@ -130,7 +146,7 @@ func file_ai_stigmer_agentic_workflow_v1_tasks_set_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_workflow_v1_tasks_set_proto_rawDesc), len(file_ai_stigmer_agentic_workflow_v1_tasks_set_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   1,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
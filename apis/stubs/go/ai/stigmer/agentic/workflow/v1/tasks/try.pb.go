@@ -45,6 +45,15 @@ const (
 //     body:
 //     error: ${ .error }
 //
+// Filtered catch blocks (catches) handle specific errors, such as rate
+// limiting or client errors, differently. They are evaluated in declaration
+// order and the first matching block handles the error; catch handles the
+// errors none of them matched.
+//
+// A retry policy (retry) runs the try tasks again when they fail with one of
+// its error types, such as "ExecutionTimeout", up to max_retries times. The
+// catch blocks only see the error of the last attempt.
+//
 // Reference: zigflow-dsl-pattern-catalog.md - Task Type 6
 type TryTaskConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -53,7 +62,16 @@ type TryTaskConfig struct {
 	Try []*v1.WorkflowTask `protobuf:"bytes,1,rep,name=try,proto3" json:"try,omitempty"`
 	// Catch block for error handling (optional).
 	// If not provided, errors propagate to parent.
-	Catch         *CatchBlock `protobuf:"bytes,2,opt,name=catch,proto3" json:"catch,omitempty"`
+	// Evaluated after catches, for the errors none of them matched.
+	Catch *CatchBlock `protobuf:"bytes,2,opt,name=catch,proto3" json:"catch,omitempty"`
+	// Catch blocks evaluated in declaration order before catch (optional).
+	// The first block whose filters match the error handles it. A block without
+	// filters matches every error, so it can only be the last one.
+	Catches []*CatchBlock `protobuf:"bytes,3,rep,name=catches,proto3" json:"catches,omitempty"`
+	// Retry policy for the try tasks (optional).
+	// A failed attempt whose error matches the policy runs the try tasks again
+	// before any catch block sees the error.
+	Retry         *RetryPolicy `protobuf:"bytes,4,opt,name=retry,proto3" json:"retry,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -102,6 +120,20 @@ func (x *TryTaskConfig) GetCatch() *CatchBlock {
 	return nil
 }
 
+func (x *TryTaskConfig) GetCatches() []*CatchBlock {
+	if x != nil {
+		return x.Catches
+	}
+	return nil
+}
+
+func (x *TryTaskConfig) GetRetry() *RetryPolicy {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
 // CatchBlock defines error handling logic.
 type CatchBlock struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -109,7 +141,14 @@ type CatchBlock struct {
 	// Accessible via ${ .error } in catch tasks.
 	As string `protobuf:"bytes,1,opt,name=as,proto3" json:"as,omitempty"`
 	// Tasks to execute when error is caught.
-	Do            []*v1.WorkflowTask `protobuf:"bytes,2,rep,name=do,proto3" json:"do,omitempty"`
+	Do []*v1.WorkflowTask `protobuf:"bytes,2,rep,name=do,proto3" json:"do,omitempty"`
+	// Only catch errors of one of these types (optional).
+	// Matches the error type (e.g. "CallHTTP error") or its classification
+	// (e.g. "UPSTREAM_4XX", "TIMEOUT").
+	ErrorTypes []string `protobuf:"bytes,3,rep,name=error_types,json=errorTypes,proto3" json:"error_types,omitempty"`
+	// Only catch failed HTTP calls answered with one of these status codes
+	// (optional). When both filters are set, an error must match both.
+	StatusCodes   []int32 `protobuf:"varint,4,rep,packed,name=status_codes,json=statusCodes,proto3" json:"status_codes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -158,18 +197,109 @@ func (x *CatchBlock) GetDo() []*v1.WorkflowTask {
 	return nil
 }
 
+func (x *CatchBlock) GetErrorTypes() []string {
+	if x != nil {
+		return x.ErrorTypes
+	}
+	return nil
+}
+
+func (x *CatchBlock) GetStatusCodes() []int32 {
+	if x != nil {
+		return x.StatusCodes
+	}
+	return nil
+}
+
+// RetryPolicy defines when and how often the tasks of a TRY task run again.
+type RetryPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Retry errors of one of these types (at least one required).
+	// Matches the error type (e.g. "ExecutionTimeout") or its classification
+	// (e.g. "UPSTREAM_5XX", "TIMEOUT"), like the error_types of a catch block.
+	ErrorTypes []string `protobuf:"bytes,1,rep,name=error_types,json=errorTypes,proto3" json:"error_types,omitempty"`
+	// Maximum number of retries after the first attempt (1-100).
+	MaxRetries int32 `protobuf:"varint,2,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	// Seconds to wait before each retry (optional, 0 retries immediately).
+	DelaySeconds  int32 `protobuf:"varint,3,opt,name=delay_seconds,json=delaySeconds,proto3" json:"delay_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryPolicy) ProtoMessage() {}
+
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RetryPolicy) GetErrorTypes() []string {
+	if x != nil {
+		return x.ErrorTypes
+	}
+	return nil
+}
+
+func (x *RetryPolicy) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetDelaySeconds() int32 {
+	if x != nil {
+		return x.DelaySeconds
+	}
+	return 0
+}
+
 var File_ai_stigmer_agentic_workflow_v1_tasks_try_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_rawDesc = "" +
 	"\n" +
-	".ai/stigmer/agentic/workflow/v1/tasks/try.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a)ai/stigmer/agentic/workflow/v1/spec.proto\x1a\x1bbuf/validate/validate.proto\"\xa1\x01\n" +
+	".ai/stigmer/agentic/workflow/v1/tasks/try.proto\x12$ai.stigmer.agentic.workflow.v1.tasks\x1a)ai/stigmer/agentic/workflow/v1/spec.proto\x1a\x1bbuf/validate/validate.proto\"\xb6\x02\n" +
 	"\rTryTaskConfig\x12H\n" +
 	"\x03try\x18\x01 \x03(\v2,.ai.stigmer.agentic.workflow.v1.WorkflowTaskB\b\xbaH\x05\x92\x01\x02\b\x01R\x03try\x12F\n" +
-	"\x05catch\x18\x02 \x01(\v20.ai.stigmer.agentic.workflow.v1.tasks.CatchBlockR\x05catch\"d\n" +
+	"\x05catch\x18\x02 \x01(\v20.ai.stigmer.agentic.workflow.v1.tasks.CatchBlockR\x05catch\x12J\n" +
+	"\acatches\x18\x03 \x03(\v20.ai.stigmer.agentic.workflow.v1.tasks.CatchBlockR\acatches\x12G\n" +
+	"\x05retry\x18\x04 \x01(\v21.ai.stigmer.agentic.workflow.v1.tasks.RetryPolicyR\x05retry\"\xbb\x01\n" +
 	"\n" +
 	"CatchBlock\x12\x0e\n" +
 	"\x02as\x18\x01 \x01(\tR\x02as\x12F\n" +
-	"\x02do\x18\x02 \x03(\v2,.ai.stigmer.agentic.workflow.v1.WorkflowTaskB\b\xbaH\x05\x92\x01\x02\b\x01R\x02doB\xbb\x02\n" +
+	"\x02do\x18\x02 \x03(\v2,.ai.stigmer.agentic.workflow.v1.WorkflowTaskB\b\xbaH\x05\x92\x01\x02\b\x01R\x02do\x12\x1f\n" +
+	"\verror_types\x18\x03 \x03(\tR\n" +
+	"errorTypes\x124\n" +
+	"\fstatus_codes\x18\x04 \x03(\x05B\x11\xbaH\x0e\x92\x01\v\x18\x01\"\a\x1a\x05\x18\xd7\x04(dR\vstatusCodes\"\x92\x01\n" +
+	"\vRetryPolicy\x12)\n" +
+	"\verror_types\x18\x01 \x03(\tB\b\xbaH\x05\x92\x01\x02\b\x01R\n" +
+	"errorTypes\x12*\n" +
+	"\vmax_retries\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x01R\n" +
+	"maxRetries\x12,\n" +
+	"\rdelay_seconds\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\fdelaySecondsB\xbb\x02\n" +
 	"(com.ai.stigmer.agentic.workflow.v1.tasksB\bTryProtoP\x01ZMgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks\xa2\x02\x06ASAWVT\xaa\x02$Ai.Stigmer.Agentic.Workflow.V1.Tasks\xca\x02$Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\xe2\x020Ai\\Stigmer\\Agentic\\Workflow\\V1\\Tasks\\GPBMetadata\xea\x02)Ai::Stigmer::Agentic::Workflow::V1::Tasksb\x06proto3"
 
 var (
@@ -184,21 +314,24 @@ func file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_rawDescGZIP() []byte {
 	return file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_goTypes = []any{
 	(*TryTaskConfig)(nil),   // 0: ai.stigmer.agentic.workflow.v1.tasks.TryTaskConfig
 	(*CatchBlock)(nil),      // 1: ai.stigmer.agentic.workflow.v1.tasks.CatchBlock
-	(*v1.WorkflowTask)(nil), // 2: ai.stigmer.agentic.workflow.v1.WorkflowTask
+	(*RetryPolicy)(nil),     // 2: ai.stigmer.agentic.workflow.v1.tasks.RetryPolicy
+	(*v1.WorkflowTask)(nil), // 3: ai.stigmer.agentic.workflow.v1.WorkflowTask
 }
 var file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_depIdxs = []int32{
-	2, // 0: ai.stigmer.agentic.workflow.v1.tasks.TryTaskConfig.try:type_name -> ai.stigmer.agentic.workflow.v1.WorkflowTask
+	3, // 0: ai.stigmer.agentic.workflow.v1.tasks.TryTaskConfig.try:type_name -> ai.stigmer.agentic.workflow.v1.WorkflowTask
 	1, // 1: ai.stigmer.agentic.workflow.v1.tasks.TryTaskConfig.catch:type_name -> ai.stigmer.agentic.workflow.v1.tasks.CatchBlock
-	2, // 2: ai.stigmer.agentic.workflow.v1.tasks.CatchBlock.do:type_name -> ai.stigmer.agentic.workflow.v1.WorkflowTask
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	1, // 2: ai.stigmer.agentic.workflow.v1.tasks.TryTaskConfig.catches:type_name -> ai.stigmer.agentic.workflow.v1.tasks.CatchBlock
+	2, // 3: ai.stigmer.agentic.workflow.v1.tasks.TryTaskConfig.retry:type_name -> ai.stigmer.agentic.workflow.v1.tasks.RetryPolicy
+	3, // 4: ai.stigmer.agentic.workflow.v1.tasks.CatchBlock.do:type_name -> ai.stigmer.agentic.workflow.v1.WorkflowTask
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_init() }
@@ -212,7 +345,7 @@ func file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_rawDesc), len(file_ai_stigmer_agentic_workflow_v1_tasks_try_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -117,20 +117,19 @@ type WorkflowExecution struct {
 	// To retry with different inputs, create a new WorkflowExecution with updated spec.
 	//
 	// Example:
-	//
-	//	spec {
-	//	  workflow_instance_id: "wfi-customer-onboarding-prod"
-	//	  trigger_message: "New customer: customer-email@example.com"
-	//	  trigger_metadata: {
-	//	    "source": "api"
-	//	    "caller_id": "usr-john-doe"
-	//	    "timestamp": "2025-01-11T14:30:22Z"
-	//	  }
-	//	  runtime_env: {
-	//	    "CUSTOMER_EMAIL": { value: "customer-email@example.com" }
-	//	    "WEBHOOK_URL": { secret_ref: "sec-webhook-callback" }
-	//	  }
-	//	}
+	// spec {
+	//   workflow_instance_id: "wfi-customer-onboarding-prod"
+	//   trigger_message: "New customer: customer-email@example.com"
+	//   trigger_metadata: {
+	//     "source": "api"
+	//     "caller_id": "usr-john-doe"
+	//     "timestamp": "2025-01-11T14:30:22Z"
+	//   }
+	//   runtime_env: {
+	//     "CUSTOMER_EMAIL": { value: "customer-email@example.com" }
+	//     "WEBHOOK_URL": { secret_ref: "sec-webhook-callback" }
+	//   }
+	// }
 	Spec *WorkflowExecutionSpec `protobuf:"bytes,4,opt,name=spec,proto3" json:"spec,omitempty"`
 	// System-managed execution state and results.
 	//
@@ -152,16 +151,15 @@ type WorkflowExecution struct {
 	// - Progress percentage: (completed_tasks / total_tasks) * 100
 	//
 	// Example (in-progress execution):
-	//
-	//	status {
-	//	  phase: EXECUTION_IN_PROGRESS
-	//	  tasks: [
-	//	    { task_id: "task-1", task_name: "validate_email", status: WORKFLOW_TASK_COMPLETED }
-	//	    { task_id: "task-2", task_name: "create_account", status: WORKFLOW_TASK_IN_PROGRESS }
-	//	    { task_id: "task-3", task_name: "send_welcome", status: WORKFLOW_TASK_PENDING }
-	//	  ]
-	//	  started_at: "2025-01-11T14:30:22Z"
-	//	}
+	// status {
+	//   phase: EXECUTION_IN_PROGRESS
+	//   tasks: [
+	//     { task_id: "task-1", task_name: "validate_email", status: WORKFLOW_TASK_COMPLETED }
+	//     { task_id: "task-2", task_name: "create_account", status: WORKFLOW_TASK_IN_PROGRESS }
+	//     { task_id: "task-3", task_name: "send_welcome", status: WORKFLOW_TASK_PENDING }
+	//   ]
+	//   started_at: "2025-01-11T14:30:22Z"
+	// }
 	Status        *WorkflowExecutionStatus `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -263,12 +261,11 @@ type WorkflowExecutionStatus struct {
 	// - created_by: User or system that created this execution
 	//
 	// Example:
-	//
-	//	audit {
-	//	  created_at: "2025-01-11T14:30:22Z"
-	//	  updated_at: "2025-01-11T14:32:15Z"
-	//	  created_by: "usr-john-doe"
-	//	}
+	// audit {
+	//   created_at: "2025-01-11T14:30:22Z"
+	//   updated_at: "2025-01-11T14:32:15Z"
+	//   created_by: "usr-john-doe"
+	// }
 	Audit *apiresource.ApiResourceAudit `protobuf:"bytes,99,opt,name=audit,proto3" json:"audit,omitempty"`
 	// Current execution lifecycle phase.
 	//
@@ -281,9 +278,8 @@ type WorkflowExecutionStatus struct {
 	//
 	// Phase Transitions:
 	// PENDING → IN_PROGRESS → COMPLETED
-	//
-	//	↓              ↘ FAILED
-	//	↓              ↘ CANCELLED
+	//        ↓              ↘ FAILED
+	//        ↓              ↘ CANCELLED
 	//
 	// The phase is used for:
 	// - UI status indicators (progress bars, badges)
@@ -325,24 +321,22 @@ type WorkflowExecutionStatus struct {
 	//
 	// Example:
 	// tasks: [
-	//
-	//	{
-	//	  task_id: "task-1"
-	//	  task_name: "validate_email"
-	//	  task_type: WORKFLOW_TASK_API_CALL
-	//	  status: WORKFLOW_TASK_COMPLETED
-	//	  started_at: "2025-01-11T14:30:23Z"
-	//	  completed_at: "2025-01-11T14:30:23.450Z"
-	//	  output: { "valid": true, "domain": "example.com" }
-	//	}
-	//	{
-	//	  task_id: "task-2"
-	//	  task_name: "create_account"
-	//	  task_type: WORKFLOW_TASK_AGENT_INVOCATION
-	//	  status: WORKFLOW_TASK_IN_PROGRESS
-	//	  started_at: "2025-01-11T14:30:24Z"
-	//	}
-	//
+	//   {
+	//     task_id: "task-1"
+	//     task_name: "validate_email"
+	//     task_type: WORKFLOW_TASK_API_CALL
+	//     status: WORKFLOW_TASK_COMPLETED
+	//     started_at: "2025-01-11T14:30:23Z"
+	//     completed_at: "2025-01-11T14:30:23.450Z"
+	//     output: { "valid": true, "domain": "example.com" }
+	//   }
+	//   {
+	//     task_id: "task-2"
+	//     task_name: "create_account"
+	//     task_type: WORKFLOW_TASK_AGENT_INVOCATION
+	//     status: WORKFLOW_TASK_IN_PROGRESS
+	//     started_at: "2025-01-11T14:30:24Z"
+	//   }
 	// ]
 	Tasks []*WorkflowTask `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
 	// Workflow output (JSON structure).
@@ -358,14 +352,13 @@ type WorkflowExecutionStatus struct {
 	// - Summary statistics (items processed, duration)
 	//
 	// Example (customer onboarding workflow):
-	//
-	//	output: {
-	//	  "customer_id": "cus-abc123"
-	//	  "account_created": true
-	//	  "welcome_email_sent": true
-	//	  "trial_activated": true
-	//	  "trial_expires_at": "2025-02-10T14:30:22Z"
-	//	}
+	// output: {
+	//   "customer_id": "cus-abc123"
+	//   "account_created": true
+	//   "welcome_email_sent": true
+	//   "trial_activated": true
+	//   "trial_expires_at": "2025-02-10T14:30:22Z"
+	// }
 	Output *structpb.Struct `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
 	// Error message if execution failed.
 	//
@@ -425,8 +418,49 @@ type WorkflowExecutionStatus struct {
 	// This field is optional and only relevant when Temporal is used as the execution engine.
 	// Other workflow engines (Step Functions, Argo, etc.) may use different correlation IDs.
 	TemporalWorkflowId string `protobuf:"bytes,7,opt,name=temporal_workflow_id,json=temporalWorkflowId,proto3" json:"temporal_workflow_id,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// Outcome of the workflow's concurrency policy for this execution.
+	//
+	// Set by the controller at creation time when the workflow declares a
+	// concurrency policy. CONCURRENCY_QUEUED executions stay in EXECUTION_PENDING
+	// until in-flight executions with the same key finish; CONCURRENCY_SKIPPED
+	// executions are created directly in EXECUTION_CANCELLED.
+	ConcurrencyDecision ConcurrencyDecision `protobuf:"varint,8,opt,name=concurrency_decision,json=concurrencyDecision,proto3,enum=ai.stigmer.agentic.workflowexecution.v1.ConcurrencyDecision" json:"concurrency_decision,omitempty"`
+	// Resolved concurrency key this execution was serialized on, scoped to the workflow.
+	// Format: "{workflow_id}/{key}={value}" (or "{workflow_id}/workflow").
+	// Example: "wfl-abc123/org=acme" for a policy keyed on "org".
+	ConcurrencyKey string `protobuf:"bytes,9,opt,name=concurrency_key,json=concurrencyKey,proto3" json:"concurrency_key,omitempty"`
+	// ID of the in-flight execution that caused this execution to be queued or
+	// skipped (empty otherwise).
+	ConcurrencyBlockingExecutionId string `protobuf:"bytes,10,opt,name=concurrency_blocking_execution_id,json=concurrencyBlockingExecutionId,proto3" json:"concurrency_blocking_execution_id,omitempty"`
+	// Approval tasks currently waiting for a decision.
+	//
+	// Added by the workflow runner when an approval task starts and removed by
+	// the approve RPC once a decision is recorded. Non-empty while the phase is
+	// EXECUTION_AWAITING_APPROVAL.
+	PendingApprovals []*PendingApproval `protobuf:"bytes,11,rep,name=pending_approvals,json=pendingApprovals,proto3" json:"pending_approvals,omitempty"`
+	// Classification of the failure (only for FAILED executions).
+	//
+	// Copied by the controller from the failed task when the execution fails,
+	// unless the runner classified the execution failure itself (e.g. a
+	// workflow timeout, or a failure before any task ran).
+	ErrorClassification ErrorClassification `protobuf:"varint,12,opt,name=error_classification,json=errorClassification,proto3,enum=ai.stigmer.agentic.workflowexecution.v1.ErrorClassification" json:"error_classification,omitempty"`
+	// Name of the task whose failure failed the execution (empty when the
+	// execution failed outside of a task).
+	FailedTask string `protobuf:"bytes,13,opt,name=failed_task,json=failedTask,proto3" json:"failed_task,omitempty"`
+	// Ordered history of task state transitions, appended by the controller
+	// as the runner reports task progress.
+	//
+	// Unlike tasks, which holds the latest reported state, task_events keeps
+	// every transition (including retries), so a completed execution can be
+	// replayed with `stigmer workflow logs <execution-id>`.
+	TaskEvents []*WorkflowTaskEvent `protobuf:"bytes,14,rep,name=task_events,json=taskEvents,proto3" json:"task_events,omitempty"`
+	// Identity that requested cancellation of the execution, set by the cancel
+	// RPC when the phase moves to EXECUTION_CANCELLING.
+	CancelledBy string `protobuf:"bytes,15,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"`
+	// Optional reason given with the cancellation request.
+	CancelReason  string `protobuf:"bytes,16,opt,name=cancel_reason,json=cancelReason,proto3" json:"cancel_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WorkflowExecutionStatus) Reset() {
@@ -515,6 +549,254 @@ func (x *WorkflowExecutionStatus) GetTemporalWorkflowId() string {
 	return ""
 }
 
+func (x *WorkflowExecutionStatus) GetConcurrencyDecision() ConcurrencyDecision {
+	if x != nil {
+		return x.ConcurrencyDecision
+	}
+	return ConcurrencyDecision_CONCURRENCY_DECISION_UNSPECIFIED
+}
+
+func (x *WorkflowExecutionStatus) GetConcurrencyKey() string {
+	if x != nil {
+		return x.ConcurrencyKey
+	}
+	return ""
+}
+
+func (x *WorkflowExecutionStatus) GetConcurrencyBlockingExecutionId() string {
+	if x != nil {
+		return x.ConcurrencyBlockingExecutionId
+	}
+	return ""
+}
+
+func (x *WorkflowExecutionStatus) GetPendingApprovals() []*PendingApproval {
+	if x != nil {
+		return x.PendingApprovals
+	}
+	return nil
+}
+
+func (x *WorkflowExecutionStatus) GetErrorClassification() ErrorClassification {
+	if x != nil {
+		return x.ErrorClassification
+	}
+	return ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED
+}
+
+func (x *WorkflowExecutionStatus) GetFailedTask() string {
+	if x != nil {
+		return x.FailedTask
+	}
+	return ""
+}
+
+func (x *WorkflowExecutionStatus) GetTaskEvents() []*WorkflowTaskEvent {
+	if x != nil {
+		return x.TaskEvents
+	}
+	return nil
+}
+
+func (x *WorkflowExecutionStatus) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+func (x *WorkflowExecutionStatus) GetCancelReason() string {
+	if x != nil {
+		return x.CancelReason
+	}
+	return ""
+}
+
+// WorkflowTaskEvent is one state transition of a task in an execution.
+type WorkflowTaskEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the task (matches WorkflowTask.task_name).
+	TaskName string `protobuf:"bytes,1,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`
+	// Transition recorded by this event.
+	Type WorkflowTaskEventType `protobuf:"varint,2,opt,name=type,proto3,enum=ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskEventType" json:"type,omitempty"`
+	// When the controller recorded the transition (RFC 3339, UTC).
+	// Example: "2025-01-11T14:30:22.512Z"
+	Timestamp string `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Attempt number of the task (1 for the first attempt).
+	Attempt int32 `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// Time from the start of the attempt to this event, in milliseconds.
+	// Only set for COMPLETED and FAILED events.
+	DurationMs int64 `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Error message (only for FAILED events).
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// Classification of the failure (only for FAILED events).
+	ErrorClassification ErrorClassification `protobuf:"varint,7,opt,name=error_classification,json=errorClassification,proto3,enum=ai.stigmer.agentic.workflowexecution.v1.ErrorClassification" json:"error_classification,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *WorkflowTaskEvent) Reset() {
+	*x = WorkflowTaskEvent{}
+	mi := &file_ai_stigmer_agentic_workflowexecution_v1_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowTaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowTaskEvent) ProtoMessage() {}
+
+func (x *WorkflowTaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflowexecution_v1_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowTaskEvent.ProtoReflect.Descriptor instead.
+func (*WorkflowTaskEvent) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WorkflowTaskEvent) GetTaskName() string {
+	if x != nil {
+		return x.TaskName
+	}
+	return ""
+}
+
+func (x *WorkflowTaskEvent) GetType() WorkflowTaskEventType {
+	if x != nil {
+		return x.Type
+	}
+	return WorkflowTaskEventType_WORKFLOW_TASK_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *WorkflowTaskEvent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *WorkflowTaskEvent) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *WorkflowTaskEvent) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *WorkflowTaskEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WorkflowTaskEvent) GetErrorClassification() ErrorClassification {
+	if x != nil {
+		return x.ErrorClassification
+	}
+	return ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED
+}
+
+// PendingApproval is an approval task paused until someone decides on it.
+type PendingApproval struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the approval task in the workflow.
+	TaskName string `protobuf:"bytes,1,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`
+	// Identities or groups allowed to decide (empty = anyone who can edit the execution).
+	Approvers []string `protobuf:"bytes,2,rep,name=approvers,proto3" json:"approvers,omitempty"`
+	// ISO 8601 timestamp when the task started waiting.
+	RequestedAt string `protobuf:"bytes,3,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// ISO 8601 timestamp after which the task times out.
+	ExpiresAt string `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Temporal task token of the waiting activity, used to resume the workflow.
+	// Internal to the execution engine; clients should not rely on it.
+	CallbackToken []byte `protobuf:"bytes,5,opt,name=callback_token,json=callbackToken,proto3" json:"callback_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingApproval) Reset() {
+	*x = PendingApproval{}
+	mi := &file_ai_stigmer_agentic_workflowexecution_v1_api_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingApproval) ProtoMessage() {}
+
+func (x *PendingApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflowexecution_v1_api_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingApproval.ProtoReflect.Descriptor instead.
+func (*PendingApproval) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PendingApproval) GetTaskName() string {
+	if x != nil {
+		return x.TaskName
+	}
+	return ""
+}
+
+func (x *PendingApproval) GetApprovers() []string {
+	if x != nil {
+		return x.Approvers
+	}
+	return nil
+}
+
+func (x *PendingApproval) GetRequestedAt() string {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return ""
+}
+
+func (x *PendingApproval) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *PendingApproval) GetCallbackToken() []byte {
+	if x != nil {
+		return x.CallbackToken
+	}
+	return nil
+}
+
 // WorkflowTask represents a single task within a workflow execution.
 //
 // Tasks are the atomic units of work in a workflow. Each task:
@@ -595,29 +877,26 @@ type WorkflowTask struct {
 	// Examples by task type:
 	//
 	// WORKFLOW_TASK_AGENT_INVOCATION:
-	//
-	//	input: {
-	//	  "agent_instance_id": "agi-customer-support"
-	//	  "prompt": "Analyze customer feedback: {{workflow.input.feedback}}"
-	//	  "max_tokens": 500
-	//	}
+	// input: {
+	//   "agent_instance_id": "agi-customer-support"
+	//   "prompt": "Analyze customer feedback: {{workflow.input.feedback}}"
+	//   "max_tokens": 500
+	// }
 	//
 	// WORKFLOW_TASK_API_CALL:
-	//
-	//	input: {
-	//	  "method": "POST"
-	//	  "url": "https://api.stripe.com/v1/customers"
-	//	  "headers": { "Authorization": "Bearer {{env.STRIPE_API_KEY}}" }
-	//	  "body": { "email": "{{workflow.input.email}}" }
-	//	}
+	// input: {
+	//   "method": "POST"
+	//   "url": "https://api.stripe.com/v1/customers"
+	//   "headers": { "Authorization": "Bearer {{env.STRIPE_API_KEY}}" }
+	//   "body": { "email": "{{workflow.input.email}}" }
+	// }
 	//
 	// WORKFLOW_TASK_APPROVAL:
-	//
-	//	input: {
-	//	  "approvers": ["usr-admin-1", "usr-admin-2"]
-	//	  "message": "Approve account creation for {{workflow.input.email}}?"
-	//	  "timeout_hours": 24
-	//	}
+	// input: {
+	//   "approvers": ["usr-admin-1", "usr-admin-2"]
+	//   "message": "Approve account creation for {{workflow.input.email}}?"
+	//   "timeout_hours": 24
+	// }
 	//
 	// Input can reference:
 	// - Workflow inputs: {{workflow.input.field_name}}
@@ -634,33 +913,30 @@ type WorkflowTask struct {
 	// Examples by task type:
 	//
 	// WORKFLOW_TASK_AGENT_INVOCATION:
-	//
-	//	output: {
-	//	  "agent_execution_id": "agx-abc123"
-	//	  "response": "The customer feedback is positive overall..."
-	//	  "sentiment": "positive"
-	//	  "confidence": 0.92
-	//	}
+	// output: {
+	//   "agent_execution_id": "agx-abc123"
+	//   "response": "The customer feedback is positive overall..."
+	//   "sentiment": "positive"
+	//   "confidence": 0.92
+	// }
 	//
 	// WORKFLOW_TASK_API_CALL:
-	//
-	//	output: {
-	//	  "status_code": 200
-	//	  "body": {
-	//	    "id": "cus_abc123"
-	//	    "email": "customer@example.com"
-	//	    "created": 1704988800
-	//	  }
-	//	}
+	// output: {
+	//   "status_code": 200
+	//   "body": {
+	//     "id": "cus_abc123"
+	//     "email": "customer@example.com"
+	//     "created": 1704988800
+	//   }
+	// }
 	//
 	// WORKFLOW_TASK_APPROVAL:
-	//
-	//	output: {
-	//	  "approved": true
-	//	  "approved_by": "usr-admin-1"
-	//	  "approved_at": "2025-01-11T15:22:33Z"
-	//	  "comment": "Looks good, approved"
-	//	}
+	// output: {
+	//   "approved": true
+	//   "approved_by": "usr-admin-1"
+	//   "approved_at": "2025-01-11T15:22:33Z"
+	//   "comment": "Looks good, approved"
+	// }
 	Output *structpb.Struct `protobuf:"bytes,5,opt,name=output,proto3" json:"output,omitempty"`
 	// Current task execution status.
 	//
@@ -673,9 +949,8 @@ type WorkflowTask struct {
 	//
 	// Status Transitions:
 	// PENDING → IN_PROGRESS → COMPLETED
-	//
-	//	↓              ↘ FAILED
-	//	↓              ↘ SKIPPED (if conditional)
+	//        ↓              ↘ FAILED
+	//        ↓              ↘ SKIPPED (if conditional)
 	//
 	// Validation: Must be a defined enum value (no unspecified).
 	Status WorkflowTaskStatus `protobuf:"varint,6,opt,name=status,proto3,enum=ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskStatus" json:"status,omitempty"`
@@ -741,38 +1016,47 @@ type WorkflowTask struct {
 	// Examples:
 	//
 	// Agent invocation task:
-	//
-	//	metadata: {
-	//	  "agent_execution_id": "agx-abc123"
-	//	  "retry_count": 0
-	//	  "tokens_used": 450
-	//	}
+	// metadata: {
+	//   "agent_execution_id": "agx-abc123"
+	//   "retry_count": 0
+	//   "tokens_used": 450
+	// }
 	//
 	// API call task:
-	//
-	//	metadata: {
-	//	  "retry_count": 2
-	//	  "response_headers": {
-	//	    "x-ratelimit-remaining": "98"
-	//	    "x-request-id": "req-xyz789"
-	//	  }
-	//	}
+	// metadata: {
+	//   "retry_count": 2
+	//   "cache": "hit"
+	//   "response_headers": {
+	//     "x-ratelimit-remaining": "98"
+	//     "x-request-id": "req-xyz789"
+	//   }
+	// }
 	//
 	// Approval task:
+	// metadata: {
+	//   "approval_history": [
+	//     { "user": "usr-admin-1", "action": "approved", "timestamp": "2025-01-11T15:22:33Z" }
+	//   ]
+	// }
+	Metadata *structpb.Struct `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Classification of the failure (only for FAILED tasks).
 	//
-	//	metadata: {
-	//	  "approval_history": [
-	//	    { "user": "usr-admin-1", "action": "approved", "timestamp": "2025-01-11T15:22:33Z" }
-	//	  ]
-	//	}
-	Metadata      *structpb.Struct `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Examples:
+	// - UPSTREAM_4XX: "API call failed: 404 Not Found"
+	// - TIMEOUT: "Agent invocation failed: Agent execution timeout after 300 seconds."
+	// - INFRA: "dial tcp: lookup api.example.com: no such host"
+	ErrorClassification ErrorClassification `protobuf:"varint,11,opt,name=error_classification,json=errorClassification,proto3,enum=ai.stigmer.agentic.workflowexecution.v1.ErrorClassification" json:"error_classification,omitempty"`
+	// Attempt number of the task, as reported by the runner (1 for the first
+	// attempt; 0 when unknown). A started task with an attempt above 1 is
+	// recorded as a retry in status.task_events.
+	Attempt       int32 `protobuf:"varint,12,opt,name=attempt,proto3" json:"attempt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WorkflowTask) Reset() {
 	*x = WorkflowTask{}
-	mi := &file_ai_stigmer_agentic_workflowexecution_v1_api_proto_msgTypes[2]
+	mi := &file_ai_stigmer_agentic_workflowexecution_v1_api_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -784,7 +1068,7 @@ func (x *WorkflowTask) String() string {
 func (*WorkflowTask) ProtoMessage() {}
 
 func (x *WorkflowTask) ProtoReflect() protoreflect.Message {
-	mi := &file_ai_stigmer_agentic_workflowexecution_v1_api_proto_msgTypes[2]
+	mi := &file_ai_stigmer_agentic_workflowexecution_v1_api_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,7 +1081,7 @@ func (x *WorkflowTask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WorkflowTask.ProtoReflect.Descriptor instead.
 func (*WorkflowTask) Descriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDescGZIP(), []int{2}
+	return file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *WorkflowTask) GetTaskId() string {
@@ -870,6 +1154,20 @@ func (x *WorkflowTask) GetMetadata() *structpb.Struct {
 	return nil
 }
 
+func (x *WorkflowTask) GetErrorClassification() ErrorClassification {
+	if x != nil {
+		return x.ErrorClassification
+	}
+	return ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED
+}
+
+func (x *WorkflowTask) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
 var File_ai_stigmer_agentic_workflowexecution_v1_api_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDesc = "" +
@@ -884,7 +1182,7 @@ const file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDesc = "" +
 	"\bmetadata\x18\x03 \x01(\v23.ai.stigmer.commons.apiresource.ApiResourceMetadataB\xc2\x01\xbaH\xbe\x01\xba\x01\xb7\x01\n" +
 	"3workflow_execution.owner_scope.org_or_identity_only\x12PWorkflowExecution resources can only have organization or identity_account scope\x1a.this.owner_scope == 2 || this.owner_scope == 3\xc8\x01\x01R\bmetadata\x12R\n" +
 	"\x04spec\x18\x04 \x01(\v2>.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionSpecR\x04spec\x12X\n" +
-	"\x06status\x18\x05 \x01(\v2@.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatusR\x06status\"\xc2\x03\n" +
+	"\x06status\x18\x05 \x01(\v2@.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatusR\x06status\"\xc5\b\n" +
 	"\x17WorkflowExecutionStatus\x12F\n" +
 	"\x05audit\x18c \x01(\v20.ai.stigmer.commons.apiresource.ApiResourceAuditR\x05audit\x12W\n" +
 	"\x05phase\x18\x01 \x01(\x0e27.ai.stigmer.agentic.workflowexecution.v1.ExecutionPhaseB\b\xbaH\x05\x82\x01\x02\x10\x01R\x05phase\x12K\n" +
@@ -894,7 +1192,35 @@ const file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDesc = "" +
 	"\n" +
 	"started_at\x18\x05 \x01(\tR\tstartedAt\x12!\n" +
 	"\fcompleted_at\x18\x06 \x01(\tR\vcompletedAt\x120\n" +
-	"\x14temporal_workflow_id\x18\a \x01(\tR\x12temporalWorkflowId\"\xf2\x03\n" +
+	"\x14temporal_workflow_id\x18\a \x01(\tR\x12temporalWorkflowId\x12o\n" +
+	"\x14concurrency_decision\x18\b \x01(\x0e2<.ai.stigmer.agentic.workflowexecution.v1.ConcurrencyDecisionR\x13concurrencyDecision\x12'\n" +
+	"\x0fconcurrency_key\x18\t \x01(\tR\x0econcurrencyKey\x12I\n" +
+	"!concurrency_blocking_execution_id\x18\n" +
+	" \x01(\tR\x1econcurrencyBlockingExecutionId\x12e\n" +
+	"\x11pending_approvals\x18\v \x03(\v28.ai.stigmer.agentic.workflowexecution.v1.PendingApprovalR\x10pendingApprovals\x12o\n" +
+	"\x14error_classification\x18\f \x01(\x0e2<.ai.stigmer.agentic.workflowexecution.v1.ErrorClassificationR\x13errorClassification\x12\x1f\n" +
+	"\vfailed_task\x18\r \x01(\tR\n" +
+	"failedTask\x12[\n" +
+	"\vtask_events\x18\x0e \x03(\v2:.ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskEventR\n" +
+	"taskEvents\x12!\n" +
+	"\fcancelled_by\x18\x0f \x01(\tR\vcancelledBy\x12#\n" +
+	"\rcancel_reason\x18\x10 \x01(\tR\fcancelReason\"\xee\x02\n" +
+	"\x11WorkflowTaskEvent\x12\x1b\n" +
+	"\ttask_name\x18\x01 \x01(\tR\btaskName\x12\\\n" +
+	"\x04type\x18\x02 \x01(\x0e2>.ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskEventTypeB\b\xbaH\x05\x82\x01\x02\x10\x01R\x04type\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\tR\ttimestamp\x12\x18\n" +
+	"\aattempt\x18\x04 \x01(\x05R\aattempt\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12o\n" +
+	"\x14error_classification\x18\a \x01(\x0e2<.ai.stigmer.agentic.workflowexecution.v1.ErrorClassificationR\x13errorClassification\"\xb5\x01\n" +
+	"\x0fPendingApproval\x12\x1b\n" +
+	"\ttask_name\x18\x01 \x01(\tR\btaskName\x12\x1c\n" +
+	"\tapprovers\x18\x02 \x03(\tR\tapprovers\x12!\n" +
+	"\frequested_at\x18\x03 \x01(\tR\vrequestedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\tR\texpiresAt\x12%\n" +
+	"\x0ecallback_token\x18\x05 \x01(\fR\rcallbackToken\"\xfd\x04\n" +
 	"\fWorkflowTask\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1b\n" +
 	"\ttask_name\x18\x02 \x01(\tR\btaskName\x12`\n" +
@@ -907,7 +1233,9 @@ const file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDesc = "" +
 	"\fcompleted_at\x18\b \x01(\tR\vcompletedAt\x12\x14\n" +
 	"\x05error\x18\t \x01(\tR\x05error\x123\n" +
 	"\bmetadata\x18\n" +
-	" \x01(\v2\x17.google.protobuf.StructR\bmetadataB\xde\x02\n" +
+	" \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12o\n" +
+	"\x14error_classification\x18\v \x01(\x0e2<.ai.stigmer.agentic.workflowexecution.v1.ErrorClassificationR\x13errorClassification\x12\x18\n" +
+	"\aattempt\x18\f \x01(\x05R\aattemptB\xde\x02\n" +
 	"+com.ai.stigmer.agentic.workflowexecution.v1B\bApiProtoP\x01Zdgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1;workflowexecutionv1\xa2\x02\x04ASAW\xaa\x02'Ai.Stigmer.Agentic.Workflowexecution.V1\xca\x02'Ai\\Stigmer\\Agentic\\Workflowexecution\\V1\xe2\x023Ai\\Stigmer\\Agentic\\Workflowexecution\\V1\\GPBMetadata\xea\x02+Ai::Stigmer::Agentic::Workflowexecution::V1b\x06proto3"
 
 var (
@@ -922,37 +1250,49 @@ func file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDescGZIP() []byte
 	return file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_workflowexecution_v1_api_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_ai_stigmer_agentic_workflowexecution_v1_api_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_ai_stigmer_agentic_workflowexecution_v1_api_proto_goTypes = []any{
 	(*WorkflowExecution)(nil),               // 0: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
 	(*WorkflowExecutionStatus)(nil),         // 1: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus
-	(*WorkflowTask)(nil),                    // 2: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask
-	(*apiresource.ApiResourceMetadata)(nil), // 3: ai.stigmer.commons.apiresource.ApiResourceMetadata
-	(*WorkflowExecutionSpec)(nil),           // 4: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionSpec
-	(*apiresource.ApiResourceAudit)(nil),    // 5: ai.stigmer.commons.apiresource.ApiResourceAudit
-	(ExecutionPhase)(0),                     // 6: ai.stigmer.agentic.workflowexecution.v1.ExecutionPhase
-	(*structpb.Struct)(nil),                 // 7: google.protobuf.Struct
-	(WorkflowTaskType)(0),                   // 8: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskType
-	(WorkflowTaskStatus)(0),                 // 9: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskStatus
+	(*WorkflowTaskEvent)(nil),               // 2: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskEvent
+	(*PendingApproval)(nil),                 // 3: ai.stigmer.agentic.workflowexecution.v1.PendingApproval
+	(*WorkflowTask)(nil),                    // 4: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask
+	(*apiresource.ApiResourceMetadata)(nil), // 5: ai.stigmer.commons.apiresource.ApiResourceMetadata
+	(*WorkflowExecutionSpec)(nil),           // 6: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionSpec
+	(*apiresource.ApiResourceAudit)(nil),    // 7: ai.stigmer.commons.apiresource.ApiResourceAudit
+	(ExecutionPhase)(0),                     // 8: ai.stigmer.agentic.workflowexecution.v1.ExecutionPhase
+	(*structpb.Struct)(nil),                 // 9: google.protobuf.Struct
+	(ConcurrencyDecision)(0),                // 10: ai.stigmer.agentic.workflowexecution.v1.ConcurrencyDecision
+	(ErrorClassification)(0),                // 11: ai.stigmer.agentic.workflowexecution.v1.ErrorClassification
+	(WorkflowTaskEventType)(0),              // 12: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskEventType
+	(WorkflowTaskType)(0),                   // 13: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskType
+	(WorkflowTaskStatus)(0),                 // 14: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskStatus
 }
 var file_ai_stigmer_agentic_workflowexecution_v1_api_proto_depIdxs = []int32{
-	3,  // 0: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution.metadata:type_name -> ai.stigmer.commons.apiresource.ApiResourceMetadata
-	4,  // 1: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution.spec:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionSpec
+	5,  // 0: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution.metadata:type_name -> ai.stigmer.commons.apiresource.ApiResourceMetadata
+	6,  // 1: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution.spec:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionSpec
 	1,  // 2: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution.status:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus
-	5,  // 3: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.audit:type_name -> ai.stigmer.commons.apiresource.ApiResourceAudit
-	6,  // 4: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.phase:type_name -> ai.stigmer.agentic.workflowexecution.v1.ExecutionPhase
-	2,  // 5: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.tasks:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowTask
-	7,  // 6: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.output:type_name -> google.protobuf.Struct
-	8,  // 7: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask.task_type:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskType
-	7,  // 8: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask.input:type_name -> google.protobuf.Struct
-	7,  // 9: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask.output:type_name -> google.protobuf.Struct
-	9,  // 10: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask.status:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskStatus
-	7,  // 11: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask.metadata:type_name -> google.protobuf.Struct
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	7,  // 3: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.audit:type_name -> ai.stigmer.commons.apiresource.ApiResourceAudit
+	8,  // 4: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.phase:type_name -> ai.stigmer.agentic.workflowexecution.v1.ExecutionPhase
+	4,  // 5: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.tasks:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowTask
+	9,  // 6: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.output:type_name -> google.protobuf.Struct
+	10, // 7: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.concurrency_decision:type_name -> ai.stigmer.agentic.workflowexecution.v1.ConcurrencyDecision
+	3,  // 8: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.pending_approvals:type_name -> ai.stigmer.agentic.workflowexecution.v1.PendingApproval
+	11, // 9: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.error_classification:type_name -> ai.stigmer.agentic.workflowexecution.v1.ErrorClassification
+	2,  // 10: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus.task_events:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskEvent
+	12, // 11: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskEvent.type:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskEventType
+	11, // 12: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskEvent.error_classification:type_name -> ai.stigmer.agentic.workflowexecution.v1.ErrorClassification
+	13, // 13: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask.task_type:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskType
+	9,  // 14: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask.input:type_name -> google.protobuf.Struct
+	9,  // 15: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask.output:type_name -> google.protobuf.Struct
+	14, // 16: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask.status:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskStatus
+	9,  // 17: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask.metadata:type_name -> google.protobuf.Struct
+	11, // 18: ai.stigmer.agentic.workflowexecution.v1.WorkflowTask.error_classification:type_name -> ai.stigmer.agentic.workflowexecution.v1.ErrorClassification
+	19, // [19:19] is the sub-list for method output_type
+	19, // [19:19] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_agentic_workflowexecution_v1_api_proto_init() }
@@ -968,7 +1308,7 @@ func file_ai_stigmer_agentic_workflowexecution_v1_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDesc), len(file_ai_stigmer_agentic_workflowexecution_v1_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -84,6 +84,153 @@ func (x *WorkflowExecutionUpdateStatusInput) GetStatus() *WorkflowExecutionStatu
 	return nil
 }
 
+// Input message for approve RPC.
+type WorkflowExecutionApproveInput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the workflow execution waiting for approval (required).
+	ExecutionId string `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	// Name of the approval task to decide on (required).
+	TaskName string `protobuf:"bytes,2,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`
+	// Identity recording the decision; must be listed in the task's approvers
+	// when the task restricts them.
+	Approver string `protobuf:"bytes,3,opt,name=approver,proto3" json:"approver,omitempty"`
+	// Reject instead of approve.
+	Reject bool `protobuf:"varint,4,opt,name=reject,proto3" json:"reject,omitempty"`
+	// Optional comment, returned in the task output.
+	Comment       string `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowExecutionApproveInput) Reset() {
+	*x = WorkflowExecutionApproveInput{}
+	mi := &file_ai_stigmer_agentic_workflowexecution_v1_command_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowExecutionApproveInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowExecutionApproveInput) ProtoMessage() {}
+
+func (x *WorkflowExecutionApproveInput) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflowexecution_v1_command_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowExecutionApproveInput.ProtoReflect.Descriptor instead.
+func (*WorkflowExecutionApproveInput) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflowexecution_v1_command_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WorkflowExecutionApproveInput) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *WorkflowExecutionApproveInput) GetTaskName() string {
+	if x != nil {
+		return x.TaskName
+	}
+	return ""
+}
+
+func (x *WorkflowExecutionApproveInput) GetApprover() string {
+	if x != nil {
+		return x.Approver
+	}
+	return ""
+}
+
+func (x *WorkflowExecutionApproveInput) GetReject() bool {
+	if x != nil {
+		return x.Reject
+	}
+	return false
+}
+
+func (x *WorkflowExecutionApproveInput) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// Input message for cancel RPC.
+type WorkflowExecutionCancelInput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the workflow execution to cancel (required).
+	ExecutionId string `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	// Identity requesting the cancellation, recorded as status.cancelled_by (required).
+	CancelledBy string `protobuf:"bytes,2,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"`
+	// Optional reason, recorded as status.cancel_reason and in the execution error.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowExecutionCancelInput) Reset() {
+	*x = WorkflowExecutionCancelInput{}
+	mi := &file_ai_stigmer_agentic_workflowexecution_v1_command_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowExecutionCancelInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowExecutionCancelInput) ProtoMessage() {}
+
+func (x *WorkflowExecutionCancelInput) ProtoReflect() protoreflect.Message {
+	mi := &file_ai_stigmer_agentic_workflowexecution_v1_command_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowExecutionCancelInput.ProtoReflect.Descriptor instead.
+func (*WorkflowExecutionCancelInput) Descriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflowexecution_v1_command_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WorkflowExecutionCancelInput) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *WorkflowExecutionCancelInput) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+func (x *WorkflowExecutionCancelInput) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 var File_ai_stigmer_agentic_workflowexecution_v1_command_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflowexecution_v1_command_proto_rawDesc = "" +
@@ -91,11 +238,23 @@ const file_ai_stigmer_agentic_workflowexecution_v1_command_proto_rawDesc = "" +
 	"5ai/stigmer/agentic/workflowexecution/v1/command.proto\x12'ai.stigmer.agentic.workflowexecution.v1\x1a1ai/stigmer/agentic/workflowexecution/v1/api.proto\x1a'ai/stigmer/commons/apiresource/io.proto\x1a8ai/stigmer/commons/apiresource/rpc_service_options.proto\x1aAai/stigmer/iam/iampolicy/v1/rpcauthorization/method_options.proto\x1a\x1bbuf/validate/validate.proto\"\xb2\x01\n" +
 	"\"WorkflowExecutionUpdateStatusInput\x12*\n" +
 	"\fexecution_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\vexecutionId\x12`\n" +
-	"\x06status\x18\x02 \x01(\v2@.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatusB\x06\xbaH\x03\xc8\x01\x01R\x06status2\x88\x06\n" +
+	"\x06status\x18\x02 \x01(\v2@.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatusB\x06\xbaH\x03\xc8\x01\x01R\x06status\"\xc8\x01\n" +
+	"\x1dWorkflowExecutionApproveInput\x12*\n" +
+	"\fexecution_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\vexecutionId\x12$\n" +
+	"\ttask_name\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\btaskName\x12#\n" +
+	"\bapprover\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bapprover\x12\x16\n" +
+	"\x06reject\x18\x04 \x01(\bR\x06reject\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\"\x8e\x01\n" +
+	"\x1cWorkflowExecutionCancelInput\x12*\n" +
+	"\fexecution_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\vexecutionId\x12*\n" +
+	"\fcancelled_by\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\vcancelledBy\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason2\xad\t\n" +
 	"\"WorkflowExecutionCommandController\x12\x80\x01\n" +
 	"\x06create\x12:.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution\x1a:.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution\x12\xc2\x01\n" +
 	"\x06update\x12:.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution\x1a:.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution\"@¸\x18<\b\x04\x104\"\vmetadata.id*)unauthorized to update workflow execution\x12\xe1\x01\n" +
-	"\fupdateStatus\x12K.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionUpdateStatusInput\x1a:.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution\"H¸\x18D\b\x04\x104\"\fexecution_id*0unauthorized to update workflow execution status\x12\xaf\x01\n" +
+	"\fupdateStatus\x12K.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionUpdateStatusInput\x1a:.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution\"H¸\x18D\b\x04\x104\"\fexecution_id*0unauthorized to update workflow execution status\x12\xd1\x01\n" +
+	"\aapprove\x12F.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionApproveInput\x1a:.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution\"B¸\x18>\b\x04\x104\"\fexecution_id**unauthorized to approve workflow execution\x12\xce\x01\n" +
+	"\x06cancel\x12E.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCancelInput\x1a:.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution\"A¸\x18=\b\x04\x104\"\fexecution_id*)unauthorized to cancel workflow execution\x12\xaf\x01\n" +
 	"\x06delete\x12-.ai.stigmer.commons.apiresource.ApiResourceId\x1a:.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution\":¸\x186\b\x04\x104\"\x05value*)unauthorized to delete workflow execution\x1a\x04\xa0\xff+4B\xe2\x02\n" +
 	"+com.ai.stigmer.agentic.workflowexecution.v1B\fCommandProtoP\x01Zdgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1;workflowexecutionv1\xa2\x02\x04ASAW\xaa\x02'Ai.Stigmer.Agentic.Workflowexecution.V1\xca\x02'Ai\\Stigmer\\Agentic\\Workflowexecution\\V1\xe2\x023Ai\\Stigmer\\Agentic\\Workflowexecution\\V1\\GPBMetadata\xea\x02+Ai::Stigmer::Agentic::Workflowexecution::V1b\x06proto3"
 
@@ -111,25 +270,31 @@ func file_ai_stigmer_agentic_workflowexecution_v1_command_proto_rawDescGZIP() []
 	return file_ai_stigmer_agentic_workflowexecution_v1_command_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_workflowexecution_v1_command_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_ai_stigmer_agentic_workflowexecution_v1_command_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_ai_stigmer_agentic_workflowexecution_v1_command_proto_goTypes = []any{
 	(*WorkflowExecutionUpdateStatusInput)(nil), // 0: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionUpdateStatusInput
-	(*WorkflowExecutionStatus)(nil),            // 1: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus
-	(*WorkflowExecution)(nil),                  // 2: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
-	(*apiresource.ApiResourceId)(nil),          // 3: ai.stigmer.commons.apiresource.ApiResourceId
+	(*WorkflowExecutionApproveInput)(nil),      // 1: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionApproveInput
+	(*WorkflowExecutionCancelInput)(nil),       // 2: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCancelInput
+	(*WorkflowExecutionStatus)(nil),            // 3: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus
+	(*WorkflowExecution)(nil),                  // 4: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
+	(*apiresource.ApiResourceId)(nil),          // 5: ai.stigmer.commons.apiresource.ApiResourceId
 }
 var file_ai_stigmer_agentic_workflowexecution_v1_command_proto_depIdxs = []int32{
-	1, // 0: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionUpdateStatusInput.status:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus
-	2, // 1: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.create:input_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
-	2, // 2: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.update:input_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
+	3, // 0: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionUpdateStatusInput.status:type_name -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionStatus
+	4, // 1: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.create:input_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
+	4, // 2: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.update:input_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
 	0, // 3: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.updateStatus:input_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionUpdateStatusInput
-	3, // 4: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.delete:input_type -> ai.stigmer.commons.apiresource.ApiResourceId
-	2, // 5: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.create:output_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
-	2, // 6: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.update:output_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
-	2, // 7: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.updateStatus:output_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
-	2, // 8: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.delete:output_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
+	1, // 4: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.approve:input_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionApproveInput
+	2, // 5: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.cancel:input_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCancelInput
+	5, // 6: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.delete:input_type -> ai.stigmer.commons.apiresource.ApiResourceId
+	4, // 7: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.create:output_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
+	4, // 8: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.update:output_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
+	4, // 9: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.updateStatus:output_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
+	4, // 10: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.approve:output_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
+	4, // 11: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.cancel:output_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
+	4, // 12: ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController.delete:output_type -> ai.stigmer.agentic.workflowexecution.v1.WorkflowExecution
+	7, // [7:13] is the sub-list for method output_type
+	1, // [1:7] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
 	1, // [1:1] is the sub-list for extension extendee
 	0, // [0:1] is the sub-list for field type_name
@@ -147,7 +312,7 @@ func file_ai_stigmer_agentic_workflowexecution_v1_command_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_workflowexecution_v1_command_proto_rawDesc), len(file_ai_stigmer_agentic_workflowexecution_v1_command_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,8 @@ const (
 	WorkflowExecutionCommandController_Create_FullMethodName       = "/ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController/create"
 	WorkflowExecutionCommandController_Update_FullMethodName       = "/ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController/update"
 	WorkflowExecutionCommandController_UpdateStatus_FullMethodName = "/ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController/updateStatus"
+	WorkflowExecutionCommandController_Approve_FullMethodName      = "/ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController/approve"
+	WorkflowExecutionCommandController_Cancel_FullMethodName       = "/ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController/cancel"
 	WorkflowExecutionCommandController_Delete_FullMethodName       = "/ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionCommandController/delete"
 )
 
@@ -269,6 +271,34 @@ type WorkflowExecutionCommandControllerClient interface {
 	//	  }
 	//	}
 	UpdateStatus(ctx context.Context, in *WorkflowExecutionUpdateStatusInput, opts ...grpc.CallOption) (*WorkflowExecution, error)
+	// Approve or reject an approval task that is waiting for a decision.
+	//
+	// Resumes an execution paused in EXECUTION_AWAITING_APPROVAL. An approval
+	// completes the task with output {approved, approved_by, approved_at, comment};
+	// a rejection fails it with error type "ApprovalRejected".
+	//
+	// Error Cases:
+	// - NOT_FOUND: Execution doesn't exist
+	// - FAILED_PRECONDITION: Task is not waiting for approval
+	// - PERMISSION_DENIED: Approver is not in the task's approvers list
+	// - UNAVAILABLE: Execution engine is not connected
+	Approve(ctx context.Context, in *WorkflowExecutionApproveInput, opts ...grpc.CallOption) (*WorkflowExecution, error)
+	// Cancel a running execution.
+	//
+	// Requests cancellation of the execution's Temporal workflow and moves the
+	// execution to EXECUTION_CANCELLING, recording who cancelled it. The runner
+	// aborts in-flight activities, stops scheduling loop iterations and reports
+	// EXECUTION_CANCELLED once the workflow has stopped. Executions that were
+	// never started (pending or queued by a concurrency policy) are cancelled
+	// immediately.
+	//
+	// Cancelling an execution that is already cancelling is a no-op.
+	//
+	// Error Cases:
+	// - NOT_FOUND: Execution doesn't exist
+	// - FAILED_PRECONDITION: Execution already completed, failed or was cancelled
+	// - UNAVAILABLE: Execution engine is not connected
+	Cancel(ctx context.Context, in *WorkflowExecutionCancelInput, opts ...grpc.CallOption) (*WorkflowExecution, error)
 	// Delete an execution.
 	Delete(ctx context.Context, in *apiresource.ApiResourceId, opts ...grpc.CallOption) (*WorkflowExecution, error)
 }
@@ -311,6 +341,26 @@ func (c *workflowExecutionCommandControllerClient) UpdateStatus(ctx context.Cont
 	return out, nil
 }
 
+func (c *workflowExecutionCommandControllerClient) Approve(ctx context.Context, in *WorkflowExecutionApproveInput, opts ...grpc.CallOption) (*WorkflowExecution, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkflowExecution)
+	err := c.cc.Invoke(ctx, WorkflowExecutionCommandController_Approve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowExecutionCommandControllerClient) Cancel(ctx context.Context, in *WorkflowExecutionCancelInput, opts ...grpc.CallOption) (*WorkflowExecution, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkflowExecution)
+	err := c.cc.Invoke(ctx, WorkflowExecutionCommandController_Cancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workflowExecutionCommandControllerClient) Delete(ctx context.Context, in *apiresource.ApiResourceId, opts ...grpc.CallOption) (*WorkflowExecution, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(WorkflowExecution)
@@ -564,6 +614,34 @@ type WorkflowExecutionCommandControllerServer interface {
 	//	  }
 	//	}
 	UpdateStatus(context.Context, *WorkflowExecutionUpdateStatusInput) (*WorkflowExecution, error)
+	// Approve or reject an approval task that is waiting for a decision.
+	//
+	// Resumes an execution paused in EXECUTION_AWAITING_APPROVAL. An approval
+	// completes the task with output {approved, approved_by, approved_at, comment};
+	// a rejection fails it with error type "ApprovalRejected".
+	//
+	// Error Cases:
+	// - NOT_FOUND: Execution doesn't exist
+	// - FAILED_PRECONDITION: Task is not waiting for approval
+	// - PERMISSION_DENIED: Approver is not in the task's approvers list
+	// - UNAVAILABLE: Execution engine is not connected
+	Approve(context.Context, *WorkflowExecutionApproveInput) (*WorkflowExecution, error)
+	// Cancel a running execution.
+	//
+	// Requests cancellation of the execution's Temporal workflow and moves the
+	// execution to EXECUTION_CANCELLING, recording who cancelled it. The runner
+	// aborts in-flight activities, stops scheduling loop iterations and reports
+	// EXECUTION_CANCELLED once the workflow has stopped. Executions that were
+	// never started (pending or queued by a concurrency policy) are cancelled
+	// immediately.
+	//
+	// Cancelling an execution that is already cancelling is a no-op.
+	//
+	// Error Cases:
+	// - NOT_FOUND: Execution doesn't exist
+	// - FAILED_PRECONDITION: Execution already completed, failed or was cancelled
+	// - UNAVAILABLE: Execution engine is not connected
+	Cancel(context.Context, *WorkflowExecutionCancelInput) (*WorkflowExecution, error)
 	// Delete an execution.
 	Delete(context.Context, *apiresource.ApiResourceId) (*WorkflowExecution, error)
 }
@@ -584,6 +662,12 @@ func (UnimplementedWorkflowExecutionCommandControllerServer) Update(context.Cont
 func (UnimplementedWorkflowExecutionCommandControllerServer) UpdateStatus(context.Context, *WorkflowExecutionUpdateStatusInput) (*WorkflowExecution, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateStatus not implemented")
 }
+func (UnimplementedWorkflowExecutionCommandControllerServer) Approve(context.Context, *WorkflowExecutionApproveInput) (*WorkflowExecution, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Approve not implemented")
+}
+func (UnimplementedWorkflowExecutionCommandControllerServer) Cancel(context.Context, *WorkflowExecutionCancelInput) (*WorkflowExecution, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
 func (UnimplementedWorkflowExecutionCommandControllerServer) Delete(context.Context, *apiresource.ApiResourceId) (*WorkflowExecution, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
@@ -661,6 +745,42 @@ func _WorkflowExecutionCommandController_UpdateStatus_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowExecutionCommandController_Approve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowExecutionApproveInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowExecutionCommandControllerServer).Approve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkflowExecutionCommandController_Approve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowExecutionCommandControllerServer).Approve(ctx, req.(*WorkflowExecutionApproveInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowExecutionCommandController_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowExecutionCancelInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowExecutionCommandControllerServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkflowExecutionCommandController_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowExecutionCommandControllerServer).Cancel(ctx, req.(*WorkflowExecutionCancelInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkflowExecutionCommandController_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(apiresource.ApiResourceId)
 	if err := dec(in); err != nil {
@@ -698,6 +818,14 @@ var WorkflowExecutionCommandController_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "updateStatus",
 			Handler:    _WorkflowExecutionCommandController_UpdateStatus_Handler,
 		},
+		{
+			MethodName: "approve",
+			Handler:    _WorkflowExecutionCommandController_Approve_Handler,
+		},
+		{
+			MethodName: "cancel",
+			Handler:    _WorkflowExecutionCommandController_Cancel_Handler,
+		},
 		{
 			MethodName: "delete",
 			Handler:    _WorkflowExecutionCommandController_Delete_Handler,
@@ -42,6 +42,10 @@ const (
 // Cancellation flow:
 // EXECUTION_PENDING → EXECUTION_CANCELLED
 // EXECUTION_IN_PROGRESS → EXECUTION_CANCELLED
+// EXECUTION_IN_PROGRESS → EXECUTION_CANCELLING → EXECUTION_CANCELLED
+//
+// Approval flow:
+// EXECUTION_IN_PROGRESS → EXECUTION_AWAITING_APPROVAL → EXECUTION_IN_PROGRESS
 //
 // Terminal States:
 // - EXECUTION_COMPLETED: Workflow finished successfully
@@ -142,6 +146,23 @@ const (
 	// - Cancelled: User or system intentionally stopped the workflow
 	// - Failed: Workflow encountered an error during execution
 	ExecutionPhase_EXECUTION_CANCELLED ExecutionPhase = 5
+	// Execution is paused at an approval task, waiting for a human decision.
+	//
+	// The workflow reached a LISTEN task configured as an approval gate.
+	// status.pending_approvals lists the tasks waiting for a decision, which is
+	// sent through WorkflowExecutionCommandController.approve.
+	//
+	// Next phases: EXECUTION_IN_PROGRESS (decision received), EXECUTION_FAILED
+	// (rejected or timed out), EXECUTION_CANCELLED
+	ExecutionPhase_EXECUTION_AWAITING_APPROVAL ExecutionPhase = 6
+	// Cancellation was requested and the runner is stopping the workflow.
+	//
+	// Set by the cancel RPC. In-flight activities are aborted and loops stop
+	// scheduling new iterations; status.cancelled_by records who cancelled.
+	//
+	// Next phases: EXECUTION_CANCELLED (workflow stopped), EXECUTION_COMPLETED or
+	// EXECUTION_FAILED (workflow finished before the cancellation reached it)
+	ExecutionPhase_EXECUTION_CANCELLING ExecutionPhase = 7
 )
 
 // Enum value maps for ExecutionPhase.
@@ -153,6 +174,8 @@ var (
 		3: "EXECUTION_COMPLETED",
 		4: "EXECUTION_FAILED",
 		5: "EXECUTION_CANCELLED",
+		6: "EXECUTION_AWAITING_APPROVAL",
+		7: "EXECUTION_CANCELLING",
 	}
 	ExecutionPhase_value = map[string]int32{
 		"EXECUTION_PHASE_UNSPECIFIED": 0,
@@ -161,6 +184,8 @@ var (
 		"EXECUTION_COMPLETED":         3,
 		"EXECUTION_FAILED":            4,
 		"EXECUTION_CANCELLED":         5,
+		"EXECUTION_AWAITING_APPROVAL": 6,
+		"EXECUTION_CANCELLING":        7,
 	}
 )
 
@@ -191,6 +216,155 @@ func (ExecutionPhase) EnumDescriptor() ([]byte, []int) {
 	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDescGZIP(), []int{0}
 }
 
+// ConcurrencyDecision records how a workflow's concurrency policy affected an execution.
+type ConcurrencyDecision int32
+
+const (
+	// No concurrency policy applied to this execution.
+	ConcurrencyDecision_CONCURRENCY_DECISION_UNSPECIFIED ConcurrencyDecision = 0
+	// No in-flight execution shared the key; started immediately.
+	ConcurrencyDecision_CONCURRENCY_STARTED ConcurrencyDecision = 1
+	// Waiting for in-flight executions with the same key to finish.
+	ConcurrencyDecision_CONCURRENCY_QUEUED ConcurrencyDecision = 2
+	// Not run because an in-flight execution shared the key.
+	ConcurrencyDecision_CONCURRENCY_SKIPPED ConcurrencyDecision = 3
+	// Started after cancelling in-flight executions with the same key.
+	ConcurrencyDecision_CONCURRENCY_CANCELLED_EXISTING ConcurrencyDecision = 4
+)
+
+// Enum value maps for ConcurrencyDecision.
+var (
+	ConcurrencyDecision_name = map[int32]string{
+		0: "CONCURRENCY_DECISION_UNSPECIFIED",
+		1: "CONCURRENCY_STARTED",
+		2: "CONCURRENCY_QUEUED",
+		3: "CONCURRENCY_SKIPPED",
+		4: "CONCURRENCY_CANCELLED_EXISTING",
+	}
+	ConcurrencyDecision_value = map[string]int32{
+		"CONCURRENCY_DECISION_UNSPECIFIED": 0,
+		"CONCURRENCY_STARTED":              1,
+		"CONCURRENCY_QUEUED":               2,
+		"CONCURRENCY_SKIPPED":              3,
+		"CONCURRENCY_CANCELLED_EXISTING":   4,
+	}
+)
+
+func (x ConcurrencyDecision) Enum() *ConcurrencyDecision {
+	p := new(ConcurrencyDecision)
+	*p = x
+	return p
+}
+
+func (x ConcurrencyDecision) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConcurrencyDecision) Descriptor() protoreflect.EnumDescriptor {
+	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[1].Descriptor()
+}
+
+func (ConcurrencyDecision) Type() protoreflect.EnumType {
+	return &file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[1]
+}
+
+func (x ConcurrencyDecision) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConcurrencyDecision.Descriptor instead.
+func (ConcurrencyDecision) EnumDescriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDescGZIP(), []int{1}
+}
+
+// ErrorClassification is the cause of a failed task or execution, set by the
+// workflow runner so alerting can tell user errors from infrastructure errors
+// without parsing error messages.
+type ErrorClassification int32
+
+const (
+	// Not classified (the task or execution did not fail).
+	ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED ErrorClassification = 0
+	// The workflow definition or its input is wrong: validation failures,
+	// expression errors, raised errors and redirects that are not followed.
+	ErrorClassification_USER_ERROR ErrorClassification = 1
+	// The target API answered with a 5xx status.
+	ErrorClassification_UPSTREAM_5XX ErrorClassification = 2
+	// The target API answered with a 4xx status.
+	ErrorClassification_UPSTREAM_4XX ErrorClassification = 3
+	// The task or execution ran out of time (activity, HTTP or workflow timeout).
+	ErrorClassification_TIMEOUT ErrorClassification = 4
+	// Infrastructure failure: DNS, connection or TLS errors, Temporal or
+	// Stigmer backend errors, and failures that could not be classified.
+	ErrorClassification_INFRA ErrorClassification = 5
+	// The task or execution was cancelled.
+	ErrorClassification_CANCELLED ErrorClassification = 6
+	// The runner's outbound host policy denied a connection, e.g. an HTTP call
+	// or one of its redirects to a host outside the allowlist.
+	ErrorClassification_POLICY_DENIED ErrorClassification = 7
+	// The execution ran past the maximum duration of its workflow
+	// (WorkflowSpec.max_duration_seconds).
+	ErrorClassification_WORKFLOW_TIMEOUT ErrorClassification = 8
+	// An agent called by a CallAgent task was stopped after exceeding one of
+	// its limits (AgentSpec.limits).
+	ErrorClassification_LIMIT_EXCEEDED ErrorClassification = 9
+)
+
+// Enum value maps for ErrorClassification.
+var (
+	ErrorClassification_name = map[int32]string{
+		0: "ERROR_CLASSIFICATION_UNSPECIFIED",
+		1: "USER_ERROR",
+		2: "UPSTREAM_5XX",
+		3: "UPSTREAM_4XX",
+		4: "TIMEOUT",
+		5: "INFRA",
+		6: "CANCELLED",
+		7: "POLICY_DENIED",
+		8: "WORKFLOW_TIMEOUT",
+		9: "LIMIT_EXCEEDED",
+	}
+	ErrorClassification_value = map[string]int32{
+		"ERROR_CLASSIFICATION_UNSPECIFIED": 0,
+		"USER_ERROR":                       1,
+		"UPSTREAM_5XX":                     2,
+		"UPSTREAM_4XX":                     3,
+		"TIMEOUT":                          4,
+		"INFRA":                            5,
+		"CANCELLED":                        6,
+		"POLICY_DENIED":                    7,
+		"WORKFLOW_TIMEOUT":                 8,
+		"LIMIT_EXCEEDED":                   9,
+	}
+)
+
+func (x ErrorClassification) Enum() *ErrorClassification {
+	p := new(ErrorClassification)
+	*p = x
+	return p
+}
+
+func (x ErrorClassification) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorClassification) Descriptor() protoreflect.EnumDescriptor {
+	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[2].Descriptor()
+}
+
+func (ErrorClassification) Type() protoreflect.EnumType {
+	return &file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[2]
+}
+
+func (x ErrorClassification) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorClassification.Descriptor instead.
+func (ErrorClassification) EnumDescriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDescGZIP(), []int{2}
+}
+
 // WorkflowTaskType defines the type of workflow task.
 //
 // Tasks are the atomic units of work within a workflow. Each task type has:
@@ -217,21 +391,19 @@ const (
 	// This task type calls an AgentInstance and waits for the agent execution to complete.
 	//
 	// Input Schema:
-	//
-	//	{
-	//	  "agent_instance_id": "agi-customer-support",
-	//	  "prompt": "Analyze this feedback: {{workflow.input.feedback}}",
-	//	  "max_tokens": 500,
-	//	  "temperature": 0.7
-	//	}
+	// {
+	//   "agent_instance_id": "agi-customer-support",
+	//   "prompt": "Analyze this feedback: {{workflow.input.feedback}}",
+	//   "max_tokens": 500,
+	//   "temperature": 0.7
+	// }
 	//
 	// Output Schema:
-	//
-	//	{
-	//	  "agent_execution_id": "agx-abc123",
-	//	  "response": "The customer feedback indicates...",
-	//	  "metadata": { "tokens_used": 450, "model": "gpt-4" }
-	//	}
+	// {
+	//   "agent_execution_id": "agx-abc123",
+	//   "response": "The customer feedback indicates...",
+	//   "metadata": { "tokens_used": 450, "model": "gpt-4" }
+	// }
 	//
 	// Use Cases:
 	// - Content generation (write email, generate report)
@@ -249,22 +421,20 @@ const (
 	// This task pauses the workflow and waits for one or more users to approve or reject.
 	//
 	// Input Schema:
-	//
-	//	{
-	//	  "approvers": ["usr-admin-1", "usr-admin-2"],
-	//	  "message": "Approve account creation for {{workflow.input.email}}?",
-	//	  "timeout_hours": 24,
-	//	  "require_all_approvers": false
-	//	}
+	// {
+	//   "approvers": ["usr-admin-1", "usr-admin-2"],
+	//   "message": "Approve account creation for {{workflow.input.email}}?",
+	//   "timeout_hours": 24,
+	//   "require_all_approvers": false
+	// }
 	//
 	// Output Schema:
-	//
-	//	{
-	//	  "approved": true,
-	//	  "approved_by": "usr-admin-1",
-	//	  "approved_at": "2025-01-11T15:22:33Z",
-	//	  "comment": "Looks good, approved"
-	//	}
+	// {
+	//   "approved": true,
+	//   "approved_by": "usr-admin-1",
+	//   "approved_at": "2025-01-11T15:22:33Z",
+	//   "comment": "Looks good, approved"
+	// }
 	//
 	// Use Cases:
 	// - Manual approval gates (approve deployment, approve budget)
@@ -281,32 +451,30 @@ const (
 	// This task sends a request to an external API and captures the response.
 	//
 	// Input Schema:
-	//
-	//	{
-	//	  "method": "POST",
-	//	  "url": "https://api.stripe.com/v1/customers",
-	//	  "headers": {
-	//	    "Authorization": "Bearer {{env.STRIPE_API_KEY}}",
-	//	    "Content-Type": "application/json"
-	//	  },
-	//	  "body": {
-	//	    "email": "{{workflow.input.email}}",
-	//	    "name": "{{workflow.input.name}}"
-	//	  },
-	//	  "timeout_seconds": 30
-	//	}
+	// {
+	//   "method": "POST",
+	//   "url": "https://api.stripe.com/v1/customers",
+	//   "headers": {
+	//     "Authorization": "Bearer {{env.STRIPE_API_KEY}}",
+	//     "Content-Type": "application/json"
+	//   },
+	//   "body": {
+	//     "email": "{{workflow.input.email}}",
+	//     "name": "{{workflow.input.name}}"
+	//   },
+	//   "timeout_seconds": 30
+	// }
 	//
 	// Output Schema:
-	//
-	//	{
-	//	  "status_code": 200,
-	//	  "headers": { "x-request-id": "req-xyz789" },
-	//	  "body": {
-	//	    "id": "cus-abc123",
-	//	    "email": "customer@example.com",
-	//	    "created": 1704988800
-	//	  }
-	//	}
+	// {
+	//   "status_code": 200,
+	//   "headers": { "x-request-id": "req-xyz789" },
+	//   "body": {
+	//     "id": "cus-abc123",
+	//     "email": "customer@example.com",
+	//     "created": 1704988800
+	//   }
+	// }
 	//
 	// Use Cases:
 	// - Create resources in external systems (create Stripe customer, create GitHub issue)
@@ -325,20 +493,18 @@ const (
 	// This task evaluates a boolean expression and determines which tasks to execute next.
 	//
 	// Input Schema:
-	//
-	//	{
-	//	  "condition": "{{tasks.validate_email.output.valid}} == true",
-	//	  "if_true": ["task-create-account", "task-send-welcome"],
-	//	  "if_false": ["task-send-error-email"]
-	//	}
+	// {
+	//   "condition": "{{tasks.validate_email.output.valid}} == true",
+	//   "if_true": ["task-create-account", "task-send-welcome"],
+	//   "if_false": ["task-send-error-email"]
+	// }
 	//
 	// Output Schema:
-	//
-	//	{
-	//	  "condition_result": true,
-	//	  "executed_branch": "if_true",
-	//	  "executed_tasks": ["task-create-account", "task-send-welcome"]
-	//	}
+	// {
+	//   "condition_result": true,
+	//   "executed_branch": "if_true",
+	//   "executed_tasks": ["task-create-account", "task-send-welcome"]
+	// }
 	//
 	// Use Cases:
 	// - Branching logic (if email is valid, create account; else send error)
@@ -354,29 +520,27 @@ const (
 	// This task spawns multiple tasks that run in parallel and waits for all to complete.
 	//
 	// Input Schema:
-	//
-	//	{
-	//	  "tasks": [
-	//	    { "task_id": "send-email", "task_type": "api_call", "input": {...} },
-	//	    { "task_id": "send-sms", "task_type": "api_call", "input": {...} },
-	//	    { "task_id": "send-slack", "task_type": "api_call", "input": {...} }
-	//	  ],
-	//	  "wait_for_all": true,
-	//	  "fail_on_any_failure": false
-	//	}
+	// {
+	//   "tasks": [
+	//     { "task_id": "send-email", "task_type": "api_call", "input": {...} },
+	//     { "task_id": "send-sms", "task_type": "api_call", "input": {...} },
+	//     { "task_id": "send-slack", "task_type": "api_call", "input": {...} }
+	//   ],
+	//   "wait_for_all": true,
+	//   "fail_on_any_failure": false
+	// }
 	//
 	// Output Schema:
-	//
-	//	{
-	//	  "total_tasks": 3,
-	//	  "successful_tasks": 2,
-	//	  "failed_tasks": 1,
-	//	  "results": [
-	//	    { "task_id": "send-email", "status": "completed", "output": {...} },
-	//	    { "task_id": "send-sms", "status": "failed", "error": "..." },
-	//	    { "task_id": "send-slack", "status": "completed", "output": {...} }
-	//	  ]
-	//	}
+	// {
+	//   "total_tasks": 3,
+	//   "successful_tasks": 2,
+	//   "failed_tasks": 1,
+	//   "results": [
+	//     { "task_id": "send-email", "status": "completed", "output": {...} },
+	//     { "task_id": "send-sms", "status": "failed", "error": "..." },
+	//     { "task_id": "send-slack", "status": "completed", "output": {...} }
+	//   ]
+	// }
 	//
 	// Use Cases:
 	// - Fan-out operations (send notifications to multiple channels)
@@ -392,17 +556,15 @@ const (
 	// This task applies transformations to data (map, filter, aggregate, format).
 	//
 	// Input Schema:
-	//
-	//	{
-	//	  "expression": "{{tasks.fetch_customers.output.customers | map('email')}}",
-	//	  "output_variable": "customer_emails"
-	//	}
+	// {
+	//   "expression": "{{tasks.fetch_customers.output.customers | map('email')}}",
+	//   "output_variable": "customer_emails"
+	// }
 	//
 	// Output Schema:
-	//
-	//	{
-	//	  "result": ["customer1@example.com", "customer2@example.com", "customer3@example.com"]
-	//	}
+	// {
+	//   "result": ["customer1@example.com", "customer2@example.com", "customer3@example.com"]
+	// }
 	//
 	// Use Cases:
 	// - Data extraction (extract email addresses from customer objects)
@@ -466,11 +628,11 @@ func (x WorkflowTaskType) String() string {
 }
 
 func (WorkflowTaskType) Descriptor() protoreflect.EnumDescriptor {
-	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[1].Descriptor()
+	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[3].Descriptor()
 }
 
 func (WorkflowTaskType) Type() protoreflect.EnumType {
-	return &file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[1]
+	return &file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[3]
 }
 
 func (x WorkflowTaskType) Number() protoreflect.EnumNumber {
@@ -479,7 +641,7 @@ func (x WorkflowTaskType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use WorkflowTaskType.Descriptor instead.
 func (WorkflowTaskType) EnumDescriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDescGZIP(), []int{1}
+	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDescGZIP(), []int{3}
 }
 
 // WorkflowTaskStatus defines the execution status of a workflow task.
@@ -501,6 +663,9 @@ func (WorkflowTaskType) EnumDescriptor() ([]byte, []int) {
 //
 // Skip flow (conditional):
 // WORKFLOW_TASK_PENDING → WORKFLOW_TASK_SKIPPED
+//
+// Approval flow:
+// WORKFLOW_TASK_IN_PROGRESS → WORKFLOW_TASK_AWAITING_APPROVAL → WORKFLOW_TASK_COMPLETED/FAILED
 type WorkflowTaskStatus int32
 
 const (
@@ -608,6 +773,14 @@ const (
 	//
 	// Next statuses: None (terminal state for this task)
 	WorkflowTaskStatus_WORKFLOW_TASK_SKIPPED WorkflowTaskStatus = 5
+	// Approval task is waiting for a human decision.
+	//
+	// The task is listed in status.pending_approvals until an approval or
+	// rejection arrives, or its timeout expires.
+	//
+	// Next statuses: WORKFLOW_TASK_COMPLETED (approved), WORKFLOW_TASK_FAILED
+	// (rejected or timed out)
+	WorkflowTaskStatus_WORKFLOW_TASK_AWAITING_APPROVAL WorkflowTaskStatus = 6
 )
 
 // Enum value maps for WorkflowTaskStatus.
@@ -619,6 +792,7 @@ var (
 		3: "WORKFLOW_TASK_COMPLETED",
 		4: "WORKFLOW_TASK_FAILED",
 		5: "WORKFLOW_TASK_SKIPPED",
+		6: "WORKFLOW_TASK_AWAITING_APPROVAL",
 	}
 	WorkflowTaskStatus_value = map[string]int32{
 		"WORKFLOW_TASK_STATUS_UNSPECIFIED": 0,
@@ -627,6 +801,7 @@ var (
 		"WORKFLOW_TASK_COMPLETED":          3,
 		"WORKFLOW_TASK_FAILED":             4,
 		"WORKFLOW_TASK_SKIPPED":            5,
+		"WORKFLOW_TASK_AWAITING_APPROVAL":  6,
 	}
 )
 
@@ -641,11 +816,11 @@ func (x WorkflowTaskStatus) String() string {
 }
 
 func (WorkflowTaskStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[2].Descriptor()
+	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[4].Descriptor()
 }
 
 func (WorkflowTaskStatus) Type() protoreflect.EnumType {
-	return &file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[2]
+	return &file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[4]
 }
 
 func (x WorkflowTaskStatus) Number() protoreflect.EnumNumber {
@@ -654,21 +829,104 @@ func (x WorkflowTaskStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use WorkflowTaskStatus.Descriptor instead.
 func (WorkflowTaskStatus) EnumDescriptor() ([]byte, []int) {
-	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDescGZIP(), []int{2}
+	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDescGZIP(), []int{4}
+}
+
+// WorkflowTaskEventType is the kind of task state transition recorded in
+// WorkflowExecutionStatus.task_events.
+type WorkflowTaskEventType int32
+
+const (
+	// Unspecified event type (invalid, should never be used).
+	WorkflowTaskEventType_WORKFLOW_TASK_EVENT_TYPE_UNSPECIFIED WorkflowTaskEventType = 0
+	// The first attempt of the task started.
+	WorkflowTaskEventType_WORKFLOW_TASK_EVENT_STARTED WorkflowTaskEventType = 1
+	// The task completed successfully.
+	WorkflowTaskEventType_WORKFLOW_TASK_EVENT_COMPLETED WorkflowTaskEventType = 2
+	// An attempt of the task failed. Followed by a RETRIED event when the
+	// runner retries the task.
+	WorkflowTaskEventType_WORKFLOW_TASK_EVENT_FAILED WorkflowTaskEventType = 3
+	// A later attempt of the task started after a failure.
+	WorkflowTaskEventType_WORKFLOW_TASK_EVENT_RETRIED WorkflowTaskEventType = 4
+)
+
+// Enum value maps for WorkflowTaskEventType.
+var (
+	WorkflowTaskEventType_name = map[int32]string{
+		0: "WORKFLOW_TASK_EVENT_TYPE_UNSPECIFIED",
+		1: "WORKFLOW_TASK_EVENT_STARTED",
+		2: "WORKFLOW_TASK_EVENT_COMPLETED",
+		3: "WORKFLOW_TASK_EVENT_FAILED",
+		4: "WORKFLOW_TASK_EVENT_RETRIED",
+	}
+	WorkflowTaskEventType_value = map[string]int32{
+		"WORKFLOW_TASK_EVENT_TYPE_UNSPECIFIED": 0,
+		"WORKFLOW_TASK_EVENT_STARTED":          1,
+		"WORKFLOW_TASK_EVENT_COMPLETED":        2,
+		"WORKFLOW_TASK_EVENT_FAILED":           3,
+		"WORKFLOW_TASK_EVENT_RETRIED":          4,
+	}
+)
+
+func (x WorkflowTaskEventType) Enum() *WorkflowTaskEventType {
+	p := new(WorkflowTaskEventType)
+	*p = x
+	return p
+}
+
+func (x WorkflowTaskEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkflowTaskEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[5].Descriptor()
+}
+
+func (WorkflowTaskEventType) Type() protoreflect.EnumType {
+	return &file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes[5]
+}
+
+func (x WorkflowTaskEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkflowTaskEventType.Descriptor instead.
+func (WorkflowTaskEventType) EnumDescriptor() ([]byte, []int) {
+	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDescGZIP(), []int{5}
 }
 
 var File_ai_stigmer_agentic_workflowexecution_v1_enum_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDesc = "" +
 	"\n" +
-	"2ai/stigmer/agentic/workflowexecution/v1/enum.proto\x12'ai.stigmer.agentic.workflowexecution.v1*\xab\x01\n" +
+	"2ai/stigmer/agentic/workflowexecution/v1/enum.proto\x12'ai.stigmer.agentic.workflowexecution.v1*\xe6\x01\n" +
 	"\x0eExecutionPhase\x12\x1f\n" +
 	"\x1bEXECUTION_PHASE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11EXECUTION_PENDING\x10\x01\x12\x19\n" +
 	"\x15EXECUTION_IN_PROGRESS\x10\x02\x12\x17\n" +
 	"\x13EXECUTION_COMPLETED\x10\x03\x12\x14\n" +
 	"\x10EXECUTION_FAILED\x10\x04\x12\x17\n" +
-	"\x13EXECUTION_CANCELLED\x10\x05*\x84\x02\n" +
+	"\x13EXECUTION_CANCELLED\x10\x05\x12\x1f\n" +
+	"\x1bEXECUTION_AWAITING_APPROVAL\x10\x06\x12\x18\n" +
+	"\x14EXECUTION_CANCELLING\x10\a*\xa9\x01\n" +
+	"\x13ConcurrencyDecision\x12$\n" +
+	" CONCURRENCY_DECISION_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CONCURRENCY_STARTED\x10\x01\x12\x16\n" +
+	"\x12CONCURRENCY_QUEUED\x10\x02\x12\x17\n" +
+	"\x13CONCURRENCY_SKIPPED\x10\x03\x12\"\n" +
+	"\x1eCONCURRENCY_CANCELLED_EXISTING\x10\x04*\xd3\x01\n" +
+	"\x13ErrorClassification\x12$\n" +
+	" ERROR_CLASSIFICATION_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"USER_ERROR\x10\x01\x12\x10\n" +
+	"\fUPSTREAM_5XX\x10\x02\x12\x10\n" +
+	"\fUPSTREAM_4XX\x10\x03\x12\v\n" +
+	"\aTIMEOUT\x10\x04\x12\t\n" +
+	"\x05INFRA\x10\x05\x12\r\n" +
+	"\tCANCELLED\x10\x06\x12\x11\n" +
+	"\rPOLICY_DENIED\x10\a\x12\x14\n" +
+	"\x10WORKFLOW_TIMEOUT\x10\b\x12\x12\n" +
+	"\x0eLIMIT_EXCEEDED\x10\t*\x84\x02\n" +
 	"\x10WorkflowTaskType\x12\"\n" +
 	"\x1eWORKFLOW_TASK_TYPE_UNSPECIFIED\x10\x00\x12\"\n" +
 	"\x1eWORKFLOW_TASK_AGENT_INVOCATION\x10\x01\x12\x1a\n" +
@@ -677,14 +935,21 @@ const file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDesc = "" +
 	"\x19WORKFLOW_TASK_CONDITIONAL\x10\x04\x12\x1a\n" +
 	"\x16WORKFLOW_TASK_PARALLEL\x10\x05\x12\x1b\n" +
 	"\x17WORKFLOW_TASK_TRANSFORM\x10\x06\x12\x18\n" +
-	"\x14WORKFLOW_TASK_CUSTOM\x10\a*\xc6\x01\n" +
+	"\x14WORKFLOW_TASK_CUSTOM\x10\a*\xeb\x01\n" +
 	"\x12WorkflowTaskStatus\x12$\n" +
 	" WORKFLOW_TASK_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15WORKFLOW_TASK_PENDING\x10\x01\x12\x1d\n" +
 	"\x19WORKFLOW_TASK_IN_PROGRESS\x10\x02\x12\x1b\n" +
 	"\x17WORKFLOW_TASK_COMPLETED\x10\x03\x12\x18\n" +
 	"\x14WORKFLOW_TASK_FAILED\x10\x04\x12\x19\n" +
-	"\x15WORKFLOW_TASK_SKIPPED\x10\x05B\xdf\x02\n" +
+	"\x15WORKFLOW_TASK_SKIPPED\x10\x05\x12#\n" +
+	"\x1fWORKFLOW_TASK_AWAITING_APPROVAL\x10\x06*\xc6\x01\n" +
+	"\x15WorkflowTaskEventType\x12(\n" +
+	"$WORKFLOW_TASK_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bWORKFLOW_TASK_EVENT_STARTED\x10\x01\x12!\n" +
+	"\x1dWORKFLOW_TASK_EVENT_COMPLETED\x10\x02\x12\x1e\n" +
+	"\x1aWORKFLOW_TASK_EVENT_FAILED\x10\x03\x12\x1f\n" +
+	"\x1bWORKFLOW_TASK_EVENT_RETRIED\x10\x04B\xdf\x02\n" +
 	"+com.ai.stigmer.agentic.workflowexecution.v1B\tEnumProtoP\x01Zdgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1;workflowexecutionv1\xa2\x02\x04ASAW\xaa\x02'Ai.Stigmer.Agentic.Workflowexecution.V1\xca\x02'Ai\\Stigmer\\Agentic\\Workflowexecution\\V1\xe2\x023Ai\\Stigmer\\Agentic\\Workflowexecution\\V1\\GPBMetadata\xea\x02+Ai::Stigmer::Agentic::Workflowexecution::V1b\x06proto3"
 
 var (
@@ -699,11 +964,14 @@ func file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDescGZIP() []byt
 	return file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDescData
 }
 
-var file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
 var file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_goTypes = []any{
-	(ExecutionPhase)(0),     // 0: ai.stigmer.agentic.workflowexecution.v1.ExecutionPhase
-	(WorkflowTaskType)(0),   // 1: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskType
-	(WorkflowTaskStatus)(0), // 2: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskStatus
+	(ExecutionPhase)(0),        // 0: ai.stigmer.agentic.workflowexecution.v1.ExecutionPhase
+	(ConcurrencyDecision)(0),   // 1: ai.stigmer.agentic.workflowexecution.v1.ConcurrencyDecision
+	(ErrorClassification)(0),   // 2: ai.stigmer.agentic.workflowexecution.v1.ErrorClassification
+	(WorkflowTaskType)(0),      // 3: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskType
+	(WorkflowTaskStatus)(0),    // 4: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskStatus
+	(WorkflowTaskEventType)(0), // 5: ai.stigmer.agentic.workflowexecution.v1.WorkflowTaskEventType
 }
 var file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -723,7 +991,7 @@ func file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDesc), len(file_ai_stigmer_agentic_workflowexecution_v1_enum_proto_rawDesc)),
-			NumEnums:      3,
+			NumEnums:      6,
 			NumMessages:   0,
 			NumExtensions: 0,
 			NumServices:   0,
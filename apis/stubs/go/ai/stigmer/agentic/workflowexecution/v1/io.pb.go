@@ -300,7 +300,7 @@ func (x *WorkflowId) GetValue() string {
 // 1. Client sends request with page_size (e.g., 50)
 // 2. Server returns up to page_size entries + total_pages count
 // 3. Client displays entries and "Page 1 of N" indicator
-// 4. Client can request next page using page_token from response
+// 4. Client can request next page using next_page_token from response
 //
 // Example Usage:
 // Response for page 1:
@@ -336,13 +336,15 @@ type WorkflowExecutionList struct {
 	//
 	// Example:
 	// entries: [
-	//
-	//	{ metadata: { id: "wfx-newest", created_at: "2025-01-11T14:30:22Z" }, ... },
-	//	{ metadata: { id: "wfx-second", created_at: "2025-01-11T10:15:00Z" }, ... },
-	//	{ metadata: { id: "wfx-third", created_at: "2025-01-10T22:05:33Z" }, ... }
-	//
+	//   { metadata: { id: "wfx-newest", created_at: "2025-01-11T14:30:22Z" }, ... },
+	//   { metadata: { id: "wfx-second", created_at: "2025-01-11T10:15:00Z" }, ... },
+	//   { metadata: { id: "wfx-third", created_at: "2025-01-10T22:05:33Z" }, ... }
 	// ]
-	Entries       []*WorkflowExecution `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	Entries []*WorkflowExecution `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Token for the next page, passed as page_token in the next request.
+	//
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -391,6 +393,13 @@ func (x *WorkflowExecutionList) GetEntries() []*WorkflowExecution {
 	return nil
 }
 
+func (x *WorkflowExecutionList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 // ListWorkflowExecutionsRequest specifies parameters for listing workflow executions.
 //
 // Supports pagination and filtering to help users find specific executions.
@@ -406,7 +415,8 @@ type ListWorkflowExecutionsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Maximum number of executions to return per page.
 	//
-	// Default: 50 (if not specified or 0)
+	// Default: all executions (if not specified or 0). Unpaginated lists are
+	// deprecated; set page_size.
 	// Maximum: 100 (backend enforces this limit)
 	//
 	// Recommendation: Use 20-50 for UI lists, 100 for bulk exports
@@ -419,16 +429,16 @@ type ListWorkflowExecutionsRequest struct {
 	//
 	// Pagination Flow:
 	// 1. Initial request: page_token is empty (or not set)
-	// 2. Server returns first page + page_token for next page
-	// 3. Subsequent request: include page_token from previous response
-	// 4. Server returns next page + new page_token
-	// 5. When page_token is empty in response, no more pages available
+	// 2. Server returns first page + next_page_token
+	// 3. Subsequent request: include next_page_token from previous response
+	// 4. Server returns next page + new next_page_token
+	// 5. When next_page_token is empty in response, no more pages available
 	//
 	// Example:
 	// Request 1: { page_size: 50 }
-	// Response 1: { entries: [...], page_token: "eyJvZmZzZXQiOjUwfQ==" }
-	// Request 2: { page_size: 50, page_token: "eyJvZmZzZXQiOjUwfQ==" }
-	// Response 2: { entries: [...], page_token: "" }  // Last page
+	// Response 1: { entries: [...], next_page_token: "eyJjIjoiMjAyNi0wMS0xMSJ9" }
+	// Request 2: { page_size: 50, page_token: "eyJjIjoiMjAyNi0wMS0xMSJ9" }
+	// Response 2: { entries: [...], next_page_token: "" }  // Last page
 	//
 	// Note: page_token is opaque - clients should not parse or modify it.
 	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
@@ -567,7 +577,8 @@ type ListWorkflowExecutionsByWorkflowRequest struct {
 	WorkflowId string `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
 	// Maximum number of executions to return per page.
 	//
-	// Default: 50 (if not specified or 0)
+	// Default: all executions (if not specified or 0). Unpaginated lists are
+	// deprecated; set page_size.
 	// Maximum: 100 (backend enforces this limit)
 	//
 	// Example: page_size=20 returns up to 20 executions
@@ -578,7 +589,7 @@ type ListWorkflowExecutionsByWorkflowRequest struct {
 	//
 	// Example:
 	// Request 1: { workflow_id: "wfi-prod", page_size: 50 }
-	// Response 1: { entries: [...], page_token: "abc123" }
+	// Response 1: { entries: [...], next_page_token: "abc123" }
 	// Request 2: { workflow_id: "wfi-prod", page_size: 50, page_token: "abc123" }
 	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -756,16 +767,15 @@ type WorkflowExecutionUpdate struct {
 	// Clients should merge this with cached state to get complete picture.
 	//
 	// Example (task completed update):
-	//
-	//	execution: {
-	//	  metadata: { id: "wfx-abc123" },
-	//	  status: {
-	//	    completed_tasks: 1,
-	//	    tasks: [
-	//	      { task_id: "task-1", status: WORKFLOW_TASK_COMPLETED, output: {...} }
-	//	    ]
-	//	  }
-	//	}
+	// execution: {
+	//   metadata: { id: "wfx-abc123" },
+	//   status: {
+	//     completed_tasks: 1,
+	//     tasks: [
+	//       { task_id: "task-1", status: WORKFLOW_TASK_COMPLETED, output: {...} }
+	//     ]
+	//   }
+	// }
 	Execution *WorkflowExecution `protobuf:"bytes,2,opt,name=execution,proto3" json:"execution,omitempty"`
 	// Updated task (if update_type is task-related).
 	//
@@ -777,14 +787,13 @@ type WorkflowExecutionUpdate struct {
 	// Provides direct access to the changed task without searching execution.status.tasks[].
 	//
 	// Example (task completed):
-	//
-	//	task: {
-	//	  task_id: "task-1",
-	//	  task_name: "validate_email",
-	//	  status: WORKFLOW_TASK_COMPLETED,
-	//	  output: { "valid": true },
-	//	  completed_at: "2025-01-11T14:30:27Z"
-	//	}
+	// task: {
+	//   task_id: "task-1",
+	//   task_name: "validate_email",
+	//   status: WORKFLOW_TASK_COMPLETED,
+	//   output: { "valid": true },
+	//   completed_at: "2025-01-11T14:30:27Z"
+	// }
 	Task          *WorkflowTask `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -850,11 +859,12 @@ const file_ai_stigmer_agentic_workflowexecution_v1_io_proto_rawDesc = "" +
 	"\x05value\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05value\"*\n" +
 	"\n" +
 	"WorkflowId\x12\x1c\n" +
-	"\x05value\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05value\"\x8e\x01\n" +
+	"\x05value\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05value\"\xb6\x01\n" +
 	"\x15WorkflowExecutionList\x12\x1f\n" +
 	"\vtotal_pages\x18\x01 \x01(\x05R\n" +
 	"totalPages\x12T\n" +
-	"\aentries\x18\x02 \x03(\v2:.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionR\aentries\"\xbe\x01\n" +
+	"\aentries\x18\x02 \x03(\v2:.ai.stigmer.agentic.workflowexecution.v1.WorkflowExecutionR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\xbe\x01\n" +
 	"\x1dListWorkflowExecutionsRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
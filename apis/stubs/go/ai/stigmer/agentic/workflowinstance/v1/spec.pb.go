@@ -10,6 +10,7 @@ package workflowinstancev1
 
 import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	v1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
 	apiresource "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -34,6 +35,7 @@ const (
 // - A reference to the Workflow template (the orchestration blueprint)
 // - Descriptive metadata for humans
 // - Environment references (layered configuration with secrets)
+// - An optional schedule overriding the workflow's
 //
 // Design Philosophy:
 // WorkflowInstanceSpec separates "what to run" (Workflow) from "how to run it" (Environments).
@@ -77,11 +79,10 @@ type WorkflowInstanceSpec struct {
 	// This enables a base + overrides pattern:
 	//
 	// Example layering:
-	//
-	//	[base-env, aws-prod-env, github-team-env]
-	//	└─ base-env: Common settings for all instances
-	//	└─ aws-prod-env: AWS production credentials (overrides base AWS settings)
-	//	└─ github-team-env: Team-specific GitHub tokens (overrides generic tokens)
+	//   [base-env, aws-prod-env, github-team-env]
+	//   └─ base-env: Common settings for all instances
+	//   └─ aws-prod-env: AWS production credentials (overrides base AWS settings)
+	//   └─ github-team-env: Team-specific GitHub tokens (overrides generic tokens)
 	//
 	// Use Cases:
 	// - Single env: [prod-env] - Simple, all config in one place
@@ -94,7 +95,13 @@ type WorkflowInstanceSpec struct {
 	//
 	// At execution time, the WorkflowExecution runtime merges these environments
 	// and provides the combined configuration to all agents in the workflow.
-	EnvRefs       []*apiresource.ApiResourceReference `protobuf:"bytes,3,rep,name=env_refs,json=envRefs,proto3" json:"env_refs,omitempty"`
+	EnvRefs []*apiresource.ApiResourceReference `protobuf:"bytes,3,rep,name=env_refs,json=envRefs,proto3" json:"env_refs,omitempty"`
+	// Cron schedule for this instance, overriding the workflow's spec.schedule.
+	//
+	// Lets instances of one workflow run on different schedules (e.g. prod
+	// nightly, staging hourly). When unset, the instance runs on the
+	// workflow's schedule, if any.
+	Schedule      *v1.WorkflowSchedule `protobuf:"bytes,4,opt,name=schedule,proto3" json:"schedule,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -150,16 +157,24 @@ func (x *WorkflowInstanceSpec) GetEnvRefs() []*apiresource.ApiResourceReference
 	return nil
 }
 
+func (x *WorkflowInstanceSpec) GetSchedule() *v1.WorkflowSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
 var File_ai_stigmer_agentic_workflowinstance_v1_spec_proto protoreflect.FileDescriptor
 
 const file_ai_stigmer_agentic_workflowinstance_v1_spec_proto_rawDesc = "" +
 	"\n" +
-	"1ai/stigmer/agentic/workflowinstance/v1/spec.proto\x12&ai.stigmer.agentic.workflowinstance.v1\x1a'ai/stigmer/commons/apiresource/io.proto\x1a\x1bbuf/validate/validate.proto\"\xb3\x01\n" +
+	"1ai/stigmer/agentic/workflowinstance/v1/spec.proto\x12&ai.stigmer.agentic.workflowinstance.v1\x1a)ai/stigmer/agentic/workflow/v1/spec.proto\x1a'ai/stigmer/commons/apiresource/io.proto\x1a\x1bbuf/validate/validate.proto\"\x81\x02\n" +
 	"\x14WorkflowInstanceSpec\x12(\n" +
 	"\vworkflow_id\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
 	"workflowId\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12O\n" +
-	"\benv_refs\x18\x03 \x03(\v24.ai.stigmer.commons.apiresource.ApiResourceReferenceR\aenvRefsB\xd8\x02\n" +
+	"\benv_refs\x18\x03 \x03(\v24.ai.stigmer.commons.apiresource.ApiResourceReferenceR\aenvRefs\x12L\n" +
+	"\bschedule\x18\x04 \x01(\v20.ai.stigmer.agentic.workflow.v1.WorkflowScheduleR\bscheduleB\xd8\x02\n" +
 	"*com.ai.stigmer.agentic.workflowinstance.v1B\tSpecProtoP\x01Zbgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1;workflowinstancev1\xa2\x02\x04ASAW\xaa\x02&Ai.Stigmer.Agentic.Workflowinstance.V1\xca\x02&Ai\\Stigmer\\Agentic\\Workflowinstance\\V1\xe2\x022Ai\\Stigmer\\Agentic\\Workflowinstance\\V1\\GPBMetadata\xea\x02*Ai::Stigmer::Agentic::Workflowinstance::V1b\x06proto3"
 
 var (
@@ -178,14 +193,16 @@ var file_ai_stigmer_agentic_workflowinstance_v1_spec_proto_msgTypes = make([]pro
 var file_ai_stigmer_agentic_workflowinstance_v1_spec_proto_goTypes = []any{
 	(*WorkflowInstanceSpec)(nil),             // 0: ai.stigmer.agentic.workflowinstance.v1.WorkflowInstanceSpec
 	(*apiresource.ApiResourceReference)(nil), // 1: ai.stigmer.commons.apiresource.ApiResourceReference
+	(*v1.WorkflowSchedule)(nil),              // 2: ai.stigmer.agentic.workflow.v1.WorkflowSchedule
 }
 var file_ai_stigmer_agentic_workflowinstance_v1_spec_proto_depIdxs = []int32{
 	1, // 0: ai.stigmer.agentic.workflowinstance.v1.WorkflowInstanceSpec.env_refs:type_name -> ai.stigmer.commons.apiresource.ApiResourceReference
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 1: ai.stigmer.agentic.workflowinstance.v1.WorkflowInstanceSpec.schedule:type_name -> ai.stigmer.agentic.workflow.v1.WorkflowSchedule
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_agentic_workflowinstance_v1_spec_proto_init() }
@@ -33,7 +33,7 @@ const (
 	ApiResourceEventType_updated     ApiResourceEventType = 2
 	ApiResourceEventType_deleted     ApiResourceEventType = 3
 	ApiResourceEventType_renamed     ApiResourceEventType = 4
-	// this is only applicable for cloud-resources
+	//this is only applicable for cloud-resources
 	ApiResourceEventType_stack_outputs_updated ApiResourceEventType = 5
 )
 
@@ -233,7 +233,7 @@ const (
 	WorkflowTaskKind_WORKFLOW_TASK_KIND_UNSPECIFIED WorkflowTaskKind = 0
 	// SET: Set variables in workflow state.
 	WorkflowTaskKind_WORKFLOW_TASK_KIND_SET WorkflowTaskKind = 1
-	// HTTP_CALL: Make HTTP requests (GET, POST, PUT, DELETE, PATCH).
+	// HTTP_CALL: Make HTTP requests (GET, POST, PUT, DELETE, PATCH, HEAD, OPTIONS).
 	WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL WorkflowTaskKind = 2
 	// GRPC_CALL: Make gRPC requests.
 	WorkflowTaskKind_WORKFLOW_TASK_KIND_GRPC_CALL WorkflowTaskKind = 3
@@ -258,6 +258,10 @@ const (
 	// AGENT_CALL: Invoke AI agents as tasks.
 	// Allows workflows to delegate complex operations to specialized agents.
 	WorkflowTaskKind_WORKFLOW_TASK_KIND_AGENT_CALL WorkflowTaskKind = 13
+	// CUSTOM: Task kind contributed by a runner extension.
+	// The extension's kind name is carried in WorkflowTask.custom_kind and its
+	// task_config is passed to the extension unchanged.
+	WorkflowTaskKind_WORKFLOW_TASK_KIND_CUSTOM WorkflowTaskKind = 14
 )
 
 // Enum value maps for WorkflowTaskKind.
@@ -277,6 +281,7 @@ var (
 		11: "WORKFLOW_TASK_KIND_RAISE",
 		12: "WORKFLOW_TASK_KIND_RUN",
 		13: "WORKFLOW_TASK_KIND_AGENT_CALL",
+		14: "WORKFLOW_TASK_KIND_CUSTOM",
 	}
 	WorkflowTaskKind_value = map[string]int32{
 		"WORKFLOW_TASK_KIND_UNSPECIFIED":   0,
@@ -293,6 +298,7 @@ var (
 		"WORKFLOW_TASK_KIND_RAISE":         11,
 		"WORKFLOW_TASK_KIND_RUN":           12,
 		"WORKFLOW_TASK_KIND_AGENT_CALL":    13,
+		"WORKFLOW_TASK_KIND_CUSTOM":        14,
 	}
 )
 
@@ -350,7 +356,7 @@ const file_ai_stigmer_commons_apiresource_enum_proto_rawDesc = "" +
 	"$api_resource_owner_scope_unspecified\x10\x00\x12\f\n" +
 	"\bplatform\x10\x01\x12\x10\n" +
 	"\forganization\x10\x02\x12\x14\n" +
-	"\x10identity_account\x10\x03*\xc9\x03\n" +
+	"\x10identity_account\x10\x03*\xe8\x03\n" +
 	"\x10WorkflowTaskKind\x12\"\n" +
 	"\x1eWORKFLOW_TASK_KIND_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16WORKFLOW_TASK_KIND_SET\x10\x01\x12 \n" +
@@ -366,7 +372,8 @@ const file_ai_stigmer_commons_apiresource_enum_proto_rawDesc = "" +
 	"\x12\x1c\n" +
 	"\x18WORKFLOW_TASK_KIND_RAISE\x10\v\x12\x1a\n" +
 	"\x16WORKFLOW_TASK_KIND_RUN\x10\f\x12!\n" +
-	"\x1dWORKFLOW_TASK_KIND_AGENT_CALL\x10\rB\x94\x02\n" +
+	"\x1dWORKFLOW_TASK_KIND_AGENT_CALL\x10\r\x12\x1d\n" +
+	"\x19WORKFLOW_TASK_KIND_CUSTOM\x10\x0eB\x94\x02\n" +
 	"\"com.ai.stigmer.commons.apiresourceB\tEnumProtoP\x01ZGgithub.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource\xa2\x02\x04ASCA\xaa\x02\x1eAi.Stigmer.Commons.Apiresource\xca\x02\x1eAi\\Stigmer\\Commons\\Apiresource\xe2\x02*Ai\\Stigmer\\Commons\\Apiresource\\GPBMetadata\xea\x02!Ai::Stigmer::Commons::Apiresourceb\x06proto3"
 
 var (
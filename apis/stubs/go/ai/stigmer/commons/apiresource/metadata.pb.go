@@ -48,9 +48,20 @@ type ApiResourceMetadata struct {
 	// Tags for categorization.
 	Tags []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
 	// Version information for the resource.
-	Version       *ApiResourceMetadataVersion `protobuf:"bytes,9,opt,name=version,proto3" json:"version,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Version *ApiResourceMetadataVersion `protobuf:"bytes,9,opt,name=version,proto3" json:"version,omitempty"`
+	// Resources that must exist before this one can be created, such as the
+	// agents a workflow calls or the skills an agent uses.
+	// Recorded by the SDK at synthesis so deploy tooling can order creation;
+	// the server does not resolve or enforce them.
+	Dependencies []*ApiResourceReference `protobuf:"bytes,10,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	// Server-managed version of the stored resource, incremented on every
+	// write (1 after create). Query responses carry the current value.
+	// Update requests must send the version they were based on; the server
+	// rejects stale or missing versions with FAILED_PRECONDITION so concurrent
+	// updates cannot silently overwrite each other.
+	ResourceVersion int64 `protobuf:"varint,11,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ApiResourceMetadata) Reset() {
@@ -146,6 +157,20 @@ func (x *ApiResourceMetadata) GetVersion() *ApiResourceMetadataVersion {
 	return nil
 }
 
+func (x *ApiResourceMetadata) GetDependencies() []*ApiResourceReference {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *ApiResourceMetadata) GetResourceVersion() int64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
 // ApiResourceMetadataVersion contains version tracking information.
 type ApiResourceMetadataVersion struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -214,7 +239,7 @@ var File_ai_stigmer_commons_apiresource_metadata_proto protoreflect.FileDescript
 
 const file_ai_stigmer_commons_apiresource_metadata_proto_rawDesc = "" +
 	"\n" +
-	"-ai/stigmer/commons/apiresource/metadata.proto\x12\x1eai.stigmer.commons.apiresource\x1a)ai/stigmer/commons/apiresource/enum.proto\x1a\x1bbuf/validate/validate.proto\"\xe7\x04\n" +
+	"-ai/stigmer/commons/apiresource/metadata.proto\x12\x1eai.stigmer.commons.apiresource\x1a)ai/stigmer/commons/apiresource/enum.proto\x1a'ai/stigmer/commons/apiresource/io.proto\x1a\x1bbuf/validate/validate.proto\"\xec\x05\n" +
 	"\x13ApiResourceMetadata\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x0e\n" +
@@ -225,7 +250,10 @@ const file_ai_stigmer_commons_apiresource_metadata_proto_rawDesc = "" +
 	"\x06labels\x18\x06 \x03(\v2?.ai.stigmer.commons.apiresource.ApiResourceMetadata.LabelsEntryR\x06labels\x12f\n" +
 	"\vannotations\x18\a \x03(\v2D.ai.stigmer.commons.apiresource.ApiResourceMetadata.AnnotationsEntryR\vannotations\x12\x12\n" +
 	"\x04tags\x18\b \x03(\tR\x04tags\x12T\n" +
-	"\aversion\x18\t \x01(\v2:.ai.stigmer.commons.apiresource.ApiResourceMetadataVersionR\aversion\x1a9\n" +
+	"\aversion\x18\t \x01(\v2:.ai.stigmer.commons.apiresource.ApiResourceMetadataVersionR\aversion\x12X\n" +
+	"\fdependencies\x18\n" +
+	" \x03(\v24.ai.stigmer.commons.apiresource.ApiResourceReferenceR\fdependencies\x12)\n" +
+	"\x10resource_version\x18\v \x01(\x03R\x0fresourceVersion\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
@@ -257,17 +285,19 @@ var file_ai_stigmer_commons_apiresource_metadata_proto_goTypes = []any{
 	nil,                                // 2: ai.stigmer.commons.apiresource.ApiResourceMetadata.LabelsEntry
 	nil,                                // 3: ai.stigmer.commons.apiresource.ApiResourceMetadata.AnnotationsEntry
 	(ApiResourceOwnerScope)(0),         // 4: ai.stigmer.commons.apiresource.ApiResourceOwnerScope
+	(*ApiResourceReference)(nil),       // 5: ai.stigmer.commons.apiresource.ApiResourceReference
 }
 var file_ai_stigmer_commons_apiresource_metadata_proto_depIdxs = []int32{
 	4, // 0: ai.stigmer.commons.apiresource.ApiResourceMetadata.owner_scope:type_name -> ai.stigmer.commons.apiresource.ApiResourceOwnerScope
 	2, // 1: ai.stigmer.commons.apiresource.ApiResourceMetadata.labels:type_name -> ai.stigmer.commons.apiresource.ApiResourceMetadata.LabelsEntry
 	3, // 2: ai.stigmer.commons.apiresource.ApiResourceMetadata.annotations:type_name -> ai.stigmer.commons.apiresource.ApiResourceMetadata.AnnotationsEntry
 	1, // 3: ai.stigmer.commons.apiresource.ApiResourceMetadata.version:type_name -> ai.stigmer.commons.apiresource.ApiResourceMetadataVersion
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5, // 4: ai.stigmer.commons.apiresource.ApiResourceMetadata.dependencies:type_name -> ai.stigmer.commons.apiresource.ApiResourceReference
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_ai_stigmer_commons_apiresource_metadata_proto_init() }
@@ -276,6 +306,7 @@ func file_ai_stigmer_commons_apiresource_metadata_proto_init() {
 		return
 	}
 	file_ai_stigmer_commons_apiresource_enum_proto_init()
+	file_ai_stigmer_commons_apiresource_io_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
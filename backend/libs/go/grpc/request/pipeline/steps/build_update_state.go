@@ -101,6 +101,8 @@ func (s *BuildUpdateStateStep[T]) Execute(ctx *pipeline.RequestContext[T]) error
 // - metadata.slug (URL-safe identifier - cannot be changed once set)
 // - metadata.org (organization - cannot be changed once set)
 //
+// metadata.resource_version is set to the existing version plus one.
+//
 // Mutable fields (NOT preserved, can be updated):
 // - metadata.name (display name - CAN be changed)
 // - metadata.title, description, labels, tags, etc.
@@ -128,6 +130,9 @@ func preserveImmutableFields[T proto.Message](merged, existing T) error {
 	mergedMeta.Slug = existingMeta.Slug // Slug (immutable, derived from original name)
 	mergedMeta.Org = existingMeta.Org   // Organization (immutable)
 
+	// Server-managed version, bumped on every write
+	mergedMeta.ResourceVersion = existingMeta.ResourceVersion + 1
+
 	// Note: metadata.name is NOT preserved - it can be updated by the client!
 	// Other metadata fields (title, description, labels, tags) are also mutable
 
@@ -22,7 +22,7 @@ import (
 //  1. Clear status field (status is system-managed, not client-modifiable)
 //  2. Clear computed fields (TODO: when needed)
 //  3. Set metadata.id: Generated from kind prefix + ULID (if not set)
-//  4. Set version (TODO: when versioning is implemented)
+//  4. Set metadata.resource_version to 1
 //  5. Set audit fields in status.audit:
 //     - created_by (actor)
 //     - created_at (timestamp)
@@ -93,7 +93,8 @@ func (s *BuildNewStateStep[T]) Execute(ctx *pipeline.RequestContext[T]) error {
 		metadata.Id = generateID(idPrefix)
 	}
 
-	// 4. TODO: Set version (when versioning is implemented)
+	// 4. Set resource version (first write)
+	metadata.ResourceVersion = 1
 
 	// 5. Set audit fields in status using proto reflection
 	if hasStatusField(resource) {
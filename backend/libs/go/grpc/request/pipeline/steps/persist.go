@@ -2,6 +2,7 @@ package steps
 
 import (
 	"fmt"
+	"sync"
 
 	apiresourceinterceptor "github.com/stigmer/stigmer/backend/libs/go/grpc/interceptors/apiresource"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
@@ -9,6 +10,10 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// persistMu serializes the version check and write of versioned updates, so
+// two updates based on the same resource version cannot both be persisted.
+var persistMu sync.Mutex
+
 // PersistStep saves a resource to the database
 //
 // This step calls store.SaveResource() to persist the resource.
@@ -16,6 +21,10 @@ import (
 //   - metadata.id must be set
 //   - api_resource_kind is extracted from request context (injected by interceptor)
 //
+// When CheckResourceVersionStep ran, the stored resource version is checked
+// again right before the write, under a lock, and a concurrent update that
+// got there first fails the request with FAILED_PRECONDITION.
+//
 // The step uses the configured store (BadgerDB, etc.) to save the resource.
 type PersistStep[T proto.Message] struct {
 	store store.Store
@@ -62,6 +71,19 @@ func (s *PersistStep[T]) Execute(ctx *pipeline.RequestContext[T]) error {
 	// Get api_resource_kind from request context (injected by interceptor)
 	kind := apiresourceinterceptor.GetApiResourceKind(ctx.Context())
 
+	if expected, ok := ctx.Get(ExpectedResourceVersionKey).(int64); ok {
+		persistMu.Lock()
+		defer persistMu.Unlock()
+
+		stored := resource.ProtoReflect().New().Interface()
+		if err := s.store.GetResource(ctx.Context(), kind, metadata.Id, stored); err != nil {
+			return fmt.Errorf("failed to load resource version from store: %w", err)
+		}
+		if current := stored.(HasMetadata).GetMetadata().GetResourceVersion(); current != expected {
+			return ResourceVersionConflictError(kind, metadata.Id, expected, current)
+		}
+	}
+
 	// Save to database
 	// Use the context from the pipeline context
 	err := s.store.SaveResource(ctx.Context(), kind, metadata.Id, resource)
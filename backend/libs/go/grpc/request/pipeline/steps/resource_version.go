@@ -0,0 +1,83 @@
+package steps
+
+import (
+	"fmt"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/backend/libs/go/apiresource"
+	apiresourceinterceptor "github.com/stigmer/stigmer/backend/libs/go/grpc/interceptors/apiresource"
+	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Context key for the resource version an update was based on
+const ExpectedResourceVersionKey = "expectedResourceVersion"
+
+// CheckResourceVersionStep rejects updates based on a stale resource version
+//
+// This step:
+//  1. Loads the existing resource from context (set by LoadExistingStep)
+//  2. Compares the input metadata.resource_version with the stored one
+//  3. Returns FAILED_PRECONDITION with the current version when they differ,
+//     including when the input does not carry a version
+//  4. Stores the expected version in context so PersistStep can re-check it
+//     atomically before writing
+//
+// Resources stored before versioning have version 0 and accept updates
+// without a version until their first versioned write.
+type CheckResourceVersionStep[T proto.Message] struct{}
+
+// NewCheckResourceVersionStep creates a new CheckResourceVersionStep
+func NewCheckResourceVersionStep[T proto.Message]() *CheckResourceVersionStep[T] {
+	return &CheckResourceVersionStep[T]{}
+}
+
+// Name returns the step name
+func (s *CheckResourceVersionStep[T]) Name() string {
+	return "CheckResourceVersion"
+}
+
+// Execute compares the requested resource version with the stored one
+func (s *CheckResourceVersionStep[T]) Execute(ctx *pipeline.RequestContext[T]) error {
+	existingVal := ctx.Get(ExistingResourceKey)
+	if existingVal == nil {
+		return fmt.Errorf("existing resource not found in context - LoadExistingStep must run first")
+	}
+
+	existing, ok := existingVal.(HasMetadata)
+	if !ok {
+		return fmt.Errorf("existing resource does not implement HasMetadata interface")
+	}
+
+	input, ok := any(ctx.Input()).(HasMetadata)
+	if !ok {
+		return fmt.Errorf("resource does not implement HasMetadata interface")
+	}
+
+	kind := apiresourceinterceptor.GetApiResourceKind(ctx.Context())
+	requested := input.GetMetadata().GetResourceVersion()
+	current := existing.GetMetadata().GetResourceVersion()
+	if requested != current {
+		return ResourceVersionConflictError(kind, existing.GetMetadata().GetId(), requested, current)
+	}
+
+	ctx.Set(ExpectedResourceVersionKey, current)
+	return nil
+}
+
+// ResourceVersionConflictError returns a gRPC FAILED_PRECONDITION error for
+// an update based on a stale (or missing) resource version. The message
+// names the current version so clients can tell what changed underneath.
+func ResourceVersionConflictError(kind apiresourcekind.ApiResourceKind, id string, requested, current int64) error {
+	kindName, _ := apiresource.GetKindName(kind)
+	if requested == 0 {
+		return status.Errorf(codes.FailedPrecondition,
+			"%s %s: resource_version is required for update (current resource_version is %d)",
+			kindName, id, current)
+	}
+	return status.Errorf(codes.FailedPrecondition,
+		"%s %s was modified: update is based on resource_version %d but the current resource_version is %d",
+		kindName, id, requested, current)
+}
@@ -0,0 +1,177 @@
+package steps
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// runVersionedUpdate runs the update steps that deal with resource versions
+// for an agent update based on the given version.
+func runVersionedUpdate(t *testing.T, s store.Store, version int64, description string) (*agentv1.Agent, error) {
+	t.Helper()
+
+	input := &agentv1.Agent{
+		Metadata: &apiresource.ApiResourceMetadata{
+			Id:              "agent-123",
+			Name:            "versioned-agent",
+			ResourceVersion: version,
+		},
+		Spec: &agentv1.AgentSpec{Description: description},
+	}
+
+	ctx := pipeline.NewRequestContext(contextWithKind(apiresourcekind.ApiResourceKind_agent), input)
+	p := pipeline.NewPipeline[*agentv1.Agent]("agent-update").
+		AddStep(NewLoadExistingStep[*agentv1.Agent](s)).
+		AddStep(NewCheckResourceVersionStep[*agentv1.Agent]()).
+		AddStep(NewBuildUpdateStateStep[*agentv1.Agent]()).
+		AddStep(NewPersistStep[*agentv1.Agent](s)).
+		Build()
+	if err := p.Execute(ctx); err != nil {
+		return nil, err
+	}
+	return ctx.NewState(), nil
+}
+
+func saveVersionedAgent(t *testing.T, s store.Store, version int64) {
+	t.Helper()
+
+	agent := &agentv1.Agent{
+		Metadata: &apiresource.ApiResourceMetadata{
+			Id:              "agent-123",
+			Name:            "versioned-agent",
+			ResourceVersion: version,
+		},
+		Spec: &agentv1.AgentSpec{Description: "original"},
+	}
+	if err := s.SaveResource(context.Background(), apiresourcekind.ApiResourceKind_agent, "agent-123", agent); err != nil {
+		t.Fatalf("Failed to save test agent: %v", err)
+	}
+}
+
+func TestCheckResourceVersionStep_MatchingVersion(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	saveVersionedAgent(t, s, 3)
+
+	updated, err := runVersionedUpdate(t, s, 3, "updated")
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if updated.Metadata.ResourceVersion != 4 {
+		t.Errorf("Expected resource_version=4, got %d", updated.Metadata.ResourceVersion)
+	}
+
+	stored := &agentv1.Agent{}
+	if err := s.GetResource(context.Background(), apiresourcekind.ApiResourceKind_agent, "agent-123", stored); err != nil {
+		t.Fatalf("Failed to load agent: %v", err)
+	}
+	if stored.Metadata.ResourceVersion != 4 || stored.Spec.Description != "updated" {
+		t.Errorf("Expected stored version 4 with updated spec, got version %d and description %q",
+			stored.Metadata.ResourceVersion, stored.Spec.Description)
+	}
+}
+
+func TestCheckResourceVersionStep_Rejected(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     int64
+		wantMessage string
+	}{
+		{"stale version", 2, "based on resource_version 2 but the current resource_version is 3"},
+		{"newer version", 4, "based on resource_version 4 but the current resource_version is 3"},
+		{"missing version", 0, "resource_version is required for update (current resource_version is 3)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := setupTestStore(t)
+			defer s.Close()
+			saveVersionedAgent(t, s, 3)
+
+			_, err := runVersionedUpdate(t, s, tt.version, "updated")
+			if status.Code(err) != codes.FailedPrecondition {
+				t.Fatalf("Expected FailedPrecondition, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMessage) {
+				t.Errorf("Expected error to contain %q, got %q", tt.wantMessage, err.Error())
+			}
+
+			stored := &agentv1.Agent{}
+			if err := s.GetResource(context.Background(), apiresourcekind.ApiResourceKind_agent, "agent-123", stored); err != nil {
+				t.Fatalf("Failed to load agent: %v", err)
+			}
+			if stored.Spec.Description != "original" {
+				t.Errorf("Expected stale update not to be persisted, got description %q", stored.Spec.Description)
+			}
+		})
+	}
+}
+
+func TestCheckResourceVersionStep_UnversionedResource(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	// Stored before resource versions existed
+	saveVersionedAgent(t, s, 0)
+
+	updated, err := runVersionedUpdate(t, s, 0, "updated")
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if updated.Metadata.ResourceVersion != 1 {
+		t.Errorf("Expected resource_version=1, got %d", updated.Metadata.ResourceVersion)
+	}
+}
+
+func TestPersistStep_ConcurrentVersionedUpdates(t *testing.T) {
+	s := setupTestStore(t)
+	defer s.Close()
+	saveVersionedAgent(t, s, 3)
+
+	// Both updates load and check version 3 before either is persisted
+	newContext := func(description string) *pipeline.RequestContext[*agentv1.Agent] {
+		input := &agentv1.Agent{
+			Metadata: &apiresource.ApiResourceMetadata{Id: "agent-123", Name: "versioned-agent", ResourceVersion: 3},
+			Spec:     &agentv1.AgentSpec{Description: description},
+		}
+		ctx := pipeline.NewRequestContext(contextWithKind(apiresourcekind.ApiResourceKind_agent), input)
+		for _, step := range []pipeline.PipelineStep[*agentv1.Agent]{
+			NewLoadExistingStep[*agentv1.Agent](s),
+			NewCheckResourceVersionStep[*agentv1.Agent](),
+			NewBuildUpdateStateStep[*agentv1.Agent](),
+		} {
+			if err := step.Execute(ctx); err != nil {
+				t.Fatalf("%s failed: %v", step.Name(), err)
+			}
+		}
+		return ctx
+	}
+	first := newContext("first")
+	second := newContext("second")
+
+	persist := NewPersistStep[*agentv1.Agent](s)
+	if err := persist.Execute(first); err != nil {
+		t.Fatalf("Expected first update to succeed, got error: %v", err)
+	}
+	err := persist.Execute(second)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected FailedPrecondition for the second update, got %v", err)
+	}
+
+	stored := &agentv1.Agent{}
+	if err := s.GetResource(context.Background(), apiresourcekind.ApiResourceKind_agent, "agent-123", stored); err != nil {
+		t.Fatalf("Failed to load agent: %v", err)
+	}
+	if stored.Spec.Description != "first" || stored.Metadata.ResourceVersion != 4 {
+		t.Errorf("Expected first update at version 4, got description %q at version %d",
+			stored.Spec.Description, stored.Metadata.ResourceVersion)
+	}
+}
@@ -19,6 +19,49 @@ var ErrNotFound = errors.New("resource not found")
 // Consumers should use errors.Is(err, store.ErrAuditNotFound) for checking.
 var ErrAuditNotFound = errors.New("audit record not found")
 
+// ErrInvalidPageToken is returned when a page token cannot be decoded.
+// Consumers should use errors.Is(err, store.ErrInvalidPageToken) for checking.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ListOptions configures a paginated list of resources.
+type ListOptions struct {
+	// PageSize is the maximum number of resources in the page (must be positive).
+	PageSize int
+
+	// PageToken resumes the list after the last resource of a previous page.
+	// Empty for the first page. Tokens are opaque to callers.
+	PageToken string
+
+	// IDPrefix restricts the list to resources whose ID starts with the prefix.
+	IDPrefix string
+
+	// Labels restricts the list to resources whose metadata.labels contain
+	// every key=value pair (exact match). Implementations resolve labels from
+	// an index rather than decoding each resource.
+	Labels map[string]string
+
+	// Descending lists newest resources first.
+	Descending bool
+
+	// Filter, if set, skips resources for which it returns false. It receives
+	// the marshaled protobuf bytes of each resource. Counting the matches for
+	// TotalCount runs it on every resource selected by the other options, not
+	// only those of the page.
+	Filter func(data []byte) bool
+}
+
+// ListPage is one page of a paginated list of resources.
+type ListPage struct {
+	// Items holds the marshaled protobuf bytes of each resource in the page.
+	Items [][]byte
+
+	// NextPageToken resumes the list after this page. Empty on the last page.
+	NextPageToken string
+
+	// TotalCount is the number of resources matching the options across all pages.
+	TotalCount int
+}
+
 // Store defines the contract for resource persistence.
 // All storage implementations (SQLite, memory) must satisfy this interface.
 //
@@ -62,6 +105,16 @@ type Store interface {
 	// Returns: slice of marshaled protobuf bytes (one per resource)
 	ListResources(ctx context.Context, kind apiresourcekind.ApiResourceKind) ([][]byte, error)
 
+	// ListResourcesPage retrieves one page of resources of a given kind.
+	// Resources are ordered by creation time, then ID, so pages are stable
+	// while resources are added. Returns ErrInvalidPageToken if the page
+	// token cannot be decoded.
+	//
+	// Parameters:
+	//   - kind: resource kind enum (e.g., ApiResourceKind_workflow_execution)
+	//   - opts: page size, page token, ID prefix, order and filter
+	ListResourcesPage(ctx context.Context, kind apiresourcekind.ApiResourceKind, opts ListOptions) (*ListPage, error)
+
 	// DeleteResource removes a resource by kind and ID.
 	// Returns nil (no error) if the resource does not exist.
 	//
@@ -6,14 +6,20 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
 	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 
 	// Pure Go SQLite driver - no CGO required
@@ -26,11 +32,19 @@ const (
 	schemaVersion1 = 1
 	// schemaVersion2: Separate audit table with foreign keys for proper relational design
 	schemaVersion2 = 2
+	// schemaVersion3: created_at column for stable paginated lists
+	schemaVersion3 = 3
+	// schemaVersion4: resource_labels index for label selector lists
+	schemaVersion4 = 4
 
 	// currentSchemaVersion is the target version for new databases
-	currentSchemaVersion = schemaVersion2
+	currentSchemaVersion = schemaVersion4
 )
 
+// metadataFieldNumber is the field number of metadata in every API resource
+// message (api_version = 1, kind = 2, metadata = 3).
+const metadataFieldNumber = 3
+
 // Store implements store.Store using SQLite as the backing storage.
 // It uses a single table with (kind, id) as the composite primary key,
 // storing protobuf-serialized data as BLOBs.
@@ -126,6 +140,18 @@ func runMigrations(db *sql.DB) error {
 		}
 	}
 
+	if currentVersion < schemaVersion3 {
+		if err := migrateToV3(db); err != nil {
+			return fmt.Errorf("migrate to v3: %w", err)
+		}
+	}
+
+	if currentVersion < schemaVersion4 {
+		if err := migrateToV4(db); err != nil {
+			return fmt.Errorf("migrate to v4: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -316,6 +342,142 @@ func migrateAuditRecords(tx *sql.Tx) error {
 	return nil
 }
 
+// migrateToV3 adds the created_at column used to order paginated lists.
+// Existing resources are backfilled with their last update time, the best
+// creation time available, written with the millisecond precision of new
+// rows so both compare as text in the same format.
+func migrateToV3(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	schema := `
+		ALTER TABLE resources ADD COLUMN created_at TEXT NOT NULL DEFAULT '';
+
+		UPDATE resources SET created_at = strftime('%Y-%m-%d %H:%M:%f', updated_at);
+
+		CREATE INDEX IF NOT EXISTS idx_resources_kind_created ON resources(kind, created_at, id);
+	`
+
+	if _, err := tx.Exec(schema); err != nil {
+		return fmt.Errorf("add created_at column: %w", err)
+	}
+
+	if err := setSchemaVersion(tx, schemaVersion3); err != nil {
+		return fmt.Errorf("set schema version: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// migrateToV4 adds the resource_labels table indexing metadata.labels, so
+// lists filtered by label selector do not decode every resource. Existing
+// resources are backfilled from their stored data.
+func migrateToV4(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	schema := `
+		CREATE TABLE IF NOT EXISTS resource_labels (
+			kind TEXT NOT NULL,
+			resource_id TEXT NOT NULL,
+			label_key TEXT NOT NULL,
+			label_value TEXT NOT NULL,
+			PRIMARY KEY (kind, resource_id, label_key)
+		) WITHOUT ROWID;
+
+		-- Index for label selector lookups (ListResourcesPage with Labels)
+		CREATE INDEX IF NOT EXISTS idx_labels_kind_key_value ON resource_labels(kind, label_key, label_value);
+
+		-- Labels are removed with their resource, whichever delete method is used
+		CREATE TRIGGER IF NOT EXISTS trg_resources_delete_labels AFTER DELETE ON resources
+		BEGIN
+			DELETE FROM resource_labels WHERE kind = OLD.kind AND resource_id = OLD.id;
+		END;
+	`
+
+	if _, err := tx.Exec(schema); err != nil {
+		return fmt.Errorf("create resource_labels table: %w", err)
+	}
+
+	rows, err := tx.Query(`SELECT kind, id, data FROM resources`)
+	if err != nil {
+		return fmt.Errorf("query resources: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var kind, id string
+		var data []byte
+		if err := rows.Scan(&kind, &id, &data); err != nil {
+			return fmt.Errorf("scan row: %w", err)
+		}
+		if err := saveResourceLabels(context.Background(), tx, kind, id, resourceLabels(data)); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate rows: %w", err)
+	}
+
+	if err := setSchemaVersion(tx, schemaVersion4); err != nil {
+		return fmt.Errorf("set schema version: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// resourceLabels returns metadata.labels of a marshaled API resource.
+// Only the metadata field is decoded; messages without metadata have no labels.
+func resourceLabels(data []byte) map[string]string {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil
+		}
+		data = data[n:]
+
+		n = protowire.ConsumeFieldValue(num, typ, data)
+		if n < 0 {
+			return nil
+		}
+		if num == metadataFieldNumber && typ == protowire.BytesType {
+			value, _ := protowire.ConsumeBytes(data)
+			metadata := &apiresource.ApiResourceMetadata{}
+			if err := proto.Unmarshal(value, metadata); err != nil {
+				return nil
+			}
+			return metadata.GetLabels()
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// saveResourceLabels replaces the indexed labels of a resource.
+func saveResourceLabels(ctx context.Context, tx *sql.Tx, kind, id string, labels map[string]string) error {
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM resource_labels WHERE kind = ? AND resource_id = ?`,
+		kind, id); err != nil {
+		return fmt.Errorf("delete resource labels: %w", err)
+	}
+
+	for key, value := range labels {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO resource_labels (kind, resource_id, label_key, label_value) VALUES (?, ?, ?, ?)`,
+			kind, id, key, value); err != nil {
+			return fmt.Errorf("save resource labels: %w", err)
+		}
+	}
+	return nil
+}
+
 // parseAuditRecord extracts resource ID and metadata from a legacy audit record.
 // Legacy format: "<type>_audit/<resource_id>/<timestamp>"
 // Returns resourceID, versionHash, tag (versionHash and tag are extracted from proto if possible)
@@ -342,7 +504,8 @@ func parseAuditRecord(id string, data []byte) (resourceID, versionHash, tag stri
 }
 
 // SaveResource persists a proto message to the store.
-// Uses INSERT OR REPLACE for upsert semantics.
+// Uses an upsert that keeps the original created_at of existing resources,
+// and re-indexes the metadata.labels of the resource in the same transaction.
 func (s *Store) SaveResource(ctx context.Context, kind apiresourcekind.ApiResourceKind, id string, msg proto.Message) error {
 	// Acquire write lock to serialize writes (SQLite single-writer limitation)
 	s.writeMu.Lock()
@@ -361,15 +524,27 @@ func (s *Store) SaveResource(ctx context.Context, kind apiresourcekind.ApiResour
 		return fmt.Errorf("marshal proto: %w", err)
 	}
 
-	// INSERT OR REPLACE provides upsert semantics
-	_, err = s.db.ExecContext(ctx,
-		`INSERT OR REPLACE INTO resources (kind, id, data, updated_at) VALUES (?, ?, ?, datetime('now'))`,
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// created_at has millisecond precision; ties are ordered by id
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO resources (kind, id, data, created_at, updated_at)
+		VALUES (?, ?, ?, strftime('%Y-%m-%d %H:%M:%f', 'now'), datetime('now'))
+		ON CONFLICT (kind, id) DO UPDATE SET data = excluded.data, updated_at = excluded.updated_at`,
 		kind.String(), id, data)
 	if err != nil {
 		return fmt.Errorf("save resource: %w", err)
 	}
 
-	return nil
+	if err := saveResourceLabels(ctx, tx, kind.String(), id, resourceLabels(data)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // GetResource retrieves a resource by kind and ID.
@@ -441,6 +616,159 @@ func (s *Store) ListResources(ctx context.Context, kind apiresourcekind.ApiResou
 	return results, nil
 }
 
+// pageCursor is the position of the last resource of a page, encoded as the
+// page token.
+type pageCursor struct {
+	CreatedAt string `json:"c"`
+	ID        string `json:"i"`
+}
+
+// encodePageToken encodes a cursor as an opaque page token.
+func encodePageToken(c pageCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken decodes a page token produced by encodePageToken.
+func decodePageToken(token string) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, fmt.Errorf("%w: %v", store.ErrInvalidPageToken, err)
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return c, fmt.Errorf("%w: malformed cursor", store.ErrInvalidPageToken)
+	}
+	return c, nil
+}
+
+// ListResourcesPage retrieves one page of resources of a given kind, ordered
+// by created_at then id.
+//
+// The page token holds the (created_at, id) of the last resource returned,
+// so the next page starts right after it even if resources were added or
+// deleted in between.
+//
+// Labels are matched against the resource_labels index in the query itself,
+// so only matching resources are read and counted. A Filter cannot be
+// expressed in SQL: TotalCount then decodes every resource matching the kind,
+// IDPrefix and Labels, so combine it with one of them on large tables.
+func (s *Store) ListResourcesPage(ctx context.Context, kind apiresourcekind.ApiResourceKind, opts store.ListOptions) (*store.ListPage, error) {
+	if opts.PageSize <= 0 {
+		return nil, fmt.Errorf("page size must be positive, got %d", opts.PageSize)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.db == nil {
+		return nil, fmt.Errorf("store is closed")
+	}
+
+	where := `kind = ?`
+	args := []any{kind.String()}
+	if opts.IDPrefix != "" {
+		where += ` AND substr(id, 1, length(?)) = ?`
+		args = append(args, opts.IDPrefix, opts.IDPrefix)
+	}
+	for _, key := range slices.Sorted(maps.Keys(opts.Labels)) {
+		where += ` AND id IN (SELECT resource_id FROM resource_labels WHERE kind = ? AND label_key = ? AND label_value = ?)`
+		args = append(args, kind.String(), key, opts.Labels[key])
+	}
+
+	total, err := s.countResources(ctx, where, args, opts.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	order, op := "ASC", ">"
+	if opts.Descending {
+		order, op = "DESC", "<"
+	}
+	if opts.PageToken != "" {
+		cursor, err := decodePageToken(opts.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		where += fmt.Sprintf(` AND (created_at %s ? OR (created_at = ? AND id %s ?))`, op, op)
+		args = append(args, cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+
+	query := fmt.Sprintf(`SELECT id, created_at, data FROM resources WHERE %s ORDER BY created_at %s, id %s`, where, order, order)
+	if opts.Filter == nil {
+		// One extra row tells whether another page follows
+		query += fmt.Sprintf(` LIMIT %d`, opts.PageSize+1)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query resources: %w", err)
+	}
+	defer rows.Close()
+
+	page := &store.ListPage{Items: make([][]byte, 0, opts.PageSize), TotalCount: total}
+	var last pageCursor
+	for rows.Next() {
+		var c pageCursor
+		var data []byte
+		if err := rows.Scan(&c.ID, &c.CreatedAt, &data); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		if opts.Filter != nil && !opts.Filter(data) {
+			continue
+		}
+		if len(page.Items) == opts.PageSize {
+			page.NextPageToken = encodePageToken(last)
+			break
+		}
+		// Copy data since database driver may reuse the buffer
+		dataCopy := make([]byte, len(data))
+		copy(dataCopy, data)
+		page.Items = append(page.Items, dataCopy)
+		last = c
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rows: %w", err)
+	}
+
+	return page, nil
+}
+
+// countResources counts the resources matching the WHERE clause and filter.
+// With a filter, every resource matching the WHERE clause is read.
+// Caller must hold s.mu.
+func (s *Store) countResources(ctx context.Context, where string, args []any, filter func([]byte) bool) (int, error) {
+	if filter == nil {
+		var count int
+		if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM resources WHERE `+where, args...).Scan(&count); err != nil {
+			return 0, fmt.Errorf("count resources: %w", err)
+		}
+		return count, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM resources WHERE `+where, args...)
+	if err != nil {
+		return 0, fmt.Errorf("query resources: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return 0, fmt.Errorf("scan row: %w", err)
+		}
+		if filter(data) {
+			count++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate rows: %w", err)
+	}
+	return count, nil
+}
+
 // DeleteResource removes a resource by kind and ID.
 // Returns nil (no error) if the resource does not exist.
 func (s *Store) DeleteResource(ctx context.Context, kind apiresourcekind.ApiResourceKind, id string) error {
@@ -3,9 +3,11 @@ package sqlite
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
@@ -14,6 +16,7 @@ import (
 	"github.com/stigmer/stigmer/backend/libs/go/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 // =============================================================================
@@ -1069,3 +1072,258 @@ func TestStore_AuditOperationsAfterClose(t *testing.T) {
 	_, err = s.DeleteAuditByResourceId(ctx, apiresourcekind.ApiResourceKind_agent, "test")
 	assert.Error(t, err)
 }
+
+// =============================================================================
+// Pagination Tests
+// =============================================================================
+
+// saveTestAgents saves agents with the given IDs, in order, with distinct
+// creation times.
+func saveTestAgents(t *testing.T, s *Store, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		agent := &agentv1.Agent{Metadata: &apiresource.ApiResourceMetadata{Id: id, Name: id}}
+		require.NoError(t, s.SaveResource(context.Background(), apiresourcekind.ApiResourceKind_agent, id, agent))
+		time.Sleep(2 * time.Millisecond)
+	}
+}
+
+// pageIDs returns the agent IDs of a page.
+func pageIDs(t *testing.T, page *store.ListPage) []string {
+	t.Helper()
+	ids := make([]string, 0, len(page.Items))
+	for _, data := range page.Items {
+		agent := &agentv1.Agent{}
+		require.NoError(t, proto.Unmarshal(data, agent))
+		ids = append(ids, agent.Metadata.Id)
+	}
+	return ids
+}
+
+// listAllPages follows page tokens until the last page and returns the IDs of
+// every page.
+func listAllPages(t *testing.T, s *Store, opts store.ListOptions) [][]string {
+	t.Helper()
+	var pages [][]string
+	for {
+		page, err := s.ListResourcesPage(context.Background(), apiresourcekind.ApiResourceKind_agent, opts)
+		require.NoError(t, err)
+		pages = append(pages, pageIDs(t, page))
+		if page.NextPageToken == "" {
+			return pages
+		}
+		opts.PageToken = page.NextPageToken
+	}
+}
+
+func TestStore_ListResourcesPage(t *testing.T) {
+	s, err := NewStore(filepath.Join(t.TempDir(), "test.sqlite"))
+	require.NoError(t, err)
+	defer s.Close()
+
+	// Creation order differs from ID order
+	saveTestAgents(t, s, "agent-c", "agent-a", "agent-e", "agent-b", "agent-d")
+
+	pages := listAllPages(t, s, store.ListOptions{PageSize: 2})
+	assert.Equal(t, [][]string{{"agent-c", "agent-a"}, {"agent-e", "agent-b"}, {"agent-d"}}, pages)
+
+	pages = listAllPages(t, s, store.ListOptions{PageSize: 2, Descending: true})
+	assert.Equal(t, [][]string{{"agent-d", "agent-b"}, {"agent-e", "agent-a"}, {"agent-c"}}, pages)
+
+	page, err := s.ListResourcesPage(context.Background(), apiresourcekind.ApiResourceKind_agent, store.ListOptions{PageSize: 5})
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 5)
+	assert.Empty(t, page.NextPageToken, "exact final page has no next token")
+	assert.Equal(t, 5, page.TotalCount)
+}
+
+func TestStore_ListResourcesPage_StableAcrossWrites(t *testing.T) {
+	s, err := NewStore(filepath.Join(t.TempDir(), "test.sqlite"))
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	saveTestAgents(t, s, "agent-1", "agent-2", "agent-3", "agent-4")
+
+	first, err := s.ListResourcesPage(ctx, apiresourcekind.ApiResourceKind_agent, store.ListOptions{PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agent-1", "agent-2"}, pageIDs(t, first))
+
+	// Updating a listed resource keeps its position; new resources go last
+	saveTestAgents(t, s, "agent-1", "agent-0")
+
+	second, err := s.ListResourcesPage(ctx, apiresourcekind.ApiResourceKind_agent, store.ListOptions{PageSize: 2, PageToken: first.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agent-3", "agent-4"}, pageIDs(t, second))
+
+	third, err := s.ListResourcesPage(ctx, apiresourcekind.ApiResourceKind_agent, store.ListOptions{PageSize: 2, PageToken: second.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agent-0"}, pageIDs(t, third))
+	assert.Empty(t, third.NextPageToken)
+}
+
+func TestStore_ListResourcesPage_PrefixAndFilter(t *testing.T) {
+	s, err := NewStore(filepath.Join(t.TempDir(), "test.sqlite"))
+	require.NoError(t, err)
+	defer s.Close()
+
+	saveTestAgents(t, s, "team-a/1", "team-b/1", "team-a/2", "team-a/3", "team-a/4")
+
+	pages := listAllPages(t, s, store.ListOptions{PageSize: 3, IDPrefix: "team-a/"})
+	assert.Equal(t, [][]string{{"team-a/1", "team-a/2", "team-a/3"}, {"team-a/4"}}, pages)
+
+	skipSecond := func(data []byte) bool {
+		agent := &agentv1.Agent{}
+		return proto.Unmarshal(data, agent) == nil && agent.Metadata.Id != "team-a/2"
+	}
+	pages = listAllPages(t, s, store.ListOptions{PageSize: 2, IDPrefix: "team-a/", Filter: skipSecond})
+	assert.Equal(t, [][]string{{"team-a/1", "team-a/3"}, {"team-a/4"}}, pages)
+
+	page, err := s.ListResourcesPage(context.Background(), apiresourcekind.ApiResourceKind_agent,
+		store.ListOptions{PageSize: 2, IDPrefix: "team-a/", Filter: skipSecond})
+	require.NoError(t, err)
+	assert.Equal(t, 3, page.TotalCount)
+}
+
+func TestStore_ListResourcesPage_Errors(t *testing.T) {
+	s, err := NewStore(filepath.Join(t.TempDir(), "test.sqlite"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = s.ListResourcesPage(ctx, apiresourcekind.ApiResourceKind_agent, store.ListOptions{PageSize: 0})
+	assert.Error(t, err)
+
+	_, err = s.ListResourcesPage(ctx, apiresourcekind.ApiResourceKind_agent, store.ListOptions{PageSize: 10, PageToken: "not a token"})
+	assert.True(t, errors.Is(err, store.ErrInvalidPageToken), "error = %v", err)
+
+	require.NoError(t, s.Close())
+	_, err = s.ListResourcesPage(ctx, apiresourcekind.ApiResourceKind_agent, store.ListOptions{PageSize: 10})
+	assert.Error(t, err)
+}
+
+// =============================================================================
+// Label Selector Tests
+// =============================================================================
+
+// saveLabeledAgents saves count agents labeled team=data every third agent
+// and env=prod every other agent.
+func saveLabeledAgents(t *testing.T, s *Store, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		labels := map[string]string{"index": fmt.Sprint(i)}
+		if i%3 == 0 {
+			labels["team"] = "data"
+		} else {
+			labels["team"] = "platform"
+		}
+		if i%2 == 0 {
+			labels["env"] = "prod"
+		}
+		id := fmt.Sprintf("agent-%03d", i)
+		agent := &agentv1.Agent{Metadata: &apiresource.ApiResourceMetadata{Id: id, Name: id, Labels: labels}}
+		require.NoError(t, s.SaveResource(context.Background(), apiresourcekind.ApiResourceKind_agent, id, agent))
+	}
+}
+
+func TestStore_ListResourcesPage_Labels(t *testing.T) {
+	s, err := NewStore(filepath.Join(t.TempDir(), "test.sqlite"))
+	require.NoError(t, err)
+	defer s.Close()
+
+	saveLabeledAgents(t, s, 300)
+
+	// The filter only sees resources the label index already matched
+	decoded := 0
+	countDecoded := func(data []byte) bool {
+		decoded++
+		return true
+	}
+
+	opts := store.ListOptions{
+		PageSize: 20,
+		Labels:   map[string]string{"team": "data", "env": "prod"},
+		Filter:   countDecoded,
+	}
+	page, err := s.ListResourcesPage(context.Background(), apiresourcekind.ApiResourceKind_agent, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 50, page.TotalCount)
+	assert.Len(t, page.Items, 20)
+	assert.LessOrEqual(t, decoded, 50+21, "non-matching resources must not be read")
+
+	var ids []string
+	for _, pageOfIDs := range listAllPages(t, s, store.ListOptions{PageSize: 20, Labels: opts.Labels}) {
+		ids = append(ids, pageOfIDs...)
+	}
+	require.Len(t, ids, 50)
+	for i, id := range ids {
+		assert.Equal(t, fmt.Sprintf("agent-%03d", i*6), id)
+	}
+
+	page, err = s.ListResourcesPage(context.Background(), apiresourcekind.ApiResourceKind_agent,
+		store.ListOptions{PageSize: 20, Labels: map[string]string{"team": "unknown"}})
+	require.NoError(t, err)
+	assert.Empty(t, page.Items)
+	assert.Equal(t, 0, page.TotalCount)
+}
+
+func TestStore_ListResourcesPage_LabelsFollowWrites(t *testing.T) {
+	s, err := NewStore(filepath.Join(t.TempDir(), "test.sqlite"))
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	saveLabeledAgents(t, s, 6)
+	selector := store.ListOptions{PageSize: 10, Labels: map[string]string{"team": "data"}}
+	assert.Equal(t, [][]string{{"agent-000", "agent-003"}}, listAllPages(t, s, selector))
+
+	// Relabeling replaces the indexed labels
+	relabeled := &agentv1.Agent{Metadata: &apiresource.ApiResourceMetadata{Id: "agent-000", Labels: map[string]string{"team": "platform"}}}
+	require.NoError(t, s.SaveResource(ctx, apiresourcekind.ApiResourceKind_agent, "agent-000", relabeled))
+	assert.Equal(t, [][]string{{"agent-003"}}, listAllPages(t, s, selector))
+
+	// Deleted resources drop out of the index
+	require.NoError(t, s.DeleteResource(ctx, apiresourcekind.ApiResourceKind_agent, "agent-003"))
+	assert.Equal(t, [][]string{{}}, listAllPages(t, s, selector))
+
+	var count int
+	require.NoError(t, s.db.QueryRow(`SELECT COUNT(*) FROM resource_labels WHERE resource_id = 'agent-003'`).Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func TestStore_MigrateToV4_BackfillsLabels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.sqlite")
+	s, err := NewStore(path)
+	require.NoError(t, err)
+	saveLabeledAgents(t, s, 6)
+
+	// Simulate a database created before the label index
+	_, err = s.db.Exec(`DROP TABLE resource_labels; DELETE FROM schema_version WHERE version = 4`)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	s, err = NewStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	pages := listAllPages(t, s, store.ListOptions{PageSize: 10, Labels: map[string]string{"env": "prod", "team": "data"}})
+	assert.Equal(t, [][]string{{"agent-000"}}, pages)
+}
+
+func TestStore_MigrateToV3_BackfillPrecision(t *testing.T) {
+	s, err := NewStore(filepath.Join(t.TempDir(), "test.sqlite"))
+	require.NoError(t, err)
+	defer s.Close()
+	saveLabeledAgents(t, s, 1)
+
+	// Simulate a database created before the created_at column
+	_, err = s.db.Exec(`DROP INDEX idx_resources_kind_created;
+		ALTER TABLE resources DROP COLUMN created_at;
+		DELETE FROM schema_version WHERE version = 3`)
+	require.NoError(t, err)
+	require.NoError(t, migrateToV3(s.db))
+
+	// Backfilled rows use the millisecond format of new rows
+	var createdAt, updatedAt string
+	require.NoError(t, s.db.QueryRow(`SELECT created_at, updated_at FROM resources`).Scan(&createdAt, &updatedAt))
+	assert.Equal(t, updatedAt+".000", createdAt)
+}
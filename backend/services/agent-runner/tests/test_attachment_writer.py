@@ -0,0 +1,93 @@
+"""Unit tests for AttachmentWriter class."""
+
+import pytest
+from unittest.mock import MagicMock
+import os
+import tempfile
+
+from worker.activities.graphton.attachment_writer import AttachmentWriter
+
+
+def make_attachment(name: str, content: bytes, mime_type: str = "") -> MagicMock:
+    """Create a mock ExecutionAttachment proto message."""
+    attachment = MagicMock()
+    attachment.name = name
+    attachment.content = content
+    attachment.mime_type = mime_type
+    return attachment
+
+
+class TestAttachmentWriterLocal:
+    """Tests for writing attachments to the local filesystem."""
+
+    def test_write_attachments_local(self):
+        """Test that attachments are written to /attachments/{name}."""
+        attachments = [
+            make_attachment("diff.patch", b"--- a/main.go\n+++ b/main.go", "text/x-patch"),
+            make_attachment("notes.md", b"Focus on error handling"),
+        ]
+        
+        with tempfile.TemporaryDirectory() as tmpdir:
+            writer = AttachmentWriter(local_root=tmpdir)
+            
+            # Act
+            result = writer.write_attachments(attachments)
+            
+            # Assert
+            assert result == {
+                "diff.patch": "/attachments/diff.patch",
+                "notes.md": "/attachments/notes.md",
+            }
+            with open(os.path.join(tmpdir, "attachments", "diff.patch"), "rb") as f:
+                assert f.read() == b"--- a/main.go\n+++ b/main.go"
+
+    def test_write_no_attachments(self):
+        """Test that an empty list writes nothing."""
+        writer = AttachmentWriter(local_root="/nonexistent")
+        assert writer.write_attachments([]) == {}
+
+    def test_write_without_target_raises(self):
+        """Test that a writer without sandbox or local_root raises RuntimeError."""
+        writer = AttachmentWriter()
+        with pytest.raises(RuntimeError):
+            writer.write_attachments([make_attachment("notes.md", b"notes")])
+
+
+class TestAttachmentWriterDaytona:
+    """Tests for Daytona-specific functionality."""
+
+    def test_write_attachments_daytona_upload_failure_raises(self):
+        """Test that upload failure raises RuntimeError."""
+        mock_sandbox = MagicMock()
+        mock_sandbox.process.exec.return_value = MagicMock(exit_code=0, output="")
+        mock_sandbox.fs.upload_files.side_effect = Exception("Upload failed")
+        
+        writer = AttachmentWriter(sandbox=mock_sandbox)
+        
+        # Act & Assert
+        with pytest.raises(RuntimeError) as exc_info:
+            writer.write_attachments([make_attachment("notes.md", b"notes")])
+        
+        assert "Failed to upload attachments" in str(exc_info.value)
+
+
+class TestAttachmentWriterPromptSection:
+    """Tests for AttachmentWriter.generate_prompt_section()."""
+
+    def test_prompt_lists_attachments(self):
+        """Test that the prompt lists each attachment with path, type and size."""
+        attachments = [
+            make_attachment("diff.patch", b"0123456789", "text/x-patch"),
+            make_attachment("notes.md", b"notes"),
+        ]
+        paths = {"diff.patch": "/attachments/diff.patch", "notes.md": "/attachments/notes.md"}
+        
+        prompt = AttachmentWriter.generate_prompt_section(attachments, paths)
+        
+        assert "## Attachments" in prompt
+        assert "- /attachments/diff.patch (text/x-patch, 10 bytes)" in prompt
+        assert "- /attachments/notes.md (text/plain, 5 bytes)" in prompt
+
+    def test_prompt_empty_without_attachments(self):
+        """Test that no section is generated without attachments."""
+        assert AttachmentWriter.generate_prompt_section([], {}) == ""
@@ -0,0 +1,77 @@
+"""Unit tests for system prompt and user message composition.
+
+The cases come from the fixture shared with the Go SDK's stigmertest package,
+so prompt changes here fail its tests until the Go port is updated as well.
+"""
+
+import json
+import os
+
+import pytest
+from unittest.mock import MagicMock
+
+from worker.activities.graphton.prompt_builder import (
+    DEFAULT_INSTRUCTIONS,
+    compose_system_prompt,
+    compose_user_message,
+)
+from worker.activities.graphton.skill_writer import SkillWriter
+
+CONTRACT_PATH = os.path.join(
+    os.path.dirname(__file__),
+    "..", "..", "..", "..",
+    "sdk", "go", "stigmertest", "testdata", "prompt_contract.json",
+)
+
+
+def load_cases() -> list[dict]:
+    """Load the prompt contract cases shared with the Go SDK."""
+    with open(CONTRACT_PATH) as f:
+        return json.load(f)["cases"]
+
+
+def make_skill(name: str, skill_md: str) -> MagicMock:
+    """Create a mock Skill proto message."""
+    skill = MagicMock()
+    skill.metadata.id = name
+    skill.metadata.name = name
+    skill.spec.skill_md = skill_md
+    return skill
+
+
+@pytest.mark.parametrize("case", load_cases(), ids=lambda case: case["name"])
+def test_prompt_contract(case):
+    """Test that composition matches the contract shared with the Go SDK."""
+    skills = [make_skill(s["name"], s["skill_md"]) for s in case.get("skills", [])]
+    skill_paths = {s["name"]: s["location"] for s in case.get("skills", [])}
+
+    output_schema = case.get("output_schema")
+    if output_schema is not None:
+        # MessageToDict decodes every number of the proto Struct as a float
+        output_schema = json.loads(json.dumps(output_schema), parse_int=float)
+
+    system_prompt = compose_system_prompt(
+        case["instructions"],
+        skills_section=SkillWriter.generate_prompt_section(skills, skill_paths),
+        output_schema=output_schema,
+    )
+
+    assert system_prompt == case["want_system_prompt"]
+    assert compose_user_message(case["message"], case["org"]) == case["want_user_message"]
+
+
+def test_default_instructions():
+    """Test that empty instructions fall back to the default."""
+    assert compose_system_prompt("") == DEFAULT_INSTRUCTIONS
+
+
+def test_attachments_section_follows_skills():
+    """Test that sections are appended in order: skills, attachments, schema."""
+    prompt = compose_system_prompt(
+        "Review code",
+        skills_section="\n\n## Available Skills\n",
+        attachments_section="\n\n## Attachments\n",
+        output_schema={"type": "object"},
+    )
+    assert prompt.index("## Available Skills") < prompt.index("## Attachments")
+    assert prompt.index("## Attachments") < prompt.index("## Response Format")
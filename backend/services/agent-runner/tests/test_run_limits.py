@@ -0,0 +1,102 @@
+"""Unit tests for per-execution agent limits."""
+
+import asyncio
+from types import SimpleNamespace
+
+from worker.activities.graphton.run_limits import RunLimits, RunUsage, total_tokens
+
+
+class FakeClock:
+    def __init__(self):
+        self.now = 100.0
+
+    def __call__(self) -> float:
+        return self.now
+
+
+def model_end(tokens: int) -> dict:
+    output = SimpleNamespace(usage_metadata={"input_tokens": tokens - 10, "output_tokens": 10, "total_tokens": tokens})
+    return {"event": "on_chat_model_end", "data": {"output": output}}
+
+
+def tool_start(name: str) -> dict:
+    return {"event": "on_tool_start", "name": name, "run_id": name}
+
+
+async def stream(events):
+    for event in events:
+        yield event
+
+
+async def collect(usage: RunUsage, events) -> list:
+    return [event async for event in usage.track(stream(events))]
+
+
+class TestRunLimits:
+    def test_from_proto(self):
+        assert not RunLimits.from_proto(None).is_set()
+
+        limits = RunLimits.from_proto(SimpleNamespace(max_tokens_per_run=200000, max_tool_calls=50, max_duration_seconds=600))
+        assert limits.is_set()
+        assert limits == RunLimits(200000, 50, 600)
+
+    def test_total_tokens(self):
+        assert total_tokens(model_end(1500)["data"]["output"]) == 1500
+        assert total_tokens({"usage_metadata": {"input_tokens": 7, "output_tokens": 3}}) == 10
+        assert total_tokens(SimpleNamespace(content="no usage")) == 0
+        assert total_tokens(None) == 0
+
+
+class TestRunUsage:
+    async def test_unlimited(self):
+        usage = RunUsage(RunLimits())
+        events = [model_end(500000)] + [tool_start(f"tool-{i}") for i in range(100)]
+
+        assert await collect(usage, events) == events
+        assert usage.exceeded is None
+        assert usage.tokens == 500000
+        assert usage.tool_calls == 100
+
+    async def test_token_limit(self):
+        usage = RunUsage(RunLimits(max_tokens_per_run=1000))
+        events = [model_end(600), tool_start("search"), model_end(600), tool_start("write_file")]
+
+        assert await collect(usage, events) == events[:2]
+        assert "max_tokens_per_run" in usage.exceeded
+        assert "used 1200 tokens, limit is 1000" in usage.exceeded
+
+    async def test_tool_call_limit(self):
+        usage = RunUsage(RunLimits(max_tool_calls=2))
+        events = [tool_start("a"), tool_start("b"), tool_start("c"), tool_start("d")]
+
+        # The call over the limit is not passed on
+        assert await collect(usage, events) == events[:2]
+        assert "attempted 3 tool calls, limit is 2 (max_tool_calls)" in usage.exceeded
+
+    async def test_duration_limit_between_events(self):
+        clock = FakeClock()
+        usage = RunUsage(RunLimits(max_duration_seconds=60), clock=clock)
+
+        async def slow_events():
+            yield tool_start("a")
+            clock.now += 61
+            yield tool_start("b")
+
+        assert [event async for event in usage.track(slow_events())] == [tool_start("a")]
+        assert "limit is 60 seconds (max_duration_seconds)" in usage.exceeded
+
+    async def test_duration_limit_while_waiting(self):
+        usage = RunUsage(RunLimits(max_duration_seconds=1))
+        closed = asyncio.Event()
+
+        async def stuck_events():
+            try:
+                yield tool_start("a")
+                await asyncio.sleep(30)
+                yield tool_start("b")
+            finally:
+                closed.set()
+
+        assert [event async for event in usage.track(stuck_events())] == [tool_start("a")]
+        assert "max_duration_seconds" in usage.exceeded
+        assert closed.is_set()
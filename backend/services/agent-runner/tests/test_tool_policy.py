@@ -0,0 +1,95 @@
+"""Unit tests for agent-wide tool policy enforcement."""
+
+from types import SimpleNamespace
+from unittest.mock import patch
+
+import pytest
+
+from worker.activities.graphton.tool_policy import (
+    TOOL_APPROVAL_INTERRUPT,
+    ToolPolicy,
+    ToolPolicyMiddleware,
+)
+
+
+def make_policy(deny=(), confirm=()) -> ToolPolicy:
+    return ToolPolicy.from_proto(SimpleNamespace(deny=list(deny), confirm=list(confirm)))
+
+
+def tool_request(name: str) -> SimpleNamespace:
+    return SimpleNamespace(tool_call={"id": "call-1", "name": name, "args": {"title": "Bump deps"}})
+
+
+class TestToolPolicy:
+    def test_unset(self):
+        policy = ToolPolicy.from_proto(None)
+        assert not policy.is_set()
+        assert not policy.is_denied("delete_repo")
+        assert not policy.requires_approval("create_pr")
+
+    def test_glob_matching(self):
+        policy = make_policy(deny=["delete_*", "drop_?"], confirm=["create_pr", "merge_*"])
+        assert policy.is_denied("delete_repo")
+        assert policy.is_denied("drop_x")
+        assert not policy.is_denied("drop_xy")
+        assert not policy.is_denied("undelete_repo")
+        assert policy.requires_approval("create_pr")
+        assert policy.requires_approval("merge_pr")
+        assert not policy.requires_approval("list_prs")
+
+    def test_deny_wins_over_confirm(self):
+        policy = make_policy(deny=["delete_*"], confirm=["*"])
+        assert policy.is_denied("delete_branch")
+        assert not policy.requires_approval("delete_branch")
+        assert policy.requires_approval("create_pr")
+
+
+class TestToolPolicyMiddleware:
+    def test_denied_tools_filtered_from_model(self):
+        middleware = ToolPolicyMiddleware(make_policy(deny=["delete_*"]))
+        request = SimpleNamespace(tools=[
+            SimpleNamespace(name="delete_repo"),
+            SimpleNamespace(name="create_pr"),
+            {"name": "delete_branch"},
+        ])
+
+        names = middleware.wrap_model_call(request, lambda r: [t.name for t in r.tools])
+        assert names == ["create_pr"]
+
+    def test_denied_tool_call_not_run(self):
+        middleware = ToolPolicyMiddleware(make_policy(deny=["delete_*"]))
+
+        result = middleware.wrap_tool_call(tool_request("delete_repo"), lambda r: pytest.fail("tool ran"))
+        assert result.status == "error"
+        assert "denied" in result.content
+
+    def test_confirmed_tool_interrupts(self):
+        middleware = ToolPolicyMiddleware(make_policy(confirm=["create_pr"]))
+
+        with patch("worker.activities.graphton.tool_policy.interrupt", return_value={"approved": True}) as interrupt:
+            result = middleware.wrap_tool_call(tool_request("create_pr"), lambda r: "ran")
+
+        assert result == "ran"
+        value = interrupt.call_args.args[0]
+        assert value["type"] == TOOL_APPROVAL_INTERRUPT
+        assert value["tool_call_id"] == "call-1"
+        assert value["tool"] == "create_pr"
+
+    def test_rejected_tool_not_run(self):
+        middleware = ToolPolicyMiddleware(make_policy(confirm=["create_pr"]))
+
+        decision = {"approved": False, "reason": "not during the freeze"}
+        with patch("worker.activities.graphton.tool_policy.interrupt", return_value=decision):
+            result = middleware.wrap_tool_call(tool_request("create_pr"), lambda r: pytest.fail("tool ran"))
+
+        assert result.status == "error"
+        assert "not during the freeze" in result.content
+
+    def test_other_tools_run(self):
+        middleware = ToolPolicyMiddleware(make_policy(deny=["delete_*"], confirm=["create_pr"]))
+
+        with patch("worker.activities.graphton.tool_policy.interrupt") as interrupt:
+            result = middleware.wrap_tool_call(tool_request("list_prs"), lambda r: "ran")
+
+        assert result == "ran"
+        interrupt.assert_not_called()
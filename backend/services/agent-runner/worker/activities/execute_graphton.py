@@ -5,7 +5,7 @@ from ai.stigmer.agentic.agentexecution.v1.api_pb2 import AgentExecution, AgentEx
 from ai.stigmer.agentic.agentexecution.v1.enum_pb2 import ExecutionPhase
 from graphton import create_deep_agent
 import logging
-import json
+from google.protobuf.json_format import MessageToDict
 from grpc_client.agent_client import AgentClient
 from grpc_client.agent_instance_client import AgentInstanceClient
 from grpc_client.skill_client import SkillClient
@@ -15,6 +15,19 @@ from grpc_client.agent_execution_client import AgentExecutionClient
 from worker.token_manager import get_api_key
 from worker.sandbox_manager import SandboxManager
 from worker.activities.graphton.status_builder import StatusBuilder
+from worker.activities.graphton.prompt_builder import (
+    DEFAULT_INSTRUCTIONS,
+    compose_system_prompt,
+    compose_user_message,
+)
+from worker.activities.graphton.run_limits import RunLimits, RunUsage
+from worker.activities.graphton.tool_policy import (
+    TOOL_APPROVAL_INTERRUPT,
+    ToolPolicy,
+    ToolPolicyMiddleware,
+    approval_decision,
+)
+from langgraph.types import Command
 import os
 
 
@@ -155,7 +168,7 @@ async def _execute_graphton_impl(
         )
         
         # Extract agent instructions
-        instructions = agent.spec.instructions if agent.spec.instructions else "You are a helpful AI assistant."
+        instructions = agent.spec.instructions or DEFAULT_INSTRUCTIONS
         
         # Step 2: Get worker configuration (for sandbox and LLM config)
         from worker.config import Config
@@ -309,6 +322,35 @@ async def _execute_graphton_impl(
                 activity_logger.error(f"Unexpected error preparing skills: {e}")
                 raise
         
+        # Step 3b: Write attachments passed with the execution (e.g. by a workflow
+        # AGENT_CALL task) to /attachments/{name} in the sandbox
+        attachments_prompt_section = ""
+        attachments = list(execution.spec.attachments)
+        
+        if attachments:
+            from worker.activities.graphton.attachment_writer import AttachmentWriter
+            
+            try:
+                if worker_config.is_local_mode():
+                    local_root = sandbox_config.get('root_dir', '/tmp/stigmer-sandbox')
+                    attachment_writer = AttachmentWriter(local_root=local_root)
+                else:
+                    if sandbox is None:
+                        raise RuntimeError("Sandbox not initialized for cloud mode")
+                    attachment_writer = AttachmentWriter(sandbox=sandbox)
+                
+                attachment_paths = attachment_writer.write_attachments(attachments)
+                attachments_prompt_section = AttachmentWriter.generate_prompt_section(
+                    attachments, attachment_paths
+                )
+                
+                activity_logger.info(
+                    f"Wrote {len(attachments)} attachments: {[a.name for a in attachments]}"
+                )
+            except RuntimeError as e:
+                activity_logger.error(f"Failed to write attachments: {e}")
+                raise ValueError(f"Attachment write failed: {e}") from e
+        
         # Step 4: Merge environments (if agent instance has environment refs)
         merged_env_vars = {}
         environment_refs = agent_instance.spec.environment_refs
@@ -365,11 +407,24 @@ async def _execute_graphton_impl(
         # Step 5: Create Graphton agent at runtime with EXISTING sandbox
         activity_logger.info(f"Creating Graphton agent for execution {execution_id}")
         
-        # Enhance system prompt with skills section
-        enhanced_system_prompt = instructions
+        # Enhance system prompt with skills and attachments sections, and ask for
+        # a structured final response when an output schema is declared
+        # (resolved from the agent spec, or overridden by a workflow AGENT_CALL task)
+        output_schema = None
+        if execution.spec.execution_config.HasField("output_schema"):
+            output_schema = MessageToDict(execution.spec.execution_config.output_schema)
+        enhanced_system_prompt = compose_system_prompt(
+            instructions,
+            skills_section=skills_prompt_section,
+            attachments_section=attachments_prompt_section,
+            output_schema=output_schema,
+        )
         if skills_prompt_section:
-            enhanced_system_prompt += skills_prompt_section
             activity_logger.info("Enhanced system prompt with skills metadata")
+        if attachments_prompt_section:
+            activity_logger.info("Enhanced system prompt with attachments")
+        if output_schema is not None:
+            activity_logger.info("Enhanced system prompt with output schema")
         
         # Configure sandbox for Graphton agent
         if worker_config.is_local_mode():
@@ -414,6 +469,24 @@ async def _execute_graphton_impl(
             # Fallback: pass model name as string and let Graphton handle it
             llm_model = model_name
         
+        # Agent-wide tool policy: denied tools are hidden from the model and
+        # confirmed tools pause the execution until approved
+        tool_policy = ToolPolicy.from_proto(
+            agent.spec.tool_policy if agent.spec.HasField("tool_policy") else None
+        )
+        middleware = [ToolPolicyMiddleware(tool_policy)] if tool_policy.is_set() else None
+        if tool_policy.is_set():
+            activity_logger.info(
+                f"Applying tool policy: deny={list(tool_policy.deny)} confirm={list(tool_policy.confirm)}"
+            )
+        
+        # Per-execution limits: the stream is stopped once one is exceeded
+        run_limits = RunLimits.from_proto(
+            agent.spec.limits if agent.spec.HasField("limits") else None
+        )
+        if run_limits.is_set():
+            activity_logger.info(f"Applying run limits: {run_limits}")
+        
         # Create Graphton agent
         # Recursion limit set to 1000 for maximum autonomy
         # Graphton's loop detection middleware prevents infinite loops
@@ -422,6 +495,7 @@ async def _execute_graphton_impl(
             system_prompt=enhanced_system_prompt,
             mcp_servers={},  # MCP support will be added later
             mcp_tools=None,
+            middleware=middleware,
             subagents=None,  # Sub-agents support will be added later
             sandbox_config=sandbox_config_for_agent,
             recursion_limit=1000,
@@ -430,14 +504,6 @@ async def _execute_graphton_impl(
         activity_logger.info(f"Graphton agent created successfully with {'new' if is_new_sandbox else 'reused'} sandbox")
         
         # Step 6: Prepare invocation input
-        # Append organization context to message
-        context_section = f"\n\n---\nContext:\n- Organization: {execution.metadata.org}"
-        message_with_context = user_message + context_section
-        
-        langgraph_input = {
-            "messages": [{"role": "user", "content": message_with_context}]
-        }
-        
         # Prepare config with thread_id for state persistence
         config = {
             "configurable": {
@@ -446,6 +512,19 @@ async def _execute_graphton_impl(
             }
         }
         
+        tool_approvals = list(execution.spec.execution_config.tool_approvals)
+        if tool_approvals:
+            # Resume the tool calls paused by the tool policy with the decisions
+            langgraph_input = await _resume_tool_approvals(agent_graph, config, tool_approvals)
+            activity_logger.info(f"Resuming {len(tool_approvals)} tool call(s) awaiting approval")
+        else:
+            # Append organization context to message
+            message_with_context = compose_user_message(user_message, execution.metadata.org)
+            
+            langgraph_input = {
+                "messages": [{"role": "user", "content": message_with_context}]
+            }
+        
         activity_logger.info(
             f"Using thread_id: {thread_id} for Graphton execution {execution_id}"
         )
@@ -466,11 +545,12 @@ async def _execute_graphton_impl(
             f"🔍 Starting Graphton agent stream for execution {execution_id}"
         )
         
-        async for event in agent_graph.astream_events(
+        run_usage = RunUsage(run_limits)
+        async for event in run_usage.track(agent_graph.astream_events(
             langgraph_input,
             config=config,
             version="v2",  # Use v2 schema for consistent event structure
-        ):
+        )):
             # Process event locally (builds status in memory)
             await status_builder.process_event(event)
             
@@ -519,6 +599,11 @@ async def _execute_graphton_impl(
             if events_processed % 10 == 0:
                 activity_logger.debug(f"Processed {events_processed} events")
         
+        if run_usage.exceeded:
+            return await _limit_exceeded_status(
+                status_builder, execution_client, execution_id, run_usage.exceeded, activity_logger
+            )
+        
         # Verify stream processed data
         if events_processed == 0:
             raise RuntimeError(
@@ -530,6 +615,12 @@ async def _execute_graphton_impl(
             f"📊 Execution {execution_id} completed - processed {events_processed} events"
         )
         
+        # Record tool calls paused by the tool policy
+        if tool_policy.is_set():
+            for request in await _pending_tool_approvals(agent_graph, config):
+                status_builder.add_tool_approval_request(request)
+                activity_logger.info(f"Tool '{request.get('tool')}' awaiting approval")
+        
         # Set phase to COMPLETED
         status_builder.current_status.phase = ExecutionPhase.EXECUTION_COMPLETED
         
@@ -620,3 +711,66 @@ async def _execute_graphton_impl(
         
         # Return failed status to workflow (already persisted via gRPC above)
         return status_builder.current_status
+
+
+async def _limit_exceeded_status(
+    status_builder: StatusBuilder,
+    execution_client: AgentExecutionClient,
+    execution_id: str,
+    message: str,
+    activity_logger,
+) -> AgentExecutionStatus:
+    """Finish an execution stopped by one of the agent's limits."""
+    from ai.stigmer.agentic.agentexecution.v1.api_pb2 import AgentMessage
+    from ai.stigmer.agentic.agentexecution.v1.enum_pb2 import MessageType
+    from datetime import datetime
+    
+    activity_logger.warning(f"Execution {execution_id} stopped: {message}")
+    
+    status = status_builder.current_status
+    status.messages.append(AgentMessage(
+        type=MessageType.MESSAGE_SYSTEM,
+        content=f"⛔ {message}",
+        timestamp=datetime.utcnow().isoformat(),
+    ))
+    status.error = message
+    status.phase = ExecutionPhase.EXECUTION_LIMIT_EXCEEDED
+    
+    try:
+        await execution_client.update_status(execution_id=execution_id, status=status)
+    except Exception as e:
+        activity_logger.error(f"Failed to send limit exceeded status update: {e}")
+        # Continue - we'll still return status to workflow
+    
+    return status
+
+
+async def _pending_tool_approvals(agent_graph, config: dict) -> list[dict]:
+    """Interrupt values of the tool calls awaiting approval on the thread."""
+    state = await agent_graph.aget_state(config)
+    return [
+        intr.value
+        for intr in state.interrupts
+        if isinstance(intr.value, dict) and intr.value.get("type") == TOOL_APPROVAL_INTERRUPT
+    ]
+
+
+async def _resume_tool_approvals(agent_graph, config: dict, approvals: list) -> Command:
+    """Command resuming the thread's paused tool calls with their decisions.
+    
+    Raises:
+        ValueError: If a decision references a tool call that is not awaiting approval
+    """
+    state = await agent_graph.aget_state(config)
+    interrupt_ids = {
+        intr.value.get("tool_call_id"): intr.id
+        for intr in state.interrupts
+        if isinstance(intr.value, dict) and intr.value.get("type") == TOOL_APPROVAL_INTERRUPT
+    }
+    
+    resume = {}
+    for approval in approvals:
+        if approval.tool_call_id not in interrupt_ids:
+            raise ValueError(f"Tool call {approval.tool_call_id} is not awaiting approval")
+        resume[interrupt_ids[approval.tool_call_id]] = approval_decision(approval)
+    return Command(resume=resume)
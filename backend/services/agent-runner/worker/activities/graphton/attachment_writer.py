@@ -0,0 +1,122 @@
+"""Utilities for writing execution attachments to sandbox.
+
+Attachments are files passed to an agent execution, typically by a workflow
+AGENT_CALL task (for example a diff fetched by a previous task). They are
+written to /attachments/{name} in the sandbox and listed in the system prompt
+so the agent can read them with its file tools.
+"""
+
+import logging
+import os
+
+logger = logging.getLogger(__name__)
+
+
+class AttachmentWriter:
+    """Writes execution attachments to sandbox (Daytona or local filesystem)."""
+    
+    ATTACHMENTS_DIR = "/attachments"
+    
+    def __init__(self, sandbox=None, local_root: str | None = None):
+        """Initialize AttachmentWriter.
+        
+        Args:
+            sandbox: Daytona Sandbox instance (for cloud mode)
+            local_root: Local filesystem root (for local mode, e.g., /tmp/stigmer-sandbox)
+        """
+        self.sandbox = sandbox
+        self.local_root = local_root
+    
+    def write_attachments(self, attachments: list) -> dict[str, str]:
+        """Write attachments to sandbox.
+        
+        Args:
+            attachments: List of ExecutionAttachment proto messages
+            
+        Returns:
+            Dictionary mapping attachment name to file path in sandbox:
+            {"diff.patch": "/attachments/diff.patch", ...}
+            
+        Raises:
+            RuntimeError: If directory creation or file upload fails
+        """
+        if not attachments:
+            return {}
+        
+        if self.local_root:
+            return self._write_attachments_local(attachments)
+        elif self.sandbox:
+            return self._write_attachments_daytona(attachments)
+        else:
+            raise RuntimeError("No sandbox or local_root configured")
+    
+    def _write_attachments_local(self, attachments: list) -> dict[str, str]:
+        """Write attachments to local filesystem."""
+        local_dir = f"{self.local_root}{self.ATTACHMENTS_DIR}"
+        attachment_paths = {}
+        
+        try:
+            os.makedirs(local_dir, exist_ok=True)
+            for attachment in attachments:
+                with open(f"{local_dir}/{attachment.name}", 'wb') as f:
+                    f.write(attachment.content)
+                attachment_paths[attachment.name] = f"{self.ATTACHMENTS_DIR}/{attachment.name}"
+        except Exception as e:
+            raise RuntimeError(f"Failed to write attachments to local filesystem: {e}") from e
+        
+        logger.info(f"Wrote {len(attachments)} attachments to {local_dir}")
+        return attachment_paths
+    
+    def _write_attachments_daytona(self, attachments: list) -> dict[str, str]:
+        """Write attachments to Daytona sandbox."""
+        from daytona import FileUpload
+        
+        try:
+            result = self.sandbox.process.exec(f"mkdir -p {self.ATTACHMENTS_DIR}", timeout=5)
+            if result.exit_code != 0:
+                raise RuntimeError(f"Failed to create attachments directory: {result.output}")
+        except Exception as e:
+            raise RuntimeError(f"Failed to create attachments directory: {e}") from e
+        
+        attachment_paths = {}
+        file_uploads = []
+        for attachment in attachments:
+            path = f"{self.ATTACHMENTS_DIR}/{attachment.name}"
+            file_uploads.append(FileUpload(source=attachment.content, destination=path))
+            attachment_paths[attachment.name] = path
+        
+        try:
+            self.sandbox.fs.upload_files(file_uploads)
+        except Exception as e:
+            raise RuntimeError(f"Failed to upload attachments to Daytona sandbox: {e}") from e
+        
+        logger.info(f"Uploaded {len(attachments)} attachments to Daytona sandbox")
+        return attachment_paths
+    
+    @staticmethod
+    def generate_prompt_section(
+        attachments: list,
+        attachment_paths: dict[str, str],
+    ) -> str:
+        """Generate system prompt section listing the attachments.
+        
+        Args:
+            attachments: List of ExecutionAttachment proto messages
+            attachment_paths: Dictionary mapping attachment name to file path
+            
+        Returns:
+            Markdown section to append to system prompt
+        """
+        if not attachments:
+            return ""
+        
+        prompt = "\n\n## Attachments\n\n"
+        prompt += "The following files were attached to this request. "
+        prompt += "Read them with your file tools when relevant.\n\n"
+        
+        for attachment in attachments:
+            path = attachment_paths.get(attachment.name, f"/attachments/{attachment.name}")
+            mime_type = attachment.mime_type or "text/plain"
+            prompt += f"- {path} ({mime_type}, {len(attachment.content)} bytes)\n"
+        
+        return prompt
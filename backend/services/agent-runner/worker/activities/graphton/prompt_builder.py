@@ -0,0 +1,49 @@
+"""System prompt and user message composition for agent executions.
+
+The functions here do no I/O so the composition can be tested on its own.
+The Go SDK's stigmertest package ports them for local agent tests; both
+implementations are checked against the shared fixture in
+sdk/go/stigmertest/testdata/prompt_contract.json.
+"""
+
+import json
+
+DEFAULT_INSTRUCTIONS = "You are a helpful AI assistant."
+
+
+def compose_system_prompt(
+    instructions: str,
+    skills_section: str = "",
+    attachments_section: str = "",
+    output_schema: dict | None = None,
+) -> str:
+    """Compose the system prompt of an agent execution.
+    
+    Args:
+        instructions: Agent instructions (empty uses DEFAULT_INSTRUCTIONS)
+        skills_section: Section from SkillWriter.generate_prompt_section
+        attachments_section: Section from AttachmentWriter.generate_prompt_section
+        output_schema: JSON Schema the final response must conform to, if any
+        
+    Returns:
+        The system prompt passed to the agent
+    """
+    prompt = instructions or DEFAULT_INSTRUCTIONS
+    prompt += skills_section
+    prompt += attachments_section
+    
+    # Ask for a structured final response when an output schema is declared
+    if output_schema is not None:
+        prompt += (
+            "\n\n## Response Format\n\n"
+            "Your final response must be a single JSON object that conforms to the "
+            "following JSON Schema. Do not wrap it in markdown or add any other text.\n\n"
+            f"{json.dumps(output_schema, indent=2, sort_keys=True)}\n"
+        )
+    
+    return prompt
+
+
+def compose_user_message(message: str, org: str) -> str:
+    """Append the organization context to the user message."""
+    return message + f"\n\n---\nContext:\n- Organization: {org}"
@@ -0,0 +1,137 @@
+"""Per-execution resource limits.
+
+The limits of an agent (AgentSpec.limits) bound each of its executions:
+
+- max_tokens_per_run: model tokens (input and output) reported in the usage
+  metadata of every model call, sub-agents included.
+- max_tool_calls: tool calls started, sub-agents included.
+- max_duration_seconds: wall-clock time since the agent stream started.
+
+Usage is counted from the graph's astream_events stream. Once a limit is
+exceeded the stream is closed, which stops the agent, and the execution ends
+with phase EXECUTION_LIMIT_EXCEEDED. A limit of 0 means no limit.
+"""
+
+import asyncio
+import time
+from dataclasses import dataclass
+from typing import Any, AsyncIterator, Callable, Optional
+
+
+@dataclass(frozen=True)
+class RunLimits:
+    """Limits of each execution of an agent."""
+
+    max_tokens_per_run: int = 0
+    max_tool_calls: int = 0
+    max_duration_seconds: int = 0
+
+    @classmethod
+    def from_proto(cls, limits: Any) -> "RunLimits":
+        """Create limits from an AgentSpec.limits message (or None)."""
+        if limits is None:
+            return cls()
+        return cls(
+            max_tokens_per_run=limits.max_tokens_per_run,
+            max_tool_calls=limits.max_tool_calls,
+            max_duration_seconds=limits.max_duration_seconds,
+        )
+
+    def is_set(self) -> bool:
+        """Whether any limit is set."""
+        return bool(self.max_tokens_per_run or self.max_tool_calls or self.max_duration_seconds)
+
+
+class RunUsage:
+    """Usage of one execution, checked against the agent's limits."""
+
+    def __init__(self, limits: RunLimits, clock: Callable[[], float] = time.monotonic):
+        self.limits = limits
+        self.tokens = 0
+        self.tool_calls = 0
+        # Message naming the exceeded limit, None while within limits
+        self.exceeded: Optional[str] = None
+        self._clock = clock
+        self._started = clock()
+
+    def record(self, event: dict[str, Any]) -> None:
+        """Count the tokens or tool call of an astream_events v2 event."""
+        event_type = event.get("event", "")
+        if event_type == "on_tool_start":
+            self.tool_calls += 1
+        elif event_type == "on_chat_model_end":
+            self.tokens += total_tokens(event.get("data", {}).get("output"))
+
+    def check(self) -> Optional[str]:
+        """Return a message naming the first exceeded limit, or None."""
+        limits = self.limits
+        if limits.max_tokens_per_run and self.tokens > limits.max_tokens_per_run:
+            return (
+                f"Token limit exceeded: used {self.tokens} tokens, "
+                f"limit is {limits.max_tokens_per_run} (max_tokens_per_run)"
+            )
+        if limits.max_tool_calls and self.tool_calls > limits.max_tool_calls:
+            return (
+                f"Tool call limit exceeded: attempted {self.tool_calls} tool calls, "
+                f"limit is {limits.max_tool_calls} (max_tool_calls)"
+            )
+        if limits.max_duration_seconds and self.elapsed() > limits.max_duration_seconds:
+            return self._duration_message()
+        return None
+
+    def elapsed(self) -> float:
+        """Seconds since the execution started."""
+        return self._clock() - self._started
+
+    async def track(self, events: AsyncIterator[dict[str, Any]]) -> AsyncIterator[dict[str, Any]]:
+        """Yield the events of an agent stream until a limit is exceeded.
+
+        The event exceeding a limit is not yielded. Waiting for the next event
+        is bounded by the remaining duration, so a long model or tool call
+        cannot run past max_duration_seconds.
+        """
+        iterator = events.__aiter__()
+        try:
+            while True:
+                try:
+                    event = await asyncio.wait_for(iterator.__anext__(), timeout=self._remaining())
+                except StopAsyncIteration:
+                    return
+                except TimeoutError:
+                    self.exceeded = self._duration_message()
+                    return
+
+                self.record(event)
+                self.exceeded = self.check()
+                if self.exceeded:
+                    return
+                yield event
+        finally:
+            aclose = getattr(iterator, "aclose", None)
+            if aclose is not None:
+                await aclose()
+
+    def _remaining(self) -> Optional[float]:
+        if not self.limits.max_duration_seconds:
+            return None
+        return max(0.0, self.limits.max_duration_seconds - self.elapsed())
+
+    def _duration_message(self) -> str:
+        return (
+            f"Duration limit exceeded: ran for {self.elapsed():.0f} seconds, "
+            f"limit is {self.limits.max_duration_seconds} seconds (max_duration_seconds)"
+        )
+
+
+def total_tokens(output: Any) -> int:
+    """Tokens used by a model call, from the usage metadata of its message."""
+    if isinstance(output, dict):
+        usage = output.get("usage_metadata")
+    else:
+        usage = getattr(output, "usage_metadata", None)
+    if not usage:
+        return 0
+    total = usage.get("total_tokens")
+    if total is None:
+        total = usage.get("input_tokens", 0) + usage.get("output_tokens", 0)
+    return int(total)
@@ -201,6 +201,42 @@ class StatusBuilder:
         
         self.logger.debug(f"Tool '{tool_name}' completed in local status")
     
+    def add_tool_approval_request(self, request: Dict[str, Any]) -> None:
+        """Record a tool call paused by the agent's tool policy.
+        
+        The tool never started, so no tool events were streamed for it. The
+        call is added with status TOOL_CALL_AWAITING_APPROVAL under the ID
+        a ToolApproval decision must reference.
+        """
+        args_struct = Struct()
+        if request.get("args"):
+            args_struct.update(request["args"])
+        
+        tool_call = ToolCall(
+            id=request.get("tool_call_id", ""),
+            name=request.get("tool", ""),
+            args=args_struct,
+            result="",
+            status=ToolCallStatus.TOOL_CALL_AWAITING_APPROVAL,
+            component_metadata=ComponentMetadata(
+                component_type="approval",
+                component_group="main-agent-tools",
+            ),
+            started_at=datetime.utcnow().isoformat(),
+        )
+        
+        tool_message = AgentMessage(
+            type=MessageType.MESSAGE_TOOL,
+            content="",
+            timestamp=datetime.utcnow().isoformat(),
+        )
+        tool_message.tool_calls.append(tool_call)
+        
+        self.current_status.messages.append(tool_message)
+        self.current_status.tool_calls.append(tool_call)
+        
+        self.logger.debug(f"Tool '{tool_call.name}' awaiting approval in local status")
+    
     def _handle_chat_model_stream_event(self, event: Dict[str, Any], namespace: str = "") -> None:
         """Handle on_chat_model_stream event - updates local status."""
         chunk_data = event.get("data", {}).get("chunk", {})
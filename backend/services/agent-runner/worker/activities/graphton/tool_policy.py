@@ -0,0 +1,128 @@
+"""Agent-wide tool policy enforcement.
+
+The tool policy of an agent (AgentSpec.tool_policy) applies to every tool the
+agent can call, whichever MCP server provides it:
+
+- Denied tools are removed from the tool list sent to the model, and calls
+  to them are answered with an error without running the tool.
+- Confirmed tools interrupt the graph before each call. The execution ends
+  with the call awaiting approval, and a later execution in the same session
+  resumes it with the decisions in execution_config.tool_approvals.
+
+Patterns use "*" and "?" wildcards, like the Go SDK's agent.DenyTools and
+agent.ConfirmTools. A tool matching both lists is denied.
+"""
+
+from dataclasses import dataclass, field
+from fnmatch import fnmatchcase
+from typing import Any, Awaitable, Callable
+
+from langchain.agents.middleware.types import AgentMiddleware
+from langchain_core.messages import ToolMessage
+from langgraph.types import interrupt
+
+# Type of the interrupt values raised for tool calls awaiting approval
+TOOL_APPROVAL_INTERRUPT = "tool_approval"
+
+
+@dataclass(frozen=True)
+class ToolPolicy:
+    """Deny and confirm patterns of an agent's tool policy."""
+
+    deny: tuple[str, ...] = field(default_factory=tuple)
+    confirm: tuple[str, ...] = field(default_factory=tuple)
+
+    @classmethod
+    def from_proto(cls, policy: Any) -> "ToolPolicy":
+        """Create a policy from an AgentSpec.tool_policy message (or None)."""
+        if policy is None:
+            return cls()
+        return cls(deny=tuple(policy.deny), confirm=tuple(policy.confirm))
+
+    def is_set(self) -> bool:
+        """Whether the policy has any pattern."""
+        return bool(self.deny or self.confirm)
+
+    def is_denied(self, tool_name: str) -> bool:
+        """Whether the tool may never be called."""
+        return any(fnmatchcase(tool_name, pattern) for pattern in self.deny)
+
+    def requires_approval(self, tool_name: str) -> bool:
+        """Whether each call of the tool must be approved first."""
+        if self.is_denied(tool_name):
+            return False
+        return any(fnmatchcase(tool_name, pattern) for pattern in self.confirm)
+
+
+def tool_name(tool: Any) -> str:
+    """Name of a tool given to the model (a BaseTool or a provider dict)."""
+    if isinstance(tool, dict):
+        return tool.get("name") or tool.get("function", {}).get("name", "")
+    return getattr(tool, "name", "")
+
+
+def approval_request(tool_call: dict[str, Any]) -> dict[str, Any]:
+    """Interrupt value describing a tool call awaiting approval."""
+    return {
+        "type": TOOL_APPROVAL_INTERRUPT,
+        "tool_call_id": tool_call.get("id", ""),
+        "tool": tool_call.get("name", ""),
+        "args": tool_call.get("args", {}),
+    }
+
+
+def approval_decision(approval: Any) -> dict[str, Any]:
+    """Resume value for a ToolApproval message."""
+    return {"approved": approval.approved, "reason": approval.reason}
+
+
+class ToolPolicyMiddleware(AgentMiddleware):
+    """Middleware enforcing an agent's tool policy."""
+
+    def __init__(self, policy: ToolPolicy) -> None:
+        super().__init__()
+        self.policy = policy
+
+    def _filter_tools(self, request: Any) -> Any:
+        request.tools = [t for t in request.tools if not self.policy.is_denied(tool_name(t))]
+        return request
+
+    def wrap_model_call(self, request: Any, handler: Callable[[Any], Any]) -> Any:
+        return handler(self._filter_tools(request))
+
+    async def awrap_model_call(self, request: Any, handler: Callable[[Any], Awaitable[Any]]) -> Any:
+        return await handler(self._filter_tools(request))
+
+    def _check(self, tool_call: dict[str, Any]) -> ToolMessage | None:
+        """Return the answer to a call that must not run, or None."""
+        name = tool_call.get("name", "")
+        if self.policy.is_denied(name):
+            return ToolMessage(
+                content=f"Tool '{name}' is denied by the agent's tool policy.",
+                tool_call_id=tool_call.get("id", ""),
+                name=name,
+                status="error",
+            )
+        if not self.policy.requires_approval(name):
+            return None
+
+        # Pauses the graph until resumed with a decision
+        decision = interrupt(approval_request(tool_call)) or {}
+        if decision.get("approved"):
+            return None
+        reason = decision.get("reason") or "no reason given"
+        return ToolMessage(
+            content=f"Call to tool '{name}' was rejected: {reason}",
+            tool_call_id=tool_call.get("id", ""),
+            name=name,
+            status="error",
+        )
+
+    def wrap_tool_call(self, request: Any, handler: Callable[[Any], Any]) -> Any:
+        return self._check(request.tool_call) or handler(request)
+
+    async def awrap_tool_call(self, request: Any, handler: Callable[[Any], Awaitable[Any]]) -> Any:
+        answer = self._check(request.tool_call)
+        if answer is not None:
+            return answer
+        return await handler(request)
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stigmer/stigmer/backend/libs/go/store/sqlite"
@@ -234,3 +235,61 @@ func TestAgentController_Delete(t *testing.T) {
 		}
 	})
 }
+
+func TestAgentController_List(t *testing.T) {
+	store, err := sqlite.NewStore(t.TempDir() + "/test.sqlite")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	controller := NewAgentController(store, nil)
+
+	// A few hundred agents, every fifth owned by team=data
+	for i := 0; i < 250; i++ {
+		team := "platform"
+		if i%5 == 0 {
+			team = "data"
+		}
+		id := fmt.Sprintf("agt-%03d", i)
+		agent := &agentv1.Agent{
+			ApiVersion: "agentic.stigmer.ai/v1",
+			Kind:       "Agent",
+			Metadata: &apiresource.ApiResourceMetadata{
+				Id:     id,
+				Name:   id,
+				Labels: map[string]string{"team": team},
+			},
+			Spec: &agentv1.AgentSpec{Instructions: "You are a helpful test agent."},
+		}
+		if err := store.SaveResource(context.Background(), apiresourcekind.ApiResourceKind_agent, id, agent); err != nil {
+			t.Fatalf("failed to save agent: %v", err)
+		}
+	}
+
+	list, err := controller.List(contextWithAgentKind(), &agentv1.ListAgentsRequest{
+		PageSize:      20,
+		LabelSelector: map[string]string{"team": "data"},
+	})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list.Entries) != 20 || list.TotalPages != 3 || list.NextPageToken == "" {
+		t.Fatalf("Expected a first page of 20 out of 3 pages, got %d entries and %d pages", len(list.Entries), list.TotalPages)
+	}
+	for _, agent := range list.Entries {
+		if agent.Metadata.Labels["team"] != "data" {
+			t.Errorf("Agent %s does not match the selector: %v", agent.Metadata.Id, agent.Metadata.Labels)
+		}
+	}
+
+	list, err = controller.List(contextWithAgentKind(), &agentv1.ListAgentsRequest{
+		LabelSelector: map[string]string{"team": "data", "env": "prod"},
+	})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list.Entries) != 0 || list.TotalPages != 0 {
+		t.Errorf("Expected no agents for an unmatched label, got %d entries and %d pages", len(list.Entries), list.TotalPages)
+	}
+}
@@ -0,0 +1,77 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxListPageSize is the largest page size List returns; larger requests are clamped.
+// It is also the page size used when the request does not set one.
+const maxListPageSize = 100
+
+// List retrieves agents in creation order, one page at a time
+//
+// Pagination:
+// - page_size limits the page (defaults to and is clamped to 100)
+// - page_token resumes after the previous page (next_page_token of the response)
+//
+// Label selector:
+// - label_selector keeps agents whose metadata.labels contain every key=value pair
+// - Labels are matched by the store's label index, so only matching agents are loaded
+//
+// Note: Compared to Stigmer Cloud, OSS excludes:
+// - IAM Policy authorization filtering (no multi-tenant auth)
+func (c *AgentController) List(ctx context.Context, req *agentv1.ListAgentsRequest) (*agentv1.AgentList, error) {
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, grpclib.InvalidArgumentError("page_size must not be negative")
+	}
+	if pageSize == 0 || pageSize > maxListPageSize {
+		pageSize = maxListPageSize
+	}
+
+	for key := range req.GetLabelSelector() {
+		if key == "" {
+			return nil, grpclib.InvalidArgumentError("label_selector keys must not be empty")
+		}
+	}
+
+	page, err := c.store.ListResourcesPage(ctx, apiresourcekind.ApiResourceKind_agent, store.ListOptions{
+		PageSize:  pageSize,
+		PageToken: req.GetPageToken(),
+		Labels:    req.GetLabelSelector(),
+	})
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidPageToken) {
+			return nil, grpclib.InvalidArgumentError("invalid page_token")
+		}
+		return nil, grpclib.InternalError(err, "failed to list agents")
+	}
+
+	return &agentv1.AgentList{
+		TotalPages:    int32((page.TotalCount + pageSize - 1) / pageSize),
+		Entries:       unmarshalAgents(page.Items),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// unmarshalAgents decodes stored agents, skipping invalid entries.
+func unmarshalAgents(data [][]byte) []*agentv1.Agent {
+	agents := make([]*agentv1.Agent, 0, len(data))
+	for _, d := range data {
+		agent := &agentv1.Agent{}
+		if err := proto.Unmarshal(d, agent); err != nil {
+			log.Warn().Err(err).Msg("Failed to unmarshal agent, skipping")
+			continue
+		}
+		agents = append(agents, agent)
+	}
+	return agents
+}
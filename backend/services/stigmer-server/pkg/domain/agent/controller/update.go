@@ -14,8 +14,9 @@ import (
 // 1. ValidateProto - Validate proto field constraints using buf validate
 // 2. ResolveSlug - Generate slug from metadata.name
 // 3. LoadExisting - Load existing agent from repository by ID
-// 4. BuildUpdateState - Merge spec, preserve IDs, update timestamps, clear computed fields
-// 5. Persist - Save updated agent to repository
+// 4. CheckResourceVersion - Reject updates based on a stale metadata.resource_version (FAILED_PRECONDITION)
+// 5. BuildUpdateState - Merge spec, preserve IDs, update timestamps, clear computed fields
+// 6. Persist - Save updated agent to repository
 //
 // Note: Compared to Stigmer Cloud, OSS excludes:
 // - Authorize step (no multi-tenant auth in OSS)
@@ -38,10 +39,11 @@ func (c *AgentController) buildUpdatePipeline() *pipeline.Pipeline[*agentv1.Agen
 	// api_resource_kind is automatically extracted from proto service descriptor
 	// by the apiresource interceptor and injected into request context
 	return pipeline.NewPipeline[*agentv1.Agent]("agent-update").
-		AddStep(steps.NewValidateProtoStep[*agentv1.Agent]()).       // 1. Validate field constraints
-		AddStep(steps.NewResolveSlugStep[*agentv1.Agent]()).         // 2. Resolve slug
-		AddStep(steps.NewLoadExistingStep[*agentv1.Agent](c.store)). // 3. Load existing agent
-		AddStep(steps.NewBuildUpdateStateStep[*agentv1.Agent]()).    // 4. Build updated state
-		AddStep(steps.NewPersistStep[*agentv1.Agent](c.store)).      // 5. Persist agent
+		AddStep(steps.NewValidateProtoStep[*agentv1.Agent]()).        // 1. Validate field constraints
+		AddStep(steps.NewResolveSlugStep[*agentv1.Agent]()).          // 2. Resolve slug
+		AddStep(steps.NewLoadExistingStep[*agentv1.Agent](c.store)).  // 3. Load existing agent
+		AddStep(steps.NewCheckResourceVersionStep[*agentv1.Agent]()). // 4. Check resource version
+		AddStep(steps.NewBuildUpdateStateStep[*agentv1.Agent]()).     // 5. Build updated state
+		AddStep(steps.NewPersistStep[*agentv1.Agent](c.store)).       // 6. Persist agent
 		Build()
 }
@@ -2,14 +2,23 @@ package agentexecution
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
 	agentexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
+	agentinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentinstance/v1"
+	sessionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/session/v1"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
 	"github.com/stigmer/stigmer/backend/libs/go/store"
 	"github.com/stigmer/stigmer/backend/libs/go/store/sqlite"
 	apiresourceinterceptor "github.com/stigmer/stigmer/backend/libs/go/grpc/interceptors/apiresource"
+	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // contextWithAgentExecutionKind creates a context with the agent execution resource kind injected
@@ -306,3 +315,244 @@ func TestAgentExecutionController_Delete(t *testing.T) {
 		}
 	})
 }
+
+func TestResolveMemoryConfigStep(t *testing.T) {
+	_, store := setupTestController(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	memory := &agentv1.MemoryConfig{Strategy: agentv1.MemoryStrategy_MEMORY_NONE}
+
+	agent := &agentv1.Agent{
+		Metadata: &apiresource.ApiResourceMetadata{Id: "agt-memory"},
+		Spec:     &agentv1.AgentSpec{Memory: memory},
+	}
+	if err := store.SaveResource(ctx, apiresourcekind.ApiResourceKind_agent, "agt-memory", agent); err != nil {
+		t.Fatalf("failed to save agent: %v", err)
+	}
+	instance := &agentinstancev1.AgentInstance{
+		Metadata: &apiresource.ApiResourceMetadata{Id: "ain-memory"},
+		Spec:     &agentinstancev1.AgentInstanceSpec{AgentId: "agt-memory"},
+	}
+	if err := store.SaveResource(ctx, apiresourcekind.ApiResourceKind_agent_instance, "ain-memory", instance); err != nil {
+		t.Fatalf("failed to save agent instance: %v", err)
+	}
+	session := &sessionv1.Session{
+		Metadata: &apiresource.ApiResourceMetadata{Id: "ses-memory"},
+		Spec:     &sessionv1.SessionSpec{AgentInstanceId: "ain-memory"},
+	}
+	if err := store.SaveResource(ctx, apiresourcekind.ApiResourceKind_session, "ses-memory", session); err != nil {
+		t.Fatalf("failed to save session: %v", err)
+	}
+
+	run := func(spec *agentexecutionv1.AgentExecutionSpec) *agentexecutionv1.AgentExecution {
+		reqCtx := pipeline.NewRequestContext(ctx, &agentexecutionv1.AgentExecution{Spec: spec})
+		if err := newResolveMemoryConfigStep(store).Execute(reqCtx); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		return reqCtx.NewState()
+	}
+
+	t.Run("resolved from agent_id", func(t *testing.T) {
+		got := run(&agentexecutionv1.AgentExecutionSpec{AgentId: "agt-memory", Message: "hi"})
+		if !proto.Equal(got.GetSpec().GetExecutionConfig().GetMemory(), memory) {
+			t.Errorf("memory = %v, want %v", got.GetSpec().GetExecutionConfig().GetMemory(), memory)
+		}
+	})
+
+	t.Run("resolved through session", func(t *testing.T) {
+		got := run(&agentexecutionv1.AgentExecutionSpec{SessionId: "ses-memory", Message: "hi"})
+		if !proto.Equal(got.GetSpec().GetExecutionConfig().GetMemory(), memory) {
+			t.Errorf("memory = %v, want %v", got.GetSpec().GetExecutionConfig().GetMemory(), memory)
+		}
+	})
+
+	t.Run("explicit memory is kept", func(t *testing.T) {
+		explicit := &agentv1.MemoryConfig{Strategy: agentv1.MemoryStrategy_MEMORY_WINDOW, WindowTurns: 5}
+		got := run(&agentexecutionv1.AgentExecutionSpec{
+			AgentId:         "agt-memory",
+			Message:         "hi",
+			ExecutionConfig: &agentexecutionv1.ExecutionConfig{Memory: explicit},
+		})
+		if !proto.Equal(got.GetSpec().GetExecutionConfig().GetMemory(), explicit) {
+			t.Errorf("memory = %v, want %v", got.GetSpec().GetExecutionConfig().GetMemory(), explicit)
+		}
+	})
+
+	t.Run("unknown agent falls back to default", func(t *testing.T) {
+		got := run(&agentexecutionv1.AgentExecutionSpec{AgentId: "agt-missing", Message: "hi"})
+		if got.GetSpec().GetExecutionConfig().GetMemory() != nil {
+			t.Errorf("memory = %v, want nil", got.GetSpec().GetExecutionConfig().GetMemory())
+		}
+	})
+}
+
+func TestResolveOutputSchemaStep(t *testing.T) {
+	_, store := setupTestController(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	schema, err := structpb.NewStruct(map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"severity": map[string]any{"type": "string"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to build schema: %v", err)
+	}
+
+	agent := &agentv1.Agent{
+		Metadata: &apiresource.ApiResourceMetadata{Id: "agt-schema"},
+		Spec:     &agentv1.AgentSpec{OutputSchema: schema},
+	}
+	if err := store.SaveResource(ctx, apiresourcekind.ApiResourceKind_agent, "agt-schema", agent); err != nil {
+		t.Fatalf("failed to save agent: %v", err)
+	}
+
+	run := func(spec *agentexecutionv1.AgentExecutionSpec) *agentexecutionv1.AgentExecution {
+		reqCtx := pipeline.NewRequestContext(ctx, &agentexecutionv1.AgentExecution{Spec: spec})
+		if err := newResolveOutputSchemaStep(store).Execute(reqCtx); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		return reqCtx.NewState()
+	}
+
+	t.Run("resolved from agent_id", func(t *testing.T) {
+		got := run(&agentexecutionv1.AgentExecutionSpec{AgentId: "agt-schema", Message: "hi"})
+		if !proto.Equal(got.GetSpec().GetExecutionConfig().GetOutputSchema(), schema) {
+			t.Errorf("output_schema = %v, want %v", got.GetSpec().GetExecutionConfig().GetOutputSchema(), schema)
+		}
+	})
+
+	t.Run("explicit schema is kept", func(t *testing.T) {
+		explicit, _ := structpb.NewStruct(map[string]any{"type": "object"})
+		got := run(&agentexecutionv1.AgentExecutionSpec{
+			AgentId:         "agt-schema",
+			Message:         "hi",
+			ExecutionConfig: &agentexecutionv1.ExecutionConfig{OutputSchema: explicit},
+		})
+		if !proto.Equal(got.GetSpec().GetExecutionConfig().GetOutputSchema(), explicit) {
+			t.Errorf("output_schema = %v, want %v", got.GetSpec().GetExecutionConfig().GetOutputSchema(), explicit)
+		}
+	})
+
+	t.Run("unknown agent runs without schema", func(t *testing.T) {
+		got := run(&agentexecutionv1.AgentExecutionSpec{AgentId: "agt-missing", Message: "hi"})
+		if got.GetSpec().GetExecutionConfig().GetOutputSchema() != nil {
+			t.Errorf("output_schema = %v, want nil", got.GetSpec().GetExecutionConfig().GetOutputSchema())
+		}
+	})
+}
+
+// saveTestExecution stores an execution directly, bypassing the create pipeline
+func saveTestExecution(t *testing.T, s store.Store, id, sessionID string, phase agentexecutionv1.ExecutionPhase) {
+	t.Helper()
+
+	execution := &agentexecutionv1.AgentExecution{
+		ApiVersion: "agentic.stigmer.ai/v1",
+		Kind:       "AgentExecution",
+		Metadata:   &apiresource.ApiResourceMetadata{Id: id, Name: id},
+		Spec:       &agentexecutionv1.AgentExecutionSpec{SessionId: sessionID, Message: "Test message"},
+		Status:     &agentexecutionv1.AgentExecutionStatus{Phase: phase},
+	}
+	if err := s.SaveResource(contextWithAgentExecutionKind(), apiresourcekind.ApiResourceKind_agent_execution, id, execution); err != nil {
+		t.Fatalf("failed to save execution %s: %v", id, err)
+	}
+}
+
+func TestAgentExecutionController_List(t *testing.T) {
+	controller, store := setupTestController(t)
+	defer store.Close()
+
+	for i := 0; i < 5; i++ {
+		phase := agentexecutionv1.ExecutionPhase_EXECUTION_COMPLETED
+		if i%2 == 0 {
+			phase = agentexecutionv1.ExecutionPhase_EXECUTION_FAILED
+		}
+		saveTestExecution(t, store, fmt.Sprintf("aex-%d", i), "session-a", phase)
+	}
+	saveTestExecution(t, store, "aex-other", "session-b", agentexecutionv1.ExecutionPhase_EXECUTION_COMPLETED)
+
+	t.Run("pages through all executions", func(t *testing.T) {
+		seen := map[string]bool{}
+		token := ""
+		pages := 0
+		for {
+			list, err := controller.List(contextWithAgentExecutionKind(), &agentexecutionv1.ListAgentExecutionsRequest{PageSize: 4, PageToken: token})
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			pages++
+			if list.TotalPages != 2 {
+				t.Errorf("Expected total_pages 2, got %d", list.TotalPages)
+			}
+			for _, e := range list.Entries {
+				if seen[e.Metadata.Id] {
+					t.Errorf("Execution %s returned twice", e.Metadata.Id)
+				}
+				seen[e.Metadata.Id] = true
+			}
+			if list.NextPageToken == "" {
+				break
+			}
+			token = list.NextPageToken
+		}
+		if pages != 2 || len(seen) != 6 {
+			t.Errorf("Expected 6 executions in 2 pages, got %d in %d", len(seen), pages)
+		}
+	})
+
+	t.Run("phase filter applies before paging", func(t *testing.T) {
+		list, err := controller.List(contextWithAgentExecutionKind(), &agentexecutionv1.ListAgentExecutionsRequest{
+			PageSize: 3,
+			Phase:    agentexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+		})
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(list.Entries) != 3 || list.NextPageToken != "" {
+			t.Errorf("Expected one full page of 3 failed executions, got %d (next %q)", len(list.Entries), list.NextPageToken)
+		}
+	})
+
+	t.Run("zero page size returns everything", func(t *testing.T) {
+		list, err := controller.List(contextWithAgentExecutionKind(), &agentexecutionv1.ListAgentExecutionsRequest{})
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(list.Entries) != 6 || list.NextPageToken != "" {
+			t.Errorf("Expected all 6 executions, got %d", len(list.Entries))
+		}
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		_, err := controller.List(contextWithAgentExecutionKind(), &agentexecutionv1.ListAgentExecutionsRequest{PageSize: 2, PageToken: "not-a-token"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("negative page size", func(t *testing.T) {
+		_, err := controller.List(contextWithAgentExecutionKind(), &agentexecutionv1.ListAgentExecutionsRequest{PageSize: -1})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("list by session", func(t *testing.T) {
+		list, err := controller.ListBySession(contextWithAgentExecutionKind(), &agentexecutionv1.ListAgentExecutionsBySessionRequest{
+			SessionId: "session-a",
+			PageSize:  2,
+		})
+		if err != nil {
+			t.Fatalf("ListBySession failed: %v", err)
+		}
+		if len(list.Entries) != 2 || list.TotalPages != 3 || list.NextPageToken == "" {
+			t.Errorf("Expected first of 3 pages, got %d entries, total_pages %d", len(list.Entries), list.TotalPages)
+		}
+		for _, e := range list.Entries {
+			if e.Spec.SessionId != "session-a" {
+				t.Errorf("Expected session-a execution, got %s", e.Spec.SessionId)
+			}
+		}
+	})
+}
@@ -41,9 +41,11 @@ const (
 // 5. BuildNewState - Generate ID, clear status, set audit fields (timestamps, actors, event)
 // 6. CreateDefaultInstanceIfNeeded - Create default agent instance if missing
 // 7. CreateSessionIfNeeded - Create session if session_id not provided
-// 8. SetInitialPhase - Set execution phase to PENDING
-// 9. Persist - Save execution to repository
-// 10. StartWorkflow - Start Temporal workflow (if Temporal is available)
+// 8. ResolveMemoryConfig - Copy the agent's memory config into the execution config
+// 9. ResolveOutputSchema - Copy the agent's output schema into the execution config
+// 10. SetInitialPhase - Set execution phase to PENDING
+// 11. Persist - Save execution to repository
+// 12. StartWorkflow - Start Temporal workflow (if Temporal is available)
 //
 // Note: Compared to Stigmer Cloud, OSS excludes:
 // - Authorize step (no multi-tenant auth in OSS)
@@ -72,9 +74,11 @@ func (c *AgentExecutionController) buildCreatePipeline() *pipeline.Pipeline[*age
 		AddStep(steps.NewBuildNewStateStep[*agentexecutionv1.AgentExecution]()).             // 4. Build new state
 		AddStep(newCreateDefaultInstanceIfNeededStep(c.agentClient, c.agentInstanceClient, c.store)). // 5. Create default instance if needed
 		AddStep(newCreateSessionIfNeededStep(c.agentClient, c.sessionClient)).               // 6. Create session if needed
-		AddStep(newSetInitialPhaseStep()).                                                   // 7. Set phase to PENDING
-		AddStep(steps.NewPersistStep[*agentexecutionv1.AgentExecution](c.store)).            // 8. Persist execution
-		AddStep(c.newStartWorkflowStep()).                                                   // 9. Start Temporal workflow
+		AddStep(newResolveMemoryConfigStep(c.store)).                                        // 7. Resolve agent memory config
+		AddStep(newResolveOutputSchemaStep(c.store)).                                        // 8. Resolve agent output schema
+		AddStep(newSetInitialPhaseStep()).                                                   // 9. Set phase to PENDING
+		AddStep(steps.NewPersistStep[*agentexecutionv1.AgentExecution](c.store)).            // 10. Persist execution
+		AddStep(c.newStartWorkflowStep()).                                                   // 11. Start Temporal workflow
 		Build()
 }
 
@@ -406,6 +410,162 @@ func (s *createSessionIfNeededStep) Execute(ctx *pipeline.RequestContext[*agente
 	return nil
 }
 
+// resolveMemoryConfigStep copies the agent's memory configuration into the execution
+//
+// The workflow cannot load the agent itself, so the memory config travels with the
+// execution in spec.execution_config.memory. An explicit memory config on the request
+// takes precedence over the agent's.
+//
+// This step:
+// 1. Skips if the execution already carries a memory config
+// 2. Resolves the agent ID (spec.agent_id, or session -> agent instance -> agent)
+// 3. Loads the agent and copies spec.memory into the execution config
+//
+// Resolution failures are logged and ignored - the execution falls back to the
+// default (full session history) rather than failing.
+type resolveMemoryConfigStep struct {
+	store store.Store
+}
+
+func newResolveMemoryConfigStep(store store.Store) *resolveMemoryConfigStep {
+	return &resolveMemoryConfigStep{store: store}
+}
+
+func (s *resolveMemoryConfigStep) Name() string {
+	return "ResolveMemoryConfig"
+}
+
+func (s *resolveMemoryConfigStep) Execute(ctx *pipeline.RequestContext[*agentexecutionv1.AgentExecution]) error {
+	execution := ctx.NewState()
+
+	if execution.GetSpec().GetExecutionConfig().GetMemory() != nil {
+		log.Debug().Msg("Execution already carries a memory config, skipping resolution")
+		return nil
+	}
+
+	agentID, err := resolveExecutionAgentID(ctx.Context(), s.store, execution.GetSpec())
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("session_id", execution.GetSpec().GetSessionId()).
+			Msg("Could not resolve agent for memory config, using default memory")
+		return nil
+	}
+
+	agent := &agentv1.Agent{}
+	if err := s.store.GetResource(ctx.Context(), apiresourcekind.ApiResourceKind_agent, agentID, agent); err != nil {
+		log.Warn().
+			Err(err).
+			Str("agent_id", agentID).
+			Msg("Could not load agent for memory config, using default memory")
+		return nil
+	}
+
+	memory := agent.GetSpec().GetMemory()
+	if memory == nil {
+		return nil
+	}
+
+	if execution.Spec.ExecutionConfig == nil {
+		execution.Spec.ExecutionConfig = &agentexecutionv1.ExecutionConfig{}
+	}
+	execution.Spec.ExecutionConfig.Memory = memory
+	ctx.SetNewState(execution)
+
+	log.Debug().
+		Str("agent_id", agentID).
+		Str("strategy", memory.GetStrategy().String()).
+		Msg("Resolved agent memory config")
+
+	return nil
+}
+
+// resolveExecutionAgentID returns the agent ID for the execution, following the
+// session's agent instance when only session_id is provided.
+func resolveExecutionAgentID(ctx context.Context, s store.Store, spec *agentexecutionv1.AgentExecutionSpec) (string, error) {
+	if agentID := spec.GetAgentId(); agentID != "" {
+		return agentID, nil
+	}
+
+	session := &sessionv1.Session{}
+	if err := s.GetResource(ctx, apiresourcekind.ApiResourceKind_session, spec.GetSessionId(), session); err != nil {
+		return "", fmt.Errorf("failed to load session: %w", err)
+	}
+
+	instance := &agentinstancev1.AgentInstance{}
+	instanceID := session.GetSpec().GetAgentInstanceId()
+	if err := s.GetResource(ctx, apiresourcekind.ApiResourceKind_agent_instance, instanceID, instance); err != nil {
+		return "", fmt.Errorf("failed to load agent instance: %w", err)
+	}
+
+	return instance.GetSpec().GetAgentId(), nil
+}
+
+// resolveOutputSchemaStep copies the agent's output schema into the execution
+//
+// The agent runner reads the schema from spec.execution_config.output_schema to ask
+// the model for a conforming JSON response, and workflow AGENT_CALL tasks read it
+// back from the completed execution to validate the response. An explicit schema
+// on the request (e.g. a per-task override) takes precedence over the agent's.
+//
+// Resolution failures are logged and ignored - the execution runs without a
+// schema rather than failing.
+type resolveOutputSchemaStep struct {
+	store store.Store
+}
+
+func newResolveOutputSchemaStep(store store.Store) *resolveOutputSchemaStep {
+	return &resolveOutputSchemaStep{store: store}
+}
+
+func (s *resolveOutputSchemaStep) Name() string {
+	return "ResolveOutputSchema"
+}
+
+func (s *resolveOutputSchemaStep) Execute(ctx *pipeline.RequestContext[*agentexecutionv1.AgentExecution]) error {
+	execution := ctx.NewState()
+
+	if execution.GetSpec().GetExecutionConfig().GetOutputSchema() != nil {
+		log.Debug().Msg("Execution already carries an output schema, skipping resolution")
+		return nil
+	}
+
+	agentID, err := resolveExecutionAgentID(ctx.Context(), s.store, execution.GetSpec())
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("session_id", execution.GetSpec().GetSessionId()).
+			Msg("Could not resolve agent for output schema, running without schema")
+		return nil
+	}
+
+	agent := &agentv1.Agent{}
+	if err := s.store.GetResource(ctx.Context(), apiresourcekind.ApiResourceKind_agent, agentID, agent); err != nil {
+		log.Warn().
+			Err(err).
+			Str("agent_id", agentID).
+			Msg("Could not load agent for output schema, running without schema")
+		return nil
+	}
+
+	schema := agent.GetSpec().GetOutputSchema()
+	if schema == nil {
+		return nil
+	}
+
+	if execution.Spec.ExecutionConfig == nil {
+		execution.Spec.ExecutionConfig = &agentexecutionv1.ExecutionConfig{}
+	}
+	execution.Spec.ExecutionConfig.OutputSchema = schema
+	ctx.SetNewState(execution)
+
+	log.Debug().
+		Str("agent_id", agentID).
+		Msg("Resolved agent output schema")
+
+	return nil
+}
+
 // setInitialPhaseStep sets the execution phase to PENDING
 //
 // This allows the frontend to show a thinking indicator immediately when the execution is created,
@@ -2,14 +2,15 @@ package agentexecution
 
 import (
 	"context"
+	"errors"
 
 	"github.com/rs/zerolog/log"
-	"github.com/stigmer/stigmer/backend/libs/go/store"
-	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
-	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
 	agentexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
-	"google.golang.org/protobuf/encoding/protojson"
+	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
+	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"google.golang.org/protobuf/proto"
 )
 
 // Context keys for list operations
@@ -17,16 +18,25 @@ const (
 	ExecutionListKey = "execution_list"
 )
 
-// List retrieves all agent executions with pagination and optional filtering
+// maxListPageSize is the largest page size the list operations return;
+// larger requests are clamped.
+const maxListPageSize = 100
+
+// List retrieves agent executions, newest first, one page at a time
 //
 // Pipeline (Stigmer OSS):
 // 1. Validate - Validate input request
-// 2. QueryAll - List all executions from store
-// 3. ApplyPhaseFilter - Filter by phase if provided
-// 4. BuildResponse - Build final AgentExecutionList response
+// 2. QueryExecutions - List one page of executions matching the phase filter
+//
+// Pagination:
+// - page_size limits the page (clamped to 100)
+// - page_token resumes after the previous page (next_page_token of the response)
+// - Pages are ordered by creation time, then ID, and stay stable as executions are created
+//
+// A page_size of 0 returns all executions. Unpaginated lists are deprecated
+// and log a warning.
 //
-// Note: For OSS (local single-user), we return all executions without authorization.
-// Pagination and filtering can be added later as needed.
+// Note: For OSS (local single-user), we return executions without authorization.
 func (c *AgentExecutionController) List(ctx context.Context, req *agentexecutionv1.ListAgentExecutionsRequest) (*agentexecutionv1.AgentExecutionList, error) {
 	reqCtx := pipeline.NewRequestContext(ctx, req)
 
@@ -48,10 +58,8 @@ func (c *AgentExecutionController) List(ctx context.Context, req *agentexecution
 // buildListPipeline constructs the pipeline for listing agent executions
 func (c *AgentExecutionController) buildListPipeline() *pipeline.Pipeline[*agentexecutionv1.ListAgentExecutionsRequest] {
 	return pipeline.NewPipeline[*agentexecutionv1.ListAgentExecutionsRequest]("agent-execution-list").
-		AddStep(newValidateListRequestStep()).            // 1. Validate request
-		AddStep(newQueryAllExecutionsStep(c.store)).      // 2. Query all executions
-		AddStep(newApplyPhaseFilterStep()).               // 3. Apply phase filter
-		AddStep(newBuildListExecutionListResponseStep()). // 4. Build response
+		AddStep(newValidateListRequestStep()).    // 1. Validate request
+		AddStep(newQueryExecutionsStep(c.store)). // 2. Query one page of executions
 		Build()
 }
 
@@ -72,131 +80,124 @@ func (s *validateListRequestStep) Name() string {
 
 func (s *validateListRequestStep) Execute(ctx *pipeline.RequestContext[*agentexecutionv1.ListAgentExecutionsRequest]) error {
 	log.Debug().Msg("Validating list request")
-	// For now, list request has no required fields
-	// Future: Add pagination validation here
-	return nil
+	return validatePageSize(ctx.Input().GetPageSize())
 }
 
-// queryAllExecutionsStep queries all executions from the store
-type queryAllExecutionsStep struct {
+// queryExecutionsStep queries one page of executions from the store,
+// applying the phase filter before paging so pages stay full
+type queryExecutionsStep struct {
 	store store.Store
 }
 
-func newQueryAllExecutionsStep(store store.Store) *queryAllExecutionsStep {
-	return &queryAllExecutionsStep{store: store}
+func newQueryExecutionsStep(store store.Store) *queryExecutionsStep {
+	return &queryExecutionsStep{store: store}
 }
 
-func (s *queryAllExecutionsStep) Name() string {
-	return "QueryAllExecutions"
+func (s *queryExecutionsStep) Name() string {
+	return "QueryExecutions"
 }
 
-func (s *queryAllExecutionsStep) Execute(ctx *pipeline.RequestContext[*agentexecutionv1.ListAgentExecutionsRequest]) error {
-	log.Debug().Msg("Listing all agent executions")
+func (s *queryExecutionsStep) Execute(ctx *pipeline.RequestContext[*agentexecutionv1.ListAgentExecutionsRequest]) error {
+	req := ctx.Input()
 
-	// List all executions from store
-	data, err := s.store.ListResources(ctx.Context(), apiresourcekind.ApiResourceKind_agent_execution)
-	if err != nil {
-		return grpclib.InternalError(err, "failed to list agent executions")
+	var match func(*agentexecutionv1.AgentExecution) bool
+	if req.GetPhase() != agentexecutionv1.ExecutionPhase_EXECUTION_PHASE_UNSPECIFIED {
+		log.Debug().
+			Str("phase", req.GetPhase().String()).
+			Msg("Applying phase filter")
+		match = func(execution *agentexecutionv1.AgentExecution) bool {
+			return execution.GetStatus().GetPhase() == req.GetPhase()
+		}
 	}
 
-	executions := make([]*agentexecutionv1.AgentExecution, 0, len(data))
-	for _, d := range data {
-		execution := &agentexecutionv1.AgentExecution{}
-		if err := protojson.Unmarshal(d, execution); err != nil {
-			log.Warn().
-				Err(err).
-				Msg("Failed to unmarshal execution, skipping")
-			continue
-		}
-		executions = append(executions, execution)
+	result, err := listExecutions(ctx.Context(), s.store, req.GetPageSize(), req.GetPageToken(), match)
+	if err != nil {
+		return err
 	}
 
 	log.Debug().
-		Int("count", len(executions)).
+		Int("count", len(result.Entries)).
 		Msg("Successfully queried executions")
 
-	// Store executions in context
-	ctx.Set(ExecutionListKey, executions)
+	// Store result in context
+	ctx.Set(ExecutionListKey, result)
 
 	return nil
 }
 
-// applyPhaseFilterStep applies phase filter to execution list
-type applyPhaseFilterStep struct{}
-
-func newApplyPhaseFilterStep() *applyPhaseFilterStep {
-	return &applyPhaseFilterStep{}
+// validatePageSize rejects negative page sizes.
+func validatePageSize(pageSize int32) error {
+	if pageSize < 0 {
+		return grpclib.InvalidArgumentError("page_size must not be negative")
+	}
+	return nil
 }
 
-func (s *applyPhaseFilterStep) Name() string {
-	return "ApplyPhaseFilter"
-}
+// listExecutions lists agent executions matching match (all if nil), newest
+// first. A pageSize of 0 returns every match in one deprecated, unpaginated
+// page.
+func listExecutions(ctx context.Context, s store.Store, pageSize int32, pageToken string, match func(*agentexecutionv1.AgentExecution) bool) (*agentexecutionv1.AgentExecutionList, error) {
+	if pageSize == 0 {
+		log.Warn().Msg("Listing agent executions without page_size is deprecated; set page_size to paginate")
 
-func (s *applyPhaseFilterStep) Execute(ctx *pipeline.RequestContext[*agentexecutionv1.ListAgentExecutionsRequest]) error {
-	req := ctx.Input()
-	executions, ok := ctx.Get(ExecutionListKey).([]*agentexecutionv1.AgentExecution)
-	if !ok {
-		return grpclib.InternalError(nil, "execution list not found in context")
+		data, err := s.ListResources(ctx, apiresourcekind.ApiResourceKind_agent_execution)
+		if err != nil {
+			return nil, grpclib.InternalError(err, "failed to list agent executions")
+		}
+
+		executions := make([]*agentexecutionv1.AgentExecution, 0, len(data))
+		for _, execution := range unmarshalExecutions(data) {
+			if match == nil || match(execution) {
+				executions = append(executions, execution)
+			}
+		}
+		return &agentexecutionv1.AgentExecutionList{TotalPages: 1, Entries: executions}, nil
 	}
 
-	// If no phase filter specified, skip filtering
-	if req.Phase == agentexecutionv1.ExecutionPhase_EXECUTION_PHASE_UNSPECIFIED {
-		log.Debug().Msg("No phase filter specified, skipping")
-		return nil
+	size := int(pageSize)
+	if size > maxListPageSize {
+		size = maxListPageSize
 	}
 
-	log.Debug().
-		Str("phase", req.Phase.String()).
-		Msg("Applying phase filter")
-
-	// Filter executions by phase
-	filtered := make([]*agentexecutionv1.AgentExecution, 0)
-	for _, execution := range executions {
-		if execution.GetStatus().GetPhase() == req.Phase {
-			filtered = append(filtered, execution)
+	opts := store.ListOptions{
+		PageSize:   size,
+		PageToken:  pageToken,
+		Descending: true,
+	}
+	if match != nil {
+		opts.Filter = func(data []byte) bool {
+			execution := &agentexecutionv1.AgentExecution{}
+			return proto.Unmarshal(data, execution) == nil && match(execution)
 		}
 	}
 
-	log.Debug().
-		Int("original_count", len(executions)).
-		Int("filtered_count", len(filtered)).
-		Msg("Phase filter applied")
-
-	// Update execution list in context
-	ctx.Set(ExecutionListKey, filtered)
-
-	return nil
-}
-
-// buildListExecutionListResponseStep builds the AgentExecutionList response for List operation
-type buildListExecutionListResponseStep struct{}
-
-func newBuildListExecutionListResponseStep() *buildListExecutionListResponseStep {
-	return &buildListExecutionListResponseStep{}
-}
-
-func (s *buildListExecutionListResponseStep) Name() string {
-	return "BuildExecutionListResponse"
-}
-
-func (s *buildListExecutionListResponseStep) Execute(ctx *pipeline.RequestContext[*agentexecutionv1.ListAgentExecutionsRequest]) error {
-	executions, ok := ctx.Get(ExecutionListKey).([]*agentexecutionv1.AgentExecution)
-	if !ok {
-		return grpclib.InternalError(nil, "execution list not found in context")
+	page, err := s.ListResourcesPage(ctx, apiresourcekind.ApiResourceKind_agent_execution, opts)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidPageToken) {
+			return nil, grpclib.InvalidArgumentError("invalid page_token")
+		}
+		return nil, grpclib.InternalError(err, "failed to list agent executions")
 	}
 
-	log.Debug().
-		Int("count", len(executions)).
-		Msg("Building execution list response")
+	return &agentexecutionv1.AgentExecutionList{
+		TotalPages:    int32((page.TotalCount + size - 1) / size),
+		Entries:       unmarshalExecutions(page.Items),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
 
-	// Build response
-	result := &agentexecutionv1.AgentExecutionList{
-		TotalPages: 1, // TODO: Implement pagination
-		Entries:    executions,
+// unmarshalExecutions decodes stored executions, skipping invalid entries.
+func unmarshalExecutions(data [][]byte) []*agentexecutionv1.AgentExecution {
+	executions := make([]*agentexecutionv1.AgentExecution, 0, len(data))
+	for _, d := range data {
+		execution := &agentexecutionv1.AgentExecution{}
+		if err := proto.Unmarshal(d, execution); err != nil {
+			log.Warn().
+				Err(err).
+				Msg("Failed to unmarshal execution, skipping")
+			continue
+		}
+		executions = append(executions, execution)
 	}
-
-	// Store result in context
-	ctx.Set(ExecutionListKey, result)
-
-	return nil
+	return executions
 }
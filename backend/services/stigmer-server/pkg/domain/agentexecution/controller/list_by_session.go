@@ -4,20 +4,20 @@ import (
 	"context"
 
 	"github.com/rs/zerolog/log"
-	"github.com/stigmer/stigmer/backend/libs/go/store"
+	agentexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
 	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
-	agentexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
-	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
-	"google.golang.org/protobuf/encoding/protojson"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
 )
 
-// ListBySession lists all executions in a specific session
+// ListBySession lists the executions in a specific session, newest first
 //
 // Pipeline (Stigmer OSS):
 // 1. Validate - Validate session_id is provided
-// 2. QueryBySession - List executions filtered by session_id
-// 3. BuildResponse - Build final AgentExecutionList response
+// 2. QueryBySession - List one page of executions filtered by session_id
+//
+// Pagination follows List: page_size (clamped to 100) and page_token, with a
+// page_size of 0 returning all executions (deprecated).
 //
 // Note: For OSS, we filter executions by session_id without authorization.
 func (c *AgentExecutionController) ListBySession(ctx context.Context, req *agentexecutionv1.ListAgentExecutionsBySessionRequest) (*agentexecutionv1.AgentExecutionList, error) {
@@ -41,9 +41,8 @@ func (c *AgentExecutionController) ListBySession(ctx context.Context, req *agent
 // buildListBySessionPipeline constructs the pipeline for listing executions by session
 func (c *AgentExecutionController) buildListBySessionPipeline() *pipeline.Pipeline[*agentexecutionv1.ListAgentExecutionsBySessionRequest] {
 	return pipeline.NewPipeline[*agentexecutionv1.ListAgentExecutionsBySessionRequest]("agent-execution-list-by-session").
-		AddStep(newValidateListBySessionRequestStep()).            // 1. Validate request
-		AddStep(newQueryExecutionsBySessionStep(c.store)).         // 2. Query by session
-		AddStep(newBuildListBySessionExecutionListResponseStep()). // 3. Build response
+		AddStep(newValidateListBySessionRequestStep()).    // 1. Validate request
+		AddStep(newQueryExecutionsBySessionStep(c.store)). // 2. Query by session
 		Build()
 }
 
@@ -72,6 +71,10 @@ func (s *validateListBySessionRequestStep) Execute(ctx *pipeline.RequestContext[
 		return grpclib.InvalidArgumentError("session_id is required")
 	}
 
+	if err := validatePageSize(req.PageSize); err != nil {
+		return err
+	}
+
 	log.Debug().
 		Str("session_id", req.SessionId).
 		Msg("Validation successful")
@@ -100,66 +103,18 @@ func (s *queryExecutionsBySessionStep) Execute(ctx *pipeline.RequestContext[*age
 		Str("session_id", sessionID).
 		Msg("Listing executions by session")
 
-	// List all executions and filter by session_id
-	data, err := s.store.ListResources(ctx.Context(), apiresourcekind.ApiResourceKind_agent_execution)
+	result, err := listExecutions(ctx.Context(), s.store, req.PageSize, req.PageToken, func(execution *agentexecutionv1.AgentExecution) bool {
+		return execution.GetSpec().GetSessionId() == sessionID
+	})
 	if err != nil {
-		return grpclib.InternalError(err, "failed to list agent executions")
-	}
-
-	executions := make([]*agentexecutionv1.AgentExecution, 0)
-	for _, d := range data {
-		execution := &agentexecutionv1.AgentExecution{}
-		if err := protojson.Unmarshal(d, execution); err != nil {
-			log.Warn().
-				Err(err).
-				Msg("Failed to unmarshal execution, skipping")
-			continue
-		}
-
-		// Filter by session_id
-		if execution.GetSpec().GetSessionId() == sessionID {
-			executions = append(executions, execution)
-		}
+		return err
 	}
 
 	log.Debug().
 		Str("session_id", sessionID).
-		Int("count", len(executions)).
+		Int("count", len(result.Entries)).
 		Msg("Successfully queried executions by session")
 
-	// Store executions in context
-	ctx.Set(ExecutionListKey, executions)
-
-	return nil
-}
-
-// buildListBySessionExecutionListResponseStep builds the AgentExecutionList response for ListBySession operation
-type buildListBySessionExecutionListResponseStep struct{}
-
-func newBuildListBySessionExecutionListResponseStep() *buildListBySessionExecutionListResponseStep {
-	return &buildListBySessionExecutionListResponseStep{}
-}
-
-func (s *buildListBySessionExecutionListResponseStep) Name() string {
-	return "BuildExecutionListResponse"
-}
-
-func (s *buildListBySessionExecutionListResponseStep) Execute(ctx *pipeline.RequestContext[*agentexecutionv1.ListAgentExecutionsBySessionRequest]) error {
-	executions, ok := ctx.Get(ExecutionListKey).([]*agentexecutionv1.AgentExecution)
-	if !ok {
-		return grpclib.InternalError(nil, "execution list not found in context")
-	}
-
-	log.Debug().
-		Int("count", len(executions)).
-		Msg("Building execution list response")
-
-	// Build response
-	result := &agentexecutionv1.AgentExecutionList{
-		TotalPages: 1, // TODO: Implement pagination
-		Entries:    executions,
-	}
-
 	// Store result in context
 	ctx.Set(ExecutionListKey, result)
 
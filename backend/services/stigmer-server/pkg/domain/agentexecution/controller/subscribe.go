@@ -200,5 +200,6 @@ func (s *StreamUpdatesStep) Execute(ctx *pipeline.RequestContext[*agentexecution
 func isTerminalPhase(phase agentexecutionv1.ExecutionPhase) bool {
 	return phase == agentexecutionv1.ExecutionPhase_EXECUTION_COMPLETED ||
 		phase == agentexecutionv1.ExecutionPhase_EXECUTION_FAILED ||
-		phase == agentexecutionv1.ExecutionPhase_EXECUTION_CANCELLED
+		phase == agentexecutionv1.ExecutionPhase_EXECUTION_CANCELLED ||
+		phase == agentexecutionv1.ExecutionPhase_EXECUTION_LIMIT_EXCEEDED
 }
@@ -20,6 +20,14 @@ type CompleteExternalActivityInput struct {
 	// Error is the error to return to the external activity (can be nil)
 	// If both Result and Error are provided, Error takes precedence
 	Error error
+
+	// ErrorType, if set, fails the external activity with a non-retryable
+	// application error of this type and ErrorMessage, so the caller can
+	// classify the failure. Takes precedence over Error and Result.
+	ErrorType string
+
+	// ErrorMessage is the message of the ErrorType application error.
+	ErrorMessage string
 }
 
 // CompleteExternalActivity completes an external Temporal activity using its task token.
@@ -56,7 +64,8 @@ func CompleteExternalActivity(ctx context.Context, input *CompleteExternalActivi
 		"token_preview", tokenPreview,
 		"token_length", len(input.CallbackToken),
 		"has_result", input.Result != nil,
-		"has_error", input.Error != nil)
+		"has_error", input.Error != nil,
+		"error_type", input.ErrorType)
 
 	// Get the Temporal client from activity context
 	// This client must be injected when registering the activity
@@ -67,8 +76,12 @@ func CompleteExternalActivity(ctx context.Context, input *CompleteExternalActivi
 	}
 
 	// Complete the external activity
-	// If Error is provided, report failure; otherwise report success with Result
-	if input.Error != nil {
+	// If ErrorType or Error is provided, report failure; otherwise report success with Result
+	if input.ErrorType != "" {
+		logger.Info("❌ Reporting typed failure to external activity", "error_type", input.ErrorType, "error", input.ErrorMessage)
+		failure := temporal.NewNonRetryableApplicationError(input.ErrorMessage, input.ErrorType, nil)
+		err = temporalClient.CompleteActivity(ctx, input.CallbackToken, nil, failure)
+	} else if input.Error != nil {
 		// Report failure to external activity
 		logger.Info("❌ Reporting failure to external activity", "error", input.Error.Error())
 		err = temporalClient.CompleteActivity(ctx, input.CallbackToken, nil, input.Error)
@@ -0,0 +1,67 @@
+package workflows
+
+import (
+	agentexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
+)
+
+// AgentLimitExceededErrorType is the type of the application error a caller's
+// activity fails with when the execution was stopped by one of the agent's
+// limits (AgentSpec.limits).
+//
+// The value MUST match the error type classified by the workflow-runner.
+const AgentLimitExceededErrorType = "AgentLimitExceeded"
+
+// callbackFailure returns the error type and message used to fail the
+// caller's activity when the execution finished without completing, or empty
+// strings when the caller should receive the result.
+func callbackFailure(execution *agentexecutionv1.AgentExecution) (errorType, message string) {
+	if execution.GetStatus().GetPhase() != agentexecutionv1.ExecutionPhase_EXECUTION_LIMIT_EXCEEDED {
+		return "", ""
+	}
+	message = execution.GetStatus().GetError()
+	if message == "" {
+		message = "agent execution exceeded one of its limits"
+	}
+	return AgentLimitExceededErrorType, message
+}
+
+// callbackResult builds the result used to complete the caller's activity.
+//
+// Shaping happens here, before the completion, because whatever is passed to
+// CompleteActivity is recorded verbatim in the caller's workflow history.
+// Executions with an output schema are returned unchanged: the caller needs
+// the raw messages to validate the structured response.
+func callbackResult(execution *agentexecutionv1.AgentExecution) any {
+	config := execution.GetSpec().GetExecutionConfig()
+	if config.GetOutputSchema() != nil {
+		return execution
+	}
+
+	mode := config.GetCallbackOutput()
+	if mode != agentexecutionv1.CallbackOutputMode_CALLBACK_OUTPUT_FINAL_ONLY &&
+		mode != agentexecutionv1.CallbackOutputMode_CALLBACK_OUTPUT_STREAM_TO_CONTEXT {
+		return execution
+	}
+
+	var final string
+	chunks := []any{}
+	for _, msg := range execution.GetStatus().GetMessages() {
+		if msg.GetType() != agentexecutionv1.MessageType_MESSAGE_AI {
+			continue
+		}
+		final = msg.GetContent()
+		chunks = append(chunks, map[string]any{
+			"text":      msg.GetContent(),
+			"timestamp": msg.GetTimestamp(),
+		})
+	}
+
+	if mode == agentexecutionv1.CallbackOutputMode_CALLBACK_OUTPUT_FINAL_ONLY {
+		return map[string]any{"final": final}
+	}
+
+	return map[string]any{
+		"final":  final,
+		"chunks": chunks,
+	}
+}
@@ -0,0 +1,103 @@
+package workflows
+
+import (
+	"reflect"
+	"testing"
+
+	agentexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func testExecution(mode agentexecutionv1.CallbackOutputMode) *agentexecutionv1.AgentExecution {
+	return &agentexecutionv1.AgentExecution{
+		Metadata: &apiresource.ApiResourceMetadata{Id: "aex-123"},
+		Spec: &agentexecutionv1.AgentExecutionSpec{
+			AgentId:         "agt-1",
+			ExecutionConfig: &agentexecutionv1.ExecutionConfig{CallbackOutput: mode},
+		},
+		Status: &agentexecutionv1.AgentExecutionStatus{
+			Messages: []*agentexecutionv1.AgentMessage{
+				{Type: agentexecutionv1.MessageType_MESSAGE_HUMAN, Content: "go", Timestamp: "t0"},
+				{Type: agentexecutionv1.MessageType_MESSAGE_AI, Content: "thinking", Timestamp: "t1"},
+				{Type: agentexecutionv1.MessageType_MESSAGE_TOOL, Content: "ok", Timestamp: "t2"},
+				{Type: agentexecutionv1.MessageType_MESSAGE_AI, Content: "done", Timestamp: "t3"},
+			},
+		},
+	}
+}
+
+func TestCallbackResult(t *testing.T) {
+	unshaped := testExecution(agentexecutionv1.CallbackOutputMode_CALLBACK_OUTPUT_MODE_UNSPECIFIED)
+	if got := callbackResult(unshaped); got != unshaped {
+		t.Errorf("callbackResult() without output mode = %v, want the execution", got)
+	}
+
+	unknown := testExecution(agentexecutionv1.CallbackOutputMode(99))
+	if got := callbackResult(unknown); got != unknown {
+		t.Errorf("callbackResult() with unknown output mode = %v, want the execution", got)
+	}
+
+	got := callbackResult(testExecution(agentexecutionv1.CallbackOutputMode_CALLBACK_OUTPUT_FINAL_ONLY))
+	want := map[string]any{"final": "done"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("callbackResult(FINAL_ONLY) = %v, want %v", got, want)
+	}
+
+	got = callbackResult(testExecution(agentexecutionv1.CallbackOutputMode_CALLBACK_OUTPUT_STREAM_TO_CONTEXT))
+	want = map[string]any{
+		"final": "done",
+		"chunks": []any{
+			map[string]any{"text": "thinking", "timestamp": "t1"},
+			map[string]any{"text": "done", "timestamp": "t3"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("callbackResult(STREAM_TO_CONTEXT) = %v, want %v", got, want)
+	}
+}
+
+func TestCallbackResult_OutputSchemaKeepsMessages(t *testing.T) {
+	schema, err := structpb.NewStruct(map[string]any{"type": "object"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	execution := testExecution(agentexecutionv1.CallbackOutputMode_CALLBACK_OUTPUT_FINAL_ONLY)
+	execution.Spec.ExecutionConfig.OutputSchema = schema
+
+	if got := callbackResult(execution); got != execution {
+		t.Errorf("callbackResult() with output schema = %v, want the execution", got)
+	}
+}
+
+func TestCallbackResult_NoMessages(t *testing.T) {
+	execution := testExecution(agentexecutionv1.CallbackOutputMode_CALLBACK_OUTPUT_STREAM_TO_CONTEXT)
+	execution.Status = nil
+
+	want := map[string]any{"final": "", "chunks": []any{}}
+	if got := callbackResult(execution); !reflect.DeepEqual(got, want) {
+		t.Errorf("callbackResult() without messages = %v, want %v", got, want)
+	}
+}
+
+func TestCallbackFailure(t *testing.T) {
+	completed := testExecution(agentexecutionv1.CallbackOutputMode_CALLBACK_OUTPUT_MODE_UNSPECIFIED)
+	completed.Status.Phase = agentexecutionv1.ExecutionPhase_EXECUTION_COMPLETED
+	if errorType, message := callbackFailure(completed); errorType != "" || message != "" {
+		t.Errorf("callbackFailure(completed) = %q, %q, want no failure", errorType, message)
+	}
+
+	limited := testExecution(agentexecutionv1.CallbackOutputMode_CALLBACK_OUTPUT_MODE_UNSPECIFIED)
+	limited.Status.Phase = agentexecutionv1.ExecutionPhase_EXECUTION_LIMIT_EXCEEDED
+	limited.Status.Error = "Tool call limit exceeded: attempted 51 tool calls, limit is 50 (max_tool_calls)"
+	errorType, message := callbackFailure(limited)
+	if errorType != AgentLimitExceededErrorType || message != limited.Status.Error {
+		t.Errorf("callbackFailure(limit exceeded) = %q, %q, want %q, %q",
+			errorType, message, AgentLimitExceededErrorType, limited.Status.Error)
+	}
+
+	limited.Status.Error = ""
+	if _, message := callbackFailure(limited); message == "" {
+		t.Error("callbackFailure(limit exceeded) without error message returned an empty message")
+	}
+}
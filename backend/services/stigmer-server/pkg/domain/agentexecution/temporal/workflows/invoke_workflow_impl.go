@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
 	agentexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
 	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/agentexecution/temporal/activities"
 	"go.temporal.io/api/enums/v1"
@@ -73,8 +74,17 @@ func (w *InvokeAgentExecutionWorkflowImpl) Run(ctx workflow.Context, execution *
 
 	// Complete external activity with success (if token provided)
 	if len(callbackToken) > 0 {
-		// Return the execution as the result
-		if err := w.completeExternalActivity(ctx, callbackToken, execution, nil); err != nil {
+		// Executions stopped by an agent limit fail the caller with a typed error
+		if errorType, message := callbackFailure(execution); errorType != "" {
+			if err := w.failExternalActivity(ctx, callbackToken, errorType, message); err != nil {
+				logger.Error("❌ Failed to complete external activity with limit error", "error", err.Error())
+				return err
+			}
+			return nil
+		}
+
+		// Return the execution (shaped per its output mode) as the result
+		if err := w.completeExternalActivity(ctx, callbackToken, callbackResult(execution), nil); err != nil {
 			logger.Error("❌ Failed to complete external activity with success", "error", err.Error())
 			return err
 		}
@@ -101,10 +111,17 @@ func (w *InvokeAgentExecutionWorkflowImpl) executeGraphtonFlow(ctx workflow.Cont
 	activityTaskQueue := w.getActivityTaskQueue(ctx)
 
 	// Step 1: Ensure thread exists (Python activity)
-	logger.Info("Step 1: Ensuring thread", "session_id", sessionID, "agent_id", agentID)
+	// Stateless agents (MEMORY_NONE) get an ephemeral thread so nothing is
+	// persisted to, or read back from, the session thread.
+	threadSessionID := sessionID
+	if isStatelessMemory(execution) {
+		logger.Info("Agent memory is MEMORY_NONE - using ephemeral thread", "session_id", sessionID)
+		threadSessionID = ""
+	}
+	logger.Info("Step 1: Ensuring thread", "session_id", threadSessionID, "agent_id", agentID)
 
 	ensureThreadActivity := activities.NewEnsureThreadActivityStub(ctx, activityTaskQueue)
-	threadID, err := ensureThreadActivity.EnsureThread(sessionID, agentID)
+	threadID, err := ensureThreadActivity.EnsureThread(threadSessionID, agentID)
 	if err != nil {
 		return w.wrapActivityError("EnsureThread", err)
 	}
@@ -131,6 +148,10 @@ func (w *InvokeAgentExecutionWorkflowImpl) executeGraphtonFlow(ctx workflow.Cont
 		return fmt.Errorf("python activity returned null status - this should never happen")
 	}
 
+	// Keep the final status on the execution so callback completion carries
+	// the agent's messages
+	execution.Status = finalStatus
+
 	logger.Info("✅ Graphton execution completed - final status received",
 		"messages", len(finalStatus.GetMessages()),
 		"tool_calls", len(finalStatus.GetToolCalls()),
@@ -139,6 +160,13 @@ func (w *InvokeAgentExecutionWorkflowImpl) executeGraphtonFlow(ctx workflow.Cont
 	return nil
 }
 
+// isStatelessMemory reports whether the execution's resolved memory config
+// disables conversation history.
+func isStatelessMemory(execution *agentexecutionv1.AgentExecution) bool {
+	strategy := execution.GetSpec().GetExecutionConfig().GetMemory().GetStrategy()
+	return strategy == agentv1.MemoryStrategy_MEMORY_NONE
+}
+
 // wrapActivityError wraps activity errors with helpful context for troubleshooting.
 //
 // This helps distinguish between different failure types:
@@ -307,6 +335,42 @@ func (w *InvokeAgentExecutionWorkflowImpl) completeExternalActivity(
 		"has_result", result != nil,
 		"has_error", err != nil)
 
+	return w.executeCompleteExternalActivity(ctx, &activities.CompleteExternalActivityInput{
+		CallbackToken: callbackToken,
+		Result:        result,
+		Error:         err,
+	})
+}
+
+// failExternalActivity fails an external Temporal activity with a
+// non-retryable application error of the given type, so the caller can
+// classify the failure (e.g. AgentLimitExceededErrorType).
+func (w *InvokeAgentExecutionWorkflowImpl) failExternalActivity(
+	ctx workflow.Context,
+	callbackToken []byte,
+	errorType string,
+	message string,
+) error {
+	logger := workflow.GetLogger(ctx)
+
+	logger.Info("📞 Failing external activity via system activity",
+		"token_length", len(callbackToken),
+		"error_type", errorType)
+
+	return w.executeCompleteExternalActivity(ctx, &activities.CompleteExternalActivityInput{
+		CallbackToken: callbackToken,
+		ErrorType:     errorType,
+		ErrorMessage:  message,
+	})
+}
+
+// executeCompleteExternalActivity runs the CompleteExternalActivity system activity.
+func (w *InvokeAgentExecutionWorkflowImpl) executeCompleteExternalActivity(
+	ctx workflow.Context,
+	input *activities.CompleteExternalActivityInput,
+) error {
+	logger := workflow.GetLogger(ctx)
+
 	// Create activity options with appropriate timeouts
 	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
 		StartToCloseTimeout: 1 * time.Minute, // System activity should be fast
@@ -317,12 +381,6 @@ func (w *InvokeAgentExecutionWorkflowImpl) completeExternalActivity(
 	})
 
 	// Call the system activity to complete the external activity
-	input := &activities.CompleteExternalActivityInput{
-		CallbackToken: callbackToken,
-		Result:        result,
-		Error:         err,
-	}
-
 	completionErr := workflow.ExecuteActivity(activityCtx, activities.CompleteExternalActivityName, input).Get(activityCtx, nil)
 	if completionErr != nil {
 		logger.Error("❌ System activity failed to complete external activity",
@@ -2,14 +2,19 @@ package agentinstance
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
 	agentinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentinstance/v1"
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
 	"github.com/stigmer/stigmer/backend/libs/go/store"
 	"github.com/stigmer/stigmer/backend/libs/go/store/sqlite"
 	apiresourceinterceptor "github.com/stigmer/stigmer/backend/libs/go/grpc/interceptors/apiresource"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // contextWithAgentInstanceKind creates a context with the agent instance resource kind injected
@@ -140,6 +145,80 @@ func TestAgentInstanceController_Create(t *testing.T) {
 
 }
 
+func TestAgentInstanceController_Create_TypedEnvironmentValues(t *testing.T) {
+	controller, store := setupTestController(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	minWorkers, maxWorkers := int64(1), int64(16)
+	agent := &agentv1.Agent{
+		Metadata: &apiresource.ApiResourceMetadata{Id: "agent-typed", Name: "Typed Agent"},
+		Spec: &agentv1.AgentSpec{
+			EnvSpec: &environmentv1.EnvironmentSpec{
+				Data: map[string]*environmentv1.EnvironmentValue{
+					"WORKERS": {Type: &environmentv1.EnvironmentValueType{
+						Kind: environmentv1.EnvironmentValueKind_ENV_VALUE_INT,
+						Min:  &minWorkers,
+						Max:  &maxWorkers,
+					}},
+				},
+			},
+		},
+	}
+	if err := store.SaveResource(ctx, apiresourcekind.ApiResourceKind_agent, "agent-typed", agent); err != nil {
+		t.Fatalf("failed to save agent: %v", err)
+	}
+
+	for slug, workers := range map[string]string{"small": "4", "huge": "64"} {
+		env := &environmentv1.Environment{
+			Metadata: &apiresource.ApiResourceMetadata{Id: "env-" + slug, Slug: slug},
+			Spec: &environmentv1.EnvironmentSpec{
+				Data: map[string]*environmentv1.EnvironmentValue{"WORKERS": {Value: workers}},
+			},
+		}
+		if err := store.SaveResource(ctx, apiresourcekind.ApiResourceKind_environment, env.Metadata.Id, env); err != nil {
+			t.Fatalf("failed to save environment: %v", err)
+		}
+	}
+
+	newInstance := func(name, envSlug string) *agentinstancev1.AgentInstance {
+		return &agentinstancev1.AgentInstance{
+			ApiVersion: "agentic.stigmer.ai/v1",
+			Kind:       "AgentInstance",
+			Metadata:   &apiresource.ApiResourceMetadata{Name: name},
+			Spec: &agentinstancev1.AgentInstanceSpec{
+				AgentId: "agent-typed",
+				EnvironmentRefs: []*apiresource.ApiResourceReference{
+					{Kind: apiresourcekind.ApiResourceKind_environment, Slug: envSlug},
+				},
+			},
+		}
+	}
+
+	t.Run("value within constraints", func(t *testing.T) {
+		if _, err := controller.Create(contextWithAgentInstanceKind(), newInstance("Small Instance", "small")); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	})
+
+	t.Run("value violating constraints", func(t *testing.T) {
+		_, err := controller.Create(contextWithAgentInstanceKind(), newInstance("Huge Instance", "huge"))
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Create error = %v, want InvalidArgument", err)
+		}
+		if msg := status.Convert(err).Message(); !strings.Contains(msg, "WORKERS") || !strings.Contains(msg, "int[1..16]") {
+			t.Errorf("error message %q should name the variable and constraint", msg)
+		}
+	})
+
+	t.Run("unknown environment", func(t *testing.T) {
+		_, err := controller.Create(contextWithAgentInstanceKind(), newInstance("Missing Instance", "missing"))
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("Create error = %v, want NotFound", err)
+		}
+	})
+}
+
 func TestAgentInstanceController_Get(t *testing.T) {
 	controller, store := setupTestController(t)
 	defer store.Close()
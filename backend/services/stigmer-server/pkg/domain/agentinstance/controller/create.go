@@ -2,10 +2,17 @@ package agentinstance
 
 import (
 	"context"
+	"errors"
 
+	"github.com/rs/zerolog/log"
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
 	agentinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentinstance/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline/steps"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/environment/valuetype"
 )
 
 // Create creates a new agent instance using the pipeline framework
@@ -13,9 +20,10 @@ import (
 // Pipeline:
 // 1. ValidateFieldConstraints - Validate proto field constraints using buf validate
 // 2. ResolveSlug - Generate slug from metadata.name
-// 3. CheckDuplicate - Verify no duplicate exists
-// 4. SetDefaults - Set ID, kind, api_version, timestamps
-// 5. Persist - Save agent instance to repository
+// 3. ValidateEnvironmentValues - Check environment values against the agent's typed variables
+// 4. CheckDuplicate - Verify no duplicate exists
+// 5. SetDefaults - Set ID, kind, api_version, timestamps
+// 6. Persist - Save agent instance to repository
 func (c *AgentInstanceController) Create(ctx context.Context, instance *agentinstancev1.AgentInstance) (*agentinstancev1.AgentInstance, error) {
 	reqCtx := pipeline.NewRequestContext(ctx, instance)
 
@@ -35,8 +43,60 @@ func (c *AgentInstanceController) buildCreatePipeline() *pipeline.Pipeline[*agen
 	return pipeline.NewPipeline[*agentinstancev1.AgentInstance]("agent-instance-create").
 		AddStep(steps.NewValidateProtoStep[*agentinstancev1.AgentInstance]()).         // 1. Validate field constraints
 		AddStep(steps.NewResolveSlugStep[*agentinstancev1.AgentInstance]()).           // 2. Resolve slug
-		AddStep(steps.NewCheckDuplicateStep[*agentinstancev1.AgentInstance](c.store)). // 3. Check duplicate
-		AddStep(steps.NewBuildNewStateStep[*agentinstancev1.AgentInstance]()).         // 4. Build new state
-		AddStep(steps.NewPersistStep[*agentinstancev1.AgentInstance](c.store)).        // 5. Persist agent instance
+		AddStep(newValidateEnvironmentValuesStep(c.store)).                            // 3. Validate environment values
+		AddStep(steps.NewCheckDuplicateStep[*agentinstancev1.AgentInstance](c.store)). // 4. Check duplicate
+		AddStep(steps.NewBuildNewStateStep[*agentinstancev1.AgentInstance]()).         // 5. Build new state
+		AddStep(steps.NewPersistStep[*agentinstancev1.AgentInstance](c.store)).        // 6. Persist agent instance
 		Build()
 }
+
+// validateEnvironmentValuesStep checks the values supplied by the referenced
+// environments against the typed variables declared in the agent's env_spec.
+//
+// Agents without typed variables are skipped, as are instances whose agent
+// is not in the store (agent_id is not otherwise enforced on creation).
+type validateEnvironmentValuesStep struct {
+	store store.Store
+}
+
+func newValidateEnvironmentValuesStep(s store.Store) *validateEnvironmentValuesStep {
+	return &validateEnvironmentValuesStep{store: s}
+}
+
+func (s *validateEnvironmentValuesStep) Name() string {
+	return "ValidateEnvironmentValues"
+}
+
+func (s *validateEnvironmentValuesStep) Execute(ctx *pipeline.RequestContext[*agentinstancev1.AgentInstance]) error {
+	spec := ctx.Input().GetSpec()
+
+	agent := &agentv1.Agent{}
+	if err := s.store.GetResource(ctx.Context(), apiresourcekind.ApiResourceKind_agent, spec.GetAgentId(), agent); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			log.Debug().
+				Str("agent_id", spec.GetAgentId()).
+				Msg("Agent not found, skipping environment value validation")
+			return nil
+		}
+		return grpclib.InternalError(err, "failed to load agent")
+	}
+
+	declared := agent.GetSpec().GetEnvSpec()
+	if !valuetype.HasTypedValues(declared) {
+		return nil
+	}
+
+	values, err := valuetype.ResolveValues(ctx.Context(), s.store, spec.GetEnvironmentRefs())
+	if err != nil {
+		return err
+	}
+
+	if err := valuetype.Validate(declared, values); err != nil {
+		log.Warn().
+			Err(err).
+			Str("agent_id", spec.GetAgentId()).
+			Msg("Environment value does not match the declared type")
+		return grpclib.InvalidArgumentError(err.Error())
+	}
+	return nil
+}
@@ -14,8 +14,9 @@ import (
 // 1. ValidateProto - Validate proto field constraints using buf validate
 // 2. ResolveSlug - Generate slug from metadata.name
 // 3. LoadExisting - Load existing agent instance from repository by ID
-// 4. BuildUpdateState - Merge spec, preserve IDs, update timestamps, clear computed fields
-// 5. Persist - Save updated agent instance to repository
+// 4. CheckResourceVersion - Reject updates based on a stale metadata.resource_version (FAILED_PRECONDITION)
+// 5. BuildUpdateState - Merge spec, preserve IDs, update timestamps, clear computed fields
+// 6. Persist - Save updated agent instance to repository
 //
 // Note: Compared to Stigmer Cloud, OSS excludes:
 // - Authorize step (no multi-tenant auth in OSS)
@@ -38,10 +39,11 @@ func (c *AgentInstanceController) buildUpdatePipeline() *pipeline.Pipeline[*agen
 	// api_resource_kind is automatically extracted from proto service descriptor
 	// by the apiresource interceptor and injected into request context
 	return pipeline.NewPipeline[*agentinstancev1.AgentInstance]("agent-instance-update").
-		AddStep(steps.NewValidateProtoStep[*agentinstancev1.AgentInstance]()).       // 1. Validate field constraints
-		AddStep(steps.NewResolveSlugStep[*agentinstancev1.AgentInstance]()).         // 2. Resolve slug
-		AddStep(steps.NewLoadExistingStep[*agentinstancev1.AgentInstance](c.store)). // 3. Load existing instance
-		AddStep(steps.NewBuildUpdateStateStep[*agentinstancev1.AgentInstance]()).    // 4. Build updated state
-		AddStep(steps.NewPersistStep[*agentinstancev1.AgentInstance](c.store)).      // 5. Persist instance
+		AddStep(steps.NewValidateProtoStep[*agentinstancev1.AgentInstance]()).        // 1. Validate field constraints
+		AddStep(steps.NewResolveSlugStep[*agentinstancev1.AgentInstance]()).          // 2. Resolve slug
+		AddStep(steps.NewLoadExistingStep[*agentinstancev1.AgentInstance](c.store)).  // 3. Load existing instance
+		AddStep(steps.NewCheckResourceVersionStep[*agentinstancev1.AgentInstance]()). // 4. Check resource version
+		AddStep(steps.NewBuildUpdateStateStep[*agentinstancev1.AgentInstance]()).     // 5. Build updated state
+		AddStep(steps.NewPersistStep[*agentinstancev1.AgentInstance](c.store)).       // 6. Persist instance
 		Build()
 }
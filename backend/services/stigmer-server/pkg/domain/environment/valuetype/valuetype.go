@@ -0,0 +1,193 @@
+// Package valuetype validates environment values against the value types
+// declared by Agent and Workflow environment specs.
+//
+// Agents and workflows declare typed variables (int, bool, enum) in their
+// env_spec. When an AgentInstance or WorkflowInstance is created, the values
+// supplied by its referenced environments are checked against those types.
+package valuetype
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	apiresourcepb "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// ValueError reports a supplied environment value that violates the type
+// declared for its variable.
+type ValueError struct {
+	// Variable is the name of the environment variable.
+	Variable string
+
+	// Constraint describes the declared type, e.g. "int[1..16]" or "enum(fast, thorough)".
+	Constraint string
+
+	// Reason explains why the value does not satisfy the constraint.
+	Reason string
+}
+
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("environment variable %s: value violates constraint %s: %s", e.Variable, e.Constraint, e.Reason)
+}
+
+// HasTypedValues reports whether the spec declares at least one typed variable.
+func HasTypedValues(declared *environmentv1.EnvironmentSpec) bool {
+	for _, v := range declared.GetData() {
+		if isTyped(v.GetType()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the supplied values against the types declared in the spec.
+// Variables without a type, or without a supplied value, are not checked.
+// Returns a *ValueError for the first violation, in variable name order.
+func Validate(declared *environmentv1.EnvironmentSpec, supplied map[string]*environmentv1.EnvironmentValue) error {
+	names := make([]string, 0, len(declared.GetData()))
+	for name := range declared.GetData() {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		valueType := declared.GetData()[name].GetType()
+		value, ok := supplied[name]
+		if !ok || !isTyped(valueType) {
+			continue
+		}
+		if reason := check(valueType, value.GetValue()); reason != "" {
+			if value.GetIsSecret() || declared.GetData()[name].GetIsSecret() {
+				reason = "secret value rejected"
+			}
+			return &ValueError{
+				Variable:   name,
+				Constraint: Describe(valueType),
+				Reason:     reason,
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveValues loads the referenced environments and merges their values.
+// Environments are merged in order: later environments override earlier ones.
+// Returns a NotFound error when a referenced environment does not exist.
+func ResolveValues(ctx context.Context, s store.Store, refs []*apiresourcepb.ApiResourceReference) (map[string]*environmentv1.EnvironmentValue, error) {
+	values := make(map[string]*environmentv1.EnvironmentValue)
+	if len(refs) == 0 {
+		return values, nil
+	}
+
+	// Note: lists all environments and filters by slug, like LoadByReferenceStep.
+	// Acceptable for local/OSS usage.
+	resources, err := s.ListResources(ctx, apiresourcekind.ApiResourceKind_environment)
+	if err != nil {
+		return nil, grpclib.InternalError(err, "failed to list environment resources")
+	}
+
+	environments := make([]*environmentv1.Environment, 0, len(resources))
+	for _, data := range resources {
+		env := &environmentv1.Environment{}
+		if err := proto.Unmarshal(data, env); err != nil {
+			continue
+		}
+		environments = append(environments, env)
+	}
+
+	for _, ref := range refs {
+		env := findBySlug(environments, ref.GetSlug(), ref.GetOrg())
+		if env == nil {
+			return nil, grpclib.NotFoundError("Environment", ref.GetSlug())
+		}
+		for name, value := range env.GetSpec().GetData() {
+			values[name] = value
+		}
+	}
+	return values, nil
+}
+
+// Describe formats a value type with its constraints, e.g. "int[1..16]".
+func Describe(t *environmentv1.EnvironmentValueType) string {
+	switch t.GetKind() {
+	case environmentv1.EnvironmentValueKind_ENV_VALUE_INT:
+		if t.Min == nil && t.Max == nil {
+			return "int"
+		}
+		return "int" + rangeString(t)
+	case environmentv1.EnvironmentValueKind_ENV_VALUE_BOOL:
+		return "bool"
+	case environmentv1.EnvironmentValueKind_ENV_VALUE_ENUM:
+		return fmt.Sprintf("enum(%s)", strings.Join(t.GetAllowedValues(), ", "))
+	default:
+		return "string"
+	}
+}
+
+// check returns why value does not satisfy the type, or "" when it does.
+func check(t *environmentv1.EnvironmentValueType, value string) string {
+	switch t.GetKind() {
+	case environmentv1.EnvironmentValueKind_ENV_VALUE_INT:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Sprintf("%q is not an integer", value)
+		}
+		if (t.Min != nil && n < t.GetMin()) || (t.Max != nil && n > t.GetMax()) {
+			return fmt.Sprintf("%d is outside the range %s", n, rangeString(t))
+		}
+	case environmentv1.EnvironmentValueKind_ENV_VALUE_BOOL:
+		if value != "true" && value != "false" {
+			return fmt.Sprintf("%q is not a boolean (true or false)", value)
+		}
+	case environmentv1.EnvironmentValueKind_ENV_VALUE_ENUM:
+		if !slices.Contains(t.GetAllowedValues(), value) {
+			return fmt.Sprintf("%q is not an allowed value", value)
+		}
+	}
+	return ""
+}
+
+// isTyped reports whether the type constrains values beyond any string.
+func isTyped(t *environmentv1.EnvironmentValueType) bool {
+	switch t.GetKind() {
+	case environmentv1.EnvironmentValueKind_ENV_VALUE_INT,
+		environmentv1.EnvironmentValueKind_ENV_VALUE_BOOL,
+		environmentv1.EnvironmentValueKind_ENV_VALUE_ENUM:
+		return true
+	}
+	return false
+}
+
+// rangeString formats the bounds of an int type, e.g. "[1..16]".
+func rangeString(t *environmentv1.EnvironmentValueType) string {
+	bound := func(b *int64) string {
+		if b == nil {
+			return ""
+		}
+		return strconv.FormatInt(*b, 10)
+	}
+	return fmt.Sprintf("[%s..%s]", bound(t.Min), bound(t.Max))
+}
+
+// findBySlug returns the environment with the given slug, filtered by org when set.
+func findBySlug(environments []*environmentv1.Environment, slug, org string) *environmentv1.Environment {
+	for _, env := range environments {
+		metadata := env.GetMetadata()
+		if metadata.GetSlug() != slug {
+			continue
+		}
+		if org != "" && metadata.GetOrg() != org {
+			continue
+		}
+		return env
+	}
+	return nil
+}
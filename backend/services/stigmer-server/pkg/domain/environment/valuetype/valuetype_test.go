@@ -0,0 +1,164 @@
+package valuetype
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	apiresourcepb "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/backend/libs/go/store/sqlite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func int64Ptr(n int64) *int64 {
+	return &n
+}
+
+func declaredSpec() *environmentv1.EnvironmentSpec {
+	return &environmentv1.EnvironmentSpec{
+		Data: map[string]*environmentv1.EnvironmentValue{
+			"WORKERS": {Type: &environmentv1.EnvironmentValueType{
+				Kind: environmentv1.EnvironmentValueKind_ENV_VALUE_INT,
+				Min:  int64Ptr(1),
+				Max:  int64Ptr(16),
+			}},
+			"REVIEW_MODE": {Type: &environmentv1.EnvironmentValueType{
+				Kind:          environmentv1.EnvironmentValueKind_ENV_VALUE_ENUM,
+				AllowedValues: []string{"fast", "thorough"},
+			}},
+			"DRY_RUN": {Type: &environmentv1.EnvironmentValueType{
+				Kind: environmentv1.EnvironmentValueKind_ENV_VALUE_BOOL,
+			}},
+			"API_URL": {},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		values         map[string]string
+		wantVariable   string
+		wantConstraint string
+	}{
+		{
+			name:   "valid values",
+			values: map[string]string{"WORKERS": "8", "REVIEW_MODE": "fast", "DRY_RUN": "false", "API_URL": "anything"},
+		},
+		{
+			name:   "missing values are not checked",
+			values: map[string]string{},
+		},
+		{
+			name:           "int out of range",
+			values:         map[string]string{"WORKERS": "32"},
+			wantVariable:   "WORKERS",
+			wantConstraint: "int[1..16]",
+		},
+		{
+			name:           "not an int",
+			values:         map[string]string{"WORKERS": "many"},
+			wantVariable:   "WORKERS",
+			wantConstraint: "int[1..16]",
+		},
+		{
+			name:           "enum value not allowed",
+			values:         map[string]string{"REVIEW_MODE": "slow"},
+			wantVariable:   "REVIEW_MODE",
+			wantConstraint: "enum(fast, thorough)",
+		},
+		{
+			name:           "invalid bool",
+			values:         map[string]string{"DRY_RUN": "yes"},
+			wantVariable:   "DRY_RUN",
+			wantConstraint: "bool",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			supplied := make(map[string]*environmentv1.EnvironmentValue)
+			for name, value := range tt.values {
+				supplied[name] = &environmentv1.EnvironmentValue{Value: value}
+			}
+
+			err := Validate(declaredSpec(), supplied)
+			if tt.wantVariable == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			var valueErr *ValueError
+			if !errors.As(err, &valueErr) {
+				t.Fatalf("Validate() error = %v, want *ValueError", err)
+			}
+			if valueErr.Variable != tt.wantVariable || valueErr.Constraint != tt.wantConstraint {
+				t.Errorf("ValueError = {%s, %s}, want {%s, %s}",
+					valueErr.Variable, valueErr.Constraint, tt.wantVariable, tt.wantConstraint)
+			}
+		})
+	}
+}
+
+func TestValidate_SecretValueNotEchoed(t *testing.T) {
+	err := Validate(declaredSpec(), map[string]*environmentv1.EnvironmentValue{
+		"WORKERS": {Value: "s3cr3t", IsSecret: true},
+	})
+	if err == nil || strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("Validate() error = %v, want an error without the secret value", err)
+	}
+}
+
+func TestResolveValues(t *testing.T) {
+	s, err := sqlite.NewStore(t.TempDir() + "/test.sqlite")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer s.Close()
+	ctx := context.Background()
+
+	for _, env := range []*environmentv1.Environment{
+		{
+			Metadata: &apiresourcepb.ApiResourceMetadata{Id: "env-base", Slug: "base"},
+			Spec: &environmentv1.EnvironmentSpec{Data: map[string]*environmentv1.EnvironmentValue{
+				"WORKERS":     {Value: "4"},
+				"REVIEW_MODE": {Value: "fast"},
+			}},
+		},
+		{
+			Metadata: &apiresourcepb.ApiResourceMetadata{Id: "env-prod", Slug: "prod"},
+			Spec: &environmentv1.EnvironmentSpec{Data: map[string]*environmentv1.EnvironmentValue{
+				"WORKERS": {Value: "12"},
+			}},
+		},
+	} {
+		if err := s.SaveResource(ctx, apiresourcekind.ApiResourceKind_environment, env.Metadata.Id, env); err != nil {
+			t.Fatalf("SaveResource() error = %v", err)
+		}
+	}
+
+	values, err := ResolveValues(ctx, s, []*apiresourcepb.ApiResourceReference{
+		{Slug: "base"},
+		{Slug: "prod"},
+	})
+	if err != nil {
+		t.Fatalf("ResolveValues() error = %v", err)
+	}
+	if got := values["WORKERS"].GetValue(); got != "12" {
+		t.Errorf("WORKERS = %q, want later environment to override (12)", got)
+	}
+	if got := values["REVIEW_MODE"].GetValue(); got != "fast" {
+		t.Errorf("REVIEW_MODE = %q, want fast", got)
+	}
+
+	_, err = ResolveValues(ctx, s, []*apiresourcepb.ApiResourceReference{{Slug: "missing"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("ResolveValues() error = %v, want NotFound", err)
+	}
+}
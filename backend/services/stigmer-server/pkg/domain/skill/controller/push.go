@@ -417,6 +417,12 @@ func (s *ArchiveCurrentSkillStep) archiveSkill(ctx context.Context, skill *skill
 // 3. Sets audit fields using common library helpers:
 //   - For create: SetAuditFieldsForCreate (sets created_at = updated_at = now)
 //   - For update: Preserves existing audit, then updates with SetAuditFieldsForUpdate
+//
+// 4. Sets metadata.resource_version (1 on create, existing + 1 on update)
+//
+// Unlike Update RPCs, push does not require the caller's resource version:
+// every pushed artifact is archived by content hash, so concurrent pushes
+// never lose a version.
 type PopulateSkillFieldsStep struct{}
 
 func (c *SkillController) newPopulateSkillFieldsStep() *PopulateSkillFieldsStep {
@@ -447,6 +453,7 @@ func (s *PopulateSkillFieldsStep) Execute(ctx *pipeline.RequestContext[*skillv1.
 		if err := steps.SetAuditFieldsForCreate(skill); err != nil {
 			return fmt.Errorf("failed to set audit fields for create: %w", err)
 		}
+		skill.Metadata.ResourceVersion = 1
 	} else {
 		// Updating existing skill - preserve existing audit, then update
 		existingSkill := ctx.Get(ExistingSkillKey).(*skillv1.Skill)
@@ -467,6 +474,7 @@ func (s *PopulateSkillFieldsStep) Execute(ctx *pipeline.RequestContext[*skillv1.
 		if err := steps.SetAuditFieldsForUpdate(skill); err != nil {
 			return fmt.Errorf("failed to set audit fields for update: %w", err)
 		}
+		skill.Metadata.ResourceVersion = existingSkill.Metadata.GetResourceVersion() + 1
 	}
 
 	return nil
@@ -3,10 +3,12 @@ package workflow
 import (
 	"context"
 
+	"github.com/rs/zerolog/log"
 	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline/steps"
 	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	workflowinstancetemporal "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowinstance/temporal"
 )
 
 // Delete deletes a workflow by ID using the pipeline pattern
@@ -15,7 +17,8 @@ import (
 // 1. ValidateProto - Validate proto field constraints (workflow ID wrapper)
 // 2. ExtractResourceId - Extract ID from WorkflowId.Value wrapper
 // 3. LoadExistingForDelete - Load workflow from database (stores in context)
-// 4. DeleteResource - Delete workflow from database
+// 4. DeleteInstanceSchedules - Remove the Temporal schedules of the workflow's instances
+// 5. DeleteResource - Delete workflow from database
 func (c *WorkflowController) Delete(ctx context.Context, workflowId *workflowv1.WorkflowId) (*workflowv1.Workflow, error) {
 	// Create request context with the ID wrapper
 	reqCtx := pipeline.NewRequestContext(ctx, workflowId)
@@ -41,6 +44,39 @@ func (c *WorkflowController) buildDeletePipeline() *pipeline.Pipeline[*workflowv
 		AddStep(steps.NewValidateProtoStep[*workflowv1.WorkflowId]()).                                 // 1. Validate field constraints
 		AddStep(steps.NewExtractResourceIdStep[*workflowv1.WorkflowId]()).                             // 2. Extract ID from wrapper
 		AddStep(steps.NewLoadExistingForDeleteStep[*workflowv1.WorkflowId, *workflowv1.Workflow](c.store)). // 3. Load workflow
-		AddStep(steps.NewDeleteResourceStep[*workflowv1.WorkflowId](c.store)).                         // 4. Delete from database
+		AddStep(newDeleteInstanceSchedulesStep(c.scheduleManager)).                                   // 4. Delete instance schedules
+		AddStep(steps.NewDeleteResourceStep[*workflowv1.WorkflowId](c.store)).                         // 5. Delete from database
 		Build()
 }
+
+// deleteInstanceSchedulesStep removes the Temporal schedules of the workflow's
+// instances, so instances left behind by the deletion are no longer triggered.
+//
+// Skipped with a warning when Temporal is not connected.
+type deleteInstanceSchedulesStep struct {
+	scheduleManager *workflowinstancetemporal.ScheduleManager
+}
+
+func newDeleteInstanceSchedulesStep(scheduleManager *workflowinstancetemporal.ScheduleManager) *deleteInstanceSchedulesStep {
+	return &deleteInstanceSchedulesStep{scheduleManager: scheduleManager}
+}
+
+func (s *deleteInstanceSchedulesStep) Name() string {
+	return "DeleteInstanceSchedules"
+}
+
+func (s *deleteInstanceSchedulesStep) Execute(ctx *pipeline.RequestContext[*workflowv1.WorkflowId]) error {
+	workflowID := ctx.Input().GetValue()
+
+	if s.scheduleManager == nil {
+		log.Warn().
+			Str("workflow_id", workflowID).
+			Msg("Schedule manager not available - skipping instance schedule deletion (Temporal not connected)")
+		return nil
+	}
+
+	if err := s.scheduleManager.DeleteWorkflow(ctx.Context(), workflowID); err != nil {
+		return grpclib.InternalError(err, "failed to delete workflow instance schedules")
+	}
+	return nil
+}
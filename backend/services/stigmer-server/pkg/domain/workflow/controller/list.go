@@ -0,0 +1,77 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxListPageSize is the largest page size List returns; larger requests are clamped.
+// It is also the page size used when the request does not set one.
+const maxListPageSize = 100
+
+// List retrieves workflows in creation order, one page at a time
+//
+// Pagination:
+// - page_size limits the page (defaults to and is clamped to 100)
+// - page_token resumes after the previous page (next_page_token of the response)
+//
+// Label selector:
+// - label_selector keeps workflows whose metadata.labels contain every key=value pair
+// - Labels are matched by the store's label index, so only matching workflows are loaded
+//
+// Note: Compared to Stigmer Cloud, OSS excludes:
+// - IAM Policy authorization filtering (no multi-tenant auth)
+func (c *WorkflowController) List(ctx context.Context, req *workflowv1.ListWorkflowsRequest) (*workflowv1.WorkflowList, error) {
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, grpclib.InvalidArgumentError("page_size must not be negative")
+	}
+	if pageSize == 0 || pageSize > maxListPageSize {
+		pageSize = maxListPageSize
+	}
+
+	for key := range req.GetLabelSelector() {
+		if key == "" {
+			return nil, grpclib.InvalidArgumentError("label_selector keys must not be empty")
+		}
+	}
+
+	page, err := c.store.ListResourcesPage(ctx, apiresourcekind.ApiResourceKind_workflow, store.ListOptions{
+		PageSize:  pageSize,
+		PageToken: req.GetPageToken(),
+		Labels:    req.GetLabelSelector(),
+	})
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidPageToken) {
+			return nil, grpclib.InvalidArgumentError("invalid page_token")
+		}
+		return nil, grpclib.InternalError(err, "failed to list workflows")
+	}
+
+	return &workflowv1.WorkflowList{
+		TotalPages:    int32((page.TotalCount + pageSize - 1) / pageSize),
+		Entries:       unmarshalWorkflows(page.Items),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// unmarshalWorkflows decodes stored workflows, skipping invalid entries.
+func unmarshalWorkflows(data [][]byte) []*workflowv1.Workflow {
+	workflows := make([]*workflowv1.Workflow, 0, len(data))
+	for _, d := range data {
+		workflow := &workflowv1.Workflow{}
+		if err := proto.Unmarshal(d, workflow); err != nil {
+			log.Warn().Err(err).Msg("Failed to unmarshal workflow, skipping")
+			continue
+		}
+		workflows = append(workflows, workflow)
+	}
+	return workflows
+}
@@ -3,9 +3,12 @@ package workflow
 import (
 	"context"
 
+	"github.com/rs/zerolog/log"
 	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline/steps"
+	workflowinstancetemporal "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowinstance/temporal"
 )
 
 // Update updates an existing workflow using the pipeline framework
@@ -15,8 +18,10 @@ import (
 // 2. ValidateWorkflowSpec - Validate workflow via Temporal (Layer 2: Go converts + validates - SSOT)
 // 3. ResolveSlug - Generate slug from metadata.name
 // 4. LoadExisting - Load existing workflow from repository to verify it exists
-// 5. BuildUpdateState - Merge spec, preserve IDs and status, update audit timestamps
-// 6. Persist - Save updated workflow to repository
+// 5. CheckResourceVersion - Reject updates based on a stale metadata.resource_version (FAILED_PRECONDITION)
+// 6. BuildUpdateState - Merge spec, preserve IDs and status, update audit timestamps
+// 7. Persist - Save updated workflow to repository
+// 8. SyncInstanceSchedules - Update the Temporal schedules of the workflow's instances
 //
 // Note: Compared to Stigmer Cloud, OSS excludes:
 // - Authorize step (no multi-tenant auth in OSS)
@@ -37,11 +42,46 @@ func (c *WorkflowController) Update(ctx context.Context, workflow *workflowv1.Wo
 // buildUpdatePipeline constructs the pipeline for workflow update
 func (c *WorkflowController) buildUpdatePipeline() *pipeline.Pipeline[*workflowv1.Workflow] {
 	return pipeline.NewPipeline[*workflowv1.Workflow]("workflow-update").
-		AddStep(steps.NewValidateProtoStep[*workflowv1.Workflow]()).       // 1. Validate field constraints (Layer 1)
-		AddStep(newValidateWorkflowSpecStep(c.validator)).                 // 2. Validate via Temporal (Layer 2: Go converts + validates - SSOT)
-		AddStep(steps.NewResolveSlugStep[*workflowv1.Workflow]()).         // 3. Resolve slug
-		AddStep(steps.NewLoadExistingStep[*workflowv1.Workflow](c.store)). // 4. Load existing workflow
-		AddStep(steps.NewBuildUpdateStateStep[*workflowv1.Workflow]()).    // 5. Build updated state (merge spec, preserve status, update audit)
-		AddStep(steps.NewPersistStep[*workflowv1.Workflow](c.store)).      // 6. Persist workflow
+		AddStep(steps.NewValidateProtoStep[*workflowv1.Workflow]()).        // 1. Validate field constraints (Layer 1)
+		AddStep(newValidateWorkflowSpecStep(c.validator)).                  // 2. Validate via Temporal (Layer 2: Go converts + validates - SSOT)
+		AddStep(steps.NewResolveSlugStep[*workflowv1.Workflow]()).          // 3. Resolve slug
+		AddStep(steps.NewLoadExistingStep[*workflowv1.Workflow](c.store)).  // 4. Load existing workflow
+		AddStep(steps.NewCheckResourceVersionStep[*workflowv1.Workflow]()). // 5. Check resource version
+		AddStep(steps.NewBuildUpdateStateStep[*workflowv1.Workflow]()).     // 6. Build updated state (merge spec, preserve status, update audit)
+		AddStep(steps.NewPersistStep[*workflowv1.Workflow](c.store)).       // 7. Persist workflow
+		AddStep(newSyncInstanceSchedulesStep(c.scheduleManager)).           // 8. Sync instance schedules
 		Build()
 }
+
+// syncInstanceSchedulesStep updates the Temporal schedules of the workflow's
+// instances after the spec changes: schedules are created, updated or removed
+// to match spec.schedule.
+//
+// Skipped with a warning when Temporal is not connected.
+type syncInstanceSchedulesStep struct {
+	scheduleManager *workflowinstancetemporal.ScheduleManager
+}
+
+func newSyncInstanceSchedulesStep(scheduleManager *workflowinstancetemporal.ScheduleManager) *syncInstanceSchedulesStep {
+	return &syncInstanceSchedulesStep{scheduleManager: scheduleManager}
+}
+
+func (s *syncInstanceSchedulesStep) Name() string {
+	return "SyncInstanceSchedules"
+}
+
+func (s *syncInstanceSchedulesStep) Execute(ctx *pipeline.RequestContext[*workflowv1.Workflow]) error {
+	workflow := ctx.NewState()
+
+	if s.scheduleManager == nil {
+		log.Warn().
+			Str("workflow_id", workflow.GetMetadata().GetId()).
+			Msg("Schedule manager not available - skipping instance schedule sync (Temporal not connected)")
+		return nil
+	}
+
+	if err := s.scheduleManager.SyncWorkflow(ctx.Context(), workflow); err != nil {
+		return grpclib.InternalError(err, "failed to sync workflow instance schedules")
+	}
+	return nil
+}
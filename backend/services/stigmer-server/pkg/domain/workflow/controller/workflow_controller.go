@@ -4,6 +4,7 @@ import (
 	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
 	"github.com/stigmer/stigmer/backend/libs/go/store"
 	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflow/temporal"
+	workflowinstancetemporal "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowinstance/temporal"
 	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/downstream/workflowinstance"
 )
 
@@ -14,6 +15,7 @@ type WorkflowController struct {
 	store                  store.Store
 	workflowInstanceClient *workflowinstance.Client
 	validator              *temporal.ServerlessWorkflowValidator
+	scheduleManager        *workflowinstancetemporal.ScheduleManager
 }
 
 // NewWorkflowController creates a new WorkflowController
@@ -37,3 +39,10 @@ func (c *WorkflowController) SetWorkflowInstanceClient(client *workflowinstance.
 func (c *WorkflowController) SetValidator(validator *temporal.ServerlessWorkflowValidator) {
 	c.validator = validator
 }
+
+// SetScheduleManager sets the workflow instance schedule manager dependency
+// This is used when the controller is created before the Temporal client is initialized
+// or when the Temporal client is reconnected
+func (c *WorkflowController) SetScheduleManager(manager *workflowinstancetemporal.ScheduleManager) {
+	c.scheduleManager = manager
+}
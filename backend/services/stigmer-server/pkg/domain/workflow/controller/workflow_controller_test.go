@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"testing"
 
@@ -16,6 +17,8 @@ import (
 	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/downstream/workflow"
 	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/downstream/workflowinstance"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -521,3 +524,93 @@ func TestWorkflowController_CreateWithDefaultInstance(t *testing.T) {
 		}
 	})
 }
+
+func TestWorkflowController_List(t *testing.T) {
+	controller, store := setupTestController(t)
+	defer store.Close()
+
+	// A few hundred workflows: every fourth is owned by team=data, every
+	// third is on tier=gold
+	for i := 0; i < 300; i++ {
+		labels := map[string]string{"team": "platform", "tier": "silver"}
+		if i%4 == 0 {
+			labels["team"] = "data"
+		}
+		if i%3 == 0 {
+			labels["tier"] = "gold"
+		}
+		id := fmt.Sprintf("wfl-%03d", i)
+		workflow := createValidWorkflow(id, "List test workflow")
+		workflow.Metadata.Id = id
+		workflow.Metadata.Labels = labels
+		if err := store.SaveResource(context.Background(), apiresourcekind.ApiResourceKind_workflow, id, workflow); err != nil {
+			t.Fatalf("failed to save workflow: %v", err)
+		}
+	}
+
+	t.Run("label selector across pages", func(t *testing.T) {
+		req := &workflowv1.ListWorkflowsRequest{PageSize: 30, LabelSelector: map[string]string{"team": "data"}}
+		var ids []string
+		for {
+			list, err := controller.List(contextWithWorkflowKind(), req)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if list.TotalPages != 3 {
+				t.Errorf("Expected 3 pages, got %d", list.TotalPages)
+			}
+			for _, workflow := range list.Entries {
+				if workflow.Metadata.Labels["team"] != "data" {
+					t.Errorf("Workflow %s does not match the selector: %v", workflow.Metadata.Id, workflow.Metadata.Labels)
+				}
+				ids = append(ids, workflow.Metadata.Id)
+			}
+			if list.NextPageToken == "" {
+				break
+			}
+			req.PageToken = list.NextPageToken
+		}
+
+		if len(ids) != 75 {
+			t.Fatalf("Expected 75 workflows, got %d", len(ids))
+		}
+		if ids[0] != "wfl-000" || ids[74] != "wfl-296" {
+			t.Errorf("Expected workflows in creation order, got %s..%s", ids[0], ids[74])
+		}
+	})
+
+	t.Run("multiple labels are ANDed", func(t *testing.T) {
+		list, err := controller.List(contextWithWorkflowKind(), &workflowv1.ListWorkflowsRequest{
+			LabelSelector: map[string]string{"team": "data", "tier": "gold"},
+		})
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(list.Entries) != 25 || list.TotalPages != 1 || list.NextPageToken != "" {
+			t.Errorf("Expected a single page of 25 workflows, got %d entries, %d pages, next token %q",
+				len(list.Entries), list.TotalPages, list.NextPageToken)
+		}
+	})
+
+	t.Run("without selector", func(t *testing.T) {
+		list, err := controller.List(contextWithWorkflowKind(), &workflowv1.ListWorkflowsRequest{})
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(list.Entries) != 100 || list.TotalPages != 3 {
+			t.Errorf("Expected a default page of 100 out of 3 pages, got %d entries and %d pages", len(list.Entries), list.TotalPages)
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, req := range []*workflowv1.ListWorkflowsRequest{
+			{PageSize: -1},
+			{LabelSelector: map[string]string{"": "data"}},
+			{PageToken: "not a token"},
+		} {
+			if _, err := controller.List(contextWithWorkflowKind(), req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Expected InvalidArgument for %v, got %v", req, err)
+			}
+		}
+	})
+}
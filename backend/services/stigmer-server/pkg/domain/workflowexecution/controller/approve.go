@@ -0,0 +1,321 @@
+package workflowexecution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
+	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
+	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline/steps"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowexecution/temporal/workflows"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Approve records a decision on an approval task and resumes the execution
+//
+// The approval task waits on an activity completed asynchronously; the
+// decision completes that activity through Temporal, using the callback token
+// stored on the pending approval.
+//
+// Pipeline Steps:
+// 1. ValidateProto - Validate execution_id, task_name and approver
+// 2. LoadApprovalExecution - Load the execution from DB
+// 3. ResolvePendingApproval - Find the pending approval and check the approver
+// 4. CompleteApproval - Complete the waiting activity with the decision
+// 5. BuildNewStateWithDecision - Record the decision on the task and phase
+// 6. Persist - Save to database
+// 7. BroadcastToStreams - Push update to active Go channels (ADR 011)
+//
+// Note: Approvers are matched by exact name. OSS has no identity provider,
+// so groups in the approvers list are not expanded.
+func (c *WorkflowExecutionController) Approve(ctx context.Context, input *workflowexecutionv1.WorkflowExecutionApproveInput) (*workflowexecutionv1.WorkflowExecution, error) {
+	reqCtx := pipeline.NewRequestContext(ctx, input)
+
+	p := pipeline.NewPipeline[*workflowexecutionv1.WorkflowExecutionApproveInput]("workflowexecution-approve").
+		AddStep(steps.NewValidateProtoStep[*workflowexecutionv1.WorkflowExecutionApproveInput]()).
+		AddStep(newLoadApprovalExecutionStep(c.store)).
+		AddStep(newResolvePendingApprovalStep()).
+		AddStep(newCompleteApprovalStep(c.store, c.workflowCreator)).
+		AddStep(newBuildNewStateWithDecisionStep()).
+		AddStep(newPersistExecutionStep[*workflowexecutionv1.WorkflowExecutionApproveInput](c.store)).
+		AddStep(newBroadcastToStreamsStep[*workflowexecutionv1.WorkflowExecutionApproveInput](c.streamBroker)).
+		Build()
+
+	if err := p.Execute(reqCtx); err != nil {
+		return nil, err
+	}
+
+	execution, ok := reqCtx.Get("execution").(*workflowexecutionv1.WorkflowExecution)
+	if !ok {
+		return nil, grpclib.InternalError(nil, "execution not found in context after pipeline")
+	}
+
+	return execution, nil
+}
+
+// LoadApprovalExecutionStep loads the execution the decision is for
+type LoadApprovalExecutionStep struct {
+	store store.Store
+}
+
+func newLoadApprovalExecutionStep(store store.Store) *LoadApprovalExecutionStep {
+	return &LoadApprovalExecutionStep{store: store}
+}
+
+func (s *LoadApprovalExecutionStep) Name() string {
+	return "LoadApprovalExecution"
+}
+
+func (s *LoadApprovalExecutionStep) Execute(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecutionApproveInput]) error {
+	executionID := ctx.Input().ExecutionId
+
+	existing := &workflowexecutionv1.WorkflowExecution{}
+	if err := s.store.GetResource(ctx.Context(), apiresourcekind.ApiResourceKind_workflow_execution, executionID, existing); err != nil {
+		return grpclib.NotFoundError("WorkflowExecution", executionID)
+	}
+
+	ctx.Set("existingExecution", existing)
+
+	return nil
+}
+
+// ResolvePendingApprovalStep finds the pending approval of the task and checks
+// that the approver may decide on it
+type ResolvePendingApprovalStep struct{}
+
+func newResolvePendingApprovalStep() *ResolvePendingApprovalStep {
+	return &ResolvePendingApprovalStep{}
+}
+
+func (s *ResolvePendingApprovalStep) Name() string {
+	return "ResolvePendingApproval"
+}
+
+func (s *ResolvePendingApprovalStep) Execute(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecutionApproveInput]) error {
+	input := ctx.Input()
+	existing, ok := ctx.Get("existingExecution").(*workflowexecutionv1.WorkflowExecution)
+	if !ok {
+		return grpclib.InternalError(nil, "existing execution not found in context")
+	}
+
+	var pending *workflowexecutionv1.PendingApproval
+	for _, approval := range existing.GetStatus().GetPendingApprovals() {
+		if approval.GetTaskName() == input.TaskName {
+			pending = approval
+			break
+		}
+	}
+	if pending == nil {
+		return status.Errorf(codes.FailedPrecondition,
+			"workflow execution %s is not waiting for approval of task %q", input.ExecutionId, input.TaskName)
+	}
+
+	if len(pending.GetApprovers()) > 0 && !slices.Contains(pending.GetApprovers(), input.Approver) {
+		return status.Errorf(codes.PermissionDenied,
+			"%q is not an approver of task %q", input.Approver, input.TaskName)
+	}
+
+	ctx.Set("pendingApproval", pending)
+
+	return nil
+}
+
+// CompleteApprovalStep completes the activity the approval task waits on
+//
+// If the activity no longer exists (timed out, execution cancelled), the stale
+// pending approval is removed and FailedPrecondition is returned.
+type CompleteApprovalStep struct {
+	store           store.Store
+	workflowCreator *workflows.InvokeWorkflowExecutionWorkflowCreator
+}
+
+func newCompleteApprovalStep(
+	store store.Store,
+	workflowCreator *workflows.InvokeWorkflowExecutionWorkflowCreator,
+) *CompleteApprovalStep {
+	return &CompleteApprovalStep{store: store, workflowCreator: workflowCreator}
+}
+
+func (s *CompleteApprovalStep) Name() string {
+	return "CompleteApproval"
+}
+
+func (s *CompleteApprovalStep) Execute(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecutionApproveInput]) error {
+	input := ctx.Input()
+	pending, ok := ctx.Get("pendingApproval").(*workflowexecutionv1.PendingApproval)
+	if !ok {
+		return grpclib.InternalError(nil, "pending approval not found in context")
+	}
+
+	if s.workflowCreator == nil {
+		return grpclib.WrapError(
+			fmt.Errorf("temporal workflow engine is currently unavailable"),
+			codes.Unavailable,
+			"Temporal workflow engine is unavailable. Please try again later",
+		)
+	}
+
+	decidedAt := time.Now().UTC().Format(time.RFC3339)
+	ctx.Set("decidedAt", decidedAt)
+
+	var err error
+	if input.Reject {
+		reason := fmt.Sprintf("approval of task %s rejected by %s", input.TaskName, input.Approver)
+		if input.Comment != "" {
+			reason += ": " + input.Comment
+		}
+		ctx.Set("rejectionReason", reason)
+		err = s.workflowCreator.RejectApproval(ctx.Context(), pending.GetCallbackToken(), reason)
+	} else {
+		err = s.workflowCreator.CompleteApproval(ctx.Context(), pending.GetCallbackToken(), map[string]interface{}{
+			"approved":    true,
+			"approved_by": input.Approver,
+			"approved_at": decidedAt,
+			"comment":     input.Comment,
+		})
+	}
+
+	if errors.Is(err, workflows.ErrApprovalNotPending) {
+		s.removeStaleApproval(ctx)
+		return status.Errorf(codes.FailedPrecondition,
+			"approval of task %q is no longer pending (timed out or cancelled)", input.TaskName)
+	}
+	if err != nil {
+		return grpclib.InternalError(err, "failed to complete approval")
+	}
+
+	log.Info().
+		Str("execution_id", input.ExecutionId).
+		Str("task_name", input.TaskName).
+		Str("approver", input.Approver).
+		Bool("rejected", input.Reject).
+		Msg("Recorded approval decision")
+
+	return nil
+}
+
+// removeStaleApproval drops a pending approval whose activity no longer
+// exists. Failures are logged only: the decision is rejected either way.
+func (s *CompleteApprovalStep) removeStaleApproval(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecutionApproveInput]) {
+	input := ctx.Input()
+	existing, ok := ctx.Get("existingExecution").(*workflowexecutionv1.WorkflowExecution)
+	if !ok {
+		return
+	}
+
+	updated := proto.Clone(existing).(*workflowexecutionv1.WorkflowExecution)
+	updated.Status.PendingApprovals = removePendingApproval(updated.Status.PendingApprovals, input.TaskName)
+	if err := s.store.SaveResource(ctx.Context(), apiresourcekind.ApiResourceKind_workflow_execution, input.ExecutionId, updated); err != nil {
+		log.Warn().
+			Err(err).
+			Str("execution_id", input.ExecutionId).
+			Str("task_name", input.TaskName).
+			Msg("Failed to remove stale pending approval")
+	}
+}
+
+// BuildNewStateWithDecisionStep records the decision on the execution
+//
+// The pending approval is removed and the task is marked COMPLETED (with the
+// decision as output) or FAILED. The phase returns to IN_PROGRESS once no
+// approvals are pending.
+type BuildNewStateWithDecisionStep struct{}
+
+func newBuildNewStateWithDecisionStep() *BuildNewStateWithDecisionStep {
+	return &BuildNewStateWithDecisionStep{}
+}
+
+func (s *BuildNewStateWithDecisionStep) Name() string {
+	return "BuildNewStateWithDecision"
+}
+
+func (s *BuildNewStateWithDecisionStep) Execute(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecutionApproveInput]) error {
+	input := ctx.Input()
+	existing, ok := ctx.Get("existingExecution").(*workflowexecutionv1.WorkflowExecution)
+	if !ok {
+		return grpclib.InternalError(nil, "existing execution not found in context")
+	}
+	decidedAt, _ := ctx.Get("decidedAt").(string)
+
+	updated := proto.Clone(existing).(*workflowexecutionv1.WorkflowExecution)
+	updated.Status.PendingApprovals = removePendingApproval(updated.Status.PendingApprovals, input.TaskName)
+	if len(updated.Status.PendingApprovals) == 0 &&
+		updated.Status.Phase == workflowexecutionv1.ExecutionPhase_EXECUTION_AWAITING_APPROVAL {
+		updated.Status.Phase = workflowexecutionv1.ExecutionPhase_EXECUTION_IN_PROGRESS
+	}
+
+	task := findOrAddTask(updated.Status, input.TaskName)
+	task.CompletedAt = decidedAt
+	if input.Reject {
+		task.Status = workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_FAILED
+		task.Error, _ = ctx.Get("rejectionReason").(string)
+	} else {
+		output, err := structpb.NewStruct(map[string]interface{}{
+			"approved":    true,
+			"approved_by": input.Approver,
+			"approved_at": decidedAt,
+			"comment":     input.Comment,
+		})
+		if err != nil {
+			return grpclib.InternalError(err, "failed to build approval output")
+		}
+		task.Status = workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_COMPLETED
+		task.Output = output
+	}
+
+	ctx.Set("execution", updated)
+
+	return nil
+}
+
+// upsertPendingApproval replaces the pending approval for the same task, or
+// appends it.
+func upsertPendingApproval(
+	approvals []*workflowexecutionv1.PendingApproval,
+	approval *workflowexecutionv1.PendingApproval,
+) []*workflowexecutionv1.PendingApproval {
+	for i, existing := range approvals {
+		if existing.GetTaskName() == approval.GetTaskName() {
+			approvals[i] = approval
+			return approvals
+		}
+	}
+	return append(approvals, approval)
+}
+
+// removePendingApproval removes the pending approval of the task.
+func removePendingApproval(
+	approvals []*workflowexecutionv1.PendingApproval,
+	taskName string,
+) []*workflowexecutionv1.PendingApproval {
+	return slices.DeleteFunc(approvals, func(approval *workflowexecutionv1.PendingApproval) bool {
+		return approval.GetTaskName() == taskName
+	})
+}
+
+// findOrAddTask returns the task with the given name, adding it if the
+// execution has no such task yet.
+func findOrAddTask(execStatus *workflowexecutionv1.WorkflowExecutionStatus, taskName string) *workflowexecutionv1.WorkflowTask {
+	for _, task := range execStatus.Tasks {
+		if task.GetTaskName() == taskName {
+			return task
+		}
+	}
+	task := &workflowexecutionv1.WorkflowTask{
+		TaskId:   taskName,
+		TaskName: taskName,
+		TaskType: workflowexecutionv1.WorkflowTaskType_WORKFLOW_TASK_APPROVAL,
+	}
+	execStatus.Tasks = append(execStatus.Tasks, task)
+	return task
+}
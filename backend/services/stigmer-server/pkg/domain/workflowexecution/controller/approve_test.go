@@ -0,0 +1,221 @@
+package workflowexecution
+
+import (
+	"errors"
+	"testing"
+
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowexecution/temporal/workflows"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/mocks"
+	"go.temporal.io/sdk/temporal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// setupApprovalTest creates an execution waiting for approval of task "approveDeploy".
+func setupApprovalTest(t *testing.T) (*WorkflowExecutionController, store.Store, *mocks.Client) {
+	controller, store := setupTestController(t)
+	t.Cleanup(func() { store.Close() })
+
+	temporalClient := &mocks.Client{}
+	controller.SetWorkflowCreator(workflows.NewInvokeWorkflowExecutionWorkflowCreator(temporalClient, "stigmer", "runner"))
+
+	execution := &workflowexecutionv1.WorkflowExecution{
+		ApiVersion: "agentic.stigmer.ai/v1",
+		Kind:       "WorkflowExecution",
+		Metadata: &apiresource.ApiResourceMetadata{
+			Id:   "wex-approval",
+			Name: "Deploy",
+		},
+		Status: &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_AWAITING_APPROVAL,
+			Tasks: []*workflowexecutionv1.WorkflowTask{
+				{
+					TaskId:   "approveDeploy",
+					TaskName: "approveDeploy",
+					TaskType: workflowexecutionv1.WorkflowTaskType_WORKFLOW_TASK_APPROVAL,
+					Status:   workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_AWAITING_APPROVAL,
+				},
+			},
+			PendingApprovals: []*workflowexecutionv1.PendingApproval{
+				{
+					TaskName:      "approveDeploy",
+					Approvers:     []string{"alice"},
+					CallbackToken: []byte("token"),
+				},
+			},
+		},
+	}
+	if err := store.SaveResource(contextWithWorkflowExecutionKind(), apiresourcekind.ApiResourceKind_workflow_execution, execution.Metadata.Id, execution); err != nil {
+		t.Fatalf("failed to save execution: %v", err)
+	}
+
+	return controller, store, temporalClient
+}
+
+func TestWorkflowExecutionController_Approve(t *testing.T) {
+	controller, _, temporalClient := setupApprovalTest(t)
+	temporalClient.On("CompleteActivity", mock.Anything, []byte("token"), mock.MatchedBy(func(result map[string]interface{}) bool {
+		return result["approved"] == true && result["approved_by"] == "alice" && result["comment"] == "ship it"
+	}), nil).Return(nil)
+
+	updated, err := controller.Approve(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionApproveInput{
+		ExecutionId: "wex-approval",
+		TaskName:    "approveDeploy",
+		Approver:    "alice",
+		Comment:     "ship it",
+	})
+	if err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+	temporalClient.AssertExpectations(t)
+
+	if got := updated.Status.Phase; got != workflowexecutionv1.ExecutionPhase_EXECUTION_IN_PROGRESS {
+		t.Errorf("phase = %v, want EXECUTION_IN_PROGRESS", got)
+	}
+	if len(updated.Status.PendingApprovals) != 0 {
+		t.Errorf("pending approvals = %v, want none", updated.Status.PendingApprovals)
+	}
+	task := updated.Status.Tasks[0]
+	if task.Status != workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_COMPLETED {
+		t.Errorf("task status = %v, want WORKFLOW_TASK_COMPLETED", task.Status)
+	}
+	if got := task.GetOutput().GetFields()["approved_by"].GetStringValue(); got != "alice" {
+		t.Errorf("task output approved_by = %q, want alice", got)
+	}
+}
+
+func TestWorkflowExecutionController_ApproveReject(t *testing.T) {
+	controller, _, temporalClient := setupApprovalTest(t)
+	temporalClient.On("CompleteActivity", mock.Anything, []byte("token"), mock.Anything, mock.MatchedBy(func(err error) bool {
+		var appErr *temporal.ApplicationError
+		return errors.As(err, &appErr) && appErr.Type() == workflows.ApprovalRejectedErrorType
+	})).Return(nil)
+
+	updated, err := controller.Approve(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionApproveInput{
+		ExecutionId: "wex-approval",
+		TaskName:    "approveDeploy",
+		Approver:    "alice",
+		Reject:      true,
+		Comment:     "not during the freeze",
+	})
+	if err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+	temporalClient.AssertExpectations(t)
+
+	task := updated.Status.Tasks[0]
+	if task.Status != workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_FAILED {
+		t.Errorf("task status = %v, want WORKFLOW_TASK_FAILED", task.Status)
+	}
+	if task.Error != "approval of task approveDeploy rejected by alice: not during the freeze" {
+		t.Errorf("task error = %q", task.Error)
+	}
+}
+
+func TestWorkflowExecutionController_ApproveErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *workflowexecutionv1.WorkflowExecutionApproveInput
+		wantCode codes.Code
+	}{
+		{
+			name:     "unknown execution",
+			input:    &workflowexecutionv1.WorkflowExecutionApproveInput{ExecutionId: "wex-missing", TaskName: "approveDeploy", Approver: "alice"},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "task not awaiting approval",
+			input:    &workflowexecutionv1.WorkflowExecutionApproveInput{ExecutionId: "wex-approval", TaskName: "deploy", Approver: "alice"},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "not an approver",
+			input:    &workflowexecutionv1.WorkflowExecutionApproveInput{ExecutionId: "wex-approval", TaskName: "approveDeploy", Approver: "mallory"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "missing approver",
+			input:    &workflowexecutionv1.WorkflowExecutionApproveInput{ExecutionId: "wex-approval", TaskName: "approveDeploy"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, _, temporalClient := setupApprovalTest(t)
+
+			_, err := controller.Approve(contextWithWorkflowExecutionKind(), tt.input)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("Approve error = %v, want code %v", err, tt.wantCode)
+			}
+			temporalClient.AssertNotCalled(t, "CompleteActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestWorkflowExecutionController_ApproveStale(t *testing.T) {
+	controller, store, temporalClient := setupApprovalTest(t)
+	temporalClient.On("CompleteActivity", mock.Anything, []byte("token"), mock.Anything, nil).
+		Return(serviceerror.NewNotFound("activity not found"))
+
+	_, err := controller.Approve(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionApproveInput{
+		ExecutionId: "wex-approval",
+		TaskName:    "approveDeploy",
+		Approver:    "alice",
+	})
+	if got := status.Code(err); got != codes.FailedPrecondition {
+		t.Fatalf("Approve error = %v, want FailedPrecondition", err)
+	}
+
+	execution := &workflowexecutionv1.WorkflowExecution{}
+	if err := store.GetResource(contextWithWorkflowExecutionKind(), apiresourcekind.ApiResourceKind_workflow_execution, "wex-approval", execution); err != nil {
+		t.Fatalf("failed to load execution: %v", err)
+	}
+	if len(execution.Status.PendingApprovals) != 0 {
+		t.Errorf("stale pending approval was not removed: %v", execution.Status.PendingApprovals)
+	}
+}
+
+func TestWorkflowExecutionController_UpdateStatusPendingApprovals(t *testing.T) {
+	controller, _, _ := setupApprovalTest(t)
+
+	// Progress of another task does not hide the pending approval
+	updated, err := controller.UpdateStatus(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionUpdateStatusInput{
+		ExecutionId: "wex-approval",
+		Status: &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_IN_PROGRESS,
+			PendingApprovals: []*workflowexecutionv1.PendingApproval{
+				{TaskName: "approveRollout", CallbackToken: []byte("token-2")},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if got := updated.Status.Phase; got != workflowexecutionv1.ExecutionPhase_EXECUTION_AWAITING_APPROVAL {
+		t.Errorf("phase = %v, want EXECUTION_AWAITING_APPROVAL", got)
+	}
+	if got := len(updated.Status.PendingApprovals); got != 2 {
+		t.Errorf("pending approvals = %d, want 2", got)
+	}
+
+	// Terminal phases clear pending approvals
+	updated, err = controller.UpdateStatus(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionUpdateStatusInput{
+		ExecutionId: "wex-approval",
+		Status: &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED,
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if len(updated.Status.PendingApprovals) != 0 {
+		t.Errorf("pending approvals = %v, want none", updated.Status.PendingApprovals)
+	}
+}
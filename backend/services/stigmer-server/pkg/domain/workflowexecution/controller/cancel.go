@@ -0,0 +1,170 @@
+package workflowexecution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
+	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
+	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline/steps"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowexecution/temporal/workflows"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Cancel requests cancellation of a running execution
+//
+// A started execution moves to EXECUTION_CANCELLING; the workflow runner
+// reports EXECUTION_CANCELLED once its workflow has stopped. Executions that
+// never started a workflow (queued by a concurrency policy) or whose workflow
+// is already gone are cancelled immediately.
+//
+// Pipeline Steps:
+// 1. ValidateProto - Validate execution_id and cancelled_by
+// 2. LoadCancelExecution - Load the execution from DB
+// 3. RequestCancellation - Cancel the Temporal workflow and build the new state
+// 4. Persist - Save to database
+// 5. BroadcastToStreams - Push update to active Go channels (ADR 011)
+func (c *WorkflowExecutionController) Cancel(ctx context.Context, input *workflowexecutionv1.WorkflowExecutionCancelInput) (*workflowexecutionv1.WorkflowExecution, error) {
+	reqCtx := pipeline.NewRequestContext(ctx, input)
+
+	p := pipeline.NewPipeline[*workflowexecutionv1.WorkflowExecutionCancelInput]("workflowexecution-cancel").
+		AddStep(steps.NewValidateProtoStep[*workflowexecutionv1.WorkflowExecutionCancelInput]()).
+		AddStep(newLoadCancelExecutionStep(c.store)).
+		AddStep(newRequestCancellationStep(c.workflowCreator)).
+		AddStep(newPersistExecutionStep[*workflowexecutionv1.WorkflowExecutionCancelInput](c.store)).
+		AddStep(newBroadcastToStreamsStep[*workflowexecutionv1.WorkflowExecutionCancelInput](c.streamBroker)).
+		Build()
+
+	if err := p.Execute(reqCtx); err != nil {
+		return nil, err
+	}
+
+	execution, ok := reqCtx.Get("execution").(*workflowexecutionv1.WorkflowExecution)
+	if !ok {
+		return nil, grpclib.InternalError(nil, "execution not found in context after pipeline")
+	}
+
+	return execution, nil
+}
+
+// LoadCancelExecutionStep loads the execution to cancel
+type LoadCancelExecutionStep struct {
+	store store.Store
+}
+
+func newLoadCancelExecutionStep(store store.Store) *LoadCancelExecutionStep {
+	return &LoadCancelExecutionStep{store: store}
+}
+
+func (s *LoadCancelExecutionStep) Name() string {
+	return "LoadCancelExecution"
+}
+
+func (s *LoadCancelExecutionStep) Execute(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecutionCancelInput]) error {
+	executionID := ctx.Input().ExecutionId
+
+	existing := &workflowexecutionv1.WorkflowExecution{}
+	if err := s.store.GetResource(ctx.Context(), apiresourcekind.ApiResourceKind_workflow_execution, executionID, existing); err != nil {
+		return grpclib.NotFoundError("WorkflowExecution", executionID)
+	}
+
+	ctx.Set("existingExecution", existing)
+
+	return nil
+}
+
+// RequestCancellationStep cancels the execution's Temporal workflow and
+// records who cancelled it
+//
+// Terminal executions cannot be cancelled (FailedPrecondition). Cancelling an
+// execution that is already cancelling returns it unchanged.
+type RequestCancellationStep struct {
+	workflowCreator *workflows.InvokeWorkflowExecutionWorkflowCreator
+}
+
+func newRequestCancellationStep(workflowCreator *workflows.InvokeWorkflowExecutionWorkflowCreator) *RequestCancellationStep {
+	return &RequestCancellationStep{workflowCreator: workflowCreator}
+}
+
+func (s *RequestCancellationStep) Name() string {
+	return "RequestCancellation"
+}
+
+func (s *RequestCancellationStep) Execute(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecutionCancelInput]) error {
+	input := ctx.Input()
+	existing, ok := ctx.Get("existingExecution").(*workflowexecutionv1.WorkflowExecution)
+	if !ok {
+		return grpclib.InternalError(nil, "existing execution not found in context")
+	}
+
+	phase := existing.GetStatus().GetPhase()
+	if isTerminalPhase(phase) {
+		return status.Errorf(codes.FailedPrecondition,
+			"workflow execution %s cannot be cancelled: it is already %s", input.ExecutionId, phase)
+	}
+	if phase == workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLING {
+		ctx.Set("execution", existing)
+		return nil
+	}
+
+	updated := proto.Clone(existing).(*workflowexecutionv1.WorkflowExecution)
+	if updated.Status == nil {
+		updated.Status = &workflowexecutionv1.WorkflowExecutionStatus{}
+	}
+	updated.Status.CancelledBy = input.CancelledBy
+	updated.Status.CancelReason = input.Reason
+
+	// Queued executions were never started, so there is no Temporal workflow to cancel
+	stopped := isQueued(existing)
+	if !stopped {
+		if s.workflowCreator == nil {
+			return grpclib.WrapError(
+				fmt.Errorf("temporal workflow engine is currently unavailable"),
+				codes.Unavailable,
+				"Temporal workflow engine is unavailable. Please try again later",
+			)
+		}
+		err := s.workflowCreator.Cancel(ctx.Context(), input.ExecutionId)
+		if err != nil && !errors.Is(err, workflows.ErrWorkflowNotRunning) {
+			return grpclib.InternalError(err, "failed to cancel workflow execution")
+		}
+		stopped = err != nil
+	}
+
+	if stopped {
+		updated.Status.Phase = workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED
+		updated.Status.ErrorClassification = workflowexecutionv1.ErrorClassification_CANCELLED
+		updated.Status.Error = cancellationMessage(input.CancelledBy, input.Reason)
+		updated.Status.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+		updated.Status.PendingApprovals = nil
+	} else {
+		updated.Status.Phase = workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLING
+	}
+
+	log.Info().
+		Str("execution_id", input.ExecutionId).
+		Str("cancelled_by", input.CancelledBy).
+		Str("phase", updated.Status.Phase.String()).
+		Msg("Requested workflow execution cancellation")
+
+	ctx.Set("execution", updated)
+
+	return nil
+}
+
+// cancellationMessage describes who cancelled an execution, and why.
+func cancellationMessage(cancelledBy, reason string) string {
+	message := "cancelled by " + cancelledBy
+	if reason != "" {
+		message += ": " + reason
+	}
+	return message
+}
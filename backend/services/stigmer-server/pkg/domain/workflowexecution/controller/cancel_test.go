@@ -0,0 +1,179 @@
+package workflowexecution
+
+import (
+	"testing"
+
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowexecution/temporal/workflows"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/mocks"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// setupCancelTest creates execution "wex-run" in the given phase.
+func setupCancelTest(t *testing.T, phase workflowexecutionv1.ExecutionPhase) (*WorkflowExecutionController, *mocks.Client) {
+	controller, store := setupTestController(t)
+	t.Cleanup(func() { store.Close() })
+
+	temporalClient := &mocks.Client{}
+	controller.SetWorkflowCreator(workflows.NewInvokeWorkflowExecutionWorkflowCreator(temporalClient, "stigmer", "runner"))
+
+	execution := &workflowexecutionv1.WorkflowExecution{
+		ApiVersion: "agentic.stigmer.ai/v1",
+		Kind:       "WorkflowExecution",
+		Metadata: &apiresource.ApiResourceMetadata{
+			Id:   "wex-run",
+			Name: "Sync",
+		},
+		Status: &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase: phase,
+		},
+	}
+	if phase == workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLING {
+		execution.Status.CancelledBy = "alice"
+	}
+	if err := store.SaveResource(contextWithWorkflowExecutionKind(), apiresourcekind.ApiResourceKind_workflow_execution, execution.Metadata.Id, execution); err != nil {
+		t.Fatalf("failed to save execution: %v", err)
+	}
+
+	return controller, temporalClient
+}
+
+const cancelTestWorkflowID = workflows.InvokeWorkflowExecutionWorkflowName + "/wex-run"
+
+func TestWorkflowExecutionController_Cancel(t *testing.T) {
+	controller, temporalClient := setupCancelTest(t, workflowexecutionv1.ExecutionPhase_EXECUTION_IN_PROGRESS)
+	temporalClient.On("CancelWorkflow", mock.Anything, cancelTestWorkflowID, "").Return(nil)
+
+	updated, err := controller.Cancel(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionCancelInput{
+		ExecutionId: "wex-run",
+		CancelledBy: "alice",
+		Reason:      "runaway loop",
+	})
+	if err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	temporalClient.AssertExpectations(t)
+
+	if got := updated.Status.Phase; got != workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLING {
+		t.Errorf("phase = %v, want EXECUTION_CANCELLING", got)
+	}
+	if updated.Status.CancelledBy != "alice" || updated.Status.CancelReason != "runaway loop" {
+		t.Errorf("cancelled_by = %q, cancel_reason = %q", updated.Status.CancelledBy, updated.Status.CancelReason)
+	}
+
+	// Cancelling again is a no-op
+	again, err := controller.Cancel(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionCancelInput{
+		ExecutionId: "wex-run",
+		CancelledBy: "bob",
+	})
+	if err != nil {
+		t.Fatalf("second Cancel failed: %v", err)
+	}
+	if again.Status.CancelledBy != "alice" {
+		t.Errorf("cancelled_by = %q after second cancel, want alice", again.Status.CancelledBy)
+	}
+	temporalClient.AssertNumberOfCalls(t, "CancelWorkflow", 1)
+}
+
+func TestWorkflowExecutionController_CancelWorkflowGone(t *testing.T) {
+	controller, temporalClient := setupCancelTest(t, workflowexecutionv1.ExecutionPhase_EXECUTION_IN_PROGRESS)
+	temporalClient.On("CancelWorkflow", mock.Anything, cancelTestWorkflowID, "").
+		Return(serviceerror.NewNotFound("workflow not found"))
+
+	updated, err := controller.Cancel(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionCancelInput{
+		ExecutionId: "wex-run",
+		CancelledBy: "alice",
+	})
+	if err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+
+	if got := updated.Status.Phase; got != workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED {
+		t.Errorf("phase = %v, want EXECUTION_CANCELLED", got)
+	}
+	if got := updated.Status.ErrorClassification; got != workflowexecutionv1.ErrorClassification_CANCELLED {
+		t.Errorf("error classification = %v, want CANCELLED", got)
+	}
+	if updated.Status.Error != "cancelled by alice" {
+		t.Errorf("error = %q", updated.Status.Error)
+	}
+}
+
+func TestWorkflowExecutionController_CancelErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		phase    workflowexecutionv1.ExecutionPhase
+		input    *workflowexecutionv1.WorkflowExecutionCancelInput
+		wantCode codes.Code
+	}{
+		{
+			name:     "unknown execution",
+			phase:    workflowexecutionv1.ExecutionPhase_EXECUTION_IN_PROGRESS,
+			input:    &workflowexecutionv1.WorkflowExecutionCancelInput{ExecutionId: "wex-missing", CancelledBy: "alice"},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "already completed",
+			phase:    workflowexecutionv1.ExecutionPhase_EXECUTION_COMPLETED,
+			input:    &workflowexecutionv1.WorkflowExecutionCancelInput{ExecutionId: "wex-run", CancelledBy: "alice"},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "missing canceller",
+			phase:    workflowexecutionv1.ExecutionPhase_EXECUTION_IN_PROGRESS,
+			input:    &workflowexecutionv1.WorkflowExecutionCancelInput{ExecutionId: "wex-run"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller, temporalClient := setupCancelTest(t, tt.phase)
+
+			_, err := controller.Cancel(contextWithWorkflowExecutionKind(), tt.input)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("Cancel error = %v, want code %v", err, tt.wantCode)
+			}
+			temporalClient.AssertNotCalled(t, "CancelWorkflow", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestWorkflowExecutionController_UpdateStatusWhileCancelling(t *testing.T) {
+	controller, _ := setupCancelTest(t, workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLING)
+
+	// Progress reported while the runner stops the workflow keeps the phase
+	updated, err := controller.UpdateStatus(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionUpdateStatusInput{
+		ExecutionId: "wex-run",
+		Status: &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_IN_PROGRESS,
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if got := updated.Status.Phase; got != workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLING {
+		t.Errorf("phase = %v, want EXECUTION_CANCELLING", got)
+	}
+
+	updated, err = controller.UpdateStatus(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionUpdateStatusInput{
+		ExecutionId: "wex-run",
+		Status: &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED,
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if got := updated.Status.Phase; got != workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED {
+		t.Errorf("phase = %v, want EXECUTION_CANCELLED", got)
+	}
+	if updated.Status.Error != "cancelled by alice" {
+		t.Errorf("error = %q, want the canceller", updated.Status.Error)
+	}
+}
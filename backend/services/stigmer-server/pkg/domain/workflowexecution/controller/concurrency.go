@@ -0,0 +1,468 @@
+package workflowexecution
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowexecution/temporal/workflows"
+	"google.golang.org/protobuf/proto"
+)
+
+// Built-in concurrency keys (see ConcurrencyPolicy.key in workflow spec.proto).
+const (
+	concurrencyKeyOrg      = "org"
+	concurrencyKeyWorkflow = "workflow"
+)
+
+// concurrencyKeyLabel holds the concurrency key of an execution in its
+// metadata labels, so in-flight executions are looked up through the store's
+// label index instead of decoding every execution.
+const concurrencyKeyLabel = "workflowexecution.stigmer.ai/concurrency-key"
+
+// concurrencyUnlockKey is the context key of the function releasing the
+// concurrency key locked by enforceConcurrencyPolicyStep.
+const concurrencyUnlockKey = "concurrency_unlock"
+
+// inFlightPageSize is the page size used to list in-flight executions.
+const inFlightPageSize = 100
+
+// concurrencyLocks holds a mutex per concurrency key. Whether an execution
+// starts depends on the executions in flight for its key, so the lookup and
+// the writes based on it run under the key's mutex: two creates cannot both
+// see the key free, and two completions cannot start the same queued
+// execution.
+type concurrencyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*concurrencyLock
+}
+
+// concurrencyLock is the mutex of a key, with the number of holders and
+// waiters so it is dropped once unused.
+type concurrencyLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lock locks the key and returns the function unlocking it.
+func (l *concurrencyLocks) lock(key string) (unlock func()) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*concurrencyLock)
+	}
+	k, ok := l.locks[key]
+	if !ok {
+		k = &concurrencyLock{}
+		l.locks[key] = k
+	}
+	k.refs++
+	l.mu.Unlock()
+
+	k.Lock()
+	return func() {
+		k.Unlock()
+		l.mu.Lock()
+		if k.refs--; k.refs == 0 {
+			delete(l.locks, key)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// releaseConcurrencyKey unlocks the concurrency key locked while creating an
+// execution, if any. Create calls it once the pipeline has finished, whether
+// or not it failed.
+func releaseConcurrencyKey(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecution]) {
+	if unlock, ok := ctx.Get(concurrencyUnlockKey).(func()); ok {
+		unlock()
+	}
+}
+
+// enforceConcurrencyPolicyStep applies the workflow's concurrency policy to a new execution
+//
+// This step:
+// 1. Loads the workflow (via the resolved instance) and skips if it has no policy
+// 2. Resolves the concurrency key for the execution
+// 3. Looks up in-flight (pending or in-progress) executions with the same key
+// 4. Applies on_conflict:
+//   - QUEUE: leave the execution PENDING without starting it (started when the key frees up)
+//   - SKIP: create the execution directly in CANCELLED
+//   - CANCEL_EXISTING: cancel in-flight executions, then start normally
+//
+// The decision is recorded in status.concurrency_decision so callers (e.g. the CLI)
+// can report it. Must run after SetInitialPhase and before Persist.
+//
+// The key stays locked until Create returns, so the execution is persisted and
+// started before another execution with the same key is looked up.
+type enforceConcurrencyPolicyStep struct {
+	store           store.Store
+	workflowCreator *workflows.InvokeWorkflowExecutionWorkflowCreator
+	streamBroker    *StreamBroker
+	locks           *concurrencyLocks
+}
+
+func (c *WorkflowExecutionController) newEnforceConcurrencyPolicyStep() *enforceConcurrencyPolicyStep {
+	return &enforceConcurrencyPolicyStep{
+		store:           c.store,
+		workflowCreator: c.workflowCreator,
+		streamBroker:    c.streamBroker,
+		locks:           &c.concurrencyLocks,
+	}
+}
+
+func (s *enforceConcurrencyPolicyStep) Name() string {
+	return "EnforceConcurrencyPolicy"
+}
+
+func (s *enforceConcurrencyPolicyStep) Execute(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecution]) error {
+	execution := ctx.NewState()
+	executionID := execution.GetMetadata().GetId()
+
+	workflowID, policy, err := loadConcurrencyPolicy(ctx.Context(), s.store, execution.GetSpec().GetWorkflowInstanceId())
+	if err != nil {
+		// The workflow cannot run without its instance either; let StartWorkflow surface that
+		log.Warn().
+			Err(err).
+			Str("execution_id", executionID).
+			Msg("Could not load workflow for concurrency policy, skipping enforcement")
+		return nil
+	}
+	if policy == nil {
+		return nil
+	}
+
+	key := resolveConcurrencyKey(workflowID, policy.GetKey(), execution)
+	execution.Status.ConcurrencyKey = key
+	if execution.Metadata.Labels == nil {
+		execution.Metadata.Labels = make(map[string]string)
+	}
+	execution.Metadata.Labels[concurrencyKeyLabel] = key
+
+	ctx.Set(concurrencyUnlockKey, s.locks.lock(key))
+
+	inFlight, err := listInFlightExecutions(ctx.Context(), s.store, key, executionID)
+	if err != nil {
+		return fmt.Errorf("failed to look up in-flight executions: %w", err)
+	}
+
+	if len(inFlight) == 0 {
+		execution.Status.ConcurrencyDecision = workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_STARTED
+		ctx.SetNewState(execution)
+		return nil
+	}
+
+	blockingID := inFlight[len(inFlight)-1].GetMetadata().GetId()
+
+	switch policy.GetOnConflict() {
+	case workflowv1.ConcurrencyConflictAction_CONCURRENCY_SKIP:
+		execution.Status.ConcurrencyDecision = workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_SKIPPED
+		execution.Status.ConcurrencyBlockingExecutionId = blockingID
+		execution.Status.Phase = workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED
+		execution.Status.Error = fmt.Sprintf("skipped by concurrency policy: execution %s is in flight for %s", blockingID, key)
+
+	case workflowv1.ConcurrencyConflictAction_CONCURRENCY_CANCEL_EXISTING:
+		for _, existing := range inFlight {
+			if err := s.cancelExecution(ctx.Context(), existing, executionID); err != nil {
+				return err
+			}
+		}
+		execution.Status.ConcurrencyDecision = workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_CANCELLED_EXISTING
+
+	default: // CONCURRENCY_QUEUE (and unspecified)
+		execution.Status.ConcurrencyDecision = workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_QUEUED
+		execution.Status.ConcurrencyBlockingExecutionId = blockingID
+	}
+
+	log.Info().
+		Str("execution_id", executionID).
+		Str("concurrency_key", key).
+		Str("blocking_execution_id", blockingID).
+		Str("decision", execution.Status.ConcurrencyDecision.String()).
+		Int("in_flight", len(inFlight)).
+		Msg("Applied workflow concurrency policy")
+
+	ctx.SetNewState(execution)
+	return nil
+}
+
+// cancelExecution cancels an in-flight execution superseded by a new one.
+func (s *enforceConcurrencyPolicyStep) cancelExecution(ctx context.Context, existing *workflowexecutionv1.WorkflowExecution, supersededBy string) error {
+	existingID := existing.GetMetadata().GetId()
+
+	// Queued executions were never started, so there is no Temporal workflow to cancel
+	if s.workflowCreator != nil && !isQueued(existing) {
+		if err := s.workflowCreator.Cancel(ctx, existingID); err != nil {
+			log.Warn().
+				Err(err).
+				Str("execution_id", existingID).
+				Msg("Failed to cancel Temporal workflow for superseded execution, marking cancelled anyway")
+		}
+	}
+
+	existing.Status.Phase = workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED
+	existing.Status.Error = fmt.Sprintf("cancelled by concurrency policy: superseded by execution %s", supersededBy)
+	if err := s.store.SaveResource(ctx, apiresourcekind.ApiResourceKind_workflow_execution, existingID, existing); err != nil {
+		return fmt.Errorf("failed to persist cancelled execution %s: %w", existingID, err)
+	}
+	s.streamBroker.Broadcast(existing)
+
+	log.Info().
+		Str("execution_id", existingID).
+		Str("superseded_by", supersededBy).
+		Msg("Cancelled in-flight execution per concurrency policy")
+
+	return nil
+}
+
+// startNextQueuedExecutionStep starts the next queued execution once its key frees up
+//
+// Runs after a status update is persisted. When the updated execution reaches a
+// terminal phase and was serialized on a concurrency key, the oldest queued
+// execution with the same key is started - unless another execution with that
+// key is still running. The key is locked from the lookup until the dequeued
+// execution is persisted.
+type startNextQueuedExecutionStep struct {
+	store           store.Store
+	workflowCreator *workflows.InvokeWorkflowExecutionWorkflowCreator
+	streamBroker    *StreamBroker
+	locks           *concurrencyLocks
+}
+
+func (c *WorkflowExecutionController) newStartNextQueuedExecutionStep() *startNextQueuedExecutionStep {
+	return &startNextQueuedExecutionStep{
+		store:           c.store,
+		workflowCreator: c.workflowCreator,
+		streamBroker:    c.streamBroker,
+		locks:           &c.concurrencyLocks,
+	}
+}
+
+func (s *startNextQueuedExecutionStep) Name() string {
+	return "StartNextQueuedExecution"
+}
+
+func (s *startNextQueuedExecutionStep) Execute(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecutionUpdateStatusInput]) error {
+	execution, ok := ctx.Get("execution").(*workflowexecutionv1.WorkflowExecution)
+	if !ok {
+		return nil
+	}
+
+	key := execution.GetStatus().GetConcurrencyKey()
+	if key == "" || !isTerminalPhase(execution.GetStatus().GetPhase()) {
+		return nil
+	}
+
+	unlock := s.locks.lock(key)
+	defer unlock()
+
+	next, err := s.dequeue(ctx.Context(), key)
+	if err != nil || next == nil {
+		// Dequeue failures must not fail the status update that triggered them
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("concurrency_key", key).
+				Msg("Failed to start next queued execution")
+		}
+		return nil
+	}
+
+	nextID := next.GetMetadata().GetId()
+	next.Status.ConcurrencyBlockingExecutionId = ""
+
+	if s.workflowCreator == nil {
+		log.Warn().
+			Str("execution_id", nextID).
+			Msg("Workflow creator not available - queued execution will remain in PENDING (Temporal not connected)")
+	} else if err := s.workflowCreator.Create(ctx.Context(), next, workflowMaxDuration(ctx.Context(), s.store, next)); err != nil {
+		next.Status.Phase = workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED
+		next.Status.Error = fmt.Sprintf("Failed to start Temporal workflow: %v", err)
+	}
+
+	if err := s.store.SaveResource(ctx.Context(), apiresourcekind.ApiResourceKind_workflow_execution, nextID, next); err != nil {
+		log.Error().
+			Err(err).
+			Str("execution_id", nextID).
+			Msg("Failed to persist dequeued execution")
+		return nil
+	}
+	s.streamBroker.Broadcast(next)
+
+	log.Info().
+		Str("execution_id", nextID).
+		Str("concurrency_key", key).
+		Str("finished_execution_id", execution.GetMetadata().GetId()).
+		Msg("Started queued execution")
+
+	return nil
+}
+
+// dequeue returns the oldest queued execution for the key, or nil if the key is
+// still held by a running execution or nothing is queued.
+func (s *startNextQueuedExecutionStep) dequeue(ctx context.Context, key string) (*workflowexecutionv1.WorkflowExecution, error) {
+	inFlight, err := listInFlightExecutions(ctx, s.store, key, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var queued []*workflowexecutionv1.WorkflowExecution
+	for _, e := range inFlight {
+		if !isQueued(e) {
+			return nil, nil // Key still held
+		}
+		queued = append(queued, e)
+	}
+	if len(queued) == 0 {
+		return nil, nil
+	}
+	return queued[0], nil
+}
+
+// keepConcurrencyKeyLabelStep restores the concurrency key label on updates
+//
+// Updates replace metadata labels with the client's, and an execution missing
+// the label would no longer be found by listInFlightExecutions. Must run after
+// BuildUpdateState, which preserves the status holding the key.
+type keepConcurrencyKeyLabelStep struct{}
+
+func newKeepConcurrencyKeyLabelStep() *keepConcurrencyKeyLabelStep {
+	return &keepConcurrencyKeyLabelStep{}
+}
+
+func (s *keepConcurrencyKeyLabelStep) Name() string {
+	return "KeepConcurrencyKeyLabel"
+}
+
+func (s *keepConcurrencyKeyLabelStep) Execute(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecution]) error {
+	execution := ctx.NewState()
+	key := execution.GetStatus().GetConcurrencyKey()
+	if key == "" {
+		return nil
+	}
+	if execution.Metadata.Labels == nil {
+		execution.Metadata.Labels = make(map[string]string)
+	}
+	execution.Metadata.Labels[concurrencyKeyLabel] = key
+	ctx.SetNewState(execution)
+	return nil
+}
+
+// loadConcurrencyPolicy loads the workflow behind an instance and returns its ID and
+// concurrency policy (nil if the workflow declares none).
+func loadConcurrencyPolicy(ctx context.Context, s store.Store, instanceID string) (string, *workflowv1.ConcurrencyPolicy, error) {
+	workflowID, workflow, err := loadInstanceWorkflow(ctx, s, instanceID)
+	if err != nil {
+		return "", nil, err
+	}
+	return workflowID, workflow.GetSpec().GetConcurrencyPolicy(), nil
+}
+
+// loadInstanceWorkflow loads the workflow behind an instance and returns its ID.
+func loadInstanceWorkflow(ctx context.Context, s store.Store, instanceID string) (string, *workflowv1.Workflow, error) {
+	instance := &workflowinstancev1.WorkflowInstance{}
+	if err := s.GetResource(ctx, apiresourcekind.ApiResourceKind_workflow_instance, instanceID, instance); err != nil {
+		return "", nil, fmt.Errorf("failed to load workflow instance %s: %w", instanceID, err)
+	}
+
+	workflowID := instance.GetSpec().GetWorkflowId()
+	workflow := &workflowv1.Workflow{}
+	if err := s.GetResource(ctx, apiresourcekind.ApiResourceKind_workflow, workflowID, workflow); err != nil {
+		return "", nil, fmt.Errorf("failed to load workflow %s: %w", workflowID, err)
+	}
+
+	return workflowID, workflow, nil
+}
+
+// workflowMaxDuration returns the maximum duration of the workflow an
+// execution runs, or 0 if it has none or cannot be loaded.
+func workflowMaxDuration(ctx context.Context, s store.Store, execution *workflowexecutionv1.WorkflowExecution) time.Duration {
+	_, workflow, err := loadInstanceWorkflow(ctx, s, execution.GetSpec().GetWorkflowInstanceId())
+	if err != nil {
+		// The runner cannot load it either and fails the execution
+		log.Warn().
+			Err(err).
+			Str("execution_id", execution.GetMetadata().GetId()).
+			Msg("Could not load workflow for its maximum duration, using the default timeout")
+		return 0
+	}
+	return time.Duration(workflow.GetSpec().GetMaxDurationSeconds()) * time.Second
+}
+
+// resolveConcurrencyKey builds the workflow-scoped concurrency key for an execution.
+//
+// Format: "{workflow_id}/{key}={value}", or "{workflow_id}/workflow" when all
+// executions of the workflow are serialized.
+func resolveConcurrencyKey(workflowID, key string, execution *workflowexecutionv1.WorkflowExecution) string {
+	switch key {
+	case concurrencyKeyWorkflow:
+		return workflowID + "/" + concurrencyKeyWorkflow
+	case concurrencyKeyOrg:
+		return fmt.Sprintf("%s/%s=%s", workflowID, key, execution.GetMetadata().GetOrg())
+	default:
+		return fmt.Sprintf("%s/%s=%s", workflowID, key, execution.GetSpec().GetTriggerMetadata()[key])
+	}
+}
+
+// listInFlightExecutions returns pending or in-progress executions holding the key,
+// oldest first. excludeID skips the execution being created.
+//
+// Only executions labeled with the key are read from the store.
+func listInFlightExecutions(ctx context.Context, s store.Store, key, excludeID string) ([]*workflowexecutionv1.WorkflowExecution, error) {
+	opts := store.ListOptions{
+		PageSize: inFlightPageSize,
+		Labels:   map[string]string{concurrencyKeyLabel: key},
+	}
+
+	var result []*workflowexecutionv1.WorkflowExecution
+	for {
+		page, err := s.ListResourcesPage(ctx, apiresourcekind.ApiResourceKind_workflow_execution, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, data := range page.Items {
+			execution := &workflowexecutionv1.WorkflowExecution{}
+			if err := proto.Unmarshal(data, execution); err != nil {
+				continue
+			}
+			if execution.GetMetadata().GetId() == excludeID {
+				continue
+			}
+			if execution.GetStatus().GetConcurrencyKey() != key || isTerminalPhase(execution.GetStatus().GetPhase()) {
+				continue
+			}
+			result = append(result, execution)
+		}
+
+		if page.NextPageToken == "" {
+			return result, nil
+		}
+		opts.PageToken = page.NextPageToken
+	}
+}
+
+// isQueued reports whether the execution is still waiting for its key to free up.
+func isQueued(execution *workflowexecutionv1.WorkflowExecution) bool {
+	status := execution.GetStatus()
+	return status.GetConcurrencyDecision() == workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_QUEUED &&
+		status.GetConcurrencyBlockingExecutionId() != ""
+}
+
+// isTerminalPhase reports whether the phase is completed, failed, or cancelled.
+func isTerminalPhase(phase workflowexecutionv1.ExecutionPhase) bool {
+	switch phase {
+	case workflowexecutionv1.ExecutionPhase_EXECUTION_COMPLETED,
+		workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+		workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+package workflowexecution
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+)
+
+// setupConcurrencyTest creates a workflow with the given policy plus an instance of it.
+func setupConcurrencyTest(t *testing.T, policy *workflowv1.ConcurrencyPolicy) (*WorkflowExecutionController, store.Store, string) {
+	controller, store := setupTestController(t)
+	t.Cleanup(func() { store.Close() })
+
+	workflow := createTestWorkflow(t, store)
+	workflow.Spec.ConcurrencyPolicy = policy
+	if err := store.SaveResource(contextWithWorkflowKind(), apiresourcekind.ApiResourceKind_workflow, workflow.Metadata.Id, workflow); err != nil {
+		t.Fatalf("failed to save workflow: %v", err)
+	}
+	instance := createTestWorkflowInstance(t, store, workflow.Metadata.Id)
+
+	return controller, store, instance.Metadata.Id
+}
+
+// createConcurrencyTestExecution creates a numbered execution for an org.
+func createConcurrencyTestExecution(t *testing.T, controller *WorkflowExecutionController, instanceID string, n int, org string) *workflowexecutionv1.WorkflowExecution {
+	execution := &workflowexecutionv1.WorkflowExecution{
+		ApiVersion: "agentic.stigmer.ai/v1",
+		Kind:       "WorkflowExecution",
+		Metadata: &apiresource.ApiResourceMetadata{
+			Name:       fmt.Sprintf("Sync %d", n),
+			Org:        org,
+			OwnerScope: apiresource.ApiResourceOwnerScope_organization,
+		},
+		Spec: &workflowexecutionv1.WorkflowExecutionSpec{
+			WorkflowInstanceId: instanceID,
+		},
+	}
+
+	created, err := controller.Create(contextWithWorkflowExecutionKind(), execution)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	return created
+}
+
+func TestConcurrencyPolicy_Queue(t *testing.T) {
+	controller, store, instanceID := setupConcurrencyTest(t, &workflowv1.ConcurrencyPolicy{
+		Key:        "org",
+		OnConflict: workflowv1.ConcurrencyConflictAction_CONCURRENCY_QUEUE,
+	})
+
+	first := createConcurrencyTestExecution(t, controller, instanceID, 1, "acme")
+	second := createConcurrencyTestExecution(t, controller, instanceID, 2, "acme")
+	other := createConcurrencyTestExecution(t, controller, instanceID, 3, "globex")
+
+	if got := first.Status.ConcurrencyDecision; got != workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_STARTED {
+		t.Errorf("first decision = %v, want CONCURRENCY_STARTED", got)
+	}
+	if got := first.Status.ConcurrencyKey; got != "wf-test-workflow/org=acme" {
+		t.Errorf("first concurrency_key = %q, want %q", got, "wf-test-workflow/org=acme")
+	}
+	if got := second.Status.ConcurrencyDecision; got != workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_QUEUED {
+		t.Errorf("second decision = %v, want CONCURRENCY_QUEUED", got)
+	}
+	if got := second.Status.ConcurrencyBlockingExecutionId; got != first.Metadata.Id {
+		t.Errorf("second blocking execution = %q, want %q", got, first.Metadata.Id)
+	}
+	if got := other.Status.ConcurrencyDecision; got != workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_STARTED {
+		t.Errorf("other org decision = %v, want CONCURRENCY_STARTED", got)
+	}
+
+	// Finishing the first execution dequeues the second
+	_, err := controller.UpdateStatus(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionUpdateStatusInput{
+		ExecutionId: first.Metadata.Id,
+		Status: &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_COMPLETED,
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	dequeued := &workflowexecutionv1.WorkflowExecution{}
+	if err := store.GetResource(contextWithWorkflowExecutionKind(), apiresourcekind.ApiResourceKind_workflow_execution, second.Metadata.Id, dequeued); err != nil {
+		t.Fatalf("failed to load second execution: %v", err)
+	}
+	if isQueued(dequeued) {
+		t.Error("second execution should no longer be queued after the first completed")
+	}
+	if got := dequeued.Status.Phase; got != workflowexecutionv1.ExecutionPhase_EXECUTION_PENDING {
+		t.Errorf("second phase = %v, want EXECUTION_PENDING", got)
+	}
+}
+
+// slowListStore delays paginated lists, widening the window between an
+// in-flight lookup and the write based on it.
+type slowListStore struct {
+	store.Store
+}
+
+func (s slowListStore) ListResourcesPage(ctx context.Context, kind apiresourcekind.ApiResourceKind, opts store.ListOptions) (*store.ListPage, error) {
+	page, err := s.Store.ListResourcesPage(ctx, kind, opts)
+	time.Sleep(200 * time.Millisecond)
+	return page, err
+}
+
+func TestConcurrencyPolicy_ConcurrentCreates(t *testing.T) {
+	controller, _, instanceID := setupConcurrencyTest(t, &workflowv1.ConcurrencyPolicy{
+		Key:        "org",
+		OnConflict: workflowv1.ConcurrencyConflictAction_CONCURRENCY_QUEUE,
+	})
+
+	// Slow lookups make the creates overlap between lookup and persist
+	controller.store = slowListStore{Store: controller.store}
+
+	const n = 4
+	created := make([]*workflowexecutionv1.WorkflowExecution, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			created[i], errs[i] = controller.Create(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecution{
+				ApiVersion: "agentic.stigmer.ai/v1",
+				Kind:       "WorkflowExecution",
+				Metadata: &apiresource.ApiResourceMetadata{
+					Name:       fmt.Sprintf("Sync %d", i),
+					Org:        "acme",
+					OwnerScope: apiresource.ApiResourceOwnerScope_organization,
+				},
+				Spec: &workflowexecutionv1.WorkflowExecutionSpec{
+					WorkflowInstanceId: instanceID,
+				},
+			})
+		}()
+	}
+	wg.Wait()
+
+	started := 0
+	for i, execution := range created {
+		if errs[i] != nil {
+			t.Fatalf("Create %d failed: %v", i, errs[i])
+		}
+		if execution.Status.ConcurrencyDecision == workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_STARTED {
+			started++
+		}
+	}
+	if started != 1 {
+		t.Errorf("%d executions started for the same key, want 1", started)
+	}
+}
+
+func TestConcurrencyPolicy_UpdateKeepsKeyLabel(t *testing.T) {
+	controller, _, instanceID := setupConcurrencyTest(t, &workflowv1.ConcurrencyPolicy{
+		Key:        "org",
+		OnConflict: workflowv1.ConcurrencyConflictAction_CONCURRENCY_QUEUE,
+	})
+
+	first := createConcurrencyTestExecution(t, controller, instanceID, 1, "acme")
+	if got := first.Metadata.Labels[concurrencyKeyLabel]; got != "wf-test-workflow/org=acme" {
+		t.Fatalf("concurrency key label = %q, want %q", got, "wf-test-workflow/org=acme")
+	}
+
+	first.Metadata.Labels = map[string]string{"team": "data"}
+	updated, err := controller.Update(contextWithWorkflowExecutionKind(), first)
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if got := updated.Metadata.Labels[concurrencyKeyLabel]; got != "wf-test-workflow/org=acme" {
+		t.Errorf("concurrency key label after update = %q, want it kept", got)
+	}
+
+	// The updated execution still holds the key
+	second := createConcurrencyTestExecution(t, controller, instanceID, 2, "acme")
+	if got := second.Status.ConcurrencyDecision; got != workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_QUEUED {
+		t.Errorf("second decision = %v, want CONCURRENCY_QUEUED", got)
+	}
+}
+
+func TestConcurrencyPolicy_Skip(t *testing.T) {
+	controller, _, instanceID := setupConcurrencyTest(t, &workflowv1.ConcurrencyPolicy{
+		Key:        "org",
+		OnConflict: workflowv1.ConcurrencyConflictAction_CONCURRENCY_SKIP,
+	})
+
+	first := createConcurrencyTestExecution(t, controller, instanceID, 1, "acme")
+	second := createConcurrencyTestExecution(t, controller, instanceID, 2, "acme")
+
+	if got := second.Status.ConcurrencyDecision; got != workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_SKIPPED {
+		t.Errorf("second decision = %v, want CONCURRENCY_SKIPPED", got)
+	}
+	if got := second.Status.Phase; got != workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED {
+		t.Errorf("second phase = %v, want EXECUTION_CANCELLED", got)
+	}
+	if got := second.Status.ConcurrencyBlockingExecutionId; got != first.Metadata.Id {
+		t.Errorf("second blocking execution = %q, want %q", got, first.Metadata.Id)
+	}
+}
+
+func TestConcurrencyPolicy_CancelExisting(t *testing.T) {
+	controller, store, instanceID := setupConcurrencyTest(t, &workflowv1.ConcurrencyPolicy{
+		Key:        "workflow",
+		OnConflict: workflowv1.ConcurrencyConflictAction_CONCURRENCY_CANCEL_EXISTING,
+	})
+
+	first := createConcurrencyTestExecution(t, controller, instanceID, 1, "acme")
+	second := createConcurrencyTestExecution(t, controller, instanceID, 2, "globex")
+
+	if got := second.Status.ConcurrencyDecision; got != workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_CANCELLED_EXISTING {
+		t.Errorf("second decision = %v, want CONCURRENCY_CANCELLED_EXISTING", got)
+	}
+	if got := second.Status.Phase; got != workflowexecutionv1.ExecutionPhase_EXECUTION_PENDING {
+		t.Errorf("second phase = %v, want EXECUTION_PENDING", got)
+	}
+
+	cancelled := &workflowexecutionv1.WorkflowExecution{}
+	if err := store.GetResource(contextWithWorkflowExecutionKind(), apiresourcekind.ApiResourceKind_workflow_execution, first.Metadata.Id, cancelled); err != nil {
+		t.Fatalf("failed to load first execution: %v", err)
+	}
+	if got := cancelled.Status.Phase; got != workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED {
+		t.Errorf("first phase = %v, want EXECUTION_CANCELLED", got)
+	}
+}
+
+func TestConcurrencyPolicy_None(t *testing.T) {
+	controller, _, instanceID := setupConcurrencyTest(t, nil)
+
+	createConcurrencyTestExecution(t, controller, instanceID, 1, "acme")
+	second := createConcurrencyTestExecution(t, controller, instanceID, 2, "acme")
+
+	if got := second.Status.ConcurrencyDecision; got != workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_DECISION_UNSPECIFIED {
+		t.Errorf("decision = %v, want CONCURRENCY_DECISION_UNSPECIFIED without a policy", got)
+	}
+	if second.Status.ConcurrencyKey != "" {
+		t.Errorf("concurrency_key = %q, want empty without a policy", second.Status.ConcurrencyKey)
+	}
+}
@@ -34,8 +34,9 @@ const (
 // 5. CheckDuplicate - Verify no duplicate exists
 // 6. BuildNewState - Generate ID, clear status, set audit fields (timestamps, actors, event)
 // 7. SetInitialPhase - Set execution phase to PENDING
-// 8. Persist - Save execution to repository
-// 9. StartWorkflow - Start Temporal workflow (if Temporal is available)
+// 8. EnforceConcurrencyPolicy - Queue, skip, or cancel conflicting executions per the workflow's policy
+// 9. Persist - Save execution to repository
+// 10. StartWorkflow - Start Temporal workflow (if Temporal is available and not queued/skipped)
 //
 // Note: Compared to Stigmer Cloud, OSS excludes:
 // - Authorize step (no multi-tenant auth in OSS)
@@ -50,6 +51,7 @@ const (
 // - Handler enforces: at least one must be provided
 func (c *WorkflowExecutionController) Create(ctx context.Context, execution *workflowexecutionv1.WorkflowExecution) (*workflowexecutionv1.WorkflowExecution, error) {
 	reqCtx := pipeline.NewRequestContext(ctx, execution)
+	defer releaseConcurrencyKey(reqCtx)
 
 	p := c.buildCreatePipeline()
 
@@ -72,8 +74,9 @@ func (c *WorkflowExecutionController) buildCreatePipeline() *pipeline.Pipeline[*
 		AddStep(steps.NewCheckDuplicateStep[*workflowexecutionv1.WorkflowExecution](c.store)). // 5. Check duplicate
 		AddStep(steps.NewBuildNewStateStep[*workflowexecutionv1.WorkflowExecution]()).         // 6. Build new state
 		AddStep(newSetInitialPhaseStep()).                                                     // 7. Set phase to PENDING
-		AddStep(steps.NewPersistStep[*workflowexecutionv1.WorkflowExecution](c.store)).        // 8. Persist execution
-		AddStep(c.newStartWorkflowStep()).                                                     // 9. Start Temporal workflow
+		AddStep(c.newEnforceConcurrencyPolicyStep()).                                          // 8. Enforce concurrency policy
+		AddStep(steps.NewPersistStep[*workflowexecutionv1.WorkflowExecution](c.store)).        // 9. Persist execution
+		AddStep(c.newStartWorkflowStep()).                                                     // 10. Start Temporal workflow
 		Build()
 }
 
@@ -434,6 +437,17 @@ func (s *startWorkflowStep) Execute(ctx *pipeline.RequestContext[*workflowexecut
 	execution := ctx.NewState()
 	executionID := execution.GetMetadata().GetId()
 
+	// Queued executions are started when their concurrency key frees up; skipped ones never run
+	switch execution.GetStatus().GetConcurrencyDecision() {
+	case workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_QUEUED,
+		workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_SKIPPED:
+		log.Info().
+			Str("execution_id", executionID).
+			Str("decision", execution.GetStatus().GetConcurrencyDecision().String()).
+			Msg("Not starting Temporal workflow due to concurrency policy")
+		return nil
+	}
+
 	// Check if Temporal client is available
 	if s.workflowCreator == nil {
 		log.Warn().
@@ -447,7 +461,8 @@ func (s *startWorkflowStep) Execute(ctx *pipeline.RequestContext[*workflowexecut
 		Msg("Starting Temporal workflow")
 
 	// Start the Temporal workflow
-	if err := s.workflowCreator.Create(ctx.Context(), execution); err != nil {
+	maxDuration := workflowMaxDuration(ctx.Context(), s.store, execution)
+	if err := s.workflowCreator.Create(ctx.Context(), execution, maxDuration); err != nil {
 		log.Error().
 			Err(err).
 			Str("execution_id", executionID).
@@ -459,6 +474,7 @@ func (s *startWorkflowStep) Execute(ctx *pipeline.RequestContext[*workflowexecut
 		}
 		execution.Status.Phase = workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED
 		execution.Status.Error = fmt.Sprintf("Failed to start Temporal workflow: %v", err)
+		execution.Status.ErrorClassification = workflowexecutionv1.ErrorClassification_INFRA
 
 		// Persist the failed state
 		if updateErr := s.store.SaveResource(ctx.Context(), apiresourcekind.ApiResourceKind_workflow_execution, executionID, execution); updateErr != nil {
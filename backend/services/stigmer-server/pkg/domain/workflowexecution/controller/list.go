@@ -2,45 +2,84 @@ package workflowexecution
 
 import (
 	"context"
+	"errors"
 
-	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
+	"github.com/rs/zerolog/log"
 	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
-	"google.golang.org/protobuf/encoding/protojson"
+	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"google.golang.org/protobuf/proto"
 )
 
-// List retrieves all workflow executions
+// maxListPageSize is the largest page size List returns; larger requests are clamped.
+const maxListPageSize = 100
+
+// List retrieves workflow executions, newest first, one page at a time
 //
-// This is a simplified implementation for Stigmer OSS.
-// In production Cloud, this would:
-// - Use IAM Policy to filter by authorized resource IDs
-// - Support pagination
-// - Support filtering by workflow_instance_id, phase, etc.
+// Pagination:
+// - page_size limits the page (clamped to 100)
+// - page_token resumes after the previous page (next_page_token of the response)
+// - Pages are ordered by creation time, then ID, and stay stable as executions are created
 //
-// For OSS (local single-user environment):
-// - Returns all executions from BadgerDB
-// - No authorization filtering (single user)
-// - No pagination (acceptable for local usage)
+// A page_size of 0 returns all executions. Unpaginated lists are deprecated
+// and log a warning.
 //
 // Note: Compared to Stigmer Cloud, OSS excludes:
 // - IAM Policy authorization filtering (no multi-tenant auth)
-// - Pagination (not needed for local usage)
 // - Advanced filtering (can be added later if needed)
 func (c *WorkflowExecutionController) List(ctx context.Context, req *workflowexecutionv1.ListWorkflowExecutionsRequest) (*workflowexecutionv1.WorkflowExecutionList, error) {
-	// List all workflow executions from store
-	data, err := c.store.ListResources(ctx, apiresourcekind.ApiResourceKind_workflow_execution)
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, grpclib.InvalidArgumentError("page_size must not be negative")
+	}
+
+	if pageSize == 0 {
+		log.Warn().Msg("Listing workflow executions without page_size is deprecated; set page_size to paginate")
+
+		data, err := c.store.ListResources(ctx, apiresourcekind.ApiResourceKind_workflow_execution)
+		if err != nil {
+			return nil, grpclib.InternalError(err, "failed to list workflow executions")
+		}
+		return &workflowexecutionv1.WorkflowExecutionList{
+			TotalPages: 1,
+			Entries:    unmarshalWorkflowExecutions(data),
+		}, nil
+	}
+
+	if pageSize > maxListPageSize {
+		pageSize = maxListPageSize
+	}
+
+	page, err := c.store.ListResourcesPage(ctx, apiresourcekind.ApiResourceKind_workflow_execution, store.ListOptions{
+		PageSize:   pageSize,
+		PageToken:  req.GetPageToken(),
+		Descending: true,
+	})
 	if err != nil {
+		if errors.Is(err, store.ErrInvalidPageToken) {
+			return nil, grpclib.InvalidArgumentError("invalid page_token")
+		}
 		return nil, grpclib.InternalError(err, "failed to list workflow executions")
 	}
 
+	return &workflowexecutionv1.WorkflowExecutionList{
+		TotalPages:    int32((page.TotalCount + pageSize - 1) / pageSize),
+		Entries:       unmarshalWorkflowExecutions(page.Items),
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// unmarshalWorkflowExecutions decodes stored executions, skipping invalid entries.
+func unmarshalWorkflowExecutions(data [][]byte) []*workflowexecutionv1.WorkflowExecution {
 	executions := make([]*workflowexecutionv1.WorkflowExecution, 0, len(data))
 	for _, d := range data {
 		execution := &workflowexecutionv1.WorkflowExecution{}
-		if err := protojson.Unmarshal(d, execution); err != nil {
-			continue // Skip invalid entries
+		if err := proto.Unmarshal(d, execution); err != nil {
+			log.Warn().Err(err).Msg("Failed to unmarshal workflow execution, skipping")
+			continue
 		}
 		executions = append(executions, execution)
 	}
-
-	return &workflowexecutionv1.WorkflowExecutionList{Entries: executions}, nil
+	return executions
 }
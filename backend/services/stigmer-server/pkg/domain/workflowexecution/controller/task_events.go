@@ -0,0 +1,91 @@
+package workflowexecution
+
+import (
+	"time"
+
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+)
+
+// taskEventTimeFormat is the timestamp format of task events: RFC 3339 with
+// millisecond precision, so that short tasks get meaningful durations.
+const taskEventTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// appendTaskEvents records the state transitions of the reported tasks.
+//
+// A report that does not change the state of a task (same status and
+// attempt as its last event) is not recorded, nor are statuses that are not
+// transitions of an attempt (pending, skipped, awaiting approval).
+func appendTaskEvents(events []*workflowexecutionv1.WorkflowTaskEvent, tasks []*workflowexecutionv1.WorkflowTask, now time.Time) []*workflowexecutionv1.WorkflowTaskEvent {
+	for _, task := range tasks {
+		attempt := task.GetAttempt()
+		if attempt < 1 {
+			attempt = 1
+		}
+
+		var eventType workflowexecutionv1.WorkflowTaskEventType
+		switch task.GetStatus() {
+		case workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_IN_PROGRESS:
+			eventType = workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_STARTED
+			if attempt > 1 {
+				eventType = workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_RETRIED
+			}
+		case workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_COMPLETED:
+			eventType = workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_COMPLETED
+		case workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_FAILED:
+			eventType = workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_FAILED
+		default:
+			continue
+		}
+
+		last := lastTaskEvent(events, task.GetTaskName())
+		if last != nil && last.GetType() == eventType && last.GetAttempt() == attempt {
+			continue
+		}
+
+		event := &workflowexecutionv1.WorkflowTaskEvent{
+			TaskName:  task.GetTaskName(),
+			Type:      eventType,
+			Timestamp: now.UTC().Format(taskEventTimeFormat),
+			Attempt:   attempt,
+		}
+		if eventType == workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_COMPLETED ||
+			eventType == workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_FAILED {
+			event.DurationMs = attemptDurationMs(last, now)
+		}
+		if eventType == workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_FAILED {
+			event.Error = task.GetError()
+			event.ErrorClassification = task.GetErrorClassification()
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// lastTaskEvent returns the most recent event of a task, or nil.
+func lastTaskEvent(events []*workflowexecutionv1.WorkflowTaskEvent, taskName string) *workflowexecutionv1.WorkflowTaskEvent {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].GetTaskName() == taskName {
+			return events[i]
+		}
+	}
+	return nil
+}
+
+// attemptDurationMs returns the time since the start event of the current
+// attempt, or 0 when the last event of the task is not a start.
+func attemptDurationMs(last *workflowexecutionv1.WorkflowTaskEvent, now time.Time) int64 {
+	if last == nil {
+		return 0
+	}
+	switch last.GetType() {
+	case workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_STARTED,
+		workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_RETRIED:
+	default:
+		return 0
+	}
+	startedAt, err := time.Parse(taskEventTimeFormat, last.GetTimestamp())
+	if err != nil {
+		return 0
+	}
+	return now.Sub(startedAt).Milliseconds()
+}
@@ -0,0 +1,100 @@
+package workflowexecution
+
+import (
+	"testing"
+	"time"
+
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+)
+
+func taskReport(name string, status workflowexecutionv1.WorkflowTaskStatus, attempt int32) *workflowexecutionv1.WorkflowTask {
+	return &workflowexecutionv1.WorkflowTask{TaskId: name, TaskName: name, Status: status, Attempt: attempt}
+}
+
+func TestAppendTaskEvents(t *testing.T) {
+	start := time.Date(2025, 1, 11, 14, 30, 22, 0, time.UTC)
+
+	failed := taskReport("fetch", workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_FAILED, 1)
+	failed.Error = "404 Not Found"
+	failed.ErrorClassification = workflowexecutionv1.ErrorClassification_UPSTREAM_4XX
+
+	reports := []struct {
+		task *workflowexecutionv1.WorkflowTask
+		at   time.Duration
+	}{
+		{taskReport("fetch", workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_IN_PROGRESS, 1), 0},
+		{taskReport("fetch", workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_IN_PROGRESS, 1), 100 * time.Millisecond},
+		{failed, 250 * time.Millisecond},
+		{taskReport("fetch", workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_IN_PROGRESS, 2), time.Second},
+		{taskReport("fetch", workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_COMPLETED, 2), 3 * time.Second},
+		{taskReport("approve", workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_AWAITING_APPROVAL, 0), 4 * time.Second},
+		{taskReport("notify", workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_IN_PROGRESS, 0), 5 * time.Second},
+	}
+
+	var events []*workflowexecutionv1.WorkflowTaskEvent
+	for _, report := range reports {
+		events = appendTaskEvents(events, []*workflowexecutionv1.WorkflowTask{report.task}, start.Add(report.at))
+	}
+
+	want := []struct {
+		task       string
+		eventType  workflowexecutionv1.WorkflowTaskEventType
+		attempt    int32
+		durationMs int64
+	}{
+		{"fetch", workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_STARTED, 1, 0},
+		{"fetch", workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_FAILED, 1, 250},
+		{"fetch", workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_RETRIED, 2, 0},
+		{"fetch", workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_COMPLETED, 2, 2000},
+		{"notify", workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_STARTED, 1, 0},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		got := events[i]
+		if got.TaskName != w.task || got.Type != w.eventType || got.Attempt != w.attempt || got.DurationMs != w.durationMs {
+			t.Errorf("event %d = %s %s attempt %d %dms, want %s %s attempt %d %dms",
+				i, got.TaskName, got.Type, got.Attempt, got.DurationMs, w.task, w.eventType, w.attempt, w.durationMs)
+		}
+	}
+
+	if got := events[0].Timestamp; got != "2025-01-11T14:30:22.000Z" {
+		t.Errorf("timestamp = %q, want 2025-01-11T14:30:22.000Z", got)
+	}
+	if events[1].Error != "404 Not Found" ||
+		events[1].ErrorClassification != workflowexecutionv1.ErrorClassification_UPSTREAM_4XX {
+		t.Errorf("failed event = %v, want error and classification", events[1])
+	}
+}
+
+func TestWorkflowExecutionController_UpdateStatusRecordsTaskEvents(t *testing.T) {
+	controller, _, _ := setupApprovalTest(t)
+
+	for _, status := range []workflowexecutionv1.WorkflowTaskStatus{
+		workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_IN_PROGRESS,
+		workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_COMPLETED,
+	} {
+		_, err := controller.UpdateStatus(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionUpdateStatusInput{
+			ExecutionId: "wex-approval",
+			Status: &workflowexecutionv1.WorkflowExecutionStatus{
+				Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_IN_PROGRESS,
+				Tasks: []*workflowexecutionv1.WorkflowTask{taskReport("fetch", status, 1)},
+			},
+		})
+		if err != nil {
+			t.Fatalf("UpdateStatus failed: %v", err)
+		}
+	}
+
+	execution, err := controller.Get(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionId{Value: "wex-approval"})
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	events := execution.Status.TaskEvents
+	if len(events) != 2 ||
+		events[0].Type != workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_STARTED ||
+		events[1].Type != workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_COMPLETED {
+		t.Errorf("task events = %v, want started then completed", events)
+	}
+}
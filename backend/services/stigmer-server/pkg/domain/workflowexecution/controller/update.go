@@ -18,7 +18,8 @@ import (
 // 2. ResolveSlug - Generate slug from metadata.name
 // 3. LoadExisting - Load existing execution from repository by ID
 // 4. BuildUpdateState - Merge spec, preserve IDs, update timestamps, clear computed fields
-// 5. Persist - Save updated execution to repository
+// 5. KeepConcurrencyKeyLabel - Restore the label indexing the concurrency key
+// 6. Persist - Save updated execution to repository
 //
 // Note: Compared to Stigmer Cloud, OSS excludes:
 // - Authorize step (no multi-tenant auth in OSS)
@@ -41,10 +42,11 @@ func (c *WorkflowExecutionController) buildUpdatePipeline() *pipeline.Pipeline[*
 	// api_resource_kind is automatically extracted from proto service descriptor
 	// by the apiresource interceptor and injected into request context
 	return pipeline.NewPipeline[*workflowexecutionv1.WorkflowExecution]("workflowexecution-update").
-		AddStep(steps.NewValidateProtoStep[*workflowexecutionv1.WorkflowExecution]()).            // 1. Validate field constraints
-		AddStep(steps.NewResolveSlugStep[*workflowexecutionv1.WorkflowExecution]()).              // 2. Resolve slug
-		AddStep(steps.NewLoadExistingStep[*workflowexecutionv1.WorkflowExecution](c.store)).     // 3. Load existing execution
-		AddStep(steps.NewBuildUpdateStateStep[*workflowexecutionv1.WorkflowExecution]()).        // 4. Build updated state
-		AddStep(steps.NewPersistStep[*workflowexecutionv1.WorkflowExecution](c.store)).          // 5. Persist execution
+		AddStep(steps.NewValidateProtoStep[*workflowexecutionv1.WorkflowExecution]()).       // 1. Validate field constraints
+		AddStep(steps.NewResolveSlugStep[*workflowexecutionv1.WorkflowExecution]()).         // 2. Resolve slug
+		AddStep(steps.NewLoadExistingStep[*workflowexecutionv1.WorkflowExecution](c.store)). // 3. Load existing execution
+		AddStep(steps.NewBuildUpdateStateStep[*workflowexecutionv1.WorkflowExecution]()).    // 4. Build updated state
+		AddStep(newKeepConcurrencyKeyLabelStep()).                                           // 5. Keep concurrency key label
+		AddStep(steps.NewPersistStep[*workflowexecutionv1.WorkflowExecution](c.store)).      // 6. Persist execution
 		Build()
 }
@@ -2,6 +2,7 @@ package workflowexecution
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/stigmer/stigmer/backend/libs/go/store"
@@ -23,6 +24,7 @@ import (
 // 3. BuildNewStateWithStatus - Merge status updates from input
 // 4. Persist - Save to database
 // 5. BroadcastToStreams - Push update to active Go channels (ADR 011)
+// 6. StartNextQueuedExecution - Start the next execution queued by the concurrency policy
 //
 // Note: Compared to Stigmer Cloud, OSS excludes:
 // - Authorize step (no multi-tenant auth in OSS)
@@ -37,8 +39,9 @@ func (c *WorkflowExecutionController) UpdateStatus(ctx context.Context, input *w
 		AddStep(newValidateUpdateStatusInputStep()).
 		AddStep(newLoadExistingExecutionStep(c.store)).
 		AddStep(newBuildNewStateWithStatusStep()).
-		AddStep(newPersistExecutionStep(c.store)).
-		AddStep(newBroadcastToStreamsStep(c.streamBroker)).
+		AddStep(newPersistExecutionStep[*workflowexecutionv1.WorkflowExecutionUpdateStatusInput](c.store)).
+		AddStep(newBroadcastToStreamsStep[*workflowexecutionv1.WorkflowExecutionUpdateStatusInput](c.streamBroker)).
+		AddStep(c.newStartNextQueuedExecutionStep()).
 		Build()
 
 	// Execute pipeline
@@ -128,9 +131,15 @@ func (s *LoadExistingExecutionStep) Execute(ctx *pipeline.RequestContext[*workfl
 // BuildNewStateWithStatusStep merges status updates from input with existing execution
 //
 // This step follows the Java implementation's merge logic:
+// - Appends task state transitions to task_events
 // - Replaces tasks array
 // - Updates phase, output, error, timestamps if provided
 // - Preserves spec from existing execution (does NOT update spec)
+//
+// Pending approvals are upserted by task name and cleared once the execution
+// reaches a terminal phase. While any are pending, the phase stays
+// EXECUTION_AWAITING_APPROVAL. A cancelling execution stays
+// EXECUTION_CANCELLING until the runner reports a terminal phase.
 type BuildNewStateWithStatusStep struct{}
 
 func newBuildNewStateWithStatusStep() *BuildNewStateWithStatusStep {
@@ -161,7 +170,8 @@ func (s *BuildNewStateWithStatusStep) Execute(ctx *pipeline.RequestContext[*work
 	// CRITICAL: Merge status from input (for progressive updates from workflow-runner)
 	// Following Java implementation's merge strategy
 
-	// Merge tasks (replace with latest from request)
+	// Record task state transitions, then merge tasks (replace with latest from request)
+	updated.Status.TaskEvents = appendTaskEvents(updated.Status.TaskEvents, requestStatus.Tasks, time.Now())
 	if len(requestStatus.Tasks) > 0 {
 		updated.Status.Tasks = requestStatus.Tasks
 	}
@@ -171,6 +181,25 @@ func (s *BuildNewStateWithStatusStep) Execute(ctx *pipeline.RequestContext[*work
 		updated.Status.Phase = requestStatus.Phase
 	}
 
+	// Merge pending approvals (upsert by task name)
+	for _, approval := range requestStatus.PendingApprovals {
+		updated.Status.PendingApprovals = upsertPendingApproval(updated.Status.PendingApprovals, approval)
+	}
+	if isTerminalPhase(updated.Status.Phase) {
+		updated.Status.PendingApprovals = nil
+	} else if len(updated.Status.PendingApprovals) > 0 {
+		// Progress of other tasks (e.g. parallel branches) must not hide that
+		// the execution waits for a decision
+		updated.Status.Phase = workflowexecutionv1.ExecutionPhase_EXECUTION_AWAITING_APPROVAL
+	}
+
+	// Once cancellation was requested, progress reported while the runner
+	// stops the workflow must not move the execution back to running
+	if existing.GetStatus().GetPhase() == workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLING &&
+		!isTerminalPhase(updated.Status.Phase) {
+		updated.Status.Phase = workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLING
+	}
+
 	// Update output (if provided)
 	if requestStatus.Output != nil {
 		updated.Status.Output = requestStatus.Output
@@ -181,6 +210,21 @@ func (s *BuildNewStateWithStatusStep) Execute(ctx *pipeline.RequestContext[*work
 		updated.Status.Error = requestStatus.Error
 	}
 
+	// Update failure classification (if provided)
+	if requestStatus.ErrorClassification != workflowexecutionv1.ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED {
+		updated.Status.ErrorClassification = requestStatus.ErrorClassification
+	}
+	if requestStatus.FailedTask != "" {
+		updated.Status.FailedTask = requestStatus.FailedTask
+	}
+	if updated.Status.Phase == workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED {
+		attributeFailure(updated.Status)
+	}
+	if updated.Status.Phase == workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED &&
+		updated.Status.Error == "" && updated.Status.CancelledBy != "" {
+		updated.Status.Error = cancellationMessage(updated.Status.CancelledBy, updated.Status.CancelReason)
+	}
+
 	// Update timestamps (if provided)
 	if requestStatus.StartedAt != "" {
 		updated.Status.StartedAt = requestStatus.StartedAt
@@ -206,20 +250,21 @@ func (s *BuildNewStateWithStatusStep) Execute(ctx *pipeline.RequestContext[*work
 	return nil
 }
 
-// PersistExecutionStep saves the execution to database
-type PersistExecutionStep struct {
+// PersistExecutionStep saves the execution stored under "execution" in the
+// request context to database
+type PersistExecutionStep[T proto.Message] struct {
 	store store.Store
 }
 
-func newPersistExecutionStep(store store.Store) *PersistExecutionStep {
-	return &PersistExecutionStep{store: store}
+func newPersistExecutionStep[T proto.Message](store store.Store) *PersistExecutionStep[T] {
+	return &PersistExecutionStep[T]{store: store}
 }
 
-func (s *PersistExecutionStep) Name() string {
+func (s *PersistExecutionStep[T]) Name() string {
 	return "PersistExecution"
 }
 
-func (s *PersistExecutionStep) Execute(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecutionUpdateStatusInput]) error {
+func (s *PersistExecutionStep[T]) Execute(ctx *pipeline.RequestContext[T]) error {
 	execution, ok := ctx.Get("execution").(*workflowexecutionv1.WorkflowExecution)
 	if !ok {
 		return grpclib.InternalError(nil, "execution not found in context")
@@ -250,19 +295,19 @@ func (s *PersistExecutionStep) Execute(ctx *pipeline.RequestContext[*workflowexe
 //
 // After persisting to BadgerDB, the daemon must push updates to in-memory channels
 // so that Subscribe() streams can receive updates in real-time without polling.
-type BroadcastToStreamsStep struct {
+type BroadcastToStreamsStep[T proto.Message] struct {
 	broker *StreamBroker
 }
 
-func newBroadcastToStreamsStep(broker *StreamBroker) *BroadcastToStreamsStep {
-	return &BroadcastToStreamsStep{broker: broker}
+func newBroadcastToStreamsStep[T proto.Message](broker *StreamBroker) *BroadcastToStreamsStep[T] {
+	return &BroadcastToStreamsStep[T]{broker: broker}
 }
 
-func (s *BroadcastToStreamsStep) Name() string {
+func (s *BroadcastToStreamsStep[T]) Name() string {
 	return "BroadcastToStreams"
 }
 
-func (s *BroadcastToStreamsStep) Execute(ctx *pipeline.RequestContext[*workflowexecutionv1.WorkflowExecutionUpdateStatusInput]) error {
+func (s *BroadcastToStreamsStep[T]) Execute(ctx *pipeline.RequestContext[T]) error {
 	execution, ok := ctx.Get("execution").(*workflowexecutionv1.WorkflowExecution)
 	if !ok {
 		return grpclib.InternalError(nil, "execution not found in context")
@@ -278,3 +323,21 @@ func (s *BroadcastToStreamsStep) Execute(ctx *pipeline.RequestContext[*workflowe
 
 	return nil
 }
+
+// attributeFailure fills the failed task and classification of a failed
+// execution from its last failed task, unless the runner reported them.
+func attributeFailure(status *workflowexecutionv1.WorkflowExecutionStatus) {
+	for i := len(status.Tasks) - 1; i >= 0; i-- {
+		task := status.Tasks[i]
+		if task.Status != workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_FAILED {
+			continue
+		}
+		if status.FailedTask == "" {
+			status.FailedTask = task.TaskName
+		}
+		if status.ErrorClassification == workflowexecutionv1.ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED {
+			status.ErrorClassification = task.ErrorClassification
+		}
+		return
+	}
+}
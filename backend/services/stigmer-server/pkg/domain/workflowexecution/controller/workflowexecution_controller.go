@@ -34,6 +34,7 @@ type WorkflowExecutionController struct {
 	workflowInstanceClient *workflowinstance.Client
 	workflowCreator        *workflows.InvokeWorkflowExecutionWorkflowCreator
 	streamBroker           *StreamBroker
+	concurrencyLocks       concurrencyLocks
 }
 
 // NewWorkflowExecutionController creates a new WorkflowExecutionController
@@ -2,6 +2,7 @@ package workflowexecution
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
@@ -12,6 +13,8 @@ import (
 	"github.com/stigmer/stigmer/backend/libs/go/store"
 	"github.com/stigmer/stigmer/backend/libs/go/store/sqlite"
 	apiresourceinterceptor "github.com/stigmer/stigmer/backend/libs/go/grpc/interceptors/apiresource"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // contextWithWorkflowExecutionKind creates a context with the workflow execution resource kind injected
@@ -478,3 +481,150 @@ func TestWorkflowExecutionController_Delete(t *testing.T) {
 		}
 	})
 }
+
+func TestWorkflowExecutionController_List(t *testing.T) {
+	controller, store := setupTestController(t)
+	defer store.Close()
+
+	for i := 0; i < 5; i++ {
+		id := fmt.Sprintf("wex-%d", i)
+		execution := &workflowexecutionv1.WorkflowExecution{
+			ApiVersion: "agentic.stigmer.ai/v1",
+			Kind:       "WorkflowExecution",
+			Metadata:   &apiresource.ApiResourceMetadata{Id: id, Name: id},
+			Spec:       &workflowexecutionv1.WorkflowExecutionSpec{WorkflowInstanceId: "wfi-test-instance"},
+		}
+		if err := store.SaveResource(contextWithWorkflowExecutionKind(), apiresourcekind.ApiResourceKind_workflow_execution, id, execution); err != nil {
+			t.Fatalf("failed to save execution: %v", err)
+		}
+	}
+
+	t.Run("pages through all executions", func(t *testing.T) {
+		seen := map[string]bool{}
+		token := ""
+		pages := 0
+		for {
+			list, err := controller.List(contextWithWorkflowExecutionKind(), &workflowexecutionv1.ListWorkflowExecutionsRequest{PageSize: 2, PageToken: token})
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			pages++
+			if list.TotalPages != 3 {
+				t.Errorf("Expected total_pages 3, got %d", list.TotalPages)
+			}
+			for _, e := range list.Entries {
+				if seen[e.Metadata.Id] {
+					t.Errorf("Execution %s returned twice", e.Metadata.Id)
+				}
+				seen[e.Metadata.Id] = true
+			}
+			if list.NextPageToken == "" {
+				break
+			}
+			token = list.NextPageToken
+		}
+		if pages != 3 || len(seen) != 5 {
+			t.Errorf("Expected 5 executions in 3 pages, got %d in %d", len(seen), pages)
+		}
+	})
+
+	t.Run("page size is clamped", func(t *testing.T) {
+		list, err := controller.List(contextWithWorkflowExecutionKind(), &workflowexecutionv1.ListWorkflowExecutionsRequest{PageSize: 1000})
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(list.Entries) != 5 || list.TotalPages != 1 || list.NextPageToken != "" {
+			t.Errorf("Expected a single page of 5, got %d entries, total_pages %d", len(list.Entries), list.TotalPages)
+		}
+	})
+
+	t.Run("zero page size returns everything", func(t *testing.T) {
+		list, err := controller.List(contextWithWorkflowExecutionKind(), &workflowexecutionv1.ListWorkflowExecutionsRequest{})
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(list.Entries) != 5 {
+			t.Errorf("Expected all 5 executions, got %d", len(list.Entries))
+		}
+	})
+
+	t.Run("invalid page token", func(t *testing.T) {
+		_, err := controller.List(contextWithWorkflowExecutionKind(), &workflowexecutionv1.ListWorkflowExecutionsRequest{PageSize: 2, PageToken: "not-a-token"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("Expected InvalidArgument, got %v", err)
+		}
+	})
+}
+
+func TestWorkflowExecutionController_UpdateStatusFailureClassification(t *testing.T) {
+	controller, store := setupTestController(t)
+	defer store.Close()
+
+	execution := &workflowexecutionv1.WorkflowExecution{
+		ApiVersion: "agentic.stigmer.ai/v1",
+		Kind:       "WorkflowExecution",
+		Metadata: &apiresource.ApiResourceMetadata{
+			Id:   "wex-failing",
+			Name: "Failing",
+		},
+		Status: &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_IN_PROGRESS,
+		},
+	}
+	if err := store.SaveResource(contextWithWorkflowExecutionKind(), apiresourcekind.ApiResourceKind_workflow_execution, execution.Metadata.Id, execution); err != nil {
+		t.Fatalf("failed to save execution: %v", err)
+	}
+
+	// The runner reports the failed task with its classification
+	_, err := controller.UpdateStatus(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionUpdateStatusInput{
+		ExecutionId: "wex-failing",
+		Status: &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_IN_PROGRESS,
+			Tasks: []*workflowexecutionv1.WorkflowTask{
+				{
+					TaskId:              "fetchOrders",
+					TaskName:            "fetchOrders",
+					Status:              workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_FAILED,
+					Error:               "503 Service Unavailable",
+					ErrorClassification: workflowexecutionv1.ErrorClassification_UPSTREAM_5XX,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	// The final status leaves the classification to the failed task
+	updated, err := controller.UpdateStatus(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionUpdateStatusInput{
+		ExecutionId: "wex-failing",
+		Status: &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+			Error: "Workflow execution failed: activity error",
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if got := updated.Status.FailedTask; got != "fetchOrders" {
+		t.Errorf("failed task = %q, want fetchOrders", got)
+	}
+	if got := updated.Status.ErrorClassification; got != workflowexecutionv1.ErrorClassification_UPSTREAM_5XX {
+		t.Errorf("error classification = %v, want UPSTREAM_5XX", got)
+	}
+
+	// A classification reported by the runner wins
+	updated, err = controller.UpdateStatus(contextWithWorkflowExecutionKind(), &workflowexecutionv1.WorkflowExecutionUpdateStatusInput{
+		ExecutionId: "wex-failing",
+		Status: &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+			ErrorClassification: workflowexecutionv1.ErrorClassification_TIMEOUT,
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if got := updated.Status.ErrorClassification; got != workflowexecutionv1.ErrorClassification_TIMEOUT {
+		t.Errorf("error classification = %v, want TIMEOUT", got)
+	}
+}
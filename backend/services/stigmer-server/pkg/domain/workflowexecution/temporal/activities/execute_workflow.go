@@ -45,14 +45,24 @@ type ExecuteWorkflowActivity interface {
 // This MUST match the activity name in the workflow-runner implementation.
 const ExecuteWorkflowActivityName = "ExecuteWorkflow"
 
+// ExecuteWorkflowHeartbeatTimeout is the heartbeat timeout of the
+// ExecuteWorkflow activity. The runner must heartbeat more often than this.
+const ExecuteWorkflowHeartbeatTimeout = 30 * time.Second
+
 // NewExecuteWorkflowActivityStub creates an activity stub for executing workflows.
 //
 // ctx: Workflow context
 // taskQueue: Task queue for routing to Go worker (from workflow memo)
-func NewExecuteWorkflowActivityStub(ctx workflow.Context, taskQueue string) ExecuteWorkflowActivity {
+// timeout: Start-to-close timeout, the run timeout of the invoking workflow
+func NewExecuteWorkflowActivityStub(ctx workflow.Context, taskQueue string, timeout time.Duration) ExecuteWorkflowActivity {
 	options := workflow.ActivityOptions{
 		TaskQueue:           taskQueue,
-		StartToCloseTimeout: 30 * time.Minute, // Longer timeout for workflows
+		StartToCloseTimeout: timeout,
+		// The runner heartbeats while the Zigflow workflow runs, which is how
+		// cancellation of this workflow reaches it. Waiting for the activity
+		// lets the runner stop the Zigflow workflow and report its tasks first.
+		HeartbeatTimeout:    ExecuteWorkflowHeartbeatTimeout,
+		WaitForCancellation: true,
 		RetryPolicy: &temporal.RetryPolicy{
 			MaximumAttempts: 1, // No retries for workflow execution
 			InitialInterval: 10 * time.Second,
@@ -44,6 +44,16 @@ func (w *InvokeWorkflowExecutionWorkflowImpl) Run(ctx workflow.Context, executio
 
 	// Execute the Zigflow workflow flow
 	if err := w.executeWorkflowFlow(ctx, execution); err != nil {
+		// Cancelled through the cancel RPC: the runner stopped the Zigflow
+		// workflow and reported its tasks, so only the phase is recorded here
+		if temporal.IsCanceledError(err) {
+			logger.Info("Workflow execution cancelled", "execution_id", executionID)
+			if err := w.updateStatusOnCancel(ctx, executionID); err != nil {
+				logger.Error("❌ Failed to update execution status", "error", err.Error())
+			}
+			return err
+		}
+
 		logger.Error("❌ Workflow execution failed", "execution_id", executionID, "error", err.Error())
 
 		// Update execution status to FAILED with error details
@@ -90,7 +100,14 @@ func (w *InvokeWorkflowExecutionWorkflowImpl) executeWorkflowFlow(ctx workflow.C
 	logger.Info("Executing Zigflow workflow", "execution_id", executionID)
 	logger.Info("workflow-runner will send progressive status updates via gRPC during execution")
 
-	executeWorkflowActivity := activities.NewExecuteWorkflowActivityStub(ctx, activityTaskQueue)
+	// The activity may run as long as this workflow, which Create bounds by
+	// the maximum duration of the workflow
+	timeout := workflow.GetInfo(ctx).WorkflowRunTimeout
+	if timeout <= 0 {
+		timeout = DefaultExecutionTimeout
+	}
+
+	executeWorkflowActivity := activities.NewExecuteWorkflowActivityStub(ctx, activityTaskQueue, timeout)
 	finalStatus, err := executeWorkflowActivity.ExecuteWorkflow(execution)
 	if err != nil {
 		return fmt.Errorf("failed to execute workflow: %w", err)
@@ -130,6 +147,27 @@ func (w *InvokeWorkflowExecutionWorkflowImpl) getActivityTaskQueue(ctx workflow.
 	return "workflow_execution_runner"
 }
 
+// updateStatusOnCancel updates the execution status to CANCELLED after the
+// workflow was cancelled. The workflow context is already cancelled, so the
+// update runs on a disconnected context.
+func (w *InvokeWorkflowExecutionWorkflowImpl) updateStatusOnCancel(ctx workflow.Context, executionID string) error {
+	cancelledStatus := &workflowexecutionv1.WorkflowExecutionStatus{
+		Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED,
+		ErrorClassification: workflowexecutionv1.ErrorClassification_CANCELLED,
+	}
+
+	disconnectedCtx, _ := workflow.NewDisconnectedContext(ctx)
+	localCtx := workflow.WithLocalActivityOptions(disconnectedCtx, workflow.LocalActivityOptions{
+		ScheduleToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+			InitialInterval: 2 * time.Second,
+		},
+	})
+
+	return workflow.ExecuteLocalActivity(localCtx, activities.UpdateWorkflowExecutionStatusActivityName, executionID, cancelledStatus).Get(localCtx, nil)
+}
+
 // updateStatusOnFailure updates the execution status to FAILED when a system error occurs.
 func (w *InvokeWorkflowExecutionWorkflowImpl) updateStatusOnFailure(ctx workflow.Context, executionID string, originalErr error) error {
 	logger := workflow.GetLogger(ctx)
@@ -139,8 +177,9 @@ func (w *InvokeWorkflowExecutionWorkflowImpl) updateStatusOnFailure(ctx workflow
 	// Create failed status with error details
 	// Only set phase and error - don't create artificial tasks
 	failedStatus := &workflowexecutionv1.WorkflowExecutionStatus{
-		Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
-		Error: fmt.Sprintf("Workflow execution failed: %s", originalErr.Error()),
+		Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+		Error:               fmt.Sprintf("Workflow execution failed: %s", originalErr.Error()),
+		ErrorClassification: workflowexecutionv1.ErrorClassification_INFRA,
 	}
 
 	// Create local activity stub for status update (runs in-process)
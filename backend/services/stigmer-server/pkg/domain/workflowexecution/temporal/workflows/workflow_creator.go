@@ -2,14 +2,48 @@ package workflows
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
 	"github.com/rs/zerolog/log"
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
 )
 
+// ApprovalRejectedErrorType is the error type an approval task fails with
+// when it is rejected. Workflows can catch it with a try task.
+const ApprovalRejectedErrorType = "ApprovalRejected"
+
+// ErrApprovalNotPending is returned when the activity an approval task waits
+// on no longer exists, e.g. because the approval timed out or the execution
+// was cancelled.
+var ErrApprovalNotPending = errors.New("approval is no longer pending")
+
+// ErrWorkflowNotRunning is returned by Cancel when the execution has no
+// running workflow, e.g. because it already finished.
+var ErrWorkflowNotRunning = errors.New("workflow is not running")
+
+// DefaultExecutionTimeout bounds the invoking workflow, and the
+// ExecuteWorkflow activity it runs, for workflows without a maximum duration
+// or with a shorter one.
+const DefaultExecutionTimeout = 30 * time.Minute
+
+// maxDurationGrace is added to the maximum duration of a workflow for the
+// server-side timeouts. It is longer than the grace period the runner gives
+// on-failure tasks, so the runner times the workflow out first and the
+// execution is classified WORKFLOW_TIMEOUT.
+const maxDurationGrace = 5 * time.Minute
+
+// ExecutionTimeout returns the run timeout of the invoking workflow for a
+// workflow with the given maximum duration (0 for none): the maximum plus a
+// grace period, and at least DefaultExecutionTimeout.
+func ExecutionTimeout(maxDuration time.Duration) time.Duration {
+	return max(DefaultExecutionTimeout, maxDuration+maxDurationGrace)
+}
+
 // InvokeWorkflowExecutionWorkflowCreator creates and starts Temporal workflows for workflow execution invocation.
 // Called by WorkflowExecutionController after persisting execution to BadgerDB.
 //
@@ -36,8 +70,10 @@ func NewInvokeWorkflowExecutionWorkflowCreator(
 	}
 }
 
-// Create starts a new workflow execution workflow.
-func (c *InvokeWorkflowExecutionWorkflowCreator) Create(ctx context.Context, execution *workflowexecutionv1.WorkflowExecution) error {
+// Create starts a new workflow execution workflow. maxDuration is the maximum
+// duration of the workflow the execution runs (0 for none), which sets the
+// run timeout with ExecutionTimeout.
+func (c *InvokeWorkflowExecutionWorkflowCreator) Create(ctx context.Context, execution *workflowexecutionv1.WorkflowExecution, maxDuration time.Duration) error {
 	executionID := execution.GetMetadata().GetId()
 
 	// Workflow ID format: stigmer/workflow-execution/invoke/{execution-id}
@@ -46,7 +82,7 @@ func (c *InvokeWorkflowExecutionWorkflowCreator) Create(ctx context.Context, exe
 	options := client.StartWorkflowOptions{
 		ID:                    workflowID,
 		TaskQueue:             c.stigmerQueue,
-		WorkflowRunTimeout:    30 * time.Minute, // Max 30 minutes per workflow execution
+		WorkflowRunTimeout:    ExecutionTimeout(maxDuration),
 		Memo: map[string]interface{}{
 			"activityTaskQueue": c.runnerQueue, // Pass runner queue to workflow
 		},
@@ -72,3 +108,64 @@ func (c *InvokeWorkflowExecutionWorkflowCreator) Create(ctx context.Context, exe
 
 	return nil
 }
+
+// Cancel requests cancellation of the workflow started for an execution.
+//
+// Returns ErrWorkflowNotRunning if the workflow does not exist or has already
+// closed.
+func (c *InvokeWorkflowExecutionWorkflowCreator) Cancel(ctx context.Context, executionID string) error {
+	workflowID := fmt.Sprintf("%s/%s", InvokeWorkflowExecutionWorkflowName, executionID)
+
+	if err := c.workflowClient.CancelWorkflow(ctx, workflowID, ""); err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			return ErrWorkflowNotRunning
+		}
+		log.Error().
+			Err(err).
+			Str("workflow_id", workflowID).
+			Str("execution_id", executionID).
+			Msg("Failed to cancel InvokeWorkflowExecutionWorkflow")
+		return fmt.Errorf("failed to cancel workflow: %w", err)
+	}
+
+	log.Info().
+		Str("workflow_id", workflowID).
+		Str("execution_id", executionID).
+		Msg("Cancelled InvokeWorkflowExecutionWorkflow")
+
+	return nil
+}
+
+// CompleteApproval approves an approval task by completing the activity it
+// waits on with the decision. callbackToken is the pending approval's token.
+func (c *InvokeWorkflowExecutionWorkflowCreator) CompleteApproval(ctx context.Context, callbackToken []byte, decision map[string]interface{}) error {
+	return c.completeApprovalActivity(ctx, callbackToken, decision, nil)
+}
+
+// RejectApproval rejects an approval task, failing it with error type
+// ApprovalRejectedErrorType.
+func (c *InvokeWorkflowExecutionWorkflowCreator) RejectApproval(ctx context.Context, callbackToken []byte, reason string) error {
+	return c.completeApprovalActivity(ctx, callbackToken, nil,
+		temporal.NewNonRetryableApplicationError(reason, ApprovalRejectedErrorType, nil))
+}
+
+func (c *InvokeWorkflowExecutionWorkflowCreator) completeApprovalActivity(
+	ctx context.Context,
+	callbackToken []byte,
+	result map[string]interface{},
+	activityErr error,
+) error {
+	if err := c.workflowClient.CompleteActivity(ctx, callbackToken, result, activityErr); err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			return ErrApprovalNotPending
+		}
+		log.Error().
+			Err(err).
+			Msg("Failed to complete approval activity")
+		return fmt.Errorf("failed to complete approval: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package workflows
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExecutionTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxDuration time.Duration
+		want        time.Duration
+	}{
+		{"no maximum", 0, DefaultExecutionTimeout},
+		{"shorter maximum", 10 * time.Minute, DefaultExecutionTimeout},
+		{"longer maximum", 2 * time.Hour, 2*time.Hour + maxDurationGrace},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExecutionTimeout(tt.maxDuration); got != tt.want {
+				t.Errorf("ExecutionTimeout(%v) = %v, want %v", tt.maxDuration, got, tt.want)
+			}
+		})
+	}
+}
@@ -11,6 +11,9 @@ import (
 	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline/steps"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/environment/valuetype"
+	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowinstance/temporal"
 	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/downstream/workflow"
 )
 
@@ -26,9 +29,11 @@ const (
 // 2. ResolveSlug - Generate slug from metadata.name
 // 3. LoadParentWorkflow - Load and validate workflow template exists
 // 4. ValidateSameOrgBusinessRule - Verify same-org for org-scoped instances
-// 5. CheckDuplicate - Verify no duplicate exists
-// 6. BuildNewState - Generate ID, clear status, set audit fields (timestamps, actors, event)
-// 7. Persist - Save workflow instance to repository
+// 5. ValidateEnvironmentValues - Check environment values against the workflow's typed variables
+// 6. CheckDuplicate - Verify no duplicate exists
+// 7. BuildNewState - Generate ID, clear status, set audit fields (timestamps, actors, event)
+// 8. Persist - Save workflow instance to repository
+// 9. SyncSchedule - Create the Temporal schedule when the instance or its workflow declares spec.schedule
 //
 // Note: Compared to Stigmer Cloud, OSS excludes:
 // - Authorize step (no multi-tenant auth in OSS)
@@ -65,9 +70,11 @@ func (c *WorkflowInstanceController) buildCreatePipeline() *pipeline.Pipeline[*w
 		AddStep(steps.NewResolveSlugStep[*workflowinstancev1.WorkflowInstance]()).           // 2. Resolve slug
 		AddStep(newLoadParentWorkflowStep(c.workflowClient)).                                 // 3. Load parent workflow
 		AddStep(newValidateSameOrgBusinessRuleStep()).                                        // 4. Validate same-org business rule
-		AddStep(steps.NewCheckDuplicateStep[*workflowinstancev1.WorkflowInstance](c.store)). // 5. Check duplicate
-		AddStep(steps.NewBuildNewStateStep[*workflowinstancev1.WorkflowInstance]()).         // 6. Build new state
-		AddStep(steps.NewPersistStep[*workflowinstancev1.WorkflowInstance](c.store)).        // 7. Persist workflow instance
+		AddStep(newValidateEnvironmentValuesStep(c.store)).                                   // 5. Validate environment values
+		AddStep(steps.NewCheckDuplicateStep[*workflowinstancev1.WorkflowInstance](c.store)). // 6. Check duplicate
+		AddStep(steps.NewBuildNewStateStep[*workflowinstancev1.WorkflowInstance]()).         // 7. Build new state
+		AddStep(steps.NewPersistStep[*workflowinstancev1.WorkflowInstance](c.store)).        // 8. Persist workflow instance
+		AddStep(newSyncScheduleStep(c.scheduleManager)).                                      // 9. Sync Temporal schedule
 		Build()
 }
 
@@ -189,3 +196,89 @@ func (s *validateSameOrgBusinessRuleStep) Execute(ctx *pipeline.RequestContext[*
 	log.Debug().Msg("Same-org validation passed")
 	return nil
 }
+
+// validateEnvironmentValuesStep checks the values supplied by the referenced
+// environments against the typed variables declared in the parent workflow's
+// env_spec.
+//
+// Workflows without typed variables are skipped.
+type validateEnvironmentValuesStep struct {
+	store store.Store
+}
+
+func newValidateEnvironmentValuesStep(s store.Store) *validateEnvironmentValuesStep {
+	return &validateEnvironmentValuesStep{store: s}
+}
+
+func (s *validateEnvironmentValuesStep) Name() string {
+	return "ValidateEnvironmentValues"
+}
+
+func (s *validateEnvironmentValuesStep) Execute(ctx *pipeline.RequestContext[*workflowinstancev1.WorkflowInstance]) error {
+	parentWorkflowVal := ctx.Get(ParentWorkflowKey)
+	if parentWorkflowVal == nil {
+		return fmt.Errorf("parent workflow not found in context")
+	}
+	parentWorkflow := parentWorkflowVal.(*workflowv1.Workflow)
+
+	declared := parentWorkflow.GetSpec().GetEnvSpec()
+	if !valuetype.HasTypedValues(declared) {
+		return nil
+	}
+
+	values, err := valuetype.ResolveValues(ctx.Context(), s.store, ctx.Input().GetSpec().GetEnvRefs())
+	if err != nil {
+		return err
+	}
+
+	if err := valuetype.Validate(declared, values); err != nil {
+		log.Warn().
+			Err(err).
+			Str("workflow_id", parentWorkflow.GetMetadata().GetId()).
+			Msg("Environment value does not match the declared type")
+		return grpclib.InvalidArgumentError(err.Error())
+	}
+	return nil
+}
+
+// syncScheduleStep creates or updates the Temporal schedule of the instance
+// when the instance or its workflow declares spec.schedule.
+//
+// On create, the parent workflow is taken from context (LoadParentWorkflow).
+// On update, it is loaded by the schedule manager.
+//
+// Skipped with a warning when Temporal is not connected; the schedule is
+// created on the next apply.
+type syncScheduleStep struct {
+	scheduleManager *temporal.ScheduleManager
+}
+
+func newSyncScheduleStep(scheduleManager *temporal.ScheduleManager) *syncScheduleStep {
+	return &syncScheduleStep{scheduleManager: scheduleManager}
+}
+
+func (s *syncScheduleStep) Name() string {
+	return "SyncSchedule"
+}
+
+func (s *syncScheduleStep) Execute(ctx *pipeline.RequestContext[*workflowinstancev1.WorkflowInstance]) error {
+	instance := ctx.NewState()
+
+	if s.scheduleManager == nil {
+		log.Warn().
+			Str("instance_id", instance.GetMetadata().GetId()).
+			Msg("Schedule manager not available - skipping schedule sync (Temporal not connected)")
+		return nil
+	}
+
+	var err error
+	if parentWorkflow, ok := ctx.Get(ParentWorkflowKey).(*workflowv1.Workflow); ok {
+		err = s.scheduleManager.Sync(ctx.Context(), instance, parentWorkflow)
+	} else {
+		err = s.scheduleManager.SyncInstance(ctx.Context(), instance)
+	}
+	if err != nil {
+		return grpclib.InternalError(err, "failed to sync workflow instance schedule")
+	}
+	return nil
+}
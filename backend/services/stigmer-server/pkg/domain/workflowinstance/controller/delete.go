@@ -3,10 +3,12 @@ package workflowinstance
 import (
 	"context"
 
+	"github.com/rs/zerolog/log"
 	grpclib "github.com/stigmer/stigmer/backend/libs/go/grpc"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline"
 	"github.com/stigmer/stigmer/backend/libs/go/grpc/request/pipeline/steps"
 	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
+	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowinstance/temporal"
 )
 
 // Delete deletes a workflow instance by ID using the pipeline pattern
@@ -35,6 +37,39 @@ func (c *WorkflowInstanceController) buildDeletePipeline() *pipeline.Pipeline[*w
 		AddStep(steps.NewValidateProtoStep[*workflowinstancev1.WorkflowInstanceId]()).                                        // 1. Validate field constraints
 		AddStep(steps.NewExtractResourceIdStep[*workflowinstancev1.WorkflowInstanceId]()).                                    // 2. Extract ID from wrapper
 		AddStep(steps.NewLoadExistingForDeleteStep[*workflowinstancev1.WorkflowInstanceId, *workflowinstancev1.WorkflowInstance](c.store)). // 3. Load workflow instance
-		AddStep(steps.NewDeleteResourceStep[*workflowinstancev1.WorkflowInstanceId](c.store)).                                // 4. Delete from database
+		AddStep(newDeleteScheduleStep(c.scheduleManager)).                                                                    // 4. Delete Temporal schedule
+		AddStep(steps.NewDeleteResourceStep[*workflowinstancev1.WorkflowInstanceId](c.store)).                                // 5. Delete from database
 		Build()
 }
+
+// deleteScheduleStep removes the Temporal schedule of the instance, if any.
+//
+// Runs before DeleteResource so a failed schedule deletion leaves the
+// instance in place and the delete can be retried.
+type deleteScheduleStep struct {
+	scheduleManager *temporal.ScheduleManager
+}
+
+func newDeleteScheduleStep(scheduleManager *temporal.ScheduleManager) *deleteScheduleStep {
+	return &deleteScheduleStep{scheduleManager: scheduleManager}
+}
+
+func (s *deleteScheduleStep) Name() string {
+	return "DeleteSchedule"
+}
+
+func (s *deleteScheduleStep) Execute(ctx *pipeline.RequestContext[*workflowinstancev1.WorkflowInstanceId]) error {
+	instanceID := ctx.Input().GetValue()
+
+	if s.scheduleManager == nil {
+		log.Warn().
+			Str("instance_id", instanceID).
+			Msg("Schedule manager not available - skipping schedule deletion (Temporal not connected)")
+		return nil
+	}
+
+	if err := s.scheduleManager.Delete(ctx.Context(), instanceID); err != nil {
+		return grpclib.InternalError(err, "failed to delete workflow instance schedule")
+	}
+	return nil
+}
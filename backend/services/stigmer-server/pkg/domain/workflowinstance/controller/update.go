@@ -14,8 +14,10 @@ import (
 // 1. ValidateProto - Validate proto field constraints using buf validate
 // 2. ResolveSlug - Generate slug from metadata.name
 // 3. LoadExisting - Load existing workflow instance from repository to verify it exists
-// 4. BuildUpdateState - Merge spec, preserve IDs and status, update audit timestamps
-// 5. Persist - Save updated workflow instance to repository
+// 4. CheckResourceVersion - Reject updates based on a stale metadata.resource_version (FAILED_PRECONDITION)
+// 5. BuildUpdateState - Merge spec, preserve IDs and status, update audit timestamps
+// 6. Persist - Save updated workflow instance to repository
+// 7. SyncSchedule - Update the Temporal schedule from the instance's or the workflow's spec.schedule
 func (c *WorkflowInstanceController) Update(ctx context.Context, instance *workflowinstancev1.WorkflowInstance) (*workflowinstancev1.WorkflowInstance, error) {
 	reqCtx := pipeline.NewRequestContext(ctx, instance)
 
@@ -31,10 +33,12 @@ func (c *WorkflowInstanceController) Update(ctx context.Context, instance *workf
 // buildUpdatePipeline constructs the pipeline for workflow instance update
 func (c *WorkflowInstanceController) buildUpdatePipeline() *pipeline.Pipeline[*workflowinstancev1.WorkflowInstance] {
 	return pipeline.NewPipeline[*workflowinstancev1.WorkflowInstance]("workflow-instance-update").
-		AddStep(steps.NewValidateProtoStep[*workflowinstancev1.WorkflowInstance]()).       // 1. Validate field constraints
-		AddStep(steps.NewResolveSlugStep[*workflowinstancev1.WorkflowInstance]()).         // 2. Resolve slug
-		AddStep(steps.NewLoadExistingStep[*workflowinstancev1.WorkflowInstance](c.store)). // 3. Load existing instance
-		AddStep(steps.NewBuildUpdateStateStep[*workflowinstancev1.WorkflowInstance]()).    // 4. Build updated state (merge spec, preserve status, update audit)
-		AddStep(steps.NewPersistStep[*workflowinstancev1.WorkflowInstance](c.store)).      // 5. Persist workflow instance
+		AddStep(steps.NewValidateProtoStep[*workflowinstancev1.WorkflowInstance]()).        // 1. Validate field constraints
+		AddStep(steps.NewResolveSlugStep[*workflowinstancev1.WorkflowInstance]()).          // 2. Resolve slug
+		AddStep(steps.NewLoadExistingStep[*workflowinstancev1.WorkflowInstance](c.store)).  // 3. Load existing instance
+		AddStep(steps.NewCheckResourceVersionStep[*workflowinstancev1.WorkflowInstance]()). // 4. Check resource version
+		AddStep(steps.NewBuildUpdateStateStep[*workflowinstancev1.WorkflowInstance]()).     // 5. Build updated state (merge spec, preserve status, update audit)
+		AddStep(steps.NewPersistStep[*workflowinstancev1.WorkflowInstance](c.store)).       // 6. Persist workflow instance
+		AddStep(newSyncScheduleStep(c.scheduleManager)).                                    // 7. Sync Temporal schedule
 		Build()
 }
@@ -3,6 +3,7 @@ package workflowinstance
 import (
 	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
 	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowinstance/temporal"
 	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/downstream/workflow"
 )
 
@@ -10,8 +11,9 @@ import (
 type WorkflowInstanceController struct {
 	workflowinstancev1.UnimplementedWorkflowInstanceCommandControllerServer
 	workflowinstancev1.UnimplementedWorkflowInstanceQueryControllerServer
-	store          store.Store
-	workflowClient *workflow.Client
+	store           store.Store
+	workflowClient  *workflow.Client
+	scheduleManager *temporal.ScheduleManager
 }
 
 // NewWorkflowInstanceController creates a new WorkflowInstanceController
@@ -27,3 +29,10 @@ func NewWorkflowInstanceController(store store.Store, workflowClient *workflow.C
 func (c *WorkflowInstanceController) SetWorkflowClient(client *workflow.Client) {
 	c.workflowClient = client
 }
+
+// SetScheduleManager sets the Temporal schedule manager dependency
+// This is used when the controller is created before the Temporal client is initialized
+// or when the Temporal client is reconnected
+func (c *WorkflowInstanceController) SetScheduleManager(manager *temporal.ScheduleManager) {
+	c.scheduleManager = manager
+}
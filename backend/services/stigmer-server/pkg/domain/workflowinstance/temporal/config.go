@@ -0,0 +1,30 @@
+package temporal
+
+import (
+	"os"
+)
+
+// Config holds configuration for workflow instance schedules.
+//
+// Environment Variables:
+// - TEMPORAL_WORKFLOW_SCHEDULE_TASK_QUEUE: Queue for the scheduled trigger workflow
+type Config struct {
+	// TaskQueue is the task queue the scheduled trigger workflow and its
+	// activity run on (stigmer-server).
+	// Default: workflow_schedule_stigmer
+	TaskQueue string
+}
+
+// LoadConfig loads configuration from environment variables.
+func LoadConfig() *Config {
+	return &Config{
+		TaskQueue: getEnv("TEMPORAL_WORKFLOW_SCHEDULE_TASK_QUEUE", "workflow_schedule_stigmer"),
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
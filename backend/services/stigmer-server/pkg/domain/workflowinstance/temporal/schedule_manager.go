@@ -0,0 +1,239 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/backend/libs/go/store"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// scheduleIDPrefix prefixes the Temporal schedule ID of a workflow instance.
+	// Schedule ID format: workflow-instance-schedule-{instance-id}
+	scheduleIDPrefix = "workflow-instance-schedule-"
+
+	// skipMissedCatchupWindow lets a fire time run only if the scheduler was
+	// briefly unavailable; longer outages drop the missed fire times.
+	skipMissedCatchupWindow = 10 * time.Second
+
+	// runMissedCatchupWindow runs fire times missed during outages of up to a year.
+	runMissedCatchupWindow = 365 * 24 * time.Hour
+)
+
+// ScheduleManager keeps a Temporal schedule in sync with each workflow
+// instance that sets spec.schedule or whose workflow declares spec.schedule.
+//
+// Each fire of the schedule starts TriggerScheduledExecutionWorkflow, which
+// creates a WorkflowExecution for the instance through the regular create
+// pipeline (concurrency policy, Temporal workflow start, etc.).
+type ScheduleManager struct {
+	client client.Client
+	config *Config
+	store  store.Store
+}
+
+// NewScheduleManager creates a new ScheduleManager.
+func NewScheduleManager(temporalClient client.Client, config *Config, store store.Store) *ScheduleManager {
+	return &ScheduleManager{
+		client: temporalClient,
+		config: config,
+		store:  store,
+	}
+}
+
+// ScheduleID returns the Temporal schedule ID for a workflow instance.
+func ScheduleID(instanceID string) string {
+	return scheduleIDPrefix + instanceID
+}
+
+// Sync creates, updates or deletes the schedule of an instance so that it
+// matches the instance's schedule, or the schedule declared by its workflow.
+func (m *ScheduleManager) Sync(ctx context.Context, instance *workflowinstancev1.WorkflowInstance, wf *workflowv1.Workflow) error {
+	instanceID := instance.GetMetadata().GetId()
+	schedule := effectiveSchedule(instance, wf)
+	if schedule == nil {
+		return m.Delete(ctx, instanceID)
+	}
+
+	options := m.scheduleOptions(instance, schedule)
+
+	_, err := m.client.ScheduleClient().Create(ctx, options)
+	if err == nil {
+		log.Info().
+			Str("schedule_id", options.ID).
+			Str("instance_id", instanceID).
+			Str("cron", schedule.GetCron()).
+			Msg("Created workflow instance schedule")
+		return nil
+	}
+	if !errors.Is(err, temporal.ErrScheduleAlreadyRunning) {
+		return fmt.Errorf("failed to create schedule %s: %w", options.ID, err)
+	}
+
+	// Schedule already exists - replace its spec, action and policies
+	handle := m.client.ScheduleClient().GetHandle(ctx, options.ID)
+	err = handle.Update(ctx, client.ScheduleUpdateOptions{
+		DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+			updated := input.Description.Schedule
+			updated.Spec = &options.Spec
+			updated.Action = options.Action
+			if updated.Policy == nil {
+				updated.Policy = &client.SchedulePolicies{}
+			}
+			updated.Policy.CatchupWindow = options.CatchupWindow
+			return &client.ScheduleUpdate{Schedule: &updated}, nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update schedule %s: %w", options.ID, err)
+	}
+
+	log.Info().
+		Str("schedule_id", options.ID).
+		Str("instance_id", instanceID).
+		Str("cron", schedule.GetCron()).
+		Msg("Updated workflow instance schedule")
+	return nil
+}
+
+// SyncInstance loads the instance's workflow and syncs the instance schedule.
+func (m *ScheduleManager) SyncInstance(ctx context.Context, instance *workflowinstancev1.WorkflowInstance) error {
+	wf := &workflowv1.Workflow{}
+	if err := m.store.GetResource(ctx, apiresourcekind.ApiResourceKind_workflow, instance.GetSpec().GetWorkflowId(), wf); err != nil {
+		return fmt.Errorf("failed to load workflow %s: %w", instance.GetSpec().GetWorkflowId(), err)
+	}
+	return m.Sync(ctx, instance, wf)
+}
+
+// SyncWorkflow syncs the schedules of all instances of a workflow.
+// Called when the workflow spec changes.
+func (m *ScheduleManager) SyncWorkflow(ctx context.Context, wf *workflowv1.Workflow) error {
+	instances, err := m.listInstances(ctx, wf.GetMetadata().GetId())
+	if err != nil {
+		return err
+	}
+	for _, instance := range instances {
+		if err := m.Sync(ctx, instance, wf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes the schedule of an instance. A missing schedule is not an error.
+func (m *ScheduleManager) Delete(ctx context.Context, instanceID string) error {
+	scheduleID := ScheduleID(instanceID)
+	err := m.client.ScheduleClient().GetHandle(ctx, scheduleID).Delete(ctx)
+	if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete schedule %s: %w", scheduleID, err)
+	}
+
+	log.Info().
+		Str("schedule_id", scheduleID).
+		Str("instance_id", instanceID).
+		Msg("Deleted workflow instance schedule")
+	return nil
+}
+
+// DeleteWorkflow removes the schedules of all instances of a workflow.
+// Called when the workflow is deleted.
+func (m *ScheduleManager) DeleteWorkflow(ctx context.Context, workflowID string) error {
+	instances, err := m.listInstances(ctx, workflowID)
+	if err != nil {
+		return err
+	}
+	for _, instance := range instances {
+		if err := m.Delete(ctx, instance.GetMetadata().GetId()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scheduleOptions builds the Temporal schedule for an instance.
+func (m *ScheduleManager) scheduleOptions(instance *workflowinstancev1.WorkflowInstance, schedule *workflowv1.WorkflowSchedule) client.ScheduleOptions {
+	instanceID := instance.GetMetadata().GetId()
+	scheduleID := ScheduleID(instanceID)
+
+	timezone := schedule.GetTimezone()
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	return client.ScheduleOptions{
+		ID: scheduleID,
+		Spec: client.ScheduleSpec{
+			CronExpressions: []string{schedule.GetCron()},
+			TimeZoneName:    timezone,
+		},
+		Action: &client.ScheduleWorkflowAction{
+			ID:        fmt.Sprintf("%s/%s", TriggerScheduledExecutionWorkflowName, instanceID),
+			Workflow:  TriggerScheduledExecutionWorkflowName,
+			TaskQueue: m.config.TaskQueue,
+			Args: []interface{}{&ScheduledExecutionInput{
+				WorkflowInstanceID: instanceID,
+				InstanceSlug:       instance.GetMetadata().GetSlug(),
+				Org:                instance.GetMetadata().GetOrg(),
+				OwnerScope:         int32(instance.GetMetadata().GetOwnerScope()),
+				ScheduleID:         scheduleID,
+			}},
+		},
+		CatchupWindow: catchupWindow(schedule.GetCatchUpPolicy()),
+	}
+}
+
+// effectiveSchedule returns the schedule an instance runs on: its own
+// spec.schedule, which overrides the workflow's, or the workflow's
+// spec.schedule. Returns nil if neither is set.
+func effectiveSchedule(instance *workflowinstancev1.WorkflowInstance, wf *workflowv1.Workflow) *workflowv1.WorkflowSchedule {
+	if schedule := instance.GetSpec().GetSchedule(); schedule != nil {
+		return schedule
+	}
+	return wf.GetSpec().GetSchedule()
+}
+
+// catchupWindow maps the catch-up policy to a Temporal catch-up window.
+// Unspecified is treated as SCHEDULE_SKIP_MISSED.
+func catchupWindow(policy workflowv1.ScheduleCatchUpPolicy) time.Duration {
+	if policy == workflowv1.ScheduleCatchUpPolicy_SCHEDULE_RUN_MISSED {
+		return runMissedCatchupWindow
+	}
+	return skipMissedCatchupWindow
+}
+
+// listInstances returns the instances of a workflow.
+//
+// Note: lists all instances and filters by workflow_id.
+// Acceptable for local/OSS usage.
+func (m *ScheduleManager) listInstances(ctx context.Context, workflowID string) ([]*workflowinstancev1.WorkflowInstance, error) {
+	resources, err := m.store.ListResources(ctx, apiresourcekind.ApiResourceKind_workflow_instance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow instances: %w", err)
+	}
+
+	var instances []*workflowinstancev1.WorkflowInstance
+	for _, data := range resources {
+		instance := &workflowinstancev1.WorkflowInstance{}
+		if err := proto.Unmarshal(data, instance); err != nil {
+			continue
+		}
+		if instance.GetSpec().GetWorkflowId() == workflowID {
+			instances = append(instances, instance)
+		}
+	}
+	return instances, nil
+}
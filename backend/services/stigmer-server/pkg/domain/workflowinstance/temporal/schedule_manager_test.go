@@ -0,0 +1,157 @@
+package temporal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"go.temporal.io/sdk/client"
+)
+
+func testInstance() *workflowinstancev1.WorkflowInstance {
+	return &workflowinstancev1.WorkflowInstance{
+		Metadata: &apiresource.ApiResourceMetadata{
+			Id:         "wfi-123",
+			Slug:       "daily-sync-prod",
+			Org:        "acme",
+			OwnerScope: apiresource.ApiResourceOwnerScope_organization,
+		},
+		Spec: &workflowinstancev1.WorkflowInstanceSpec{WorkflowId: "wfl-1"},
+	}
+}
+
+func TestScheduleOptions(t *testing.T) {
+	m := NewScheduleManager(nil, &Config{TaskQueue: "schedules"}, nil)
+
+	options := m.scheduleOptions(testInstance(), &workflowv1.WorkflowSchedule{
+		Cron:          "0 2 * * *",
+		CatchUpPolicy: workflowv1.ScheduleCatchUpPolicy_SCHEDULE_RUN_MISSED,
+	})
+
+	if options.ID != "workflow-instance-schedule-wfi-123" {
+		t.Errorf("ID = %q", options.ID)
+	}
+	if len(options.Spec.CronExpressions) != 1 || options.Spec.CronExpressions[0] != "0 2 * * *" {
+		t.Errorf("CronExpressions = %v", options.Spec.CronExpressions)
+	}
+	if options.Spec.TimeZoneName != "UTC" {
+		t.Errorf("TimeZoneName = %q, want UTC when unset", options.Spec.TimeZoneName)
+	}
+	if options.CatchupWindow != runMissedCatchupWindow {
+		t.Errorf("CatchupWindow = %v, want %v", options.CatchupWindow, runMissedCatchupWindow)
+	}
+
+	action, ok := options.Action.(*client.ScheduleWorkflowAction)
+	if !ok {
+		t.Fatalf("Action = %T, want *client.ScheduleWorkflowAction", options.Action)
+	}
+	if action.Workflow != TriggerScheduledExecutionWorkflowName || action.TaskQueue != "schedules" {
+		t.Errorf("action = %s on %s", action.Workflow, action.TaskQueue)
+	}
+	input := action.Args[0].(*ScheduledExecutionInput)
+	if input.WorkflowInstanceID != "wfi-123" || input.ScheduleID != options.ID {
+		t.Errorf("input = %+v", input)
+	}
+}
+
+func TestEffectiveSchedule(t *testing.T) {
+	workflowSchedule := &workflowv1.WorkflowSchedule{Cron: "0 2 * * *"}
+	wf := &workflowv1.Workflow{Spec: &workflowv1.WorkflowSpec{Schedule: workflowSchedule}}
+
+	instance := testInstance()
+	if got := effectiveSchedule(instance, wf); got != workflowSchedule {
+		t.Errorf("effectiveSchedule() = %v, want the workflow's schedule", got)
+	}
+
+	instanceSchedule := &workflowv1.WorkflowSchedule{Cron: "@hourly"}
+	instance.Spec.Schedule = instanceSchedule
+	if got := effectiveSchedule(instance, wf); got != instanceSchedule {
+		t.Errorf("effectiveSchedule() = %v, want the instance's schedule", got)
+	}
+	if got := effectiveSchedule(instance, &workflowv1.Workflow{}); got != instanceSchedule {
+		t.Errorf("effectiveSchedule() = %v, want the instance's schedule without a workflow schedule", got)
+	}
+
+	if got := effectiveSchedule(testInstance(), &workflowv1.Workflow{}); got != nil {
+		t.Errorf("effectiveSchedule() = %v, want nil", got)
+	}
+}
+
+func TestCatchupWindow(t *testing.T) {
+	tests := []struct {
+		policy workflowv1.ScheduleCatchUpPolicy
+		want   time.Duration
+	}{
+		{workflowv1.ScheduleCatchUpPolicy_SCHEDULE_CATCH_UP_POLICY_UNSPECIFIED, skipMissedCatchupWindow},
+		{workflowv1.ScheduleCatchUpPolicy_SCHEDULE_SKIP_MISSED, skipMissedCatchupWindow},
+		{workflowv1.ScheduleCatchUpPolicy_SCHEDULE_RUN_MISSED, runMissedCatchupWindow},
+	}
+	for _, tt := range tests {
+		if got := catchupWindow(tt.policy); got != tt.want {
+			t.Errorf("catchupWindow(%v) = %v, want %v", tt.policy, got, tt.want)
+		}
+	}
+}
+
+type fakeCreator struct {
+	created []*workflowexecutionv1.WorkflowExecution
+	err     error
+}
+
+func (f *fakeCreator) CreateAsSystem(_ context.Context, execution *workflowexecutionv1.WorkflowExecution) (*workflowexecutionv1.WorkflowExecution, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.created = append(f.created, execution)
+	return execution, nil
+}
+
+func TestCreateScheduledExecution(t *testing.T) {
+	input := &ScheduledExecutionInput{
+		WorkflowInstanceID: "wfi-123",
+		InstanceSlug:       "daily-sync-prod",
+		Org:                "acme",
+		OwnerScope:         int32(apiresource.ApiResourceOwnerScope_organization),
+		ScheduleID:         "workflow-instance-schedule-wfi-123",
+		FireTime:           time.Date(2026, 1, 2, 2, 0, 0, 0, time.UTC),
+	}
+
+	creator := &fakeCreator{}
+	if err := NewCreateScheduledExecutionActivity(creator).CreateExecution(context.Background(), input); err != nil {
+		t.Fatalf("CreateExecution() error = %v", err)
+	}
+	if len(creator.created) != 1 {
+		t.Fatalf("created %d executions, want 1", len(creator.created))
+	}
+
+	execution := creator.created[0]
+	if got := execution.GetMetadata().GetName(); got != "daily-sync-prod-20260102-020000" {
+		t.Errorf("name = %q", got)
+	}
+	if got := execution.GetSpec().GetWorkflowInstanceId(); got != "wfi-123" {
+		t.Errorf("workflow_instance_id = %q", got)
+	}
+	metadata := execution.GetSpec().GetTriggerMetadata()
+	if metadata["source"] != "schedule" || metadata["caller_id"] != "sys-scheduler" || metadata["timestamp"] != "2026-01-02T02:00:00Z" {
+		t.Errorf("trigger_metadata = %v", metadata)
+	}
+}
+
+func TestCreateScheduledExecution_Errors(t *testing.T) {
+	input := &ScheduledExecutionInput{WorkflowInstanceID: "wfi-123", InstanceSlug: "daily-sync-prod"}
+
+	existing := &fakeCreator{err: errors.New("WorkflowExecution with slug 'daily-sync-prod-00010101-000000' already exists (id: wex-1)")}
+	if err := NewCreateScheduledExecutionActivity(existing).CreateExecution(context.Background(), input); err != nil {
+		t.Errorf("CreateExecution() error = %v, want nil for an already created fire", err)
+	}
+
+	failing := &fakeCreator{err: errors.New("unavailable")}
+	if err := NewCreateScheduledExecutionActivity(failing).CreateExecution(context.Background(), input); err == nil {
+		t.Error("CreateExecution() error = nil, want error")
+	}
+}
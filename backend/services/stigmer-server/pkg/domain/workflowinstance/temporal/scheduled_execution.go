@@ -0,0 +1,116 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// TriggerScheduledExecutionWorkflowName is the workflow type started by
+	// workflow instance schedules.
+	// Workflow ID format: stigmer/workflow-instance/trigger-scheduled/{instance-id}
+	TriggerScheduledExecutionWorkflowName = "stigmer/workflow-instance/trigger-scheduled"
+
+	// CreateScheduledExecutionActivityName is the activity that creates the
+	// WorkflowExecution for a schedule fire.
+	CreateScheduledExecutionActivityName = "stigmer/workflow-instance/create-scheduled-execution"
+
+	// schedulerCallerID identifies the scheduler in execution trigger metadata.
+	schedulerCallerID = "sys-scheduler"
+)
+
+// ScheduledExecutionInput is passed by a schedule to TriggerScheduledExecutionWorkflow.
+type ScheduledExecutionInput struct {
+	WorkflowInstanceID string
+	InstanceSlug       string
+	Org                string
+	OwnerScope         int32
+	ScheduleID         string
+
+	// FireTime is set by the workflow from its start time.
+	FireTime time.Time
+}
+
+// TriggerScheduledExecutionWorkflow creates a WorkflowExecution for each
+// schedule fire. It only orchestrates; the execution itself runs through
+// InvokeWorkflowExecutionWorkflow like any other execution.
+func TriggerScheduledExecutionWorkflow(ctx workflow.Context, input *ScheduledExecutionInput) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		},
+	})
+
+	input.FireTime = workflow.Now(ctx).UTC()
+
+	return workflow.ExecuteActivity(ctx, CreateScheduledExecutionActivityName, input).Get(ctx, nil)
+}
+
+// ExecutionCreator creates workflow executions.
+// Implemented by the in-process WorkflowExecution client.
+type ExecutionCreator interface {
+	CreateAsSystem(ctx context.Context, execution *workflowexecutionv1.WorkflowExecution) (*workflowexecutionv1.WorkflowExecution, error)
+}
+
+// CreateScheduledExecutionActivity creates the WorkflowExecution for a schedule fire.
+type CreateScheduledExecutionActivity struct {
+	creator ExecutionCreator
+}
+
+// NewCreateScheduledExecutionActivity creates a new CreateScheduledExecutionActivity.
+func NewCreateScheduledExecutionActivity(creator ExecutionCreator) *CreateScheduledExecutionActivity {
+	return &CreateScheduledExecutionActivity{creator: creator}
+}
+
+// CreateExecution creates the execution through the regular create pipeline.
+//
+// The execution name is derived from the fire time, so retries of the same
+// fire do not create a second execution.
+func (a *CreateScheduledExecutionActivity) CreateExecution(ctx context.Context, input *ScheduledExecutionInput) error {
+	execution := newScheduledExecution(input)
+
+	_, err := a.creator.CreateAsSystem(ctx, execution)
+	if err != nil {
+		// CheckDuplicate reports an existing slug as a plain error
+		if strings.Contains(err.Error(), "already exists") {
+			log.Info().
+				Str("schedule_id", input.ScheduleID).
+				Str("name", execution.GetMetadata().GetName()).
+				Msg("Scheduled execution already created for this fire time")
+			return nil
+		}
+		return fmt.Errorf("failed to create scheduled execution for instance %s: %w", input.WorkflowInstanceID, err)
+	}
+	return nil
+}
+
+// newScheduledExecution builds the execution created for a schedule fire.
+func newScheduledExecution(input *ScheduledExecutionInput) *workflowexecutionv1.WorkflowExecution {
+	return &workflowexecutionv1.WorkflowExecution{
+		ApiVersion: "agentic.stigmer.ai/v1",
+		Kind:       "WorkflowExecution",
+		Metadata: &apiresource.ApiResourceMetadata{
+			Name:       fmt.Sprintf("%s-%s", input.InstanceSlug, input.FireTime.Format("20060102-150405")),
+			Org:        input.Org,
+			OwnerScope: apiresource.ApiResourceOwnerScope(input.OwnerScope),
+		},
+		Spec: &workflowexecutionv1.WorkflowExecutionSpec{
+			WorkflowInstanceId: input.WorkflowInstanceID,
+			TriggerMetadata: map[string]string{
+				"source":      "schedule",
+				"caller_id":   schedulerCallerID,
+				"schedule_id": input.ScheduleID,
+				"timestamp":   input.FireTime.Format(time.RFC3339),
+			},
+		},
+	}
+}
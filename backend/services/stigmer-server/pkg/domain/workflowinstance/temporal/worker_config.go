@@ -0,0 +1,57 @@
+package temporal
+
+import (
+	"github.com/rs/zerolog/log"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// WorkerConfig configures and creates the Temporal worker for workflow instance schedules.
+//
+// Task Queue: "workflow_schedule_stigmer" (stigmer-server owns both the workflow and the activity)
+//
+// Registered Components:
+// - Workflows: TriggerScheduledExecutionWorkflow
+// - Activities: CreateScheduledExecutionActivity
+//
+// Environment Variables:
+// - TEMPORAL_WORKFLOW_SCHEDULE_TASK_QUEUE (default: workflow_schedule_stigmer)
+type WorkerConfig struct {
+	config   *Config
+	activity *CreateScheduledExecutionActivity
+}
+
+// NewWorkerConfig creates a new WorkerConfig.
+func NewWorkerConfig(config *Config, creator ExecutionCreator) *WorkerConfig {
+	return &WorkerConfig{
+		config:   config,
+		activity: NewCreateScheduledExecutionActivity(creator),
+	}
+}
+
+// CreateWorker creates and configures a Temporal worker for scheduled executions.
+func (wc *WorkerConfig) CreateWorker(temporalClient client.Client) worker.Worker {
+	w := worker.New(temporalClient, wc.config.TaskQueue, worker.Options{})
+
+	// Register with explicit names to match the schedule action and activity invocation
+	w.RegisterWorkflowWithOptions(
+		TriggerScheduledExecutionWorkflow,
+		workflow.RegisterOptions{
+			Name: TriggerScheduledExecutionWorkflowName,
+		},
+	)
+	w.RegisterActivityWithOptions(
+		wc.activity.CreateExecution,
+		activity.RegisterOptions{
+			Name: CreateScheduledExecutionActivityName,
+		},
+	)
+
+	log.Info().
+		Str("queue", wc.config.TaskQueue).
+		Msg("✅ Registered TriggerScheduledExecutionWorkflow and CreateScheduledExecutionActivity")
+
+	return w
+}
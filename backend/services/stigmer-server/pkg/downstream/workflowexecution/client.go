@@ -0,0 +1,67 @@
+package workflowexecution
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"google.golang.org/grpc"
+)
+
+// Client provides in-process gRPC calls to the WorkflowExecution service.
+//
+// Architecture Note: This client lives OUTSIDE the workflow execution domain because it's
+// infrastructure for calling the workflow execution service from other domains (for example,
+// the workflow instance scheduler). It uses in-process gRPC with bufconn, so all gRPC
+// interceptors execute (validation, logging, api_resource_kind injection, etc.).
+type Client struct {
+	conn   *grpc.ClientConn
+	client workflowexecutionv1.WorkflowExecutionCommandControllerClient
+}
+
+// NewClient creates a new in-process WorkflowExecution client using a gRPC connection.
+// The connection should be an in-process gRPC connection created via NewInProcessConnection.
+func NewClient(conn *grpc.ClientConn) *Client {
+	return &Client{
+		conn:   conn,
+		client: workflowexecutionv1.NewWorkflowExecutionCommandControllerClient(conn),
+	}
+}
+
+// CreateAsSystem creates a new workflow execution using system credentials.
+//
+// This makes an in-process gRPC call to WorkflowExecutionCommandController.Create()
+// using system context, so the full create pipeline runs (concurrency policy,
+// Temporal workflow start, etc.).
+//
+// Use case: Scheduled workflow instances trigger executions without a user request.
+func (c *Client) CreateAsSystem(ctx context.Context, execution *workflowexecutionv1.WorkflowExecution) (*workflowexecutionv1.WorkflowExecution, error) {
+	log.Debug().
+		Str("workflow_instance_id", execution.GetSpec().GetWorkflowInstanceId()).
+		Str("name", execution.GetMetadata().GetName()).
+		Msg("Creating workflow execution via in-process gRPC (as system)")
+
+	created, err := c.client.Create(ctx, execution)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("workflow_instance_id", execution.GetSpec().GetWorkflowInstanceId()).
+			Msg("Failed to create workflow execution (as system)")
+		return nil, err
+	}
+
+	log.Info().
+		Str("id", created.GetMetadata().GetId()).
+		Str("workflow_instance_id", created.GetSpec().GetWorkflowInstanceId()).
+		Msg("Successfully created workflow execution (as system)")
+
+	return created, nil
+}
+
+// Close closes the underlying gRPC connection
+func (c *Client) Close() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
@@ -38,10 +38,12 @@ import (
 	workflowexecutiontemporal "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowexecution/temporal"
 	workflowexecutionworkflows "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowexecution/temporal/workflows"
 	workflowinstancecontroller "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowinstance/controller"
+	workflowinstancetemporal "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowinstance/temporal"
 	agentclient "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/downstream/agent"
 	agentinstanceclient "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/downstream/agentinstance"
 	sessionclient "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/downstream/session"
 	workflowclient "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/downstream/workflow"
+	workflowexecutionclient "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/downstream/workflowexecution"
 	workflowinstanceclient "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/downstream/workflowinstance"
 	"github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/supervisor"
 )
@@ -124,6 +126,7 @@ func Run() error {
 	var workflowExecutionWorkflowCreator *workflowexecutionworkflows.InvokeWorkflowExecutionWorkflowCreator
 	var agentExecutionWorkflowCreator *agentexecutiontemporal.InvokeAgentExecutionWorkflowCreator
 	var workflowValidator *workflowtemporal.ServerlessWorkflowValidator
+	var scheduleManager *workflowinstancetemporal.ScheduleManager
 
 	if temporalClient != nil {
 		// Create workflow execution workflow creator
@@ -162,6 +165,18 @@ func Run() error {
 			Str("stigmer_queue", workflowValidationTemporalConfig.StigmerQueue).
 			Str("runner_queue", workflowValidationTemporalConfig.RunnerQueue).
 			Msg("Created workflow validator")
+
+		// Create workflow instance schedule manager
+		scheduleTemporalConfig := workflowinstancetemporal.LoadConfig()
+		scheduleManager = workflowinstancetemporal.NewScheduleManager(
+			temporalClient,
+			scheduleTemporalConfig,
+			store,
+		)
+
+		log.Info().
+			Str("task_queue", scheduleTemporalConfig.TaskQueue).
+			Msg("Created workflow instance schedule manager")
 	}
 
 	// Create gRPC server with apiresource interceptor and in-process support
@@ -243,6 +258,9 @@ func Run() error {
 	workflowinstancev1.RegisterWorkflowInstanceCommandControllerServer(grpcServer, workflowInstanceController)
 	workflowinstancev1.RegisterWorkflowInstanceQueryControllerServer(grpcServer, workflowInstanceController)
 
+	// Update Temporal manager with workflow instance controller dependency (for schedule manager reinjection)
+	temporalManager.serverDeps.workflowInstanceController = workflowInstanceController
+
 	log.Info().Msg("Registered WorkflowInstance controllers")
 
 	// Register WorkflowExecution controller (created earlier for Temporal worker dependency)
@@ -260,16 +278,6 @@ func Run() error {
 		log.Fatal().Err(err).Msg("Failed to start in-process gRPC server")
 	}
 
-	// ============================================================================
-	// Start Temporal workers (after gRPC services ready)
-	// ============================================================================
-
-	if err := temporalManager.StartWorkers(temporalClient); err != nil {
-		log.Fatal().
-			Err(err).
-			Msg("Failed to start Temporal workers")
-	}
-
 	// Create in-process gRPC connection
 	// This connection goes through all gRPC interceptors (validation, logging, etc.)
 	// even though it's in-process, ensuring consistent behavior with network calls
@@ -285,8 +293,22 @@ func Run() error {
 	sessionClient := sessionclient.NewClient(inProcessConn)
 	workflowClient := workflowclient.NewClient(inProcessConn)
 	workflowInstanceClient := workflowinstanceclient.NewClient(inProcessConn)
+	workflowExecutionClient := workflowexecutionclient.NewClient(inProcessConn)
 
-	log.Info().Msg("Created in-process gRPC clients for Agent, AgentInstance, Session, Workflow, and WorkflowInstance")
+	log.Info().Msg("Created in-process gRPC clients for Agent, AgentInstance, Session, Workflow, WorkflowInstance, and WorkflowExecution")
+
+	// ============================================================================
+	// Start Temporal workers (after gRPC services and clients are ready)
+	// ============================================================================
+
+	// The schedule worker creates executions through the in-process WorkflowExecution client
+	temporalManager.serverDeps.workflowExecutionClient = workflowExecutionClient
+
+	if err := temporalManager.StartWorkers(temporalClient); err != nil {
+		log.Fatal().
+			Err(err).
+			Msg("Failed to start Temporal workers")
+	}
 
 	// Now inject dependencies into controllers that need them
 	// Note: Controllers are already registered, we're just updating their internal state
@@ -299,6 +321,10 @@ func Run() error {
 	// Inject workflow creators (nil-safe, controllers handle gracefully)
 	workflowExecutionController.SetWorkflowCreator(workflowExecutionWorkflowCreator)
 	agentExecutionController.SetWorkflowCreator(agentExecutionWorkflowCreator)
+	if scheduleManager != nil {
+		workflowController.SetScheduleManager(scheduleManager)
+		workflowInstanceController.SetScheduleManager(scheduleManager)
+	}
 
 	log.Info().Msg("Injected dependencies into controllers")
 
@@ -18,6 +18,7 @@ import (
 	workflowexecutiontemporal "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowexecution/temporal"
 	workflowexecutionactivities "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowexecution/temporal/activities"
 	workflowexecutionworkflows "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowexecution/temporal/workflows"
+	workflowinstancetemporal "github.com/stigmer/stigmer/backend/services/stigmer-server/pkg/domain/workflowinstance/temporal"
 	"go.temporal.io/sdk/client"
 	temporallog "go.temporal.io/sdk/log"
 	"go.temporal.io/sdk/worker"
@@ -54,6 +55,8 @@ type serverDependencies struct {
 	agentExecutionController      interface{} // *agentexecutioncontroller.AgentExecutionController
 	workflowExecutionController   interface{} // *workflowexecutioncontroller.WorkflowExecutionController
 	workflowController            interface{} // *workflowcontroller.WorkflowController
+	workflowInstanceController    interface{} // *workflowinstancecontroller.WorkflowInstanceController
+	workflowExecutionClient       interface{} // *workflowexecutionclient.Client
 	agentExecutionStreamBroker    interface{} // *agentexecution.StreamBroker
 	workflowExecutionStreamBroker interface{} // *workflowexecution.StreamBroker
 }
@@ -415,6 +418,20 @@ func (tm *TemporalManager) createWorkers(temporalClient client.Client) []worker.
 		Str("runner_queue", workflowValidationTemporalConfig.RunnerQueue).
 		Msg("Created workflow validation worker")
 
+	// 4. Create workflow instance schedule worker
+	if tm.serverDeps.workflowExecutionClient != nil {
+		if creator, ok := tm.serverDeps.workflowExecutionClient.(workflowinstancetemporal.ExecutionCreator); ok {
+			scheduleTemporalConfig := workflowinstancetemporal.LoadConfig()
+			scheduleWorkerConfig := workflowinstancetemporal.NewWorkerConfig(scheduleTemporalConfig, creator)
+			workers = append(workers, scheduleWorkerConfig.CreateWorker(temporalClient))
+			log.Debug().
+				Str("task_queue", scheduleTemporalConfig.TaskQueue).
+				Msg("Created workflow instance schedule worker")
+		} else {
+			log.Warn().Msg("Failed to type assert workflow execution client")
+		}
+	}
+
 	return workers
 }
 
@@ -517,6 +534,24 @@ func (tm *TemporalManager) reinjectWorkflowCreators(temporalClient client.Client
 		}
 	}
 
+	// 4. Create and inject workflow instance schedule manager
+	if storeVal, ok := tm.serverDeps.store.(store.Store); ok {
+		scheduleManager := workflowinstancetemporal.NewScheduleManager(
+			temporalClient,
+			workflowinstancetemporal.LoadConfig(),
+			storeVal,
+		)
+
+		for _, dep := range []interface{}{tm.serverDeps.workflowController, tm.serverDeps.workflowInstanceController} {
+			if controller, ok := dep.(interface {
+				SetScheduleManager(*workflowinstancetemporal.ScheduleManager)
+			}); ok {
+				controller.SetScheduleManager(scheduleManager)
+			}
+		}
+		log.Debug().Msg("Reinjected workflow instance schedule manager")
+	}
+
 	log.Info().Msg("✅ Workflow creators reinjected successfully")
 }
 
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2026 Leftbin/Stigmer
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package converter
+
+import (
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// CustomTaskConverter converts the task_config of a custom task to the Zigflow
+// task that runs it, typically a "call: activity" task dispatching to an
+// activity registered by the extension. It validates the config and returns
+// an error if it is malformed.
+type CustomTaskConverter func(config *structpb.Struct) (map[string]interface{}, error)
+
+var (
+	customTaskConvertersMu sync.RWMutex
+	customTaskConverters   = make(map[string]CustomTaskConverter)
+)
+
+// RegisterCustomTaskKind registers the converter of a custom task kind, the
+// custom_kind of WORKFLOW_TASK_KIND_CUSTOM tasks (e.g. "SNOWFLAKE_QUERY").
+// Runner extensions call it at startup, before workflows are converted.
+//
+// Example:
+//
+//	err := converter.RegisterCustomTaskKind("SNOWFLAKE_QUERY",
+//	    func(config *structpb.Struct) (map[string]interface{}, error) {
+//	        return map[string]interface{}{
+//	            "call": "activity",
+//	            "with": map[string]interface{}{
+//	                "name":      "snowflake.v1.Query",
+//	                "arguments": []interface{}{config.AsMap()},
+//	            },
+//	        }, nil
+//	    })
+func RegisterCustomTaskKind(kind string, convert CustomTaskConverter) error {
+	if kind == "" {
+		return fmt.Errorf("custom task kind is required")
+	}
+	if convert == nil {
+		return fmt.Errorf("custom task kind %s needs a converter", kind)
+	}
+
+	customTaskConvertersMu.Lock()
+	defer customTaskConvertersMu.Unlock()
+	if _, exists := customTaskConverters[kind]; exists {
+		return fmt.Errorf("custom task kind %s is already registered", kind)
+	}
+	customTaskConverters[kind] = convert
+	return nil
+}
+
+// convertCustomTask converts a custom task with the converter registered for
+// its kind.
+func convertCustomTask(kind string, config *structpb.Struct) (map[string]interface{}, error) {
+	customTaskConvertersMu.RLock()
+	convert, ok := customTaskConverters[kind]
+	customTaskConvertersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("custom task kind %q is not registered with this runner", kind)
+	}
+
+	task, err := convert(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s task config: %w", kind, err)
+	}
+	if task == nil {
+		return nil, fmt.Errorf("%s converter returned no task", kind)
+	}
+	return task, nil
+}
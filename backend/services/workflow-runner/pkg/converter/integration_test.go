@@ -50,10 +50,10 @@ func TestE2E_TypedProtoToYAML_AllTaskTypes(t *testing.T) {
 			name:     "SET task",
 			taskKind: apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET,
 			typedProto: &tasksv1.SetTaskConfig{
-				Variables: map[string]string{
+				Variables: mustStruct(map[string]interface{}{
 					"key": "value",
 					"foo": "bar",
-				},
+				}),
 			},
 			expectYAML: []string{"set:", "key: value", "foo: bar"},
 		},
@@ -109,7 +109,7 @@ func TestE2E_TypedProtoToYAML_AllTaskTypes(t *testing.T) {
 				Error:   "ValidationError",
 				Message: "Invalid data",
 			},
-			expectYAML: []string{"raise:", "error: ValidationError"},
+			expectYAML: []string{"raise:", "title: ValidationError", "detail: Invalid data"},
 		},
 		{
 			name:     "RUN task",
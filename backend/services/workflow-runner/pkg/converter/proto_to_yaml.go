@@ -23,6 +23,8 @@ import (
 	tasksv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
 	apiresourcev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/validation"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
 	"gopkg.in/yaml.v3"
 )
 
@@ -115,6 +117,27 @@ func (c *Converter) ProtoToYAML(spec *workflowv1.WorkflowSpec) (string, error) {
 
 	workflow["do"] = doTasks
 
+	// The DSL has no workflow-level failure handler or maximum duration, so
+	// they are passed to the runner through the document metadata.
+	documentMetadata := map[string]interface{}{}
+	if len(spec.OnFailure) > 0 {
+		onFailureTasks := make([]map[string]interface{}, 0, len(spec.OnFailure))
+		for _, task := range spec.OnFailure {
+			yamlTask, err := c.convertTask(task)
+			if err != nil {
+				return "", fmt.Errorf("failed to convert on-failure task '%s': %w", task.Name, err)
+			}
+			onFailureTasks = append(onFailureTasks, yamlTask)
+		}
+		documentMetadata[metadata.MetadataOnFailure] = onFailureTasks
+	}
+	if spec.MaxDurationSeconds > 0 {
+		documentMetadata[metadata.MetadataMaxDuration] = int(spec.MaxDurationSeconds)
+	}
+	if len(documentMetadata) > 0 {
+		workflow["document"].(map[string]interface{})["metadata"] = documentMetadata
+	}
+
 	// Marshal to YAML
 	yamlBytes, err := yaml.Marshal(workflow)
 	if err != nil {
@@ -145,10 +168,11 @@ func (c *Converter) ProtoToYAML(spec *workflowv1.WorkflowSpec) (string, error) {
 // - TRY → try
 // - LISTEN → listen
 // - WAIT → wait
-// - CALL_ACTIVITY → callActivity (future)
+// - CALL_ACTIVITY → call: activity
 // - RAISE → raise
 // - RUN → run
 // - AGENT_CALL → call: agent
+// - CUSTOM → the task of the extension registered for custom_kind
 func (c *Converter) convertTask(task *workflowv1.WorkflowTask) (map[string]interface{}, error) {
 	if task.Name == "" {
 		return nil, fmt.Errorf("task name is required")
@@ -184,7 +208,11 @@ func (c *Converter) convertTask(task *workflowv1.WorkflowTask) (map[string]inter
 		yamlTask[task.Name] = c.convertForkTask(typedProto.(*tasksv1.ForkTaskConfig))
 
 	case apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_TRY:
-		yamlTask[task.Name] = c.convertTryTask(typedProto.(*tasksv1.TryTaskConfig))
+		tryTask, err := c.convertTryTask(typedProto.(*tasksv1.TryTaskConfig))
+		if err != nil {
+			return nil, fmt.Errorf("task '%s': %w", task.Name, err)
+		}
+		yamlTask[task.Name] = tryTask
 
 	case apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_LISTEN:
 		yamlTask[task.Name] = c.convertListenTask(typedProto.(*tasksv1.ListenTaskConfig))
@@ -202,16 +230,26 @@ func (c *Converter) convertTask(task *workflowv1.WorkflowTask) (map[string]inter
 		yamlTask[task.Name] = c.convertAgentCallTask(typedProto.(*tasksv1.AgentCallTaskConfig))
 
 	case apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_CALL_ACTIVITY:
-		// CALL_ACTIVITY: Future implementation for Temporal activities
-		return nil, fmt.Errorf("CALL_ACTIVITY not yet implemented")
+		yamlTask[task.Name] = c.convertCallActivityTask(typedProto.(*tasksv1.CallActivityTaskConfig))
+
+	case apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_CUSTOM:
+		customTask, err := convertCustomTask(task.CustomKind, typedProto.(*structpb.Struct))
+		if err != nil {
+			return nil, fmt.Errorf("task '%s': %w", task.Name, err)
+		}
+		yamlTask[task.Name] = customTask
 
 	default:
 		return nil, fmt.Errorf("unsupported task kind: %v", task.Kind)
 	}
 
+	taskMap, ok := yamlTask[task.Name].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("task '%s' converted to %T, expected a map", task.Name, yamlTask[task.Name])
+	}
+
 	// Add export if present
 	if task.Export != nil && task.Export.As != "" {
-		taskMap := yamlTask[task.Name].(map[string]interface{})
 		taskMap["export"] = map[string]interface{}{
 			"as": task.Export.As,
 		}
@@ -219,9 +257,33 @@ func (c *Converter) convertTask(task *workflowv1.WorkflowTask) (map[string]inter
 
 	// Add flow control if present
 	if task.Flow != nil && task.Flow.Then != "" {
-		taskMap := yamlTask[task.Name].(map[string]interface{})
 		taskMap["then"] = task.Flow.Then
 	}
 
+	// Add run guard if present
+	if task.If != "" {
+		taskMap["if"] = task.If
+	}
+
+	// Add execution timeout if present (spec-native task timeout)
+	if task.ExecutionTimeoutSeconds > 0 {
+		taskMap["timeout"] = map[string]interface{}{
+			"after": map[string]interface{}{
+				"seconds": task.ExecutionTimeoutSeconds,
+			},
+		}
+	}
+
+	// Add sensitive output fields if present. The DSL has no field for output
+	// redaction, so it is passed to the runner through task metadata.
+	if len(task.SensitiveOutputFields) > 0 {
+		taskMeta, ok := taskMap["metadata"].(map[string]interface{})
+		if !ok {
+			taskMeta = make(map[string]interface{})
+			taskMap["metadata"] = taskMeta
+		}
+		taskMeta[metadata.MetadataSensitiveOutputFields] = task.SensitiveOutputFields
+	}
+
 	return yamlTask, nil
 }
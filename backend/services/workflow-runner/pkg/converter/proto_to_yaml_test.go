@@ -25,6 +25,8 @@ import (
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/validation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
 )
 
 // Phase 3 refactoring: Tests now use typed proto construction instead of raw Structs.
@@ -32,12 +34,21 @@ import (
 // Before: TaskConfig: &structpb.Struct{ Fields: map[string]*structpb.Value{...} }
 // After: Build typed proto → Marshal to Struct using validation.MarshalTaskConfig
 
+// mustStruct builds a structpb.Struct for SET task variables in test fixtures.
+func mustStruct(fields map[string]interface{}) *structpb.Struct {
+	s, err := structpb.NewStruct(fields)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
 func TestProtoToYAML_SimpleSetTask(t *testing.T) {
 	// Create typed proto
 	setConfig := &tasksv1.SetTaskConfig{
-		Variables: map[string]string{
+		Variables: mustStruct(map[string]interface{}{
 			"status": "initialized",
-		},
+		}),
 	}
 
 	// Marshal to Struct
@@ -76,6 +87,80 @@ func TestProtoToYAML_SimpleSetTask(t *testing.T) {
 	t.Logf("Generated YAML:\n%s", yaml)
 }
 
+func TestProtoToYAML_SetTaskTypedVariables(t *testing.T) {
+	setConfig := &tasksv1.SetTaskConfig{
+		Variables: mustStruct(map[string]interface{}{
+			"retryCount": 3,
+			"debug":      true,
+			"label":      "nightly",
+			"limits":     map[string]interface{}{"max": 10},
+		}),
+	}
+
+	taskConfig, err := validation.MarshalTaskConfig(setConfig)
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "typed-set-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "init",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// Numbers and booleans are not quoted, so the runner sees their JSON types
+	assert.Contains(t, yaml, "retryCount: 3\n")
+	assert.Contains(t, yaml, "debug: true\n")
+	assert.Contains(t, yaml, "label: nightly\n")
+	assert.Contains(t, yaml, "max: 10\n")
+}
+
+func TestProtoToYAML_SetTaskUnset(t *testing.T) {
+	setConfig := &tasksv1.SetTaskConfig{
+		Variables: mustStruct(map[string]interface{}{"phase": "publish"}),
+		Unset:     []string{"tempToken"},
+	}
+
+	taskConfig, err := validation.MarshalTaskConfig(setConfig)
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "unset-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "drop-token",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// Unset variables are explicit nulls, not empty strings
+	assert.Contains(t, yaml, "tempToken: null")
+	assert.Contains(t, yaml, "phase: publish")
+}
+
 func TestProtoToYAML_HTTPCallTask(t *testing.T) {
 	// Create typed proto
 	httpConfig := &tasksv1.HttpCallTaskConfig{
@@ -127,14 +212,14 @@ func TestProtoToYAML_HTTPCallTask(t *testing.T) {
 func TestProtoToYAML_WithFlowControl(t *testing.T) {
 	// Create typed protos for two tasks
 	validateConfig := &tasksv1.SetTaskConfig{
-		Variables: map[string]string{
+		Variables: mustStruct(map[string]interface{}{
 			"valid": "true",
-		},
+		}),
 	}
 	processConfig := &tasksv1.SetTaskConfig{
-		Variables: map[string]string{
+		Variables: mustStruct(map[string]interface{}{
 			"status": "processed",
-		},
+		}),
 	}
 
 	// Marshal to Structs
@@ -185,7 +270,7 @@ func TestProtoToYAML_WithFlowControl(t *testing.T) {
 func TestProtoToYAML_MissingDocument(t *testing.T) {
 	// Create typed proto
 	setConfig := &tasksv1.SetTaskConfig{
-		Variables: map[string]string{},
+		Variables: mustStruct(map[string]interface{}{}),
 	}
 	taskConfig, err := validation.MarshalTaskConfig(setConfig)
 	require.NoError(t, err)
@@ -342,9 +427,9 @@ func TestProtoToYAML_SwitchTask(t *testing.T) {
 func TestProtoToYAML_ComplexWorkflow(t *testing.T) {
 	// Create multiple typed protos
 	setConfig := &tasksv1.SetTaskConfig{
-		Variables: map[string]string{
+		Variables: mustStruct(map[string]interface{}{
 			"userId": "123",
-		},
+		}),
 	}
 	httpConfig := &tasksv1.HttpCallTaskConfig{
 		Method: "GET",
@@ -422,3 +507,904 @@ func TestProtoToYAML_ComplexWorkflow(t *testing.T) {
 
 	t.Logf("Generated YAML:\n%s", yaml)
 }
+
+func TestProtoToYAML_TaskExecutionTimeout(t *testing.T) {
+	setConfig := &tasksv1.SetTaskConfig{
+		Variables: mustStruct(map[string]interface{}{
+			"status": "initialized",
+		}),
+	}
+
+	taskConfig, err := validation.MarshalTaskConfig(setConfig)
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "timeout-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:                    "set-status",
+				Kind:                    apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET,
+				TaskConfig:              taskConfig,
+				ExecutionTimeoutSeconds: 600,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// Execution timeout maps to the spec-native task timeout
+	assert.Contains(t, yaml, "timeout:")
+	assert.Contains(t, yaml, "after:")
+	assert.Contains(t, yaml, "seconds: 600")
+}
+
+func TestProtoToYAML_ForkContinueOnBranchError(t *testing.T) {
+	setConfig, err := validation.MarshalTaskConfig(&tasksv1.SetTaskConfig{
+		Variables: mustStruct(map[string]interface{}{"status": "fetched"}),
+	})
+	require.NoError(t, err)
+
+	branchTask := func(name string) []*workflowv1.WorkflowTask {
+		return []*workflowv1.WorkflowTask{{
+			Name:       name,
+			Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET,
+			TaskConfig: setConfig,
+		}}
+	}
+
+	forkConfig := &tasksv1.ForkTaskConfig{
+		Branches: []*tasksv1.ForkBranch{
+			{Name: "users", Do: branchTask("fetch-users")},
+			{Name: "posts", Do: branchTask("fetch-posts")},
+		},
+		ContinueOnBranchError: true,
+	}
+
+	taskConfig, err := validation.MarshalTaskConfig(forkConfig)
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "fork-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "gather",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_FORK,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// Branch error handling is carried to the runner via task metadata
+	assert.Contains(t, yaml, "metadata:")
+	assert.Contains(t, yaml, "continueOnBranchError: true")
+}
+
+func TestProtoToYAML_TryFilteredCatches(t *testing.T) {
+	callConfig, err := validation.MarshalTaskConfig(&tasksv1.RaiseTaskConfig{
+		Error:   "UpstreamError",
+		Message: "call failed",
+	})
+	require.NoError(t, err)
+	handlerConfig := func(outcome string) *structpb.Struct {
+		config, err := validation.MarshalTaskConfig(&tasksv1.SetTaskConfig{
+			Variables: mustStruct(map[string]interface{}{"outcome": outcome}),
+		})
+		require.NoError(t, err)
+		return config
+	}
+	handler := func(name, outcome string) []*workflowv1.WorkflowTask {
+		return []*workflowv1.WorkflowTask{{
+			Name:       name,
+			Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET,
+			TaskConfig: handlerConfig(outcome),
+		}}
+	}
+
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.TryTaskConfig{
+		Try: []*workflowv1.WorkflowTask{{
+			Name:       "call",
+			Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_RAISE,
+			TaskConfig: callConfig,
+		}},
+		Catches: []*tasksv1.CatchBlock{
+			{StatusCodes: []int32{429}, Do: handler("backoff", "rate-limited")},
+			{ErrorTypes: []string{"UPSTREAM_4XX"}, As: "clientError", Do: handler("report", "rejected")},
+		},
+		Catch: &tasksv1.CatchBlock{As: "err", Do: handler("fallback", "failed")},
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "try-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{{
+			Name:       "guardedCall",
+			Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_TRY,
+			TaskConfig: taskConfig,
+		}},
+	}
+
+	converter := NewConverter()
+	out, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(out), &doc))
+	task := doc["do"].([]interface{})[0].(map[string]interface{})["guardedCall"].(map[string]interface{})
+
+	// The try tasks and the unfiltered catch use the DSL fields
+	try := task["try"].([]interface{})
+	require.Len(t, try, 1)
+	assert.Contains(t, try[0], "call")
+	catch := task["catch"].(map[string]interface{})
+	assert.Equal(t, "err", catch["as"])
+	assert.Contains(t, catch["do"].([]interface{})[0], "fallback")
+
+	// The filtered catch blocks are carried in declaration order via task metadata
+	catches := task["metadata"].(map[string]interface{})["tryCatches"].([]interface{})
+	require.Len(t, catches, 2)
+	first := catches[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{429}, first["statusCodes"])
+	assert.Contains(t, first["do"].([]interface{})[0], "backoff")
+	second := catches[1].(map[string]interface{})
+	assert.Equal(t, []interface{}{"UPSTREAM_4XX"}, second["errorTypes"])
+	assert.Equal(t, "clientError", second["as"])
+	assert.Contains(t, second["do"].([]interface{})[0], "report")
+}
+
+func TestProtoToYAML_TryRetry(t *testing.T) {
+	callConfig, err := validation.MarshalTaskConfig(&tasksv1.RaiseTaskConfig{
+		Error:   "UpstreamError",
+		Message: "call failed",
+	})
+	require.NoError(t, err)
+
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.TryTaskConfig{
+		Try: []*workflowv1.WorkflowTask{{
+			Name:       "call",
+			Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_RAISE,
+			TaskConfig: callConfig,
+		}},
+		Retry: &tasksv1.RetryPolicy{
+			ErrorTypes:   []string{"ExecutionTimeout", "UPSTREAM_5XX"},
+			MaxRetries:   3,
+			DelaySeconds: 5,
+		},
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "try-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{{
+			Name:       "guardedCall",
+			Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_TRY,
+			TaskConfig: taskConfig,
+		}},
+	}
+
+	converter := NewConverter()
+	out, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(out), &doc))
+	task := doc["do"].([]interface{})[0].(map[string]interface{})["guardedCall"].(map[string]interface{})
+
+	// The retry policy is carried via task metadata, with the empty catch
+	// the DSL requires
+	retry := task["metadata"].(map[string]interface{})["tryRetry"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"ExecutionTimeout", "UPSTREAM_5XX"}, retry["errorTypes"])
+	assert.Equal(t, 3, retry["maxRetries"])
+	assert.Equal(t, 5, retry["delaySeconds"])
+	assert.Equal(t, map[string]interface{}{}, task["catch"])
+}
+
+func TestProtoToYAML_ForLoopControl(t *testing.T) {
+	setConfig, err := validation.MarshalTaskConfig(&tasksv1.SetTaskConfig{
+		Variables: mustStruct(map[string]interface{}{"attempt": "${ .item }"}),
+	})
+	require.NoError(t, err)
+
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.ForTaskConfig{
+		Each: "item",
+		In:   "${ [range(0; 30; 1)] }",
+		Do: []*workflowv1.WorkflowTask{{
+			Name:       "record",
+			Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET,
+			TaskConfig: setConfig,
+		}},
+		Until:            `${ $context["poll"].status == "ready" }`,
+		MaxIterations:    30,
+		ReportIterations: true,
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "repeat-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "waitUntilReady",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_FOR,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// Loop control is carried to the runner via task metadata
+	assert.Contains(t, yaml, "metadata:")
+	assert.Contains(t, yaml, "forUntil:")
+	assert.Contains(t, yaml, "forMaxIterations: 30")
+	assert.Contains(t, yaml, "forReportIterations: true")
+}
+
+func TestProtoToYAML_ListenCorrelationAndTimeout(t *testing.T) {
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.ListenTaskConfig{
+		To: &tasksv1.ListenTo{
+			Mode: "one",
+			Signals: []*tasksv1.SignalSpec{{
+				Id:       "orderPaid",
+				Type:     "signal",
+				AcceptIf: "${ .orderId == $context.createOrder.id }",
+			}},
+		},
+		TimeoutSeconds: 3600,
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "listen-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "waitForPayment",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_LISTEN,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	assert.Contains(t, yaml, "id: orderPaid")
+	assert.Contains(t, yaml, "acceptIf: ${ .orderId == $context.createOrder.id }")
+	assert.Contains(t, yaml, "timeout: 1h0m0s")
+}
+
+func TestProtoToYAML_SensitiveOutputFields(t *testing.T) {
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.HttpCallTaskConfig{
+		Method:         "POST",
+		Endpoint:       &tasksv1.HttpEndpoint{Uri: "https://auth.example.com/token"},
+		TimeoutSeconds: 30,
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "login-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:                  "login",
+				Kind:                  apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL,
+				TaskConfig:            taskConfig,
+				Export:                &workflowv1.Export{As: "${.}"},
+				SensitiveOutputFields: []string{"access_token", "refresh_token"},
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// Sensitive fields are carried to the runner via task metadata
+	assert.Contains(t, yaml, "sensitiveOutputFields:")
+	assert.Contains(t, yaml, "- access_token")
+	assert.Contains(t, yaml, "- refresh_token")
+}
+
+func TestProtoToYAML_TaskIf(t *testing.T) {
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.HttpCallTaskConfig{
+		Method:         "POST",
+		Endpoint:       &tasksv1.HttpEndpoint{Uri: "https://pager.example.com/page"},
+		TimeoutSeconds: 30,
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "triage-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "escalate",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL,
+				TaskConfig: taskConfig,
+				If:         `${ $context["analyze"].severity | . == "high" }`,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// The guard maps to the spec-native if directive
+	assert.Contains(t, yaml, `if: ${ $context["analyze"].severity | . == "high" }`)
+}
+
+func TestProtoToYAML_HttpCallTLS(t *testing.T) {
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.HttpCallTaskConfig{
+		Method:         "GET",
+		Endpoint:       &tasksv1.HttpEndpoint{Uri: "https://internal.example.com/data"},
+		TimeoutSeconds: 30,
+		Tls: &tasksv1.HttpTls{
+			ClientCert: "${.secrets.CLIENT_CERT}",
+			ClientKey:  "${.secrets.CLIENT_KEY}",
+			CaBundle:   "${.secrets.INTERNAL_CA}",
+		},
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "internal-sync",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "fetch",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// TLS settings are carried to the runner via task metadata, as placeholders
+	assert.Contains(t, yaml, "httpTls:")
+	assert.Contains(t, yaml, "clientKey: ${.secrets.CLIENT_KEY}")
+	assert.Contains(t, yaml, "caBundle: ${.secrets.INTERNAL_CA}")
+}
+
+func TestProtoToYAML_HttpCallCache(t *testing.T) {
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.HttpCallTaskConfig{
+		Method:         "GET",
+		Endpoint:       &tasksv1.HttpEndpoint{Uri: "https://api.example.com/config"},
+		TimeoutSeconds: 30,
+		Cache: &tasksv1.HttpCache{
+			TtlSeconds:  600,
+			Key:         "uri",
+			IncludeAuth: true,
+		},
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "config-sync",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "fetch",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// The cache directive is carried to the runner via task metadata
+	assert.Contains(t, yaml, "httpCache:")
+	assert.Contains(t, yaml, "ttl: 10m0s")
+	assert.Contains(t, yaml, "key: uri")
+	assert.Contains(t, yaml, "includeAuth: true")
+}
+
+func TestProtoToYAML_HttpCallProxy(t *testing.T) {
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.HttpCallTaskConfig{
+		Method:         "GET",
+		Endpoint:       &tasksv1.HttpEndpoint{Uri: "https://partner.example.com/data"},
+		TimeoutSeconds: 30,
+		Proxy:          &tasksv1.HttpProxy{Url: "http://proxy.corp:3128"},
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "partner-sync",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "fetch",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// The proxy override is carried to the runner via task metadata
+	assert.Contains(t, yaml, "httpProxy:")
+	assert.Contains(t, yaml, "url: http://proxy.corp:3128")
+}
+
+func TestProtoToYAML_HttpCallResponseFormat(t *testing.T) {
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.HttpCallTaskConfig{
+		Method:         "GET",
+		Endpoint:       &tasksv1.HttpEndpoint{Uri: "https://api.example.com/report.csv"},
+		TimeoutSeconds: 30,
+		ResponseFormat: "text",
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "report-sync",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "fetch",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// The response format is carried to the runner via task metadata
+	assert.Contains(t, yaml, "httpResponseFormat: text")
+}
+
+func TestProtoToYAML_HttpCallStatusCheck(t *testing.T) {
+	expectConfig, err := validation.MarshalTaskConfig(&tasksv1.HttpCallTaskConfig{
+		Method:         "POST",
+		Endpoint:       &tasksv1.HttpEndpoint{Uri: "https://api.example.com/users"},
+		TimeoutSeconds: 30,
+		ExpectStatus:   []int32{200, 201},
+	})
+	require.NoError(t, err)
+	allowAnyConfig, err := validation.MarshalTaskConfig(&tasksv1.HttpCallTaskConfig{
+		Method:         "GET",
+		Endpoint:       &tasksv1.HttpEndpoint{Uri: "https://api.example.com/users/42"},
+		TimeoutSeconds: 30,
+		AllowAnyStatus: true,
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "user-sync",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "create",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL,
+				TaskConfig: expectConfig,
+			},
+			{
+				Name:       "lookup",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL,
+				TaskConfig: allowAnyConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// The status checks are carried to the runner via task metadata
+	assert.Contains(t, yaml, "httpExpectStatus:\n")
+	assert.Contains(t, yaml, "- 201")
+	assert.Contains(t, yaml, "httpAllowAnyStatus: true")
+}
+
+func TestProtoToYAML_HttpCallMultipartBody(t *testing.T) {
+	body, err := structpb.NewStruct(map[string]interface{}{
+		"file": "${ $context.export.body }",
+		"meta": map[string]interface{}{"source": "daily-export"},
+	})
+	require.NoError(t, err)
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.HttpCallTaskConfig{
+		Method:         "POST",
+		Endpoint:       &tasksv1.HttpEndpoint{Uri: "https://files.example.com/upload"},
+		Body:           body,
+		TimeoutSeconds: 30,
+		BodyEncoding:   "multipart",
+		MultipartParts: []*tasksv1.HttpMultipartPart{
+			{Name: "file", Filename: "report.csv", ContentType: "text/csv"},
+			{Name: "meta"},
+		},
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "report-upload",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "upload",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// The encoding and part settings are carried to the runner via task
+	// metadata; part values stay in the body
+	assert.Contains(t, yaml, "httpBodyEncoding: multipart")
+	assert.Contains(t, yaml, "httpMultipartParts:")
+	assert.Contains(t, yaml, "filename: report.csv")
+	assert.Contains(t, yaml, "contentType: text/csv")
+	assert.Contains(t, yaml, "source: daily-export")
+}
+
+func TestProtoToYAML_OnFailure(t *testing.T) {
+	chargeConfig, err := validation.MarshalTaskConfig(&tasksv1.RaiseTaskConfig{
+		Error:   "PaymentDeclined",
+		Message: "card declined",
+	})
+	require.NoError(t, err)
+	notifyConfig, err := validation.MarshalTaskConfig(&tasksv1.SetTaskConfig{
+		Variables: mustStruct(map[string]interface{}{"failedTask": "${ $data.error.task }"}),
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "billing",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "charge",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_RAISE,
+				TaskConfig: chargeConfig,
+			},
+		},
+		OnFailure: []*workflowv1.WorkflowTask{
+			{
+				Name:       "notify",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET,
+				TaskConfig: notifyConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	out, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// The on-failure tasks are carried to the runner via the document
+	// metadata, outside the main "do" list
+	var doc map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(out), &doc))
+	document := doc["document"].(map[string]interface{})
+	onFailure := document["metadata"].(map[string]interface{})["onFailure"].([]interface{})
+	require.Len(t, onFailure, 1)
+	assert.Contains(t, onFailure[0], "notify")
+	assert.Contains(t, out, "failedTask: ${ $data.error.task }")
+
+	do := doc["do"].([]interface{})
+	require.Len(t, do, 1)
+	assert.Contains(t, do[0], "charge")
+}
+
+func TestProtoToYAML_ListenApproval(t *testing.T) {
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.ListenTaskConfig{
+		To: &tasksv1.ListenTo{
+			Mode:    "one",
+			Signals: []*tasksv1.SignalSpec{{Id: "approval", Type: "signal"}},
+		},
+		Approval: &tasksv1.ListenApproval{
+			Approvers:      []string{"ops-team"},
+			TimeoutSeconds: 172800,
+			OnTimeout:      "approve",
+		},
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "deploy",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "approveDeploy",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_LISTEN,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	assert.Contains(t, yaml, "one:")
+	assert.Contains(t, yaml, "id: approval")
+	assert.Contains(t, yaml, "approval:")
+	assert.Contains(t, yaml, "- ops-team")
+	assert.Contains(t, yaml, "timeout: 48h0m0s")
+	assert.Contains(t, yaml, "onTimeout: approve")
+}
+
+func TestProtoToYAML_AgentCallOutputSchema(t *testing.T) {
+	schema, err := structpb.NewStruct(map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"severity": map[string]any{"type": "string"}},
+	})
+	require.NoError(t, err)
+
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.AgentCallTaskConfig{
+		Agent:   "code-reviewer",
+		Message: "Review the PR",
+		Config:  &tasksv1.AgentExecutionConfig{Timeout: 300, OutputSchema: schema},
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "review-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "review",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_AGENT_CALL,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	// The runner reads the schema back from the task's with.config
+	assert.Contains(t, yaml, "outputSchema:")
+	assert.Contains(t, yaml, "severity:")
+}
+
+func TestProtoToYAML_AgentCallAttachments(t *testing.T) {
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.AgentCallTaskConfig{
+		Agent:   "code-reviewer",
+		Message: "Review the attached diff",
+		Attachments: []*tasksv1.AgentAttachment{
+			{Name: "diff.patch", Content: `${ $context["fetch-diff"].body }`, MimeType: "text/x-patch"},
+		},
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "review-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "review",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_AGENT_CALL,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	assert.Contains(t, yaml, "attachments:")
+	assert.Contains(t, yaml, "name: diff.patch")
+	assert.Contains(t, yaml, "mimeType: text/x-patch")
+}
+
+func TestProtoToYAML_AgentCallOrgAndVersion(t *testing.T) {
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.AgentCallTaskConfig{
+		Agent:   "code-reviewer-prod",
+		Message: "Review this PR",
+		Org:     "my-org",
+		Version: "stable",
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "review-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "review",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_AGENT_CALL,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	assert.Contains(t, yaml, "org: my-org")
+	assert.Contains(t, yaml, "version: stable")
+}
+
+func TestProtoToYAML_CallActivity(t *testing.T) {
+	input, err := structpb.NewStruct(map[string]any{"data": "${ .fetch.body }"})
+	require.NoError(t, err)
+
+	taskConfig, err := validation.MarshalTaskConfig(&tasksv1.CallActivityTaskConfig{
+		Activity:       "transform.v1.Normalize",
+		Input:          input,
+		TaskQueue:      "custom-q",
+		TimeoutSeconds: 120,
+	})
+	require.NoError(t, err)
+
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "normalize-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "normalize",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_CALL_ACTIVITY,
+				TaskConfig: taskConfig,
+			},
+		},
+	}
+
+	converter := NewConverter()
+	yaml, err := converter.ProtoToYAML(spec)
+	require.NoError(t, err)
+
+	assert.Contains(t, yaml, "call: activity")
+	assert.Contains(t, yaml, "name: transform.v1.Normalize")
+	assert.Contains(t, yaml, "arguments:")
+	assert.Contains(t, yaml, "taskQueue: custom-q")
+	assert.Contains(t, yaml, "timeoutSeconds: 120")
+}
+
+func TestProtoToYAML_CustomTask(t *testing.T) {
+	require.NoError(t, RegisterCustomTaskKind("TEST_SNOWFLAKE_QUERY",
+		func(config *structpb.Struct) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"call": "activity",
+				"with": map[string]interface{}{
+					"name":      "snowflake.v1.Query",
+					"arguments": []interface{}{config.AsMap()},
+				},
+			}, nil
+		}))
+	require.Error(t, RegisterCustomTaskKind("TEST_SNOWFLAKE_QUERY",
+		func(*structpb.Struct) (map[string]interface{}, error) { return nil, nil }))
+
+	taskConfig, err := structpb.NewStruct(map[string]any{"sql": "SELECT 1"})
+	require.NoError(t, err)
+	spec := &workflowv1.WorkflowSpec{
+		Document: &workflowv1.WorkflowDocument{
+			Dsl:       "1.0.0",
+			Namespace: "test",
+			Name:      "custom-workflow",
+			Version:   "1.0",
+		},
+		Tasks: []*workflowv1.WorkflowTask{
+			{
+				Name:       "query",
+				Kind:       apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_CUSTOM,
+				CustomKind: "TEST_SNOWFLAKE_QUERY",
+				TaskConfig: taskConfig,
+				Export:     &workflowv1.Export{As: "${.}"},
+			},
+		},
+	}
+
+	yaml, err := NewConverter().ProtoToYAML(spec)
+	require.NoError(t, err)
+	assert.Contains(t, yaml, "call: activity")
+	assert.Contains(t, yaml, "name: snowflake.v1.Query")
+	assert.Contains(t, yaml, "sql: SELECT 1")
+	assert.Contains(t, yaml, "as: ${.}")
+
+	// Kinds without a registered extension fail the conversion
+	spec.Tasks[0].CustomKind = "TEST_UNREGISTERED"
+	_, err = NewConverter().ProtoToYAML(spec)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `custom task kind "TEST_UNREGISTERED" is not registered`)
+}
@@ -17,7 +17,12 @@
 package converter
 
 import (
+	"fmt"
+	"time"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
 	tasksv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
 )
 
 // Type-safe task converters for Phase 3.
@@ -26,10 +31,20 @@ import (
 // They provide compile-time type safety and better error messages compared to
 // the generic map-based approach.
 
-// convertSetTask converts SetTaskConfig to YAML structure
+// convertSetTask converts SetTaskConfig to YAML structure.
+// Variable values keep their JSON type. Unset variables are written as
+// explicit nulls, which the SET task builder treats as removals.
 func (c *Converter) convertSetTask(cfg *tasksv1.SetTaskConfig) map[string]interface{} {
+	variables := cfg.GetVariables().AsMap()
+	set := make(map[string]interface{}, len(variables)+len(cfg.Unset))
+	for name, value := range variables {
+		set[name] = value
+	}
+	for _, name := range cfg.Unset {
+		set[name] = nil
+	}
 	return map[string]interface{}{
-		"set": cfg.Variables,
+		"set": set,
 	}
 }
 
@@ -60,10 +75,120 @@ func (c *Converter) convertHttpCallTask(cfg *tasksv1.HttpCallTaskConfig) map[str
 		with["body"] = cfg.Body.AsMap()
 	}
 
-	return map[string]interface{}{
+	httpTask := map[string]interface{}{
 		"call": "http",
 		"with": with,
 	}
+
+	// The DSL HTTP call has no TLS, cache, proxy, response format, status
+	// check or body encoding settings, so they are passed to the runner
+	// through task metadata
+	taskMetadata := map[string]interface{}{}
+	if tls := convertHttpTLS(cfg.Tls); len(tls) > 0 {
+		taskMetadata[metadata.MetadataHTTPTLS] = tls
+	}
+	if cache := convertHttpCache(cfg.Cache); cache != nil {
+		taskMetadata[metadata.MetadataHTTPCache] = cache
+	}
+	if proxy := convertHttpProxy(cfg.Proxy); proxy != nil {
+		taskMetadata[metadata.MetadataHTTPProxy] = proxy
+	}
+	if cfg.ResponseFormat != "" {
+		taskMetadata[metadata.MetadataHTTPResponseFormat] = cfg.ResponseFormat
+	}
+	if len(cfg.ExpectStatus) > 0 {
+		taskMetadata[metadata.MetadataHTTPExpectStatus] = cfg.ExpectStatus
+	}
+	if cfg.AllowAnyStatus {
+		taskMetadata[metadata.MetadataHTTPAllowAnyStatus] = true
+	}
+	if cfg.BodyEncoding != "" && cfg.BodyEncoding != "json" {
+		taskMetadata[metadata.MetadataHTTPBodyEncoding] = cfg.BodyEncoding
+	}
+	if parts := convertHttpMultipartParts(cfg.MultipartParts); len(parts) > 0 {
+		taskMetadata[metadata.MetadataHTTPMultipartParts] = parts
+	}
+	if len(taskMetadata) > 0 {
+		httpTask["metadata"] = taskMetadata
+	}
+
+	return httpTask
+}
+
+// convertHttpTLS converts HttpTls to the task metadata read by the HTTP activity.
+func convertHttpTLS(cfg *tasksv1.HttpTls) map[string]interface{} {
+	if cfg == nil {
+		return nil
+	}
+
+	tls := map[string]interface{}{}
+	if cfg.ClientCert != "" {
+		tls["clientCert"] = cfg.ClientCert
+	}
+	if cfg.ClientKey != "" {
+		tls["clientKey"] = cfg.ClientKey
+	}
+	if cfg.CaBundle != "" {
+		tls["caBundle"] = cfg.CaBundle
+	}
+	if cfg.CaBundleFile != "" {
+		tls["caBundleFile"] = cfg.CaBundleFile
+	}
+	if cfg.InsecureSkipVerify {
+		tls["insecureSkipVerify"] = true
+	}
+	return tls
+}
+
+// convertHttpCache converts HttpCache to the task metadata read by the HTTP activity.
+func convertHttpCache(cfg *tasksv1.HttpCache) map[string]interface{} {
+	if cfg == nil || cfg.TtlSeconds <= 0 {
+		return nil
+	}
+
+	cache := map[string]interface{}{
+		"ttl": (time.Duration(cfg.TtlSeconds) * time.Second).String(),
+	}
+	if cfg.Key != "" {
+		cache["key"] = cfg.Key
+	}
+	if cfg.IncludeAuth {
+		cache["includeAuth"] = true
+	}
+	return cache
+}
+
+// convertHttpProxy converts HttpProxy to the task metadata read by the HTTP activity.
+func convertHttpProxy(cfg *tasksv1.HttpProxy) map[string]interface{} {
+	switch {
+	case cfg == nil:
+		return nil
+	case cfg.Disabled:
+		return map[string]interface{}{"disabled": true}
+	case cfg.Url != "":
+		return map[string]interface{}{"url": cfg.Url}
+	}
+	return nil
+}
+
+// convertHttpMultipartParts converts the parts of a multipart body to the
+// task metadata read by the HTTP activity.
+func convertHttpMultipartParts(parts []*tasksv1.HttpMultipartPart) []interface{} {
+	converted := make([]interface{}, 0, len(parts))
+	for _, p := range parts {
+		part := map[string]interface{}{"name": p.Name}
+		if p.Filename != "" {
+			part["filename"] = p.Filename
+		}
+		if p.ContentType != "" {
+			part["contentType"] = p.ContentType
+		}
+		if p.Base64 {
+			part["base64"] = true
+		}
+		converted = append(converted, part)
+	}
+	return converted
 }
 
 // convertGrpcCallTask converts GrpcCallTaskConfig to YAML structure
@@ -123,9 +248,27 @@ func (c *Converter) convertForTask(cfg *tasksv1.ForTaskConfig) map[string]interf
 	// Note: cfg.Do is []*WorkflowTask - would need recursive conversion
 	// For now, this is handled by the existing generic converter logic
 
-	return map[string]interface{}{
+	forTask := map[string]interface{}{
 		"for": forMap,
 	}
+
+	// The DSL for task has no fields for early exit, iteration caps or the
+	// iteration count, so they are passed to the runner through task metadata
+	taskMetadata := map[string]interface{}{}
+	if cfg.Until != "" {
+		taskMetadata[metadata.MetadataForUntil] = cfg.Until
+	}
+	if cfg.MaxIterations > 0 {
+		taskMetadata[metadata.MetadataForMaxIterations] = int(cfg.MaxIterations)
+	}
+	if cfg.ReportIterations {
+		taskMetadata[metadata.MetadataForReportIterations] = true
+	}
+	if len(taskMetadata) > 0 {
+		forTask["metadata"] = taskMetadata
+	}
+
+	return forTask
 }
 
 // convertForkTask converts ForkTaskConfig to YAML structure
@@ -142,43 +285,197 @@ func (c *Converter) convertForkTask(cfg *tasksv1.ForkTaskConfig) map[string]inte
 		branches[i] = branchMap
 	}
 
-	return map[string]interface{}{
+	forkTask := map[string]interface{}{
 		"fork": map[string]interface{}{
 			"branches": branches,
 		},
 	}
+
+	// The DSL fork task has no field for branch error handling, so it is
+	// passed to the runner through task metadata
+	if cfg.ContinueOnBranchError {
+		forkTask["metadata"] = map[string]interface{}{
+			metadata.MetadataContinueOnBranchError: true,
+		}
+	}
+
+	return forkTask
 }
 
-// convertTryTask converts TryTaskConfig to YAML structure
-// Note: Try tasks have nested WorkflowTask arrays which need special handling
-func (c *Converter) convertTryTask(cfg *tasksv1.TryTaskConfig) map[string]interface{} {
-	tryMap := map[string]interface{}{}
-	// Note: cfg.Try is []*WorkflowTask - would need recursive conversion
-	// For now, this is handled by the existing generic converter logic
+// convertTryTask converts TryTaskConfig to YAML structure, including the
+// nested tasks of its try and catch blocks.
+//
+// The DSL try task has a single catch without classification filters, so the
+// filtered catch blocks (catches) and the retry policy are passed to the
+// runner through task metadata. A catch carrying filters is appended to them
+// rather than used as the DSL catch, which would ignore its filters.
+func (c *Converter) convertTryTask(cfg *tasksv1.TryTaskConfig) (map[string]interface{}, error) {
+	tryTasks, err := c.convertTaskList(cfg.GetTry())
+	if err != nil {
+		return nil, fmt.Errorf("try: %w", err)
+	}
+	tryTask := map[string]interface{}{
+		"try": tryTasks,
+	}
+
+	catches := cfg.GetCatches()
+	if cfg.Catch != nil && isFilteredCatch(cfg.Catch) {
+		catches = append(catches[:len(catches):len(catches)], cfg.Catch)
+	} else if cfg.Catch != nil {
+		catchMap, err := c.convertCatchBlock(cfg.Catch)
+		if err != nil {
+			return nil, fmt.Errorf("catch: %w", err)
+		}
+		tryTask["catch"] = catchMap
+	}
+
+	tryMetadata := map[string]interface{}{}
+	if len(catches) > 0 {
+		catchList := make([]interface{}, 0, len(catches))
+		for i, catch := range catches {
+			catchMap, err := c.convertCatchBlock(catch)
+			if err != nil {
+				return nil, fmt.Errorf("catches[%d]: %w", i, err)
+			}
+			if len(catch.ErrorTypes) > 0 {
+				catchMap["errorTypes"] = catch.ErrorTypes
+			}
+			if len(catch.StatusCodes) > 0 {
+				codes := make([]int, len(catch.StatusCodes))
+				for j, code := range catch.StatusCodes {
+					codes[j] = int(code)
+				}
+				catchMap["statusCodes"] = codes
+			}
+			catchList = append(catchList, catchMap)
+		}
+		tryMetadata[metadata.MetadataTryCatches] = catchList
+	}
 
-	// Add catch block if present (single block, not array)
-	if cfg.Catch != nil {
-		catchMap := map[string]interface{}{}
-		if cfg.Catch.As != "" {
-			catchMap["as"] = cfg.Catch.As
+	// The DSL catch retries are matched against DSL error filters rather than
+	// the runner's error types and classifications, so the retry policy is
+	// passed through task metadata too
+	if retry := cfg.GetRetry(); retry != nil {
+		retryMap := map[string]interface{}{
+			"errorTypes": retry.GetErrorTypes(),
+			"maxRetries": int(retry.GetMaxRetries()),
+		}
+		if retry.GetDelaySeconds() > 0 {
+			retryMap["delaySeconds"] = int(retry.GetDelaySeconds())
 		}
-		// Note: cfg.Catch.Do is []*WorkflowTask - would need recursive conversion
-		tryMap["catch"] = catchMap
+		tryMetadata[metadata.MetadataTryRetry] = retryMap
 	}
 
-	return map[string]interface{}{
-		"try": tryMap,
+	if len(tryMetadata) > 0 {
+		tryTask["metadata"] = tryMetadata
+
+		// The DSL requires a catch; without tasks, it lets the errors no
+		// filtered catch block matched propagate
+		if _, ok := tryTask["catch"]; !ok {
+			tryTask["catch"] = map[string]interface{}{}
+		}
+	}
+
+	return tryTask, nil
+}
+
+// convertCatchBlock converts the error variable and tasks of a CatchBlock.
+func (c *Converter) convertCatchBlock(catch *tasksv1.CatchBlock) (map[string]interface{}, error) {
+	doTasks, err := c.convertTaskList(catch.GetDo())
+	if err != nil {
+		return nil, err
+	}
+	catchMap := map[string]interface{}{
+		"do": doTasks,
+	}
+	if catch.As != "" {
+		catchMap["as"] = catch.As
+	}
+	return catchMap, nil
+}
+
+// isFilteredCatch reports whether a catch block only handles some errors.
+func isFilteredCatch(catch *tasksv1.CatchBlock) bool {
+	return len(catch.GetErrorTypes()) > 0 || len(catch.GetStatusCodes()) > 0
+}
+
+// convertTaskList converts nested tasks, such as those of a try block.
+func (c *Converter) convertTaskList(tasks []*workflowv1.WorkflowTask) ([]map[string]interface{}, error) {
+	converted := make([]map[string]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		yamlTask, err := c.convertTask(task)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert task '%s': %w", task.Name, err)
+		}
+		converted = append(converted, yamlTask)
 	}
+	return converted, nil
 }
 
 // convertListenTask converts ListenTaskConfig to YAML structure
 // Note: Listen tasks have a nested ListenTo structure
 func (c *Converter) convertListenTask(cfg *tasksv1.ListenTaskConfig) map[string]interface{} {
-	// Note: cfg.To is *ListenTo - would need to be converted based on its structure
-	// For now, this is handled by the existing generic converter logic
-	return map[string]interface{}{
-		"listen": map[string]interface{}{},
+	filters := make([]interface{}, 0, len(cfg.GetTo().GetSignals()))
+	for _, signal := range cfg.GetTo().GetSignals() {
+		with := map[string]interface{}{
+			"id":   signal.Id,
+			"type": signal.Type,
+		}
+		// Signals for which acceptIf is false are ignored by the runner
+		if signal.AcceptIf != "" {
+			with["acceptIf"] = signal.AcceptIf
+		}
+		filters = append(filters, map[string]interface{}{
+			"with": with,
+		})
+	}
+
+	// "all" waits for every signal; a single signal is a "one"; otherwise
+	// the first signal received completes the task
+	to := map[string]interface{}{}
+	switch {
+	case cfg.GetTo().GetMode() == "all":
+		to["all"] = filters
+	case len(filters) == 1:
+		to["one"] = filters[0]
+	default:
+		to["any"] = filters
+	}
+
+	listenTask := map[string]interface{}{
+		"listen": map[string]interface{}{
+			"to": to,
+		},
+	}
+
+	// The DSL listen task has no timeout or approval settings, so they are
+	// passed to the runner through task metadata
+	meta := map[string]interface{}{}
+	if cfg.TimeoutSeconds > 0 {
+		meta[metadata.MetadataListenTimeout] = (time.Duration(cfg.TimeoutSeconds) * time.Second).String()
+	}
+	if cfg.Approval != nil {
+		approval := map[string]interface{}{}
+		if len(cfg.Approval.Approvers) > 0 {
+			approvers := make([]interface{}, 0, len(cfg.Approval.Approvers))
+			for _, approver := range cfg.Approval.Approvers {
+				approvers = append(approvers, approver)
+			}
+			approval["approvers"] = approvers
+		}
+		if cfg.Approval.TimeoutSeconds > 0 {
+			approval["timeout"] = (time.Duration(cfg.Approval.TimeoutSeconds) * time.Second).String()
+		}
+		if cfg.Approval.OnTimeout != "" {
+			approval["onTimeout"] = cfg.Approval.OnTimeout
+		}
+		meta[metadata.MetadataApproval] = approval
 	}
+	if len(meta) > 0 {
+		listenTask["metadata"] = meta
+	}
+
+	return listenTask
 }
 
 // convertWaitTask converts WaitTaskConfig to YAML structure
@@ -188,19 +485,25 @@ func (c *Converter) convertWaitTask(cfg *tasksv1.WaitTaskConfig) map[string]inte
 	}
 }
 
-// convertRaiseTask converts RaiseTaskConfig to YAML structure
+// convertRaiseTask converts RaiseTaskConfig to YAML structure.
+// The error name is not a URI, so it is carried as the title of an error of
+// type metadata.RaiseErrorType, with the message as its detail.
 func (c *Converter) convertRaiseTask(cfg *tasksv1.RaiseTaskConfig) map[string]interface{} {
-	raiseMap := map[string]interface{}{
-		"error": cfg.Error,
+	definition := map[string]interface{}{
+		"type":   metadata.RaiseErrorType,
+		"status": 500,
+		"title":  cfg.Error,
 	}
 
 	// Add optional message
 	if cfg.Message != "" {
-		raiseMap["message"] = cfg.Message
+		definition["detail"] = cfg.Message
 	}
 
 	return map[string]interface{}{
-		"raise": raiseMap,
+		"raise": map[string]interface{}{
+			"error": definition,
+		},
 	}
 }
 
@@ -220,6 +523,34 @@ func (c *Converter) convertRunTask(cfg *tasksv1.RunTaskConfig) map[string]interf
 	}
 }
 
+// convertCallActivityTask converts CallActivityTaskConfig to YAML structure.
+// The input is passed to the activity as its single argument.
+func (c *Converter) convertCallActivityTask(cfg *tasksv1.CallActivityTaskConfig) map[string]interface{} {
+	with := map[string]interface{}{
+		"name": cfg.Activity,
+	}
+
+	// Add optional input
+	if cfg.Input != nil && len(cfg.Input.AsMap()) > 0 {
+		with["arguments"] = []interface{}{cfg.Input.AsMap()}
+	}
+
+	// Add optional task queue (defaults to the runner's execution queue)
+	if cfg.TaskQueue != "" {
+		with["taskQueue"] = cfg.TaskQueue
+	}
+
+	// Add optional timeout
+	if cfg.TimeoutSeconds > 0 {
+		with["timeoutSeconds"] = cfg.TimeoutSeconds
+	}
+
+	return map[string]interface{}{
+		"call": "activity",
+		"with": with,
+	}
+}
+
 // convertAgentCallTask converts AgentCallTaskConfig to YAML structure
 func (c *Converter) convertAgentCallTask(cfg *tasksv1.AgentCallTaskConfig) map[string]interface{} {
 	with := map[string]interface{}{
@@ -232,6 +563,14 @@ func (c *Converter) convertAgentCallTask(cfg *tasksv1.AgentCallTaskConfig) map[s
 		with["scope"] = cfg.Scope.String()
 	}
 
+	// Add owning org and version for agents deployed by another project
+	if cfg.Org != "" {
+		with["org"] = cfg.Org
+	}
+	if cfg.Version != "" {
+		with["version"] = cfg.Version
+	}
+
 	// Add env variables if present
 	if len(cfg.Env) > 0 {
 		with["env"] = cfg.Env
@@ -249,11 +588,33 @@ func (c *Converter) convertAgentCallTask(cfg *tasksv1.AgentCallTaskConfig) map[s
 		if cfg.Config.Temperature != 0 {
 			config["temperature"] = cfg.Config.Temperature
 		}
+		if cfg.Config.OutputSchema != nil {
+			config["outputSchema"] = cfg.Config.OutputSchema.AsMap()
+		}
 		if len(config) > 0 {
 			with["config"] = config
 		}
 	}
 
+	// Add attachments if present (content expressions are evaluated by the runner)
+	if len(cfg.Attachments) > 0 {
+		attachments := make([]interface{}, 0, len(cfg.Attachments))
+		for _, a := range cfg.Attachments {
+			attachment := map[string]interface{}{
+				"name":    a.Name,
+				"content": a.Content,
+			}
+			if a.MimeType != "" {
+				attachment["mimeType"] = a.MimeType
+			}
+			if a.MaxSizeBytes > 0 {
+				attachment["maxSizeBytes"] = a.MaxSizeBytes
+			}
+			attachments = append(attachments, attachment)
+		}
+		with["attachments"] = attachments
+	}
+
 	return map[string]interface{}{
 		"call": "agent",
 		"with": with,
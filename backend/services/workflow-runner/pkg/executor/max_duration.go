@@ -0,0 +1,64 @@
+/*
+ * Copyright 2026 Leftbin/Stigmer
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"time"
+
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/tasks"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// runWithMaxDuration runs the tasks of the workflow, stopping them when they
+// run past maxDuration (if not 0).
+//
+// The tasks run on a child context, cancelled when a timer for the maximum
+// duration fires; the workflow then fails with utils.NewWorkflowTimeoutError.
+// The workflow context itself is not cancelled, so the on-failure tasks still
+// run afterwards.
+func runWithMaxDuration(
+	ctx workflow.Context, maxDuration time.Duration, run tasks.TemporalWorkflowFunc, input any, state *utils.State,
+) (any, error) {
+	if maxDuration <= 0 {
+		return run(ctx, input, state)
+	}
+
+	tasksCtx, cancel := workflow.WithCancel(ctx)
+	defer cancel()
+
+	timedOut := false
+	workflow.Go(tasksCtx, func(timerCtx workflow.Context) {
+		if err := workflow.NewTimer(timerCtx, maxDuration).Get(timerCtx, nil); err == nil {
+			timedOut = true
+			cancel()
+		}
+	})
+
+	result, err := run(tasksCtx, input, state)
+	if timedOut {
+		workflow.GetLogger(ctx).Error("Workflow exceeded its maximum duration", "maxDuration", maxDuration, "error", err)
+		// The cancellation the tasks were stopped with is not the cause:
+		// the workflow was not cancelled
+		if temporal.IsCanceledError(err) {
+			err = nil
+		}
+		return nil, utils.NewWorkflowTimeoutError(maxDuration, err)
+	}
+	return result, err
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2026 Leftbin/Stigmer
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"time"
+
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/tasks"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// runOnFailure runs the on-failure tasks of the workflow after its tasks
+// failed with err, and returns the error the workflow fails with.
+//
+// The tasks read the failure as $data.error. Whether they succeed or not, the
+// workflow fails with the original error, wrapped with its description so the
+// execution status reports the original failed task rather than a failed
+// on-failure task. They are not run for continue-as-new.
+//
+// They also run when the workflow was cancelled, on a disconnected context so
+// their activities can still be scheduled; $data.error.type is then
+// utils.CancelledErrorType. The cancellation is returned unwrapped so the
+// execution still ends cancelled rather than failed.
+func runOnFailure(
+	ctx workflow.Context, onFailure tasks.TemporalWorkflowFunc, input any, state *utils.State, err error,
+) error {
+	if onFailure == nil || workflow.IsContinueAsNewError(err) {
+		return err
+	}
+	cancelled := temporal.IsCanceledError(err) || ctx.Err() != nil
+	if cancelled {
+		ctx, _ = workflow.NewDisconnectedContext(ctx)
+	}
+	logger := workflow.GetLogger(ctx)
+
+	var failedTask string
+	if task, ok := state.Data["task"].(map[string]any); ok {
+		failedTask, _ = task["name"].(string)
+	}
+	failure := utils.NewWorkflowFailure(err, failedTask)
+	if cancelled {
+		failure.Type = utils.CancelledErrorType
+		failure.Classification = workflowexecutionv1.ErrorClassification_CANCELLED.String()
+	}
+
+	state.AddData(map[string]any{
+		"error": map[string]any{
+			"message":        failure.Message,
+			"type":           failure.Type,
+			"classification": failure.Classification,
+			"task":           failure.Task,
+			"timestamp":      workflow.Now(ctx).UTC().Format(time.RFC3339),
+		},
+	})
+
+	logger.Info("Running on-failure tasks", "failedTask", failedTask, "error", err)
+	if _, handlerErr := onFailure(ctx, input, state); handlerErr != nil {
+		logger.Error("On-failure tasks failed", "error", handlerErr)
+	}
+
+	if cancelled {
+		return err
+	}
+
+	return utils.NewWorkflowFailureError(err, failure)
+}
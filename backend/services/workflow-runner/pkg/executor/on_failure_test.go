@@ -0,0 +1,182 @@
+/*
+ * Copyright 2026 Leftbin/Stigmer
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/types"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/tasks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+// onFailureWorkflowYAML notifies the server at %[1]s from its on-failure
+// tasks. %[2]s is an extra on-failure task and %[3]s an extra task.
+const onFailureWorkflowYAML = `
+document:
+  dsl: '1.0.0'
+  namespace: test
+  name: on-failure-test
+  version: '1.0.0'
+  metadata:
+    onFailure:
+      - notify:
+          call: http
+          with:
+            method: post
+            endpoint: %[1]s
+            body:
+              task: ${ $data.error.task }
+              message: ${ $data.error.message }
+              type: ${ $data.error.type }
+              classification: ${ $data.error.classification }
+%[2]s
+do:
+  - prepare:
+      set:
+        ready: true
+%[3]s
+`
+
+// chargeTask is a task that fails.
+const chargeTask = `  - charge:
+      raise:
+        error:
+          type: https://stigmer.ai/errors/raise
+          status: 500
+          title: PaymentDeclined
+          detail: card declined`
+
+// failingOnFailureTask is an on-failure task that fails itself.
+const failingOnFailureTask = `      - alsoFail:
+          raise:
+            error:
+              type: https://stigmer.ai/errors/raise
+              status: 500
+              title: NotifyFailed
+              detail: notification failed`
+
+// waitTask is a task that waits long enough for the workflow to be cancelled.
+const waitTask = `  - hold:
+      wait:
+        minutes: 5`
+
+// runOnFailureWorkflow runs the workflow, after configuring the test
+// environment with setup if any.
+func runOnFailureWorkflow(
+	t *testing.T, extraOnFailureTask, extraTask string, setup ...func(*testsuite.TestWorkflowEnvironment),
+) (notified []map[string]any, err error) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		notified = append(notified, body)
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(ExecuteServerlessWorkflow)
+	env.RegisterActivity(&tasks.CallHTTPActivities{})
+	for _, f := range setup {
+		f(env)
+	}
+
+	env.ExecuteWorkflow(ExecuteServerlessWorkflow, &types.TemporalWorkflowInput{
+		WorkflowExecutionID: "wfx-test",
+		WorkflowYaml:        fmt.Sprintf(onFailureWorkflowYAML, server.URL, extraOnFailureTask, extraTask),
+	})
+	require.True(t, env.IsWorkflowCompleted())
+	return notified, env.GetWorkflowError()
+}
+
+func TestOnFailureRunsAfterFailure(t *testing.T) {
+	notified, err := runOnFailureWorkflow(t, "", chargeTask)
+	require.Error(t, err)
+
+	assert.Equal(t, []map[string]any{{
+		"task":           "charge",
+		"message":        "card declined",
+		"type":           "PaymentDeclined",
+		"classification": "USER_ERROR",
+	}}, notified)
+
+	failure, ok := utils.WorkflowFailureFromError(err)
+	require.True(t, ok)
+	assert.Equal(t, "charge", failure.Task)
+	assert.Equal(t, "USER_ERROR", failure.Classification)
+}
+
+func TestOnFailureSkippedOnSuccess(t *testing.T) {
+	notified, err := runOnFailureWorkflow(t, "", "")
+	require.NoError(t, err)
+	assert.Empty(t, notified)
+}
+
+func TestOnFailureKeepsOriginalError(t *testing.T) {
+	notified, err := runOnFailureWorkflow(t, failingOnFailureTask, chargeTask)
+	require.Error(t, err)
+	assert.Len(t, notified, 1)
+
+	// The failed on-failure task does not replace the original failure
+	assert.ErrorContains(t, err, "card declined")
+	assert.NotContains(t, err.Error(), "notification failed")
+	failure, ok := utils.WorkflowFailureFromError(err)
+	require.True(t, ok)
+	assert.Equal(t, "charge", failure.Task)
+	assert.Equal(t, "PaymentDeclined", failure.Type)
+}
+
+func TestOnFailureRunsAfterCancellation(t *testing.T) {
+	notified, err := runOnFailureWorkflow(t, "", waitTask, func(env *testsuite.TestWorkflowEnvironment) {
+		env.RegisterDelayedCallback(env.CancelWorkflow, time.Minute)
+	})
+	require.Error(t, err)
+	assert.True(t, temporal.IsCanceledError(err), "the execution ends cancelled, not failed: %v", err)
+
+	require.Len(t, notified, 1)
+	assert.Equal(t, utils.CancelledErrorType, notified[0]["type"])
+	assert.Equal(t, "CANCELLED", notified[0]["classification"])
+}
+
+// maxDurationMetadata sets a maximum duration of one minute on the workflow,
+// as a document metadata entry following the on-failure tasks.
+const maxDurationMetadata = `    maxDurationSeconds: 60`
+
+func TestOnFailureRunsAfterMaxDuration(t *testing.T) {
+	notified, err := runOnFailureWorkflow(t, maxDurationMetadata, waitTask)
+	require.Error(t, err)
+	assert.False(t, temporal.IsCanceledError(err), "the execution ends failed, not cancelled: %v", err)
+
+	require.Len(t, notified, 1)
+	assert.Equal(t, utils.WorkflowTimeoutErrorType, notified[0]["type"])
+	assert.Equal(t, "WORKFLOW_TIMEOUT", notified[0]["classification"])
+
+	failure, ok := utils.WorkflowFailureFromError(err)
+	require.True(t, ok)
+	assert.Equal(t, "hold", failure.Task)
+	assert.Equal(t, "WORKFLOW_TIMEOUT", failure.Classification)
+}
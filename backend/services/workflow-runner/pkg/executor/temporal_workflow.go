@@ -24,6 +24,7 @@ import (
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/types"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/tasks"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"go.temporal.io/sdk/log"
@@ -154,6 +155,28 @@ func ExecuteServerlessWorkflow(ctx workflow.Context, input *types.TemporalWorkfl
 		return nil, fmt.Errorf("failed to build workflow: %w", err)
 	}
 
+	// Build the tasks run when the workflow fails, if any
+	onFailureBuilder, err := tasks.NewOnFailureTaskBuilder(nil, workflowDef, tasks.DoTaskOpts{
+		Envvars: envVars,
+	})
+	if err != nil {
+		logger.Error("Failed to create on-failure task builder", "error", err)
+		return nil, fmt.Errorf("failed to create on-failure task builder: %w", err)
+	}
+	var onFailureFunc tasks.TemporalWorkflowFunc
+	if onFailureBuilder != nil {
+		if onFailureFunc, err = onFailureBuilder.Build(); err != nil {
+			logger.Error("Failed to build on-failure tasks", "error", err)
+			return nil, fmt.Errorf("failed to build on-failure tasks: %w", err)
+		}
+	}
+
+	maxDuration, err := metadata.GetMaxDuration(workflowDef)
+	if err != nil {
+		logger.Error("Invalid maximum duration", "error", err)
+		return nil, fmt.Errorf("invalid maximum duration: %w", err)
+	}
+
 	// Log execution starting
 	taskCount := 0
 	if workflowDef.Do != nil {
@@ -162,8 +185,9 @@ func ExecuteServerlessWorkflow(ctx workflow.Context, input *types.TemporalWorkfl
 	logger.Info("Starting workflow task execution", "task_count", taskCount)
 
 	// Execute workflow tasks
-	result, err := workflowFunc(ctx, input.InitialData, state)
+	result, err := runWithMaxDuration(ctx, maxDuration, workflowFunc, input.InitialData, state)
 	if err != nil {
+		err = runOnFailure(ctx, onFailureFunc, input.InitialData, state, err)
 		logger.Error("Workflow execution failed", "error", err)
 		return nil, fmt.Errorf("workflow execution failed: %w", err)
 	}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2026 Leftbin/Stigmer
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interceptors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/metrics"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/types"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/workflow"
+)
+
+// workflowNameHeader carries the workflow name from the workflow to its
+// activities, which cannot see the workflow input.
+const workflowNameHeader = "stigmer-workflow-name"
+
+// MetricsInterceptor records execution metrics of Zigflow activities
+// (duration, retries, HTTP status class and payload sizes) to a metrics.Sink.
+//
+// The workflow side stamps the workflow name on every scheduled activity so
+// metrics can be labeled per workflow. Task names and payloads are never used
+// as labels.
+type MetricsInterceptor struct {
+	interceptor.WorkerInterceptorBase
+	sink metrics.Sink
+}
+
+// NewMetricsInterceptor creates a metrics interceptor writing to sink.
+func NewMetricsInterceptor(sink metrics.Sink) *MetricsInterceptor {
+	return &MetricsInterceptor{sink: sink}
+}
+
+// InterceptWorkflow hooks into workflows to pass the workflow name to activities.
+func (i *MetricsInterceptor) InterceptWorkflow(
+	ctx workflow.Context,
+	next interceptor.WorkflowInboundInterceptor,
+) interceptor.WorkflowInboundInterceptor {
+	return &metricsWorkflowInbound{
+		WorkflowInboundInterceptorBase: interceptor.WorkflowInboundInterceptorBase{
+			Next: next,
+		},
+	}
+}
+
+// InterceptActivity hooks into activity execution to record its metrics.
+func (i *MetricsInterceptor) InterceptActivity(
+	ctx context.Context,
+	next interceptor.ActivityInboundInterceptor,
+) interceptor.ActivityInboundInterceptor {
+	return &metricsActivityInbound{
+		ActivityInboundInterceptorBase: interceptor.ActivityInboundInterceptorBase{
+			Next: next,
+		},
+		sink: i.sink,
+	}
+}
+
+// metricsWorkflowInbound remembers the name of the running workflow.
+type metricsWorkflowInbound struct {
+	interceptor.WorkflowInboundInterceptorBase
+	workflowName string
+}
+
+func (w *metricsWorkflowInbound) Init(outbound interceptor.WorkflowOutboundInterceptor) error {
+	return w.Next.Init(&metricsWorkflowOutbound{
+		WorkflowOutboundInterceptorBase: interceptor.WorkflowOutboundInterceptorBase{
+			Next: outbound,
+		},
+		root: w,
+	})
+}
+
+func (w *metricsWorkflowInbound) ExecuteWorkflow(
+	ctx workflow.Context,
+	in *interceptor.ExecuteWorkflowInput,
+) (interface{}, error) {
+	if len(in.Args) > 0 {
+		if input, ok := in.Args[0].(*types.TemporalWorkflowInput); ok && input.Metadata != nil {
+			w.workflowName = input.Metadata.Name
+		}
+	}
+	return w.Next.ExecuteWorkflow(ctx, in)
+}
+
+// metricsWorkflowOutbound writes the workflow name to activity headers.
+type metricsWorkflowOutbound struct {
+	interceptor.WorkflowOutboundInterceptorBase
+	root *metricsWorkflowInbound
+}
+
+func (w *metricsWorkflowOutbound) ExecuteActivity(
+	ctx workflow.Context,
+	activityType string,
+	args ...interface{},
+) workflow.Future {
+	if w.root.workflowName != "" {
+		if payload, err := converter.GetDefaultDataConverter().ToPayload(w.root.workflowName); err == nil {
+			interceptor.WorkflowHeader(ctx)[workflowNameHeader] = payload
+		}
+	}
+	return w.Next.ExecuteActivity(ctx, activityType, args...)
+}
+
+// metricsActivityInbound records the metrics of each activity attempt.
+type metricsActivityInbound struct {
+	interceptor.ActivityInboundInterceptorBase
+	sink metrics.Sink
+}
+
+func (a *metricsActivityInbound) ExecuteActivity(
+	ctx context.Context,
+	in *interceptor.ExecuteActivityInput,
+) (interface{}, error) {
+	info := activity.GetInfo(ctx)
+
+	// Internal activities are not user tasks
+	if shouldSkipProgressReporting(info.ActivityType.Name) {
+		return a.Next.ExecuteActivity(ctx, in)
+	}
+
+	// Reuse the collector of the progress interceptor when it runs first
+	taskMetadata, ok := utils.TaskMetadataFromContext(ctx)
+	if !ok {
+		ctx, taskMetadata = utils.WithTaskMetadata(ctx)
+	}
+
+	start := time.Now()
+	result, err := a.Next.ExecuteActivity(ctx, in)
+
+	// Activities completed asynchronously have no meaningful duration here
+	if errors.Is(err, activity.ErrResultPending) {
+		return result, err
+	}
+
+	httpStatus, _ := taskMetadata.Values()[utils.TaskMetadataHTTPStatus].(int)
+	metrics.RecordTask(a.sink, metrics.TaskObservation{
+		Workflow:    workflowNameFromHeader(ctx),
+		TaskKind:    info.ActivityType.Name,
+		Attempt:     info.Attempt,
+		Duration:    time.Since(start),
+		Failed:      err != nil,
+		HTTPStatus:  httpStatus,
+		InputBytes:  encodedSize(in.Args),
+		OutputBytes: encodedSize(result),
+	})
+
+	return result, err
+}
+
+// workflowNameFromHeader returns the workflow name stamped on the activity, or "".
+func workflowNameFromHeader(ctx context.Context) string {
+	payload, ok := interceptor.Header(ctx)[workflowNameHeader]
+	if !ok {
+		return ""
+	}
+	var name string
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &name); err != nil {
+		return ""
+	}
+	return name
+}
+
+// encodedSize returns the JSON-encoded size of v, or 0 if it cannot be encoded.
+func encodedSize(v any) int {
+	if v == nil {
+		return 0
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2026 Leftbin/Stigmer
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/metrics"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/types"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// counterValue returns the value of the named counter for the series
+// matching labels, or 0 if there is none.
+func counterValue(t *testing.T, sink *metrics.PrometheusSink, name string, labels metrics.Labels) float64 {
+	t.Helper()
+
+	families, err := sink.Registry().Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			matched := 0
+			for _, label := range m.GetLabel() {
+				if labels[label.GetName()] == label.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestMetricsInterceptor_Activity(t *testing.T) {
+	sink := metrics.NewPrometheusSink()
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{NewMetricsInterceptor(sink)},
+	})
+	env.RegisterActivityWithOptions(func(ctx context.Context, body string) (string, error) {
+		utils.RecordTaskMetadata(ctx, utils.TaskMetadataHTTPStatus, 200)
+		return "ok", nil
+	}, activity.RegisterOptions{Name: "CallHTTPActivity"})
+	env.RegisterActivityWithOptions(func(ctx context.Context) error {
+		utils.RecordTaskMetadata(ctx, utils.TaskMetadataHTTPStatus, 503)
+		return errors.New("service unavailable")
+	}, activity.RegisterOptions{Name: "CallFailingHTTPActivity"})
+
+	_, err := env.ExecuteActivity("CallHTTPActivity", "payload")
+	require.NoError(t, err)
+	_, err = env.ExecuteActivity("CallHTTPActivity", "payload")
+	require.NoError(t, err)
+	_, err = env.ExecuteActivity("CallFailingHTTPActivity")
+	require.Error(t, err)
+
+	assert.Equal(t, 2.0, counterValue(t, sink, metrics.TaskExecutionsTotal, metrics.Labels{
+		metrics.LabelTaskKind:        "CallHTTPActivity",
+		metrics.LabelStatus:          metrics.StatusCompleted,
+		metrics.LabelHTTPStatusClass: "2xx",
+	}))
+	assert.Equal(t, 1.0, counterValue(t, sink, metrics.TaskExecutionsTotal, metrics.Labels{
+		metrics.LabelTaskKind:        "CallFailingHTTPActivity",
+		metrics.LabelStatus:          metrics.StatusFailed,
+		metrics.LabelHTTPStatusClass: "5xx",
+	}))
+	assert.Equal(t, 2, testutil.CollectAndCount(sink.Registry(), metrics.TaskDurationSeconds))
+}
+
+func TestMetricsInterceptor_Workflow(t *testing.T) {
+	sink := metrics.NewPrometheusSink()
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{NewMetricsInterceptor(sink)},
+	})
+
+	attempts := 0
+	env.RegisterActivityWithOptions(func(ctx context.Context) (string, error) {
+		attempts++
+		if attempts == 1 {
+			return "", errors.New("transient failure")
+		}
+		return "done", nil
+	}, activity.RegisterOptions{Name: "CallFunctionActivity"})
+	env.RegisterWorkflowWithOptions(func(ctx workflow.Context, input *types.TemporalWorkflowInput) (string, error) {
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout: time.Minute,
+			RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 2},
+		})
+		var res string
+		err := workflow.ExecuteActivity(ctx, "CallFunctionActivity").Get(ctx, &res)
+		return res, err
+	}, workflow.RegisterOptions{Name: "ExecuteServerlessWorkflow"})
+
+	env.ExecuteWorkflow("ExecuteServerlessWorkflow", &types.TemporalWorkflowInput{
+		Metadata: &types.WorkflowMetadata{Name: "daily-report"},
+	})
+	require.NoError(t, env.GetWorkflowError())
+
+	// Both attempts are labeled with the workflow name from the input
+	assert.Equal(t, 1.0, counterValue(t, sink, metrics.TaskExecutionsTotal, metrics.Labels{
+		metrics.LabelWorkflow: "daily-report",
+		metrics.LabelStatus:   metrics.StatusFailed,
+	}))
+	assert.Equal(t, 1.0, counterValue(t, sink, metrics.TaskExecutionsTotal, metrics.Labels{
+		metrics.LabelWorkflow: "daily-report",
+		metrics.LabelStatus:   metrics.StatusCompleted,
+	}))
+	assert.Equal(t, 1.0, counterValue(t, sink, metrics.TaskRetriesTotal, metrics.Labels{
+		metrics.LabelWorkflow: "daily-report",
+		metrics.LabelTaskKind: "CallFunctionActivity",
+	}))
+}
@@ -18,6 +18,7 @@ package interceptors
 
 import (
 	"context"
+	"errors"
 
 	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/config"
@@ -27,6 +28,7 @@ import (
 	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/interceptor"
 	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // ProgressReportingInterceptor intercepts Zigflow activity executions to report
@@ -98,16 +100,21 @@ func (a *activityInterceptor) ExecuteActivity(
 	taskName := extractTaskName(activityInfo)
 
 	// Report task started
-	a.reportTaskProgress(ctx, executionID, taskName, "started", nil)
+	a.reportTaskProgress(ctx, executionID, taskName, activityInfo.Attempt, "started", nil, nil)
 
-	// Execute the actual activity
+	// Execute the actual activity, collecting the metadata it records
+	ctx, taskMetadata := utils.WithTaskMetadata(ctx)
 	result, err := a.Next.ExecuteActivity(ctx, in)
 
-	// Report task completed or failed
+	// Report task completed or failed. Activities completed asynchronously
+	// report their own status while pending.
+	if errors.Is(err, activity.ErrResultPending) {
+		return result, err
+	}
 	if err != nil {
-		a.reportTaskProgress(ctx, executionID, taskName, "failed", err)
+		a.reportTaskProgress(ctx, executionID, taskName, activityInfo.Attempt, "failed", err, taskMetadata.Values())
 	} else {
-		a.reportTaskProgress(ctx, executionID, taskName, "completed", nil)
+		a.reportTaskProgress(ctx, executionID, taskName, activityInfo.Attempt, "completed", nil, taskMetadata.Values())
 	}
 
 	return result, err
@@ -118,8 +125,10 @@ func (a *activityInterceptor) reportTaskProgress(
 	ctx context.Context,
 	executionID string,
 	taskName string,
+	attempt int32,
 	status string,
 	err error,
+	metadata map[string]any,
 ) {
 	// Create gRPC client
 	client, clientErr := grpc_client.NewWorkflowExecutionClient(a.stigmerConfig)
@@ -147,10 +156,20 @@ func (a *activityInterceptor) reportTaskProgress(
 		TaskName: taskName,
 		TaskType: workflowexecutionv1.WorkflowTaskType_WORKFLOW_TASK_CUSTOM,
 		Status:   taskStatus,
+		Attempt:  attempt,
 	}
 
 	if err != nil {
 		task.Error = err.Error()
+		httpStatus, _ := metadata[utils.TaskMetadataHTTPStatus].(int)
+		task.ErrorClassification = utils.ClassifyTaskError(err, httpStatus)
+	}
+	if len(metadata) > 0 {
+		if md, mdErr := structpb.NewStruct(metadata); mdErr == nil {
+			task.Metadata = md
+		} else {
+			log.Warn().Err(mdErr).Str("task_name", taskName).Msg("Failed to convert task metadata")
+		}
 	}
 
 	// Send update
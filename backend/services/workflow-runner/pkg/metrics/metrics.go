@@ -0,0 +1,177 @@
+/*
+ * Copyright 2026 Leftbin/Stigmer
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics records task execution metrics of the workflow runner.
+//
+// Metrics are written to a Sink. The default implementation is PrometheusSink,
+// which exposes them on /metrics. Labels carry only runner-defined values
+// (workflow name, activity type, status) and never task input or output.
+package metrics
+
+import (
+	"fmt"
+	"time"
+)
+
+// Metric names recorded by RecordTask.
+const (
+	// TaskExecutionsTotal counts finished task attempts.
+	TaskExecutionsTotal = "stigmer_workflow_task_executions_total"
+
+	// TaskRetriesTotal counts task attempts after the first.
+	TaskRetriesTotal = "stigmer_workflow_task_retries_total"
+
+	// TaskDurationSeconds observes the duration of task attempts.
+	TaskDurationSeconds = "stigmer_workflow_task_duration_seconds"
+
+	// TaskPayloadBytes observes the encoded size of task input and output.
+	TaskPayloadBytes = "stigmer_workflow_task_payload_bytes"
+)
+
+// Label names used by the task metrics.
+const (
+	LabelWorkflow        = "workflow"
+	LabelTaskKind        = "task_kind"
+	LabelStatus          = "status"
+	LabelHTTPStatusClass = "http_status_class"
+	LabelDirection       = "direction"
+)
+
+// Labels are metric label values keyed by label name.
+type Labels map[string]string
+
+// Sink receives counters and histogram observations.
+// Implementations must be safe for concurrent use.
+type Sink interface {
+	// IncCounter increments the named counter by one.
+	IncCounter(name string, labels Labels)
+
+	// ObserveHistogram records a value in the named histogram.
+	ObserveHistogram(name string, value float64, labels Labels)
+}
+
+// NopSink discards all metrics.
+type NopSink struct{}
+
+func (NopSink) IncCounter(string, Labels)                {}
+func (NopSink) ObserveHistogram(string, float64, Labels) {}
+
+// definition describes a metric known to the runner.
+type definition struct {
+	help    string
+	labels  []string
+	buckets []float64 // nil for counters
+}
+
+// definitions lists every metric recorded by RecordTask with its label names.
+var definitions = map[string]definition{
+	TaskExecutionsTotal: {
+		help:   "Finished workflow task attempts.",
+		labels: []string{LabelWorkflow, LabelTaskKind, LabelStatus, LabelHTTPStatusClass},
+	},
+	TaskRetriesTotal: {
+		help:   "Workflow task attempts after the first.",
+		labels: []string{LabelWorkflow, LabelTaskKind},
+	},
+	TaskDurationSeconds: {
+		help:    "Duration of workflow task attempts in seconds.",
+		labels:  []string{LabelWorkflow, LabelTaskKind, LabelStatus},
+		buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300},
+	},
+	TaskPayloadBytes: {
+		help:    "Encoded size of workflow task input and output in bytes.",
+		labels:  []string{LabelWorkflow, LabelTaskKind, LabelDirection},
+		buckets: []float64{256, 1024, 4096, 16384, 65536, 262144, 1048576},
+	},
+}
+
+// Task status label values.
+const (
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+// TaskObservation describes one finished task attempt.
+type TaskObservation struct {
+	// Workflow is the name of the workflow document ("unknown" if empty).
+	Workflow string
+
+	// TaskKind is the activity type that ran the task, such as CallHTTPActivity.
+	TaskKind string
+
+	// Attempt is the 1-based attempt number; attempts after the first are retries.
+	Attempt int32
+
+	Duration time.Duration
+	Failed   bool
+
+	// HTTPStatus is the response status of HTTP calls, 0 for other tasks.
+	HTTPStatus int
+
+	InputBytes  int
+	OutputBytes int
+}
+
+// RecordTask writes the metrics of a finished task attempt to sink.
+func RecordTask(sink Sink, obs TaskObservation) {
+	workflow := obs.Workflow
+	if workflow == "" {
+		workflow = "unknown"
+	}
+	status := StatusCompleted
+	if obs.Failed {
+		status = StatusFailed
+	}
+
+	sink.IncCounter(TaskExecutionsTotal, Labels{
+		LabelWorkflow:        workflow,
+		LabelTaskKind:        obs.TaskKind,
+		LabelStatus:          status,
+		LabelHTTPStatusClass: httpStatusClass(obs.HTTPStatus),
+	})
+	if obs.Attempt > 1 {
+		sink.IncCounter(TaskRetriesTotal, Labels{
+			LabelWorkflow: workflow,
+			LabelTaskKind: obs.TaskKind,
+		})
+	}
+	sink.ObserveHistogram(TaskDurationSeconds, obs.Duration.Seconds(), Labels{
+		LabelWorkflow: workflow,
+		LabelTaskKind: obs.TaskKind,
+		LabelStatus:   status,
+	})
+	sink.ObserveHistogram(TaskPayloadBytes, float64(obs.InputBytes), Labels{
+		LabelWorkflow:  workflow,
+		LabelTaskKind:  obs.TaskKind,
+		LabelDirection: "input",
+	})
+	if !obs.Failed {
+		sink.ObserveHistogram(TaskPayloadBytes, float64(obs.OutputBytes), Labels{
+			LabelWorkflow:  workflow,
+			LabelTaskKind:  obs.TaskKind,
+			LabelDirection: "output",
+		})
+	}
+}
+
+// httpStatusClass returns the status class label ("2xx", "5xx") of an HTTP
+// status, or "none" for tasks without one.
+func httpStatusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "none"
+	}
+	return fmt.Sprintf("%dxx", status/100)
+}
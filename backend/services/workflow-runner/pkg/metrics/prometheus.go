@@ -0,0 +1,108 @@
+/*
+ * Copyright 2026 Leftbin/Stigmer
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+)
+
+// PrometheusSink is a Sink backed by a Prometheus registry.
+// Metrics not known to the runner are dropped.
+type PrometheusSink struct {
+	registry   *prometheus.Registry
+	counters   map[string]*prometheus.CounterVec
+	histograms map[string]*prometheus.HistogramVec
+}
+
+// NewPrometheusSink creates a sink with every task metric registered on a new registry.
+func NewPrometheusSink() *PrometheusSink {
+	s := &PrometheusSink{
+		registry:   prometheus.NewRegistry(),
+		counters:   make(map[string]*prometheus.CounterVec),
+		histograms: make(map[string]*prometheus.HistogramVec),
+	}
+
+	for name, def := range definitions {
+		if def.buckets == nil {
+			counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: def.help}, def.labels)
+			s.registry.MustRegister(counter)
+			s.counters[name] = counter
+			continue
+		}
+		histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    name,
+			Help:    def.help,
+			Buckets: def.buckets,
+		}, def.labels)
+		s.registry.MustRegister(histogram)
+		s.histograms[name] = histogram
+	}
+
+	return s
+}
+
+// IncCounter implements Sink.
+func (s *PrometheusSink) IncCounter(name string, labels Labels) {
+	counter, ok := s.counters[name]
+	if !ok {
+		return
+	}
+	c, err := counter.GetMetricWith(prometheus.Labels(labels))
+	if err != nil {
+		log.Warn().Err(err).Str("metric", name).Msg("Dropping counter with invalid labels")
+		return
+	}
+	c.Inc()
+}
+
+// ObserveHistogram implements Sink.
+func (s *PrometheusSink) ObserveHistogram(name string, value float64, labels Labels) {
+	histogram, ok := s.histograms[name]
+	if !ok {
+		return
+	}
+	h, err := histogram.GetMetricWith(prometheus.Labels(labels))
+	if err != nil {
+		log.Warn().Err(err).Str("metric", name).Msg("Dropping observation with invalid labels")
+		return
+	}
+	h.Observe(value)
+}
+
+// Registry returns the registry holding the sink's metrics.
+func (s *PrometheusSink) Registry() *prometheus.Registry {
+	return s.registry
+}
+
+// NewServer returns an HTTP server exposing the metrics on /metrics at port.
+// The caller starts it with ListenAndServe and stops it with Shutdown.
+func (s *PrometheusSink) NewServer(port int) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{}))
+
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
@@ -0,0 +1,242 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"go.temporal.io/sdk/temporal"
+)
+
+// PolicyDeniedErrorType is the type of the application error a task fails
+// with when the runner's outbound host policy denies a connection.
+const PolicyDeniedErrorType = "PolicyDenied"
+
+// WorkflowTimeoutErrorType is the type of the application error a workflow
+// fails with when it runs past its maximum duration (WithMaxDuration in the
+// SDK).
+const WorkflowTimeoutErrorType = "WorkflowTimeout"
+
+// AgentLimitExceededErrorType is the type of the application error a CallAgent
+// task fails with when the agent execution was stopped by one of the agent's
+// limits (WithLimits in the SDK). stigmer-server fails the task's activity
+// with it through the callback token.
+const AgentLimitExceededErrorType = "AgentLimitExceeded"
+
+// NewAgentLimitExceededError returns the error a CallAgent task fails with
+// when the agent execution was stopped by one of its limits. message names
+// the exceeded limit.
+func NewAgentLimitExceededError(message string) error {
+	return temporal.NewNonRetryableApplicationError(message, AgentLimitExceededErrorType, nil)
+}
+
+// NewWorkflowTimeoutError returns the error a workflow fails with when it runs
+// past maxDuration. cause is the error its tasks were stopped with.
+func NewWorkflowTimeoutError(maxDuration time.Duration, cause error) error {
+	return temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("workflow exceeded its maximum duration of %s", maxDuration),
+		WorkflowTimeoutErrorType,
+		cause,
+	)
+}
+
+// ClassifyTaskError classifies the error of a failed task. httpStatus is the
+// response status the task recorded (TaskMetadataHTTPStatus), 0 if none.
+//
+// Responses with an error status are classified by status class, then
+// connections denied by the host policy, workflows stopped at their maximum
+// duration, agents stopped by their limits, cancellations and timeouts, then
+// non-retryable application errors as user errors. Everything else, such as
+// DNS or connection errors, is INFRA.
+func ClassifyTaskError(err error, httpStatus int) workflowexecutionv1.ErrorClassification {
+	switch {
+	case err == nil:
+		return workflowexecutionv1.ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED
+	case httpStatus >= 500 && httpStatus < 600:
+		return workflowexecutionv1.ErrorClassification_UPSTREAM_5XX
+	case httpStatus >= 400 && httpStatus < 500:
+		return workflowexecutionv1.ErrorClassification_UPSTREAM_4XX
+	}
+
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) && appErr.Type() == PolicyDeniedErrorType {
+		return workflowexecutionv1.ErrorClassification_POLICY_DENIED
+	}
+	if errors.As(err, &appErr) && appErr.Type() == WorkflowTimeoutErrorType {
+		return workflowexecutionv1.ErrorClassification_WORKFLOW_TIMEOUT
+	}
+	if errors.As(err, &appErr) && appErr.Type() == AgentLimitExceededErrorType {
+		return workflowexecutionv1.ErrorClassification_LIMIT_EXCEEDED
+	}
+
+	var canceledErr *temporal.CanceledError
+	if errors.As(err, &canceledErr) || errors.Is(err, context.Canceled) {
+		return workflowexecutionv1.ErrorClassification_CANCELLED
+	}
+
+	var timeoutErr *temporal.TimeoutError
+	var netErr net.Error
+	if errors.As(err, &timeoutErr) || errors.Is(err, context.DeadlineExceeded) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) {
+		return workflowexecutionv1.ErrorClassification_TIMEOUT
+	}
+
+	if errors.As(err, &netErr) {
+		return workflowexecutionv1.ErrorClassification_INFRA
+	}
+
+	if errors.As(err, &appErr) && appErr.NonRetryable() {
+		return workflowexecutionv1.ErrorClassification_USER_ERROR
+	}
+
+	return workflowexecutionv1.ErrorClassification_INFRA
+}
+
+// ClassifyWorkflowError classifies the error a workflow run failed with.
+//
+// Failures of activities are left unclassified: the progress interceptor
+// reported the failed task with its classification, which the controller
+// copies to the execution. Other failures, such as workflow timeouts and
+// errors raised by the workflow itself, are classified like task errors.
+func ClassifyWorkflowError(err error) workflowexecutionv1.ErrorClassification {
+	var activityErr *temporal.ActivityError
+	if errors.As(err, &activityErr) {
+		var canceledErr *temporal.CanceledError
+		if !errors.As(err, &canceledErr) {
+			return workflowexecutionv1.ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED
+		}
+	}
+	return ClassifyTaskError(err, 0)
+}
+
+// CancelledErrorType is the type of the failure on-failure tasks see when
+// the workflow was cancelled rather than failed.
+const CancelledErrorType = "Cancelled"
+
+// WorkflowFailureErrorType is the type of the application error a workflow
+// fails with after running its on-failure tasks. The error wraps the original
+// failure and carries its WorkflowFailure as details, so the execution status
+// reports the original failure even if an on-failure task failed too.
+const WorkflowFailureErrorType = "WorkflowFailure"
+
+// WorkflowFailure describes the error a workflow failed with. The on-failure
+// tasks of the workflow read it as $data.error, and the catch blocks of try
+// tasks match their filters against it.
+type WorkflowFailure struct {
+	Message        string `json:"message"`
+	Type           string `json:"type"`
+	Classification string `json:"classification"`
+	Task           string `json:"task"`
+	// Status is the response status of an HTTP call failing with an error
+	// status, 0 for other errors.
+	Status int `json:"status,omitempty"`
+}
+
+// NewWorkflowFailure describes the error a workflow failed with in task.
+// HTTP calls failing with an error status are classified by status class, and
+// cancellations have the type CancelledErrorType.
+func NewWorkflowFailure(err error, task string) WorkflowFailure {
+	failure := WorkflowFailure{
+		Message:        err.Error(),
+		Classification: ClassifyTaskError(err, httpStatusClass(err)).String(),
+		Task:           task,
+		Status:         HTTPStatusCode(err),
+	}
+	var appErr *temporal.ApplicationError
+	var canceledErr *temporal.CanceledError
+	switch {
+	case errors.As(err, &canceledErr):
+		failure.Message = "workflow execution cancelled"
+		failure.Type = CancelledErrorType
+	case errors.As(err, &appErr):
+		failure.Message = appErr.Message()
+		failure.Type = appErr.Type()
+	}
+	return failure
+}
+
+// NewWorkflowFailureError returns the error a workflow fails with after
+// running its on-failure tasks: err, with the failure as details.
+func NewWorkflowFailureError(err error, failure WorkflowFailure) error {
+	return temporal.NewApplicationErrorWithOptions("on-failure tasks ran", WorkflowFailureErrorType, temporal.ApplicationErrorOptions{
+		NonRetryable: true,
+		Cause:        err,
+		Details:      []any{failure},
+	})
+}
+
+// WorkflowFailureFromError returns the original failure carried by an error
+// returned by NewWorkflowFailureError, if err is one.
+func WorkflowFailureFromError(err error) (WorkflowFailure, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		appErr, ok := err.(*temporal.ApplicationError)
+		if !ok || appErr.Type() != WorkflowFailureErrorType || !appErr.HasDetails() {
+			continue
+		}
+		var failure WorkflowFailure
+		if appErr.Details(&failure) != nil {
+			return WorkflowFailure{}, false
+		}
+		return failure, true
+	}
+	return WorkflowFailure{}, false
+}
+
+// httpStatusPattern matches the status in the error message of an HTTP call
+// failing with an error status, such as "CallHTTP returned 4xx status code 429".
+var httpStatusPattern = regexp.MustCompile(`^CallHTTP returned \S+ status code (\d{3})\b`)
+
+// HTTPStatusCode returns the response status of an HTTP call failing with an
+// error status, 0 for other errors. Like the status class, it is only known
+// from the error message of the HTTP activity.
+func HTTPStatusCode(err error) int {
+	var appErr *temporal.ApplicationError
+	if !errors.As(err, &appErr) || appErr.Type() != "CallHTTP error" {
+		return 0
+	}
+	match := httpStatusPattern.FindStringSubmatch(appErr.Message())
+	if match == nil {
+		return 0
+	}
+	code, _ := strconv.Atoi(match[1])
+	return code
+}
+
+// httpStatusClass returns 400 or 500 for the errors of HTTP calls answered
+// with a 4xx or 5xx status, 0 otherwise. Within a workflow, the response
+// status is only known from the error message of the HTTP activity.
+func httpStatusClass(err error) int {
+	var appErr *temporal.ApplicationError
+	if !errors.As(err, &appErr) || appErr.Type() != "CallHTTP error" {
+		return 0
+	}
+	switch {
+	case strings.Contains(appErr.Message(), "4xx"):
+		return 400
+	case strings.Contains(appErr.Message(), "5xx"):
+		return 500
+	}
+	return 0
+}
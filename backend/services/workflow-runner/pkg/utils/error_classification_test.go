@@ -0,0 +1,207 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/temporal"
+)
+
+type netTimeoutError struct{ timeout bool }
+
+func (e netTimeoutError) Error() string   { return "dial tcp: i/o timeout" }
+func (e netTimeoutError) Timeout() bool   { return e.timeout }
+func (e netTimeoutError) Temporary() bool { return false }
+
+func TestClassifyTaskError(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Err        error
+		HTTPStatus int
+		Expected   workflowexecutionv1.ErrorClassification
+	}{
+		{
+			Name:     "no error",
+			Expected: workflowexecutionv1.ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED,
+		},
+		{
+			Name:       "http 5xx",
+			Err:        temporal.NewApplicationError("503 Service Unavailable", "CallHTTP error"),
+			HTTPStatus: 503,
+			Expected:   workflowexecutionv1.ErrorClassification_UPSTREAM_5XX,
+		},
+		{
+			Name:       "http 4xx",
+			Err:        temporal.NewNonRetryableApplicationError("CallHTTP error", "CallHTTP error", errors.New("404 Not Found")),
+			HTTPStatus: 404,
+			Expected:   workflowexecutionv1.ErrorClassification_UPSTREAM_4XX,
+		},
+		{
+			Name:     "host policy denied",
+			Err:      temporal.NewNonRetryableApplicationError(`host "10.0.0.1" is denied`, utils.PolicyDeniedErrorType, netTimeoutError{}),
+			Expected: workflowexecutionv1.ErrorClassification_POLICY_DENIED,
+		},
+		{
+			Name:     "workflow maximum duration",
+			Err:      utils.NewWorkflowTimeoutError(15*time.Minute, nil),
+			Expected: workflowexecutionv1.ErrorClassification_WORKFLOW_TIMEOUT,
+		},
+		{
+			Name: "agent limit exceeded",
+			Err: fmt.Errorf("agent call activity failed: %w",
+				utils.NewAgentLimitExceededError("Tool call limit exceeded: attempted 51 tool calls, limit is 50 (max_tool_calls)")),
+			Expected: workflowexecutionv1.ErrorClassification_LIMIT_EXCEEDED,
+		},
+		{
+			Name:     "cancelled",
+			Err:      fmt.Errorf("call failed: %w", context.Canceled),
+			Expected: workflowexecutionv1.ErrorClassification_CANCELLED,
+		},
+		{
+			Name:     "deadline exceeded",
+			Err:      fmt.Errorf("call failed: %w", context.DeadlineExceeded),
+			Expected: workflowexecutionv1.ErrorClassification_TIMEOUT,
+		},
+		{
+			Name:     "network timeout",
+			Err:      fmt.Errorf("call failed: %w", netTimeoutError{timeout: true}),
+			Expected: workflowexecutionv1.ErrorClassification_TIMEOUT,
+		},
+		{
+			Name:     "network error",
+			Err:      fmt.Errorf("call failed: %w", netTimeoutError{}),
+			Expected: workflowexecutionv1.ErrorClassification_INFRA,
+		},
+		{
+			Name:     "non-retryable application error",
+			Err:      temporal.NewNonRetryableApplicationError("invalid input", "Validation error", nil),
+			Expected: workflowexecutionv1.ErrorClassification_USER_ERROR,
+		},
+		{
+			Name:     "retryable application error",
+			Err:      temporal.NewApplicationError("connection reset", "CallGRPC error"),
+			Expected: workflowexecutionv1.ErrorClassification_INFRA,
+		},
+		{
+			Name:     "plain error",
+			Err:      errors.New("something broke"),
+			Expected: workflowexecutionv1.ErrorClassification_INFRA,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, utils.ClassifyTaskError(test.Err, test.HTTPStatus))
+		})
+	}
+}
+
+func TestClassifyWorkflowError(t *testing.T) {
+	assert.Equal(t,
+		workflowexecutionv1.ErrorClassification_USER_ERROR,
+		utils.ClassifyWorkflowError(temporal.NewNonRetryableApplicationError("raised", "Raise error", nil)),
+	)
+	assert.Equal(t,
+		workflowexecutionv1.ErrorClassification_CANCELLED,
+		utils.ClassifyWorkflowError(temporal.NewCanceledError()),
+	)
+}
+
+func TestNewWorkflowFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		expect utils.WorkflowFailure
+	}{
+		{
+			name: "raised error",
+			err:  temporal.NewNonRetryableApplicationError("card declined", "PaymentDeclined", nil),
+			expect: utils.WorkflowFailure{
+				Message: "card declined", Type: "PaymentDeclined", Classification: "USER_ERROR", Task: "charge",
+			},
+		},
+		{
+			name: "http 5xx",
+			err:  fmt.Errorf("wrapped: %w", temporal.NewApplicationError("CallHTTP returned 5xx error", "CallHTTP error")),
+			expect: utils.WorkflowFailure{
+				Message: "CallHTTP returned 5xx error", Type: "CallHTTP error", Classification: "UPSTREAM_5XX", Task: "charge",
+			},
+		},
+		{
+			name: "http 4xx",
+			err:  temporal.NewNonRetryableApplicationError("CallHTTP returned 4xx status code", "CallHTTP error", nil),
+			expect: utils.WorkflowFailure{
+				Message: "CallHTTP returned 4xx status code", Type: "CallHTTP error", Classification: "UPSTREAM_4XX", Task: "charge",
+			},
+		},
+		{
+			name: "http 429",
+			err:  temporal.NewNonRetryableApplicationError("CallHTTP returned 4xx status code 429: slow down", "CallHTTP error", nil),
+			expect: utils.WorkflowFailure{
+				Message: "CallHTTP returned 4xx status code 429: slow down", Type: "CallHTTP error", Classification: "UPSTREAM_4XX",
+				Task: "charge", Status: 429,
+			},
+		},
+		{
+			name: "plain error",
+			err:  errors.New("boom"),
+			expect: utils.WorkflowFailure{
+				Message: "boom", Classification: "INFRA", Task: "charge",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, utils.NewWorkflowFailure(tc.err, "charge"))
+		})
+	}
+}
+
+func TestHTTPStatusCode(t *testing.T) {
+	assert.Equal(t, 503, utils.HTTPStatusCode(fmt.Errorf("wrapped: %w",
+		temporal.NewApplicationError("CallHTTP returned 5xx status code 503: upstream down", "CallHTTP error"))))
+	assert.Equal(t, 404, utils.HTTPStatusCode(
+		temporal.NewNonRetryableApplicationError("CallHTTP returned 4xx status code 404", "CallHTTP error", nil)))
+	assert.Zero(t, utils.HTTPStatusCode(
+		temporal.NewNonRetryableApplicationError("invalid request body", "CallHTTP error", nil)))
+	assert.Zero(t, utils.HTTPStatusCode(
+		temporal.NewNonRetryableApplicationError("CallHTTP returned 4xx status code 404", "Raise error", nil)))
+	assert.Zero(t, utils.HTTPStatusCode(errors.New("CallHTTP returned 4xx status code 404")))
+}
+
+func TestWorkflowFailureFromError(t *testing.T) {
+	original := temporal.NewNonRetryableApplicationError("card declined", "PaymentDeclined", nil)
+	failure := utils.NewWorkflowFailure(original, "charge")
+	err := fmt.Errorf("workflow execution failed: %w", utils.NewWorkflowFailureError(original, failure))
+
+	got, ok := utils.WorkflowFailureFromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, failure, got)
+	assert.ErrorIs(t, err, original)
+
+	_, ok = utils.WorkflowFailureFromError(original)
+	assert.False(t, ok)
+}
@@ -25,6 +25,9 @@ import (
 	"go.temporal.io/sdk/workflow"
 )
 
+// RedactedValue replaces sensitive task output fields in exported context and output.
+const RedactedValue = "[REDACTED]"
+
 type State struct {
 	CANStartFrom        *string        `json:"canStartFrom,omitempty"`        // Continue-as-new from here
 	Context             any            `json:"context"`                       // Output data exported to later tasks output
@@ -33,6 +36,11 @@ type State struct {
 	Input               any            `json:"input,omitempty"`               // The input given by the caller
 	Output              any            `json:"output"`                        // What will be output to the caller
 	TemporalWorkflowCtx any            `json:"temporalWorkflowCtx,omitempty"` // Original Temporal workflow input for continue-as-new
+
+	// Sensitive holds redacted task output fields, keyed by task then field.
+	// It is never serialized, so the values stay out of activity inputs,
+	// workflow results and continue-as-new payloads.
+	Sensitive map[string]map[string]any `json:"-"`
 }
 
 func (s *State) init() *State {
@@ -52,6 +60,22 @@ func (s *State) AddData(data map[string]any) *State {
 	return s
 }
 
+// RemoveData deletes the given variables from the state's data and from every
+// task export in the context, so that no later task can read them.
+func (s *State) RemoveData(keys ...string) *State {
+	contextMap, _ := s.Context.(map[string]any)
+	for _, key := range keys {
+		delete(s.Data, key)
+		for _, export := range contextMap {
+			if fields, ok := export.(map[string]any); ok {
+				delete(fields, key)
+			}
+		}
+	}
+
+	return s
+}
+
 func (s *State) AddActivityInfo(ctx context.Context) *State {
 	info := activity.GetInfo(ctx)
 
@@ -137,13 +161,77 @@ func (s *State) Clone() *State {
 	s1.Input = swUtils.DeepCloneValue(s.Input)
 	s1.Output = swUtils.DeepCloneValue(s.Output)
 
+	if s.Sensitive != nil {
+		s1.Sensitive = make(map[string]map[string]any, len(s.Sensitive))
+		for taskName, fields := range s.Sensitive {
+			s1.Sensitive[taskName] = maps.Clone(fields)
+		}
+	}
+
 	return s1
 }
 
+// RedactSensitive moves the named top-level fields of a task's export and
+// output into Sensitive, leaving RedactedValue in their place. Expressions
+// evaluated through GetAsMap still see the original values in $context.
+func (s *State) RedactSensitive(taskName string, fields []string) *State {
+	if len(fields) == 0 {
+		return s
+	}
+
+	values := map[string]any{}
+
+	if contextMap, ok := s.Context.(map[string]any); ok {
+		if export, ok := contextMap[taskName].(map[string]any); ok {
+			contextMap[taskName] = redactFields(export, fields, values)
+		}
+	}
+
+	if output, ok := s.Output.(map[string]any); ok {
+		s.Output = redactFields(output, fields, values)
+	}
+
+	if len(values) > 0 {
+		if s.Sensitive == nil {
+			s.Sensitive = map[string]map[string]any{}
+		}
+		s.Sensitive[taskName] = values
+	}
+
+	return s
+}
+
+// redactFields returns a copy of obj with the given fields replaced by
+// RedactedValue, recording the original values in values. The input map is
+// left untouched as it may be shared between the export and the output.
+func redactFields(obj map[string]any, fields []string, values map[string]any) map[string]any {
+	redacted := maps.Clone(obj)
+	for _, field := range fields {
+		v, ok := obj[field]
+		if !ok {
+			continue
+		}
+		if _, seen := values[field]; !seen {
+			values[field] = v
+		}
+		redacted[field] = RedactedValue
+	}
+	return redacted
+}
+
 // Returns the state as a map.
 func (s *State) GetAsMap() map[string]any {
 	s1 := s.Clone()
 
+	// Restore redacted values so later tasks can still reference them
+	if contextMap, ok := s1.Context.(map[string]any); ok {
+		for taskName, values := range s1.Sensitive {
+			if export, ok := contextMap[taskName].(map[string]any); ok {
+				maps.Copy(export, values)
+			}
+		}
+	}
+
 	return map[string]any{
 		"$context": s1.Context,
 		"$data":    s1.Data,
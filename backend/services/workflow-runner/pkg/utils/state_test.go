@@ -0,0 +1,91 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateRedactSensitive(t *testing.T) {
+	output := map[string]any{
+		"access_token": "secret-token",
+		"expires_in":   3600,
+	}
+
+	state := utils.NewState()
+	state.Output = output
+	state.Context = map[string]any{"login": output}
+
+	state.RedactSensitive("login", []string{"access_token", "refresh_token"})
+
+	// Recorded export and output are redacted
+	assert.Equal(t, utils.RedactedValue, state.Context.(map[string]any)["login"].(map[string]any)["access_token"])
+	assert.Equal(t, utils.RedactedValue, state.Output.(map[string]any)["access_token"])
+	assert.Equal(t, 3600, state.Output.(map[string]any)["expires_in"])
+	assert.NotContains(t, state.Output.(map[string]any), "refresh_token")
+
+	// The original task output is not mutated
+	assert.Equal(t, "secret-token", output["access_token"])
+
+	// Serialized state never contains the value
+	data, err := json.Marshal(state)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-token")
+
+	// Later expressions still resolve the original value
+	v, err := utils.EvaluateString(`${ $context.login.access_token }`, nil, state)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret-token", v)
+
+	// Clones (e.g. fork branches) keep access to the value
+	v, err = utils.EvaluateString(`${ $context.login.access_token }`, nil, state.Clone())
+	assert.NoError(t, err)
+	assert.Equal(t, "secret-token", v)
+}
+
+func TestStateRedactSensitive_NoMatchingFields(t *testing.T) {
+	state := utils.NewState()
+	state.Output = "plain"
+
+	state.RedactSensitive("login", []string{"access_token"})
+
+	assert.Equal(t, "plain", state.Output)
+	assert.Nil(t, state.Sensitive)
+}
+
+func TestStateRemoveData(t *testing.T) {
+	state := utils.NewState()
+	state.AddData(map[string]any{"tempToken": "secret", "region": "eu"})
+	state.Context = map[string]any{
+		"login": map[string]any{"tempToken": "secret", "user": "ada"},
+		"count": 3,
+	}
+
+	state.RemoveData("tempToken")
+
+	assert.NotContains(t, state.Data, "tempToken")
+	assert.Equal(t, "eu", state.Data["region"])
+
+	login := state.Context.(map[string]any)["login"].(map[string]any)
+	assert.NotContains(t, login, "tempToken")
+	assert.Equal(t, "ada", login["user"])
+	assert.Equal(t, 3, state.Context.(map[string]any)["count"])
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+type taskMetadataKey struct{}
+
+// TaskMetadataHTTPStatus is the metadata key of the response status of HTTP calls.
+const TaskMetadataHTTPStatus = "httpStatus"
+
+// TaskMetadata collects metadata an activity records about its run, such as
+// whether an HTTP call was served from cache. The progress interceptor
+// reports it as the metadata of the workflow task, and the metrics
+// interceptor reads the HTTP status from it.
+type TaskMetadata struct {
+	mu     sync.Mutex
+	values map[string]any
+}
+
+// WithTaskMetadata returns a context in which activities can record task
+// metadata, and the collector they record into.
+func WithTaskMetadata(ctx context.Context) (context.Context, *TaskMetadata) {
+	meta := &TaskMetadata{values: map[string]any{}}
+	return context.WithValue(ctx, taskMetadataKey{}, meta), meta
+}
+
+// TaskMetadataFromContext returns the collector of the running task, if any.
+func TaskMetadataFromContext(ctx context.Context) (*TaskMetadata, bool) {
+	meta, ok := ctx.Value(taskMetadataKey{}).(*TaskMetadata)
+	return meta, ok
+}
+
+// RecordTaskMetadata records a metadata value for the running task. It is a
+// no-op if the context has no collector, such as in unit tests.
+func RecordTaskMetadata(ctx context.Context, key string, value any) {
+	meta, ok := TaskMetadataFromContext(ctx)
+	if !ok {
+		return
+	}
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	meta.values[key] = value
+}
+
+// Values returns a copy of the recorded metadata.
+func (m *TaskMetadata) Values() map[string]any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return maps.Clone(m.values)
+}
@@ -24,9 +24,19 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // TestMarshalTaskConfig_Success tests successful marshaling of typed protos
+// mustStruct builds a structpb.Struct for SET task variables in test fixtures.
+func mustStruct(fields map[string]interface{}) *structpb.Struct {
+	s, err := structpb.NewStruct(fields)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
 func TestMarshalTaskConfig_Success(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -35,10 +45,10 @@ func TestMarshalTaskConfig_Success(t *testing.T) {
 		{
 			name: "SET task",
 			proto: &tasksv1.SetTaskConfig{
-				Variables: map[string]string{
+				Variables: mustStruct(map[string]interface{}{
 					"key1": "value1",
 					"key2": "value2",
-				},
+				}),
 			},
 		},
 		{
@@ -93,10 +103,10 @@ func TestMarshalTaskConfig_RoundTrip(t *testing.T) {
 			name: "SET task round-trip",
 			kind: apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET,
 			original: &tasksv1.SetTaskConfig{
-				Variables: map[string]string{
+				Variables: mustStruct(map[string]interface{}{
 					"foo": "bar",
 					"baz": "qux",
-				},
+				}),
 			},
 		},
 		{
@@ -43,6 +43,7 @@ import (
 // - RAISE → RaiseTaskConfig
 // - RUN → RunTaskConfig
 // - AGENT_CALL → AgentCallTaskConfig
+// - CUSTOM → Struct (opaque; validated by the runner extension for the kind)
 func UnmarshalTaskConfig(
 	kind apiresourcev1.WorkflowTaskKind,
 	config *structpb.Struct,
@@ -100,6 +101,9 @@ func UnmarshalTaskConfig(
 	case apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_AGENT_CALL:
 		protoMsg = &tasksv1.AgentCallTaskConfig{}
 
+	case apiresourcev1.WorkflowTaskKind_WORKFLOW_TASK_KIND_CUSTOM:
+		protoMsg = &structpb.Struct{}
+
 	default:
 		return nil, fmt.Errorf("unsupported task kind: %v", kind)
 	}
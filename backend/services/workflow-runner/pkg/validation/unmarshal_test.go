@@ -46,8 +46,8 @@ func TestUnmarshalSetTaskConfig(t *testing.T) {
 
 		setConfig, ok := msg.(*tasksv1.SetTaskConfig)
 		require.True(t, ok, "expected SetTaskConfig type")
-		assert.Equal(t, "initialized", setConfig.Variables["status"])
-		assert.Equal(t, "${ .items | length }", setConfig.Variables["count"])
+		assert.Equal(t, "initialized", setConfig.Variables.GetFields()["status"].GetStringValue())
+		assert.Equal(t, "${ .items | length }", setConfig.Variables.GetFields()["count"].GetStringValue())
 	})
 
 	t.Run("nil config", func(t *testing.T) {
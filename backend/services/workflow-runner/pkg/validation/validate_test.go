@@ -30,9 +30,9 @@ import (
 func TestValidateSetTaskConfig(t *testing.T) {
 	t.Run("valid config passes", func(t *testing.T) {
 		config := &tasksv1.SetTaskConfig{
-			Variables: map[string]string{
+			Variables: mustStruct(map[string]interface{}{
 				"status": "initialized",
-			},
+			}),
 		}
 
 		err := ValidateTaskConfig(config)
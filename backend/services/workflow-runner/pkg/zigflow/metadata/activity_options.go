@@ -131,6 +131,7 @@ func SetActivityOptions(ctx workflow.Context, wf *model.Workflow, task *model.Ta
 	ao.Summary = taskName
 	ao.RetryPolicy = defaultRetryPolicy
 	ao.StartToCloseTimeout = defaultWorkflowTimeout
+	ao.ScheduleToCloseTimeout = 0 // Unbounded unless set by a task execution timeout
 
 	// Convert the timeout
 	if wf.Timeout != nil && wf.Timeout.Timeout != nil && wf.Timeout.Timeout.After != nil {
@@ -148,6 +149,13 @@ func SetActivityOptions(ctx workflow.Context, wf *model.Workflow, task *model.Ta
 		ao = opts.ToTemporal(&ao)
 	}
 
+	// Apply the task's execution timeout, overriding the workflow default.
+	// ScheduleToClose is set too so retries can't extend the wall-clock bound.
+	if task.Timeout != nil && task.Timeout.Timeout != nil && task.Timeout.Timeout.After != nil {
+		ao.StartToCloseTimeout = utils.ToDuration(task.Timeout.Timeout.After)
+		ao.ScheduleToCloseTimeout = ao.StartToCloseTimeout
+	}
+
 	// Override any task-specific activity options
 	if a, ok := task.Metadata[MetadataActvitiyOptions]; ok {
 		var opts ActivityOptions
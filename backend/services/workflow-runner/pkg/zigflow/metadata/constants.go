@@ -34,6 +34,100 @@ const (
 
 const MaxHistoryLengthAttribute string = "canMaxHistoryLength"
 
+// MetadataOnFailure is the document metadata entry listing the tasks a
+// workflow runs when it fails, in the "do" format. They run after the main
+// tasks fail, with the failure in $data.error.
+const MetadataOnFailure string = "onFailure"
+
+// MetadataMaxDuration is the document metadata entry holding the maximum
+// duration of an execution, in seconds. The tasks are stopped once it is
+// exceeded and the workflow fails with a WorkflowTimeout error.
+const MetadataMaxDuration string = "maxDurationSeconds"
+
+// MetadataContinueOnBranchError makes a fork record failing branches as
+// {"succeeded": false, "error": {...}} instead of failing the whole fork.
+const MetadataContinueOnBranchError string = "continueOnBranchError"
+
+// MetadataTryCatches lists the filtered catch blocks of a try task, each with
+// its error types, status codes, error variable and tasks. They are evaluated
+// in declaration order before the task's catch, which the DSL allows only one
+// of and cannot filter by classification.
+const MetadataTryCatches string = "tryCatches"
+
+// MetadataTryRetry is the retry policy of a try task, with its error types,
+// maximum retries and delay. Matching failures run the try tasks again before
+// any catch block handles them.
+const MetadataTryRetry string = "tryRetry"
+
+// MetadataForUntil is an expression a for task evaluates after each
+// iteration, against the iteration's state; the loop stops when it is true.
+const MetadataForUntil string = "forUntil"
+
+// MetadataForMaxIterations caps the iterations of a for task. The task fails
+// instead of starting an iteration beyond the cap.
+const MetadataForMaxIterations string = "forMaxIterations"
+
+// MetadataForReportIterations makes a for task output
+// {"iterations": <count>, "results": [...]} instead of the iteration results.
+const MetadataForReportIterations string = "forReportIterations"
+
+// MetadataSensitiveOutputFields lists top-level task output fields that are
+// redacted from exported context and output but remain available to
+// expressions in later tasks of the same run.
+const MetadataSensitiveOutputFields string = "sensitiveOutputFields"
+
+// MetadataHTTPTLS holds the TLS configuration of an HTTP call task (client
+// certificate, CA bundle). Certificates and keys are runtime placeholders
+// resolved by the HTTP activity.
+const MetadataHTTPTLS string = "httpTls"
+
+// MetadataHTTPCache marks an HTTP call task as cacheable (TTL, cache key,
+// whether requests carrying secrets may be cached). The HTTP activity serves
+// fresh responses from the runner's local cache.
+const MetadataHTTPCache string = "httpCache"
+
+// MetadataHTTPProxy overrides the worker's proxy for an HTTP call task, with
+// either a proxy URL or a flag to connect directly.
+const MetadataHTTPProxy string = "httpProxy"
+
+// MetadataHTTPResponseFormat sets how an HTTP call task stores the response
+// body in its output: "json", "text" or "bytes_base64". Without it, JSON
+// objects and arrays are parsed and other bodies are stored as text.
+const MetadataHTTPResponseFormat string = "httpResponseFormat"
+
+// MetadataHTTPExpectStatus lists the response statuses an HTTP call task
+// succeeds on. Any other status fails the task, 2xx included.
+const MetadataHTTPExpectStatus string = "httpExpectStatus"
+
+// MetadataHTTPAllowAnyStatus makes an HTTP call task succeed whatever the
+// response status, instead of failing on 3xx, 4xx and 5xx statuses.
+const MetadataHTTPAllowAnyStatus string = "httpAllowAnyStatus"
+
+// MetadataHTTPBodyEncoding sets how an HTTP call task encodes its request
+// body: "form" (url-encoded) or "multipart". Without it, the body is sent as
+// JSON.
+const MetadataHTTPBodyEncoding string = "httpBodyEncoding"
+
+// MetadataHTTPMultipartParts lists the parts of a multipart request body in
+// order, with their file name, content type and whether the value is
+// base64-encoded. Part values are the body fields of the same name.
+const MetadataHTTPMultipartParts string = "httpMultipartParts"
+
+// MetadataApproval turns a listen task into an approval gate (approvers,
+// timeout, timeout action). The task waits for a decision sent through
+// stigmer-server instead of its listen signals.
+const MetadataApproval string = "approval"
+
+// MetadataListenTimeout is how long a listen task waits for its signals, as a
+// Go duration string (default one minute).
+const MetadataListenTimeout string = "timeout"
+
+// RaiseErrorType is the error type URI of raise tasks converted from a
+// RaiseTaskConfig. Such tasks fail with an application error whose type is
+// the error title (the configured error name) and whose message is the error
+// detail, so both end up in the execution status.
+const RaiseErrorType string = "https://stigmer.ai/errors/raise"
+
 const defaultWorkflowTimeout = time.Minute * 5
 
 var defaultRetryPolicy = &temporal.RetryPolicy{
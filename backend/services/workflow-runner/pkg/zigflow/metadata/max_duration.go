@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// GetMaxDuration returns the maximum duration of an execution of the
+// workflow, 0 if it has none.
+func GetMaxDuration(doc *model.Workflow) (time.Duration, error) {
+	var seconds int64
+	switch v := doc.Document.Metadata[MetadataMaxDuration].(type) {
+	case nil:
+		return 0, nil
+	case int:
+		seconds = int64(v)
+	case int64:
+		seconds = v
+	case uint64:
+		seconds = int64(v)
+	case float64:
+		seconds = int64(v)
+	default:
+		return 0, fmt.Errorf("document.metadata.%s must be a number of seconds", MetadataMaxDuration)
+	}
+	if seconds < 0 {
+		return 0, fmt.Errorf("document.metadata.%s must not be negative", MetadataMaxDuration)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
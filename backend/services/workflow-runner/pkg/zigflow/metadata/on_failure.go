@@ -0,0 +1,49 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// GetOnFailureTasks returns the tasks the workflow runs when it fails, nil if
+// there are none.
+func GetOnFailureTasks(doc *model.Workflow) (*model.TaskList, error) {
+	raw, ok := doc.Document.Metadata[MetadataOnFailure]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	if _, ok := raw.([]any); !ok {
+		return nil, fmt.Errorf("document.metadata.%s must be a list of tasks", MetadataOnFailure)
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document.metadata.%s: %w", MetadataOnFailure, err)
+	}
+	var tasks model.TaskList
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return nil, fmt.Errorf("invalid document.metadata.%s: %w", MetadataOnFailure, err)
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+	return &tasks, nil
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOnFailureTasks(t *testing.T) {
+	doc := &model.Workflow{Document: model.Document{}}
+	tasks, err := metadata.GetOnFailureTasks(doc)
+	assert.NoError(t, err)
+	assert.Nil(t, tasks)
+
+	doc.Document.Metadata = map[string]any{
+		metadata.MetadataOnFailure: []any{
+			map[string]any{"notify": map[string]any{"set": map[string]any{"failed": true}}},
+			map[string]any{"cleanup": map[string]any{"wait": map[string]any{"seconds": 1}}},
+		},
+	}
+	tasks, err = metadata.GetOnFailureTasks(doc)
+	assert.NoError(t, err)
+	if assert.NotNil(t, tasks) && assert.Len(t, *tasks, 2) {
+		assert.Equal(t, "notify", (*tasks)[0].Key)
+		assert.NotNil(t, (*tasks)[0].AsSetTask())
+		assert.Equal(t, "cleanup", (*tasks)[1].Key)
+		assert.NotNil(t, (*tasks)[1].AsWaitTask())
+	}
+
+	doc.Document.Metadata[metadata.MetadataOnFailure] = map[string]any{"notify": true}
+	_, err = metadata.GetOnFailureTasks(doc)
+	assert.ErrorContains(t, err, "document.metadata.onFailure must be a list of tasks")
+}
+
+func TestGetMaxDuration(t *testing.T) {
+	doc := &model.Workflow{Document: model.Document{}}
+	maxDuration, err := metadata.GetMaxDuration(doc)
+	assert.NoError(t, err)
+	assert.Zero(t, maxDuration)
+
+	doc.Document.Metadata = map[string]any{metadata.MetadataMaxDuration: 900}
+	maxDuration, err = metadata.GetMaxDuration(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, maxDuration)
+
+	doc.Document.Metadata[metadata.MetadataMaxDuration] = "15m"
+	_, err = metadata.GetMaxDuration(doc)
+	assert.ErrorContains(t, err, "document.metadata.maxDurationSeconds must be a number of seconds")
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+)
+
+// TryCatch is a filtered catch block of a try task (MetadataTryCatches).
+// Without error types or status codes, it catches every error.
+type TryCatch struct {
+	// ErrorTypes match the error type or its classification, such as
+	// "CallHTTP error" or "UPSTREAM_4XX".
+	ErrorTypes []string `json:"errorTypes,omitempty"`
+	// StatusCodes match the response status of failed HTTP calls.
+	StatusCodes []int `json:"statusCodes,omitempty"`
+	// As is the variable the caught error is stored in, "error" if empty.
+	As string          `json:"as,omitempty"`
+	Do *model.TaskList `json:"do"`
+}
+
+// GetTryCatches returns the filtered catch blocks of a try task, in
+// declaration order, nil if there are none.
+func GetTryCatches(task *model.TryTask) ([]TryCatch, error) {
+	raw, ok := task.Metadata[MetadataTryCatches]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	if _, ok := raw.([]any); !ok {
+		return nil, fmt.Errorf("metadata.%s must be a list of catch blocks", MetadataTryCatches)
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata.%s: %w", MetadataTryCatches, err)
+	}
+	var catches []TryCatch
+	if err := json.Unmarshal(data, &catches); err != nil {
+		return nil, fmt.Errorf("invalid metadata.%s: %w", MetadataTryCatches, err)
+	}
+	for i, catch := range catches {
+		if catch.Do == nil || len(*catch.Do) == 0 {
+			return nil, fmt.Errorf("metadata.%s[%d] has no tasks", MetadataTryCatches, i)
+		}
+	}
+	return catches, nil
+}
+
+// Matches reports whether the catch block handles failure: its type or
+// classification is one of the error types and its status one of the status
+// codes, for the filters that are set.
+func (c TryCatch) Matches(failure utils.WorkflowFailure) bool {
+	if len(c.ErrorTypes) > 0 &&
+		!slices.Contains(c.ErrorTypes, failure.Type) && !slices.Contains(c.ErrorTypes, failure.Classification) {
+		return false
+	}
+	if len(c.StatusCodes) > 0 && !slices.Contains(c.StatusCodes, failure.Status) {
+		return false
+	}
+	return true
+}
+
+// TryRetry is the retry policy of a try task (MetadataTryRetry): attempts
+// failing with one of its error types run the try tasks again, up to
+// MaxRetries times.
+type TryRetry struct {
+	// ErrorTypes match the error type or its classification, such as
+	// "ExecutionTimeout" or "UPSTREAM_5XX".
+	ErrorTypes   []string `json:"errorTypes"`
+	MaxRetries   int      `json:"maxRetries"`
+	DelaySeconds int      `json:"delaySeconds,omitempty"`
+}
+
+// GetTryRetry returns the retry policy of a try task, nil if it has none.
+func GetTryRetry(task *model.TryTask) (*TryRetry, error) {
+	raw, ok := task.Metadata[MetadataTryRetry]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	if _, ok := raw.(map[string]any); !ok {
+		return nil, fmt.Errorf("metadata.%s must be a retry policy", MetadataTryRetry)
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata.%s: %w", MetadataTryRetry, err)
+	}
+	var retry TryRetry
+	if err := json.Unmarshal(data, &retry); err != nil {
+		return nil, fmt.Errorf("invalid metadata.%s: %w", MetadataTryRetry, err)
+	}
+	if len(retry.ErrorTypes) == 0 {
+		return nil, fmt.Errorf("metadata.%s has no error types", MetadataTryRetry)
+	}
+	if retry.MaxRetries < 1 {
+		return nil, fmt.Errorf("metadata.%s must allow at least one retry", MetadataTryRetry)
+	}
+	if retry.DelaySeconds < 0 {
+		return nil, fmt.Errorf("metadata.%s has a negative delay", MetadataTryRetry)
+	}
+	return &retry, nil
+}
+
+// Matches reports whether failure is retried: its type or classification is
+// one of the error types.
+func (r TryRetry) Matches(failure utils.WorkflowFailure) bool {
+	return slices.Contains(r.ErrorTypes, failure.Type) || slices.Contains(r.ErrorTypes, failure.Classification)
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata_test
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTryCatches(t *testing.T) {
+	task := &model.TryTask{}
+	catches, err := metadata.GetTryCatches(task)
+	assert.NoError(t, err)
+	assert.Nil(t, catches)
+
+	task.Metadata = map[string]any{
+		metadata.MetadataTryCatches: []any{
+			map[string]any{
+				"errorTypes": []any{"UPSTREAM_4XX"},
+				"as":         "clientError",
+				"do":         []any{map[string]any{"report": map[string]any{"set": map[string]any{"failed": true}}}},
+			},
+			map[string]any{
+				"statusCodes": []any{429, 503},
+				"do":          []any{map[string]any{"backoff": map[string]any{"wait": map[string]any{"seconds": 5}}}},
+			},
+		},
+	}
+	catches, err = metadata.GetTryCatches(task)
+	assert.NoError(t, err)
+	if assert.Len(t, catches, 2) {
+		assert.Equal(t, []string{"UPSTREAM_4XX"}, catches[0].ErrorTypes)
+		assert.Equal(t, "clientError", catches[0].As)
+		if assert.NotNil(t, catches[0].Do) && assert.Len(t, *catches[0].Do, 1) {
+			assert.NotNil(t, (*catches[0].Do)[0].AsSetTask())
+		}
+		assert.Equal(t, []int{429, 503}, catches[1].StatusCodes)
+		if assert.NotNil(t, catches[1].Do) && assert.Len(t, *catches[1].Do, 1) {
+			assert.NotNil(t, (*catches[1].Do)[0].AsWaitTask())
+		}
+	}
+
+	task.Metadata[metadata.MetadataTryCatches] = []any{map[string]any{"errorTypes": []any{"TIMEOUT"}}}
+	_, err = metadata.GetTryCatches(task)
+	assert.ErrorContains(t, err, "metadata.tryCatches[0] has no tasks")
+
+	task.Metadata[metadata.MetadataTryCatches] = map[string]any{"do": []any{}}
+	_, err = metadata.GetTryCatches(task)
+	assert.ErrorContains(t, err, "metadata.tryCatches must be a list of catch blocks")
+}
+
+func TestTryCatch_Matches(t *testing.T) {
+	rateLimited := utils.WorkflowFailure{Type: "CallHTTP error", Classification: "UPSTREAM_4XX", Status: 429}
+	timeout := utils.WorkflowFailure{Type: "ActivityTimeout", Classification: "TIMEOUT"}
+
+	tests := []struct {
+		name   string
+		catch  metadata.TryCatch
+		expect map[string]bool
+	}{
+		{
+			name:   "no filters",
+			catch:  metadata.TryCatch{},
+			expect: map[string]bool{"rateLimited": true, "timeout": true},
+		},
+		{
+			name:   "classification",
+			catch:  metadata.TryCatch{ErrorTypes: []string{"UPSTREAM_4XX"}},
+			expect: map[string]bool{"rateLimited": true, "timeout": false},
+		},
+		{
+			name:   "error type",
+			catch:  metadata.TryCatch{ErrorTypes: []string{"ActivityTimeout", "PolicyDenied"}},
+			expect: map[string]bool{"rateLimited": false, "timeout": true},
+		},
+		{
+			name:   "status code",
+			catch:  metadata.TryCatch{StatusCodes: []int{429, 503}},
+			expect: map[string]bool{"rateLimited": true, "timeout": false},
+		},
+		{
+			name:   "both filters must match",
+			catch:  metadata.TryCatch{ErrorTypes: []string{"UPSTREAM_4XX"}, StatusCodes: []int{404}},
+			expect: map[string]bool{"rateLimited": false, "timeout": false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect["rateLimited"], tc.catch.Matches(rateLimited), "rate limited")
+			assert.Equal(t, tc.expect["timeout"], tc.catch.Matches(timeout), "timeout")
+		})
+	}
+}
+
+func TestGetTryRetry(t *testing.T) {
+	task := &model.TryTask{}
+	retry, err := metadata.GetTryRetry(task)
+	assert.NoError(t, err)
+	assert.Nil(t, retry)
+
+	task.Metadata = map[string]any{
+		metadata.MetadataTryRetry: map[string]any{
+			"errorTypes":   []any{"ExecutionTimeout"},
+			"maxRetries":   2,
+			"delaySeconds": 5,
+		},
+	}
+	retry, err = metadata.GetTryRetry(task)
+	assert.NoError(t, err)
+	assert.Equal(t, &metadata.TryRetry{ErrorTypes: []string{"ExecutionTimeout"}, MaxRetries: 2, DelaySeconds: 5}, retry)
+
+	assert.True(t, retry.Matches(utils.WorkflowFailure{Type: "ExecutionTimeout", Classification: "TIMEOUT"}))
+	assert.False(t, retry.Matches(utils.WorkflowFailure{Type: "CallHTTP error", Classification: "UPSTREAM_5XX"}))
+
+	task.Metadata[metadata.MetadataTryRetry] = map[string]any{"maxRetries": 2}
+	_, err = metadata.GetTryRetry(task)
+	assert.ErrorContains(t, err, "metadata.tryRetry has no error types")
+
+	task.Metadata[metadata.MetadataTryRetry] = map[string]any{"errorTypes": []any{"TIMEOUT"}}
+	_, err = metadata.GetTryRetry(task)
+	assert.ErrorContains(t, err, "metadata.tryRetry must allow at least one retry")
+
+	task.Metadata[metadata.MetadataTryRetry] = []any{}
+	_, err = metadata.GetTryRetry(task)
+	assert.ErrorContains(t, err, "metadata.tryRetry must be a retry policy")
+}
@@ -19,5 +19,8 @@ package models
 type ActivityCallWith struct {
 	Name      string `json:"name"`
 	Arguments []any  `json:"arguments"`
+	// TaskQueue defaults to the runner's own task queue when empty.
 	TaskQueue string `json:"taskQueue"`
+	// TimeoutSeconds overrides the activity start-to-close timeout when set.
+	TimeoutSeconds int32 `json:"timeoutSeconds"`
 }
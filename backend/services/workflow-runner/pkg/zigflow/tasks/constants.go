@@ -20,3 +20,7 @@ const (
 	customCallFunctionActivity = "activity"
 	customCallFunctionAgent    = "agent"
 )
+
+// errTypeExecutionTimeout is the error type raised when a task exceeds its
+// execution timeout. Must match workflow.ErrorTypeExecutionTimeout in the SDK.
+const errTypeExecutionTimeout = "ExecutionTimeout"
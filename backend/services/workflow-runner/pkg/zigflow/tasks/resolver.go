@@ -65,8 +65,8 @@ func ResolvePlaceholders(s string, runtimeEnv map[string]any) (string, error) {
 	}
 
 	// Pattern: ${.secrets.KEY} or ${.env_vars.VAR}
-	// Key/Var names must be UPPERCASE with optional underscores and numbers
-	pattern := regexp.MustCompile(`\$\{\.(?P<type>secrets|env_vars)\.(?P<key>[A-Z_][A-Z0-9_]*)\}`)
+	// Key/Var names are identifiers: letters, digits and underscores
+	pattern := regexp.MustCompile(`\$\{\.(?P<type>secrets|env_vars)\.(?P<key>[A-Za-z_][A-Za-z0-9_]*)\}`)
 
 	// Track missing variables for error reporting
 	var missingVars []string
@@ -101,7 +101,10 @@ func (d *builder[T]) executeActivity(ctx workflow.Context, activity, input any,
 	var res any
 	if err := workflow.ExecuteActivity(ctx, activity, evaluatedTask, input, state.Env).Get(ctx, &res); err != nil {
 		if temporal.IsCanceledError(err) {
-			return nil, nil
+			// Returned as is, so a cancelled workflow stops instead of running
+			// its remaining tasks
+			logger.Info("Activity cancelled", "name", d.name)
+			return nil, err
 		}
 
 		logger.Error("Error calling activity", "name", d.name, "error", err)
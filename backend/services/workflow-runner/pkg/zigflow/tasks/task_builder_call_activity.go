@@ -19,6 +19,10 @@ package tasks
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/models"
@@ -30,6 +34,51 @@ import (
 	"go.temporal.io/sdk/workflow"
 )
 
+// customActivities holds activities registered with RegisterCustomActivity,
+// keyed by the name CALL_ACTIVITY tasks use to invoke them.
+var customActivities = map[string]any{}
+
+// RegisterCustomActivity registers a Temporal activity that CALL_ACTIVITY
+// tasks can invoke by name on the runner's execution queue (e.g.
+// "transform.v1.Normalize"). The activity receives the task input as its
+// single argument. Must be called before the worker starts.
+func RegisterCustomActivity(name string, fn any) {
+	customActivities[name] = fn
+}
+
+// CustomActivities returns the activities registered with RegisterCustomActivity.
+func CustomActivities() map[string]any {
+	return customActivities
+}
+
+// RegisteredActivityNames returns the sorted names of all activities the
+// runner registers on its execution queue: built-in Zigflow activities and
+// custom activities.
+func RegisteredActivityNames() []string {
+	names := make([]string, 0, len(customActivities))
+	for name := range customActivities {
+		names = append(names, name)
+	}
+	// Temporal registers struct activities under their method names
+	for _, a := range activitiesRegistry {
+		typ := reflect.TypeOf(a)
+		for i := 0; i < typ.NumMethod(); i++ {
+			names = append(names, typ.Method(i).Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isRegisteredActivity(name string) bool {
+	for _, registered := range RegisteredActivityNames() {
+		if registered == name {
+			return true
+		}
+	}
+	return false
+}
+
 func NewCallActivityTaskBuilder(
 	temporalWorker worker.Worker,
 	task *model.CallFunction,
@@ -72,9 +121,15 @@ func (t *CallActivityTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 			return nil, err
 		}
 
-		// Set the task queue
+		// Set the task queue and timeout. An empty task queue dispatches to
+		// the runner's own queue.
 		opts := workflow.GetActivityOptions(ctx)
-		opts.TaskQueue = t.activity.TaskQueue
+		if t.activity.TaskQueue != "" {
+			opts.TaskQueue = t.activity.TaskQueue
+		}
+		if t.activity.TimeoutSeconds > 0 {
+			opts.StartToCloseTimeout = time.Duration(t.activity.TimeoutSeconds) * time.Second
+		}
 		ctx = workflow.WithActivityOptions(ctx, opts)
 
 		logger.Info("Executing Temporal activity", "activity", t.activity.Name, "task", t.GetTaskName())
@@ -114,8 +169,13 @@ func (t *CallActivityTaskBuilder) convertToType() error {
 		return fmt.Errorf("call activity requires a name: %s", t.GetTaskName())
 	}
 
-	if result.TaskQueue == "" {
-		return fmt.Errorf("activity task queue must be set: %s", t.GetTaskName())
+	// Activities on the runner's own queue must be registered here, so an
+	// unknown name can be reported before the workflow starts
+	if result.TaskQueue == "" && !isRegisteredActivity(result.Name) {
+		return fmt.Errorf(
+			"unknown activity '%s' in task '%s': registered activities are [%s]; set taskQueue to call an activity on another worker",
+			result.Name, t.GetTaskName(), strings.Join(RegisteredActivityNames(), ", "),
+		)
 	}
 
 	t.activity = &result
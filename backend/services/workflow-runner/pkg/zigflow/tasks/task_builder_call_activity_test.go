@@ -18,6 +18,7 @@ package tasks
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -74,3 +75,67 @@ func TestCallActivityTaskBuilderExecute(t *testing.T) {
 	assert.NoError(t, env.GetWorkflowResult(&got))
 	assert.Equal(t, "ping-processed", got)
 }
+
+func TestCallActivityTaskBuilderCustomActivity(t *testing.T) {
+	const activityName = "transform.v1.Normalize"
+	normalize := func(ctx context.Context, input map[string]any) (map[string]any, error) {
+		return map[string]any{"data": strings.ToLower(input["data"].(string))}, nil
+	}
+	RegisterCustomActivity(activityName, normalize)
+	defer delete(customActivities, activityName)
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterActivityWithOptions(normalize, activity.RegisterOptions{Name: activityName})
+
+	task := &model.CallFunction{
+		Call: customCallFunctionActivity,
+		With: map[string]any{
+			"name":           activityName,
+			"arguments":      []any{map[string]any{"data": "${ $input.message }"}},
+			"timeoutSeconds": 120,
+		},
+	}
+
+	b, err := NewCallActivityTaskBuilder(nil, task, "normalize", nil)
+	assert.NoError(t, err)
+
+	fn, err := b.Build()
+	assert.NoError(t, err)
+
+	workflowFunc := func(ctx workflow.Context) (map[string]any, error) {
+		state := utils.NewState().AddWorkflowInfo(ctx)
+		state.Input = map[string]any{"message": "PING"}
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+		result, err := fn(ctx, nil, state)
+		if err != nil {
+			return nil, err
+		}
+		return result.(map[string]any), nil
+	}
+
+	env.ExecuteWorkflow(workflowFunc)
+
+	var got map[string]any
+	assert.NoError(t, env.GetWorkflowError())
+	assert.NoError(t, env.GetWorkflowResult(&got))
+	assert.Equal(t, map[string]any{"data": "ping"}, got)
+}
+
+func TestCallActivityTaskBuilderUnknownActivity(t *testing.T) {
+	RegisterCustomActivity("transform.v1.Normalize", func(ctx context.Context) error { return nil })
+	defer delete(customActivities, "transform.v1.Normalize")
+
+	task := &model.CallFunction{
+		Call: customCallFunctionActivity,
+		With: map[string]any{"name": "transform.v1.Normalise"},
+	}
+
+	b, err := NewCallActivityTaskBuilder(nil, task, "normalize", nil)
+	assert.NoError(t, err)
+
+	_, err = b.Build()
+	assert.ErrorContains(t, err, "unknown activity 'transform.v1.Normalise'")
+	assert.ErrorContains(t, err, "transform.v1.Normalize")
+	assert.ErrorContains(t, err, "CallHTTPActivity")
+}
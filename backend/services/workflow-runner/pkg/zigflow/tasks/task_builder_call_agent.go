@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	agentexecv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
 	"github.com/rs/zerolog/log"
@@ -28,6 +29,7 @@ import (
 	"go.temporal.io/sdk/worker"
 	"go.temporal.io/sdk/workflow"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 // NewCallAgentTaskBuilder creates a new task builder for AGENT_CALL tasks.
@@ -105,6 +107,34 @@ func (t *CallAgentTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 			return nil, fmt.Errorf("agent call activity failed: %w", err)
 		}
 
+		// With an output schema, the task output is the validated JSON object.
+		// Non-conforming responses are retried with the validation error.
+		if schema := agentOutputSchema(res, t.agentConfig); schema != nil {
+			output, err := parseAgentStructuredOutput(res, schema)
+			for attempt := 2; err != nil && attempt <= maxOutputSchemaAttempts; attempt++ {
+				logger.Warn("Agent response does not match output schema, retrying",
+					"attempt", attempt, "error", err)
+
+				retryConfig := proto.Clone(t.agentConfig).(*tasks.AgentCallTaskConfig)
+				retryConfig.Message = outputSchemaRetryMessage(t.agentConfig.Message, schema, err)
+				if err = workflow.ExecuteActivity(ctx, (*CallAgentActivities).CallAgentActivity,
+					retryConfig, input, state.Env).Get(ctx, &res); err != nil {
+					if temporal.IsCanceledError(err) {
+						logger.Debug("Agent call activity cancelled")
+						return nil, nil
+					}
+					logger.Error("Agent call activity failed", "error", err)
+					return nil, fmt.Errorf("agent call activity failed: %w", err)
+				}
+				output, err = parseAgentStructuredOutput(res, schema)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("agent response does not match output schema after %d attempts: %w",
+					maxOutputSchemaAttempts, err)
+			}
+			res = output
+		}
+
 		// Store result in state
 		state.AddData(map[string]any{
 			t.GetTaskName(): res,
@@ -114,6 +144,58 @@ func (t *CallAgentTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 	}, nil
 }
 
+// agentOutputMode returns the output mode for the task's stream_to_context and
+// final_output_only options, or CALLBACK_OUTPUT_MODE_UNSPECIFIED to complete
+// with the full agent execution:
+//   - final_output_only: {"final": "<last AI message>"}
+//   - stream_to_context: {"final": ..., "chunks": [{"text": ..., "timestamp": ...}]}
+//
+// The mode is sent as the execution config's callback_output so the agent
+// execution workflow shapes the result it completes CallAgentActivity with.
+// The activity itself returns ErrResultPending, so the completion payload is
+// what lands in Temporal history; shaping on our side after Get would be too
+// late.
+//
+// final_output_only takes precedence. Tasks with an output schema always get
+// the full execution, since its messages are needed for validation.
+func agentOutputMode(cfg *tasks.AgentCallTaskConfig) agentexecv1.CallbackOutputMode {
+	switch {
+	case cfg.GetConfig().GetOutputSchema() != nil:
+		return agentexecv1.CallbackOutputMode_CALLBACK_OUTPUT_MODE_UNSPECIFIED
+	case cfg.GetFinalOutputOnly():
+		return agentexecv1.CallbackOutputMode_CALLBACK_OUTPUT_FINAL_ONLY
+	case cfg.GetStreamToContext():
+		return agentexecv1.CallbackOutputMode_CALLBACK_OUTPUT_STREAM_TO_CONTEXT
+	default:
+		return agentexecv1.CallbackOutputMode_CALLBACK_OUTPUT_MODE_UNSPECIFIED
+	}
+}
+
+// agentMessagesFromResult extracts status.messages from a decoded AgentExecution.
+// Non-conforming results yield no messages.
+func agentMessagesFromResult(res any) []map[string]any {
+	execution, ok := res.(map[string]any)
+	if !ok {
+		return nil
+	}
+	status, ok := execution["status"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	rawMessages, ok := status["messages"].([]any)
+	if !ok {
+		return nil
+	}
+
+	messages := make([]map[string]any, 0, len(rawMessages))
+	for _, raw := range rawMessages {
+		if msg, ok := raw.(map[string]any); ok {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
 // parseConfig unmarshals the CallFunction.With field into AgentCallTaskConfig.
 // The With field contains a JSON object matching the AgentCallTaskConfig proto.
 func (t *CallAgentTaskBuilder) parseConfig() error {
@@ -192,6 +274,12 @@ func (t *CallAgentTaskBuilder) evaluateExpressions(ctx workflow.Context, state *
 		}
 	}
 
+	// 3. Evaluate attachment content and enforce size limits
+	// Example: "${ $context[\"fetch-diff\"].body }" → the fetched diff
+	if err := evaluateAttachments(t.agentConfig.Attachments, state); err != nil {
+		return err
+	}
+
 	logger.Debug("Agent task expressions evaluated successfully")
 	return nil
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/config"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
 	"go.temporal.io/sdk/activity"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -123,15 +124,19 @@ func (a *CallAgentActivities) CallAgentActivity(
 	}
 
 	// **STEP 2: Agent Resolution**
-	// Extract org ID from runtime environment (set by workflow execution context)
-	orgId := getOrgIdFromRuntimeEnv(runtimeEnv)
+	// Agents deployed by another project name their owning org; otherwise
+	// extract org ID from runtime environment (set by workflow execution context)
+	orgId := resolvedConfig.Org
+	if orgId == "" {
+		orgId = getOrgIdFromRuntimeEnv(runtimeEnv)
+	}
 	if orgId == "" {
 		logger.Error("Organization ID not found in runtime environment")
 		return nil, fmt.Errorf("organization ID not available in workflow execution context")
 	}
 
-	// Resolve agent slug + scope to actual agent ID
-	agentId, err := a.resolveAgent(ctx, resolvedConfig.Agent, resolvedConfig.Scope, orgId)
+	// Resolve agent slug + scope (+ version) to actual agent ID
+	agentId, err := a.resolveAgent(ctx, resolvedConfig.Agent, resolvedConfig.Scope, orgId, resolvedConfig.Version)
 	if err != nil {
 		logger.Error("Failed to resolve agent", "agent", resolvedConfig.Agent, "error", err)
 		return nil, fmt.Errorf("agent '%s' not found: %w", resolvedConfig.Agent, err)
@@ -167,8 +172,9 @@ func (a *CallAgentActivities) CallAgentActivity(
 	// The agent workflow will now complete this activity asynchronously using
 	// ActivityCompletionClient.complete(token, result) when it finishes.
 	//
-	// Output sanitization (secret leakage detection) will be handled by the
-	// agent workflow before calling the completion callback.
+	// Output sanitization (secret leakage detection) and output shaping
+	// (stream_to_context / final_output_only) are handled by the agent
+	// workflow before calling the completion callback.
 }
 
 // resolveRuntimePlaceholders resolves JIT placeholders in the agent config.
@@ -181,9 +187,14 @@ func (a *CallAgentActivities) resolveRuntimePlaceholders(
 	resolvedConfig := &workflowtasks.AgentCallTaskConfig{
 		Agent:   config.Agent,
 		Scope:   config.Scope,
+		Org:     config.Org,
+		Version: config.Version,
 		Message: config.Message,
 		Config:  config.Config,
 		Env:     make(map[string]string),
+
+		StreamToContext: config.StreamToContext,
+		FinalOutputOnly: config.FinalOutputOnly,
 	}
 
 	// Resolve placeholders in message
@@ -208,28 +219,31 @@ func (a *CallAgentActivities) resolveRuntimePlaceholders(
 }
 
 // resolveAgent resolves an agent slug to an agent ID using the Agent query service.
-// Uses ApiResourceReference to query by scope, org, and slug.
+// Uses ApiResourceReference to query by scope, org, slug and version.
 func (a *CallAgentActivities) resolveAgent(
 	ctx context.Context,
 	slug string,
 	scope apiresource.ApiResourceOwnerScope,
 	orgId string,
+	version string,
 ) (string, error) {
 	logger := activity.GetLogger(ctx)
 
 	// Build the ApiResourceReference
 	// This tells the backend: "Find agent with this slug in this scope/org"
 	reference := &apiresource.ApiResourceReference{
-		Scope: scope,
-		Org:   orgId,
-		Kind:  apiresourcekind.ApiResourceKind_agent,
-		Slug:  slug,
+		Scope:   scope,
+		Org:     orgId,
+		Kind:    apiresourcekind.ApiResourceKind_agent,
+		Slug:    slug,
+		Version: version,
 	}
 
 	logger.Debug("Resolving agent by reference",
 		"slug", slug,
 		"scope", scope,
-		"org", orgId)
+		"org", orgId,
+		"version", version)
 
 	// Get gRPC client
 	client, err := getAgentQueryClient()
@@ -243,9 +257,34 @@ func (a *CallAgentActivities) resolveAgent(
 		return "", fmt.Errorf("getByReference failed: %w", err)
 	}
 
+	// Agent lookups return the current agent, so a pinned version must be
+	// one of its tags or its version ID
+	if !agentHasVersion(agent, version) {
+		return "", fmt.Errorf("agent %s/%s has no version %q", orgId, slug, version)
+	}
+
 	return agent.Metadata.Id, nil
 }
 
+// agentHasVersion reports whether the agent matches the requested version:
+// empty and "latest" match any agent, anything else must be one of the
+// agent's tags or its version ID.
+func agentHasVersion(agent *agentv1.Agent, version string) bool {
+	if version == "" || version == "latest" {
+		return true
+	}
+	metadata := agent.GetMetadata()
+	if metadata.GetVersion().GetId() == version {
+		return true
+	}
+	for _, tag := range metadata.GetTags() {
+		if tag == version {
+			return true
+		}
+	}
+	return false
+}
+
 // createAgentExecution creates a new agent execution through the AgentExecution command service.
 // The callbackToken enables async activity completion pattern.
 func (a *CallAgentActivities) createAgentExecution(
@@ -273,17 +312,28 @@ func (a *CallAgentActivities) createAgentExecution(
 		Message:       config.Message,
 		RuntimeEnv:    runtimeEnv,
 		CallbackToken: callbackToken, // 👈 Pass token for async completion
+		Attachments:   executionAttachments(config.Attachments),
 	}
 
 	// Add execution config if provided
 	if config.Config != nil {
 		spec.ExecutionConfig = &agentexecv1.ExecutionConfig{
-			ModelName: config.Config.Model,
+			ModelName:    config.Config.Model,
+			OutputSchema: config.Config.OutputSchema,
 			// Note: timeout is for activity timeout, not agent execution timeout
 			// Agent execution timeout is handled by agent-runner
 		}
 	}
 
+	// Output shaping must happen before the callback completes this activity,
+	// so the agent execution workflow does it from callback_output
+	if mode := agentOutputMode(config); mode != agentexecv1.CallbackOutputMode_CALLBACK_OUTPUT_MODE_UNSPECIFIED {
+		if spec.ExecutionConfig == nil {
+			spec.ExecutionConfig = &agentexecv1.ExecutionConfig{}
+		}
+		spec.ExecutionConfig.CallbackOutput = mode
+	}
+
 	// Build full AgentExecution message
 	// Generate a name for the execution (backend will slugify it)
 	// Format: {agent-slug}-execution-{timestamp}
@@ -355,6 +405,9 @@ func (a *CallAgentActivities) waitForCompletion(
 				if phase == agentexecv1.ExecutionPhase_EXECUTION_CANCELLED {
 					return nil, fmt.Errorf("agent execution was cancelled")
 				}
+				if phase == agentexecv1.ExecutionPhase_EXECUTION_LIMIT_EXCEEDED {
+					return nil, utils.NewAgentLimitExceededError(execution.Status.Error)
+				}
 
 				// Extract final response from messages
 				response := extractAgentResponse(execution.Status.Messages)
@@ -368,11 +421,12 @@ func (a *CallAgentActivities) waitForCompletion(
 	}
 }
 
-// isTerminalPhase checks if an execution phase is terminal (completed/failed/cancelled).
+// isTerminalPhase checks if an execution phase is terminal (completed/failed/cancelled/limit exceeded).
 func isTerminalPhase(phase agentexecv1.ExecutionPhase) bool {
 	return phase == agentexecv1.ExecutionPhase_EXECUTION_COMPLETED ||
 		phase == agentexecv1.ExecutionPhase_EXECUTION_FAILED ||
-		phase == agentexecv1.ExecutionPhase_EXECUTION_CANCELLED
+		phase == agentexecv1.ExecutionPhase_EXECUTION_CANCELLED ||
+		phase == agentexecv1.ExecutionPhase_EXECUTION_LIMIT_EXCEEDED
 }
 
 // extractAgentResponse extracts the final AI response from execution messages.
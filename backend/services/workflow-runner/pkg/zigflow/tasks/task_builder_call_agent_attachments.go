@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	agentexecv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+)
+
+// defaultAttachmentMaxSizeBytes is the size limit of attachments that do not
+// set max_size_bytes (1 MiB).
+const defaultAttachmentMaxSizeBytes = 1 << 20
+
+// evaluateAttachments resolves attachment content expressions against the
+// workflow state and enforces each attachment's size limit.
+//
+// Expressions resolving to non-string values (objects, arrays, numbers) are
+// JSON-encoded so they can be written to the agent's sandbox as files.
+func evaluateAttachments(attachments []*tasks.AgentAttachment, state *utils.State) error {
+	for _, attachment := range attachments {
+		if model.IsStrictExpr(attachment.Content) && !isRuntimePlaceholder(attachment.Content) {
+			evaluated, err := utils.EvaluateString(attachment.Content, nil, state)
+			if err != nil {
+				return fmt.Errorf("error evaluating attachment %q content expression: %w", attachment.Name, err)
+			}
+			content, ok := evaluated.(string)
+			if !ok {
+				encoded, err := json.Marshal(evaluated)
+				if err != nil {
+					return fmt.Errorf("error encoding attachment %q content: %w", attachment.Name, err)
+				}
+				content = string(encoded)
+			}
+			attachment.Content = content
+		}
+
+		if err := validateAttachmentSize(attachment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateAttachmentSize checks an attachment's resolved content against its
+// size limit.
+func validateAttachmentSize(attachment *tasks.AgentAttachment) error {
+	limit := int(attachment.MaxSizeBytes)
+	if limit == 0 {
+		limit = defaultAttachmentMaxSizeBytes
+	}
+	if len(attachment.Content) > limit {
+		return fmt.Errorf("attachment %q is %d bytes, exceeding the %d byte limit",
+			attachment.Name, len(attachment.Content), limit)
+	}
+	return nil
+}
+
+// executionAttachments converts resolved task attachments to the attachments
+// of an agent execution.
+func executionAttachments(attachments []*tasks.AgentAttachment) []*agentexecv1.ExecutionAttachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	result := make([]*agentexecv1.ExecutionAttachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		mimeType := attachment.MimeType
+		if mimeType == "" {
+			mimeType = "text/plain"
+		}
+		result = append(result, &agentexecv1.ExecutionAttachment{
+			Name:     attachment.Name,
+			Content:  []byte(attachment.Content),
+			MimeType: mimeType,
+		})
+	}
+	return result
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	agentexecv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
+)
+
+// maxOutputSchemaAttempts bounds how many times an agent is invoked when its
+// response does not conform to the declared output schema.
+const maxOutputSchemaAttempts = 3
+
+// agentOutputSchema returns the output schema that applies to an agent call:
+// the task's own override, or the schema the server resolved from the agent
+// onto the completed execution. Returns nil when no schema is declared.
+func agentOutputSchema(res any, cfg *tasks.AgentCallTaskConfig) map[string]any {
+	if schema := cfg.GetConfig().GetOutputSchema(); schema != nil {
+		return schema.AsMap()
+	}
+
+	execution, ok := res.(map[string]any)
+	if !ok {
+		return nil
+	}
+	spec, _ := execution["spec"].(map[string]any)
+	for _, configKey := range []string{"executionConfig", "execution_config"} {
+		executionConfig, _ := spec[configKey].(map[string]any)
+		for _, schemaKey := range []string{"outputSchema", "output_schema"} {
+			if schema, ok := executionConfig[schemaKey].(map[string]any); ok && len(schema) > 0 {
+				return schema
+			}
+		}
+	}
+	return nil
+}
+
+// parseAgentStructuredOutput decodes the agent's final AI message as JSON and
+// validates it against schema. Markdown code fences around the JSON are
+// tolerated since models add them despite instructions.
+func parseAgentStructuredOutput(res any, schema map[string]any) (any, error) {
+	var final string
+	found := false
+	for _, msg := range agentMessagesFromResult(res) {
+		if msgType, _ := msg["type"].(string); msgType == agentexecv1.MessageType_MESSAGE_AI.String() {
+			final, _ = msg["content"].(string)
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("agent returned no final response")
+	}
+
+	var output any
+	if err := json.Unmarshal([]byte(stripCodeFence(final)), &output); err != nil {
+		return nil, fmt.Errorf("agent response is not valid JSON: %w", err)
+	}
+	if err := validateAgainstSchema(output, schema, "$"); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// outputSchemaRetryMessage builds the follow-up message sent to the agent
+// after a response failed schema validation.
+func outputSchemaRetryMessage(original string, schema map[string]any, cause error) string {
+	schemaJSON, _ := json.Marshal(schema)
+	return fmt.Sprintf("%s\n\nYour previous response was rejected: %v\n"+
+		"Respond with only a JSON object that conforms to this JSON Schema:\n%s",
+		original, cause, schemaJSON)
+}
+
+func stripCodeFence(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "```") {
+		return text
+	}
+	text = strings.TrimPrefix(text, "```")
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[i+1:]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "```"))
+}
+
+// validateAgainstSchema checks value against the subset of JSON Schema used
+// for agent output schemas: type, enum, properties, required,
+// additionalProperties and items.
+func validateAgainstSchema(value any, schema map[string]any, path string) error {
+	if typ, ok := schema["type"].(string); ok && !matchesSchemaType(value, typ) {
+		return fmt.Errorf("%s: expected %s, got %s", path, typ, jsonTypeName(value))
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		matched := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(value, allowed) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: value %v is not one of %v", path, value, enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		if required, ok := schema["required"].([]any); ok {
+			for _, name := range required {
+				key, _ := name.(string)
+				if _, ok := v[key]; !ok {
+					return fmt.Errorf("%s: missing required property %q", path, key)
+				}
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			propSchema, ok := properties[key].(map[string]any)
+			if !ok {
+				if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+					return fmt.Errorf("%s: unexpected property %q", path, key)
+				}
+				continue
+			}
+			if err := validateAgainstSchema(v[key], propSchema, path+"."+key); err != nil {
+				return err
+			}
+		}
+	case []any:
+		items, ok := schema["items"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		for i, item := range v {
+			if err := validateAgainstSchema(item, items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func matchesSchemaType(value any, typ string) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return true
+	}
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
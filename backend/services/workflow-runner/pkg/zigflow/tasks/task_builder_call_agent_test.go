@@ -0,0 +1,206 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"testing"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	agentexecv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestAgentOutputMode(t *testing.T) {
+	schema, err := structpb.NewStruct(map[string]any{"type": "object"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		cfg      *tasks.AgentCallTaskConfig
+		expected agentexecv1.CallbackOutputMode
+	}{
+		{
+			name:     "no options completes with the full execution",
+			cfg:      &tasks.AgentCallTaskConfig{},
+			expected: agentexecv1.CallbackOutputMode_CALLBACK_OUTPUT_MODE_UNSPECIFIED,
+		},
+		{
+			name:     "stream to context",
+			cfg:      &tasks.AgentCallTaskConfig{StreamToContext: true},
+			expected: agentexecv1.CallbackOutputMode_CALLBACK_OUTPUT_STREAM_TO_CONTEXT,
+		},
+		{
+			name:     "final output only takes precedence",
+			cfg:      &tasks.AgentCallTaskConfig{StreamToContext: true, FinalOutputOnly: true},
+			expected: agentexecv1.CallbackOutputMode_CALLBACK_OUTPUT_FINAL_ONLY,
+		},
+		{
+			name: "output schema needs the full execution",
+			cfg: &tasks.AgentCallTaskConfig{
+				FinalOutputOnly: true,
+				Config:          &tasks.AgentExecutionConfig{OutputSchema: schema},
+			},
+			expected: agentexecv1.CallbackOutputMode_CALLBACK_OUTPUT_MODE_UNSPECIFIED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, agentOutputMode(tt.cfg))
+		})
+	}
+}
+
+func TestAgentOutputSchema(t *testing.T) {
+	schema := map[string]any{"type": "object"}
+
+	override, err := structpb.NewStruct(map[string]any{"type": "array"})
+	require.NoError(t, err)
+	cfg := &tasks.AgentCallTaskConfig{Config: &tasks.AgentExecutionConfig{OutputSchema: override}}
+	assert.Equal(t, map[string]any{"type": "array"}, agentOutputSchema(nil, cfg))
+
+	execution := map[string]any{
+		"spec": map[string]any{
+			"executionConfig": map[string]any{"outputSchema": schema},
+		},
+	}
+	assert.Equal(t, schema, agentOutputSchema(execution, &tasks.AgentCallTaskConfig{}))
+	assert.Nil(t, agentOutputSchema(map[string]any{}, &tasks.AgentCallTaskConfig{}))
+}
+
+func TestParseAgentStructuredOutput(t *testing.T) {
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"severity", "issues"},
+		"properties": map[string]any{
+			"severity": map[string]any{"type": "string", "enum": []any{"low", "high"}},
+			"issues": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"line": map[string]any{"type": "integer"}},
+				},
+			},
+		},
+	}
+	result := func(content string) any {
+		return map[string]any{
+			"status": map[string]any{
+				"messages": []any{
+					map[string]any{"type": "MESSAGE_AI", "content": "draft"},
+					map[string]any{"type": "MESSAGE_AI", "content": content},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    any
+		wantErr string
+	}{
+		{
+			name:    "valid object",
+			content: `{"severity": "high", "issues": [{"line": 12}]}`,
+			want:    map[string]any{"severity": "high", "issues": []any{map[string]any{"line": float64(12)}}},
+		},
+		{
+			name:    "code fence is stripped",
+			content: "```json\n{\"severity\": \"low\", \"issues\": []}\n```",
+			want:    map[string]any{"severity": "low", "issues": []any{}},
+		},
+		{name: "not json", content: "Looks good to me", wantErr: "not valid JSON"},
+		{name: "missing required", content: `{"severity": "low"}`, wantErr: `missing required property "issues"`},
+		{name: "enum mismatch", content: `{"severity": "medium", "issues": []}`, wantErr: "$.severity"},
+		{name: "nested type mismatch", content: `{"severity": "low", "issues": [{"line": 1.5}]}`, wantErr: "$.issues[0].line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAgentStructuredOutput(result(tt.content), schema)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvaluateAttachments(t *testing.T) {
+	state := utils.NewState()
+	state.Context = map[string]any{
+		"fetch-diff": map[string]any{
+			"body":  "--- a/main.go\n+++ b/main.go",
+			"files": []any{"main.go"},
+		},
+	}
+
+	attachments := []*tasks.AgentAttachment{
+		{Name: "diff.patch", Content: `${ $context["fetch-diff"].body }`, MimeType: "text/x-patch"},
+		{Name: "files.json", Content: `${ $context["fetch-diff"].files }`},
+		{Name: "notes.md", Content: "Focus on error handling"},
+	}
+	require.NoError(t, evaluateAttachments(attachments, state))
+	assert.Equal(t, "--- a/main.go\n+++ b/main.go", attachments[0].Content)
+	assert.Equal(t, `["main.go"]`, attachments[1].Content)
+	assert.Equal(t, "Focus on error handling", attachments[2].Content)
+
+	converted := executionAttachments(attachments)
+	require.Len(t, converted, 3)
+	assert.Equal(t, "text/x-patch", converted[0].MimeType)
+	assert.Equal(t, "text/plain", converted[1].MimeType)
+	assert.Equal(t, []byte("Focus on error handling"), converted[2].Content)
+
+	tooLarge := []*tasks.AgentAttachment{
+		{Name: "diff.patch", Content: `${ $context["fetch-diff"].body }`, MaxSizeBytes: 8},
+	}
+	assert.ErrorContains(t, evaluateAttachments(tooLarge, state), "exceeding the 8 byte limit")
+}
+
+func TestAgentHasVersion(t *testing.T) {
+	agent := &agentv1.Agent{
+		Metadata: &apiresource.ApiResourceMetadata{
+			Tags:    []string{"stable", "v2"},
+			Version: &apiresource.ApiResourceMetadataVersion{Id: "abc123"},
+		},
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"", true},
+		{"latest", true},
+		{"stable", true},
+		{"abc123", true},
+		{"canary", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, agentHasVersion(agent, tt.version))
+		})
+	}
+}
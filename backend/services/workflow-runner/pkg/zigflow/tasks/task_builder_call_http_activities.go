@@ -20,7 +20,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -29,6 +28,7 @@ import (
 	"time"
 
 	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
 	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/temporal"
 )
@@ -45,6 +45,36 @@ func (c *CallHTTPActivities) CallHTTPActivity(ctx context.Context, task *model.C
 
 	info := activity.GetInfo(ctx)
 
+	responseFormat, err := httpResponseFormatFromMetadata(task)
+	if err != nil {
+		logger.Error("Invalid response format", "error", err)
+		return nil, temporal.NewNonRetryableApplicationError("invalid response format", "CallHTTP error", err)
+	}
+	bodyEncoding, err := httpBodyEncodingFromMetadata(task)
+	if err != nil {
+		logger.Error("Invalid body encoding", "error", err)
+		return nil, temporal.NewNonRetryableApplicationError("invalid body encoding", "CallHTTP error", err)
+	}
+	statusCheck, err := httpStatusCheckFromMetadata(task)
+	if err != nil {
+		logger.Error("Invalid status check", "error", err)
+		return nil, temporal.NewNonRetryableApplicationError("invalid status check", "CallHTTP error", err)
+	}
+
+	// The cache directive and the secret check must be read before runtime
+	// placeholders are resolved: afterwards, secret-derived headers can no
+	// longer be told apart from literal ones.
+	cache, err := httpCacheFromMetadata(task)
+	if err != nil {
+		logger.Error("Invalid cache configuration", "error", err)
+		return nil, temporal.NewNonRetryableApplicationError("invalid cache configuration", "CallHTTP error", err)
+	}
+	if cache != nil && !cache.IncludeAuth && headersReferenceSecrets(task.With.Headers) {
+		logger.Debug("Request headers reference runtime secrets, bypassing cache")
+		utils.RecordTaskMetadata(ctx, "cache", httpCacheBypass)
+		cache = nil
+	}
+
 	// **CRITICAL SECURITY**: Resolve runtime placeholders just-in-time (JIT)
 	// Task has evaluated expressions, but still contains runtime placeholders like:
 	//   - ${.secrets.API_KEY} → resolved to actual secret value
@@ -73,12 +103,94 @@ func (c *CallHTTPActivities) CallHTTPActivity(ctx context.Context, task *model.C
 		logger.Debug("Runtime placeholders resolved successfully")
 	}
 
+	var cacheKey string
+	if cache != nil {
+		// Check the host policy before the cache, so cached responses are
+		// never served for a denied host
+		if err := httpHostPolicy.checkEndpoint(task.With.Endpoint.String()); err != nil {
+			logger.Error("HTTP call denied by host policy", "url", task.With.Endpoint.String(), "error", err)
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), utils.PolicyDeniedErrorType, err)
+		}
+
+		cacheKey = cache.key(task)
+		if response, body, ok := httpResponses.get(cacheKey); ok {
+			logger.Debug("Serving HTTP call from cache", "method", response.Request.Method, "url", response.Request.URI)
+			utils.RecordTaskMetadata(ctx, "cache", httpCacheHit)
+			utils.RecordTaskMetadata(ctx, utils.TaskMetadataHTTPStatus, response.StatusCode)
+			return c.output(ctx, task, response, body, responseFormat, runtimeEnv)
+		}
+		utils.RecordTaskMetadata(ctx, "cache", httpCacheMiss)
+	}
+
+	// Build the transport from the task's TLS configuration and proxy
+	// override. Certificates and keys are resolved from the runtime
+	// environment here, in memory only.
+	transport, err := httpTransport(task, runtimeEnv)
+	if err != nil {
+		logger.Error("Invalid transport configuration", "error", err)
+		return nil, temporal.NewNonRetryableApplicationError("invalid transport configuration", "CallHTTP error", err)
+	}
+
+	stopHeartbeat := heartbeatUntilDone(ctx)
+	httpResponse, bodyRes, err := c.doHTTPCall(ctx, task, info.StartToCloseTimeout, transport, bodyEncoding, statusCheck, runtimeEnv)
+	stopHeartbeat()
+	if err != nil {
+		return nil, err
+	}
+
+	if cache != nil {
+		httpResponses.put(cacheKey, httpResponse, bodyRes, cache.TTL)
+	}
+
+	return c.output(ctx, task, httpResponse, bodyRes, responseFormat, runtimeEnv)
+}
+
+// httpHeartbeatInterval is how often an in-flight HTTP call heartbeats.
+const httpHeartbeatInterval = 5 * time.Second
+
+// heartbeatUntilDone heartbeats the activity until the returned function is
+// called. Cancellation of the workflow is only delivered to an activity on a
+// heartbeat, so this is what lets a cancel abort the request through ctx.
+func heartbeatUntilDone(ctx context.Context) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(httpHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				activity.RecordHeartbeat(ctx, "waiting for HTTP response")
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// doHTTPCall makes the HTTP call of a resolved task and returns the response
+// with its raw body. Responses failing the task's status check are returned
+// as errors.
+func (c *CallHTTPActivities) doHTTPCall(
+	ctx context.Context,
+	task *model.CallHTTP,
+	timeout time.Duration,
+	transport http.RoundTripper,
+	bodyEncoding *httpBodyEncoding,
+	statusCheck httpStatusCheck,
+	runtimeEnv map[string]any,
+) (HTTPResponse, []byte, error) {
+	logger := activity.GetLogger(ctx)
+
 	// Task now has fully resolved values (expressions + runtime placeholders)
-	resp, method, url, reqHeaders, err := c.callHTTPAction(ctx, task, info.StartToCloseTimeout)
+	resp, method, url, reqHeaders, err := c.callHTTPAction(ctx, task, timeout, transport, bodyEncoding, runtimeEnv)
 	if err != nil {
 		logger.Error("Error making HTTP call", "method", method, "url", url, "error", err)
-		return nil, err
+		return HTTPResponse{}, nil, err
 	}
+	utils.RecordTaskMetadata(ctx, utils.TaskMetadataHTTPStatus, resp.StatusCode)
 	defer func() {
 		err = resp.Body.Close()
 		if err != nil {
@@ -89,54 +201,20 @@ func (c *CallHTTPActivities) CallHTTPActivity(ctx context.Context, task *model.C
 	bodyRes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		logger.Error("Error reading HTTP body", "method", method, "url", url, "error", err)
-		return nil, err
+		return HTTPResponse{}, nil, err
 	}
 
-	// Try converting the body as JSON, returning as string if not possible
-	var content any
-	var bodyJSON map[string]any
-	if err := json.Unmarshal(bodyRes, &bodyJSON); err != nil {
-		// Log error
-		logger.Debug("Error converting body to JSON", "error", err)
-		content = string(bodyRes)
-	} else {
+	// Error details carry the body as JSON if possible, as a string otherwise.
+	// Successful responses are converted to the task's response format when
+	// the output is built.
+	var content any = string(bodyRes)
+	if bodyJSON, ok := parseJSONBody(bodyRes); ok {
 		content = bodyJSON
 	}
 
-	// Treat redirects as an error - if you have "redirect = true", this will be ignored
-	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
-		logger.Error("CallHTTP returned 3xx status", "statusCode", resp.StatusCode, "responseBody", content)
-		return nil, temporal.NewNonRetryableApplicationError(
-			"CallHTTP returned 3xx status code",
-			"CallHTTP error",
-			errors.New(resp.Status),
-			content,
-		)
-	}
-
-	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-		// Client error - treat as non-retryable error as we need to fix it
-		logger.Error("CallHTTP returned 4xx error", "statusCode", resp.StatusCode, "responseBody", content)
-		return nil, temporal.NewNonRetryableApplicationError(
-			"CallHTTP returned 4xx status code",
-			"CallHTTP error",
-			errors.New(resp.Status),
-			content,
-		)
-	}
-
-	if resp.StatusCode >= 500 && resp.StatusCode < 600 {
-		// Server error - treat as retryable error as we can't fix it
-		logger.Error("CallHTTP returned 5xx error", "statusCode", resp.StatusCode, "responseBody", content)
-		return nil, temporal.NewApplicationError(
-			"CallHTTP returned 5xx error",
-			"CallHTTP error",
-			errors.New(resp.Status),
-			map[string]any{
-				"statusCode": resp.StatusCode,
-				"content":    content,
-			},
-		)
+	if err := statusCheck.check(resp, content, bodyRes); err != nil {
+		logger.Error("CallHTTP failed its status check", "statusCode", resp.StatusCode, "responseBody", content)
+		return HTTPResponse{}, nil, err
 	}
 
 	respHeader := map[string]string{}
@@ -152,10 +230,39 @@ func (c *CallHTTPActivities) CallHTTPActivity(ctx context.Context, task *model.C
 		},
 		StatusCode: resp.StatusCode,
 		Headers:    respHeader,
-		Content:    content,
 	}
 
-	output := c.parseOutput(task.With.Output, httpResponse, bodyRes)
+	return httpResponse, bodyRes, nil
+}
+
+// output builds the task output from an HTTP response, with the body
+// converted to the task's response format. HEAD and OPTIONS calls output only
+// the status code and headers.
+func (c *CallHTTPActivities) output(
+	ctx context.Context,
+	task *model.CallHTTP,
+	httpResponse HTTPResponse,
+	bodyRes []byte,
+	responseFormat string,
+	runtimeEnv map[string]any,
+) (any, error) {
+	logger := activity.GetLogger(ctx)
+
+	var output any
+	if httpHeaderOnlyMethods[httpResponse.Request.Method] {
+		// HEAD and OPTIONS responses have no body to decode
+		output = headerOnlyHTTPOutput(httpResponse)
+	} else {
+		content, format, err := decodeHTTPBody(responseFormat, bodyRes)
+		if err != nil {
+			logger.Error("Error converting HTTP body", "format", responseFormat, "error", err)
+			return nil, temporal.NewNonRetryableApplicationError("CallHTTP response does not match its format", "CallHTTP error", err)
+		}
+		utils.RecordTaskMetadata(ctx, httpResponseFormatMetadata, format)
+		httpResponse.Content = content
+
+		output = c.parseOutput(task.With.Output, httpResponse, bodyRes)
+	}
 	
 	// **SECURITY**: Sanitize output to detect accidental secret leakage
 	// This is a defensive measure - ideally secrets should never appear in outputs,
@@ -167,10 +274,17 @@ func (c *CallHTTPActivities) CallHTTPActivity(ctx context.Context, task *model.C
 		}
 	}
 	
-	return output, err
+	return output, nil
 }
 
-func (c *CallHTTPActivities) callHTTPAction(ctx context.Context, task *model.CallHTTP, timeout time.Duration) (
+func (c *CallHTTPActivities) callHTTPAction(
+	ctx context.Context,
+	task *model.CallHTTP,
+	timeout time.Duration,
+	transport http.RoundTripper,
+	bodyEncoding *httpBodyEncoding,
+	runtimeEnv map[string]any,
+) (
 	resp *http.Response,
 	method, url string,
 	reqHeaders map[string]string,
@@ -183,7 +297,19 @@ func (c *CallHTTPActivities) callHTTPAction(ctx context.Context, task *model.Cal
 
 	method = strings.ToUpper(args.Method)
 	url = args.Endpoint.String()
-	body := args.Body
+	body := []byte(args.Body)
+
+	// Form and multipart bodies are encoded from the evaluated JSON body,
+	// with runtime placeholders resolved here so secrets stay out of history
+	var contentType string
+	if bodyEncoding != nil {
+		body, contentType, err = bodyEncoding.encode(args.Body, runtimeEnv)
+		if err != nil {
+			logger.Error("Error encoding HTTP body", "encoding", bodyEncoding.Encoding, "error", err)
+			err = temporal.NewNonRetryableApplicationError("invalid request body", "CallHTTP error", err)
+			return resp, method, url, reqHeaders, err
+		}
+	}
 
 	logger.Debug("Making HTTP call", "method", method, "url", url)
 	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
@@ -198,6 +324,15 @@ func (c *CallHTTPActivities) callHTTPAction(ctx context.Context, task *model.Cal
 		req.Header.Add(k, v)
 		reqHeaders[k] = v
 	}
+	if contentType != "" {
+		for k := range reqHeaders {
+			if strings.EqualFold(k, "Content-Type") {
+				delete(reqHeaders, k)
+			}
+		}
+		req.Header.Set("Content-Type", contentType)
+		reqHeaders["Content-Type"] = contentType
+	}
 
 	// Add in query strings
 	q := req.URL.Query()
@@ -207,16 +342,31 @@ func (c *CallHTTPActivities) callHTTPAction(ctx context.Context, task *model.Cal
 	req.URL.RawQuery = q.Encode()
 
 	client := &http.Client{
-		Timeout: timeout,
+		Timeout:   timeout,
+		Transport: transport,
 	}
 
 	if !args.Redirect {
 		client.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
 			return http.ErrUseLastResponse
 		}
+	} else if httpHostPolicy != nil {
+		client.CheckRedirect = func(redirect *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return httpHostPolicy.checkURL(redirect.URL)
+		}
 	}
 
-	resp, err = client.Do(req)
+	if err = httpHostPolicy.checkURL(req.URL); err == nil {
+		resp, err = client.Do(req)
+	}
+	var policyErr *hostPolicyError
+	if errors.As(err, &policyErr) {
+		logger.Error("HTTP call denied by host policy", "method", method, "url", url, "error", policyErr)
+		err = temporal.NewNonRetryableApplicationError(policyErr.Error(), utils.PolicyDeniedErrorType, policyErr)
+	}
 	if err != nil {
 		return resp, method, url, reqHeaders, err
 	}
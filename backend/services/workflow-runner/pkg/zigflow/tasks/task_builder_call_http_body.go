@@ -0,0 +1,262 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+)
+
+const (
+	// Request body encodings of an HTTP call task. Without one, the body is
+	// sent as JSON.
+	httpBodyEncodingJSON      = "json"
+	httpBodyEncodingForm      = "form"
+	httpBodyEncodingMultipart = "multipart"
+
+	httpContentTypeForm = "application/x-www-form-urlencoded"
+)
+
+// httpMultipartPart configures a part of a multipart request body.
+type httpMultipartPart struct {
+	Name        string
+	Filename    string
+	ContentType string
+	Base64      bool
+}
+
+// httpBodyEncoding is the request body encoding of an HTTP call task.
+type httpBodyEncoding struct {
+	Encoding string
+	Parts    []httpMultipartPart
+}
+
+// httpBodyEncodingFromMetadata reads the request body encoding of an HTTP
+// call task, carried in the task metadata because the DSL HTTP call only
+// sends JSON bodies. Returns nil if the body is sent as JSON.
+func httpBodyEncodingFromMetadata(task *model.CallHTTP) (*httpBodyEncoding, error) {
+	raw, ok := task.Metadata[metadata.MetadataHTTPBodyEncoding]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	encoding, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s metadata: expected a string, got %T", metadata.MetadataHTTPBodyEncoding, raw)
+	}
+	switch encoding {
+	case "", httpBodyEncodingJSON:
+		return nil, nil
+	case httpBodyEncodingForm:
+		return &httpBodyEncoding{Encoding: encoding}, nil
+	case httpBodyEncodingMultipart:
+	default:
+		return nil, fmt.Errorf("invalid %s metadata: unknown encoding %q", metadata.MetadataHTTPBodyEncoding, encoding)
+	}
+
+	cfg := &httpBodyEncoding{Encoding: encoding}
+	rawParts, ok := task.Metadata[metadata.MetadataHTTPMultipartParts]
+	if !ok || rawParts == nil {
+		return cfg, nil
+	}
+	list, ok := rawParts.([]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s metadata: expected a list, got %T", metadata.MetadataHTTPMultipartParts, rawParts)
+	}
+	for i, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s metadata: part %d: expected an object, got %T", metadata.MetadataHTTPMultipartParts, i, item)
+		}
+		name, _ := m["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("invalid %s metadata: part %d has no name", metadata.MetadataHTTPMultipartParts, i)
+		}
+		part := httpMultipartPart{Name: name}
+		part.Filename, _ = m["filename"].(string)
+		part.ContentType, _ = m["contentType"].(string)
+		part.Base64, _ = m["base64"].(bool)
+		cfg.Parts = append(cfg.Parts, part)
+	}
+	return cfg, nil
+}
+
+// encode converts an evaluated JSON request body to the encoding and
+// returns it with the Content-Type header to send. Runtime placeholders in
+// the body fields are resolved from runtimeEnv.
+func (e *httpBodyEncoding) encode(body json.RawMessage, runtimeEnv map[string]any) ([]byte, string, error) {
+	fields := map[string]any{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&fields); err != nil {
+			return nil, "", fmt.Errorf("%s body must be an object: %w", e.Encoding, err)
+		}
+	}
+	if len(runtimeEnv) > 0 {
+		resolved, err := ResolveObject(fields, runtimeEnv)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to resolve runtime placeholders: %w", err)
+		}
+		fields = resolved.(map[string]any)
+	}
+
+	if e.Encoding == httpBodyEncodingForm {
+		return encodeFormBody(fields)
+	}
+	return e.encodeMultipart(fields)
+}
+
+// encodeFormBody url-encodes the body fields, flattening objects and arrays
+// with bracket notation: {"a": {"b": 1}, "c": [2]} is a[b]=1&c[0]=2.
+// Null values are omitted.
+func encodeFormBody(fields map[string]any) ([]byte, string, error) {
+	values := url.Values{}
+	for key, value := range fields {
+		if err := addFormValue(values, key, value); err != nil {
+			return nil, "", err
+		}
+	}
+	return []byte(values.Encode()), httpContentTypeForm, nil
+}
+
+func addFormValue(values url.Values, key string, value any) error {
+	switch v := value.(type) {
+	case nil:
+	case string:
+		values.Add(key, v)
+	case json.Number:
+		values.Add(key, v.String())
+	case bool:
+		values.Add(key, strconv.FormatBool(v))
+	case map[string]any:
+		for k, item := range v {
+			if err := addFormValue(values, key+"["+k+"]", item); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if err := addFormValue(values, key+"["+strconv.Itoa(i)+"]", item); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("form field %q has unsupported type %T", key, value)
+	}
+	return nil
+}
+
+// encodeMultipart writes the body fields as the parts of a multipart/form-data
+// body: configured parts first, in order, then the other fields sorted by
+// name.
+func (e *httpBodyEncoding) encodeMultipart(fields map[string]any) ([]byte, string, error) {
+	parts := make([]httpMultipartPart, 0, len(fields))
+	configured := make(map[string]bool, len(e.Parts))
+	for _, part := range e.Parts {
+		configured[part.Name] = true
+		parts = append(parts, part)
+	}
+	var others []string
+	for name := range fields {
+		if !configured[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		parts = append(parts, httpMultipartPart{Name: name})
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	for _, part := range parts {
+		value, ok := fields[part.Name]
+		if !ok {
+			return nil, "", fmt.Errorf("multipart part %q has no value in the body", part.Name)
+		}
+		content, contentType, err := multipartContent(part, value)
+		if err != nil {
+			return nil, "", err
+		}
+
+		disposition := map[string]string{"name": part.Name}
+		if part.Filename != "" {
+			disposition["filename"] = part.Filename
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", mime.FormatMediaType("form-data", disposition))
+		if contentType != "" {
+			header.Set("Content-Type", contentType)
+		}
+
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := w.Write(content); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
+
+// multipartContent returns the content of a part and its content type.
+// Strings are sent as-is, base64-decoded if the part says so; other values
+// are sent as JSON.
+func multipartContent(part httpMultipartPart, value any) ([]byte, string, error) {
+	contentType := part.ContentType
+	if contentType == "" && part.Filename != "" {
+		contentType = "application/octet-stream"
+	}
+
+	s, isString := value.(string)
+	switch {
+	case part.Base64 && !isString:
+		return nil, "", fmt.Errorf("multipart part %q: base64 content must be a string, got %T", part.Name, value)
+	case part.Base64:
+		content, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, "", fmt.Errorf("multipart part %q: invalid base64 content: %w", part.Name, err)
+		}
+		return content, contentType, nil
+	case isString:
+		return []byte(s), contentType, nil
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, "", fmt.Errorf("multipart part %q: %w", part.Name, err)
+	}
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	return content, contentType, nil
+}
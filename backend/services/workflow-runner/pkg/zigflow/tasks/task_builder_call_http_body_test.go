@@ -0,0 +1,217 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestEncodeFormBody(t *testing.T) {
+	encoding := &httpBodyEncoding{Encoding: httpBodyEncodingForm}
+	body, contentType, err := encoding.encode([]byte(`{
+		"amount": 1250,
+		"rate": 0.5,
+		"capture": true,
+		"note": "a&b c",
+		"skip": null,
+		"card": {"number": "4242", "exp": {"month": 12}},
+		"items": ["a", {"sku": "x"}]
+	}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, httpContentTypeForm, contentType)
+
+	values, err := url.ParseQuery(string(body))
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"amount":           {"1250"},
+		"rate":             {"0.5"},
+		"capture":          {"true"},
+		"note":             {"a&b c"},
+		"card[number]":     {"4242"},
+		"card[exp][month]": {"12"},
+		"items[0]":         {"a"},
+		"items[1][sku]":    {"x"},
+	}, values)
+}
+
+func TestEncodeFormBodyRejectsNonObject(t *testing.T) {
+	encoding := &httpBodyEncoding{Encoding: httpBodyEncodingForm}
+	_, _, err := encoding.encode([]byte(`["a"]`), nil)
+	assert.ErrorContains(t, err, "form body must be an object")
+}
+
+func TestEncodeMultipartBody(t *testing.T) {
+	encoding := &httpBodyEncoding{
+		Encoding: httpBodyEncodingMultipart,
+		Parts: []httpMultipartPart{
+			{Name: "file", Filename: "report.csv", ContentType: "text/csv"},
+			{Name: "image", Filename: "logo.png", Base64: true},
+		},
+	}
+	image := base64.StdEncoding.EncodeToString([]byte("\x89PNG"))
+	body, contentType, err := encoding.encode([]byte(`{
+		"meta": {"source": "export"},
+		"file": "id,name\n1,alice\n",
+		"image": "`+image+`",
+		"comment": "daily"
+	}`), nil)
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/form-data", mediaType)
+
+	type part struct {
+		name, filename, contentType, content string
+	}
+	var parts []part
+	reader := multipart.NewReader(strings.NewReader(string(body)), params["boundary"])
+	for {
+		p, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(p)
+		require.NoError(t, err)
+		parts = append(parts, part{p.FormName(), p.FileName(), p.Header.Get("Content-Type"), string(content)})
+	}
+
+	// Configured parts come first, in order, then the others sorted by name
+	assert.Equal(t, []part{
+		{"file", "report.csv", "text/csv", "id,name\n1,alice\n"},
+		{"image", "logo.png", "application/octet-stream", "\x89PNG"},
+		{"comment", "", "", "daily"},
+		{"meta", "", "application/json", `{"source":"export"}`},
+	}, parts)
+}
+
+func TestEncodeMultipartBodyErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		parts     []httpMultipartPart
+		body      string
+		expectErr string
+	}{
+		{
+			name:      "missing value",
+			parts:     []httpMultipartPart{{Name: "file"}},
+			body:      `{}`,
+			expectErr: `multipart part "file" has no value in the body`,
+		},
+		{
+			name:      "invalid base64",
+			parts:     []httpMultipartPart{{Name: "file", Base64: true}},
+			body:      `{"file": "not base64!"}`,
+			expectErr: "invalid base64 content",
+		},
+		{
+			name:      "base64 non-string",
+			parts:     []httpMultipartPart{{Name: "file", Base64: true}},
+			body:      `{"file": 1}`,
+			expectErr: "base64 content must be a string",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			encoding := &httpBodyEncoding{Encoding: httpBodyEncodingMultipart, Parts: tc.parts}
+			_, _, err := encoding.encode([]byte(tc.body), nil)
+			assert.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestHTTPBodyEncodingFromMetadata(t *testing.T) {
+	encoding, err := httpBodyEncodingFromMetadata(&model.CallHTTP{})
+	require.NoError(t, err)
+	assert.Nil(t, encoding)
+
+	task := &model.CallHTTP{TaskBase: model.TaskBase{
+		Metadata: map[string]any{
+			metadata.MetadataHTTPBodyEncoding: "multipart",
+			metadata.MetadataHTTPMultipartParts: []any{
+				map[string]any{"name": "file", "filename": "report.csv", "contentType": "text/csv", "base64": true},
+			},
+		},
+	}}
+	encoding, err = httpBodyEncodingFromMetadata(task)
+	require.NoError(t, err)
+	assert.Equal(t, &httpBodyEncoding{
+		Encoding: httpBodyEncodingMultipart,
+		Parts:    []httpMultipartPart{{Name: "file", Filename: "report.csv", ContentType: "text/csv", Base64: true}},
+	}, encoding)
+
+	task.Metadata[metadata.MetadataHTTPBodyEncoding] = "xml"
+	_, err = httpBodyEncodingFromMetadata(task)
+	assert.ErrorContains(t, err, `unknown encoding "xml"`)
+
+	task.Metadata[metadata.MetadataHTTPBodyEncoding] = "multipart"
+	task.Metadata[metadata.MetadataHTTPMultipartParts] = []any{map[string]any{"filename": "x"}}
+	_, err = httpBodyEncodingFromMetadata(task)
+	assert.ErrorContains(t, err, "part 0 has no name")
+}
+
+func TestCallHTTPActivityFormBody(t *testing.T) {
+	var contentType string
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		require.NoError(t, r.ParseForm())
+		form = r.PostForm
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	task := &model.CallHTTP{
+		TaskBase: model.TaskBase{
+			Metadata: map[string]any{metadata.MetadataHTTPBodyEncoding: httpBodyEncodingForm},
+		},
+		Call: "http",
+		With: model.HTTPArguments{
+			Method:   "POST",
+			Endpoint: model.NewEndpoint(server.URL),
+			// A Content-Type set on the task is replaced by the encoding's
+			Headers: map[string]string{"content-type": "application/json"},
+			Body:    []byte(`{"amount": 1250, "card": {"number": "${.secrets.CARD_NUMBER}"}}`),
+		},
+	}
+	runtimeEnv := secretEnv(map[string]string{"CARD_NUMBER": "4242"})
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestActivityEnvironment()
+	env.RegisterActivity(&CallHTTPActivities{})
+
+	_, err := env.ExecuteActivity((&CallHTTPActivities{}).CallHTTPActivity, task, nil, runtimeEnv)
+	require.NoError(t, err)
+	assert.Equal(t, httpContentTypeForm, contentType)
+	assert.Equal(t, url.Values{"amount": {"1250"}, "card[number]": {"4242"}}, form)
+}
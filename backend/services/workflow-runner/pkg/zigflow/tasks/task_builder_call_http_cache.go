@@ -0,0 +1,213 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+)
+
+const (
+	httpCacheKeyURI     = "uri"
+	httpCacheKeyRequest = "request"
+
+	// httpCacheMaxEntries bounds the memory used by cached responses.
+	httpCacheMaxEntries = 1024
+
+	// Values of the "cache" task metadata recorded by the HTTP activity.
+	httpCacheHit    = "hit"
+	httpCacheMiss   = "miss"
+	httpCacheBypass = "bypass"
+)
+
+// httpResponses is the runner's local cache of HTTP call responses, shared by
+// all workflows the runner executes.
+var httpResponses = newHTTPResponseCache(httpCacheMaxEntries)
+
+// httpCacheConfig is the cache directive of an HTTP call task, carried in the
+// task metadata because the DSL HTTP call has no cache settings.
+type httpCacheConfig struct {
+	TTL         time.Duration
+	Key         string
+	IncludeAuth bool
+}
+
+// httpCacheFromMetadata reads the cache directive of an HTTP call task.
+// Returns nil if the task is not cacheable.
+func httpCacheFromMetadata(task *model.CallHTTP) (*httpCacheConfig, error) {
+	raw, ok := task.Metadata[metadata.MetadataHTTPCache]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s metadata: expected an object, got %T", metadata.MetadataHTTPCache, raw)
+	}
+
+	if method := strings.ToUpper(task.With.Method); method != "GET" {
+		return nil, fmt.Errorf("invalid %s metadata: only GET requests can be cached, got %s", metadata.MetadataHTTPCache, method)
+	}
+
+	ttlStr, _ := m["ttl"].(string)
+	ttl, err := time.ParseDuration(ttlStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: error parsing ttl to duration: %w", metadata.MetadataHTTPCache, err)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("invalid %s metadata: ttl must be positive", metadata.MetadataHTTPCache)
+	}
+
+	cfg := &httpCacheConfig{
+		TTL: ttl,
+		Key: httpCacheKeyURI,
+	}
+	if v, ok := m["key"]; ok {
+		key, _ := v.(string)
+		switch key {
+		case httpCacheKeyURI, httpCacheKeyRequest:
+			cfg.Key = key
+		default:
+			return nil, fmt.Errorf("invalid %s metadata: unknown key %v", metadata.MetadataHTTPCache, v)
+		}
+	}
+	if v, ok := m["includeAuth"].(bool); ok {
+		cfg.IncludeAuth = v
+	}
+	return cfg, nil
+}
+
+// headersReferenceSecrets reports whether any request header is derived from
+// a runtime secret. It must be called before runtime placeholders are
+// resolved.
+func headersReferenceSecrets(headers map[string]string) bool {
+	for _, v := range headers {
+		if strings.Contains(v, "${.secrets.") {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the cache key of a resolved HTTP call. The key is a hash, so
+// resolved secrets in headers are not kept in memory as map keys.
+func (c *httpCacheConfig) key(task *model.CallHTTP) string {
+	args := &task.With
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\nredirect=%t\n", strings.ToUpper(args.Method), args.Endpoint.String(), args.Redirect)
+
+	query := make([]string, 0, len(args.Query))
+	for k, v := range args.Query {
+		query = append(query, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(query)
+	fmt.Fprintf(h, "query:%s\n", strings.Join(query, "&"))
+
+	if c.Key == httpCacheKeyRequest {
+		headers := make([]string, 0, len(args.Headers))
+		for k, v := range args.Headers {
+			headers = append(headers, strings.ToLower(k)+": "+v)
+		}
+		sort.Strings(headers)
+		fmt.Fprintf(h, "headers:%s\n", strings.Join(headers, "\n"))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cachedHTTPResponse is a successful response kept in the cache.
+type cachedHTTPResponse struct {
+	response HTTPResponse
+	body     []byte
+	expires  time.Time
+}
+
+// httpResponseCache is an in-memory cache of HTTP responses with a TTL per
+// entry. When full, the entry closest to expiring is evicted.
+type httpResponseCache struct {
+	mu         sync.Mutex
+	entries    map[string]cachedHTTPResponse
+	maxEntries int
+	now        func() time.Time
+}
+
+func newHTTPResponseCache(maxEntries int) *httpResponseCache {
+	return &httpResponseCache{
+		entries:    map[string]cachedHTTPResponse{},
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+// get returns the fresh response cached under key, if any.
+func (c *httpResponseCache) get(key string) (HTTPResponse, []byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return HTTPResponse{}, nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return HTTPResponse{}, nil, false
+	}
+	return entry.response, entry.body, true
+}
+
+// put caches a response under key for ttl.
+func (c *httpResponseCache) put(key string, response HTTPResponse, body []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		c.evictLocked(now)
+	}
+	c.entries[key] = cachedHTTPResponse{
+		response: response,
+		body:     body,
+		expires:  now.Add(ttl),
+	}
+}
+
+// evictLocked drops expired entries, or the entry closest to expiring if
+// none has expired. The caller must hold c.mu.
+func (c *httpResponseCache) evictLocked(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+			continue
+		}
+		if oldestKey == "" || entry.expires.Before(oldest) {
+			oldestKey, oldest = k, entry.expires
+		}
+	}
+	if len(c.entries) >= c.maxEntries {
+		delete(c.entries, oldestKey)
+	}
+}
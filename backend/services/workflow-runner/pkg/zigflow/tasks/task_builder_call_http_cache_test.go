@@ -0,0 +1,209 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func httpTaskWithCache(uri string, headers map[string]string, cacheMeta map[string]any) *model.CallHTTP {
+	return &model.CallHTTP{
+		TaskBase: model.TaskBase{
+			Metadata: map[string]any{metadata.MetadataHTTPCache: cacheMeta},
+		},
+		Call: "http",
+		With: model.HTTPArguments{
+			Method:   "GET",
+			Endpoint: model.NewEndpoint(uri),
+			Headers:  headers,
+		},
+	}
+}
+
+func TestHTTPCacheFromMetadata(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		meta      map[string]any
+		expected  *httpCacheConfig
+		expectErr string
+	}{
+		{
+			name:     "defaults",
+			meta:     map[string]any{"ttl": "10m0s"},
+			expected: &httpCacheConfig{TTL: 10 * time.Minute, Key: httpCacheKeyURI},
+		},
+		{
+			name:     "all settings",
+			meta:     map[string]any{"ttl": "1h0m0s", "key": "request", "includeAuth": true},
+			expected: &httpCacheConfig{TTL: time.Hour, Key: httpCacheKeyRequest, IncludeAuth: true},
+		},
+		{
+			name:      "missing ttl",
+			meta:      map[string]any{},
+			expectErr: "error parsing ttl",
+		},
+		{
+			name:      "unknown key",
+			meta:      map[string]any{"ttl": "1m", "key": "body"},
+			expectErr: "unknown key",
+		},
+		{
+			name:      "non-GET request",
+			method:    "POST",
+			meta:      map[string]any{"ttl": "1m"},
+			expectErr: "only GET requests can be cached",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task := httpTaskWithCache("https://api.example.com/config", nil, tc.meta)
+			if tc.method != "" {
+				task.With.Method = tc.method
+			}
+
+			cfg, err := httpCacheFromMetadata(task)
+			if tc.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, cfg)
+		})
+	}
+
+	cfg, err := httpCacheFromMetadata(&model.CallHTTP{})
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+}
+
+func TestHTTPCacheKey(t *testing.T) {
+	uriKey := &httpCacheConfig{Key: httpCacheKeyURI}
+	requestKey := &httpCacheConfig{Key: httpCacheKeyRequest}
+
+	en := httpTaskWithCache("https://api.example.com/config", map[string]string{"Accept-Language": "en"}, nil)
+	fr := httpTaskWithCache("https://api.example.com/config", map[string]string{"Accept-Language": "fr"}, nil)
+	other := httpTaskWithCache("https://api.example.com/other", map[string]string{"Accept-Language": "en"}, nil)
+
+	assert.Equal(t, uriKey.key(en), uriKey.key(fr))
+	assert.NotEqual(t, requestKey.key(en), requestKey.key(fr))
+	assert.NotEqual(t, uriKey.key(en), uriKey.key(other))
+
+	en.With.Query = map[string]any{"page": "1"}
+	assert.NotEqual(t, uriKey.key(en), uriKey.key(fr))
+}
+
+func TestHTTPResponseCache(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newHTTPResponseCache(2)
+	cache.now = func() time.Time { return now }
+
+	cache.put("a", HTTPResponse{StatusCode: 200}, []byte("a"), time.Minute)
+	cache.put("b", HTTPResponse{StatusCode: 200}, []byte("b"), time.Hour)
+
+	_, body, ok := cache.get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("a"), body)
+
+	// A full cache evicts the entry closest to expiring
+	cache.put("c", HTTPResponse{StatusCode: 200}, []byte("c"), time.Hour)
+	_, _, ok = cache.get("a")
+	assert.False(t, ok)
+	_, _, ok = cache.get("b")
+	assert.True(t, ok)
+
+	// Entries expire after their TTL
+	now = now.Add(2 * time.Hour)
+	_, _, ok = cache.get("b")
+	assert.False(t, ok)
+}
+
+func TestCallHTTPActivityCache(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"call": %d}`, n)
+	}))
+	defer server.Close()
+
+	original := httpResponses
+	t.Cleanup(func() { httpResponses = original })
+
+	tests := []struct {
+		name          string
+		headers       map[string]string
+		cacheMeta     map[string]any
+		expectedCalls int32
+	}{
+		{
+			name:          "cached",
+			cacheMeta:     map[string]any{"ttl": "1m"},
+			expectedCalls: 1,
+		},
+		{
+			name:          "secret header bypasses cache",
+			headers:       map[string]string{"Authorization": "Bearer ${.secrets.TOKEN}"},
+			cacheMeta:     map[string]any{"ttl": "1m"},
+			expectedCalls: 2,
+		},
+		{
+			name:          "secret header cached on opt-in",
+			headers:       map[string]string{"Authorization": "Bearer ${.secrets.TOKEN}"},
+			cacheMeta:     map[string]any{"ttl": "1m", "includeAuth": true},
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpResponses = newHTTPResponseCache(httpCacheMaxEntries)
+			calls.Store(0)
+			runtimeEnv := secretEnv(map[string]string{"TOKEN": "s3cr3t"})
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestActivityEnvironment()
+			env.RegisterActivity(&CallHTTPActivities{})
+
+			for i := 0; i < 2; i++ {
+				task := httpTaskWithCache(server.URL, tc.headers, tc.cacheMeta)
+				val, err := env.ExecuteActivity((&CallHTTPActivities{}).CallHTTPActivity, task, nil, runtimeEnv)
+				require.NoError(t, err)
+
+				var output map[string]any
+				require.NoError(t, val.Get(&output))
+				// Both runs return the first response when it is cached
+				assert.EqualValues(t, min(int32(i+1), tc.expectedCalls), output["call"])
+			}
+			assert.Equal(t, tc.expectedCalls, calls.Load())
+		})
+	}
+}
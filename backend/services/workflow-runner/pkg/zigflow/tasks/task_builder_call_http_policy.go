@@ -0,0 +1,260 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// HTTPHostPolicy restricts the hosts HTTP call tasks may connect to. Each
+// pattern is a host glob ("*.example.com", "metadata.google.internal"), an IP
+// address or a CIDR range ("10.0.0.0/8").
+//
+// A denied host is never reached, whatever the allowlist says. With a
+// non-empty allowlist, only hosts matching one of its patterns are reached.
+type HTTPHostPolicy struct {
+	Allow []string
+	Deny  []string
+}
+
+// httpHostPolicy is the compiled host policy of the worker, nil if HTTP call
+// tasks may connect to any host.
+var httpHostPolicy *hostPolicy
+
+// httpGuardedTransport is httpBaseTransport with the host policy enforced
+// when dialing, nil without a host policy or if httpBaseTransport can't be
+// guarded. HTTP call tasks fail rather than use an unguarded transport.
+var httpGuardedTransport http.RoundTripper
+
+// SetHTTPHostPolicy sets the host policy of the worker's HTTP call tasks.
+// The policy is checked against the request URL and every redirect before
+// they are followed, and against the resolved addresses before dialing, so
+// host names resolving to denied ranges are blocked too. A nil or empty
+// policy lets tasks connect to any host.
+//
+// Enforcing the policy when dialing requires the base transport to be an
+// *http.Transport; otherwise an error is returned and HTTP call tasks fail
+// until the base transport is restored.
+//
+// It must be called before the worker starts executing activities.
+func SetHTTPHostPolicy(p *HTTPHostPolicy) error {
+	if p == nil || (len(p.Allow) == 0 && len(p.Deny) == 0) {
+		httpHostPolicy = nil
+		return updateGuardedTransport()
+	}
+
+	compiled := &hostPolicy{}
+	var err error
+	if compiled.allow, err = compileHostRules(p.Allow); err != nil {
+		return fmt.Errorf("invalid allowed host: %w", err)
+	}
+	if compiled.deny, err = compileHostRules(p.Deny); err != nil {
+		return fmt.Errorf("invalid denied host: %w", err)
+	}
+	httpHostPolicy = compiled
+	return updateGuardedTransport()
+}
+
+// updateGuardedTransport derives httpGuardedTransport from httpBaseTransport
+// and the host policy. It fails if the host policy can't be enforced on
+// httpBaseTransport.
+func updateGuardedTransport() error {
+	httpGuardedTransport = nil
+	if httpHostPolicy == nil {
+		return nil
+	}
+	base, ok := httpBaseTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("cannot enforce the HTTP host policy on base transport %T", httpBaseTransport)
+	}
+	transport := base.Clone()
+	httpHostPolicy.guard(transport)
+	httpGuardedTransport = transport
+	return nil
+}
+
+// hostPolicyError reports a connection denied by the host policy.
+type hostPolicyError struct {
+	host   string
+	reason string
+}
+
+func (e *hostPolicyError) Error() string {
+	return fmt.Sprintf("host %q is denied by the HTTP host policy: %s", e.host, e.reason)
+}
+
+// hostRule is a compiled host pattern: a CIDR range or a host glob.
+type hostRule struct {
+	pattern string
+	cidr    *net.IPNet
+}
+
+func compileHostRules(patterns []string) ([]hostRule, error) {
+	rules := make([]hostRule, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		rule := hostRule{pattern: pattern}
+		switch {
+		case strings.Contains(pattern, "/"):
+			_, cidr, err := net.ParseCIDR(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a valid CIDR range", pattern)
+			}
+			rule.cidr = cidr
+		case net.ParseIP(pattern) != nil:
+			ip := net.ParseIP(pattern)
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			rule.cidr = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+		default:
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("%q is not a valid host pattern", pattern)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// matches reports whether the rule matches host, or ip when known.
+func (r hostRule) matches(host string, ip net.IP) bool {
+	if r.cidr != nil {
+		return ip != nil && r.cidr.Contains(ip)
+	}
+	ok, _ := path.Match(r.pattern, host)
+	return ok
+}
+
+type hostPolicy struct {
+	allow []hostRule
+	deny  []hostRule
+}
+
+// check returns a *hostPolicyError if the policy denies connecting to host,
+// resolved to ip. Before the host name is resolved, ip is nil and the CIDR
+// patterns are checked again when dialing.
+func (p *hostPolicy) check(host string, ip net.IP) error {
+	if p == nil {
+		return nil
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if ip == nil {
+		ip = net.ParseIP(host)
+	}
+
+	for _, r := range p.deny {
+		if r.matches(host, ip) {
+			return &hostPolicyError{host: host, reason: fmt.Sprintf("matches denied pattern %q", r.pattern)}
+		}
+	}
+	if len(p.allow) == 0 {
+		return nil
+	}
+	for _, r := range p.allow {
+		if r.matches(host, ip) {
+			return nil
+		}
+		if ip == nil && r.cidr != nil {
+			return nil
+		}
+	}
+	return &hostPolicyError{host: host, reason: "does not match any allowed pattern"}
+}
+
+// checkURL checks the host of a request or redirect URL.
+func (p *hostPolicy) checkURL(u *url.URL) error {
+	return p.check(u.Hostname(), nil)
+}
+
+// checkEndpoint checks the host of a task endpoint. Endpoints that don't
+// parse are left to fail when the request is built.
+func (p *hostPolicy) checkEndpoint(endpoint string) error {
+	if p == nil {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil
+	}
+	return p.checkURL(u)
+}
+
+// guard makes a transport check the host policy against the addresses it
+// dials. Connections to the transport's proxy are not checked: the target
+// of a proxied request is checked by its URL.
+func (p *hostPolicy) guard(transport *http.Transport) {
+	var proxies sync.Map
+	if proxy := transport.Proxy; proxy != nil {
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			proxyURL, err := proxy(req)
+			if proxyURL != nil {
+				proxies.Store(proxyAddr(proxyURL), true)
+			}
+			return proxyURL, err
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if _, ok := proxies.Load(addr); ok {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		guarded := *dialer
+		guarded.Control = func(_, address string, _ syscall.RawConn) error {
+			ip, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			return p.check(host, net.ParseIP(ip))
+		}
+		return guarded.DialContext(ctx, network, addr)
+	}
+}
+
+// proxyAddr returns the address a transport dials to reach a proxy.
+func proxyAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "socks5":
+			port = "1080"
+		default:
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
@@ -0,0 +1,212 @@
+package tasks
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func setHostPolicy(t *testing.T, policy *HTTPHostPolicy) {
+	t.Helper()
+	require.NoError(t, SetHTTPHostPolicy(policy))
+	t.Cleanup(func() { require.NoError(t, SetHTTPHostPolicy(nil)) })
+}
+
+func executeHTTPCall(t *testing.T, endpoint string, redirect bool) error {
+	t.Helper()
+	task := &model.CallHTTP{
+		Call: "http",
+		With: model.HTTPArguments{
+			Method:   http.MethodGet,
+			Endpoint: model.NewEndpoint(endpoint),
+			Redirect: redirect,
+		},
+	}
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestActivityEnvironment()
+	env.RegisterActivity(&CallHTTPActivities{})
+	_, err := env.ExecuteActivity((&CallHTTPActivities{}).CallHTTPActivity, task, nil, nil)
+	return err
+}
+
+func TestHostPolicyCheck(t *testing.T) {
+	require.NoError(t, SetHTTPHostPolicy(&HTTPHostPolicy{
+		Allow: []string{"*.example.com", "10.0.0.0/8"},
+		Deny:  []string{"admin.example.com", "10.1.2.3"},
+	}))
+	t.Cleanup(func() { require.NoError(t, SetHTTPHostPolicy(nil)) })
+
+	tests := []struct {
+		host    string
+		ip      string
+		allowed bool
+	}{
+		{host: "api.example.com", allowed: true},
+		{host: "API.Example.com.", allowed: true},
+		{host: "admin.example.com", allowed: false},
+		{host: "api.other.com", ip: "192.168.0.1", allowed: false},
+		{host: "10.4.5.6", allowed: true},
+		{host: "10.1.2.3", allowed: false},
+		// Decided by the CIDR ranges once resolved
+		{host: "internal.corp", allowed: true},
+		{host: "internal.corp", ip: "10.4.5.6", allowed: true},
+		{host: "internal.corp", ip: "10.1.2.3", allowed: false},
+		{host: "api.example.com", ip: "10.1.2.3", allowed: false},
+	}
+	for _, tt := range tests {
+		err := httpHostPolicy.check(tt.host, net.ParseIP(tt.ip))
+		if tt.allowed {
+			assert.NoError(t, err, "host %s ip %s", tt.host, tt.ip)
+		} else {
+			assert.Error(t, err, "host %s ip %s", tt.host, tt.ip)
+		}
+	}
+}
+
+func TestSetHTTPHostPolicy_InvalidPatterns(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, SetHTTPHostPolicy(nil)) })
+
+	assert.Error(t, SetHTTPHostPolicy(&HTTPHostPolicy{Deny: []string{"10.0.0.0/33"}}))
+	assert.Error(t, SetHTTPHostPolicy(&HTTPHostPolicy{Allow: []string{"[api.example.com"}}))
+}
+
+// roundTripperFunc is a base transport the host policy can't guard.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestSetHTTPHostPolicy_UnguardableTransport(t *testing.T) {
+	original := httpBaseTransport
+	httpBaseTransport = roundTripperFunc(http.DefaultTransport.RoundTrip)
+	t.Cleanup(func() {
+		httpBaseTransport = original
+		require.NoError(t, SetHTTPHostPolicy(nil))
+	})
+
+	err := SetHTTPHostPolicy(&HTTPHostPolicy{Deny: []string{"10.0.0.0/8"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot enforce the HTTP host policy")
+
+	// Tasks fail rather than fall back to the unguarded transport
+	_, err = httpTransport(&model.CallHTTP{}, nil)
+	assert.Error(t, err)
+}
+
+func TestCallHTTPActivity_HostPolicyBeforeCache(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	original := httpResponses
+	httpResponses = newHTTPResponseCache(httpCacheMaxEntries)
+	t.Cleanup(func() { httpResponses = original })
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestActivityEnvironment()
+	env.RegisterActivity(&CallHTTPActivities{})
+	call := func() error {
+		task := httpTaskWithCache(server.URL, nil, map[string]any{"ttl": "1m"})
+		_, err := env.ExecuteActivity((&CallHTTPActivities{}).CallHTTPActivity, task, nil, nil)
+		return err
+	}
+
+	// Cache the response while the host is allowed
+	require.NoError(t, call())
+	require.Equal(t, int32(1), hits.Load())
+
+	setHostPolicy(t, &HTTPHostPolicy{Deny: []string{"127.0.0.1"}})
+
+	err := call()
+	require.Error(t, err)
+	assert.Equal(t, workflowexecutionv1.ErrorClassification_POLICY_DENIED, utils.ClassifyTaskError(err, 0))
+	assert.Equal(t, int32(1), hits.Load())
+}
+
+func TestCallHTTPActivity_HostPolicy(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	t.Run("denied host", func(t *testing.T) {
+		setHostPolicy(t, &HTTPHostPolicy{Deny: []string{"127.0.0.1"}})
+		hits.Store(0)
+
+		err := executeHTTPCall(t, server.URL, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "denied by the HTTP host policy")
+		assert.Equal(t, workflowexecutionv1.ErrorClassification_POLICY_DENIED, utils.ClassifyTaskError(err, 0))
+		assert.Zero(t, hits.Load())
+	})
+
+	t.Run("host outside allowlist", func(t *testing.T) {
+		setHostPolicy(t, &HTTPHostPolicy{Allow: []string{"*.example.com"}})
+
+		err := executeHTTPCall(t, server.URL, false)
+		require.Error(t, err)
+		assert.Equal(t, workflowexecutionv1.ErrorClassification_POLICY_DENIED, utils.ClassifyTaskError(err, 0))
+	})
+
+	t.Run("host resolving to denied range", func(t *testing.T) {
+		setHostPolicy(t, &HTTPHostPolicy{Deny: []string{"127.0.0.0/8"}})
+		hits.Store(0)
+
+		err := executeHTTPCall(t, "http://localhost:"+serverURL.Port(), false)
+		require.Error(t, err)
+		assert.Equal(t, workflowexecutionv1.ErrorClassification_POLICY_DENIED, utils.ClassifyTaskError(err, 0))
+		assert.Zero(t, hits.Load())
+	})
+
+	t.Run("allowed host", func(t *testing.T) {
+		setHostPolicy(t, &HTTPHostPolicy{Allow: []string{"127.0.0.0/8"}, Deny: []string{"*.internal"}})
+
+		require.NoError(t, executeHTTPCall(t, server.URL, false))
+	})
+}
+
+func TestCallHTTPActivity_HostPolicyRedirects(t *testing.T) {
+	var deniedHits atomic.Int32
+	denied := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		deniedHits.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer denied.Close()
+	deniedURL, err := url.Parse(denied.URL)
+	require.NoError(t, err)
+
+	// The redirect names the denied server by host name, the request by IP
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://localhost:"+deniedURL.Port()+"/secret", http.StatusFound)
+	}))
+	defer redirecting.Close()
+
+	setHostPolicy(t, &HTTPHostPolicy{Deny: []string{"localhost"}})
+
+	err = executeHTTPCall(t, redirecting.URL, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `host "localhost" is denied`)
+	assert.Equal(t, workflowexecutionv1.ErrorClassification_POLICY_DENIED, utils.ClassifyTaskError(err, 0))
+	assert.Zero(t, deniedHits.Load())
+}
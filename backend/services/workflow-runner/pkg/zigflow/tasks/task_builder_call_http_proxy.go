@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// httpBaseTransport is the transport of HTTP call tasks that configure
+// neither TLS nor a proxy, and the template of those that do. It is the
+// default transport unless SetHTTPProxy replaced it at worker startup.
+var httpBaseTransport http.RoundTripper = http.DefaultTransport
+
+// SetHTTPProxy sets the proxy configuration of the worker's HTTP call tasks.
+// Tasks can override it with a proxy URL or bypass it. A nil configuration
+// restores the default transport, which reads HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY from the environment.
+//
+// It must be called before the worker starts executing activities.
+func SetHTTPProxy(cfg *httpproxy.Config) {
+	// Without a guarded transport, HTTP call tasks fail closed
+	defer func() { _ = updateGuardedTransport() }()
+	if cfg == nil {
+		httpBaseTransport = http.DefaultTransport
+		return
+	}
+	proxyFunc := cfg.ProxyFunc()
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+	httpBaseTransport = transport
+}
+
+// httpProxyConfig is the proxy override of an HTTP call task, carried in the
+// task metadata because the DSL HTTP call has no proxy settings.
+type httpProxyConfig struct {
+	URL      *url.URL
+	Disabled bool
+}
+
+// httpProxyFromMetadata reads the proxy override of an HTTP call task.
+// Returns nil if the task uses the worker's proxy.
+func httpProxyFromMetadata(task *model.CallHTTP) (*httpProxyConfig, error) {
+	raw, ok := task.Metadata[metadata.MetadataHTTPProxy]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s metadata: expected an object, got %T", metadata.MetadataHTTPProxy, raw)
+	}
+
+	if disabled, _ := m["disabled"].(bool); disabled {
+		return &httpProxyConfig{Disabled: true}, nil
+	}
+	rawURL, ok := m["url"].(string)
+	if !ok || rawURL == "" {
+		return nil, fmt.Errorf("invalid %s metadata: url must be a non-empty string", metadata.MetadataHTTPProxy)
+	}
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid %s metadata: url must be an absolute URL", metadata.MetadataHTTPProxy)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid %s metadata: unsupported proxy scheme %q", metadata.MetadataHTTPProxy, proxyURL.Scheme)
+	}
+	return &httpProxyConfig{URL: proxyURL}, nil
+}
+
+// apply sets the proxy of a transport cloned from httpBaseTransport.
+func (c *httpProxyConfig) apply(transport *http.Transport) {
+	if c.Disabled {
+		transport.Proxy = nil
+		return
+	}
+	transport.Proxy = http.ProxyURL(c.URL)
+}
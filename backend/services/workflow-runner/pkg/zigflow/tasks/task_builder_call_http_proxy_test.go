@@ -0,0 +1,88 @@
+package tasks
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http/httpproxy"
+)
+
+func httpTaskWithProxy(proxyMeta any) *model.CallHTTP {
+	return &model.CallHTTP{
+		TaskBase: model.TaskBase{
+			Metadata: map[string]any{metadata.MetadataHTTPProxy: proxyMeta},
+		},
+		Call: "http",
+	}
+}
+
+// proxyFor returns the proxy a transport uses for a request to target.
+func proxyFor(t *testing.T, transport http.RoundTripper, target string) *url.URL {
+	t.Helper()
+	tr, ok := transport.(*http.Transport)
+	require.True(t, ok, "transport is %T", transport)
+	if tr.Proxy == nil {
+		return nil
+	}
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	require.NoError(t, err)
+	proxyURL, err := tr.Proxy(req)
+	require.NoError(t, err)
+	return proxyURL
+}
+
+func TestHTTPTransport_WorkerProxy(t *testing.T) {
+	SetHTTPProxy(&httpproxy.Config{
+		HTTPProxy:  "http://worker-proxy:3128",
+		HTTPSProxy: "http://worker-proxy:3128",
+		NoProxy:    "internal.example.com",
+	})
+	t.Cleanup(func() { SetHTTPProxy(nil) })
+
+	transport, err := httpTransport(&model.CallHTTP{Call: "http"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://worker-proxy:3128", proxyFor(t, transport, "https://api.example.com/data").String())
+	assert.Nil(t, proxyFor(t, transport, "https://internal.example.com/data"))
+
+	// The worker proxy is kept by tasks that only configure TLS
+	transport, err = httpTransport(httpTaskWithTLS(map[string]any{"insecureSkipVerify": true}), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://worker-proxy:3128", proxyFor(t, transport, "https://api.example.com/data").String())
+}
+
+func TestHTTPTransport_TaskProxyOverride(t *testing.T) {
+	SetHTTPProxy(&httpproxy.Config{HTTPSProxy: "http://worker-proxy:3128"})
+	t.Cleanup(func() { SetHTTPProxy(nil) })
+
+	transport, err := httpTransport(httpTaskWithProxy(map[string]any{"url": "http://proxy.corp:3128"}), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.corp:3128", proxyFor(t, transport, "https://api.example.com/data").String())
+
+	transport, err = httpTransport(httpTaskWithProxy(map[string]any{"disabled": true}), nil)
+	require.NoError(t, err)
+	assert.Nil(t, proxyFor(t, transport, "https://api.example.com/data"))
+}
+
+func TestHTTPTransport_InvalidProxy(t *testing.T) {
+	tests := []struct {
+		name      string
+		proxyMeta any
+	}{
+		{"not an object", "http://proxy.corp:3128"},
+		{"missing url", map[string]any{}},
+		{"relative url", map[string]any{"url": "proxy.corp:3128"}},
+		{"unsupported scheme", map[string]any{"url": "ftp://proxy.corp:21"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := httpTransport(httpTaskWithProxy(tt.proxyMeta), nil)
+			assert.Error(t, err)
+		})
+	}
+}
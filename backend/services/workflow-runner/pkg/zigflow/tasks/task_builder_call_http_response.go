@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+)
+
+const (
+	// Response formats of an HTTP call task. The default (empty) format
+	// parses JSON objects and arrays and falls back to text.
+	httpResponseFormatJSON        = "json"
+	httpResponseFormatText        = "text"
+	httpResponseFormatBytesBase64 = "bytes_base64"
+
+	// httpResponseMaxBase64Bytes bounds the bodies stored base64-encoded, which
+	// are kept in workflow history.
+	httpResponseMaxBase64Bytes = 1 << 20
+
+	// httpResponseFormatMetadata is the task metadata key of the format the
+	// HTTP activity stored the response body in.
+	httpResponseFormatMetadata = "responseFormat"
+)
+
+// httpResponseFormatFromMetadata reads the response format of an HTTP call
+// task, carried in the task metadata because the DSL HTTP call has no
+// response format. Returns "" if the task uses the default format.
+func httpResponseFormatFromMetadata(task *model.CallHTTP) (string, error) {
+	raw, ok := task.Metadata[metadata.MetadataHTTPResponseFormat]
+	if !ok || raw == nil {
+		return "", nil
+	}
+	format, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid %s metadata: expected a string, got %T", metadata.MetadataHTTPResponseFormat, raw)
+	}
+	switch format {
+	case "", httpResponseFormatJSON, httpResponseFormatText, httpResponseFormatBytesBase64:
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid %s metadata: unknown format %q", metadata.MetadataHTTPResponseFormat, format)
+}
+
+// decodeHTTPBody converts a response body to the content of the task output
+// in the given format, and returns the format used. Text and base64 bodies
+// are stored under "body", so later tasks read them as task.Field("body").
+func decodeHTTPBody(format string, body []byte) (any, string, error) {
+	switch format {
+	case httpResponseFormatJSON:
+		var content any
+		if err := json.Unmarshal(body, &content); err != nil {
+			return nil, "", fmt.Errorf("response body is not JSON: %w", err)
+		}
+		return content, httpResponseFormatJSON, nil
+	case httpResponseFormatText:
+		return map[string]any{"body": string(body)}, httpResponseFormatText, nil
+	case httpResponseFormatBytesBase64:
+		if len(body) > httpResponseMaxBase64Bytes {
+			return nil, "", fmt.Errorf("response body of %d bytes exceeds the %d bytes limit of the %s format",
+				len(body), httpResponseMaxBase64Bytes, httpResponseFormatBytesBase64)
+		}
+		return map[string]any{"body": base64.StdEncoding.EncodeToString(body)}, httpResponseFormatBytesBase64, nil
+	}
+
+	if content, ok := parseJSONBody(body); ok {
+		return content, httpResponseFormatJSON, nil
+	}
+	return map[string]any{"body": string(body)}, httpResponseFormatText, nil
+}
+
+// parseJSONBody parses a body that is a JSON object or array. Bodies that are
+// JSON scalars, such as a bare number in a text/plain response, are not
+// treated as JSON.
+func parseJSONBody(body []byte) (any, bool) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return nil, false
+	}
+	var content any
+	if err := json.Unmarshal(trimmed, &content); err != nil {
+		return nil, false
+	}
+	return content, true
+}
+
+// httpHeaderOnlyMethods are the methods whose responses carry no body. Their
+// task output holds only the status code and headers.
+var httpHeaderOnlyMethods = map[string]bool{"HEAD": true, "OPTIONS": true}
+
+// headerOnlyHTTPOutput builds the output of a HEAD or OPTIONS call. Header
+// names are lowercased so workflows read them as task.Field("headers.etag")
+// whatever case the server used.
+func headerOnlyHTTPOutput(httpResp HTTPResponse) map[string]any {
+	headers := make(map[string]any, len(httpResp.Headers))
+	for k, v := range httpResp.Headers {
+		headers[strings.ToLower(k)] = v
+	}
+	return map[string]any{
+		"statusCode": httpResp.StatusCode,
+		"headers":    headers,
+	}
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestDecodeHTTPBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		body       string
+		expected   any
+		expectUsed string
+		expectErr  string
+	}{
+		{
+			name:       "default parses JSON object",
+			body:       `{"id": 1}`,
+			expected:   map[string]any{"id": float64(1)},
+			expectUsed: httpResponseFormatJSON,
+		},
+		{
+			name:       "default parses JSON array",
+			body:       `[1, 2]`,
+			expected:   []any{float64(1), float64(2)},
+			expectUsed: httpResponseFormatJSON,
+		},
+		{
+			name:       "default falls back to text",
+			body:       "id,name\n1,alice\n",
+			expected:   map[string]any{"body": "id,name\n1,alice\n"},
+			expectUsed: httpResponseFormatText,
+		},
+		{
+			name:       "default keeps JSON scalars as text",
+			body:       "42",
+			expected:   map[string]any{"body": "42"},
+			expectUsed: httpResponseFormatText,
+		},
+		{
+			name:       "json parses scalars",
+			format:     httpResponseFormatJSON,
+			body:       `"ok"`,
+			expected:   "ok",
+			expectUsed: httpResponseFormatJSON,
+		},
+		{
+			name:      "json fails on non-JSON body",
+			format:    httpResponseFormatJSON,
+			body:      "id,name",
+			expectErr: "response body is not JSON",
+		},
+		{
+			name:       "text keeps JSON as a string",
+			format:     httpResponseFormatText,
+			body:       `{"id": 1}`,
+			expected:   map[string]any{"body": `{"id": 1}`},
+			expectUsed: httpResponseFormatText,
+		},
+		{
+			name:       "bytes_base64 encodes the body",
+			format:     httpResponseFormatBytesBase64,
+			body:       "\x89PNG",
+			expected:   map[string]any{"body": base64.StdEncoding.EncodeToString([]byte("\x89PNG"))},
+			expectUsed: httpResponseFormatBytesBase64,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			content, used, err := decodeHTTPBody(tc.format, []byte(tc.body))
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, content)
+			assert.Equal(t, tc.expectUsed, used)
+		})
+	}
+}
+
+func TestDecodeHTTPBodyBase64SizeCap(t *testing.T) {
+	body := bytes.Repeat([]byte{0xff}, httpResponseMaxBase64Bytes+1)
+	_, _, err := decodeHTTPBody(httpResponseFormatBytesBase64, body)
+	assert.ErrorContains(t, err, "exceeds the 1048576 bytes limit")
+}
+
+func TestHTTPResponseFormatFromMetadata(t *testing.T) {
+	task := &model.CallHTTP{TaskBase: model.TaskBase{
+		Metadata: map[string]any{metadata.MetadataHTTPResponseFormat: "text"},
+	}}
+	format, err := httpResponseFormatFromMetadata(task)
+	require.NoError(t, err)
+	assert.Equal(t, httpResponseFormatText, format)
+
+	task.Metadata[metadata.MetadataHTTPResponseFormat] = "xml"
+	_, err = httpResponseFormatFromMetadata(task)
+	assert.ErrorContains(t, err, `unknown format "xml"`)
+
+	format, err = httpResponseFormatFromMetadata(&model.CallHTTP{})
+	require.NoError(t, err)
+	assert.Empty(t, format)
+}
+
+func TestCallHTTPActivityResponseFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte("id,name\n1,alice\n"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		format    string
+		expectErr bool
+	}{
+		{name: "default falls back to text"},
+		{name: "text", format: httpResponseFormatText},
+		{name: "json fails", format: httpResponseFormatJSON, expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &model.CallHTTP{
+				Call: "http",
+				With: model.HTTPArguments{
+					Method:   "GET",
+					Endpoint: model.NewEndpoint(server.URL),
+				},
+			}
+			if tc.format != "" {
+				task.Metadata = map[string]any{metadata.MetadataHTTPResponseFormat: tc.format}
+			}
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestActivityEnvironment()
+			env.RegisterActivity(&CallHTTPActivities{})
+
+			val, err := env.ExecuteActivity((&CallHTTPActivities{}).CallHTTPActivity, task, nil, nil)
+			if tc.expectErr {
+				assert.ErrorContains(t, err, "response body is not JSON")
+				return
+			}
+			require.NoError(t, err)
+
+			var output map[string]any
+			require.NoError(t, val.Get(&output))
+			assert.Equal(t, "id,name\n1,alice\n", output["body"])
+		})
+	}
+}
+
+func TestCallHTTPActivityHeaderOnlyMethods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"33a64df5"`)
+		w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+		if r.Method == http.MethodOptions {
+			// A body that is not JSON must not be parsed
+			_, _ = w.Write([]byte("not json"))
+		}
+	}))
+	defer server.Close()
+
+	for _, method := range []string{"HEAD", "OPTIONS"} {
+		t.Run(method, func(t *testing.T) {
+			task := &model.CallHTTP{
+				Call: "http",
+				With: model.HTTPArguments{
+					Method:   method,
+					Endpoint: model.NewEndpoint(server.URL),
+				},
+			}
+			task.Metadata = map[string]any{metadata.MetadataHTTPResponseFormat: httpResponseFormatJSON}
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestActivityEnvironment()
+			env.RegisterActivity(&CallHTTPActivities{})
+
+			val, err := env.ExecuteActivity((&CallHTTPActivities{}).CallHTTPActivity, task, nil, nil)
+			require.NoError(t, err)
+
+			var output map[string]any
+			require.NoError(t, val.Get(&output))
+			assert.Equal(t, float64(http.StatusOK), output["statusCode"])
+			headers, ok := output["headers"].(map[string]any)
+			require.True(t, ok)
+			assert.Equal(t, `"33a64df5"`, headers["etag"])
+			assert.Equal(t, "GET, HEAD, OPTIONS", headers["allow"])
+			assert.NotContains(t, output, "body")
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"go.temporal.io/sdk/temporal"
+)
+
+// httpStatusBodySnippetBytes bounds the response body quoted in the message
+// of a failed status check.
+const httpStatusBodySnippetBytes = 512
+
+// httpStatusCheck is the response status check of an HTTP call task. Without
+// expected statuses or allowAny, 3xx, 4xx and 5xx statuses fail the task.
+type httpStatusCheck struct {
+	expect   map[int]bool
+	allowAny bool
+}
+
+// httpStatusCheckFromMetadata reads the status check of an HTTP call task,
+// carried in the task metadata because the DSL HTTP call has none.
+func httpStatusCheckFromMetadata(task *model.CallHTTP) (httpStatusCheck, error) {
+	var check httpStatusCheck
+
+	if raw, ok := task.Metadata[metadata.MetadataHTTPAllowAnyStatus]; ok && raw != nil {
+		allowAny, ok := raw.(bool)
+		if !ok {
+			return check, fmt.Errorf("invalid %s metadata: expected a bool, got %T", metadata.MetadataHTTPAllowAnyStatus, raw)
+		}
+		check.allowAny = allowAny
+	}
+
+	raw, ok := task.Metadata[metadata.MetadataHTTPExpectStatus]
+	if !ok || raw == nil {
+		return check, nil
+	}
+	codes, ok := raw.([]any)
+	if !ok {
+		return check, fmt.Errorf("invalid %s metadata: expected a list, got %T", metadata.MetadataHTTPExpectStatus, raw)
+	}
+	check.expect = make(map[int]bool, len(codes))
+	for _, v := range codes {
+		code, ok := statusCode(v)
+		if !ok || code < 100 || code > 599 {
+			return check, fmt.Errorf("invalid %s metadata: %v is not an HTTP status code", metadata.MetadataHTTPExpectStatus, v)
+		}
+		check.expect[code] = true
+	}
+	if check.allowAny && len(check.expect) > 0 {
+		return check, fmt.Errorf("invalid %s metadata: cannot be combined with %s",
+			metadata.MetadataHTTPExpectStatus, metadata.MetadataHTTPAllowAnyStatus)
+	}
+	return check, nil
+}
+
+// statusCode converts a status code decoded from YAML or JSON to an int.
+func statusCode(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case float64:
+		if n != float64(int(n)) {
+			return 0, false
+		}
+		return int(n), true
+	}
+	return 0, false
+}
+
+// check returns the error an HTTP call fails with for the response status,
+// nil if the status passes. 5xx failures are retryable, others are not.
+//
+// Messages keep the "CallHTTP returned 4xx/5xx" prefix the error
+// classification relies on, followed by the status and a snippet of the
+// response body. The body is also in the error details, as JSON if possible.
+func (c httpStatusCheck) check(resp *http.Response, content any, body []byte) error {
+	code := resp.StatusCode
+	switch {
+	case c.allowAny:
+		return nil
+	case len(c.expect) > 0:
+		if c.expect[code] {
+			return nil
+		}
+	case code < 300:
+		return nil
+	}
+
+	var class string
+	switch {
+	case code >= 500 && code < 600:
+		// Server error - retryable as we can't fix it
+		return temporal.NewApplicationError(
+			httpStatusMessage("5xx", code, body),
+			"CallHTTP error",
+			errors.New(resp.Status),
+			map[string]any{
+				"statusCode": code,
+				"content":    content,
+			},
+		)
+	case code >= 400 && code < 500:
+		// Client error - non-retryable as we need to fix it
+		class = "4xx"
+	case code >= 300 && code < 400:
+		// Redirects are not followed - if you have "redirect = true", this will be ignored
+		class = "3xx"
+	default:
+		// A status the task does not expect, such as a 2xx
+		class = "unexpected"
+	}
+	return temporal.NewNonRetryableApplicationError(
+		httpStatusMessage(class, code, body),
+		"CallHTTP error",
+		errors.New(resp.Status),
+		content,
+	)
+}
+
+// httpStatusMessage is the message of a failed status check for a status of
+// the given class ("3xx", "4xx", "5xx" or "unexpected").
+func httpStatusMessage(class string, code int, body []byte) string {
+	msg := fmt.Sprintf("CallHTTP returned %s status code %d", class, code)
+	if snippet := httpBodySnippet(body); snippet != "" {
+		msg += ": " + snippet
+	}
+	return msg
+}
+
+// httpBodySnippet returns the start of a response body for error messages,
+// cut on a rune boundary.
+func httpBodySnippet(body []byte) string {
+	if len(body) <= httpStatusBodySnippetBytes {
+		return string(body)
+	}
+	cut := httpStatusBodySnippetBytes
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return string(body[:cut]) + "..."
+}
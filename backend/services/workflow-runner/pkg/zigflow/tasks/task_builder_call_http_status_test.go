@@ -0,0 +1,162 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestHTTPStatusCheckFromMetadata(t *testing.T) {
+	task := &model.CallHTTP{TaskBase: model.TaskBase{
+		Metadata: map[string]any{metadata.MetadataHTTPExpectStatus: []any{float64(200), 404}},
+	}}
+	check, err := httpStatusCheckFromMetadata(task)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{200: true, 404: true}, check.expect)
+	assert.False(t, check.allowAny)
+
+	task.Metadata[metadata.MetadataHTTPExpectStatus] = []any{float64(700)}
+	_, err = httpStatusCheckFromMetadata(task)
+	assert.ErrorContains(t, err, "700 is not an HTTP status code")
+
+	task.Metadata[metadata.MetadataHTTPExpectStatus] = []any{200}
+	task.Metadata[metadata.MetadataHTTPAllowAnyStatus] = true
+	_, err = httpStatusCheckFromMetadata(task)
+	assert.ErrorContains(t, err, "cannot be combined")
+
+	check, err = httpStatusCheckFromMetadata(&model.CallHTTP{})
+	require.NoError(t, err)
+	assert.Empty(t, check.expect)
+	assert.False(t, check.allowAny)
+}
+
+func TestCallHTTPActivityStatusCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"user 42 not found"}`))
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":"maintenance"}`))
+		case "/large":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`"` + strings.Repeat("x", 2*httpStatusBodySnippetBytes) + `"`))
+		default:
+			_, _ = w.Write([]byte(`{"id":42}`))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		path           string
+		metadata       map[string]any
+		wantMessage    string
+		wantClassified string
+		wantRetryable  bool
+		wantOutputKeys []string
+	}{
+		{name: "default passes 2xx", path: "/", wantOutputKeys: []string{"id"}},
+		{
+			name:           "default fails 4xx with the body",
+			path:           "/missing",
+			wantMessage:    `CallHTTP returned 4xx status code 404: {"error":"user 42 not found"}`,
+			wantClassified: "UPSTREAM_4XX",
+		},
+		{
+			name:           "default retries 5xx",
+			path:           "/unavailable",
+			wantMessage:    `CallHTTP returned 5xx status code 503: {"error":"maintenance"}`,
+			wantClassified: "UPSTREAM_5XX",
+			wantRetryable:  true,
+		},
+		{
+			name:           "expected 404 passes",
+			path:           "/missing",
+			metadata:       map[string]any{metadata.MetadataHTTPExpectStatus: []any{200, 404}},
+			wantOutputKeys: []string{"error"},
+		},
+		{
+			name:        "unexpected 2xx fails",
+			path:        "/",
+			metadata:    map[string]any{metadata.MetadataHTTPExpectStatus: []any{201}},
+			wantMessage: `CallHTTP returned unexpected status code 200: {"id":42}`,
+		},
+		{
+			name:           "allow any passes 4xx",
+			path:           "/missing",
+			metadata:       map[string]any{metadata.MetadataHTTPAllowAnyStatus: true},
+			wantOutputKeys: []string{"error"},
+		},
+		{
+			name:           "long bodies are cut",
+			path:           "/large",
+			wantMessage:    `CallHTTP returned 4xx status code 400: "` + strings.Repeat("x", httpStatusBodySnippetBytes-1) + "...",
+			wantClassified: "UPSTREAM_4XX",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &model.CallHTTP{
+				Call: "http",
+				With: model.HTTPArguments{
+					Method:   "GET",
+					Endpoint: model.NewEndpoint(server.URL + tc.path),
+				},
+			}
+			task.Metadata = tc.metadata
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestActivityEnvironment()
+			env.RegisterActivity(&CallHTTPActivities{})
+
+			val, err := env.ExecuteActivity((&CallHTTPActivities{}).CallHTTPActivity, task, nil, nil)
+			if tc.wantMessage != "" {
+				var appErr *temporal.ApplicationError
+				require.True(t, errors.As(err, &appErr), "error = %v, want an application error", err)
+				assert.Equal(t, tc.wantMessage, appErr.Message())
+				assert.Equal(t, tc.wantRetryable, !appErr.NonRetryable())
+				if tc.wantClassified != "" {
+					assert.Equal(t, tc.wantClassified, utils.NewWorkflowFailure(err, "lookup").Classification)
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			var output map[string]any
+			require.NoError(t, val.Get(&output))
+			for _, key := range tc.wantOutputKeys {
+				assert.Contains(t, output, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+)
+
+// httpTLSConfig is the TLS configuration of an HTTP call task, carried in the
+// task metadata because the DSL HTTP call has no TLS settings.
+//
+// ClientCert, ClientKey and CABundle hold PEM data or runtime placeholders
+// (${.secrets.KEY}) that are resolved in the activity, so key material never
+// appears in workflow history.
+type httpTLSConfig struct {
+	ClientCert         string
+	ClientKey          string
+	CABundle           string
+	CABundleFile       string
+	InsecureSkipVerify bool
+}
+
+// httpTLSFromMetadata reads the TLS configuration of an HTTP call task.
+// Returns nil if the task does not configure TLS.
+func httpTLSFromMetadata(task *model.CallHTTP) (*httpTLSConfig, error) {
+	raw, ok := task.Metadata[metadata.MetadataHTTPTLS]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s metadata: expected an object, got %T", metadata.MetadataHTTPTLS, raw)
+	}
+
+	cfg := &httpTLSConfig{}
+	fields := map[string]*string{
+		"clientCert":   &cfg.ClientCert,
+		"clientKey":    &cfg.ClientKey,
+		"caBundle":     &cfg.CABundle,
+		"caBundleFile": &cfg.CABundleFile,
+	}
+	for key, dst := range fields {
+		if v, ok := m[key]; ok {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s metadata: %s must be a string", metadata.MetadataHTTPTLS, key)
+			}
+			*dst = s
+		}
+	}
+	if v, ok := m["insecureSkipVerify"].(bool); ok {
+		cfg.InsecureSkipVerify = v
+	}
+	return cfg, nil
+}
+
+// validate checks the configuration before runtime placeholders are resolved.
+func (c *httpTLSConfig) validate() error {
+	if (c.ClientCert == "") != (c.ClientKey == "") {
+		return errors.New("TLS client certificate and key must be set together")
+	}
+	// Literal keys would have been recorded in the manifest and workflow history
+	if c.ClientKey != "" && !strings.HasPrefix(c.ClientKey, "${.secrets.") {
+		return errors.New("TLS client key must be a runtime secret reference (${.secrets.KEY})")
+	}
+	if c.CABundle != "" && c.CABundleFile != "" {
+		return errors.New("TLS CA bundle and CA bundle file are mutually exclusive")
+	}
+	return nil
+}
+
+// resolve replaces runtime placeholders in the certificate, key and CA bundle
+// with their values from the runtime environment.
+func (c *httpTLSConfig) resolve(runtimeEnv map[string]any) (*httpTLSConfig, error) {
+	resolved := *c
+	for name, field := range map[string]*string{
+		"client certificate": &resolved.ClientCert,
+		"client key":         &resolved.ClientKey,
+		"CA bundle":          &resolved.CABundle,
+	} {
+		value, err := ResolvePlaceholders(*field, runtimeEnv)
+		if err != nil {
+			return nil, fmt.Errorf("TLS %s: %w", name, err)
+		}
+		if isRuntimePlaceholder(value) {
+			return nil, fmt.Errorf("TLS %s: runtime value %s not provided", name, value)
+		}
+		*field = value
+	}
+	return &resolved, nil
+}
+
+// tlsClientConfig builds the tls.Config of the HTTP client from a resolved
+// configuration.
+func (c *httpTLSConfig) tlsClientConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec // explicitly requested by the workflow
+	}
+
+	if c.ClientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+		if err != nil {
+			// The error never contains key material
+			return nil, fmt.Errorf("invalid TLS client certificate or key: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	caBundle := []byte(c.CABundle)
+	if c.CABundleFile != "" {
+		data, err := os.ReadFile(c.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading TLS CA bundle file: %w", err)
+		}
+		caBundle = data
+	}
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("TLS CA bundle contains no PEM certificates")
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
+// httpTransport returns the transport of an HTTP call task: the worker's
+// base transport, or a copy of it using the task's TLS configuration and
+// proxy override.
+func httpTransport(task *model.CallHTTP, runtimeEnv map[string]any) (http.RoundTripper, error) {
+	cfg, err := httpTLSFromMetadata(task)
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := httpProxyFromMetadata(task)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil && proxy == nil {
+		if httpHostPolicy == nil {
+			return httpBaseTransport, nil
+		}
+		if httpGuardedTransport == nil {
+			return nil, fmt.Errorf("cannot enforce the HTTP host policy on base transport %T", httpBaseTransport)
+		}
+		return httpGuardedTransport, nil
+	}
+
+	base, ok := httpBaseTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unsupported base transport %T", httpBaseTransport)
+	}
+	transport := base.Clone()
+
+	if cfg != nil {
+		if err := cfg.validate(); err != nil {
+			return nil, err
+		}
+		resolved, err := cfg.resolve(runtimeEnv)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig, err := resolved.tlsClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	if proxy != nil {
+		proxy.apply(transport)
+	}
+	if httpHostPolicy != nil {
+		httpHostPolicy.guard(transport)
+	}
+	return transport, nil
+}
@@ -18,6 +18,7 @@ package tasks
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/claimcheck"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/types"
@@ -320,6 +321,7 @@ func (t *DoTaskBuilder) iterateTasks(
 			return err
 		} else if !toRun {
 			logger.Debug("Skipping task as if statement resolve as false", "name", task.Name)
+			t.recordSkipped(task, state)
 			continue
 		}
 
@@ -423,6 +425,25 @@ func (t *DoTaskBuilder) processTaskExport(task workflowFunc, taskOutput any, sta
 	return nil
 }
 
+// recordSkipped records {"skipped": true} as the export of a task skipped by
+// its if statement, so later tasks can tell a skipped task from one that ran.
+// Tasks without an export are not referenced by name and record nothing.
+func (t *DoTaskBuilder) recordSkipped(task workflowFunc, state *utils.State) {
+	if task.GetTask().GetBase().Export == nil {
+		return
+	}
+
+	contextMap, ok := state.Context.(map[string]any)
+	if !ok {
+		contextMap = make(map[string]any)
+		if state.Context != nil {
+			contextMap["__previous_context"] = state.Context
+		}
+	}
+	contextMap[task.Name] = map[string]any{"skipped": true}
+	state.Context = contextMap
+}
+
 func (t *DoTaskBuilder) processTaskOutput(task workflowFunc, taskOutput any, state *utils.State) error {
 	taskBase := task.GetTask().GetBase()
 
@@ -449,11 +470,43 @@ func (t *DoTaskBuilder) runTask(ctx workflow.Context, task workflowFunc, input a
 	logger := workflow.GetLogger(ctx)
 
 	logger.Info("Running task", "name", task.Name)
-	output, err := task.Func(ctx, input, state)
+
+	// Activity options only bound tasks that run as activities. Tasks that
+	// run in workflow code (set, for, switch, ...) are bounded by cancelling
+	// their context when a timer for the execution timeout fires.
+	taskCtx := ctx
+	timedOut := false
+	if timeout := taskExecutionTimeout(task.GetTask()); timeout > 0 {
+		var cancel workflow.CancelFunc
+		taskCtx, cancel = workflow.WithCancel(ctx)
+		defer cancel()
+
+		workflow.Go(taskCtx, func(timerCtx workflow.Context) {
+			if err := workflow.NewTimer(timerCtx, timeout).Get(timerCtx, nil); err == nil {
+				timedOut = true
+				cancel()
+			}
+		})
+	}
+
+	output, err := task.Func(taskCtx, input, state)
+	if timedOut {
+		// The cancellation came from the timer, not the workflow, so it is not
+		// kept as the cause: Try would take it for a cancelled workflow
+		logger.Error("Task exceeded execution timeout", "name", task.Name, "error", err)
+		return executionTimeoutError(task.Name, nil)
+	}
 	if err != nil {
 		if temporal.IsCanceledError(err) {
+			// Returned as is, so a cancelled workflow stops instead of
+			// running its remaining tasks and ends cancelled
 			logger.Debug("Task cancelled", "name", task.Name)
-			return nil
+			return err
+		}
+
+		if timeout := task.GetTask().GetBase().Timeout; timeout != nil && temporal.IsTimeoutError(err) {
+			logger.Error("Task exceeded execution timeout", "name", task.Name, "error", err)
+			return executionTimeoutError(task.Name, err)
 		}
 
 		logger.Error("Error running task", "name", task.Name, "error", err)
@@ -470,6 +523,11 @@ func (t *DoTaskBuilder) runTask(ctx workflow.Context, task workflowFunc, input a
 		return fmt.Errorf("error processing task export: %w", err)
 	}
 
+	// Redact sensitive fields from the recorded export and output
+	if fields := sensitiveOutputFields(task.GetTask()); len(fields) > 0 {
+		state.RedactSensitive(task.Name, fields)
+	}
+
 	// Phase 4+: Apply Claim Check to large state data AFTER each step
 	// This prevents large data from being passed to the next activity
 	if claimcheck.IsEnabled() {
@@ -487,6 +545,41 @@ func (t *DoTaskBuilder) runTask(ctx workflow.Context, task workflowFunc, input a
 	return nil
 }
 
+// taskExecutionTimeout returns the task's execution timeout, or 0 if unset.
+func taskExecutionTimeout(task model.Task) time.Duration {
+	timeout := task.GetBase().Timeout
+	if timeout == nil || timeout.Timeout == nil || timeout.Timeout.After == nil {
+		return 0
+	}
+	return utils.ToDuration(timeout.Timeout.After)
+}
+
+// executionTimeoutError is the non-retryable error raised when a task runs
+// past its execution timeout.
+func executionTimeoutError(taskName string, cause error) error {
+	return temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("task %s exceeded its execution timeout", taskName),
+		errTypeExecutionTimeout,
+		cause,
+	)
+}
+
+// sensitiveOutputFields returns the output fields marked sensitive in the task metadata.
+func sensitiveOutputFields(task model.Task) []string {
+	raw, ok := task.GetBase().Metadata[metadata.MetadataSensitiveOutputFields].([]any)
+	if !ok {
+		return nil
+	}
+
+	fields := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if field, ok := v.(string); ok && field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
 func (t *DoTaskBuilder) shouldContinueAsNew(ctx workflow.Context) bool {
 	logger := workflow.GetLogger(ctx)
 	info := workflow.GetInfo(ctx)
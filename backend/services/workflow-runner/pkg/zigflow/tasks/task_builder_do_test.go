@@ -19,10 +19,12 @@ package tasks
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/testsuite"
 	"go.temporal.io/sdk/workflow"
 )
@@ -209,6 +211,27 @@ func TestDoTaskBuilderIterateTasksFlowControl(t *testing.T) {
 	}
 }
 
+func TestDoTaskBuilderRecordSkipped(t *testing.T) {
+	builder := newTestDoTaskBuilder("guarded-workflow")
+	runOrder := make([]string, 0)
+
+	state := utils.NewState()
+	state.Context = map[string]any{"analyze": map[string]any{"severity": "low"}}
+
+	exported := newSimpleWorkflowFunc("escalate", &model.TaskBase{
+		Export: &model.Export{As: model.NewObjectOrRuntimeExpr("${.}")},
+	}, &runOrder)
+	builder.recordSkipped(exported, state)
+
+	notExported := newSimpleWorkflowFunc("notify", &model.TaskBase{}, &runOrder)
+	builder.recordSkipped(notExported, state)
+
+	assert.Equal(t, map[string]any{
+		"analyze":  map[string]any{"severity": "low"},
+		"escalate": map[string]any{"skipped": true},
+	}, state.Context)
+}
+
 func TestDoTaskBuilderShouldContinueAsNew(t *testing.T) {
 	t.Helper()
 
@@ -481,6 +504,57 @@ func newOutputWorkflowFuncs(runOrder *[]string, capturedState **utils.State) []w
 	}
 }
 
+func TestDoTaskBuilderRunTaskExecutionTimeout(t *testing.T) {
+	builder := newTestDoTaskBuilder("run-timeout")
+
+	tests := []struct {
+		name      string
+		sleep     time.Duration
+		expectErr bool
+	}{
+		{name: "in-workflow task past its timeout fails", sleep: time.Hour, expectErr: true},
+		{name: "in-workflow task within its timeout succeeds", sleep: time.Second},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task := workflowFunc{
+				TaskBuilder: newFakeTaskBuilder("loop", &model.TaskBase{
+					Timeout: &model.TimeoutOrReference{
+						Timeout: &model.Timeout{
+							After: &model.Duration{Value: model.DurationInline{Seconds: 10}},
+						},
+					},
+				}),
+				Name: "loop",
+				Func: func(ctx workflow.Context, input any, state *utils.State) (any, error) {
+					return nil, workflow.Sleep(ctx, tc.sleep)
+				},
+			}
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestWorkflowEnvironment()
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (any, error) {
+				return nil, builder.runTask(ctx, task, nil, utils.NewState())
+			}, workflow.RegisterOptions{Name: builder.GetTaskName()})
+
+			env.ExecuteWorkflow(builder.GetTaskName())
+
+			err := env.GetWorkflowError()
+			if !tc.expectErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var appErr *temporal.ApplicationError
+			if assert.ErrorAs(t, err, &appErr) {
+				assert.Equal(t, errTypeExecutionTimeout, appErr.Type())
+				assert.True(t, appErr.NonRetryable())
+			}
+		})
+	}
+}
+
 func newSimpleWorkflowFunc(name string, base *model.TaskBase, runOrder *[]string) workflowFunc {
 	tb := newFakeTaskBuilder(name, base)
 	return workflowFunc{
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
 	"github.com/rs/zerolog/log"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"go.temporal.io/sdk/worker"
@@ -125,12 +126,43 @@ func (t *ForTaskBuilder) exec() (TemporalWorkflowFunc, error) {
 
 		logger.Debug("For task evaluated data", "task", t.GetTaskName(), "data", data, "type", fmt.Sprintf("%T", data))
 
+		iterations := 0
+		maxIterations := t.maxIterations()
+
+		// next runs one iteration on a fresh copy of the state, enforcing the
+		// iteration cap, and reports whether the until condition stops the loop
+		next := func(key, value any) (res any, stop bool, err error) {
+			// A cancelled workflow schedules no further iterations
+			if err := ctx.Err(); err != nil {
+				logger.Info("For task cancelled, stopping iteration", "task", t.GetTaskName(), "iterations", iterations)
+				return nil, false, err
+			}
+			if maxIterations > 0 && iterations >= maxIterations {
+				logger.Error("For task exceeded its iteration cap", "task", t.GetTaskName(), "maxIterations", maxIterations)
+				return nil, false, fmt.Errorf("for task exceeded its cap of %d iterations", maxIterations)
+			}
+
+			iterationState := state.Clone().ClearOutput()
+			res, err = t.iterator(ctx, key, value, iterationState)
+			if err != nil {
+				return nil, false, err
+			}
+			iterations++
+
+			stop, err = t.checkUntil(ctx, iterationState)
+			if err != nil {
+				logger.Error("Error checking for until", "error", err, "key", key, "task", t.GetTaskName())
+				return nil, false, fmt.Errorf("error checking for until: %w", err)
+			}
+			return res, stop, nil
+		}
+
 		switch v := data.(type) {
 		case map[string]any:
 			logger.Debug("Iterating data as object", "task", t.GetTaskName())
 			output := map[string]any{}
 			for key, value := range v {
-				res, err := t.iterator(ctx, key, value, state.Clone().ClearOutput())
+				res, stop, err := next(key, value)
 				if err != nil {
 					if errors.Is(err, errForkIterationStop) {
 						break
@@ -139,14 +171,17 @@ func (t *ForTaskBuilder) exec() (TemporalWorkflowFunc, error) {
 				}
 
 				output[key] = res
+				if stop {
+					break
+				}
 			}
 
-			return output, nil
+			return t.loopOutput(output, iterations), nil
 		case []any:
 			logger.Debug("Iterating data as array", "task", t.GetTaskName())
 			output := make([]any, 0)
 			for i, value := range v {
-				res, err := t.iterator(ctx, i, value, state.Clone().ClearOutput())
+				res, stop, err := next(i, value)
 				if err != nil {
 					if errors.Is(err, errForkIterationStop) {
 						break
@@ -155,14 +190,17 @@ func (t *ForTaskBuilder) exec() (TemporalWorkflowFunc, error) {
 				}
 
 				output = append(output, res)
+				if stop {
+					break
+				}
 			}
 
-			return output, nil
+			return t.loopOutput(output, iterations), nil
 		case int:
 			logger.Debug("Iterating data as a number", "task", t.GetTaskName())
 			output := make([]any, 0)
 			for i := range v {
-				res, err := t.iterator(ctx, i, i, state.Clone().ClearOutput())
+				res, stop, err := next(i, i)
 				if err != nil {
 					if errors.Is(err, errForkIterationStop) {
 						break
@@ -171,9 +209,12 @@ func (t *ForTaskBuilder) exec() (TemporalWorkflowFunc, error) {
 				}
 
 				output = append(output, res)
+				if stop {
+					break
+				}
 			}
 
-			return output, nil
+			return t.loopOutput(output, iterations), nil
 		default:
 			logger.Error("For task data is not iterable", "task", t.GetTaskName(), "type", fmt.Sprintf("%T", data), "value", data)
 			return nil, fmt.Errorf("for task data is not iterable: expected map, array, or int, got %T: %v", data, data)
@@ -260,3 +301,53 @@ func (t *ForTaskBuilder) checkWhile(ctx workflow.Context, state *utils.State) (r
 
 	return
 }
+
+// checkUntil decides if the loop should stop after an iteration. The until
+// expression is carried in the task metadata because the DSL for task has no
+// field for it, and is evaluated against the iteration's state so it can read
+// the exports of the body tasks.
+func (t *ForTaskBuilder) checkUntil(ctx workflow.Context, state *utils.State) (bool, error) {
+	until, ok := t.task.Metadata[metadata.MetadataForUntil].(string)
+	if !ok || until == "" {
+		return false, nil
+	}
+
+	res, err := utils.EvaluateString(until, nil, state)
+	if err != nil {
+		return false, fmt.Errorf("error parsing for task until: %w", err)
+	}
+
+	if v, ok := res.(bool); ok {
+		workflow.GetLogger(ctx).Debug("Task until has resolved", "response", v, "task", t.GetTaskName())
+		return v, nil
+	}
+
+	workflow.GetLogger(ctx).Warn("Task until has resolved to a non-boolean - continuing", "response", res, "task", t.GetTaskName())
+	return false, nil
+}
+
+// maxIterations returns the iteration cap of the loop, or 0 for no cap.
+func (t *ForTaskBuilder) maxIterations() int {
+	switch v := t.task.Metadata[metadata.MetadataForMaxIterations].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// loopOutput returns the task output: the iteration results, or the results
+// with the iteration count when the task reports iterations.
+func (t *ForTaskBuilder) loopOutput(results any, iterations int) any {
+	if report, ok := t.task.Metadata[metadata.MetadataForReportIterations].(bool); !ok || !report {
+		return results
+	}
+	return map[string]any{
+		"iterations": iterations,
+		"results":    results,
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/stretchr/testify/assert"
 	"go.temporal.io/sdk/testsuite"
@@ -160,3 +161,97 @@ func TestForTaskBuilderIterator(t *testing.T) {
 	assert.Equal(t, "item-value", state.Data["value"])
 	assert.Equal(t, 0, state.Data["idx"])
 }
+
+func TestForTaskBuilderLoopControl(t *testing.T) {
+	tests := []struct {
+		name        string
+		metadata    map[string]any
+		expect      any
+		expectError bool
+	}{
+		{
+			name:   "runs every iteration by default",
+			expect: []any{float64(0), float64(1), float64(2), float64(3), float64(4)},
+		},
+		{
+			name: "until stops after the matching iteration",
+			metadata: map[string]any{
+				metadata.MetadataForUntil: `${ $context["poll"].status == "done" }`,
+			},
+			expect: []any{float64(0), float64(1), float64(2)},
+		},
+		{
+			name: "reports iterations",
+			metadata: map[string]any{
+				metadata.MetadataForUntil:            `${ $context["poll"].status == "done" }`,
+				metadata.MetadataForReportIterations: true,
+			},
+			expect: map[string]any{
+				"iterations": float64(3),
+				"results":    []any{float64(0), float64(1), float64(2)},
+			},
+		},
+		{
+			name: "cap reached by the until iteration",
+			metadata: map[string]any{
+				metadata.MetadataForUntil:         `${ $context["poll"].status == "done" }`,
+				metadata.MetadataForMaxIterations: 3,
+			},
+			expect: []any{float64(0), float64(1), float64(2)},
+		},
+		{
+			name: "exceeding the cap fails",
+			metadata: map[string]any{
+				metadata.MetadataForMaxIterations: float64(4),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := &ForTaskBuilder{
+				builder: builder[*model.ForTask]{
+					name: "poll-loop",
+					task: &model.ForTask{
+						TaskBase: model.TaskBase{Metadata: tc.metadata},
+						For:      model.ForTaskConfiguration{In: "${ [range(0; 5)] }"},
+						Do:       &model.TaskList{},
+					},
+				},
+				// The body exports a "poll" task that is done on the third iteration
+				childWorkflowFunc: func(ctx workflow.Context, input any, state *utils.State) (any, error) {
+					index := state.Data["index"].(int)
+					status := "pending"
+					if index == 2 {
+						status = "done"
+					}
+					state.Context = map[string]any{"poll": map[string]any{"status": status}}
+					return index, nil
+				},
+			}
+
+			exec, err := builder.exec()
+			assert.NoError(t, err)
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestWorkflowEnvironment()
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (any, error) {
+				return exec(ctx, nil, utils.NewState())
+			}, workflow.RegisterOptions{Name: "loop-control"})
+
+			env.ExecuteWorkflow("loop-control")
+
+			err = env.GetWorkflowError()
+			if tc.expectError {
+				assert.ErrorContains(t, err, "cap of 4 iterations")
+				return
+			}
+			assert.NoError(t, err)
+
+			var res any
+			assert.NoError(t, env.GetWorkflowResult(&res))
+			assert.Equal(t, tc.expect, res)
+		})
+	}
+}
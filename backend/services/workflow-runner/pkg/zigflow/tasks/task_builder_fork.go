@@ -17,10 +17,12 @@
 package tasks
 
 import (
+	"errors"
 	"fmt"
 	"maps"
 
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
 	"github.com/rs/zerolog/log"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"go.temporal.io/sdk/temporal"
@@ -152,9 +154,10 @@ func (t *ForkTaskBuilder) buildOrPostLoad() ([]*forkedTask, []TaskBuilder, error
 func (t *ForkTaskBuilder) exec(forkedTasks []*forkedTask) (TemporalWorkflowFunc, error) {
 	return func(ctx workflow.Context, input any, state *utils.State) (any, error) {
 		isCompeting := t.task.Fork.Compete
+		continueOnError := !isCompeting && t.continueOnBranchError()
 
 		logger := workflow.GetLogger(ctx)
-		logger.Debug("Forking a task", "isCompeting", isCompeting)
+		logger.Debug("Forking a task", "isCompeting", isCompeting, "continueOnBranchError", continueOnError)
 
 		// Create channels to collect results from parallel branches
 		type branchResult struct {
@@ -219,6 +222,12 @@ func (t *ForkTaskBuilder) exec(forkedTasks []*forkedTask) (TemporalWorkflowFunc,
 					continue
 				}
 
+				if continueOnError {
+					logger.Warn("Forked branch failed, recording error", "error", result.err, "task", result.taskName)
+					output[result.taskName] = branchErrorOutput(result.err)
+					continue
+				}
+
 				logger.Error("Error executing forked branch", "error", result.err, "task", result.taskName)
 				replyErr = fmt.Errorf("error executing forked branch %s: %w", result.taskName, result.err)
 				break
@@ -251,6 +260,41 @@ func (t *ForkTaskBuilder) exec(forkedTasks []*forkedTask) (TemporalWorkflowFunc,
 			return nil, replyErr
 		}
 
+		// Branches cancelled with the workflow must not pass for a completed fork
+		if err := ctx.Err(); err != nil {
+			logger.Info("Forked task cancelled", "task", t.GetTaskName())
+			return nil, err
+		}
+
 		return output, nil
 	}, nil
 }
+
+// continueOnBranchError reports whether failing branches should be recorded
+// in the fork output instead of failing the fork. The flag is carried in the
+// task metadata because the DSL fork task has no field for it.
+func (t *ForkTaskBuilder) continueOnBranchError() bool {
+	v, ok := t.task.Metadata[metadata.MetadataContinueOnBranchError].(bool)
+	return ok && v
+}
+
+// branchErrorOutput builds the output recorded for a failed branch when
+// continueOnBranchError is enabled.
+func branchErrorOutput(err error) map[string]any {
+	branchErr := map[string]any{
+		"message": err.Error(),
+	}
+
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) {
+		branchErr["message"] = appErr.Message()
+		if appErr.Type() != "" {
+			branchErr["type"] = appErr.Type()
+		}
+	}
+
+	return map[string]any{
+		"succeeded": false,
+		"error":     branchErr,
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestForkTaskBuilderBranchErrors(t *testing.T) {
+	succeed := func(ctx workflow.Context, input any, state *utils.State) (any, error) {
+		return map[string]any{"count": 2}, nil
+	}
+	fail := func(ctx workflow.Context, input any, state *utils.State) (any, error) {
+		return nil, temporal.NewNonRetryableApplicationError("upstream unavailable", "HTTPError", nil)
+	}
+
+	tests := []struct {
+		name            string
+		continueOnError bool
+		expectError     bool
+	}{
+		{
+			name:        "failing branch fails the fork by default",
+			expectError: true,
+		},
+		{
+			name:            "failing branch is recorded with continueOnBranchError",
+			continueOnError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &model.ForkTask{Fork: model.ForkTaskConfiguration{}}
+			if tc.continueOnError {
+				task.Metadata = map[string]any{metadata.MetadataContinueOnBranchError: true}
+			}
+
+			builder := &ForkTaskBuilder{
+				builder: builder[*model.ForkTask]{name: "gather", task: task},
+			}
+			fn, err := builder.exec([]*forkedTask{
+				{taskName: "users", childWorkflowFunc: succeed},
+				{taskName: "posts", childWorkflowFunc: fail},
+			})
+			require.NoError(t, err)
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestWorkflowEnvironment()
+
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (any, error) {
+				return fn(ctx, nil, utils.NewState())
+			}, workflow.RegisterOptions{Name: "fork"})
+
+			env.ExecuteWorkflow("fork")
+
+			err = env.GetWorkflowError()
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var res map[string]any
+			require.NoError(t, env.GetWorkflowResult(&res))
+			assert.Equal(t, map[string]any{"count": float64(2)}, res["users"])
+			assert.Equal(t, map[string]any{
+				"succeeded": false,
+				"error": map[string]any{
+					"message": "upstream unavailable",
+					"type":    "HTTPError",
+				},
+			}, res["posts"])
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
 	swUtil "github.com/serverlessworkflow/sdk-go/v3/impl/utils"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"go.temporal.io/sdk/temporal"
@@ -37,6 +38,10 @@ const (
 	ListenTaskTypeUpdate ListenTaskType = "update"
 )
 
+// ListenTimeoutErrorType is the error type of a listen task that received no
+// accepted signal before its timeout.
+const ListenTimeoutErrorType = "ListenTimeout"
+
 func NewListenTaskBuilder(
 	temporalWorker worker.Worker,
 	task *model.ListenTask,
@@ -59,13 +64,23 @@ type ListenTaskBuilder struct {
 }
 
 func (t *ListenTaskBuilder) Build() (TemporalWorkflowFunc, error) {
+	// Approval tasks wait for a decision sent through stigmer-server rather
+	// than for their signals
+	approval, err := approvalFromMetadata(t.task.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	if approval != nil {
+		return t.buildApproval(approval), nil
+	}
+
 	events, isAll, err := t.listEvents()
 	if err != nil {
 		return nil, err
 	}
 
 	timeout := time.Minute
-	if timeoutInterface, ok := t.task.Metadata["timeout"]; ok {
+	if timeoutInterface, ok := t.task.Metadata[metadata.MetadataListenTimeout]; ok {
 		if timeoutStr, ok := timeoutInterface.(string); !ok {
 			return nil, fmt.Errorf("timeout must be a string")
 		} else {
@@ -85,13 +100,17 @@ func (t *ListenTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 		areAnyComplete := false
 		await := true
 
-		fn := func(key int) func() {
-			return func() {
+		// Accepted payloads by event ID, returned as the task output
+		received := make(map[string]any, len(events))
+
+		fn := func(key int) func(data any) {
+			return func(data any) {
 				if isAll {
 					areAllComplete[key] = true
 				} else {
 					areAnyComplete = true
 				}
+				received[events[key].With.ID] = data
 			}
 		}
 
@@ -121,18 +140,23 @@ func (t *ListenTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 			}
 		}
 
-		if await {
-			if err := t.await(ctx, timeout, isAll, areAnyComplete, areAllComplete); err != nil {
-				return nil, err
-			}
+		if !await {
+			return nil, nil
+		}
+		if err := t.await(ctx, timeout, isAll, &areAnyComplete, &areAllComplete); err != nil {
+			return nil, err
 		}
 
-		return nil, nil
+		// A single event outputs its payload; several output a map by event ID
+		if len(events) == 1 {
+			return received[events[0].With.ID], nil
+		}
+		return received, nil
 	}, nil
 }
 
 func (t *ListenTaskBuilder) await(
-	ctx workflow.Context, timeout time.Duration, isAll, areAnyComplete bool, areAllComplete []bool,
+	ctx workflow.Context, timeout time.Duration, isAll bool, areAnyComplete *bool, areAllComplete *[]bool,
 ) error {
 	logger := workflow.GetLogger(ctx)
 
@@ -143,11 +167,11 @@ func (t *ListenTaskBuilder) await(
 		}
 		// Calculate if the task has finished
 		if isAll {
-			logger.Debug("Waiting for all listeners to complete", "status", areAllComplete)
-			return utils.SlicesEqual(areAllComplete, true)
+			logger.Debug("Waiting for all listeners to complete", "status", *areAllComplete)
+			return utils.SlicesEqual(*areAllComplete, true)
 		} else {
-			logger.Debug("Waiting for first listening to complete", "state", areAnyComplete)
-			return areAnyComplete
+			logger.Debug("Waiting for first listening to complete", "state", *areAnyComplete)
+			return *areAnyComplete
 		}
 	})
 	if err != nil {
@@ -164,7 +188,11 @@ func (t *ListenTaskBuilder) await(
 	}
 	if !ok {
 		logger.Warn("Await timeout", "task", t.GetTaskName())
-		return fmt.Errorf("timeout")
+		return temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("no accepted signal for task %s within %s", t.GetTaskName(), timeout),
+			ListenTimeoutErrorType,
+			nil,
+		)
 	}
 
 	return nil
@@ -185,7 +213,7 @@ func (t *ListenTaskBuilder) configureQuery(
 }
 
 func (t *ListenTaskBuilder) configureSignal(
-	ctx workflow.Context, cancel workflow.CancelFunc, event *model.EventFilter, state *utils.State, onSuccess func(),
+	ctx workflow.Context, cancel workflow.CancelFunc, event *model.EventFilter, state *utils.State, onSuccess func(any),
 ) {
 	logger := workflow.GetLogger(ctx)
 	logger.Debug("Creating signal", "signal", event.With.ID)
@@ -208,7 +236,7 @@ func (t *ListenTaskBuilder) configureSignal(
 				t.GetTaskName(): inputData,
 			})
 
-			isComplete, err := t.getAcceptIf(event, state)
+			isComplete, err := t.getAcceptIf(event, inputData, state)
 			if err != nil {
 				// Break the for loop
 				logger.Error("Error parsing signal complete status", "error", err)
@@ -217,15 +245,16 @@ func (t *ListenTaskBuilder) configureSignal(
 			}
 
 			if isComplete {
-				onSuccess()
+				onSuccess(inputData)
 				return
 			}
+			logger.Debug("Signal not accepted, waiting for the next one", "signal", event.With.ID)
 		}
 	})
 }
 
 func (t *ListenTaskBuilder) configureUpdate(
-	ctx workflow.Context, event *model.EventFilter, state *utils.State, onSuccess func(),
+	ctx workflow.Context, event *model.EventFilter, state *utils.State, onSuccess func(any),
 ) error {
 	logger := workflow.GetLogger(ctx)
 
@@ -237,7 +266,7 @@ func (t *ListenTaskBuilder) configureUpdate(
 			event.With.ID: data,
 		})
 
-		isComplete, err := t.getAcceptIf(event, state)
+		isComplete, err := t.getAcceptIf(event, data, state)
 		if err != nil {
 			logger.Error("Error parsing update complete status", "error", err)
 			return nil, err
@@ -246,7 +275,7 @@ func (t *ListenTaskBuilder) configureUpdate(
 		res, err := t.processReply(ctx, event, state)
 
 		if isComplete {
-			onSuccess()
+			onSuccess(data)
 		}
 
 		return res, err
@@ -263,8 +292,9 @@ func (t *ListenTaskBuilder) configureUpdate(
 		})
 }
 
-// Search for an acceptIf
-func (t *ListenTaskBuilder) getAcceptIf(event *model.EventFilter, state *utils.State) (isComplete bool, err error) {
+// Search for an acceptIf. The expression is evaluated against the received
+// payload, so it can correlate the event with the workflow state.
+func (t *ListenTaskBuilder) getAcceptIf(event *model.EventFilter, data any, state *utils.State) (isComplete bool, err error) {
 	// Deep clone the additional map so we get the uninterpolated template out each time
 	additional := swUtil.DeepClone(event.With.Additional)
 
@@ -277,7 +307,7 @@ func (t *ListenTaskBuilder) getAcceptIf(event *model.EventFilter, state *utils.S
 				// Put in a map as the template could be anything
 				templateKey: tpl,
 			}),
-			nil,
+			data,
 			state,
 		)
 		if err != nil {
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/config"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/grpc_client"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+func init() {
+	activitiesRegistry = append(activitiesRegistry, &ApprovalActivities{})
+}
+
+const (
+	// defaultApprovalTimeout applies when the approval sets no timeout.
+	defaultApprovalTimeout = 24 * time.Hour
+
+	approvalOnTimeoutFail    = "fail"
+	approvalOnTimeoutApprove = "approve"
+
+	// ApprovalTimeoutErrorType is the error type of an approval task that
+	// received no decision before its timeout.
+	ApprovalTimeoutErrorType = "ApprovalTimeout"
+
+	// workflowExecutionIDPrefix prefixes the execution ID in the Temporal
+	// workflow ID set by ExecuteWorkflowActivity.
+	workflowExecutionIDPrefix = "workflow-exec-"
+)
+
+// approvalConfig is the approval gate of a listen task, read from the task
+// metadata.
+type approvalConfig struct {
+	Approvers []string
+	Timeout   time.Duration
+	OnTimeout string
+}
+
+// approvalFromMetadata reads the approval gate of a listen task. It returns
+// nil if the task is a plain listen task.
+func approvalFromMetadata(meta map[string]any) (*approvalConfig, error) {
+	raw, ok := meta[metadata.MetadataApproval]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s metadata: expected an object, got %T", metadata.MetadataApproval, raw)
+	}
+
+	cfg := &approvalConfig{
+		Timeout:   defaultApprovalTimeout,
+		OnTimeout: approvalOnTimeoutFail,
+	}
+
+	if v, ok := m["approvers"]; ok {
+		list, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s metadata: approvers must be a list", metadata.MetadataApproval)
+		}
+		for _, item := range list {
+			approver, ok := item.(string)
+			if !ok || approver == "" {
+				return nil, fmt.Errorf("invalid %s metadata: approvers must be non-empty strings", metadata.MetadataApproval)
+			}
+			cfg.Approvers = append(cfg.Approvers, approver)
+		}
+	}
+
+	if v, ok := m["timeout"]; ok {
+		timeoutStr, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s metadata: timeout must be a string", metadata.MetadataApproval)
+		}
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s metadata: error parsing timeout to duration: %w", metadata.MetadataApproval, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("invalid %s metadata: timeout must be positive", metadata.MetadataApproval)
+		}
+		cfg.Timeout = timeout
+	}
+
+	if v, ok := m["onTimeout"]; ok {
+		onTimeout, _ := v.(string)
+		switch onTimeout {
+		case approvalOnTimeoutFail, approvalOnTimeoutApprove:
+			cfg.OnTimeout = onTimeout
+		default:
+			return nil, fmt.Errorf("invalid %s metadata: unknown onTimeout %v", metadata.MetadataApproval, v)
+		}
+	}
+
+	return cfg, nil
+}
+
+// buildApproval waits for a decision on an approval task.
+//
+// The wait is an activity completed asynchronously by stigmer-server when
+// the approve RPC is called, so the decision is recorded in the workflow
+// history like any other activity result. The activity's start-to-close
+// timeout is the approval timeout.
+func (t *ListenTaskBuilder) buildApproval(approval *approvalConfig) TemporalWorkflowFunc {
+	return func(ctx workflow.Context, input any, state *utils.State) (any, error) {
+		logger := workflow.GetLogger(ctx)
+		logger.Info("Waiting for approval", "task", t.GetTaskName(), "approvers", approval.Approvers)
+
+		// The ActivityID carries the task name for progress reporting
+		opts := workflow.GetActivityOptions(ctx)
+		opts.ActivityID = fmt.Sprintf("task-%s-%d", t.GetTaskName(), workflow.Now(ctx).UnixNano())
+		opts.StartToCloseTimeout = approval.Timeout
+		opts.ScheduleToCloseTimeout = 0
+		opts.HeartbeatTimeout = 0
+		opts.RetryPolicy = &temporal.RetryPolicy{MaximumAttempts: 1}
+		ctx = workflow.WithActivityOptions(ctx, opts)
+
+		var res map[string]any
+		err := workflow.ExecuteActivity(
+			ctx, (*ApprovalActivities).AwaitApprovalActivity, t.GetTaskName(), approval.Approvers, approval.Timeout,
+		).Get(ctx, &res)
+		if err != nil {
+			if temporal.IsCanceledError(err) {
+				return nil, nil
+			}
+			if !temporal.IsTimeoutError(err) {
+				// Rejections fail with error type "ApprovalRejected"
+				return nil, err
+			}
+			if approval.OnTimeout != approvalOnTimeoutApprove {
+				logger.Warn("Approval timed out", "task", t.GetTaskName())
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("no approval decision for task %s within %s", t.GetTaskName(), approval.Timeout),
+					ApprovalTimeoutErrorType,
+					err,
+				)
+			}
+
+			logger.Warn("Approval timed out, approving automatically", "task", t.GetTaskName())
+			res = map[string]any{
+				"approved":    true,
+				"approved_by": "timeout",
+				"approved_at": workflow.Now(ctx).UTC().Format(time.RFC3339),
+				"comment":     "",
+			}
+		}
+
+		state.AddData(map[string]any{
+			t.GetTaskName(): res,
+		})
+
+		return res, nil
+	}
+}
+
+// ApprovalActivities implements the activity an approval task waits on.
+type ApprovalActivities struct{}
+
+// AwaitApprovalActivity registers a pending approval with stigmer-server and
+// pauses until a decision arrives.
+//
+// Like CallAgentActivity, it uses async completion: the Temporal task token
+// is stored on the execution as the pending approval's callback token, and
+// the activity returns activity.ErrResultPending. The approve RPC completes
+// the activity with the decision ({approved, approved_by, approved_at,
+// comment}) or fails it with error type "ApprovalRejected".
+func (a *ApprovalActivities) AwaitApprovalActivity(
+	ctx context.Context, taskName string, approvers []string, timeout time.Duration,
+) (map[string]any, error) {
+	logger := activity.GetLogger(ctx)
+	info := activity.GetInfo(ctx)
+
+	executionID, ok := strings.CutPrefix(info.WorkflowExecution.ID, workflowExecutionIDPrefix)
+	if !ok || executionID == "" {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("approval task %s must run in a workflow execution (workflow ID %q)", taskName, info.WorkflowExecution.ID),
+			"ApprovalUnsupported",
+			nil,
+		)
+	}
+
+	cfg, err := config.LoadStigmerConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stigmer config: %w", err)
+	}
+	client, err := grpc_client.NewWorkflowExecutionClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow execution client: %w", err)
+	}
+	defer client.Close()
+
+	now := time.Now().UTC()
+	status := &workflowexecutionv1.WorkflowExecutionStatus{
+		Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_AWAITING_APPROVAL,
+		Tasks: []*workflowexecutionv1.WorkflowTask{
+			{
+				TaskId:    taskName,
+				TaskName:  taskName,
+				TaskType:  workflowexecutionv1.WorkflowTaskType_WORKFLOW_TASK_APPROVAL,
+				Status:    workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_AWAITING_APPROVAL,
+				StartedAt: now.Format(time.RFC3339),
+			},
+		},
+		PendingApprovals: []*workflowexecutionv1.PendingApproval{
+			{
+				TaskName:      taskName,
+				Approvers:     approvers,
+				RequestedAt:   now.Format(time.RFC3339),
+				ExpiresAt:     now.Add(timeout).Format(time.RFC3339),
+				CallbackToken: info.TaskToken,
+			},
+		},
+	}
+
+	if _, err := client.UpdateStatus(ctx, executionID, status); err != nil {
+		return nil, fmt.Errorf("failed to register pending approval: %w", err)
+	}
+
+	logger.Info("Approval requested, waiting for decision",
+		"execution_id", executionID,
+		"task", taskName,
+		"expires_at", status.PendingApprovals[0].ExpiresAt)
+
+	return nil, activity.ErrResultPending
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestApprovalFromMetadata(t *testing.T) {
+	tests := []struct {
+		name      string
+		meta      map[string]any
+		expected  *approvalConfig
+		expectErr string
+	}{
+		{
+			name: "plain listen task",
+			meta: map[string]any{"timeout": "1m"},
+		},
+		{
+			name: "defaults",
+			meta: map[string]any{metadata.MetadataApproval: map[string]any{}},
+			expected: &approvalConfig{
+				Timeout:   defaultApprovalTimeout,
+				OnTimeout: approvalOnTimeoutFail,
+			},
+		},
+		{
+			name: "all settings",
+			meta: map[string]any{metadata.MetadataApproval: map[string]any{
+				"approvers": []any{"ops-team", "alice"},
+				"timeout":   "48h0m0s",
+				"onTimeout": "approve",
+			}},
+			expected: &approvalConfig{
+				Approvers: []string{"ops-team", "alice"},
+				Timeout:   48 * time.Hour,
+				OnTimeout: approvalOnTimeoutApprove,
+			},
+		},
+		{
+			name:      "invalid timeout",
+			meta:      map[string]any{metadata.MetadataApproval: map[string]any{"timeout": "soon"}},
+			expectErr: "error parsing timeout",
+		},
+		{
+			name:      "unknown timeout action",
+			meta:      map[string]any{metadata.MetadataApproval: map[string]any{"onTimeout": "escalate"}},
+			expectErr: "unknown onTimeout",
+		},
+		{
+			name:      "empty approver",
+			meta:      map[string]any{metadata.MetadataApproval: map[string]any{"approvers": []any{""}}},
+			expectErr: "approvers must be non-empty strings",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := approvalFromMetadata(tc.meta)
+			if tc.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, cfg)
+		})
+	}
+}
+
+func TestListenTaskBuilderApproval(t *testing.T) {
+	newBuilder := func(onTimeout string) *ListenTaskBuilder {
+		return &ListenTaskBuilder{
+			builder: builder[*model.ListenTask]{
+				name: "approveDeploy",
+				task: &model.ListenTask{
+					TaskBase: model.TaskBase{
+						Metadata: map[string]any{
+							metadata.MetadataApproval: map[string]any{
+								"approvers": []any{"ops-team"},
+								"timeout":   "1h",
+								"onTimeout": onTimeout,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		onTimeout  string
+		result     map[string]any
+		err        error
+		expected   map[string]any
+		expectType string
+	}{
+		{
+			name:      "approved",
+			onTimeout: approvalOnTimeoutFail,
+			result: map[string]any{
+				"approved":    true,
+				"approved_by": "alice",
+				"approved_at": "2026-01-11T14:30:22Z",
+				"comment":     "ship it",
+			},
+			expected: map[string]any{
+				"approved":    true,
+				"approved_by": "alice",
+				"approved_at": "2026-01-11T14:30:22Z",
+				"comment":     "ship it",
+			},
+		},
+		{
+			name:       "rejected",
+			onTimeout:  approvalOnTimeoutFail,
+			err:        temporal.NewNonRetryableApplicationError("approval rejected by alice", "ApprovalRejected", nil),
+			expectType: "ApprovalRejected",
+		},
+		{
+			name:       "timeout fails",
+			onTimeout:  approvalOnTimeoutFail,
+			err:        temporal.NewTimeoutError(enumspb.TIMEOUT_TYPE_START_TO_CLOSE, nil),
+			expectType: ApprovalTimeoutErrorType,
+		},
+		{
+			name:      "timeout approves",
+			onTimeout: approvalOnTimeoutApprove,
+			err:       temporal.NewTimeoutError(enumspb.TIMEOUT_TYPE_START_TO_CLOSE, nil),
+			expected: map[string]any{
+				"approved":    true,
+				"approved_by": "timeout",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fn, err := newBuilder(tc.onTimeout).Build()
+			require.NoError(t, err)
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestWorkflowEnvironment()
+			env.RegisterActivity(&ApprovalActivities{})
+			env.OnActivity((&ApprovalActivities{}).AwaitApprovalActivity, mock.Anything, "approveDeploy", []string{"ops-team"}, time.Hour).
+				Return(tc.result, tc.err)
+
+			env.ExecuteWorkflow(func(ctx workflow.Context) (any, error) {
+				ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+				return fn(ctx, nil, utils.NewState())
+			})
+
+			if tc.expectType != "" {
+				var appErr *temporal.ApplicationError
+				require.True(t, errors.As(env.GetWorkflowError(), &appErr), "error = %v", env.GetWorkflowError())
+				assert.Equal(t, tc.expectType, appErr.Type())
+				return
+			}
+
+			require.NoError(t, env.GetWorkflowError())
+			var got map[string]any
+			require.NoError(t, env.GetWorkflowResult(&got))
+			for k, v := range tc.expected {
+				assert.Equal(t, v, got[k], k)
+			}
+		})
+	}
+}
@@ -18,10 +18,13 @@ package tasks
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/testsuite"
 	"go.temporal.io/sdk/workflow"
 )
@@ -169,3 +172,100 @@ func TestListenTaskBuilderProcessReply(t *testing.T) {
 
 	assert.Equal(t, map[string]any{"result": "hello"}, result)
 }
+
+func TestListenTaskBuilderSignals(t *testing.T) {
+	newTask := func(acceptIf string) *model.ListenTask {
+		with := &model.EventProperties{
+			ID:   "orderPaid",
+			Type: string(ListenTaskTypeSignal),
+		}
+		if acceptIf != "" {
+			with.Additional = map[string]any{"acceptIf": acceptIf}
+		}
+		return &model.ListenTask{
+			TaskBase: model.TaskBase{
+				Metadata: map[string]any{metadata.MetadataListenTimeout: "10m"},
+			},
+			Listen: model.ListenTaskConfiguration{
+				To: &model.EventConsumptionStrategy{
+					One: &model.EventFilter{With: with},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		acceptIf  string
+		signals   []map[string]any
+		expect    any
+		expectErr string
+	}{
+		{
+			name:    "signal completes the task with its payload",
+			signals: []map[string]any{{"orderId": "o-1"}},
+			expect:  map[string]any{"orderId": "o-1"},
+		},
+		{
+			name:     "correlation skips signals for other orders",
+			acceptIf: `${ .orderId == $data.orderId }`,
+			signals:  []map[string]any{{"orderId": "o-2"}, {"orderId": "o-1"}},
+			expect:   map[string]any{"orderId": "o-1"},
+		},
+		{
+			name:      "correlation mismatch times out",
+			acceptIf:  `${ .orderId == $data.orderId }`,
+			signals:   []map[string]any{{"orderId": "o-2"}},
+			expectErr: ListenTimeoutErrorType,
+		},
+		{
+			name:      "no signal times out",
+			expectErr: ListenTimeoutErrorType,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := &ListenTaskBuilder{
+				builder: builder[*model.ListenTask]{
+					name: "waitForPayment",
+					task: newTask(tc.acceptIf),
+				},
+			}
+
+			fn, err := builder.Build()
+			assert.NoError(t, err)
+
+			state := utils.NewState()
+			state.AddData(map[string]any{"orderId": "o-1"})
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestWorkflowEnvironment()
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (any, error) {
+				return fn(ctx, nil, state)
+			}, workflow.RegisterOptions{Name: "listen"})
+
+			for i, signal := range tc.signals {
+				env.RegisterDelayedCallback(func() {
+					env.SignalWorkflow("orderPaid", signal)
+				}, time.Duration(i+1)*time.Minute)
+			}
+
+			env.ExecuteWorkflow("listen")
+
+			err = env.GetWorkflowError()
+			if tc.expectErr != "" {
+				var appErr *temporal.ApplicationError
+				if assert.ErrorAs(t, err, &appErr) {
+					assert.Equal(t, tc.expectErr, appErr.Type())
+				}
+				return
+			}
+			assert.NoError(t, err)
+
+			var res any
+			assert.NoError(t, env.GetWorkflowResult(&res))
+			assert.Equal(t, tc.expect, res)
+		})
+	}
+}
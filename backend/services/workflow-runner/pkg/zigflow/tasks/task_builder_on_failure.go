@@ -0,0 +1,52 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"go.temporal.io/sdk/worker"
+)
+
+// NewOnFailureTaskBuilder creates a builder for the tasks the workflow runs
+// when it fails (document.metadata.onFailure). Returns nil if the workflow
+// has none.
+//
+// The tasks run inline in the workflow, like the catch tasks of a try task,
+// so the builder never registers a workflow.
+func NewOnFailureTaskBuilder(
+	temporalWorker worker.Worker,
+	doc *model.Workflow,
+	opts DoTaskOpts,
+) (*DoTaskBuilder, error) {
+	list, err := metadata.GetOnFailureTasks(doc)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, nil
+	}
+
+	opts.DisableRegisterWorkflow = true
+	b, err := NewDoTaskBuilder(temporalWorker, &model.DoTask{Do: list}, doc.Document.Name, doc, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the on-failure builder: %w", err)
+	}
+	return b, nil
+}
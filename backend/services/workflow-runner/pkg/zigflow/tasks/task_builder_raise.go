@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
 	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"go.temporal.io/sdk/temporal"
@@ -76,6 +77,11 @@ var temporalErrMapping = map[string]func(error, string) error{
 }
 
 func (t *RaiseTaskBuilder) Build() (TemporalWorkflowFunc, error) {
+	definition, err := t.errorDefinition()
+	if err != nil {
+		return nil, err
+	}
+
 	return func(ctx workflow.Context, input any, state *utils.State) (_ any, err error) {
 		logger := workflow.GetLogger(ctx)
 		logger.Debug("Raising error")
@@ -84,55 +90,84 @@ func (t *RaiseTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 		info := workflow.GetInfo(ctx)
 		instanceID := info.WorkflowExecution.ID
 
-		var raiseErr *model.Error
 		var titleResult any = ""
 		var detailResult any = ""
 
-		if definition := t.task.Raise.Error.Definition; definition != nil {
-			if detail := definition.Title; detail != nil {
-				detailResult, err = expr.TraverseAndEvaluateObj(
-					detail.AsObjectOrRuntimeExpr(),
-					state,
-					t.GetTaskName(),
-					gtx,
-				)
-				if err != nil {
-					logger.Error("Error finding error definition", "error", err)
-					err = fmt.Errorf("error finding error definition: %w", err)
-					return nil, err
-				}
+		if detail := definition.Detail; detail != nil {
+			detailResult, err = expr.TraverseAndEvaluateObj(
+				detail.AsObjectOrRuntimeExpr(),
+				state,
+				t.GetTaskName(),
+				gtx,
+			)
+			if err != nil {
+				logger.Error("Error finding error definition", "error", err)
+				err = fmt.Errorf("error finding error definition: %w", err)
+				return nil, err
 			}
+		}
 
-			if title := definition.Title; title != nil {
-				titleResult, err = expr.TraverseAndEvaluateObj(
-					t.task.Raise.Error.Definition.Title.AsObjectOrRuntimeExpr(),
-					state,
-					t.GetTaskName(),
-					gtx,
-				)
-				if err != nil {
-					logger.Error("Error finding error title definition", "error", err)
-					err = fmt.Errorf("error finding error title definition: %w", err)
-					return nil, err
-				}
+		if title := definition.Title; title != nil {
+			titleResult, err = expr.TraverseAndEvaluateObj(
+				title.AsObjectOrRuntimeExpr(),
+				state,
+				t.GetTaskName(),
+				gtx,
+			)
+			if err != nil {
+				logger.Error("Error finding error title definition", "error", err)
+				err = fmt.Errorf("error finding error title definition: %w", err)
+				return nil, err
 			}
+		}
 
-			if raiseErrF, ok := raiseErrFuncMapping[definition.Type.String()]; ok {
-				raiseErr = raiseErrF(fmt.Errorf("%v", detailResult), instanceID)
-			} else if temporalErrF, ok := temporalErrMapping[definition.Title.String()]; ok {
-				return nil, temporalErrF(fmt.Errorf("%v", detailResult), instanceID)
-			} else {
-				raiseErr = definition
-				raiseErr.Detail = model.NewStringOrRuntimeExpr(fmt.Sprintf("%v", detailResult))
-				raiseErr.Instance = &model.JsonPointerOrRuntimeExpression{
-					Value: instanceID,
-				}
-			}
+		// Errors raised by Stigmer RAISE tasks keep their name as the error
+		// type, so try/catch and the execution status can tell them apart
+		if definition.Type.String() == metadata.RaiseErrorType {
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("%v", detailResult),
+				fmt.Sprintf("%v", titleResult),
+				nil,
+			)
+		}
 
-			raiseErr.Title = model.NewStringOrRuntimeExpr(fmt.Sprintf("%v", titleResult))
-			raiseErr.Status = definition.Status
+		var raiseErr *model.Error
+		if raiseErrF, ok := raiseErrFuncMapping[definition.Type.String()]; ok {
+			raiseErr = raiseErrF(fmt.Errorf("%v", detailResult), instanceID)
+		} else if temporalErrF, ok := temporalErrMapping[definition.Title.String()]; ok {
+			return nil, temporalErrF(fmt.Errorf("%v", detailResult), instanceID)
+		} else {
+			// Copy the definition so the workflow document is not modified
+			custom := *definition
+			raiseErr = &custom
+			raiseErr.Detail = model.NewStringOrRuntimeExpr(fmt.Sprintf("%v", detailResult))
+			raiseErr.Instance = &model.JsonPointerOrRuntimeExpression{
+				Value: instanceID,
+			}
 		}
 
+		raiseErr.Title = model.NewStringOrRuntimeExpr(fmt.Sprintf("%v", titleResult))
+		raiseErr.Status = definition.Status
+
 		return nil, raiseErr
 	}, nil
 }
+
+// errorDefinition returns the error the task raises, either defined inline or
+// referenced from the errors declared in the workflow's use section.
+func (t *RaiseTaskBuilder) errorDefinition() (*model.Error, error) {
+	raise := t.task.Raise.Error
+	if raise.Definition != nil {
+		return raise.Definition, nil
+	}
+	if raise.Ref == nil {
+		return nil, fmt.Errorf("raise task %s has no error definition", t.GetTaskName())
+	}
+
+	if t.doc != nil && t.doc.Use != nil {
+		if definition, ok := t.doc.Use.Errors[*raise.Ref]; ok && definition != nil {
+			return definition, nil
+		}
+	}
+	return nil, fmt.Errorf("raise task %s references unknown error %q", t.GetTaskName(), *raise.Ref)
+}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/stretchr/testify/assert"
 	"go.temporal.io/sdk/temporal"
@@ -65,6 +66,24 @@ func TestRaiseTaskBuilderBuild(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "stigmer raise keeps the error name as type",
+			errorDef: &model.Error{
+				Type:   model.NewUriTemplate(metadata.RaiseErrorType),
+				Status: 500,
+				Title:  model.NewStringOrRuntimeExpr("ValidationError"),
+				Detail: model.NewStringOrRuntimeExpr("Invalid payload"),
+			},
+			expectErr: func(err error) {
+				var appErr *temporal.ApplicationError
+				assert.ErrorAs(t, err, &appErr)
+				if assert.NotNil(t, appErr) {
+					assert.Equal(t, "ValidationError", appErr.Type())
+					assert.Equal(t, "Invalid payload", appErr.Message())
+					assert.True(t, appErr.NonRetryable())
+				}
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -101,3 +120,34 @@ func TestRaiseTaskBuilderBuild(t *testing.T) {
 		})
 	}
 }
+
+func TestRaiseTaskBuilderErrorReference(t *testing.T) {
+	declared := &model.Error{
+		Type:   model.NewUriTemplate(model.ErrorTypeValidation),
+		Status: 400,
+	}
+	doc := &model.Workflow{
+		Use: &model.Use{Errors: map[string]*model.Error{"invalidOrder": declared}},
+	}
+
+	newBuilder := func(ref string) *RaiseTaskBuilder {
+		return &RaiseTaskBuilder{
+			builder: builder[*model.RaiseTask]{
+				doc:  doc,
+				name: "raise-task",
+				task: &model.RaiseTask{
+					Raise: model.RaiseTaskConfiguration{
+						Error: model.RaiseTaskError{Ref: &ref},
+					},
+				},
+			},
+		}
+	}
+
+	definition, err := newBuilder("invalidOrder").errorDefinition()
+	assert.NoError(t, err)
+	assert.Same(t, declared, definition)
+
+	_, err = newBuilder("missing").Build()
+	assert.ErrorContains(t, err, `references unknown error "missing"`)
+}
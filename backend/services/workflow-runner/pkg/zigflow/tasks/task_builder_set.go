@@ -52,6 +52,15 @@ func (t *SetTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 
 		setObject := swUtils.DeepClone(t.task.Set)
 
+		// Explicit nulls unset variables rather than setting them to null
+		unset := make([]string, 0)
+		for key, value := range setObject {
+			if value == nil {
+				unset = append(unset, key)
+				delete(setObject, key)
+			}
+		}
+
 		logger.Debug("Traversing set data")
 		result, err := utils.TraverseAndEvaluateObj(
 			model.NewObjectOrRuntimeExpr(setObject),
@@ -70,6 +79,11 @@ func (t *SetTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 		logger.Debug("Setting data to the state")
 		state.AddData(result.(map[string]any))
 
+		if len(unset) > 0 {
+			logger.Debug("Removing unset variables from the state", "variables", unset)
+			state.RemoveData(unset...)
+		}
+
 		return result, nil
 	}, nil
 }
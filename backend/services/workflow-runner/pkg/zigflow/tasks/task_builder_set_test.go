@@ -66,3 +66,39 @@ func TestSetTaskBuilderBuild(t *testing.T) {
 	assert.Equal(t, expected, result)
 	assert.Equal(t, expected["result"], state.Data["result"])
 }
+
+func TestSetTaskBuilderBuild_Unset(t *testing.T) {
+	task := &model.SetTask{
+		Set: map[string]any{
+			"phase":     "publish",
+			"tempToken": nil,
+		},
+	}
+
+	builder, err := NewSetTaskBuilder(nil, task, "set-task", nil)
+	assert.NoError(t, err)
+
+	fn, err := builder.Build()
+	assert.NoError(t, err)
+
+	state := utils.NewState()
+	state.AddData(map[string]any{"tempToken": "secret"})
+	state.Context = map[string]any{
+		"login": map[string]any{"tempToken": "secret"},
+	}
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestWorkflowEnvironment()
+
+	env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (any, error) {
+		return fn(ctx, nil, state)
+	}, workflow.RegisterOptions{Name: "set-task"})
+
+	env.ExecuteWorkflow("set-task")
+	assert.NoError(t, env.GetWorkflowError())
+
+	// The key is removed, not set to null or an empty string
+	assert.NotContains(t, state.Data, "tempToken")
+	assert.NotContains(t, state.Context.(map[string]any)["login"], "tempToken")
+	assert.Equal(t, "publish", state.Data["phase"])
+}
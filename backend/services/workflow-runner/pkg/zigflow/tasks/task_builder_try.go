@@ -18,10 +18,13 @@ package tasks
 
 import (
 	"fmt"
+	"time"
 
-	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
 	"github.com/rs/zerolog/log"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/worker"
 	"go.temporal.io/sdk/workflow"
 )
@@ -32,6 +35,15 @@ func NewTryTaskBuilder(
 	taskName string,
 	doc *model.Workflow,
 ) (*TryTaskBuilder, error) {
+	catches, err := metadata.GetTryCatches(task)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the catch blocks of %s: %w", taskName, err)
+	}
+	retry, err := metadata.GetTryRetry(task)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the retry policy of %s: %w", taskName, err)
+	}
+
 	return &TryTaskBuilder{
 		builder: builder[*model.TryTask]{
 			doc:            doc,
@@ -39,14 +51,25 @@ func NewTryTaskBuilder(
 			task:           task,
 			temporalWorker: temporalWorker,
 		},
+		catches:                  catches,
+		catchesChildWorkflowFunc: make([]TemporalWorkflowFunc, len(catches)),
+		retry:                    retry,
 	}, nil
 }
 
 type TryTaskBuilder struct {
 	builder[*model.TryTask]
 
-	tryChildWorkflowFunc  TemporalWorkflowFunc
+	tryChildWorkflowFunc   TemporalWorkflowFunc
 	catchChildWorkflowFunc TemporalWorkflowFunc
+
+	// catches are the filtered catch blocks evaluated before the catch of the
+	// task, with their workflow functions at the same index
+	catches                  []metadata.TryCatch
+	catchesChildWorkflowFunc []TemporalWorkflowFunc
+
+	// retry is the retry policy of the try workflow, nil if it has none
+	retry *metadata.TryRetry
 }
 
 func (t *TryTaskBuilder) Build() (TemporalWorkflowFunc, error) {
@@ -77,6 +100,21 @@ func (t *TryTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 		}
 	}
 
+	for i, catch := range t.catches {
+		taskType := catchesTaskType(i)
+		_, builder, err := t.createBuilder(taskType, catch.Do)
+		if err != nil {
+			return nil, fmt.Errorf("erroring registering %s tasks for %s: %w", taskType, t.GetTaskName(), err)
+		}
+
+		wf, err := builder.Build()
+		if err != nil {
+			log.Error().Str("task", t.GetTaskName()).Str("taskType", taskType).Msg("Error building for workflow")
+			return nil, fmt.Errorf("error building for workflow: %w", err)
+		}
+		t.catchesChildWorkflowFunc[i] = wf
+	}
+
 	return t.exec()
 }
 
@@ -98,6 +136,19 @@ func (t *TryTaskBuilder) PostLoad() error {
 		}
 	}
 
+	for i, catch := range t.catches {
+		taskType := catchesTaskType(i)
+		_, builder, err := t.createBuilder(taskType, catch.Do)
+		if err != nil {
+			return fmt.Errorf("erroring registering %s post load tasks for %s: %w", taskType, t.GetTaskName(), err)
+		}
+
+		if err = builder.PostLoad(); err != nil {
+			log.Error().Str("task", t.GetTaskName()).Str("taskType", taskType).Msg("Error building for workflow")
+			return fmt.Errorf("error building for post load workflow: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -107,21 +158,32 @@ func (t *TryTaskBuilder) exec() (TemporalWorkflowFunc, error) {
 
 		// Execute the try workflow function inline
 		if t.tryChildWorkflowFunc != nil {
-			res, err := t.tryChildWorkflowFunc(ctx, state.Input, state)
+			res, err := t.runTry(ctx, state)
 			if err != nil {
-				logger.Warn("Try workflow failed, executing catch workflow", "task", t.GetTaskName(), "error", err)
-				
-				// The try workflow has failed - let's run the catch workflow
-				if t.catchChildWorkflowFunc != nil {
-					res, err := t.catchChildWorkflowFunc(ctx, state.Input, state)
-					if err != nil {
-						logger.Error("Catch workflow also failed", "task", t.GetTaskName(), "error", err)
-						return nil, fmt.Errorf("error executing catch workflow: %w", err)
+				// A cancelled workflow must stop, so cancellation is never caught
+				if temporal.IsCanceledError(err) || ctx.Err() != nil {
+					logger.Info("Try workflow cancelled, skipping catch workflow", "task", t.GetTaskName())
+					return nil, err
+				}
+
+				// The try workflow has failed - run the first catch block that
+				// handles the error, filtered ones first
+				failure := t.failure(err, state)
+				for i, catch := range t.catches {
+					if !catch.Matches(failure) {
+						continue
 					}
-					return res, nil
+					logger.Warn("Try workflow failed, executing matching catch workflow",
+						"task", t.GetTaskName(), "catch", i, "error", err)
+					return t.runCatch(ctx, state, t.catchesChildWorkflowFunc[i], catch.As, failure)
+				}
+
+				if t.catchChildWorkflowFunc != nil {
+					logger.Warn("Try workflow failed, executing catch workflow", "task", t.GetTaskName(), "error", err)
+					return t.runCatch(ctx, state, t.catchChildWorkflowFunc, t.task.Catch.As, failure)
 				}
-				
-				// No catch workflow defined, return the error
+
+				// No catch workflow handles the error, return it
 				return nil, err
 			}
 			return res, nil
@@ -132,6 +194,76 @@ func (t *TryTaskBuilder) exec() (TemporalWorkflowFunc, error) {
 	}, nil
 }
 
+// runTry runs the try workflow, and runs it again for as long as it fails
+// with an error the retry policy matches and retries are left.
+func (t *TryTaskBuilder) runTry(ctx workflow.Context, state *utils.State) (any, error) {
+	logger := workflow.GetLogger(ctx)
+
+	for attempt := 0; ; attempt++ {
+		res, err := t.tryChildWorkflowFunc(ctx, state.Input, state)
+		if err == nil || t.retry == nil || attempt >= t.retry.MaxRetries {
+			return res, err
+		}
+		// A cancelled workflow must stop, so cancellation is never retried
+		if temporal.IsCanceledError(err) || ctx.Err() != nil || !t.retry.Matches(t.failure(err, state)) {
+			return res, err
+		}
+
+		logger.Warn("Try workflow failed, retrying",
+			"task", t.GetTaskName(), "retry", attempt+1, "maxRetries", t.retry.MaxRetries, "error", err)
+		if t.retry.DelaySeconds > 0 {
+			if err := workflow.Sleep(ctx, time.Duration(t.retry.DelaySeconds)*time.Second); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// failure describes the error the try workflow failed with, as matched by
+// the filters of the catch blocks.
+func (t *TryTaskBuilder) failure(err error, state *utils.State) utils.WorkflowFailure {
+	var failedTask string
+	if task, ok := state.Data["task"].(map[string]any); ok {
+		failedTask, _ = task["name"].(string)
+	}
+	return utils.NewWorkflowFailure(err, failedTask)
+}
+
+// runCatch runs the tasks of a catch block. They read the caught error as
+// $data.<as> ($data.error by default), like the on-failure tasks of the
+// workflow.
+func (t *TryTaskBuilder) runCatch(
+	ctx workflow.Context, state *utils.State, catchFunc TemporalWorkflowFunc, as string, failure utils.WorkflowFailure,
+) (any, error) {
+	if as == "" {
+		as = "error"
+	}
+	caught := map[string]any{
+		"message":        failure.Message,
+		"type":           failure.Type,
+		"classification": failure.Classification,
+		"task":           failure.Task,
+		"timestamp":      workflow.Now(ctx).UTC().Format(time.RFC3339),
+	}
+	if failure.Status != 0 {
+		caught["status"] = failure.Status
+	}
+	state.AddData(map[string]any{as: caught})
+
+	res, err := catchFunc(ctx, state.Input, state)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("Catch workflow also failed", "task", t.GetTaskName(), "error", err)
+		return nil, fmt.Errorf("error executing catch workflow: %w", err)
+	}
+	return res, nil
+}
+
+// catchesTaskType is the task type of the i-th filtered catch block, used to
+// name its workflow.
+func catchesTaskType(i int) string {
+	return fmt.Sprintf("catch%d", i)
+}
+
 func (t *TryTaskBuilder) getTasks() map[string]*model.TaskList {
 	var catchDo *model.TaskList
 	if t.task.Catch != nil {
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/stigmer/stigmer/backend/services/workflow-runner/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/metadata"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestTryTaskBuilderRetriesExecutionTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		retry      *metadata.TryRetry
+		slowRuns   int
+		expectRuns int
+		expectErr  bool
+	}{
+		{
+			name:       "timed-out task retries and succeeds",
+			retry:      &metadata.TryRetry{ErrorTypes: []string{errTypeExecutionTimeout}, MaxRetries: 2},
+			slowRuns:   1,
+			expectRuns: 2,
+		},
+		{
+			name:       "retries after a delay",
+			retry:      &metadata.TryRetry{ErrorTypes: []string{errTypeExecutionTimeout}, MaxRetries: 2, DelaySeconds: 30},
+			slowRuns:   2,
+			expectRuns: 3,
+		},
+		{
+			name:       "fails once the retries are used up",
+			retry:      &metadata.TryRetry{ErrorTypes: []string{errTypeExecutionTimeout}, MaxRetries: 1},
+			slowRuns:   2,
+			expectRuns: 2,
+			expectErr:  true,
+		},
+		{
+			name:       "other error types are not retried",
+			retry:      &metadata.TryRetry{ErrorTypes: []string{"UPSTREAM_5XX"}, MaxRetries: 2},
+			slowRuns:   1,
+			expectRuns: 1,
+			expectErr:  true,
+		},
+		{
+			name:       "no retry policy",
+			slowRuns:   1,
+			expectRuns: 1,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doBuilder := newTestDoTaskBuilder("try-retry")
+
+			// The task outlives its 10s execution timeout on its first slowRuns runs
+			runs := 0
+			task := workflowFunc{
+				TaskBuilder: newFakeTaskBuilder("review", &model.TaskBase{
+					Timeout: &model.TimeoutOrReference{
+						Timeout: &model.Timeout{
+							After: &model.Duration{Value: model.DurationInline{Seconds: 10}},
+						},
+					},
+				}),
+				Name: "review",
+				Func: func(ctx workflow.Context, input any, state *utils.State) (any, error) {
+					runs++
+					if runs <= tc.slowRuns {
+						return nil, workflow.Sleep(ctx, time.Hour)
+					}
+					return map[string]any{"approved": true}, nil
+				},
+			}
+
+			builder := &TryTaskBuilder{
+				builder: builder[*model.TryTask]{
+					doc:  &model.Workflow{},
+					name: "guardedReview",
+					task: &model.TryTask{},
+				},
+				tryChildWorkflowFunc: func(ctx workflow.Context, input any, state *utils.State) (any, error) {
+					return nil, doBuilder.runTask(ctx, task, input, state)
+				},
+				retry: tc.retry,
+			}
+			exec, err := builder.exec()
+			assert.NoError(t, err)
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestWorkflowEnvironment()
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (any, error) {
+				return exec(ctx, nil, utils.NewState())
+			}, workflow.RegisterOptions{Name: builder.GetTaskName()})
+
+			env.ExecuteWorkflow(builder.GetTaskName())
+
+			assert.Equal(t, tc.expectRuns, runs)
+			err = env.GetWorkflowError()
+			if !tc.expectErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var appErr *temporal.ApplicationError
+			if assert.ErrorAs(t, err, &appErr) {
+				assert.Equal(t, errTypeExecutionTimeout, appErr.Type())
+			}
+		})
+	}
+}
@@ -56,7 +56,8 @@ func (t *WaitTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 
 		if err := workflow.Sleep(ctx, duration); err != nil {
 			if temporal.IsCanceledError(err) {
-				return nil, nil
+				logger.Info("Sleep cancelled")
+				return nil, err
 			}
 
 			logger.Error("Error creating sleep instruction", "error", err)
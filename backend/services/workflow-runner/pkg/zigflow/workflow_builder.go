@@ -23,6 +23,7 @@ import (
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/tasks"
 	"github.com/rs/zerolog/log"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/worker"
 )
 
@@ -64,6 +65,11 @@ func NewWorkflow(temporalWorker worker.Worker, doc *model.Workflow, envvars map[
 		temporalWorker.RegisterActivity(a)
 	}
 
+	for name, fn := range tasks.CustomActivities() {
+		l.Debug().Str("activity", name).Msg("Registering custom activity")
+		temporalWorker.RegisterActivityWithOptions(fn, activity.RegisterOptions{Name: name})
+	}
+
 	return nil
 }
 
@@ -21,14 +21,17 @@ import (
 	"fmt"
 	"time"
 
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
 	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
 	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/config"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/converter"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/grpc_client"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/types"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
 	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
 )
 
 // ExecuteWorkflowActivity implements the ExecuteWorkflowActivity interface from Java.
@@ -156,8 +159,9 @@ func (a *ExecuteWorkflowActivityImpl) ExecuteWorkflow(
 				"error", err)
 
 			a.workflowExecutionClient.UpdateStatus(ctx, executionID, &workflowexecutionv1.WorkflowExecutionStatus{
-				Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
-				Error: fmt.Sprintf("Failed to query workflow instance: %v", err),
+				Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+				Error:               fmt.Sprintf("Failed to query workflow instance: %v", err),
+				ErrorClassification: workflowexecutionv1.ErrorClassification_INFRA,
 			})
 
 			return nil, fmt.Errorf("failed to query workflow instance %s: %w", workflowInstanceID, err)
@@ -174,8 +178,9 @@ func (a *ExecuteWorkflowActivityImpl) ExecuteWorkflow(
 				"error", err)
 
 			a.workflowExecutionClient.UpdateStatus(ctx, executionID, &workflowexecutionv1.WorkflowExecutionStatus{
-				Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
-				Error: fmt.Sprintf("Failed to query workflow: %v", err),
+				Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+				Error:               fmt.Sprintf("Failed to query workflow: %v", err),
+				ErrorClassification: workflowexecutionv1.ErrorClassification_INFRA,
 			})
 
 			return nil, fmt.Errorf("failed to query workflow %s: %w", workflowID, err)
@@ -189,8 +194,9 @@ func (a *ExecuteWorkflowActivityImpl) ExecuteWorkflow(
 			logger.Error("Workflow missing default instance", "workflow_id", workflowID)
 
 			a.workflowExecutionClient.UpdateStatus(ctx, executionID, &workflowexecutionv1.WorkflowExecutionStatus{
-				Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
-				Error: err.Error(),
+				Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+				Error:               err.Error(),
+				ErrorClassification: workflowexecutionv1.ErrorClassification_USER_ERROR,
 			})
 
 			return nil, err
@@ -205,8 +211,9 @@ func (a *ExecuteWorkflowActivityImpl) ExecuteWorkflow(
 				"error", err)
 
 			a.workflowExecutionClient.UpdateStatus(ctx, executionID, &workflowexecutionv1.WorkflowExecutionStatus{
-				Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
-				Error: fmt.Sprintf("Failed to query default instance: %v", err),
+				Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+				Error:               fmt.Sprintf("Failed to query default instance: %v", err),
+				ErrorClassification: workflowexecutionv1.ErrorClassification_INFRA,
 			})
 
 			return nil, fmt.Errorf("failed to query default instance %s: %w", workflowInstanceID, err)
@@ -226,8 +233,9 @@ func (a *ExecuteWorkflowActivityImpl) ExecuteWorkflow(
 			"error", err)
 
 		a.workflowExecutionClient.UpdateStatus(ctx, executionID, &workflowexecutionv1.WorkflowExecutionStatus{
-			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
-			Error: fmt.Sprintf("Failed to query workflow: %v", err),
+			Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+			Error:               fmt.Sprintf("Failed to query workflow: %v", err),
+			ErrorClassification: workflowexecutionv1.ErrorClassification_INFRA,
 		})
 
 		return nil, fmt.Errorf("failed to query workflow %s: %w", workflowID, err)
@@ -247,8 +255,9 @@ func (a *ExecuteWorkflowActivityImpl) ExecuteWorkflow(
 			"error", err)
 
 		a.workflowExecutionClient.UpdateStatus(ctx, executionID, &workflowexecutionv1.WorkflowExecutionStatus{
-			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
-			Error: fmt.Sprintf("Failed to convert workflow to YAML: %v", err),
+			Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+			Error:               fmt.Sprintf("Failed to convert workflow to YAML: %v", err),
+			ErrorClassification: workflowexecutionv1.ErrorClassification_USER_ERROR,
 		})
 
 		return nil, fmt.Errorf("failed to convert workflow to YAML: %w", err)
@@ -262,9 +271,10 @@ func (a *ExecuteWorkflowActivityImpl) ExecuteWorkflow(
 		"execution_id", executionID,
 		"task_queue", a.executionTaskQueue)
 
-	// Build runtime environment from execution.Spec.RuntimeEnv
+	// Build runtime environment from the workflow defaults, overridden by
+	// execution.Spec.RuntimeEnv
 	// This enables just-in-time secret resolution in Zigflow activities
-	runtimeEnv := make(map[string]any)
+	runtimeEnv := workflowEnvDefaults(workflow.Spec.GetEnvSpec())
 	if execution.Spec != nil && execution.Spec.RuntimeEnv != nil {
 		logger.Info("Processing runtime environment variables",
 			"execution_id", executionID,
@@ -301,11 +311,17 @@ func (a *ExecuteWorkflowActivityImpl) ExecuteWorkflow(
 
 	// Start ExecuteServerlessWorkflow on zigflow_execution queue
 	workflowOptions := client.StartWorkflowOptions{
-		ID:        fmt.Sprintf("workflow-exec-%s", executionID),
-		TaskQueue: a.executionTaskQueue,
-		// TODO: Configure timeouts based on workflow spec
+		ID:                       fmt.Sprintf("workflow-exec-%s", executionID),
+		TaskQueue:                a.executionTaskQueue,
 		WorkflowExecutionTimeout: 30 * time.Minute,
 	}
+	// The runner stops the tasks at the workflow's maximum duration and runs
+	// its on-failure tasks; the run timeout is only a backstop past them.
+	maxDuration := time.Duration(workflow.Spec.GetMaxDurationSeconds()) * time.Second
+	if maxDuration > 0 {
+		workflowOptions.WorkflowExecutionTimeout = 0
+		workflowOptions.WorkflowRunTimeout = maxDuration + maxDurationGracePeriod
+	}
 
 	workflowInput := &types.TemporalWorkflowInput{
 		WorkflowExecutionID: executionID,
@@ -324,8 +340,9 @@ func (a *ExecuteWorkflowActivityImpl) ExecuteWorkflow(
 
 		// Update status to FAILED
 		a.workflowExecutionClient.UpdateStatus(ctx, executionID, &workflowexecutionv1.WorkflowExecutionStatus{
-			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
-			Error: fmt.Sprintf("Failed to start workflow: %v", err),
+			Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+			Error:               fmt.Sprintf("Failed to start workflow: %v", err),
+			ErrorClassification: workflowexecutionv1.ErrorClassification_INFRA,
 		})
 
 		return nil, fmt.Errorf("failed to start ExecuteServerlessWorkflow: %w", err)
@@ -338,16 +355,49 @@ func (a *ExecuteWorkflowActivityImpl) ExecuteWorkflow(
 
 	// Wait for workflow to complete
 	var workflowOutput types.TemporalWorkflowOutput
-	err = run.Get(ctx, &workflowOutput)
+	err = a.waitForWorkflow(ctx, run, &workflowOutput)
+	if temporal.IsCanceledError(err) || ctx.Err() != nil {
+		logger.Info("ExecuteServerlessWorkflow cancelled",
+			"execution_id", executionID,
+			"error", err)
+
+		// The activity context is done, so the final status is reported on
+		// a context that outlives it. The controller fills in who cancelled.
+		status := &workflowexecutionv1.WorkflowExecutionStatus{
+			Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED,
+			ErrorClassification: workflowexecutionv1.ErrorClassification_CANCELLED,
+		}
+		a.workflowExecutionClient.UpdateStatus(context.WithoutCancel(ctx), executionID, status)
+
+		// Returning the context error reports the activity as cancelled
+		if ctx.Err() != nil {
+			return status, ctx.Err()
+		}
+		return status, nil
+	}
 	if err != nil {
 		logger.Error("ExecuteServerlessWorkflow failed",
 			"execution_id", executionID,
 			"error", err)
 
-		// Update status to FAILED
+		// Update status to FAILED. Task failures were already classified by
+		// the progress interceptor; the controller copies the failed task's
+		// classification when this one is unspecified. After on-failure
+		// tasks ran, the original failure is reported explicitly, so a failed
+		// on-failure task does not take its place.
 		status := &workflowexecutionv1.WorkflowExecutionStatus{
-			Phase: workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
-			Error: fmt.Sprintf("Workflow execution failed: %v", err),
+			Phase:               workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED,
+			Error:               fmt.Sprintf("Workflow execution failed: %v", err),
+			ErrorClassification: utils.ClassifyWorkflowError(err),
+		}
+		if failure, ok := utils.WorkflowFailureFromError(err); ok {
+			status.FailedTask = failure.Task
+			if classification, ok := workflowexecutionv1.ErrorClassification_value[failure.Classification]; ok {
+				status.ErrorClassification = workflowexecutionv1.ErrorClassification(classification)
+			}
+		} else if maxDuration > 0 && status.ErrorClassification == workflowexecutionv1.ErrorClassification_TIMEOUT {
+			// The run timeout backstop fired before the runner could stop the tasks
+			status.ErrorClassification = workflowexecutionv1.ErrorClassification_WORKFLOW_TIMEOUT
 		}
 
 		a.workflowExecutionClient.UpdateStatus(ctx, executionID, status)
@@ -369,6 +419,79 @@ func (a *ExecuteWorkflowActivityImpl) ExecuteWorkflow(
 	return status, nil
 }
 
+// maxDurationGracePeriod is how long a workflow with a maximum duration may
+// run past it, for its on-failure tasks, before Temporal times the run out.
+const maxDurationGracePeriod = 2 * time.Minute
+
+// executeWorkflowHeartbeatInterval is how often the activity heartbeats while
+// the Zigflow workflow runs. It must stay well below the heartbeat timeout set
+// by stigmer-server (30s).
+const executeWorkflowHeartbeatInterval = 10 * time.Second
+
+// waitForWorkflow waits for the Zigflow workflow to finish and stores its
+// output.
+//
+// The activity heartbeats while it waits: heartbeats are how Temporal delivers
+// the cancellation of the execution (the cancel RPC) to the activity. Once the
+// activity context is cancelled, the Zigflow workflow is cancelled and awaited,
+// so its in-flight activities are aborted and its loops stop before the final
+// status is reported.
+func (a *ExecuteWorkflowActivityImpl) waitForWorkflow(
+	ctx context.Context,
+	run client.WorkflowRun,
+	output *types.TemporalWorkflowOutput,
+) error {
+	logger := activity.GetLogger(ctx)
+
+	// The result is read on a context that outlives the activity context, so
+	// the cancelled workflow can still be awaited
+	waitCtx := context.WithoutCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- run.Get(waitCtx, output)
+	}()
+
+	ticker := time.NewTicker(executeWorkflowHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-ticker.C:
+			activity.RecordHeartbeat(ctx)
+		case <-ctx.Done():
+			logger.Info("Execution cancelled, cancelling ExecuteServerlessWorkflow",
+				"workflow_id", run.GetID(),
+				"run_id", run.GetRunID())
+			if err := a.temporalClient.CancelWorkflow(waitCtx, run.GetID(), run.GetRunID()); err != nil {
+				logger.Warn("Failed to cancel ExecuteServerlessWorkflow",
+					"workflow_id", run.GetID(),
+					"error", err)
+			}
+			return <-done
+		}
+	}
+}
+
+// workflowEnvDefaults returns the runtime environment seeded with the
+// non-secret default values declared in the workflow env spec, such as the
+// values exported with ctx.ExportToRuntime. Secrets never have defaults:
+// they must be provided with each execution.
+func workflowEnvDefaults(envSpec *environmentv1.EnvironmentSpec) map[string]any {
+	runtimeEnv := make(map[string]any)
+	for key, value := range envSpec.GetData() {
+		if value.GetIsSecret() || value.GetValue() == "" {
+			continue
+		}
+		runtimeEnv[key] = map[string]interface{}{
+			"value":     value.GetValue(),
+			"is_secret": false,
+		}
+	}
+	return runtimeEnv
+}
+
 // Close releases resources held by the activity.
 func (a *ExecuteWorkflowActivityImpl) Close() error {
 	var errs []error
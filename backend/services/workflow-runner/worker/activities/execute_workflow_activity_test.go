@@ -0,0 +1,43 @@
+/*
+ * Copyright 2026 Leftbin/Stigmer
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package activities
+
+import (
+	"testing"
+
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowEnvDefaults(t *testing.T) {
+	envSpec := &environmentv1.EnvironmentSpec{
+		Data: map[string]*environmentv1.EnvironmentValue{
+			"apiBase":   {Value: "https://api.example.com"},
+			"REGION":    {Description: "no default"},
+			"API_TOKEN": {Value: "ignored", IsSecret: true},
+		},
+	}
+
+	assert.Equal(t, map[string]any{
+		"apiBase": map[string]interface{}{
+			"value":     "https://api.example.com",
+			"is_secret": false,
+		},
+	}, workflowEnvDefaults(envSpec))
+
+	assert.Empty(t, workflowEnvDefaults(nil))
+}
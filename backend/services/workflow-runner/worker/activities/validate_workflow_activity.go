@@ -225,6 +225,12 @@ func (a *ValidateWorkflowActivities) ValidateStructureActivity(ctx context.Conte
 		output.Errors = append(output.Errors, fmt.Sprintf("Task validation failed: %v", err))
 		return output, nil
 	}
+	if err := buildOnFailureTasks(workflow); err != nil {
+		logger.Error("On-failure task validation failed", "error", err)
+		output.IsValid = false
+		output.Errors = append(output.Errors, fmt.Sprintf("On-failure task validation failed: %v", err))
+		return output, nil
+	}
 
 	logger.Info("Workflow structure validation completed successfully")
 
@@ -351,6 +357,15 @@ func (a *ValidateWorkflowActivities) ValidateWorkflow(ctx context.Context, spec
 			Errors: errors,
 		}, nil
 	}
+	if err := buildOnFailureTasks(workflow); err != nil {
+		logger.Error("On-failure task validation failed", "error", err)
+		errors = append(errors, fmt.Sprintf("On-failure task validation failed: %v", err))
+		return &serverlessv1.ServerlessWorkflowValidation{
+			State:  serverlessv1.ValidationState_INVALID,
+			Yaml:   yaml,
+			Errors: errors,
+		}, nil
+	}
 
 	logger.Info("Workflow validation completed successfully")
 
@@ -362,3 +377,16 @@ func (a *ValidateWorkflowActivities) ValidateWorkflow(ctx context.Context, spec
 		Warnings: warnings,
 	}, nil
 }
+
+// buildOnFailureTasks builds the tasks the workflow runs when it fails, if
+// any, to validate them like the main tasks.
+func buildOnFailureTasks(workflow *model.Workflow) error {
+	builder, err := tasks.NewOnFailureTaskBuilder(nil, workflow, tasks.DoTaskOpts{
+		Envvars: map[string]any{},
+	})
+	if err != nil || builder == nil {
+		return err
+	}
+	_, err = builder.Build()
+	return err
+}
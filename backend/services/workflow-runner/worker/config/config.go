@@ -19,10 +19,13 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 
 	stigmerconfig "github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/config"
+	"golang.org/x/net/http/httpproxy"
 )
 
 // Config holds Temporal worker configuration loaded from environment variables
@@ -55,6 +58,24 @@ type Config struct {
 	R2SecretAccessKey string
 	R2Region          string
 
+	// Task execution metrics, exposed in Prometheus format on /metrics
+	MetricsEnabled bool
+	MetricsPort    int
+
+	// Outbound proxy of HTTP call tasks, from the standard HTTP_PROXY,
+	// HTTPS_PROXY and NO_PROXY variables (or their lowercase forms).
+	// Tasks can override it with workflow.Proxy or workflow.NoProxy.
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+
+	// Outbound host policy of HTTP call tasks, from the comma-separated
+	// HTTP_ALLOWED_HOSTS and HTTP_DENIED_HOSTS variables. Entries are host
+	// globs ("*.example.com"), IP addresses or CIDR ranges ("10.0.0.0/8").
+	// Denied hosts win; a non-empty allowlist blocks every other host.
+	HTTPAllowedHosts []string
+	HTTPDeniedHosts  []string
+
 	// Stigmer backend configuration (for progress callbacks and workflow queries)
 	StigmerConfig *stigmerconfig.StigmerConfig
 }
@@ -67,6 +88,8 @@ func LoadFromEnv() (*Config, error) {
 		return nil, fmt.Errorf("failed to load Stigmer config: %w", err)
 	}
 
+	proxyCfg := httpproxy.FromEnvironment()
+
 	cfg := &Config{
 		TemporalServiceAddress: getEnvOrDefault("TEMPORAL_SERVICE_ADDRESS", "localhost:7233"),
 		TemporalNamespace:      getEnvOrDefault("TEMPORAL_NAMESPACE", "default"),
@@ -88,6 +111,19 @@ func LoadFromEnv() (*Config, error) {
 		R2SecretAccessKey: getEnvOrDefault("R2_SECRET_ACCESS_KEY", ""),
 		R2Region:          getEnvOrDefault("R2_REGION", "auto"),
 
+		// Metrics configuration
+		MetricsEnabled: getEnvAsBoolOrDefault("METRICS_ENABLED", false),
+		MetricsPort:    getEnvAsIntOrDefault("METRICS_PORT", 9090),
+
+		// Proxy configuration
+		HTTPProxy:  proxyCfg.HTTPProxy,
+		HTTPSProxy: proxyCfg.HTTPSProxy,
+		NoProxy:    proxyCfg.NoProxy,
+
+		// Host policy configuration
+		HTTPAllowedHosts: getEnvAsListOrDefault("HTTP_ALLOWED_HOSTS", nil),
+		HTTPDeniedHosts:  getEnvAsListOrDefault("HTTP_DENIED_HOSTS", nil),
+
 		// Stigmer backend configuration
 		StigmerConfig: stigmerCfg,
 	}
@@ -122,9 +158,54 @@ func LoadFromEnv() (*Config, error) {
 		}
 	}
 
+	if cfg.MetricsEnabled && (cfg.MetricsPort < 1 || cfg.MetricsPort > 65535) {
+		return nil, fmt.Errorf("METRICS_PORT must be between 1 and 65535, got %d", cfg.MetricsPort)
+	}
+
+	// Proxy URLs are not echoed: they may embed credentials
+	if err := validateProxyURL(cfg.HTTPProxy); err != nil {
+		return nil, fmt.Errorf("HTTP_PROXY is invalid: %w", err)
+	}
+	if err := validateProxyURL(cfg.HTTPSProxy); err != nil {
+		return nil, fmt.Errorf("HTTPS_PROXY is invalid: %w", err)
+	}
+
 	return cfg, nil
 }
 
+// ProxyConfig returns the proxy configuration of HTTP call tasks.
+func (c *Config) ProxyConfig() *httpproxy.Config {
+	return &httpproxy.Config{
+		HTTPProxy:  c.HTTPProxy,
+		HTTPSProxy: c.HTTPSProxy,
+		NoProxy:    c.NoProxy,
+	}
+}
+
+// validateProxyURL checks a proxy URL the way the HTTP client reads it: a
+// URL without a scheme is an http proxy.
+func validateProxyURL(proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return errors.New("not a valid URL")
+	}
+	if u.Host == "" {
+		return errors.New("missing proxy host")
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+		return nil
+	default:
+		return fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+	}
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -132,6 +213,20 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvAsListOrDefault(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func getEnvAsIntOrDefault(key string, defaultValue int) int {
 	if valueStr := os.Getenv(key); valueStr != "" {
 		if value, err := strconv.Atoi(valueStr); err == nil {
@@ -160,6 +255,6 @@ func getEnvAsBoolOrDefault(key string, defaultValue bool) bool {
 }
 
 func (c *Config) String() string {
-	return fmt.Sprintf("TemporalServiceAddress=%s, Namespace=%s, OrchestrationQueue=%s, ExecutionQueue=%s, ValidationQueue=%s, MaxConcurrency=%d, ClaimCheckEnabled=%v",
-		c.TemporalServiceAddress, c.TemporalNamespace, c.OrchestrationTaskQueue, c.ExecutionTaskQueue, c.ValidationTaskQueue, c.MaxConcurrency, c.ClaimCheckEnabled)
+	return fmt.Sprintf("TemporalServiceAddress=%s, Namespace=%s, OrchestrationQueue=%s, ExecutionQueue=%s, ValidationQueue=%s, MaxConcurrency=%d, ClaimCheckEnabled=%v, MetricsEnabled=%v, MetricsPort=%d",
+		c.TemporalServiceAddress, c.TemporalNamespace, c.OrchestrationTaskQueue, c.ExecutionTaskQueue, c.ValidationTaskQueue, c.MaxConcurrency, c.ClaimCheckEnabled, c.MetricsEnabled, c.MetricsPort)
 }
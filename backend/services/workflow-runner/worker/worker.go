@@ -18,11 +18,15 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/claimcheck"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/executor"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/interceptors"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/metrics"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/temporal/searchattributes"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/tasks"
 	"github.com/stigmer/stigmer/backend/services/workflow-runner/worker/activities"
@@ -52,10 +56,27 @@ type ZigflowWorker struct {
 	claimCheckManager          *claimcheck.Manager
 	executeWorkflowActivity    *activities.ExecuteWorkflowActivityImpl
 	validateWorkflowActivities *activities.ValidateWorkflowActivities
+
+	// metricsServer exposes task metrics on /metrics (nil if metrics are disabled)
+	metricsServer *http.Server
 }
 
 // NewZigflowWorker creates a new Temporal worker system with two-queue architecture.
 func NewZigflowWorker(cfg *config.Config) (*ZigflowWorker, error) {
+	// HTTP call tasks only reach the hosts allowed by the worker's host policy
+	if err := tasks.SetHTTPHostPolicy(&tasks.HTTPHostPolicy{
+		Allow: cfg.HTTPAllowedHosts,
+		Deny:  cfg.HTTPDeniedHosts,
+	}); err != nil {
+		return nil, fmt.Errorf("invalid HTTP host policy: %w", err)
+	}
+	if len(cfg.HTTPAllowedHosts) > 0 || len(cfg.HTTPDeniedHosts) > 0 {
+		log.Info().
+			Strs("allowed_hosts", cfg.HTTPAllowedHosts).
+			Strs("denied_hosts", cfg.HTTPDeniedHosts).
+			Msg("HTTP call tasks are restricted by the host policy")
+	}
+
 	// Create Temporal client
 	temporalClient, err := client.Dial(client.Options{
 		HostPort:  cfg.TemporalServiceAddress,
@@ -80,6 +101,16 @@ func NewZigflowWorker(cfg *config.Config) (*ZigflowWorker, error) {
 		// The failure will surface later when workflows try to use them
 	}
 
+	// HTTP call tasks use the worker's proxy unless they override it
+	tasks.SetHTTPProxy(cfg.ProxyConfig())
+	if cfg.HTTPProxy != "" || cfg.HTTPSProxy != "" {
+		log.Info().
+			Bool("http_proxy", cfg.HTTPProxy != "").
+			Bool("https_proxy", cfg.HTTPSProxy != "").
+			Str("no_proxy", cfg.NoProxy).
+			Msg("HTTP call tasks use the configured proxy")
+	}
+
 	// Initialize Claim Check Manager if enabled
 	var claimCheckMgr *claimcheck.Manager
 	if cfg.ClaimCheckEnabled {
@@ -132,6 +163,19 @@ func NewZigflowWorker(cfg *config.Config) (*ZigflowWorker, error) {
 
 	// Create progress reporting interceptor
 	progressInterceptor := interceptors.NewProgressReportingInterceptor(cfg.StigmerConfig)
+	executionInterceptors := []interceptor.WorkerInterceptor{
+		progressInterceptor, // Automatic progress reporting for Zigflow activities
+	}
+
+	// Create metrics interceptor if enabled. It runs inside the progress
+	// interceptor so it shares the task metadata the activities record.
+	var metricsServer *http.Server
+	if cfg.MetricsEnabled {
+		sink := metrics.NewPrometheusSink()
+		metricsServer = sink.NewServer(cfg.MetricsPort)
+		executionInterceptors = append(executionInterceptors, interceptors.NewMetricsInterceptor(sink))
+		log.Info().Int("port", cfg.MetricsPort).Msg("Task metrics enabled")
+	}
 
 	// Create Worker 1: Orchestration Queue (workflow_execution)
 	// Handles: ExecuteWorkflowActivity (Java → Go polyglot activity)
@@ -147,9 +191,7 @@ func NewZigflowWorker(cfg *config.Config) (*ZigflowWorker, error) {
 	// Handles: ExecuteServerlessWorkflow + all Zigflow activities
 	executionWorker := worker.New(temporalClient, cfg.ExecutionTaskQueue, worker.Options{
 		MaxConcurrentActivityExecutionSize: cfg.MaxConcurrency,
-		Interceptors: []interceptor.WorkerInterceptor{
-			progressInterceptor, // Automatic progress reporting for Zigflow activities
-		},
+		Interceptors:                       executionInterceptors,
 	})
 
 	log.Info().
@@ -175,6 +217,7 @@ func NewZigflowWorker(cfg *config.Config) (*ZigflowWorker, error) {
 		claimCheckManager:          claimCheckMgr,
 		executeWorkflowActivity:    executeWorkflowActivity,
 		validateWorkflowActivities: validateWorkflowActivities,
+		metricsServer:              metricsServer,
 	}, nil
 }
 
@@ -231,6 +274,12 @@ func (w *ZigflowWorker) RegisterWorkflowsAndActivities() {
 	}
 	log.Info().Msg("✅ Registered Zigflow activities on execution queue")
 
+	// Register custom activities invoked by CALL_ACTIVITY tasks
+	for name, fn := range tasks.CustomActivities() {
+		w.executionWorker.RegisterActivityWithOptions(fn, activity.RegisterOptions{Name: name})
+		log.Info().Str("activity", name).Msg("✅ Registered custom activity on execution queue")
+	}
+
 	// Register Claim Check activities if enabled
 	if w.claimCheckManager != nil {
 		w.executionWorker.RegisterActivity(w.claimCheckManager.OffloadActivity)
@@ -276,6 +325,17 @@ func (w *ZigflowWorker) Start() error {
 		}
 	}()
 
+	// Start metrics server in background. A failing metrics endpoint is
+	// logged but does not stop the workers.
+	if w.metricsServer != nil {
+		go func() {
+			log.Info().Str("addr", w.metricsServer.Addr).Msg("Starting metrics server")
+			if err := w.metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error().Err(err).Msg("Metrics server failed")
+			}
+		}()
+	}
+
 	log.Info().Msg("✅ All three workers started successfully")
 
 	// Wait for any worker to fail or interrupt
@@ -303,6 +363,15 @@ func (w *ZigflowWorker) Stop() {
 	w.validationWorker.Stop()
 	log.Info().Str("queue", w.config.ValidationTaskQueue).Msg("Validation worker stopped")
 
+	// Stop metrics server
+	if w.metricsServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := w.metricsServer.Shutdown(ctx); err != nil {
+			log.Warn().Err(err).Msg("Failed to stop metrics server")
+		}
+		cancel()
+	}
+
 	// Close ExecuteWorkflowActivity
 	if w.executeWorkflowActivity != nil {
 		if err := w.executeWorkflowActivity.Close(); err != nil {
@@ -47,6 +47,8 @@ func init() {
 	rootCmd.AddCommand(root.NewSkillCommand())
 	rootCmd.AddCommand(root.NewApplyCommand())
 	rootCmd.AddCommand(root.NewRunCommand())
+	rootCmd.AddCommand(root.NewAgentCommand())
+	rootCmd.AddCommand(root.NewWorkflowCommand())
 
 	// Add hidden internal commands (used by daemon for BusyBox pattern)
 	rootCmd.AddCommand(root.NewInternalServerCommand())
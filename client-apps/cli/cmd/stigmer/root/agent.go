@@ -0,0 +1,399 @@
+package root
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	agentexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
+	executioncontextv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/executioncontext/v1"
+	sessionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/session/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/clierr"
+	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/cliprint"
+	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/config"
+	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/session"
+	"golang.org/x/term"
+	"google.golang.org/grpc"
+)
+
+// NewAgentCommand creates the agent command group
+func NewAgentCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent",
+		Short: "Talk to deployed agents",
+		Long: `Send messages to deployed agents.
+
+Use "stigmer run" to deploy and start an agent from your project directory.`,
+	}
+
+	cmd.AddCommand(newAgentExecuteCommand())
+
+	return cmd
+}
+
+// newAgentExecuteCommand creates the agent execute subcommand
+func newAgentExecuteCommand() *cobra.Command {
+	var message string
+	var sessionID string
+	var interactive bool
+	var output string
+	var runtimeEnv []string
+	var orgOverride string
+
+	cmd := &cobra.Command{
+		Use:   "execute <agent-name-or-id>",
+		Short: "Send a message to an agent, or chat with it interactively",
+		Long: `Send a message to a deployed agent and stream its reply.
+
+Each message runs as an execution in a session, which holds the conversation
+history. Without --session, a single message starts a new session; its ID is
+printed so the conversation can be continued with --session.
+
+With --interactive, a session is opened and every line you type is sent as a
+message. Input history is kept in ~/.stigmer/agent_history and can be
+recalled with the arrow keys. Commands:
+  /reset   start a new session (the agent forgets the conversation)
+  /quit    end the session (Ctrl+D works too)
+
+Ctrl+C while a reply streams stops waiting for it.
+
+With --output json, each reply is printed as one JSON object with the
+session ID, execution ID, final phase, response and error.`,
+		Example: `  # Send a single message
+  stigmer agent execute my-agent --message "Summarize the open issues"
+
+  # Continue the conversation
+  stigmer agent execute my-agent --session ses_01kf4nagdmjjjxbg63bhhm59m0 --message "Only the bugs"
+
+  # Chat interactively
+  stigmer agent execute my-agent --interactive
+
+  # Print replies as JSON
+  stigmer agent execute my-agent --message "List the open issues" -o json`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if output != "text" && output != "json" {
+				clierr.Handle(fmt.Errorf("invalid output format %q (expected text or json)", output))
+			}
+			if !interactive && message == "" {
+				clierr.Handle(errors.New("a message is required: use --message, or --interactive to chat"))
+			}
+
+			runtimeEnvMap, err := parseRuntimeEnv(runtimeEnv)
+			if err != nil {
+				clierr.Handle(fmt.Errorf("invalid runtime environment format: %w", err))
+			}
+
+			conn, orgID, err := connectToBackend(orgOverride)
+			if err != nil {
+				os.Exit(1)
+			}
+			defer conn.Close()
+
+			agent, err := resolveAgent(args[0], orgID, conn)
+			clierr.Handle(err)
+
+			s := &agentSession{
+				conn:       conn,
+				agent:      agent,
+				orgID:      orgID,
+				sessionID:  sessionID,
+				runtimeEnv: runtimeEnvMap,
+				jsonOutput: output == "json",
+			}
+
+			if interactive {
+				clierr.Handle(s.interact(message))
+				return
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			execution, err := s.send(ctx, message)
+			clierr.Handle(err)
+			if !s.jsonOutput {
+				cliprint.PrintInfo("Session: %s", s.sessionID)
+			}
+			if execution.GetStatus().GetPhase() != agentexecutionv1.ExecutionPhase_EXECUTION_COMPLETED {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&message, "message", "m", "", "message to send (with --interactive, sent before the first prompt)")
+	cmd.Flags().StringVar(&sessionID, "session", "", "ID of an existing session to continue")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "chat with the agent until /quit")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "output format: text or json")
+	cmd.Flags().StringArrayVar(&runtimeEnv, "runtime-env", []string{}, "runtime environment variables (key=value, can be used multiple times, prefix with 'secret:' for secrets)")
+	cmd.Flags().StringVar(&orgOverride, "org", "", "organization ID (overrides context)")
+
+	return cmd
+}
+
+// agentSession is a conversation with an agent. The executions it sends share
+// a session, so the agent sees the earlier messages.
+type agentSession struct {
+	conn       *grpc.ClientConn
+	agent      *agentv1.Agent
+	orgID      string
+	sessionID  string
+	runtimeEnv map[string]*executioncontextv1.ExecutionValue
+	jsonOutput bool
+}
+
+// agentReplyOutput is the JSON form of a reply (--output json)
+type agentReplyOutput struct {
+	SessionID   string `json:"session_id"`
+	ExecutionID string `json:"execution_id"`
+	Phase       string `json:"phase"`
+	Response    string `json:"response"`
+	Error       string `json:"error,omitempty"`
+}
+
+// start creates a new session on the agent's default instance
+func (s *agentSession) start() error {
+	instanceID := s.agent.GetStatus().GetDefaultInstanceId()
+	if instanceID == "" {
+		return fmt.Errorf("agent %s has no default instance", s.agent.GetMetadata().GetName())
+	}
+
+	metadata := &apiresource.ApiResourceMetadata{
+		Name:       fmt.Sprintf("session-%d", time.Now().UnixMilli()),
+		OwnerScope: s.agent.GetMetadata().GetOwnerScope(),
+	}
+	if metadata.OwnerScope == apiresource.ApiResourceOwnerScope_organization {
+		metadata.Org = s.orgID
+	}
+
+	client := sessionv1.NewSessionCommandControllerClient(s.conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	created, err := client.Create(ctx, &sessionv1.Session{
+		ApiVersion: "agentic.stigmer.ai/v1",
+		Kind:       "Session",
+		Metadata:   metadata,
+		Spec: &sessionv1.SessionSpec{
+			AgentInstanceId: instanceID,
+			Subject:         "Interactive session",
+			Metadata:        map[string]string{"client": "stigmer-cli"},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	s.sessionID = created.GetMetadata().GetId()
+	return nil
+}
+
+// send runs one message in the session and streams the reply until the
+// execution finishes or ctx is cancelled. Without a session yet, the backend
+// starts one and the session adopts it.
+func (s *agentSession) send(ctx context.Context, message string) (*agentexecutionv1.AgentExecution, error) {
+	execution, err := createAgentExecution(s.agent.GetMetadata().GetId(), s.sessionID, s.orgID, message, s.runtimeEnv, s.conn)
+	if err != nil {
+		return nil, err
+	}
+	if s.sessionID == "" {
+		s.sessionID = execution.GetSpec().GetSessionId()
+	}
+
+	client := agentexecutionv1.NewAgentExecutionQueryControllerClient(s.conn)
+	stream, err := client.Subscribe(ctx, &agentexecutionv1.AgentExecutionId{Value: execution.GetMetadata().GetId()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to execution: %w", err)
+	}
+
+	var reply *session.Reply
+	if !s.jsonOutput {
+		reply = session.NewReply(os.Stdout)
+	}
+	for !isTerminalAgentPhase(execution.GetStatus().GetPhase()) {
+		update, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if reply != nil {
+				reply.Finish()
+			}
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("stopped waiting for execution %s", execution.GetMetadata().GetId())
+			}
+			return nil, fmt.Errorf("stream error: %w", err)
+		}
+		execution = update
+		if reply != nil {
+			reply.Update(execution)
+		}
+	}
+
+	if reply != nil {
+		reply.Finish()
+		s.printOutcome(execution)
+		return execution, nil
+	}
+
+	data, err := json.Marshal(agentReplyOutput{
+		SessionID:   s.sessionID,
+		ExecutionID: execution.GetMetadata().GetId(),
+		Phase:       execution.GetStatus().GetPhase().String(),
+		Response:    session.Response(execution),
+		Error:       execution.GetStatus().GetError(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println(string(data))
+	return execution, nil
+}
+
+// printOutcome reports an execution that did not complete
+func (s *agentSession) printOutcome(execution *agentexecutionv1.AgentExecution) {
+	switch execution.GetStatus().GetPhase() {
+	case agentexecutionv1.ExecutionPhase_EXECUTION_FAILED:
+		cliprint.PrintError("Execution failed: %s", execution.GetStatus().GetError())
+	case agentexecutionv1.ExecutionPhase_EXECUTION_CANCELLED:
+		cliprint.PrintWarning("Execution cancelled")
+	case agentexecutionv1.ExecutionPhase_EXECUTION_LIMIT_EXCEEDED:
+		cliprint.PrintError("Execution stopped: %s", execution.GetStatus().GetError())
+	}
+}
+
+// notice prints a status line, on stderr with JSON output so that stdout only
+// carries replies
+func (s *agentSession) notice(format string, args ...interface{}) {
+	if s.jsonOutput {
+		fmt.Fprintf(os.Stderr, format+"\n", args...)
+		return
+	}
+	cliprint.PrintInfo(format, args...)
+}
+
+// interact reads messages until /quit or end of input and sends each one.
+// Failed messages are reported and the session goes on.
+func (s *agentSession) interact(firstMessage string) error {
+	if s.sessionID == "" {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+
+	reader := newAgentLineReader()
+	s.notice("Session %s with agent %s", s.sessionID, s.agent.GetMetadata().GetName())
+	s.notice("Type /reset to start a new session, /quit to exit")
+
+	line := firstMessage
+	for {
+		switch line = strings.TrimSpace(line); line {
+		case "":
+		case "/quit", "/exit":
+			return nil
+		case "/reset":
+			if err := s.start(); err != nil {
+				return err
+			}
+			s.notice("Started session %s", s.sessionID)
+		default:
+			s.turn(line)
+		}
+
+		var err error
+		line, err = reader.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// turn sends one message of an interactive session. Ctrl+C stops waiting for
+// the reply without ending the session.
+func (s *agentSession) turn(message string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if _, err := s.send(ctx, message); err != nil {
+		if s.jsonOutput {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		cliprint.PrintError("%v", err)
+	}
+}
+
+// agentLineReader reads the messages of an interactive session. On a
+// terminal, lines are edited in place and the arrow keys recall the history
+// kept in ~/.stigmer/agent_history. Otherwise plain lines are read from stdin.
+type agentLineReader struct {
+	fd       int
+	terminal *term.Terminal
+	scanner  *bufio.Scanner
+}
+
+func newAgentLineReader() *agentLineReader {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return &agentLineReader{scanner: bufio.NewScanner(os.Stdin)}
+	}
+
+	// The prompt and echo go to stderr so stdout only carries replies
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stderr}, "› ")
+	if width, height, err := term.GetSize(fd); err == nil {
+		_ = t.SetSize(width, height)
+	}
+
+	configDir, err := config.GetConfigDir()
+	if err == nil {
+		var history *session.History
+		history, err = session.LoadHistory(filepath.Join(configDir, session.HistoryFileName), session.DefaultHistorySize)
+		if err == nil {
+			t.History = history
+		}
+	}
+	if err != nil {
+		cliprint.PrintWarning("Input history unavailable: %v", err)
+	}
+
+	return &agentLineReader{fd: fd, terminal: t}
+}
+
+// ReadLine reads the next message; it returns io.EOF on Ctrl+D, Ctrl+C or end
+// of input
+func (r *agentLineReader) ReadLine() (string, error) {
+	if r.scanner != nil {
+		if r.scanner.Scan() {
+			return r.scanner.Text(), nil
+		}
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+
+	state, err := term.MakeRaw(r.fd)
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	defer term.Restore(r.fd, state)
+
+	return r.terminal.ReadLine()
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/config"
 	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/daemon"
 	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/deploy"
+	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/synthesis"
 	"github.com/stigmer/stigmer/client-apps/cli/pkg/display"
 )
 
@@ -22,6 +23,7 @@ func NewApplyCommand() *cobra.Command {
 	var dryRun bool
 	var configFile string
 	var orgOverride string
+	var verifyAgentRefs bool
 
 	cmd := &cobra.Command{
 		Use:   "apply",
@@ -29,7 +31,9 @@ func NewApplyCommand() *cobra.Command {
 		Long: `Deploy resources from your Stigmer project.
 
 Reads Stigmer.yaml and executes your entry point (main.go) to deploy
-Agents and Workflows. Resources are auto-discovered from your code.
+Agents, Workflows, Agent Instances and Workflow Instances. Resources are
+auto-discovered from your code. Secrets bound with SecretFromEnv are read
+from the environment of this command.
 
 The Stigmer.yaml file contains project metadata:
   name: my-project
@@ -52,14 +56,18 @@ For skill artifacts, use 'stigmer skill push' instead.`,
   stigmer apply --dry-run
   
   # Override organization
-  stigmer apply --org my-org-id`,
+  stigmer apply --org my-org-id
+
+  # Fail if a workflow calls an agent of another organization that does not exist
+  stigmer apply --verify-agent-refs`,
 		Run: func(cmd *cobra.Command, args []string) {
 			// Deploy from Stigmer.yaml + code execution
 			deployedSkills, deployedAgents, deployedWorkflows, err := ApplyCodeMode(ApplyCodeModeOptions{
-				ConfigFile:  configFile,
-				OrgOverride: orgOverride,
-				DryRun:      dryRun,
-				Quiet:       false,
+				ConfigFile:      configFile,
+				OrgOverride:     orgOverride,
+				DryRun:          dryRun,
+				Quiet:           false,
+				VerifyAgentRefs: verifyAgentRefs,
 			})
 			clierr.Handle(err)
 
@@ -131,6 +139,7 @@ For skill artifacts, use 'stigmer skill push' instead.`,
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "validate without deploying")
 	cmd.Flags().StringVar(&configFile, "config", "", "path to Stigmer.yaml or directory containing it (default: current directory)")
 	cmd.Flags().StringVar(&orgOverride, "org", "", "organization ID (overrides Stigmer.yaml and context)")
+	cmd.Flags().BoolVar(&verifyAgentRefs, "verify-agent-refs", false, "check that agents referenced by organization (workflow.AgentRef) exist before deploying")
 
 	return cmd
 }
@@ -141,6 +150,8 @@ type ApplyCodeModeOptions struct {
 	OrgOverride string
 	DryRun      bool
 	Quiet       bool // If true, suppress detailed output
+	// VerifyAgentRefs checks agents referenced by organization exist before deploying
+	VerifyAgentRefs bool
 }
 
 // ApplyCodeMode applies skills, agents, and workflows from code (Stigmer.yaml + entry point execution)
@@ -187,6 +198,8 @@ func ApplyCodeMode(opts ApplyCodeModeOptions) ([]*skillv1.Skill, []*agentv1.Agen
 	skillCount := synthesisResult.SkillCount()
 	agentCount := synthesisResult.AgentCount()
 	workflowCount := synthesisResult.WorkflowCount()
+	agentInstanceCount := synthesisResult.AgentInstanceCount()
+	workflowInstanceCount := synthesisResult.WorkflowInstanceCount()
 	totalResources := synthesisResult.TotalResources()
 
 	if totalResources == 0 {
@@ -197,8 +210,8 @@ func ApplyCodeMode(opts ApplyCodeModeOptions) ([]*skillv1.Skill, []*agentv1.Agen
 	}
 
 	if !opts.Quiet {
-		cliprint.PrintSuccess("✓ Synthesis complete: %d resource(s) discovered (%d skill(s), %d agent(s), %d workflow(s))",
-			totalResources, skillCount, agentCount, workflowCount)
+		cliprint.PrintSuccess("✓ Synthesis complete: %d resource(s) discovered (%d skill(s), %d agent(s), %d workflow(s), %d agent instance(s), %d workflow instance(s))",
+			totalResources, skillCount, agentCount, workflowCount, agentInstanceCount, workflowInstanceCount)
 		fmt.Println()
 
 		// Show preview of discovered resources
@@ -218,6 +231,12 @@ func ApplyCodeMode(opts ApplyCodeModeOptions) ([]*skillv1.Skill, []*agentv1.Agen
 				if agent.Spec.Description != "" {
 					cliprint.PrintInfo("     Description: %s", agent.Spec.Description)
 				}
+				if agent.Metadata.Org != "" {
+					cliprint.PrintInfo("     Org:         %s", agent.Metadata.Org)
+				}
+				if provenance := synthesis.Provenance(agent.Metadata); provenance != "" {
+					cliprint.PrintInfo("     Provenance:  %s", provenance)
+				}
 			}
 			fmt.Println()
 		}
@@ -229,6 +248,49 @@ func ApplyCodeMode(opts ApplyCodeModeOptions) ([]*skillv1.Skill, []*agentv1.Agen
 				if wf.Spec.Description != "" {
 					cliprint.PrintInfo("     Description: %s", wf.Spec.Description)
 				}
+				if wf.Metadata.Org != "" {
+					cliprint.PrintInfo("     Org:         %s", wf.Metadata.Org)
+				}
+				if provenance := synthesis.Provenance(wf.Metadata); provenance != "" {
+					cliprint.PrintInfo("     Provenance:  %s", provenance)
+				}
+			}
+			fmt.Println()
+		}
+
+		if agentInstanceCount > 0 {
+			cliprint.PrintInfo("Agent instances discovered: %d", agentInstanceCount)
+			for i, instance := range synthesisResult.AgentInstances {
+				cliprint.PrintInfo("  %d. %s (agent: %s)", i+1, instance.Metadata.Name, instance.Spec.AgentId)
+				if instance.Metadata.Org != "" {
+					cliprint.PrintInfo("     Org:         %s", instance.Metadata.Org)
+				}
+			}
+			fmt.Println()
+		}
+
+		if workflowInstanceCount > 0 {
+			cliprint.PrintInfo("Workflow instances discovered: %d", workflowInstanceCount)
+			for i, instance := range synthesisResult.WorkflowInstances {
+				cliprint.PrintInfo("  %d. %s (workflow: %s)", i+1, instance.Metadata.Name, instance.Spec.WorkflowId)
+				if schedule := instance.Spec.GetSchedule(); schedule != nil {
+					cliprint.PrintInfo("     Schedule:    %s", schedule.GetCron())
+				}
+				if instance.Metadata.Org != "" {
+					cliprint.PrintInfo("     Org:         %s", instance.Metadata.Org)
+				}
+			}
+			fmt.Println()
+		}
+
+		if len(synthesisResult.Config) > 0 {
+			cliprint.PrintInfo("Configuration:")
+			for _, v := range synthesisResult.Config {
+				source := v.Source
+				if v.EnvVar != "" {
+					source = fmt.Sprintf("%s %s", v.Source, v.EnvVar)
+				}
+				cliprint.PrintInfo("  %s = %s (%s)", v.Name, v.Value, source)
 			}
 			fmt.Println()
 		}
@@ -273,6 +335,28 @@ func ApplyCodeMode(opts ApplyCodeModeOptions) ([]*skillv1.Skill, []*agentv1.Agen
 				)
 			}
 
+			// Add agent instances to table
+			for _, instance := range synthesisResult.AgentInstances {
+				resultTable.AddResource(
+					display.ResourceTypeAgentInstance,
+					instance.Metadata.Name,
+					display.ApplyStatusCreated,
+					"",
+					nil,
+				)
+			}
+
+			// Add workflow instances to table
+			for _, instance := range synthesisResult.WorkflowInstances {
+				resultTable.AddResource(
+					display.ResourceTypeWorkflowInstance,
+					instance.Metadata.Name,
+					display.ApplyStatusCreated,
+					"",
+					nil,
+				)
+			}
+
 			// Render dry-run table
 			resultTable.RenderDryRun()
 		}
@@ -366,6 +450,7 @@ func ApplyCodeMode(opts ApplyCodeModeOptions) ([]*skillv1.Skill, []*agentv1.Agen
 		Quiet:            opts.Quiet,
 		DryRun:           opts.DryRun,
 		ProgressCallback: progressCallback,
+		VerifyAgentRefs:  opts.VerifyAgentRefs,
 	})
 
 	// Deploy all resources
@@ -430,7 +430,7 @@ func executeAgent(agent *agentv1.Agent, orgID string, message string, runtimeEnv
 
 	// Create execution
 	cliprint.PrintInfo("Creating agent execution...")
-	execution, err := createAgentExecution(agent.Metadata.Id, orgID, message, runtimeEnvMap, conn)
+	execution, err := createAgentExecution(agent.Metadata.Id, "", orgID, message, runtimeEnvMap, conn)
 	if err != nil {
 		cliprint.PrintError("Failed to create execution: %s", err)
 		return
@@ -466,8 +466,29 @@ func executeWorkflow(workflow *workflowv1.Workflow, orgID string, message string
 		return
 	}
 
-	cliprint.PrintSuccess("✓ Workflow execution started: %s", workflow.Metadata.Name)
-	cliprint.PrintInfo("  Execution ID: %s", execution.Metadata.Id)
+	// Report the outcome of the workflow's concurrency policy (if any)
+	status := execution.GetStatus()
+	switch status.GetConcurrencyDecision() {
+	case workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_SKIPPED:
+		cliprint.PrintWarning("⊘ Workflow execution skipped: %s", workflow.Metadata.Name)
+		cliprint.PrintInfo("  Execution ID: %s", execution.Metadata.Id)
+		cliprint.PrintInfo("  Execution %s is already in flight (concurrency key: %s)",
+			status.GetConcurrencyBlockingExecutionId(), status.GetConcurrencyKey())
+		fmt.Println()
+		return
+	case workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_QUEUED:
+		cliprint.PrintWarning("⏸ Workflow execution queued: %s", workflow.Metadata.Name)
+		cliprint.PrintInfo("  Execution ID: %s", execution.Metadata.Id)
+		cliprint.PrintInfo("  Waiting for execution %s to finish (concurrency key: %s)",
+			status.GetConcurrencyBlockingExecutionId(), status.GetConcurrencyKey())
+	case workflowexecutionv1.ConcurrencyDecision_CONCURRENCY_CANCELLED_EXISTING:
+		cliprint.PrintSuccess("✓ Workflow execution started: %s", workflow.Metadata.Name)
+		cliprint.PrintInfo("  Execution ID: %s", execution.Metadata.Id)
+		cliprint.PrintWarning("  Cancelled in-flight executions (concurrency key: %s)", status.GetConcurrencyKey())
+	default:
+		cliprint.PrintSuccess("✓ Workflow execution started: %s", workflow.Metadata.Name)
+		cliprint.PrintInfo("  Execution ID: %s", execution.Metadata.Id)
+	}
 	fmt.Println()
 
 	// Stream execution logs if --follow flag is set
@@ -479,8 +500,9 @@ func executeWorkflow(workflow *workflowv1.Workflow, orgID string, message string
 	}
 }
 
-// createAgentExecution creates a new agent execution
-func createAgentExecution(agentID string, orgID string, message string, runtimeEnv map[string]*executioncontextv1.ExecutionValue, conn *grpc.ClientConn) (*agentexecutionv1.AgentExecution, error) {
+// createAgentExecution creates a new agent execution. With an empty sessionID,
+// the backend starts a new session on the agent's default instance.
+func createAgentExecution(agentID string, sessionID string, orgID string, message string, runtimeEnv map[string]*executioncontextv1.ExecutionValue, conn *grpc.ClientConn) (*agentexecutionv1.AgentExecution, error) {
 	// If no message provided, use default
 	if message == "" {
 		message = "execute"
@@ -491,6 +513,7 @@ func createAgentExecution(agentID string, orgID string, message string, runtimeE
 
 	// Build execution spec
 	spec := &agentexecutionv1.AgentExecutionSpec{
+		SessionId:  sessionID,
 		AgentId:    agentID,
 		Message:    message,
 		RuntimeEnv: runtimeEnv,
@@ -650,6 +673,9 @@ func streamWorkflowExecutionLogs(executionID string, conn *grpc.ClientConn) {
 		// Display phase changes
 		if execution.Status.Phase != lastPhase {
 			displayWorkflowPhaseChange(execution.Status.Phase)
+			if execution.Status.Phase == workflowexecutionv1.ExecutionPhase_EXECUTION_AWAITING_APPROVAL {
+				displayPendingApprovals(execution)
+			}
 			lastPhase = execution.Status.Phase
 		}
 
@@ -680,6 +706,8 @@ func displayAgentPhaseChange(phase agentexecutionv1.ExecutionPhase) {
 		cliprint.PrintError("❌ Execution failed")
 	case agentexecutionv1.ExecutionPhase_EXECUTION_CANCELLED:
 		cliprint.PrintWarning("⚠️  Execution cancelled")
+	case agentexecutionv1.ExecutionPhase_EXECUTION_LIMIT_EXCEEDED:
+		cliprint.PrintError("⛔ Execution stopped: agent limit exceeded")
 	}
 	fmt.Println()
 }
@@ -720,6 +748,24 @@ func displayWorkflowPhaseChange(phase workflowexecutionv1.ExecutionPhase) {
 		cliprint.PrintError("❌ Execution failed")
 	case workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED:
 		cliprint.PrintWarning("⚠️  Execution cancelled")
+	case workflowexecutionv1.ExecutionPhase_EXECUTION_AWAITING_APPROVAL:
+		cliprint.PrintWarning("⏸️  Execution awaiting approval")
+	}
+	fmt.Println()
+}
+
+// displayPendingApprovals shows how to decide on each pending approval
+func displayPendingApprovals(execution *workflowexecutionv1.WorkflowExecution) {
+	for _, approval := range execution.Status.PendingApprovals {
+		cliprint.PrintInfo("Task %s is waiting for approval", approval.TaskName)
+		if len(approval.Approvers) > 0 {
+			cliprint.PrintInfo("  Approvers: %s", strings.Join(approval.Approvers, ", "))
+		}
+		if approval.ExpiresAt != "" {
+			cliprint.PrintInfo("  Expires:   %s", approval.ExpiresAt)
+		}
+		cliprint.PrintInfo("  Approve:   stigmer workflow approve %s %s", execution.Metadata.Id, approval.TaskName)
+		cliprint.PrintInfo("  Reject:    stigmer workflow approve %s %s --reject", execution.Metadata.Id, approval.TaskName)
 	}
 	fmt.Println()
 }
@@ -745,6 +791,9 @@ func displayWorkflowTask(task *workflowexecutionv1.WorkflowTask) {
 	case workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_SKIPPED:
 		icon = "⊘"
 		statusText = "Skipped"
+	case workflowexecutionv1.WorkflowTaskStatus_WORKFLOW_TASK_AWAITING_APPROVAL:
+		icon = "⏸"
+		statusText = "Awaiting approval"
 	}
 
 	fmt.Printf("%s Task: %s [%s]\n", icon, task.TaskName, statusText)
@@ -753,6 +802,9 @@ func displayWorkflowTask(task *workflowexecutionv1.WorkflowTask) {
 	if task.Error != "" {
 		fmt.Printf("   ✗ Error: %s\n", task.Error)
 	}
+	if task.ErrorClassification != workflowexecutionv1.ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED {
+		fmt.Printf("   Classification: %s\n", task.ErrorClassification)
+	}
 
 	fmt.Println()
 }
@@ -772,6 +824,11 @@ func displayAgentExecutionComplete(execution *agentexecutionv1.AgentExecution) {
 		}
 	case agentexecutionv1.ExecutionPhase_EXECUTION_CANCELLED:
 		cliprint.PrintWarning("Execution cancelled")
+	case agentexecutionv1.ExecutionPhase_EXECUTION_LIMIT_EXCEEDED:
+		cliprint.PrintError("Execution stopped by an agent limit")
+		if execution.Status.Error != "" {
+			cliprint.PrintError("Error: %s", execution.Status.Error)
+		}
 	}
 
 	// Display timing information
@@ -800,11 +857,20 @@ func displayWorkflowExecutionComplete(execution *workflowexecutionv1.WorkflowExe
 		cliprint.PrintSuccess("Done!")
 	case workflowexecutionv1.ExecutionPhase_EXECUTION_FAILED:
 		cliprint.PrintError("Workflow execution failed")
+		if execution.Status.FailedTask != "" {
+			cliprint.PrintError("Failed task: %s", execution.Status.FailedTask)
+		}
+		if execution.Status.ErrorClassification != workflowexecutionv1.ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED {
+			cliprint.PrintError("Classification: %s", execution.Status.ErrorClassification)
+		}
 		if execution.Status.Error != "" {
 			cliprint.PrintError("Error: %s", execution.Status.Error)
 		}
 	case workflowexecutionv1.ExecutionPhase_EXECUTION_CANCELLED:
 		cliprint.PrintWarning("Workflow execution cancelled")
+		if execution.Status.Error != "" {
+			cliprint.PrintWarning("%s", execution.Status.Error)
+		}
 	}
 
 	// Display timing information
@@ -849,7 +915,8 @@ func displayWorkflowExecutionComplete(execution *workflowexecutionv1.WorkflowExe
 func isTerminalAgentPhase(phase agentexecutionv1.ExecutionPhase) bool {
 	return phase == agentexecutionv1.ExecutionPhase_EXECUTION_COMPLETED ||
 		phase == agentexecutionv1.ExecutionPhase_EXECUTION_FAILED ||
-		phase == agentexecutionv1.ExecutionPhase_EXECUTION_CANCELLED
+		phase == agentexecutionv1.ExecutionPhase_EXECUTION_CANCELLED ||
+		phase == agentexecutionv1.ExecutionPhase_EXECUTION_LIMIT_EXCEEDED
 }
 
 // isTerminalWorkflowPhase checks if workflow execution phase is terminal
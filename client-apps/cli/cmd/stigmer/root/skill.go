@@ -11,6 +11,7 @@ import (
 	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/cliprint"
 	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/config"
 	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/daemon"
+	"github.com/stigmer/stigmer/sdk/go/skill"
 )
 
 // NewSkillCommand creates the skill management command group
@@ -42,6 +43,8 @@ func newSkillPushCommand() *cobra.Command {
 	var tag string
 	var orgOverride string
 	var dryRun bool
+	var requiredSections []string
+	var maxSectionTokens int
 
 	cmd := &cobra.Command{
 		Use:   "push [directory]",
@@ -54,7 +57,11 @@ ZIP artifact and uploaded to the registry.
 
 The skill name is derived from the directory name. A SHA256 hash is
 calculated from the artifact contents for content-addressable storage
-and deduplication.`,
+and deduplication.
+
+Before packaging, SKILL.md is validated: links to anchors in the file must
+match a heading, sections passed with --require-section must exist, and no
+section may exceed --max-section-tokens (estimated from its word count).`,
 		Example: `  # Push skill from current directory
   stigmer skill push
 
@@ -67,6 +74,9 @@ and deduplication.`,
   # Push to a specific organization
   stigmer skill push --org my-org
 
+  # Require sections that agent instructions refer to
+  stigmer skill push --require-section Overview --require-section Checklist --max-section-tokens 2000
+
   # Dry run (validate without pushing)
   stigmer skill push --dry-run`,
 		Args: cobra.MaximumNArgs(1),
@@ -81,6 +91,10 @@ and deduplication.`,
 				Tag:         tag,
 				OrgOverride: orgOverride,
 				DryRun:      dryRun,
+				ValidationRules: []skill.Rule{
+					skill.RequireSections(requiredSections...),
+					skill.MaxSectionTokens(maxSectionTokens),
+				},
 			})
 			clierr.Handle(err)
 
@@ -94,6 +108,8 @@ and deduplication.`,
 	cmd.Flags().StringVar(&tag, "tag", "latest", "version tag for the skill")
 	cmd.Flags().StringVar(&orgOverride, "org", "", "organization ID (overrides context)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "validate without pushing")
+	cmd.Flags().StringArrayVar(&requiredSections, "require-section", nil, "section title SKILL.md must contain (repeatable)")
+	cmd.Flags().IntVar(&maxSectionTokens, "max-section-tokens", 0, "maximum estimated tokens per SKILL.md section (0 for no limit)")
 
 	return cmd
 }
@@ -104,6 +120,8 @@ type skillPushOptions struct {
 	Tag         string
 	OrgOverride string
 	DryRun      bool
+	// ValidationRules are checked against SKILL.md before pushing
+	ValidationRules []skill.Rule
 }
 
 // resolveSkillDirectory determines the skill directory from args or current directory
@@ -126,10 +144,15 @@ func executeSkillPush(opts skillPushOptions) (*artifact.SkillArtifactResult, err
 		return nil, fmt.Errorf("SKILL.md not found in %s\n\nA skill directory must contain a SKILL.md file defining the skill interface", opts.Directory)
 	}
 
+	// Step 2: Validate SKILL.md content
+	if err := skill.ValidateFile(opts.Directory, opts.ValidationRules...); err != nil {
+		return nil, err
+	}
+
 	cliprint.PrintInfo("Pushing skill from: %s", opts.Directory)
 	fmt.Println()
 
-	// Step 2: Dry run mode - just validate
+	// Step 3: Dry run mode - just validate
 	if opts.DryRun {
 		cliprint.PrintInfo("Dry run mode - would push skill with:")
 		cliprint.PrintInfo("  Directory: %s", opts.Directory)
@@ -137,19 +160,19 @@ func executeSkillPush(opts skillPushOptions) (*artifact.SkillArtifactResult, err
 		return nil, nil
 	}
 
-	// Step 3: Load backend configuration
+	// Step 4: Load backend configuration
 	cfg, err := config.Load()
 	if err != nil {
 		return nil, err
 	}
 
-	// Step 4: Determine organization based on backend mode
+	// Step 5: Determine organization based on backend mode
 	orgID, err := resolveOrganization(cfg, opts.OrgOverride)
 	if err != nil {
 		return nil, err
 	}
 
-	// Step 5: Ensure daemon is running (local mode only)
+	// Step 6: Ensure daemon is running (local mode only)
 	if cfg.Backend.Type == config.BackendTypeLocal {
 		dataDir, err := config.GetDataDir()
 		if err != nil {
@@ -161,7 +184,7 @@ func executeSkillPush(opts skillPushOptions) (*artifact.SkillArtifactResult, err
 		}
 	}
 
-	// Step 6: Connect to backend
+	// Step 7: Connect to backend
 	cliprint.PrintInfo("Connecting to backend...")
 
 	conn, err := backend.NewConnection()
@@ -173,7 +196,7 @@ func executeSkillPush(opts skillPushOptions) (*artifact.SkillArtifactResult, err
 	cliprint.PrintSuccess("✓ Connected to backend")
 	fmt.Println()
 
-	// Step 7: Push skill artifact
+	// Step 8: Push skill artifact
 	result, err := artifact.PushSkill(&artifact.SkillArtifactOptions{
 		Directory: opts.Directory,
 		OrgID:     orgID,
@@ -0,0 +1,420 @@
+package root
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"os/user"
+	"time"
+
+	"github.com/spf13/cobra"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	workflowexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowexecution/v1"
+	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/backend"
+	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/clierr"
+	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/cliprint"
+	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/localrun"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewWorkflowCommand creates the workflow command group
+func NewWorkflowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workflow",
+		Short: "Manage workflow executions",
+		Long: `Manage running workflow executions.
+
+Use "stigmer run" to start a workflow, or "stigmer workflow run --local" to
+try a synthesized workflow manifest without deploying it.`,
+	}
+
+	cmd.AddCommand(newWorkflowApproveCommand())
+	cmd.AddCommand(newWorkflowCancelCommand())
+	cmd.AddCommand(newWorkflowLogsCommand())
+	cmd.AddCommand(newWorkflowRunCommand())
+
+	return cmd
+}
+
+// newWorkflowApproveCommand creates the workflow approve subcommand
+func newWorkflowApproveCommand() *cobra.Command {
+	var reject bool
+	var comment string
+	var approver string
+
+	cmd := &cobra.Command{
+		Use:   "approve <execution-id> <task>",
+		Short: "Approve or reject a task waiting for approval",
+		Long: `Record a decision on an approval task of a workflow execution.
+
+The execution waits in phase EXECUTION_AWAITING_APPROVAL until every
+approval task is decided. Approving resumes the workflow; rejecting fails
+the task with error type "ApprovalRejected".
+
+The decision is recorded under your OS user name unless --as is set. If the
+task restricts its approvers, the name must be one of them.`,
+		Example: `  # Approve a deployment
+  stigmer workflow approve wex_01kf4nagdmjjjxbg63bhhm59m0 approveDeploy
+
+  # Reject it with a reason
+  stigmer workflow approve wex_01kf4nagdmjjjxbg63bhhm59m0 approveDeploy --reject --comment "change freeze"
+
+  # Approve as a specific approver
+  stigmer workflow approve wex_01kf4nagdmjjjxbg63bhhm59m0 approveDeploy --as ops-team`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			if approver == "" {
+				current, err := user.Current()
+				clierr.Handle(err)
+				approver = current.Username
+			}
+
+			execution, err := approveWorkflowTask(&workflowexecutionv1.WorkflowExecutionApproveInput{
+				ExecutionId: args[0],
+				TaskName:    args[1],
+				Approver:    approver,
+				Reject:      reject,
+				Comment:     comment,
+			})
+			clierr.Handle(err)
+
+			if reject {
+				cliprint.PrintWarning("Task %s rejected by %s", args[1], approver)
+			} else {
+				cliprint.PrintSuccess("Task %s approved by %s", args[1], approver)
+			}
+			cliprint.PrintInfo("Execution phase: %s", execution.GetStatus().GetPhase())
+		},
+	}
+
+	cmd.Flags().BoolVar(&reject, "reject", false, "reject the task instead of approving it")
+	cmd.Flags().StringVar(&comment, "comment", "", "comment recorded with the decision")
+	cmd.Flags().StringVar(&approver, "as", "", "approver name (default: current OS user)")
+
+	return cmd
+}
+
+// approveWorkflowTask sends the approval decision to the backend
+func approveWorkflowTask(input *workflowexecutionv1.WorkflowExecutionApproveInput) (*workflowexecutionv1.WorkflowExecution, error) {
+	conn, err := backend.NewConnection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to backend: %w", err)
+	}
+	defer conn.Close()
+
+	client := workflowexecutionv1.NewWorkflowExecutionCommandControllerClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	return client.Approve(ctx, input)
+}
+
+// newWorkflowCancelCommand creates the workflow cancel subcommand
+func newWorkflowCancelCommand() *cobra.Command {
+	var reason string
+	var cancelledBy string
+	var wait bool
+
+	cmd := &cobra.Command{
+		Use:   "cancel <execution-id>",
+		Short: "Cancel a running workflow execution",
+		Long: `Stop a running workflow execution.
+
+The execution moves to phase EXECUTION_CANCELLING while the workflow stops:
+in-flight HTTP calls are aborted, loops and forks schedule no new iterations
+and on-failure tasks run with error type "Cancelled". It then ends in phase
+EXECUTION_CANCELLED. Executions waiting in a concurrency queue are cancelled
+immediately.
+
+The cancellation is recorded under your OS user name unless --as is set.
+With --wait, the command returns once the execution has stopped.`,
+		Example: `  # Cancel an execution
+  stigmer workflow cancel wex_01kf4nagdmjjjxbg63bhhm59m0
+
+  # Cancel with a reason and wait until it has stopped
+  stigmer workflow cancel wex_01kf4nagdmjjjxbg63bhhm59m0 --reason "runaway loop" --wait`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if cancelledBy == "" {
+				current, err := user.Current()
+				clierr.Handle(err)
+				cancelledBy = current.Username
+			}
+
+			conn, err := backend.NewConnection()
+			if err != nil {
+				clierr.Handle(fmt.Errorf("failed to connect to backend: %w", err))
+			}
+			defer conn.Close()
+
+			cancelCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			execution, err := workflowexecutionv1.NewWorkflowExecutionCommandControllerClient(conn).Cancel(cancelCtx,
+				&workflowexecutionv1.WorkflowExecutionCancelInput{
+					ExecutionId: args[0],
+					CancelledBy: cancelledBy,
+					Reason:      reason,
+				})
+			clierr.Handle(err)
+
+			cliprint.PrintWarning("Cancellation of %s requested by %s", args[0], cancelledBy)
+			if !wait || isTerminalWorkflowPhase(execution.GetStatus().GetPhase()) {
+				cliprint.PrintInfo("Execution phase: %s", execution.GetStatus().GetPhase())
+				return
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			cliprint.PrintInfo("Waiting for the execution to stop (Ctrl+C to stop waiting)...")
+			execution, err = waitForWorkflowExecution(ctx, workflowexecutionv1.NewWorkflowExecutionQueryControllerClient(conn), args[0])
+			clierr.Handle(err)
+			if execution != nil {
+				displayWorkflowExecutionComplete(execution)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "reason recorded with the cancellation")
+	cmd.Flags().StringVar(&cancelledBy, "as", "", "name recorded as the canceller (default: current OS user)")
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait until the execution has stopped")
+
+	return cmd
+}
+
+// waitForWorkflowExecution subscribes to an execution and returns it once it
+// reaches a terminal phase, or nil if ctx is cancelled first
+func waitForWorkflowExecution(ctx context.Context, client workflowexecutionv1.WorkflowExecutionQueryControllerClient, executionID string) (*workflowexecutionv1.WorkflowExecution, error) {
+	stream, err := client.Subscribe(ctx, &workflowexecutionv1.SubscribeWorkflowExecutionRequest{
+		ExecutionId: executionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to execution: %w", err)
+	}
+
+	for {
+		execution, err := stream.Recv()
+		if errors.Is(err, io.EOF) || ctx.Err() != nil {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("stream error: %w", err)
+		}
+
+		if isTerminalWorkflowPhase(execution.GetStatus().GetPhase()) {
+			return execution, nil
+		}
+	}
+}
+
+// newWorkflowLogsCommand creates the workflow logs subcommand
+func newWorkflowLogsCommand() *cobra.Command {
+	var follow bool
+
+	cmd := &cobra.Command{
+		Use:   "logs <execution-id>",
+		Short: "Show the task-level progress of a workflow execution",
+		Long: `Print the task state transitions of a workflow execution in order: when
+each task started, completed or failed (with its error classification), and
+when it was retried, with timestamps and durations.
+
+Without --follow, the history recorded so far is printed. With --follow, new
+transitions are streamed as they happen until the execution finishes or
+Ctrl+C is pressed. Completed executions replay their full history.`,
+		Example: `  # Replay the history of an execution
+  stigmer workflow logs wex_01kf4nagdmjjjxbg63bhhm59m0
+
+  # Watch a running execution
+  stigmer workflow logs wex_01kf4nagdmjjjxbg63bhhm59m0 --follow`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			conn, err := backend.NewConnection()
+			if err != nil {
+				clierr.Handle(fmt.Errorf("failed to connect to backend: %w", err))
+			}
+			defer conn.Close()
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			client := workflowexecutionv1.NewWorkflowExecutionQueryControllerClient(conn)
+			if follow {
+				clierr.Handle(followWorkflowTaskEvents(ctx, client, args[0]))
+				return
+			}
+
+			execution, err := client.Get(ctx, &workflowexecutionv1.WorkflowExecutionId{Value: args[0]})
+			clierr.Handle(err)
+
+			printed := printWorkflowTaskEvents(execution, 0)
+			if printed == 0 {
+				cliprint.PrintInfo("No task events recorded yet")
+			}
+			if isTerminalWorkflowPhase(execution.GetStatus().GetPhase()) {
+				displayWorkflowExecutionComplete(execution)
+			} else {
+				fmt.Println()
+				cliprint.PrintInfo("Execution phase: %s (use --follow to stream new events)", execution.GetStatus().GetPhase())
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "stream new task events until the execution finishes")
+
+	return cmd
+}
+
+// followWorkflowTaskEvents streams the task events of an execution until it
+// reaches a terminal phase or ctx is cancelled
+func followWorkflowTaskEvents(ctx context.Context, client workflowexecutionv1.WorkflowExecutionQueryControllerClient, executionID string) error {
+	stream, err := client.Subscribe(ctx, &workflowexecutionv1.SubscribeWorkflowExecutionRequest{
+		ExecutionId: executionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to execution: %w", err)
+	}
+
+	// The first message is the current state: replay its history, then
+	// print only the events added by each update
+	printed := 0
+	for {
+		execution, err := stream.Recv()
+		if errors.Is(err, io.EOF) || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("stream error: %w", err)
+		}
+
+		printed += printWorkflowTaskEvents(execution, printed)
+		if isTerminalWorkflowPhase(execution.GetStatus().GetPhase()) {
+			displayWorkflowExecutionComplete(execution)
+			return nil
+		}
+	}
+}
+
+// printWorkflowTaskEvents prints the task events of an execution from index
+// from on and returns how many were printed
+func printWorkflowTaskEvents(execution *workflowexecutionv1.WorkflowExecution, from int) int {
+	events := execution.GetStatus().GetTaskEvents()
+	if from >= len(events) {
+		return 0
+	}
+	for _, event := range events[from:] {
+		fmt.Println(formatWorkflowTaskEvent(event))
+	}
+	return len(events) - from
+}
+
+// formatWorkflowTaskEvent renders a task event as a log line, e.g.
+// "14:30:22.762  ✗ fetch failed after 250ms [UPSTREAM_4XX]: 404 Not Found"
+func formatWorkflowTaskEvent(event *workflowexecutionv1.WorkflowTaskEvent) string {
+	timestamp := event.GetTimestamp()
+	if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+		timestamp = t.Local().Format("15:04:05.000")
+	}
+	duration := time.Duration(event.GetDurationMs()) * time.Millisecond
+
+	var line string
+	switch event.GetType() {
+	case workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_STARTED:
+		line = fmt.Sprintf("▶ %s started", event.GetTaskName())
+	case workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_RETRIED:
+		line = fmt.Sprintf("↻ %s retried (attempt %d)", event.GetTaskName(), event.GetAttempt())
+	case workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_COMPLETED:
+		line = fmt.Sprintf("✓ %s completed in %s", event.GetTaskName(), duration)
+	case workflowexecutionv1.WorkflowTaskEventType_WORKFLOW_TASK_EVENT_FAILED:
+		line = fmt.Sprintf("✗ %s failed after %s", event.GetTaskName(), duration)
+		if event.GetErrorClassification() != workflowexecutionv1.ErrorClassification_ERROR_CLASSIFICATION_UNSPECIFIED {
+			line += fmt.Sprintf(" [%s]", event.GetErrorClassification())
+		}
+		if event.GetError() != "" {
+			line += ": " + event.GetError()
+		}
+	default:
+		line = fmt.Sprintf("%s %s", event.GetTaskName(), event.GetType())
+	}
+	return timestamp + "  " + line
+}
+
+// newWorkflowRunCommand creates the workflow run subcommand
+func newWorkflowRunCommand() *cobra.Command {
+	var local bool
+	var runtimeEnv []string
+
+	cmd := &cobra.Command{
+		Use:   "run <manifest.pb> --local",
+		Short: "Run a synthesized workflow manifest locally",
+		Long: `Execute a synthesized workflow manifest in-process, without Temporal or a
+Stigmer server, and print the resolved input and output of each task.
+
+HTTP calls are real. Expressions are evaluated by the workflow runner's own
+evaluator, and ${.secrets.KEY} and ${.env_vars.VAR} placeholders are resolved
+from --runtime-env. Secrets are redacted in the printed inputs.
+
+Only SET, HTTP_CALL, SWITCH, WAIT and RAISE tasks are supported. The run
+stops with an error at the first task of another kind, such as AGENT_CALL or
+LISTEN; deploy the workflow and use "stigmer run" for those. On-failure
+tasks (workflow.WithOnFailure) are not run.`,
+		Example: `  # Run the first workflow synthesized by the project
+  stigmer workflow run .stigmer/workflow-0.pb --local
+
+  # Provide the secrets and environment variables the workflow references
+  stigmer workflow run .stigmer/workflow-0.pb --local \
+    --runtime-env "REGION=eu-west-1" --runtime-env "secret:API_TOKEN=abc123"`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if !local {
+				clierr.Handle(fmt.Errorf("only local execution is supported; pass --local, or deploy with 'stigmer apply' and use 'stigmer run'"))
+			}
+
+			wf, err := readWorkflowManifest(args[0])
+			clierr.Handle(err)
+
+			env, err := parseRuntimeEnv(runtimeEnv)
+			if err != nil {
+				clierr.Handle(fmt.Errorf("invalid --runtime-env: %w", err))
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			cliprint.PrintInfo("Running workflow %s locally", wf.GetMetadata().GetName())
+			result, err := localrun.Run(ctx, wf, localrun.Options{RuntimeEnv: env, Out: os.Stdout})
+			clierr.Handle(err)
+
+			output, err := json.MarshalIndent(result.Output, "", "  ")
+			clierr.Handle(err)
+			cliprint.PrintSuccess("Workflow completed")
+			fmt.Println(string(output))
+		},
+	}
+
+	cmd.Flags().BoolVar(&local, "local", false, "execute the workflow in-process instead of on a Stigmer server")
+	cmd.Flags().StringArrayVar(&runtimeEnv, "runtime-env", []string{}, "runtime environment variables (key=value, can be used multiple times, prefix with 'secret:' for secrets)")
+
+	return cmd
+}
+
+// readWorkflowManifest reads a workflow manifest written by synthesis
+func readWorkflowManifest(path string) (*workflowv1.Workflow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow manifest: %w", err)
+	}
+
+	wf := &workflowv1.Workflow{}
+	if err := proto.Unmarshal(data, wf); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow manifest %s: %w", path, err)
+	}
+	if len(wf.GetSpec().GetTasks()) == 0 {
+		return nil, fmt.Errorf("%s is not a workflow manifest: no tasks found", path)
+	}
+	return wf, nil
+}
@@ -139,6 +139,25 @@ func PushSkill(opts *SkillArtifactOptions) (*SkillArtifactResult, error) {
 	}, nil
 }
 
+// ZipSkillContent returns a skill artifact holding only a SKILL.md with the
+// given content, for skills defined inline in code.
+func ZipSkillContent(skillMd string) ([]byte, error) {
+	zipBuffer := new(bytes.Buffer)
+	zipArchive := zip.NewWriter(zipBuffer)
+
+	writer, err := zipArchive.Create(SkillFileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create zip entry for %s", SkillFileName)
+	}
+	if _, err := io.WriteString(writer, skillMd); err != nil {
+		return nil, errors.Wrapf(err, "failed to write %s to zip", SkillFileName)
+	}
+	if err := zipArchive.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to finish skill artifact")
+	}
+	return zipBuffer.Bytes(), nil
+}
+
 // createSkillZip creates a zip archive of the skill directory
 // Returns the size of the zip file in bytes
 func createSkillZip(sourceDir string, zipWriter io.Writer) (int64, error) {
@@ -0,0 +1,118 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+)
+
+// externalAgentRef is an agent call to an agent owned by an explicit
+// organization (workflow.AgentRef in the Go SDK), which is not deployed by
+// this project.
+type externalAgentRef struct {
+	workflow string
+	task     string
+	org      string
+	agent    string
+	version  string
+}
+
+func (r externalAgentRef) String() string {
+	ref := r.org + "/" + r.agent
+	if r.version != "" {
+		ref += "@" + r.version
+	}
+	return fmt.Sprintf("%s (workflow '%s', task '%s')", ref, r.workflow, r.task)
+}
+
+// externalAgentRefs lists the top-level agent calls of the workflows, and of
+// their on-failure tasks, that name the organization owning the agent.
+func externalAgentRefs(workflows []*workflowv1.Workflow) []externalAgentRef {
+	var refs []externalAgentRef
+	for _, wf := range workflows {
+		tasks := append(slices.Clone(wf.GetSpec().GetTasks()), wf.GetSpec().GetOnFailure()...)
+		for _, task := range tasks {
+			if task.GetKind() != apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_AGENT_CALL {
+				continue
+			}
+			fields := task.GetTaskConfig().GetFields()
+			org := fields["org"].GetStringValue()
+			if org == "" {
+				continue
+			}
+			refs = append(refs, externalAgentRef{
+				workflow: wf.GetMetadata().GetName(),
+				task:     task.GetName(),
+				org:      org,
+				agent:    fields["agent"].GetStringValue(),
+				version:  fields["version"].GetStringValue(),
+			})
+		}
+	}
+	return refs
+}
+
+// verifyAgentRefs checks that the agents referenced by organization exist on
+// the backend, and carry the requested version, before anything is deployed.
+// All missing agents are reported at once.
+func (d *Deployer) verifyAgentRefs(workflows []*workflowv1.Workflow) error {
+	refs := externalAgentRefs(workflows)
+	if len(refs) == 0 {
+		return nil
+	}
+
+	if d.opts.ProgressCallback != nil {
+		d.opts.ProgressCallback(fmt.Sprintf("Verifying %d agent reference(s)...", len(refs)))
+	}
+
+	client := agentv1.NewAgentQueryControllerClient(d.opts.Conn)
+	var missing []string
+	for _, ref := range refs {
+		agent, err := client.GetByReference(context.Background(), &apiresource.ApiResourceReference{
+			Scope:   apiresource.ApiResourceOwnerScope_organization,
+			Org:     ref.org,
+			Kind:    apiresourcekind.ApiResourceKind_agent,
+			Slug:    ref.agent,
+			Version: ref.version,
+		})
+		if err != nil {
+			missing = append(missing, fmt.Sprintf("%s: %v", ref, err))
+			continue
+		}
+		if !agentHasVersion(agent, ref.version) {
+			missing = append(missing, fmt.Sprintf("%s: agent has no version '%s'", ref, ref.version))
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.Errorf("agent references not found:\n  %s", strings.Join(missing, "\n  "))
+	}
+	return nil
+}
+
+// agentHasVersion reports whether the agent matches the requested version,
+// like the workflow runner does when it resolves the call: empty and
+// "latest" match any agent, anything else must be one of the agent's tags or
+// its version ID.
+func agentHasVersion(agent *agentv1.Agent, version string) bool {
+	if version == "" || version == "latest" {
+		return true
+	}
+	metadata := agent.GetMetadata()
+	if metadata.GetVersion().GetId() == version {
+		return true
+	}
+	for _, tag := range metadata.GetTags() {
+		if tag == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package deploy
+
+import (
+	"testing"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newAgentCallTask(t *testing.T, name string, config map[string]any) *workflowv1.WorkflowTask {
+	t.Helper()
+	taskConfig, err := structpb.NewStruct(config)
+	if err != nil {
+		t.Fatalf("structpb.NewStruct() error = %v", err)
+	}
+	return &workflowv1.WorkflowTask{
+		Name:       name,
+		Kind:       apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_AGENT_CALL,
+		TaskConfig: taskConfig,
+	}
+}
+
+func TestExternalAgentRefs(t *testing.T) {
+	workflows := []*workflowv1.Workflow{{
+		Metadata: &apiresource.ApiResourceMetadata{Name: "review-pr"},
+		Spec: &workflowv1.WorkflowSpec{
+			Tasks: []*workflowv1.WorkflowTask{
+				newAgentCallTask(t, "local", map[string]any{"agent": "summarizer", "message": "hi"}),
+				newAgentCallTask(t, "review", map[string]any{
+					"agent":   "code-reviewer-prod",
+					"org":     "my-org",
+					"version": "stable",
+					"message": "review",
+				}),
+				{Name: "init", Kind: apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET},
+			},
+		},
+	}}
+
+	refs := externalAgentRefs(workflows)
+	if len(refs) != 1 {
+		t.Fatalf("externalAgentRefs() returned %d refs, want 1: %v", len(refs), refs)
+	}
+
+	want := "my-org/code-reviewer-prod@stable (workflow 'review-pr', task 'review')"
+	if got := refs[0].String(); got != want {
+		t.Errorf("ref = %q, want %q", got, want)
+	}
+}
+
+func TestExternalAgentRefsOnFailure(t *testing.T) {
+	workflows := []*workflowv1.Workflow{{
+		Metadata: &apiresource.ApiResourceMetadata{Name: "charge"},
+		Spec: &workflowv1.WorkflowSpec{
+			Tasks: []*workflowv1.WorkflowTask{
+				{Name: "init", Kind: apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET},
+			},
+			OnFailure: []*workflowv1.WorkflowTask{
+				newAgentCallTask(t, "triage", map[string]any{"agent": "triager", "org": "ops", "message": "triage"}),
+			},
+		},
+	}}
+
+	refs := externalAgentRefs(workflows)
+	if len(refs) != 1 || refs[0].task != "triage" {
+		t.Fatalf("externalAgentRefs() = %v, want the triage on-failure task", refs)
+	}
+}
+
+func TestAgentHasVersion(t *testing.T) {
+	agent := &agentv1.Agent{
+		Metadata: &apiresource.ApiResourceMetadata{
+			Tags:    []string{"stable"},
+			Version: &apiresource.ApiResourceMetadataVersion{Id: "v-123"},
+		},
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"", true},
+		{"latest", true},
+		{"stable", true},
+		{"v-123", true},
+		{"beta", false},
+	}
+	for _, tt := range tests {
+		if got := agentHasVersion(agent, tt.version); got != tt.want {
+			t.Errorf("agentHasVersion(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
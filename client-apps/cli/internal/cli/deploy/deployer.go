@@ -3,13 +3,20 @@ package deploy
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
 	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	agentinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentinstance/v1"
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
 	skillv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/skill/v1"
 	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/artifact"
 	"github.com/stigmer/stigmer/client-apps/cli/internal/cli/synthesis"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
@@ -26,13 +33,26 @@ type DeployOptions struct {
 	// When true, resources at the same dependency depth are created concurrently.
 	// When false, all resources are created sequentially (legacy behavior).
 	EnableParallelDeployment bool
+	// VerifyAgentRefs checks that agents referenced by organization from
+	// workflow agent calls exist on the backend before deploying anything.
+	// When false, the references are only resolved when the workflow runs.
+	VerifyAgentRefs bool
 }
 
+// annotationSecretFromEnvPrefix prefixes the Environment annotations the SDK
+// writes for secrets bound with agentinstance.SecretFromEnv or
+// workflowinstance.SecretFromEnv. It must match
+// agentinstance.AnnotationSecretFromEnvPrefix in the Go SDK.
+const annotationSecretFromEnvPrefix = "stigmer.ai/secret-from-env."
+
 // DeployResult contains the results of a deployment
 type DeployResult struct {
-	DeployedSkills    []*skillv1.Skill
-	DeployedAgents    []*agentv1.Agent
-	DeployedWorkflows []*workflowv1.Workflow
+	DeployedSkills            []*skillv1.Skill
+	DeployedAgents            []*agentv1.Agent
+	DeployedWorkflows         []*workflowv1.Workflow
+	DeployedEnvironments      []*environmentv1.Environment
+	DeployedAgentInstances    []*agentinstancev1.AgentInstance
+	DeployedWorkflowInstances []*workflowinstancev1.WorkflowInstance
 }
 
 // Deployer handles deploying skills, agents, and workflows to the backend
@@ -55,7 +75,17 @@ func NewDeployer(opts *DeployOptions) *Deployer {
 // When EnableParallelDeployment is false:
 //   - Resources are deployed sequentially in dependency order
 //   - Legacy behavior for compatibility
+//
+// The order comes from dependencies.json and the dependencies recorded in
+// the manifests. Resources depending on resources outside the manifest set
+// are retried while the backend reports a missing reference.
 func (d *Deployer) Deploy(synthesisResult *synthesis.Result) (*DeployResult, error) {
+	if d.opts.VerifyAgentRefs {
+		if err := d.verifyAgentRefs(synthesisResult.Workflows); err != nil {
+			return nil, err
+		}
+	}
+
 	// Choose deployment strategy based on options
 	if d.opts.EnableParallelDeployment {
 		return d.deployParallel(synthesisResult)
@@ -64,7 +94,7 @@ func (d *Deployer) Deploy(synthesisResult *synthesis.Result) (*DeployResult, err
 	return d.deploySequential(synthesisResult)
 }
 
-// deploySequential deploys resources sequentially (legacy behavior).
+// deploySequential deploys resources one at a time in dependency order.
 func (d *Deployer) deploySequential(synthesisResult *synthesis.Result) (*DeployResult, error) {
 	result := &DeployResult{
 		DeployedSkills:    make([]*skillv1.Skill, 0),
@@ -72,32 +102,34 @@ func (d *Deployer) deploySequential(synthesisResult *synthesis.Result) (*DeployR
 		DeployedWorkflows: make([]*workflowv1.Workflow, 0),
 	}
 
-	// TODO(T01.4): Skills are no longer deployed from code (SDK).
-	// Skills must be pushed as artifacts using `stigmer apply` in a directory with SKILL.md.
-	// The inline skill feature will be removed in T01.4.
-	// For now, skip skill deployment from Code Mode.
-	if len(synthesisResult.Skills) > 0 {
-		if d.opts.ProgressCallback != nil {
-			d.opts.ProgressCallback("⚠️  Skills detected but Code Mode deployment is deprecated. Use Artifact Mode (SKILL.md) to upload skills.")
-		}
+	// Deploy inline skills, agents and workflows in dependency order
+	ordered, err := synthesisResult.GetOrderedResources()
+	if err != nil {
+		return nil, err
 	}
-
-	// Deploy agents
-	if len(synthesisResult.Agents) > 0 {
-		agents, err := d.deployAgents(synthesisResult.Agents)
+	for _, res := range ordered {
+		deployed, err := d.deployResource(res, synthesisResult.HasExternalDependencies(res.ID))
 		if err != nil {
 			return nil, err
 		}
-		result.DeployedAgents = agents
+		switch r := deployed.(type) {
+		case *skillv1.Skill:
+			result.DeployedSkills = append(result.DeployedSkills, r)
+		case *agentv1.Agent:
+			result.DeployedAgents = append(result.DeployedAgents, r)
+		case *workflowv1.Workflow:
+			result.DeployedWorkflows = append(result.DeployedWorkflows, r)
+		}
 	}
 
-	// Deploy workflows
-	if len(synthesisResult.Workflows) > 0 {
-		workflows, err := d.deployWorkflows(synthesisResult.Workflows)
-		if err != nil {
-			return nil, err
-		}
-		result.DeployedWorkflows = workflows
+	// Deploy agent instances once their agents exist
+	if err := d.deployAgentInstances(synthesisResult, result); err != nil {
+		return nil, err
+	}
+
+	// Deploy workflow instances once their workflows exist
+	if err := d.deployWorkflowInstances(synthesisResult, result); err != nil {
+		return nil, err
 	}
 
 	return result, nil
@@ -133,7 +165,7 @@ func (d *Deployer) deployParallel(synthesisResult *synthesis.Result) (*DeployRes
 		}
 
 		// Deploy all resources at this depth level in parallel
-		deployed, err := d.deployResourceGroup(resources)
+		deployed, err := d.deployResourceGroup(synthesisResult, resources)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to deploy depth level %d", depthLevel)
 		}
@@ -151,6 +183,15 @@ func (d *Deployer) deployParallel(synthesisResult *synthesis.Result) (*DeployRes
 		}
 	}
 
+	// Instances are not part of the dependency graph; deploy them once all
+	// agents and workflows exist
+	if err := d.deployAgentInstances(synthesisResult, result); err != nil {
+		return nil, err
+	}
+	if err := d.deployWorkflowInstances(synthesisResult, result); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -158,7 +199,7 @@ func (d *Deployer) deployParallel(synthesisResult *synthesis.Result) (*DeployRes
 // All resources in the group are at the same dependency depth and can be deployed concurrently.
 //
 // Returns the deployed resources or an error if any deployment fails.
-func (d *Deployer) deployResourceGroup(resources []*synthesis.ResourceWithID) ([]proto.Message, error) {
+func (d *Deployer) deployResourceGroup(synthesisResult *synthesis.Result, resources []*synthesis.ResourceWithID) ([]proto.Message, error) {
 	if len(resources) == 0 {
 		return []proto.Message{}, nil
 	}
@@ -182,7 +223,7 @@ func (d *Deployer) deployResourceGroup(resources []*synthesis.ResourceWithID) ([
 		go func() {
 			defer wg.Done()
 
-			deployed, err := d.deployResource(resource)
+			deployed, err := d.deployResource(resource, synthesisResult.HasExternalDependencies(resource.ID))
 			results <- deployResult{
 				resource: deployed,
 				err:      err,
@@ -215,23 +256,72 @@ func (d *Deployer) deployResourceGroup(resources []*synthesis.ResourceWithID) ([
 }
 
 // deployResource deploys a single resource based on its type.
-func (d *Deployer) deployResource(res *synthesis.ResourceWithID) (proto.Message, error) {
-	switch r := res.Resource.(type) {
-	case *skillv1.Skill:
-		return d.deploySkill(r)
-	case *agentv1.Agent:
-		return d.deployAgent(r)
-	case *workflowv1.Workflow:
-		return d.deployWorkflow(r)
-	default:
-		return nil, errors.Errorf("unknown resource type: %T", res.Resource)
+//
+// Resources with external dependencies are retried while the backend
+// reports a missing reference (see retryMissingReference).
+func (d *Deployer) deployResource(res *synthesis.ResourceWithID, external bool) (proto.Message, error) {
+	var deployed proto.Message
+	err := d.retryMissingReference(res.ID, external, func() error {
+		var err error
+		switch r := res.Resource.(type) {
+		case *skillv1.Skill:
+			deployed, err = d.deploySkill(r)
+		case *agentv1.Agent:
+			deployed, err = d.deployAgent(r)
+		case *workflowv1.Workflow:
+			deployed, err = d.deployWorkflow(r)
+		default:
+			err = errors.Errorf("unknown resource type: %T", res.Resource)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return deployed, nil
 }
 
-// deploySkill is deprecated - skills are now pushed as artifacts only.
-// TODO(T01.4): Remove this method when inline skill feature is removed.
+// deploySkill pushes an inline skill synthesized by the SDK as a skill
+// artifact holding its SKILL.md, like `stigmer skill push` does for a skill
+// directory.
 func (d *Deployer) deploySkill(skill *skillv1.Skill) (*skillv1.Skill, error) {
-	return nil, fmt.Errorf("skill deployment from code is no longer supported - use Artifact Mode with SKILL.md")
+	name := skill.GetMetadata().GetSlug()
+	if name == "" {
+		name = skill.GetMetadata().GetName()
+	}
+
+	if d.opts.ProgressCallback != nil {
+		d.opts.ProgressCallback(fmt.Sprintf("Deploying skill: %s", name))
+	}
+
+	zipBytes, err := artifact.ZipSkillContent(skill.GetSpec().GetSkillMd())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create artifact for skill '%s'", name)
+	}
+
+	// Default tag to "latest" if not provided
+	tag := skill.GetSpec().GetTag()
+	if tag == "" {
+		tag = "latest"
+	}
+
+	client := skillv1.NewSkillCommandControllerClient(d.opts.Conn)
+	deployed, err := client.Push(context.Background(), &skillv1.PushSkillRequest{
+		Name:     name,
+		Scope:    apiresource.ApiResourceOwnerScope_organization,
+		Org:      d.opts.OrgID,
+		Artifact: zipBytes,
+		Tag:      tag,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to deploy skill '%s'", name)
+	}
+
+	if d.opts.ProgressCallback != nil {
+		d.opts.ProgressCallback(fmt.Sprintf("✓ Skill deployed: %s (ID: %s)", deployed.GetMetadata().GetName(), deployed.GetMetadata().GetId()))
+	}
+
+	return deployed, nil
 }
 
 // deployAgent deploys a single agent.
@@ -250,7 +340,21 @@ func (d *Deployer) deployAgent(agent *agentv1.Agent) (*agentv1.Agent, error) {
 	}
 
 	client := agentv1.NewAgentCommandControllerClient(d.opts.Conn)
-	deployed, err := client.Apply(context.Background(), agent)
+	query := agentv1.NewAgentQueryControllerClient(d.opts.Conn)
+	fetch := func(ref *apiresource.ApiResourceReference) (*deployedState, error) {
+		existing, err := query.GetByReference(context.Background(), ref)
+		return deployedOrNil(&deployedState{
+			metadata: existing.GetMetadata(),
+			audit:    existing.GetStatus().GetAudit().GetSpecAudit(),
+		}, err)
+	}
+
+	var deployed *agentv1.Agent
+	err := d.applyWithResourceVersion(apiresourcekind.ApiResourceKind_agent, agent.Metadata, fetch, func() error {
+		var err error
+		deployed, err = client.Apply(context.Background(), agent)
+		return err
+	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to deploy agent '%s'", agent.Metadata.Name)
 	}
@@ -278,7 +382,21 @@ func (d *Deployer) deployWorkflow(workflow *workflowv1.Workflow) (*workflowv1.Wo
 	}
 
 	client := workflowv1.NewWorkflowCommandControllerClient(d.opts.Conn)
-	deployed, err := client.Apply(context.Background(), workflow)
+	query := workflowv1.NewWorkflowQueryControllerClient(d.opts.Conn)
+	fetch := func(ref *apiresource.ApiResourceReference) (*deployedState, error) {
+		existing, err := query.GetByReference(context.Background(), ref)
+		return deployedOrNil(&deployedState{
+			metadata: existing.GetMetadata(),
+			audit:    existing.GetStatus().GetAudit().GetSpecAudit(),
+		}, err)
+	}
+
+	var deployed *workflowv1.Workflow
+	err := d.applyWithResourceVersion(apiresourcekind.ApiResourceKind_workflow, workflow.Metadata, fetch, func() error {
+		var err error
+		deployed, err = client.Apply(context.Background(), workflow)
+		return err
+	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to deploy workflow '%s'", workflow.Metadata.Name)
 	}
@@ -290,82 +408,262 @@ func (d *Deployer) deployWorkflow(workflow *workflowv1.Workflow) (*workflowv1.Wo
 	return deployed, nil
 }
 
-// deploySkills is deprecated - skills are now pushed as artifacts only.
-// TODO(T01.4): Remove this method when inline skill feature is removed.
-func (d *Deployer) deploySkills(skills []*skillv1.Skill) ([]*skillv1.Skill, error) {
-	return nil, fmt.Errorf("skill deployment from code is no longer supported - use Artifact Mode with SKILL.md")
-}
+// deployAgentInstances deploys the agent instances of the synthesis result,
+// each after the environment holding its bindings.
+//
+// The SDK sets spec.agent_id to the agent's slug. It is replaced with the ID
+// of the agent deployed in this run, or looked up by slug for agents that
+// were deployed earlier.
+func (d *Deployer) deployAgentInstances(synthesisResult *synthesis.Result, result *DeployResult) error {
+	if len(synthesisResult.AgentInstances) == 0 {
+		return nil
+	}
 
-// deployAgents deploys all agents
-func (d *Deployer) deployAgents(agents []*agentv1.Agent) ([]*agentv1.Agent, error) {
-	client := agentv1.NewAgentCommandControllerClient(d.opts.Conn)
-	deployedAgents := make([]*agentv1.Agent, 0, len(agents))
+	environments := make(map[string]*environmentv1.Environment, len(synthesisResult.Environments))
+	for _, env := range synthesisResult.Environments {
+		environments[env.GetMetadata().GetSlug()] = env
+	}
 
-	for i, agent := range agents {
-		// Ensure metadata is initialized and org is set
-		if agent.Metadata == nil {
-			agent.Metadata = &apiresource.ApiResourceMetadata{}
+	agentIDs := make(map[string]string, len(result.DeployedAgents))
+	for _, agent := range result.DeployedAgents {
+		agentIDs[agent.GetMetadata().GetSlug()] = agent.GetMetadata().GetId()
+	}
+
+	envClient := environmentv1.NewEnvironmentCommandControllerClient(d.opts.Conn)
+	instanceClient := agentinstancev1.NewAgentInstanceCommandControllerClient(d.opts.Conn)
+	instanceQuery := agentinstancev1.NewAgentInstanceQueryControllerClient(d.opts.Conn)
+	fetch := func(ref *apiresource.ApiResourceReference) (*deployedState, error) {
+		existing, err := instanceQuery.GetByReference(context.Background(), ref)
+		return deployedOrNil(&deployedState{metadata: existing.GetMetadata()}, err)
+	}
+
+	for i, instance := range synthesisResult.AgentInstances {
+		if instance.Metadata == nil {
+			instance.Metadata = &apiresource.ApiResourceMetadata{}
 		}
-		agent.Metadata.Org = d.opts.OrgID
-		if agent.Metadata.OwnerScope == apiresource.ApiResourceOwnerScope_api_resource_owner_scope_unspecified {
-			agent.Metadata.OwnerScope = apiresource.ApiResourceOwnerScope_organization
+		instance.Metadata.Org = d.opts.OrgID
+		if instance.Metadata.OwnerScope == apiresource.ApiResourceOwnerScope_api_resource_owner_scope_unspecified {
+			instance.Metadata.OwnerScope = apiresource.ApiResourceOwnerScope_organization
 		}
 
 		if d.opts.ProgressCallback != nil {
-			d.opts.ProgressCallback(fmt.Sprintf("Deploying agent %d/%d: %s", i+1, len(agents), agent.Metadata.Name))
+			d.opts.ProgressCallback(fmt.Sprintf("Deploying agent instance %d/%d: %s", i+1, len(synthesisResult.AgentInstances), instance.Metadata.Name))
 		}
 
-		// Call apply RPC (creates or updates)
-		// The agent already has full spec from SDK (skills, MCP servers, etc.)
-		deployed, err := client.Apply(context.Background(), agent)
+		agentID, err := d.resolveAgentID(instance.GetSpec().GetAgentId(), agentIDs)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to deploy agent '%s'", agent.Metadata.Name)
+			return errors.Wrapf(err, "failed to deploy agent instance '%s'", instance.Metadata.Name)
 		}
+		instance.Spec.AgentId = agentID
+
+		for _, ref := range instance.GetSpec().GetEnvironmentRefs() {
+			ref.Org = d.opts.OrgID
+			env, ok := environments[ref.GetSlug()]
+			if !ok {
+				// References an environment managed outside this project
+				continue
+			}
 
-		deployedAgents = append(deployedAgents, deployed)
+			deployed, err := d.deployEnvironment(envClient, env)
+			if err != nil {
+				return errors.Wrapf(err, "failed to deploy agent instance '%s'", instance.Metadata.Name)
+			}
+			result.DeployedEnvironments = append(result.DeployedEnvironments, deployed)
+		}
+
+		var deployed *agentinstancev1.AgentInstance
+		err = d.applyWithResourceVersion(apiresourcekind.ApiResourceKind_agent_instance, instance.Metadata, fetch, func() error {
+			var err error
+			deployed, err = instanceClient.Apply(context.Background(), instance)
+			return err
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to deploy agent instance '%s'", instance.Metadata.Name)
+		}
+		result.DeployedAgentInstances = append(result.DeployedAgentInstances, deployed)
 
 		if d.opts.ProgressCallback != nil {
-			d.opts.ProgressCallback(fmt.Sprintf("✓ Agent deployed: %s (ID: %s)", deployed.Metadata.Name, deployed.Metadata.Id))
+			d.opts.ProgressCallback(fmt.Sprintf("✓ Agent instance deployed: %s (ID: %s)", deployed.Metadata.Name, deployed.Metadata.Id))
 		}
 	}
 
-	return deployedAgents, nil
+	return nil
 }
 
-// deployWorkflows deploys all workflows
-func (d *Deployer) deployWorkflows(workflows []*workflowv1.Workflow) ([]*workflowv1.Workflow, error) {
-	client := workflowv1.NewWorkflowCommandControllerClient(d.opts.Conn)
-	deployedWorkflows := make([]*workflowv1.Workflow, 0, len(workflows))
+// resolveAgentID returns the ID of the agent with the given slug, looking it
+// up on the backend if it was not deployed in this run.
+func (d *Deployer) resolveAgentID(slug string, deployed map[string]string) (string, error) {
+	if id, ok := deployed[slug]; ok {
+		return id, nil
+	}
+
+	client := agentv1.NewAgentQueryControllerClient(d.opts.Conn)
+	agent, err := client.GetByReference(context.Background(), &apiresource.ApiResourceReference{
+		Scope: apiresource.ApiResourceOwnerScope_organization,
+		Org:   d.opts.OrgID,
+		Kind:  apiresourcekind.ApiResourceKind_agent,
+		Slug:  slug,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find agent '%s'", slug)
+	}
+	return agent.GetMetadata().GetId(), nil
+}
+
+// deployWorkflowInstances deploys the workflow instances of the synthesis
+// result, each after the environment holding its bindings.
+//
+// The SDK sets spec.workflow_id to the workflow's slug. It is replaced with
+// the ID of the workflow deployed in this run, or looked up by slug for
+// workflows that were deployed earlier.
+func (d *Deployer) deployWorkflowInstances(synthesisResult *synthesis.Result, result *DeployResult) error {
+	if len(synthesisResult.WorkflowInstances) == 0 {
+		return nil
+	}
+
+	environments := make(map[string]*environmentv1.Environment, len(synthesisResult.Environments))
+	for _, env := range synthesisResult.Environments {
+		environments[env.GetMetadata().GetSlug()] = env
+	}
 
-	for i, workflow := range workflows {
-		// Ensure metadata is initialized
-		if workflow.Metadata == nil {
-			workflow.Metadata = &apiresource.ApiResourceMetadata{}
+	workflowIDs := make(map[string]string, len(result.DeployedWorkflows))
+	for _, workflow := range result.DeployedWorkflows {
+		workflowIDs[workflow.GetMetadata().GetSlug()] = workflow.GetMetadata().GetId()
+	}
+
+	envClient := environmentv1.NewEnvironmentCommandControllerClient(d.opts.Conn)
+	instanceClient := workflowinstancev1.NewWorkflowInstanceCommandControllerClient(d.opts.Conn)
+	instanceQuery := workflowinstancev1.NewWorkflowInstanceQueryControllerClient(d.opts.Conn)
+	fetch := func(ref *apiresource.ApiResourceReference) (*deployedState, error) {
+		existing, err := instanceQuery.GetByReference(context.Background(), ref)
+		return deployedOrNil(&deployedState{metadata: existing.GetMetadata()}, err)
+	}
+
+	for i, instance := range synthesisResult.WorkflowInstances {
+		if instance.Metadata == nil {
+			instance.Metadata = &apiresource.ApiResourceMetadata{}
+		}
+		instance.Metadata.Org = d.opts.OrgID
+		if instance.Metadata.OwnerScope == apiresource.ApiResourceOwnerScope_api_resource_owner_scope_unspecified {
+			instance.Metadata.OwnerScope = apiresource.ApiResourceOwnerScope_organization
 		}
 
 		if d.opts.ProgressCallback != nil {
-			d.opts.ProgressCallback(fmt.Sprintf("Deploying workflow %d/%d: %s", i+1, len(workflows), workflow.Metadata.Name))
+			d.opts.ProgressCallback(fmt.Sprintf("Deploying workflow instance %d/%d: %s", i+1, len(synthesisResult.WorkflowInstances), instance.Metadata.Name))
+		}
+
+		workflowID, err := d.resolveWorkflowID(instance.GetSpec().GetWorkflowId(), workflowIDs)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deploy workflow instance '%s'", instance.Metadata.Name)
 		}
+		instance.Spec.WorkflowId = workflowID
+
+		for _, ref := range instance.GetSpec().GetEnvRefs() {
+			ref.Org = d.opts.OrgID
+			env, ok := environments[ref.GetSlug()]
+			if !ok {
+				// References an environment managed outside this project
+				continue
+			}
 
-		// The workflow is already in the correct format from SDK
-		// Just need to ensure org is set
-		workflow.Metadata.Org = d.opts.OrgID
-		if workflow.Metadata.OwnerScope == apiresource.ApiResourceOwnerScope_api_resource_owner_scope_unspecified {
-			workflow.Metadata.OwnerScope = apiresource.ApiResourceOwnerScope_organization
+			deployed, err := d.deployEnvironment(envClient, env)
+			if err != nil {
+				return errors.Wrapf(err, "failed to deploy workflow instance '%s'", instance.Metadata.Name)
+			}
+			result.DeployedEnvironments = append(result.DeployedEnvironments, deployed)
 		}
 
-		// Call apply RPC (creates or updates)
-		deployed, err := client.Apply(context.Background(), workflow)
+		var deployed *workflowinstancev1.WorkflowInstance
+		err = d.applyWithResourceVersion(apiresourcekind.ApiResourceKind_workflow_instance, instance.Metadata, fetch, func() error {
+			var err error
+			deployed, err = instanceClient.Apply(context.Background(), instance)
+			return err
+		})
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to deploy workflow '%s'", workflow.Metadata.Name)
+			return errors.Wrapf(err, "failed to deploy workflow instance '%s'", instance.Metadata.Name)
 		}
-
-		deployedWorkflows = append(deployedWorkflows, deployed)
+		result.DeployedWorkflowInstances = append(result.DeployedWorkflowInstances, deployed)
 
 		if d.opts.ProgressCallback != nil {
-			d.opts.ProgressCallback(fmt.Sprintf("✓ Workflow deployed: %s (ID: %s)", deployed.Metadata.Name, deployed.Metadata.Id))
+			d.opts.ProgressCallback(fmt.Sprintf("✓ Workflow instance deployed: %s (ID: %s)", deployed.Metadata.Name, deployed.Metadata.Id))
+		}
+	}
+
+	return nil
+}
+
+// resolveWorkflowID returns the ID of the workflow with the given slug,
+// looking it up on the backend if it was not deployed in this run.
+func (d *Deployer) resolveWorkflowID(slug string, deployed map[string]string) (string, error) {
+	if id, ok := deployed[slug]; ok {
+		return id, nil
+	}
+
+	client := workflowv1.NewWorkflowQueryControllerClient(d.opts.Conn)
+	workflow, err := client.GetByReference(context.Background(), &apiresource.ApiResourceReference{
+		Scope: apiresource.ApiResourceOwnerScope_organization,
+		Org:   d.opts.OrgID,
+		Kind:  apiresourcekind.ApiResourceKind_workflow,
+		Slug:  slug,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find workflow '%s'", slug)
+	}
+	return workflow.GetMetadata().GetId(), nil
+}
+
+// deployEnvironment deploys the environment holding an agent or workflow
+// instance's bindings, first filling in the secrets the SDK left to be read from the
+// environment of this process.
+func (d *Deployer) deployEnvironment(client environmentv1.EnvironmentCommandControllerClient, env *environmentv1.Environment) (*environmentv1.Environment, error) {
+	if env.Metadata == nil {
+		env.Metadata = &apiresource.ApiResourceMetadata{}
+	}
+	env.Metadata.Org = d.opts.OrgID
+	if env.Metadata.OwnerScope == apiresource.ApiResourceOwnerScope_api_resource_owner_scope_unspecified {
+		env.Metadata.OwnerScope = apiresource.ApiResourceOwnerScope_organization
+	}
+
+	if err := resolveSecretsFromEnv(env); err != nil {
+		return nil, err
+	}
+
+	deployed, err := client.Apply(context.Background(), env)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to deploy environment '%s'", env.Metadata.Name)
+	}
+
+	if d.opts.ProgressCallback != nil {
+		d.opts.ProgressCallback(fmt.Sprintf("✓ Environment deployed: %s (ID: %s)", deployed.Metadata.Name, deployed.Metadata.Id))
+	}
+
+	return deployed, nil
+}
+
+// resolveSecretsFromEnv fills in the secret values recorded as
+// annotationSecretFromEnvPrefix annotations from the process environment and
+// removes the annotations.
+func resolveSecretsFromEnv(env *environmentv1.Environment) error {
+	for key, source := range env.GetMetadata().GetAnnotations() {
+		name, ok := strings.CutPrefix(key, annotationSecretFromEnvPrefix)
+		if !ok {
+			continue
+		}
+
+		value, ok := os.LookupEnv(source)
+		if !ok {
+			return errors.Errorf("environment variable %s is not set (needed for secret %s of environment '%s')",
+				source, name, env.GetMetadata().GetName())
+		}
+
+		data, ok := env.GetSpec().GetData()[name]
+		if !ok {
+			return errors.Errorf("environment '%s' has no variable %s for secret source %s",
+				env.GetMetadata().GetName(), name, source)
 		}
+		data.Value = value
+		delete(env.Metadata.Annotations, key)
 	}
 
-	return deployedWorkflows, nil
+	return nil
 }
@@ -0,0 +1,58 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+)
+
+func newSecretEnvironment() *environmentv1.Environment {
+	return &environmentv1.Environment{
+		Metadata: &apiresource.ApiResourceMetadata{
+			Name: "code-reviewer-prod-env",
+			Annotations: map[string]string{
+				annotationSecretFromEnvPrefix + "GITHUB_TOKEN": "GITHUB_TOKEN_PROD",
+				"stigmer.ai/sdk.language":                      "go",
+			},
+		},
+		Spec: &environmentv1.EnvironmentSpec{
+			Data: map[string]*environmentv1.EnvironmentValue{
+				"GITHUB_TOKEN": {IsSecret: true},
+				"AWS_REGION":   {Value: "us-east-1"},
+			},
+		},
+	}
+}
+
+func TestResolveSecretsFromEnv(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN_PROD", "ghp_secret")
+	env := newSecretEnvironment()
+
+	if err := resolveSecretsFromEnv(env); err != nil {
+		t.Fatalf("resolveSecretsFromEnv() error = %v", err)
+	}
+
+	if got := env.Spec.Data["GITHUB_TOKEN"].Value; got != "ghp_secret" {
+		t.Errorf("GITHUB_TOKEN = %q, want value of GITHUB_TOKEN_PROD", got)
+	}
+	if got := env.Spec.Data["AWS_REGION"].Value; got != "us-east-1" {
+		t.Errorf("AWS_REGION = %q, want us-east-1", got)
+	}
+	if _, ok := env.Metadata.Annotations[annotationSecretFromEnvPrefix+"GITHUB_TOKEN"]; ok {
+		t.Errorf("secret source annotation was not removed")
+	}
+	if _, ok := env.Metadata.Annotations["stigmer.ai/sdk.language"]; !ok {
+		t.Errorf("unrelated annotation was removed")
+	}
+}
+
+func TestResolveSecretsFromEnv_Unset(t *testing.T) {
+	env := newSecretEnvironment()
+
+	err := resolveSecretsFromEnv(env)
+	if err == nil || !strings.Contains(err.Error(), "GITHUB_TOKEN_PROD is not set") {
+		t.Fatalf("resolveSecretsFromEnv() error = %v, want unset variable error", err)
+	}
+}
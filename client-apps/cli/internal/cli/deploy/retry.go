@@ -0,0 +1,50 @@
+package deploy
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Resources depending on agents or skills managed outside the manifest set
+// cannot be ordered: the referenced resource may be deployed concurrently by
+// another project. Their creation is retried with exponential backoff while
+// the backend reports a missing reference.
+var (
+	missingReferenceAttempts = 5
+	missingReferenceDelay    = 2 * time.Second
+)
+
+// retryMissingReference calls apply and, for a resource with external
+// dependencies, retries it while it fails with a missing reference.
+func (d *Deployer) retryMissingReference(resourceID string, external bool, apply func() error) error {
+	delay := missingReferenceDelay
+	for attempt := 1; ; attempt++ {
+		err := apply()
+		if err == nil || !external || !isMissingReference(err) || attempt == missingReferenceAttempts {
+			return err
+		}
+
+		if d.opts.ProgressCallback != nil {
+			d.opts.ProgressCallback(fmt.Sprintf("⏳ %s references a resource that does not exist yet, retrying in %s (attempt %d/%d)",
+				resourceID, delay, attempt+1, missingReferenceAttempts))
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// isMissingReference reports whether the backend rejected a resource because
+// a resource it references does not exist. Resource version conflicts are
+// handled by applyWithResourceVersion and not retried here.
+func isMissingReference(err error) bool {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return true
+	case codes.FailedPrecondition:
+		return !isVersionConflict(err)
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryMissingReference(t *testing.T) {
+	missingReferenceDelay = 0
+	missing := errors.Wrap(status.Error(codes.NotFound, "agent 'code-reviewer' not found"), "failed to deploy workflow 'pr-review'")
+
+	tests := []struct {
+		name      string
+		external  bool
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "external dependency retried until created", external: true, errs: []error{missing, missing, nil}, wantCalls: 3},
+		{name: "external dependency gives up", external: true, errs: []error{missing, missing, missing, missing, missing}, wantCalls: 5, wantErr: true},
+		{name: "dependencies in the manifest set are not retried", errs: []error{missing}, wantCalls: 1, wantErr: true},
+		{
+			name:      "other errors are not retried",
+			external:  true,
+			errs:      []error{status.Error(codes.InvalidArgument, "invalid spec")},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDeployer(&DeployOptions{})
+			calls := 0
+			err := d.retryMissingReference("workflow:pr-review", tt.external, func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("retryMissingReference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("apply called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// deployedState is the version of a resource currently stored on the backend.
+type deployedState struct {
+	metadata *apiresource.ApiResourceMetadata
+	// audit is the spec audit of the resource, nil for kinds without one
+	audit *apiresource.ApiResourceAuditInfo
+}
+
+// fetchDeployedFunc loads the deployed state of a resource by reference.
+// It returns nil when the resource does not exist yet.
+type fetchDeployedFunc func(ref *apiresource.ApiResourceReference) (*deployedState, error)
+
+// applyWithResourceVersion applies a resource based on the version currently
+// stored on the backend.
+//
+// The backend rejects updates whose metadata.resource_version is not the
+// stored one, so a deploy racing with another one cannot silently overwrite
+// its changes. The resource is fetched first to set the version it is based
+// on; if another deploy updates it in between, the apply is retried once
+// with the latest version, reporting what changed underneath.
+func (d *Deployer) applyWithResourceVersion(kind apiresourcekind.ApiResourceKind, metadata *apiresource.ApiResourceMetadata, fetch fetchDeployedFunc, apply func() error) error {
+	ref := &apiresource.ApiResourceReference{
+		Scope: metadata.GetOwnerScope(),
+		Org:   metadata.GetOrg(),
+		Kind:  kind,
+		Slug:  metadata.GetSlug(),
+	}
+	kindName := strings.ReplaceAll(kind.String(), "_", " ")
+
+	// The SDK always sets the slug; without it the backend resolves the
+	// resource by name and it is applied as a new resource
+	if ref.Slug != "" {
+		current, err := fetch(ref)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load deployed %s '%s'", kindName, metadata.GetName())
+		}
+		metadata.ResourceVersion = current.resourceVersion()
+	}
+
+	err := apply()
+	if !isVersionConflict(err) || ref.Slug == "" {
+		return err
+	}
+
+	latest, fetchErr := fetch(ref)
+	if fetchErr != nil {
+		return err
+	}
+
+	if d.opts.ProgressCallback != nil {
+		d.opts.ProgressCallback(fmt.Sprintf("⚠ %s '%s' was changed on the server during this deploy (resource_version %d → %d%s); retrying with the latest version",
+			kindName, metadata.GetName(), metadata.GetResourceVersion(), latest.resourceVersion(), latest.describeUpdate()))
+	}
+	metadata.ResourceVersion = latest.resourceVersion()
+
+	err = apply()
+	if isVersionConflict(err) {
+		return errors.Wrapf(err, "%s '%s' keeps changing on the server, another deploy is probably updating it; re-run deploy once it completes",
+			kindName, metadata.GetName())
+	}
+	return err
+}
+
+// resourceVersion returns the stored resource version, 0 for resources that
+// do not exist yet.
+func (s *deployedState) resourceVersion() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.metadata.GetResourceVersion()
+}
+
+// describeUpdate describes who last updated the resource and when, if known.
+func (s *deployedState) describeUpdate() string {
+	if s == nil || s.audit == nil || s.audit.GetUpdatedAt() == nil {
+		return ""
+	}
+	by := s.audit.GetUpdatedBy().GetId()
+	if by == "" {
+		by = "unknown"
+	}
+	return fmt.Sprintf(", last updated by %s at %s", by, s.audit.GetUpdatedAt().AsTime().Local().Format("15:04:05"))
+}
+
+// isVersionConflict reports whether the backend rejected an update because
+// it was based on a stale resource version.
+func isVersionConflict(err error) bool {
+	return status.Code(err) == codes.FailedPrecondition &&
+		strings.Contains(status.Convert(err).Message(), "resource_version")
+}
+
+// deployedOrNil turns a NotFound error from a lookup into a nil state.
+func deployedOrNil(state *deployedState, err error) (*deployedState, error) {
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
@@ -0,0 +1,129 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestApplyWithResourceVersion(t *testing.T) {
+	conflict := status.Error(codes.FailedPrecondition,
+		"agent agt-123 was modified: update is based on resource_version 3 but the current resource_version is 4")
+
+	deployedAt := func(version int64) *deployedState {
+		return &deployedState{
+			metadata: &apiresource.ApiResourceMetadata{ResourceVersion: version},
+			audit: &apiresource.ApiResourceAuditInfo{
+				UpdatedBy: &apiresource.ApiResourceAuditActor{Id: "alice"},
+				UpdatedAt: timestamppb.New(time.Now()),
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		fetched      []*deployedState
+		applyErrs    []error
+		wantVersions []int64
+		wantErr      string
+		wantProgress string
+	}{
+		{
+			name:         "new resource",
+			fetched:      []*deployedState{nil},
+			applyErrs:    []error{nil},
+			wantVersions: []int64{0},
+		},
+		{
+			name:         "based on the deployed version",
+			fetched:      []*deployedState{deployedAt(3)},
+			applyErrs:    []error{nil},
+			wantVersions: []int64{3},
+		},
+		{
+			name:         "retried once with the latest version",
+			fetched:      []*deployedState{deployedAt(3), deployedAt(4)},
+			applyErrs:    []error{conflict, nil},
+			wantVersions: []int64{3, 4},
+			wantProgress: "resource_version 3 → 4, last updated by alice",
+		},
+		{
+			name:         "gives up after the retry",
+			fetched:      []*deployedState{deployedAt(3), deployedAt(4)},
+			applyErrs:    []error{conflict, conflict},
+			wantVersions: []int64{3, 4},
+			wantErr:      "keeps changing on the server",
+		},
+		{
+			name:         "other errors are not retried",
+			fetched:      []*deployedState{deployedAt(3)},
+			applyErrs:    []error{status.Error(codes.InvalidArgument, "invalid spec")},
+			wantVersions: []int64{3},
+			wantErr:      "invalid spec",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var progress []string
+			d := NewDeployer(&DeployOptions{ProgressCallback: func(msg string) { progress = append(progress, msg) }})
+			metadata := &apiresource.ApiResourceMetadata{Name: "code-reviewer", Slug: "code-reviewer", Org: "acme"}
+
+			fetches := 0
+			fetch := func(ref *apiresource.ApiResourceReference) (*deployedState, error) {
+				if ref.GetSlug() != "code-reviewer" || ref.GetOrg() != "acme" || ref.GetKind() != apiresourcekind.ApiResourceKind_agent {
+					t.Errorf("fetch called with reference %v", ref)
+				}
+				state := tt.fetched[fetches]
+				fetches++
+				return state, nil
+			}
+
+			var versions []int64
+			err := d.applyWithResourceVersion(apiresourcekind.ApiResourceKind_agent, metadata, fetch, func() error {
+				versions = append(versions, metadata.GetResourceVersion())
+				return tt.applyErrs[len(versions)-1]
+			})
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("applyWithResourceVersion() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("applyWithResourceVersion() error = %v, want %q", err, tt.wantErr)
+			}
+			if len(versions) != len(tt.wantVersions) {
+				t.Fatalf("apply called with versions %v, want %v", versions, tt.wantVersions)
+			}
+			for i := range versions {
+				if versions[i] != tt.wantVersions[i] {
+					t.Errorf("apply called with versions %v, want %v", versions, tt.wantVersions)
+					break
+				}
+			}
+			if tt.wantProgress != "" && (len(progress) != 1 || !strings.Contains(progress[0], tt.wantProgress)) {
+				t.Errorf("progress = %q, want a message containing %q", progress, tt.wantProgress)
+			}
+		})
+	}
+}
+
+func TestIsMissingReference_VersionConflict(t *testing.T) {
+	conflict := errors.Wrap(status.Error(codes.FailedPrecondition,
+		"workflow wfl-123: resource_version is required for update (current resource_version is 2)"), "failed to deploy workflow 'pr-review'")
+	if !isVersionConflict(conflict) {
+		t.Errorf("isVersionConflict() = false for %v", conflict)
+	}
+	if isMissingReference(conflict) {
+		t.Errorf("isMissingReference() = true for a resource version conflict")
+	}
+	if !isMissingReference(status.Error(codes.FailedPrecondition, "agent 'code-reviewer' is not ready")) {
+		t.Errorf("isMissingReference() = false for other failed preconditions")
+	}
+}
@@ -0,0 +1,162 @@
+package localrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	tasksv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/zigflow/tasks"
+)
+
+// runtimePlaceholder matches ${.secrets.KEY} and ${.env_vars.VAR}. Strings
+// containing one are left to the placeholder resolver instead of being
+// evaluated as jq expressions.
+var runtimePlaceholder = regexp.MustCompile(`\$\{\.(secrets|env_vars)\.`)
+
+// httpClient does not follow redirects: like the runner, a 3xx response
+// fails the task.
+var httpClient = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// callHTTP evaluates the expressions of an HTTP_CALL task, resolves its
+// runtime placeholders and sends the request. The input is the request with
+// secrets redacted; the output is the response body, decoded as a JSON
+// object when possible.
+func (r *run) callHTTP(ctx context.Context, cfg *tasksv1.HttpCallTaskConfig) (input, output any, err error) {
+	if cfg.GetTls() != nil {
+		return nil, nil, fmt.Errorf("%w: HTTP calls with TLS configuration", ErrUnsupportedTask)
+	}
+
+	headers := make(map[string]any, len(cfg.GetHeaders()))
+	for key, value := range cfg.GetHeaders() {
+		headers[key] = value
+	}
+	request := map[string]any{
+		"method":  cfg.GetMethod(),
+		"uri":     cfg.GetEndpoint().GetUri(),
+		"headers": headers,
+	}
+	if cfg.GetBody() != nil {
+		request["body"] = cfg.GetBody().AsMap()
+	}
+
+	evaluated, err := r.evaluate(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	input, err = tasks.ResolveObject(evaluated, r.displayEnv)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved, err := tasks.ResolveObject(evaluated, r.runtimeEnv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(cfg.GetTimeoutSeconds())*time.Second)
+	defer cancel()
+
+	output, err = send(ctx, resolved.(map[string]any))
+	if err != nil {
+		// The redacted request is used so secrets in the URI are not printed.
+		displayed := input.(map[string]any)
+		return nil, nil, fmt.Errorf("%v %v: %w", displayed["method"], displayed["uri"], err)
+	}
+	return input, output, nil
+}
+
+// evaluate evaluates the runtime expressions in a value with a nil context,
+// as the runner does for HTTP calls.
+func (r *run) evaluate(node any) (any, error) {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, value := range v {
+			evaluated, err := r.evaluate(value)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = evaluated
+		}
+		return v, nil
+	case []any:
+		for i, value := range v {
+			evaluated, err := r.evaluate(value)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = evaluated
+		}
+		return v, nil
+	case string:
+		if runtimePlaceholder.MatchString(v) {
+			return v, nil
+		}
+		return utils.EvaluateString(v, nil, r.state)
+	default:
+		return v, nil
+	}
+}
+
+// send makes a resolved HTTP request and returns the response content.
+func send(ctx context.Context, request map[string]any) (any, error) {
+	var body io.Reader
+	if b, ok := request["body"]; ok {
+		data, err := json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding request body: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	method, _ := request["method"].(string)
+	uri, _ := request["uri"].(string)
+	req, err := http.NewRequestWithContext(ctx, method, uri, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range request["headers"].(map[string]any) {
+		req.Header.Set(key, fmt.Sprint(value))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		// Drop the resolved URL, which may contain secrets, from the error.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("error making HTTP call: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading HTTP body: %w", err)
+	}
+
+	var content any = string(data)
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err == nil {
+		content = obj
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("returned %s: %s", resp.Status, toJSON(content))
+	}
+	return content, nil
+}
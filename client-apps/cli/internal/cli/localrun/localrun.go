@@ -0,0 +1,338 @@
+// Package localrun executes synthesized workflows in-process, without
+// Temporal or a Stigmer server, to shorten the edit-run loop.
+//
+// Runtime expressions are evaluated with the workflow runner's own evaluator
+// and runtime placeholders with its resolver, so they behave as in a deployed
+// run. The runner's guarantees do not apply: tasks are not retried and no
+// history is recorded. Only SET, HTTP_CALL, SWITCH, WAIT and RAISE tasks are
+// supported; execution stops at the first task of another kind with
+// ErrUnsupportedTask.
+package localrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	executioncontextv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/executioncontext/v1"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	tasksv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/utils"
+	"github.com/stigmer/stigmer/backend/services/workflow-runner/pkg/validation"
+)
+
+var (
+	// ErrUnsupportedTask is returned when execution reaches a task kind the
+	// local interpreter cannot run, such as CALL_AGENT or LISTEN.
+	ErrUnsupportedTask = errors.New("task not supported by local execution")
+
+	// ErrRaised is returned when a RAISE task fails the workflow.
+	ErrRaised = errors.New("workflow raised an error")
+)
+
+// Flow directives that do not name a task (see FlowControl.then).
+const (
+	flowContinue = "continue"
+	flowExit     = "exit"
+	flowEnd      = "end"
+)
+
+// supportedKinds are the task kinds Run can execute.
+var supportedKinds = map[apiresource.WorkflowTaskKind]bool{
+	apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET:       true,
+	apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL: true,
+	apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SWITCH:    true,
+	apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_WAIT:      true,
+	apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_RAISE:     true,
+}
+
+// Options configures Run.
+type Options struct {
+	// RuntimeEnv provides the values of ${.secrets.KEY} and ${.env_vars.VAR}
+	// placeholders, as --runtime-env does for deployed runs.
+	RuntimeEnv map[string]*executioncontextv1.ExecutionValue
+
+	// Out receives the resolved input and the output of each task. Secrets
+	// and sensitive output fields are redacted. Nothing is printed when nil.
+	Out io.Writer
+}
+
+// Result is the final state of a local run.
+type Result struct {
+	// Output is the output of the last task that ran.
+	Output any
+	// Context holds the task exports, keyed by task name ($context).
+	Context map[string]any
+	// Data holds the variables set by SET tasks ($data).
+	Data map[string]any
+}
+
+// Run executes the tasks of a workflow in order, following then directives
+// and switch cases like the workflow runner.
+func Run(ctx context.Context, wf *workflowv1.Workflow, opts Options) (*Result, error) {
+	r := &run{
+		opts:       opts,
+		state:      utils.NewState(),
+		runtimeEnv: runtimeEnvMap(opts.RuntimeEnv, false),
+		displayEnv: runtimeEnvMap(opts.RuntimeEnv, true),
+	}
+	if err := r.iterateTasks(ctx, wf.GetSpec().GetTasks()); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Output: r.state.Output, Data: r.state.Data}
+	if contextMap, ok := r.state.Context.(map[string]any); ok {
+		result.Context = contextMap
+	}
+	return result, nil
+}
+
+// runtimeEnvMap converts runtime values to the map the runner's placeholder
+// resolver expects. With redact set, secret values are replaced with
+// utils.RedactedValue, for printing.
+func runtimeEnvMap(env map[string]*executioncontextv1.ExecutionValue, redact bool) map[string]any {
+	result := make(map[string]any, len(env))
+	for key, value := range env {
+		v := value.GetValue()
+		if redact && value.GetIsSecret() {
+			v = utils.RedactedValue
+		}
+		result[key] = map[string]any{"value": v, "is_secret": value.GetIsSecret()}
+	}
+	return result
+}
+
+type run struct {
+	opts       Options
+	state      *utils.State
+	runtimeEnv map[string]any
+	displayEnv map[string]any
+}
+
+// iterateTasks mirrors the runner's DoTaskBuilder: tasks run in order, a then
+// directive skips forward to its target, and "end" or "exit" stop the run.
+func (r *run) iterateTasks(ctx context.Context, taskList []*workflowv1.WorkflowTask) error {
+	var next string
+	for _, task := range taskList {
+		r.state.AddData(map[string]any{"task": map[string]any{"name": task.GetName()}})
+
+		if next != "" {
+			if task.GetName() != next {
+				continue
+			}
+			next = ""
+		}
+
+		if task.GetIf() != "" {
+			ok, err := utils.CheckIfStatement(model.NewExpr(task.GetIf()), r.state)
+			if err != nil {
+				return fmt.Errorf("task %q: %w", task.GetName(), err)
+			}
+			if !ok {
+				r.recordSkipped(task)
+				r.printf("- %s (%s) skipped: %s is false\n", task.GetName(), kindName(task), task.GetIf())
+				continue
+			}
+		}
+
+		then, err := r.runTask(ctx, task)
+		if err != nil {
+			return err
+		}
+		if then == "" {
+			then = task.GetFlow().GetThen()
+		}
+
+		switch then {
+		case "", flowContinue:
+		case flowExit, flowEnd:
+			return nil
+		default:
+			next = then
+		}
+	}
+
+	if next != "" {
+		return fmt.Errorf("next target specified but not found: %s", next)
+	}
+	return nil
+}
+
+// runTask executes a task, records its output and export, and returns the
+// task a switch jumps to, if any.
+func (r *run) runTask(ctx context.Context, task *workflowv1.WorkflowTask) (string, error) {
+	name := task.GetName()
+	r.printf("> %s (%s)\n", name, kindName(task))
+
+	if timeout := task.GetExecutionTimeoutSeconds(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancel()
+	}
+
+	if !supportedKinds[task.GetKind()] {
+		return "", fmt.Errorf("%w: task %q is a %s task; deploy the workflow and use 'stigmer run' to execute it",
+			ErrUnsupportedTask, name, kindName(task))
+	}
+	config, err := validation.UnmarshalTaskConfig(task.GetKind(), task.GetTaskConfig())
+	if err != nil {
+		return "", fmt.Errorf("task %q: %w", name, err)
+	}
+
+	var input, output any
+	var then string
+	switch cfg := config.(type) {
+	case *tasksv1.SetTaskConfig:
+		input, output, err = r.set(cfg)
+	case *tasksv1.HttpCallTaskConfig:
+		input, output, err = r.callHTTP(ctx, cfg)
+	case *tasksv1.SwitchTaskConfig:
+		then, err = r.evaluateSwitch(cfg)
+		output = r.state.Output
+	case *tasksv1.WaitTaskConfig:
+		err = r.wait(ctx, cfg)
+		output = r.state.Output
+	case *tasksv1.RaiseTaskConfig:
+		return "", fmt.Errorf("%w: task %q raised %s: %s", ErrRaised, name, cfg.GetError(), cfg.GetMessage())
+	default:
+		return "", fmt.Errorf("task %q: unexpected task config %T", name, config)
+	}
+	if err != nil {
+		return "", fmt.Errorf("task %q: %w", name, err)
+	}
+
+	r.state.Output = output
+	if as := task.GetExport().GetAs(); as != "" {
+		export, err := utils.TraverseAndEvaluateObj(model.NewObjectOrRuntimeExpr(as), output, r.state)
+		if err != nil {
+			return "", fmt.Errorf("task %q: error processing task export: %w", name, err)
+		}
+		r.setContext(name, export)
+	}
+
+	if input != nil {
+		r.printf("  input:  %s\n", toJSON(input))
+	}
+	if then != "" {
+		r.printf("  then:   %s\n", then)
+	}
+	r.printf("  output: %s\n", toJSON(redactFields(output, task.GetSensitiveOutputFields())))
+	return then, nil
+}
+
+// set evaluates the variables of a SET task into the state's data and removes
+// its unset variables, like the runner's SetTaskBuilder.
+func (r *run) set(cfg *tasksv1.SetTaskConfig) (input, output any, err error) {
+	variables := cfg.GetVariables().AsMap()
+	result, err := utils.TraverseAndEvaluateObj(model.NewObjectOrRuntimeExpr(variables), nil, r.state)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing set object: %w", err)
+	}
+	r.state.AddData(result.(map[string]any))
+	if unset := cfg.GetUnset(); len(unset) > 0 {
+		r.state.RemoveData(unset...)
+	}
+	return variables, result, nil
+}
+
+// evaluateSwitch returns the target of the first case whose condition holds.
+// A case without a condition is the default. As on the runner, a matching
+// case without a task target continues with the next task.
+func (r *run) evaluateSwitch(cfg *tasksv1.SwitchTaskConfig) (string, error) {
+	for _, c := range cfg.GetCases() {
+		if c.GetWhen() != "" {
+			ok, err := utils.CheckIfStatement(model.NewExpr(c.GetWhen()), r.state)
+			if err != nil {
+				return "", err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		switch c.GetThen() {
+		case flowExit, flowEnd:
+			return "", nil
+		default:
+			return c.GetThen(), nil
+		}
+	}
+	return "", nil
+}
+
+// wait sleeps for the duration of a WAIT task, or until ctx is done.
+func (r *run) wait(ctx context.Context, cfg *tasksv1.WaitTaskConfig) error {
+	timer := time.NewTimer(time.Duration(cfg.GetSeconds()) * time.Second)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// setContext stores a task export in $context under the task name.
+func (r *run) setContext(name string, export any) {
+	contextMap, ok := r.state.Context.(map[string]any)
+	if !ok {
+		contextMap = make(map[string]any)
+	}
+	contextMap[name] = export
+	r.state.Context = contextMap
+}
+
+// recordSkipped records {"skipped": true} as the export of a task skipped by
+// its if statement, as the runner does.
+func (r *run) recordSkipped(task *workflowv1.WorkflowTask) {
+	if task.GetExport().GetAs() == "" {
+		return
+	}
+	r.setContext(task.GetName(), map[string]any{"skipped": true})
+}
+
+func (r *run) printf(format string, args ...any) {
+	if r.opts.Out != nil {
+		fmt.Fprintf(r.opts.Out, format, args...)
+	}
+}
+
+// kindName returns the task kind without its enum prefix, such as HTTP_CALL.
+func kindName(task *workflowv1.WorkflowTask) string {
+	return strings.TrimPrefix(task.GetKind().String(), "WORKFLOW_TASK_KIND_")
+}
+
+// redactFields replaces the given top-level fields of a map output with
+// utils.RedactedValue.
+func redactFields(output any, fields []string) any {
+	obj, ok := output.(map[string]any)
+	if !ok || len(fields) == 0 {
+		return output
+	}
+	redacted := maps.Clone(obj)
+	for _, field := range fields {
+		if _, ok := redacted[field]; ok {
+			redacted[field] = utils.RedactedValue
+		}
+	}
+	return redacted
+}
+
+func toJSON(v any) string {
+	if v == nil {
+		return "null"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
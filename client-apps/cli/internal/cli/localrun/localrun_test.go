@@ -0,0 +1,160 @@
+package localrun
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	executioncontextv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/executioncontext/v1"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newTask(t *testing.T, name string, kind apiresource.WorkflowTaskKind, config map[string]any) *workflowv1.WorkflowTask {
+	t.Helper()
+	taskConfig, err := structpb.NewStruct(config)
+	if err != nil {
+		t.Fatalf("structpb.NewStruct() error = %v", err)
+	}
+	return &workflowv1.WorkflowTask{Name: name, Kind: kind, TaskConfig: taskConfig}
+}
+
+func newWorkflow(tasks ...*workflowv1.WorkflowTask) *workflowv1.Workflow {
+	return &workflowv1.Workflow{Spec: &workflowv1.WorkflowSpec{Tasks: tasks}}
+}
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" || r.URL.Path != "/greet" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"status": "ok", "message": "hello"}`))
+	}))
+	defer server.Close()
+
+	fetch := newTask(t, "fetch", apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL, map[string]any{
+		"method":          "GET",
+		"timeout_seconds": 5,
+		"endpoint":        map[string]any{"uri": `${ $data.base + "/greet" }`},
+		"headers":         map[string]any{"Authorization": "Bearer ${.secrets.TOKEN}"},
+	})
+	fetch.Export = &workflowv1.Export{As: "${.}"}
+
+	wf := newWorkflow(
+		newTask(t, "init", apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET, map[string]any{
+			"variables": map[string]any{"base": server.URL},
+		}),
+		fetch,
+		newTask(t, "route", apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SWITCH, map[string]any{
+			"cases": []any{
+				map[string]any{"name": "ok", "when": `${ $context.fetch.status == "ok" }`, "then": "done"},
+				map[string]any{"name": "default", "then": "fail"},
+			},
+		}),
+		newTask(t, "fail", apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_RAISE, map[string]any{
+			"error": "Unexpected", "message": "status not ok",
+		}),
+		newTask(t, "done", apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET, map[string]any{
+			"variables": map[string]any{"greeting": "${ $context.fetch.message }"},
+		}),
+	)
+
+	var out bytes.Buffer
+	result, err := Run(context.Background(), wf, Options{
+		RuntimeEnv: map[string]*executioncontextv1.ExecutionValue{
+			"TOKEN": {Value: "s3cret", IsSecret: true},
+		},
+		Out: &out,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v\noutput:\n%s", err, out.String())
+	}
+
+	if got := result.Data["greeting"]; got != "hello" {
+		t.Errorf("greeting = %v, want hello", got)
+	}
+	if strings.Contains(out.String(), "s3cret") {
+		t.Errorf("output contains the secret:\n%s", out.String())
+	}
+	for _, want := range []string{"> fetch (HTTP_CALL)", "Bearer [REDACTED]", "then:   done"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRun_SkippedTask(t *testing.T) {
+	skipped := newTask(t, "notify", apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET, map[string]any{
+		"variables": map[string]any{"notified": "yes"},
+	})
+	skipped.If = "${ $data.severity == \"high\" }"
+	skipped.Export = &workflowv1.Export{As: "${.}"}
+
+	wf := newWorkflow(
+		newTask(t, "init", apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET, map[string]any{
+			"variables": map[string]any{"severity": "low"},
+		}),
+		skipped,
+	)
+
+	result, err := Run(context.Background(), wf, Options{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if _, ok := result.Data["notified"]; ok {
+		t.Error("skipped task set its variables")
+	}
+	if got, ok := result.Context["notify"].(map[string]any); !ok || got["skipped"] != true {
+		t.Errorf("context[notify] = %v, want skipped", result.Context["notify"])
+	}
+}
+
+func TestRun_UnsupportedTask(t *testing.T) {
+	wf := newWorkflow(
+		newTask(t, "init", apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET, map[string]any{
+			"variables": map[string]any{"x": "1"},
+		}),
+		newTask(t, "review", apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_AGENT_CALL, map[string]any{
+			"agent": "code-reviewer", "message": "review",
+		}),
+	)
+
+	_, err := Run(context.Background(), wf, Options{})
+	if !errors.Is(err, ErrUnsupportedTask) {
+		t.Fatalf("Run() error = %v, want ErrUnsupportedTask", err)
+	}
+	if !strings.Contains(err.Error(), `"review" is a AGENT_CALL task`) {
+		t.Errorf("error = %q, want it to name the task and kind", err)
+	}
+}
+
+func TestRun_HTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "missing", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	wf := newWorkflow(newTask(t, "fetch", apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL, map[string]any{
+		"method":          "GET",
+		"timeout_seconds": 5,
+		"endpoint":        map[string]any{"uri": server.URL + "/items/${.secrets.TOKEN}"},
+	}))
+
+	_, err := Run(context.Background(), wf, Options{
+		RuntimeEnv: map[string]*executioncontextv1.ExecutionValue{
+			"TOKEN": {Value: "s3cret", IsSecret: true},
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("Run() error = %v, want a 404 error", err)
+	}
+	if strings.Contains(err.Error(), "s3cret") {
+		t.Errorf("error contains the secret: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package session
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// HistoryFileName is the file under the config directory (~/.stigmer) that
+// keeps the input lines of interactive agent sessions
+const HistoryFileName = "agent_history"
+
+// DefaultHistorySize is the number of lines kept in the history file
+const DefaultHistorySize = 1000
+
+// History is an input history persisted to a file, one line per entry.
+// It implements term.History, so the entries of previous sessions can be
+// recalled with the arrow keys.
+type History struct {
+	path    string
+	max     int
+	entries []string // oldest first
+}
+
+// LoadHistory reads the history file at path, keeping at most max entries.
+// A missing file yields an empty history.
+func LoadHistory(path string, max int) (*History, error) {
+	h := &History{path: path, max: max}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open history file")
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read history file")
+	}
+	h.trim()
+
+	return h, nil
+}
+
+// Add records an entry and appends it to the history file. Empty entries and
+// repeats of the most recent entry are dropped. Write errors are ignored: a
+// lost history line must not interrupt the session.
+func (h *History) Add(entry string) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" || strings.ContainsAny(entry, "\r\n") {
+		return
+	}
+	if n := len(h.entries); n > 0 && h.entries[n-1] == entry {
+		return
+	}
+
+	h.entries = append(h.entries, entry)
+	if h.trim() {
+		_ = h.rewrite()
+		return
+	}
+	_ = h.append(entry)
+}
+
+// Len returns the number of entries.
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+// At returns an entry; index 0 is the most recent one.
+func (h *History) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
+
+// trim drops the oldest entries beyond max and reports whether it did.
+func (h *History) trim() bool {
+	if h.max <= 0 || len(h.entries) <= h.max {
+		return false
+	}
+	h.entries = h.entries[len(h.entries)-h.max:]
+	return true
+}
+
+func (h *History) append(entry string) error {
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(entry + "\n")
+	return err
+}
+
+func (h *History) rewrite() error {
+	data := strings.Join(h.entries, "\n") + "\n"
+	return os.WriteFile(h.path, []byte(data), 0600)
+}
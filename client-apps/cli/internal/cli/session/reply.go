@@ -0,0 +1,76 @@
+package session
+
+import (
+	"fmt"
+	"io"
+
+	agentexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
+)
+
+// Reply writes the agent's side of an execution as it streams in.
+//
+// Subscribe sends the whole execution on every update, and the content of
+// the message being generated grows between updates. Reply writes only what
+// was not written before: the new text of AI messages, and the names of the
+// tools an AI message called once that message is complete. Human, tool and
+// system messages are not written.
+type Reply struct {
+	w       io.Writer
+	done    int // messages fully written
+	written int // bytes of messages[done].Content written so far
+}
+
+// NewReply creates a Reply writing to w.
+func NewReply(w io.Writer) *Reply {
+	return &Reply{w: w}
+}
+
+// Update writes the part of the execution's messages not written yet.
+func (r *Reply) Update(execution *agentexecutionv1.AgentExecution) {
+	messages := execution.GetStatus().GetMessages()
+	for r.done < len(messages) {
+		msg := messages[r.done]
+		complete := r.done < len(messages)-1
+
+		if msg.GetType() == agentexecutionv1.MessageType_MESSAGE_AI {
+			if content := msg.GetContent(); len(content) > r.written {
+				fmt.Fprint(r.w, content[r.written:])
+				r.written = len(content)
+			}
+			if !complete {
+				return
+			}
+			r.endLine()
+			for _, call := range msg.GetToolCalls() {
+				fmt.Fprintf(r.w, "🔧 %s\n", call.GetName())
+			}
+		}
+
+		r.done++
+		r.written = 0
+	}
+}
+
+// Finish terminates the last line written, if any.
+func (r *Reply) Finish() {
+	r.endLine()
+}
+
+func (r *Reply) endLine() {
+	if r.written > 0 {
+		fmt.Fprintln(r.w)
+		r.written = 0
+	}
+}
+
+// Response returns the content of the last AI message of an execution, which
+// is the agent's answer once the execution completed.
+func Response(execution *agentexecutionv1.AgentExecution) string {
+	messages := execution.GetStatus().GetMessages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].GetType() == agentexecutionv1.MessageType_MESSAGE_AI && messages[i].GetContent() != "" {
+			return messages[i].GetContent()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	agentexecutionv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentexecution/v1"
+)
+
+func TestHistory_PersistsAcrossSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".stigmer", HistoryFileName)
+
+	h, err := LoadHistory(path, DefaultHistorySize)
+	if err != nil {
+		t.Fatalf("LoadHistory() error = %v", err)
+	}
+	h.Add("summarize the open issues")
+	h.Add("summarize the open issues") // repeat of the last entry
+	h.Add("   ")
+	h.Add("now the closed ones")
+
+	reloaded, err := LoadHistory(path, DefaultHistorySize)
+	if err != nil {
+		t.Fatalf("LoadHistory() error = %v", err)
+	}
+	if reloaded.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", reloaded.Len())
+	}
+	if got := reloaded.At(0); got != "now the closed ones" {
+		t.Errorf("At(0) = %q, want the most recent entry", got)
+	}
+	if got := reloaded.At(1); got != "summarize the open issues" {
+		t.Errorf("At(1) = %q, want the oldest entry", got)
+	}
+}
+
+func TestHistory_Bounded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), HistoryFileName)
+
+	h, err := LoadHistory(path, 2)
+	if err != nil {
+		t.Fatalf("LoadHistory() error = %v", err)
+	}
+	for _, entry := range []string{"one", "two", "three"} {
+		h.Add(entry)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "two\nthree\n" {
+		t.Errorf("history file = %q, want the two most recent entries", data)
+	}
+}
+
+func TestReply_StreamsNewText(t *testing.T) {
+	var out strings.Builder
+	reply := NewReply(&out)
+
+	update := func(messages ...*agentexecutionv1.AgentMessage) {
+		reply.Update(&agentexecutionv1.AgentExecution{
+			Status: &agentexecutionv1.AgentExecutionStatus{Messages: messages},
+		})
+	}
+	human := &agentexecutionv1.AgentMessage{Type: agentexecutionv1.MessageType_MESSAGE_HUMAN, Content: "any open issues?"}
+	ai := func(content string, tools ...string) *agentexecutionv1.AgentMessage {
+		msg := &agentexecutionv1.AgentMessage{Type: agentexecutionv1.MessageType_MESSAGE_AI, Content: content}
+		for _, name := range tools {
+			msg.ToolCalls = append(msg.ToolCalls, &agentexecutionv1.ToolCall{Name: name})
+		}
+		return msg
+	}
+	tool := &agentexecutionv1.AgentMessage{Type: agentexecutionv1.MessageType_MESSAGE_TOOL, Content: `[{"id": 1}]`}
+
+	update(human)
+	update(human, ai("Let me"))
+	update(human, ai("Let me check."))
+	update(human, ai("Let me check.", "list_issues"), tool)
+	update(human, ai("Let me check.", "list_issues"), tool, ai("There is"))
+	update(human, ai("Let me check.", "list_issues"), tool, ai("There is one."))
+	reply.Finish()
+
+	want := "Let me check.\n🔧 list_issues\nThere is one.\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestResponse(t *testing.T) {
+	execution := &agentexecutionv1.AgentExecution{
+		Status: &agentexecutionv1.AgentExecutionStatus{Messages: []*agentexecutionv1.AgentMessage{
+			{Type: agentexecutionv1.MessageType_MESSAGE_HUMAN, Content: "hi"},
+			{Type: agentexecutionv1.MessageType_MESSAGE_AI, Content: "Hello!"},
+			{Type: agentexecutionv1.MessageType_MESSAGE_SYSTEM, Content: "done"},
+		}},
+	}
+	if got := Response(execution); got != "Hello!" {
+		t.Errorf("Response() = %q, want %q", got, "Hello!")
+	}
+}
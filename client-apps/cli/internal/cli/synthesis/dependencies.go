@@ -0,0 +1,62 @@
+package synthesis
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+)
+
+// addManifestDependencies adds the dependencies recorded in the metadata of
+// the agent and workflow manifests to the dependency graph, so resources are
+// ordered even when dependencies.json is missing or was written by an older
+// SDK.
+//
+// A referenced resource in the manifest set gets its own ID ("agent:reviewer");
+// any other is external ("agent:external:reviewer"), since it is managed
+// outside this deployment.
+func (r *Result) addManifestDependencies() {
+	if r.Dependencies == nil {
+		r.Dependencies = make(map[string][]string)
+	}
+
+	ids := make(map[string]bool)
+	for _, agent := range r.Agents {
+		ids[GetResourceID(agent)] = true
+	}
+	for _, workflow := range r.Workflows {
+		ids[GetResourceID(workflow)] = true
+	}
+
+	for _, agent := range r.Agents {
+		r.addDependencies(GetResourceID(agent), agent.GetMetadata().GetDependencies(), ids)
+	}
+	for _, workflow := range r.Workflows {
+		r.addDependencies(GetResourceID(workflow), workflow.GetMetadata().GetDependencies(), ids)
+	}
+}
+
+// addDependencies adds the references of one resource to the dependency
+// graph, skipping dependencies already recorded.
+func (r *Result) addDependencies(resourceID string, refs []*apiresource.ApiResourceReference, ids map[string]bool) {
+	for _, ref := range refs {
+		kind := ref.GetKind().String()
+		slug := strings.ToLower(ref.GetSlug())
+		depID := fmt.Sprintf("%s:%s", kind, slug)
+		if !ids[depID] {
+			depID = fmt.Sprintf("%s:external:%s", kind, slug)
+		}
+		if slices.Contains(r.Dependencies[resourceID], depID) {
+			continue
+		}
+		r.Dependencies[resourceID] = append(r.Dependencies[resourceID], depID)
+	}
+}
+
+// HasExternalDependencies reports whether a resource depends on resources
+// managed outside the manifest set, which cannot be ordered and may not
+// exist yet when it is created.
+func (r *Result) HasExternalDependencies(resourceID string) bool {
+	return slices.ContainsFunc(r.Dependencies[resourceID], isExternalReference)
+}
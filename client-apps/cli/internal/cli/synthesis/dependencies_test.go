@@ -0,0 +1,79 @@
+package synthesis
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"google.golang.org/protobuf/proto"
+)
+
+func writeManifest(t *testing.T, dir, name string, msg proto.Message) {
+	t.Helper()
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+}
+
+func TestReadFromDirectory_ManifestDependencies(t *testing.T) {
+	dir := t.TempDir()
+
+	agent := createTestAgent("code-reviewer")
+	agent.Metadata.Dependencies = []*apiresource.ApiResourceReference{
+		{Kind: apiresourcekind.ApiResourceKind_skill, Slug: "code-analysis"},
+	}
+	workflow := createTestWorkflow("pr-review")
+	workflow.Metadata.Dependencies = []*apiresource.ApiResourceReference{
+		{Kind: apiresourcekind.ApiResourceKind_agent, Slug: "code-reviewer"},
+		{Kind: apiresourcekind.ApiResourceKind_agent, Org: "platform-team", Slug: "summarizer"},
+	}
+	// The workflow depends on the agent and on an agent deployed elsewhere
+	writeManifest(t, dir, "agent-0.pb", agent)
+	writeManifest(t, dir, "workflow-0.pb", workflow)
+	if err := os.WriteFile(filepath.Join(dir, "dependencies.json"),
+		[]byte(`{"workflow:pr-review": ["agent:code-reviewer"]}`), 0o644); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	result, err := ReadFromDirectory(dir)
+	if err != nil {
+		t.Fatalf("ReadFromDirectory() error = %v", err)
+	}
+
+	want := map[string][]string{
+		"agent:code-reviewer": {"skill:external:code-analysis"},
+		"workflow:pr-review":  {"agent:code-reviewer", "agent:external:summarizer"},
+	}
+	if !reflect.DeepEqual(result.Dependencies, want) {
+		t.Errorf("Dependencies = %v, want %v", result.Dependencies, want)
+	}
+	if err := result.ValidateDependencies(); err != nil {
+		t.Errorf("ValidateDependencies() error = %v", err)
+	}
+
+	ordered, err := result.GetOrderedResources()
+	if err != nil {
+		t.Fatalf("GetOrderedResources() error = %v", err)
+	}
+	var ids []string
+	for _, res := range ordered {
+		ids = append(ids, res.ID)
+	}
+	if want := []string{"agent:code-reviewer", "workflow:pr-review"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("order = %v, want %v", ids, want)
+	}
+
+	if !result.HasExternalDependencies("workflow:pr-review") {
+		t.Error("HasExternalDependencies(workflow:pr-review) = false, want true")
+	}
+	if result.HasExternalDependencies("agent:missing") {
+		t.Error("HasExternalDependencies(agent:missing) = true, want false")
+	}
+}
@@ -0,0 +1,45 @@
+package synthesis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+)
+
+// Annotation keys the SDK records on every synthesized resource.
+const (
+	annotationSDKVersion     = "stigmer.ai/sdk.version"
+	annotationSDKGoVersion   = "stigmer.ai/sdk.go-version"
+	annotationSDKGeneratedAt = "stigmer.ai/sdk.generated-at"
+	annotationSourceRevision = "stigmer.ai/source.revision"
+)
+
+// Provenance summarizes which SDK build and source revision synthesized a
+// resource, from its annotations. It returns "" if none are recorded.
+//
+// Example: "sdk v0.4.2 (go1.24.0), revision 3f2c9a1, synthesized 2026-01-20T10:15:00Z"
+func Provenance(metadata *apiresource.ApiResourceMetadata) string {
+	annotations := metadata.GetAnnotations()
+
+	var parts []string
+	if version := annotations[annotationSDKVersion]; version != "" {
+		sdk := "sdk " + version
+		if goVersion := annotations[annotationSDKGoVersion]; goVersion != "" {
+			sdk += fmt.Sprintf(" (%s)", goVersion)
+		}
+		parts = append(parts, sdk)
+	}
+	if revision := annotations[annotationSourceRevision]; revision != "" {
+		parts = append(parts, "revision "+revision)
+	}
+	if generatedAt := annotations[annotationSDKGeneratedAt]; generatedAt != "" {
+		if seconds, err := strconv.ParseInt(generatedAt, 10, 64); err == nil {
+			generatedAt = time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+		}
+		parts = append(parts, "synthesized "+generatedAt)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,47 @@
+package synthesis
+
+import (
+	"testing"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+)
+
+func TestProvenance(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+	}{
+		{
+			name: "all fields",
+			annotations: map[string]string{
+				annotationSDKVersion:     "v0.4.2",
+				annotationSDKGoVersion:   "go1.24.0",
+				annotationSDKGeneratedAt: "1768904100",
+				annotationSourceRevision: "3f2c9a1",
+			},
+			want: "sdk v0.4.2 (go1.24.0), revision 3f2c9a1, synthesized 2026-01-20T10:15:00Z",
+		},
+		{
+			name: "older SDK without go version or revision",
+			annotations: map[string]string{
+				annotationSDKVersion:     "0.1.0",
+				annotationSDKGeneratedAt: "1768904100",
+			},
+			want: "sdk 0.1.0, synthesized 2026-01-20T10:15:00Z",
+		},
+		{
+			name: "none recorded",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Provenance(&apiresource.ApiResourceMetadata{Annotations: tt.annotations})
+			if got != tt.want {
+				t.Errorf("Provenance() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,8 +9,11 @@ import (
 	"strings"
 
 	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	agentinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentinstance/v1"
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
 	skillv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/skill/v1"
 	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
 )
@@ -21,9 +24,14 @@ import (
 //   - skill-0.pb, skill-1.pb, ...
 //   - agent-0.pb, agent-1.pb, ...
 //   - workflow-0.pb, workflow-1.pb, ...
+//   - environment-0.pb, environment-1.pb, ...
+//   - agentinstance-0.pb, agentinstance-1.pb, ...
+//   - workflowinstance-0.pb, workflowinstance-1.pb, ...
+//   - config.json (only when context variables exist)
 //   - dependencies.json
 //
-// This function reads all these files and returns a Result.
+// This function reads all these files and returns a Result. The dependencies
+// recorded in the manifests' metadata are merged into the dependency graph.
 func ReadFromDirectory(outputDir string) (*Result, error) {
 	result := &Result{
 		Skills:    make([]*skillv1.Skill, 0),
@@ -52,6 +60,27 @@ func ReadFromDirectory(outputDir string) (*Result, error) {
 	}
 	result.Workflows = workflows
 
+	// Read instance environments (environment-0.pb, environment-1.pb, ...)
+	environments, err := readProtoFiles[*environmentv1.Environment](outputDir, "environment-*.pb")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read environments")
+	}
+	result.Environments = environments
+
+	// Read agent instances (agentinstance-0.pb, agentinstance-1.pb, ...)
+	agentInstances, err := readProtoFiles[*agentinstancev1.AgentInstance](outputDir, "agentinstance-*.pb")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read agent instances")
+	}
+	result.AgentInstances = agentInstances
+
+	// Read workflow instances (workflowinstance-0.pb, workflowinstance-1.pb, ...)
+	workflowInstances, err := readProtoFiles[*workflowinstancev1.WorkflowInstance](outputDir, "workflowinstance-*.pb")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read workflow instances")
+	}
+	result.WorkflowInstances = workflowInstances
+
 	// Read dependencies.json
 	deps, err := readDependencies(outputDir)
 	if err != nil {
@@ -62,6 +91,14 @@ func ReadFromDirectory(outputDir string) (*Result, error) {
 		deps = make(map[string][]string)
 	}
 	result.Dependencies = deps
+	result.addManifestDependencies()
+
+	// Read config.json (optional)
+	config, err := readConfig(outputDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "failed to read config")
+	}
+	result.Config = config
 
 	// Validate that at least one resource exists
 	if result.TotalResources() == 0 {
@@ -99,7 +136,7 @@ func readProtoFiles[T proto.Message](dir, pattern string) ([]T, error) {
 
 		// Unmarshal
 		if err := proto.Unmarshal(data, msg); err != nil {
-			return nil, errors.Wrapf(err, "failed to unmarshal %s", path)
+			return nil, errors.Wrapf(err, "failed to unmarshal %s (the file may be truncated by an interrupted synthesis with an older SDK; run synthesis again)", path)
 		}
 
 		results = append(results, msg)
@@ -125,6 +162,21 @@ func readDependencies(outputDir string) (map[string][]string, error) {
 	return deps, nil
 }
 
+// readConfig reads the config.json file.
+func readConfig(outputDir string) ([]ConfigValue, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, "config.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var config []ConfigValue
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "failed to parse config.json")
+	}
+
+	return config, nil
+}
+
 // GetResourceID generates a resource ID from a proto message.
 //
 // Format:
@@ -2,8 +2,11 @@ package synthesis
 
 import (
 	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	agentinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentinstance/v1"
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
 	skillv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/skill/v1"
 	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
 )
 
 // Result contains all resources synthesized from SDK code execution.
@@ -20,14 +23,40 @@ type Result struct {
 	// Workflows are workflow definitions (workflow-0.pb, workflow-1.pb, ...)
 	Workflows []*workflowv1.Workflow
 
+	// Environments hold the bindings of agent and workflow instances
+	// (environment-0.pb, ...)
+	Environments []*environmentv1.Environment
+
+	// AgentInstances are agent instance definitions (agentinstance-0.pb, ...).
+	// Their spec.agent_id holds the agent's slug until deployment.
+	AgentInstances []*agentinstancev1.AgentInstance
+
+	// WorkflowInstances are workflow instance definitions
+	// (workflowinstance-0.pb, ...). Their spec.workflow_id holds the
+	// workflow's slug until deployment.
+	WorkflowInstances []*workflowinstancev1.WorkflowInstance
+
 	// Dependencies maps resource IDs to their dependencies
 	// Format: {"agent:reviewer": ["skill:code-analysis"], ...}
 	Dependencies map[string][]string
+
+	// Config lists the resolved context variables (config.json), empty when
+	// the SDK code declared none
+	Config []ConfigValue
+}
+
+// ConfigValue is a context variable resolved during synthesis, with the
+// source of its value ("code", "env" or "default").
+type ConfigValue struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	EnvVar string `json:"envVar,omitempty"`
 }
 
 // TotalResources returns the total count of all resources
 func (r *Result) TotalResources() int {
-	return len(r.Skills) + len(r.Agents) + len(r.Workflows)
+	return len(r.Skills) + len(r.Agents) + len(r.Workflows) + len(r.AgentInstances) + len(r.WorkflowInstances)
 }
 
 // AgentCount returns the number of agents
@@ -44,3 +73,13 @@ func (r *Result) SkillCount() int {
 func (r *Result) WorkflowCount() int {
 	return len(r.Workflows)
 }
+
+// AgentInstanceCount returns the number of agent instances
+func (r *Result) AgentInstanceCount() int {
+	return len(r.AgentInstances)
+}
+
+// WorkflowInstanceCount returns the number of workflow instances
+func (r *Result) WorkflowInstanceCount() int {
+	return len(r.WorkflowInstances)
+}
@@ -13,9 +13,11 @@ import (
 type ResourceType string
 
 const (
-	ResourceTypeAgent    ResourceType = "Agent"
-	ResourceTypeWorkflow ResourceType = "Workflow"
-	ResourceTypeSkill    ResourceType = "Skill"
+	ResourceTypeAgent            ResourceType = "Agent"
+	ResourceTypeWorkflow         ResourceType = "Workflow"
+	ResourceTypeSkill            ResourceType = "Skill"
+	ResourceTypeAgentInstance    ResourceType = "AgentInstance"
+	ResourceTypeWorkflowInstance ResourceType = "WorkflowInstance"
 )
 
 // ApplyStatus represents the status of an apply operation
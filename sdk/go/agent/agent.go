@@ -1,13 +1,18 @@
 package agent
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"sync"
 
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
 	"github.com/stigmer/stigmer/sdk/go/environment"
 	genAgent "github.com/stigmer/stigmer/sdk/go/gen/agent"
+	"github.com/stigmer/stigmer/sdk/go/internal/outputschema"
 	"github.com/stigmer/stigmer/sdk/go/mcpserver"
+	"github.com/stigmer/stigmer/sdk/go/skill"
 	"github.com/stigmer/stigmer/sdk/go/stigmer/naming"
 	"github.com/stigmer/stigmer/sdk/go/subagent"
 )
@@ -24,6 +29,12 @@ type Context interface {
 	RegisterAgent(*Agent)
 }
 
+// openChecker is implemented by contexts that are closed once they can no
+// longer synthesize resources, such as stigmer.Context after Run returns.
+type openChecker interface {
+	CheckOpen() error
+}
+
 // Agent represents an AI agent template with skills, MCP servers, and configuration.
 //
 // The Agent is the "template" layer - it defines the immutable logic and requirements
@@ -50,7 +61,12 @@ type Agent struct {
 	// Description is a human-readable description for UI display (optional, max 500 chars).
 	Description string
 
+	// Localizations are the display texts per BCP-47 language tag, with
+	// Description as the fallback. Use WithLocalizedDescription to set them.
+	Localizations map[string]Localization
+
 	// IconURL is the icon URL for marketplace and UI display (optional).
+	// May be a runtime expression when the URL varies by environment.
 	IconURL string
 
 	// Org is the organization that owns this agent (optional).
@@ -58,9 +74,15 @@ type Agent struct {
 
 	// SkillRefs are references to Skill resources providing agent knowledge.
 	// Use AddSkillRef() for platform skills or AddOrgSkillRef() for organization skills.
-	// Skills are pushed via `stigmer skill push` CLI - the SDK only references them.
+	// Skills pushed via `stigmer skill push` CLI are only referenced; inline
+	// skills are in Skills.
 	SkillRefs []*apiresource.ApiResourceReference
 
+	// Skills are inline skills defined in the program, added with AddSkill.
+	// Synthesis emits each as a skill manifest and adds a reference to it,
+	// by slug, to the agent's skill references.
+	Skills []*skill.Skill
+
 	// MCPServers are MCP server definitions declaring required servers.
 	MCPServers []mcpserver.MCPServer
 
@@ -70,11 +92,48 @@ type Agent struct {
 	// EnvironmentVariables are environment variables required by the agent.
 	EnvironmentVariables []environment.Variable
 
+	// EnvironmentSets are shared sets of environment variables, expanded into
+	// the agent's environment spec at synthesis. Use WithEnvironmentSet.
+	EnvironmentSets []*environment.Set
+
+	// Memory controls how much conversation history the agent keeps between turns.
+	// Use WithMemory() to set it; the zero value keeps the full session history.
+	Memory Memory
+
+	// ToolPolicy denies tools or requires approval before calling them,
+	// across all MCP servers. Use WithToolPolicy() to set it.
+	ToolPolicy ToolPolicy
+
+	// Limits bounds the tokens, tool calls and duration of each execution.
+	// Use WithLimits() to set them; the zero value is unlimited.
+	Limits Limits
+
+	// OutputSchema is the JSON Schema the agent's final response must conform to.
+	// Use WithOutputSchema() or WithOutputSchemaFile() to set it.
+	OutputSchema map[string]any
+
+	// orgSkillRefs marks the SkillRefs added with OrgSkill, whose org is
+	// resolved at synthesis
+	orgSkillRefs map[*apiresource.ApiResourceReference]bool
+
+	// optionErr is the first error of an option applied by New, such as a
+	// failure to load instructions from a file
+	optionErr error
+
+	// instructionsFile is the file passed to WithInstructionsFromFile, and
+	// baseDir the directory set with WithBaseDir, for New to load it
+	instructionsFile *instructionsFile
+	baseDir          string
+
+	// expressionFields records fields whose values are runtime expressions
+	// (e.g. "icon_url"). These fields skip format validation at synthesis.
+	expressionFields map[string]bool
+
 	// Context reference (optional, used for typed variable management)
 	ctx Context
 
-	// mu protects concurrent access to SkillRefs, MCPServers, SubAgents, and EnvironmentVariables slices
-	mu sync.Mutex
+	// mu protects concurrent access to SkillRefs, Skills, MCPServers, SubAgents, EnvironmentVariables and EnvironmentSets slices
+	mu sync.RWMutex
 }
 
 // New creates a new Agent with struct-based args (Pulumi pattern).
@@ -84,12 +143,15 @@ type Agent struct {
 //
 // Required:
 //   - name: agent name (lowercase alphanumeric with hyphens)
-//   - args.Instructions: behavior instructions (min 10 characters)
+//   - args.Instructions: behavior instructions (min 10 characters), unless
+//     loaded with WithInstructionsFromFile or WithInstructionsFromFS
 //
 // Optional args fields:
 //   - Description: human-readable description
 //   - IconUrl: icon URL for UI display
 //
+// Options such as WithOrgSkill are applied after the args.
+//
 // Example (clean single-package import):
 //
 //	import (
@@ -109,7 +171,13 @@ type Agent struct {
 //	    ag.AddOrgSkillRef("internal-docs", "v1.0")
 //	    return nil
 //	})
-func New(ctx Context, name string, args *AgentArgs) (*Agent, error) {
+func New(ctx Context, name string, args *AgentArgs, opts ...AgentOption) (*Agent, error) {
+	if oc, ok := ctx.(openChecker); ok {
+		if err := oc.CheckOpen(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Nil-safety: if args is nil, create empty args
 	if args == nil {
 		args = &AgentArgs{}
@@ -144,6 +212,7 @@ func New(ctx Context, name string, args *AgentArgs) (*Agent, error) {
 	if err := validate(a); err != nil {
 		return nil, err
 	}
+	a.recordExpressionField("icon_url", a.IconURL)
 
 	// Validate slug format
 	if a.Slug != "" {
@@ -152,6 +221,17 @@ func New(ctx Context, name string, args *AgentArgs) (*Agent, error) {
 		}
 	}
 
+	for _, opt := range opts {
+		opt(a)
+		if a.optionErr != nil {
+			return nil, a.optionErr
+		}
+	}
+	a.loadInstructionsFile()
+	if a.optionErr != nil {
+		return nil, a.optionErr
+	}
+
 	// Register with context (if provided)
 	if ctx != nil {
 		ctx.RegisterAgent(a)
@@ -164,9 +244,126 @@ func New(ctx Context, name string, args *AgentArgs) (*Agent, error) {
 // Builder Methods - Modify agent after construction
 // ============================================================================
 
+// WithIconURL sets the agent's icon URL from a string or StringRef.
+//
+// Literal values (including StringRefs resolved at synthesis, such as
+// concatenations of context variables) are validated immediately and must be
+// http or https URLs. Values that can only be resolved at runtime (containing
+// a RuntimeEnv placeholder or a computed expression) skip format validation
+// and are recorded as expression-valued, so agents whose icon varies by
+// environment can still be declared.
+//
+// Example:
+//
+//	iconBase := ctx.SetString("iconBase", "https://cdn.example.com")
+//	err := ag.WithIconURL(iconBase.Concat("/agent.png"))  // Validated literal
+//
+//	err := ag.WithIconURL("https://cdn-" + workflow.RuntimeEnv("ENV") + ".example.com/agent.png")
+//	ag.IsExpressionValued("icon_url")  // true
+func (a *Agent) WithIconURL(iconURL interface{}) error {
+	value := toExpression(iconURL)
+	if err := validateURLField("icon_url", value, ErrInvalidIconURL); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.IconURL = value
+	a.recordExpressionField("icon_url", value)
+	return nil
+}
+
+// WithMemory sets the agent's conversation memory configuration.
+//
+// The configuration is validated immediately: windows must keep at least one
+// turn and summarizing budgets must be between 1000 and 1000000 tokens.
+//
+// Example:
+//
+//	err := ag.WithMemory(agent.MemoryNone)                               // Stateless
+//	err := ag.WithMemory(agent.MemoryWindow(20))                         // Last 20 turns
+//	err := ag.WithMemory(agent.MemorySummarizing(agent.MaxTokens(8000))) // Summarize past 8000 tokens
+func (a *Agent) WithMemory(memory Memory) error {
+	if err := memory.validate(); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.Memory = memory
+	return nil
+}
+
+// WithOutputSchema declares the structure of the agent's final response.
+//
+// The schema may be a full JSON Schema document or a shorthand map of
+// property names to types, which expands to an object with every property
+// required. Workflows calling the agent receive the parsed JSON object as
+// the task output, and Field() references are checked against the schema
+// at synthesis.
+//
+// Example:
+//
+//	err := ag.WithOutputSchema(map[string]any{
+//	    "severity": "string",
+//	    "issues":   []any{map[string]any{"title": "string", "line": "integer"}},
+//	})
+func (a *Agent) WithOutputSchema(schema map[string]any) error {
+	normalized, err := outputschema.Normalize(schema)
+	if err != nil {
+		return NewValidationErrorWithCause("output_schema", "", "schema", err.Error(), ErrInvalidOutputSchema)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.OutputSchema = normalized
+	return nil
+}
+
+// WithOutputSchemaFile loads the agent's output schema from a JSON file.
+// See WithOutputSchema for the accepted formats.
+func (a *Agent) WithOutputSchemaFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NewValidationErrorWithCause("output_schema", path, "file",
+			fmt.Sprintf("failed to read output schema file: %v", err), ErrInvalidOutputSchema)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return NewValidationErrorWithCause("output_schema", path, "file",
+			fmt.Sprintf("output schema file must contain a JSON object: %v", err), ErrInvalidOutputSchema)
+	}
+
+	return a.WithOutputSchema(schema)
+}
+
+// IsExpressionValued reports whether the given field (e.g. "icon_url") holds
+// a runtime expression rather than a literal value.
+func (a *Agent) IsExpressionValued(field string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.expressionFields[field]
+}
+
+// recordExpressionField tracks whether a field holds a runtime expression.
+// Note: caller must hold a.mu if the agent is shared.
+func (a *Agent) recordExpressionField(field, value string) {
+	if !isExpression(value) {
+		delete(a.expressionFields, field)
+		return
+	}
+	if a.expressionFields == nil {
+		a.expressionFields = make(map[string]bool)
+	}
+	a.expressionFields[field] = true
+}
+
 // AddSkillRef adds a skill reference to the agent.
 //
 // Use skillref.Platform() to create platform skill references.
+// A reference identical to one already added is ignored; references to the
+// same skill with different versions fail synthesis with ErrConflictingSkillRef.
 // This method is thread-safe and can be called concurrently.
 //
 // Example:
@@ -178,11 +375,12 @@ func New(ctx Context, name string, args *AgentArgs) (*Agent, error) {
 func (a *Agent) AddSkillRef(ref *apiresource.ApiResourceReference) *Agent {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	a.SkillRefs = append(a.SkillRefs, ref)
+	a.appendSkillRef(ref, false)
 	return a
 }
 
-// AddSkillRefs adds multiple skill references to the agent.
+// AddSkillRefs adds multiple skill references to the agent, ignoring
+// duplicates like AddSkillRef.
 // This method is thread-safe and can be called concurrently.
 //
 // Example:
@@ -194,14 +392,17 @@ func (a *Agent) AddSkillRef(ref *apiresource.ApiResourceReference) *Agent {
 func (a *Agent) AddSkillRefs(refs ...*apiresource.ApiResourceReference) *Agent {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	a.SkillRefs = append(a.SkillRefs, refs...)
+	for _, ref := range refs {
+		a.appendSkillRef(ref, false)
+	}
 	return a
 }
 
 // AddOrgSkillRef adds an organization-scoped skill reference using the agent's Org.
 //
 // This is a convenience method that creates a skill reference scoped to the
-// agent's organization. The agent's Org field must be set for this to work correctly.
+// agent's organization. The agent's Org field must be set for this to work correctly;
+// use OrgSkill to resolve the org at synthesis instead.
 //
 // Version is optional - if omitted or empty, "latest" is used.
 //
@@ -223,11 +424,37 @@ func (a *Agent) AddOrgSkillRef(slug string, version ...string) *Agent {
 
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	a.SkillRefs = append(a.SkillRefs, ref)
+	a.appendSkillRef(ref, false)
+	return a
+}
+
+// AddSkill adds an inline skill to the agent.
+//
+// At synthesis the skill is emitted as a skill manifest, once for all the
+// agents sharing it, and the agent references it by slug in its organization
+// instead of embedding the markdown. Adding the same skill twice is ignored.
+// This method is thread-safe and can be called concurrently.
+//
+// Example:
+//
+//	guidelines, _ := skill.New("security-guidelines",
+//	    skill.WithMarkdown("# Security Guidelines\n\nValidate all user input."))
+//	agent.AddSkill(guidelines)
+func (a *Agent) AddSkill(s *skill.Skill) *Agent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, existing := range a.Skills {
+		if existing == s {
+			return a
+		}
+	}
+	a.Skills = append(a.Skills, s)
 	return a
 }
 
 // AddMCPServer adds an MCP server to the agent after creation.
+// A server identical to one already added is dropped at synthesis; different
+// servers with the same name fail synthesis with ErrMCPServerNameConflict.
 // This method is thread-safe and can be called concurrently.
 //
 // Example:
@@ -257,6 +484,9 @@ func (a *Agent) AddMCPServers(servers ...mcpserver.MCPServer) *Agent {
 }
 
 // AddSubAgent adds a sub-agent to the agent after creation.
+// A sub-agent identical to one already added is dropped at synthesis;
+// different sub-agents with the same name fail synthesis with
+// ErrSubAgentNameConflict.
 // This method is thread-safe and can be called concurrently.
 //
 // Example:
@@ -313,6 +543,64 @@ func (a *Agent) AddEnvironmentVariables(variables ...environment.Variable) *Agen
 	return a
 }
 
+// WithEnvironmentSet adds shared sets of environment variables to the agent.
+//
+// The variables are expanded into the agent's environment spec at synthesis,
+// so changes to a set apply to every agent using it. A variable also added
+// with AddEnvironmentVariable is kept once when both definitions are
+// identical; different definitions fail synthesis with
+// environment.ErrConflictingVariable.
+//
+// Example:
+//
+//	githubStack, err := environment.NewSet("github-stack", githubToken, awsRegion)
+//	if err != nil {
+//	    return err
+//	}
+//	ag, err := agent.New(ctx, "pr-reviewer", &agent.AgentArgs{
+//	    Instructions: "Review pull requests",
+//	}, agent.WithEnvironmentSet(githubStack))
+func WithEnvironmentSet(sets ...*environment.Set) AgentOption {
+	return func(a *Agent) {
+		a.AddEnvironmentSet(sets...)
+	}
+}
+
+// AddEnvironmentSet adds shared sets of environment variables to the agent
+// after creation. See WithEnvironmentSet.
+// This method is thread-safe and can be called concurrently.
+func (a *Agent) AddEnvironmentSet(sets ...*environment.Set) *Agent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.EnvironmentSets = append(a.EnvironmentSets, sets...)
+	return a
+}
+
+// Environment returns the environment variables of the agent: those added
+// individually, then those of its environment sets. A variable defined
+// identically more than once is returned once; different definitions of the
+// same variable fail with environment.ErrConflictingVariable.
+func (a *Agent) Environment() ([]environment.Variable, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	variables, err := environment.MergeVariables(nil, a.EnvironmentVariables, fmt.Sprintf("agent %q", a.Name))
+	if err != nil {
+		return nil, err
+	}
+	for _, set := range a.EnvironmentSets {
+		if set == nil {
+			continue
+		}
+		variables, err = environment.MergeVariables(variables, set.Variables,
+			fmt.Sprintf("environment set %q of agent %q", set.Name, a.Name))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return variables, nil
+}
+
 // String returns a string representation of the Agent.
 func (a *Agent) String() string {
 	return "Agent(name=" + a.Name + ")"
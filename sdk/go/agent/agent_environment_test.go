@@ -1,6 +1,8 @@
 package agent
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stigmer/stigmer/sdk/go/environment"
@@ -85,3 +87,88 @@ func TestAgentWithMultipleEnvironmentVariables(t *testing.T) {
 		}
 	}
 }
+
+func TestAgentWithEnvironmentSet(t *testing.T) {
+	ctx := &mockEnvTestCtx{}
+
+	githubToken, _ := environment.New(ctx, "GITHUB_TOKEN", &environment.VariableArgs{
+		IsSecret:    true,
+		Description: "GitHub API token",
+	})
+	awsRegion, _ := environment.New(ctx, "AWS_REGION", &environment.VariableArgs{
+		DefaultValue: "us-east-1",
+	})
+	logLevel, _ := environment.New(ctx, "LOG_LEVEL", &environment.VariableArgs{
+		DefaultValue: "info",
+	})
+
+	githubStack, err := environment.NewSet("github-stack", githubToken, awsRegion)
+	if err != nil {
+		t.Fatalf("NewSet() error = %v", err)
+	}
+
+	agent, err := New(nil, "pr-reviewer", &AgentArgs{
+		Instructions: "Review pull requests",
+	}, WithEnvironmentSet(githubStack))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	// Identical to the set's definition: declared once
+	agent.AddEnvironmentVariables(*githubToken, *logLevel)
+
+	pb, err := agent.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	data := pb.GetSpec().GetEnvSpec().GetData()
+	if len(data) != 3 {
+		t.Fatalf("env spec has %d variables, want 3: %v", len(data), data)
+	}
+	if !data["GITHUB_TOKEN"].GetIsSecret() || data["GITHUB_TOKEN"].GetDescription() != "GitHub API token" {
+		t.Errorf("GITHUB_TOKEN = %v, want the set's definition", data["GITHUB_TOKEN"])
+	}
+	if data["AWS_REGION"].GetValue() != "us-east-1" {
+		t.Errorf("AWS_REGION = %v, want default us-east-1", data["AWS_REGION"])
+	}
+
+	// Adding a variable to the set is reflected at synthesis
+	githubStack.Variables = append(githubStack.Variables, environment.Variable{Name: "GITHUB_ORG", Required: true})
+	pb, err = agent.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if _, ok := pb.GetSpec().GetEnvSpec().GetData()["GITHUB_ORG"]; !ok {
+		t.Error("GITHUB_ORG added to the set is missing from the env spec")
+	}
+}
+
+func TestAgentWithEnvironmentSet_ConflictingVariable(t *testing.T) {
+	ctx := &mockEnvTestCtx{}
+
+	awsRegion, _ := environment.New(ctx, "AWS_REGION", &environment.VariableArgs{
+		DefaultValue: "us-east-1",
+	})
+	otherRegion, _ := environment.New(ctx, "AWS_REGION", &environment.VariableArgs{
+		DefaultValue: "eu-west-1",
+	})
+	awsStack, err := environment.NewSet("aws-stack", awsRegion)
+	if err != nil {
+		t.Fatalf("NewSet() error = %v", err)
+	}
+
+	agent, err := New(nil, "deployer", &AgentArgs{
+		Instructions: "Deploy services to AWS",
+	}, WithEnvironmentSet(awsStack))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	agent.AddEnvironmentVariable(*otherRegion)
+
+	_, err = agent.ToProto()
+	if !errors.Is(err, environment.ErrConflictingVariable) {
+		t.Fatalf("ToProto() error = %v, want ErrConflictingVariable", err)
+	}
+	if !strings.Contains(err.Error(), `environment set "aws-stack"`) || !strings.Contains(err.Error(), `default "eu-west-1" vs "us-east-1"`) {
+		t.Errorf("error = %v, want the set and the conflicting defaults named", err)
+	}
+}
@@ -1,8 +1,14 @@
 package agent
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/sdk/go/skill"
 	"github.com/stigmer/stigmer/sdk/go/skillref"
 )
 
@@ -63,3 +69,104 @@ func TestAgentWithMultipleSkills(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestAgentOrgSkill(t *testing.T) {
+	ag, err := New(nil, "test-agent", &AgentArgs{
+		Instructions: "Test instructions for agent",
+	}, WithOrgSkill("internal-docs"))
+	if err != nil {
+		t.Fatalf("New() unexpected error = %v", err)
+	}
+	ag.AddSkillRef(skillref.Platform("coding-best-practices"))
+	ag.OrgSkill("security-policy", "v2.0")
+
+	// The org is resolved at synthesis, so it may be set after OrgSkill
+	ag.Org = "my-org"
+
+	pb, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() unexpected error = %v", err)
+	}
+
+	want := []*apiresource.ApiResourceReference{
+		skillref.Organization("my-org", "internal-docs"),
+		skillref.Platform("coding-best-practices"),
+		skillref.Organization("my-org", "security-policy", "v2.0"),
+	}
+	got := pb.GetSpec().GetSkillRefs()
+	if len(got) != len(want) {
+		t.Fatalf("ToProto() skill refs = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("skill_refs[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	// Resolution does not modify the agent
+	if ag.SkillRefs[0].Org != "" {
+		t.Errorf("SkillRefs[0].Org = %q after ToProto, want empty", ag.SkillRefs[0].Org)
+	}
+}
+
+func TestAgentOrgSkill_MissingOrg(t *testing.T) {
+	ag, err := New(nil, "test-agent", &AgentArgs{
+		Instructions: "Test instructions for agent",
+	}, WithOrgSkill("internal-docs"))
+	if err != nil {
+		t.Fatalf("New() unexpected error = %v", err)
+	}
+
+	_, err = ag.ToProto()
+	if !errors.Is(err, ErrMissingOrg) {
+		t.Fatalf("ToProto() error = %v, want ErrMissingOrg", err)
+	}
+	if !strings.Contains(err.Error(), "internal-docs") {
+		t.Errorf("error %q does not name the skill", err)
+	}
+}
+
+func TestAgentAddSkill(t *testing.T) {
+	guidelines, err := skill.New("security-guidelines", skill.WithMarkdown("# Security\n\nValidate all input."))
+	if err != nil {
+		t.Fatalf("skill.New() error = %v", err)
+	}
+
+	ag, err := New(nil, "test-agent", &AgentArgs{
+		Instructions: "Test instructions for agent",
+	})
+	if err != nil {
+		t.Fatalf("New() unexpected error = %v", err)
+	}
+	ag.Org = "my-org"
+	ag.AddSkillRef(skillref.Platform("coding-best-practices"))
+	ag.AddSkill(guidelines)
+	ag.AddSkill(guidelines)
+
+	if len(ag.Skills) != 1 {
+		t.Errorf("Skills = %v, want the skill once", ag.Skills)
+	}
+
+	pb, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() unexpected error = %v", err)
+	}
+
+	// The skill is referenced by slug; its markdown is not in the manifest
+	want := []*apiresource.ApiResourceReference{
+		skillref.Platform("coding-best-practices"),
+		skillref.Organization("my-org", "security-guidelines"),
+	}
+	got := pb.GetSpec().GetSkillRefs()
+	if len(got) != len(want) {
+		t.Fatalf("ToProto() skill refs = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("skill_refs[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if len(ag.SkillRefs) != 1 {
+		t.Errorf("SkillRefs = %v after ToProto, want only the platform reference", ag.SkillRefs)
+	}
+}
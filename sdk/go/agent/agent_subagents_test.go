@@ -1,8 +1,12 @@
 package agent
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
 	"github.com/stigmer/stigmer/sdk/go/mcpserver"
 	"github.com/stigmer/stigmer/sdk/go/skillref"
@@ -190,3 +194,213 @@ func TestAgentWithSubAgentUsingToolSelections(t *testing.T) {
 		t.Error("ToolSelections() missing 'github' key")
 	}
 }
+
+func TestAgentWithSubAgentLocalMCPServers(t *testing.T) {
+	ctx := &mockSubAgentCtx{}
+
+	filesystem, err := mcpserver.Stdio(ctx, "filesystem", &mcpserver.StdioArgs{
+		Command: "npx",
+		Args:    []string{"-y", "@modelcontextprotocol/server-filesystem"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create MCP server: %v", err)
+	}
+	github, err := mcpserver.Stdio(ctx, "github", &mcpserver.StdioArgs{
+		Command: "npx",
+		Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create MCP server: %v", err)
+	}
+
+	scanner := mustSubAgent("scanner", &subagent.Args{
+		Instructions:         "Scan repositories for leaked secrets",
+		McpServers:           []string{"filesystem"},
+		McpServerDefinitions: []mcpserver.MCPServer{github},
+	})
+
+	agent, err := New(nil, "main-agent", &AgentArgs{
+		Instructions: "Main agent with a self-contained sub-agent",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	agent.AddMCPServer(filesystem)
+	agent.AddSubAgent(scanner)
+
+	proto, err := agent.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+
+	sub := proto.Spec.SubAgents[0]
+	if len(sub.McpServers) != 1 || sub.McpServers[0] != "filesystem" {
+		t.Errorf("McpServers = %v, want [filesystem]", sub.McpServers)
+	}
+	if len(sub.McpServerDefinitions) != 1 {
+		t.Fatalf("len(McpServerDefinitions) = %d, want 1", len(sub.McpServerDefinitions))
+	}
+	def := sub.McpServerDefinitions[0]
+	if def.Name != "github" {
+		t.Errorf("McpServerDefinitions[0].Name = %q, want %q", def.Name, "github")
+	}
+	if def.GetStdio().GetCommand() != "npx" {
+		t.Errorf("McpServerDefinitions[0].Stdio.Command = %q, want %q", def.GetStdio().GetCommand(), "npx")
+	}
+}
+
+func TestAgentWithSubAgentMCPServerNameConflict(t *testing.T) {
+	ctx := &mockSubAgentCtx{}
+
+	github, err := mcpserver.Stdio(ctx, "github", &mcpserver.StdioArgs{
+		Command: "npx",
+		Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create MCP server: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		sub  subagent.SubAgent
+	}{
+		{
+			name: "local collides with referenced parent server",
+			sub: mustSubAgent("helper", &subagent.Args{
+				Instructions: "Helper instructions",
+				McpServers:   []string{"github"},
+			}).WithMCPServers(github),
+		},
+		{
+			name: "local definition in args collides with referenced parent server",
+			sub: mustSubAgent("helper", &subagent.Args{
+				Instructions:         "Helper instructions",
+				McpServers:           []string{"github"},
+				McpServerDefinitions: []mcpserver.MCPServer{github},
+			}),
+		},
+		{
+			name: "duplicate local servers",
+			sub: mustSubAgent("helper", &subagent.Args{
+				Instructions: "Helper instructions",
+			}).WithMCPServers(github, github),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent, err := New(nil, "main-agent", &AgentArgs{
+				Instructions: "Main agent instructions",
+			})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			agent.AddSubAgent(tt.sub)
+
+			_, err = agent.ToProto()
+			if !errors.Is(err, ErrMCPServerNameConflict) {
+				t.Errorf("ToProto() error = %v, want ErrMCPServerNameConflict", err)
+			}
+		})
+	}
+}
+
+func TestAgentWithReferencedSubAgent(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+
+	agent, err := New(nil, "main-agent", &AgentArgs{
+		Instructions: "Main agent delegating to deployed instances",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	agent.AddSubAgents(
+		subagent.Reference("security-checker", "sec-checker-prod", "stable"),
+		subagent.NewReference("auditor", &subagent.ReferenceArgs{
+			Org:     "security-team",
+			Name:    "auditor-prod",
+			Version: hash,
+		}),
+	)
+
+	proto, err := agent.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+
+	checker := proto.Spec.SubAgents[0]
+	if checker.Name != "security-checker" || checker.Instructions != "" {
+		t.Errorf("SubAgents[0] = %v, want a reference without inline instructions", checker)
+	}
+	ref := checker.GetAgentInstanceRef()
+	if ref.GetSlug() != "sec-checker-prod" || ref.GetVersion() != "stable" {
+		t.Errorf("AgentInstanceRef = %v, want sec-checker-prod@stable", ref)
+	}
+	if ref.GetKind() != apiresourcekind.ApiResourceKind_agent_instance {
+		t.Errorf("AgentInstanceRef.Kind = %v, want agent_instance", ref.GetKind())
+	}
+
+	auditor := proto.Spec.SubAgents[1].GetAgentInstanceRef()
+	if auditor.GetOrg() != "security-team" || auditor.GetScope() != apiresource.ApiResourceOwnerScope_organization {
+		t.Errorf("AgentInstanceRef = %v, want organization security-team", auditor)
+	}
+	if auditor.GetVersion() != hash {
+		t.Errorf("AgentInstanceRef.Version = %q, want %q", auditor.GetVersion(), hash)
+	}
+}
+
+func TestAgentWithInvalidSubAgentReference(t *testing.T) {
+	github, err := mcpserver.Stdio(&mockSubAgentCtx{}, "github", &mcpserver.StdioArgs{Command: "npx"})
+	if err != nil {
+		t.Fatalf("Failed to create MCP server: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		sub  subagent.SubAgent
+	}{
+		{
+			name: "missing instance name",
+			sub:  subagent.NewReference("checker", &subagent.ReferenceArgs{Version: "stable"}),
+		},
+		{
+			name: "malformed version",
+			sub:  subagent.Reference("checker", "sec-checker-prod", "v1 final"),
+		},
+		{
+			name: "local MCP servers",
+			sub:  subagent.Reference("checker", "sec-checker-prod").WithMCPServers(github),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent, err := New(nil, "main-agent", &AgentArgs{
+				Instructions: "Main agent instructions",
+			})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			agent.AddSubAgent(tt.sub)
+
+			_, err = agent.ToProto()
+			if !errors.Is(err, ErrInvalidSubAgentRef) {
+				t.Errorf("ToProto() error = %v, want ErrInvalidSubAgentRef", err)
+			}
+		})
+	}
+}
+
+func TestAgentWithInlineSubAgentRequiresInstructions(t *testing.T) {
+	agent, err := New(nil, "main-agent", &AgentArgs{
+		Instructions: "Main agent instructions",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	agent.AddSubAgent(mustSubAgent("helper", &subagent.Args{Instructions: "short"}))
+
+	if _, err := agent.ToProto(); err == nil {
+		t.Error("ToProto() error = nil, want instructions validation error")
+	}
+}
@@ -3,20 +3,27 @@ package agent
 import (
 	"fmt"
 	"time"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/provenance"
 )
 
 const (
 	// SDKLanguage is the programming language used for this agent definition
 	SDKLanguage = "go"
 
-	// SDKVersion is the version of the Go SDK
-	// TODO: Read from version file or embed during build
+	// SDKVersion is the version of the Go SDK reported when the program was
+	// not built against a released SDK module (see SDKAnnotations)
 	SDKVersion = "0.1.0"
 
 	// Annotation keys for SDK metadata
-	AnnotationSDKLanguage   = "stigmer.ai/sdk.language"
-	AnnotationSDKVersion    = "stigmer.ai/sdk.version"
+	AnnotationSDKLanguage    = "stigmer.ai/sdk.language"
+	AnnotationSDKVersion     = "stigmer.ai/sdk.version"
 	AnnotationSDKGeneratedAt = "stigmer.ai/sdk.generated-at"
+	AnnotationSDKGoVersion   = "stigmer.ai/sdk.go-version"
+
+	// AnnotationSourceRevision records the revision of the code that defined
+	// the resource, as given to stigmer.WithSourceRevision
+	AnnotationSourceRevision = "stigmer.ai/source.revision"
 )
 
 // SDKAnnotations returns a map of SDK metadata annotations to be added to resource metadata.
@@ -24,18 +31,25 @@ const (
 // These annotations track that the resource was created by the Go SDK and when.
 // The CLI and platform use these annotations for telemetry and debugging.
 //
+// The SDK version is read from the build info of the running program, so it
+// is the released module version the definition was built against; local
+// builds report SDKVersion. Provenance annotations change on every synthesis
+// and are not part of the resource definition.
+//
 // Returns:
 //
 //	map[string]string{
 //	    "stigmer.ai/sdk.language":    "go",
-//	    "stigmer.ai/sdk.version":     "0.1.0",
+//	    "stigmer.ai/sdk.version":     "v0.4.2",
 //	    "stigmer.ai/sdk.generated-at": "1706789123",  // Unix timestamp
+//	    "stigmer.ai/sdk.go-version":  "go1.24.0",
 //	}
 func SDKAnnotations() map[string]string {
 	return map[string]string{
-		AnnotationSDKLanguage:   SDKLanguage,
-		AnnotationSDKVersion:    SDKVersion,
+		AnnotationSDKLanguage:    SDKLanguage,
+		AnnotationSDKVersion:     provenance.SDKVersion(SDKVersion),
 		AnnotationSDKGeneratedAt: fmt.Sprintf("%d", time.Now().Unix()),
+		AnnotationSDKGoVersion:   provenance.GoVersion(),
 	}
 }
 
@@ -0,0 +1,127 @@
+package agent
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// Agents composed from shared helpers often add the same skill, MCP server or
+// sub-agent more than once. Identical entries are kept once; entries with the
+// same identity but different content fail synthesis rather than one of them
+// being picked.
+
+// appendSkillRef appends ref to the agent's skill references unless an
+// identical reference is already present. Callers hold a.mu.
+func (a *Agent) appendSkillRef(ref *apiresource.ApiResourceReference, orgSkill bool) {
+	if ref != nil {
+		for _, existing := range a.SkillRefs {
+			if a.orgSkillRefs[existing] == orgSkill && proto.Equal(existing, ref) {
+				return
+			}
+		}
+	}
+	a.SkillRefs = append(a.SkillRefs, ref)
+	if orgSkill {
+		if a.orgSkillRefs == nil {
+			a.orgSkillRefs = make(map[*apiresource.ApiResourceReference]bool)
+		}
+		a.orgSkillRefs[ref] = true
+	}
+}
+
+// skillVersion returns the version a reference resolves to; empty means latest.
+func skillVersion(ref *apiresource.ApiResourceReference) string {
+	if ref.GetVersion() == "" {
+		return "latest"
+	}
+	return ref.GetVersion()
+}
+
+// dedupSkillRefs drops repeated references to the same skill version and
+// reports references to the same skill with different versions. refs are
+// resolved references, so organization skills compare by their org.
+func dedupSkillRefs(refs []*apiresource.ApiResourceReference, path ...interface{}) ([]*apiresource.ApiResourceReference, error) {
+	type skillKey struct {
+		scope apiresource.ApiResourceOwnerScope
+		org   string
+		slug  string
+	}
+
+	seen := make(map[skillKey]*apiresource.ApiResourceReference, len(refs))
+	result := make([]*apiresource.ApiResourceReference, 0, len(refs))
+	for i, ref := range refs {
+		key := skillKey{scope: ref.GetScope(), org: ref.GetOrg(), slug: ref.GetSlug()}
+		first, ok := seen[key]
+		if !ok {
+			seen[key] = ref
+			result = append(result, ref)
+			continue
+		}
+		if skillVersion(first) != skillVersion(ref) {
+			return nil, NewValidationErrorWithCause(
+				validation.FieldPath(append(path, i, "version")...),
+				ref.GetVersion(),
+				"unique",
+				fmt.Sprintf("skill %q is referenced with conflicting versions %q and %q",
+					ref.GetSlug(), skillVersion(first), skillVersion(ref)),
+				ErrConflictingSkillRef,
+			)
+		}
+	}
+	return result, nil
+}
+
+// dedupMCPServers drops repeated identical MCP server definitions and reports
+// different definitions sharing a name.
+func dedupMCPServers(servers []*agentv1.McpServerDefinition) ([]*agentv1.McpServerDefinition, error) {
+	seen := make(map[string]*agentv1.McpServerDefinition, len(servers))
+	result := make([]*agentv1.McpServerDefinition, 0, len(servers))
+	for i, server := range servers {
+		first, ok := seen[server.GetName()]
+		if !ok {
+			seen[server.GetName()] = server
+			result = append(result, server)
+			continue
+		}
+		if !proto.Equal(first, server) {
+			return nil, NewValidationErrorWithCause(
+				validation.FieldPath("spec", "mcp_servers", i),
+				server.GetName(),
+				"unique",
+				fmt.Sprintf("MCP server %q is added more than once with different configurations", server.GetName()),
+				ErrMCPServerNameConflict,
+			)
+		}
+	}
+	return result, nil
+}
+
+// dedupSubAgents drops repeated identical sub-agents and reports different
+// sub-agents sharing a name.
+func dedupSubAgents(subAgents []*agentv1.SubAgent) ([]*agentv1.SubAgent, error) {
+	seen := make(map[string]*agentv1.SubAgent, len(subAgents))
+	result := make([]*agentv1.SubAgent, 0, len(subAgents))
+	for i, sa := range subAgents {
+		first, ok := seen[sa.GetName()]
+		if !ok {
+			seen[sa.GetName()] = sa
+			result = append(result, sa)
+			continue
+		}
+		if !proto.Equal(first, sa) {
+			return nil, NewValidationErrorWithCause(
+				validation.FieldPath("spec", "sub_agents", i),
+				sa.GetName(),
+				"unique",
+				fmt.Sprintf("sub-agent %q is added more than once with different configurations", sa.GetName()),
+				ErrSubAgentNameConflict,
+			)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package agent
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/mcpserver"
+	"github.com/stigmer/stigmer/sdk/go/skillref"
+	"github.com/stigmer/stigmer/sdk/go/subagent"
+)
+
+func newDedupTestAgent(t *testing.T) *Agent {
+	t.Helper()
+	ag, err := New(nil, "main-agent", &AgentArgs{
+		Instructions: "Main agent instructions",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return ag
+}
+
+func TestAgentSkillRefs_DeduplicatesIdenticalReferences(t *testing.T) {
+	ag := newDedupTestAgent(t)
+	ag.Org = "acme"
+
+	ag.AddSkillRef(skillref.Platform("code-review"))
+	ag.AddSkillRefs(skillref.Platform("code-review"), skillref.Platform("security"))
+	ag.OrgSkill("internal-docs", "v2.0")
+	ag.OrgSkill("internal-docs", "v2.0")
+	// Resolves to the same reference as the OrgSkill above
+	ag.AddSkillRef(skillref.Organization("acme", "internal-docs", "v2.0"))
+
+	if len(ag.SkillRefs) != 4 {
+		t.Errorf("len(SkillRefs) = %d, want 4", len(ag.SkillRefs))
+	}
+
+	pb, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	var slugs []string
+	for _, ref := range pb.GetSpec().GetSkillRefs() {
+		slugs = append(slugs, ref.GetSlug())
+	}
+	if got := strings.Join(slugs, ","); got != "code-review,security,internal-docs" {
+		t.Errorf("skill refs = %s, want code-review,security,internal-docs", got)
+	}
+}
+
+func TestAgentSkillRefs_LatestMatchesUnversioned(t *testing.T) {
+	ag := newDedupTestAgent(t)
+	ag.AddSkillRefs(skillref.Platform("code-review"), skillref.Platform("code-review", "latest"))
+
+	pb, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if n := len(pb.GetSpec().GetSkillRefs()); n != 1 {
+		t.Errorf("len(skill_refs) = %d, want 1", n)
+	}
+}
+
+func TestAgentSkillRefs_ConflictingVersions(t *testing.T) {
+	ag := newDedupTestAgent(t)
+	ag.AddSkillRefs(skillref.Platform("code-review"), skillref.Platform("code-review", "v1.0"))
+
+	_, err := ag.ToProto()
+	if !errors.Is(err, ErrConflictingSkillRef) {
+		t.Fatalf("ToProto() error = %v, want ErrConflictingSkillRef", err)
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("ToProto() error = %T, want *ValidationError", err)
+	}
+	want := `skill "code-review" is referenced with conflicting versions "latest" and "v1.0"`
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("ToProto() error = %q, want it to contain %q", err, want)
+	}
+}
+
+func TestAgentMCPServers_Deduplicated(t *testing.T) {
+	ctx := &mockSubAgentCtx{}
+	github, err := mcpserver.Stdio(ctx, "github", &mcpserver.StdioArgs{Command: "npx"})
+	if err != nil {
+		t.Fatalf("Stdio() error = %v", err)
+	}
+	sameGithub, err := mcpserver.Stdio(ctx, "github", &mcpserver.StdioArgs{Command: "npx"})
+	if err != nil {
+		t.Fatalf("Stdio() error = %v", err)
+	}
+	otherGithub, err := mcpserver.Stdio(ctx, "github", &mcpserver.StdioArgs{Command: "docker"})
+	if err != nil {
+		t.Fatalf("Stdio() error = %v", err)
+	}
+
+	ag := newDedupTestAgent(t)
+	ag.AddMCPServers(github, sameGithub)
+	pb, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if n := len(pb.GetSpec().GetMcpServers()); n != 1 {
+		t.Errorf("len(mcp_servers) = %d, want 1", n)
+	}
+
+	ag = newDedupTestAgent(t)
+	ag.AddMCPServers(github, otherGithub)
+	if _, err := ag.ToProto(); !errors.Is(err, ErrMCPServerNameConflict) {
+		t.Errorf("ToProto() error = %v, want ErrMCPServerNameConflict", err)
+	}
+}
+
+func TestAgentSubAgents_Deduplicated(t *testing.T) {
+	helper := mustSubAgent("helper", &subagent.Args{Instructions: "Helper instructions"})
+
+	ag := newDedupTestAgent(t)
+	ag.AddSubAgents(helper, mustSubAgent("helper", &subagent.Args{Instructions: "Helper instructions"}))
+	pb, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if n := len(pb.GetSpec().GetSubAgents()); n != 1 {
+		t.Errorf("len(sub_agents) = %d, want 1", n)
+	}
+
+	ag = newDedupTestAgent(t)
+	ag.AddSubAgents(helper, mustSubAgent("helper", &subagent.Args{Instructions: "Other helper instructions"}))
+	if _, err := ag.ToProto(); !errors.Is(err, ErrSubAgentNameConflict) {
+		t.Errorf("ToProto() error = %v, want ErrSubAgentNameConflict", err)
+	}
+}
@@ -58,7 +58,8 @@
 // Agents are created with struct-based args and configured with builder methods:
 //
 // Constructor Args (AgentArgs):
-//   - Instructions: Agent behavior definition (required, 10-10,000 chars)
+//   - Instructions: Agent behavior definition (required, 10-10,000 chars),
+//     or loaded with WithInstructionsFromFile / WithInstructionsFromFS
 //   - Description: Human-readable description (optional, max 500 chars)
 //   - IconUrl: Display icon URL (optional)
 //
@@ -71,6 +72,11 @@
 //   - AddSubAgents: Add multiple sub-agents
 //   - AddEnvironmentVariable: Add an environment variable
 //   - AddEnvironmentVariables: Add multiple environment variables
+//   - WithMemory: Set conversation memory (MemoryNone, MemoryWindow, MemorySummarizing)
+//   - WithToolPolicy: Deny tools or require approval across MCP servers (DenyTools, ConfirmTools)
+//   - WithLimits: Bound the tokens, tool calls and duration of each execution (MaxTokensPerRun, MaxToolCalls, MaxDuration)
+//   - WithOutputSchema / WithOutputSchemaFile: Declare a structured JSON response
+//   - WithLocalizedDescription: Description for a BCP-47 language, falling back to Description
 //
 // # Error Handling
 //
@@ -331,6 +331,36 @@ func TestAgent_ConcurrentSkillAddition(t *testing.T) {
 	t.Logf("Skills added concurrently: %d (expected 50)", skillCount)
 }
 
+// TestAgent_ConcurrentIconURL tests reading expression-valued fields while the
+// icon URL is being set.
+func TestAgent_ConcurrentIconURL(t *testing.T) {
+	agent, _ := New(nil, "concurrent-icon", &AgentArgs{
+		Instructions: "Testing concurrent icon URL updates",
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(idx int) {
+			defer wg.Done()
+			iconURL := fmt.Sprintf("https://cdn-${.env_vars.ENV}.example.com/icon%d.png", idx)
+			if err := agent.WithIconURL(iconURL); err != nil {
+				t.Errorf("WithIconURL() failed: %v", err)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			agent.IsExpressionValued("icon_url")
+		}()
+	}
+
+	wg.Wait()
+
+	if !agent.IsExpressionValued("icon_url") {
+		t.Error("icon URL should be recorded as expression-valued")
+	}
+}
+
 // =============================================================================
 // Edge Case Tests - Complex Nested Structures
 // =============================================================================
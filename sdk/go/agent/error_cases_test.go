@@ -2,12 +2,16 @@ package agent
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	"github.com/stigmer/stigmer/sdk/go/environment"
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"github.com/stigmer/stigmer/sdk/go/mcpserver"
 	"github.com/stigmer/stigmer/sdk/go/skillref"
+	"github.com/stigmer/stigmer/sdk/go/subagent"
 )
 
 // mockEnvCtx implements the environment.Context interface for testing
@@ -478,3 +482,118 @@ func TestValidationError_Unwrap_Detailed(t *testing.T) {
 		t.Error("Error should be of type ValidationError")
 	}
 }
+
+// TestAgentToProto_ConversionErrorPaths tests that conversion errors name the
+// offending entry by its index.
+func TestAgentToProto_ConversionErrorPaths(t *testing.T) {
+	newAgent := func(t *testing.T) *Agent {
+		t.Helper()
+		a, err := New(nil, "path-agent", &AgentArgs{
+			Instructions: "Agent for testing conversion error paths",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create agent: %v", err)
+		}
+		return a
+	}
+	docker := func(t *testing.T, name string, volumes []*types.VolumeMount) mcpserver.MCPServer {
+		t.Helper()
+		server, err := mcpserver.Docker(nil, name, &mcpserver.DockerArgs{
+			Image:   "ghcr.io/org/mcp:latest",
+			Volumes: volumes,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create Docker server: %v", err)
+		}
+		return server
+	}
+
+	tests := []struct {
+		name      string
+		build     func(t *testing.T) *Agent
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name: "nil skill reference",
+			build: func(t *testing.T) *Agent {
+				a := newAgent(t)
+				for i := 0; i < 7; i++ {
+					a.AddSkillRef(skillref.Platform(fmt.Sprintf("skill-%d", i)))
+				}
+				a.AddSkillRef(nil)
+				return a
+			},
+			wantField: "spec.skill_refs[7]",
+			wantMsg:   "nil skill reference",
+		},
+		{
+			name: "nil MCP server",
+			build: func(t *testing.T) *Agent {
+				a := newAgent(t)
+				a.AddMCPServer(docker(t, "first", nil))
+				a.AddMCPServer(nil)
+				return a
+			},
+			wantField: "spec.mcp_servers[1]",
+			wantMsg:   "nil MCP server",
+		},
+		{
+			name: "missing volume host path",
+			build: func(t *testing.T) *Agent {
+				a := newAgent(t)
+				a.AddMCPServer(docker(t, "first", nil))
+				a.AddMCPServer(docker(t, "second", nil))
+				a.AddMCPServer(docker(t, "third", []*types.VolumeMount{{ContainerPath: "/data"}}))
+				return a
+			},
+			wantField: "spec.mcp_servers[2].docker.volumes[0].host_path",
+			wantMsg:   "value is required",
+		},
+		{
+			name: "nil volume mount",
+			build: func(t *testing.T) *Agent {
+				a := newAgent(t)
+				a.AddMCPServer(docker(t, "first", []*types.VolumeMount{
+					{HostPath: "/host", ContainerPath: "/data"},
+					nil,
+				}))
+				return a
+			},
+			wantField: "spec.mcp_servers[0].docker.volumes[1]",
+			wantMsg:   "nil volume mount",
+		},
+		{
+			name: "nil sub-agent MCP server",
+			build: func(t *testing.T) *Agent {
+				a := newAgent(t)
+				first, _ := subagent.New("first", &subagent.Args{Instructions: "First sub-agent"})
+				second, _ := subagent.New("second", &subagent.Args{Instructions: "Second sub-agent"})
+				a.AddSubAgents(first, second.WithMCPServers(docker(t, "local", nil), mcpserver.MCPServer(nil)))
+				return a
+			},
+			wantField: "spec.sub_agents[1].mcp_server_definitions[1]",
+			wantMsg:   "nil MCP server",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.build(t).ToProto()
+			if err == nil {
+				t.Fatal("ToProto() succeeded, want conversion error")
+			}
+
+			var convErr *ConversionError
+			if !errors.As(err, &convErr) {
+				t.Fatalf("ToProto() error = %v, want ConversionError", err)
+			}
+			if convErr.Type != "Agent" || convErr.Field != tt.wantField {
+				t.Errorf("ConversionError = %s.%s, want Agent.%s", convErr.Type, convErr.Field, tt.wantField)
+			}
+			if !strings.Contains(convErr.Message, tt.wantMsg) {
+				t.Errorf("ConversionError.Message = %q, want %q", convErr.Message, tt.wantMsg)
+			}
+		})
+	}
+}
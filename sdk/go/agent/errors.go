@@ -20,6 +20,40 @@ var (
 	// ErrInvalidIconURL is returned when the icon URL is invalid.
 	ErrInvalidIconURL = errors.New("invalid icon URL")
 
+	// ErrInvalidMemory is returned when a memory configuration is invalid.
+	ErrInvalidMemory = errors.New("invalid memory configuration")
+
+	// ErrInvalidToolPolicy is returned when a tool policy pattern is invalid.
+	ErrInvalidToolPolicy = errors.New("invalid tool policy")
+
+	// ErrInvalidLimits is returned when an execution limit is not positive.
+	ErrInvalidLimits = errors.New("invalid agent limits")
+
+	// ErrInvalidOutputSchema is returned when an output schema is invalid.
+	ErrInvalidOutputSchema = errors.New("invalid output schema")
+
+	// ErrMCPServerNameConflict is returned when an agent has different MCP
+	// servers with the same name, or a sub-agent defines a local MCP server
+	// whose name collides with another server visible to the sub-agent.
+	ErrMCPServerNameConflict = errors.New("MCP server name conflict")
+
+	// ErrInvalidSubAgentRef is returned when a sub-agent references an
+	// AgentInstance without a name, with a malformed version, or together with
+	// inline configuration.
+	ErrInvalidSubAgentRef = errors.New("invalid sub-agent reference")
+
+	// ErrSubAgentNameConflict is returned when an agent has different
+	// sub-agents with the same name.
+	ErrSubAgentNameConflict = errors.New("sub-agent name conflict")
+
+	// ErrConflictingSkillRef is returned when an agent references the same
+	// skill with different versions.
+	ErrConflictingSkillRef = errors.New("conflicting skill references")
+
+	// ErrMissingOrg is returned when an agent references an organization skill
+	// with OrgSkill but has no org.
+	ErrMissingOrg = errors.New("agent has no organization")
+
 	// ErrMissingRequiredField is returned when a required field is missing.
 	ErrMissingRequiredField = errors.New("missing required field")
 
@@ -0,0 +1,135 @@
+package agent
+
+import (
+	"io/fs"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/textfile"
+)
+
+// maxInstructionsChars is the maximum length of instructions loaded from a file.
+const maxInstructionsChars = 10000
+
+// WithInstructionsFromFile sets the agent's instructions to the content of a
+// file on disk; use WithInstructionsFromFS for content compiled into the
+// synthesis program.
+//
+// Relative paths resolve against the directory of the Go file calling
+// WithInstructionsFromFile, then against the working directory, so the
+// program finds the file wherever it is run from. WithBaseDir, or
+// stigmer.WithWorkDir for all agents of a run, resolves them against another
+// directory instead.
+//
+// New fails with ErrInvalidInstructions if the file cannot be found (the
+// error lists every path tried), cannot be read, is empty, is not UTF-8 text
+// or exceeds 10000 characters. The instructions replace
+// AgentArgs.Instructions.
+//
+// Example:
+//
+//	ag, err := agent.New(ctx, "code-reviewer", nil,
+//	    agent.WithInstructionsFromFile("instructions/code-reviewer.md"))
+func WithInstructionsFromFile(path string) AgentOption {
+	callerDir := textfile.CallerDir(1)
+	return func(a *Agent) {
+		a.instructionsFile = &instructionsFile{path: path, callerDir: callerDir}
+	}
+}
+
+// WithBaseDir sets the directory relative paths given to
+// WithInstructionsFromFile resolve against, instead of the directory of the
+// calling Go file and the working directory. It overrides
+// stigmer.WithWorkDir for the agent, and may come before or after the
+// option loading the file.
+//
+// Example:
+//
+//	ag, err := agent.New(ctx, "code-reviewer", nil,
+//	    agent.WithBaseDir(os.Getenv("AGENTS_DIR")),
+//	    agent.WithInstructionsFromFile("code-reviewer.md"))
+func WithBaseDir(dir string) AgentOption {
+	return func(a *Agent) {
+		a.baseDir = dir
+	}
+}
+
+// WithInstructionsFromFS sets the agent's instructions to the content of a
+// file in fsys, typically an embed.FS, so the synthesis program does not
+// depend on its working directory. The path is slash-separated and relative
+// to the root of fsys.
+//
+// The content is checked like with WithInstructionsFromFile.
+//
+// Example:
+//
+//	//go:embed instructions
+//	var content embed.FS
+//
+//	ag, err := agent.New(ctx, "code-reviewer", nil,
+//	    agent.WithInstructionsFromFS(content, "instructions/code-reviewer.md"))
+func WithInstructionsFromFS(fsys fs.FS, path string) AgentOption {
+	return func(a *Agent) {
+		if fsys == nil {
+			a.optionErr = NewValidationErrorWithCause("instructions", path, "file",
+				"WithInstructionsFromFS requires a non-nil fs.FS", ErrInvalidInstructions)
+			return
+		}
+		a.instructionsFile = nil
+		a.loadInstructions(fsys, path)
+	}
+}
+
+// instructionsFile is a file passed to WithInstructionsFromFile, loaded by New
+// once every option is applied.
+type instructionsFile struct {
+	path string
+
+	// callerDir is the directory of the Go file that called
+	// WithInstructionsFromFile ("" if unknown).
+	callerDir string
+}
+
+// workDirProvider is implemented by contexts with a directory for relative
+// file paths, such as stigmer.Context with stigmer.WithWorkDir.
+type workDirProvider interface {
+	WorkDir() string
+}
+
+// loadInstructionsFile loads the file passed to WithInstructionsFromFile, if
+// any, recording a failure for New to return.
+func (a *Agent) loadInstructionsFile() {
+	if a.instructionsFile == nil {
+		return
+	}
+
+	var dirs []string
+	if a.baseDir != "" {
+		dirs = []string{a.baseDir}
+	} else if wd, ok := a.ctx.(workDirProvider); ok && wd.WorkDir() != "" {
+		dirs = []string{wd.WorkDir()}
+	} else {
+		dirs = []string{a.instructionsFile.callerDir, ""}
+	}
+
+	path, err := textfile.Resolve(a.instructionsFile.path, dirs...)
+	if err != nil {
+		a.optionErr = NewValidationErrorWithCause("instructions", a.instructionsFile.path, "file",
+			"failed to load instructions: "+err.Error(), ErrInvalidInstructions)
+		return
+	}
+	a.loadInstructions(nil, path)
+}
+
+// loadInstructions reads the instructions from fsys (or disk when nil),
+// recording a failure for New to return.
+func (a *Agent) loadInstructions(fsys fs.FS, path string) {
+	content, err := textfile.Read(fsys, path, maxInstructionsChars)
+	if err != nil {
+		a.optionErr = NewValidationErrorWithCause("instructions", path, "file",
+			"failed to load instructions: "+err.Error(), ErrInvalidInstructions)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.Instructions = content
+}
@@ -0,0 +1,156 @@
+package agent
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const testInstructions = "Review code for correctness, security and style."
+
+func TestWithInstructionsFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"instructions/code-reviewer.md": {Data: []byte(testInstructions)},
+	}
+
+	ag, err := New(nil, "code-reviewer", &AgentArgs{Instructions: "replaced by the file"},
+		WithInstructionsFromFS(fsys, "instructions/code-reviewer.md"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if ag.Instructions != testInstructions {
+		t.Errorf("Instructions = %q, want the file content", ag.Instructions)
+	}
+
+	manifest, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if manifest.Spec.Instructions != testInstructions {
+		t.Errorf("Spec.Instructions = %q, want the file content", manifest.Spec.Instructions)
+	}
+}
+
+func TestWithInstructionsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "code-reviewer.md")
+	if err := os.WriteFile(path, []byte(testInstructions), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ag, err := New(nil, "code-reviewer", nil, WithInstructionsFromFile(path))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if ag.Instructions != testInstructions {
+		t.Errorf("Instructions = %q, want the file content", ag.Instructions)
+	}
+}
+
+func TestWithInstructionsFromFile_ResolvesAgainstCallerDir(t *testing.T) {
+	pkgDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(t.TempDir())
+	workDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Relative paths resolve against this file's directory first
+	ag, err := New(nil, "code-reviewer", nil, WithInstructionsFromFile("testdata/instructions/code-reviewer.md"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if ag.Instructions != testInstructions+"\n" {
+		t.Errorf("Instructions = %q, want the file content", ag.Instructions)
+	}
+
+	// Then against the working directory
+	if err := os.WriteFile("local.md", []byte(testInstructions), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(nil, "code-reviewer", nil, WithInstructionsFromFile("local.md")); err != nil {
+		t.Errorf("New() with a file in the working directory error = %v", err)
+	}
+
+	// The error lists every path tried
+	_, err = New(nil, "code-reviewer", nil, WithInstructionsFromFile("instructions/missing.md"))
+	if !errors.Is(err, ErrInvalidInstructions) {
+		t.Fatalf("New() error = %v, want ErrInvalidInstructions", err)
+	}
+	for _, want := range []string{
+		filepath.Join(pkgDir, "instructions", "missing.md"),
+		filepath.Join(workDir, "instructions", "missing.md"),
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("New() error = %v, want it to list %s", err, want)
+		}
+	}
+}
+
+func TestWithBaseDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "code-reviewer.md"), []byte(testInstructions), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The base directory applies whatever the option order
+	ag, err := New(nil, "code-reviewer", nil,
+		WithInstructionsFromFile("code-reviewer.md"),
+		WithBaseDir(dir))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if ag.Instructions != testInstructions {
+		t.Errorf("Instructions = %q, want the file content", ag.Instructions)
+	}
+
+	// It replaces the caller's directory
+	_, err = New(nil, "code-reviewer", nil,
+		WithBaseDir(dir),
+		WithInstructionsFromFile("testdata/instructions/code-reviewer.md"))
+	if !errors.Is(err, ErrInvalidInstructions) {
+		t.Fatalf("New() error = %v, want ErrInvalidInstructions", err)
+	}
+	if want := filepath.Join(dir, "testdata", "instructions", "code-reviewer.md"); !strings.Contains(err.Error(), want) {
+		t.Errorf("New() error = %v, want it to list %s", err, want)
+	}
+}
+
+func TestWithInstructionsFrom_Errors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"empty.md":  {Data: []byte("\n\n")},
+		"binary.md": {Data: []byte{0xff, 0xfe, 0x00}},
+		"long.md":   {Data: []byte(strings.Repeat("x", maxInstructionsChars+1))},
+	}
+
+	tests := []struct {
+		name    string
+		opt     AgentOption
+		wantMsg string
+	}{
+		{name: "missing fs path", opt: WithInstructionsFromFS(fsys, "missing.md"), wantMsg: `fs.FS path "missing.md"`},
+		{name: "missing disk path", opt: WithInstructionsFromFile("missing.md"), wantMsg: `disk path "missing.md"`},
+		{name: "invalid fs path", opt: WithInstructionsFromFS(fsys, "../outside.md"), wantMsg: "relative"},
+		{name: "nil fs", opt: WithInstructionsFromFS(nil, "empty.md"), wantMsg: "non-nil fs.FS"},
+		{name: "empty", opt: WithInstructionsFromFS(fsys, "empty.md"), wantMsg: "empty"},
+		{name: "binary", opt: WithInstructionsFromFS(fsys, "binary.md"), wantMsg: "not UTF-8 text"},
+		{name: "too long", opt: WithInstructionsFromFS(fsys, "long.md"), wantMsg: "more than the maximum of 10000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(nil, "code-reviewer", nil, tt.opt)
+			if !errors.Is(err, ErrInvalidInstructions) {
+				t.Fatalf("New() error = %v, want ErrInvalidInstructions", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("New() error = %v, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package agent
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+)
+
+// Limits bounds the resources each execution of an agent may consume. Zero
+// fields are unlimited.
+//
+// An execution exceeding a limit is stopped and ends with phase
+// EXECUTION_LIMIT_EXCEEDED, with the exceeded limit named in its error.
+// Workflows calling the agent see the CallAgent task fail with error type
+// "AgentLimitExceeded" and classification LIMIT_EXCEEDED.
+//
+// Use WithLimits to set them.
+type Limits struct {
+	// MaxTokensPerRun is the maximum number of model tokens, input and
+	// output across all model calls, per execution.
+	MaxTokensPerRun int
+
+	// MaxToolCalls is the maximum number of tool calls per execution.
+	MaxToolCalls int
+
+	// MaxDuration is the maximum wall-clock duration of an execution,
+	// rounded up to whole seconds.
+	MaxDuration time.Duration
+}
+
+// Limit sets one field of an agent's Limits.
+type Limit func(*Limits) error
+
+// MaxTokensPerRun limits the model tokens (input and output, across all model
+// calls) each execution may use. n must be positive.
+//
+// Example:
+//
+//	agent.MaxTokensPerRun(200000)
+func MaxTokensPerRun(n int) Limit {
+	return func(l *Limits) error {
+		if err := validateLimitCount("limits.max_tokens_per_run", n); err != nil {
+			return err
+		}
+		l.MaxTokensPerRun = n
+		return nil
+	}
+}
+
+// MaxToolCalls limits the number of tool calls each execution may make,
+// including those of sub-agents. n must be positive.
+//
+// Example:
+//
+//	agent.MaxToolCalls(50)
+func MaxToolCalls(n int) Limit {
+	return func(l *Limits) error {
+		if err := validateLimitCount("limits.max_tool_calls", n); err != nil {
+			return err
+		}
+		l.MaxToolCalls = n
+		return nil
+	}
+}
+
+// MaxDuration limits the wall-clock duration of each execution. d must be
+// positive and is rounded up to whole seconds.
+//
+// Example:
+//
+//	agent.MaxDuration(10 * time.Minute)
+func MaxDuration(d time.Duration) Limit {
+	return func(l *Limits) error {
+		if d <= 0 || math.Ceil(d.Seconds()) > math.MaxInt32 {
+			return NewValidationErrorWithCause(
+				"limits.max_duration",
+				d.String(),
+				"range",
+				fmt.Sprintf("maximum duration must be positive and at most %d seconds", math.MaxInt32),
+				ErrInvalidLimits,
+			)
+		}
+		l.MaxDuration = d
+		return nil
+	}
+}
+
+// WithLimits bounds the resources each execution of the agent may consume.
+// Limits not given stay unlimited; giving the same limit twice keeps the
+// last value.
+//
+// New fails with ErrInvalidLimits if a value is not positive.
+//
+// Example:
+//
+//	ag, err := agent.New(ctx, "code-reviewer", &agent.AgentArgs{
+//	    Instructions: "Review code and suggest improvements",
+//	}, agent.WithLimits(
+//	    agent.MaxTokensPerRun(200000),
+//	    agent.MaxToolCalls(50),
+//	    agent.MaxDuration(10*time.Minute),
+//	))
+func WithLimits(limits ...Limit) AgentOption {
+	return func(a *Agent) {
+		if err := a.SetLimits(limits...); err != nil {
+			a.optionErr = err
+		}
+	}
+}
+
+// SetLimits sets limits after creation. See WithLimits. The agent's limits
+// are unchanged if any value is invalid.
+// This method is thread-safe and can be called concurrently.
+func (a *Agent) SetLimits(limits ...Limit) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	updated := a.Limits
+	for _, limit := range limits {
+		if err := limit(&updated); err != nil {
+			return err
+		}
+	}
+	a.Limits = updated
+	return nil
+}
+
+// IsSet reports whether any limit is set.
+func (l Limits) IsSet() bool {
+	return l.MaxTokensPerRun > 0 || l.MaxToolCalls > 0 || l.MaxDuration > 0
+}
+
+// validate checks limits set directly on the struct rather than with
+// WithLimits. Zero fields are unlimited.
+func (l Limits) validate() error {
+	var limits []Limit
+	if l.MaxTokensPerRun != 0 {
+		limits = append(limits, MaxTokensPerRun(l.MaxTokensPerRun))
+	}
+	if l.MaxToolCalls != 0 {
+		limits = append(limits, MaxToolCalls(l.MaxToolCalls))
+	}
+	if l.MaxDuration != 0 {
+		limits = append(limits, MaxDuration(l.MaxDuration))
+	}
+	for _, limit := range limits {
+		if err := limit(&Limits{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toProto converts the limits to their proto form, with the maximum duration
+// rounded up to whole seconds. Returns nil when no limit is set.
+func (l Limits) toProto() *agentv1.AgentLimits {
+	if !l.IsSet() {
+		return nil
+	}
+	return &agentv1.AgentLimits{
+		MaxTokensPerRun:    int32(l.MaxTokensPerRun),
+		MaxToolCalls:       int32(l.MaxToolCalls),
+		MaxDurationSeconds: int32(math.Ceil(l.MaxDuration.Seconds())),
+	}
+}
+
+// validateLimitCount checks that a count limit is positive and fits the
+// proto field.
+func validateLimitCount(field string, n int) error {
+	if n > 0 && n <= math.MaxInt32 {
+		return nil
+	}
+	return NewValidationErrorWithCause(
+		field,
+		strconv.Itoa(n),
+		"range",
+		fmt.Sprintf("limit must be between 1 and %d", math.MaxInt32),
+		ErrInvalidLimits,
+	)
+}
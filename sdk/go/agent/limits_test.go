@@ -0,0 +1,157 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+)
+
+func TestWithLimits_ManifestRoundTrip(t *testing.T) {
+	ag, err := New(nil, "code-reviewer", &AgentArgs{
+		Instructions: "Review code and suggest improvements",
+	}, WithLimits(
+		MaxTokensPerRun(200000),
+		MaxToolCalls(50),
+		MaxDuration(10*time.Minute),
+	))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	manifest, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	want := &agentv1.AgentLimits{
+		MaxTokensPerRun:    200000,
+		MaxToolCalls:       50,
+		MaxDurationSeconds: 600,
+	}
+	if !proto.Equal(manifest.Spec.Limits, want) {
+		t.Fatalf("Spec.Limits = %v, want %v", manifest.Spec.Limits, want)
+	}
+
+	// Binary manifests, as written to STIGMER_OUT_DIR
+	data, err := proto.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+	decoded := &agentv1.Agent{}
+	if err := proto.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %v", err)
+	}
+	if !proto.Equal(decoded.Spec.Limits, want) {
+		t.Errorf("binary round trip Limits = %v, want %v", decoded.Spec.Limits, want)
+	}
+
+	// JSON manifests, as shown by the CLI
+	jsonData, err := protojson.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("protojson.Marshal() error = %v", err)
+	}
+	decoded = &agentv1.Agent{}
+	if err := protojson.Unmarshal(jsonData, decoded); err != nil {
+		t.Fatalf("protojson.Unmarshal() error = %v", err)
+	}
+	if !proto.Equal(decoded.Spec.Limits, want) {
+		t.Errorf("JSON round trip Limits = %v, want %v", decoded.Spec.Limits, want)
+	}
+}
+
+func TestWithLimits_Partial(t *testing.T) {
+	ag, err := New(nil, "code-reviewer", &AgentArgs{
+		Instructions: "Review code and suggest improvements",
+	}, WithLimits(MaxToolCalls(5), MaxDuration(1500*time.Millisecond), MaxToolCalls(20)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	manifest, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	// Unset limits stay 0, durations round up, the last value wins
+	want := &agentv1.AgentLimits{MaxToolCalls: 20, MaxDurationSeconds: 2}
+	if !proto.Equal(manifest.Spec.Limits, want) {
+		t.Errorf("Spec.Limits = %v, want %v", manifest.Spec.Limits, want)
+	}
+}
+
+func TestWithLimits_Unset(t *testing.T) {
+	ag, err := New(nil, "code-reviewer", &AgentArgs{
+		Instructions: "Review code and suggest improvements",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	manifest, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if manifest.Spec.Limits != nil {
+		t.Errorf("Spec.Limits = %v, want nil when no limit is set", manifest.Spec.Limits)
+	}
+}
+
+func TestWithLimits_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit Limit
+	}{
+		{"zero tokens", MaxTokensPerRun(0)},
+		{"negative tokens", MaxTokensPerRun(-1)},
+		{"zero tool calls", MaxToolCalls(0)},
+		{"negative tool calls", MaxToolCalls(-5)},
+		{"zero duration", MaxDuration(0)},
+		{"negative duration", MaxDuration(-time.Minute)},
+		{"duration too long", MaxDuration(100 * 365 * 24 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(nil, "code-reviewer", &AgentArgs{
+				Instructions: "Review code and suggest improvements",
+			}, WithLimits(MaxToolCalls(50), tt.limit))
+			if !errors.Is(err, ErrInvalidLimits) {
+				t.Errorf("New() error = %v, want ErrInvalidLimits", err)
+			}
+		})
+	}
+}
+
+func TestSetLimits_InvalidKeepsLimits(t *testing.T) {
+	ag, err := New(nil, "code-reviewer", &AgentArgs{
+		Instructions: "Review code and suggest improvements",
+	}, WithLimits(MaxToolCalls(50)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	err = ag.SetLimits(MaxTokensPerRun(1000), MaxToolCalls(0))
+	if !errors.Is(err, ErrInvalidLimits) {
+		t.Fatalf("SetLimits() error = %v, want ErrInvalidLimits", err)
+	}
+	if ag.Limits != (Limits{MaxToolCalls: 50}) {
+		t.Errorf("Limits = %+v after a failed SetLimits, want them unchanged", ag.Limits)
+	}
+}
+
+func TestLimits_SetDirectlyValidatedAtSynthesis(t *testing.T) {
+	ag, err := New(nil, "code-reviewer", &AgentArgs{
+		Instructions: "Review code and suggest improvements",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ag.Limits = Limits{MaxTokensPerRun: -100}
+
+	if _, err := ag.ToProto(); !errors.Is(err, ErrInvalidLimits) {
+		t.Errorf("ToProto() error = %v, want ErrInvalidLimits", err)
+	}
+}
@@ -0,0 +1,128 @@
+package agent
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+)
+
+// maxDescriptionChars is the maximum length of a description, base or
+// localized.
+const maxDescriptionChars = 500
+
+// languageTagRegex matches well-formed BCP-47 language tags with hyphen
+// separators (mirrors the AgentSpec.localizations key constraint).
+var languageTagRegex = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+// Localization holds the display texts of an agent in one language.
+type Localization struct {
+	// Description replaces the base description for the language.
+	Description string
+}
+
+// WithLocalizedDescription sets the description shown to users of language
+// lang, a BCP-47 tag such as "de" or "fr-CA". The base AgentArgs.Description
+// remains the default for languages without a localized description.
+//
+// New fails with ErrInvalidDescription if the tag is malformed or unknown, or
+// the description is empty or exceeds 500 characters. Tags are stored in
+// canonical form, so "DE-ch" and "de-CH" set the same description; setting a
+// language twice keeps the last description.
+//
+// Example:
+//
+//	ag, err := agent.New(ctx, "code-reviewer", &agent.AgentArgs{
+//	    Instructions: "Review code and suggest improvements",
+//	    Description:  "AI code reviewer",
+//	},
+//	    agent.WithLocalizedDescription("de", "KI-Code-Reviewer"),
+//	    agent.WithLocalizedDescription("fr", "Relecteur de code IA"),
+//	)
+func WithLocalizedDescription(lang, description string) AgentOption {
+	return func(a *Agent) {
+		if err := a.SetLocalizedDescription(lang, description); err != nil {
+			a.optionErr = err
+		}
+	}
+}
+
+// SetLocalizedDescription sets the description for language lang after
+// creation. See WithLocalizedDescription.
+// This method is thread-safe and can be called concurrently.
+func (a *Agent) SetLocalizedDescription(lang, description string) error {
+	tag, err := canonicalLanguageTag(lang)
+	if err != nil {
+		return err
+	}
+
+	field := fmt.Sprintf("localizations[%s].description", tag)
+	if description == "" {
+		return NewValidationErrorWithCause(field, "", "required",
+			"localized description must not be empty", ErrInvalidDescription)
+	}
+	if n := utf8.RuneCountInString(description); n > maxDescriptionChars {
+		return NewValidationErrorWithCause(field, description, "max_length",
+			fmt.Sprintf("localized description is %d characters, the maximum is %d", n, maxDescriptionChars),
+			ErrInvalidDescription)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.Localizations == nil {
+		a.Localizations = make(map[string]Localization)
+	}
+	localization := a.Localizations[tag]
+	localization.Description = description
+	a.Localizations[tag] = localization
+	return nil
+}
+
+// LocalizedDescription returns the description for language lang, falling
+// back to the base description when the language has none.
+func (a *Agent) LocalizedDescription(lang string) string {
+	tag, err := canonicalLanguageTag(lang)
+	if err != nil {
+		return a.Description
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if localization, ok := a.Localizations[tag]; ok && localization.Description != "" {
+		return localization.Description
+	}
+	return a.Description
+}
+
+// canonicalLanguageTag validates a BCP-47 language tag and returns its
+// canonical form.
+func canonicalLanguageTag(lang string) (string, error) {
+	if !languageTagRegex.MatchString(lang) {
+		return "", NewValidationErrorWithCause("localizations", lang, "language_tag",
+			fmt.Sprintf("%q is not a BCP-47 language tag (e.g. \"de\", \"fr-CA\")", lang),
+			ErrInvalidDescription)
+	}
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return "", NewValidationErrorWithCause("localizations", lang, "language_tag",
+			fmt.Sprintf("%q is not a known BCP-47 language tag: %v", lang, err),
+			ErrInvalidDescription)
+	}
+	return tag.String(), nil
+}
+
+// localizationsToProto converts the localizations to their proto form.
+// Returns nil when there are none.
+func localizationsToProto(localizations map[string]Localization) map[string]*agentv1.AgentLocalization {
+	if len(localizations) == 0 {
+		return nil
+	}
+	result := make(map[string]*agentv1.AgentLocalization, len(localizations))
+	for tag, localization := range localizations {
+		result[tag] = &agentv1.AgentLocalization{Description: localization.Description}
+	}
+	return result
+}
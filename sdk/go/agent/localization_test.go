@@ -0,0 +1,159 @@
+package agent
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+)
+
+func TestWithLocalizedDescription_ManifestRoundTrip(t *testing.T) {
+	ag, err := New(nil, "code-reviewer", &AgentArgs{
+		Instructions: "Review code and suggest improvements",
+		Description:  "AI code reviewer",
+	},
+		WithLocalizedDescription("de", "KI-Code-Reviewer"),
+		WithLocalizedDescription("fr", "Relecteur de code IA"),
+		WithLocalizedDescription("pt-br", "Revisor de código com IA"),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	manifest, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	want := map[string]*agentv1.AgentLocalization{
+		"de":    {Description: "KI-Code-Reviewer"},
+		"fr":    {Description: "Relecteur de code IA"},
+		"pt-BR": {Description: "Revisor de código com IA"},
+	}
+	if manifest.Spec.Description != "AI code reviewer" {
+		t.Errorf("Spec.Description = %q, want the base description", manifest.Spec.Description)
+	}
+	assertLocalizations(t, "ToProto", manifest.Spec.Localizations, want)
+
+	data, err := proto.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+	decoded := &agentv1.Agent{}
+	if err := proto.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %v", err)
+	}
+	assertLocalizations(t, "binary round trip", decoded.Spec.Localizations, want)
+
+	jsonData, err := protojson.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("protojson.Marshal() error = %v", err)
+	}
+	decoded = &agentv1.Agent{}
+	if err := protojson.Unmarshal(jsonData, decoded); err != nil {
+		t.Fatalf("protojson.Unmarshal() error = %v", err)
+	}
+	assertLocalizations(t, "JSON round trip", decoded.Spec.Localizations, want)
+}
+
+func assertLocalizations(t *testing.T, what string, got, want map[string]*agentv1.AgentLocalization) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: localizations = %v, want %v", what, got, want)
+	}
+	for tag, localization := range want {
+		if !proto.Equal(got[tag], localization) {
+			t.Errorf("%s: localizations[%q] = %v, want %v", what, tag, got[tag], localization)
+		}
+	}
+}
+
+func TestWithLocalizedDescription_Unset(t *testing.T) {
+	ag, err := New(nil, "test-agent", &AgentArgs{
+		Instructions: "Test instructions for agent",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	manifest, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if manifest.Spec.Localizations != nil {
+		t.Errorf("Spec.Localizations = %v, want nil when no localization is set", manifest.Spec.Localizations)
+	}
+}
+
+func TestWithLocalizedDescription_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		lang        string
+		description string
+		wantErr     bool
+	}{
+		{name: "language", lang: "de", description: "KI-Code-Reviewer"},
+		{name: "language and region", lang: "fr-CA", description: "Relecteur de code IA"},
+		{name: "script", lang: "zh-Hant-TW", description: "AI 程式碼審查員"},
+		{name: "max length", lang: "de", description: strings.Repeat("ä", maxDescriptionChars)},
+		{name: "empty tag", lang: "", description: "KI-Code-Reviewer", wantErr: true},
+		{name: "underscore separator", lang: "de_DE", description: "KI-Code-Reviewer", wantErr: true},
+		{name: "language name", lang: "german", description: "KI-Code-Reviewer", wantErr: true},
+		{name: "trailing hyphen", lang: "de-", description: "KI-Code-Reviewer", wantErr: true},
+		{name: "unknown language", lang: "xx", description: "KI-Code-Reviewer", wantErr: true},
+		{name: "empty description", lang: "de", description: "", wantErr: true},
+		{name: "too long", lang: "de", description: strings.Repeat("ä", maxDescriptionChars+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ag, err := New(nil, "test-agent", &AgentArgs{
+				Instructions: "Test instructions for agent",
+			}, WithLocalizedDescription(tt.lang, tt.description))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDescription) {
+					t.Errorf("New() error = %v, want ErrInvalidDescription", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if _, err := ag.ToProto(); err != nil {
+				t.Errorf("ToProto() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLocalizedDescription_Fallback(t *testing.T) {
+	ag, err := New(nil, "test-agent", &AgentArgs{
+		Instructions: "Test instructions for agent",
+		Description:  "AI code reviewer",
+	}, WithLocalizedDescription("de", "KI-Code-Reviewer"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// Tags are canonicalized, and a later call for the same language replaces it
+	if err := ag.SetLocalizedDescription("DE", "KI-Code-Prüfer"); err != nil {
+		t.Fatalf("SetLocalizedDescription() error = %v", err)
+	}
+
+	tests := map[string]string{
+		"de":    "KI-Code-Prüfer",
+		"fr":    "AI code reviewer",
+		"de_DE": "AI code reviewer",
+	}
+	for lang, want := range tests {
+		if got := ag.LocalizedDescription(lang); got != want {
+			t.Errorf("LocalizedDescription(%q) = %q, want %q", lang, got, want)
+		}
+	}
+	if len(ag.Localizations) != 1 {
+		t.Errorf("Localizations = %v, want a single entry", ag.Localizations)
+	}
+}
@@ -0,0 +1,110 @@
+package agent
+
+import (
+	"fmt"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+)
+
+// Memory bounds enforced by the SDK (mirrors the AgentSpec proto constraints).
+const (
+	memoryMaxWindowTurns = 1000
+	memoryMinMaxTokens   = 1000
+	memoryMaxMaxTokens   = 1000000
+)
+
+// Memory describes how much conversation history an agent keeps between turns.
+//
+// Use one of MemoryNone, MemoryWindow, or MemorySummarizing and pass the result
+// to Agent.WithMemory. Agents without a memory configuration keep the full
+// session history.
+type Memory struct {
+	strategy    agentv1.MemoryStrategy
+	windowTurns int
+	maxTokens   MaxTokens
+}
+
+// MaxTokens is the token budget for summarizing memory.
+type MaxTokens int
+
+// MemoryNone makes the agent stateless: every execution starts from an empty
+// history and nothing is persisted to the session thread.
+var MemoryNone = Memory{strategy: agentv1.MemoryStrategy_MEMORY_NONE}
+
+// MemoryWindow keeps only the most recent turns of the conversation.
+//
+// Example:
+//
+//	err := ag.WithMemory(agent.MemoryWindow(20))
+func MemoryWindow(turns int) Memory {
+	return Memory{strategy: agentv1.MemoryStrategy_MEMORY_WINDOW, windowTurns: turns}
+}
+
+// MemorySummarizing summarizes older history once the conversation exceeds
+// the given token budget.
+//
+// Example:
+//
+//	err := ag.WithMemory(agent.MemorySummarizing(agent.MaxTokens(8000)))
+func MemorySummarizing(maxTokens MaxTokens) Memory {
+	return Memory{strategy: agentv1.MemoryStrategy_MEMORY_SUMMARIZING, maxTokens: maxTokens}
+}
+
+// IsSet reports whether a memory strategy has been configured.
+func (m Memory) IsSet() bool {
+	return m.strategy != agentv1.MemoryStrategy_MEMORY_STRATEGY_UNSPECIFIED
+}
+
+// String returns a short description of the memory configuration.
+func (m Memory) String() string {
+	switch m.strategy {
+	case agentv1.MemoryStrategy_MEMORY_NONE:
+		return "none"
+	case agentv1.MemoryStrategy_MEMORY_WINDOW:
+		return fmt.Sprintf("window(%d)", m.windowTurns)
+	case agentv1.MemoryStrategy_MEMORY_SUMMARIZING:
+		return fmt.Sprintf("summarizing(%d tokens)", m.maxTokens)
+	default:
+		return "default"
+	}
+}
+
+// validate checks the memory configuration against SDK bounds.
+func (m Memory) validate() error {
+	switch m.strategy {
+	case agentv1.MemoryStrategy_MEMORY_WINDOW:
+		if m.windowTurns <= 0 || m.windowTurns > memoryMaxWindowTurns {
+			return NewValidationErrorWithCause(
+				"memory.window_turns",
+				fmt.Sprintf("%d", m.windowTurns),
+				"range",
+				fmt.Sprintf("window must be between 1 and %d turns", memoryMaxWindowTurns),
+				ErrInvalidMemory,
+			)
+		}
+	case agentv1.MemoryStrategy_MEMORY_SUMMARIZING:
+		if m.maxTokens < memoryMinMaxTokens || m.maxTokens > memoryMaxMaxTokens {
+			return NewValidationErrorWithCause(
+				"memory.max_tokens",
+				fmt.Sprintf("%d", m.maxTokens),
+				"range",
+				fmt.Sprintf("max tokens must be between %d and %d", memoryMinMaxTokens, memoryMaxMaxTokens),
+				ErrInvalidMemory,
+			)
+		}
+	}
+	return nil
+}
+
+// toProto converts the memory configuration to its proto form.
+// Returns nil when no strategy is configured.
+func (m Memory) toProto() *agentv1.MemoryConfig {
+	if !m.IsSet() {
+		return nil
+	}
+	return &agentv1.MemoryConfig{
+		Strategy:    m.strategy,
+		WindowTurns: int32(m.windowTurns),
+		MaxTokens:   int32(m.maxTokens),
+	}
+}
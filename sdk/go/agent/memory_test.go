@@ -0,0 +1,117 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+)
+
+func TestWithMemory(t *testing.T) {
+	tests := []struct {
+		name         string
+		memory       Memory
+		wantErr      bool
+		wantStrategy agentv1.MemoryStrategy
+		wantWindow   int32
+		wantTokens   int32
+	}{
+		{
+			name:         "none",
+			memory:       MemoryNone,
+			wantStrategy: agentv1.MemoryStrategy_MEMORY_NONE,
+		},
+		{
+			name:         "window",
+			memory:       MemoryWindow(20),
+			wantStrategy: agentv1.MemoryStrategy_MEMORY_WINDOW,
+			wantWindow:   20,
+		},
+		{
+			name:         "summarizing",
+			memory:       MemorySummarizing(MaxTokens(8000)),
+			wantStrategy: agentv1.MemoryStrategy_MEMORY_SUMMARIZING,
+			wantTokens:   8000,
+		},
+		{
+			name:    "zero window",
+			memory:  MemoryWindow(0),
+			wantErr: true,
+		},
+		{
+			name:    "window too large",
+			memory:  MemoryWindow(memoryMaxWindowTurns + 1),
+			wantErr: true,
+		},
+		{
+			name:    "max tokens too small",
+			memory:  MemorySummarizing(MaxTokens(10)),
+			wantErr: true,
+		},
+		{
+			name:    "max tokens too large",
+			memory:  MemorySummarizing(MaxTokens(memoryMaxMaxTokens + 1)),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ag, err := New(nil, "test-agent", &AgentArgs{
+				Instructions: "Test instructions for agent",
+			})
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			err = ag.WithMemory(tt.memory)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMemory) {
+					t.Errorf("WithMemory() error = %v, want ErrInvalidMemory", err)
+				}
+				if ag.Memory.IsSet() {
+					t.Error("Memory should not be set after a validation error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WithMemory() error = %v", err)
+			}
+
+			proto, err := ag.ToProto()
+			if err != nil {
+				t.Fatalf("ToProto() error = %v", err)
+			}
+			memory := proto.Spec.Memory
+			if memory == nil {
+				t.Fatal("Spec.Memory is nil")
+			}
+			if memory.Strategy != tt.wantStrategy {
+				t.Errorf("Strategy = %v, want %v", memory.Strategy, tt.wantStrategy)
+			}
+			if memory.WindowTurns != tt.wantWindow {
+				t.Errorf("WindowTurns = %d, want %d", memory.WindowTurns, tt.wantWindow)
+			}
+			if memory.MaxTokens != tt.wantTokens {
+				t.Errorf("MaxTokens = %d, want %d", memory.MaxTokens, tt.wantTokens)
+			}
+		})
+	}
+}
+
+func TestWithMemory_Unset(t *testing.T) {
+	ag, err := New(nil, "test-agent", &AgentArgs{
+		Instructions: "Test instructions for agent",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	proto, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if proto.Spec.Memory != nil {
+		t.Errorf("Spec.Memory = %v, want nil when no memory is configured", proto.Spec.Memory)
+	}
+}
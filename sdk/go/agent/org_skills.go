@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"github.com/stigmer/stigmer/sdk/go/skillref"
+)
+
+// AgentOption configures an Agent created with New.
+type AgentOption func(*Agent)
+
+// WithOrgSkill references a skill of the agent's own organization.
+// See Agent.OrgSkill.
+//
+// Example:
+//
+//	ag, err := agent.New(ctx, "code-reviewer", &agent.AgentArgs{
+//	    Instructions: "Review code using our internal guidelines",
+//	}, agent.WithOrgSkill("internal-docs"))
+func WithOrgSkill(slug string, version ...string) AgentOption {
+	return func(a *Agent) {
+		a.OrgSkill(slug, version...)
+	}
+}
+
+// OrgSkill references a skill of the agent's own organization, so the org
+// is not repeated in every reference.
+//
+// Unlike AddOrgSkillRef, the organization is resolved at synthesis: the
+// reference uses the agent's Org at that point, whether it was set directly
+// (for example from a StringRef value) or inherited from a scope created
+// with stigmer.WithOrg. The synthesized reference is identical to
+// skillref.Organization(org, slug, version...). Synthesis fails with
+// ErrMissingOrg if the agent has no org.
+//
+// Version is optional - if omitted or empty, "latest" is used.
+//
+// Example:
+//
+//	ag.OrgSkill("internal-docs")          // Latest version
+//	ag.OrgSkill("internal-docs", "v2.0")  // Specific version
+func (a *Agent) OrgSkill(slug string, version ...string) *Agent {
+	ref := skillref.Organization("", slug, version...)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.appendSkillRef(ref, true)
+	return a
+}
+
+// resolveSkillRefs returns the agent's skill references with its org filled
+// into those added with OrgSkill. The agent itself is not modified.
+func (a *Agent) resolveSkillRefs() ([]*apiresource.ApiResourceReference, error) {
+	if len(a.orgSkillRefs) == 0 {
+		return a.SkillRefs, nil
+	}
+
+	refs := make([]*apiresource.ApiResourceReference, len(a.SkillRefs))
+	for i, ref := range a.SkillRefs {
+		if !a.orgSkillRefs[ref] {
+			refs[i] = ref
+			continue
+		}
+		if a.Org == "" {
+			return nil, NewValidationErrorWithCause(
+				validation.FieldPath("spec", "skill_refs", i, "org"),
+				ref.Slug,
+				"required",
+				fmt.Sprintf("agent %q references organization skill %q but has no org; set Agent.Org or create it in a scope with stigmer.WithOrg",
+					a.Name, ref.Slug),
+				ErrMissingOrg,
+			)
+		}
+		resolved := proto.Clone(ref).(*apiresource.ApiResourceReference)
+		resolved.Org = a.Org
+		refs[i] = resolved
+	}
+	return refs, nil
+}
+
+// inlineSkillRefs appends to refs a reference, by slug, to each of the
+// agent's inline skills. Synthesis creates the skills in the agent's Org, so
+// the references use it too.
+func (a *Agent) inlineSkillRefs(refs []*apiresource.ApiResourceReference) ([]*apiresource.ApiResourceReference, error) {
+	if len(a.Skills) == 0 {
+		return refs, nil
+	}
+
+	result := make([]*apiresource.ApiResourceReference, 0, len(refs)+len(a.Skills))
+	result = append(result, refs...)
+	for i, s := range a.Skills {
+		if s == nil {
+			return nil, NewConversionErrorWithCause(
+				"Agent",
+				validation.FieldPath("skills", i),
+				"nil skill",
+				ErrConversion,
+			)
+		}
+		result = append(result, skillref.Organization(a.Org, s.Slug))
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package agent
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWithOutputSchema(t *testing.T) {
+	ag, err := New(nil, "code-reviewer", &AgentArgs{
+		Instructions: "Review code and report issues",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := ag.WithOutputSchema(map[string]any{
+		"severity": "string",
+		"issues":   "array",
+	}); err != nil {
+		t.Fatalf("WithOutputSchema() error = %v", err)
+	}
+
+	proto, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	schema := proto.Spec.OutputSchema.AsMap()
+	if schema["type"] != "object" {
+		t.Errorf("schema type = %v, want object", schema["type"])
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	for _, name := range []string{"severity", "issues"} {
+		if _, ok := properties[name]; !ok {
+			t.Errorf("schema properties missing %q: %v", name, properties)
+		}
+	}
+}
+
+func TestWithOutputSchema_Invalid(t *testing.T) {
+	ag, err := New(nil, "code-reviewer", &AgentArgs{
+		Instructions: "Review code and report issues",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	err = ag.WithOutputSchema(map[string]any{"severity": "text"})
+	if !errors.Is(err, ErrInvalidOutputSchema) {
+		t.Errorf("WithOutputSchema() error = %v, want ErrInvalidOutputSchema", err)
+	}
+	if ag.OutputSchema != nil {
+		t.Error("OutputSchema should not be set after a validation error")
+	}
+}
+
+func TestWithOutputSchemaFile(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "review.schema.json")
+	if err := os.WriteFile(valid, []byte(`{
+		"type": "object",
+		"properties": {"summary": {"type": "string"}},
+		"required": ["summary"]
+	}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "broken.json")
+	if err := os.WriteFile(invalid, []byte(`["summary"]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ag, err := New(nil, "code-reviewer", &AgentArgs{
+		Instructions: "Review code and report issues",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := ag.WithOutputSchemaFile(valid); err != nil {
+		t.Fatalf("WithOutputSchemaFile() error = %v", err)
+	}
+	if ag.OutputSchema["type"] != "object" {
+		t.Errorf("OutputSchema = %v, want object schema", ag.OutputSchema)
+	}
+
+	for _, path := range []string{invalid, filepath.Join(dir, "missing.json")} {
+		if err := ag.WithOutputSchemaFile(path); !errors.Is(err, ErrInvalidOutputSchema) {
+			t.Errorf("WithOutputSchemaFile(%q) error = %v, want ErrInvalidOutputSchema", path, err)
+		}
+	}
+}
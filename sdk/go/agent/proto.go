@@ -4,11 +4,13 @@ import (
 	"fmt"
 
 	"buf.build/go/protovalidate"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
 	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	"github.com/stigmer/stigmer/sdk/go/environment"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
 	"github.com/stigmer/stigmer/sdk/go/mcpserver"
 	"github.com/stigmer/stigmer/sdk/go/stigmer/naming"
 	"github.com/stigmer/stigmer/sdk/go/subagent"
@@ -41,24 +43,68 @@ func init() {
 //	agent.AddSkillRef(skillref.Platform("coding-best-practices"))
 //	proto, err := agent.ToProto()
 func (a *Agent) ToProto() (*agentv1.Agent, error) {
+	if err := checkSkillRefs(a.SkillRefs, "spec", "skill_refs"); err != nil {
+		return nil, err
+	}
+	skillRefs, err := a.resolveSkillRefs()
+	if err != nil {
+		return nil, err
+	}
+	skillRefs, err = a.inlineSkillRefs(skillRefs)
+	if err != nil {
+		return nil, err
+	}
+	skillRefs, err = dedupSkillRefs(skillRefs, "spec", "skill_refs")
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert MCP servers
-	mcpServers, err := convertMCPServers(a.MCPServers)
+	mcpServers, err := convertMCPServers(a.MCPServers, "spec", "mcp_servers")
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert MCP servers: %w", err)
 	}
+	mcpServers, err = dedupMCPServers(mcpServers)
+	if err != nil {
+		return nil, err
+	}
 
 	// Convert sub-agents
 	subAgents, err := convertSubAgents(a.SubAgents)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert sub-agents: %w", err)
 	}
+	subAgents, err = dedupSubAgents(subAgents)
+	if err != nil {
+		return nil, err
+	}
 
-	// Convert environment variables
-	envSpec, err := convertEnvironmentVariables(a.EnvironmentVariables)
+	// Convert environment variables, with the environment sets expanded
+	variables, err := a.Environment()
+	if err != nil {
+		return nil, err
+	}
+	envSpec, err := convertEnvironmentVariables(variables)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert environment variables: %w", err)
 	}
 
+	if err := a.ToolPolicy.validate(); err != nil {
+		return nil, err
+	}
+	if err := a.Limits.validate(); err != nil {
+		return nil, err
+	}
+
+	// Convert output schema
+	var outputSchema *structpb.Struct
+	if len(a.OutputSchema) > 0 {
+		outputSchema, err = structpb.NewStruct(a.OutputSchema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert output schema: %w", err)
+		}
+	}
+
 	// Auto-generate slug if empty
 	slug := a.Slug
 	if slug == "" {
@@ -82,32 +128,60 @@ func (a *Agent) ToProto() (*agentv1.Agent, error) {
 		Kind:       "Agent",
 		Metadata:   metadata,
 		Spec: &agentv1.AgentSpec{
-			Description:  a.Description,
-			IconUrl:      a.IconURL,
-			Instructions: a.Instructions,
-			SkillRefs:    a.SkillRefs,
-			McpServers:   mcpServers,
-			SubAgents:    subAgents,
-			EnvSpec:      envSpec,
+			Description:   a.Description,
+			IconUrl:       a.IconURL,
+			Instructions:  a.Instructions,
+			SkillRefs:     skillRefs,
+			McpServers:    mcpServers,
+			SubAgents:     subAgents,
+			EnvSpec:       envSpec,
+			Memory:        a.Memory.toProto(),
+			OutputSchema:  outputSchema,
+			ToolPolicy:    a.ToolPolicy.toProto(),
+			Localizations: localizationsToProto(a.Localizations),
+			Limits:        a.Limits.toProto(),
 		},
 	}
 
 	// Validate the proto message against buf.validate rules
 	if err := validator.Validate(agent); err != nil {
-		return nil, fmt.Errorf("agent validation failed: %w", err)
+		return nil, fmt.Errorf("agent validation failed: %w",
+			validation.NewConversionErrorFromViolations("Agent", "", err))
 	}
 
 	return agent, nil
 }
 
+// checkSkillRefs reports the first nil skill reference, with its index in
+// the field at path.
+func checkSkillRefs(refs []*apiresource.ApiResourceReference, path ...interface{}) error {
+	for i, ref := range refs {
+		if ref == nil {
+			return NewConversionErrorWithCause(
+				"Agent",
+				validation.FieldPath(append(path, i)...),
+				"nil skill reference",
+				ErrConversion,
+			)
+		}
+	}
+	return nil
+}
+
 // convertMCPServers converts SDK MCP servers to proto MCP server definitions.
-func convertMCPServers(servers []mcpserver.MCPServer) ([]*agentv1.McpServerDefinition, error) {
+// path is the field path of the servers, used in conversion errors.
+func convertMCPServers(servers []mcpserver.MCPServer, path ...interface{}) ([]*agentv1.McpServerDefinition, error) {
 	if len(servers) == 0 {
 		return []*agentv1.McpServerDefinition{}, nil
 	}
 
 	defs := make([]*agentv1.McpServerDefinition, 0, len(servers))
-	for _, server := range servers {
+	for i, server := range servers {
+		field := validation.FieldPath(append(path, i)...)
+		if server == nil {
+			return nil, NewConversionErrorWithCause("Agent", field, "nil MCP server", ErrConversion)
+		}
+
 		def := &agentv1.McpServerDefinition{
 			Name:         server.Name(),
 			EnabledTools: server.EnabledTools(),
@@ -118,7 +192,8 @@ func convertMCPServers(servers []mcpserver.MCPServer) ([]*agentv1.McpServerDefin
 		case mcpserver.TypeStdio:
 			stdioServer, ok := server.(*mcpserver.StdioServer)
 			if !ok {
-				return nil, fmt.Errorf("server %q: type mismatch - expected StdioServer", server.Name())
+				return nil, NewConversionErrorWithCause("Agent", field,
+					fmt.Sprintf("server %q: type mismatch - expected StdioServer", server.Name()), ErrConversion)
 			}
 			def.ServerType = &agentv1.McpServerDefinition_Stdio{
 				Stdio: &agentv1.StdioServer{
@@ -132,7 +207,8 @@ func convertMCPServers(servers []mcpserver.MCPServer) ([]*agentv1.McpServerDefin
 		case mcpserver.TypeHTTP:
 			httpServer, ok := server.(*mcpserver.HTTPServer)
 			if !ok {
-				return nil, fmt.Errorf("server %q: type mismatch - expected HTTPServer", server.Name())
+				return nil, NewConversionErrorWithCause("Agent", field,
+					fmt.Sprintf("server %q: type mismatch - expected HTTPServer", server.Name()), ErrConversion)
 			}
 			def.ServerType = &agentv1.McpServerDefinition_Http{
 				Http: &agentv1.HttpServer{
@@ -140,37 +216,43 @@ func convertMCPServers(servers []mcpserver.MCPServer) ([]*agentv1.McpServerDefin
 					Headers:        httpServer.Headers(),
 					QueryParams:    httpServer.QueryParams(),
 					TimeoutSeconds: httpServer.TimeoutSeconds(),
+					Auth:           oauth2ToProto(httpServer.Auth()),
 				},
 			}
 
 		case mcpserver.TypeDocker:
 			dockerServer, ok := server.(*mcpserver.DockerServer)
 			if !ok {
-				return nil, fmt.Errorf("server %q: type mismatch - expected DockerServer", server.Name())
+				return nil, NewConversionErrorWithCause("Agent", field,
+					fmt.Sprintf("server %q: type mismatch - expected DockerServer", server.Name()), ErrConversion)
 			}
 
 			// Convert volume mounts (types.VolumeMount has same fields as agentv1.VolumeMount)
 			volumes := make([]*agentv1.VolumeMount, 0, len(dockerServer.Volumes()))
-			for _, vol := range dockerServer.Volumes() {
-				if vol != nil {
-					volumes = append(volumes, &agentv1.VolumeMount{
-						HostPath:      vol.HostPath,
-						ContainerPath: vol.ContainerPath,
-						ReadOnly:      vol.ReadOnly,
-					})
+			for j, vol := range dockerServer.Volumes() {
+				if vol == nil {
+					return nil, NewConversionErrorWithCause("Agent",
+						validation.FieldPath(field, "docker", "volumes", j), "nil volume mount", ErrConversion)
 				}
+				volumes = append(volumes, &agentv1.VolumeMount{
+					HostPath:      vol.HostPath,
+					ContainerPath: vol.ContainerPath,
+					ReadOnly:      vol.ReadOnly,
+				})
 			}
 
 			// Convert port mappings (types.PortMapping has same fields as agentv1.PortMapping)
 			ports := make([]*agentv1.PortMapping, 0, len(dockerServer.Ports()))
-			for _, port := range dockerServer.Ports() {
-				if port != nil {
-					ports = append(ports, &agentv1.PortMapping{
-						HostPort:      port.HostPort,
-						ContainerPort: port.ContainerPort,
-						Protocol:      port.Protocol,
-					})
+			for j, port := range dockerServer.Ports() {
+				if port == nil {
+					return nil, NewConversionErrorWithCause("Agent",
+						validation.FieldPath(field, "docker", "ports", j), "nil port mapping", ErrConversion)
 				}
+				ports = append(ports, &agentv1.PortMapping{
+					HostPort:      port.HostPort,
+					ContainerPort: port.ContainerPort,
+					Protocol:      port.Protocol,
+				})
 			}
 
 			def.ServerType = &agentv1.McpServerDefinition_Docker{
@@ -186,7 +268,8 @@ func convertMCPServers(servers []mcpserver.MCPServer) ([]*agentv1.McpServerDefin
 			}
 
 		default:
-			return nil, fmt.Errorf("server %q: unknown server type %v", server.Name(), server.Type())
+			return nil, NewConversionErrorWithCause("Agent", field,
+				fmt.Sprintf("server %q: unknown server type %v", server.Name(), server.Type()), ErrConversion)
 		}
 
 		defs = append(defs, def)
@@ -195,15 +278,30 @@ func convertMCPServers(servers []mcpserver.MCPServer) ([]*agentv1.McpServerDefin
 	return defs, nil
 }
 
+// oauth2ToProto converts an HTTP server's OAuth2 configuration. Returns nil
+// when the server does not use OAuth2.
+func oauth2ToProto(auth *mcpserver.OAuth2ClientCredentials) *agentv1.OAuth2ClientCredentials {
+	if auth == nil {
+		return nil
+	}
+	return &agentv1.OAuth2ClientCredentials{
+		TokenUrl:        auth.TokenUrl,
+		ClientIdEnv:     auth.ClientIdEnv,
+		ClientSecretEnv: auth.ClientSecretEnv,
+		Scopes:          auth.Scopes,
+	}
+}
+
 // convertSubAgents converts SDK sub-agents to proto sub-agents.
-// SubAgent fields are now directly on the proto message (no InlineSpec wrapper).
+// SubAgent fields are now directly on the proto message (no InlineSpec wrapper);
+// referenced sub-agents only set agent_instance_ref.
 func convertSubAgents(subAgents []subagent.SubAgent) ([]*agentv1.SubAgent, error) {
 	if len(subAgents) == 0 {
 		return []*agentv1.SubAgent{}, nil
 	}
 
 	protoSubAgents := make([]*agentv1.SubAgent, 0, len(subAgents))
-	for _, sa := range subAgents {
+	for i, sa := range subAgents {
 		// Convert tool selections map to proto format
 		toolSelections := make(map[string]*agentv1.McpToolSelection)
 		for serverName, selection := range sa.ToolSelections() {
@@ -214,14 +312,32 @@ func convertSubAgents(subAgents []subagent.SubAgent) ([]*agentv1.SubAgent, error
 			}
 		}
 
+		if err := validateSubAgentReference(i, sa); err != nil {
+			return nil, err
+		}
+		if err := validateSubAgentMCPServers(i, sa); err != nil {
+			return nil, err
+		}
+		if err := checkSkillRefs(sa.SkillRefs(), "spec", "sub_agents", i, "skill_refs"); err != nil {
+			return nil, fmt.Errorf("sub-agent %s: %w", sa.Name(), err)
+		}
+
+		// Sub-agent-local MCP servers use the same conversion as the parent's
+		localServers, err := convertMCPServers(sa.MCPServers(), "spec", "sub_agents", i, "mcp_server_definitions")
+		if err != nil {
+			return nil, fmt.Errorf("sub-agent %s: %w", sa.Name(), err)
+		}
+
 		// SubAgent fields are directly on the proto message
 		protoSubAgents = append(protoSubAgents, &agentv1.SubAgent{
-			Name:              sa.Name(),
-			Description:       sa.Description(),
-			Instructions:      sa.Instructions(),
-			McpServers:        sa.MCPServerNames(),
-			McpToolSelections: toolSelections,
-			SkillRefs:         sa.SkillRefs(),
+			Name:                 sa.Name(),
+			Description:          sa.Description(),
+			Instructions:         sa.Instructions(),
+			McpServers:           sa.MCPServerNames(),
+			McpToolSelections:    toolSelections,
+			SkillRefs:            sa.SkillRefs(),
+			McpServerDefinitions: localServers,
+			AgentInstanceRef:     sa.InstanceRef(),
 		})
 	}
 
@@ -241,6 +357,7 @@ func convertEnvironmentVariables(vars []environment.Variable) (*environmentv1.En
 			Value:       v.DefaultValue, // Use default value as the template value
 			IsSecret:    v.IsSecret,
 			Description: v.Description,
+			Type:        v.Type.ToProto(),
 		}
 	}
 
@@ -3,6 +3,7 @@ package agent
 import (
 	"testing"
 
+	"github.com/stigmer/stigmer/sdk/go/mcpserver"
 	"github.com/stigmer/stigmer/sdk/go/skillref"
 )
 
@@ -243,3 +244,44 @@ func TestAgentToProto_CustomSlug(t *testing.T) {
 		t.Errorf("Slug = %v, want custom-slug-123", proto.Metadata.Slug)
 	}
 }
+
+// TestAgentToProto_HTTPServerOAuth2 tests that OAuth2 auth on an HTTP MCP
+// server is carried into the proto.
+func TestAgentToProto_HTTPServerOAuth2(t *testing.T) {
+	agent, err := New(nil, "api-agent", &AgentArgs{
+		Instructions: "Query the API and summarize the results",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	api, err := mcpserver.HTTP(nil, "api-service", &mcpserver.HTTPArgs{
+		Url: "https://mcp.example.com",
+		Auth: &mcpserver.OAuth2ClientCredentials{
+			TokenUrl:        "https://auth.example.com/oauth2/token",
+			ClientIdEnv:     "MCP_CLIENT_ID",
+			ClientSecretEnv: "MCP_CLIENT_SECRET",
+			Scopes:          []string{"mcp.read"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create MCP server: %v", err)
+	}
+	agent.AddMCPServer(api)
+
+	proto, err := agent.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	auth := proto.Spec.McpServers[0].GetHttp().GetAuth()
+	if auth.GetTokenUrl() != "https://auth.example.com/oauth2/token" {
+		t.Errorf("TokenUrl = %v", auth.GetTokenUrl())
+	}
+	if auth.GetClientIdEnv() != "MCP_CLIENT_ID" || auth.GetClientSecretEnv() != "MCP_CLIENT_SECRET" {
+		t.Errorf("credential env = %v/%v, want MCP_CLIENT_ID/MCP_CLIENT_SECRET", auth.GetClientIdEnv(), auth.GetClientSecretEnv())
+	}
+	if len(auth.GetScopes()) != 1 || auth.GetScopes()[0] != "mcp.read" {
+		t.Errorf("Scopes = %v, want [mcp.read]", auth.GetScopes())
+	}
+}
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"fmt"
+	"strings"
 )
 
 // Ref is a minimal interface that represents a typed reference to a value.
@@ -21,6 +22,12 @@ type StringValue interface {
 	Value() string
 }
 
+// computedValue is implemented by references whose value may only be known at
+// runtime (e.g. stigmer.StringRef after Upper or a Concat with a task field).
+type computedValue interface {
+	IsComputed() bool
+}
+
 // toExpression converts various input types to expression strings.
 // This helper enables agent builders to accept both legacy string values
 // and new typed Ref values, maintaining backward compatibility while
@@ -28,7 +35,8 @@ type StringValue interface {
 //
 // Supported types:
 //   - string: returned as-is
-//   - StringValue: returns the initial value (used during synthesis)
+//   - StringValue: returns the initial value (used during synthesis), or the
+//     expression if the reference is computed and only known at runtime
 //   - Ref: calls Expression() to get JQ expression (though rarely needed for agents)
 //
 // Examples:
@@ -42,6 +50,13 @@ func toExpression(value interface{}) string {
 	case StringValue:
 		// For synthesis, we need the actual value, not an expression
 		// Check StringValue BEFORE Ref because StringRef implements both interfaces
+		// Computed StringRefs have no synthesis-time value - use the expression.
+		// Literal refs keep their value, even when it is empty.
+		if c, ok := value.(computedValue); ok && c.IsComputed() {
+			if ref, ok := value.(Ref); ok {
+				return ref.Expression()
+			}
+		}
 		return v.Value()
 	case Ref:
 		// Use Expression() for context variables and computed expressions
@@ -51,3 +66,10 @@ func toExpression(value interface{}) string {
 		return fmt.Sprintf("%v", value)
 	}
 }
+
+// isExpression reports whether a string value contains a runtime expression
+// (e.g. "${ $context.iconBase + \"/agent.png\" }" or "${.env_vars.CDN_HOST}")
+// and therefore cannot be format-validated at synthesis time.
+func isExpression(value string) bool {
+	return strings.Contains(value, "${")
+}
@@ -292,3 +292,40 @@ func TestRefHelpers_toExpression(t *testing.T) {
 	// This is tested indirectly through all the agent builder tests above,
 	// but we can add explicit tests if needed
 }
+
+func TestAgent_WithIconURL_StringRef(t *testing.T) {
+	ctx := stigmer.NewContext()
+
+	t.Run("empty literal stays empty", func(t *testing.T) {
+		ag, err := agent.New(ctx, "empty-icon", &agent.AgentArgs{Instructions: "Agent with an empty icon URL"})
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		if err := ag.WithIconURL(ctx.SetString("emptyIcon", "")); err != nil {
+			t.Fatalf("WithIconURL() failed: %v", err)
+		}
+		if ag.IconURL != "" {
+			t.Errorf("IconURL = %q, want empty", ag.IconURL)
+		}
+		if ag.IsExpressionValued("icon_url") {
+			t.Error("empty literal icon URL should not be expression-valued")
+		}
+	})
+
+	t.Run("computed ref uses the expression", func(t *testing.T) {
+		ag, err := agent.New(ctx, "computed-icon", &agent.AgentArgs{Instructions: "Agent with a computed icon URL"})
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		iconURL := ctx.SetString("iconHost", "https://cdn.example.com/icon.png").Lower()
+		if err := ag.WithIconURL(iconURL); err != nil {
+			t.Fatalf("WithIconURL() failed: %v", err)
+		}
+		if ag.IconURL != iconURL.Expression() {
+			t.Errorf("IconURL = %q, want %q", ag.IconURL, iconURL.Expression())
+		}
+		if !ag.IsExpressionValued("icon_url") {
+			t.Error("computed icon URL should be recorded as expression-valued")
+		}
+	})
+}
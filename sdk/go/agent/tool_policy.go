@@ -0,0 +1,138 @@
+package agent
+
+import (
+	"regexp"
+	"slices"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+)
+
+// toolPatternRegex matches tool name patterns: name characters with "*" and
+// "?" wildcards (mirrors the ToolPolicy proto constraint).
+var toolPatternRegex = regexp.MustCompile(`^[A-Za-z0-9_.*?-]+$`)
+
+// ToolPolicy restricts the tools an agent may call, across all of its MCP
+// servers. Patterns are tool names with "*" (any characters) and "?" (one
+// character) wildcards.
+//
+// Denied tools are removed from the model's tool list. Confirmed tools pause
+// the execution before each call until a human approves or rejects it. A tool
+// matching both lists is denied.
+//
+// Use WithToolPolicy to set it.
+type ToolPolicy struct {
+	// Deny lists the patterns of tools the agent may never call.
+	Deny []string
+
+	// Confirm lists the patterns of tools that require human approval.
+	Confirm []string
+}
+
+// ToolPolicyRule adds patterns to a ToolPolicy.
+type ToolPolicyRule func(*ToolPolicy)
+
+// DenyTools removes the tools matching the patterns from the agent's tool
+// list, whichever MCP server provides them.
+//
+// Example:
+//
+//	agent.DenyTools("delete_*", "drop_table")
+func DenyTools(patterns ...string) ToolPolicyRule {
+	return func(p *ToolPolicy) {
+		p.Deny = append(p.Deny, patterns...)
+	}
+}
+
+// ConfirmTools requires human approval before each call of a tool matching
+// the patterns. The execution pauses with the call awaiting approval.
+//
+// Example:
+//
+//	agent.ConfirmTools("create_pr", "merge_pr")
+func ConfirmTools(patterns ...string) ToolPolicyRule {
+	return func(p *ToolPolicy) {
+		p.Confirm = append(p.Confirm, patterns...)
+	}
+}
+
+// WithToolPolicy sets an agent-wide tool policy. Unlike
+// mcpserver.WithEnabledTools, it applies to the tools of every MCP server.
+// Patterns are validated at synthesis.
+//
+// Example:
+//
+//	ag, err := agent.New(ctx, "pr-bot", &agent.AgentArgs{
+//	    Instructions: "Open pull requests for dependency updates",
+//	}, agent.WithToolPolicy(
+//	    agent.DenyTools("delete_*"),
+//	    agent.ConfirmTools("create_pr", "merge_pr"),
+//	))
+func WithToolPolicy(rules ...ToolPolicyRule) AgentOption {
+	return func(a *Agent) {
+		a.SetToolPolicy(rules...)
+	}
+}
+
+// SetToolPolicy adds rules to the agent's tool policy after creation. See
+// WithToolPolicy.
+// This method is thread-safe and can be called concurrently.
+func (a *Agent) SetToolPolicy(rules ...ToolPolicyRule) *Agent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, rule := range rules {
+		rule(&a.ToolPolicy)
+	}
+	return a
+}
+
+// IsSet reports whether the policy has any pattern.
+func (p ToolPolicy) IsSet() bool {
+	return len(p.Deny) > 0 || len(p.Confirm) > 0
+}
+
+// validate checks the syntax of the policy patterns.
+func (p ToolPolicy) validate() error {
+	for _, list := range []struct {
+		field    string
+		patterns []string
+	}{
+		{"tool_policy.deny", p.Deny},
+		{"tool_policy.confirm", p.Confirm},
+	} {
+		for _, pattern := range list.patterns {
+			if !toolPatternRegex.MatchString(pattern) {
+				return NewValidationErrorWithCause(
+					list.field,
+					pattern,
+					"pattern",
+					"tool patterns may only contain letters, digits, '_', '-', '.' and the wildcards '*' and '?'",
+					ErrInvalidToolPolicy,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// toProto converts the policy to its proto form, with duplicate patterns
+// removed. Returns nil when no pattern is set.
+func (p ToolPolicy) toProto() *agentv1.ToolPolicy {
+	if !p.IsSet() {
+		return nil
+	}
+	return &agentv1.ToolPolicy{
+		Deny:    dedupPatterns(p.Deny),
+		Confirm: dedupPatterns(p.Confirm),
+	}
+}
+
+// dedupPatterns returns patterns without duplicates, in first-seen order.
+func dedupPatterns(patterns []string) []string {
+	var result []string
+	for _, pattern := range patterns {
+		if !slices.Contains(result, pattern) {
+			result = append(result, pattern)
+		}
+	}
+	return result
+}
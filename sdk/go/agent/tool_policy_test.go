@@ -0,0 +1,130 @@
+package agent
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+)
+
+func TestWithToolPolicy_ManifestRoundTrip(t *testing.T) {
+	ag, err := New(nil, "pr-bot", &AgentArgs{
+		Instructions: "Open pull requests for dependency updates",
+	}, WithToolPolicy(
+		DenyTools("delete_*", "drop_table"),
+		ConfirmTools("create_pr", "merge_pr"),
+		DenyTools("delete_*"),
+	))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	manifest, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	want := &agentv1.ToolPolicy{
+		Deny:    []string{"delete_*", "drop_table"},
+		Confirm: []string{"create_pr", "merge_pr"},
+	}
+	if !proto.Equal(manifest.Spec.ToolPolicy, want) {
+		t.Fatalf("Spec.ToolPolicy = %v, want %v", manifest.Spec.ToolPolicy, want)
+	}
+
+	// Binary manifests, as written to STIGMER_OUT_DIR
+	data, err := proto.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+	decoded := &agentv1.Agent{}
+	if err := proto.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %v", err)
+	}
+	if !proto.Equal(decoded.Spec.ToolPolicy, want) {
+		t.Errorf("binary round trip ToolPolicy = %v, want %v", decoded.Spec.ToolPolicy, want)
+	}
+
+	// JSON manifests, as shown by the CLI
+	jsonData, err := protojson.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("protojson.Marshal() error = %v", err)
+	}
+	decoded = &agentv1.Agent{}
+	if err := protojson.Unmarshal(jsonData, decoded); err != nil {
+		t.Fatalf("protojson.Unmarshal() error = %v", err)
+	}
+	if !proto.Equal(decoded.Spec.ToolPolicy, want) {
+		t.Errorf("JSON round trip ToolPolicy = %v, want %v", decoded.Spec.ToolPolicy, want)
+	}
+}
+
+func TestWithToolPolicy_Unset(t *testing.T) {
+	ag, err := New(nil, "test-agent", &AgentArgs{
+		Instructions: "Test instructions for agent",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	manifest, err := ag.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if manifest.Spec.ToolPolicy != nil {
+		t.Errorf("Spec.ToolPolicy = %v, want nil when no policy is configured", manifest.Spec.ToolPolicy)
+	}
+}
+
+func TestWithToolPolicy_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    ToolPolicyRule
+		wantErr bool
+	}{
+		{name: "glob", rule: DenyTools("delete_*", "get_?ser", "github.create-issue")},
+		{name: "empty pattern", rule: DenyTools(""), wantErr: true},
+		{name: "character class", rule: ConfirmTools("merge_[ab]"), wantErr: true},
+		{name: "whitespace", rule: ConfirmTools("create pr"), wantErr: true},
+		{name: "path separator", rule: DenyTools("github/delete_*"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ag, err := New(nil, "test-agent", &AgentArgs{
+				Instructions: "Test instructions for agent",
+			}, WithToolPolicy(tt.rule))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			_, err = ag.ToProto()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToolPolicy) {
+					t.Errorf("ToProto() error = %v, want ErrInvalidToolPolicy", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ToProto() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSetToolPolicy_AddsRules(t *testing.T) {
+	ag, err := New(nil, "test-agent", &AgentArgs{
+		Instructions: "Test instructions for agent",
+	}, WithToolPolicy(DenyTools("delete_*")))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ag.SetToolPolicy(ConfirmTools("create_pr"))
+
+	if !slices.Equal(ag.ToolPolicy.Deny, []string{"delete_*"}) || !slices.Equal(ag.ToolPolicy.Confirm, []string{"create_pr"}) {
+		t.Errorf("ToolPolicy = %+v, want deny [delete_*] and confirm [create_pr]", ag.ToolPolicy)
+	}
+}
@@ -2,7 +2,11 @@ package agent
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"github.com/stigmer/stigmer/sdk/go/subagent"
 )
 
 // Validation constants for SDK-specific name format.
@@ -10,6 +14,11 @@ const (
 	nameMaxLength = 63
 )
 
+// versionRegex matches the versions a sub-agent reference can pin: empty,
+// "latest", a tag or a 64-char hex hash. This mirrors the pattern protovalidate
+// enforces on ApiResourceReference.version, checked early for a clearer error.
+var versionRegex = regexp.MustCompile(`^$|^latest$|^[a-zA-Z0-9._-]+$|^[a-f0-9]{64}$`)
+
 // nameRegex matches valid agent names (lowercase alphanumeric with hyphens).
 // This is an SDK-specific naming convention not enforced by proto validation.
 var nameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
@@ -22,8 +31,119 @@ var nameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
 //
 // SDK-specific rules validated here:
 //   - Name format: lowercase alphanumeric with hyphens (SDK naming convention)
+//   - Icon URL format: http(s) URL, only for literal values (expression values
+//     are resolved at runtime and cannot be validated here)
 func validate(a *Agent) error {
-	return validateName(a.Name)
+	if err := validateName(a.Name); err != nil {
+		return err
+	}
+	return validateURLField("icon_url", a.IconURL, ErrInvalidIconURL)
+}
+
+// validateSubAgentMCPServers checks that a sub-agent's locally defined MCP
+// servers can be told apart from each other and from the parent servers it
+// references by name. Sub-agents are attached after New, so this runs during
+// proto conversion rather than in validate.
+func validateSubAgentMCPServers(index int, sa subagent.SubAgent) error {
+	referenced := make(map[string]bool, len(sa.MCPServerNames()))
+	for _, name := range sa.MCPServerNames() {
+		referenced[name] = true
+	}
+
+	local := make(map[string]bool, len(sa.MCPServers()))
+	for i, server := range sa.MCPServers() {
+		if server == nil {
+			// Reported by the MCP server conversion
+			continue
+		}
+		name := server.Name()
+		field := validation.FieldPath("spec", "sub_agents", index, "mcp_server_definitions", i)
+		if referenced[name] {
+			return NewValidationErrorWithCause(
+				field,
+				name,
+				"unique",
+				fmt.Sprintf("local MCP server %q collides with referenced parent server of the same name", name),
+				ErrMCPServerNameConflict,
+			)
+		}
+		if local[name] {
+			return NewValidationErrorWithCause(
+				field,
+				name,
+				"unique",
+				fmt.Sprintf("local MCP server %q is defined more than once", name),
+				ErrMCPServerNameConflict,
+			)
+		}
+		local[name] = true
+	}
+	return nil
+}
+
+// validateSubAgentReference checks a sub-agent that references a deployed
+// AgentInstance: the instance name is required, the version must be a valid
+// tag or hash, and the sub-agent must not also carry inline configuration.
+func validateSubAgentReference(index int, sa subagent.SubAgent) error {
+	ref := sa.InstanceRef()
+	if ref == nil {
+		return nil
+	}
+
+	field := validation.FieldPath("spec", "sub_agents", index, "agent_instance_ref")
+	if ref.GetSlug() == "" {
+		return NewValidationErrorWithCause(
+			field+".slug",
+			"",
+			"required",
+			fmt.Sprintf("sub-agent %q references an AgentInstance without a name", sa.Name()),
+			ErrInvalidSubAgentRef,
+		)
+	}
+	if !versionRegex.MatchString(ref.GetVersion()) {
+		return NewValidationErrorWithCause(
+			field+".version",
+			ref.GetVersion(),
+			"version_format",
+			fmt.Sprintf("sub-agent %q: version must be empty, \"latest\", a tag (letters, digits, '.', '_', '-') or a 64-char version hash", sa.Name()),
+			ErrInvalidSubAgentRef,
+		)
+	}
+	if len(sa.MCPServers()) > 0 {
+		return NewValidationErrorWithCause(
+			validation.FieldPath("spec", "sub_agents", index, "mcp_server_definitions"),
+			"",
+			"referenced_sub_agent",
+			fmt.Sprintf("sub-agent %q references AgentInstance %q and cannot define its own MCP servers", sa.Name(), ref.GetSlug()),
+			ErrInvalidSubAgentRef,
+		)
+	}
+	return nil
+}
+
+// validateURLField validates a URL-valued field.
+//
+// Literal values are validated immediately: they must be absolute http or
+// https URLs with a host. Empty values are allowed (URL fields are optional).
+// Expression values (containing "${") are skipped - their format is only known
+// once the expression is resolved at runtime.
+func validateURLField(field, value string, sentinel error) error {
+	if value == "" || isExpression(value) {
+		return nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return &ValidationError{
+			Field:   field,
+			Value:   truncateValue(value),
+			Rule:    "url",
+			Message: "invalid URL: must be an absolute http or https URL",
+			Err:     sentinel,
+		}
+	}
+
+	return nil
 }
 
 // validateName validates the agent name against SDK naming conventions.
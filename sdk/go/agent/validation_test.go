@@ -136,6 +136,87 @@ func TestValidate(t *testing.T) {
 	}
 }
 
-// Note: Instructions, Description, and IconURL validation is now handled
-// by protovalidate in ToProto(). SDK only validates the name format
-// (lowercase alphanumeric with hyphens).
+// Note: Instructions and Description validation is handled by protovalidate
+// in ToProto(). SDK validates the name format (lowercase alphanumeric with
+// hyphens) and the format of literal URL fields.
+
+func TestValidateURLField(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		// Literal values - validated immediately
+		{name: "literal valid https", input: "https://example.com/icon.png", wantErr: false},
+		{name: "literal valid http", input: "http://example.com/icon.png", wantErr: false},
+		{name: "empty is allowed", input: "", wantErr: false},
+		{name: "literal invalid - no scheme", input: "example.com/icon.png", wantErr: true},
+		{name: "literal invalid - wrong scheme", input: "ftp://example.com/icon.png", wantErr: true},
+		{name: "literal invalid - not a URL", input: "not-a-valid-url", wantErr: true},
+
+		// Expression values - format validation skipped
+		{name: "runtime env placeholder", input: "https://cdn-${.env_vars.ENV}.example.com/icon.png", wantErr: false},
+		{name: "computed expression", input: `${ $context.iconBase + "/icon.png" }`, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateURLField("icon_url", tt.input, ErrInvalidIconURL)
+
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidIconURL) {
+					t.Errorf("validateURLField() expected ErrInvalidIconURL, got %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("validateURLField() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestAgent_WithIconURL(t *testing.T) {
+	newAgent := func(t *testing.T) *Agent {
+		ag, err := New(nil, "icon-agent", &AgentArgs{Instructions: "Agent used for icon URL tests"})
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		return ag
+	}
+
+	t.Run("literal invalid", func(t *testing.T) {
+		ag := newAgent(t)
+		if err := ag.WithIconURL("ftp://example.com/icon.png"); !errors.Is(err, ErrInvalidIconURL) {
+			t.Errorf("expected ErrInvalidIconURL, got %v", err)
+		}
+		if ag.IconURL != "" {
+			t.Errorf("IconURL should be unchanged on error, got %q", ag.IconURL)
+		}
+	})
+
+	t.Run("literal valid", func(t *testing.T) {
+		ag := newAgent(t)
+		if err := ag.WithIconURL("https://example.com/icon.png"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ag.IconURL != "https://example.com/icon.png" {
+			t.Errorf("IconURL = %q", ag.IconURL)
+		}
+		if ag.IsExpressionValued("icon_url") {
+			t.Error("literal icon URL should not be expression-valued")
+		}
+	})
+
+	t.Run("expression valued", func(t *testing.T) {
+		ag := newAgent(t)
+		iconURL := "https://cdn-${.env_vars.ENVIRONMENT}.example.com/icon.png"
+		if err := ag.WithIconURL(iconURL); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ag.IconURL != iconURL {
+			t.Errorf("IconURL = %q, expected %q", ag.IconURL, iconURL)
+		}
+		if !ag.IsExpressionValued("icon_url") {
+			t.Error("icon URL should be recorded as expression-valued")
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package agentinstance
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/internal/envbinding"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"github.com/stigmer/stigmer/sdk/go/stigmer/naming"
+)
+
+// Context is a minimal interface that represents a stigmer context.
+// This allows the agentinstance package to work with contexts without importing
+// the stigmer package (avoiding import cycles).
+//
+// The stigmer.Context type implements this interface.
+type Context interface {
+	RegisterAgentInstance(*AgentInstance)
+}
+
+// openChecker is implemented by contexts that are closed once they can no
+// longer synthesize resources, such as stigmer.Context after Run returns.
+type openChecker interface {
+	CheckOpen() error
+}
+
+// Args contains the configuration arguments for creating an AgentInstance.
+//
+// This struct follows the Pulumi Args pattern for resource configuration.
+type Args struct {
+	// Agent is the agent template this instance deploys (required).
+	Agent *agent.Agent
+
+	// Description is a human-readable description of the instance.
+	Description string
+
+	// Env binds the agent's environment variables by name.
+	Env map[string]Value
+}
+
+// AgentInstance is a deployment of an Agent template with its environment
+// variables bound.
+//
+// The bindings are synthesized into an Environment resource named
+// "{instance}-env", which the instance references.
+type AgentInstance struct {
+	// Name is the instance name (lowercase alphanumeric with hyphens).
+	Name string
+
+	// Slug is the URL-friendly identifier (generated from the name).
+	Slug string
+
+	// Agent is the agent template this instance deploys.
+	Agent *agent.Agent
+
+	// Description is a human-readable description of the instance.
+	Description string
+
+	// Env binds the agent's environment variables by name.
+	Env map[string]Value
+
+	// Org is the organization that owns this instance (optional).
+	Org string
+}
+
+// nameRegex matches valid instance names (lowercase alphanumeric with hyphens).
+var nameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// New creates a new AgentInstance with struct-based args (Pulumi pattern).
+//
+// The instance is automatically registered with the provided context for
+// synthesis. Its bindings are checked against the environment variables the
+// agent declares: every required variable without a default must be bound,
+// every binding must name a declared variable, and secret variables must be
+// bound with SecretFromEnv while plain variables must be bound with Literal.
+// Literals are also checked against the variable's declared type.
+//
+// Example:
+//
+//	prod, err := agentinstance.New(ctx, "code-reviewer-prod", &agentinstance.Args{
+//	    Agent: codeReviewer,
+//	    Env: map[string]agentinstance.Value{
+//	        "GITHUB_TOKEN": agentinstance.SecretFromEnv("GITHUB_TOKEN_PROD"),
+//	        "AWS_REGION":   agentinstance.Literal("us-east-1"),
+//	    },
+//	})
+func New(ctx Context, name string, args *Args) (*AgentInstance, error) {
+	if oc, ok := ctx.(openChecker); ok {
+		if err := oc.CheckOpen(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Nil-safety: if args is nil, create empty args
+	if args == nil {
+		args = &Args{}
+	}
+
+	i := &AgentInstance{
+		Name:        name,
+		Slug:        naming.GenerateSlug(name),
+		Agent:       args.Agent,
+		Description: args.Description,
+		Env:         args.Env,
+	}
+
+	if err := i.validate(); err != nil {
+		return nil, err
+	}
+
+	// Register with context (if provided)
+	if ctx != nil {
+		ctx.RegisterAgentInstance(i)
+	}
+
+	return i, nil
+}
+
+// EnvironmentSlug returns the slug of the Environment resource holding the
+// instance's bindings.
+func (i *AgentInstance) EnvironmentSlug() string {
+	return i.Slug + "-env"
+}
+
+// agentSlug returns the slug of the instance's agent.
+func (i *AgentInstance) agentSlug() string {
+	if i.Agent.Slug != "" {
+		return i.Agent.Slug
+	}
+	return naming.GenerateSlug(i.Agent.Name)
+}
+
+// String returns a string representation of the AgentInstance.
+func (i *AgentInstance) String() string {
+	return fmt.Sprintf("AgentInstance(name=%s)", i.Name)
+}
+
+// validate checks the instance name and its bindings.
+func (i *AgentInstance) validate() error {
+	if err := validation.RequiredWithMessage("name", i.Name, "agent instance name is required"); err != nil {
+		return err
+	}
+	if !nameRegex.MatchString(i.Name) || len(i.Name) > 63 {
+		return validation.NewValidationErrorWithCause(
+			"name",
+			i.Name,
+			"format",
+			"agent instance name must be lowercase alphanumeric with hyphens, max 63 characters",
+			ErrInvalidName,
+		)
+	}
+	if i.Agent == nil {
+		return validation.NewValidationErrorWithCause(
+			"agent",
+			"",
+			"required",
+			fmt.Sprintf("agent instance %q requires an agent", i.Name),
+			ErrMissingAgent,
+		)
+	}
+	return i.validateBindings()
+}
+
+// validateBindings checks the bindings against the agent's environment
+// variables, including those of its environment sets. Variables added to the
+// agent after New are checked again at synthesis.
+func (i *AgentInstance) validateBindings() error {
+	variables, err := i.Agent.Environment()
+	if err != nil {
+		return err
+	}
+	return envbinding.Validate(fmt.Sprintf("agent %q", i.Agent.Name), variables, i.Env)
+}
@@ -0,0 +1,284 @@
+package agentinstance
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/environment"
+)
+
+// mockContext records registered agent instances.
+type mockContext struct {
+	instances []*AgentInstance
+}
+
+func (m *mockContext) RegisterAgentInstance(i *AgentInstance) {
+	m.instances = append(m.instances, i)
+}
+
+// newTestAgent returns an agent declaring a required secret GITHUB_TOKEN,
+// an optional AWS_REGION and a typed WORKERS variable.
+func newTestAgent(t *testing.T) *agent.Agent {
+	t.Helper()
+
+	ag, err := agent.New(nil, "code-reviewer", &agent.AgentArgs{
+		Instructions: "Review code and suggest improvements",
+	})
+	if err != nil {
+		t.Fatalf("agent.New() error = %v", err)
+	}
+
+	githubToken, err := environment.New(nil, "GITHUB_TOKEN", &environment.VariableArgs{
+		IsSecret:    true,
+		Description: "GitHub API token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	awsRegion, err := environment.New(nil, "AWS_REGION", &environment.VariableArgs{
+		DefaultValue: "us-west-2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	workers, err := environment.New(nil, "WORKERS", &environment.VariableArgs{
+		DefaultValue: "4",
+	}, environment.WithType(environment.Int, environment.Range(1, 16)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ag.AddEnvironmentVariables(*githubToken, *awsRegion, *workers)
+	return ag
+}
+
+func TestNew(t *testing.T) {
+	ctx := &mockContext{}
+	ag := newTestAgent(t)
+
+	inst, err := New(ctx, "code-reviewer-prod", &Args{
+		Agent:       ag,
+		Description: "Production reviewer",
+		Env: map[string]Value{
+			"GITHUB_TOKEN": SecretFromEnv("GITHUB_TOKEN_PROD"),
+			"AWS_REGION":   Literal("us-east-1"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if len(ctx.instances) != 1 || ctx.instances[0] != inst {
+		t.Errorf("instance not registered with context")
+	}
+	if inst.Slug != "code-reviewer-prod" {
+		t.Errorf("Slug = %q, want code-reviewer-prod", inst.Slug)
+	}
+	if inst.EnvironmentSlug() != "code-reviewer-prod-env" {
+		t.Errorf("EnvironmentSlug() = %q, want code-reviewer-prod-env", inst.EnvironmentSlug())
+	}
+}
+
+func TestNew_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance string
+		args     func(ag *agent.Agent) *Args
+		wantErr  error
+	}{
+		{
+			name:     "invalid name",
+			instance: "Code_Reviewer",
+			args: func(ag *agent.Agent) *Args {
+				return &Args{Agent: ag, Env: map[string]Value{"GITHUB_TOKEN": SecretFromEnv("TOKEN")}}
+			},
+			wantErr: ErrInvalidName,
+		},
+		{
+			name:     "missing agent",
+			instance: "code-reviewer-prod",
+			args:     func(ag *agent.Agent) *Args { return nil },
+			wantErr:  ErrMissingAgent,
+		},
+		{
+			name:     "missing required binding",
+			instance: "code-reviewer-prod",
+			args: func(ag *agent.Agent) *Args {
+				return &Args{Agent: ag, Env: map[string]Value{"AWS_REGION": Literal("us-east-1")}}
+			},
+			wantErr: ErrMissingBinding,
+		},
+		{
+			name:     "unknown binding",
+			instance: "code-reviewer-prod",
+			args: func(ag *agent.Agent) *Args {
+				return &Args{Agent: ag, Env: map[string]Value{
+					"GITHUB_TOKEN": SecretFromEnv("TOKEN"),
+					"GITLAB_TOKEN": SecretFromEnv("TOKEN"),
+				}}
+			},
+			wantErr: ErrUnknownBinding,
+		},
+		{
+			name:     "secret bound to literal",
+			instance: "code-reviewer-prod",
+			args: func(ag *agent.Agent) *Args {
+				return &Args{Agent: ag, Env: map[string]Value{"GITHUB_TOKEN": Literal("ghp_123")}}
+			},
+			wantErr: ErrSecretMismatch,
+		},
+		{
+			name:     "plain variable bound to secret",
+			instance: "code-reviewer-prod",
+			args: func(ag *agent.Agent) *Args {
+				return &Args{Agent: ag, Env: map[string]Value{
+					"GITHUB_TOKEN": SecretFromEnv("TOKEN"),
+					"AWS_REGION":   SecretFromEnv("REGION"),
+				}}
+			},
+			wantErr: ErrSecretMismatch,
+		},
+		{
+			name:     "literal does not match type",
+			instance: "code-reviewer-prod",
+			args: func(ag *agent.Agent) *Args {
+				return &Args{Agent: ag, Env: map[string]Value{
+					"GITHUB_TOKEN": SecretFromEnv("TOKEN"),
+					"WORKERS":      Literal("64"),
+				}}
+			},
+			wantErr: ErrInvalidBinding,
+		},
+		{
+			name:     "invalid secret source",
+			instance: "code-reviewer-prod",
+			args: func(ag *agent.Agent) *Args {
+				return &Args{Agent: ag, Env: map[string]Value{"GITHUB_TOKEN": SecretFromEnv("GITHUB-TOKEN")}}
+			},
+			wantErr: ErrInvalidBinding,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &mockContext{}
+			_, err := New(ctx, tt.instance, tt.args(newTestAgent(t)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("New() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(ctx.instances) != 0 {
+				t.Errorf("invalid instance was registered")
+			}
+		})
+	}
+}
+
+func TestAgentInstanceToProto(t *testing.T) {
+	ag := newTestAgent(t)
+	inst, err := New(nil, "code-reviewer-prod", &Args{
+		Agent:       ag,
+		Description: "Production reviewer",
+		Env: map[string]Value{
+			"GITHUB_TOKEN": SecretFromEnv("GITHUB_TOKEN_PROD"),
+			"AWS_REGION":   Literal("us-east-1"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	instance, env, err := inst.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+
+	spec := instance.GetSpec()
+	if spec.GetAgentId() != "code-reviewer" {
+		t.Errorf("agent_id = %q, want agent slug code-reviewer", spec.GetAgentId())
+	}
+	if spec.GetDescription() != "Production reviewer" {
+		t.Errorf("description = %q", spec.GetDescription())
+	}
+	if len(spec.GetEnvironmentRefs()) != 1 {
+		t.Fatalf("environment_refs = %v, want one reference", spec.GetEnvironmentRefs())
+	}
+	ref := spec.GetEnvironmentRefs()[0]
+	if ref.GetKind() != apiresourcekind.ApiResourceKind_environment || ref.GetSlug() != "code-reviewer-prod-env" {
+		t.Errorf("environment ref = %v, want environment code-reviewer-prod-env", ref)
+	}
+
+	if env.GetMetadata().GetSlug() != "code-reviewer-prod-env" {
+		t.Errorf("environment slug = %q", env.GetMetadata().GetSlug())
+	}
+	data := env.GetSpec().GetData()
+	if got := data["AWS_REGION"]; got.GetValue() != "us-east-1" || got.GetIsSecret() {
+		t.Errorf("AWS_REGION = %v, want plain us-east-1", got)
+	}
+	token := data["GITHUB_TOKEN"]
+	if !token.GetIsSecret() || token.GetValue() != "" {
+		t.Errorf("GITHUB_TOKEN = %v, want an empty secret", token)
+	}
+	if token.GetDescription() != "GitHub API token" {
+		t.Errorf("GITHUB_TOKEN description = %q", token.GetDescription())
+	}
+	if got := env.GetMetadata().GetAnnotations()[AnnotationSecretFromEnvPrefix+"GITHUB_TOKEN"]; got != "GITHUB_TOKEN_PROD" {
+		t.Errorf("secret source annotation = %q, want GITHUB_TOKEN_PROD", got)
+	}
+}
+
+func TestAgentInstanceToProto_RevalidatesBindings(t *testing.T) {
+	ag := newTestAgent(t)
+	inst, err := New(nil, "code-reviewer-prod", &Args{
+		Agent: ag,
+		Env:   map[string]Value{"GITHUB_TOKEN": SecretFromEnv("GITHUB_TOKEN_PROD")},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// A required variable added after the instance was created is still enforced
+	slackToken, err := environment.New(nil, "SLACK_TOKEN", &environment.VariableArgs{IsSecret: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ag.AddEnvironmentVariable(*slackToken)
+
+	if _, _, err := inst.ToProto(); !errors.Is(err, ErrMissingBinding) {
+		t.Errorf("ToProto() error = %v, want %v", err, ErrMissingBinding)
+	}
+}
+
+func TestAgentInstanceToProto_NoBindings(t *testing.T) {
+	ag, err := agent.New(nil, "summarizer", &agent.AgentArgs{
+		Instructions: "Summarize documents concisely",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inst, err := New(nil, "summarizer-prod", &Args{Agent: ag})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	instance, env, err := inst.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if env != nil {
+		t.Errorf("environment = %v, want nil without bindings", env)
+	}
+	if len(instance.GetSpec().GetEnvironmentRefs()) != 0 {
+		t.Errorf("environment_refs = %v, want none", instance.GetSpec().GetEnvironmentRefs())
+	}
+}
+
+func TestValueString(t *testing.T) {
+	if got := SecretFromEnv("GITHUB_TOKEN_PROD").String(); got != "SecretFromEnv(GITHUB_TOKEN_PROD)" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := Literal("us-east-1").String(); got != `Literal("us-east-1")` {
+		t.Errorf("String() = %q", got)
+	}
+}
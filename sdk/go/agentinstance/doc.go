@@ -0,0 +1,37 @@
+// Package agentinstance provides the AgentInstance builder for deploying
+// agent templates with their environment variables bound.
+//
+// An Agent declares the environment variables it needs; an AgentInstance
+// supplies them for one deployment (for example, production):
+//
+//	githubToken, _ := environment.New(ctx, "GITHUB_TOKEN", &environment.VariableArgs{
+//	    IsSecret: true,
+//	})
+//	awsRegion, _ := environment.New(ctx, "AWS_REGION", nil)
+//	codeReviewer.AddEnvironmentVariables(*githubToken, *awsRegion)
+//
+//	_, err := agentinstance.New(ctx, "code-reviewer-prod", &agentinstance.Args{
+//	    Agent: codeReviewer,
+//	    Env: map[string]agentinstance.Value{
+//	        "GITHUB_TOKEN": agentinstance.SecretFromEnv("GITHUB_TOKEN_PROD"),
+//	        "AWS_REGION":   agentinstance.Literal("us-east-1"),
+//	    },
+//	})
+//
+// # Validation
+//
+// Bindings are checked against the agent's variables when the instance is
+// created and again at synthesis:
+//   - Required variables without a default must be bound
+//   - Bindings must name variables the agent declares
+//   - Secret variables must use SecretFromEnv, plain variables Literal
+//   - Literals must match the variable's declared type
+//
+// # Synthesis
+//
+// Each instance is synthesized into two manifests: an Environment named
+// "{instance}-env" holding the bindings, and the AgentInstance referencing
+// it. `stigmer apply` deploys them after the agents, filling in the agent ID
+// and reading SecretFromEnv values from its own environment, so secrets are
+// never written to the manifests.
+package agentinstance
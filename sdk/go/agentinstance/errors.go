@@ -0,0 +1,32 @@
+package agentinstance
+
+import (
+	"errors"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/envbinding"
+)
+
+// Common errors that can occur when working with agent instances.
+var (
+	// ErrInvalidName is returned when an agent instance name is invalid.
+	ErrInvalidName = errors.New("invalid agent instance name")
+
+	// ErrMissingAgent is returned when no agent is given.
+	ErrMissingAgent = errors.New("missing agent")
+
+	// ErrMissingBinding is returned when a required agent environment
+	// variable without a default value is not bound.
+	ErrMissingBinding = envbinding.ErrMissingBinding
+
+	// ErrUnknownBinding is returned when a binding names a variable the
+	// agent does not declare.
+	ErrUnknownBinding = envbinding.ErrUnknownBinding
+
+	// ErrSecretMismatch is returned when a secret variable is bound to a
+	// literal, or a plain variable to a secret.
+	ErrSecretMismatch = envbinding.ErrSecretMismatch
+
+	// ErrInvalidBinding is returned when a literal does not match the
+	// variable's declared type, or a secret names an invalid variable.
+	ErrInvalidBinding = envbinding.ErrInvalidBinding
+)
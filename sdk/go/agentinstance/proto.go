@@ -0,0 +1,101 @@
+package agentinstance
+
+import (
+	"fmt"
+
+	"buf.build/go/protovalidate"
+
+	agentinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentinstance/v1"
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/environment"
+	"github.com/stigmer/stigmer/sdk/go/internal/envbinding"
+)
+
+// AnnotationSecretFromEnvPrefix prefixes the Environment annotations that
+// record where a secret binding is read from. The annotation
+// "stigmer.ai/secret-from-env.GITHUB_TOKEN" = "GITHUB_TOKEN_PROD" tells the
+// CLI to fill GITHUB_TOKEN from $GITHUB_TOKEN_PROD when deploying.
+const AnnotationSecretFromEnvPrefix = envbinding.AnnotationSecretFromEnvPrefix
+
+// validator is the global protovalidate validator instance.
+var validator protovalidate.Validator
+
+func init() {
+	// Initialize validator once at package load time
+	var err error
+	validator, err = protovalidate.New()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize protovalidate: %v", err))
+	}
+}
+
+// ToProto converts the SDK AgentInstance to platform AgentInstance and
+// Environment proto messages.
+//
+// The Environment holds the bindings and is nil when there are none. Secret
+// values are left empty and recorded as AnnotationSecretFromEnvPrefix
+// annotations instead.
+//
+// The agent is not deployed yet at synthesis, so spec.agent_id holds the
+// agent's slug. The CLI replaces it with the deployed agent's ID.
+func (i *AgentInstance) ToProto() (*agentinstancev1.AgentInstance, *environmentv1.Environment, error) {
+	// Bindings are checked again for variables added to the agent after New
+	if err := i.validateBindings(); err != nil {
+		return nil, nil, err
+	}
+
+	instance := &agentinstancev1.AgentInstance{
+		ApiVersion: "agentic.stigmer.ai/v1",
+		Kind:       "AgentInstance",
+		Metadata: &apiresource.ApiResourceMetadata{
+			Name:        i.Name,
+			Slug:        i.Slug,
+			Annotations: agent.SDKAnnotations(),
+			OwnerScope:  apiresource.ApiResourceOwnerScope_organization,
+		},
+		Spec: &agentinstancev1.AgentInstanceSpec{
+			AgentId:     i.agentSlug(),
+			Description: i.Description,
+		},
+	}
+
+	var env *environmentv1.Environment
+	if len(i.Env) > 0 {
+		variables, err := i.Agent.Environment()
+		if err != nil {
+			return nil, nil, err
+		}
+		env = i.environmentProto(variables)
+		instance.Spec.EnvironmentRefs = []*apiresource.ApiResourceReference{
+			{
+				Scope: apiresource.ApiResourceOwnerScope_organization,
+				Kind:  apiresourcekind.ApiResourceKind_environment,
+				Slug:  env.Metadata.Slug,
+			},
+		}
+		if err := validator.Validate(env); err != nil {
+			return nil, nil, fmt.Errorf("environment validation failed: %w", err)
+		}
+	}
+
+	if err := validator.Validate(instance); err != nil {
+		return nil, nil, fmt.Errorf("agent instance validation failed: %w", err)
+	}
+
+	return instance, env, nil
+}
+
+// environmentProto builds the Environment holding the instance's bindings
+// of the agent's variables.
+func (i *AgentInstance) environmentProto(variables []environment.Variable) *environmentv1.Environment {
+	return envbinding.Environment(
+		i.EnvironmentSlug(),
+		fmt.Sprintf("Environment bindings for agent instance %s", i.Name),
+		variables,
+		i.Env,
+		agent.SDKAnnotations(),
+	)
+}
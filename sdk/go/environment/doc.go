@@ -33,6 +33,20 @@
 //   - Required (default): Must be provided at AgentInstance creation
 //   - Optional: Can use default value if not provided
 //
+// # Typed Values
+//
+// Variables can declare the kind of value they hold with WithType. Default
+// values are checked when the variable is created, and the server rejects
+// AgentInstance and WorkflowInstance creation when a referenced environment
+// supplies a value that does not match:
+//
+//	workers, err := environment.New(ctx, "WORKERS", &environment.VariableArgs{
+//	    DefaultValue: "4",
+//	}, environment.WithType(environment.Int, environment.Range(1, 16)))
+//
+//	mode, err := environment.New(ctx, "REVIEW_MODE", nil,
+//	    environment.WithType(environment.Enum("fast", "thorough")))
+//
 // # Integration with Agent
 //
 // Add environment variables to agents using builder methods:
@@ -43,6 +57,20 @@
 //	agent.AddEnvironmentVariable(githubToken)
 //	agent.AddEnvironmentVariable(region)
 //
+// # Shared Sets
+//
+// Agents that need the same variables can share one definition with a Set.
+// Each agent declares the set's variables in its own spec at synthesis:
+//
+//	githubStack, err := environment.NewSet("github-stack", githubToken, region)
+//
+//	reviewer, err := agent.New(ctx, "pr-reviewer", reviewerArgs, agent.WithEnvironmentSet(githubStack))
+//	triager, err := agent.New(ctx, "issue-triager", triagerArgs, agent.WithEnvironmentSet(githubStack))
+//
+// A variable both in a set and added to the agent is declared once when the
+// definitions are identical; different definitions fail with
+// ErrConflictingVariable.
+//
 // # Proto Conversion
 //
 // The package converts to protobuf EnvironmentSpec messages:
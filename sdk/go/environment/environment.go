@@ -81,6 +81,10 @@ type Variable struct {
 	// Required indicates whether this variable must be provided.
 	// Required variables without a default value must be provided at AgentInstance creation.
 	Required bool
+
+	// Type declares the kind and constraints of the value (nil accepts any string).
+	// Set it with WithType.
+	Type *ValueType
 }
 
 // envVarNameRegex matches valid environment variable names.
@@ -101,6 +105,9 @@ var envVarNameRegex = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
 //   - DefaultValue: default value (makes the variable optional)
 //   - Required: whether the variable is required (defaults to true)
 //
+// Options:
+//   - WithType: declares the value type (int, bool, enum) and its constraints
+//
 // Example:
 //
 //	githubToken, err := environment.New(ctx, "GITHUB_TOKEN", &environment.VariableArgs{
@@ -121,7 +128,13 @@ var envVarNameRegex = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
 // Example with nil args (creates required variable):
 //
 //	apiKey, err := environment.New(ctx, "API_KEY", nil)
-func New(ctx Context, name string, args *VariableArgs) (*Variable, error) {
+//
+// Example with a typed value:
+//
+//	workers, err := environment.New(ctx, "WORKERS", &environment.VariableArgs{
+//	    DefaultValue: "4",
+//	}, environment.WithType(environment.Int, environment.Range(1, 16)))
+func New(ctx Context, name string, args *VariableArgs, opts ...VariableOption) (*Variable, error) {
 	// Nil-safety: if args is nil, create empty args
 	if args == nil {
 		args = &VariableArgs{}
@@ -144,6 +157,9 @@ func New(ctx Context, name string, args *VariableArgs) (*Variable, error) {
 		DefaultValue: args.DefaultValue,
 		Required:     required,
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
 
 	// Validate the variable
 	if err := validate(v); err != nil {
@@ -184,5 +200,5 @@ func validate(v *Variable) error {
 		)
 	}
 
-	return nil
+	return validateType(v)
 }
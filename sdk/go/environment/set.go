@@ -0,0 +1,127 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// ErrConflictingVariable is returned when two different definitions of the
+// same environment variable are combined, such as a variable of a Set and a
+// variable added to the same agent.
+var ErrConflictingVariable = errors.New("conflicting environment variable definitions")
+
+// Set is a named group of environment variables declared once and shared by
+// several agents, so their names, descriptions and defaults stay in sync.
+//
+// A set is not a resource: each agent it is added to declares its variables
+// in its own environment spec at synthesis.
+type Set struct {
+	// Name identifies the set in error messages (e.g., "github-stack").
+	Name string
+
+	// Variables are the variables of the set, in declaration order.
+	Variables []Variable
+}
+
+// NewSet creates a set of environment variables.
+//
+// A variable passed more than once is kept once; two different definitions
+// of the same variable name fail with ErrConflictingVariable.
+//
+// Example:
+//
+//	githubStack, err := environment.NewSet("github-stack", githubToken, awsRegion)
+//	if err != nil {
+//	    return err
+//	}
+//
+//	reviewer, err := agent.New(ctx, "reviewer", args, agent.WithEnvironmentSet(githubStack))
+func NewSet(name string, variables ...*Variable) (*Set, error) {
+	if err := validation.RequiredWithMessage("name", name, "environment set name is required"); err != nil {
+		return nil, err
+	}
+
+	set := &Set{Name: name}
+	for i, v := range variables {
+		if v == nil {
+			return nil, validation.NewValidationErrorWithCause(
+				validation.FieldPath("variables", i),
+				"",
+				"required",
+				fmt.Sprintf("environment set %q: variable %d is nil", name, i),
+				validation.ErrRequired,
+			)
+		}
+		variables, err := MergeVariables(set.Variables, []Variable{*v}, fmt.Sprintf("environment set %q", name))
+		if err != nil {
+			return nil, err
+		}
+		set.Variables = variables
+	}
+	return set, nil
+}
+
+// Equal reports whether two variables have the same definition.
+func (v Variable) Equal(other Variable) bool {
+	return reflect.DeepEqual(v, other)
+}
+
+// MergeVariables appends the variables of added to variables, keeping a
+// variable once when it is defined identically in both. A variable of added
+// whose name is defined differently fails with ErrConflictingVariable; source
+// names where the added variables come from in the error message.
+func MergeVariables(variables, added []Variable, source string) ([]Variable, error) {
+	result := append([]Variable{}, variables...)
+	index := make(map[string]int, len(result))
+	for i, v := range result {
+		index[v.Name] = i
+	}
+
+	for _, v := range added {
+		i, ok := index[v.Name]
+		if !ok {
+			index[v.Name] = len(result)
+			result = append(result, v)
+			continue
+		}
+		if !result[i].Equal(v) {
+			return nil, validation.NewValidationErrorWithCause(
+				"name",
+				v.Name,
+				"unique",
+				fmt.Sprintf("%s defines environment variable %s differently: %s",
+					source, v.Name, describeConflict(result[i], v)),
+				ErrConflictingVariable,
+			)
+		}
+	}
+	return result, nil
+}
+
+// describeConflict names the first field on which two definitions of a
+// variable differ.
+func describeConflict(a, b Variable) string {
+	switch {
+	case a.IsSecret != b.IsSecret:
+		return fmt.Sprintf("secret %t vs %t", a.IsSecret, b.IsSecret)
+	case a.Required != b.Required:
+		return fmt.Sprintf("required %t vs %t", a.Required, b.Required)
+	case a.DefaultValue != b.DefaultValue && a.IsSecret:
+		return "different default values"
+	case a.DefaultValue != b.DefaultValue:
+		return fmt.Sprintf("default %q vs %q", a.DefaultValue, b.DefaultValue)
+	case a.Description != b.Description:
+		return fmt.Sprintf("description %q vs %q", a.Description, b.Description)
+	}
+	return fmt.Sprintf("type %s vs %s", typeString(a.Type), typeString(b.Type))
+}
+
+func typeString(t *ValueType) string {
+	if t == nil {
+		return "any"
+	}
+	return t.String()
+}
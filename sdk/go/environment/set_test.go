@@ -0,0 +1,58 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewSet(t *testing.T) {
+	ctx := &mockContext{}
+
+	token, _ := New(ctx, "GITHUB_TOKEN", &VariableArgs{IsSecret: true, Description: "GitHub API token"})
+	region, _ := New(ctx, "AWS_REGION", &VariableArgs{DefaultValue: "us-east-1"})
+	sameToken, _ := New(ctx, "GITHUB_TOKEN", &VariableArgs{IsSecret: true, Description: "GitHub API token"})
+
+	set, err := NewSet("github-stack", token, region, sameToken)
+	if err != nil {
+		t.Fatalf("NewSet() error = %v", err)
+	}
+	if set.Name != "github-stack" {
+		t.Errorf("Name = %q, want github-stack", set.Name)
+	}
+	if len(set.Variables) != 2 || set.Variables[0].Name != "GITHUB_TOKEN" || set.Variables[1].Name != "AWS_REGION" {
+		t.Errorf("Variables = %v, want GITHUB_TOKEN and AWS_REGION once each", set.Variables)
+	}
+}
+
+func TestNewSet_Errors(t *testing.T) {
+	ctx := &mockContext{}
+
+	token, _ := New(ctx, "GITHUB_TOKEN", &VariableArgs{IsSecret: true})
+	plainToken, _ := New(ctx, "GITHUB_TOKEN", nil)
+	workers, _ := New(ctx, "WORKERS", &VariableArgs{DefaultValue: "4"}, WithType(Int))
+	untypedWorkers, _ := New(ctx, "WORKERS", &VariableArgs{DefaultValue: "4"})
+
+	tests := []struct {
+		name      string
+		setName   string
+		variables []*Variable
+		wantErr   error
+	}{
+		{"missing name", "", []*Variable{token}, nil},
+		{"nil variable", "stack", []*Variable{token, nil}, nil},
+		{"conflicting secrecy", "stack", []*Variable{token, plainToken}, ErrConflictingVariable},
+		{"conflicting type", "stack", []*Variable{workers, untypedWorkers}, ErrConflictingVariable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSet(tt.setName, tt.variables...)
+			if err == nil {
+				t.Fatal("NewSet() error = nil, want an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewSet() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package environment
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// ValueKind is the kind of value an environment variable holds.
+type ValueKind string
+
+const (
+	// KindString accepts any string (the default for untyped variables).
+	KindString ValueKind = "string"
+
+	// KindInt accepts base-10 integers, optionally bounded with Range.
+	KindInt ValueKind = "int"
+
+	// KindBool accepts "true" or "false".
+	KindBool ValueKind = "bool"
+
+	// KindEnum accepts one of a fixed set of values (see Enum).
+	KindEnum ValueKind = "enum"
+)
+
+// ValueType declares the kind and constraints of an environment variable value.
+//
+// Values supplied by the environments referenced when an AgentInstance or
+// WorkflowInstance is created are validated against it by the server.
+// Use WithType to set it on a variable.
+type ValueType struct {
+	// Kind is the kind of value.
+	Kind ValueKind
+
+	// Min is the inclusive lower bound of KindInt values (nil for none).
+	Min *int64
+
+	// Max is the inclusive upper bound of KindInt values (nil for none).
+	Max *int64
+
+	// AllowedValues lists the values accepted by KindEnum.
+	AllowedValues []string
+}
+
+// Predefined value types for WithType.
+var (
+	// String accepts any string.
+	String = ValueType{Kind: KindString}
+
+	// Int accepts base-10 integers. Combine with Range to bound them.
+	Int = ValueType{Kind: KindInt}
+
+	// Bool accepts "true" or "false".
+	Bool = ValueType{Kind: KindBool}
+)
+
+// Enum returns a value type accepting only the given values.
+//
+// Example:
+//
+//	environment.WithType(environment.Enum("fast", "thorough"))
+func Enum(values ...string) ValueType {
+	return ValueType{Kind: KindEnum, AllowedValues: slices.Clone(values)}
+}
+
+// TypeConstraint narrows a ValueType passed to WithType.
+type TypeConstraint func(*ValueType)
+
+// Range bounds KindInt values to [min, max], inclusive.
+//
+// Example:
+//
+//	environment.WithType(environment.Int, environment.Range(1, 16))
+func Range(min, max int64) TypeConstraint {
+	return func(t *ValueType) {
+		t.Min = &min
+		t.Max = &max
+	}
+}
+
+// VariableOption configures a Variable created with New.
+type VariableOption func(*Variable)
+
+// WithType declares the type of a variable's value. The default value is
+// checked against it when the variable is created, and values supplied at
+// instance creation are checked by the server.
+//
+// Example:
+//
+//	workers, err := environment.New(ctx, "WORKERS", &environment.VariableArgs{
+//	    DefaultValue: "4",
+//	}, environment.WithType(environment.Int, environment.Range(1, 16)))
+//
+//	mode, err := environment.New(ctx, "REVIEW_MODE", nil,
+//	    environment.WithType(environment.Enum("fast", "thorough")))
+func WithType(valueType ValueType, constraints ...TypeConstraint) VariableOption {
+	return func(v *Variable) {
+		t := valueType
+		t.AllowedValues = slices.Clone(valueType.AllowedValues)
+		for _, constraint := range constraints {
+			constraint(&t)
+		}
+		v.Type = &t
+	}
+}
+
+// Check reports whether value is valid for the type.
+// The returned error names the violated constraint.
+func (t *ValueType) Check(value string) error {
+	if t == nil {
+		return nil
+	}
+
+	switch t.Kind {
+	case KindInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		if (t.Min != nil && n < *t.Min) || (t.Max != nil && n > *t.Max) {
+			return fmt.Errorf("%d is outside the range %s", n, t.rangeString())
+		}
+	case KindBool:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%q is not a boolean (true or false)", value)
+		}
+	case KindEnum:
+		if !slices.Contains(t.AllowedValues, value) {
+			return fmt.Errorf("%q is not one of [%s]", value, strings.Join(t.AllowedValues, ", "))
+		}
+	}
+	return nil
+}
+
+// ToProto converts the type to its proto representation.
+func (t *ValueType) ToProto() *environmentv1.EnvironmentValueType {
+	if t == nil {
+		return nil
+	}
+
+	kind := environmentv1.EnvironmentValueKind_ENV_VALUE_STRING
+	switch t.Kind {
+	case KindInt:
+		kind = environmentv1.EnvironmentValueKind_ENV_VALUE_INT
+	case KindBool:
+		kind = environmentv1.EnvironmentValueKind_ENV_VALUE_BOOL
+	case KindEnum:
+		kind = environmentv1.EnvironmentValueKind_ENV_VALUE_ENUM
+	}
+
+	return &environmentv1.EnvironmentValueType{
+		Kind:          kind,
+		Min:           t.Min,
+		Max:           t.Max,
+		AllowedValues: slices.Clone(t.AllowedValues),
+	}
+}
+
+// String returns the type with its constraints, e.g. "int[1..16]".
+func (t ValueType) String() string {
+	switch {
+	case t.Kind == KindInt && (t.Min != nil || t.Max != nil):
+		return string(t.Kind) + t.rangeString()
+	case t.Kind == KindEnum:
+		return fmt.Sprintf("enum(%s)", strings.Join(t.AllowedValues, ", "))
+	}
+	return string(t.Kind)
+}
+
+// rangeString formats the bounds of an int type, e.g. "[1..16]".
+func (t *ValueType) rangeString() string {
+	bound := func(b *int64) string {
+		if b == nil {
+			return ""
+		}
+		return strconv.FormatInt(*b, 10)
+	}
+	return fmt.Sprintf("[%s..%s]", bound(t.Min), bound(t.Max))
+}
+
+// validateType validates the declared type of a variable and its default value.
+func validateType(v *Variable) error {
+	t := v.Type
+	if t == nil {
+		return nil
+	}
+
+	switch t.Kind {
+	case KindString, KindInt, KindBool, KindEnum:
+	default:
+		return validation.NewValidationErrorWithCause(
+			"type", string(t.Kind), "enum",
+			fmt.Sprintf("environment variable %s: unknown value kind %q", v.Name, t.Kind),
+			validation.ErrInvalidEnum,
+		)
+	}
+
+	if (t.Min != nil || t.Max != nil) && t.Kind != KindInt {
+		return validation.NewValidationErrorWithCause(
+			"type", t.String(), "range",
+			fmt.Sprintf("environment variable %s: Range only applies to int variables", v.Name),
+			validation.ErrInvalidFormat,
+		)
+	}
+	if t.Min != nil && t.Max != nil && *t.Min > *t.Max {
+		return validation.NewValidationErrorWithCause(
+			"type", t.String(), "range",
+			fmt.Sprintf("environment variable %s: range minimum %d exceeds maximum %d", v.Name, *t.Min, *t.Max),
+			validation.ErrOutOfRange,
+		)
+	}
+	if t.Kind == KindEnum && len(t.AllowedValues) == 0 {
+		return validation.NewValidationErrorWithCause(
+			"type", t.String(), "required",
+			fmt.Sprintf("environment variable %s: enum requires at least one allowed value", v.Name),
+			validation.ErrRequired,
+		)
+	}
+
+	if v.DefaultValue != "" {
+		if err := t.Check(v.DefaultValue); err != nil {
+			return validation.NewValidationErrorWithCause(
+				"default_value", v.DefaultValue, string(t.Kind),
+				fmt.Sprintf("environment variable %s: default value %v", v.Name, err),
+				validation.ErrInvalidFormat,
+			)
+		}
+	}
+	return nil
+}
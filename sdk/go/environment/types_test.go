@@ -0,0 +1,136 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+func TestNew_WithType(t *testing.T) {
+	ctx := &mockContext{}
+
+	tests := []struct {
+		name    string
+		args    *VariableArgs
+		opt     VariableOption
+		wantErr error
+	}{
+		{
+			name: "int in range",
+			args: &VariableArgs{DefaultValue: "4"},
+			opt:  WithType(Int, Range(1, 16)),
+		},
+		{
+			name:    "int out of range",
+			args:    &VariableArgs{DefaultValue: "32"},
+			opt:     WithType(Int, Range(1, 16)),
+			wantErr: validation.ErrInvalidFormat,
+		},
+		{
+			name:    "not an int",
+			args:    &VariableArgs{DefaultValue: "four"},
+			opt:     WithType(Int),
+			wantErr: validation.ErrInvalidFormat,
+		},
+		{
+			name: "bool",
+			args: &VariableArgs{DefaultValue: "true"},
+			opt:  WithType(Bool),
+		},
+		{
+			name:    "invalid bool",
+			args:    &VariableArgs{DefaultValue: "yes"},
+			opt:     WithType(Bool),
+			wantErr: validation.ErrInvalidFormat,
+		},
+		{
+			name: "enum without default",
+			opt:  WithType(Enum("fast", "thorough")),
+		},
+		{
+			name:    "enum default not allowed",
+			args:    &VariableArgs{DefaultValue: "slow"},
+			opt:     WithType(Enum("fast", "thorough")),
+			wantErr: validation.ErrInvalidFormat,
+		},
+		{
+			name:    "empty enum",
+			opt:     WithType(Enum()),
+			wantErr: validation.ErrRequired,
+		},
+		{
+			name:    "inverted range",
+			opt:     WithType(Int, Range(16, 1)),
+			wantErr: validation.ErrOutOfRange,
+		},
+		{
+			name:    "range on non-int",
+			opt:     WithType(String, Range(1, 16)),
+			wantErr: validation.ErrInvalidFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := New(ctx, "WORKERS", tt.args, tt.opt)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("New() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() unexpected error = %v", err)
+			}
+			if v.Type == nil {
+				t.Fatal("New().Type is nil")
+			}
+		})
+	}
+}
+
+func TestWithType_DoesNotShareState(t *testing.T) {
+	ctx := &mockContext{}
+
+	a, err := New(ctx, "A", nil, WithType(Int, Range(1, 2)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	b, err := New(ctx, "B", nil, WithType(Int))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if a.Type.Max == nil || *a.Type.Max != 2 {
+		t.Errorf("A max = %v, want 2", a.Type.Max)
+	}
+	if b.Type.Min != nil || b.Type.Max != nil || Int.Min != nil {
+		t.Error("Range should not modify the shared Int type")
+	}
+}
+
+func TestValueType_ToProto(t *testing.T) {
+	var untyped *ValueType
+	if untyped.ToProto() != nil {
+		t.Error("nil type should convert to nil")
+	}
+
+	v, err := New(&mockContext{}, "WORKERS", nil, WithType(Int, Range(1, 16)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	got := v.Type.ToProto()
+	if got.GetKind() != environmentv1.EnvironmentValueKind_ENV_VALUE_INT {
+		t.Errorf("kind = %v, want ENV_VALUE_INT", got.GetKind())
+	}
+	if got.GetMin() != 1 || got.GetMax() != 16 {
+		t.Errorf("range = [%d, %d], want [1, 16]", got.GetMin(), got.GetMax())
+	}
+
+	enum := Enum("fast", "thorough")
+	got = enum.ToProto()
+	if got.GetKind() != environmentv1.EnvironmentValueKind_ENV_VALUE_ENUM || len(got.GetAllowedValues()) != 2 {
+		t.Errorf("enum proto = %v", got)
+	}
+}
@@ -42,11 +42,17 @@ func main() {
 			return fmt.Errorf("failed to create full agent: %w", err)
 		}
 
+		// Keep only the last 20 turns of conversation history
+		if err := fullAgent.WithMemory(agent.MemoryWindow(20)); err != nil {
+			return fmt.Errorf("failed to set memory: %w", err)
+		}
+
 		fmt.Println("\n✅ Created full agent:")
 		fmt.Printf("   Name: %s\n", fullAgent.Name)
 		fmt.Printf("   Instructions: %s\n", fullAgent.Instructions)
 		fmt.Printf("   Description: %s\n", fullAgent.Description)
 		fmt.Printf("   IconURL: %s\n", fullAgent.IconURL)
+		fmt.Printf("   Memory: %s\n", fullAgent.Memory)
 
 		// Example of validation error
 		fmt.Println("\n❌ Attempting to create invalid agent:")
@@ -3,7 +3,8 @@
 // Example 04: Agent with Sub-Agents
 //
 // This example demonstrates how to create agents with sub-agents.
-// Sub-agents are defined inline within the parent agent spec.
+// Sub-agents are defined inline within the parent agent spec, or reference
+// an AgentInstance deployed separately, pinned to a version.
 //
 // Run: go run examples/04_agent_with_subagents.go
 package main
@@ -66,7 +67,7 @@ func main() {
 	}
 }
 
-// Example 1: Simple sub-agent
+// Example 1: Simple sub-agent, plus a referenced one pinned to a version
 func createSimpleAgentWithSubAgent(ctx *stigmer.Context) (*agent.Agent, error) {
 	// Create sub-agent using struct args pattern
 	securityScanner, err := subagent.New("security-scanner", &subagent.Args{
@@ -85,8 +86,12 @@ func createSimpleAgentWithSubAgent(ctx *stigmer.Context) (*agent.Agent, error) {
 		return nil, fmt.Errorf("failed to create agent: %w", err)
 	}
 
-	// Add sub-agent using builder method
-	ag.AddSubAgent(securityScanner)
+	// Reference a deployed AgentInstance. Pinning the "stable" tag keeps this
+	// agent's behavior unchanged when the instance is redeployed.
+	dependencyAuditor := subagent.Reference("dependency-auditor", "dependency-auditor-prod", "stable")
+
+	// Add sub-agents using builder method
+	ag.AddSubAgents(securityScanner, dependencyAuditor)
 	return ag, nil
 }
 
@@ -289,6 +294,10 @@ func printAgent(title string, ag *agent.Agent) {
 	fmt.Printf("Sub-Agents: %d\n", len(ag.SubAgents))
 
 	for i, sub := range ag.SubAgents {
+		if sub.IsReference() {
+			fmt.Printf("  [%d] %s\n", i+1, sub)
+			continue
+		}
 		fmt.Printf("  [%d] %s: %s\n", i+1, sub.Name(), sub.Description())
 	}
 
@@ -6,9 +6,12 @@
 //  1. Organizing large instructions as Go variables (better code organization)
 //  2. Referencing skills that are managed separately
 //  3. Using multi-line strings for complex agent instructions
+//  4. Embedding instruction files with //go:embed, so a compiled synthesis
+//     binary works from any working directory
+//  5. Defining small skills inline with skill.New, synthesized as skill
+//     manifests that agents reference by slug
 //
-// IMPORTANT: The SDK references skills - it doesn't create them.
-// To use custom skills:
+// Larger skills are usually managed separately:
 //  1. Create skill content files (e.g., security-guidelines.md)
 //  2. Push skills via CLI: stigmer skill push security-guidelines.md
 //  3. Reference skills in your agent using skillref.Platform() or skillref.Organization()
@@ -18,10 +21,12 @@
 package main
 
 import (
+	"embed"
 	"fmt"
 	"log"
 
 	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/skill"
 	"github.com/stigmer/stigmer/sdk/go/skillref"
 	"github.com/stigmer/stigmer/sdk/go/stigmer"
 )
@@ -64,15 +69,24 @@ Leverage your skill references for detailed guidelines on security and testing.
 Always explain the "why" behind your recommendations.`
 )
 
+// instructionFiles holds the instruction files compiled into the binary.
+// Unlike WithInstructionsFromFile, which reads relative to the working
+// directory, WithInstructionsFromFS keeps working when the synthesis program
+// is built once and run from elsewhere (e.g. a CI temp directory).
+//
+//go:embed instructions/code-reviewer.md
+var instructionFiles embed.FS
+
 // =============================================================================
-// Skill Content (for reference - created via CLI)
+// Skill Content (inline skills)
 // =============================================================================
-// These markdown strings show what the skill content might look like.
-// In practice, save these to .md files and push via: stigmer skill push <file>
+// These markdown strings are synthesized as inline skills in Example 4.
+// Larger skills are better saved to .md files and pushed via:
+// stigmer skill push <file>
 
 var (
-	// This would be saved to security-guidelines.md and pushed as a skill
-	_ = `# Security Review Guidelines
+	// securityGuidelines is the content of the security-guidelines skill
+	securityGuidelines = `# Security Review Guidelines
 
 ## Key Security Checks
 
@@ -91,8 +105,8 @@ var (
    - Check for sensitive data exposure
    - Verify secure communication`
 
-	// This would be saved to testing-best-practices.md and pushed as a skill
-	_ = `# Testing Best Practices
+	// testingBestPractices is the content of the testing-best-practices skill
+	testingBestPractices = `# Testing Best Practices
 
 ## Testing Standards
 
@@ -136,6 +150,26 @@ func main() {
 		}
 		printAgent("2. Agent with Skill References", agentWithSkills)
 
+		// =============================================================================
+		// Example 3: Agent with instructions embedded from a file
+		// =============================================================================
+		embeddedAgent, err := createAgentWithEmbeddedInstructions(ctx)
+		if err != nil {
+			return err
+		}
+		printAgent("3. Agent with Embedded Instructions", embeddedAgent)
+
+		// =============================================================================
+		// Example 4: Agent with inline skills
+		// =============================================================================
+		// Inline skills are written to skill-{n}.pb and referenced by slug,
+		// so the markdown is not copied into the agent manifest.
+		inlineAgent, err := createAgentWithInlineSkills(ctx)
+		if err != nil {
+			return err
+		}
+		printAgent("4. Agent with Inline Skills", inlineAgent)
+
 		// =============================================================================
 		// Summary
 		// =============================================================================
@@ -143,6 +177,8 @@ func main() {
 		fmt.Println("This example demonstrates:")
 		fmt.Println("  1. Organizing instructions as Go variables (code organization)")
 		fmt.Println("  2. Referencing skills that are managed separately")
+		fmt.Println("  3. Embedding instruction files with //go:embed")
+		fmt.Println("  4. Defining small skills inline with skill.New")
 		fmt.Println()
 		fmt.Println("Skill management workflow:")
 		fmt.Println("  1. Create skill content as .md files")
@@ -197,6 +233,41 @@ func createAgentWithSkillRefs(ctx *stigmer.Context) (*agent.Agent, error) {
 	return ag, nil
 }
 
+// createAgentWithInlineSkills creates an agent using skills defined in this
+// program. Synthesis emits each skill once as a skill manifest.
+func createAgentWithInlineSkills(ctx *stigmer.Context) (*agent.Agent, error) {
+	security, err := skill.New("security-guidelines", skill.WithMarkdown(securityGuidelines))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create skill: %w", err)
+	}
+	testingSkill, err := skill.New("testing-best-practices", skill.WithMarkdown(testingBestPractices))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create skill: %w", err)
+	}
+
+	ag, err := agent.New(ctx, "inline-skills-reviewer", &agent.AgentArgs{
+		Instructions: seniorReviewerInstructions,
+		Description:  "Senior code reviewer with skills defined in code",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent: %w", err)
+	}
+	ag.AddSkill(security).AddSkill(testingSkill)
+	return ag, nil
+}
+
+// createAgentWithEmbeddedInstructions creates an agent whose instructions
+// are read from the embedded instructions/code-reviewer.md.
+func createAgentWithEmbeddedInstructions(ctx *stigmer.Context) (*agent.Agent, error) {
+	ag, err := agent.New(ctx, "embedded-reviewer", &agent.AgentArgs{
+		Description: "Code reviewer with instructions compiled into the binary",
+	}, agent.WithInstructionsFromFS(instructionFiles, "instructions/code-reviewer.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent: %w", err)
+	}
+	return ag, nil
+}
+
 // printAgent displays agent information for demonstration.
 func printAgent(title string, ag *agent.Agent) {
 	fmt.Printf("\n%s\n", title)
@@ -205,6 +276,7 @@ func printAgent(title string, ag *agent.Agent) {
 	fmt.Printf("Description: %s\n", ag.Description)
 	fmt.Printf("Instructions Length: %d characters\n", len(ag.Instructions))
 	fmt.Printf("Skill Refs: %d\n", len(ag.SkillRefs))
+	fmt.Printf("Inline Skills: %d\n", len(ag.Skills))
 
 	// Show skill refs if any
 	if len(ag.SkillRefs) > 0 {
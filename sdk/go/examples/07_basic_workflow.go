@@ -74,7 +74,7 @@ func main() {
 		// Clear origin: title, body, state, and author come from fetchTask
 		// Note: Map values require .Expression() (smart conversion only works for top-level fields)
 		processTask := wf.Set("processResponse", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"prTitle":  fetchTask.Field("title").Expression(),      // ✅ Clear: PR title from fetchTask!
 				"prBody":   fetchTask.Field("body").Expression(),       // ✅ Clear: PR description from fetchTask!
 				"prState":  fetchTask.Field("state").Expression(),      // ✅ PR state (open/closed)
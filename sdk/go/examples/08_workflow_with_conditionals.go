@@ -39,7 +39,7 @@ func main() {
 
 		// Task 1: Check pull request status from hello-stigmer repository
 		checkTask := wf.HttpGet("checkPullRequest",
-			apiBase.Concat("/repos/stigmer/hello-stigmer/pulls/1"),
+			apiBase.Concat("/repos/stigmer/hello-stigmer/pulls/1").StringExpression(),
 			map[string]string{
 				"Accept":     "application/vnd.github.v3+json",
 				"User-Agent": "Stigmer-SDK-Example",
@@ -72,7 +72,7 @@ func main() {
 		// Task 3a: Production deployment (PR is closed/merged)
 		// Note: Map values require .Expression() (smart conversion only works for top-level fields)
 		wf.Set("deployProduction", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"environment": "production",
 				"replicas":    "5",
 				"pr_title":    checkTask.Field("title").Expression(),
@@ -82,7 +82,7 @@ func main() {
 
 		// Task 3b: Staging deployment (PR is open)
 		wf.Set("deployStaging", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"environment": "staging",
 				"replicas":    "2",
 				"pr_title":    checkTask.Field("title").Expression(),
@@ -92,7 +92,7 @@ func main() {
 
 		// Task 3c: Error handler
 		wf.Set("handleError", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"status": "failed",
 				"reason": "Unable to determine deployment status",
 			},
@@ -105,7 +105,7 @@ func main() {
 		// Example 2: Numeric comparisons
 		// Fetch repository statistics from GitHub
 		metricsTask := wf.HttpGet("fetchRepoStats",
-			apiBase.Concat("/repos/stigmer/hello-stigmer"),
+			apiBase.Concat("/repos/stigmer/hello-stigmer").StringExpression(),
 			map[string]string{
 				"Accept":     "application/vnd.github.v3+json",
 				"User-Agent": "Stigmer-SDK-Example",
@@ -141,7 +141,7 @@ func main() {
 		// Example 3: String operations
 		// Fetch pull request for string matching demonstrations
 		statusTask := wf.HttpGet("fetchPRForStringMatch",
-			apiBase.Concat("/repos/stigmer/hello-stigmer/pulls/1"),
+			apiBase.Concat("/repos/stigmer/hello-stigmer/pulls/1").StringExpression(),
 			map[string]string{
 				"Accept":     "application/vnd.github.v3+json",
 				"User-Agent": "Stigmer-SDK-Example",
@@ -182,25 +182,25 @@ func main() {
 
 		// Placeholder tasks for the additional examples
 		wf.Set("alertCritical", &workflow.SetArgs{
-			Variables: map[string]string{"alert": "critical"},
+			Variables: map[string]interface{}{"alert": "critical"},
 		})
 		wf.Set("alertWarning", &workflow.SetArgs{
-			Variables: map[string]string{"alert": "warning"},
+			Variables: map[string]interface{}{"alert": "warning"},
 		})
 		wf.Set("continueNormal", &workflow.SetArgs{
-			Variables: map[string]string{"status": "healthy"},
+			Variables: map[string]interface{}{"status": "healthy"},
 		})
 		wf.Set("handleDeploymentError", &workflow.SetArgs{
-			Variables: map[string]string{"action": "handle_error"},
+			Variables: map[string]interface{}{"action": "handle_error"},
 		})
 		wf.Set("initiateRollback", &workflow.SetArgs{
-			Variables: map[string]string{"action": "rollback"},
+			Variables: map[string]interface{}{"action": "rollback"},
 		})
 		wf.Set("markSuccess", &workflow.SetArgs{
-			Variables: map[string]string{"status": "success"},
+			Variables: map[string]interface{}{"status": "success"},
 		})
 		wf.Set("investigateStatus", &workflow.SetArgs{
-			Variables: map[string]string{"action": "investigate"},
+			Variables: map[string]interface{}{"action": "investigate"},
 		})
 
 		log.Printf("Created workflow with conditional logic demonstrating fluent API: %s", wf)
@@ -32,7 +32,7 @@ func main() {
 
 		// Task 1: Get list of commits from hello-stigmer repository
 		fetchTask := wf.HttpGet("fetchCommits",
-			apiBase.Concat("/repos/stigmer/hello-stigmer/commits"),
+			apiBase.Concat("/repos/stigmer/hello-stigmer/commits").StringExpression(),
 			map[string]string{
 				"Accept":     "application/vnd.github.v3+json",
 				"User-Agent": "Stigmer-SDK-Example",
@@ -43,12 +43,12 @@ func main() {
 		// For each commit in the collection, execute the tasks defined in the Do array
 		// Using LoopBody for type-safe access to loop variables
 		loopTask := wf.ForEach("processEachCommit", &workflow.ForArgs{
-			In: fetchTask, // ✅ GitHub API returns array directly - no .Field("items") needed!
+			InRef: fetchTask, // ✅ GitHub API returns array directly - no .Field("items") needed!
 			Do: workflow.LoopBody(func(commit workflow.LoopVar) []*workflow.Task {
 				return []*workflow.Task{
 					wf.Set("analyzeCommit",
 						&workflow.SetArgs{
-							Variables: map[string]string{
+							Variables: map[string]interface{}{
 								"sha":     commit.Field("sha"),                // ✅ Type-safe reference!
 								"message": commit.Field("commit.message"),     // ✅ Commit message
 								"author":  commit.Field("commit.author.name"), // ✅ Author name
@@ -64,7 +64,7 @@ func main() {
 		// Task 3: Collect results
 		// The loopTask itself represents the completion of the loop
 		wf.Set("collectResults", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"loopCompleted": "true",
 				"status":        "completed",
 			},
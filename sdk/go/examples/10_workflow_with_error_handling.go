@@ -35,7 +35,7 @@ func main() {
 		tryTask := wf.Try("attemptGitHubCall", &workflow.TryArgs{
 			Try: workflow.TryBody(
 				wf.HttpGet("fetchPullRequest",
-					apiBase.Concat("/repos/stigmer/hello-stigmer/pulls/1"),
+					apiBase.Concat("/repos/stigmer/hello-stigmer/pulls/1").StringExpression(),
 					map[string]string{
 						"Accept":     "application/vnd.github.v3+json",
 						"User-Agent": "Stigmer-SDK-Example",
@@ -44,7 +44,7 @@ func main() {
 			),
 			Catch: workflow.CatchBody("error",
 				wf.Set("handleError", &workflow.SetArgs{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"error":     "${.error.message}",
 						"timestamp": "${.error.timestamp}",
 						"retryable": "true",
@@ -73,7 +73,7 @@ func main() {
 		// Task 3a: Process successful result from GitHub API
 		// Note: Map values require .Expression() (smart conversion only works for top-level fields)
 		wf.Set("processSuccess", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"pr_title":  tryTask.Field("title").Expression(),
 				"pr_state":  tryTask.Field("state").Expression(),
 				"pr_author": tryTask.Field("user.login").Expression(),
@@ -83,7 +83,7 @@ func main() {
 
 		// Task 3b: Log failure
 		wf.Set("logFailure", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"status": "failed",
 				"reason": tryTask.Field("error").Expression(),
 			},
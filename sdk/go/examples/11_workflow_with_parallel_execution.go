@@ -36,7 +36,7 @@ func main() {
 			Branches: workflow.ForkBranches(
 				workflow.ForkBranch("fetchPullRequests",
 					wf.HttpGet("getPulls",
-						apiBase.Concat("/pulls"),
+						apiBase.Concat("/pulls").StringExpression(),
 						map[string]string{
 							"Accept":     "application/vnd.github.v3+json",
 							"User-Agent": "Stigmer-SDK-Example",
@@ -45,7 +45,7 @@ func main() {
 				),
 				workflow.ForkBranch("fetchIssues",
 					wf.HttpGet("getIssues",
-						apiBase.Concat("/issues"),
+						apiBase.Concat("/issues").StringExpression(),
 						map[string]string{
 							"Accept":     "application/vnd.github.v3+json",
 							"User-Agent": "Stigmer-SDK-Example",
@@ -54,7 +54,7 @@ func main() {
 				),
 				workflow.ForkBranch("fetchCommits",
 					wf.HttpGet("getCommits",
-						apiBase.Concat("/commits"),
+						apiBase.Concat("/commits").StringExpression(),
 						map[string]string{
 							"Accept":     "application/vnd.github.v3+json",
 							"User-Agent": "Stigmer-SDK-Example",
@@ -66,7 +66,7 @@ func main() {
 
 		// Task 2: Merge results from all parallel GitHub API calls
 		wf.Set("mergeResults", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"pulls":   "${ $context[\"fetchAllGitHubData\"].branches.fetchPullRequests.data }",
 				"issues":  "${ $context[\"fetchAllGitHubData\"].branches.fetchIssues.data }",
 				"commits": "${ $context[\"fetchAllGitHubData\"].branches.fetchCommits.data }",
@@ -76,7 +76,7 @@ func main() {
 
 		// Task 3: Process merged GitHub data
 		wf.Set("processMerged", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"totalRecords": "${pulls.length + issues.length + commits.length}",
 				"completedAt":  "${now()}",
 				"repository":   "stigmer/hello-stigmer",
@@ -64,7 +64,7 @@ func main() {
 
 		// Task 2: Process data
 		_ = wf.Set("processData", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"status":  "processing",
 				"retries": retryCount.Expression(), // Uses shared retryCount
 			},
@@ -167,9 +167,11 @@ func runWorkflow(ctx *stigmer.Context) error {
 		map[string]string{
 			"Authorization":   workflow.Interpolate("Bearer ", workflow.RuntimeSecret("STRIPE_API_KEY")),
 			"Idempotency-Key": workflow.RuntimeSecret("STRIPE_IDEMPOTENCY_KEY"),
-			"Content-Type":    "application/x-www-form-urlencoded",
 		},
-		map[string]any{
+		nil,
+		// ✅ Stripe expects a form body: BodyForm url-encodes it and sets the
+		// Content-Type; nested metadata is sent as metadata[environment]=...
+		workflow.BodyForm(map[string]any{
 			"amount":   2000,
 			"currency": "usd",
 			"source":   "tok_visa",
@@ -180,7 +182,7 @@ func runWorkflow(ctx *stigmer.Context) error {
 				"request_id":    processData.Field("id"),
 				"ai_conclusion": analyzeError.Field("choices[0].message.content"),
 			},
-		},
+		}),
 	)
 
 	// ============================================================================
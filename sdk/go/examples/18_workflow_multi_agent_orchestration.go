@@ -200,7 +200,7 @@ Return JSON: {"status": "healthy|degraded|failed", "metrics": {...}, "action": "
 		// Step 5: Aggregate results
 		// ============================================================================
 		aggregateResults := wf.Set("aggregateResults", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"security_status":      securityScan.Field("recommendation").Expression(),
 				"code_quality_score":   codeReview.Field("quality_score").Expression(),
 				"performance_score":    performanceAnalysis.Field("performance_score").Expression(),
@@ -0,0 +1,85 @@
+//go:build ignore
+
+package main
+
+import (
+	"log"
+
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"github.com/stigmer/stigmer/sdk/go/stigmer"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// This example demonstrates passing files to an agent with attachments:
+// A workflow fetches a pull request diff and hands it to a code reviewer.
+//
+// Key learning points:
+// - Using workflow.Attachment() instead of inlining large content in the message
+// - Referencing a previous task's output as attachment content (implicit dependency)
+// - Setting the MIME type and size limit of an attachment
+//
+// The agent runner writes each attachment to /attachments/{name} in the
+// agent's sandbox, where the agent reads it with its file tools.
+func main() {
+	err := stigmer.Run(func(ctx *stigmer.Context) error {
+		// ============================================================================
+		// Step 1: Create the reviewing agent
+		// ============================================================================
+		diffReviewer, err := agent.New(ctx, "diff-reviewer", &agent.AgentArgs{
+			Instructions: `You are a code reviewer. Review the attached diff for:
+- Potential bugs
+- Missing tests
+
+Reference file names and line numbers in your feedback.`,
+			Description: "AI reviewer for pull request diffs",
+		})
+		if err != nil {
+			return err
+		}
+
+		// ============================================================================
+		// Step 2: Create a workflow that fetches the diff
+		// ============================================================================
+		wf, err := workflow.New(ctx, "code-review/diff-review", &workflow.WorkflowArgs{
+			Version:     "1.0.0",
+			Description: "Review a pull request diff passed as an attachment",
+		})
+		if err != nil {
+			return err
+		}
+
+		fetchDiff := wf.HttpGet("fetchDiff",
+			"https://api.github.com/repos/stigmer/hello-stigmer/pulls/1",
+			map[string]string{"Accept": "application/vnd.github.v3.diff"},
+		)
+
+		// ============================================================================
+		// Step 3: Call the agent with the diff attached
+		// ============================================================================
+		reviewTask := wf.CallAgent("reviewDiff", &workflow.AgentCallArgs{
+			Agent:   workflow.Agent(diffReviewer).Slug(),
+			Message: "Review the attached pull request diff.",
+			Attachments: []*types.AgentAttachment{
+				// Content comes from the previous task - reviewDiff depends on fetchDiff
+				workflow.AttachmentRef("pr.diff", fetchDiff.Field("body"),
+					workflow.MimeType("text/x-patch"),
+					workflow.MaxSize(512*1024),
+				),
+				workflow.Attachment("guidelines.md", "Prefer small functions and table-driven tests."),
+			},
+		})
+
+		log.Printf("✅ Created workflow: %s", wf.Document.Name)
+		log.Printf("✅ Created agent call task: %s", reviewTask.Name)
+		log.Printf("   - Attachments: pr.diff (from %s), guidelines.md", fetchDiff.Name)
+
+		return nil
+	})
+
+	if err != nil {
+		log.Fatalf("❌ Error: %v", err)
+	}
+
+	log.Println("✅ Agent and workflow manifests created successfully!")
+}
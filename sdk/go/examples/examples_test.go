@@ -10,8 +10,10 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	skillv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/skill/v1"
 	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
 )
 
 // TestExample01_BasicAgent tests the basic agent example
@@ -239,19 +241,29 @@ func TestExample04_AgentWithSubAgents(t *testing.T) {
 
 		// Check for both inline and referenced sub-agents
 		hasInline := false
-		hasReferenced := false
+		var referenced *agentv1.SubAgent
 
 		for _, subAgent := range agent.Spec.SubAgents {
-			switch subAgent.AgentReference.(type) {
-			case *agentv1.SubAgent_InlineSpec:
+			if subAgent.GetAgentInstanceRef() != nil {
+				referenced = subAgent
+			} else {
 				hasInline = true
-			case *agentv1.SubAgent_AgentInstanceRefs:
-				hasReferenced = true
 			}
 		}
 
-		t.Logf("✅ Agent with %d sub-agents created (inline: %v, referenced: %v)",
-			len(agent.Spec.SubAgents), hasInline, hasReferenced)
+		if !hasInline {
+			t.Error("Agent should have an inline sub-agent")
+		}
+		if referenced == nil {
+			t.Fatal("Agent should have a referenced sub-agent")
+		}
+		ref := referenced.GetAgentInstanceRef()
+		if ref.GetSlug() != "dependency-auditor-prod" || ref.GetVersion() != "stable" {
+			t.Errorf("Referenced sub-agent = %s@%s, want dependency-auditor-prod@stable", ref.GetSlug(), ref.GetVersion())
+		}
+
+		t.Logf("✅ Agent with %d sub-agents created (inline: %v, referenced: %s@%s)",
+			len(agent.Spec.SubAgents), hasInline, ref.GetSlug(), ref.GetVersion())
 	})
 }
 
@@ -300,6 +312,64 @@ func TestExample05_AgentWithEnvironmentVariables(t *testing.T) {
 	})
 }
 
+// TestExample06_AgentWithInlineContent tests the organized content example,
+// including instructions embedded with //go:embed
+func TestExample06_AgentWithInlineContent(t *testing.T) {
+	runExampleTest(t, "06_agent_with_inline_content.go", func(t *testing.T, outputDir string) {
+		agentPath := filepath.Join(outputDir, "agent-2.pb")
+		assertFileExists(t, agentPath)
+
+		var agent agentv1.Agent
+		readProto(t, agentPath, &agent)
+
+		if agent.Metadata.Name != "embedded-reviewer" {
+			t.Errorf("Agent name = %v, want embedded-reviewer", agent.Metadata.Name)
+		}
+
+		want, err := os.ReadFile(filepath.Join("instructions", "code-reviewer.md"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if agent.Spec.Instructions != string(want) {
+			t.Error("Agent instructions should be the content of instructions/code-reviewer.md")
+		}
+
+		// Inline skills are written as skill manifests, referenced by slug
+		// from the agent manifest
+		inlineAgentPath := filepath.Join(outputDir, "agent-3.pb")
+		assertFileExists(t, inlineAgentPath)
+
+		var inlineAgent agentv1.Agent
+		readProto(t, inlineAgentPath, &inlineAgent)
+
+		wantSkills := []string{"security-guidelines", "testing-best-practices"}
+		refs := inlineAgent.Spec.SkillRefs
+		if len(refs) != len(wantSkills) {
+			t.Fatalf("Inline skill agent skill refs = %v, want %v", refs, wantSkills)
+		}
+		for i, slug := range wantSkills {
+			skillPath := filepath.Join(outputDir, fmt.Sprintf("skill-%d.pb", i))
+			assertFileExists(t, skillPath)
+
+			var skill skillv1.Skill
+			readProto(t, skillPath, &skill)
+
+			if skill.Metadata.Slug != slug {
+				t.Errorf("skill-%d.pb slug = %v, want %v", i, skill.Metadata.Slug, slug)
+			}
+			if skill.Spec.SkillMd == "" {
+				t.Errorf("skill %s should have markdown content", slug)
+			}
+			if refs[i].Slug != slug || refs[i].Kind != apiresourcekind.ApiResourceKind_skill {
+				t.Errorf("skill_refs[%d] = %v, want a reference to skill %s", i, refs[i], slug)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(outputDir, "skill-2.pb")); err == nil {
+			t.Error("Each inline skill should be written once")
+		}
+	})
+}
+
 // TestExample08_WorkflowWithConditionals tests the workflow with conditionals example
 func TestExample08_WorkflowWithConditionals(t *testing.T) {
 	runExampleTest(t, "08_workflow_with_conditionals.go", func(t *testing.T, outputDir string) {
@@ -619,6 +689,39 @@ func TestExample19_WorkflowAgentExecutionConfig(t *testing.T) {
 	})
 }
 
+// TestExample20_WorkflowAgentWithAttachments tests the agent call attachments example
+func TestExample20_WorkflowAgentWithAttachments(t *testing.T) {
+	runExampleTest(t, "20_workflow_agent_with_attachments.go", func(t *testing.T, outputDir string) {
+		workflowPath := filepath.Join(outputDir, "workflow-0.pb")
+		assertFileExists(t, workflowPath)
+
+		var wf workflowv1.Workflow
+		readProto(t, workflowPath, &wf)
+
+		if len(wf.Spec.Tasks) != 2 {
+			t.Fatalf("Workflow should have 2 tasks, got %d", len(wf.Spec.Tasks))
+		}
+		review := wf.Spec.Tasks[1]
+		if review.Kind != apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_AGENT_CALL {
+			t.Fatalf("Task kind = %v, want AGENT_CALL", review.Kind)
+		}
+
+		attachments := review.TaskConfig.GetFields()["attachments"].GetListValue().GetValues()
+		if len(attachments) != 2 {
+			t.Fatalf("Task should have 2 attachments, got %d", len(attachments))
+		}
+		diff := attachments[0].GetStructValue().GetFields()
+		if diff["name"].GetStringValue() != "pr.diff" {
+			t.Errorf("Attachment name = %v, want pr.diff", diff["name"].GetStringValue())
+		}
+		if content := diff["content"].GetStringValue(); content != `${ $context["fetchDiff"].body }` {
+			t.Errorf("Attachment content = %v, want fetchDiff body expression", content)
+		}
+
+		t.Logf("✅ Workflow with %d attachments created successfully", len(attachments))
+	})
+}
+
 // Helper function to run an example and verify output
 func runExampleTest(t *testing.T, exampleFile string, verify func(*testing.T, string)) {
 	t.Helper()
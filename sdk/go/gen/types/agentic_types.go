@@ -1,13 +1,123 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: agentic_types.go
-// Generated: 2026-01-26T03:24:19+05:30
 
 package types
 
 import (
+	"encoding/json"
+	"fmt"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+	"math"
+	"strings"
+	"time"
 )
 
+// AgentAttachment defines a file passed to an agent call.
+type AgentAttachment struct {
+	// File name in the agent's attachments directory (e.g., "diff.patch").  Required field.
+	Name string `json:"name,omitempty"`
+	// File content. Usually an expression referencing a previous task output.  Non-string expression results are encoded as JSON.  Example: "${ $context.fetchDiff.body }"  Required field.
+	Content string `json:"content,omitempty"`
+	// ContentRef sets Content from a typed string reference, such as a
+	// StringRef or a task's Field(). Only one of Content and ContentRef may be set.
+	ContentRef StringExpr `json:"-"`
+	// MIME type of the content.  Default: "text/plain"  Optional.
+	MimeType string `json:"mimeType,omitempty"`
+	// Maximum size in bytes of the evaluated content.  The task fails when the content is larger.  Default: 1048576 (1 MiB)  Optional.
+	MaxSizeBytes int32 `json:"maxSizeBytes,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// ValidateOneofs checks that at most one member of each oneof group is set.
+func (c *AgentAttachment) ValidateOneofs() error {
+	// oneof content: content, contentRef
+	{
+		var set []string
+		if c.Content != "" {
+			set = append(set, "content")
+		}
+		if c.ContentRef != nil {
+			set = append(set, "contentRef")
+		}
+		if len(set) > 1 {
+			return validation.NewValidationErrorWithCause(
+				"content",
+				strings.Join(set, ", "),
+				"oneof",
+				fmt.Sprintf("only one of %s may be set, got %s", "content, contentRef", strings.Join(set, " and ")),
+				validation.ErrMutuallyExclusive,
+			)
+		}
+	}
+
+	return nil
+}
+
+// ContentExpression returns the expression of ContentRef when set, otherwise the literal
+// or raw expression held by Content.
+func (c *AgentAttachment) ContentExpression() string {
+	if c.ContentRef != nil {
+		return c.ContentRef.StringExpression()
+	}
+	return c.Content
+}
+
+// FromProto converts google.protobuf.Struct to AgentAttachment.
+func (c *AgentAttachment) FromProto(s *structpb.Struct) error {
+	fields := s.GetFields()
+
+	if val, ok := fields["name"]; ok {
+		c.Name = val.GetStringValue()
+	}
+
+	if val, ok := fields["content"]; ok {
+		c.Content = val.GetStringValue()
+	}
+
+	if val, ok := fields["mimeType"]; ok {
+		c.MimeType = val.GetStringValue()
+	}
+
+	if val, ok := fields["maxSizeBytes"]; ok {
+		c.MaxSizeBytes = int32(val.GetNumberValue())
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "name", "content", "mimeType", "maxSizeBytes":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes AgentAttachment, including the fields FromProto did not recognize.
+func (c AgentAttachment) MarshalJSON() ([]byte, error) {
+	type plain AgentAttachment
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // AgentExecutionConfig defines optional execution parameters for agent calls.
 //
 //	These settings override the agent's default configuration for this specific invocation.
@@ -15,9 +125,37 @@ type AgentExecutionConfig struct {
 	// LLM model to use for this invocation.  Example: "claude-3-5-sonnet", "gpt-4", "claude-3-opus"  Optional - uses agent's default model if not specified.
 	Model string `json:"model,omitempty"`
 	// Timeout for agent execution in seconds.  Default: 300 (5 minutes)  Optional.
+	//
+	// Deprecated: Use SetTimeoutDuration, which takes a time.Duration.
 	Timeout int32 `json:"timeout,omitempty"`
 	// Temperature for LLM sampling (0.0 to 1.0).  Lower = more deterministic, Higher = more creative  Default: 0.7  Optional.
 	Temperature float32 `json:"temperature,omitempty"`
+	// JSON Schema the agent's final response must conform to.  Overrides the agent's output_schema for this invocation.  The runner validates the response (retrying on mismatch) and the task  output becomes the parsed JSON object.  Optional.
+	OutputSchema map[string]interface{} `json:"outputSchema,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// TimeoutDuration returns Timeout as a time.Duration.
+func (c *AgentExecutionConfig) TimeoutDuration() time.Duration {
+	return time.Duration(c.Timeout) * time.Second
+}
+
+// SetTimeoutDuration sets Timeout from d, which must be a non-negative
+// whole number of seconds.
+func (c *AgentExecutionConfig) SetTimeoutDuration(d time.Duration) error {
+	if d < 0 || d%time.Second != 0 || d/time.Second > math.MaxInt32 {
+		return validation.NewValidationErrorWithCause(
+			"timeout",
+			d.String(),
+			"duration",
+			"must be a non-negative whole number of seconds",
+			validation.ErrInvalidDuration,
+		)
+	}
+	c.Timeout = int32(d / time.Second)
+	return nil
 }
 
 // FromProto converts google.protobuf.Struct to AgentExecutionConfig.
@@ -36,15 +174,55 @@ func (c *AgentExecutionConfig) FromProto(s *structpb.Struct) error {
 		c.Temperature = float32(val.GetNumberValue())
 	}
 
+	if val, ok := fields["outputSchema"]; ok {
+		c.OutputSchema = val.GetStructValue().AsMap()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "model", "timeout", "temperature", "outputSchema":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes AgentExecutionConfig, including the fields FromProto did not recognize.
+func (c AgentExecutionConfig) MarshalJSON() ([]byte, error) {
+	type plain AgentExecutionConfig
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // CatchBlock defines error handling logic.
 type CatchBlock struct {
 	// Variable name to store the error (optional).  Accessible via ${ .error } in catch tasks.
 	As string `json:"as,omitempty"`
 	// Tasks to execute when error is caught.
 	Do []*WorkflowTask `json:"do,omitempty"`
+	// Only catch errors of one of these types (optional).  Matches the error type (e.g. "CallHTTP error") or its classification  (e.g. "UPSTREAM_4XX", "TIMEOUT").
+	ErrorTypes []string `json:"errorTypes,omitempty"`
+	// Only catch failed HTTP calls answered with one of these status codes  (optional). When both filters are set, an error must match both.
+	StatusCodes []int32 `json:"statusCodes,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to CatchBlock.
@@ -66,9 +244,52 @@ func (c *CatchBlock) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	if val, ok := fields["errorTypes"]; ok {
+		c.ErrorTypes = make([]string, 0)
+		for _, v := range val.GetListValue().GetValues() {
+			c.ErrorTypes = append(c.ErrorTypes, v.GetStringValue())
+		}
+	}
+
+	if val, ok := fields["statusCodes"]; ok {
+		c.StatusCodes = make([]int32, 0)
+		for _, v := range val.GetListValue().GetValues() {
+			c.StatusCodes = append(c.StatusCodes, int32(v.GetNumberValue()))
+		}
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "as", "do", "errorTypes", "statusCodes":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes CatchBlock, including the fields FromProto did not recognize.
+func (c CatchBlock) MarshalJSON() ([]byte, error) {
+	type plain CatchBlock
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // DockerServer defines an MCP server that runs in a Docker container.
 type DockerServer struct {
 	// Docker image name and tag.  Example: "ghcr.io/org/mcp-server:latest"
@@ -85,6 +306,9 @@ type DockerServer struct {
 	Ports []*PortMapping `json:"ports,omitempty"`
 	// Container name (optional, auto-generated if not provided).
 	ContainerName string `json:"containerName,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to DockerServer.
@@ -139,9 +363,38 @@ func (c *DockerServer) FromProto(s *structpb.Struct) error {
 		c.ContainerName = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "image", "args", "envPlaceholders", "volumes", "network", "ports", "containerName":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes DockerServer, including the fields FromProto did not recognize.
+func (c DockerServer) MarshalJSON() ([]byte, error) {
+	type plain DockerServer
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // EnvironmentSpec defines a collection of configuration and secrets.
 //
 //	Created before AgentInstance or WorkflowInstance, referenced during instance creation.
@@ -150,6 +403,9 @@ type EnvironmentSpec struct {
 	Description string `json:"description,omitempty"`
 	// Key-value pairs containing both configuration and secrets.  Each value includes a flag indicating whether it's a secret.  Example: {"AWS_REGION": {value: "us-west-2", is_secret: false},            "AWS_ACCESS_KEY_ID": {value: "AKIA...", is_secret: true}}
 	Data map[string]*EnvironmentValue `json:"data,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to EnvironmentSpec.
@@ -171,9 +427,38 @@ func (c *EnvironmentSpec) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "description", "data":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes EnvironmentSpec, including the fields FromProto did not recognize.
+func (c EnvironmentSpec) MarshalJSON() ([]byte, error) {
+	type plain EnvironmentSpec
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // EnvironmentValue represents a single configuration or secret value.
 type EnvironmentValue struct {
 	// The actual value.  - If is_secret=true: This value is encrypted at rest and redacted in logs  - If is_secret=false: This value is stored as plaintext
@@ -182,6 +467,9 @@ type EnvironmentValue struct {
 	IsSecret bool `json:"isSecret,omitempty"`
 	// Optional description for documentation.  Example: "AWS access key for S3 bucket access"
 	Description string `json:"description,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to EnvironmentValue.
@@ -200,9 +488,38 @@ func (c *EnvironmentValue) FromProto(s *structpb.Struct) error {
 		c.Description = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "value", "isSecret", "description":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes EnvironmentValue, including the fields FromProto did not recognize.
+func (c EnvironmentValue) MarshalJSON() ([]byte, error) {
+	type plain EnvironmentValue
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // Export defines how to save task output to context.
 //
 //	Maps to the `export:` block in Zigflow DSL.
@@ -214,6 +531,9 @@ func (c *EnvironmentValue) FromProto(s *structpb.Struct) error {
 type Export struct {
 	// Expression defining how to export output.  Uses Zigflow expression syntax: ${...}
 	As string `json:"as,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to Export.
@@ -224,9 +544,38 @@ func (c *Export) FromProto(s *structpb.Struct) error {
 		c.As = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "as":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes Export, including the fields FromProto did not recognize.
+func (c Export) MarshalJSON() ([]byte, error) {
+	type plain Export
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // FlowControl defines which task executes next.
 //
 //	Maps to the `then:` directive in Zigflow DSL.
@@ -238,6 +587,9 @@ func (c *Export) FromProto(s *structpb.Struct) error {
 type FlowControl struct {
 	// Target task name or "end" to terminate workflow.
 	Then string `json:"then,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to FlowControl.
@@ -248,15 +600,47 @@ func (c *FlowControl) FromProto(s *structpb.Struct) error {
 		c.Then = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "then":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes FlowControl, including the fields FromProto did not recognize.
+func (c FlowControl) MarshalJSON() ([]byte, error) {
+	type plain FlowControl
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // ForkBranch defines a single branch in parallel execution.
 type ForkBranch struct {
 	// Branch name/identifier.
 	Name string `json:"name,omitempty"`
 	// Tasks to execute in this branch.
 	Do []*WorkflowTask `json:"do,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to ForkBranch.
@@ -278,13 +662,82 @@ func (c *ForkBranch) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "name", "do":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes ForkBranch, including the fields FromProto did not recognize.
+func (c ForkBranch) MarshalJSON() ([]byte, error) {
+	type plain ForkBranch
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // HttpEndpoint defines the HTTP endpoint to call.
 type HttpEndpoint struct {
 	// URI of the endpoint.  Can contain expressions: "https://api.example.com/${.resource}"
-	Uri interface{} `json:"uri,omitempty"`
+	Uri string `json:"uri,omitempty"`
+	// UriRef sets Uri from a typed string reference, such as a
+	// StringRef or a task's Field(). Only one of Uri and UriRef may be set.
+	UriRef StringExpr `json:"-"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// ValidateOneofs checks that at most one member of each oneof group is set.
+func (c *HttpEndpoint) ValidateOneofs() error {
+	// oneof uri: uri, uriRef
+	{
+		var set []string
+		if c.Uri != "" {
+			set = append(set, "uri")
+		}
+		if c.UriRef != nil {
+			set = append(set, "uriRef")
+		}
+		if len(set) > 1 {
+			return validation.NewValidationErrorWithCause(
+				"uri",
+				strings.Join(set, ", "),
+				"oneof",
+				fmt.Sprintf("only one of %s may be set, got %s", "uri, uriRef", strings.Join(set, " and ")),
+				validation.ErrMutuallyExclusive,
+			)
+		}
+	}
+
+	return nil
+}
+
+// UriExpression returns the expression of UriRef when set, otherwise the literal
+// or raw expression held by Uri.
+func (c *HttpEndpoint) UriExpression() string {
+	if c.UriRef != nil {
+		return c.UriRef.StringExpression()
+	}
+	return c.Uri
 }
 
 // FromProto converts google.protobuf.Struct to HttpEndpoint.
@@ -295,9 +748,259 @@ func (c *HttpEndpoint) FromProto(s *structpb.Struct) error {
 		c.Uri = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "uri":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes HttpEndpoint, including the fields FromProto did not recognize.
+func (c HttpEndpoint) MarshalJSON() ([]byte, error) {
+	type plain HttpEndpoint
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
+// HttpCache marks an HTTP_CALL task as cacheable.
+//
+//	The workflow runner keeps successful responses in a local cache keyed by the
+//	resolved request and skips the network call while a cached response is
+//	fresh. The task output metadata records whether the call was a cache hit.
+//
+//	Requests whose headers reference runtime secrets (${.secrets.KEY}) are never
+//	cached unless include_auth is set, so responses fetched with one caller's
+//	credentials are not served to another by default.
+type HttpCache struct {
+	// How long a cached response is reused, in seconds.
+	//
+	// Deprecated: Use SetTtlDuration, which takes a time.Duration.
+	TtlSeconds int32 `json:"ttlSeconds,omitempty"`
+	// Parts of the resolved request that make up the cache key (optional, default: "uri").  "uri": method and URI, including the query string.  "request": method, URI and request headers.
+	Key string `json:"key,omitempty"`
+	// Cache requests whose headers reference runtime secrets.  Cached responses are then shared by every caller of the same cache key.
+	IncludeAuth bool `json:"includeAuth,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// TtlDuration returns TtlSeconds as a time.Duration.
+func (c *HttpCache) TtlDuration() time.Duration {
+	return time.Duration(c.TtlSeconds) * time.Second
+}
+
+// SetTtlDuration sets TtlSeconds from d, which must be a non-negative
+// whole number of seconds.
+func (c *HttpCache) SetTtlDuration(d time.Duration) error {
+	if d < 0 || d%time.Second != 0 || d/time.Second > math.MaxInt32 {
+		return validation.NewValidationErrorWithCause(
+			"ttlSeconds",
+			d.String(),
+			"duration",
+			"must be a non-negative whole number of seconds",
+			validation.ErrInvalidDuration,
+		)
+	}
+	c.TtlSeconds = int32(d / time.Second)
+	return nil
+}
+
+// FromProto converts google.protobuf.Struct to HttpCache.
+func (c *HttpCache) FromProto(s *structpb.Struct) error {
+	fields := s.GetFields()
+
+	if val, ok := fields["ttlSeconds"]; ok {
+		c.TtlSeconds = int32(val.GetNumberValue())
+	}
+
+	if val, ok := fields["key"]; ok {
+		c.Key = val.GetStringValue()
+	}
+
+	if val, ok := fields["includeAuth"]; ok {
+		c.IncludeAuth = val.GetBoolValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "ttlSeconds", "key", "includeAuth":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes HttpCache, including the fields FromProto did not recognize.
+func (c HttpCache) MarshalJSON() ([]byte, error) {
+	type plain HttpCache
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
+// HttpMultipartPart configures a part of a multipart/form-data request body.
+//
+//	The value of the part is the body field of the same name, so it can use
+//	expressions and runtime references like any other body value. String values
+//	are sent as-is; other values are sent as JSON.
+type HttpMultipartPart struct {
+	// Name of the part, and of the body field holding its value.
+	Name string `json:"name,omitempty"`
+	// File name sent with the part, making it a file upload (optional).
+	Filename string `json:"filename,omitempty"`
+	// Content type of the part (optional).  Default: "application/octet-stream" for files, "application/json" for  non-string values, none otherwise.
+	ContentType string `json:"contentType,omitempty"`
+	// The value is base64-encoded binary content, decoded before it is sent,  such as the output of an HTTP_CALL with response_format "bytes_base64".
+	Base64 bool `json:"base64,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// FromProto converts google.protobuf.Struct to HttpMultipartPart.
+func (c *HttpMultipartPart) FromProto(s *structpb.Struct) error {
+	fields := s.GetFields()
+
+	if val, ok := fields["name"]; ok {
+		c.Name = val.GetStringValue()
+	}
+
+	if val, ok := fields["filename"]; ok {
+		c.Filename = val.GetStringValue()
+	}
+
+	if val, ok := fields["contentType"]; ok {
+		c.ContentType = val.GetStringValue()
+	}
+
+	if val, ok := fields["base64"]; ok {
+		c.Base64 = val.GetBoolValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "name", "filename", "contentType", "base64":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes HttpMultipartPart, including the fields FromProto did not recognize.
+func (c HttpMultipartPart) MarshalJSON() ([]byte, error) {
+	type plain HttpMultipartPart
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
+// HttpProxy overrides the proxy of an HTTP_CALL task.
+//
+//	Without it, the workflow runner uses its own proxy configuration
+//	(HTTP_PROXY, HTTPS_PROXY and NO_PROXY).
+type HttpProxy struct {
+	// URL of the proxy, such as "http://proxy.corp:3128".  Supported schemes: http, https and socks5.
+	Url string `json:"url,omitempty"`
+	// Connect directly, bypassing the runner's proxy.
+	Disabled bool `json:"disabled,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// FromProto converts google.protobuf.Struct to HttpProxy.
+func (c *HttpProxy) FromProto(s *structpb.Struct) error {
+	fields := s.GetFields()
+
+	if val, ok := fields["url"]; ok {
+		c.Url = val.GetStringValue()
+	}
+
+	if val, ok := fields["disabled"]; ok {
+		c.Disabled = val.GetBoolValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "url", "disabled":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes HttpProxy, including the fields FromProto did not recognize.
+func (c HttpProxy) MarshalJSON() ([]byte, error) {
+	type plain HttpProxy
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // HttpServer defines an MCP server accessible via HTTP + SSE.
 //
 //	Used for remote/managed MCP services.
@@ -309,28 +1012,237 @@ type HttpServer struct {
 	// Query parameters for the MCP endpoint (optional).  Example: {"version": "v1", "region": "${AWS_REGION}"}
 	QueryParams map[string]string `json:"queryParams,omitempty"`
 	// Timeout for HTTP requests in seconds (default: 30).
+	//
+	// Deprecated: Use SetTimeoutDuration, which takes a time.Duration.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// OAuth2 authentication (optional).  When set, the runtime fetches an access token before connecting and  refreshes it before it expires, sending it as "Authorization: Bearer".
+	Auth *OAuth2ClientCredentials `json:"auth,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// TimeoutDuration returns TimeoutSeconds as a time.Duration.
+func (c *HttpServer) TimeoutDuration() time.Duration {
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// SetTimeoutDuration sets TimeoutSeconds from d, which must be a non-negative
+// whole number of seconds.
+func (c *HttpServer) SetTimeoutDuration(d time.Duration) error {
+	if d < 0 || d%time.Second != 0 || d/time.Second > math.MaxInt32 {
+		return validation.NewValidationErrorWithCause(
+			"timeoutSeconds",
+			d.String(),
+			"duration",
+			"must be a non-negative whole number of seconds",
+			validation.ErrInvalidDuration,
+		)
+	}
+	c.TimeoutSeconds = int32(d / time.Second)
+	return nil
+}
+
+// FromProto converts google.protobuf.Struct to HttpServer.
+func (c *HttpServer) FromProto(s *structpb.Struct) error {
+	fields := s.GetFields()
+
+	if val, ok := fields["url"]; ok {
+		c.Url = val.GetStringValue()
+	}
+
+	if val, ok := fields["headers"]; ok {
+		c.Headers = make(map[string]string)
+		for k, v := range val.GetStructValue().GetFields() {
+			c.Headers[k] = v.GetStringValue()
+		}
+	}
+
+	if val, ok := fields["queryParams"]; ok {
+		c.QueryParams = make(map[string]string)
+		for k, v := range val.GetStructValue().GetFields() {
+			c.QueryParams[k] = v.GetStringValue()
+		}
+	}
+
+	if val, ok := fields["timeoutSeconds"]; ok {
+		c.TimeoutSeconds = int32(val.GetNumberValue())
+	}
+
+	if val, ok := fields["auth"]; ok {
+		c.Auth = &OAuth2ClientCredentials{}
+		if err := c.Auth.FromProto(val.GetStructValue()); err != nil {
+			return err
+		}
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "url", "headers", "queryParams", "timeoutSeconds", "auth":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes HttpServer, including the fields FromProto did not recognize.
+func (c HttpServer) MarshalJSON() ([]byte, error) {
+	type plain HttpServer
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
+// HttpTls defines the TLS configuration of an HTTP_CALL task.
+//
+//	Certificates and keys are PEM-encoded. Key material must be supplied as
+//	runtime secret references (${.secrets.KEY}), which the workflow runner
+//	resolves just-in-time, so it never appears in manifests or workflow history.
+type HttpTls struct {
+	// Client certificate presented to the server (PEM).  Runtime reference or literal PEM. Requires client_key.
+	ClientCert string `json:"clientCert,omitempty"`
+	// Private key of the client certificate (PEM).  Must be a runtime secret reference: "${.secrets.CLIENT_KEY}".  Requires client_cert.
+	ClientKey string `json:"clientKey,omitempty"`
+	// CA certificates used to verify the server (PEM), replacing the system roots.  Runtime reference or literal PEM.
+	CaBundle string `json:"caBundle,omitempty"`
+	// Path of a CA bundle file on the workflow runner (PEM).  Alternative to ca_bundle for CAs provisioned on the runner host.
+	CaBundleFile string `json:"caBundleFile,omitempty"`
+	// Disable verification of the server certificate.  Only for testing: the connection is open to interception.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// FromProto converts google.protobuf.Struct to HttpTls.
+func (c *HttpTls) FromProto(s *structpb.Struct) error {
+	fields := s.GetFields()
+
+	if val, ok := fields["clientCert"]; ok {
+		c.ClientCert = val.GetStringValue()
+	}
+
+	if val, ok := fields["clientKey"]; ok {
+		c.ClientKey = val.GetStringValue()
+	}
+
+	if val, ok := fields["caBundle"]; ok {
+		c.CaBundle = val.GetStringValue()
+	}
+
+	if val, ok := fields["caBundleFile"]; ok {
+		c.CaBundleFile = val.GetStringValue()
+	}
+
+	if val, ok := fields["insecureSkipVerify"]; ok {
+		c.InsecureSkipVerify = val.GetBoolValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "clientCert", "clientKey", "caBundle", "caBundleFile", "insecureSkipVerify":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes HttpTls, including the fields FromProto did not recognize.
+func (c HttpTls) MarshalJSON() ([]byte, error) {
+	type plain HttpTls
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
+// ListenApproval configures a LISTEN task as a human approval gate.
+//
+//	YAML Example:
+//	  - approveDeploy:
+//	      listen:
+//	        to:
+//	          one:
+//	            with:
+//	              id: approval
+//	              type: signal
+//	      metadata:
+//	        approval:
+//	          approvers: [ops-team]
+//	          timeout: 48h
+//	          onTimeout: fail
+type ListenApproval struct {
+	// Identities or groups allowed to decide on the approval.  Empty means anyone with edit permission on the execution.
+	Approvers []string `json:"approvers,omitempty"`
+	// How long to wait for a decision, in seconds (0 = 24 hours).
+	//
+	// Deprecated: Use SetTimeoutDuration, which takes a time.Duration.
 	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// What happens when no decision arrives before the timeout:  - "fail": Fail the task with error type "ApprovalTimeout" (default)  - "approve": Approve automatically (approved_by is "timeout")
+	OnTimeout string `json:"onTimeout,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
-// FromProto converts google.protobuf.Struct to HttpServer.
-func (c *HttpServer) FromProto(s *structpb.Struct) error {
-	fields := s.GetFields()
+// TimeoutDuration returns TimeoutSeconds as a time.Duration.
+func (c *ListenApproval) TimeoutDuration() time.Duration {
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
 
-	if val, ok := fields["url"]; ok {
-		c.Url = val.GetStringValue()
-	}
+// SetTimeoutDuration sets TimeoutSeconds from d, which must be a non-negative
+// whole number of seconds.
+func (c *ListenApproval) SetTimeoutDuration(d time.Duration) error {
+	if d < 0 || d%time.Second != 0 || d/time.Second > math.MaxInt32 {
+		return validation.NewValidationErrorWithCause(
+			"timeoutSeconds",
+			d.String(),
+			"duration",
+			"must be a non-negative whole number of seconds",
+			validation.ErrInvalidDuration,
+		)
+	}
+	c.TimeoutSeconds = int32(d / time.Second)
+	return nil
+}
 
-	if val, ok := fields["headers"]; ok {
-		c.Headers = make(map[string]string)
-		for k, v := range val.GetStructValue().GetFields() {
-			c.Headers[k] = v.GetStringValue()
-		}
-	}
+// FromProto converts google.protobuf.Struct to ListenApproval.
+func (c *ListenApproval) FromProto(s *structpb.Struct) error {
+	fields := s.GetFields()
 
-	if val, ok := fields["queryParams"]; ok {
-		c.QueryParams = make(map[string]string)
-		for k, v := range val.GetStructValue().GetFields() {
-			c.QueryParams[k] = v.GetStringValue()
+	if val, ok := fields["approvers"]; ok {
+		c.Approvers = make([]string, 0)
+		for _, v := range val.GetListValue().GetValues() {
+			c.Approvers = append(c.Approvers, v.GetStringValue())
 		}
 	}
 
@@ -338,15 +1250,51 @@ func (c *HttpServer) FromProto(s *structpb.Struct) error {
 		c.TimeoutSeconds = int32(val.GetNumberValue())
 	}
 
+	if val, ok := fields["onTimeout"]; ok {
+		c.OnTimeout = val.GetStringValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "approvers", "timeoutSeconds", "onTimeout":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes ListenApproval, including the fields FromProto did not recognize.
+func (c ListenApproval) MarshalJSON() ([]byte, error) {
+	type plain ListenApproval
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // ListenTo defines what signals to listen for.
 type ListenTo struct {
 	// Listening mode:  - "one": Wait for any one signal  - "all": Wait for all signals
 	Mode string `json:"mode,omitempty"`
 	// Signals to listen for.
 	Signals []*SignalSpec `json:"signals,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to ListenTo.
@@ -368,9 +1316,38 @@ func (c *ListenTo) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "mode", "signals":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes ListenTo, including the fields FromProto did not recognize.
+func (c ListenTo) MarshalJSON() ([]byte, error) {
+	type plain ListenTo
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // McpServerDefinition defines an MCP server without configuration.
 //
 //	Configuration with secrets happens at AgentInstance level.
@@ -401,6 +1378,37 @@ type McpServerDefinition struct {
 	Docker *DockerServer `json:"docker,omitempty"`
 	// Tool names to enable from this server (empty = all tools).
 	EnabledTools []string `json:"enabledTools,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// ValidateOneofs checks that at most one member of each oneof group is set.
+func (c *McpServerDefinition) ValidateOneofs() error {
+	// oneof server_type: stdio, http, docker
+	{
+		var set []string
+		if c.Stdio != nil {
+			set = append(set, "stdio")
+		}
+		if c.Http != nil {
+			set = append(set, "http")
+		}
+		if c.Docker != nil {
+			set = append(set, "docker")
+		}
+		if len(set) > 1 {
+			return validation.NewValidationErrorWithCause(
+				"server_type",
+				strings.Join(set, ", "),
+				"oneof",
+				fmt.Sprintf("only one of %s may be set, got %s", "stdio, http, docker", strings.Join(set, " and ")),
+				validation.ErrMutuallyExclusive,
+			)
+		}
+	}
+
+	return nil
 }
 
 // FromProto converts google.protobuf.Struct to McpServerDefinition.
@@ -439,13 +1447,45 @@ func (c *McpServerDefinition) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "name", "stdio", "http", "docker", "enabledTools":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes McpServerDefinition, including the fields FromProto did not recognize.
+func (c McpServerDefinition) MarshalJSON() ([]byte, error) {
+	type plain McpServerDefinition
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // McpToolSelection defines which tools from an MCP server are enabled.
 type McpToolSelection struct {
 	// Tool names to enable from the MCP server (empty = all tools).
 	EnabledTools []string `json:"enabledTools,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to McpToolSelection.
@@ -459,9 +1499,114 @@ func (c *McpToolSelection) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "enabledTools":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes McpToolSelection, including the fields FromProto did not recognize.
+func (c McpToolSelection) MarshalJSON() ([]byte, error) {
+	type plain McpToolSelection
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
+// OAuth2ClientCredentials configures the OAuth2 client-credentials grant for
+//
+//	an HTTP MCP server.
+//
+//	The client ID and secret are never stored in the spec. Both fields name
+//	environment variables that are resolved at runtime from the AgentInstance's
+//	environment, like ${VAR_NAME} header placeholders.
+type OAuth2ClientCredentials struct {
+	// Token endpoint of the authorization server.  Example: "https://auth.example.com/oauth2/token"
+	TokenUrl string `json:"tokenUrl,omitempty"`
+	// Environment variable holding the client ID.  Example: "MCP_CLIENT_ID"
+	ClientIdEnv string `json:"clientIdEnv,omitempty"`
+	// Environment variable holding the client secret.  Example: "MCP_CLIENT_SECRET"
+	ClientSecretEnv string `json:"clientSecretEnv,omitempty"`
+	// Scopes to request (optional).  Example: ["mcp.read", "mcp.write"]
+	Scopes []string `json:"scopes,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// FromProto converts google.protobuf.Struct to OAuth2ClientCredentials.
+func (c *OAuth2ClientCredentials) FromProto(s *structpb.Struct) error {
+	fields := s.GetFields()
+
+	if val, ok := fields["tokenUrl"]; ok {
+		c.TokenUrl = val.GetStringValue()
+	}
+
+	if val, ok := fields["clientIdEnv"]; ok {
+		c.ClientIdEnv = val.GetStringValue()
+	}
+
+	if val, ok := fields["clientSecretEnv"]; ok {
+		c.ClientSecretEnv = val.GetStringValue()
+	}
+
+	if val, ok := fields["scopes"]; ok {
+		c.Scopes = make([]string, 0)
+		for _, v := range val.GetListValue().GetValues() {
+			c.Scopes = append(c.Scopes, v.GetStringValue())
+		}
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "tokenUrl", "clientIdEnv", "clientSecretEnv", "scopes":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes OAuth2ClientCredentials, including the fields FromProto did not recognize.
+func (c OAuth2ClientCredentials) MarshalJSON() ([]byte, error) {
+	type plain OAuth2ClientCredentials
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // PortMapping defines a Docker port mapping.
 type PortMapping struct {
 	// Host port to bind to.
@@ -470,6 +1615,9 @@ type PortMapping struct {
 	ContainerPort int32 `json:"containerPort,omitempty"`
 	// Protocol (default: "tcp"). Can be "tcp" or "udp".
 	Protocol string `json:"protocol,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to PortMapping.
@@ -488,15 +1636,150 @@ func (c *PortMapping) FromProto(s *structpb.Struct) error {
 		c.Protocol = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "hostPort", "containerPort", "protocol":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes PortMapping, including the fields FromProto did not recognize.
+func (c PortMapping) MarshalJSON() ([]byte, error) {
+	type plain PortMapping
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
+// RetryPolicy defines when and how often the tasks of a TRY task run again.
+type RetryPolicy struct {
+	// Retry errors of one of these types (at least one required).  Matches the error type (e.g. "ExecutionTimeout") or its classification  (e.g. "UPSTREAM_5XX", "TIMEOUT"), like the error_types of a catch block.
+	ErrorTypes []string `json:"errorTypes,omitempty"`
+	// Maximum number of retries after the first attempt (1-100).
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+	// Seconds to wait before each retry (optional, 0 retries immediately).
+	DelaySeconds int32 `json:"delaySeconds,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// FromProto converts google.protobuf.Struct to RetryPolicy.
+func (c *RetryPolicy) FromProto(s *structpb.Struct) error {
+	fields := s.GetFields()
+
+	if val, ok := fields["errorTypes"]; ok {
+		c.ErrorTypes = make([]string, 0)
+		for _, v := range val.GetListValue().GetValues() {
+			c.ErrorTypes = append(c.ErrorTypes, v.GetStringValue())
+		}
+	}
+
+	if val, ok := fields["maxRetries"]; ok {
+		c.MaxRetries = int32(val.GetNumberValue())
+	}
+
+	if val, ok := fields["delaySeconds"]; ok {
+		c.DelaySeconds = int32(val.GetNumberValue())
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "errorTypes", "maxRetries", "delaySeconds":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes RetryPolicy, including the fields FromProto did not recognize.
+func (c RetryPolicy) MarshalJSON() ([]byte, error) {
+	type plain RetryPolicy
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // SignalSpec defines a signal/event to listen for.
 type SignalSpec struct {
 	// Signal identifier.
 	Id string `json:"id,omitempty"`
 	// Signal type:  - "signal": Temporal signal  - "query": Temporal query  - "update": Temporal update
 	Type string `json:"type,omitempty"`
+	// Correlation filter (optional).  A boolean expression evaluated against each received payload, available  as ".". Payloads for which it is false are ignored and the task keeps  waiting, so a signal meant for another order or request cannot complete  the task.  Example: "${ .orderId == $context.createOrder.id }"
+	AcceptIf string `json:"acceptIf,omitempty"`
+	// AcceptIfRef sets AcceptIf from a typed string reference, such as a
+	// StringRef or a task's Field(). Only one of AcceptIf and AcceptIfRef may be set.
+	AcceptIfRef StringExpr `json:"-"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// ValidateOneofs checks that at most one member of each oneof group is set.
+func (c *SignalSpec) ValidateOneofs() error {
+	// oneof acceptIf: acceptIf, acceptIfRef
+	{
+		var set []string
+		if c.AcceptIf != "" {
+			set = append(set, "acceptIf")
+		}
+		if c.AcceptIfRef != nil {
+			set = append(set, "acceptIfRef")
+		}
+		if len(set) > 1 {
+			return validation.NewValidationErrorWithCause(
+				"acceptIf",
+				strings.Join(set, ", "),
+				"oneof",
+				fmt.Sprintf("only one of %s may be set, got %s", "acceptIf, acceptIfRef", strings.Join(set, " and ")),
+				validation.ErrMutuallyExclusive,
+			)
+		}
+	}
+
+	return nil
+}
+
+// AcceptIfExpression returns the expression of AcceptIfRef when set, otherwise the literal
+// or raw expression held by AcceptIf.
+func (c *SignalSpec) AcceptIfExpression() string {
+	if c.AcceptIfRef != nil {
+		return c.AcceptIfRef.StringExpression()
+	}
+	return c.AcceptIf
 }
 
 // FromProto converts google.protobuf.Struct to SignalSpec.
@@ -511,9 +1794,42 @@ func (c *SignalSpec) FromProto(s *structpb.Struct) error {
 		c.Type = val.GetStringValue()
 	}
 
+	if val, ok := fields["acceptIf"]; ok {
+		c.AcceptIf = val.GetStringValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "id", "type", "acceptIf":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes SignalSpec, including the fields FromProto did not recognize.
+func (c SignalSpec) MarshalJSON() ([]byte, error) {
+	type plain SignalSpec
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // StdioServer defines an MCP server that runs as a subprocess.
 //
 //	Communication happens via stdin/stdout (most common type).
@@ -526,6 +1842,9 @@ type StdioServer struct {
 	EnvPlaceholders map[string]string `json:"envPlaceholders,omitempty"`
 	// Working directory for the process (optional).
 	WorkingDir string `json:"workingDir,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to StdioServer.
@@ -554,15 +1873,47 @@ func (c *StdioServer) FromProto(s *structpb.Struct) error {
 		c.WorkingDir = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "command", "args", "envPlaceholders", "workingDir":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes StdioServer, including the fields FromProto did not recognize.
+func (c StdioServer) MarshalJSON() ([]byte, error) {
+	type plain StdioServer
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // SubAgent represents a sub-agent that can be delegated to.
 type SubAgent struct {
 	// Inline sub-agent definition.
 	InlineSpec *InlineSubAgentSpec `json:"inlineSpec,omitempty"`
 	// Reference to existing Agent resource.
 	AgentInstanceRefs *ApiResourceReference `json:"agentInstanceRefs,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to SubAgent.
@@ -583,9 +1934,38 @@ func (c *SubAgent) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "inlineSpec", "agentInstanceRefs":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes SubAgent, including the fields FromProto did not recognize.
+func (c SubAgent) MarshalJSON() ([]byte, error) {
+	type plain SubAgent
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // SwitchCase defines a single case in a switch statement.
 type SwitchCase struct {
 	// Case name/identifier.
@@ -594,6 +1974,9 @@ type SwitchCase struct {
 	When string `json:"when,omitempty"`
 	// Target task name to execute if condition matches.
 	Then string `json:"then,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to SwitchCase.
@@ -612,9 +1995,38 @@ func (c *SwitchCase) FromProto(s *structpb.Struct) error {
 		c.Then = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "name", "when", "then":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes SwitchCase, including the fields FromProto did not recognize.
+func (c SwitchCase) MarshalJSON() ([]byte, error) {
+	type plain SwitchCase
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // VolumeMount defines a Docker volume mount.
 type VolumeMount struct {
 	// Host path to mount.
@@ -623,6 +2035,9 @@ type VolumeMount struct {
 	ContainerPath string `json:"containerPath,omitempty"`
 	// Whether the mount is read-only (default: false).
 	ReadOnly bool `json:"readOnly,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to VolumeMount.
@@ -641,9 +2056,38 @@ func (c *VolumeMount) FromProto(s *structpb.Struct) error {
 		c.ReadOnly = val.GetBoolValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "hostPath", "containerPath", "readOnly":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes VolumeMount, including the fields FromProto did not recognize.
+func (c VolumeMount) MarshalJSON() ([]byte, error) {
+	type plain VolumeMount
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // WorkflowTask represents a single task in the workflow.
 //
 //	Uses the "kind + Struct" pattern (like CloudResource in Planton Cloud):
@@ -674,6 +2118,40 @@ type WorkflowTask struct {
 	Export *Export `json:"export,omitempty"`
 	// Flow control (which task executes next).  Optional - if not set, continues to next task in sequence.
 	Flow *FlowControl `json:"flow,omitempty"`
+	// Wall-clock execution bound for this task, in seconds.  Applies to every task kind: activity-backed tasks get it as their activity  timeout (overriding the workflow/queue default) and tasks that run in  workflow code are cancelled by a workflow timer.  Distinct from HTTP_CALL timeout_seconds, which only bounds the HTTP request.  When exceeded, the task fails with error type "ExecutionTimeout".  Optional - 0 means the runner default applies.
+	//
+	// Deprecated: Use SetExecutionTimeoutDuration, which takes a time.Duration.
+	ExecutionTimeoutSeconds int32 `json:"executionTimeoutSeconds,omitempty"`
+	// Top-level output fields that carry sensitive data (tokens, credentials).  The runner keeps these values available to later tasks in the same  execution but replaces them with a redaction marker in exported context,  task output and execution history.  Optional - empty means the output is recorded as-is.
+	SensitiveOutputFields []string `json:"sensitiveOutputFields,omitempty"`
+	// Guard expression deciding whether this task runs.  Maps to the `if:` directive in Zigflow DSL.  Example: ${ $context["analyze"].severity | . == "high" }  When it evaluates to false the task is skipped; a skipped task that  exports records {"skipped": true} in the context under its name.  Optional - empty means the task always runs.
+	If string `json:"if,omitempty"`
+	// Human-readable description of what the task does, for handover notes  and generated documentation. Not interpreted by the runner.  Example: "Fetches the user's open PRs from GitHub, paginated"  Optional - at most 500 characters.
+	Description string `json:"description,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// ExecutionTimeoutDuration returns ExecutionTimeoutSeconds as a time.Duration.
+func (c *WorkflowTask) ExecutionTimeoutDuration() time.Duration {
+	return time.Duration(c.ExecutionTimeoutSeconds) * time.Second
+}
+
+// SetExecutionTimeoutDuration sets ExecutionTimeoutSeconds from d, which must be a non-negative
+// whole number of seconds.
+func (c *WorkflowTask) SetExecutionTimeoutDuration(d time.Duration) error {
+	if d < 0 || d%time.Second != 0 || d/time.Second > math.MaxInt32 {
+		return validation.NewValidationErrorWithCause(
+			"executionTimeoutSeconds",
+			d.String(),
+			"duration",
+			"must be a non-negative whole number of seconds",
+			validation.ErrInvalidDuration,
+		)
+	}
+	c.ExecutionTimeoutSeconds = int32(d / time.Second)
+	return nil
 }
 
 // FromProto converts google.protobuf.Struct to WorkflowTask.
@@ -706,9 +2184,57 @@ func (c *WorkflowTask) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	if val, ok := fields["executionTimeoutSeconds"]; ok {
+		c.ExecutionTimeoutSeconds = int32(val.GetNumberValue())
+	}
+
+	if val, ok := fields["sensitiveOutputFields"]; ok {
+		c.SensitiveOutputFields = make([]string, 0)
+		for _, v := range val.GetListValue().GetValues() {
+			c.SensitiveOutputFields = append(c.SensitiveOutputFields, v.GetStringValue())
+		}
+	}
+
+	if val, ok := fields["if"]; ok {
+		c.If = val.GetStringValue()
+	}
+
+	if val, ok := fields["description"]; ok {
+		c.Description = val.GetStringValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "name", "kind", "taskConfig", "export", "flow", "executionTimeoutSeconds", "sensitiveOutputFields", "if", "description":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes WorkflowTask, including the fields FromProto did not recognize.
+func (c WorkflowTask) MarshalJSON() ([]byte, error) {
+	type plain WorkflowTask
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // InlineSubAgentSpec defines a sub-agent inline without creating a separate resource.
 type InlineSubAgentSpec struct {
 	// Name of the sub-agent.
@@ -723,6 +2249,9 @@ type InlineSubAgentSpec struct {
 	McpToolSelections map[string]*McpToolSelection `json:"mcpToolSelections,omitempty"`
 	// References to Skill resources for this sub-agent's knowledge.
 	SkillRefs []*ApiResourceReference `json:"skillRefs,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to InlineSubAgentSpec.
@@ -770,13 +2299,45 @@ func (c *InlineSubAgentSpec) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "name", "description", "instructions", "mcpServers", "mcpToolSelections", "skillRefs":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes InlineSubAgentSpec, including the fields FromProto did not recognize.
+func (c InlineSubAgentSpec) MarshalJSON() ([]byte, error) {
+	type plain InlineSubAgentSpec
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // Configuration that can be applied at execution time.
 type ExecutionConfig struct {
 	// The model to use for this execution.  Example: "claude-sonnet-4-20250514"
 	ModelName string `json:"modelName,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to ExecutionConfig.
@@ -787,15 +2348,47 @@ func (c *ExecutionConfig) FromProto(s *structpb.Struct) error {
 		c.ModelName = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "modelName":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes ExecutionConfig, including the fields FromProto did not recognize.
+func (c ExecutionConfig) MarshalJSON() ([]byte, error) {
+	type plain ExecutionConfig
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // ExecutionValue represents a single runtime configuration or secret value.
 type ExecutionValue struct {
 	// The actual value.  - If is_secret=true: This value is encrypted at rest and redacted in logs  - If is_secret=false: This value is stored as plaintext
 	Value string `json:"value,omitempty"`
 	// Whether this value should be treated as a secret.  When true:  - Value is encrypted at rest  - Value is redacted in logs  - Value is deleted when execution completes  When false:  - Value is stored as plaintext  - Value is visible in audit logs
 	IsSecret bool `json:"isSecret,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to ExecutionValue.
@@ -810,9 +2403,38 @@ func (c *ExecutionValue) FromProto(s *structpb.Struct) error {
 		c.IsSecret = val.GetBoolValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "value", "isSecret":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
 
+// MarshalJSON encodes ExecutionValue, including the fields FromProto did not recognize.
+func (c ExecutionValue) MarshalJSON() ([]byte, error) {
+	type plain ExecutionValue
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
+
 // WorkflowDocument contains workflow metadata.
 //
 //	Maps to the `document:` block in Zigflow DSL YAML.
@@ -827,6 +2449,9 @@ type WorkflowDocument struct {
 	Version string `json:"version,omitempty"`
 	// Human-readable description.
 	Description string `json:"description,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to WorkflowDocument.
@@ -853,5 +2478,34 @@ func (c *WorkflowDocument) FromProto(s *structpb.Struct) error {
 		c.Description = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "dsl", "namespace", "name", "version", "description":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// MarshalJSON encodes WorkflowDocument, including the fields FromProto did not recognize.
+func (c WorkflowDocument) MarshalJSON() ([]byte, error) {
+	type plain WorkflowDocument
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
@@ -1,10 +1,11 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: commons_types.go
-// Generated: 2026-01-26T03:24:19+05:30
 
 package types
 
 import (
+	"encoding/json"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -19,6 +20,9 @@ type ApiResourceReference struct {
 	Kind string `json:"kind,omitempty"`
 	// Resource slug (user-friendly name, not ID)
 	Slug string `json:"slug,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // FromProto converts google.protobuf.Struct to ApiResourceReference.
@@ -41,5 +45,34 @@ func (c *ApiResourceReference) FromProto(s *structpb.Struct) error {
 		c.Slug = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "scope", "org", "kind", "slug":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// MarshalJSON encodes ApiResourceReference, including the fields FromProto did not recognize.
+func (c ApiResourceReference) MarshalJSON() ([]byte, error) {
+	type plain ApiResourceReference
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,16 @@
+// Code generated by stigmer-codegen. DO NOT EDIT.
+
+package types
+
+// StringExpr is a typed value for an expression field, set through the
+// field's Ref companion (e.g. MessageRef for Message).
+//
+// It is implemented by the SDK's string references, task field references,
+// raw expressions and tasks. Int and bool references do not implement it,
+// so passing one where a string is expected fails to compile. Literals and
+// hand-written "${ }" expressions go in the plain field instead.
+type StringExpr interface {
+	// StringExpression returns the value known at synthesis time, or the
+	// "${ }" expression that computes it at runtime.
+	StringExpression() string
+}
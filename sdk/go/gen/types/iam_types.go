@@ -0,0 +1,77 @@
+// Code generated by stigmer-codegen. DO NOT EDIT.
+// Source: iam_types.go
+
+package types
+
+import (
+	"encoding/json"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ApiResourceRef represents a reference to ANY API resource in the system.
+//
+//	It is intentionally generic and reusable for both principals and resources
+//	to maintain symmetry and flexibility in the authorization model.
+//
+//	This message contains ONLY identification fields (kind, id, relation).
+//	For view/query responses that need display information, use ApiResourceRefView in io.proto.
+type ApiResourceRef struct {
+	// Type of the API resource being referenced  This should be the resource kind as defined in ApiResourceKind enum.  Examples: "identity_account", "team", "organization", "environment",  "cloud_resource", "service", etc.
+	Kind string `json:"kind,omitempty"`
+	// Unique identifier of the resource  This is the resource's ID field (e.g., ia-01HQUSER123, tm-01HQTEAM456)
+	Id string `json:"id,omitempty"`
+	// Optional relation qualifier for the resource reference  Used when the reference needs additional context about the relationship.   Primary use case: For team principals, this specifies the relation of the  user to the team (e.g., "member", "admin").  Example: principal { kind: "team", id: "tm-123", relation: "member" }  means "members of team tm-123"   In OpenFGA tuple notation, this becomes:  team:tm-123#member (as the subject of the tuple)   This field qualifies HOW the principal relates to this resource reference,  NOT the permission being granted (that's IamPolicySpec.relation).
+	Relation string `json:"relation,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
+}
+
+// FromProto converts google.protobuf.Struct to ApiResourceRef.
+func (c *ApiResourceRef) FromProto(s *structpb.Struct) error {
+	fields := s.GetFields()
+
+	if val, ok := fields["kind"]; ok {
+		c.Kind = val.GetStringValue()
+	}
+
+	if val, ok := fields["id"]; ok {
+		c.Id = val.GetStringValue()
+	}
+
+	if val, ok := fields["relation"]; ok {
+		c.Relation = val.GetStringValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "kind", "id", "relation":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes ApiResourceRef, including the fields FromProto did not recognize.
+func (c ApiResourceRef) MarshalJSON() ([]byte, error) {
+	type plain ApiResourceRef
+	data, err := json.Marshal(plain(c))
+	if err != nil || len(c.unknownFields) == 0 {
+		return data, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		m[key] = val.AsInterface()
+	}
+	return json.Marshal(m)
+}
@@ -1,13 +1,16 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: agentcalltaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+	"strings"
 )
 
 // AgentCallTaskConfig defines the configuration for AGENT_CALL tasks.
@@ -37,6 +40,10 @@ import (
 //	        config:
 //	          model: "claude-3-5-sonnet"
 //	          timeout: 300
+//	        attachments:
+//	          - name: "diff.patch"
+//	            content: "${ $context.fetchDiff.body }"
+//	            mime_type: "text/x-patch"
 //
 //	Reference: design doc at stigmer/_cursor/add-agent-config-to-workflow.md
 type AgentCallTaskConfig struct {
@@ -45,18 +52,81 @@ type AgentCallTaskConfig struct {
 	// Agent owner scope (platform or organization).  Determines where to resolve the agent slug.  Default (UNSPECIFIED) = ORGANIZATION scope.  Optional (defaults to organization if not specified).
 	Scope string `json:"scope,omitempty"`
 	// Instructions/prompt to send to the agent.  Supports interpolation of workflow variables using JQ expressions.  Example: "Analyze this code: ${ $context.fetchCode.body }"  Required field.
-	Message interface{} `json:"message,omitempty"`
+	Message string `json:"message,omitempty"`
+	// MessageRef sets Message from a typed string reference, such as a
+	// StringRef or a task's Field(). Only one of Message and MessageRef may be set.
+	MessageRef types.StringExpr `json:"-"`
 	// Runtime environment variables to pass to the agent.  Values can be literal strings or JQ expressions that reference  workflow context or secrets.  Example: {"GITHUB_TOKEN": "${ .secrets.GH_TOKEN }"}  Optional.
 	Env map[string]string `json:"env,omitempty"`
 	// Execution configuration for the agent invocation.  Optional - defaults are applied if not specified.
 	Config *types.AgentExecutionConfig `json:"config,omitempty"`
+	// Record the agent's output as an array of chunks with timestamps.  When true, the task output includes "chunks": [{"text": ..., "timestamp": ...}]  alongside "final", so progress can be persisted for audit logs.  Ignored when final_output_only is true.  Optional (default: false).
+	StreamToContext bool `json:"streamToContext,omitempty"`
+	// Record only the agent's final message in the task output ({"final": ...}).  Use this for long-running agents to keep Temporal history small.  Takes precedence over stream_to_context.  Optional (default: false).
+	FinalOutputOnly bool `json:"finalOutputOnly,omitempty"`
+	// Files passed to the agent alongside the message, such as a diff fetched  by a previous task. Use attachments instead of inlining large content  into the message; the agent runner writes them into the agent's sandbox.  Optional.
+	Attachments []*types.AgentAttachment `json:"attachments,omitempty"`
+	// Organization that owns the agent, for agents deployed outside the  workflow's project (possibly by another organization).  Empty resolves the agent in the organization running the workflow.  Optional.
+	Org string `json:"org,omitempty"`
+	// Version of the agent to invoke: empty or "latest" for the current  version, otherwise a tag (e.g., "stable") or version hash the resolved  agent must carry. The call fails when the agent doesn't match.  Optional.
+	Version string `json:"version,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks AgentCallTaskConfig as a TaskConfig implementation.
 func (c *AgentCallTaskConfig) IsTaskConfig() {}
 
+// ValidateOneofs checks that at most one member of each oneof group is set.
+func (c *AgentCallTaskConfig) ValidateOneofs() error {
+	// oneof message: message, messageRef
+	{
+		var set []string
+		if c.Message != "" {
+			set = append(set, "message")
+		}
+		if c.MessageRef != nil {
+			set = append(set, "messageRef")
+		}
+		if len(set) > 1 {
+			return validation.NewValidationErrorWithCause(
+				"message",
+				strings.Join(set, ", "),
+				"oneof",
+				fmt.Sprintf("only one of %s may be set, got %s", "message, messageRef", strings.Join(set, " and ")),
+				validation.ErrMutuallyExclusive,
+			)
+		}
+	}
+
+	return nil
+}
+
+// MessageExpression returns the expression of MessageRef when set, otherwise the literal
+// or raw expression held by Message.
+func (c *AgentCallTaskConfig) MessageExpression() string {
+	if c.MessageRef != nil {
+		return c.MessageRef.StringExpression()
+	}
+	return c.Message
+}
+
 // ToProto converts AgentCallTaskConfig to google.protobuf.Struct for proto marshaling.
 func (c *AgentCallTaskConfig) ToProto() (*structpb.Struct, error) {
+	if err := c.ValidateOneofs(); err != nil {
+		return nil, err
+	}
+
+	for _, item := range c.Attachments {
+		if item == nil {
+			continue
+		}
+		if err := item.ValidateOneofs(); err != nil {
+			return nil, err
+		}
+	}
+
 	data := make(map[string]interface{})
 
 	if !isEmpty(c.Agent) {
@@ -65,9 +135,8 @@ func (c *AgentCallTaskConfig) ToProto() (*structpb.Struct, error) {
 	if !isEmpty(c.Scope) {
 		data["scope"] = c.Scope
 	}
-	if !isEmpty(c.Message) {
-		// Smart conversion: accepts string or TaskFieldRef
-		data["message"] = coerceToString(c.Message)
+	if message := c.MessageExpression(); message != "" {
+		data["message"] = message
 	}
 	if !isEmpty(c.Env) {
 		data["env"] = c.Env
@@ -85,8 +154,48 @@ func (c *AgentCallTaskConfig) ToProto() (*structpb.Struct, error) {
 		// Apply smart conversion to expression fields within the message
 		data["config"] = ConfigMap
 	}
+	if !isEmpty(c.StreamToContext) {
+		data["streamToContext"] = c.StreamToContext
+	}
+	if !isEmpty(c.FinalOutputOnly) {
+		data["finalOutputOnly"] = c.FinalOutputOnly
+	}
+	if !isEmpty(c.Attachments) {
+		// Convert Attachments array to proto-compatible format using JSON marshaling
+		jsonBytes, err := json.Marshal(c.Attachments)
+		if err != nil {
+			return nil, err
+		}
+		var AttachmentsArray []interface{}
+		if err := json.Unmarshal(jsonBytes, &AttachmentsArray); err != nil {
+			return nil, err
+		}
+		// Convert expression fields within each element
+		for i, item := range c.Attachments {
+			if item == nil || (item.Content == "" && item.ContentRef == nil) {
+				continue
+			}
+			if m, ok := AttachmentsArray[i].(map[string]interface{}); ok {
+				m["content"] = item.ContentExpression()
+			}
+		}
+		data["attachments"] = AttachmentsArray
+	}
+	if !isEmpty(c.Org) {
+		data["org"] = c.Org
+	}
+	if !isEmpty(c.Version) {
+		data["version"] = c.Version
+	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to AgentCallTaskConfig.
@@ -119,5 +228,59 @@ func (c *AgentCallTaskConfig) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	if val, ok := fields["streamToContext"]; ok {
+		c.StreamToContext = val.GetBoolValue()
+	}
+
+	if val, ok := fields["finalOutputOnly"]; ok {
+		c.FinalOutputOnly = val.GetBoolValue()
+	}
+
+	if val, ok := fields["attachments"]; ok {
+		c.Attachments = make([]*types.AgentAttachment, 0)
+		for _, v := range val.GetListValue().GetValues() {
+			item := &types.AgentAttachment{}
+			if err := item.FromProto(v.GetStructValue()); err != nil {
+				return err
+			}
+			c.Attachments = append(c.Attachments, item)
+		}
+	}
+
+	if val, ok := fields["org"]; ok {
+		c.Org = val.GetStringValue()
+	}
+
+	if val, ok := fields["version"]; ok {
+		c.Version = val.GetStringValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "agent", "scope", "message", "env", "config", "streamToContext", "finalOutputOnly", "attachments", "org", "version":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of AgentCallTaskConfig.
+func (c *AgentCallTaskConfig) String() string {
+	return summarizeConfig("AGENT_CALL",
+		summaryField("agent", c.Agent),
+		summaryField("scope", c.Scope),
+		summaryField("env", c.Env),
+		summaryField("config", c.Config),
+		summaryField("streamToContext", c.StreamToContext),
+		summaryField("finalOutputOnly", c.FinalOutputOnly),
+		summaryField("attachments", c.Attachments),
+		summaryField("org", c.Org),
+		summaryField("version", c.Version),
+	)
+}
@@ -1,24 +1,30 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: callactivitytaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+	"math"
+	"time"
 )
 
 // CallActivityTaskConfig defines the configuration for CALL_ACTIVITY tasks.
 //
-//	CALL_ACTIVITY tasks execute Temporal activities.
+//	CALL_ACTIVITY tasks execute Temporal activities registered on a worker,
+//	such as custom activities registered on the workflow runner.
 //
 //	YAML Example:
 //	  - taskName:
 //	      call: activity
 //	      with:
-//	        activity: "ProcessDataActivity"
+//	        activity: "transform.v1.Normalize"
 //	        input:
 //	          data: ${ .data }
+//	        task_queue: "custom-q"
+//	        timeout_seconds: 120
 //
 //	Reference: zigflow-dsl-pattern-catalog.md - Task Type 10
 type CallActivityTaskConfig struct {
@@ -26,11 +32,41 @@ type CallActivityTaskConfig struct {
 	Activity string `json:"activity,omitempty"`
 	// Activity input (optional).  Can be any JSON structure.  Supports expressions in string values.
 	Input map[string]interface{} `json:"input,omitempty"`
+	// Task queue of the worker that registered the activity (optional).  Defaults to the workflow runner's execution queue.
+	TaskQueue string `json:"taskQueue,omitempty"`
+	// Activity start-to-close timeout in seconds (optional).  0 uses the runner's default activity timeout.
+	//
+	// Deprecated: Use SetTimeoutDuration, which takes a time.Duration.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks CallActivityTaskConfig as a TaskConfig implementation.
 func (c *CallActivityTaskConfig) IsTaskConfig() {}
 
+// TimeoutDuration returns TimeoutSeconds as a time.Duration.
+func (c *CallActivityTaskConfig) TimeoutDuration() time.Duration {
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// SetTimeoutDuration sets TimeoutSeconds from d, which must be a non-negative
+// whole number of seconds.
+func (c *CallActivityTaskConfig) SetTimeoutDuration(d time.Duration) error {
+	if d < 0 || d%time.Second != 0 || d/time.Second > math.MaxInt32 {
+		return validation.NewValidationErrorWithCause(
+			"timeoutSeconds",
+			d.String(),
+			"duration",
+			"must be a non-negative whole number of seconds",
+			validation.ErrInvalidDuration,
+		)
+	}
+	c.TimeoutSeconds = int32(d / time.Second)
+	return nil
+}
+
 // ToProto converts CallActivityTaskConfig to google.protobuf.Struct for proto marshaling.
 func (c *CallActivityTaskConfig) ToProto() (*structpb.Struct, error) {
 	data := make(map[string]interface{})
@@ -41,8 +77,21 @@ func (c *CallActivityTaskConfig) ToProto() (*structpb.Struct, error) {
 	if !isEmpty(c.Input) {
 		data["input"] = c.Input
 	}
+	if !isEmpty(c.TaskQueue) {
+		data["taskQueue"] = c.TaskQueue
+	}
+	if !isEmpty(c.TimeoutSeconds) {
+		data["timeoutSeconds"] = c.TimeoutSeconds
+	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to CallActivityTaskConfig.
@@ -57,5 +106,35 @@ func (c *CallActivityTaskConfig) FromProto(s *structpb.Struct) error {
 		c.Input = val.GetStructValue().AsMap()
 	}
 
+	if val, ok := fields["taskQueue"]; ok {
+		c.TaskQueue = val.GetStringValue()
+	}
+
+	if val, ok := fields["timeoutSeconds"]; ok {
+		c.TimeoutSeconds = int32(val.GetNumberValue())
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "activity", "input", "taskQueue", "timeoutSeconds":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of CallActivityTaskConfig.
+func (c *CallActivityTaskConfig) String() string {
+	return summarizeConfig("CALL_ACTIVITY",
+		summaryField("activity", c.Activity),
+		summaryField("input", c.Input),
+		summaryField("taskQueue", c.TaskQueue),
+		summaryField("timeoutSeconds", c.TimeoutSeconds),
+	)
+}
@@ -1,12 +1,12 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: forktaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
 	"encoding/json"
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -37,6 +37,11 @@ type ForkTaskConfig struct {
 	Branches []*types.ForkBranch `json:"branches,omitempty"`
 	// If true, first branch to complete wins (race mode).  If false, all branches must complete (default).
 	Compete bool `json:"compete,omitempty"`
+	// If true, a failing branch records an error object instead of failing the fork.  The branch output becomes {"succeeded": false, "error": {"message": ...}},  so downstream tasks can branch on each outcome.  Ignored in race mode (compete).  Optional (default: false).
+	ContinueOnBranchError bool `json:"continueOnBranchError,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks ForkTaskConfig as a TaskConfig implementation.
@@ -61,8 +66,18 @@ func (c *ForkTaskConfig) ToProto() (*structpb.Struct, error) {
 	if !isEmpty(c.Compete) {
 		data["compete"] = c.Compete
 	}
+	if !isEmpty(c.ContinueOnBranchError) {
+		data["continueOnBranchError"] = c.ContinueOnBranchError
+	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to ForkTaskConfig.
@@ -84,5 +99,30 @@ func (c *ForkTaskConfig) FromProto(s *structpb.Struct) error {
 		c.Compete = val.GetBoolValue()
 	}
 
+	if val, ok := fields["continueOnBranchError"]; ok {
+		c.ContinueOnBranchError = val.GetBoolValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "branches", "compete", "continueOnBranchError":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of ForkTaskConfig.
+func (c *ForkTaskConfig) String() string {
+	return summarizeConfig("FORK",
+		summaryField("branches", c.Branches),
+		summaryField("compete", c.Compete),
+		summaryField("continueOnBranchError", c.ContinueOnBranchError),
+	)
+}
@@ -1,13 +1,16 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: fortaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+	"strings"
 )
 
 // ForTaskConfig defines the configuration for FOR tasks.
@@ -33,24 +36,105 @@ type ForTaskConfig struct {
 	// Variable name for each item in the iteration.  Accessible via ${ $data.item } in expressions.
 	Each string `json:"each,omitempty"`
 	// Expression evaluating to the collection to iterate over.  Example: "${ $data.items }" or "${ [1, 2, 3] }"
-	In interface{} `json:"in,omitempty"`
+	In string `json:"in,omitempty"`
+	// InRef sets In from a typed string reference, such as a
+	// StringRef or a task's Field(). Only one of In and InRef may be set.
+	InRef types.StringExpr `json:"-"`
 	// Tasks to execute for each iteration.  Loop variables available: ${ $data.item }, ${ $data.index }
 	Do []*types.WorkflowTask `json:"do,omitempty"`
+	// Expression evaluated after each iteration; the loop stops early when it is true.  Evaluated against the iteration's state, so it can read tasks of the body.  Example: ${ $context["poll"].status == "done" }  Optional (default: run every iteration).
+	Until string `json:"until,omitempty"`
+	// UntilRef sets Until from a typed string reference, such as a
+	// StringRef or a task's Field(). Only one of Until and UntilRef may be set.
+	UntilRef types.StringExpr `json:"-"`
+	// Maximum number of iterations the task may run.  The task fails instead of starting an iteration beyond the cap.  Optional (default: 0, no cap).
+	MaxIterations int32 `json:"maxIterations,omitempty"`
+	// If true, the task output is {"iterations": <count>, "results": [...]}  instead of the list of iteration results.  Optional (default: false).
+	ReportIterations bool `json:"reportIterations,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks ForTaskConfig as a TaskConfig implementation.
 func (c *ForTaskConfig) IsTaskConfig() {}
 
+// ValidateOneofs checks that at most one member of each oneof group is set.
+func (c *ForTaskConfig) ValidateOneofs() error {
+	// oneof in: in, inRef
+	{
+		var set []string
+		if c.In != "" {
+			set = append(set, "in")
+		}
+		if c.InRef != nil {
+			set = append(set, "inRef")
+		}
+		if len(set) > 1 {
+			return validation.NewValidationErrorWithCause(
+				"in",
+				strings.Join(set, ", "),
+				"oneof",
+				fmt.Sprintf("only one of %s may be set, got %s", "in, inRef", strings.Join(set, " and ")),
+				validation.ErrMutuallyExclusive,
+			)
+		}
+	}
+
+	// oneof until: until, untilRef
+	{
+		var set []string
+		if c.Until != "" {
+			set = append(set, "until")
+		}
+		if c.UntilRef != nil {
+			set = append(set, "untilRef")
+		}
+		if len(set) > 1 {
+			return validation.NewValidationErrorWithCause(
+				"until",
+				strings.Join(set, ", "),
+				"oneof",
+				fmt.Sprintf("only one of %s may be set, got %s", "until, untilRef", strings.Join(set, " and ")),
+				validation.ErrMutuallyExclusive,
+			)
+		}
+	}
+
+	return nil
+}
+
+// InExpression returns the expression of InRef when set, otherwise the literal
+// or raw expression held by In.
+func (c *ForTaskConfig) InExpression() string {
+	if c.InRef != nil {
+		return c.InRef.StringExpression()
+	}
+	return c.In
+}
+
+// UntilExpression returns the expression of UntilRef when set, otherwise the literal
+// or raw expression held by Until.
+func (c *ForTaskConfig) UntilExpression() string {
+	if c.UntilRef != nil {
+		return c.UntilRef.StringExpression()
+	}
+	return c.Until
+}
+
 // ToProto converts ForTaskConfig to google.protobuf.Struct for proto marshaling.
 func (c *ForTaskConfig) ToProto() (*structpb.Struct, error) {
+	if err := c.ValidateOneofs(); err != nil {
+		return nil, err
+	}
+
 	data := make(map[string]interface{})
 
 	if !isEmpty(c.Each) {
 		data["each"] = c.Each
 	}
-	if !isEmpty(c.In) {
-		// Smart conversion: accepts string or TaskFieldRef
-		data["in"] = coerceToString(c.In)
+	if in := c.InExpression(); in != "" {
+		data["in"] = in
 	}
 	if !isEmpty(c.Do) {
 		// Convert Do array to proto-compatible format using JSON marshaling
@@ -64,8 +148,24 @@ func (c *ForTaskConfig) ToProto() (*structpb.Struct, error) {
 		}
 		data["do"] = DoArray
 	}
+	if until := c.UntilExpression(); until != "" {
+		data["until"] = until
+	}
+	if !isEmpty(c.MaxIterations) {
+		data["maxIterations"] = c.MaxIterations
+	}
+	if !isEmpty(c.ReportIterations) {
+		data["reportIterations"] = c.ReportIterations
+	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to ForTaskConfig.
@@ -91,5 +191,38 @@ func (c *ForTaskConfig) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	if val, ok := fields["until"]; ok {
+		c.Until = val.GetStringValue()
+	}
+
+	if val, ok := fields["maxIterations"]; ok {
+		c.MaxIterations = int32(val.GetNumberValue())
+	}
+
+	if val, ok := fields["reportIterations"]; ok {
+		c.ReportIterations = val.GetBoolValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "each", "in", "do", "until", "maxIterations", "reportIterations":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of ForTaskConfig.
+func (c *ForTaskConfig) String() string {
+	return summarizeConfig("FOR",
+		summaryField("each", c.Each),
+		summaryField("do", c.Do),
+		summaryField("maxIterations", c.MaxIterations),
+	)
+}
@@ -1,10 +1,10 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: grpccalltaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -29,6 +29,9 @@ type GrpcCallTaskConfig struct {
 	Method string `json:"method,omitempty"`
 	// Request message (optional).  Can be any JSON structure matching the proto schema.  Supports expressions in string values.
 	Request map[string]interface{} `json:"request,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks GrpcCallTaskConfig as a TaskConfig implementation.
@@ -48,7 +51,14 @@ func (c *GrpcCallTaskConfig) ToProto() (*structpb.Struct, error) {
 		data["request"] = c.Request
 	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to GrpcCallTaskConfig.
@@ -67,5 +77,26 @@ func (c *GrpcCallTaskConfig) FromProto(s *structpb.Struct) error {
 		c.Request = val.GetStructValue().AsMap()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "service", "method", "request":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of GrpcCallTaskConfig.
+func (c *GrpcCallTaskConfig) String() string {
+	return summarizeConfig("GRPC_CALL",
+		summaryField("service", c.Service),
+		summaryField("method", c.Method),
+		summaryField("request", c.Request),
+	)
+}
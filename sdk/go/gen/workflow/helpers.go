@@ -1,11 +1,11 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // isEmpty checks if a value is empty/zero.
@@ -18,29 +18,34 @@ func isEmpty(v interface{}) bool {
 	return val.IsZero()
 }
 
-// coerceToString converts various types to strings for expression support.
-// Used by option functions to accept both string literals and expressions.
-func coerceToString(value interface{}) string {
-	if s, ok := value.(string); ok {
-		return s
+// summaryField formats a single field for a config's String() summary.
+// Empty fields are dropped; maps, lists and nested messages are reduced to
+// their size or presence so values (which may hold secrets) are never printed.
+func summaryField(name string, value interface{}) string {
+	if isEmpty(value) {
+		return ""
 	}
-	// NOTE: *Task handling omitted to avoid circular dependency between gen/workflow and workflow packages.
-	// Task-to-expression conversion is handled in the hand-written workflow package helpers.
-	// Handle StringRef - use Value() for resolved literals, Expression() for computed
-	if sr, ok := value.(interface {
-		Value() string
-		Expression() string
-	}); ok {
-		// Try Value() first (for resolved StringRef from Concat, etc.)
-		if v := sr.Value(); v != "" {
-			return v
-		}
-		// Fall back to Expression() for computed/context refs
-		return sr.Expression()
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Map:
+		return fmt.Sprintf("%s=<%d entries>", name, val.Len())
+	case reflect.Slice, reflect.Array:
+		return fmt.Sprintf("%s=<%d items>", name, val.Len())
+	case reflect.Ptr, reflect.Struct, reflect.Interface:
+		return fmt.Sprintf("%s=<set>", name)
+	default:
+		return fmt.Sprintf("%s=%v", name, value)
 	}
-	// Handle TaskFieldRef and other expression types
-	if expr, ok := value.(interface{ Expression() string }); ok {
-		return expr.Expression()
+}
+
+// summarizeConfig joins non-empty summary fields into a one-line summary.
+// Example: HTTP_CALL{method=GET endpoint=<set> timeoutSeconds=30}
+func summarizeConfig(kind string, fields ...string) string {
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f != "" {
+			parts = append(parts, f)
+		}
 	}
-	return fmt.Sprintf("%v", value)
+	return kind + "{" + strings.Join(parts, " ") + "}"
 }
@@ -1,18 +1,22 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: httpcalltaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
 	"encoding/json"
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+	"math"
+	"time"
 )
 
 // HttpCallTaskConfig defines the configuration for HTTP_CALL tasks.
 //
-//	HTTP_CALL tasks make HTTP requests (GET, POST, PUT, DELETE, PATCH).
+//	HTTP_CALL tasks make HTTP requests (GET, POST, PUT, DELETE, PATCH, HEAD,
+//	OPTIONS).
 //
 //	YAML Example:
 //	  - taskName:
@@ -25,10 +29,22 @@ import (
 //	          Authorization: "Bearer ${TOKEN}"
 //	        body:
 //	          field1: value
+//	        tls:
+//	          client_cert: "${.secrets.CLIENT_CERT}"
+//	          client_key: "${.secrets.CLIENT_KEY}"
+//	          ca_bundle: "${.secrets.INTERNAL_CA}"
+//	        cache:
+//	          ttl_seconds: 600
+//	          key: uri
+//	        proxy:
+//	          url: http://proxy.corp:3128
+//	        response_format: text
+//	        body_encoding: form
+//	        expect_status: [200, 201]
 //
 //	Reference: zigflow-dsl-pattern-catalog.md - Task Type 2
 type HttpCallTaskConfig struct {
-	// HTTP method (GET, POST, PUT, DELETE, PATCH).
+	// HTTP method (GET, POST, PUT, DELETE, PATCH, HEAD, OPTIONS).  HEAD and OPTIONS tasks output only the status code and the response  headers (lowercased names): {"statusCode": 200, "headers": {"etag": ...}}.
 	Method string `json:"method,omitempty"`
 	// HTTP endpoint configuration.
 	Endpoint *types.HttpEndpoint `json:"endpoint,omitempty"`
@@ -37,14 +53,62 @@ type HttpCallTaskConfig struct {
 	// Request body (optional).  Can be any JSON structure. Supports expressions in string values.
 	Body map[string]interface{} `json:"body,omitempty"`
 	// Request timeout in seconds (optional, default: 30).
+	//
+	// Deprecated: Use SetTimeoutDuration, which takes a time.Duration.
 	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// TLS configuration for the connection (optional).  Used for endpoints that require client certificates or a private CA.
+	Tls *types.HttpTls `json:"tls,omitempty"`
+	// Response caching for idempotent GET requests (optional).
+	Cache *types.HttpCache `json:"cache,omitempty"`
+	// Proxy used for the request (optional).  Overrides the proxy configured on the workflow runner.
+	Proxy *types.HttpProxy `json:"proxy,omitempty"`
+	// How the response body is stored in the task output (optional).  "": parsed as JSON when it is a JSON object or array, otherwise a string.  "json": parsed as JSON; the task fails if the body is not JSON.  "text": stored as a string.  "bytes_base64": stored base64-encoded, for binary payloads up to 1 MiB.  The format used is recorded in the task output metadata.
+	ResponseFormat string `json:"responseFormat,omitempty"`
+	// How the request body is encoded (optional).  "" or "json": the body is sent as JSON.  "form": the body is sent as application/x-www-form-urlencoded; nested    objects and arrays are flattened with bracket notation (a[b]=1, c[0]=2).  "multipart": each body field is sent as a part of a multipart/form-data    body, configured by multipart_parts.  The runner sets the Content-Type header to match the encoding.
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+	// Parts of a multipart body, in the order they are sent (optional).  Body fields without an entry are sent after them, sorted by name.
+	MultipartParts []*types.HttpMultipartPart `json:"multipartParts,omitempty"`
+	// Response statuses that complete the task (optional).  By default, the task fails on 3xx, 4xx and 5xx statuses. With  expect_status, it fails on any status not listed, including 2xx ones.  Failures carry a snippet of the response body and are classified  UPSTREAM_4XX or UPSTREAM_5XX by status class.
+	ExpectStatus []int32 `json:"expectStatus,omitempty"`
+	// The task completes whatever the response status (optional), for tasks  that branch on the status themselves. Exclusive with expect_status.
+	AllowAnyStatus bool `json:"allowAnyStatus,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks HttpCallTaskConfig as a TaskConfig implementation.
 func (c *HttpCallTaskConfig) IsTaskConfig() {}
 
+// TimeoutDuration returns TimeoutSeconds as a time.Duration.
+func (c *HttpCallTaskConfig) TimeoutDuration() time.Duration {
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// SetTimeoutDuration sets TimeoutSeconds from d, which must be a non-negative
+// whole number of seconds.
+func (c *HttpCallTaskConfig) SetTimeoutDuration(d time.Duration) error {
+	if d < 0 || d%time.Second != 0 || d/time.Second > math.MaxInt32 {
+		return validation.NewValidationErrorWithCause(
+			"timeoutSeconds",
+			d.String(),
+			"duration",
+			"must be a non-negative whole number of seconds",
+			validation.ErrInvalidDuration,
+		)
+	}
+	c.TimeoutSeconds = int32(d / time.Second)
+	return nil
+}
+
 // ToProto converts HttpCallTaskConfig to google.protobuf.Struct for proto marshaling.
 func (c *HttpCallTaskConfig) ToProto() (*structpb.Struct, error) {
+	if c.Endpoint != nil {
+		if err := c.Endpoint.ValidateOneofs(); err != nil {
+			return nil, err
+		}
+	}
+
 	data := make(map[string]interface{})
 
 	if !isEmpty(c.Method) {
@@ -61,8 +125,8 @@ func (c *HttpCallTaskConfig) ToProto() (*structpb.Struct, error) {
 			return nil, err
 		}
 		// Apply smart conversion to expression fields within the message
-		if uri, ok := EndpointMap["uri"]; ok {
-			EndpointMap["uri"] = coerceToString(uri)
+		if uri := c.Endpoint.UriExpression(); uri != "" {
+			EndpointMap["uri"] = uri
 		}
 		data["endpoint"] = EndpointMap
 	}
@@ -75,8 +139,82 @@ func (c *HttpCallTaskConfig) ToProto() (*structpb.Struct, error) {
 	if !isEmpty(c.TimeoutSeconds) {
 		data["timeoutSeconds"] = c.TimeoutSeconds
 	}
+	if !isEmpty(c.Tls) && c.Tls != nil {
+		// Convert Tls to proto-compatible format using JSON marshaling
+		jsonBytes, err := json.Marshal(c.Tls)
+		if err != nil {
+			return nil, err
+		}
+		var TlsMap map[string]interface{}
+		if err := json.Unmarshal(jsonBytes, &TlsMap); err != nil {
+			return nil, err
+		}
+		// Apply smart conversion to expression fields within the message
+		data["tls"] = TlsMap
+	}
+	if !isEmpty(c.Cache) && c.Cache != nil {
+		// Convert Cache to proto-compatible format using JSON marshaling
+		jsonBytes, err := json.Marshal(c.Cache)
+		if err != nil {
+			return nil, err
+		}
+		var CacheMap map[string]interface{}
+		if err := json.Unmarshal(jsonBytes, &CacheMap); err != nil {
+			return nil, err
+		}
+		// Apply smart conversion to expression fields within the message
+		data["cache"] = CacheMap
+	}
+	if !isEmpty(c.Proxy) && c.Proxy != nil {
+		// Convert Proxy to proto-compatible format using JSON marshaling
+		jsonBytes, err := json.Marshal(c.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		var ProxyMap map[string]interface{}
+		if err := json.Unmarshal(jsonBytes, &ProxyMap); err != nil {
+			return nil, err
+		}
+		// Apply smart conversion to expression fields within the message
+		data["proxy"] = ProxyMap
+	}
+	if !isEmpty(c.ResponseFormat) {
+		data["responseFormat"] = c.ResponseFormat
+	}
+	if !isEmpty(c.BodyEncoding) {
+		data["bodyEncoding"] = c.BodyEncoding
+	}
+	if !isEmpty(c.MultipartParts) {
+		// Convert MultipartParts array to proto-compatible format using JSON marshaling
+		jsonBytes, err := json.Marshal(c.MultipartParts)
+		if err != nil {
+			return nil, err
+		}
+		var MultipartPartsArray []interface{}
+		if err := json.Unmarshal(jsonBytes, &MultipartPartsArray); err != nil {
+			return nil, err
+		}
+		data["multipartParts"] = MultipartPartsArray
+	}
+	if !isEmpty(c.ExpectStatus) {
+		ExpectStatusList := make([]interface{}, 0, len(c.ExpectStatus))
+		for _, v := range c.ExpectStatus {
+			ExpectStatusList = append(ExpectStatusList, v)
+		}
+		data["expectStatus"] = ExpectStatusList
+	}
+	if !isEmpty(c.AllowAnyStatus) {
+		data["allowAnyStatus"] = c.AllowAnyStatus
+	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to HttpCallTaskConfig.
@@ -109,5 +247,87 @@ func (c *HttpCallTaskConfig) FromProto(s *structpb.Struct) error {
 		c.TimeoutSeconds = int32(val.GetNumberValue())
 	}
 
+	if val, ok := fields["tls"]; ok {
+		c.Tls = &types.HttpTls{}
+		if err := c.Tls.FromProto(val.GetStructValue()); err != nil {
+			return err
+		}
+	}
+
+	if val, ok := fields["cache"]; ok {
+		c.Cache = &types.HttpCache{}
+		if err := c.Cache.FromProto(val.GetStructValue()); err != nil {
+			return err
+		}
+	}
+
+	if val, ok := fields["proxy"]; ok {
+		c.Proxy = &types.HttpProxy{}
+		if err := c.Proxy.FromProto(val.GetStructValue()); err != nil {
+			return err
+		}
+	}
+
+	if val, ok := fields["responseFormat"]; ok {
+		c.ResponseFormat = val.GetStringValue()
+	}
+
+	if val, ok := fields["bodyEncoding"]; ok {
+		c.BodyEncoding = val.GetStringValue()
+	}
+
+	if val, ok := fields["multipartParts"]; ok {
+		c.MultipartParts = make([]*types.HttpMultipartPart, 0)
+		for _, v := range val.GetListValue().GetValues() {
+			item := &types.HttpMultipartPart{}
+			if err := item.FromProto(v.GetStructValue()); err != nil {
+				return err
+			}
+			c.MultipartParts = append(c.MultipartParts, item)
+		}
+	}
+
+	if val, ok := fields["expectStatus"]; ok {
+		c.ExpectStatus = make([]int32, 0)
+		for _, v := range val.GetListValue().GetValues() {
+			c.ExpectStatus = append(c.ExpectStatus, int32(v.GetNumberValue()))
+		}
+	}
+
+	if val, ok := fields["allowAnyStatus"]; ok {
+		c.AllowAnyStatus = val.GetBoolValue()
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "method", "endpoint", "headers", "body", "timeoutSeconds", "tls", "cache", "proxy", "responseFormat", "bodyEncoding", "multipartParts", "expectStatus", "allowAnyStatus":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of HttpCallTaskConfig.
+func (c *HttpCallTaskConfig) String() string {
+	return summarizeConfig("HTTP_CALL",
+		summaryField("method", c.Method),
+		summaryField("endpoint", c.Endpoint),
+		summaryField("headers", c.Headers),
+		summaryField("body", c.Body),
+		summaryField("timeoutSeconds", c.TimeoutSeconds),
+		summaryField("tls", c.Tls),
+		summaryField("cache", c.Cache),
+		summaryField("proxy", c.Proxy),
+		summaryField("responseFormat", c.ResponseFormat),
+		summaryField("bodyEncoding", c.BodyEncoding),
+		summaryField("multipartParts", c.MultipartParts),
+		summaryField("expectStatus", c.ExpectStatus),
+		summaryField("allowAnyStatus", c.AllowAnyStatus),
+	)
+}
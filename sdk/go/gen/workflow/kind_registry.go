@@ -0,0 +1,112 @@
+// Code generated by stigmer-codegen. DO NOT EDIT.
+// Source: kind_registry.go
+
+package workflow
+
+import (
+	"fmt"
+	"sort"
+
+	_ "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TaskConfig is implemented by every generated task config.
+type TaskConfig interface {
+	IsTaskConfig()
+	ToProto() (*structpb.Struct, error)
+	FromProto(s *structpb.Struct) error
+	String() string
+}
+
+// TaskConfigFactory creates a zero-valued task config.
+type TaskConfigFactory func() TaskConfig
+
+// taskConfigRegistry maps task kinds (e.g. "HTTP_CALL") to config factories.
+var taskConfigRegistry = make(map[string]TaskConfigFactory)
+
+// RegisterTaskConfig registers the config factory for a task kind.
+// Registering a kind again replaces its factory.
+func RegisterTaskConfig(kind string, factory TaskConfigFactory) {
+	taskConfigRegistry[kind] = factory
+}
+
+// LookupTaskConfig returns the config factory registered for a task kind.
+func LookupTaskConfig(kind string) (TaskConfigFactory, bool) {
+	factory, ok := taskConfigRegistry[kind]
+	return factory, ok
+}
+
+// TaskConfigFromProto decodes s into a new config of the given task kind.
+func TaskConfigFromProto(kind string, s *structpb.Struct) (TaskConfig, error) {
+	factory, ok := LookupTaskConfig(kind)
+	if !ok {
+		return nil, fmt.Errorf("unknown task kind: %s", kind)
+	}
+	config := factory()
+	if err := config.FromProto(s); err != nil {
+		return nil, fmt.Errorf("failed to decode %s task config: %w", kind, err)
+	}
+	return config, nil
+}
+
+// RegisteredTaskKinds returns all registered task kinds in sorted order.
+func RegisteredTaskKinds() []string {
+	kinds := make([]string, 0, len(taskConfigRegistry))
+	for kind := range taskConfigRegistry {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// taskConfigMessages maps task kinds to the full name of their proto config
+// message, which the buf.validate rules are declared on.
+var taskConfigMessages = map[string]protoreflect.FullName{
+	"AGENT_CALL":    "ai.stigmer.agentic.workflow.v1.tasks.AgentCallTaskConfig",
+	"CALL_ACTIVITY": "ai.stigmer.agentic.workflow.v1.tasks.CallActivityTaskConfig",
+	"FOR":           "ai.stigmer.agentic.workflow.v1.tasks.ForTaskConfig",
+	"FORK":          "ai.stigmer.agentic.workflow.v1.tasks.ForkTaskConfig",
+	"GRPC_CALL":     "ai.stigmer.agentic.workflow.v1.tasks.GrpcCallTaskConfig",
+	"HTTP_CALL":     "ai.stigmer.agentic.workflow.v1.tasks.HttpCallTaskConfig",
+	"LISTEN":        "ai.stigmer.agentic.workflow.v1.tasks.ListenTaskConfig",
+	"RAISE":         "ai.stigmer.agentic.workflow.v1.tasks.RaiseTaskConfig",
+	"RUN":           "ai.stigmer.agentic.workflow.v1.tasks.RunTaskConfig",
+	"SET":           "ai.stigmer.agentic.workflow.v1.tasks.SetTaskConfig",
+	"SWITCH":        "ai.stigmer.agentic.workflow.v1.tasks.SwitchTaskConfig",
+	"TRY":           "ai.stigmer.agentic.workflow.v1.tasks.TryTaskConfig",
+	"WAIT":          "ai.stigmer.agentic.workflow.v1.tasks.WaitTaskConfig",
+}
+
+// NewTaskConfigMessage returns an empty proto config message for a task kind,
+// such as tasks.HttpCallTaskConfig for "HTTP_CALL".
+func NewTaskConfigMessage(kind string) (proto.Message, error) {
+	name, ok := taskConfigMessages[kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported task kind: %s", kind)
+	}
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("config message of task kind %s: %w", kind, err)
+	}
+	return messageType.New().Interface(), nil
+}
+
+func init() {
+	RegisterTaskConfig("AGENT_CALL", func() TaskConfig { return &AgentCallTaskConfig{} })
+	RegisterTaskConfig("CALL_ACTIVITY", func() TaskConfig { return &CallActivityTaskConfig{} })
+	RegisterTaskConfig("FOR", func() TaskConfig { return &ForTaskConfig{} })
+	RegisterTaskConfig("FORK", func() TaskConfig { return &ForkTaskConfig{} })
+	RegisterTaskConfig("GRPC_CALL", func() TaskConfig { return &GrpcCallTaskConfig{} })
+	RegisterTaskConfig("HTTP_CALL", func() TaskConfig { return &HttpCallTaskConfig{} })
+	RegisterTaskConfig("LISTEN", func() TaskConfig { return &ListenTaskConfig{} })
+	RegisterTaskConfig("RAISE", func() TaskConfig { return &RaiseTaskConfig{} })
+	RegisterTaskConfig("RUN", func() TaskConfig { return &RunTaskConfig{} })
+	RegisterTaskConfig("SET", func() TaskConfig { return &SetTaskConfig{} })
+	RegisterTaskConfig("SWITCH", func() TaskConfig { return &SwitchTaskConfig{} })
+	RegisterTaskConfig("TRY", func() TaskConfig { return &TryTaskConfig{} })
+	RegisterTaskConfig("WAIT", func() TaskConfig { return &WaitTaskConfig{} })
+}
@@ -1,13 +1,16 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: listentaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
 	"encoding/json"
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+	"math"
+	"time"
 )
 
 // ListenTaskConfig defines the configuration for LISTEN tasks.
@@ -27,11 +30,55 @@ import (
 type ListenTaskConfig struct {
 	// Signal listening configuration.
 	To *types.ListenTo `json:"to,omitempty"`
+	// Human approval gate (optional).
+	//
+	//	When set, the task pauses the workflow until an approval decision is sent
+	//	through WorkflowExecutionCommandController.approve. While waiting, the
+	//	execution is in phase EXECUTION_AWAITING_APPROVAL and lists the task in
+	//	status.pending_approvals.
+	//
+	//	Task output once approved:
+	//	  {approved: true, approved_by: "...", approved_at: "...", comment: "..."}
+	//
+	//	A rejection fails the task with error type "ApprovalRejected".
+	Approval *types.ListenApproval `json:"approval,omitempty"`
+	// How long to wait for the signals, in seconds (0 = 1 minute).
+	//
+	//	The task fails with error type "ListenTimeout" when no accepted signal
+	//	arrives in time. Ignored for approval gates, which use
+	//	approval.timeout_seconds.
+	//
+	// Deprecated: Use SetTimeoutDuration, which takes a time.Duration.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks ListenTaskConfig as a TaskConfig implementation.
 func (c *ListenTaskConfig) IsTaskConfig() {}
 
+// TimeoutDuration returns TimeoutSeconds as a time.Duration.
+func (c *ListenTaskConfig) TimeoutDuration() time.Duration {
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// SetTimeoutDuration sets TimeoutSeconds from d, which must be a non-negative
+// whole number of seconds.
+func (c *ListenTaskConfig) SetTimeoutDuration(d time.Duration) error {
+	if d < 0 || d%time.Second != 0 || d/time.Second > math.MaxInt32 {
+		return validation.NewValidationErrorWithCause(
+			"timeoutSeconds",
+			d.String(),
+			"duration",
+			"must be a non-negative whole number of seconds",
+			validation.ErrInvalidDuration,
+		)
+	}
+	c.TimeoutSeconds = int32(d / time.Second)
+	return nil
+}
+
 // ToProto converts ListenTaskConfig to google.protobuf.Struct for proto marshaling.
 func (c *ListenTaskConfig) ToProto() (*structpb.Struct, error) {
 	data := make(map[string]interface{})
@@ -49,8 +96,31 @@ func (c *ListenTaskConfig) ToProto() (*structpb.Struct, error) {
 		// Apply smart conversion to expression fields within the message
 		data["to"] = ToMap
 	}
+	if !isEmpty(c.Approval) && c.Approval != nil {
+		// Convert Approval to proto-compatible format using JSON marshaling
+		jsonBytes, err := json.Marshal(c.Approval)
+		if err != nil {
+			return nil, err
+		}
+		var ApprovalMap map[string]interface{}
+		if err := json.Unmarshal(jsonBytes, &ApprovalMap); err != nil {
+			return nil, err
+		}
+		// Apply smart conversion to expression fields within the message
+		data["approval"] = ApprovalMap
+	}
+	if !isEmpty(c.TimeoutSeconds) {
+		data["timeoutSeconds"] = c.TimeoutSeconds
+	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to ListenTaskConfig.
@@ -64,5 +134,37 @@ func (c *ListenTaskConfig) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	if val, ok := fields["approval"]; ok {
+		c.Approval = &types.ListenApproval{}
+		if err := c.Approval.FromProto(val.GetStructValue()); err != nil {
+			return err
+		}
+	}
+
+	if val, ok := fields["timeoutSeconds"]; ok {
+		c.TimeoutSeconds = int32(val.GetNumberValue())
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "to", "approval", "timeoutSeconds":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of ListenTaskConfig.
+func (c *ListenTaskConfig) String() string {
+	return summarizeConfig("LISTEN",
+		summaryField("to", c.To),
+		summaryField("approval", c.Approval),
+		summaryField("timeoutSeconds", c.TimeoutSeconds),
+	)
+}
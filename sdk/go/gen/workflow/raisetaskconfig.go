@@ -1,11 +1,15 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: raisetaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
+	"fmt"
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+	"strings"
 )
 
 // RaiseTaskConfig defines the configuration for RAISE tasks.
@@ -21,28 +25,109 @@ import (
 //	Reference: zigflow-dsl-pattern-catalog.md - Task Type 11
 type RaiseTaskConfig struct {
 	// Error type/name.
-	Error interface{} `json:"error,omitempty"`
+	Error string `json:"error,omitempty"`
+	// ErrorRef sets Error from a typed string reference, such as a
+	// StringRef or a task's Field(). Only one of Error and ErrorRef may be set.
+	ErrorRef types.StringExpr `json:"-"`
 	// Error message.  Can contain expressions: "${ .errorMessage }"
-	Message interface{} `json:"message,omitempty"`
+	Message string `json:"message,omitempty"`
+	// MessageRef sets Message from a typed string reference, such as a
+	// StringRef or a task's Field(). Only one of Message and MessageRef may be set.
+	MessageRef types.StringExpr `json:"-"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks RaiseTaskConfig as a TaskConfig implementation.
 func (c *RaiseTaskConfig) IsTaskConfig() {}
 
+// ValidateOneofs checks that at most one member of each oneof group is set.
+func (c *RaiseTaskConfig) ValidateOneofs() error {
+	// oneof error: error, errorRef
+	{
+		var set []string
+		if c.Error != "" {
+			set = append(set, "error")
+		}
+		if c.ErrorRef != nil {
+			set = append(set, "errorRef")
+		}
+		if len(set) > 1 {
+			return validation.NewValidationErrorWithCause(
+				"error",
+				strings.Join(set, ", "),
+				"oneof",
+				fmt.Sprintf("only one of %s may be set, got %s", "error, errorRef", strings.Join(set, " and ")),
+				validation.ErrMutuallyExclusive,
+			)
+		}
+	}
+
+	// oneof message: message, messageRef
+	{
+		var set []string
+		if c.Message != "" {
+			set = append(set, "message")
+		}
+		if c.MessageRef != nil {
+			set = append(set, "messageRef")
+		}
+		if len(set) > 1 {
+			return validation.NewValidationErrorWithCause(
+				"message",
+				strings.Join(set, ", "),
+				"oneof",
+				fmt.Sprintf("only one of %s may be set, got %s", "message, messageRef", strings.Join(set, " and ")),
+				validation.ErrMutuallyExclusive,
+			)
+		}
+	}
+
+	return nil
+}
+
+// ErrorExpression returns the expression of ErrorRef when set, otherwise the literal
+// or raw expression held by Error.
+func (c *RaiseTaskConfig) ErrorExpression() string {
+	if c.ErrorRef != nil {
+		return c.ErrorRef.StringExpression()
+	}
+	return c.Error
+}
+
+// MessageExpression returns the expression of MessageRef when set, otherwise the literal
+// or raw expression held by Message.
+func (c *RaiseTaskConfig) MessageExpression() string {
+	if c.MessageRef != nil {
+		return c.MessageRef.StringExpression()
+	}
+	return c.Message
+}
+
 // ToProto converts RaiseTaskConfig to google.protobuf.Struct for proto marshaling.
 func (c *RaiseTaskConfig) ToProto() (*structpb.Struct, error) {
+	if err := c.ValidateOneofs(); err != nil {
+		return nil, err
+	}
+
 	data := make(map[string]interface{})
 
-	if !isEmpty(c.Error) {
-		// Smart conversion: accepts string or TaskFieldRef
-		data["error"] = coerceToString(c.Error)
+	if error := c.ErrorExpression(); error != "" {
+		data["error"] = error
 	}
-	if !isEmpty(c.Message) {
-		// Smart conversion: accepts string or TaskFieldRef
-		data["message"] = coerceToString(c.Message)
+	if message := c.MessageExpression(); message != "" {
+		data["message"] = message
 	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to RaiseTaskConfig.
@@ -57,5 +142,22 @@ func (c *RaiseTaskConfig) FromProto(s *structpb.Struct) error {
 		c.Message = val.GetStringValue()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "error", "message":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of RaiseTaskConfig.
+func (c *RaiseTaskConfig) String() string {
+	return summarizeConfig("RAISE")
+}
@@ -1,10 +1,10 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: runtaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -25,6 +25,9 @@ type RunTaskConfig struct {
 	Workflow string `json:"workflow,omitempty"`
 	// Sub-workflow input (optional).  Can be any JSON structure.  Supports expressions in string values.
 	Input map[string]interface{} `json:"input,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks RunTaskConfig as a TaskConfig implementation.
@@ -41,7 +44,14 @@ func (c *RunTaskConfig) ToProto() (*structpb.Struct, error) {
 		data["input"] = c.Input
 	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to RunTaskConfig.
@@ -56,5 +66,25 @@ func (c *RunTaskConfig) FromProto(s *structpb.Struct) error {
 		c.Input = val.GetStructValue().AsMap()
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "workflow", "input":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of RunTaskConfig.
+func (c *RunTaskConfig) String() string {
+	return summarizeConfig("RUN",
+		summaryField("workflow", c.Workflow),
+		summaryField("input", c.Input),
+	)
+}
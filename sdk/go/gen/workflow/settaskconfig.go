@@ -1,10 +1,10 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: settaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -18,11 +18,17 @@ import (
 //	        variable1: value
 //	        variable2: ${ expression }
 //	        computed: ${ .a + .b }
+//	        tempToken: null  # unset
 //
 //	Reference: zigflow-dsl-pattern-catalog.md - Task Type 1
 type SetTaskConfig struct {
-	// Variables to set in workflow state.  Keys are variable names, values can be literals or expressions.  Expressions use ${...} syntax, e.g., "${.a + .b}" or "${now}"
-	Variables map[string]string `json:"variables,omitempty"`
+	// Variables to set in workflow state.  Keys are variable names, values can be literals or expressions.  Literals keep their JSON type: numbers, booleans, objects and arrays are  set as such, not as strings.  Expressions use ${...} syntax, e.g., "${.a + .b}" or "${now}"
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	// Variables to remove from workflow state.  The runner deletes each key from the workflow data and from task exports  in $context, rather than setting it to an empty value. A variable cannot  be both set and unset by the same task.
+	Unset []string `json:"unset,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks SetTaskConfig as a TaskConfig implementation.
@@ -36,7 +42,22 @@ func (c *SetTaskConfig) ToProto() (*structpb.Struct, error) {
 		data["variables"] = c.Variables
 	}
 
-	return structpb.NewStruct(data)
+	if !isEmpty(c.Unset) {
+		UnsetList := make([]interface{}, 0, len(c.Unset))
+		for _, v := range c.Unset {
+			UnsetList = append(UnsetList, v)
+		}
+		data["unset"] = UnsetList
+	}
+
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to SetTaskConfig.
@@ -44,11 +65,35 @@ func (c *SetTaskConfig) FromProto(s *structpb.Struct) error {
 	fields := s.GetFields()
 
 	if val, ok := fields["variables"]; ok {
-		c.Variables = make(map[string]string)
-		for k, v := range val.GetStructValue().GetFields() {
-			c.Variables[k] = v.GetStringValue()
+		c.Variables = val.GetStructValue().AsMap()
+	}
+
+	if val, ok := fields["unset"]; ok {
+		c.Unset = make([]string, 0)
+		for _, v := range val.GetListValue().GetValues() {
+			c.Unset = append(c.Unset, v.GetStringValue())
 		}
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "variables", "unset":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of SetTaskConfig.
+func (c *SetTaskConfig) String() string {
+	return summarizeConfig("SET",
+		summaryField("variables", c.Variables),
+		summaryField("unset", c.Unset),
+	)
+}
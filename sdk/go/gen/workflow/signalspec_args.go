@@ -1,6 +1,5 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: signalspec_args.go
-// Generated: 2026-01-26T03:24:19+05:30
 
 package workflow
 
@@ -14,4 +13,6 @@ type SignalArgs struct {
 	Id string `json:"id,omitempty"`
 	// Signal type:  - "signal": Temporal signal  - "query": Temporal query  - "update": Temporal update
 	Type string `json:"type,omitempty"`
+	// Correlation filter (optional).  A boolean expression evaluated against each received payload, available  as ".". Payloads for which it is false are ignored and the task keeps  waiting, so a signal meant for another order or request cannot complete  the task.  Example: "${ .orderId == $context.createOrder.id }"
+	AcceptIf string `json:"acceptIf,omitempty"`
 }
@@ -1,12 +1,12 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: switchtaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
 	"encoding/json"
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -27,6 +27,9 @@ import (
 type SwitchTaskConfig struct {
 	// List of switch cases (at least one required).  Cases are evaluated in order. First matching case executes.  If no "when" is specified, the case acts as default.
 	Cases []*types.SwitchCase `json:"cases,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks SwitchTaskConfig as a TaskConfig implementation.
@@ -49,7 +52,14 @@ func (c *SwitchTaskConfig) ToProto() (*structpb.Struct, error) {
 		data["cases"] = CasesArray
 	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to SwitchTaskConfig.
@@ -67,5 +77,24 @@ func (c *SwitchTaskConfig) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "cases":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of SwitchTaskConfig.
+func (c *SwitchTaskConfig) String() string {
+	return summarizeConfig("SWITCH",
+		summaryField("cases", c.Cases),
+	)
+}
@@ -1,12 +1,12 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: trytaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
 	"encoding/json"
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -32,12 +32,28 @@ import (
 //	                body:
 //	                  error: ${ .error }
 //
+//	Filtered catch blocks (catches) handle specific errors, such as rate
+//	limiting or client errors, differently. They are evaluated in declaration
+//	order and the first matching block handles the error; catch handles the
+//	errors none of them matched.
+//
+//	A retry policy (retry) runs the try tasks again when they fail with one of
+//	its error types, such as "ExecutionTimeout", up to max_retries times. The
+//	catch blocks only see the error of the last attempt.
+//
 //	Reference: zigflow-dsl-pattern-catalog.md - Task Type 6
 type TryTaskConfig struct {
 	// Tasks to attempt (at least one required).  If any task fails, execution jumps to catch block.
 	Try []*types.WorkflowTask `json:"try,omitempty"`
-	// Catch block for error handling (optional).  If not provided, errors propagate to parent.
+	// Catch block for error handling (optional).  If not provided, errors propagate to parent.  Evaluated after catches, for the errors none of them matched.
 	Catch *types.CatchBlock `json:"catch,omitempty"`
+	// Catch blocks evaluated in declaration order before catch (optional).  The first block whose filters match the error handles it. A block without  filters matches every error, so it can only be the last one.
+	Catches []*types.CatchBlock `json:"catches,omitempty"`
+	// Retry policy for the try tasks (optional).  A failed attempt whose error matches the policy runs the try tasks again  before any catch block sees the error.
+	Retry *types.RetryPolicy `json:"retry,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks TryTaskConfig as a TaskConfig implementation.
@@ -72,8 +88,40 @@ func (c *TryTaskConfig) ToProto() (*structpb.Struct, error) {
 		// Apply smart conversion to expression fields within the message
 		data["catch"] = CatchMap
 	}
+	if !isEmpty(c.Catches) {
+		// Convert Catches array to proto-compatible format using JSON marshaling
+		jsonBytes, err := json.Marshal(c.Catches)
+		if err != nil {
+			return nil, err
+		}
+		var CatchesArray []interface{}
+		if err := json.Unmarshal(jsonBytes, &CatchesArray); err != nil {
+			return nil, err
+		}
+		data["catches"] = CatchesArray
+	}
+	if !isEmpty(c.Retry) && c.Retry != nil {
+		// Convert Retry to proto-compatible format using JSON marshaling
+		jsonBytes, err := json.Marshal(c.Retry)
+		if err != nil {
+			return nil, err
+		}
+		var RetryMap map[string]interface{}
+		if err := json.Unmarshal(jsonBytes, &RetryMap); err != nil {
+			return nil, err
+		}
+		// Apply smart conversion to expression fields within the message
+		data["retry"] = RetryMap
+	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to TryTaskConfig.
@@ -98,5 +146,45 @@ func (c *TryTaskConfig) FromProto(s *structpb.Struct) error {
 		}
 	}
 
+	if val, ok := fields["catches"]; ok {
+		c.Catches = make([]*types.CatchBlock, 0)
+		for _, v := range val.GetListValue().GetValues() {
+			item := &types.CatchBlock{}
+			if err := item.FromProto(v.GetStructValue()); err != nil {
+				return err
+			}
+			c.Catches = append(c.Catches, item)
+		}
+	}
+
+	if val, ok := fields["retry"]; ok {
+		c.Retry = &types.RetryPolicy{}
+		if err := c.Retry.FromProto(val.GetStructValue()); err != nil {
+			return err
+		}
+	}
+
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "try", "catch", "catches", "retry":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of TryTaskConfig.
+func (c *TryTaskConfig) String() string {
+	return summarizeConfig("TRY",
+		summaryField("try", c.Try),
+		summaryField("catch", c.Catch),
+		summaryField("catches", c.Catches),
+		summaryField("retry", c.Retry),
+	)
+}
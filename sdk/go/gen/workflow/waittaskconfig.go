@@ -1,11 +1,14 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: waittaskconfig.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package workflow
 
 import (
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
+	"math"
+	"time"
 )
 
 // WaitTaskConfig defines the configuration for WAIT tasks.
@@ -20,12 +23,38 @@ import (
 //	Reference: zigflow-dsl-pattern-catalog.md - Task Type 8
 type WaitTaskConfig struct {
 	// Number of seconds to wait.  Must be at least 1 second.
+	//
+	// Deprecated: Use SetDuration, which takes a time.Duration.
 	Seconds int32 `json:"seconds,omitempty"`
+	// unknownFields holds the fields FromProto did not recognize, such as
+	// fields added by a newer SDK, so they are written back unchanged.
+	unknownFields map[string]*structpb.Value
 }
 
 // IsTaskConfig marks WaitTaskConfig as a TaskConfig implementation.
 func (c *WaitTaskConfig) IsTaskConfig() {}
 
+// Duration returns Seconds as a time.Duration.
+func (c *WaitTaskConfig) Duration() time.Duration {
+	return time.Duration(c.Seconds) * time.Second
+}
+
+// SetDuration sets Seconds from d, which must be a non-negative
+// whole number of seconds.
+func (c *WaitTaskConfig) SetDuration(d time.Duration) error {
+	if d < 0 || d%time.Second != 0 || d/time.Second > math.MaxInt32 {
+		return validation.NewValidationErrorWithCause(
+			"seconds",
+			d.String(),
+			"duration",
+			"must be a non-negative whole number of seconds",
+			validation.ErrInvalidDuration,
+		)
+	}
+	c.Seconds = int32(d / time.Second)
+	return nil
+}
+
 // ToProto converts WaitTaskConfig to google.protobuf.Struct for proto marshaling.
 func (c *WaitTaskConfig) ToProto() (*structpb.Struct, error) {
 	data := make(map[string]interface{})
@@ -34,7 +63,14 @@ func (c *WaitTaskConfig) ToProto() (*structpb.Struct, error) {
 		data["seconds"] = c.Seconds
 	}
 
-	return structpb.NewStruct(data)
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range c.unknownFields {
+		s.Fields[key] = proto.Clone(val).(*structpb.Value)
+	}
+	return s, nil
 }
 
 // FromProto converts google.protobuf.Struct to WaitTaskConfig.
@@ -45,5 +81,24 @@ func (c *WaitTaskConfig) FromProto(s *structpb.Struct) error {
 		c.Seconds = int32(val.GetNumberValue())
 	}
 
+	c.unknownFields = nil
+	for key, val := range fields {
+		switch key {
+		case "seconds":
+			continue
+		}
+		if c.unknownFields == nil {
+			c.unknownFields = make(map[string]*structpb.Value)
+		}
+		c.unknownFields[key] = proto.Clone(val).(*structpb.Value)
+	}
+
 	return nil
 }
+
+// String returns a redacted one-line summary of WaitTaskConfig.
+func (c *WaitTaskConfig) String() string {
+	return summarizeConfig("WAIT",
+		summaryField("seconds", c.Seconds),
+	)
+}
@@ -0,0 +1,24 @@
+// Code generated by stigmer-codegen. DO NOT EDIT.
+// Source: apikeyspec_args.go
+
+package gen
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ApiKeyArgs contains the configuration arguments for creating a ApiKey.
+//
+// This struct follows the Pulumi Args pattern for resource configuration.
+//
+// api-key spec
+type ApiKeyArgs struct {
+	// SHA-256/Bcrypt hash of the raw key generated by the system during.  the system returns the actual key in this field in create response but the  actual key itself is never persisted in the database. only the last 6 chars of the key are persisted in the fingerprint field for UI presentation.
+	KeyHash string `json:"keyHash,omitempty"`
+	// UI-visible fingerprint (e.g. last 6 chars)
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// absolute expiry time (null if neverExpires = true)
+	ExpiresAt *timestamppb.Timestamp `json:"expiresAt,omitempty"`
+	// never expire flag (forces expires_at to be ignored)
+	NeverExpires bool `json:"neverExpires,omitempty"`
+}
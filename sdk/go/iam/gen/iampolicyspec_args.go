@@ -0,0 +1,24 @@
+// Code generated by stigmer-codegen. DO NOT EDIT.
+// Source: iampolicyspec_args.go
+
+package gen
+
+import (
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+)
+
+// IamPolicyArgs contains the configuration arguments for creating a IamPolicy.
+//
+// This struct follows the Pulumi Args pattern for resource configuration.
+//
+// IamPolicySpec defines the desired state of an IAM policy binding.
+//
+//	It specifies WHO (principal) gets WHAT permission (relation) on WHICH resource.
+type IamPolicyArgs struct {
+	// Principal: WHO is being granted access  This can be any API resource that acts as an identity:  - identity_account (individual user)  - team (group of users)  - organization (for cross-org grants)  - Any other resource that can be a subject in authorization
+	Principal *types.ApiResourceRef `json:"principal"`
+	// Resource: WHAT is being accessed  This can be any API resource that is being protected:  - organization  - environment  - cloud_resource (VPC, S3 bucket, etc.)  - service  - Any other resource that requires access control
+	Resource *types.ApiResourceRef `json:"resource"`
+	// Relation: HOW/what permission is being granted  This is the FGA relation/permission being granted (e.g., "admin", "viewer", "owner")  The relation value maps to the role_code from IamRole.  Examples: "admin", "editor", "viewer", "owner", "member"   When this policy is synced to OpenFGA, this becomes the relation in the tuple:  principal.kind:principal.id#principal.relation@resource.kind:resource.id#relation
+	Relation string `json:"relation"`
+}
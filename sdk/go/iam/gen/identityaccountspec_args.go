@@ -0,0 +1,24 @@
+// Code generated by stigmer-codegen. DO NOT EDIT.
+// Source: identityaccountspec_args.go
+
+package gen
+
+// IdentityAccountArgs contains the configuration arguments for creating a IdentityAccount.
+//
+// This struct follows the Pulumi Args pattern for resource configuration.
+//
+// identity-account spec
+type IdentityAccountArgs struct {
+	// idp id of the identity account.  this is the id assigned by auth0 to the identity account.
+	IdpId string `json:"idpId"`
+	// email of the identity account.  (ignored for create) this value is assigned by backend.  email is based on the email used to signup to the application.
+	Email string `json:"email,omitempty"`
+	// first name of the identity account.
+	FirstName string `json:"firstName,omitempty"`
+	// last name of the identity account.
+	LastName string `json:"lastName,omitempty"`
+	// url of the profile picture for the user on auth0
+	PictureUrl string `json:"pictureUrl,omitempty"`
+	// indicates if this is a machine account used for inter-service communication.  machine accounts have idp_id values ending with "@clients" suffix.  (ignored for create) this value is assigned by backend based on idp_id.
+	IsMachineAccount bool `json:"isMachineAccount,omitempty"`
+}
@@ -373,7 +373,7 @@ func TestIntegration_ManyResourcesStressTest(t *testing.T) {
 			// Add 10 tasks per workflow with unique names
 			for j := 0; j < 10; j++ {
 				setTask := workflow.Set(fmt.Sprintf("task-%d", j), &workflow.SetArgs{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						fmt.Sprintf("key%d", j): fmt.Sprintf("value%d", j),
 					},
 				})
@@ -0,0 +1,181 @@
+// Package envbinding binds the environment variables declared by a template
+// (an agent or a workflow) for one of its instances.
+//
+// It holds what agentinstance and workflowinstance share: the Value type,
+// the checks of bindings against the declared variables, and the Environment
+// resource the bindings are synthesized into.
+package envbinding
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/sdk/go/environment"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// AnnotationSecretFromEnvPrefix prefixes the Environment annotations that
+// record where a secret binding is read from. The annotation
+// "stigmer.ai/secret-from-env.GITHUB_TOKEN" = "GITHUB_TOKEN_PROD" tells the
+// CLI to fill GITHUB_TOKEN from $GITHUB_TOKEN_PROD when deploying.
+const AnnotationSecretFromEnvPrefix = "stigmer.ai/secret-from-env."
+
+// Errors returned by Validate.
+var (
+	ErrMissingBinding = errors.New("missing environment binding")
+	ErrUnknownBinding = errors.New("unknown environment binding")
+	ErrSecretMismatch = errors.New("secret binding mismatch")
+	ErrInvalidBinding = errors.New("invalid environment binding")
+)
+
+// Value is the value bound to an environment variable.
+type Value struct {
+	// literal is the bound value (empty for secrets)
+	literal string
+
+	// fromEnv is the environment variable a secret is read from
+	fromEnv string
+}
+
+// Literal binds a plain value.
+func Literal(value string) Value {
+	return Value{literal: value}
+}
+
+// SecretFromEnv binds a secret read from the environment variable name of
+// the process that deploys the instance.
+func SecretFromEnv(name string) Value {
+	return Value{fromEnv: name}
+}
+
+// IsSecret reports whether the value is a secret.
+func (v Value) IsSecret() bool {
+	return v.fromEnv != ""
+}
+
+// String returns a representation of the value that never includes secrets.
+func (v Value) String() string {
+	if v.IsSecret() {
+		return fmt.Sprintf("SecretFromEnv(%s)", v.fromEnv)
+	}
+	return fmt.Sprintf("Literal(%q)", v.literal)
+}
+
+// sourceEnvRegex matches the environment variable names SecretFromEnv reads.
+var sourceEnvRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Validate checks bindings against the variables a template declares: every
+// required variable without a default must be bound, every binding must name
+// a declared variable, secret variables must be bound with SecretFromEnv and
+// plain ones with Literal, and literals must match the variable's type.
+//
+// owner names the template in error messages, e.g. `agent "code-reviewer"`.
+func Validate(owner string, vars []environment.Variable, env map[string]Value) error {
+	declared := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		declared[v.Name] = true
+		field := fmt.Sprintf("env[%s]", v.Name)
+
+		value, bound := env[v.Name]
+		if !bound {
+			if v.Required && v.DefaultValue == "" {
+				return validation.NewValidationErrorWithCause(
+					field, v.Name, "required",
+					fmt.Sprintf("%s requires environment variable %s", owner, v.Name),
+					ErrMissingBinding,
+				)
+			}
+			continue
+		}
+
+		if v.IsSecret && !value.IsSecret() {
+			return validation.NewValidationErrorWithCause(
+				field, v.Name, "secret",
+				fmt.Sprintf("%s is a secret and must be bound with SecretFromEnv, not a literal", v.Name),
+				ErrSecretMismatch,
+			)
+		}
+		if !v.IsSecret && value.IsSecret() {
+			return validation.NewValidationErrorWithCause(
+				field, v.Name, "secret",
+				fmt.Sprintf("%s is not a secret and must be bound with Literal", v.Name),
+				ErrSecretMismatch,
+			)
+		}
+
+		if value.IsSecret() {
+			if !sourceEnvRegex.MatchString(value.fromEnv) {
+				return validation.NewValidationErrorWithCause(
+					field, value.fromEnv, "format",
+					fmt.Sprintf("invalid environment variable name %q for secret %s", value.fromEnv, v.Name),
+					ErrInvalidBinding,
+				)
+			}
+		} else if err := v.Type.Check(value.literal); err != nil {
+			return validation.NewValidationErrorWithCause(
+				field, value.literal, "type",
+				fmt.Sprintf("%s: %v", v.Name, err),
+				ErrInvalidBinding,
+			)
+		}
+	}
+
+	// Report unknown bindings in name order so errors are deterministic
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !declared[name] {
+			return validation.NewValidationErrorWithCause(
+				fmt.Sprintf("env[%s]", name), name, "declared",
+				fmt.Sprintf("%s does not declare environment variable %s", owner, name),
+				ErrUnknownBinding,
+			)
+		}
+	}
+
+	return nil
+}
+
+// Environment builds the Environment resource named slug holding the
+// bindings. Secret values are left empty and recorded as
+// AnnotationSecretFromEnvPrefix annotations, added to annotations.
+func Environment(slug, description string, vars []environment.Variable, env map[string]Value, annotations map[string]string) *environmentv1.Environment {
+	descriptions := make(map[string]string, len(vars))
+	for _, v := range vars {
+		descriptions[v.Name] = v.Description
+	}
+
+	data := make(map[string]*environmentv1.EnvironmentValue, len(env))
+	for name, value := range env {
+		data[name] = &environmentv1.EnvironmentValue{
+			Value:       value.literal,
+			IsSecret:    value.IsSecret(),
+			Description: descriptions[name],
+		}
+		if value.IsSecret() {
+			annotations[AnnotationSecretFromEnvPrefix+name] = value.fromEnv
+		}
+	}
+
+	return &environmentv1.Environment{
+		ApiVersion: "agentic.stigmer.ai/v1",
+		Kind:       "Environment",
+		Metadata: &apiresource.ApiResourceMetadata{
+			Name:        slug,
+			Slug:        slug,
+			Annotations: annotations,
+			OwnerScope:  apiresource.ApiResourceOwnerScope_organization,
+		},
+		Spec: &environmentv1.EnvironmentSpec{
+			Description: description,
+			Data:        data,
+		},
+	}
+}
@@ -0,0 +1,248 @@
+// Package outputschema normalizes agent output schemas and resolves field
+// paths against them.
+//
+// Agents and agent call tasks accept either a full JSON Schema document or a
+// shorthand map of property names to type names:
+//
+//	{"severity": "string", "issues": "array"}
+//
+// Normalize expands the shorthand into an object schema with every listed
+// property required. Resolve checks that a field path such as
+// "issues[0].title" is declared by the schema, which lets the SDK reject
+// Field() references to properties the agent never returns. Lookup returns
+// the schema of the value at a path, to check its type.
+package outputschema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrInvalidSchema indicates the schema could not be normalized.
+	ErrInvalidSchema = errors.New("invalid output schema")
+
+	// ErrUnknownPath indicates a field path is not declared by the schema.
+	ErrUnknownPath = errors.New("field path not declared by output schema")
+)
+
+// schemaKeywords are top-level keys that mark a map as a full JSON Schema
+// document rather than shorthand.
+var schemaKeywords = []string{
+	"$schema", "$ref", "type", "properties", "items",
+	"anyOf", "oneOf", "allOf", "enum", "const",
+}
+
+var typeNames = map[string]bool{
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"array":   true,
+	"object":  true,
+}
+
+// Normalize returns a JSON Schema document for schema.
+//
+// Full JSON Schema documents are deep-copied unchanged. Shorthand maps are
+// expanded: string values name a type, nested maps become objects, and a
+// single-element slice becomes an array whose items follow the element.
+func Normalize(schema map[string]any) (map[string]any, error) {
+	if len(schema) == 0 {
+		return nil, fmt.Errorf("%w: schema is empty", ErrInvalidSchema)
+	}
+	if isFullSchema(schema) {
+		return deepCopy(schema).(map[string]any), nil
+	}
+	return expandObject(schema, "")
+}
+
+func isFullSchema(schema map[string]any) bool {
+	for _, key := range schemaKeywords {
+		if _, ok := schema[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func expandObject(shorthand map[string]any, path string) (map[string]any, error) {
+	properties := make(map[string]any, len(shorthand))
+	required := make([]any, 0, len(shorthand))
+
+	keys := make([]string, 0, len(shorthand))
+	for key := range shorthand {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		prop, err := expandValue(shorthand[key], joinPath(path, key))
+		if err != nil {
+			return nil, err
+		}
+		properties[key] = prop
+		required = append(required, key)
+	}
+
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}, nil
+}
+
+func expandValue(value any, path string) (map[string]any, error) {
+	switch v := value.(type) {
+	case string:
+		if !typeNames[v] {
+			return nil, fmt.Errorf("%w: %s has unknown type %q", ErrInvalidSchema, path, v)
+		}
+		return map[string]any{"type": v}, nil
+	case map[string]any:
+		if isFullSchema(v) {
+			return deepCopy(v).(map[string]any), nil
+		}
+		return expandObject(v, path)
+	case []any:
+		if len(v) != 1 {
+			return nil, fmt.Errorf("%w: %s must list exactly one item type", ErrInvalidSchema, path)
+		}
+		items, err := expandValue(v[0], path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s has unsupported value of type %T", ErrInvalidSchema, path, value)
+	}
+}
+
+// Resolve reports whether path is declared by a normalized schema.
+//
+// Paths use dot notation with optional index segments, e.g. "issues[0].title".
+// Objects without declared properties, or with additionalProperties enabled,
+// accept any key below them.
+func Resolve(schema map[string]any, path string) error {
+	_, err := Lookup(schema, path)
+	return err
+}
+
+// Lookup returns the schema of the value at path, like Resolve. The result
+// is nil when the path is accepted but its value is not described, such as a
+// key below an object without declared properties.
+func Lookup(schema map[string]any, path string) (map[string]any, error) {
+	current := schema
+	for _, segment := range splitPath(path) {
+		if current == nil {
+			return nil, nil
+		}
+		if segment.index {
+			if schemaType(current) != "array" {
+				return nil, fmt.Errorf("%w: %q indexes a non-array value", ErrUnknownPath, path)
+			}
+			items, _ := current["items"].(map[string]any)
+			current = items
+			continue
+		}
+
+		switch schemaType(current) {
+		case "object", "":
+			properties, _ := current["properties"].(map[string]any)
+			if len(properties) == 0 {
+				return nil, nil
+			}
+			prop, ok := properties[segment.name].(map[string]any)
+			if !ok {
+				if allowsAdditional(current) {
+					return nil, nil
+				}
+				return nil, fmt.Errorf("%w: %q has no property %q", ErrUnknownPath, path, segment.name)
+			}
+			current = prop
+		default:
+			return nil, fmt.Errorf("%w: %q descends into a %s value", ErrUnknownPath, path, schemaType(current))
+		}
+	}
+	return current, nil
+}
+
+// Type returns the type declared by a schema returned by Lookup, or "" when
+// the schema is nil or declares no single type.
+func Type(schema map[string]any) string {
+	return schemaType(schema)
+}
+
+type pathSegment struct {
+	name  string
+	index bool
+}
+
+func splitPath(path string) []pathSegment {
+	var segments []pathSegment
+	for _, part := range strings.Split(path, ".") {
+		name := part
+		var indexes int
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			name = part[:i]
+			indexes = strings.Count(part[i:], "[")
+		}
+		if name != "" {
+			segments = append(segments, pathSegment{name: name})
+		}
+		for j := 0; j < indexes; j++ {
+			segments = append(segments, pathSegment{index: true})
+		}
+	}
+	return segments
+}
+
+func schemaType(schema map[string]any) string {
+	t, _ := schema["type"].(string)
+	return t
+}
+
+func allowsAdditional(schema map[string]any) bool {
+	switch v := schema["additionalProperties"].(type) {
+	case bool:
+		return v
+	case map[string]any:
+		return true
+	default:
+		return false
+	}
+}
+
+func joinPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = deepCopy(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = deepCopy(item)
+		}
+		return out
+	case []string:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = item
+		}
+		return out
+	default:
+		return value
+	}
+}
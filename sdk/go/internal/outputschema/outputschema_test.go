@@ -0,0 +1,159 @@
+package outputschema
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeShorthand(t *testing.T) {
+	got, err := Normalize(map[string]any{
+		"severity": "string",
+		"issues": []any{map[string]any{
+			"title": "string",
+			"line":  "integer",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+
+	want := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"issues": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"line":  map[string]any{"type": "integer"},
+						"title": map[string]any{"type": "string"},
+					},
+					"required": []any{"line", "title"},
+				},
+			},
+			"severity": map[string]any{"type": "string"},
+		},
+		"required": []any{"issues", "severity"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Normalize() = %#v, want %#v", got, want)
+	}
+}
+
+func TestNormalizeFullSchemaIsCopied(t *testing.T) {
+	schema := map[string]any{
+		"type":     "object",
+		"required": []string{"summary"},
+		"properties": map[string]any{
+			"summary": map[string]any{"type": "string"},
+		},
+	}
+
+	got, err := Normalize(schema)
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	if !reflect.DeepEqual(got["required"], []any{"summary"}) {
+		t.Errorf("required = %#v, want []any{\"summary\"}", got["required"])
+	}
+
+	got["properties"].(map[string]any)["extra"] = map[string]any{}
+	if _, ok := schema["properties"].(map[string]any)["extra"]; ok {
+		t.Error("Normalize() returned a schema sharing state with its input")
+	}
+}
+
+func TestNormalizeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema map[string]any
+	}{
+		{name: "empty", schema: map[string]any{}},
+		{name: "unknown type", schema: map[string]any{"severity": "text"}},
+		{name: "multiple item types", schema: map[string]any{"issues": []any{"string", "integer"}}},
+		{name: "unsupported value", schema: map[string]any{"count": 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Normalize(tt.schema); !errors.Is(err, ErrInvalidSchema) {
+				t.Errorf("Normalize() error = %v, want ErrInvalidSchema", err)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	schema, err := Normalize(map[string]any{
+		"severity": "string",
+		"issues":   []any{map[string]any{"title": "string"}},
+		"metadata": map[string]any{"type": "object"},
+	})
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "severity"},
+		{path: "issues"},
+		{path: "issues[0]"},
+		{path: "issues[0].title"},
+		{path: "metadata.anything"},
+		{path: "summary", wantErr: true},
+		{path: "issues[0].line", wantErr: true},
+		{path: "severity[0]", wantErr: true},
+		{path: "severity.level", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := Resolve(schema, tt.path)
+			if tt.wantErr && !errors.Is(err, ErrUnknownPath) {
+				t.Errorf("Resolve(%q) error = %v, want ErrUnknownPath", tt.path, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Resolve(%q) unexpected error = %v", tt.path, err)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	schema, err := Normalize(map[string]any{
+		"issues":   []any{map[string]any{"title": "string"}},
+		"metadata": map[string]any{"type": "object"},
+	})
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+
+	tests := []struct {
+		path     string
+		wantType string
+	}{
+		{path: "issues", wantType: "array"},
+		{path: "issues[0]", wantType: "object"},
+		{path: "issues[0].title", wantType: "string"},
+		{path: "metadata.anything", wantType: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := Lookup(schema, tt.path)
+			if err != nil {
+				t.Fatalf("Lookup(%q) unexpected error = %v", tt.path, err)
+			}
+			if Type(got) != tt.wantType {
+				t.Errorf("Type(Lookup(%q)) = %q, want %q", tt.path, Type(got), tt.wantType)
+			}
+		})
+	}
+
+	if _, err := Lookup(schema, "summary"); !errors.Is(err, ErrUnknownPath) {
+		t.Errorf("Lookup(summary) error = %v, want ErrUnknownPath", err)
+	}
+}
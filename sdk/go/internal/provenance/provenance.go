@@ -0,0 +1,65 @@
+// Package provenance reports the build of the SDK that synthesizes a
+// manifest, so deployed resources can be traced back to the SDK release and
+// Go toolchain that produced them.
+package provenance
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// ModulePath is the module path of the Go SDK.
+const ModulePath = "github.com/stigmer/stigmer/sdk/go"
+
+var (
+	moduleVersionOnce sync.Once
+	moduleVersion     string
+)
+
+// SDKVersion returns the version of the SDK module the running program was
+// built with, read from its build info. It returns fallback when the version
+// is unknown, such as in development builds or with a replace directive.
+func SDKVersion(fallback string) string {
+	moduleVersionOnce.Do(func() {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			moduleVersion = versionFromBuildInfo(info)
+		}
+	})
+	if moduleVersion == "" {
+		return fallback
+	}
+	return moduleVersion
+}
+
+// versionFromBuildInfo returns the released version of the SDK module in
+// info, or "" if the program was not built against a released version.
+func versionFromBuildInfo(info *debug.BuildInfo) string {
+	if info.Main.Path == ModulePath {
+		return releasedVersion(info.Main.Version)
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != ModulePath {
+			continue
+		}
+		if dep.Replace != nil {
+			return releasedVersion(dep.Replace.Version)
+		}
+		return releasedVersion(dep.Version)
+	}
+	return ""
+}
+
+// releasedVersion filters out the placeholder versions of local builds.
+func releasedVersion(version string) string {
+	if version == "" || version == "(devel)" {
+		return ""
+	}
+	return version
+}
+
+// GoVersion returns the version of the Go toolchain the program was built
+// with, such as "go1.24.0".
+func GoVersion() string {
+	return runtime.Version()
+}
@@ -0,0 +1,59 @@
+package provenance
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestVersionFromBuildInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		info *debug.BuildInfo
+		want string
+	}{
+		{
+			name: "dependency",
+			info: &debug.BuildInfo{
+				Main: debug.Module{Path: "example.com/agents", Version: "(devel)"},
+				Deps: []*debug.Module{
+					{Path: "google.golang.org/protobuf", Version: "v1.36.0"},
+					{Path: ModulePath, Version: "v0.4.2"},
+				},
+			},
+			want: "v0.4.2",
+		},
+		{
+			name: "main module",
+			info: &debug.BuildInfo{Main: debug.Module{Path: ModulePath, Version: "v0.4.2"}},
+			want: "v0.4.2",
+		},
+		{
+			name: "local replace",
+			info: &debug.BuildInfo{
+				Main: debug.Module{Path: "example.com/agents"},
+				Deps: []*debug.Module{
+					{Path: ModulePath, Version: "v0.4.2", Replace: &debug.Module{Path: "../sdk/go"}},
+				},
+			},
+			want: "",
+		},
+		{
+			name: "development build",
+			info: &debug.BuildInfo{Main: debug.Module{Path: ModulePath, Version: "(devel)"}},
+			want: "",
+		},
+		{
+			name: "not a dependency",
+			info: &debug.BuildInfo{Main: debug.Module{Path: "example.com/agents"}},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versionFromBuildInfo(tt.info); got != tt.want {
+				t.Errorf("versionFromBuildInfo() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Package semver parses and compares semantic versions (https://semver.org)
+// of synthesized resources.
+//
+// Parse accepts strict MAJOR.MINOR.PATCH versions with optional pre-release
+// and build metadata. Normalize additionally accepts two common mistakes, a
+// leading "v" and a missing patch component, and rewrites them to the strict
+// form:
+//
+//	Normalize("v1.2")     // 1.2.0
+//	Normalize("1.2.3-rc") // 1.2.3-rc, unchanged
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalid is returned when a string is not a valid semantic version.
+var ErrInvalid = errors.New("invalid semantic version")
+
+// Version is a parsed semantic version.
+type Version struct {
+	Major, Minor, Patch int
+
+	// Prerelease is the pre-release suffix without its "-", e.g. "rc.1".
+	Prerelease string
+
+	// Build is the build metadata without its "+", e.g. "sha.3f2c9a1".
+	// It is ignored when comparing versions.
+	Build string
+}
+
+var versionRegex = regexp.MustCompile(
+	`^(0|[1-9]\d*)\.(0|[1-9]\d*)(?:\.(0|[1-9]\d*))?(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`,
+)
+
+// leadingNumbers matches the numeric components at the start of a version,
+// used to suggest a fix for invalid versions.
+var leadingNumbers = regexp.MustCompile(`^[vV]?(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+
+// Parse parses a strict semantic version such as "1.2.3" or "1.2.3-rc.1+build.5".
+func Parse(s string) (Version, error) {
+	v, normalized, err := Normalize(s)
+	if err != nil {
+		return Version{}, err
+	}
+	if normalized {
+		return Version{}, invalidError(s)
+	}
+	return v, nil
+}
+
+// Normalize parses s like Parse, but also accepts a leading "v" or "V" and a
+// missing patch component. normalized reports whether s had either, so the
+// caller can tell the user about the rewrite.
+func Normalize(s string) (v Version, normalized bool, err error) {
+	trimmed := s
+	if strings.HasPrefix(trimmed, "v") || strings.HasPrefix(trimmed, "V") {
+		trimmed = trimmed[1:]
+		normalized = true
+	}
+
+	m := versionRegex.FindStringSubmatch(trimmed)
+	if m == nil {
+		return Version{}, false, invalidError(s)
+	}
+
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	if m[3] == "" {
+		normalized = true
+	} else {
+		v.Patch, _ = strconv.Atoi(m[3])
+	}
+	v.Prerelease = m[4]
+	v.Build = m[5]
+	return v, normalized, nil
+}
+
+// invalidError describes the expected format. Use Suggest for a fix.
+func invalidError(s string) error {
+	return fmt.Errorf("%w %q: expected MAJOR.MINOR.PATCH with optional -prerelease and +build suffixes",
+		ErrInvalid, s)
+}
+
+// Suggest returns a valid version close to s, built from its leading numeric
+// components, or "1.0.0" if it has none.
+//
+// Example: Suggest("1.0.0.1") -> "1.0.0", Suggest("release-2") -> "1.0.0".
+func Suggest(s string) string {
+	m := leadingNumbers.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return "1.0.0"
+	}
+	parts := make([]string, 3)
+	for i := range parts {
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil {
+			n = 0
+		}
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
+
+// String formats the version, e.g. "1.2.3-rc.1+build.5".
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or +1 depending on whether v has lower, equal or
+// higher precedence than other. Build metadata is ignored.
+func (v Version) Compare(other Version) int {
+	for _, d := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// comparePrerelease compares pre-release suffixes by semver precedence: a
+// release is higher than any pre-release, numeric identifiers compare
+// numerically and are lower than alphanumeric ones.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return sign(an - bn)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return sign(len(as) - len(bs))
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
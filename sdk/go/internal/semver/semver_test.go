@@ -0,0 +1,97 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input          string
+		want           string
+		wantNormalized bool
+	}{
+		{"1.2.3", "1.2.3", false},
+		{"1.2.3-rc.1+build.5", "1.2.3-rc.1+build.5", false},
+		{"v1.2.3", "1.2.3", true},
+		{"1.0", "1.0.0", true},
+		{"v1.0", "1.0.0", true},
+		{"V2.1-beta", "2.1.0-beta", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v, normalized, err := Normalize(tt.input)
+			if err != nil {
+				t.Fatalf("Normalize(%q) error = %v", tt.input, err)
+			}
+			if v.String() != tt.want || normalized != tt.wantNormalized {
+				t.Errorf("Normalize(%q) = %q, %v, want %q, %v", tt.input, v, normalized, tt.want, tt.wantNormalized)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		input      string
+		suggestion string
+	}{
+		{"", "1.0.0"},
+		{"1", "1.0.0"},
+		{"1.0.0.1", "1.0.0"},
+		{"01.2.3", "1.2.3"},
+		{"1.2.3-", "1.2.3"},
+		{"release-2", "1.0.0"},
+		{"v1.0", "1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if !errors.Is(err, ErrInvalid) {
+				t.Fatalf("Parse(%q) error = %v, want ErrInvalid", tt.input, err)
+			}
+			if !strings.Contains(err.Error(), "MAJOR.MINOR.PATCH") {
+				t.Errorf("Parse(%q) error = %q, want expected format", tt.input, err)
+			}
+			if got := Suggest(tt.input); got != tt.suggestion {
+				t.Errorf("Suggest(%q) = %q, want %q", tt.input, got, tt.suggestion)
+			}
+		})
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"2.0.0",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := Parse(ordered[i])
+			b, _ := Parse(ordered[j])
+			want := sign(i - j)
+			if got := a.Compare(b); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+
+	a, _ := Parse("1.0.0+build.1")
+	b, _ := Parse("1.0.0+build.2")
+	if a.Compare(b) != 0 {
+		t.Error("build metadata should not affect precedence")
+	}
+}
@@ -0,0 +1,169 @@
+// Package textfile loads text content for synthesized resources, such as
+// agent instructions and skill markdown, from disk or from an fs.FS.
+//
+// Reading from an fs.FS lets programs embed their content with //go:embed,
+// so a compiled synthesis binary works from any working directory. Both
+// sources apply the same checks, and errors name the path and whether it was
+// read from an fs.FS or from disk:
+//
+//	textfile.Read(nil, "instructions/reviewer.md", 10000)     // disk, relative to the working directory
+//	textfile.Read(content, "instructions/reviewer.md", 10000) // embed.FS
+//
+// Relative disk paths given to the SDK resolve against the directory of the
+// Go file that names them, then against the working directory, so a program
+// finds its files wherever it is run from:
+//
+//	path, err := textfile.Resolve("instructions/reviewer.md", textfile.CallerDir(1), "")
+package textfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalid is returned when a file cannot be read or does not hold valid
+// text content.
+var ErrInvalid = errors.New("invalid text file")
+
+// Read returns the content of path, read from fsys or, when fsys is nil,
+// from disk. The content must be non-empty UTF-8 text of at most maxChars
+// characters (no limit when maxChars is 0).
+//
+// fs.FS paths are slash-separated and relative to the root of fsys; a
+// leading "./" is accepted.
+func Read(fsys fs.FS, name string, maxChars int) (string, error) {
+	var data []byte
+	var err error
+	if fsys == nil {
+		data, err = os.ReadFile(name)
+	} else {
+		name = strings.TrimPrefix(name, "./")
+		if !fs.ValidPath(name) {
+			return "", fmt.Errorf("%w: fs.FS path %q must be slash-separated and relative, without \"..\" elements",
+				ErrInvalid, name)
+		}
+		data, err = fs.ReadFile(fsys, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrInvalid, Describe(fsys, name), unwrapPathError(err))
+	}
+
+	if err := check(data, maxChars); err != nil {
+		return "", fmt.Errorf("%w: %s: %s", ErrInvalid, Describe(fsys, name), err)
+	}
+	return string(data), nil
+}
+
+// CallerDir returns the directory of the source file skip frames above the
+// caller of CallerDir: 1 is the file calling the function that calls
+// CallerDir. It returns "" when the file is unknown or not an absolute path,
+// such as in programs built with -trimpath.
+func CallerDir(skip int) string {
+	_, file, _, ok := runtime.Caller(skip + 1)
+	if !ok || !filepath.IsAbs(file) {
+		return ""
+	}
+	return filepath.Dir(file)
+}
+
+// Resolve returns the disk path of name. Absolute names are returned as is.
+// Relative names are joined to each of dirs in order, "" standing for the
+// working directory, and the first path that exists is returned. Without
+// dirs, relative names resolve against the working directory.
+//
+// If none exists, the error wraps ErrInvalid and lists every path tried.
+func Resolve(name string, dirs ...string) (string, error) {
+	if filepath.IsAbs(name) {
+		return name, nil
+	}
+	if len(dirs) == 0 {
+		dirs = []string{""}
+	}
+
+	var tried []string
+	seen := make(map[string]bool, len(dirs))
+	for _, dir := range dirs {
+		candidate := filepath.Join(dir, name)
+		abs, err := filepath.Abs(candidate)
+		if err != nil {
+			abs = candidate
+		}
+		if seen[abs] {
+			continue
+		}
+		seen[abs] = true
+
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+		tried = append(tried, abs)
+	}
+	return "", fmt.Errorf("%w: disk path %q: file not found, tried %s", ErrInvalid, name, strings.Join(tried, ", "))
+}
+
+// IsDir reports whether path names a directory in fsys or, when fsys is nil,
+// on disk.
+func IsDir(fsys fs.FS, name string) bool {
+	var info fs.FileInfo
+	var err error
+	if fsys == nil {
+		info, err = os.Stat(name)
+	} else {
+		info, err = fs.Stat(fsys, strings.TrimPrefix(name, "./"))
+	}
+	return err == nil && info.IsDir()
+}
+
+// Join joins path elements the way the source expects: with the OS separator
+// on disk and with slashes in an fs.FS.
+func Join(fsys fs.FS, elem ...string) string {
+	if fsys == nil {
+		return filepath.Join(elem...)
+	}
+	return path.Join(elem...)
+}
+
+// Describe names a path and its source for error messages. Relative disk
+// paths include the absolute path they resolved to, since they depend on the
+// working directory.
+func Describe(fsys fs.FS, name string) string {
+	if fsys != nil {
+		return fmt.Sprintf("fs.FS path %q", name)
+	}
+	if abs, err := filepath.Abs(name); err == nil && abs != name {
+		return fmt.Sprintf("disk path %q (resolved to %s)", name, abs)
+	}
+	return fmt.Sprintf("disk path %q", name)
+}
+
+// check applies the format and size checks shared by all sources.
+func check(data []byte, maxChars int) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return errors.New("file is empty")
+	}
+	if !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0 {
+		return errors.New("file is not UTF-8 text")
+	}
+	if n := utf8.RuneCount(data); maxChars > 0 && n > maxChars {
+		return fmt.Errorf("file has %d characters, more than the maximum of %d", n, maxChars)
+	}
+	return nil
+}
+
+// unwrapPathError drops the *fs.PathError wrapper, whose message repeats the
+// path already named by Describe.
+func unwrapPathError(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+	return err
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"buf.build/go/protovalidate"
 )
 
 // Sentinel errors for validation rules.
@@ -31,6 +33,12 @@ var (
 	// ErrInvalidEnum indicates a value was not one of the allowed values.
 	ErrInvalidEnum = errors.New("invalid enum value")
 
+	// ErrMutuallyExclusive indicates more than one member of a oneof group was set.
+	ErrMutuallyExclusive = errors.New("mutually exclusive fields set")
+
+	// ErrInvalidDuration indicates a duration could not be stored in a whole-seconds field.
+	ErrInvalidDuration = errors.New("invalid duration")
+
 	// ErrConversion indicates a proto conversion failed.
 	ErrConversion = errors.New("proto conversion failed")
 )
@@ -172,6 +180,51 @@ func NewConversionErrorWithCause(typeName, field, message string, err error) *Co
 	}
 }
 
+// NewConversionErrorFromViolations converts a protovalidate error into a
+// conversion error whose Field is the path of the first violation, appended
+// to prefix. The message lists every violation. Other errors are returned
+// unchanged.
+//
+// Example:
+//
+//	err := NewConversionErrorFromViolations("Workflow", "spec.tasks[3].task_config", err)
+//	// err.Error() = "failed to convert Workflow.spec.tasks[3].task_config.endpoint.uri: value is required"
+func NewConversionErrorFromViolations(typeName, prefix string, err error) error {
+	var violationErr *protovalidate.ValidationError
+	if !errors.As(err, &violationErr) || len(violationErr.Violations) == 0 {
+		return err
+	}
+
+	first := violationErr.Violations[0]
+	field := protovalidate.FieldPathString(first.Proto.GetField())
+	if prefix != "" && field != "" {
+		field = prefix + "." + field
+	} else if prefix != "" {
+		field = prefix
+	}
+
+	messages := make([]string, len(violationErr.Violations))
+	for i, v := range violationErr.Violations {
+		if i == 0 {
+			messages[i] = violationMessage(v)
+			continue
+		}
+		messages[i] = v.String()
+	}
+	return NewConversionErrorWithCause(typeName, field, strings.Join(messages, "; "), err)
+}
+
+// violationMessage returns the message of a violation without its field path.
+func violationMessage(v *protovalidate.Violation) string {
+	if message := v.Proto.GetMessage(); message != "" {
+		return message
+	}
+	if ruleID := v.Proto.GetRuleId(); ruleID != "" {
+		return "[" + ruleID + "]"
+	}
+	return "[unknown]"
+}
+
 // =============================================================================
 // Resource Errors
 // =============================================================================
@@ -27,6 +27,17 @@
 //		},
 //	})
 //
+//	// HTTP server with OAuth2 tokens that expire: credentials are named by
+//	// environment variable, never passed by value
+//	oauthAPI, err := mcpserver.HTTP(ctx, "oauth-service", &mcpserver.HTTPArgs{
+//		Url: "https://mcp.example.com",
+//		Auth: &mcpserver.OAuth2ClientCredentials{
+//			TokenUrl:        "https://auth.example.com/oauth2/token",
+//			ClientIdEnv:     "MCP_CLIENT_ID",
+//			ClientSecretEnv: "MCP_CLIENT_SECRET",
+//		},
+//	})
+//
 //	// Docker server (containerized MCP)
 //	custom, err := mcpserver.Docker(ctx, "custom-mcp", &mcpserver.DockerArgs{
 //		Image: "ghcr.io/org/mcp:latest",
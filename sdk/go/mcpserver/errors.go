@@ -0,0 +1,11 @@
+package mcpserver
+
+import "errors"
+
+// Common errors that can occur when configuring MCP servers.
+var (
+	// ErrInvalidAuth is returned when an HTTP server's OAuth2 configuration is
+	// invalid, such as a literal client secret instead of an environment
+	// variable name.
+	ErrInvalidAuth = errors.New("invalid MCP server auth")
+)
@@ -1,13 +1,26 @@
 package mcpserver
 
 import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
 )
 
 // HTTPArgs is an alias for the generated HttpServer type from codegen.
 // This follows the pattern of using generated types for Args structs.
 type HTTPArgs = types.HttpServer
 
+// OAuth2ClientCredentials is an alias for the generated OAuth2ClientCredentials
+// type from codegen. It configures the Auth field of HTTPArgs.
+type OAuth2ClientCredentials = types.OAuth2ClientCredentials
+
+// envNameRegex matches environment variable names.
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // HTTPServer represents an HTTP-based MCP server that communicates via HTTP + SSE.
 // Used for remote or managed MCP services.
 //
@@ -26,6 +39,7 @@ type HTTPServer struct {
 	headers        map[string]string
 	queryParams    map[string]string
 	timeoutSeconds int32
+	auth           *OAuth2ClientCredentials
 }
 
 // HTTP creates a new HTTP-based MCP server with struct-based args (Pulumi pattern).
@@ -44,6 +58,7 @@ type HTTPServer struct {
 //   - Headers: HTTP headers (can contain placeholders)
 //   - QueryParams: query parameters (can contain placeholders)
 //   - TimeoutSeconds: HTTP timeout (defaults to 30)
+//   - Auth: OAuth2 client-credentials authentication
 //
 // Note: EnabledTools is set separately via the EnableTools() builder method,
 // as it's defined on McpServerDefinition in proto, not on HttpServer.
@@ -61,12 +76,32 @@ type HTTPServer struct {
 //	    TimeoutSeconds: 60,
 //	})
 //	api.EnableTools("search", "fetch")  // Set enabled tools
+//
+// Services whose tokens expire use Auth instead of a static Authorization
+// header. The runtime fetches a token with the client-credentials grant and
+// refreshes it before it expires. The client ID and secret are names of
+// environment variables, never the values themselves:
+//
+//	api, err := mcpserver.HTTP(ctx, "api-service", &mcpserver.HTTPArgs{
+//	    Url: "https://mcp.example.com",
+//	    Auth: &mcpserver.OAuth2ClientCredentials{
+//	        TokenUrl:        "https://auth.example.com/oauth2/token",
+//	        ClientIdEnv:     "MCP_CLIENT_ID",
+//	        ClientSecretEnv: "MCP_CLIENT_SECRET",
+//	        Scopes:          []string{"mcp.read"},
+//	    },
+//	})
 func HTTP(ctx Context, name string, args *HTTPArgs) (*HTTPServer, error) {
 	// Nil-safety: if args is nil, create empty args
 	if args == nil {
 		args = &HTTPArgs{}
 	}
 
+	auth, err := normalizeAuth(args.Auth)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize maps
 	headers := args.Headers
 	if headers == nil {
@@ -91,11 +126,77 @@ func HTTP(ctx Context, name string, args *HTTPArgs) (*HTTPServer, error) {
 		headers:        headers,
 		queryParams:    queryParams,
 		timeoutSeconds: timeout,
+		auth:           auth,
 	}
 
 	return server, nil
 }
 
+// normalizeAuth validates an OAuth2 configuration and returns a copy with
+// ${VAR} placeholders reduced to variable names. Returns nil for nil auth.
+func normalizeAuth(auth *OAuth2ClientCredentials) (*OAuth2ClientCredentials, error) {
+	if auth == nil {
+		return nil, nil
+	}
+
+	u, err := url.Parse(auth.TokenUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, validation.NewValidationErrorWithCause(
+			"auth.tokenUrl", auth.TokenUrl, "url",
+			"auth.tokenUrl must be an http or https URL",
+			ErrInvalidAuth,
+		)
+	}
+
+	clientIDEnv, err := envPlaceholderName("auth.clientIdEnv", "client ID", "MCP_CLIENT_ID", auth.ClientIdEnv)
+	if err != nil {
+		return nil, err
+	}
+	clientSecretEnv, err := envPlaceholderName("auth.clientSecretEnv", "client secret", "MCP_CLIENT_SECRET", auth.ClientSecretEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, scope := range auth.Scopes {
+		if strings.TrimSpace(scope) == "" {
+			return nil, validation.NewValidationErrorWithCause(
+				validation.FieldPath("auth", "scopes", i), scope, "required",
+				"auth.scopes must not contain empty scopes",
+				ErrInvalidAuth,
+			)
+		}
+	}
+
+	return &OAuth2ClientCredentials{
+		TokenUrl:        auth.TokenUrl,
+		ClientIdEnv:     clientIDEnv,
+		ClientSecretEnv: clientSecretEnv,
+		Scopes:          append([]string(nil), auth.Scopes...),
+	}, nil
+}
+
+// envPlaceholderName returns the environment variable named by value, which
+// is either a bare name ("MCP_CLIENT_SECRET") or a placeholder
+// ("${MCP_CLIENT_SECRET}"). Anything else is treated as a literal credential
+// and rejected, so credentials never end up in manifests. The value is not
+// echoed back in the error in case it is a secret.
+func envPlaceholderName(field, what, example, value string) (string, error) {
+	name := value
+	if strings.HasPrefix(name, "${") && strings.HasSuffix(name, "}") {
+		name = name[2 : len(name)-1]
+	}
+	if envNameRegex.MatchString(name) {
+		return name, nil
+	}
+
+	message := fmt.Sprintf("%s is required: name the environment variable holding the %s (e.g. %q)", field, what, example)
+	if value != "" {
+		message = fmt.Sprintf("%s must name an environment variable holding the %s (e.g. %q), not the %s itself: "+
+			"pass credentials through env placeholders so they never appear in manifests", field, what, example, what)
+	}
+	return "", validation.NewValidationErrorWithCause(field, "<redacted>", "env_placeholder", message, ErrInvalidAuth)
+}
+
 // EnableTools sets the enabled tools for this server (builder pattern).
 // If not called or called with empty slice, all tools are enabled.
 func (h *HTTPServer) EnableTools(tools ...string) *HTTPServer {
@@ -123,6 +224,12 @@ func (h *HTTPServer) TimeoutSeconds() int32 {
 	return h.timeoutSeconds
 }
 
+// Auth returns the OAuth2 client-credentials configuration, or nil if the
+// server does not use OAuth2.
+func (h *HTTPServer) Auth() *OAuth2ClientCredentials {
+	return h.auth
+}
+
 // Type returns the server type (http).
 func (h *HTTPServer) Type() ServerType {
 	return TypeHTTP
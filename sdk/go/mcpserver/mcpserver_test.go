@@ -1,6 +1,8 @@
 package mcpserver
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
@@ -176,6 +178,86 @@ func TestHTTPServer_Type(t *testing.T) {
 	}
 }
 
+func TestHTTPServer_OAuth2(t *testing.T) {
+	ctx := &mockContext{}
+	server, err := HTTP(ctx, "api-service", &HTTPArgs{
+		Url: "https://mcp.example.com",
+		Auth: &OAuth2ClientCredentials{
+			TokenUrl:        "https://auth.example.com/oauth2/token",
+			ClientIdEnv:     "MCP_CLIENT_ID",
+			ClientSecretEnv: "${MCP_CLIENT_SECRET}",
+			Scopes:          []string{"mcp.read"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	auth := server.Auth()
+	if auth == nil {
+		t.Fatal("expected auth to be set")
+	}
+	if auth.ClientIdEnv != "MCP_CLIENT_ID" {
+		t.Errorf("expected client ID env 'MCP_CLIENT_ID', got %q", auth.ClientIdEnv)
+	}
+	// Placeholders are reduced to the variable name
+	if auth.ClientSecretEnv != "MCP_CLIENT_SECRET" {
+		t.Errorf("expected client secret env 'MCP_CLIENT_SECRET', got %q", auth.ClientSecretEnv)
+	}
+}
+
+func TestHTTPServer_OAuth2Validation(t *testing.T) {
+	valid := func() *OAuth2ClientCredentials {
+		return &OAuth2ClientCredentials{
+			TokenUrl:        "https://auth.example.com/oauth2/token",
+			ClientIdEnv:     "MCP_CLIENT_ID",
+			ClientSecretEnv: "MCP_CLIENT_SECRET",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(a *OAuth2ClientCredentials)
+	}{
+		{"missing token URL", func(a *OAuth2ClientCredentials) { a.TokenUrl = "" }},
+		{"relative token URL", func(a *OAuth2ClientCredentials) { a.TokenUrl = "/oauth2/token" }},
+		{"missing client ID env", func(a *OAuth2ClientCredentials) { a.ClientIdEnv = "" }},
+		{"literal client secret", func(a *OAuth2ClientCredentials) { a.ClientSecretEnv = "s3cr3t-Xy9.zz" }},
+		{"empty scope", func(a *OAuth2ClientCredentials) { a.Scopes = []string{"mcp.read", ""} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := valid()
+			tt.mutate(auth)
+			_, err := HTTP(&mockContext{}, "api-service", &HTTPArgs{Url: "https://mcp.example.com", Auth: auth})
+			if !errors.Is(err, ErrInvalidAuth) {
+				t.Fatalf("expected ErrInvalidAuth, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHTTPServer_OAuth2LiteralSecretNotEchoed(t *testing.T) {
+	_, err := HTTP(&mockContext{}, "api-service", &HTTPArgs{
+		Url: "https://mcp.example.com",
+		Auth: &OAuth2ClientCredentials{
+			TokenUrl:        "https://auth.example.com/oauth2/token",
+			ClientIdEnv:     "MCP_CLIENT_ID",
+			ClientSecretEnv: "s3cr3t-Xy9.zz",
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a literal client secret")
+	}
+	if strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("error message leaks the secret: %v", err)
+	}
+	if !strings.Contains(err.Error(), "env placeholders") {
+		t.Errorf("expected the error to point at env placeholders, got %v", err)
+	}
+}
+
 // Test Docker Server
 
 func TestDockerServer_Success(t *testing.T) {
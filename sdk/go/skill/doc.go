@@ -0,0 +1,52 @@
+// Package skill validates skill content before it is pushed, and defines
+// inline skills.
+//
+// Skills are directories with a SKILL.md, pushed with `stigmer skill push`
+// and referenced from agents with the skillref package. Agent instructions
+// often point at sections of a skill ("follow the Security Checklist
+// section"), so renaming or removing a section, or letting one grow too
+// large for the context window, breaks agents without any error.
+//
+// Validate and ValidateFile catch this at synthesis. They parse the markdown
+// headings, check that links to anchors in the skill resolve, and apply the
+// rules passed to them:
+//
+//	err := skill.ValidateFile("skills/code-review",
+//	    skill.RequireSections("Overview", "Security Checklist"),
+//	    skill.MaxSectionTokens(2000),
+//	)
+//
+// Relative paths resolve against the directory of the Go file calling
+// ValidateFile, then against the working directory. ValidateFS does the same
+// for skills embedded in the program with //go:embed, so a compiled
+// synthesis binary works from any directory.
+//
+// The error lists every problem found; each is a *ValidationError matching
+// ErrMissingSection, ErrSectionTooLarge or ErrBrokenLink with errors.Is.
+//
+// Call ValidateFile from the program that defines the agents so `stigmer
+// apply` fails before deploying, or pass the same rules to `stigmer skill
+// push` with --require-section and --max-section-tokens.
+//
+// # Inline Skills
+//
+// Small skills can be defined in the program instead, with New:
+//
+//	guidelines, err := skill.New("security-guidelines",
+//	    skill.WithMarkdown(securityGuidelines))
+//	if err != nil {
+//	    return err
+//	}
+//	ag.AddSkill(guidelines)
+//
+// Synthesis writes each inline skill once to skill-{n}.pb, even when several
+// agents use it, and the agents reference it by slug in their organization,
+// so the markdown is not copied into every agent manifest. Agents of the
+// same organization cannot use different skills with the same slug.
+//
+// # Token Estimates
+//
+// Section sizes are estimated from whitespace-separated words, assuming
+// about four tokens for every three words, without a model tokenizer. Code
+// and non-English text tokenize less efficiently, so leave some headroom.
+package skill
@@ -0,0 +1,38 @@
+package skill
+
+import (
+	"errors"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// Errors returned by New and Validate, matched with errors.Is.
+var (
+	// ErrInvalidName is returned by New when the skill name does not produce
+	// a valid slug.
+	ErrInvalidName = errors.New("invalid skill name")
+
+	// ErrMissingMarkdown is returned by New when the skill has no content.
+	ErrMissingMarkdown = errors.New("missing skill markdown")
+
+	// ErrMissingSection is returned when a section required with
+	// RequireSections has no heading in the skill.
+	ErrMissingSection = errors.New("missing skill section")
+
+	// ErrSectionTooLarge is returned when a section exceeds the estimate set
+	// with MaxSectionTokens.
+	ErrSectionTooLarge = errors.New("skill section too large")
+
+	// ErrBrokenLink is returned when a link to an anchor in the skill
+	// (e.g. [checklist](#security-checklist)) matches no heading.
+	ErrBrokenLink = errors.New("broken internal link")
+)
+
+// ValidationError is an alias to the shared validation error type.
+type ValidationError = validation.ValidationError
+
+// NewValidationErrorWithCause creates a new validation error with an underlying cause.
+// This is a convenience wrapper around the shared validation package.
+func NewValidationErrorWithCause(field, value, rule, message string, err error) *ValidationError {
+	return validation.NewValidationErrorWithCause(field, value, rule, message, err)
+}
@@ -0,0 +1,103 @@
+package skill
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	skillv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/skill/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/sdk/go/stigmer/naming"
+)
+
+// Skill is an inline skill: its content is defined in the program rather
+// than in a directory pushed with `stigmer skill push`.
+//
+// Add it to agents with agent.AddSkill. Synthesis emits each inline skill
+// once as a skill manifest, and the agents reference it by slug like any
+// other organization skill.
+type Skill struct {
+	// Name is the skill name.
+	Name string
+
+	// Slug is the URL-friendly identifier, generated from Name.
+	Slug string
+
+	// Markdown is the content of the skill, as it would be in SKILL.md.
+	Markdown string
+}
+
+// Option configures a Skill created with New.
+type Option func(*Skill)
+
+// WithMarkdown sets the content of the skill.
+func WithMarkdown(markdown string) Option {
+	return func(s *Skill) {
+		s.Markdown = markdown
+	}
+}
+
+// New creates an inline skill. The markdown set with WithMarkdown is
+// required; run Validate on it to check its sections and links.
+//
+// Example:
+//
+//	guidelines, err := skill.New("security-guidelines",
+//	    skill.WithMarkdown("# Security Guidelines\n\nValidate all user input."))
+//	if err != nil {
+//	    return err
+//	}
+//	ag.AddSkill(guidelines)
+func New(name string, opts ...Option) (*Skill, error) {
+	s := &Skill{
+		Name: name,
+		Slug: naming.GenerateSlug(name),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := naming.ValidateSlug(s.Slug); err != nil {
+		return nil, NewValidationErrorWithCause(
+			"name",
+			name,
+			"format",
+			fmt.Sprintf("invalid skill name %q: %v", name, err),
+			ErrInvalidName,
+		)
+	}
+	if strings.TrimSpace(s.Markdown) == "" {
+		return nil, NewValidationErrorWithCause(
+			"skill_md",
+			"",
+			"required",
+			fmt.Sprintf("skill %q has no markdown content; set it with WithMarkdown", name),
+			ErrMissingMarkdown,
+		)
+	}
+	return s, nil
+}
+
+// ContentHash returns the hex-encoded SHA-256 of the skill's markdown.
+func (s *Skill) ContentHash() string {
+	sum := sha256.Sum256([]byte(s.Markdown))
+	return hex.EncodeToString(sum[:])
+}
+
+// ToProto converts the skill to a platform Skill proto message, owned by
+// an organization set by the caller.
+func (s *Skill) ToProto() *skillv1.Skill {
+	return &skillv1.Skill{
+		ApiVersion: "agentic.stigmer.ai/v1",
+		Kind:       "Skill",
+		Metadata: &apiresource.ApiResourceMetadata{
+			Name:       s.Name,
+			Slug:       s.Slug,
+			OwnerScope: apiresource.ApiResourceOwnerScope_organization,
+		},
+		Spec: &skillv1.SkillSpec{
+			SkillMd: s.Markdown,
+		},
+	}
+}
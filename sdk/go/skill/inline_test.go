@@ -0,0 +1,55 @@
+package skill
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	s, err := New("Security Guidelines", WithMarkdown("# Security\n\nValidate all input."))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if s.Slug != "security-guidelines" {
+		t.Errorf("Slug = %q, want security-guidelines", s.Slug)
+	}
+
+	same, err := New("security-guidelines", WithMarkdown("# Security\n\nValidate all input."))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if s.ContentHash() != same.ContentHash() || len(s.ContentHash()) != 64 {
+		t.Errorf("ContentHash() = %q and %q, want the same SHA-256", s.ContentHash(), same.ContentHash())
+	}
+	other, _ := New("security-guidelines", WithMarkdown("# Security\n\nEscape all output."))
+	if other.ContentHash() == s.ContentHash() {
+		t.Error("ContentHash() is the same for different markdown")
+	}
+
+	pb := s.ToProto()
+	if pb.GetMetadata().GetSlug() != "security-guidelines" || pb.GetSpec().GetSkillMd() != s.Markdown {
+		t.Errorf("ToProto() = %v", pb)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		skill   string
+		opts    []Option
+		wantErr error
+	}{
+		{name: "no markdown", skill: "guidelines", wantErr: ErrMissingMarkdown},
+		{name: "blank markdown", skill: "guidelines", opts: []Option{WithMarkdown(" \n")}, wantErr: ErrMissingMarkdown},
+		{name: "no name", skill: "", opts: []Option{WithMarkdown("# Guidelines")}, wantErr: ErrInvalidName},
+		{name: "symbols only", skill: "!!!", opts: []Option{WithMarkdown("# Guidelines")}, wantErr: ErrInvalidName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.skill, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("New() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package skill
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/textfile"
+)
+
+// FileName is the name of the file holding a skill's content.
+const FileName = "SKILL.md"
+
+// Section is a section of a skill: a heading and the text up to the next
+// heading of any level.
+type Section struct {
+	// Title is the heading text, e.g. "Security Checklist".
+	Title string
+
+	// Level is the heading level (1 for "#", 2 for "##", ...).
+	Level int
+
+	// Line is the 1-based line of the heading.
+	Line int
+
+	// Anchor is the link target of the heading, e.g. "security-checklist".
+	Anchor string
+
+	// Tokens is the estimated token count of the section's text.
+	Tokens int
+}
+
+// Rule configures the checks run by Validate.
+type Rule func(*rules)
+
+type rules struct {
+	requiredSections []string
+	maxSectionTokens int
+}
+
+// RequireSections fails validation if the skill has no section with one of
+// the given titles. Titles are compared case-insensitively.
+func RequireSections(titles ...string) Rule {
+	return func(r *rules) {
+		r.requiredSections = append(r.requiredSections, titles...)
+	}
+}
+
+// MaxSectionTokens fails validation if the estimated token count of a
+// section exceeds n.
+func MaxSectionTokens(n int) Rule {
+	return func(r *rules) {
+		r.maxSectionTokens = n
+	}
+}
+
+var (
+	atxHeadingRegex   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	fenceRegex        = regexp.MustCompile("^ {0,3}(```|~~~)")
+	internalLinkRegex = regexp.MustCompile(`\]\(#([^)\s]*)\)`)
+)
+
+// Sections parses the headings of markdown. Text before the first heading
+// does not belong to a section. Headings in fenced code blocks are ignored.
+func Sections(markdown string) []Section {
+	var sections []Section
+	var words int
+	anchors := make(map[string]int)
+
+	flush := func() {
+		if len(sections) > 0 {
+			sections[len(sections)-1].Tokens = estimateTokens(words)
+		}
+		words = 0
+	}
+
+	fence := ""
+	for i, line := range strings.Split(markdown, "\n") {
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			switch fence {
+			case "":
+				fence = m[1]
+			case m[1]:
+				fence = ""
+			}
+		}
+
+		if fence == "" {
+			if m := atxHeadingRegex.FindStringSubmatch(line); m != nil {
+				flush()
+				title := strings.TrimSpace(m[2])
+				sections = append(sections, Section{
+					Title:  title,
+					Level:  len(m[1]),
+					Line:   i + 1,
+					Anchor: uniqueAnchor(anchors, title),
+				})
+				continue
+			}
+		}
+		words += len(strings.Fields(line))
+	}
+	flush()
+
+	return sections
+}
+
+// estimateTokens estimates the tokens of a text from its words, assuming
+// about four tokens for every three words.
+func estimateTokens(words int) int {
+	return (words*4 + 2) / 3
+}
+
+// uniqueAnchor returns the anchor of a heading the way GitHub renders it,
+// numbering repeated headings ("setup", "setup-1", ...).
+func uniqueAnchor(seen map[string]int, title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		}
+	}
+	anchor := b.String()
+
+	n := seen[anchor]
+	seen[anchor] = n + 1
+	if n > 0 {
+		return fmt.Sprintf("%s-%d", anchor, n)
+	}
+	return anchor
+}
+
+// Validate checks the content of a skill. Links to anchors in the skill must
+// match a heading; the rules add checks on sections.
+//
+// All problems are reported: the error joins a *ValidationError for each,
+// matching ErrMissingSection, ErrSectionTooLarge or ErrBrokenLink.
+func Validate(markdown string, opts ...Rule) error {
+	r := &rules{}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	sections := Sections(markdown)
+	var errs []error
+
+	for _, required := range r.requiredSections {
+		if !hasSection(sections, required) {
+			errs = append(errs, NewValidationErrorWithCause(
+				"sections",
+				required,
+				"required",
+				fmt.Sprintf("missing section %q", required),
+				ErrMissingSection,
+			))
+		}
+	}
+
+	if r.maxSectionTokens > 0 {
+		for _, s := range sections {
+			if s.Tokens > r.maxSectionTokens {
+				errs = append(errs, NewValidationErrorWithCause(
+					"sections",
+					s.Title,
+					"max_tokens",
+					fmt.Sprintf("section %q (line %d) has about %d tokens, more than the maximum of %d",
+						s.Title, s.Line, s.Tokens, r.maxSectionTokens),
+					ErrSectionTooLarge,
+				))
+			}
+		}
+	}
+
+	anchors := make(map[string]bool, len(sections))
+	for _, s := range sections {
+		anchors[s.Anchor] = true
+	}
+	for _, link := range internalLinks(markdown) {
+		if !anchors[strings.ToLower(link.anchor)] {
+			errs = append(errs, NewValidationErrorWithCause(
+				"links",
+				"#"+link.anchor,
+				"anchor",
+				fmt.Sprintf("link to #%s (line %d) matches no heading", link.anchor, link.line),
+				ErrBrokenLink,
+			))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateFile validates the SKILL.md at path, which is either the file or
+// the skill directory containing it; use ValidateFS for skills compiled into
+// the program.
+//
+// Relative paths resolve against the directory of the Go file calling
+// ValidateFile, then against the working directory. If neither holds the
+// path, the error lists every path tried.
+func ValidateFile(path string, opts ...Rule) error {
+	resolved, err := textfile.Resolve(path, textfile.CallerDir(1), "")
+	if err != nil {
+		return fmt.Errorf("failed to read skill: %w", err)
+	}
+	return validateSource(nil, resolved, opts)
+}
+
+// ValidateFS validates the SKILL.md at path in fsys, typically an embed.FS,
+// so a compiled synthesis program does not depend on its working directory.
+// The path is slash-separated and relative to the root of fsys, and names
+// either the file or the skill directory containing it.
+//
+// Example:
+//
+//	//go:embed skills
+//	var skills embed.FS
+//
+//	err := skill.ValidateFS(skills, "skills/code-review",
+//	    skill.RequireSections("Security Checklist"))
+func ValidateFS(fsys fs.FS, path string, opts ...Rule) error {
+	if fsys == nil {
+		return errors.New("ValidateFS requires a non-nil fs.FS")
+	}
+	return validateSource(fsys, path, opts)
+}
+
+// validateSource reads a skill from fsys (or disk when nil) and validates it.
+func validateSource(fsys fs.FS, path string, opts []Rule) error {
+	if textfile.IsDir(fsys, path) {
+		path = textfile.Join(fsys, path, FileName)
+	}
+
+	content, err := textfile.Read(fsys, path, 0)
+	if err != nil {
+		return fmt.Errorf("failed to read skill: %w", err)
+	}
+
+	if err := Validate(content, opts...); err != nil {
+		return fmt.Errorf("skill %s is invalid:\n%w", path, err)
+	}
+	return nil
+}
+
+// hasSection reports whether a section has the given title.
+func hasSection(sections []Section, title string) bool {
+	for _, s := range sections {
+		if strings.EqualFold(s.Title, strings.TrimSpace(title)) {
+			return true
+		}
+	}
+	return false
+}
+
+type internalLink struct {
+	anchor string
+	line   int
+}
+
+// internalLinks returns the links to anchors in markdown, outside fenced
+// code blocks.
+func internalLinks(markdown string) []internalLink {
+	var links []internalLink
+	fence := ""
+	for i, line := range strings.Split(markdown, "\n") {
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			switch fence {
+			case "":
+				fence = m[1]
+			case m[1]:
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+		for _, m := range internalLinkRegex.FindAllStringSubmatch(line, -1) {
+			links = append(links, internalLink{anchor: m[1], line: i + 1})
+		}
+	}
+	return links
+}
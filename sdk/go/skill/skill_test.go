@@ -0,0 +1,178 @@
+package skill
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const testSkill = `# Code Review
+
+Intro text before the first section.
+
+## Overview
+
+Review pull requests. Follow the [checklist](#security-checklist).
+
+## Security Checklist
+
+- Check input validation
+- Check authentication
+
+` + "```markdown" + `
+## Not a heading
+[ignored](#not-a-heading)
+` + "```" + `
+
+## Setup
+
+First setup.
+
+## Setup
+
+Second setup, see [the first one](#setup) and [this one](#setup-1).
+`
+
+func TestSections(t *testing.T) {
+	sections := Sections(testSkill)
+
+	var titles, anchors []string
+	for _, s := range sections {
+		titles = append(titles, s.Title)
+		anchors = append(anchors, s.Anchor)
+	}
+	if got, want := strings.Join(titles, "|"), "Code Review|Overview|Security Checklist|Setup|Setup"; got != want {
+		t.Errorf("titles = %s, want %s", got, want)
+	}
+	if got, want := strings.Join(anchors, "|"), "code-review|overview|security-checklist|setup|setup-1"; got != want {
+		t.Errorf("anchors = %s, want %s", got, want)
+	}
+	if sections[0].Level != 1 || sections[1].Level != 2 {
+		t.Errorf("levels = %d, %d, want 1, 2", sections[0].Level, sections[1].Level)
+	}
+	if sections[2].Line != 9 {
+		t.Errorf("Security Checklist line = %d, want 9", sections[2].Line)
+	}
+	// 6 words: "Intro text before the first section."
+	if sections[0].Tokens != 8 {
+		t.Errorf("Code Review tokens = %d, want 8", sections[0].Tokens)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(testSkill,
+		RequireSections("overview", "Security Checklist"),
+		MaxSectionTokens(100),
+	); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+}
+
+func TestValidate_ReportsAllProblems(t *testing.T) {
+	content := "## Overview\n\nSee the [checklist](#checklist).\n\n## Details\n\n" +
+		strings.Repeat("word ", 300) + "\n"
+
+	err := Validate(content,
+		RequireSections("Overview", "Checklist", "Examples"),
+		MaxSectionTokens(200),
+	)
+	if err == nil {
+		t.Fatal("Validate() succeeded, want error")
+	}
+
+	for _, sentinel := range []error{ErrMissingSection, ErrSectionTooLarge, ErrBrokenLink} {
+		if !errors.Is(err, sentinel) {
+			t.Errorf("error does not match %v: %v", sentinel, err)
+		}
+	}
+	for _, want := range []string{
+		`missing section "Checklist"`,
+		`missing section "Examples"`,
+		`section "Details" (line 5) has about 400 tokens, more than the maximum of 200`,
+		"link to #checklist (line 3) matches no heading",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error is missing %q:\n%v", want, err)
+		}
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("error is not a *ValidationError: %v", err)
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(testSkill), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ValidateFile(dir, RequireSections("Overview")); err != nil {
+		t.Errorf("ValidateFile(dir) error = %v", err)
+	}
+	err := ValidateFile(filepath.Join(dir, FileName), RequireSections("Examples"))
+	if !errors.Is(err, ErrMissingSection) {
+		t.Errorf("ValidateFile(file) error = %v, want ErrMissingSection", err)
+	}
+	if err := ValidateFile(filepath.Join(dir, "missing")); err == nil {
+		t.Error("ValidateFile() on a missing path succeeded")
+	}
+}
+
+func TestValidateFile_ResolvesAgainstCallerDir(t *testing.T) {
+	// Relative paths resolve against this file's directory, not the
+	// working directory
+	pkgDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(t.TempDir())
+	workDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ValidateFile("testdata/code-review", RequireSections("Overview")); err != nil {
+		t.Errorf("ValidateFile() error = %v", err)
+	}
+
+	err = ValidateFile("testdata/missing")
+	if err == nil {
+		t.Fatal("ValidateFile() on a missing path succeeded")
+	}
+	for _, want := range []string{
+		filepath.Join(pkgDir, "testdata", "missing"),
+		filepath.Join(workDir, "testdata", "missing"),
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateFile() error = %v, want it to list %s", err, want)
+		}
+	}
+}
+
+func TestValidateFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"skills/code-review/SKILL.md": {Data: []byte(testSkill)},
+		"skills/empty/SKILL.md":       {Data: []byte("  \n")},
+	}
+
+	if err := ValidateFS(fsys, "skills/code-review", RequireSections("Overview")); err != nil {
+		t.Errorf("ValidateFS(dir) error = %v", err)
+	}
+	err := ValidateFS(fsys, "./skills/code-review/SKILL.md", RequireSections("Examples"))
+	if !errors.Is(err, ErrMissingSection) {
+		t.Errorf("ValidateFS(file) error = %v, want ErrMissingSection", err)
+	}
+
+	err = ValidateFS(fsys, "skills/missing")
+	if err == nil || !strings.Contains(err.Error(), `fs.FS path "skills/missing"`) {
+		t.Errorf("ValidateFS() on a missing path error = %v, want it to name the fs.FS path", err)
+	}
+	if err := ValidateFS(fsys, "skills/empty"); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("ValidateFS() on an empty skill error = %v, want an empty file error", err)
+	}
+}
@@ -0,0 +1,175 @@
+package stigmer
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// ErrVariableConflict is returned when synthesis fails because a bulk setter
+// such as SetStrings redefined an existing context variable, or a setter
+// redefined one with another type.
+var ErrVariableConflict = errors.New("context variable already set")
+
+// VariableConflictError reports a variable set by a bulk setter that was
+// already set, by any setter and with any type, or set by a single-variable
+// setter such as SetInt with another type than it already had. It matches
+// ErrVariableConflict with errors.Is.
+type VariableConflictError struct {
+	// Name is the variable name.
+	Name string
+
+	// Op is the setter that redefined the variable, such as SetStrings.
+	Op string
+
+	// SetAt and PreviousSetAt are the call sites ("file.go:line") of the
+	// conflicting call and of the call that first set the variable.
+	SetAt         string
+	PreviousSetAt string
+
+	// Type and PreviousType are the conflicting variable types ("string",
+	// "int", ...) when a single-variable setter changed the type; they are
+	// empty for bulk setter conflicts.
+	Type         string
+	PreviousType string
+}
+
+func (e *VariableConflictError) Error() string {
+	if e.Type != "" {
+		return fmt.Sprintf("%v: %s at %s sets %q as %s, already set as %s at %s",
+			ErrVariableConflict, e.Op, e.SetAt, e.Name, e.Type, e.PreviousType, e.PreviousSetAt)
+	}
+	return fmt.Sprintf("%v: %s at %s sets %q, already set at %s", ErrVariableConflict, e.Op, e.SetAt, e.Name, e.PreviousSetAt)
+}
+
+func (e *VariableConflictError) Unwrap() error {
+	return ErrVariableConflict
+}
+
+// SetStrings creates a string variable for every entry of values, like
+// SetString, and returns their references by name.
+//
+// Variables are created in key order, so synthesis output doesn't depend on
+// map iteration. Unlike SetString, which replaces an existing string
+// variable, a key naming a variable that is already set keeps the existing
+// variable and fails synthesis with a VariableConflictError naming both call
+// sites. A later single-variable setter may still replace a variable set
+// here with one of the same type.
+//
+// Example:
+//
+//	cfg := ctx.SetStrings(map[string]string{
+//	    "apiBase": "https://api.example.com",
+//	    "region":  "eu-west-1",
+//	})
+//	endpoint := cfg["apiBase"].Concat("/users")
+func (c *Context) SetStrings(values map[string]string) map[string]*StringRef {
+	c.mustBeOpen("SetStrings")
+	return setAll(c, "SetStrings", callerLocation(), values, func(name, value, setAt string) *StringRef {
+		return &StringRef{baseRef: baseRef{name: name, setAt: setAt}, value: value}
+	})
+}
+
+// SetInts creates an integer variable for every entry of values. It behaves
+// like SetStrings.
+func (c *Context) SetInts(values map[string]int) map[string]*IntRef {
+	c.mustBeOpen("SetInts")
+	return setAll(c, "SetInts", callerLocation(), values, func(name string, value int, setAt string) *IntRef {
+		return &IntRef{baseRef: baseRef{name: name, setAt: setAt}, value: value}
+	})
+}
+
+// SetBools creates a boolean variable for every entry of values. It behaves
+// like SetStrings.
+func (c *Context) SetBools(values map[string]bool) map[string]*BoolRef {
+	c.mustBeOpen("SetBools")
+	return setAll(c, "SetBools", callerLocation(), values, func(name string, value bool, setAt string) *BoolRef {
+		return &BoolRef{baseRef: baseRef{name: name, setAt: setAt}, value: value}
+	})
+}
+
+// setAll creates the variables of a bulk setter in key order and records a
+// conflict for every name that is already set.
+func setAll[T any, R Ref](c *Context, op, setAt string, values map[string]T, newRef func(name string, value T, setAt string) R) map[string]R {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	refs := make(map[string]R, len(values))
+	for _, name := range names {
+		ref := newRef(name, values[name], setAt)
+		refs[name] = ref
+
+		if existing, ok := c.variables[name]; ok {
+			c.variableConflicts = append(c.variableConflicts, &VariableConflictError{
+				Name:          name,
+				Op:            op,
+				SetAt:         setAt,
+				PreviousSetAt: setLocation(existing),
+			})
+			continue
+		}
+		c.variables[name] = ref
+	}
+	return refs
+}
+
+// checkVariableConflicts fails synthesis if a setter of the context or of one
+// of its scopes redefined a variable it may not.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) checkVariableConflicts() error {
+	conflicts := make([]error, 0, len(c.variableConflicts))
+	for _, conflict := range c.variableConflicts {
+		conflicts = append(conflicts, conflict)
+	}
+	for _, scope := range c.scopes {
+		scope.mu.RLock()
+		for _, conflict := range scope.variableConflicts {
+			conflicts = append(conflicts, conflict)
+		}
+		scope.mu.RUnlock()
+	}
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+	// The conflicts name the variables and both call sites, so they are
+	// part of the message rather than only of the cause
+	lines := make([]string, len(conflicts))
+	for i, conflict := range conflicts {
+		lines[i] = conflict.Error()
+	}
+	return validation.NewSynthesisErrorWithCause(
+		"config",
+		fmt.Sprintf("%d context variables set more than once:\n  %s", len(conflicts), strings.Join(lines, "\n  ")),
+		errors.Join(conflicts...),
+	)
+}
+
+// callerLocation returns the call site ("file.go:line") of the exported
+// Context method that called it.
+func callerLocation() string {
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return "unknown location"
+	}
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
+
+// setLocation returns the call site that created a context variable.
+func setLocation(ref Ref) string {
+	if located, ok := ref.(interface{ location() string }); ok && located.location() != "" {
+		return located.location()
+	}
+	return "unknown location"
+}
@@ -0,0 +1,105 @@
+package stigmer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestContext_SetStrings(t *testing.T) {
+	ctx := newContext()
+
+	refs := ctx.SetStrings(map[string]string{
+		"apiBase": "https://api.example.com",
+		"region":  "eu-west-1",
+	})
+
+	if len(refs) != 2 {
+		t.Fatalf("SetStrings() returned %d refs, want 2", len(refs))
+	}
+	if got := ctx.GetString("region"); got != refs["region"] {
+		t.Errorf("GetString(region) = %v, want the returned ref", got)
+	}
+
+	single := newContext().SetString("apiBase", "https://api.example.com")
+	bulk := refs["apiBase"]
+	if bulk.Expression() != single.Expression() {
+		t.Errorf("Expression() = %q, want %q", bulk.Expression(), single.Expression())
+	}
+
+	endpoint := bulk.Concat("/users")
+	if endpoint.IsComputed() || endpoint.Value() != "https://api.example.com/users" {
+		t.Errorf("Concat() = %q (computed %v), want resolved at synthesis", endpoint.Value(), endpoint.IsComputed())
+	}
+}
+
+func TestContext_SetIntsAndBools(t *testing.T) {
+	ctx := newContext()
+
+	ints := ctx.SetInts(map[string]int{"retries": 3, "timeout": 30})
+	bools := ctx.SetBools(map[string]bool{"isProd": true})
+
+	if ints["timeout"].Value() != 30 || ctx.GetInt("retries") != ints["retries"] {
+		t.Errorf("SetInts() refs = %v", ints)
+	}
+	if !bools["isProd"].Value() || ctx.GetBool("isProd") != bools["isProd"] {
+		t.Errorf("SetBools() refs = %v", bools)
+	}
+
+	single := newContext().SetInt("timeout", 30)
+	if got, want := ints["timeout"].Add(Int(10)).Expression(), single.Add(Int(10)).Expression(); got != want {
+		t.Errorf("Add() = %q, want %q", got, want)
+	}
+}
+
+func TestContext_SetStringsConflict(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+	ctx := newContext()
+
+	ctx.SetInt("region", 1)
+	ctx.SetStrings(map[string]string{"region": "eu-west-1", "zone": "a"})
+
+	if ctx.GetInt("region") == nil {
+		t.Error("SetStrings() replaced the existing variable")
+	}
+	if ctx.GetString("zone") == nil {
+		t.Error("SetStrings() skipped the variables without conflicts")
+	}
+
+	err := ctx.Synthesize()
+	if !errors.Is(err, ErrVariableConflict) {
+		t.Fatalf("Synthesize() error = %v, want ErrVariableConflict", err)
+	}
+
+	var conflict *VariableConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Synthesize() error = %v, want a VariableConflictError", err)
+	}
+	if conflict.Name != "region" || conflict.Op != "SetStrings" {
+		t.Errorf("conflict = %+v, want region redefined by SetStrings", conflict)
+	}
+	if !strings.HasPrefix(conflict.SetAt, "bulk_test.go:") || !strings.HasPrefix(conflict.PreviousSetAt, "bulk_test.go:") ||
+		conflict.SetAt == conflict.PreviousSetAt {
+		t.Errorf("conflict call sites = %q and %q, want two lines of bulk_test.go", conflict.SetAt, conflict.PreviousSetAt)
+	}
+	for _, want := range []string{`"region"`, conflict.SetAt, conflict.PreviousSetAt} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestContext_SetStringAfterSetStringsReplaces(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+	ctx := newContext()
+
+	ctx.SetStrings(map[string]string{"region": "eu-west-1"})
+	ctx.SetString("region", "us-east-1")
+
+	if got := ctx.GetString("region").Value(); got != "us-east-1" {
+		t.Errorf("GetString(region) = %q, want the replacing value", got)
+	}
+	if err := ctx.Synthesize(); err != nil {
+		t.Errorf("Synthesize() error = %v, want overwrites by SetString allowed", err)
+	}
+}
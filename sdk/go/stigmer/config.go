@@ -0,0 +1,268 @@
+package stigmer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// ConfigSource identifies where the value of a context variable came from.
+type ConfigSource string
+
+const (
+	// ConfigSourceCode is a value set in code, such as with SetString.
+	ConfigSourceCode ConfigSource = "code"
+
+	// ConfigSourceEnv is a value read from an environment variable by RequireString.
+	ConfigSourceEnv ConfigSource = "env"
+
+	// ConfigSourceDefault is the default of a RequireString variable whose
+	// environment variables were all unset.
+	ConfigSourceDefault ConfigSource = "default"
+)
+
+// ErrMissingConfig is returned when synthesis fails because a required
+// variable has no value.
+var ErrMissingConfig = errors.New("required configuration missing")
+
+// MissingConfigError reports a required variable that could not be resolved.
+// It matches ErrMissingConfig with errors.Is.
+type MissingConfigError struct {
+	// Name is the variable name passed to RequireString.
+	Name string
+
+	// EnvVars lists the environment variables that were checked.
+	EnvVars []string
+}
+
+func (e *MissingConfigError) Error() string {
+	if len(e.EnvVars) == 0 {
+		return fmt.Sprintf("%v: %q has no environment variable or default", ErrMissingConfig, e.Name)
+	}
+	return fmt.Sprintf("%v: %q requires one of %s to be set", ErrMissingConfig, e.Name, strings.Join(e.EnvVars, ", "))
+}
+
+func (e *MissingConfigError) Unwrap() error {
+	return ErrMissingConfig
+}
+
+// RequireOption configures how RequireString resolves a value.
+type RequireOption func(*requireOptions)
+
+// requireOptions holds the settings collected from RequireOption values.
+type requireOptions struct {
+	envVars      []string
+	defaultValue *string
+}
+
+// FromEnv resolves the value from the environment variable key of the
+// process running synthesis. Empty values count as unset. The option may be
+// given multiple times; the first variable that is set wins.
+func FromEnv(key string) RequireOption {
+	return func(o *requireOptions) {
+		o.envVars = append(o.envVars, key)
+	}
+}
+
+// Default sets the value used when none of the FromEnv variables is set.
+func Default(value string) RequireOption {
+	return func(o *requireOptions) {
+		o.defaultValue = &value
+	}
+}
+
+// RequireString creates a string variable whose value is resolved when it
+// is declared, from the deployer's environment rather than from code.
+//
+// The value is taken from the first FromEnv variable that is set, then from
+// Default. If neither yields a value, synthesis fails with a
+// MissingConfigError. The returned StringRef behaves like one created with
+// SetString: it is resolved at synthesis time and works with Concat.
+//
+// The source of each value is listed by ConfigValues and written to the
+// config manifest, so `stigmer apply --dry-run` shows which values came from
+// the environment.
+//
+// Example:
+//
+//	apiBase := ctx.RequireString("apiBase",
+//	    stigmer.FromEnv("API_BASE"),
+//	    stigmer.Default("https://api.example.com"),
+//	)
+//	endpoint := apiBase.Concat("/users")
+func (c *Context) RequireString(name string, opts ...RequireOption) *StringRef {
+	c.mustBeOpen("RequireString")
+
+	options := &requireOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	ref := &StringRef{
+		baseRef: baseRef{
+			name:  name,
+			setAt: callerLocation(),
+		},
+	}
+	ref.resolveRequired(options)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.setVariable("RequireString", name, ref)
+	return ref
+}
+
+// resolveRequired sets the value and source of a RequireString variable.
+func (s *StringRef) resolveRequired(options *requireOptions) {
+	for _, key := range options.envVars {
+		if value := os.Getenv(key); value != "" {
+			s.value = value
+			s.source = ConfigSourceEnv
+			s.envVar = key
+			return
+		}
+	}
+	if options.defaultValue != nil {
+		s.value = *options.defaultValue
+		s.source = ConfigSourceDefault
+		return
+	}
+	s.missing = &MissingConfigError{Name: s.name, EnvVars: options.envVars}
+}
+
+// ConfigValue describes the resolved value of a context variable.
+type ConfigValue struct {
+	// Name is the variable name.
+	Name string `json:"name"`
+
+	// Value is the resolved value, or "***" for secrets.
+	Value string `json:"value"`
+
+	// Source is where the value came from.
+	Source ConfigSource `json:"source"`
+
+	// EnvVar is the environment variable the value was read from, if any.
+	EnvVar string `json:"envVar,omitempty"`
+}
+
+// ConfigValues returns the resolved value and source of every context
+// variable, sorted by name. Required variables without a value are omitted.
+//
+// Example:
+//
+//	for _, v := range ctx.ConfigValues() {
+//	    fmt.Printf("%s = %s (%s %s)\n", v.Name, v.Value, v.Source, v.EnvVar)
+//	}
+func (c *Context) ConfigValues() []ConfigValue {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return configValues(c.variables)
+}
+
+// configValues lists the values of variables sorted by name.
+// NOTE: The caller must hold the lock of the context owning variables.
+func configValues(variables map[string]Ref) []ConfigValue {
+	values := make([]ConfigValue, 0, len(variables))
+	for name, ref := range variables {
+		value := ConfigValue{Name: name, Source: ConfigSourceCode}
+		if s, ok := ref.(*StringRef); ok {
+			if s.missing != nil {
+				continue
+			}
+			if s.source != "" {
+				value.Source = s.source
+				value.EnvVar = s.envVar
+			}
+		}
+		value.Value = displayValue(ref)
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Name < values[j].Name
+	})
+	return values
+}
+
+// displayValue formats the value of ref for the config manifest.
+func displayValue(ref Ref) string {
+	if ref.IsSecret() {
+		return "***"
+	}
+	if s, ok := ref.ToValue().(string); ok {
+		return s
+	}
+	data, err := json.Marshal(ref.ToValue())
+	if err != nil {
+		return fmt.Sprint(ref.ToValue())
+	}
+	return string(data)
+}
+
+// checkRequiredConfig fails synthesis if a RequireString variable of the
+// context or its scopes has no value.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) checkRequiredConfig() error {
+	var missing []error
+	seen := make(map[*StringRef]bool)
+	collect := func(variables map[string]Ref) {
+		for _, ref := range variables {
+			if s, ok := ref.(*StringRef); ok && s.missing != nil && !seen[s] {
+				seen[s] = true
+				missing = append(missing, s.missing)
+			}
+		}
+	}
+
+	collect(c.variables)
+	for _, scope := range c.scopes {
+		scope.mu.RLock()
+		collect(scope.variables)
+		scope.mu.RUnlock()
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].Error() < missing[j].Error()
+	})
+	return validation.NewSynthesisErrorWithCause(
+		"config",
+		fmt.Sprintf("%d required variables missing", len(missing)),
+		errors.Join(missing...),
+	)
+}
+
+// synthesizeConfig emits the resolved context variables as JSON.
+// Contexts without variables emit nothing.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) synthesizeConfig(sinks []ManifestSink) error {
+	if len(c.variables) == 0 {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(configValues(c.variables), "", "  ")
+	if err != nil {
+		return validation.NewSynthesisErrorWithCause(
+			"config",
+			"failed to marshal config values",
+			err,
+		)
+	}
+
+	if err := emitManifest(sinks, ManifestKindConfig, data); err != nil {
+		return validation.NewSynthesisErrorWithCause(
+			"config",
+			err.Error(),
+			manifestWriteError(err),
+		)
+	}
+	return nil
+}
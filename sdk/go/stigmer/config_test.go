@@ -0,0 +1,155 @@
+package stigmer
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestContext_RequireString(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        string
+		opts       []RequireOption
+		wantValue  string
+		wantSource ConfigSource
+		wantEnvVar string
+	}{
+		{
+			name:       "from env",
+			env:        "https://api.prod.example.com",
+			opts:       []RequireOption{FromEnv("TEST_API_BASE"), Default("https://api.example.com")},
+			wantValue:  "https://api.prod.example.com",
+			wantSource: ConfigSourceEnv,
+			wantEnvVar: "TEST_API_BASE",
+		},
+		{
+			name:       "default when env unset",
+			opts:       []RequireOption{FromEnv("TEST_API_BASE"), Default("https://api.example.com")},
+			wantValue:  "https://api.example.com",
+			wantSource: ConfigSourceDefault,
+		},
+		{
+			name:       "empty default",
+			opts:       []RequireOption{FromEnv("TEST_API_BASE"), Default("")},
+			wantValue:  "",
+			wantSource: ConfigSourceDefault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_API_BASE", tt.env)
+			ctx := NewContext()
+
+			ref := ctx.RequireString("apiBase", tt.opts...)
+			if ref.Value() != tt.wantValue {
+				t.Errorf("Value() = %q, want %q", ref.Value(), tt.wantValue)
+			}
+			if ctx.GetString("apiBase") != ref {
+				t.Error("GetString() did not return the required variable")
+			}
+
+			values := ctx.ConfigValues()
+			if len(values) != 1 {
+				t.Fatalf("ConfigValues() = %v, want 1 value", values)
+			}
+			if values[0].Source != tt.wantSource || values[0].EnvVar != tt.wantEnvVar {
+				t.Errorf("ConfigValues()[0] = %+v, want source %q env %q", values[0], tt.wantSource, tt.wantEnvVar)
+			}
+		})
+	}
+}
+
+func TestContext_RequireString_FirstEnvWins(t *testing.T) {
+	t.Setenv("TEST_ORG", "")
+	t.Setenv("TEST_CI_ORG", "ci-org")
+	ctx := NewContext()
+
+	org := ctx.RequireString("org", FromEnv("TEST_ORG"), FromEnv("TEST_CI_ORG"))
+	if org.Value() != "ci-org" {
+		t.Errorf("Value() = %q, want %q", org.Value(), "ci-org")
+	}
+}
+
+func TestContext_RequireString_Concat(t *testing.T) {
+	t.Setenv("TEST_API_BASE", "https://api.staging.example.com")
+	ctx := NewContext()
+
+	apiBase := ctx.RequireString("apiBase", FromEnv("TEST_API_BASE"))
+	endpoint := apiBase.Concat("/users")
+	if endpoint.Value() != "https://api.staging.example.com/users" {
+		t.Errorf("Concat() = %q, want resolved URL", endpoint.Value())
+	}
+}
+
+func TestRun_RequireStringMissing(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+	t.Setenv("TEST_API_BASE", "")
+
+	err := Run(func(ctx *Context) error {
+		ctx.RequireString("apiBase", FromEnv("TEST_API_BASE"))
+		return nil
+	})
+	if !errors.Is(err, ErrMissingConfig) {
+		t.Fatalf("Run() error = %v, want %v", err, ErrMissingConfig)
+	}
+
+	var missing *MissingConfigError
+	if !errors.As(err, &missing) {
+		t.Fatalf("Run() error = %v, want MissingConfigError", err)
+	}
+	if missing.Name != "apiBase" || len(missing.EnvVars) != 1 || missing.EnvVars[0] != "TEST_API_BASE" {
+		t.Errorf("MissingConfigError = %+v", missing)
+	}
+}
+
+func TestRunWithOptions_ConfigManifest(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+	t.Setenv("TEST_API_BASE", "https://api.prod.example.com")
+
+	var kinds []ManifestKind
+	var values []ConfigValue
+	sink := func(kind ManifestKind, data []byte) error {
+		kinds = append(kinds, kind)
+		if kind == ManifestKindConfig {
+			return json.Unmarshal(data, &values)
+		}
+		return nil
+	}
+
+	err := RunWithOptions(func(ctx *Context) error {
+		ctx.RequireString("apiBase", FromEnv("TEST_API_BASE"), Default("https://api.example.com"))
+		ctx.SetSecret("apiKey", "secret-key-123")
+		ctx.SetInt("retries", 3)
+		registerTestAgent(ctx, "code-reviewer")
+		return nil
+	}, WithManifestSink(sink))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	want := []ManifestKind{ManifestKindAgent, ManifestKindConfig, ManifestKindDependencies}
+	if len(kinds) != len(want) {
+		t.Fatalf("sink received %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Errorf("manifest %d kind = %q, want %q", i, kinds[i], want[i])
+		}
+	}
+
+	wantValues := []ConfigValue{
+		{Name: "apiBase", Value: "https://api.prod.example.com", Source: ConfigSourceEnv, EnvVar: "TEST_API_BASE"},
+		{Name: "apiKey", Value: "***", Source: ConfigSourceCode},
+		{Name: "retries", Value: "3", Source: ConfigSourceCode},
+	}
+	if len(values) != len(wantValues) {
+		t.Fatalf("config manifest = %+v, want %+v", values, wantValues)
+	}
+	for i := range wantValues {
+		if values[i] != wantValues[i] {
+			t.Errorf("config value %d = %+v, want %+v", i, values[i], wantValues[i])
+		}
+	}
+}
@@ -5,14 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
 	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/agentinstance"
 	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"github.com/stigmer/stigmer/sdk/go/stigmer/naming"
+	"github.com/stigmer/stigmer/sdk/go/tenancy/organization"
 	"github.com/stigmer/stigmer/sdk/go/workflow"
+	"github.com/stigmer/stigmer/sdk/go/workflowinstance"
 )
 
 // Context is the central orchestration context for Stigmer SDK.
@@ -50,12 +56,34 @@ type Context struct {
 	// variables stores all context variables by name
 	variables map[string]Ref
 
+	// variableConflicts records the variables bulk setters tried to redefine
+	// and those setters tried to redefine with another type; they fail
+	// synthesis
+	variableConflicts []*VariableConflictError
+
+	// variableRedefinitions records the variables set again with the same
+	// type and a different value
+	variableRedefinitions []VariableRedefinition
+
+	// runtimeExports lists the variables exported to the runtime environment
+	// of workflows via ExportToRuntime
+	runtimeExports []runtimeExport
+
 	// workflows tracks all workflows created in this context
 	workflows []*workflow.Workflow
 
 	// agents tracks all agents created in this context
 	agents []*agent.Agent
 
+	// agentInstances tracks all agent instances created in this context
+	agentInstances []*agentinstance.AgentInstance
+
+	// workflowInstances tracks all workflow instances created in this context
+	workflowInstances []*workflowinstance.WorkflowInstance
+
+	// organizations tracks all organizations created in this context
+	organizations []*organization.Organization
+
 	// dependencies tracks resource dependencies for creation order
 	// Map format: resourceID -> []dependencyIDs
 	// Example: "workflow:pr-review" -> ["agent:code-reviewer"]
@@ -66,6 +94,62 @@ type Context struct {
 
 	// synthesized tracks whether synthesis has been performed
 	synthesized bool
+
+	// closed is set when the Run call that created the context returns.
+	// It is shared with scopes and contexts derived via WithValue.
+	closed *atomic.Bool
+
+	// events delivers progress events to the handlers registered via
+	// WithEventHandler. It is shared like closed.
+	events *eventEmitter
+
+	// sinks receive synthesized manifests in addition to STIGMER_OUT_DIR output
+	sinks []ManifestSink
+
+	// scopes tracks the scoped contexts created via Scope, in creation order
+	scopes []*Context
+
+	// root is the context a scoped context was created from (nil for the root)
+	root *Context
+
+	// scope holds the scope settings (nil for the root context)
+	scope *scopeOptions
+
+	// defaultOrg holds the org set via SetDefaultOrg (a string), and
+	// defaultOrgErr the error of a value not known at synthesis
+	defaultOrg    atomic.Value
+	defaultOrgErr error
+
+	// lintMode and lintRules configure the lint pass run during synthesis
+	lintMode  LintMode
+	lintRules []workflow.LintRule
+
+	// hostPolicy is the project's outbound host policy (nil if none)
+	hostPolicy *HostPolicy
+
+	// resourcePolicies are the policies agents and workflows are checked
+	// against (set via WithResourcePolicy)
+	resourcePolicies []ResourcePolicy
+
+	// lintFindings holds the findings of the last lint pass
+	lintFindings []workflow.LintFinding
+
+	// sourceRevision is recorded in the annotations of synthesized resources
+	// (set via WithSourceRevision)
+	sourceRevision string
+
+	// workDir is the directory relative file paths of agents resolve against
+	// (set via WithWorkDir)
+	workDir string
+
+	// failIfManifestNewer guards manifests in STIGMER_OUT_DIR synthesized
+	// after startedAt from being overwritten (set via FailIfManifestNewer)
+	failIfManifestNewer bool
+	startedAt           time.Time
+
+	// requireResources fails synthesis with ErrNoResources when nothing was
+	// registered (set by Run unless AllowEmpty is given)
+	requireResources bool
 }
 
 // newContextWithContext creates a new Context with the given Go context.
@@ -77,6 +161,7 @@ func newContextWithContext(ctx context.Context) *Context {
 		workflows:    make([]*workflow.Workflow, 0),
 		agents:       make([]*agent.Agent, 0),
 		dependencies: make(map[string][]string),
+		closed:       new(atomic.Bool),
 	}
 }
 
@@ -139,12 +224,17 @@ func (c *Context) Context() context.Context {
 //	ctx = ctx.WithValue("requestID", "abc-123")
 //	reqID := ctx.Value("requestID").(string)
 func (c *Context) WithValue(key, val any) *Context {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return &Context{
 		ctx:          context.WithValue(c.ctx, key, val),
 		variables:    c.variables,
 		workflows:    c.workflows,
 		agents:       c.agents,
 		dependencies: c.dependencies,
+		closed:       c.closed,
+		events:       c.events,
 		// Note: mu and synthesized are zero-valued (new mutex, false)
 		// This is intentional - WithValue creates a derived context for
 		// value propagation, not for shared mutation tracking.
@@ -191,6 +281,17 @@ func (c *Context) Err() error {
 // =============================================================================
 // Variable Management - Typed Setters
 // =============================================================================
+//
+// The setters panic with an error wrapping ErrContextClosed when called after
+// the Run call that created the context has returned.
+//
+// Setting a name that is already set with the same type replaces the
+// variable: the last call wins, and a different value is listed by
+// VariableRedefinitions and reported by the variable-redefined lint rule.
+// Setting it with another type keeps the existing variable and fails
+// synthesis with a VariableConflictError naming both call sites. The bulk
+// setters (SetStrings, SetInts, SetBools) refuse to redefine a variable
+// whatever its type.
 
 // SetString creates a string variable in the context and returns a typed reference.
 // The variable is resolved at synthesis time (compile-time) by interpolating ${variableName}
@@ -201,6 +302,8 @@ func (c *Context) Err() error {
 //	apiURL := ctx.SetString("apiURL", "https://api.example.com")
 //	// In task config: "${apiURL}/users" → synthesizes to: "https://api.example.com/users"
 func (c *Context) SetString(name, value string) *StringRef {
+	c.mustBeOpen("SetString")
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -208,10 +311,11 @@ func (c *Context) SetString(name, value string) *StringRef {
 		baseRef: baseRef{
 			name:     name,
 			isSecret: false,
+			setAt:    callerLocation(),
 		},
 		value: value,
 	}
-	c.variables[name] = ref
+	c.setVariable("SetString", name, ref)
 	return ref
 }
 
@@ -224,6 +328,8 @@ func (c *Context) SetString(name, value string) *StringRef {
 //	apiKey := ctx.SetSecret("apiKey", "secret-key-123")
 //	// In headers: "Bearer ${apiKey}" → synthesizes to: "Bearer secret-key-123"
 func (c *Context) SetSecret(name, value string) *StringRef {
+	c.mustBeOpen("SetSecret")
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -231,10 +337,11 @@ func (c *Context) SetSecret(name, value string) *StringRef {
 		baseRef: baseRef{
 			name:     name,
 			isSecret: true,
+			setAt:    callerLocation(),
 		},
 		value: value,
 	}
-	c.variables[name] = ref
+	c.setVariable("SetSecret", name, ref)
 	return ref
 }
 
@@ -246,6 +353,8 @@ func (c *Context) SetSecret(name, value string) *StringRef {
 //	retries := ctx.SetInt("retries", 3)
 //	// In config: {"max_retries": "${retries}"} → synthesizes to: {"max_retries": 3}
 func (c *Context) SetInt(name string, value int) *IntRef {
+	c.mustBeOpen("SetInt")
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -253,10 +362,11 @@ func (c *Context) SetInt(name string, value int) *IntRef {
 		baseRef: baseRef{
 			name:     name,
 			isSecret: false,
+			setAt:    callerLocation(),
 		},
 		value: value,
 	}
-	c.variables[name] = ref
+	c.setVariable("SetInt", name, ref)
 	return ref
 }
 
@@ -268,6 +378,8 @@ func (c *Context) SetInt(name string, value int) *IntRef {
 //	isProd := ctx.SetBool("isProd", true)
 //	// In config: {"production": "${isProd}"} → synthesizes to: {"production": true}
 func (c *Context) SetBool(name string, value bool) *BoolRef {
+	c.mustBeOpen("SetBool")
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -275,10 +387,11 @@ func (c *Context) SetBool(name string, value bool) *BoolRef {
 		baseRef: baseRef{
 			name:     name,
 			isSecret: false,
+			setAt:    callerLocation(),
 		},
 		value: value,
 	}
-	c.variables[name] = ref
+	c.setVariable("SetBool", name, ref)
 	return ref
 }
 
@@ -293,8 +406,11 @@ func (c *Context) SetBool(name string, value bool) *BoolRef {
 //	        "port": 5432,
 //	    },
 //	})
-//	// In config: "${config}" → synthesizes to: {"database": {"host": "localhost", "port": 5432}}
+//	// As a task value (WithBody, SetVars, ...): config → synthesizes to:
+//	// {"database": {"host": "localhost", "port": 5432}}
 func (c *Context) SetObject(name string, value map[string]interface{}) *ObjectRef {
+	c.mustBeOpen("SetObject")
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -302,10 +418,36 @@ func (c *Context) SetObject(name string, value map[string]interface{}) *ObjectRe
 		baseRef: baseRef{
 			name:     name,
 			isSecret: false,
+			setAt:    callerLocation(),
 		},
 		value: value,
 	}
-	c.variables[name] = ref
+	c.setVariable("SetObject", name, ref)
+	return ref
+}
+
+// SetList creates a list variable in the context and returns a typed reference.
+// The variable is resolved at synthesis time (compile-time).
+//
+// Example:
+//
+//	regions := ctx.SetList("regions", []interface{}{"eu-west-1", "us-east-1"})
+//	// As a task value: regions → synthesizes to: ["eu-west-1", "us-east-1"]
+func (c *Context) SetList(name string, value []interface{}) *ListRef {
+	c.mustBeOpen("SetList")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ref := &ListRef{
+		baseRef: baseRef{
+			name:     name,
+			isSecret: false,
+			setAt:    callerLocation(),
+		},
+		value: value,
+	}
+	c.setVariable("SetList", name, ref)
 	return ref
 }
 
@@ -369,6 +511,16 @@ func (c *Context) GetObject(name string) *ObjectRef {
 	return nil
 }
 
+// GetList retrieves a list variable by name.
+// Returns nil if the variable doesn't exist or is not a ListRef.
+func (c *Context) GetList(name string) *ListRef {
+	ref := c.Get(name)
+	if listRef, ok := ref.(*ListRef); ok {
+		return listRef
+	}
+	return nil
+}
+
 // ExportVariables exports all context variables as a map for synthesis.
 // This is used internally during workflow synthesis to pass compile-time
 // variables to the interpolation layer.
@@ -398,28 +550,76 @@ func (c *Context) ExportVariables() map[string]interface{} {
 // RegisterWorkflow registers a workflow with this context.
 // This is typically called automatically by workflow.New() when passed a context.
 //
-// Dependency tracking: Scans workflow tasks for agent references and tracks
-// dependencies automatically.
+// The agents the workflow calls are recorded as its dependencies at
+// synthesis, once all its tasks have been added.
 func (c *Context) RegisterWorkflow(wf *workflow.Workflow) {
+	c.mustBeOpen("RegisterWorkflow")
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if wf.Org == "" {
+		wf.Org = c.DefaultOrg()
+	}
 	c.workflows = append(c.workflows, wf)
-
-	// Track agent dependencies from workflow tasks
-	workflowID := workflowResourceID(wf)
-	c.trackWorkflowAgentDependencies(workflowID, wf)
+	c.emit(ResourceRegistered{Kind: ManifestKindWorkflow, Name: wf.Document.Name, Scope: c.ScopeName()})
 }
 
 // RegisterAgent registers an agent with this context.
 // This is typically called automatically by agent.New() when passed a context.
 func (c *Context) RegisterAgent(ag *agent.Agent) {
+	c.mustBeOpen("RegisterAgent")
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if ag.Org == "" {
+		ag.Org = c.DefaultOrg()
+	}
 	c.agents = append(c.agents, ag)
-	// Skills are now pushed via CLI (`stigmer skill push`), not created through SDK.
-	// The agent only holds references to existing skills via SkillRefs.
+	c.emit(ResourceRegistered{Kind: ManifestKindAgent, Name: ag.Name, Scope: c.ScopeName()})
+}
+
+// RegisterAgentInstance registers an agent instance with this context.
+// This is typically called automatically by agentinstance.New() when passed a context.
+func (c *Context) RegisterAgentInstance(inst *agentinstance.AgentInstance) {
+	c.mustBeOpen("RegisterAgentInstance")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if inst.Org == "" {
+		inst.Org = c.DefaultOrg()
+	}
+	c.agentInstances = append(c.agentInstances, inst)
+	c.emit(ResourceRegistered{Kind: ManifestKindAgentInstance, Name: inst.Name, Scope: c.ScopeName()})
+}
+
+// RegisterWorkflowInstance registers a workflow instance with this context.
+// This is typically called automatically by workflowinstance.New() when passed a context.
+func (c *Context) RegisterWorkflowInstance(inst *workflowinstance.WorkflowInstance) {
+	c.mustBeOpen("RegisterWorkflowInstance")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if inst.Org == "" {
+		inst.Org = c.DefaultOrg()
+	}
+	c.workflowInstances = append(c.workflowInstances, inst)
+	c.emit(ResourceRegistered{Kind: ManifestKindWorkflowInstance, Name: inst.Name, Scope: c.ScopeName()})
+}
+
+// RegisterOrganization registers an organization with this context.
+// This is typically called automatically by organization.New() when passed a context.
+func (c *Context) RegisterOrganization(org *organization.Organization) {
+	c.mustBeOpen("RegisterOrganization")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.organizations = append(c.organizations, org)
+	c.emit(ResourceRegistered{Kind: ManifestKindOrganization, Name: org.Name, Scope: c.ScopeName()})
 }
 
 // =============================================================================
@@ -449,39 +649,6 @@ func (c *Context) TrackDependency(resourceID, dependsOnID string) {
 	c.addDependency(resourceID, dependsOnID)
 }
 
-// trackWorkflowAgentDependencies scans workflow tasks for agent references
-// and records dependencies.
-func (c *Context) trackWorkflowAgentDependencies(workflowID string, wf *workflow.Workflow) {
-	// Note: caller must hold c.mu.Lock()
-
-	// Scan all tasks for agent_call task type
-	for _, task := range wf.Tasks {
-		if task.Kind == workflow.TaskKindAgentCall {
-			// Extract agent reference from task config
-			// TODO: This requires accessing the AgentCallTaskConfig
-			// For now, we'll implement a helper method to extract agent refs
-			agentRefs := extractAgentRefsFromTask(task)
-			for _, agentRef := range agentRefs {
-				// Only track dependencies for inline agents (not platform refs)
-				if agentRef != "" {
-					agentID := fmt.Sprintf("agent:%s", agentRef)
-					c.addDependency(workflowID, agentID)
-				}
-			}
-		}
-	}
-}
-
-// extractAgentRefsFromTask extracts agent references from a workflow task.
-// Returns agent names/slugs that this task depends on.
-func extractAgentRefsFromTask(task *workflow.Task) []string {
-	// TODO: Implement proper extraction from task config
-	// This requires accessing AgentCallTaskConfig.Agent field
-	// For now, return empty - this will be implemented when we have
-	// better access to task configs
-	return []string{}
-}
-
 // workflowResourceID generates a resource ID for a workflow.
 func workflowResourceID(wf *workflow.Workflow) string {
 	return fmt.Sprintf("workflow:%s", wf.Document.Name)
@@ -497,8 +664,17 @@ func agentResourceID(ag *agent.Agent) string {
 // =============================================================================
 
 // Synthesize converts all registered workflows and agents to their proto representations
-// and writes them to disk. This is called automatically by Run() when the function completes.
+// and delivers them to the manifest sinks. Manifests are written to disk when
+// STIGMER_OUT_DIR is set, and passed to any sinks registered via WithManifestSink.
+// This is called automatically by Run() when the function completes.
+//
+// Scopes created via Scope are synthesized after the root context's own
+// resources. Calling Synthesize on a scoped context synthesizes its root.
 func (c *Context) Synthesize() error {
+	if c.root != nil {
+		return c.root.Synthesize()
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -511,61 +687,181 @@ func (c *Context) Synthesize() error {
 	}
 
 	// Get output directory from environment variable
-	// If not set, we're in dry-run mode (just validate, don't write files)
+	// File output is just the default sink; without it and without registered
+	// sinks we're in dry-run mode (just validate, don't emit anything)
 	outputDir := os.Getenv("STIGMER_OUT_DIR")
-	if outputDir == "" {
+
+	c.emit(ValidationStarted{})
+	c.applyDefaultOrgs()
+	err := c.checkVariableConflicts()
+	if err == nil {
+		err = c.checkRequiredConfig()
+	}
+	if err == nil {
+		err = c.checkRuntimeExports()
+	}
+	if err == nil {
+		err = c.checkDefaultOrg()
+	}
+	if err == nil {
+		// Lint before emitting so previous manifests are still on disk
+		err = c.lint(outputDir)
+	}
+	if err == nil {
+		// Checked before anything is written: an empty manifest set would
+		// look like every resource was removed
+		err = c.checkResources()
+	}
+	c.emit(ValidationFinished{Findings: c.lintFindings, Err: err})
+	if err != nil {
+		return err
+	}
+
+	if outputDir != "" {
+		// Ensure output directory exists
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return validation.NewSynthesisErrorWithCause(
+				"init",
+				fmt.Sprintf("failed to create output directory %q", outputDir),
+				err,
+			)
+		}
+	}
+	sinks := c.manifestSinks(outputDir, c.sinks)
+
+	if len(sinks) == 0 {
 		// Dry-run mode: just mark as synthesized
 		c.synthesized = true
+		c.emitSynthesisCompleted()
 		return nil
 	}
 
-	// Synthesize all resources to disk
-	if err := c.synthesizeManifests(outputDir); err != nil {
+	// Synthesize all resources to the sinks
+	if err := c.synthesizeManifests(sinks); err != nil {
 		return err // Already a structured error from synthesize methods
 	}
 
+	// Synthesize each scope into its own output directory
+	for _, scope := range c.scopes {
+		if err := scope.synthesizeScope(outputDir, c.sinks); err != nil {
+			return err
+		}
+	}
+
 	c.synthesized = true
+	c.emitSynthesisCompleted()
 	return nil
 }
 
-// synthesizeManifests writes agent and workflow manifests to disk.
-// Skills are pushed via CLI (`stigmer skill push`), not synthesized from SDK.
-func (c *Context) synthesizeManifests(outputDir string) error {
-	// Ensure output directory exists
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return validation.NewSynthesisErrorWithCause(
-			"init",
-			fmt.Sprintf("failed to create output directory %q", outputDir),
-			err,
-		)
+// synthesizeManifests emits the manifests of the context's resources to the
+// sinks. Of skills, only the agents' inline skills are synthesized; others
+// are pushed with `stigmer skill push`.
+func (c *Context) synthesizeManifests(sinks []ManifestSink) error {
+	// Synthesize organizations first: they own the other resources
+	if len(c.organizations) > 0 {
+		if err := c.synthesizeOrganizations(sinks); err != nil {
+			return err
+		}
 	}
 
-	// Synthesize agents
+	// Synthesize agents, after the inline skills they reference
 	if len(c.agents) > 0 {
-		if err := c.synthesizeAgents(outputDir); err != nil {
+		if err := c.synthesizeSkills(sinks); err != nil {
+			return err
+		}
+		if err := c.synthesizeAgents(sinks); err != nil {
 			return err
 		}
 	}
 
 	// Synthesize workflows
 	if len(c.workflows) > 0 {
-		if err := c.synthesizeWorkflows(outputDir); err != nil {
+		if err := c.synthesizeWorkflows(sinks); err != nil {
 			return err
 		}
 	}
 
-	// Write dependency graph
-	if err := c.synthesizeDependencies(outputDir); err != nil {
+	// Synthesize agent instances
+	if len(c.agentInstances) > 0 {
+		if err := c.synthesizeAgentInstances(sinks); err != nil {
+			return err
+		}
+	}
+
+	// Synthesize workflow instances
+	if len(c.workflowInstances) > 0 {
+		if err := c.synthesizeWorkflowInstances(sinks); err != nil {
+			return err
+		}
+	}
+
+	// Emit resolved configuration
+	if err := c.synthesizeConfig(sinks); err != nil {
+		return err
+	}
+
+	// Emit dependency graph
+	if err := c.synthesizeDependencies(sinks); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// synthesizeAgents converts agents to protobuf and writes to disk
-func (c *Context) synthesizeAgents(outputDir string) error {
-	// Convert each agent to proto and write individually
-	for i, ag := range c.agents {
+// emitManifest delivers a manifest to every sink, stopping at the first error.
+func emitManifest(sinks []ManifestSink, kind ManifestKind, data []byte) error {
+	for _, sink := range sinks {
+		if err := sink(kind, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// manifestWriteError wraps a sink error so callers can match both
+// validation.ErrManifestWrite and the sink's own error.
+func manifestWriteError(err error) error {
+	return fmt.Errorf("%w: %w", validation.ErrManifestWrite, err)
+}
+
+// synthesizeOrganizations converts organizations to protobuf and emits them to the sinks
+func (c *Context) synthesizeOrganizations(sinks []ManifestSink) error {
+	for _, org := range c.organizations {
+		orgProto, err := org.ToProto()
+		if err != nil {
+			return validation.NewSynthesisErrorForResource(
+				"organizations", "Organization", org.Name,
+				"failed to convert to proto",
+				err,
+			)
+		}
+		c.applySourceRevision(orgProto.Metadata)
+
+		data, err := proto.Marshal(orgProto)
+		if err != nil {
+			return validation.NewSynthesisErrorForResource(
+				"organizations", "Organization", org.Name,
+				"failed to serialize protobuf",
+				err,
+			)
+		}
+
+		if err := emitManifest(sinks, ManifestKindOrganization, data); err != nil {
+			return validation.NewSynthesisErrorForResource(
+				"organizations", "Organization", org.Name,
+				err.Error(),
+				manifestWriteError(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// synthesizeAgents converts agents to protobuf and emits them to the sinks
+func (c *Context) synthesizeAgents(sinks []ManifestSink) error {
+	// Convert each agent to proto and emit individually (in creation order)
+	for _, ag := range c.agents {
 		// Convert agent to proto using ToProto() method
 		agentProto, err := ag.ToProto()
 		if err != nil {
@@ -575,6 +871,9 @@ func (c *Context) synthesizeAgents(outputDir string) error {
 				err,
 			)
 		}
+		applyOrg(agentProto.Metadata, ag.Org)
+		c.applySourceRevision(agentProto.Metadata)
+		c.applyAgentDependencies(ag, agentProto.Metadata, agentProto.GetSpec().GetSkillRefs())
 
 		// Serialize to binary protobuf
 		data, err := proto.Marshal(agentProto)
@@ -586,27 +885,50 @@ func (c *Context) synthesizeAgents(outputDir string) error {
 			)
 		}
 
-		// Write to agent-{index}.pb (use index to maintain order)
-		filename := fmt.Sprintf("agent-%d.pb", i)
-		agentPath := filepath.Join(outputDir, filename)
-		if err := os.WriteFile(agentPath, data, 0644); err != nil {
-			return &validation.SynthesisError{
-				Phase:        "agents",
-				ResourceType: "Agent",
-				ResourceName: ag.Name,
-				Message:      fmt.Sprintf("failed to write manifest to %s", agentPath),
-				Err:          validation.ErrManifestWrite,
-			}
+		if err := emitManifest(sinks, ManifestKindAgent, data); err != nil {
+			return validation.NewSynthesisErrorForResource(
+				"agents", "Agent", ag.Name,
+				err.Error(),
+				manifestWriteError(err),
+			)
 		}
 	}
 
 	return nil
 }
 
-// synthesizeWorkflows converts workflows to protobuf and writes to disk
-func (c *Context) synthesizeWorkflows(outputDir string) error {
-	// Convert each workflow to proto and write individually
-	for i, wf := range c.workflows {
+// agentOutputSchemas maps the name and slug of every registered agent that
+// declares an output schema to that schema.
+func (c *Context) agentOutputSchemas() map[string]map[string]any {
+	schemas := make(map[string]map[string]any)
+	for _, ag := range c.agents {
+		if len(ag.OutputSchema) == 0 {
+			continue
+		}
+		schemas[ag.Name] = ag.OutputSchema
+		if ag.Slug != "" {
+			schemas[ag.Slug] = ag.OutputSchema
+		} else {
+			schemas[naming.GenerateSlug(ag.Name)] = ag.OutputSchema
+		}
+	}
+	return schemas
+}
+
+// synthesizeWorkflows converts workflows to protobuf and emits them to the sinks
+func (c *Context) synthesizeWorkflows(sinks []ManifestSink) error {
+	// Convert each workflow to proto and emit individually (in creation order)
+	schemas := c.agentOutputSchemas()
+	for _, wf := range c.workflows {
+		// Check agent call field references against the called agents' output schemas
+		if err := wf.CheckAgentOutputSchemas(schemas); err != nil {
+			return validation.NewSynthesisErrorForResource(
+				"workflows", "Workflow", wf.Document.Name,
+				"field reference not declared by agent output schema",
+				err,
+			)
+		}
+
 		// Convert workflow to proto using ToProto() method
 		workflowProto, err := wf.ToProto()
 		if err != nil {
@@ -616,6 +938,10 @@ func (c *Context) synthesizeWorkflows(outputDir string) error {
 				err,
 			)
 		}
+		applyOrg(workflowProto.Metadata, wf.Org)
+		c.applySourceRevision(workflowProto.Metadata)
+		c.applyWorkflowDependencies(wf, workflowProto.Metadata)
+		c.applyRuntimeExports(workflowProto.Spec)
 
 		// Serialize to binary protobuf
 		data, err := proto.Marshal(workflowProto)
@@ -627,26 +953,145 @@ func (c *Context) synthesizeWorkflows(outputDir string) error {
 			)
 		}
 
-		// Write to workflow-{index}.pb (use index to maintain order)
-		filename := fmt.Sprintf("workflow-%d.pb", i)
-		workflowPath := filepath.Join(outputDir, filename)
-		if err := os.WriteFile(workflowPath, data, 0644); err != nil {
-			return &validation.SynthesisError{
-				Phase:        "workflows",
-				ResourceType: "Workflow",
-				ResourceName: wf.Document.Name,
-				Message:      fmt.Sprintf("failed to write manifest to %s", workflowPath),
-				Err:          validation.ErrManifestWrite,
+		if err := emitManifest(sinks, ManifestKindWorkflow, data); err != nil {
+			return validation.NewSynthesisErrorForResource(
+				"workflows", "Workflow", wf.Document.Name,
+				err.Error(),
+				manifestWriteError(err),
+			)
+		}
+
+		c.emitWorkflowNote(wf, exportSummaryNote(wf))
+		c.emitWorkflowNote(wf, wf.VersionNote())
+	}
+
+	return nil
+}
+
+// synthesizeAgentInstances converts agent instances to protobuf and emits
+// them to the sinks. Each instance with bindings emits its Environment
+// first, then the AgentInstance referencing it.
+func (c *Context) synthesizeAgentInstances(sinks []ManifestSink) error {
+	for _, inst := range c.agentInstances {
+		instanceProto, envProto, err := inst.ToProto()
+		if err != nil {
+			return validation.NewSynthesisErrorForResource(
+				"agent_instances", "AgentInstance", inst.Name,
+				"failed to convert to proto",
+				err,
+			)
+		}
+
+		applyOrg(instanceProto.Metadata, inst.Org)
+		c.applySourceRevision(instanceProto.Metadata)
+		if envProto != nil {
+			applyOrg(envProto.Metadata, inst.Org)
+			c.applySourceRevision(envProto.Metadata)
+			instanceProto.Spec.EnvironmentRefs[0].Org = envProto.Metadata.Org
+			if err := emitAgentInstanceManifest(sinks, inst.Name, ManifestKindEnvironment, envProto); err != nil {
+				return err
 			}
 		}
+		if err := emitAgentInstanceManifest(sinks, inst.Name, ManifestKindAgentInstance, instanceProto); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// synthesizeDependencies writes the dependency graph to dependencies.json
+// emitAgentInstanceManifest serializes one manifest of an agent instance and
+// emits it to the sinks.
+func emitAgentInstanceManifest(sinks []ManifestSink, name string, kind ManifestKind, msg proto.Message) error {
+	return emitInstanceManifest(sinks, "agent_instances", "AgentInstance", name, kind, msg)
+}
+
+// synthesizeWorkflowInstances converts workflow instances to protobuf and
+// emits them to the sinks. Each instance with bindings emits its Environment
+// first, then the WorkflowInstance referencing it.
+func (c *Context) synthesizeWorkflowInstances(sinks []ManifestSink) error {
+	for _, inst := range c.workflowInstances {
+		instanceProto, envProto, err := inst.ToProto()
+		if err != nil {
+			return validation.NewSynthesisErrorForResource(
+				"workflow_instances", "WorkflowInstance", inst.Name,
+				"failed to convert to proto",
+				err,
+			)
+		}
+
+		applyOrg(instanceProto.Metadata, inst.Org)
+		c.applySourceRevision(instanceProto.Metadata)
+		if envProto != nil {
+			applyOrg(envProto.Metadata, inst.Org)
+			c.applySourceRevision(envProto.Metadata)
+			instanceProto.Spec.EnvRefs[0].Org = envProto.Metadata.Org
+			if err := emitInstanceManifest(sinks, "workflow_instances", "WorkflowInstance", inst.Name, ManifestKindEnvironment, envProto); err != nil {
+				return err
+			}
+		}
+		if err := emitInstanceManifest(sinks, "workflow_instances", "WorkflowInstance", inst.Name, ManifestKindWorkflowInstance, instanceProto); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// emitInstanceManifest serializes one manifest of an agent or workflow
+// instance and emits it to the sinks.
+func emitInstanceManifest(sinks []ManifestSink, field, resourceType, name string, kind ManifestKind, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return validation.NewSynthesisErrorForResource(
+			field, resourceType, name,
+			"failed to serialize protobuf",
+			err,
+		)
+	}
+
+	if err := emitManifest(sinks, kind, data); err != nil {
+		return validation.NewSynthesisErrorForResource(
+			field, resourceType, name,
+			err.Error(),
+			manifestWriteError(err),
+		)
+	}
+
+	return nil
+}
+
+// exportSummaryNote describes how many tasks of a workflow export their full
+// output to the workflow context, or returns "" if none do. Every task
+// referenced via Field() is auto-exported as ${.}, so workflows touching
+// large payloads can exceed Temporal's blob size limits at runtime. The note
+// lets users spot this early and narrow exports with task.Exports().
+func exportSummaryNote(wf *workflow.Workflow) string {
+	summary := wf.ExportSummary()
+	if len(summary.FullExports) == 0 {
+		return ""
+	}
+
+	note := fmt.Sprintf("%d of %d tasks export full outputs (%s)",
+		len(summary.FullExports), summary.TotalTasks,
+		strings.Join(summary.FullExports, ", "))
+	if len(summary.NarrowedExports) == 0 {
+		note += "; no exports are narrowed, consider task.Exports(...) for large payloads"
+	}
+	return note
+}
+
+// emitWorkflowNote emits a WorkflowNote for wf unless note is empty.
+func (c *Context) emitWorkflowNote(wf *workflow.Workflow, note string) {
+	if note == "" {
+		return
+	}
+	c.emit(WorkflowNote{Workflow: wf.Document.Name, Message: note, Scope: c.ScopeName()})
+}
+
+// synthesizeDependencies emits the dependency graph as JSON
 // NOTE: This method assumes the caller already holds c.mu lock
-func (c *Context) synthesizeDependencies(outputDir string) error {
+func (c *Context) synthesizeDependencies(sinks []ManifestSink) error {
 	// Access dependencies directly (caller holds lock)
 	// Don't call c.Dependencies() which would try to acquire lock again (deadlock!)
 	deps := c.dependencies
@@ -661,14 +1106,12 @@ func (c *Context) synthesizeDependencies(outputDir string) error {
 		)
 	}
 
-	// Write to dependencies.json
-	depsPath := filepath.Join(outputDir, "dependencies.json")
-	if err := os.WriteFile(depsPath, data, 0644); err != nil {
-		return &validation.SynthesisError{
-			Phase:   "dependencies",
-			Message: fmt.Sprintf("failed to write dependencies to %s", depsPath),
-			Err:     validation.ErrManifestWrite,
-		}
+	if err := emitManifest(sinks, ManifestKindDependencies, data); err != nil {
+		return validation.NewSynthesisErrorWithCause(
+			"dependencies",
+			err.Error(),
+			manifestWriteError(err),
+		)
 	}
 
 	return nil
@@ -719,14 +1162,44 @@ func (c *Context) synthesizeDependencies(outputDir string) error {
 //	    return nil
 //	})
 func RunWithContext(ctx context.Context, fn func(*Context) error) error {
+	return run(&runOptions{ctx: ctx}, fn)
+}
+
+// run is the shared implementation of Run, RunWithContext and RunWithOptions.
+func run(options *runOptions, fn func(*Context) error) error {
+	ctx := options.ctx
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
+	exitRun, err := enterRun()
+	if err != nil {
+		return err
+	}
+	defer exitRun()
+
 	sCtx := newContextWithContext(ctx)
+	// Resources created through the context after run returns would never be
+	// synthesized, so fail them instead
+	defer sCtx.close()
+	sCtx.sinks = options.sinks
+	sCtx.lintMode = options.lintMode
+	sCtx.lintRules = options.lintRules
+	sCtx.hostPolicy = options.hostPolicy
+	sCtx.resourcePolicies = options.resourcePolicies
+	sCtx.sourceRevision = options.sourceRevision
+	sCtx.workDir = options.workDir
+	sCtx.failIfManifestNewer = options.failIfNewerOnDisk
+	sCtx.requireResources = !options.allowEmpty
+	sCtx.startedAt = time.Now()
+	handlers := options.eventHandlers
+	if len(handlers) == 0 {
+		handlers = []EventHandler{ConsoleReport()}
+	}
+	sCtx.events = &eventEmitter{handlers: handlers}
 
-	// Execute the user function
-	if err := fn(sCtx); err != nil {
+	// Execute the user function; panics are returned as a *PanicError
+	if err := callRunFunction(fn, sCtx); err != nil {
 		return fmt.Errorf("context function failed: %w", err)
 	}
 
@@ -753,6 +1226,19 @@ func RunWithContext(ctx context.Context, fn func(*Context) error) error {
 // created within the function are automatically registered and synthesized when
 // the function completes successfully.
 //
+// The Context is closed when Run returns: creating resources or variables with
+// it afterwards fails with ErrContextClosed. Calling Run from inside the
+// function returns ErrNestedRun; use Scope to synthesize a separate set of
+// resources instead.
+//
+// A panic in the function, such as a method called on a nil task whose
+// constructor error was ignored, is returned as a *PanicError naming the
+// line of the caller's code that caused it.
+//
+// A function that registers no resources fails with ErrNoResources and no
+// manifest is written; pass AllowEmpty to RunWithOptions if that is
+// intentional.
+//
 // Example:
 //
 //	func main() {
@@ -823,6 +1309,42 @@ func (c *Context) Agents() []*agent.Agent {
 	return result
 }
 
+// AgentInstances returns a copy of all agent instances registered in the context.
+// This is primarily useful for testing and debugging.
+func (c *Context) AgentInstances() []*agentinstance.AgentInstance {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// Return a copy to prevent external modification
+	result := make([]*agentinstance.AgentInstance, len(c.agentInstances))
+	copy(result, c.agentInstances)
+	return result
+}
+
+// WorkflowInstances returns a copy of all workflow instances registered in the context.
+// This is primarily useful for testing and debugging.
+func (c *Context) WorkflowInstances() []*workflowinstance.WorkflowInstance {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// Return a copy to prevent external modification
+	result := make([]*workflowinstance.WorkflowInstance, len(c.workflowInstances))
+	copy(result, c.workflowInstances)
+	return result
+}
+
+// Organizations returns a copy of all organizations registered in the context.
+// This is primarily useful for testing and debugging.
+func (c *Context) Organizations() []*organization.Organization {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// Return a copy to prevent external modification
+	result := make([]*organization.Organization, len(c.organizations))
+	copy(result, c.organizations)
+	return result
+}
+
 // Dependencies returns a copy of the dependency graph.
 // The map format is: resourceID -> []dependencyIDs
 //
@@ -375,7 +375,7 @@ func TestContext_ComputedExpressions(t *testing.T) {
 func TestRun_Success(t *testing.T) {
 	executed := false
 
-	err := Run(func(ctx *Context) error {
+	err := RunWithOptions(func(ctx *Context) error {
 		executed = true
 
 		// Create some variables
@@ -383,7 +383,7 @@ func TestRun_Success(t *testing.T) {
 		ctx.SetInt("retries", 3)
 
 		return nil
-	})
+	}, AllowEmpty())
 
 	if err != nil {
 		t.Errorf("Run() returned error: %v", err)
@@ -411,10 +411,10 @@ func TestRun_FunctionError(t *testing.T) {
 func TestRun_ContextAvailable(t *testing.T) {
 	var capturedCtx *Context
 
-	err := Run(func(ctx *Context) error {
+	err := RunWithOptions(func(ctx *Context) error {
 		capturedCtx = ctx
 		return nil
-	})
+	}, AllowEmpty())
 
 	if err != nil {
 		t.Fatalf("Run() returned error: %v", err)
@@ -666,7 +666,7 @@ func TestResourceIDGeneration(t *testing.T) {
 // =============================================================================
 
 func TestContext_CompleteWorkflow(t *testing.T) {
-	err := Run(func(ctx *Context) error {
+	err := RunWithOptions(func(ctx *Context) error {
 		// Set up configuration
 		baseURL := ctx.SetString("baseURL", "https://api.example.com")
 		apiKey := ctx.SetSecret("apiKey", "secret-key")
@@ -705,7 +705,7 @@ func TestContext_CompleteWorkflow(t *testing.T) {
 		}
 
 		return nil
-	})
+	}, AllowEmpty())
 
 	if err != nil {
 		t.Errorf("Complete workflow failed: %v", err)
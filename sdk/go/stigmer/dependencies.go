@@ -0,0 +1,138 @@
+package stigmer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/stigmer/naming"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// Synthesis records the resources each manifest needs before it can be
+// created in its metadata.dependencies: the agents a workflow calls and the
+// skills an agent uses. The same edges are added to the dependency graph
+// written to dependencies.json, with resources that are not synthesized by
+// this context marked external ("skill:external:code-analysis"), so
+// `stigmer apply` creates agents before the workflows calling them and
+// retries references it cannot order.
+
+// applyWorkflowDependencies records the agents called by a workflow's
+// top-level and on-failure tasks in its manifest metadata and in the
+// dependency graph.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) applyWorkflowDependencies(wf *workflow.Workflow, metadata *apiresource.ApiResourceMetadata) {
+	workflowID := workflowResourceID(wf)
+	seen := make(map[string]bool)
+
+	tasks := append(slices.Clone(wf.Tasks), wf.OnFailure...)
+	for _, task := range tasks {
+		cfg, ok := task.Config.(*workflow.AgentCallTaskConfig)
+		if !ok || task.Kind != workflow.TaskKindAgentCall {
+			continue
+		}
+		// Agents named by a runtime expression are only known when the task runs
+		if cfg.Agent == "" || strings.Contains(cfg.Agent, "${") {
+			continue
+		}
+
+		ref, local := c.agentDependency(cfg, metadata.GetOrg())
+		graphID := dependencyGraphID(ref, local)
+		if seen[graphID] {
+			continue
+		}
+		seen[graphID] = true
+
+		metadata.Dependencies = append(metadata.Dependencies, ref)
+		c.addDependency(workflowID, graphID)
+	}
+}
+
+// agentDependency returns a reference to the agent an agent call invokes,
+// and whether the agent is synthesized by this context. Agents without an
+// explicit organization belong to org, the organization of the workflow.
+func (c *Context) agentDependency(cfg *workflow.AgentCallTaskConfig, org string) (*apiresource.ApiResourceReference, bool) {
+	ref := &apiresource.ApiResourceReference{
+		Scope:   apiresource.ApiResourceOwnerScope_organization,
+		Org:     cfg.Org,
+		Kind:    apiresourcekind.ApiResourceKind_agent,
+		Slug:    cfg.Agent,
+		Version: cfg.Version,
+	}
+
+	// Agent references by organization always name an external agent
+	if ag := c.findAgent(cfg.Agent); ag != nil && cfg.Org == "" {
+		ref.Slug = agentSlug(ag)
+		ref.Org = ag.Org
+		if ref.Org == "" {
+			ref.Org = org
+		}
+		return ref, true
+	}
+
+	if cfg.Scope == "platform" {
+		ref.Scope = apiresource.ApiResourceOwnerScope_platform
+	} else if ref.Org == "" {
+		ref.Org = org
+	}
+	return ref, false
+}
+
+// findAgent returns the agent of this context with the given name or slug,
+// or nil.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) findAgent(nameOrSlug string) *agent.Agent {
+	for _, ag := range c.agents {
+		if ag.Name == nameOrSlug || agentSlug(ag) == nameOrSlug {
+			return ag
+		}
+	}
+	return nil
+}
+
+// agentSlug returns the slug an agent is synthesized with.
+func agentSlug(ag *agent.Agent) string {
+	if ag.Slug != "" {
+		return ag.Slug
+	}
+	return naming.GenerateSlug(ag.Name)
+}
+
+// applyAgentDependencies records the skills an agent references in its
+// manifest metadata and in the dependency graph. Only the agent's inline
+// skills are synthesized; skills pushed with `stigmer skill push` are
+// external.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) applyAgentDependencies(ag *agent.Agent, metadata *apiresource.ApiResourceMetadata, skillRefs []*apiresource.ApiResourceReference) {
+	agentID := fmt.Sprintf("agent:%s", agentSlug(ag))
+	seen := make(map[string]bool)
+
+	for _, skillRef := range skillRefs {
+		ref := proto.Clone(skillRef).(*apiresource.ApiResourceReference)
+		ref.Kind = apiresourcekind.ApiResourceKind_skill
+		graphID := dependencyGraphID(ref, isInlineSkillRef(ag, ref))
+		if seen[graphID] {
+			continue
+		}
+		seen[graphID] = true
+
+		metadata.Dependencies = append(metadata.Dependencies, ref)
+		c.addDependency(agentID, graphID)
+	}
+}
+
+// dependencyGraphID returns the dependency graph ID of a referenced
+// resource: "agent:reviewer" for resources synthesized by this context,
+// "agent:external:reviewer" for the others.
+func dependencyGraphID(ref *apiresource.ApiResourceReference, local bool) string {
+	kind := ref.GetKind().String()
+	if local {
+		return fmt.Sprintf("%s:%s", kind, ref.GetSlug())
+	}
+	return fmt.Sprintf("%s:external:%s", kind, ref.GetSlug())
+}
@@ -0,0 +1,108 @@
+package stigmer
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/skillref"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+func TestSynthesize_RecordsDependencies(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var agents []*agentv1.Agent
+	var workflows []*workflowv1.Workflow
+	var graph *Context
+	sink := func(kind ManifestKind, data []byte) error {
+		switch kind {
+		case ManifestKindAgent:
+			a := &agentv1.Agent{}
+			agents = append(agents, a)
+			return proto.Unmarshal(data, a)
+		case ManifestKindWorkflow:
+			w := &workflowv1.Workflow{}
+			workflows = append(workflows, w)
+			return proto.Unmarshal(data, w)
+		}
+		return nil
+	}
+
+	err := RunWithOptions(func(ctx *Context) error {
+		graph = ctx
+		reviewer, err := agent.New(ctx, "code-reviewer", &agent.AgentArgs{
+			Instructions: "Review code quality and report issues",
+		})
+		if err != nil {
+			return err
+		}
+		reviewer.Org = "acme"
+		reviewer.AddSkillRef(skillref.Platform("code-analysis"))
+
+		wf, err := workflow.New(ctx, "ci/pr-review", &workflow.WorkflowArgs{Version: "1.0.0", Org: "acme"})
+		if err != nil {
+			return err
+		}
+		wf.CallAgent("review", &workflow.AgentCallArgs{Message: "Review the PR"}, workflow.Agent(reviewer))
+		wf.CallAgent("review-again", &workflow.AgentCallArgs{Message: "Review the PR"}, workflow.Agent(reviewer))
+		wf.CallAgent("summarize", &workflow.AgentCallArgs{Message: "Summarize"},
+			workflow.AgentRef("platform-team", "summarizer", workflow.AgentVersion("stable")))
+		return nil
+	}, WithManifestSink(sink))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	if len(agents) != 1 || len(workflows) != 1 {
+		t.Fatalf("sink received %d agents and %d workflows, want 1 each", len(agents), len(workflows))
+	}
+
+	wantSkill := &apiresource.ApiResourceReference{
+		Scope: apiresource.ApiResourceOwnerScope_platform,
+		Kind:  apiresourcekind.ApiResourceKind_skill,
+		Slug:  "code-analysis",
+	}
+	if deps := agents[0].GetMetadata().GetDependencies(); len(deps) != 1 || !proto.Equal(deps[0], wantSkill) {
+		t.Errorf("agent dependencies = %v, want [%v]", deps, wantSkill)
+	}
+
+	wantAgents := []*apiresource.ApiResourceReference{
+		{
+			Scope: apiresource.ApiResourceOwnerScope_organization,
+			Org:   "acme",
+			Kind:  apiresourcekind.ApiResourceKind_agent,
+			Slug:  "code-reviewer",
+		},
+		{
+			Scope:   apiresource.ApiResourceOwnerScope_organization,
+			Org:     "platform-team",
+			Kind:    apiresourcekind.ApiResourceKind_agent,
+			Slug:    "summarizer",
+			Version: "stable",
+		},
+	}
+	deps := workflows[0].GetMetadata().GetDependencies()
+	if len(deps) != len(wantAgents) {
+		t.Fatalf("workflow dependencies = %v, want %v", deps, wantAgents)
+	}
+	for i, want := range wantAgents {
+		if !proto.Equal(deps[i], want) {
+			t.Errorf("workflow dependency %d = %v, want %v", i, deps[i], want)
+		}
+	}
+
+	wantGraph := map[string][]string{
+		"agent:code-reviewer": {"skill:external:code-analysis"},
+		"workflow:pr-review":  {"agent:code-reviewer", "agent:external:summarizer"},
+	}
+	if got := graph.Dependencies(); !reflect.DeepEqual(got, wantGraph) {
+		t.Errorf("Dependencies() = %v, want %v", got, wantGraph)
+	}
+}
@@ -72,14 +72,54 @@
 //	wf.WithOrg(ctx.SetString("org", "my-org"))
 //	endpoint := apiBase.Concat("/users")
 //
+// Setting a name twice with SetString, SetInt and the other single-variable
+// setters replaces the variable if the type is the same; a changed value is
+// listed by VariableRedefinitions and reported by the variable-redefined lint
+// rule. Setting it with another type fails synthesis with a
+// VariableConflictError naming both call sites. SetStrings, SetInts and
+// SetBools set several variables at once and return their references by
+// name; redefining an existing variable this way fails synthesis too:
+//
+//	cfg := ctx.SetStrings(map[string]string{"apiBase": "https://api.example.com", "region": "eu-west-1"})
+//	endpoint := cfg["apiBase"].Concat("/users")
+//
+// ExportToRuntime declares variables in every workflow's runtime environment,
+// with their resolved values as overridable defaults read with
+// workflow.RuntimeEnv. Secrets cannot be exported:
+//
+//	ctx.ExportToRuntime("apiBase", "org")
+//	url := workflow.Interpolate(workflow.RuntimeEnv("apiBase"), "/users")
+//
 // ## Typed References
 //
-// Context variables are typed references (StringRef, IntRef, BoolRef, ObjectRef)
-// that provide compile-time safety and IDE autocomplete:
+// Context variables are typed references (StringRef, IntRef, BoolRef, ObjectRef,
+// ListRef) that provide compile-time safety and IDE autocomplete:
 //
 //	apiBase := ctx.SetString("apiBase", "https://api.example.com")
 //	endpoint := apiBase.Concat("/posts")  // ✅ Type-safe string operations
 //
+// IntRef and BoolRef support arithmetic (Add, Subtract, Multiply, Divide) and
+// logic (And, Or, Not). Literals from Int() and Bool() fold at synthesis time;
+// task outputs wrapped with IntField() or BoolField() produce runtime expressions:
+//
+//	timeout := ctx.SetInt("timeout", 30)
+//	extended := timeout.Add(stigmer.Int(10))
+//	approved := stigmer.BoolField(reviewTask.Field("approved"))
+//	canDeploy := approved.And(isProd)  // usable as a switch condition
+//
+// ObjectRef and ListRef (from SetObject and SetList) can be used wherever a
+// task accepts map-shaped values, such as request bodies, SetVars values and
+// activity or sub-workflow inputs. Values known at synthesis are expanded into
+// nested objects and arrays; members that reference task outputs become
+// runtime expressions. Fields that only accept literals, such as an agent call
+// output schema, fail synthesis with workflow.ErrRuntimeValueInLiteral when
+// the object contains such a member:
+//
+//	defaults := ctx.SetObject("defaults", map[string]interface{}{"region": "eu-west-1"})
+//	wf.HttpPost("create", endpoint, nil, map[string]interface{}{
+//	    "defaults": defaults,  // {"region": "eu-west-1"}, not a string
+//	})
+//
 // ## Task Output References
 //
 // Tasks produce outputs that other tasks can reference directly, making data flow
@@ -112,6 +152,74 @@
 //	    // Manifests synthesized automatically here!
 //	})
 //
+// A panic in the function is returned as a *PanicError (ErrPanic) naming the
+// line of the caller's code that caused it, and Field on a nil task fails
+// synthesis with workflow.ErrNilTaskReference instead of panicking:
+//
+//	fetch, _ := buildFetch(wf)            // error ignored, fetch is nil
+//	wf.SetVars("use", "id", fetch.Field("id"))
+//	// synthesis failed: ... nil *Task (Field called at main.go:42) ...
+//
+// ## Scopes
+//
+// Programs defining resources for several teams can split them into scopes.
+// Each scope is synthesized into its own directory, names only need to be
+// unique within a scope, and resources default to the scope's org:
+//
+//	teamA := ctx.Scope("team-a",
+//	    stigmer.WithOutDir("out/team-a"),
+//	    stigmer.WithOrg("team-a-org"),
+//	)
+//	ag, _ := agent.New(teamA, ...)  // Written to $STIGMER_OUT_DIR/out/team-a
+//
+// ## Default Organization
+//
+// SetDefaultOrg gives agents, workflows and agent instances that do not set
+// their own Org a default one. It accepts a literal or a StringRef:
+//
+//	ctx.SetDefaultOrg(ctx.SetString("org", "my-org"))
+//
+// A scope's WithOrg takes precedence over the root's default. Synthesized
+// manifests record the effective org, and WithLint reports resources left
+// without an org while others have one (missing-org).
+//
+// ## Linting
+//
+// WithLint runs opinionated checks over workflows during synthesis, such as
+// calls without timeouts, switches without a default case, or task outputs
+// and Set variables nothing reads. LintWarn only reports findings; LintError
+// also fails synthesis on error-severity findings.
+// Rules are suppressed per workflow or task with SuppressLint:
+//
+//	err := stigmer.RunWithOptions(fn, stigmer.WithLint(stigmer.LintError))
+//	wf.SuppressLint(workflow.LintRuleNoDefaultCase)  // inside fn
+//
+// WithHostPolicy reports HTTP calls to literal endpoints whose host the
+// project's host policy denies as denied-host warnings, even without WithLint:
+//
+//	err := stigmer.RunWithOptions(fn, stigmer.WithHostPolicy(stigmer.HostPolicy{
+//	    Deny: []string{"169.254.169.254", "*.internal"},
+//	}))
+//
+// WithResourcePolicy checks every agent and workflow against organization
+// policies such as NamePrefix, NamespaceAllowlist and RequiredLabels.
+// Violations are reported as resource-policy findings and fail synthesis,
+// whatever the lint mode:
+//
+//	err := stigmer.RunWithOptions(fn, stigmer.WithResourcePolicy(
+//	    stigmer.ForKinds(stigmer.NamePrefix("pay-"), stigmer.ManifestKindAgent),
+//	    stigmer.NamespaceAllowlist("payments", "risk"),
+//	))
+//
+// ## Progress Events
+//
+// WithEventHandler receives typed events as resources are registered,
+// validated and written, in a stable order. Without a handler, Run uses
+// ConsoleReport, which prints workflow notes such as the export summary.
+// ConsoleProgress prints one line per event to stderr:
+//
+//	err := stigmer.RunWithOptions(fn, stigmer.WithEventHandler(stigmer.ConsoleProgress()))
+//
 // # Architecture
 //
 // The SDK follows Pulumi-aligned infrastructure-as-code patterns:
@@ -176,7 +284,7 @@
 //	// ✅ Good: Clean, intuitive
 //	task := wf.HttpGet("fetch", endpoint,
 //	    workflow.Header("Content-Type", "application/json"),
-//	    workflow.Timeout(30),
+//	    workflow.TimeoutDuration(30*time.Second),
 //	)
 //	
 //	// ❌ Bad: Verbose (OLD API)
@@ -0,0 +1,48 @@
+package stigmer
+
+import (
+	"errors"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// ErrNoResources is returned by Run, RunWithContext and RunWithOptions when
+// the function registered no organization, agent, workflow, agent instance
+// or workflow instance, in the context or any of its scopes. No manifest is
+// written in that case, so a deploy step cannot mistake an accidentally
+// empty program for one that removed every resource.
+//
+// Use AllowEmpty for programs that are intentionally empty.
+var ErrNoResources = errors.New("no resources registered")
+
+// AllowEmpty lets Run succeed when the function registers no resources.
+// Without it, such a Run fails with ErrNoResources and writes nothing.
+//
+// Example:
+//
+//	err := stigmer.RunWithOptions(fn, stigmer.AllowEmpty())
+func AllowEmpty() RunOption {
+	return func(o *runOptions) {
+		o.allowEmpty = true
+	}
+}
+
+// checkResources fails with ErrNoResources when resources are required (see
+// AllowEmpty) and neither the context nor its scopes registered any.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) checkResources() error {
+	if !c.requireResources {
+		return nil
+	}
+
+	counts := c.resourceCounts()
+	if counts.Organizations+counts.Agents+counts.Workflows+counts.AgentInstances+counts.WorkflowInstances > 0 {
+		return nil
+	}
+
+	return validation.NewSynthesisErrorWithCause(
+		"validation",
+		"the function passed to Run registered no resources; use stigmer.AllowEmpty() if this is intentional",
+		ErrNoResources,
+	)
+}
@@ -0,0 +1,76 @@
+package stigmer
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestRun_NoResources(t *testing.T) {
+	outDir := t.TempDir()
+	t.Setenv("STIGMER_OUT_DIR", outDir)
+
+	sinkCalled := false
+	err := RunWithOptions(func(ctx *Context) error {
+		ctx.SetString("apiURL", "https://api.example.com")
+		ctx.Scope("team-a")
+		return nil
+	}, WithManifestSink(func(ManifestKind, []byte) error {
+		sinkCalled = true
+		return nil
+	}))
+	if !errors.Is(err, ErrNoResources) {
+		t.Fatalf("RunWithOptions() error = %v, want ErrNoResources", err)
+	}
+
+	if sinkCalled {
+		t.Error("sink received a manifest for an empty run")
+	}
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("STIGMER_OUT_DIR contains %d entries, want none", len(entries))
+	}
+}
+
+func TestRun_AllowEmpty(t *testing.T) {
+	outDir := t.TempDir()
+	t.Setenv("STIGMER_OUT_DIR", outDir)
+
+	var kinds []ManifestKind
+	err := RunWithOptions(func(*Context) error { return nil },
+		AllowEmpty(),
+		WithManifestSink(func(kind ManifestKind, _ []byte) error {
+			kinds = append(kinds, kind)
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+	if len(kinds) == 0 {
+		t.Error("sink received no manifest with AllowEmpty")
+	}
+}
+
+func TestRun_ScopeResourcesAreNotEmpty(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var completed SynthesisCompleted
+	err := RunWithOptions(func(ctx *Context) error {
+		registerTestAgent(ctx.Scope("team-a"), "code-reviewer")
+		return nil
+	}, WithEventHandler(func(e Event) {
+		if c, ok := e.(SynthesisCompleted); ok {
+			completed = c
+		}
+	}))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+	if completed.Agents != 1 {
+		t.Errorf("SynthesisCompleted.Agents = %d, want 1", completed.Agents)
+	}
+}
@@ -0,0 +1,293 @@
+package stigmer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// Event is a progress event emitted during Run. It is one of
+// ResourceRegistered, ValidationStarted, ValidationFinished, ManifestWritten,
+// WorkflowNote or SynthesisCompleted.
+//
+// Events are delivered in a stable order: ResourceRegistered for each
+// resource in registration order while the function passed to Run executes,
+// then ValidationStarted and ValidationFinished, then ManifestWritten for
+// each manifest in the order documented on ManifestSink (root context first,
+// then each scope), each workflow manifest followed by its WorkflowNotes,
+// then SynthesisCompleted.
+type Event interface {
+	event()
+}
+
+// ResourceRegistered is emitted when an agent, workflow, agent instance or
+// workflow instance is registered with a Context.
+type ResourceRegistered struct {
+	// Kind is ManifestKindAgent, ManifestKindWorkflow,
+	// ManifestKindAgentInstance or ManifestKindWorkflowInstance.
+	Kind ManifestKind
+	// Name is the name of the resource.
+	Name string
+	// Scope is the name of the scope the resource was registered in, or ""
+	// for the root context.
+	Scope string
+}
+
+// ValidationStarted is emitted before the required configuration is checked
+// and the lint pass runs.
+type ValidationStarted struct{}
+
+// ValidationFinished is emitted after validation, whether or not it failed.
+type ValidationFinished struct {
+	// Findings lists the lint findings, including warnings. Without
+	// WithLint, it only holds the findings reported whatever the lint mode.
+	Findings []workflow.LintFinding
+	// Err is the validation error that stops synthesis, or nil.
+	Err error
+}
+
+// ManifestWritten is emitted after a manifest was accepted by the file
+// output and every registered sink.
+type ManifestWritten struct {
+	Kind ManifestKind
+	// Path is the file the manifest was written to, or "" when
+	// STIGMER_OUT_DIR is unset and the manifest only went to sinks.
+	Path string
+	// Size is the size of the manifest in bytes.
+	Size int
+	// Scope is the name of the scope the manifest belongs to, or "" for the
+	// root context.
+	Scope string
+}
+
+// WorkflowNote is emitted after a workflow manifest was written when
+// synthesis has advice about the workflow, such as tasks exporting their
+// full output or a normalized version.
+type WorkflowNote struct {
+	// Workflow is the name of the workflow.
+	Workflow string
+	// Message is the advice, without the workflow name.
+	Message string
+	// Scope is the name of the scope the workflow belongs to, or "" for the
+	// root context.
+	Scope string
+}
+
+// SynthesisCompleted is emitted once all manifests were written.
+type SynthesisCompleted struct {
+	// Organizations, Agents, Workflows, AgentInstances and
+	// WorkflowInstances count the synthesized resources, including those
+	// of scopes.
+	Organizations     int
+	Agents            int
+	Workflows         int
+	AgentInstances    int
+	WorkflowInstances int
+	// Manifests counts the emitted manifests. It is 0 in dry-run mode.
+	Manifests int
+	// Duration is the time elapsed since Run started.
+	Duration time.Duration
+}
+
+func (ResourceRegistered) event() {}
+func (ValidationStarted) event()  {}
+func (ValidationFinished) event() {}
+func (ManifestWritten) event()    {}
+func (WorkflowNote) event()       {}
+func (SynthesisCompleted) event() {}
+
+// EventHandler receives the events emitted during Run.
+//
+// Handlers are called synchronously, one event at a time, and must not call
+// methods of the Context.
+type EventHandler func(Event)
+
+// WithEventHandler registers a handler receiving progress events. Without
+// one, Run uses ConsoleReport. The option may be given multiple times.
+//
+// Example:
+//
+//	err := stigmer.RunWithOptions(fn, stigmer.WithEventHandler(stigmer.ConsoleProgress()))
+func WithEventHandler(handler EventHandler) RunOption {
+	return func(o *runOptions) {
+		if handler != nil {
+			o.eventHandlers = append(o.eventHandlers, handler)
+		}
+	}
+}
+
+// ConsoleProgress returns an EventHandler printing one concise line per
+// event to stderr.
+func ConsoleProgress() EventHandler {
+	return consoleProgress(os.Stderr)
+}
+
+// ConsoleReport returns an EventHandler printing only the advice of
+// synthesis to stderr: lint findings and workflow notes. It is the handler
+// of Run when no handler is registered with WithEventHandler.
+func ConsoleReport() EventHandler {
+	return consoleReport(os.Stderr)
+}
+
+func consoleReport(w io.Writer) EventHandler {
+	return func(e Event) {
+		switch e := e.(type) {
+		case ValidationFinished:
+			printFindings(w, e.Findings)
+		case WorkflowNote:
+			printWorkflowNote(w, e)
+		}
+	}
+}
+
+func consoleProgress(w io.Writer) EventHandler {
+	return func(e Event) {
+		switch e := e.(type) {
+		case ResourceRegistered:
+			fmt.Fprintf(w, "registered %s %s%s\n", e.Kind, e.Name, scopeSuffix(e.Scope))
+		case ValidationStarted:
+			fmt.Fprintln(w, "validating...")
+		case ValidationFinished:
+			printFindings(w, e.Findings)
+			if e.Err != nil {
+				fmt.Fprintf(w, "validation failed (%d findings)\n", len(e.Findings))
+			} else {
+				fmt.Fprintf(w, "validated (%d findings)\n", len(e.Findings))
+			}
+		case ManifestWritten:
+			target := e.Path
+			if target == "" {
+				target = "sinks"
+			}
+			fmt.Fprintf(w, "wrote %s manifest to %s (%d bytes)%s\n", e.Kind, target, e.Size, scopeSuffix(e.Scope))
+		case WorkflowNote:
+			printWorkflowNote(w, e)
+		case SynthesisCompleted:
+			fmt.Fprintf(w, "synthesized %d organizations, %d agents, %d workflows, %d agent instances and %d workflow instances into %d manifests in %s\n",
+				e.Organizations, e.Agents, e.Workflows, e.AgentInstances, e.WorkflowInstances, e.Manifests, e.Duration.Round(time.Millisecond))
+		}
+	}
+}
+
+func printFindings(w io.Writer, findings []workflow.LintFinding) {
+	for _, f := range findings {
+		fmt.Fprintln(w, f.String())
+	}
+}
+
+func printWorkflowNote(w io.Writer, e WorkflowNote) {
+	fmt.Fprintf(w, "workflow %q: %s%s\n", e.Workflow, e.Message, scopeSuffix(e.Scope))
+}
+
+func scopeSuffix(scope string) string {
+	if scope == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [scope %s]", scope)
+}
+
+// eventEmitter delivers events to the handlers of a Run. It is shared by the
+// root context, its scopes and the contexts derived from it via WithValue.
+type eventEmitter struct {
+	mu        sync.Mutex
+	handlers  []EventHandler
+	manifests int
+}
+
+// emit delivers an event to the handlers. It is a no-op without handlers,
+// including on a nil emitter.
+func (e *eventEmitter) emit(event Event) {
+	if e == nil || len(e.handlers) == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, ok := event.(ManifestWritten); ok {
+		e.manifests++
+	}
+	for _, handler := range e.handlers {
+		handler(event)
+	}
+}
+
+// manifestCount returns the number of ManifestWritten events emitted.
+func (e *eventEmitter) manifestCount() int {
+	if e == nil {
+		return 0
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.manifests
+}
+
+// emit delivers an event to the handlers registered via WithEventHandler.
+func (c *Context) emit(event Event) {
+	c.events.emit(event)
+}
+
+// manifestSinks returns the sinks receiving the manifests of the context:
+// the file output when outputDir is set, then the registered sinks, then a
+// sink emitting ManifestWritten when event handlers are registered.
+func (c *Context) manifestSinks(outputDir string, sinks []ManifestSink) []ManifestSink {
+	var path string
+	var result []ManifestSink
+	if outputDir != "" {
+		result = append(result, c.fileSink(outputDir, func(p string) { path = p }))
+	}
+	result = append(result, sinks...)
+	if len(result) == 0 || c.events == nil || len(c.events.handlers) == 0 {
+		return result
+	}
+
+	return append(result, func(kind ManifestKind, data []byte) error {
+		c.emit(ManifestWritten{Kind: kind, Path: path, Size: len(data), Scope: c.ScopeName()})
+		path = ""
+		return nil
+	})
+}
+
+// emitSynthesisCompleted emits SynthesisCompleted with the resources of the
+// context and its scopes.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) emitSynthesisCompleted() {
+	if c.events == nil || len(c.events.handlers) == 0 {
+		return
+	}
+
+	event := c.resourceCounts()
+	event.Manifests = c.events.manifestCount()
+	if !c.startedAt.IsZero() {
+		event.Duration = time.Since(c.startedAt)
+	}
+	c.emit(event)
+}
+
+// resourceCounts returns a SynthesisCompleted with the resource counts of
+// the context and its scopes set.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) resourceCounts() SynthesisCompleted {
+	counts := SynthesisCompleted{
+		Organizations:     len(c.organizations),
+		Agents:            len(c.agents),
+		Workflows:         len(c.workflows),
+		AgentInstances:    len(c.agentInstances),
+		WorkflowInstances: len(c.workflowInstances),
+	}
+	for _, scope := range c.scopes {
+		scope.mu.RLock()
+		counts.Organizations += len(scope.organizations)
+		counts.Agents += len(scope.agents)
+		counts.Workflows += len(scope.workflows)
+		counts.AgentInstances += len(scope.agentInstances)
+		counts.WorkflowInstances += len(scope.workflowInstances)
+		scope.mu.RUnlock()
+	}
+	return counts
+}
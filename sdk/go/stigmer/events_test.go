@@ -0,0 +1,160 @@
+package stigmer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// eventSummary formats an event without its non-deterministic fields.
+func eventSummary(e Event) string {
+	switch e := e.(type) {
+	case ResourceRegistered:
+		return fmt.Sprintf("registered %s %s %s", e.Kind, e.Name, e.Scope)
+	case ValidationStarted:
+		return "validation started"
+	case ValidationFinished:
+		return fmt.Sprintf("validation finished %v", e.Err)
+	case ManifestWritten:
+		return fmt.Sprintf("manifest %s %s %s", e.Kind, filepath.Base(e.Path), e.Scope)
+	case WorkflowNote:
+		return fmt.Sprintf("note %s: %s", e.Workflow, e.Message)
+	case SynthesisCompleted:
+		return fmt.Sprintf("completed %d agents %d manifests", e.Agents, e.Manifests)
+	}
+	return fmt.Sprintf("unknown %T", e)
+}
+
+func TestRunWithOptions_EventHandler(t *testing.T) {
+	outDir := t.TempDir()
+	t.Setenv("STIGMER_OUT_DIR", outDir)
+
+	var events []string
+	err := RunWithOptions(func(ctx *Context) error {
+		registerTestAgent(ctx, "code-reviewer")
+		registerTestAgent(ctx.Scope("team-a"), "sec-reviewer")
+		return nil
+	}, WithEventHandler(func(e Event) {
+		if m, ok := e.(ManifestWritten); ok && m.Size == 0 {
+			t.Errorf("ManifestWritten %s has no size", m.Kind)
+		}
+		events = append(events, eventSummary(e))
+	}))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	want := []string{
+		"registered agent code-reviewer ",
+		"registered agent sec-reviewer team-a",
+		"validation started",
+		"validation finished <nil>",
+		"manifest agent agent-0.pb ",
+		"manifest dependencies dependencies.json ",
+		"manifest agent agent-0.pb team-a",
+		"manifest dependencies dependencies.json team-a",
+		"completed 2 agents 4 manifests",
+	}
+	if got := strings.Join(events, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("events:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestRunWithOptions_EventHandlerValidationFailed(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+	t.Setenv("EVENTS_TEST_REQUIRED", "")
+
+	var finished *ValidationFinished
+	completed := false
+	err := RunWithOptions(func(ctx *Context) error {
+		ctx.RequireString("EVENTS_TEST_REQUIRED")
+		return nil
+	}, WithEventHandler(func(e Event) {
+		switch e := e.(type) {
+		case ValidationFinished:
+			finished = &e
+		case SynthesisCompleted:
+			completed = true
+		}
+	}))
+	if err == nil {
+		t.Fatal("RunWithOptions() error = nil, want missing configuration error")
+	}
+
+	if finished == nil || !errors.Is(err, finished.Err) {
+		t.Errorf("ValidationFinished = %+v, want the synthesis error", finished)
+	}
+	if completed {
+		t.Error("SynthesisCompleted emitted after failed validation")
+	}
+}
+
+func TestRunWithOptions_WorkflowNote(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", t.TempDir())
+
+	var notes []string
+	err := RunWithOptions(func(ctx *Context) error {
+		newLintTestWorkflow(t, ctx)
+		return nil
+	}, WithEventHandler(func(e Event) {
+		if note, ok := e.(WorkflowNote); ok {
+			notes = append(notes, eventSummary(note))
+		}
+	}))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	want := []string{
+		"note health-check: 1 of 2 tasks export full outputs (fetch); " +
+			"no exports are narrowed, consider task.Exports(...) for large payloads",
+	}
+	if got := strings.Join(notes, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("notes:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestConsoleProgress(t *testing.T) {
+	var buf bytes.Buffer
+	handler := consoleProgress(&buf)
+
+	handler(ResourceRegistered{Kind: ManifestKindWorkflow, Name: "daily-sync", Scope: "team-a"})
+	handler(ManifestWritten{Kind: ManifestKindWorkflow, Size: 42})
+	handler(WorkflowNote{Workflow: "daily-sync", Message: "version normalized", Scope: "team-a"})
+
+	want := "registered workflow daily-sync [scope team-a]\n" +
+		"wrote workflow manifest to sinks (42 bytes)\n" +
+		"workflow \"daily-sync\": version normalized [scope team-a]\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestConsoleReport(t *testing.T) {
+	var buf bytes.Buffer
+	handler := consoleReport(&buf)
+
+	handler(ResourceRegistered{Kind: ManifestKindWorkflow, Name: "daily-sync"})
+	handler(ValidationFinished{Findings: []workflow.LintFinding{{
+		RuleID:   workflow.LintRuleInsecureHTTP,
+		Severity: workflow.LintSeverityWarning,
+		Workflow: "daily-sync",
+		Task:     "fetch",
+		Message:  "plain HTTP endpoint",
+	}}})
+	handler(ManifestWritten{Kind: ManifestKindWorkflow, Size: 42})
+	handler(WorkflowNote{Workflow: "daily-sync", Message: "1 of 2 tasks export full outputs (fetch)"})
+	handler(SynthesisCompleted{Workflows: 1, Manifests: 1})
+
+	// Progress is left out, advice is printed
+	want := "warning: workflow \"daily-sync\" task \"fetch\": plain HTTP endpoint [" + workflow.LintRuleInsecureHTTP + "]\n" +
+		"workflow \"daily-sync\": 1 of 2 tasks export full outputs (fetch)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package stigmer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// ErrInvalidHostPolicy is returned when synthesis fails because a pattern
+// given to WithHostPolicy is not a valid host glob, IP address or CIDR range.
+var ErrInvalidHostPolicy = errors.New("invalid host policy")
+
+// LintRuleDeniedHost reports HTTP calls to literal endpoints whose host the
+// project's host policy denies. The workflow runner enforces its own host
+// policy; the rule catches calls that would fail with POLICY_DENIED before
+// they are deployed.
+const LintRuleDeniedHost = "denied-host"
+
+// HostPolicy is the outbound host policy of a project, mirroring the
+// HTTP_ALLOWED_HOSTS and HTTP_DENIED_HOSTS of the workflow runner. Each
+// pattern is a host glob ("*.example.com"), an IP address or a CIDR range
+// ("10.0.0.0/8").
+type HostPolicy struct {
+	// Allow lists the hosts HTTP calls may reach. Empty allows every host
+	// that is not denied.
+	Allow []string
+
+	// Deny lists the hosts HTTP calls must not reach, whatever Allow says.
+	Deny []string
+}
+
+// WithHostPolicy reports HTTP calls to hosts the policy denies as
+// denied-host warnings during synthesis, whether or not linting is enabled
+// with WithLint. Like lint findings, they are delivered in the
+// ValidationFinished event and printed by the handler: ConsoleReport by
+// default, or those registered with WithEventHandler.
+//
+// Only literal endpoints are checked: endpoints built from runtime
+// expressions are only known when the task runs, and host names are not
+// resolved, so CIDR ranges only match IP address hosts. Findings can be
+// suppressed per workflow or task with SuppressLint(LintRuleDeniedHost).
+//
+// Example:
+//
+//	err := stigmer.RunWithOptions(fn, stigmer.WithHostPolicy(stigmer.HostPolicy{
+//	    Deny: []string{"169.254.169.254", "*.internal"},
+//	}))
+func WithHostPolicy(policy HostPolicy) RunOption {
+	return func(o *runOptions) {
+		o.hostPolicy = &policy
+	}
+}
+
+// lintHostPolicy returns the denied-host findings of the workflows of the
+// context and its scopes.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) lintHostPolicy() ([]workflow.LintFinding, error) {
+	if c.hostPolicy == nil {
+		return nil, nil
+	}
+	if err := c.hostPolicy.validate(); err != nil {
+		return nil, err
+	}
+
+	rule := workflow.NewLintRule(LintRuleDeniedHost, workflow.LintSeverityWarning, c.hostPolicy.check)
+	var findings []workflow.LintFinding
+	for _, wf := range c.workflows {
+		findings = append(findings, wf.Lint(rule)...)
+	}
+	for _, scope := range c.scopes {
+		scope.mu.RLock()
+		workflows := scope.workflows
+		scope.mu.RUnlock()
+		for _, wf := range workflows {
+			findings = append(findings, wf.Lint(rule)...)
+		}
+	}
+	return findings, nil
+}
+
+// validate checks the patterns of the policy.
+func (p *HostPolicy) validate() error {
+	for _, pattern := range append(append([]string{}, p.Allow...), p.Deny...) {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if strings.Contains(pattern, "/") {
+			if _, _, err := net.ParseCIDR(pattern); err != nil {
+				return fmt.Errorf("%w: %q is not a valid CIDR range", ErrInvalidHostPolicy, pattern)
+			}
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: %q is not a valid host pattern", ErrInvalidHostPolicy, pattern)
+		}
+	}
+	return nil
+}
+
+// check reports the HTTP calls of a workflow to literal endpoints whose host
+// the policy denies.
+func (p *HostPolicy) check(w *workflow.Workflow) []workflow.LintFinding {
+	var findings []workflow.LintFinding
+	for _, task := range w.Tasks {
+		cfg, ok := task.Config.(*workflow.HttpCallTaskConfig)
+		if !ok || cfg.Endpoint == nil {
+			continue
+		}
+		uri := cfg.Endpoint.UriExpression()
+		if strings.Contains(uri, "${") {
+			continue
+		}
+		u, err := url.Parse(uri)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		if reason := p.denies(u.Hostname()); reason != "" {
+			findings = append(findings, workflow.LintFinding{
+				Task:    task.Name,
+				Message: fmt.Sprintf("endpoint host %q %s; the runner fails the call with POLICY_DENIED", u.Hostname(), reason),
+			})
+		}
+	}
+	return findings
+}
+
+// denies returns why the policy denies host, or "" if it allows it.
+func (p *HostPolicy) denies(host string) string {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, pattern := range p.Deny {
+		if hostMatches(pattern, host) {
+			return fmt.Sprintf("matches denied pattern %q", pattern)
+		}
+	}
+	if len(p.Allow) == 0 {
+		return ""
+	}
+	for _, pattern := range p.Allow {
+		if hostMatches(pattern, host) {
+			return ""
+		}
+		// Host names may resolve into allowed ranges
+		if strings.Contains(pattern, "/") && net.ParseIP(host) == nil {
+			return ""
+		}
+	}
+	return "does not match any allowed pattern"
+}
+
+// hostMatches reports whether a host glob, IP address or CIDR range matches
+// host.
+func hostMatches(pattern, host string) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "" {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if strings.Contains(pattern, "/") {
+		_, cidr, err := net.ParseCIDR(pattern)
+		return err == nil && ip != nil && cidr.Contains(ip)
+	}
+	if patternIP := net.ParseIP(pattern); patternIP != nil {
+		return ip != nil && patternIP.Equal(ip)
+	}
+	ok, _ := path.Match(pattern, host)
+	return ok
+}
@@ -0,0 +1,106 @@
+package stigmer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+func TestWithHostPolicy_WarnsOnDeniedHosts(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var captured *Context
+	err := RunWithOptions(func(ctx *Context) error {
+		captured = ctx
+		wf, err := workflow.New(ctx, "ops/fetch", &workflow.WorkflowArgs{Version: "1.0.0"})
+		if err != nil {
+			return err
+		}
+		wf.HttpGet("metadata", "http://169.254.169.254/latest/meta-data", nil)
+		wf.HttpGet("internal", "https://billing.corp.internal/invoices", nil)
+		wf.HttpGet("public", "https://api.example.com/status", nil)
+		wf.HttpGet("dynamic", "${ $env.API_URL }", nil)
+		wf.HttpGet("suppressed", "https://admin.corp.internal/", nil).SuppressLint(LintRuleDeniedHost)
+		return nil
+	}, WithHostPolicy(HostPolicy{Deny: []string{"169.254.0.0/16", "*.corp.internal"}}))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	findings := captured.LintFindings()
+	got := map[string]bool{}
+	for _, f := range findings {
+		if f.RuleID != LintRuleDeniedHost || f.Severity != workflow.LintSeverityWarning {
+			t.Errorf("unexpected finding %v", f)
+		}
+		got[f.Task] = true
+	}
+	if len(findings) != 2 || !got["metadata"] || !got["internal"] {
+		t.Errorf("findings = %v, want denied-host warnings for metadata and internal", findings)
+	}
+}
+
+func TestWithHostPolicy_FindingsEvent(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var reported []workflow.LintFinding
+	err := RunWithOptions(func(ctx *Context) error {
+		wf, err := workflow.New(ctx, "ops/fetch", &workflow.WorkflowArgs{Version: "1.0.0"})
+		if err != nil {
+			return err
+		}
+		wf.HttpGet("metadata", "http://169.254.169.254/latest/meta-data", nil)
+		return nil
+	}, WithHostPolicy(HostPolicy{Deny: []string{"169.254.0.0/16"}}), WithEventHandler(func(e Event) {
+		if finished, ok := e.(ValidationFinished); ok {
+			reported = finished.Findings
+		}
+	}))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	// Reported with linting off, through the handler
+	if len(reported) != 1 || reported[0].RuleID != LintRuleDeniedHost {
+		t.Errorf("ValidationFinished findings = %v, want one denied-host warning", reported)
+	}
+}
+
+func TestWithHostPolicy_Allowlist(t *testing.T) {
+	policy := HostPolicy{Allow: []string{"*.example.com", "10.0.0.0/8"}, Deny: []string{"admin.example.com"}}
+
+	tests := []struct {
+		host   string
+		denied bool
+	}{
+		{host: "api.example.com"},
+		{host: "admin.example.com", denied: true},
+		{host: "api.other.com"},
+		{host: "10.1.2.3"},
+		{host: "192.168.1.1", denied: true},
+	}
+	// Host names may resolve into the allowed range, so only IPs are denied
+	for _, tt := range tests {
+		if got := policy.denies(tt.host) != ""; got != tt.denied {
+			t.Errorf("denies(%q) = %v, want %v", tt.host, got, tt.denied)
+		}
+	}
+
+	strict := HostPolicy{Allow: []string{"*.example.com"}}
+	if strict.denies("api.other.com") == "" {
+		t.Error("denies(api.other.com) allowed a host outside the allowlist")
+	}
+}
+
+func TestWithHostPolicy_InvalidPattern(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	err := RunWithOptions(func(ctx *Context) error {
+		_, err := workflow.New(ctx, "ops/fetch", &workflow.WorkflowArgs{Version: "1.0.0"})
+		return err
+	}, WithHostPolicy(HostPolicy{Deny: []string{"10.0.0.0/40"}}))
+	if !errors.Is(err, ErrInvalidHostPolicy) {
+		t.Fatalf("RunWithOptions() error = %v, want ErrInvalidHostPolicy", err)
+	}
+}
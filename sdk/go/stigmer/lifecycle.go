@@ -0,0 +1,173 @@
+package stigmer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrContextClosed is returned when a Context is used after the Run call that
+// created it has returned, for example from a goroutine that outlived Run or
+// a Context stored in a global variable.
+var ErrContextClosed = errors.New("stigmer context used after Run returned")
+
+// ErrNestedRun is returned when Run, RunWithContext or RunWithOptions is
+// called from inside the function passed to another Run. Use the Context
+// passed to the outer function, or Scope for a separate output directory.
+var ErrNestedRun = errors.New("stigmer.Run called inside another stigmer.Run")
+
+// ErrPanic is returned when the function passed to Run, RunWithContext or
+// RunWithOptions panics. The returned error is a *PanicError.
+var ErrPanic = errors.New("stigmer.Run function panicked")
+
+// PanicError reports a panic in the function passed to Run, such as a method
+// called on a nil *workflow.Workflow whose constructor error was ignored.
+// It matches ErrPanic with errors.Is; when the panic value is an error,
+// Unwrap returns it.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value interface{}
+
+	// Location is the innermost call site ("file.go:line") outside the SDK,
+	// which is where the caller's code misused it.
+	Location string
+
+	// Stack is the stack trace of the panic.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v at %s: %v", ErrPanic, e.Location, e.Value)
+}
+
+// Is reports whether target is ErrPanic.
+func (e *PanicError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// callRunFunction calls the function passed to Run, converting a panic into
+// a *PanicError.
+func callRunFunction(fn func(*Context) error, ctx *Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{
+				Value:    r,
+				Location: panicLocation(),
+				Stack:    debug.Stack(),
+			}
+		}
+	}()
+	return fn(ctx)
+}
+
+// sdkPackagePrefix prefixes the function names of the SDK packages.
+const sdkPackagePrefix = "github.com/stigmer/stigmer/sdk/go/"
+
+// panicLocation returns the innermost frame of the panicking goroutine that
+// is neither in the Go runtime nor in the SDK. It must be called from the
+// deferred function that recovered the panic.
+func panicLocation() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !isSDKFrame(frame) {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return "unknown location"
+		}
+	}
+}
+
+// isSDKFrame reports whether frame belongs to the Go runtime or to the SDK.
+// Tests and examples of the SDK count as callers' code.
+func isSDKFrame(frame runtime.Frame) bool {
+	if strings.HasPrefix(frame.Function, "runtime.") {
+		return true
+	}
+	if !strings.HasPrefix(frame.Function, sdkPackagePrefix) || strings.HasSuffix(frame.File, "_test.go") {
+		return false
+	}
+	return !strings.HasPrefix(frame.Function, sdkPackagePrefix+"examples")
+}
+
+// activeRuns holds the goroutines currently executing a Run function.
+var activeRuns = struct {
+	sync.Mutex
+	goroutines map[uint64]bool
+}{goroutines: make(map[uint64]bool)}
+
+// enterRun records that the calling goroutine executes a Run function and
+// returns the function that removes the record. It fails with ErrNestedRun
+// if the goroutine is already inside one.
+func enterRun() (func(), error) {
+	id := goroutineID()
+
+	activeRuns.Lock()
+	defer activeRuns.Unlock()
+
+	if activeRuns.goroutines[id] {
+		return nil, ErrNestedRun
+	}
+	activeRuns.goroutines[id] = true
+
+	return func() {
+		activeRuns.Lock()
+		defer activeRuns.Unlock()
+		delete(activeRuns.goroutines, id)
+	}, nil
+}
+
+// goroutineID returns the ID of the calling goroutine, parsed from the
+// "goroutine N [running]:" header of its stack trace.
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := buf[:runtime.Stack(buf[:], false)]
+	header = bytes.TrimPrefix(header, []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i >= 0 {
+		header = header[:i]
+	}
+	id, _ := strconv.ParseUint(string(header), 10, 64)
+	return id
+}
+
+// CheckOpen returns an error wrapping ErrContextClosed once the Run call that
+// created the context has returned.
+//
+// agent.New, workflow.New, agentinstance.New and workflowinstance.New call it
+// before registering a resource, so resources created after Run returned fail
+// instead of being silently dropped from synthesis.
+func (c *Context) CheckOpen() error {
+	if c.closed != nil && c.closed.Load() {
+		return fmt.Errorf("%w: resources must be created inside the function passed to Run", ErrContextClosed)
+	}
+	return nil
+}
+
+// mustBeOpen panics with an error wrapping ErrContextClosed if the context is
+// closed. It guards the methods that cannot return an error, such as
+// SetString.
+func (c *Context) mustBeOpen(op string) {
+	if err := c.CheckOpen(); err != nil {
+		panic(fmt.Errorf("stigmer: %s: %w", op, err))
+	}
+}
+
+// close marks the context, its scopes and the contexts derived from it via
+// WithValue as closed.
+func (c *Context) close() {
+	c.closed.Store(true)
+}
@@ -0,0 +1,171 @@
+package stigmer
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/agentinstance"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+func TestRun_ClosesContext(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var leaked, leakedScope *Context
+	err := RunWithOptions(func(ctx *Context) error {
+		leaked = ctx
+		leakedScope = ctx.Scope("team-a")
+		if err := ctx.CheckOpen(); err != nil {
+			t.Errorf("CheckOpen() inside Run = %v, want nil", err)
+		}
+		return nil
+	}, AllowEmpty())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	for name, ctx := range map[string]*Context{"root": leaked, "scope": leakedScope, "derived": leaked.WithValue("k", "v")} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := agent.New(ctx, "late-agent", &agent.AgentArgs{Instructions: "Review code and suggest improvements"}); !errors.Is(err, ErrContextClosed) {
+				t.Errorf("agent.New() error = %v, want ErrContextClosed", err)
+			}
+			if _, err := workflow.New(ctx, "ops/late-workflow", &workflow.WorkflowArgs{Version: "1.0.0"}); !errors.Is(err, ErrContextClosed) {
+				t.Errorf("workflow.New() error = %v, want ErrContextClosed", err)
+			}
+			if _, err := agentinstance.New(ctx, "late-instance", &agentinstance.Args{}); !errors.Is(err, ErrContextClosed) {
+				t.Errorf("agentinstance.New() error = %v, want ErrContextClosed", err)
+			}
+
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, ErrContextClosed) {
+					t.Errorf("SetString() panic = %v, want ErrContextClosed", err)
+				}
+			}()
+			ctx.SetString("late", "value")
+		})
+	}
+}
+
+func TestRun_ClosesContextOnError(t *testing.T) {
+	var leaked *Context
+	_ = Run(func(ctx *Context) error {
+		leaked = ctx
+		return errors.New("boom")
+	})
+
+	if err := leaked.CheckOpen(); !errors.Is(err, ErrContextClosed) {
+		t.Errorf("CheckOpen() = %v, want ErrContextClosed", err)
+	}
+}
+
+func TestRun_Nested(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var nestedErr error
+	err := RunWithOptions(func(ctx *Context) error {
+		nestedErr = Run(func(*Context) error {
+			t.Error("nested Run function was called")
+			return nil
+		})
+		return nil
+	}, AllowEmpty())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !errors.Is(nestedErr, ErrNestedRun) {
+		t.Errorf("nested Run() error = %v, want ErrNestedRun", nestedErr)
+	}
+
+	// Sequential runs are not nested
+	if err := RunWithOptions(func(*Context) error { return nil }, AllowEmpty()); err != nil {
+		t.Errorf("Run() after Run() error = %v", err)
+	}
+}
+
+func TestRun_Concurrent(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = Run(func(ctx *Context) error {
+				// Register from several goroutines at once
+				var inner sync.WaitGroup
+				for j := 0; j < 4; j++ {
+					inner.Add(1)
+					go func() {
+						defer inner.Done()
+						ctx.SetInt("n", j)
+						_, err := workflow.New(ctx, "ops/concurrent", &workflow.WorkflowArgs{Version: "1.0.0"})
+						if err != nil {
+							t.Errorf("workflow.New() error = %v", err)
+						}
+					}()
+				}
+				inner.Wait()
+				if got := len(ctx.Workflows()); got != 4 {
+					t.Errorf("len(Workflows()) = %d, want 4", got)
+				}
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Run() #%d error = %v", i, err)
+		}
+	}
+}
+
+func TestRun_Panic(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	t.Run("nil workflow", func(t *testing.T) {
+		err := Run(func(ctx *Context) error {
+			var wf *workflow.Workflow // e.g. workflow.New error ignored
+			wf.HttpGet("fetch", "https://api.example.com", nil)
+			return nil
+		})
+
+		var panicErr *PanicError
+		if !errors.As(err, &panicErr) || !errors.Is(err, ErrPanic) {
+			t.Fatalf("Run() error = %v, want a *PanicError", err)
+		}
+		if !strings.HasPrefix(panicErr.Location, "lifecycle_test.go:") {
+			t.Errorf("Location = %q, want the line in lifecycle_test.go", panicErr.Location)
+		}
+		if len(panicErr.Stack) == 0 {
+			t.Error("Stack is empty")
+		}
+	})
+
+	t.Run("error value", func(t *testing.T) {
+		boom := errors.New("boom")
+		err := Run(func(ctx *Context) error {
+			panic(boom)
+		})
+		if !errors.Is(err, boom) || !errors.Is(err, ErrPanic) {
+			t.Errorf("Run() error = %v, want ErrPanic wrapping the panic value", err)
+		}
+	})
+
+	t.Run("context closed", func(t *testing.T) {
+		var leaked *Context
+		_ = Run(func(ctx *Context) error {
+			leaked = ctx
+			panic("boom")
+		})
+		if err := leaked.CheckOpen(); !errors.Is(err, ErrContextClosed) {
+			t.Errorf("CheckOpen() = %v, want ErrContextClosed", err)
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package stigmer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// LintMode controls the lint pass run during synthesis.
+type LintMode int
+
+const (
+	// LintOff disables linting (the default).
+	LintOff LintMode = iota
+
+	// LintWarn reports findings on stderr without failing synthesis.
+	LintWarn
+
+	// LintError reports findings and fails synthesis when any finding has
+	// error severity. Warnings are reported but do not fail synthesis.
+	LintError
+)
+
+// ErrLintFailed is returned when synthesis fails because of lint findings.
+var ErrLintFailed = errors.New("lint findings with error severity")
+
+// LintFailedError carries the findings that failed synthesis.
+// It matches ErrLintFailed with errors.Is.
+type LintFailedError struct {
+	// Findings lists all findings, including warnings.
+	Findings []workflow.LintFinding
+}
+
+func (e *LintFailedError) Error() string {
+	lines := make([]string, len(e.Findings))
+	for i, f := range e.Findings {
+		lines[i] = f.String()
+	}
+	return fmt.Sprintf("%v:\n  %s", ErrLintFailed, strings.Join(lines, "\n  "))
+}
+
+func (e *LintFailedError) Unwrap() error {
+	return ErrLintFailed
+}
+
+// WithLint runs the lint pass over all workflows during synthesis.
+//
+// Findings are reported with their workflow and task names in the
+// ValidationFinished event; ConsoleReport, the handler of Run without
+// WithEventHandler, prints them to stderr. With
+// LintError, findings of error severity also fail synthesis with a
+// LintFailedError. Rules can be suppressed per workflow or task with
+// SuppressLint.
+//
+// Example:
+//
+//	err := stigmer.RunWithOptions(fn, stigmer.WithLint(stigmer.LintError))
+func WithLint(mode LintMode) RunOption {
+	return func(o *runOptions) {
+		o.lintMode = mode
+	}
+}
+
+// WithLintRules replaces the default lint rules (workflow.DefaultLintRules).
+// It has no effect unless linting is enabled with WithLint.
+//
+// Example:
+//
+//	rules := append(workflow.DefaultLintRules(), myRule)
+//	err := stigmer.RunWithOptions(fn,
+//	    stigmer.WithLint(stigmer.LintWarn),
+//	    stigmer.WithLintRules(rules...),
+//	)
+func WithLintRules(rules ...workflow.LintRule) RunOption {
+	return func(o *runOptions) {
+		o.lintRules = rules
+	}
+}
+
+// LintFindings returns the findings of the lint pass run during synthesis,
+// including those of scoped contexts. Tests can capture the Context inside
+// Run and inspect the findings once it returns.
+func (c *Context) LintFindings() []workflow.LintFinding {
+	if c.root != nil {
+		return c.root.LintFindings()
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]workflow.LintFinding, len(c.lintFindings))
+	copy(result, c.lintFindings)
+	return result
+}
+
+// lint runs the lint pass over the workflows of the context and its scopes,
+// and the resource policies over its agents and workflows.
+// rootOutDir is STIGMER_OUT_DIR ("" when unset); manifests previously written
+// there are used by the version-not-bumped rule.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) lint(rootOutDir string) error {
+	// Host policy findings are reported even when linting is off
+	findings, err := c.lintHostPolicy()
+	if err != nil {
+		return validation.NewSynthesisErrorWithCause("lint", "invalid host policy", err)
+	}
+	// Resource policy findings are reported and fail synthesis whatever the
+	// lint mode
+	policyFindings := c.lintResourcePolicies()
+	findings = append(findings, policyFindings...)
+	if c.lintMode != LintOff {
+		findings = append(findings, lintWorkflows(c.workflows, c.lintRules, rootOutDir)...)
+		for _, scope := range c.scopes {
+			scope.mu.RLock()
+			workflows := scope.workflows
+			scope.mu.RUnlock()
+
+			outDir := ""
+			if rootOutDir != "" {
+				outDir = scope.scopeOutputDir(rootOutDir)
+			}
+			findings = append(findings, lintWorkflows(workflows, c.lintRules, outDir)...)
+		}
+		findings = append(findings, c.lintMissingOrg()...)
+		findings = append(findings, c.lintVariableRedefinitions()...)
+	}
+	c.lintFindings = findings
+
+	// Findings are reported by the event handlers with ValidationFinished
+	failed := false
+	for _, f := range findings {
+		if f.Severity == workflow.LintSeverityError {
+			failed = true
+		}
+	}
+
+	var violations []workflow.LintFinding
+	for _, f := range policyFindings {
+		if f.Severity == workflow.LintSeverityError {
+			violations = append(violations, f)
+		}
+	}
+	if len(violations) > 0 {
+		return validation.NewSynthesisErrorWithCause(
+			"lint",
+			fmt.Sprintf("%d resource policy violations", len(violations)),
+			&ResourcePolicyError{Findings: violations},
+		)
+	}
+
+	if failed && c.lintMode == LintError {
+		return validation.NewSynthesisErrorWithCause(
+			"lint",
+			fmt.Sprintf("%d lint findings", len(findings)),
+			&LintFailedError{Findings: findings},
+		)
+	}
+	return nil
+}
+
+// lintWorkflows runs the lint rules against workflows. Without custom rules,
+// the default rules are used with the manifests previously written to outDir.
+func lintWorkflows(workflows []*workflow.Workflow, rules []workflow.LintRule, outDir string) []workflow.LintFinding {
+	if len(workflows) == 0 {
+		return nil
+	}
+	if len(rules) == 0 {
+		rules = workflow.DefaultLintRules(previousWorkflowManifests(outDir)...)
+	}
+
+	var findings []workflow.LintFinding
+	for _, wf := range workflows {
+		findings = append(findings, wf.Lint(rules...)...)
+	}
+	return findings
+}
+
+// previousWorkflowManifests reads the workflow manifests left in dir by a
+// previous synthesis. Unreadable manifests are skipped.
+func previousWorkflowManifests(dir string) []*workflowv1.Workflow {
+	if dir == "" {
+		return nil
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "workflow-*.pb"))
+	var manifests []*workflowv1.Workflow
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var wf workflowv1.Workflow
+		if err := proto.Unmarshal(data, &wf); err != nil {
+			continue
+		}
+		manifests = append(manifests, &wf)
+	}
+	return manifests
+}
@@ -0,0 +1,116 @@
+package stigmer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// newLintTestWorkflow registers a workflow calling an http:// endpoint and
+// an agent without timeout.
+func newLintTestWorkflow(t *testing.T, ctx *Context) *workflow.Workflow {
+	t.Helper()
+	wf, err := workflow.New(ctx, "ops/health-check", &workflow.WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("workflow.New() error = %v", err)
+	}
+	fetch := wf.HttpGet("fetch", "http://api.example.com/status", nil)
+	wf.CallAgent("summarize", &workflow.AgentCallArgs{Agent: "summarizer", Message: "Summarize " + fetch.Field("status").Expression()})
+	return wf
+}
+
+func TestRunWithOptions_Lint(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", t.TempDir())
+
+	tests := []struct {
+		name         string
+		mode         LintMode
+		suppress     bool
+		wantErr      bool
+		wantFindings int
+	}{
+		{name: "off", mode: LintOff},
+		{name: "warn", mode: LintWarn, wantFindings: 2},
+		{name: "error", mode: LintError, wantErr: true, wantFindings: 2},
+		{name: "error with suppression", mode: LintError, suppress: true, wantFindings: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var captured *Context
+			err := RunWithOptions(func(ctx *Context) error {
+				captured = ctx
+				wf := newLintTestWorkflow(t, ctx)
+				if tt.suppress {
+					wf.SuppressLint(workflow.LintRuleInsecureHTTP)
+				}
+				return nil
+			}, WithLint(tt.mode))
+
+			if gotErr := errors.Is(err, ErrLintFailed); gotErr != tt.wantErr {
+				t.Errorf("RunWithOptions() error = %v, want lint failure %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("RunWithOptions() error = %v", err)
+			}
+			if got := len(captured.LintFindings()); got != tt.wantFindings {
+				t.Errorf("LintFindings() = %v, want %d findings", captured.LintFindings(), tt.wantFindings)
+			}
+
+			var lintErr *LintFailedError
+			if tt.wantErr && (!errors.As(err, &lintErr) || len(lintErr.Findings) != tt.wantFindings) {
+				t.Errorf("error = %v, want LintFailedError with %d findings", err, tt.wantFindings)
+			}
+		})
+	}
+}
+
+func TestRunWithOptions_LintRules(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	rule := workflow.NewLintRule("no-agents", workflow.LintSeverityError,
+		func(wf *workflow.Workflow) []workflow.LintFinding {
+			var findings []workflow.LintFinding
+			for _, task := range wf.Tasks {
+				if task.Kind == workflow.TaskKindAgentCall {
+					findings = append(findings, workflow.LintFinding{Task: task.Name, Message: "agent call"})
+				}
+			}
+			return findings
+		})
+
+	err := RunWithOptions(func(ctx *Context) error {
+		newLintTestWorkflow(t, ctx)
+		return nil
+	}, WithLint(LintError), WithLintRules(rule))
+
+	var lintErr *LintFailedError
+	if !errors.As(err, &lintErr) {
+		t.Fatalf("RunWithOptions() error = %v, want LintFailedError", err)
+	}
+	if len(lintErr.Findings) != 1 || lintErr.Findings[0].Task != "summarize" {
+		t.Errorf("Findings = %v, want no-agents on summarize", lintErr.Findings)
+	}
+}
+
+func TestRunWithOptions_LintFindingsEvent(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var reported []workflow.LintFinding
+	err := RunWithOptions(func(ctx *Context) error {
+		newLintTestWorkflow(t, ctx)
+		return nil
+	}, WithLint(LintWarn), WithEventHandler(func(e Event) {
+		if finished, ok := e.(ValidationFinished); ok {
+			reported = finished.Findings
+		}
+	}))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	// Findings are left to the handlers to report
+	if len(reported) != 2 {
+		t.Errorf("ValidationFinished findings = %v, want the 2 lint findings", reported)
+	}
+}
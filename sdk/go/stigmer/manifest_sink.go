@@ -0,0 +1,166 @@
+package stigmer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// ManifestKind identifies the type of a synthesized manifest passed to a ManifestSink.
+type ManifestKind string
+
+const (
+	// ManifestKindOrganization is a binary-encoded Organization proto.
+	ManifestKindOrganization ManifestKind = "organization"
+
+	// ManifestKindAgent is a binary-encoded Agent proto.
+	ManifestKindAgent ManifestKind = "agent"
+
+	// ManifestKindWorkflow is a binary-encoded Workflow proto.
+	ManifestKindWorkflow ManifestKind = "workflow"
+
+	// ManifestKindAgentInstance is a binary-encoded AgentInstance proto.
+	ManifestKindAgentInstance ManifestKind = "agentinstance"
+
+	// ManifestKindWorkflowInstance is a binary-encoded WorkflowInstance proto.
+	ManifestKindWorkflowInstance ManifestKind = "workflowinstance"
+
+	// ManifestKindEnvironment is a binary-encoded Environment proto holding
+	// the bindings of an agent or workflow instance. It is emitted just
+	// before the instance that references it.
+	ManifestKindEnvironment ManifestKind = "environment"
+
+	// ManifestKindSkill is a binary-encoded Skill proto of an inline skill
+	// added with agent.AddSkill. Skills pushed with `stigmer skill push` are
+	// only referenced, not emitted.
+	ManifestKindSkill ManifestKind = "skill"
+
+	// ManifestKindConfig is the JSON-encoded list of resolved context
+	// variables (see ConfigValues). It is emitted just before the dependency
+	// graph, and only by contexts that have variables.
+	ManifestKindConfig ManifestKind = "config"
+
+	// ManifestKindDependencies is the JSON-encoded resource dependency graph.
+	// It is always emitted last, after all other manifests.
+	ManifestKindDependencies ManifestKind = "dependencies"
+)
+
+// ManifestSink receives each synthesized manifest.
+//
+// Manifests are emitted in creation order: the agents' inline skills first,
+// then agents, then workflows, then agent instances and workflow instances
+// (each preceded by its environment), then the resolved configuration, then
+// the dependency graph. Returning an error aborts synthesis.
+type ManifestSink func(kind ManifestKind, data []byte) error
+
+// FileManifestSink returns the default sink used when STIGMER_OUT_DIR is set.
+// It writes organization-{n}.pb, skill-{n}.pb, agent-{n}.pb, workflow-{n}.pb,
+// environment-{n}.pb, agentinstance-{n}.pb, workflowinstance-{n}.pb,
+// config.json and dependencies.json into outputDir,
+// numbering each kind in the order it is received.
+//
+// Each file is written to a temporary file in outputDir and renamed into
+// place, so readers never see a partially written manifest, even if the
+// process crashes or another synthesis writes the same directory.
+func FileManifestSink(outputDir string) ManifestSink {
+	return fileManifestSink(outputDir, time.Time{}, nil)
+}
+
+// fileManifestSink is FileManifestSink with an optional overwrite guard:
+// unless notAfter is zero, manifests synthesized after notAfter are not
+// overwritten (see FailIfManifestNewer). If written is non-nil, it is called
+// with the path of each manifest written.
+func fileManifestSink(outputDir string, notAfter time.Time, written func(path string)) ManifestSink {
+	counts := make(map[ManifestKind]int)
+
+	return func(kind ManifestKind, data []byte) error {
+		var filename string
+		switch kind {
+		case ManifestKindConfig:
+			filename = "config.json"
+		case ManifestKindDependencies:
+			filename = "dependencies.json"
+		default:
+			filename = fmt.Sprintf("%s-%d.pb", kind, counts[kind])
+			counts[kind]++
+		}
+
+		path := filepath.Join(outputDir, filename)
+		if !notAfter.IsZero() {
+			if err := checkManifestNotNewer(path, kind, notAfter); err != nil {
+				return err
+			}
+		}
+		if err := writeFileAtomic(path, data, 0644); err != nil {
+			return fmt.Errorf("failed to write manifest to %s: %w", path, err)
+		}
+		if written != nil {
+			written(path)
+		}
+		return nil
+	}
+}
+
+// RunOption configures RunWithOptions.
+type RunOption func(*runOptions)
+
+// runOptions holds the settings collected from RunOption values.
+type runOptions struct {
+	ctx       context.Context
+	sinks     []ManifestSink
+	lintMode  LintMode
+	lintRules []workflow.LintRule
+
+	hostPolicy       *HostPolicy
+	resourcePolicies []ResourcePolicy
+
+	sourceRevision    string
+	failIfNewerOnDisk bool
+	allowEmpty        bool
+
+	workDir string
+
+	eventHandlers []EventHandler
+}
+
+// WithManifestSink registers a sink that receives every synthesized manifest.
+//
+// Sinks are called in addition to the file output enabled by STIGMER_OUT_DIR.
+// When STIGMER_OUT_DIR is unset, manifests are delivered only to registered
+// sinks. The option may be given multiple times.
+//
+// Example:
+//
+//	err := stigmer.RunWithOptions(fn, stigmer.WithManifestSink(
+//	    func(kind stigmer.ManifestKind, data []byte) error {
+//	        return client.Push(string(kind), data)
+//	    },
+//	))
+func WithManifestSink(sink ManifestSink) RunOption {
+	return func(o *runOptions) {
+		if sink != nil {
+			o.sinks = append(o.sinks, sink)
+		}
+	}
+}
+
+// WithParentContext sets the parent context.Context, as RunWithContext does.
+func WithParentContext(ctx context.Context) RunOption {
+	return func(o *runOptions) {
+		o.ctx = ctx
+	}
+}
+
+// RunWithOptions executes a function with a new Context like Run, applying
+// the given options. Use it to receive manifests programmatically instead of
+// reading them back from STIGMER_OUT_DIR.
+func RunWithOptions(fn func(*Context) error, opts ...RunOption) error {
+	options := &runOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return run(options, fn)
+}
@@ -0,0 +1,280 @@
+package stigmer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
+	organizationv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/tenancy/organization/v1"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/agentinstance"
+	"github.com/stigmer/stigmer/sdk/go/environment"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"github.com/stigmer/stigmer/sdk/go/tenancy/organization"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+	"github.com/stigmer/stigmer/sdk/go/workflowinstance"
+)
+
+// registerTestAgent registers a minimal agent with the context.
+func registerTestAgent(ctx *Context, name string) {
+	ctx.RegisterAgent(&agent.Agent{
+		Name:         name,
+		Instructions: "Review code quality and report issues",
+	})
+}
+
+func TestRunWithOptions_ManifestSink(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var kinds []ManifestKind
+	sink := func(kind ManifestKind, data []byte) error {
+		if len(data) == 0 {
+			t.Errorf("sink received empty %s manifest", kind)
+		}
+		kinds = append(kinds, kind)
+		return nil
+	}
+
+	err := RunWithOptions(func(ctx *Context) error {
+		registerTestAgent(ctx, "code-reviewer")
+		registerTestAgent(ctx, "sec-reviewer")
+		return nil
+	}, WithManifestSink(sink))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	want := []ManifestKind{ManifestKindAgent, ManifestKindAgent, ManifestKindDependencies}
+	if len(kinds) != len(want) {
+		t.Fatalf("sink received %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Errorf("manifest %d kind = %q, want %q", i, kinds[i], want[i])
+		}
+	}
+}
+
+func TestRunWithOptions_ManifestSinkWithOutDir(t *testing.T) {
+	outDir := t.TempDir()
+	t.Setenv("STIGMER_OUT_DIR", outDir)
+
+	received := 0
+	err := RunWithOptions(func(ctx *Context) error {
+		registerTestAgent(ctx, "code-reviewer")
+		return nil
+	}, WithManifestSink(func(kind ManifestKind, data []byte) error {
+		received++
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	if received != 2 {
+		t.Errorf("sink received %d manifests, want 2", received)
+	}
+	for _, name := range []string{"agent-0.pb", "dependencies.json"} {
+		if _, err := os.Stat(filepath.Join(outDir, name)); err != nil {
+			t.Errorf("expected %s in STIGMER_OUT_DIR: %v", name, err)
+		}
+	}
+}
+
+func TestRunWithOptions_ManifestSinkAgentInstance(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var kinds []ManifestKind
+	var env environmentv1.Environment
+	sink := func(kind ManifestKind, data []byte) error {
+		kinds = append(kinds, kind)
+		if kind == ManifestKindEnvironment {
+			return proto.Unmarshal(data, &env)
+		}
+		return nil
+	}
+
+	err := RunWithOptions(func(ctx *Context) error {
+		ag, err := agent.New(ctx, "code-reviewer", &agent.AgentArgs{
+			Instructions: "Review code quality and report issues",
+		})
+		if err != nil {
+			return err
+		}
+		token, err := environment.New(ctx, "GITHUB_TOKEN", &environment.VariableArgs{IsSecret: true})
+		if err != nil {
+			return err
+		}
+		ag.AddEnvironmentVariable(*token)
+
+		_, err = agentinstance.New(ctx, "code-reviewer-prod", &agentinstance.Args{
+			Agent: ag,
+			Env: map[string]agentinstance.Value{
+				"GITHUB_TOKEN": agentinstance.SecretFromEnv("GITHUB_TOKEN_PROD"),
+			},
+		})
+		return err
+	}, WithManifestSink(sink))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	want := []ManifestKind{ManifestKindAgent, ManifestKindEnvironment, ManifestKindAgentInstance, ManifestKindDependencies}
+	if len(kinds) != len(want) {
+		t.Fatalf("sink received %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Errorf("manifest %d kind = %q, want %q", i, kinds[i], want[i])
+		}
+	}
+
+	// The secret itself is resolved by the CLI, never written to the manifest
+	if got := env.GetSpec().GetData()["GITHUB_TOKEN"].GetValue(); got != "" {
+		t.Errorf("GITHUB_TOKEN value = %q, want empty", got)
+	}
+}
+
+func TestRunWithOptions_ManifestSinkWorkflowInstance(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var kinds []ManifestKind
+	var instance workflowinstancev1.WorkflowInstance
+	sink := func(kind ManifestKind, data []byte) error {
+		kinds = append(kinds, kind)
+		if kind == ManifestKindWorkflowInstance {
+			return proto.Unmarshal(data, &instance)
+		}
+		return nil
+	}
+
+	err := RunWithOptions(func(ctx *Context) error {
+		ctx.SetDefaultOrg("acme")
+		token, err := environment.New(ctx, "SLACK_TOKEN", &environment.VariableArgs{IsSecret: true})
+		if err != nil {
+			return err
+		}
+		wf, err := workflow.New(ctx, "ops/daily-sync", &workflow.WorkflowArgs{
+			EnvironmentVariables: []environment.Variable{*token},
+		})
+		if err != nil {
+			return err
+		}
+		wf.SetVars("init", "status", "started")
+
+		_, err = workflowinstance.New(ctx, "daily-sync-prod", &workflowinstance.Args{
+			Workflow: wf,
+			Env: map[string]workflowinstance.Value{
+				"SLACK_TOKEN": workflowinstance.SecretFromEnv("SLACK_TOKEN_PROD"),
+			},
+			Schedule: "0 2 * * *",
+		})
+		return err
+	}, WithManifestSink(sink))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	want := []ManifestKind{ManifestKindWorkflow, ManifestKindEnvironment, ManifestKindWorkflowInstance, ManifestKindDependencies}
+	if len(kinds) != len(want) {
+		t.Fatalf("sink received %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Errorf("manifest %d kind = %q, want %q", i, kinds[i], want[i])
+		}
+	}
+
+	if got := instance.GetMetadata().GetOrg(); got != "acme" {
+		t.Errorf("instance org = %q, want acme", got)
+	}
+	if got := instance.GetSpec().GetEnvRefs()[0].GetOrg(); got != "acme" {
+		t.Errorf("environment reference org = %q, want acme", got)
+	}
+	if got := instance.GetSpec().GetSchedule().GetCron(); got != "0 2 * * *" {
+		t.Errorf("schedule cron = %q, want %q", got, "0 2 * * *")
+	}
+}
+
+func TestRunWithOptions_ManifestSinkError(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	errPush := errors.New("push rejected")
+	err := RunWithOptions(func(ctx *Context) error {
+		registerTestAgent(ctx, "code-reviewer")
+		return nil
+	}, WithManifestSink(func(kind ManifestKind, data []byte) error {
+		return errPush
+	}))
+
+	if !errors.Is(err, validation.ErrManifestWrite) {
+		t.Errorf("error should match ErrManifestWrite, got %v", err)
+	}
+	if !errors.Is(err, errPush) {
+		t.Errorf("error should wrap the sink error, got %v", err)
+	}
+}
+
+func TestFileManifestSink_NumbersEachKind(t *testing.T) {
+	outDir := t.TempDir()
+	sink := FileManifestSink(outDir)
+
+	for _, kind := range []ManifestKind{ManifestKindAgent, ManifestKindWorkflow, ManifestKindAgent} {
+		if err := sink(kind, []byte("data")); err != nil {
+			t.Fatalf("sink(%s) error = %v", kind, err)
+		}
+	}
+
+	for _, name := range []string{"agent-0.pb", "agent-1.pb", "workflow-0.pb"} {
+		if _, err := os.Stat(filepath.Join(outDir, name)); err != nil {
+			t.Errorf("expected %s: %v", name, err)
+		}
+	}
+}
+
+func TestRunWithOptions_ManifestSinkOrganization(t *testing.T) {
+	outDir := t.TempDir()
+	t.Setenv("STIGMER_OUT_DIR", outDir)
+
+	var kinds []ManifestKind
+	err := RunWithOptions(func(ctx *Context) error {
+		registerTestAgent(ctx, "code-reviewer")
+		_, err := organization.New(ctx, "acme", &organization.OrganizationArgs{
+			Description: "Acme platform team",
+		})
+		return err
+	}, WithManifestSink(func(kind ManifestKind, data []byte) error {
+		kinds = append(kinds, kind)
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	want := []ManifestKind{ManifestKindOrganization, ManifestKindAgent, ManifestKindDependencies}
+	if len(kinds) != len(want) {
+		t.Fatalf("sink received %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Errorf("manifest %d kind = %q, want %q", i, kinds[i], want[i])
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(outDir, "organization-0.pb"))
+	if err != nil {
+		t.Fatalf("organization manifest not written: %v", err)
+	}
+	var org organizationv1.Organization
+	if err := proto.Unmarshal(data, &org); err != nil {
+		t.Fatalf("failed to unmarshal organization manifest: %v", err)
+	}
+	if org.GetMetadata().GetSlug() != "acme" || org.GetSpec().GetDescription() != "Acme platform team" {
+		t.Errorf("organization = %v", &org)
+	}
+}
@@ -0,0 +1,148 @@
+package stigmer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	agentinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agentinstance/v1"
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+)
+
+// ErrManifestNewer is returned when FailIfManifestNewer is set and
+// STIGMER_OUT_DIR holds a manifest synthesized after the current run started.
+var ErrManifestNewer = errors.New("manifest on disk is newer than this synthesis")
+
+// FailIfManifestNewer makes synthesis refuse to overwrite a manifest in
+// STIGMER_OUT_DIR that was synthesized after the current run started, as
+// recorded in its stigmer.ai/sdk.generated-at annotation. Use it when
+// parallel jobs synthesize into the same directory, so a slow job cannot
+// replace the output of a job that started later.
+//
+// Synthesis fails with an error matching ErrManifestNewer. Manifests
+// without a timestamp, such as config.json and dependencies.json, are
+// always overwritten.
+func FailIfManifestNewer() RunOption {
+	return func(o *runOptions) {
+		o.failIfNewerOnDisk = true
+	}
+}
+
+// checkManifestNotNewer fails if the manifest at path was synthesized after
+// notAfter. Missing files and files that cannot be parsed, such as a
+// manifest truncated by a crash, may be overwritten.
+func checkManifestNotNewer(path string, kind ManifestKind, notAfter time.Time) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	generatedAt, ok := manifestGeneratedAt(kind, data)
+	if !ok || !generatedAt.After(notAfter) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s was synthesized at %s, after this run started at %s",
+		ErrManifestNewer, path, generatedAt.UTC().Format(time.RFC3339), notAfter.UTC().Format(time.RFC3339))
+}
+
+// manifestGeneratedAt returns the synthesis time recorded in the metadata of
+// a binary manifest.
+func manifestGeneratedAt(kind ManifestKind, data []byte) (time.Time, bool) {
+	var metadata interface {
+		GetMetadata() *apiresource.ApiResourceMetadata
+		proto.Message
+	}
+	switch kind {
+	case ManifestKindAgent:
+		metadata = &agentv1.Agent{}
+	case ManifestKindWorkflow:
+		metadata = &workflowv1.Workflow{}
+	case ManifestKindAgentInstance:
+		metadata = &agentinstancev1.AgentInstance{}
+	case ManifestKindWorkflowInstance:
+		metadata = &workflowinstancev1.WorkflowInstance{}
+	case ManifestKindEnvironment:
+		metadata = &environmentv1.Environment{}
+	default:
+		return time.Time{}, false
+	}
+	if err := proto.Unmarshal(data, metadata); err != nil {
+		return time.Time{}, false
+	}
+
+	seconds, err := strconv.ParseInt(metadata.GetMetadata().GetAnnotations()[agent.AnnotationSDKGeneratedAt], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0), true
+}
+
+// writeFileAtomic writes data to path through a temporary file in the same
+// directory that is synced and then renamed over path. Readers see either
+// the previous content or the new content, never a partial write. The
+// temporary file is removed if any step fails.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	// The leading dot keeps temporary files out of manifest globs like agent-*.pb
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	// Sync the directory so the rename survives a crash. Not every platform
+	// supports syncing directories, so failures are ignored.
+	if d, dirErr := os.Open(dir); dirErr == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// fileSink returns the sink writing manifests to STIGMER_OUT_DIR, guarded
+// when FailIfManifestNewer is set. Scoped contexts use the settings of their
+// root. written is called with the path of each manifest written.
+func (c *Context) fileSink(outputDir string, written func(path string)) ManifestSink {
+	root := c
+	if c.root != nil {
+		root = c.root
+	}
+	if !root.failIfManifestNewer {
+		return fileManifestSink(outputDir, time.Time{}, written)
+	}
+	return fileManifestSink(outputDir, root.startedAt, written)
+}
@@ -0,0 +1,199 @@
+package stigmer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// assertNoTempFiles fails if a temporary file of writeFileAtomic is left in dir.
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("temporary file %s left in %s", entry.Name(), dir)
+		}
+	}
+}
+
+// writeAgentManifest writes an agent manifest synthesized at generatedAt.
+func writeAgentManifest(t *testing.T, path string, generatedAt time.Time) []byte {
+	t.Helper()
+	data, err := proto.Marshal(&agentv1.Agent{
+		Metadata: &apiresource.ApiResourceMetadata{
+			Name: "code-reviewer",
+			Annotations: map[string]string{
+				agent.AnnotationSDKGeneratedAt: fmt.Sprintf("%d", generatedAt.Unix()),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return data
+}
+
+func TestFileManifestSink_ReplacesTruncatedManifest(t *testing.T) {
+	outDir := t.TempDir()
+	t.Setenv("STIGMER_OUT_DIR", outDir)
+
+	// A previous synthesis crashed halfway through writing agent-0.pb
+	path := filepath.Join(outDir, "agent-0.pb")
+	if err := os.WriteFile(path, []byte{0x0a, 0x7f, 0x12}, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	err := RunWithOptions(func(ctx *Context) error {
+		registerTestAgent(ctx, "code-reviewer")
+		return nil
+	}, FailIfManifestNewer())
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var got agentv1.Agent
+	if err := proto.Unmarshal(data, &got); err != nil {
+		t.Fatalf("agent-0.pb is not a valid manifest: %v", err)
+	}
+	if got.GetMetadata().GetName() != "code-reviewer" {
+		t.Errorf("agent name = %q, want code-reviewer", got.GetMetadata().GetName())
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if info.Mode().Perm() != 0644 {
+		t.Errorf("agent-0.pb mode = %v, want 0644", info.Mode().Perm())
+	}
+	assertNoTempFiles(t, outDir)
+}
+
+func TestWriteFileAtomic_FailureLeavesNoPartialFile(t *testing.T) {
+	dir := t.TempDir()
+
+	// Renaming over a non-empty directory fails after the data was written
+	path := filepath.Join(dir, "agent-0.pb")
+	if err := os.MkdirAll(filepath.Join(path, "busy"), 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	if err := writeFileAtomic(path, []byte("manifest"), 0644); err == nil {
+		t.Fatal("writeFileAtomic() error = nil, want rename error")
+	}
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		t.Errorf("target was replaced after a failed write: %v", err)
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func TestWriteFileAtomic_ReadersSeeCompleteContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workflow-0.pb")
+	versions := [][]byte{
+		bytes.Repeat([]byte("a"), 1<<20),
+		bytes.Repeat([]byte("b"), 1<<19),
+	}
+	if err := writeFileAtomic(path, versions[0], 0644); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			if err := writeFileAtomic(path, versions[i%2], 0644); err != nil {
+				t.Errorf("writeFileAtomic() error = %v", err)
+				break
+			}
+		}
+		close(done)
+	}()
+
+	for {
+		select {
+		case <-done:
+			wg.Wait()
+			return
+		default:
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		if !bytes.Equal(data, versions[0]) && !bytes.Equal(data, versions[1]) {
+			t.Fatalf("reader saw a partial manifest of %d bytes", len(data))
+		}
+	}
+}
+
+func TestFailIfManifestNewer(t *testing.T) {
+	tests := []struct {
+		name        string
+		generatedAt time.Time
+		opts        []RunOption
+		wantErr     bool
+	}{
+		{"newer manifest is kept", time.Now().Add(time.Hour), []RunOption{FailIfManifestNewer()}, true},
+		{"older manifest is replaced", time.Now().Add(-time.Hour), []RunOption{FailIfManifestNewer()}, false},
+		{"guard is opt-in", time.Now().Add(time.Hour), nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			t.Setenv("STIGMER_OUT_DIR", outDir)
+			path := filepath.Join(outDir, "agent-0.pb")
+			existing := writeAgentManifest(t, path, tt.generatedAt)
+
+			err := RunWithOptions(func(ctx *Context) error {
+				registerTestAgent(ctx, "code-reviewer")
+				return nil
+			}, tt.opts...)
+
+			data, readErr := os.ReadFile(path)
+			if readErr != nil {
+				t.Fatalf("ReadFile() error = %v", readErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrManifestNewer) || !errors.Is(err, validation.ErrManifestWrite) {
+					t.Fatalf("RunWithOptions() error = %v, want ErrManifestNewer and ErrManifestWrite", err)
+				}
+				if !bytes.Equal(data, existing) {
+					t.Error("newer manifest was overwritten")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RunWithOptions() error = %v", err)
+			}
+			if bytes.Equal(data, existing) {
+				t.Error("manifest was not replaced")
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package stigmer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// ErrInvalidDefaultOrg is returned when synthesis fails because the value
+// given to SetDefaultOrg is not known at synthesis.
+var ErrInvalidDefaultOrg = errors.New("invalid default organization")
+
+// LintRuleMissingOrg reports agents and workflows without an organization in
+// programs where other resources have one. Resources without an org are
+// deployed to the org selected by the CLI, which is rarely intended when the
+// rest of the program names its orgs.
+const LintRuleMissingOrg = "missing-org"
+
+// missingOrgMessage is the message of missing-org findings.
+const missingOrgMessage = "no organization set while other resources have one; use Org or SetDefaultOrg"
+
+// SetDefaultOrg sets the organization of agents, workflows and agent
+// instances created in this context that do not set their own Org.
+//
+// org is a string or a *StringRef whose value is known at synthesis, such as
+// one returned by SetString or RequireString; other values fail synthesis
+// with ErrInvalidDefaultOrg. An explicit Org always wins. On a scoped context
+// the default applies to the scope and takes precedence over the scope's
+// WithOrg; scopes without a default of their own use the root's.
+//
+// Resources created before the call get the default at synthesis.
+//
+// Example:
+//
+//	ctx.SetDefaultOrg(ctx.RequireString("org", stigmer.FromEnv("STIGMER_ORG")))
+//	ag, _ := agent.New(ctx, "code-reviewer", &agent.AgentArgs{...})  // ag.Org set
+func (c *Context) SetDefaultOrg(org interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.defaultOrgErr = nil
+	switch v := org.(type) {
+	case string:
+		c.defaultOrg.Store(v)
+	case *StringRef:
+		if v.IsComputed() {
+			c.defaultOrgErr = fmt.Errorf("%w: %s is only known at runtime", ErrInvalidDefaultOrg, v.Expression())
+			return
+		}
+		c.defaultOrg.Store(v.Value())
+	default:
+		c.defaultOrgErr = fmt.Errorf("%w: expected a string or *StringRef, got %T", ErrInvalidDefaultOrg, org)
+	}
+}
+
+// DefaultOrg returns the organization given to resources that do not set
+// their own: the default set with SetDefaultOrg, the scope's WithOrg, or the
+// root's default, in that order.
+func (c *Context) DefaultOrg() string {
+	if org, _ := c.defaultOrg.Load().(string); org != "" {
+		return org
+	}
+	if org := c.scopeOrg(); org != "" {
+		return org
+	}
+	if c.root != nil {
+		return c.root.DefaultOrg()
+	}
+	return ""
+}
+
+// applyDefaultOrgs sets the default org on resources created before
+// SetDefaultOrg was called, in the context and its scopes.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) applyDefaultOrgs() {
+	c.applyDefaultOrg()
+	for _, scope := range c.scopes {
+		scope.mu.Lock()
+		scope.applyDefaultOrg()
+		scope.mu.Unlock()
+	}
+}
+
+// applyDefaultOrg sets the default org on the resources of this context.
+func (c *Context) applyDefaultOrg() {
+	org := c.DefaultOrg()
+	if org == "" {
+		return
+	}
+	for _, wf := range c.workflows {
+		if wf.Org == "" {
+			wf.Org = org
+		}
+	}
+	for _, ag := range c.agents {
+		if ag.Org == "" {
+			ag.Org = org
+		}
+	}
+	for _, inst := range c.agentInstances {
+		if inst.Org == "" {
+			inst.Org = org
+		}
+	}
+	for _, inst := range c.workflowInstances {
+		if inst.Org == "" {
+			inst.Org = org
+		}
+	}
+}
+
+// applyOrg sets the owning organization on the metadata of a synthesized
+// resource. Resources without an org are left to the org selected at deploy.
+func applyOrg(metadata *apiresource.ApiResourceMetadata, org string) {
+	if org == "" || metadata == nil {
+		return
+	}
+	metadata.Org = org
+}
+
+// checkDefaultOrg fails synthesis if SetDefaultOrg was given a value that is
+// not known at synthesis, in the context or one of its scopes.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) checkDefaultOrg() error {
+	err := c.defaultOrgErr
+	for _, scope := range c.scopes {
+		scope.mu.RLock()
+		if err == nil {
+			err = scope.defaultOrgErr
+		}
+		scope.mu.RUnlock()
+	}
+
+	if err == nil {
+		return nil
+	}
+	return validation.NewSynthesisErrorWithCause("config", err.Error(), err)
+}
+
+// lintMissingOrg reports agents and workflows without an org when another
+// resource of the context or its scopes has one. Workflows can suppress the
+// rule with SuppressLint(LintRuleMissingOrg).
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) lintMissingOrg() []workflow.LintFinding {
+	contexts := []*Context{c}
+	for _, scope := range c.scopes {
+		scope.mu.RLock()
+		defer scope.mu.RUnlock()
+		contexts = append(contexts, scope)
+	}
+
+	hasOrg := false
+	for _, ctx := range contexts {
+		for _, wf := range ctx.workflows {
+			hasOrg = hasOrg || wf.Org != ""
+		}
+		for _, ag := range ctx.agents {
+			hasOrg = hasOrg || ag.Org != ""
+		}
+		for _, inst := range ctx.agentInstances {
+			hasOrg = hasOrg || inst.Org != ""
+		}
+		for _, inst := range ctx.workflowInstances {
+			hasOrg = hasOrg || inst.Org != ""
+		}
+	}
+	if !hasOrg {
+		return nil
+	}
+
+	rule := workflow.NewLintRule(LintRuleMissingOrg, workflow.LintSeverityWarning, func(w *workflow.Workflow) []workflow.LintFinding {
+		if w.Org != "" {
+			return nil
+		}
+		return []workflow.LintFinding{{Message: missingOrgMessage}}
+	})
+
+	var findings []workflow.LintFinding
+	for _, ctx := range contexts {
+		for _, wf := range ctx.workflows {
+			findings = append(findings, wf.Lint(rule)...)
+		}
+		for _, ag := range ctx.agents {
+			if ag.Org != "" {
+				continue
+			}
+			findings = append(findings, workflow.LintFinding{
+				RuleID:   LintRuleMissingOrg,
+				Severity: workflow.LintSeverityWarning,
+				Agent:    ag.Name,
+				Message:  missingOrgMessage,
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,199 @@
+package stigmer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+func TestContext_SetDefaultOrg(t *testing.T) {
+	outDir := t.TempDir()
+	t.Setenv("STIGMER_OUT_DIR", outDir)
+
+	var captured *Context
+	err := Run(func(ctx *Context) error {
+		captured = ctx
+		// Resources created before the default get it at synthesis
+		registerTestAgent(ctx, "early-reviewer")
+
+		ctx.SetDefaultOrg(ctx.SetString("org", "acme"))
+		registerTestAgent(ctx, "code-reviewer")
+		ctx.RegisterAgent(&agent.Agent{
+			Name:         "partner-reviewer",
+			Instructions: "Review code quality and report issues",
+			Org:          "partner",
+		})
+		wf, err := workflow.New(ctx, "ops/health-check", &workflow.WorkflowArgs{Version: "1.0.0"})
+		if err != nil {
+			return err
+		}
+		wf.HttpGet("fetch", "https://api.example.com/status", nil)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	wantAgents := map[string]string{
+		"early-reviewer":   "acme",
+		"code-reviewer":    "acme",
+		"partner-reviewer": "partner",
+	}
+	for _, ag := range captured.Agents() {
+		if ag.Org != wantAgents[ag.Name] {
+			t.Errorf("agent %s Org = %q, want %q", ag.Name, ag.Org, wantAgents[ag.Name])
+		}
+	}
+	if got := captured.Workflows()[0].Org; got != "acme" {
+		t.Errorf("workflow Org = %q, want acme", got)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outDir, "agent-2.pb"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var ag agentv1.Agent
+	if err := proto.Unmarshal(data, &ag); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := ag.GetMetadata().GetOrg(); got != "partner" {
+		t.Errorf("agent metadata.org = %q, want partner", got)
+	}
+
+	data, err = os.ReadFile(filepath.Join(outDir, "workflow-0.pb"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var wf workflowv1.Workflow
+	if err := proto.Unmarshal(data, &wf); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := wf.GetMetadata().GetOrg(); got != "acme" {
+		t.Errorf("workflow metadata.org = %q, want acme", got)
+	}
+}
+
+func TestContext_DefaultOrgScopes(t *testing.T) {
+	ctx := NewContext()
+	ctx.SetDefaultOrg("acme")
+
+	teamA := ctx.Scope("team-a", WithOrg("team-a-org"))
+	teamB := ctx.Scope("team-b")
+	teamC := ctx.Scope("team-c", WithOrg("team-c-org"))
+	teamC.SetDefaultOrg("team-c-override")
+
+	for scope, want := range map[*Context]string{
+		ctx:   "acme",
+		teamA: "team-a-org",
+		teamB: "acme",
+		teamC: "team-c-override",
+	} {
+		if got := scope.DefaultOrg(); got != want {
+			t.Errorf("scope %q DefaultOrg() = %q, want %q", scope.ScopeName(), got, want)
+		}
+	}
+}
+
+func TestContext_SetDefaultOrgInvalid(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	tests := []struct {
+		name string
+		org  func(ctx *Context) interface{}
+		want string
+	}{
+		{
+			name: "runtime expression",
+			org: func(ctx *Context) interface{} {
+				return ctx.SetString("org", "acme").Upper()
+			},
+			want: "only known at runtime",
+		},
+		{
+			name: "unsupported type",
+			org:  func(*Context) interface{} { return 42 },
+			want: "got int",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Run(func(ctx *Context) error {
+				ctx.SetDefaultOrg(tt.org(ctx))
+				return nil
+			})
+			if !errors.Is(err, ErrInvalidDefaultOrg) {
+				t.Fatalf("Run() error = %v, want ErrInvalidDefaultOrg", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Run() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunWithOptions_LintMissingOrg(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var captured *Context
+	err := RunWithOptions(func(ctx *Context) error {
+		captured = ctx
+		registerTestAgent(ctx, "code-reviewer")
+		ctx.RegisterAgent(&agent.Agent{
+			Name:         "partner-reviewer",
+			Instructions: "Review code quality and report issues",
+			Org:          "partner",
+		})
+		if _, err := workflow.New(ctx, "ops/health-check", &workflow.WorkflowArgs{Version: "1.0.0"}); err != nil {
+			return err
+		}
+		wf, err := workflow.New(ctx, "ops/cleanup", &workflow.WorkflowArgs{Version: "1.0.0"})
+		if err != nil {
+			return err
+		}
+		wf.SuppressLint(LintRuleMissingOrg)
+		return nil
+	}, WithLint(LintError), WithLintRules())
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range captured.LintFindings() {
+		if f.RuleID == LintRuleMissingOrg {
+			got = append(got, f.String())
+		}
+	}
+	want := []string{
+		`warning: workflow "health-check": ` + missingOrgMessage + ` [missing-org]`,
+		`warning: agent "code-reviewer": ` + missingOrgMessage + ` [missing-org]`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("missing-org findings = %q, want %q", got, want)
+	}
+}
+
+func TestRunWithOptions_LintMissingOrgWithoutOrgs(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var captured *Context
+	err := RunWithOptions(func(ctx *Context) error {
+		captured = ctx
+		registerTestAgent(ctx, "code-reviewer")
+		return nil
+	}, WithLint(LintWarn))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+	if findings := captured.LintFindings(); len(findings) != 0 {
+		t.Errorf("LintFindings() = %v, want none when no resource has an org", findings)
+	}
+}
@@ -0,0 +1,39 @@
+package stigmer
+
+import (
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+)
+
+// WithSourceRevision records revision, such as the git commit SHA of the
+// definitions, in the stigmer.ai/source.revision annotation of every
+// synthesized resource, next to the SDK version, Go version and synthesis
+// time the SDK always records.
+//
+// Example:
+//
+//	err := stigmer.RunWithOptions(fn,
+//	    stigmer.WithSourceRevision(os.Getenv("GITHUB_SHA")),
+//	)
+func WithSourceRevision(revision string) RunOption {
+	return func(o *runOptions) {
+		o.sourceRevision = revision
+	}
+}
+
+// applySourceRevision adds the source revision given to WithSourceRevision
+// to the annotations of a synthesized resource. Scoped contexts use the
+// revision of their root.
+func (c *Context) applySourceRevision(metadata *apiresource.ApiResourceMetadata) {
+	revision := c.sourceRevision
+	if c.root != nil {
+		revision = c.root.sourceRevision
+	}
+	if revision == "" || metadata == nil {
+		return
+	}
+	if metadata.Annotations == nil {
+		metadata.Annotations = make(map[string]string)
+	}
+	metadata.Annotations[agent.AnnotationSourceRevision] = revision
+}
@@ -0,0 +1,73 @@
+package stigmer
+
+import (
+	"runtime"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+)
+
+func TestRunWithOptions_SourceRevision(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	tests := []struct {
+		name     string
+		opts     []RunOption
+		revision string
+	}{
+		{
+			name:     "with source revision",
+			opts:     []RunOption{WithSourceRevision("3f2c9a1")},
+			revision: "3f2c9a1",
+		},
+		{
+			name: "without source revision",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var agents []*agentv1.Agent
+			sink := func(kind ManifestKind, data []byte) error {
+				if kind != ManifestKindAgent {
+					return nil
+				}
+				a := &agentv1.Agent{}
+				if err := proto.Unmarshal(data, a); err != nil {
+					return err
+				}
+				agents = append(agents, a)
+				return nil
+			}
+
+			err := RunWithOptions(func(ctx *Context) error {
+				registerTestAgent(ctx, "code-reviewer")
+				registerTestAgent(ctx.Scope("team-a", WithOrg("team-a")), "code-reviewer")
+				return nil
+			}, append(tt.opts, WithManifestSink(sink))...)
+			if err != nil {
+				t.Fatalf("RunWithOptions() error = %v", err)
+			}
+
+			if len(agents) != 2 {
+				t.Fatalf("sink received %d agents, want 2", len(agents))
+			}
+			for _, a := range agents {
+				annotations := a.GetMetadata().GetAnnotations()
+				revision, ok := annotations[agent.AnnotationSourceRevision]
+				if revision != tt.revision || ok != (tt.revision != "") {
+					t.Errorf("%s annotation = %q (set %v), want %q", agent.AnnotationSourceRevision, revision, ok, tt.revision)
+				}
+				if got := annotations[agent.AnnotationSDKGoVersion]; got != runtime.Version() {
+					t.Errorf("%s annotation = %q, want %q", agent.AnnotationSDKGoVersion, got, runtime.Version())
+				}
+				if annotations[agent.AnnotationSDKVersion] == "" {
+					t.Errorf("%s annotation is empty", agent.AnnotationSDKVersion)
+				}
+			}
+		})
+	}
+}
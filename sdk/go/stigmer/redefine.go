@@ -0,0 +1,130 @@
+package stigmer
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// LintRuleVariableRedefined reports context variables set again with the
+// same type but a different value. The last value wins, so resources
+// created before the second call may have been written against the first.
+const LintRuleVariableRedefined = "variable-redefined"
+
+// VariableRedefinition records a context variable that a single-variable
+// setter set again, with the same type and a different value.
+type VariableRedefinition struct {
+	// Name is the variable name.
+	Name string
+
+	// Op is the setter that redefined the variable, such as SetInt.
+	Op string
+
+	// SetAt and PreviousSetAt are the call sites ("file.go:line") of the
+	// redefining call and of the call that set the replaced value.
+	SetAt         string
+	PreviousSetAt string
+}
+
+// VariableRedefinitions returns the variables of the context and its scopes
+// that were set again with a different value, in call order. Values are not
+// included, since they may be secrets.
+//
+// Example:
+//
+//	for _, r := range ctx.VariableRedefinitions() {
+//	    log.Printf("%s: %s at %s replaces the value set at %s", r.Name, r.Op, r.SetAt, r.PreviousSetAt)
+//	}
+func (c *Context) VariableRedefinitions() []VariableRedefinition {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.variableRedefinitionsLocked()
+}
+
+// variableRedefinitionsLocked returns the redefinitions of the context and
+// its scopes.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) variableRedefinitionsLocked() []VariableRedefinition {
+	result := append([]VariableRedefinition(nil), c.variableRedefinitions...)
+	for _, scope := range c.scopes {
+		scope.mu.RLock()
+		result = append(result, scope.variableRedefinitions...)
+		scope.mu.RUnlock()
+	}
+	return result
+}
+
+// setVariable stores the variable ref set by op.
+//
+// A variable already set with another type is kept, and the conflict fails
+// synthesis with a VariableConflictError naming both call sites. Setting it
+// again with the same type replaces it; a different value is recorded as a
+// VariableRedefinition.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) setVariable(op, name string, ref Ref) {
+	existing, ok := c.variables[name]
+	if !ok {
+		c.variables[name] = ref
+		return
+	}
+
+	setAt, previousSetAt := setLocation(ref), setLocation(existing)
+	if typ, previousType := variableType(ref), variableType(existing); typ != previousType {
+		c.variableConflicts = append(c.variableConflicts, &VariableConflictError{
+			Name:          name,
+			Op:            op,
+			SetAt:         setAt,
+			PreviousSetAt: previousSetAt,
+			Type:          typ,
+			PreviousType:  previousType,
+		})
+		return
+	}
+
+	if !reflect.DeepEqual(ref.ToValue(), existing.ToValue()) {
+		c.variableRedefinitions = append(c.variableRedefinitions, VariableRedefinition{
+			Name:          name,
+			Op:            op,
+			SetAt:         setAt,
+			PreviousSetAt: previousSetAt,
+		})
+	}
+	c.variables[name] = ref
+}
+
+// variableType returns the type of a context variable as named in conflict
+// errors. Secrets are strings.
+func variableType(ref Ref) string {
+	switch ref.(type) {
+	case *StringRef:
+		return "string"
+	case *IntRef:
+		return "int"
+	case *BoolRef:
+		return "bool"
+	case *ObjectRef:
+		return "object"
+	case *ListRef:
+		return "list"
+	default:
+		return fmt.Sprintf("%T", ref)
+	}
+}
+
+// lintVariableRedefinitions reports the variables of the context and its
+// scopes that were set again with a different value.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) lintVariableRedefinitions() []workflow.LintFinding {
+	var findings []workflow.LintFinding
+	for _, r := range c.variableRedefinitionsLocked() {
+		findings = append(findings, workflow.LintFinding{
+			RuleID:   LintRuleVariableRedefined,
+			Severity: workflow.LintSeverityWarning,
+			Variable: r.Name,
+			Message:  fmt.Sprintf("%s at %s replaces the value set at %s", r.Op, r.SetAt, r.PreviousSetAt),
+		})
+	}
+	return findings
+}
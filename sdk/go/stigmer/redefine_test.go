@@ -0,0 +1,151 @@
+package stigmer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// configureReviewer stands for agent setup code living apart from the
+// workflow code sharing its context: it sets "timeout" as a string.
+func configureReviewer(ctx *Context) {
+	ctx.SetString("timeout", "30s")
+	registerTestAgent(ctx, "code-reviewer")
+}
+
+func TestContext_RedefineVariableWithAnotherType(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	err := RunWithOptions(func(ctx *Context) error {
+		timeout := ctx.SetInt("timeout", 30)
+		wf, err := workflow.New(ctx, "ops/health-check", &workflow.WorkflowArgs{Version: "1.0.0"})
+		if err != nil {
+			return err
+		}
+		wf.Set("init", &workflow.SetArgs{Variables: map[string]interface{}{"timeout": timeout}})
+
+		configureReviewer(ctx)
+
+		// The workflow keeps reading the int
+		if ctx.GetInt("timeout") != timeout {
+			t.Error("SetString() replaced the int variable")
+		}
+		return nil
+	})
+	if !errors.Is(err, ErrVariableConflict) {
+		t.Fatalf("RunWithOptions() error = %v, want ErrVariableConflict", err)
+	}
+
+	var conflict *VariableConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("RunWithOptions() error = %v, want a VariableConflictError", err)
+	}
+	if conflict.Name != "timeout" || conflict.Op != "SetString" || conflict.Type != "string" || conflict.PreviousType != "int" {
+		t.Errorf("conflict = %+v, want timeout redefined as a string by SetString", conflict)
+	}
+	if !strings.HasPrefix(conflict.SetAt, "redefine_test.go:") || !strings.HasPrefix(conflict.PreviousSetAt, "redefine_test.go:") ||
+		conflict.SetAt == conflict.PreviousSetAt {
+		t.Errorf("conflict call sites = %q and %q, want two lines of redefine_test.go", conflict.SetAt, conflict.PreviousSetAt)
+	}
+	for _, want := range []string{conflict.SetAt, conflict.PreviousSetAt, "as string, already set as int"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestContext_RedefineVariableTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		redefine func(ctx *Context)
+		wantType string
+	}{
+		{name: "bool", redefine: func(ctx *Context) { ctx.SetBool("limit", true) }, wantType: "bool"},
+		{name: "secret", redefine: func(ctx *Context) { ctx.SetSecret("limit", "10") }, wantType: "string"},
+		{name: "object", redefine: func(ctx *Context) { ctx.SetObject("limit", map[string]interface{}{"max": 10}) }, wantType: "object"},
+		{name: "list", redefine: func(ctx *Context) { ctx.SetList("limit", []interface{}{10}) }, wantType: "list"},
+		{name: "required string", redefine: func(ctx *Context) { ctx.RequireString("limit", Default("10")) }, wantType: "string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("STIGMER_OUT_DIR", "")
+			ctx := newContext()
+			ctx.SetInt("limit", 10)
+			tt.redefine(ctx)
+
+			var conflict *VariableConflictError
+			if err := ctx.Synthesize(); !errors.As(err, &conflict) {
+				t.Fatalf("Synthesize() error = %v, want a VariableConflictError", err)
+			}
+			if conflict.Type != tt.wantType || conflict.PreviousType != "int" {
+				t.Errorf("conflict types = %s over %s, want %s over int", conflict.Type, conflict.PreviousType, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestContext_RedefineVariableWithSameType(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var captured *Context
+	err := RunWithOptions(func(ctx *Context) error {
+		captured = ctx
+		ctx.SetInt("timeout", 30)
+		ctx.SetInt("retries", 3)
+		if _, err := workflow.New(ctx, "ops/health-check", &workflow.WorkflowArgs{Version: "1.0.0"}); err != nil {
+			return err
+		}
+		registerTestAgent(ctx, "code-reviewer")
+
+		ctx.SetInt("timeout", 60)
+		ctx.SetInt("retries", 3) // Same value: not a redefinition
+		return nil
+	}, WithLint(LintWarn))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v, want same-type redefinitions allowed", err)
+	}
+
+	if got := captured.GetInt("timeout").Value(); got != 60 {
+		t.Errorf("GetInt(timeout) = %d, want the last value", got)
+	}
+
+	redefinitions := captured.VariableRedefinitions()
+	if len(redefinitions) != 1 {
+		t.Fatalf("VariableRedefinitions() = %+v, want timeout only", redefinitions)
+	}
+	r := redefinitions[0]
+	if r.Name != "timeout" || r.Op != "SetInt" || r.SetAt == r.PreviousSetAt ||
+		!strings.HasPrefix(r.SetAt, "redefine_test.go:") || !strings.HasPrefix(r.PreviousSetAt, "redefine_test.go:") {
+		t.Errorf("redefinition = %+v, want timeout redefined by SetInt in redefine_test.go", r)
+	}
+
+	var found []string
+	for _, f := range captured.LintFindings() {
+		if f.RuleID == LintRuleVariableRedefined {
+			found = append(found, f.String())
+		}
+	}
+	want := `warning: context variable "timeout": SetInt at ` + r.SetAt + ` replaces the value set at ` + r.PreviousSetAt + ` [variable-redefined]`
+	if len(found) != 1 || found[0] != want {
+		t.Errorf("variable-redefined findings = %q, want %q", found, want)
+	}
+}
+
+func TestContext_RedefineVariableInScope(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+	ctx := newContext()
+	ctx.SetString("region", "eu-west-1")
+
+	scope := ctx.Scope("billing")
+	scope.SetString("region", "us-east-1")
+	scope.SetBool("region", true)
+
+	if got := ctx.VariableRedefinitions(); len(got) != 1 || got[0].Name != "region" {
+		t.Errorf("VariableRedefinitions() = %+v, want the scope's redefinition of region", got)
+	}
+	if err := ctx.Synthesize(); !errors.Is(err, ErrVariableConflict) {
+		t.Errorf("Synthesize() error = %v, want ErrVariableConflict for the scope's bool", err)
+	}
+}
@@ -3,6 +3,8 @@ package stigmer
 import (
 	"fmt"
 	"strings"
+
+	"github.com/stigmer/stigmer/sdk/go/workflow"
 )
 
 // Ref is the base interface for all typed references.
@@ -40,6 +42,12 @@ type baseRef struct {
 	isSecret     bool
 	isComputed   bool   // If true, name contains full expression, not just variable name
 	rawExpression string // For computed expressions, the full expression without ${ }
+	setAt        string // Call site of the Set* call that created a context variable
+}
+
+// location returns the call site that created the variable, if recorded.
+func (r *baseRef) location() string {
+	return r.setAt
 }
 
 func (r *baseRef) Name() string {
@@ -50,6 +58,12 @@ func (r *baseRef) IsSecret() bool {
 	return r.isSecret
 }
 
+// IsComputed reports whether this reference is a runtime expression rather
+// than a value known at synthesis time.
+func (r *baseRef) IsComputed() bool {
+	return r.isComputed
+}
+
 func (r *baseRef) Expression() string {
 	if r.isComputed {
 		return fmt.Sprintf("${ %s }", r.rawExpression)
@@ -77,6 +91,13 @@ func (r *baseRef) Expression() string {
 type StringRef struct {
 	baseRef
 	value string // Initial value (used during synthesis)
+
+	// source and envVar record how a RequireString value was resolved
+	source ConfigSource
+	envVar string
+
+	// missing is set when a RequireString value could not be resolved
+	missing *MissingConfigError
 }
 
 // Value returns the initial value of this string reference (used during synthesis).
@@ -84,6 +105,16 @@ func (s *StringRef) Value() string {
 	return s.value
 }
 
+// StringExpression returns the value for task fields such as
+// ForArgs.InRef: the resolved literal when it is known at synthesis, and the
+// runtime expression otherwise. Implements workflow.StringExpr.
+func (s *StringRef) StringExpression() string {
+	if s.value != "" && !s.isComputed {
+		return s.value
+	}
+	return s.Expression()
+}
+
 // String implements fmt.Stringer interface for StringRef.
 // This allows StringRef to be used directly in string concatenation and fmt.Sprint().
 // Returns the resolved string value.
@@ -372,109 +403,118 @@ func (i *IntRef) ToValue() interface{} {
 	return i.value
 }
 
-// Add creates a new IntRef that adds another integer to this one.
-// It generates a JQ expression for runtime addition.
+// Int returns a literal IntRef that is not bound to a context variable.
+// Literal refs are known at synthesis time, so arithmetic between literals
+// is folded immediately instead of producing a runtime expression.
 //
 // Example:
 //
-//	base := ctx.SetInt("base", 10)
-//	total := base.Add(ctx.SetInt("increment", 5))
-//	// Result: "${ $context.base + $context.increment }"
-func (i *IntRef) Add(other *IntRef) *IntRef {
-	var left, right string
-	if i.isComputed {
-		left = i.rawExpression
-	} else {
-		left = fmt.Sprintf("$context.%s", i.name)
-	}
-	if other.isComputed {
-		right = other.rawExpression
-	} else {
-		right = fmt.Sprintf("$context.%s", other.name)
-	}
+//	timeout := ctx.SetInt("timeout", 30)
+//	extended := timeout.Add(stigmer.Int(10))
+//	// Result: "${ ($context.timeout + 10) }"
+func Int(value int) *IntRef {
+	return &IntRef{value: value}
+}
+
+// IntField returns an IntRef that reads an integer field from a task output.
+// The value is only known at runtime, so any arithmetic involving it produces
+// a JQ expression.
+//
+// Example:
+//
+//	count := stigmer.IntField(fetchTask.Field("count"))
+//	doubled := count.Multiply(stigmer.Int(2))
+//	// Result: "${ ($context["fetch"].count * 2) }"
+func IntField(ref workflow.TaskFieldRef) *IntRef {
 	return &IntRef{
 		baseRef: baseRef{
-			name:         "",
-			isSecret:     false,
-			isComputed:   true,
-			rawExpression: fmt.Sprintf("(%s + %s)", left, right),
+			isComputed:    true,
+			rawExpression: taskFieldExpression(ref),
 		},
-		value: 0,
 	}
 }
 
+// Expression implements Ref.Expression() for IntRef.
+// Literal refs render as a constant JQ expression so they can be used
+// anywhere an expression string is expected.
+func (i *IntRef) Expression() string {
+	if i.isLiteral() {
+		return fmt.Sprintf("${ %d }", i.value)
+	}
+	return i.baseRef.Expression()
+}
+
+// Add creates a new IntRef that adds another integer to this one.
+// Two literals are added at synthesis time; otherwise it generates a JQ
+// expression for runtime addition.
+//
+// Example:
+//
+//	base := ctx.SetInt("base", 10)
+//	total := base.Add(ctx.SetInt("increment", 5))
+//	// Result: "${ ($context.base + $context.increment) }"
+func (i *IntRef) Add(other *IntRef) *IntRef {
+	return i.arithmetic("+", other, func(a, b int) (int, bool) { return a + b, true })
+}
+
 // Subtract creates a new IntRef that subtracts another integer from this one.
-// It generates a JQ expression for runtime subtraction.
+// Two literals are subtracted at synthesis time; otherwise it generates a JQ
+// expression for runtime subtraction.
 func (i *IntRef) Subtract(other *IntRef) *IntRef {
-	var left, right string
-	if i.isComputed {
-		left = i.rawExpression
-	} else {
-		left = fmt.Sprintf("$context.%s", i.name)
-	}
-	if other.isComputed {
-		right = other.rawExpression
-	} else {
-		right = fmt.Sprintf("$context.%s", other.name)
-	}
-	return &IntRef{
-		baseRef: baseRef{
-			name:         "",
-			isSecret:     false,
-			isComputed:   true,
-			rawExpression: fmt.Sprintf("(%s - %s)", left, right),
-		},
-		value: 0,
-	}
+	return i.arithmetic("-", other, func(a, b int) (int, bool) { return a - b, true })
 }
 
 // Multiply creates a new IntRef that multiplies this integer by another.
-// It generates a JQ expression for runtime multiplication.
+// Two literals are multiplied at synthesis time; otherwise it generates a JQ
+// expression for runtime multiplication.
 func (i *IntRef) Multiply(other *IntRef) *IntRef {
-	var left, right string
-	if i.isComputed {
-		left = i.rawExpression
-	} else {
-		left = fmt.Sprintf("$context.%s", i.name)
-	}
-	if other.isComputed {
-		right = other.rawExpression
-	} else {
-		right = fmt.Sprintf("$context.%s", other.name)
-	}
-	return &IntRef{
-		baseRef: baseRef{
-			name:         "",
-			isSecret:     false,
-			isComputed:   true,
-			rawExpression: fmt.Sprintf("(%s * %s)", left, right),
-		},
-		value: 0,
-	}
+	return i.arithmetic("*", other, func(a, b int) (int, bool) { return a * b, true })
 }
 
 // Divide creates a new IntRef that divides this integer by another.
-// It generates a JQ expression for runtime division.
+// Two literals are divided at synthesis time only when the result is exact,
+// because JQ division is not integer division; otherwise it generates a JQ
+// expression for runtime division.
 func (i *IntRef) Divide(other *IntRef) *IntRef {
-	var left, right string
-	if i.isComputed {
-		left = i.rawExpression
-	} else {
-		left = fmt.Sprintf("$context.%s", i.name)
-	}
-	if other.isComputed {
-		right = other.rawExpression
-	} else {
-		right = fmt.Sprintf("$context.%s", other.name)
+	return i.arithmetic("/", other, func(a, b int) (int, bool) {
+		if b == 0 || a%b != 0 {
+			return 0, false
+		}
+		return a / b, true
+	})
+}
+
+// arithmetic combines two IntRefs with a binary operator.
+// fold computes the synthesis-time result for two literals and reports
+// whether folding is valid.
+func (i *IntRef) arithmetic(op string, other *IntRef, fold func(a, b int) (int, bool)) *IntRef {
+	if i.isLiteral() && other.isLiteral() {
+		if value, ok := fold(i.value, other.value); ok {
+			return Int(value)
+		}
 	}
 	return &IntRef{
 		baseRef: baseRef{
-			name:         "",
-			isSecret:     false,
-			isComputed:   true,
-			rawExpression: fmt.Sprintf("(%s / %s)", left, right),
+			isComputed:    true,
+			rawExpression: fmt.Sprintf("(%s %s %s)", i.operand(), op, other.operand()),
 		},
-		value: 0,
+	}
+}
+
+// isLiteral reports whether this IntRef is a synthesis-time constant.
+func (i *IntRef) isLiteral() bool {
+	return !i.isComputed && i.name == ""
+}
+
+// operand returns the JQ fragment for this IntRef inside a larger expression.
+func (i *IntRef) operand() string {
+	switch {
+	case i.isComputed:
+		return i.rawExpression
+	case i.name == "":
+		return fmt.Sprintf("%d", i.value)
+	default:
+		return fmt.Sprintf("$context.%s", i.name)
 	}
 }
 
@@ -509,65 +549,80 @@ func (b *BoolRef) ToValue() interface{} {
 	return b.value
 }
 
+// Bool returns a literal BoolRef that is not bound to a context variable.
+// Logic between literals is folded at synthesis time.
+func Bool(value bool) *BoolRef {
+	return &BoolRef{value: value}
+}
+
+// BoolField returns a BoolRef that reads a boolean field from a task output.
+// The value is only known at runtime, so any logic involving it produces
+// a JQ expression.
+//
+// Example:
+//
+//	approved := stigmer.BoolField(reviewTask.Field("approved"))
+//	canDeploy := approved.And(isProd)
+//	// Result: "${ ($context["review"].approved and $context.isProd) }"
+func BoolField(ref workflow.TaskFieldRef) *BoolRef {
+	return &BoolRef{
+		baseRef: baseRef{
+			isComputed:    true,
+			rawExpression: taskFieldExpression(ref),
+		},
+	}
+}
+
+// Expression implements Ref.Expression() for BoolRef.
+// Literal refs render as a constant JQ expression, which lets any BoolRef be
+// used directly as a switch case condition.
+func (b *BoolRef) Expression() string {
+	if b.isLiteral() {
+		return fmt.Sprintf("${ %t }", b.value)
+	}
+	return b.baseRef.Expression()
+}
+
 // And creates a new BoolRef that performs logical AND with another boolean.
-// It generates a JQ expression for runtime evaluation.
+// Two literals are combined at synthesis time; otherwise it generates a JQ
+// expression for runtime evaluation.
 //
 // Example:
 //
 //	hasAccess := ctx.SetBool("hasAccess", true)
 //	isEnabled := ctx.SetBool("isEnabled", true)
 //	canProceed := hasAccess.And(isEnabled)
-//	// Result: "${ $context.hasAccess and $context.isEnabled }"
+//	// Result: "${ ($context.hasAccess and $context.isEnabled) }"
 func (b *BoolRef) And(other *BoolRef) *BoolRef {
-	var left, right string
-	if b.isComputed {
-		left = b.rawExpression
-	} else {
-		left = fmt.Sprintf("$context.%s", b.name)
-	}
-	if other.isComputed {
-		right = other.rawExpression
-	} else {
-		right = fmt.Sprintf("$context.%s", other.name)
+	if b.isLiteral() && other.isLiteral() {
+		return Bool(b.value && other.value)
 	}
 	return &BoolRef{
 		baseRef: baseRef{
-			name:         "",
-			isSecret:     false,
-			isComputed:   true,
-			rawExpression: fmt.Sprintf("(%s and %s)", left, right),
+			isComputed:    true,
+			rawExpression: fmt.Sprintf("(%s and %s)", b.operand(), other.operand()),
 		},
-		value: false,
 	}
 }
 
 // Or creates a new BoolRef that performs logical OR with another boolean.
-// It generates a JQ expression for runtime evaluation.
+// Two literals are combined at synthesis time; otherwise it generates a JQ
+// expression for runtime evaluation.
 func (b *BoolRef) Or(other *BoolRef) *BoolRef {
-	var left, right string
-	if b.isComputed {
-		left = b.rawExpression
-	} else {
-		left = fmt.Sprintf("$context.%s", b.name)
-	}
-	if other.isComputed {
-		right = other.rawExpression
-	} else {
-		right = fmt.Sprintf("$context.%s", other.name)
+	if b.isLiteral() && other.isLiteral() {
+		return Bool(b.value || other.value)
 	}
 	return &BoolRef{
 		baseRef: baseRef{
-			name:         "",
-			isSecret:     false,
-			isComputed:   true,
-			rawExpression: fmt.Sprintf("(%s or %s)", left, right),
+			isComputed:    true,
+			rawExpression: fmt.Sprintf("(%s or %s)", b.operand(), other.operand()),
 		},
-		value: false,
 	}
 }
 
 // Not creates a new BoolRef that negates this boolean.
-// It generates a JQ expression for runtime evaluation.
+// A literal is negated at synthesis time; otherwise it generates a JQ
+// expression for runtime evaluation.
 //
 // Example:
 //
@@ -575,20 +630,31 @@ func (b *BoolRef) Or(other *BoolRef) *BoolRef {
 //	isDisabled := isEnabled.Not()
 //	// Result: "${ ($context.isEnabled | not) }"
 func (b *BoolRef) Not() *BoolRef {
-	var expr string
-	if b.isComputed {
-		expr = fmt.Sprintf("(%s | not)", b.rawExpression)
-	} else {
-		expr = fmt.Sprintf("($context.%s | not)", b.name)
+	if b.isLiteral() {
+		return Bool(!b.value)
 	}
 	return &BoolRef{
 		baseRef: baseRef{
-			name:         "",
-			isSecret:     false,
-			isComputed:   true,
-			rawExpression: expr,
+			isComputed:    true,
+			rawExpression: fmt.Sprintf("(%s | not)", b.operand()),
 		},
-		value: false,
+	}
+}
+
+// isLiteral reports whether this BoolRef is a synthesis-time constant.
+func (b *BoolRef) isLiteral() bool {
+	return !b.isComputed && b.name == ""
+}
+
+// operand returns the JQ fragment for this BoolRef inside a larger expression.
+func (b *BoolRef) operand() string {
+	switch {
+	case b.isComputed:
+		return b.rawExpression
+	case b.name == "":
+		return fmt.Sprintf("%t", b.value)
+	default:
+		return fmt.Sprintf("$context.%s", b.name)
 	}
 }
 
@@ -726,3 +792,66 @@ func (o *ObjectRef) FieldAsBool(fields ...string) *BoolRef {
 		value: false,
 	}
 }
+
+// =============================================================================
+// ListRef - Reference to a list value
+// =============================================================================
+
+// ListRef represents a reference to a list (array) value in the workflow
+// context. Like ObjectRef, a list known at synthesis is expanded into its
+// elements where it is used as a task value.
+//
+// Example:
+//
+//	regions := ctx.SetList("regions", []interface{}{"eu-west-1", "us-east-1"})
+//	first := regions.Index(0)
+//	// Result: "${ $context.regions[0] }"
+type ListRef struct {
+	baseRef
+	value []interface{} // Initial value (used during synthesis)
+}
+
+// Value returns the initial value of this list reference (used during synthesis).
+func (l *ListRef) Value() []interface{} {
+	return l.value
+}
+
+// ToValue implements Ref.ToValue() for synthesis/serialization.
+// Returns the list value as interface{} for JSON serialization.
+func (l *ListRef) ToValue() interface{} {
+	return l.value
+}
+
+// Index accesses an element of the list and returns it as an ObjectRef.
+// It generates a JQ expression for runtime element access.
+//
+// Example:
+//
+//	servers := ctx.SetList("servers", serverList)
+//	primary := servers.Index(0).FieldAsString("host")
+//	// Result: "${ (($context.servers[0]).host) }"
+func (l *ListRef) Index(i int) *ObjectRef {
+	var expr string
+	if l.isComputed {
+		expr = fmt.Sprintf("(%s[%d])", l.rawExpression, i)
+	} else {
+		expr = fmt.Sprintf("($context.%s[%d])", l.name, i)
+	}
+	return &ObjectRef{
+		baseRef: baseRef{
+			name:          "",
+			isSecret:      l.isSecret,
+			isComputed:    true,
+			rawExpression: expr,
+		},
+		value: nil, // Element value, not known at synthesis time
+	}
+}
+
+// taskFieldExpression returns the raw JQ expression (without ${ }) for a
+// task output field.
+func taskFieldExpression(ref workflow.TaskFieldRef) string {
+	expr := strings.TrimPrefix(ref.Expression(), "${")
+	expr = strings.TrimSuffix(expr, "}")
+	return strings.TrimSpace(expr)
+}
@@ -1,7 +1,13 @@
 package stigmer
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
 )
 
 // =============================================================================
@@ -281,6 +287,67 @@ func TestIntRef_Divide(t *testing.T) {
 	}
 }
 
+func TestIntRef_LiteralArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *IntRef
+		expected int
+	}{
+		{"add", Int(30).Add(Int(10)), 40},
+		{"subtract", Int(30).Subtract(Int(10)), 20},
+		{"multiply", Int(3).Multiply(Int(2)), 6},
+		{"exact divide", Int(30).Divide(Int(10)), 3},
+		{"chained", Int(3).Multiply(Int(2)).Add(Int(1)), 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result.IsComputed() {
+				t.Fatalf("literal arithmetic should resolve at synthesis time, got %q", tt.result.Expression())
+			}
+			if got := tt.result.Value(); got != tt.expected {
+				t.Errorf("Value() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIntRef_InexactDivideStaysRuntime(t *testing.T) {
+	// JQ division is not integer division, so 7 / 2 must not be folded to 3
+	result := Int(7).Divide(Int(2))
+	expected := "${ (7 / 2) }"
+
+	if got := result.Expression(); got != expected {
+		t.Errorf("Divide() expression = %q, want %q", got, expected)
+	}
+}
+
+func TestIntRef_LiteralWithContextVariable(t *testing.T) {
+	timeout := &IntRef{
+		baseRef: baseRef{name: "timeout"},
+		value:   30,
+	}
+
+	result := timeout.Add(Int(10))
+	expected := "${ ($context.timeout + 10) }"
+
+	if got := result.Expression(); got != expected {
+		t.Errorf("Add() expression = %q, want %q", got, expected)
+	}
+}
+
+func TestIntRef_TaskField(t *testing.T) {
+	fetch := &workflow.Task{Name: "fetch"}
+	count := IntField(fetch.Field("count"))
+
+	result := count.Multiply(Int(2))
+	expected := `${ ($context["fetch"].count * 2) }`
+
+	if got := result.Expression(); got != expected {
+		t.Errorf("Multiply() expression = %q, want %q", got, expected)
+	}
+}
+
 // =============================================================================
 // BoolRef Tests
 // =============================================================================
@@ -370,6 +437,49 @@ func TestBoolRef_Not(t *testing.T) {
 	}
 }
 
+func TestBoolRef_LiteralLogic(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *BoolRef
+		expected bool
+	}{
+		{"and", Bool(true).And(Bool(false)), false},
+		{"or", Bool(true).Or(Bool(false)), true},
+		{"not", Bool(false).Not(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.result.IsComputed() {
+				t.Fatalf("literal logic should resolve at synthesis time, got %q", tt.result.Expression())
+			}
+			if got := tt.result.Value(); got != tt.expected {
+				t.Errorf("Value() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	if got := Bool(true).Expression(); got != "${ true }" {
+		t.Errorf("literal Expression() = %q, want %q", got, "${ true }")
+	}
+}
+
+func TestBoolRef_TaskFieldCondition(t *testing.T) {
+	review := &workflow.Task{Name: "review"}
+	isProd := &BoolRef{
+		baseRef: baseRef{name: "isProd"},
+		value:   true,
+	}
+
+	// BoolRefs satisfy workflow.ConditionMatcher, so the result can drive a switch case
+	var condition workflow.ConditionMatcher = BoolField(review.Field("approved")).And(isProd.Not())
+	expected := `${ ($context["review"].approved and ($context.isProd | not)) }`
+
+	if got := condition.Expression(); got != expected {
+		t.Errorf("And() expression = %q, want %q", got, expected)
+	}
+}
+
 // =============================================================================
 // ObjectRef Tests
 // =============================================================================
@@ -498,6 +608,154 @@ func TestObjectRef_FieldAsBool(t *testing.T) {
 	}
 }
 
+func TestObjectRef_TaskValues(t *testing.T) {
+	defaults := &ObjectRef{
+		baseRef: baseRef{name: "defaults"},
+		value: map[string]interface{}{
+			"region": "eu-west-1",
+			"limits": map[string]interface{}{"cpu": 2},
+			"tags":   []string{"billing", "prod"},
+		},
+	}
+	regions := &ListRef{
+		baseRef: baseRef{name: "regions"},
+		value:   []interface{}{"eu-west-1", map[string]interface{}{"name": "us-east-1"}},
+	}
+
+	wf, err := workflow.New(nil, "ops/provision", &workflow.WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("workflow.New() error = %v", err)
+	}
+	wf.SetVars("init", "defaults", defaults)
+	wf.HttpPost("create", "https://api.example.com/servers", nil, map[string]interface{}{
+		"defaults": defaults,
+		"regions":  regions,
+	})
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+
+	wantDefaults := map[string]interface{}{
+		"region": "eu-west-1",
+		"limits": map[string]interface{}{"cpu": float64(2)},
+		"tags":   []interface{}{"billing", "prod"},
+	}
+	wantRegions := []interface{}{"eu-west-1", map[string]interface{}{"name": "us-east-1"}}
+
+	variables := proto.Spec.Tasks[0].TaskConfig.Fields["variables"].GetStructValue()
+	if got := variables.Fields["defaults"].GetStructValue(); got == nil {
+		t.Fatalf("SetVars value = %v, want a nested struct", variables.Fields["defaults"])
+	} else if !reflect.DeepEqual(got.AsMap(), wantDefaults) {
+		t.Errorf("SetVars value = %v, want %v", got.AsMap(), wantDefaults)
+	}
+
+	body := proto.Spec.Tasks[1].TaskConfig.Fields["body"].GetStructValue()
+	if got := body.Fields["defaults"].GetStructValue(); got == nil {
+		t.Fatalf("body defaults = %v, want a nested struct", body.Fields["defaults"])
+	} else if !reflect.DeepEqual(got.AsMap(), wantDefaults) {
+		t.Errorf("body defaults = %v, want %v", got.AsMap(), wantDefaults)
+	}
+	if got := body.Fields["regions"].GetListValue(); got == nil {
+		t.Fatalf("body regions = %v, want a list", body.Fields["regions"])
+	} else if !reflect.DeepEqual(got.AsSlice(), wantRegions) {
+		t.Errorf("body regions = %v, want %v", got.AsSlice(), wantRegions)
+	}
+}
+
+func TestObjectRef_RuntimeMembers(t *testing.T) {
+	newWorkflow := func(t *testing.T) (*workflow.Workflow, *workflow.Task) {
+		t.Helper()
+		wf, err := workflow.New(nil, "ops/review", &workflow.WorkflowArgs{Version: "1.0.0"})
+		if err != nil {
+			t.Fatalf("workflow.New() error = %v", err)
+		}
+		return wf, wf.HttpGet("fetch", "https://api.example.com/pr", nil)
+	}
+
+	t.Run("body keeps runtime members as expressions", func(t *testing.T) {
+		wf, fetch := newWorkflow(t)
+		payload := &ObjectRef{
+			baseRef: baseRef{name: "payload"},
+			value:   map[string]interface{}{"source": "ci", "id": fetch.Field("id")},
+		}
+		wf.HttpPost("notify", "https://hooks.example.com", nil, map[string]interface{}{"payload": payload})
+
+		proto, err := wf.ToProto()
+		if err != nil {
+			t.Fatalf("ToProto() error = %v", err)
+		}
+		body := proto.Spec.Tasks[1].TaskConfig.Fields["body"].GetStructValue()
+		want := map[string]interface{}{"source": "ci", "id": `${ $context["fetch"].id }`}
+		if got := body.Fields["payload"].GetStructValue().AsMap(); !reflect.DeepEqual(got, want) {
+			t.Errorf("body payload = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("output schema expands known objects", func(t *testing.T) {
+		wf, _ := newWorkflow(t)
+		issue := &ObjectRef{
+			baseRef: baseRef{name: "issueSchema"},
+			value:   map[string]interface{}{"severity": "string"},
+		}
+		wf.CallAgent("review", &workflow.AgentCallArgs{
+			Agent:   "code-reviewer",
+			Message: "Review the PR",
+			Config:  &types.AgentExecutionConfig{Timeout: 300, OutputSchema: map[string]any{"issue": issue}},
+		})
+
+		proto, err := wf.ToProto()
+		if err != nil {
+			t.Fatalf("ToProto() error = %v", err)
+		}
+		schema := proto.Spec.Tasks[1].TaskConfig.Fields["config"].GetStructValue().Fields["output_schema"].GetStructValue().AsMap()
+		issueSchema, _ := schema["properties"].(map[string]interface{})["issue"].(map[string]interface{})
+		if issueSchema["type"] != "object" {
+			t.Errorf("output_schema issue = %v, want the expanded object schema", issueSchema)
+		}
+	})
+
+	t.Run("output schema rejects runtime members", func(t *testing.T) {
+		wf, fetch := newWorkflow(t)
+		issue := &ObjectRef{
+			baseRef: baseRef{name: "issueSchema"},
+			value:   map[string]interface{}{"severity": fetch.Field("severityType")},
+		}
+		wf.CallAgent("review", &workflow.AgentCallArgs{
+			Agent:   "code-reviewer",
+			Message: "Review the PR",
+			Config:  &types.AgentExecutionConfig{Timeout: 300, OutputSchema: map[string]any{"issue": issue}},
+		})
+
+		_, err := wf.ToProto()
+		if !errors.Is(err, workflow.ErrRuntimeValueInLiteral) {
+			t.Fatalf("ToProto() error = %v, want ErrRuntimeValueInLiteral", err)
+		}
+		if !strings.Contains(err.Error(), "output_schema.issue.severity") {
+			t.Errorf("error %q does not name the runtime member", err)
+		}
+	})
+}
+
+// =============================================================================
+// ListRef Tests
+// =============================================================================
+
+func TestListRef_Index(t *testing.T) {
+	servers := &ListRef{
+		baseRef: baseRef{name: "servers"},
+		value:   []interface{}{map[string]interface{}{"host": "a.example.com"}},
+	}
+
+	if got, want := servers.Index(0).Expression(), "${ ($context.servers[0]) }"; got != want {
+		t.Errorf("Index() expression = %q, want %q", got, want)
+	}
+	if got, want := servers.Index(1).FieldAsString("host").Expression(), "${ (($context.servers[1]).host) }"; got != want {
+		t.Errorf("Index().FieldAsString() expression = %q, want %q", got, want)
+	}
+}
+
 // =============================================================================
 // Integration Tests - Complex Scenarios
 // =============================================================================
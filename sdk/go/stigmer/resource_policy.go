@@ -0,0 +1,274 @@
+package stigmer
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// ErrResourcePolicy is returned when synthesis fails because a resource
+// violates a policy given to WithResourcePolicy.
+var ErrResourcePolicy = errors.New("resource policy violation")
+
+// LintRuleResourcePolicy reports resources that violate a policy given to
+// WithResourcePolicy.
+const LintRuleResourcePolicy = "resource-policy"
+
+// ResourceInfo describes a resource checked by a ResourcePolicy.
+type ResourceInfo struct {
+	// Kind is the kind of the resource (ManifestKindAgent or
+	// ManifestKindWorkflow).
+	Kind ManifestKind
+
+	// Name is the resource name, without the namespace of workflows.
+	Name string
+
+	// Namespace is the workflow namespace ("" for agents).
+	Namespace string
+
+	// Org is the organization of the resource, after SetDefaultOrg and
+	// scope defaults are applied ("" if none).
+	Org string
+
+	// Labels are the labels of the resource (nil for agents). Policies must
+	// not modify them.
+	Labels map[string]string
+
+	// Scope is the name of the scope the resource was created in ("" for
+	// the root context).
+	Scope string
+}
+
+// ResourcePolicy checks a resource during synthesis. A returned error fails
+// synthesis, with its message reported on the resource; wrap it with
+// PolicyWarning to report it without failing synthesis.
+type ResourcePolicy func(r ResourceInfo) error
+
+// policyWarning marks a policy error reported with warning severity.
+type policyWarning struct {
+	err error
+}
+
+func (w *policyWarning) Error() string {
+	return w.err.Error()
+}
+
+func (w *policyWarning) Unwrap() error {
+	return w.err
+}
+
+// PolicyWarning makes a ResourcePolicy error a warning: it is reported like
+// lint warnings but does not fail synthesis. Returns nil if err is nil.
+//
+// Example:
+//
+//	func(r stigmer.ResourceInfo) error {
+//	    if r.Labels["owner"] == "" {
+//	        return stigmer.PolicyWarning(errors.New("no owner label; required from next quarter"))
+//	    }
+//	    return nil
+//	}
+func PolicyWarning(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &policyWarning{err: err}
+}
+
+// ResourcePolicyError carries the policy findings that failed synthesis.
+// It matches ErrResourcePolicy with errors.Is.
+type ResourcePolicyError struct {
+	// Findings lists the findings of error severity.
+	Findings []workflow.LintFinding
+}
+
+func (e *ResourcePolicyError) Error() string {
+	lines := make([]string, len(e.Findings))
+	for i, f := range e.Findings {
+		lines[i] = f.String()
+	}
+	return fmt.Sprintf("%v:\n  %s", ErrResourcePolicy, strings.Join(lines, "\n  "))
+}
+
+func (e *ResourcePolicyError) Unwrap() error {
+	return ErrResourcePolicy
+}
+
+// WithResourcePolicy checks every agent and workflow against the policies
+// during synthesis, whether or not linting is enabled with WithLint. Skills,
+// including the inline skills of agents, are not checked.
+//
+// Violations are reported as resource-policy findings of error severity,
+// alongside lint findings in the ValidationFinished event, and fail
+// synthesis with a ResourcePolicyError. Errors wrapped with PolicyWarning are
+// reported as warnings instead. Unlike lint rules, policies cannot be
+// suppressed with SuppressLint. The option may be given multiple times;
+// policies run in order.
+//
+// Example:
+//
+//	err := stigmer.RunWithOptions(fn, stigmer.WithResourcePolicy(
+//	    stigmer.ForKinds(stigmer.NamePrefix("pay-", "risk-"), stigmer.ManifestKindAgent),
+//	    stigmer.NamespaceAllowlist("payments", "risk"),
+//	    stigmer.RequiredLabels("team"),
+//	))
+func WithResourcePolicy(policies ...ResourcePolicy) RunOption {
+	return func(o *runOptions) {
+		for _, policy := range policies {
+			if policy != nil {
+				o.resourcePolicies = append(o.resourcePolicies, policy)
+			}
+		}
+	}
+}
+
+// NamePrefix requires resource names to start with one of the prefixes.
+//
+// Example:
+//
+//	stigmer.NamePrefix("pay-", "risk-")  // "pay-reviewer" passes, "reviewer" fails
+func NamePrefix(prefixes ...string) ResourcePolicy {
+	return func(r ResourceInfo) error {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(r.Name, prefix) {
+				return nil
+			}
+		}
+		return fmt.Errorf("name %q must start with one of %s", r.Name, quoteAll(prefixes))
+	}
+}
+
+// NamespaceAllowlist requires workflow namespaces to be one of the given
+// namespaces. Agents have no namespace and always pass.
+//
+// Example:
+//
+//	stigmer.NamespaceAllowlist("payments", "risk")
+func NamespaceAllowlist(namespaces ...string) ResourcePolicy {
+	return func(r ResourceInfo) error {
+		if r.Namespace == "" || slices.Contains(namespaces, r.Namespace) {
+			return nil
+		}
+		return fmt.Errorf("namespace %q is not one of %s", r.Namespace, quoteAll(namespaces))
+	}
+}
+
+// RequiredLabels requires resources to have a non-empty value for each label
+// key. Agents have no labels, so combine it with ForKinds to check workflows
+// only in projects that define agents.
+//
+// Example:
+//
+//	stigmer.ForKinds(stigmer.RequiredLabels("team", "cost-center"), stigmer.ManifestKindWorkflow)
+func RequiredLabels(keys ...string) ResourcePolicy {
+	return func(r ResourceInfo) error {
+		var missing []string
+		for _, key := range keys {
+			if r.Labels[key] == "" {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("missing required labels %s", quoteAll(missing))
+		}
+		return nil
+	}
+}
+
+// ForKinds restricts a policy to resources of the given kinds; resources of
+// other kinds pass.
+//
+// Example:
+//
+//	stigmer.ForKinds(stigmer.NamePrefix("pay-"), stigmer.ManifestKindAgent)
+func ForKinds(policy ResourcePolicy, kinds ...ManifestKind) ResourcePolicy {
+	return func(r ResourceInfo) error {
+		if !slices.Contains(kinds, r.Kind) {
+			return nil
+		}
+		return policy(r)
+	}
+}
+
+// lintResourcePolicies returns the resource-policy findings of the agents
+// and workflows of the context and its scopes.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) lintResourcePolicies() []workflow.LintFinding {
+	if len(c.resourcePolicies) == 0 {
+		return nil
+	}
+
+	contexts := []*Context{c}
+	for _, scope := range c.scopes {
+		scope.mu.RLock()
+		defer scope.mu.RUnlock()
+		contexts = append(contexts, scope)
+	}
+
+	var findings []workflow.LintFinding
+	for _, ctx := range contexts {
+		for _, ag := range ctx.agents {
+			info := ResourceInfo{
+				Kind:  ManifestKindAgent,
+				Name:  ag.Name,
+				Org:   ag.Org,
+				Scope: ctx.ScopeName(),
+			}
+			for _, f := range c.checkResourcePolicies(info) {
+				f.Agent = ag.Name
+				findings = append(findings, f)
+			}
+		}
+		for _, wf := range ctx.workflows {
+			info := ResourceInfo{
+				Kind:      ManifestKindWorkflow,
+				Name:      wf.Document.Name,
+				Namespace: wf.Document.Namespace,
+				Org:       wf.Org,
+				Labels:    maps.Clone(wf.Labels),
+				Scope:     ctx.ScopeName(),
+			}
+			for _, f := range c.checkResourcePolicies(info) {
+				f.Workflow = wf.Document.Name
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings
+}
+
+// checkResourcePolicies runs the policies against a resource and returns a
+// finding per violation, without its location.
+func (c *Context) checkResourcePolicies(info ResourceInfo) []workflow.LintFinding {
+	var findings []workflow.LintFinding
+	for _, policy := range c.resourcePolicies {
+		err := policy(info)
+		if err == nil {
+			continue
+		}
+		severity := workflow.LintSeverityError
+		var warning *policyWarning
+		if errors.As(err, &warning) {
+			severity = workflow.LintSeverityWarning
+		}
+		findings = append(findings, workflow.LintFinding{
+			RuleID:   LintRuleResourcePolicy,
+			Severity: severity,
+			Message:  err.Error(),
+		})
+	}
+	return findings
+}
+
+// quoteAll formats values as a comma-separated list of quoted strings.
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
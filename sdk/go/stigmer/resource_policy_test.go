@@ -0,0 +1,167 @@
+package stigmer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+func TestWithResourcePolicy_FailsSynthesis(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var captured *Context
+	err := RunWithOptions(func(ctx *Context) error {
+		captured = ctx
+		registerTestAgent(ctx, "pay-reviewer")
+		registerTestAgent(ctx, "reviewer")
+		_, err := workflow.New(ctx, "payments/settle", &workflow.WorkflowArgs{
+			Version: "1.0.0",
+			Labels:  map[string]string{"team": "pay"},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = workflow.New(ctx, "sandbox/experiment", &workflow.WorkflowArgs{Version: "1.0.0"})
+		return err
+	}, WithResourcePolicy(
+		ForKinds(NamePrefix("pay-"), ManifestKindAgent),
+		NamespaceAllowlist("payments", "risk"),
+		ForKinds(RequiredLabels("team"), ManifestKindWorkflow),
+	))
+	if !errors.Is(err, ErrResourcePolicy) {
+		t.Fatalf("RunWithOptions() error = %v, want ErrResourcePolicy", err)
+	}
+
+	var policyErr *ResourcePolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("RunWithOptions() error = %v, want a *ResourcePolicyError", err)
+	}
+	got := map[string][]string{}
+	for _, f := range policyErr.Findings {
+		if f.RuleID != LintRuleResourcePolicy || f.Severity != workflow.LintSeverityError {
+			t.Errorf("unexpected finding %v", f)
+		}
+		got[f.Agent+f.Workflow] = append(got[f.Agent+f.Workflow], f.Message)
+	}
+	if len(policyErr.Findings) != 3 || len(got["reviewer"]) != 1 || len(got["experiment"]) != 2 {
+		t.Errorf("findings = %v, want one for agent reviewer and two for workflow experiment", policyErr.Findings)
+	}
+	if !strings.Contains(policyErr.Error(), `agent "reviewer": name "reviewer" must start with one of "pay-"`) {
+		t.Errorf("error = %q, want the policy message on the agent", policyErr)
+	}
+
+	// Violations are reported with the lint findings even with linting off
+	if findings := captured.LintFindings(); len(findings) != 3 {
+		t.Errorf("LintFindings() = %v, want the 3 policy findings", findings)
+	}
+}
+
+func TestWithResourcePolicy_Passes(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var infos []ResourceInfo
+	err := RunWithOptions(func(ctx *Context) error {
+		ctx.SetDefaultOrg("acme")
+		registerTestAgent(ctx, "pay-reviewer")
+		wf, err := workflow.New(ctx.Scope("risk", WithOrg("risk-org")), "risk/score", &workflow.WorkflowArgs{
+			Version: "1.0.0",
+			Labels:  map[string]string{"team": "risk"},
+		})
+		if err != nil {
+			return err
+		}
+		wf.HttpGet("fetch", "https://api.example.com/score", nil)
+		return nil
+	}, WithResourcePolicy(
+		func(r ResourceInfo) error {
+			infos = append(infos, r)
+			return nil
+		},
+		NamespaceAllowlist("payments", "risk"),
+		ForKinds(RequiredLabels("team"), ManifestKindWorkflow),
+	))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	if len(infos) != 2 {
+		t.Fatalf("policy called with %v, want the agent and the workflow", infos)
+	}
+	if r := infos[0]; r.Kind != ManifestKindAgent || r.Name != "pay-reviewer" || r.Org != "acme" || r.Scope != "" {
+		t.Errorf("agent info = %+v", r)
+	}
+	r := infos[1]
+	if r.Kind != ManifestKindWorkflow || r.Name != "score" || r.Namespace != "risk" || r.Org != "risk-org" || r.Scope != "risk" {
+		t.Errorf("workflow info = %+v", r)
+	}
+	if r.Labels["team"] != "risk" {
+		t.Errorf("workflow labels = %v, want team=risk", r.Labels)
+	}
+}
+
+func TestWithResourcePolicy_Warning(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var findings []workflow.LintFinding
+	err := RunWithOptions(func(ctx *Context) error {
+		registerTestAgent(ctx, "reviewer")
+		return nil
+	}, WithResourcePolicy(func(r ResourceInfo) error {
+		return PolicyWarning(errors.New("no team prefix; required from next quarter"))
+	}), WithEventHandler(func(e Event) {
+		if finished, ok := e.(ValidationFinished); ok {
+			findings = finished.Findings
+		}
+	}))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v, want warnings not to fail synthesis", err)
+	}
+
+	// Warnings reach the handlers like lint findings
+	if len(findings) != 1 || findings[0].Severity != workflow.LintSeverityWarning || findings[0].Agent != "reviewer" {
+		t.Errorf("LintFindings() = %v, want a resource-policy warning on agent reviewer", findings)
+	}
+}
+
+func TestResourcePolicies(t *testing.T) {
+	agent := ResourceInfo{Kind: ManifestKindAgent, Name: "pay-reviewer"}
+	wf := ResourceInfo{
+		Kind:      ManifestKindWorkflow,
+		Name:      "settle",
+		Namespace: "payments",
+		Labels:    map[string]string{"team": "pay", "cost-center": ""},
+	}
+
+	tests := []struct {
+		name    string
+		policy  ResourcePolicy
+		info    ResourceInfo
+		wantErr string
+	}{
+		{name: "prefix match", policy: NamePrefix("risk-", "pay-"), info: agent},
+		{name: "prefix mismatch", policy: NamePrefix("risk-"), info: wf, wantErr: `name "settle" must start with one of "risk-"`},
+		{name: "namespace allowed", policy: NamespaceAllowlist("payments"), info: wf},
+		{name: "namespace denied", policy: NamespaceAllowlist("risk", "ops"), info: wf, wantErr: `namespace "payments" is not one of "risk", "ops"`},
+		{name: "agent without namespace", policy: NamespaceAllowlist("risk"), info: agent},
+		{name: "labels present", policy: RequiredLabels("team"), info: wf},
+		{name: "empty label", policy: RequiredLabels("team", "cost-center", "owner"), info: wf, wantErr: `missing required labels "cost-center", "owner"`},
+		{name: "other kind", policy: ForKinds(RequiredLabels("team"), ManifestKindWorkflow), info: agent},
+		{name: "matching kind", policy: ForKinds(NamePrefix("risk-"), ManifestKindAgent), info: agent, wantErr: "must start with"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy(tt.info)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("policy() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("policy() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
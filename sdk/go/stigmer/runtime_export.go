@@ -0,0 +1,167 @@
+package stigmer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// ErrRuntimeExport is returned when synthesis fails because ExportToRuntime
+// named a variable that cannot be exported.
+var ErrRuntimeExport = errors.New("cannot export variable to runtime environment")
+
+// RuntimeExportError reports a variable passed to ExportToRuntime that is
+// unknown or secret. It matches ErrRuntimeExport with errors.Is.
+type RuntimeExportError struct {
+	// Name is the variable name.
+	Name string
+
+	// ExportedAt is the call site ("file.go:line") of ExportToRuntime.
+	ExportedAt string
+
+	// Reason explains why the variable cannot be exported.
+	Reason string
+}
+
+func (e *RuntimeExportError) Error() string {
+	return fmt.Sprintf("%v: ExportToRuntime at %s exports %q: %s", ErrRuntimeExport, e.ExportedAt, e.Name, e.Reason)
+}
+
+func (e *RuntimeExportError) Unwrap() error {
+	return ErrRuntimeExport
+}
+
+// runtimeExport is a variable name passed to ExportToRuntime.
+type runtimeExport struct {
+	name       string
+	exportedAt string
+}
+
+// ExportToRuntime makes the resolved values of context variables available
+// to workflows at runtime, so configuration needed at synthesis and at
+// runtime is declared once.
+//
+// At synthesis, every workflow of the context declares each exported
+// variable in its environment spec, as a non-secret variable whose default
+// is the resolved value. The values are visible in the workflow manifest,
+// can be overridden per execution with --runtime-env, and are referenced in
+// tasks with workflow.RuntimeEnv(name). A variable the workflow declares
+// itself is left unchanged.
+//
+// Secrets cannot be exported: runtime env defaults are stored in plaintext.
+// Exporting a secret or an unknown variable fails synthesis with a
+// RuntimeExportError.
+//
+// Example:
+//
+//	ctx.SetString("apiBase", "https://api.example.com")
+//	ctx.SetString("org", "acme")
+//	ctx.ExportToRuntime("apiBase", "org")
+//
+//	wf.HttpGet("fetch", workflow.Interpolate(workflow.RuntimeEnv("apiBase"), "/users"))
+func (c *Context) ExportToRuntime(names ...string) {
+	c.mustBeOpen("ExportToRuntime")
+	exportedAt := callerLocation()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, name := range names {
+		c.runtimeExports = append(c.runtimeExports, runtimeExport{name: name, exportedAt: exportedAt})
+	}
+}
+
+// checkRuntimeExports fails synthesis if the context or one of its scopes
+// exports an unknown or secret variable.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) checkRuntimeExports() error {
+	// Scopes start with a copy of the exports of their root: report each
+	// failing export once
+	var errs []error
+	var messages []string
+	seen := make(map[runtimeExport]bool)
+	collect := func(exports []runtimeExport, variables map[string]Ref) {
+		for _, err := range runtimeExportErrors(exports, variables) {
+			key := runtimeExport{name: err.Name, exportedAt: err.ExportedAt}
+			if !seen[key] {
+				seen[key] = true
+				errs = append(errs, err)
+				messages = append(messages, err.Error())
+			}
+		}
+	}
+
+	collect(c.runtimeExports, c.variables)
+	for _, scope := range c.scopes {
+		scope.mu.RLock()
+		collect(scope.runtimeExports, scope.variables)
+		scope.mu.RUnlock()
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return validation.NewSynthesisErrorWithCause(
+		"config",
+		strings.Join(messages, "; "),
+		errors.Join(errs...),
+	)
+}
+
+// runtimeExportErrors checks exports against the variables they name.
+func runtimeExportErrors(exports []runtimeExport, variables map[string]Ref) []*RuntimeExportError {
+	var errs []*RuntimeExportError
+	for _, export := range exports {
+		ref, ok := variables[export.name]
+		switch {
+		case !ok:
+			errs = append(errs, &RuntimeExportError{
+				Name:       export.name,
+				ExportedAt: export.exportedAt,
+				Reason:     "no context variable has this name",
+			})
+		case ref.IsSecret():
+			errs = append(errs, &RuntimeExportError{
+				Name:       export.name,
+				ExportedAt: export.exportedAt,
+				Reason: fmt.Sprintf("it is a secret (set at %s) and runtime env defaults are stored in plaintext; "+
+					"reference it with workflow.RuntimeSecret(%q) and pass it with --runtime-env secret:%s=<value>",
+					setLocation(ref), export.name, export.name),
+			})
+		}
+	}
+	return errs
+}
+
+// applyRuntimeExports declares the exported variables in the environment
+// spec of a synthesized workflow.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) applyRuntimeExports(spec *workflowv1.WorkflowSpec) {
+	if len(c.runtimeExports) == 0 || spec == nil {
+		return
+	}
+	if spec.EnvSpec == nil {
+		spec.EnvSpec = &environmentv1.EnvironmentSpec{}
+	}
+	if spec.EnvSpec.Data == nil {
+		spec.EnvSpec.Data = make(map[string]*environmentv1.EnvironmentValue)
+	}
+
+	for _, export := range c.runtimeExports {
+		if _, declared := spec.EnvSpec.Data[export.name]; declared {
+			continue
+		}
+		ref, ok := c.variables[export.name]
+		if !ok || ref.IsSecret() {
+			continue
+		}
+		spec.EnvSpec.Data[export.name] = &environmentv1.EnvironmentValue{
+			Value:       displayValue(ref),
+			Description: "Exported from context variable " + export.name,
+		}
+	}
+}
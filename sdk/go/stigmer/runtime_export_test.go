@@ -0,0 +1,100 @@
+package stigmer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/sdk/go/environment"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+func TestExportToRuntime(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var wf workflowv1.Workflow
+	sink := func(kind ManifestKind, data []byte) error {
+		if kind == ManifestKindWorkflow {
+			return proto.Unmarshal(data, &wf)
+		}
+		return nil
+	}
+
+	err := RunWithOptions(func(ctx *Context) error {
+		ctx.SetString("apiBase", "https://api.example.com")
+		ctx.SetString("REGION", "eu-west-1")
+		ctx.SetInt("retries", 3)
+		ctx.ExportToRuntime("apiBase", "REGION", "retries")
+
+		region, err := environment.New(ctx, "REGION", &environment.VariableArgs{DefaultValue: "declared"})
+		if err != nil {
+			return err
+		}
+		w, err := workflow.New(ctx, "ops/sync", &workflow.WorkflowArgs{
+			EnvironmentVariables: []environment.Variable{*region},
+		})
+		if err != nil {
+			return err
+		}
+		w.SetVars("init", "base", workflow.RuntimeEnv("apiBase"))
+		return nil
+	}, WithManifestSink(sink))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	data := wf.GetSpec().GetEnvSpec().GetData()
+	if got := data["apiBase"]; got.GetValue() != "https://api.example.com" || got.GetIsSecret() {
+		t.Errorf("apiBase = %v, want a non-secret default", got)
+	}
+	if got := data["retries"].GetValue(); got != "3" {
+		t.Errorf("retries = %q, want 3", got)
+	}
+	// Variables declared by the workflow are left unchanged
+	if got := data["REGION"].GetValue(); got != "declared" {
+		t.Errorf("REGION = %q, want the workflow's own default", got)
+	}
+}
+
+func TestExportToRuntime_RejectsSecretsAndUnknownVariables(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	err := Run(func(ctx *Context) error {
+		ctx.SetSecret("apiKey", "secret-key-123")
+		ctx.ExportToRuntime("apiKey", "missing")
+		return nil
+	})
+	if !errors.Is(err, ErrRuntimeExport) {
+		t.Fatalf("Run() error = %v, want ErrRuntimeExport", err)
+	}
+
+	var exportErr *RuntimeExportError
+	if !errors.As(err, &exportErr) || exportErr.Name != "apiKey" {
+		t.Fatalf("Run() error = %v, want a RuntimeExportError for apiKey", err)
+	}
+	for _, want := range []string{
+		"runtime_export_test.go:",
+		`workflow.RuntimeSecret("apiKey")`,
+		`exports "missing": no context variable has this name`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Run() error = %q, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestExportToRuntime_ScopesReportOnce(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	err := Run(func(ctx *Context) error {
+		ctx.ExportToRuntime("missing")
+		ctx.Scope("team-a")
+		return nil
+	})
+	if err == nil || strings.Count(err.Error(), `exports "missing"`) != 1 {
+		t.Errorf("Run() error = %v, want the export reported once", err)
+	}
+}
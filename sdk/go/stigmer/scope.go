@@ -0,0 +1,215 @@
+package stigmer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"github.com/stigmer/stigmer/sdk/go/stigmer/naming"
+)
+
+// ErrDuplicateResourceName is returned when two resources of the same kind
+// share a name within one scope.
+var ErrDuplicateResourceName = errors.New("duplicate resource name in scope")
+
+// ScopeOption configures a scope created with Context.Scope.
+type ScopeOption func(*scopeOptions)
+
+// scopeOptions holds the settings of a scoped Context.
+type scopeOptions struct {
+	name   string
+	outDir string
+	org    string
+}
+
+// WithOutDir sets the directory a scope's manifests are written to.
+//
+// Relative paths are resolved against STIGMER_OUT_DIR. Defaults to a
+// subdirectory named after the scope.
+func WithOutDir(dir string) ScopeOption {
+	return func(o *scopeOptions) {
+		o.outDir = dir
+	}
+}
+
+// WithOrg sets the default organization of resources created in a scope.
+// Resources that set their own Org keep it.
+func WithOrg(org string) ScopeOption {
+	return func(o *scopeOptions) {
+		o.org = org
+	}
+}
+
+// Scope returns a Context whose resources are synthesized into their own
+// manifest directory, separately from the resources of the parent context.
+// Use it to split agents and workflows of several teams or projects defined
+// in one program.
+//
+// The scoped Context starts with a copy of the parent's variables and of
+// its ExportToRuntime exports. Resource
+// names must be unique within a scope; the same name may be reused in
+// different scopes. Calling Scope again with the same name returns the
+// existing scope, and scopes cannot be nested: calling Scope on a scoped
+// Context creates a sibling scope.
+//
+// Manifests of the parent context are written to STIGMER_OUT_DIR exactly as
+// without scopes. Sinks registered via WithManifestSink receive the parent's
+// manifests first, then each scope's manifests in creation order, each
+// ending with that scope's dependency graph.
+//
+// Example:
+//
+//	stigmer.Run(func(ctx *stigmer.Context) error {
+//	    teamA := ctx.Scope("team-a",
+//	        stigmer.WithOutDir("out/team-a"),
+//	        stigmer.WithOrg("team-a-org"),
+//	    )
+//	    _, err := agent.New(teamA, &agent.AgentArgs{...})
+//	    return err
+//	})
+func (c *Context) Scope(name string, opts ...ScopeOption) *Context {
+	if c.root != nil {
+		return c.root.Scope(name, opts...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, scope := range c.scopes {
+		if scope.scope.name == name {
+			return scope
+		}
+	}
+
+	options := &scopeOptions{name: name}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	variables := make(map[string]Ref, len(c.variables))
+	for k, v := range c.variables {
+		variables[k] = v
+	}
+
+	scope := newContextWithContext(c.ctx)
+	scope.variables = variables
+	scope.runtimeExports = append([]runtimeExport(nil), c.runtimeExports...)
+	scope.root = c
+	scope.closed = c.closed
+	scope.events = c.events
+	scope.scope = options
+	c.scopes = append(c.scopes, scope)
+	return scope
+}
+
+// ScopeName returns the name of a scoped Context, or "" for the root Context.
+func (c *Context) ScopeName() string {
+	if c.scope == nil {
+		return ""
+	}
+	return c.scope.name
+}
+
+// Scopes returns the scopes created from this context, in creation order.
+// This is primarily useful for testing and debugging.
+func (c *Context) Scopes() []*Context {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]*Context, len(c.scopes))
+	copy(result, c.scopes)
+	return result
+}
+
+// scopeOrg returns the default organization of a scoped context.
+func (c *Context) scopeOrg() string {
+	if c.scope == nil {
+		return ""
+	}
+	return c.scope.org
+}
+
+// scopeOutputDir returns the directory a scope's manifests are written to.
+func (c *Context) scopeOutputDir(rootOutDir string) string {
+	outputDir := c.scope.outDir
+	if outputDir == "" {
+		outputDir = c.scope.name
+	}
+	if !filepath.IsAbs(outputDir) {
+		outputDir = filepath.Join(rootOutDir, outputDir)
+	}
+	return outputDir
+}
+
+// synthesizeScope validates a scope and emits its manifests.
+// rootOutDir is STIGMER_OUT_DIR ("" when unset) and sinks are the sinks
+// registered on the root context.
+func (c *Context) synthesizeScope(rootOutDir string, sinks []ManifestSink) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.validateUniqueNames(); err != nil {
+		return err
+	}
+
+	outputDir := ""
+	if rootOutDir != "" {
+		outputDir = c.scopeOutputDir(rootOutDir)
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return validation.NewSynthesisErrorWithCause(
+				"init",
+				fmt.Sprintf("failed to create output directory %q for scope %q", outputDir, c.scope.name),
+				err,
+			)
+		}
+	}
+	sinks = c.manifestSinks(outputDir, sinks)
+
+	if len(sinks) == 0 {
+		c.synthesized = true
+		return nil
+	}
+
+	if err := c.synthesizeManifests(sinks); err != nil {
+		return fmt.Errorf("scope %q: %w", c.scope.name, err)
+	}
+
+	c.synthesized = true
+	return nil
+}
+
+// validateUniqueNames checks that agent slugs and workflow names are unique
+// within the scope.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) validateUniqueNames() error {
+	seen := make(map[string]bool)
+	check := func(id string) error {
+		if seen[id] {
+			return validation.NewSynthesisErrorWithCause(
+				"validation",
+				fmt.Sprintf("%s is defined more than once in scope %q", id, c.scope.name),
+				ErrDuplicateResourceName,
+			)
+		}
+		seen[id] = true
+		return nil
+	}
+
+	for _, ag := range c.agents {
+		slug := ag.Slug
+		if slug == "" {
+			slug = naming.GenerateSlug(ag.Name)
+		}
+		if err := check("agent:" + slug); err != nil {
+			return err
+		}
+	}
+	for _, wf := range c.workflows {
+		if err := check(fmt.Sprintf("workflow:%s/%s", wf.Document.Namespace, wf.Document.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
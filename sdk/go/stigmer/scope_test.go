@@ -0,0 +1,131 @@
+package stigmer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/skillref"
+)
+
+func TestContext_ScopeWritesPerScopeManifests(t *testing.T) {
+	outDir := t.TempDir()
+	t.Setenv("STIGMER_OUT_DIR", outDir)
+
+	err := Run(func(ctx *Context) error {
+		registerTestAgent(ctx, "shared-reviewer")
+
+		teamA := ctx.Scope("team-a", WithOutDir("out/team-a"), WithOrg("team-a-org"))
+		registerTestAgent(teamA, "code-reviewer")
+
+		// Names only need to be unique per scope
+		teamB := ctx.Scope("team-b")
+		registerTestAgent(teamB, "code-reviewer")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	for _, name := range []string{
+		"agent-0.pb",
+		"dependencies.json",
+		"out/team-a/agent-0.pb",
+		"out/team-a/dependencies.json",
+		"team-b/agent-0.pb",
+	} {
+		if _, err := os.Stat(filepath.Join(outDir, name)); err != nil {
+			t.Errorf("expected %s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "agent-1.pb")); err == nil {
+		t.Error("scoped agents should not be written to the root directory")
+	}
+
+	data, err := os.ReadFile(filepath.Join(outDir, "out/team-a/agent-0.pb"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var ag agentv1.Agent
+	if err := proto.Unmarshal(data, &ag); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := ag.GetMetadata().GetOrg(); got != "team-a-org" {
+		t.Errorf("metadata.org = %q, want team-a-org", got)
+	}
+}
+
+func TestContext_ScopeDuplicateNames(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", t.TempDir())
+
+	err := Run(func(ctx *Context) error {
+		teamA := ctx.Scope("team-a")
+		registerTestAgent(teamA, "code-reviewer")
+		registerTestAgent(ctx.Scope("team-a"), "code-reviewer")
+		return nil
+	})
+	if !errors.Is(err, ErrDuplicateResourceName) {
+		t.Errorf("Run() error = %v, want ErrDuplicateResourceName", err)
+	}
+}
+
+func TestContext_Scope(t *testing.T) {
+	ctx := NewContext()
+	ctx.SetString("region", "eu-west-1")
+
+	teamA := ctx.Scope("team-a", WithOrg("team-a-org"))
+	if teamA.ScopeName() != "team-a" {
+		t.Errorf("ScopeName() = %q, want team-a", teamA.ScopeName())
+	}
+	if _, ok := teamA.Variables()["region"]; !ok {
+		t.Error("scope should inherit the parent's variables")
+	}
+	if ctx.Scope("team-a") != teamA {
+		t.Error("Scope() with an existing name should return the existing scope")
+	}
+	if teamA.Scope("team-b") != ctx.Scopes()[1] {
+		t.Error("Scope() on a scope should create a sibling scope")
+	}
+
+	registerTestAgent(teamA, "code-reviewer")
+	if got := teamA.Agents()[0].Org; got != "team-a-org" {
+		t.Errorf("agent Org = %q, want team-a-org", got)
+	}
+	if len(ctx.Agents()) != 0 {
+		t.Errorf("root context has %d agents, want 0", len(ctx.Agents()))
+	}
+}
+
+func TestContext_ScopeOrgSkill(t *testing.T) {
+	outDir := t.TempDir()
+	t.Setenv("STIGMER_OUT_DIR", outDir)
+
+	err := Run(func(ctx *Context) error {
+		teamA := ctx.Scope("team-a", WithOrg("team-a-org"))
+		_, err := agent.New(teamA, "code-reviewer", &agent.AgentArgs{
+			Instructions: "Review code using the team guidelines",
+		}, agent.WithOrgSkill("internal-docs", "v2.0"))
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outDir, "team-a/agent-0.pb"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var ag agentv1.Agent
+	if err := proto.Unmarshal(data, &ag); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := skillref.Organization("team-a-org", "internal-docs", "v2.0")
+	if got := ag.GetSpec().GetSkillRefs(); len(got) != 1 || !proto.Equal(got[0], want) {
+		t.Errorf("skill_refs = %v, want [%v]", got, want)
+	}
+}
@@ -0,0 +1,114 @@
+package stigmer
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"github.com/stigmer/stigmer/sdk/go/skill"
+)
+
+// ErrConflictingSkill is returned when agents of the same organization use
+// different inline skills with the same slug.
+var ErrConflictingSkill = errors.New("conflicting inline skills")
+
+// Inline skills added with agent.AddSkill are emitted as skill manifests
+// before the agents, so `stigmer apply` creates them first. Agents reference
+// them by slug in their organization, so a skill shared by several agents is
+// emitted once.
+
+// synthesizedSkill is an inline skill to emit, with the first agent using it.
+type synthesizedSkill struct {
+	skill *skill.Skill
+	org   string
+	agent string
+}
+
+// inlineSkills returns the inline skills of the context's agents, once per
+// organization, slug and content, in the order agents added them.
+// NOTE: This method assumes the caller already holds c.mu lock
+func (c *Context) inlineSkills() ([]synthesizedSkill, error) {
+	type skillKey struct {
+		org  string
+		slug string
+	}
+
+	var skills []synthesizedSkill
+	seen := make(map[skillKey]synthesizedSkill)
+	for _, ag := range c.agents {
+		for _, s := range ag.Skills {
+			if s == nil {
+				continue // reported when the agent is converted
+			}
+			key := skillKey{org: ag.Org, slug: s.Slug}
+			first, ok := seen[key]
+			if !ok {
+				entry := synthesizedSkill{skill: s, org: ag.Org, agent: ag.Name}
+				seen[key] = entry
+				skills = append(skills, entry)
+				continue
+			}
+			if first.skill.ContentHash() != s.ContentHash() {
+				return nil, validation.NewSynthesisErrorForResource(
+					"skills", "Skill", s.Name,
+					fmt.Sprintf("skill %q has different content in agents %q and %q", s.Slug, first.agent, ag.Name),
+					ErrConflictingSkill,
+				)
+			}
+		}
+	}
+	return skills, nil
+}
+
+// synthesizeSkills converts the inline skills of the agents to protobuf and
+// emits them to the sinks
+func (c *Context) synthesizeSkills(sinks []ManifestSink) error {
+	skills, err := c.inlineSkills()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range skills {
+		skillProto := entry.skill.ToProto()
+		skillProto.Metadata.Annotations = agent.SDKAnnotations()
+		applyOrg(skillProto.Metadata, entry.org)
+		c.applySourceRevision(skillProto.Metadata)
+
+		data, err := proto.Marshal(skillProto)
+		if err != nil {
+			return validation.NewSynthesisErrorForResource(
+				"skills", "Skill", entry.skill.Name,
+				"failed to serialize protobuf",
+				err,
+			)
+		}
+
+		if err := emitManifest(sinks, ManifestKindSkill, data); err != nil {
+			return validation.NewSynthesisErrorForResource(
+				"skills", "Skill", entry.skill.Name,
+				err.Error(),
+				manifestWriteError(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// isInlineSkillRef reports whether a resolved skill reference of an agent
+// names one of its inline skills.
+func isInlineSkillRef(ag *agent.Agent, ref *apiresource.ApiResourceReference) bool {
+	if ref.GetScope() != apiresource.ApiResourceOwnerScope_organization || ref.GetOrg() != ag.Org {
+		return false
+	}
+	for _, s := range ag.Skills {
+		if s != nil && s.Slug == ref.GetSlug() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package stigmer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	agentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1"
+	skillv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/skill/v1"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/skill"
+	"github.com/stigmer/stigmer/sdk/go/skillref"
+)
+
+const testSkillMarkdown = "# Security Guidelines\n\nValidate all user input."
+
+// newSkilledAgent creates an agent of org acme using an inline skill.
+func newSkilledAgent(ctx *Context, name, markdown string) error {
+	s, err := skill.New("security-guidelines", skill.WithMarkdown(markdown))
+	if err != nil {
+		return err
+	}
+	ag, err := agent.New(ctx, name, &agent.AgentArgs{
+		Instructions: "Review code quality and report issues",
+	})
+	if err != nil {
+		return err
+	}
+	ag.Org = "acme"
+	ag.AddSkill(s)
+	return nil
+}
+
+func TestSynthesize_InlineSkills(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	var kinds []ManifestKind
+	var skills []*skillv1.Skill
+	var agents []*agentv1.Agent
+	sink := func(kind ManifestKind, data []byte) error {
+		kinds = append(kinds, kind)
+		switch kind {
+		case ManifestKindSkill:
+			s := &skillv1.Skill{}
+			skills = append(skills, s)
+			return proto.Unmarshal(data, s)
+		case ManifestKindAgent:
+			a := &agentv1.Agent{}
+			agents = append(agents, a)
+			return proto.Unmarshal(data, a)
+		}
+		return nil
+	}
+
+	var graph *Context
+	err := RunWithOptions(func(ctx *Context) error {
+		graph = ctx
+		// Separately created skills with the same name and content are one skill
+		if err := newSkilledAgent(ctx, "reviewer", testSkillMarkdown); err != nil {
+			return err
+		}
+		return newSkilledAgent(ctx, "auditor", testSkillMarkdown)
+	}, WithManifestSink(sink))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+
+	wantKinds := []ManifestKind{ManifestKindSkill, ManifestKindAgent, ManifestKindAgent, ManifestKindDependencies}
+	if !reflect.DeepEqual(kinds, wantKinds) {
+		t.Fatalf("sink received %v, want %v", kinds, wantKinds)
+	}
+
+	s := skills[0]
+	if s.GetMetadata().GetSlug() != "security-guidelines" || s.GetMetadata().GetOrg() != "acme" {
+		t.Errorf("skill metadata = %v, want slug security-guidelines in org acme", s.GetMetadata())
+	}
+	if s.GetSpec().GetSkillMd() != testSkillMarkdown {
+		t.Errorf("skill_md = %q, want %q", s.GetSpec().GetSkillMd(), testSkillMarkdown)
+	}
+
+	want := skillref.Organization("acme", "security-guidelines")
+	for _, a := range agents {
+		refs := a.GetSpec().GetSkillRefs()
+		if len(refs) != 1 || !proto.Equal(refs[0], want) {
+			t.Errorf("agent %s skill refs = %v, want [%v]", a.GetMetadata().GetName(), refs, want)
+		}
+	}
+
+	wantGraph := map[string][]string{
+		"agent:reviewer": {"skill:security-guidelines"},
+		"agent:auditor":  {"skill:security-guidelines"},
+	}
+	if got := graph.Dependencies(); !reflect.DeepEqual(got, wantGraph) {
+		t.Errorf("Dependencies() = %v, want %v", got, wantGraph)
+	}
+}
+
+func TestSynthesize_ConflictingInlineSkills(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+
+	err := RunWithOptions(func(ctx *Context) error {
+		if err := newSkilledAgent(ctx, "reviewer", testSkillMarkdown); err != nil {
+			return err
+		}
+		return newSkilledAgent(ctx, "auditor", "# Security Guidelines\n\nEscape all output.")
+	}, WithManifestSink(func(ManifestKind, []byte) error { return nil }))
+	if !errors.Is(err, ErrConflictingSkill) {
+		t.Fatalf("RunWithOptions() error = %v, want ErrConflictingSkill", err)
+	}
+}
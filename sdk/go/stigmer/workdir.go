@@ -0,0 +1,27 @@
+package stigmer
+
+// WithWorkDir sets the directory relative paths given to
+// agent.WithInstructionsFromFile resolve against, for every agent of the run.
+//
+// By default they resolve against the directory of the Go file naming them,
+// then against the working directory. Set a work directory for programs built
+// with -trimpath, whose source file paths are unknown, or whose files live
+// apart from the code. agent.WithBaseDir overrides it for a single agent.
+//
+// Example:
+//
+//	err := stigmer.RunWithOptions(fn, stigmer.WithWorkDir(os.Getenv("STIGMER_DEFINITIONS_DIR")))
+func WithWorkDir(dir string) RunOption {
+	return func(o *runOptions) {
+		o.workDir = dir
+	}
+}
+
+// WorkDir returns the directory set with WithWorkDir, "" if none. Scoped
+// contexts return the directory of their root.
+func (c *Context) WorkDir() string {
+	if c.root != nil {
+		return c.root.workDir
+	}
+	return c.workDir
+}
@@ -0,0 +1,74 @@
+package stigmer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/agent"
+)
+
+func TestWithWorkDir(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+	pkgDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	workDir := filepath.Join(pkgDir, "testdata", "agents")
+	t.Chdir(t.TempDir())
+
+	var instructions []string
+	err = RunWithOptions(func(ctx *Context) error {
+		if got := ctx.Scope("billing").WorkDir(); got != workDir {
+			t.Errorf("scope WorkDir() = %q, want %q", got, workDir)
+		}
+		for _, c := range []*Context{ctx, ctx.Scope("billing")} {
+			ag, err := agent.New(c, "code-reviewer", nil, agent.WithInstructionsFromFile("code-reviewer.md"))
+			if err != nil {
+				return err
+			}
+			instructions = append(instructions, ag.Instructions)
+		}
+		return nil
+	}, WithWorkDir(workDir))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+	for _, got := range instructions {
+		if !strings.HasPrefix(got, "Review code") {
+			t.Errorf("Instructions = %q, want the content of testdata/agents/code-reviewer.md", got)
+		}
+	}
+}
+
+func TestWithWorkDir_AgentBaseDirOverrides(t *testing.T) {
+	t.Setenv("STIGMER_OUT_DIR", "")
+	baseDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(baseDir, "code-reviewer.md"), []byte("Review code for security issues only."), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := RunWithOptions(func(ctx *Context) error {
+		// The work directory alone does not hold the file
+		_, err := agent.New(ctx, "code-reviewer", nil, agent.WithInstructionsFromFile("code-reviewer.md"))
+		if !errors.Is(err, agent.ErrInvalidInstructions) {
+			t.Errorf("agent.New() error = %v, want ErrInvalidInstructions", err)
+		}
+
+		ag, err := agent.New(ctx, "code-reviewer", nil,
+			agent.WithBaseDir(baseDir),
+			agent.WithInstructionsFromFile("code-reviewer.md"))
+		if err != nil {
+			return err
+		}
+		if ag.Instructions != "Review code for security issues only." {
+			t.Errorf("Instructions = %q, want the content of the base directory's file", ag.Instructions)
+		}
+		return nil
+	}, WithWorkDir(t.TempDir()))
+	if err != nil {
+		t.Fatalf("RunWithOptions() error = %v", err)
+	}
+}
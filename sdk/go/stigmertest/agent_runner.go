@@ -0,0 +1,335 @@
+package stigmertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stigmer/stigmer/sdk/go/agent"
+)
+
+// DefaultMaxTurns is the number of model turns Run allows by default.
+const DefaultMaxTurns = 10
+
+// DefaultOrg is the organization in the user message when WithOrg is not used.
+const DefaultOrg = "local"
+
+// toolCallPrefix marks a model response as a tool call (see CallTool).
+const toolCallPrefix = "@@stigmertest.call_tool "
+
+// ModelFunc is a fake model. It receives the rendered conversation (see
+// Transcript.Prompt) and returns the assistant's response: an answer, or a
+// tool call built with CallTool.
+type ModelFunc func(prompt string) string
+
+// ToolFunc is a fake tool. The returned string is the tool result the model
+// sees on its next turn; an error is shown to the model as the result.
+type ToolFunc func(call ToolCall) (string, error)
+
+// ToolCall is a tool call made by the model.
+type ToolCall struct {
+	// Name is the tool name, e.g. "github.create_issue".
+	Name string
+
+	// Args are the arguments the model passed.
+	Args map[string]any
+
+	// Env is the resolved environment of the execution.
+	Env map[string]string
+
+	// Result is the tool result, set in the transcript once the tool ran.
+	Result string
+
+	// Err is the error the tool returned, if any.
+	Err error
+}
+
+// Role identifies the author of a transcript message.
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+	RoleTool      Role = "tool"
+)
+
+// Message is a message of the conversation.
+type Message struct {
+	Role    Role
+	Content string
+
+	// ToolCall is set on assistant messages that call a tool and on the
+	// tool message holding its result.
+	ToolCall *ToolCall
+}
+
+// Transcript records a local agent execution.
+type Transcript struct {
+	// SystemPrompt is the system prompt the agent runner would use.
+	SystemPrompt string
+
+	// Messages is the conversation, starting with the user message.
+	Messages []Message
+
+	// Env is the resolved environment of the execution.
+	Env map[string]string
+}
+
+// Output returns the final response of the agent.
+func (t *Transcript) Output() string {
+	if len(t.Messages) == 0 {
+		return ""
+	}
+	last := t.Messages[len(t.Messages)-1]
+	if last.Role != RoleAssistant || last.ToolCall != nil {
+		return ""
+	}
+	return last.Content
+}
+
+// ToolCalls returns the tool calls of the execution in order, with results.
+func (t *Transcript) ToolCalls() []ToolCall {
+	var calls []ToolCall
+	for _, m := range t.Messages {
+		if m.Role == RoleTool && m.ToolCall != nil {
+			calls = append(calls, *m.ToolCall)
+		}
+	}
+	return calls
+}
+
+// Prompt renders the conversation as the fake model receives it:
+//
+//	<system>...</system>
+//	<user>...</user>
+//	<assistant>...</assistant>
+//	<tool name="github.create_issue">...</tool>
+func (t *Transcript) Prompt() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<system>%s</system>\n", t.SystemPrompt)
+	for _, m := range t.Messages {
+		if m.Role == RoleTool {
+			fmt.Fprintf(&b, "<tool name=%q>%s</tool>\n", m.ToolCall.Name, m.Content)
+			continue
+		}
+		fmt.Fprintf(&b, "<%s>%s</%s>\n", m.Role, m.Content, m.Role)
+	}
+	return b.String()
+}
+
+// CallTool returns a model response that calls the named tool. Return it
+// from a fake model to exercise the agent's tools.
+func CallTool(name string, args map[string]any) string {
+	if args == nil {
+		args = map[string]any{}
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		panic(fmt.Sprintf("stigmertest: tool arguments for %s: %v", name, err))
+	}
+	return toolCallPrefix + name + " " + string(data)
+}
+
+// parseToolCall parses a response built with CallTool.
+func parseToolCall(response string) (*ToolCall, bool, error) {
+	rest, ok := strings.CutPrefix(response, toolCallPrefix)
+	if !ok {
+		return nil, false, nil
+	}
+	name, argsJSON, _ := strings.Cut(rest, " ")
+	call := &ToolCall{Name: name, Args: map[string]any{}}
+	if argsJSON != "" {
+		if err := json.Unmarshal([]byte(argsJSON), &call.Args); err != nil {
+			return nil, true, fmt.Errorf("invalid arguments for tool %s: %w", name, err)
+		}
+	}
+	return call, true, nil
+}
+
+// AgentRunner runs an agent in-process against a fake model.
+type AgentRunner struct {
+	agent    *agent.Agent
+	model    ModelFunc
+	tools    map[string]ToolFunc
+	skills   map[string]string
+	env      map[string]string
+	org      string
+	maxTurns int
+}
+
+// RunnerOption configures an AgentRunner.
+type RunnerOption func(*AgentRunner)
+
+// WithFakeModel sets the model the agent talks to.
+func WithFakeModel(model ModelFunc) RunnerOption {
+	return func(r *AgentRunner) {
+		r.model = model
+	}
+}
+
+// WithFakeTool registers a tool the model can call.
+func WithFakeTool(name string, tool ToolFunc) RunnerOption {
+	return func(r *AgentRunner) {
+		r.tools[name] = tool
+	}
+}
+
+// WithSkill provides the SKILL.md content of a skill the agent references
+// by slug.
+func WithSkill(slug, skillMD string) RunnerOption {
+	return func(r *AgentRunner) {
+		r.skills[slug] = skillMD
+	}
+}
+
+// WithEnv sets environment variables, overriding the agent's defaults.
+func WithEnv(env map[string]string) RunnerOption {
+	return func(r *AgentRunner) {
+		for k, v := range env {
+			r.env[k] = v
+		}
+	}
+}
+
+// WithOrg sets the organization in the user message.
+func WithOrg(org string) RunnerOption {
+	return func(r *AgentRunner) {
+		r.org = org
+	}
+}
+
+// WithMaxTurns sets the number of model turns after which Run fails.
+func WithMaxTurns(n int) RunnerOption {
+	return func(r *AgentRunner) {
+		r.maxTurns = n
+	}
+}
+
+// NewAgentRunner creates a runner for ag.
+//
+// Example:
+//
+//	runner := stigmertest.NewAgentRunner(ag,
+//	    stigmertest.WithFakeModel(func(prompt string) string { return "LGTM" }),
+//	)
+//	transcript, err := runner.Run("Review PR #42")
+func NewAgentRunner(ag *agent.Agent, opts ...RunnerOption) *AgentRunner {
+	r := &AgentRunner{
+		agent:    ag,
+		tools:    make(map[string]ToolFunc),
+		skills:   make(map[string]string),
+		env:      make(map[string]string),
+		org:      DefaultOrg,
+		maxTurns: DefaultMaxTurns,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run executes one turn of the agent for message. The model is called until
+// it answers without calling a tool. The transcript is returned with the
+// error when the run fails after the prompt was built.
+func (r *AgentRunner) Run(message string) (*Transcript, error) {
+	if r.model == nil {
+		return nil, ErrNoModel
+	}
+
+	pb, err := r.agent.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	spec := pb.GetSpec()
+
+	env, err := r.resolveEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	var skills []skillContent
+	for _, ref := range spec.GetSkillRefs() {
+		skillMD, ok := r.skills[ref.GetSlug()]
+		if !ok {
+			return nil, fmt.Errorf("%w %q: provide it with WithSkill", ErrMissingSkill, ref.GetSlug())
+		}
+		skills = append(skills, skillContent{
+			name:     ref.GetSlug(),
+			location: "/bin/skills/" + ref.GetSlug(),
+			skillMD:  skillMD,
+		})
+	}
+
+	var outputSchema map[string]any
+	if spec.GetOutputSchema() != nil {
+		outputSchema = spec.GetOutputSchema().AsMap()
+	}
+
+	transcript := &Transcript{
+		SystemPrompt: composeSystemPrompt(spec.GetInstructions(), skills, outputSchema),
+		Messages: []Message{
+			{Role: RoleUser, Content: composeUserMessage(message, r.org)},
+		},
+		Env: env,
+	}
+
+	for turn := 0; turn < r.maxTurns; turn++ {
+		response := r.model(transcript.Prompt())
+
+		call, isCall, err := parseToolCall(response)
+		if err != nil {
+			return transcript, err
+		}
+		if !isCall {
+			transcript.Messages = append(transcript.Messages, Message{Role: RoleAssistant, Content: response})
+			return transcript, nil
+		}
+
+		transcript.Messages = append(transcript.Messages, Message{Role: RoleAssistant, Content: response, ToolCall: call})
+
+		tool, ok := r.tools[call.Name]
+		if !ok {
+			return transcript, fmt.Errorf("%w %q: register it with WithFakeTool", ErrUnknownTool, call.Name)
+		}
+
+		result := *call
+		result.Env = env
+		result.Result, result.Err = tool(result)
+		content := result.Result
+		if result.Err != nil {
+			content = "Error: " + result.Err.Error()
+		}
+		transcript.Messages = append(transcript.Messages, Message{Role: RoleTool, Content: content, ToolCall: &result})
+	}
+
+	return transcript, fmt.Errorf("%w (%d turns)", ErrMaxTurns, r.maxTurns)
+}
+
+// resolveEnv merges the agent's default values with WithEnv and checks that
+// required variables are set.
+func (r *AgentRunner) resolveEnv() (map[string]string, error) {
+	variables, err := r.agent.Environment()
+	if err != nil {
+		return nil, err
+	}
+
+	env := make(map[string]string)
+	var missing []string
+	for _, v := range variables {
+		if v.DefaultValue != "" {
+			env[v.Name] = v.DefaultValue
+		}
+		if _, ok := r.env[v.Name]; v.Required && v.DefaultValue == "" && !ok {
+			missing = append(missing, v.Name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("%w: %s", ErrMissingEnv, strings.Join(missing, ", "))
+	}
+	for k, v := range r.env {
+		env[k] = v
+	}
+	return env, nil
+}
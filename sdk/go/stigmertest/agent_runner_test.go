@@ -0,0 +1,251 @@
+package stigmertest
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/environment"
+	"github.com/stigmer/stigmer/sdk/go/skillref"
+)
+
+// promptContract is testdata/prompt_contract.json, shared with the agent
+// runner's test_prompt_builder.py.
+type promptContract struct {
+	Cases []struct {
+		Name         string         `json:"name"`
+		Instructions string         `json:"instructions"`
+		Message      string         `json:"message"`
+		Org          string         `json:"org"`
+		OutputSchema map[string]any `json:"output_schema"`
+		Skills       []struct {
+			Name     string `json:"name"`
+			Location string `json:"location"`
+			SkillMD  string `json:"skill_md"`
+		} `json:"skills"`
+		WantSystemPrompt string `json:"want_system_prompt"`
+		WantUserMessage  string `json:"want_user_message"`
+	} `json:"cases"`
+}
+
+func TestPromptContract(t *testing.T) {
+	data, err := os.ReadFile("testdata/prompt_contract.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	var contract promptContract
+	if err := json.Unmarshal(data, &contract); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+
+	for _, tc := range contract.Cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var skills []skillContent
+			for _, s := range tc.Skills {
+				skills = append(skills, skillContent{name: s.Name, location: s.Location, skillMD: s.SkillMD})
+			}
+
+			if got := composeSystemPrompt(tc.Instructions, skills, tc.OutputSchema); got != tc.WantSystemPrompt {
+				t.Errorf("system prompt mismatch\ngot:\n%s\nwant:\n%s", got, tc.WantSystemPrompt)
+			}
+			if got := composeUserMessage(tc.Message, tc.Org); got != tc.WantUserMessage {
+				t.Errorf("user message = %q, want %q", got, tc.WantUserMessage)
+			}
+		})
+	}
+}
+
+func TestPythonFloat(t *testing.T) {
+	tests := map[float64]string{
+		5:       "5.0",
+		-1:      "-1.0",
+		0:       "0.0",
+		0.5:     "0.5",
+		1e16:    "1e+16",
+		1.5e-05: "1.5e-05",
+	}
+	for in, want := range tests {
+		if got := pythonFloat(in); got != want {
+			t.Errorf("pythonFloat(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func newTestAgent(t *testing.T) *agent.Agent {
+	t.Helper()
+	ag, err := agent.New(nil, "issue-triager", &agent.AgentArgs{
+		Instructions: "Triage incoming GitHub issues for the platform team.",
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %v", err)
+	}
+	return ag
+}
+
+func TestAgentRunner_Answer(t *testing.T) {
+	ag := newTestAgent(t)
+	ag.AddSkillRef(skillref.Platform("github-triage"))
+
+	var prompts []string
+	runner := NewAgentRunner(ag,
+		WithOrg("acme"),
+		WithSkill("github-triage", "# GitHub Triage\n\nLabel issues by component."),
+		WithFakeModel(func(prompt string) string {
+			prompts = append(prompts, prompt)
+			return "Labeled as bug."
+		}),
+	)
+
+	transcript, err := runner.Run("Triage issue #7")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if got := transcript.Output(); got != "Labeled as bug." {
+		t.Errorf("Output() = %q, want %q", got, "Labeled as bug.")
+	}
+	if !strings.HasPrefix(transcript.SystemPrompt, "Triage incoming GitHub issues") {
+		t.Errorf("system prompt does not start with the instructions:\n%s", transcript.SystemPrompt)
+	}
+	if !strings.Contains(transcript.SystemPrompt, "### SKILL: github-triage\nLOCATION: /bin/skills/github-triage/\n\n# GitHub Triage") {
+		t.Errorf("system prompt is missing the skill section:\n%s", transcript.SystemPrompt)
+	}
+	if len(prompts) != 1 {
+		t.Fatalf("model called %d times, want 1", len(prompts))
+	}
+	if !strings.Contains(prompts[0], "<user>Triage issue #7\n\n---\nContext:\n- Organization: acme</user>") {
+		t.Errorf("prompt is missing the user message:\n%s", prompts[0])
+	}
+}
+
+func TestAgentRunner_ToolCalls(t *testing.T) {
+	ag := newTestAgent(t)
+	token, err := environment.New(nil, "GITHUB_TOKEN", &environment.VariableArgs{IsSecret: true})
+	if err != nil {
+		t.Fatalf("failed to create variable: %v", err)
+	}
+	ag.AddEnvironmentVariable(*token)
+
+	runner := NewAgentRunner(ag,
+		WithEnv(map[string]string{"GITHUB_TOKEN": "test-token"}),
+		WithFakeModel(func(prompt string) string {
+			if !strings.Contains(prompt, `<tool name="github.create_issue">`) {
+				return CallTool("github.create_issue", map[string]any{"title": "Flaky test"})
+			}
+			return "Filed the issue."
+		}),
+		WithFakeTool("github.create_issue", func(call ToolCall) (string, error) {
+			if call.Env["GITHUB_TOKEN"] != "test-token" {
+				return "", errors.New("unauthorized")
+			}
+			return `{"number": 7}`, nil
+		}),
+	)
+
+	transcript, err := runner.Run("File an issue for the flaky test")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	calls := transcript.ToolCalls()
+	if len(calls) != 1 {
+		t.Fatalf("ToolCalls() returned %d calls, want 1", len(calls))
+	}
+	if calls[0].Name != "github.create_issue" || calls[0].Args["title"] != "Flaky test" {
+		t.Errorf("unexpected tool call: %+v", calls[0])
+	}
+	if calls[0].Result != `{"number": 7}` || calls[0].Err != nil {
+		t.Errorf("unexpected tool result: %q, %v", calls[0].Result, calls[0].Err)
+	}
+	if got := transcript.Output(); got != "Filed the issue." {
+		t.Errorf("Output() = %q, want %q", got, "Filed the issue.")
+	}
+	if strings.Contains(transcript.SystemPrompt, "test-token") {
+		t.Error("environment values must not appear in the system prompt")
+	}
+}
+
+func TestAgentRunner_OutputSchema(t *testing.T) {
+	ag := newTestAgent(t)
+	if err := ag.WithOutputSchema(map[string]any{
+		"type":     "object",
+		"required": []any{"severity"},
+		"properties": map[string]any{
+			"severity": map[string]any{"type": "integer", "minimum": 1, "maximum": 5},
+		},
+	}); err != nil {
+		t.Fatalf("WithOutputSchema() error = %v", err)
+	}
+
+	runner := NewAgentRunner(ag, WithFakeModel(func(string) string { return `{"severity": 2}` }))
+	transcript, err := runner.Run("Triage issue #7")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !strings.Contains(transcript.SystemPrompt, "## Response Format") ||
+		!strings.Contains(transcript.SystemPrompt, `"maximum": 5.0`) {
+		t.Errorf("system prompt is missing the response format:\n%s", transcript.SystemPrompt)
+	}
+}
+
+func TestAgentRunner_Errors(t *testing.T) {
+	answer := WithFakeModel(func(string) string { return "done" })
+
+	tests := []struct {
+		name    string
+		setup   func(ag *agent.Agent)
+		opts    []RunnerOption
+		wantErr error
+	}{
+		{
+			name:    "no model",
+			wantErr: ErrNoModel,
+		},
+		{
+			name:    "missing skill",
+			setup:   func(ag *agent.Agent) { ag.AddSkillRef(skillref.Platform("github-triage")) },
+			opts:    []RunnerOption{answer},
+			wantErr: ErrMissingSkill,
+		},
+		{
+			name: "missing required env",
+			setup: func(ag *agent.Agent) {
+				ag.AddEnvironmentVariable(environment.Variable{Name: "GITHUB_TOKEN", Required: true})
+			},
+			opts:    []RunnerOption{answer},
+			wantErr: ErrMissingEnv,
+		},
+		{
+			name: "unknown tool",
+			opts: []RunnerOption{WithFakeModel(func(string) string {
+				return CallTool("github.create_issue", nil)
+			})},
+			wantErr: ErrUnknownTool,
+		},
+		{
+			name: "max turns",
+			opts: []RunnerOption{
+				WithMaxTurns(3),
+				WithFakeModel(func(string) string { return CallTool("ping", nil) }),
+				WithFakeTool("ping", func(ToolCall) (string, error) { return "pong", nil }),
+			},
+			wantErr: ErrMaxTurns,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ag := newTestAgent(t)
+			if tt.setup != nil {
+				tt.setup(ag)
+			}
+			_, err := NewAgentRunner(ag, tt.opts...).Run("Triage issue #7")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Run() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
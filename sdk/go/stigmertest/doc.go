@@ -0,0 +1,48 @@
+// Package stigmertest runs agents in-process with a fake model and fake
+// tools, so agent definitions can be unit tested without deploying them.
+//
+// # Running an Agent Locally
+//
+// NewAgentRunner takes an agent built with the SDK. Run assembles the system
+// prompt and user message the way the agent runner does for a real execution,
+// then drives the fake model until it answers without calling a tool:
+//
+//	ag, _ := agent.New(nil, "issue-triager", &agent.AgentArgs{
+//	    Instructions: "Triage incoming GitHub issues for the platform team.",
+//	})
+//	ag.AddSkillRef(skillref.Platform("github-triage"))
+//
+//	runner := stigmertest.NewAgentRunner(ag,
+//	    stigmertest.WithSkill("github-triage", "# GitHub Triage\n..."),
+//	    stigmertest.WithEnv(map[string]string{"GITHUB_TOKEN": "test-token"}),
+//	    stigmertest.WithFakeModel(func(prompt string) string {
+//	        if !strings.Contains(prompt, `<tool name="github.create_issue">`) {
+//	            return stigmertest.CallTool("github.create_issue", map[string]any{"title": "Flaky test"})
+//	        }
+//	        return "Filed the issue."
+//	    }),
+//	    stigmertest.WithFakeTool("github.create_issue", func(call stigmertest.ToolCall) (string, error) {
+//	        return `{"number": 7}`, nil
+//	    }),
+//	)
+//
+//	transcript, err := runner.Run("Triage issue #7")
+//	// transcript.SystemPrompt, transcript.ToolCalls(), transcript.Output()
+//
+// # What Is Covered
+//
+// The system prompt is the agent's instructions followed by the content of
+// its skills and, when the agent has an output schema, the response format
+// section. The user message carries the organization context. The
+// composition is ported from the agent runner and both are checked against
+// the shared fixture in testdata/prompt_contract.json, so the prompts a test
+// sees are the prompts a deployed agent gets.
+//
+// Skills are referenced by slug and their content lives on the server, so
+// tests provide it with WithSkill. Environment variables are resolved from
+// the agent's defaults and WithEnv, and passed to fake tools; as on the
+// agent runner, they are not substituted into the prompt.
+//
+// MCP servers and sub-agents are not part of the prompt, because the agent
+// runner does not pass them to the model yet.
+package stigmertest
@@ -0,0 +1,25 @@
+package stigmertest
+
+import "errors"
+
+// Errors returned by AgentRunner.Run.
+var (
+	// ErrNoModel is returned when the runner has no fake model.
+	ErrNoModel = errors.New("no fake model configured")
+
+	// ErrMissingSkill is returned when the agent references a skill whose
+	// content was not provided with WithSkill.
+	ErrMissingSkill = errors.New("no content for skill")
+
+	// ErrMissingEnv is returned when a required environment variable without
+	// a default value was not provided with WithEnv.
+	ErrMissingEnv = errors.New("required environment variable not set")
+
+	// ErrUnknownTool is returned when the model calls a tool that was not
+	// registered with WithFakeTool.
+	ErrUnknownTool = errors.New("unknown tool")
+
+	// ErrMaxTurns is returned when the model keeps calling tools beyond the
+	// turn limit set with WithMaxTurns.
+	ErrMaxTurns = errors.New("model did not answer within the turn limit")
+)
@@ -0,0 +1,197 @@
+package stigmertest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The functions in this file port the prompt composition of the agent runner
+// (worker/activities/graphton/prompt_builder.py and
+// SkillWriter.generate_prompt_section). Keep them in step; both sides are
+// tested against testdata/prompt_contract.json.
+
+// defaultInstructions is the system prompt of agents without instructions.
+const defaultInstructions = "You are a helpful AI assistant."
+
+// skillContent is a skill as it appears in the system prompt.
+type skillContent struct {
+	name     string
+	location string
+	skillMD  string
+}
+
+// composeSystemPrompt returns the system prompt of an agent execution: the
+// instructions, the skills section and, with an output schema, the response
+// format section.
+func composeSystemPrompt(instructions string, skills []skillContent, outputSchema map[string]any) string {
+	if instructions == "" {
+		instructions = defaultInstructions
+	}
+
+	var b strings.Builder
+	b.WriteString(instructions)
+	b.WriteString(skillsPromptSection(skills))
+
+	if outputSchema != nil {
+		b.WriteString("\n\n## Response Format\n\n")
+		b.WriteString("Your final response must be a single JSON object that conforms to the ")
+		b.WriteString("following JSON Schema. Do not wrap it in markdown or add any other text.\n\n")
+		writePythonJSON(&b, outputSchema, 0)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// skillsPromptSection returns the section listing the full SKILL.md content
+// of each skill with its location.
+func skillsPromptSection(skills []skillContent) string {
+	if len(skills) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\n\n## Available Skills\n\n")
+	b.WriteString("The following skills provide specialized capabilities. ")
+	b.WriteString("Each skill includes instructions and executable tools.\n")
+	for _, skill := range skills {
+		fmt.Fprintf(&b, "\n### SKILL: %s\n", skill.name)
+		fmt.Fprintf(&b, "LOCATION: %s/\n\n", skill.location)
+		b.WriteString(skill.skillMD)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// composeUserMessage appends the organization context to the user message.
+func composeUserMessage(message, org string) string {
+	return message + "\n\n---\nContext:\n- Organization: " + org
+}
+
+// writePythonJSON writes v the way Python's json.dumps(v, indent=2,
+// sort_keys=True) does for a dict decoded from a protobuf Struct: numbers
+// are floats ("5.0"), non-ASCII characters are escaped, and empty objects
+// and arrays stay on one line.
+func writePythonJSON(b *strings.Builder, v any, depth int) {
+	indent := strings.Repeat("  ", depth+1)
+	closing := strings.Repeat("  ", depth)
+
+	switch val := v.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool:
+		b.WriteString(strconv.FormatBool(val))
+	case float64:
+		b.WriteString(pythonFloat(val))
+	case string:
+		writePythonString(b, val)
+	case map[string]any:
+		if len(val) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteString("{\n")
+		for i, k := range keys {
+			b.WriteString(indent)
+			writePythonString(b, k)
+			b.WriteString(": ")
+			writePythonJSON(b, val[k], depth+1)
+			if i < len(keys)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(closing + "}")
+	case []any:
+		if len(val) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[\n")
+		for i, item := range val {
+			b.WriteString(indent)
+			writePythonJSON(b, item, depth+1)
+			if i < len(val)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(closing + "]")
+	default:
+		// Struct values decode to the types above only
+		data, _ := json.Marshal(val)
+		b.Write(data)
+	}
+}
+
+// pythonFloat formats f like Python's repr of a float.
+func pythonFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+
+	abs := math.Abs(f)
+	if abs != 0 && (abs < 1e-4 || abs >= 1e16) {
+		// Python: 1e+16, 1.5e-05
+		s := strconv.FormatFloat(f, 'e', -1, 64)
+		mantissa, exp, _ := strings.Cut(s, "e")
+		sign := exp[0]
+		digits := strings.TrimLeft(exp[1:], "0")
+		if len(digits) < 2 {
+			digits = fmt.Sprintf("%02s", digits)
+		}
+		return mantissa + "e" + string(sign) + digits
+	}
+
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// writePythonString writes s as a JSON string with non-ASCII characters
+// escaped, like Python's json.dumps with ensure_ascii.
+func writePythonString(b *strings.Builder, s string) {
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\b':
+			b.WriteString(`\b`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r < 0x20 || (r > 0x7e && r <= 0xffff) || r == utf8.RuneError:
+			fmt.Fprintf(b, `\u%04x`, r)
+		case r > 0xffff:
+			r -= 0x10000
+			fmt.Fprintf(b, `\u%04x\u%04x`, 0xd800+(r>>10), 0xdc00+(r&0x3ff))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+}
@@ -2,7 +2,7 @@
 // that can be delegated to within an agent.
 //
 // Sub-agents are defined inline within the parent agent spec using the
-// struct args pattern (Pulumi-aligned).
+// struct args pattern (Pulumi-aligned), or reference a deployed AgentInstance.
 //
 // # Creating Sub-Agents
 //
@@ -17,6 +17,40 @@
 //	    },
 //	})
 //
+// # Local MCP Servers
+//
+// McpServers references servers defined on the parent agent by name. A
+// sub-agent can also own MCP servers outright, through McpServerDefinitions
+// or WithMCPServers; local names must not collide with referenced ones:
+//
+//	github, _ := mcpserver.Stdio(ctx, "github", &mcpserver.StdioArgs{
+//	    Command: "npx",
+//	    Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+//	})
+//	sub, _ := subagent.New("scanner", &subagent.Args{
+//	    Instructions:         "Scan repositories for leaked secrets",
+//	    McpServers:           []string{"filesystem"},
+//	    McpServerDefinitions: []mcpserver.MCPServer{github},
+//	})
+//
+// # Referenced Sub-Agents
+//
+// Reference delegates to an AgentInstance deployed separately. Without a
+// version the sub-agent follows the instance's latest version; pin a tag or
+// an exact hash to keep the parent agent's behavior stable:
+//
+//	checker := subagent.Reference("security-checker", "sec-checker-prod", "stable")
+//
+// NewReference also accepts an instance owned by another organization:
+//
+//	auditor := subagent.NewReference("auditor", &subagent.ReferenceArgs{
+//	    Org:     "security-team",
+//	    Name:    "auditor-prod",
+//	    Version: "v2.1",
+//	})
+//
+// The version format is validated when the parent agent is synthesized.
+//
 // # Integration with Agent
 //
 // Sub-agents are added to agents using the AddSubAgent method:
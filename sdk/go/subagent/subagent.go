@@ -2,20 +2,60 @@ package subagent
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
-	genAgent "github.com/stigmer/stigmer/sdk/go/gen/agent"
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"github.com/stigmer/stigmer/sdk/go/mcpserver"
 )
 
 // Args contains configuration for a sub-agent (Pulumi Args pattern).
-// This is an alias to the generated InlineSubAgentArgs type.
-// Note: After proto regeneration, this will become SubAgentArgs.
-type Args = genAgent.InlineSubAgentArgs
+//
+// It mirrors the generated InlineSubAgentArgs, adding McpServerDefinitions
+// for MCP servers the sub-agent owns, which the generated type can only
+// express by name.
+type Args struct {
+	// Description of what this sub-agent does.
+	Description string
+
+	// Behavior instructions for this sub-agent.
+	Instructions string
+
+	// McpServers references MCP servers defined on the parent agent by name.
+	McpServers []string
+
+	// McpServerDefinitions are MCP servers defined locally on the sub-agent,
+	// created with mcpserver.Stdio, HTTP or Docker. Their names must not
+	// collide with each other or with McpServers.
+	McpServerDefinitions []mcpserver.MCPServer
+
+	// Tool selections for each MCP server.
+	McpToolSelections map[string]*types.McpToolSelection
+
+	// References to Skill resources for this sub-agent's knowledge.
+	SkillRefs []*types.ApiResourceReference
+}
+
+// ReferenceArgs identifies a deployed AgentInstance for NewReference.
+type ReferenceArgs struct {
+	// Org owns the AgentInstance. Empty means the parent agent's organization.
+	Org string
+
+	// Name is the AgentInstance name (slug).
+	Name string
+
+	// Version pins the AgentInstance version. Supported formats match
+	// skill references:
+	//   - Empty or "latest": the most recent version
+	//   - Tag name: e.g., "v1.0", "stable"
+	//   - Exact hash: 64-char hex, immutable reference
+	Version string
+}
 
 // SubAgent represents a sub-agent that can be delegated to.
-// Sub-agents are defined inline within the parent agent spec.
+// Sub-agents are either defined inline within the parent agent spec (New) or
+// reference a deployed AgentInstance (Reference, NewReference).
 type SubAgent struct {
 	name              string
 	description       string
@@ -23,6 +63,8 @@ type SubAgent struct {
 	mcpServers        []string
 	mcpToolSelections map[string]*types.McpToolSelection
 	skillRefs         []*apiresource.ApiResourceReference
+	localMCPServers   []mcpserver.MCPServer
+	instanceRef       *apiresource.ApiResourceReference
 }
 
 // New creates a sub-agent definition with struct args (Pulumi pattern).
@@ -33,16 +75,22 @@ type SubAgent struct {
 //
 // Optional args fields:
 //   - Description: human-readable description
-//   - McpServers: MCP server names this sub-agent can use
+//   - McpServers: names of parent agent MCP servers this sub-agent can use
+//   - McpServerDefinitions: MCP servers owned by this sub-agent
 //   - McpToolSelections: tool selections for each MCP server
 //   - SkillRefs: references to Skill resources
 //
 // Example:
 //
+//	github, _ := mcpserver.Stdio(ctx, "github", &mcpserver.StdioArgs{
+//	    Command: "npx",
+//	    Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+//	})
 //	sub, err := subagent.New("code-analyzer", &subagent.Args{
-//	    Instructions: "Analyze code for bugs and security issues",
-//	    Description:  "Static code analyzer",
-//	    McpServers:   []string{"github"},
+//	    Instructions:         "Analyze code for bugs and security issues",
+//	    Description:          "Static code analyzer",
+//	    McpServers:           []string{"filesystem"}, // defined on the parent agent
+//	    McpServerDefinitions: []mcpserver.MCPServer{github},
 //	})
 func New(name string, args *Args) (SubAgent, error) {
 	// Nil-safety: if args is nil, create empty args
@@ -57,12 +105,63 @@ func New(name string, args *Args) (SubAgent, error) {
 		mcpServers:        args.McpServers,
 		mcpToolSelections: args.McpToolSelections,
 		skillRefs:         convertSkillRefs(args.SkillRefs),
+		localMCPServers:   slices.Clone(args.McpServerDefinitions),
 	}
 
 	return s, nil
 }
 
+// Reference creates a sub-agent that delegates to a deployed AgentInstance
+// in the parent agent's organization.
+//
+// The version parameter is optional - if omitted or empty, "latest" is used,
+// and the sub-agent follows the instance as it is redeployed. Pin a tag or an
+// exact version hash to keep the parent agent's behavior stable.
+//
+// The version format is validated when the parent agent is synthesized.
+//
+// Examples:
+//
+//	subagent.Reference("security-checker", "sec-checker-prod")           // Latest version
+//	subagent.Reference("security-checker", "sec-checker-prod", "stable") // Specific tag
+func Reference(name, instanceName string, version ...string) SubAgent {
+	args := &ReferenceArgs{Name: instanceName}
+	if len(version) > 0 {
+		args.Version = version[0]
+	}
+	return NewReference(name, args)
+}
+
+// NewReference creates a sub-agent that delegates to the AgentInstance
+// described by args, which may belong to another organization.
+//
+// Example:
+//
+//	sub := subagent.NewReference("security-checker", &subagent.ReferenceArgs{
+//	    Org:     "security-team",
+//	    Name:    "sec-checker-prod",
+//	    Version: "v2.1",
+//	})
+func NewReference(name string, args *ReferenceArgs) SubAgent {
+	if args == nil {
+		args = &ReferenceArgs{}
+	}
+
+	ref := &apiresource.ApiResourceReference{
+		Kind:    apiresourcekind.ApiResourceKind_agent_instance,
+		Slug:    args.Name,
+		Version: args.Version,
+	}
+	if args.Org != "" {
+		ref.Scope = apiresource.ApiResourceOwnerScope_organization
+		ref.Org = args.Org
+	}
+
+	return SubAgent{name: name, instanceRef: ref}
+}
+
 // convertSkillRefs converts generated types.ApiResourceReference to proto apiresource.ApiResourceReference.
+// An empty kind defaults to skill, the only kind skill_refs accepts.
 func convertSkillRefs(refs []*types.ApiResourceReference) []*apiresource.ApiResourceReference {
 	if refs == nil {
 		return nil
@@ -70,11 +169,15 @@ func convertSkillRefs(refs []*types.ApiResourceReference) []*apiresource.ApiReso
 	result := make([]*apiresource.ApiResourceReference, 0, len(refs))
 	for _, ref := range refs {
 		if ref != nil {
+			kind := apiresourcekind.ApiResourceKind_skill
+			if ref.Kind != "" {
+				kind = parseKind(ref.Kind)
+			}
 			result = append(result, &apiresource.ApiResourceReference{
 				Slug:  ref.Slug,
 				Org:   ref.Org,
 				Scope: parseScope(ref.Scope),
-				Kind:  parseKind(ref.Kind),
+				Kind:  kind,
 			})
 		}
 	}
@@ -127,7 +230,49 @@ func (s SubAgent) SkillRefs() []*apiresource.ApiResourceReference {
 	return s.skillRefs
 }
 
+// WithMCPServers returns a copy of the sub-agent with the given MCP servers
+// defined locally on it, after those of Args.McpServerDefinitions.
+//
+// Local servers are owned by the sub-agent, unlike Args.McpServers which
+// references servers defined on the parent agent by name. Both forms can be
+// combined, but a local server must not share a name with a referenced one.
+//
+// Example:
+//
+//	sub = sub.WithMCPServers(github)
+func (s SubAgent) WithMCPServers(servers ...mcpserver.MCPServer) SubAgent {
+	local := make([]mcpserver.MCPServer, 0, len(s.localMCPServers)+len(servers))
+	local = append(local, s.localMCPServers...)
+	local = append(local, servers...)
+	s.localMCPServers = local
+	return s
+}
+
+// MCPServers returns the MCP servers defined locally on the sub-agent.
+func (s SubAgent) MCPServers() []mcpserver.MCPServer {
+	return s.localMCPServers
+}
+
+// IsReference reports whether the sub-agent references a deployed
+// AgentInstance instead of being defined inline.
+func (s SubAgent) IsReference() bool {
+	return s.instanceRef != nil
+}
+
+// InstanceRef returns the referenced AgentInstance, or nil for inline
+// sub-agents.
+func (s SubAgent) InstanceRef() *apiresource.ApiResourceReference {
+	return s.instanceRef
+}
+
 // String returns a string representation of the sub-agent.
 func (s SubAgent) String() string {
+	if s.instanceRef != nil {
+		version := s.instanceRef.GetVersion()
+		if version == "" {
+			version = "latest"
+		}
+		return fmt.Sprintf("SubAgent(%s -> %s@%s)", s.name, s.instanceRef.GetSlug(), version)
+	}
 	return fmt.Sprintf("SubAgent(%s)", s.name)
 }
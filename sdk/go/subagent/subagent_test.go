@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"github.com/stigmer/stigmer/sdk/go/mcpserver"
 )
 
 func TestNew(t *testing.T) {
@@ -149,3 +150,110 @@ func TestSkillRefs(t *testing.T) {
 		t.Errorf("refs[1].Slug = %q, want %q", refs[1].Slug, "skill2")
 	}
 }
+
+func TestWithMCPServers(t *testing.T) {
+	github, err := mcpserver.Stdio(nil, "github", &mcpserver.StdioArgs{Command: "npx"})
+	if err != nil {
+		t.Fatalf("Stdio() error = %v", err)
+	}
+
+	base, _ := New("helper", &Args{Instructions: "Helper instructions"})
+	withServer := base.WithMCPServers(github)
+
+	if len(base.MCPServers()) != 0 {
+		t.Errorf("base MCPServers() = %d, want 0 (WithMCPServers must not mutate)", len(base.MCPServers()))
+	}
+	if got := withServer.MCPServers(); len(got) != 1 || got[0].Name() != "github" {
+		t.Errorf("MCPServers() = %v, want [github]", got)
+	}
+}
+
+func TestNewMCPServerDefinitions(t *testing.T) {
+	github, err := mcpserver.Stdio(nil, "github", &mcpserver.StdioArgs{Command: "npx"})
+	if err != nil {
+		t.Fatalf("Stdio() error = %v", err)
+	}
+	slack, err := mcpserver.HTTP(nil, "slack", &mcpserver.HTTPArgs{Url: "https://mcp.example.com/slack"})
+	if err != nil {
+		t.Fatalf("HTTP() error = %v", err)
+	}
+
+	args := &Args{
+		Instructions:         "Helper instructions",
+		McpServers:           []string{"filesystem"},
+		McpServerDefinitions: []mcpserver.MCPServer{github},
+	}
+	sub, err := New("helper", args)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	args.McpServerDefinitions[0] = slack
+	sub = sub.WithMCPServers(slack)
+
+	if got := sub.MCPServerNames(); len(got) != 1 || got[0] != "filesystem" {
+		t.Errorf("MCPServerNames() = %v, want [filesystem]", got)
+	}
+	got := sub.MCPServers()
+	if len(got) != 2 || got[0].Name() != "github" || got[1].Name() != "slack" {
+		t.Errorf("MCPServers() = %v, want [github slack]", got)
+	}
+}
+
+func TestReference(t *testing.T) {
+	tests := []struct {
+		name        string
+		sub         SubAgent
+		wantOrg     string
+		wantVersion string
+		wantString  string
+	}{
+		{
+			name:       "latest",
+			sub:        Reference("security-checker", "sec-checker-prod"),
+			wantString: "SubAgent(security-checker -> sec-checker-prod@latest)",
+		},
+		{
+			name:        "pinned tag",
+			sub:         Reference("security-checker", "sec-checker-prod", "stable"),
+			wantVersion: "stable",
+			wantString:  "SubAgent(security-checker -> sec-checker-prod@stable)",
+		},
+		{
+			name: "args with org",
+			sub: NewReference("security-checker", &ReferenceArgs{
+				Org:     "security-team",
+				Name:    "sec-checker-prod",
+				Version: "v2.1",
+			}),
+			wantOrg:     "security-team",
+			wantVersion: "v2.1",
+			wantString:  "SubAgent(security-checker -> sec-checker-prod@v2.1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.sub.IsReference() {
+				t.Fatal("IsReference() = false, want true")
+			}
+			ref := tt.sub.InstanceRef()
+			if ref.GetSlug() != "sec-checker-prod" {
+				t.Errorf("InstanceRef().Slug = %q, want %q", ref.GetSlug(), "sec-checker-prod")
+			}
+			if ref.GetOrg() != tt.wantOrg {
+				t.Errorf("InstanceRef().Org = %q, want %q", ref.GetOrg(), tt.wantOrg)
+			}
+			if ref.GetVersion() != tt.wantVersion {
+				t.Errorf("InstanceRef().Version = %q, want %q", ref.GetVersion(), tt.wantVersion)
+			}
+			if got := tt.sub.String(); got != tt.wantString {
+				t.Errorf("String() = %q, want %q", got, tt.wantString)
+			}
+		})
+	}
+
+	inline, _ := New("helper", &Args{Instructions: "Helper instructions"})
+	if inline.IsReference() || inline.InstanceRef() != nil {
+		t.Error("inline sub-agent reports a reference")
+	}
+}
@@ -103,7 +103,7 @@ func main() {
 		// Task 2: Process response using direct task references
 		// Dependencies are automatic through field references!
 		processTask := wf.Set("processResponse", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"postTitle": fetchTask.Field("title").Expression(),
 				"postBody":  fetchTask.Field("body").Expression(),
 				"status":    "completed",
@@ -222,7 +222,7 @@ Be concise and helpful.` + "`" + `,
 
 		// Step 4: Store the results
 		results := pipeline.Set("store-results", &workflow.SetArgs{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"prTitle":    fetchPR.Field("title").Expression(),
 				"prNumber":   fetchPR.Field("number").Expression(),
 				"review":     analyze.Field("response").Expression(),
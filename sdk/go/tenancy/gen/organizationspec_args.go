@@ -0,0 +1,20 @@
+// Code generated by stigmer-codegen. DO NOT EDIT.
+// Source: organizationspec_args.go
+
+package gen
+
+// OrganizationArgs contains the configuration arguments for creating a Organization.
+//
+// This struct follows the Pulumi Args pattern for resource configuration.
+//
+// OrganizationSpec defines the configuration for an organization.
+//
+//	Organizations are the top-level container for all Stigmer resources.
+//	Similar to GitHub organizations, all agents, workflows, and other resources
+//	are scoped under an organization.
+type OrganizationArgs struct {
+	// description for the organization
+	Description string `json:"description,omitempty"`
+	// public url for the organization logo (optional)
+	LogoUrl string `json:"logoUrl,omitempty"`
+}
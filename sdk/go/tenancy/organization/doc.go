@@ -0,0 +1,17 @@
+// Package organization provides the Organization builder for declaring
+// organizations alongside the agents and workflows they own.
+//
+//	_, err := organization.New(ctx, "acme", &organization.OrganizationArgs{
+//	    Description: "Acme platform team",
+//	    LogoUrl:     "https://acme.example.com/logo.png",
+//	})
+//
+// OrganizationArgs is generated from the OrganizationSpec proto into
+// sdk/go/tenancy/gen.
+//
+// # Synthesis
+//
+// Each organization is synthesized into its own manifest
+// (organization-{n}.pb in STIGMER_OUT_DIR), next to the agent and workflow
+// manifests.
+package organization
@@ -0,0 +1,9 @@
+package organization
+
+import "errors"
+
+// Common errors that can occur when working with organizations.
+var (
+	// ErrInvalidName is returned when an organization name is invalid.
+	ErrInvalidName = errors.New("invalid organization name")
+)
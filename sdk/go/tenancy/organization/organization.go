@@ -0,0 +1,110 @@
+package organization
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"github.com/stigmer/stigmer/sdk/go/stigmer/naming"
+	gen "github.com/stigmer/stigmer/sdk/go/tenancy/gen"
+)
+
+// OrganizationArgs is an alias for the generated OrganizationArgs (Pulumi Args pattern).
+type OrganizationArgs = gen.OrganizationArgs
+
+// Context is a minimal interface that represents a stigmer context.
+// This allows the organization package to work with contexts without importing
+// the stigmer package (avoiding import cycles).
+//
+// The stigmer.Context type implements this interface.
+type Context interface {
+	RegisterOrganization(*Organization)
+}
+
+// openChecker is implemented by contexts that are closed once they can no
+// longer synthesize resources, such as stigmer.Context after Run returns.
+type openChecker interface {
+	CheckOpen() error
+}
+
+// Organization is the top-level container of Stigmer resources.
+type Organization struct {
+	// Name is the organization name.
+	Name string
+
+	// Slug is the URL-friendly identifier (generated from the name).
+	Slug string
+
+	// Description is a human-readable description of the organization.
+	Description string
+
+	// LogoUrl is the public URL of the organization logo.
+	LogoUrl string
+}
+
+// slugRegex matches valid organization slugs: 2 to 15 lowercase letters,
+// numbers and hyphens, starting with a letter.
+var slugRegex = regexp.MustCompile(`^[a-z][a-z0-9-]{1,14}$`)
+
+// New creates a new Organization with struct-based args (Pulumi pattern).
+//
+// The organization is automatically registered with the provided context for
+// synthesis.
+//
+// Example:
+//
+//	acme, err := organization.New(ctx, "acme", &organization.OrganizationArgs{
+//	    Description: "Acme platform team",
+//	})
+func New(ctx Context, name string, args *OrganizationArgs) (*Organization, error) {
+	if oc, ok := ctx.(openChecker); ok {
+		if err := oc.CheckOpen(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Nil-safety: if args is nil, create empty args
+	if args == nil {
+		args = &OrganizationArgs{}
+	}
+
+	o := &Organization{
+		Name:        name,
+		Slug:        naming.GenerateSlug(name),
+		Description: args.Description,
+		LogoUrl:     args.LogoUrl,
+	}
+
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	// Register with context (if provided)
+	if ctx != nil {
+		ctx.RegisterOrganization(o)
+	}
+
+	return o, nil
+}
+
+// String returns a string representation of the Organization.
+func (o *Organization) String() string {
+	return fmt.Sprintf("Organization(name=%s)", o.Name)
+}
+
+// validate checks the organization name and slug.
+func (o *Organization) validate() error {
+	if err := validation.RequiredWithMessage("name", o.Name, "organization name is required"); err != nil {
+		return err
+	}
+	if !slugRegex.MatchString(o.Slug) {
+		return validation.NewValidationErrorWithCause(
+			"name",
+			o.Name,
+			"format",
+			fmt.Sprintf("organization slug %q must be 2 to 15 lowercase letters, numbers and hyphens, starting with a letter", o.Slug),
+			ErrInvalidName,
+		)
+	}
+	return nil
+}
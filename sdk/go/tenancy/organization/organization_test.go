@@ -0,0 +1,73 @@
+package organization
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+)
+
+type mockContext struct {
+	organizations []*Organization
+}
+
+func (m *mockContext) RegisterOrganization(o *Organization) {
+	m.organizations = append(m.organizations, o)
+}
+
+func TestNew(t *testing.T) {
+	ctx := &mockContext{}
+	org, err := New(ctx, "Acme Corp", &OrganizationArgs{
+		Description: "Acme platform team",
+		LogoUrl:     "https://acme.example.com/logo.png",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if org.Slug != "acme-corp" {
+		t.Errorf("Slug = %q, want acme-corp", org.Slug)
+	}
+	if len(ctx.organizations) != 1 || ctx.organizations[0] != org {
+		t.Errorf("registered organizations = %v, want [%v]", ctx.organizations, org)
+	}
+
+	pb, err := org.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if pb.GetKind() != "Organization" || pb.GetApiVersion() != "tenancy.stigmer.ai/v1" {
+		t.Errorf("kind = %q, apiVersion = %q", pb.GetKind(), pb.GetApiVersion())
+	}
+	if pb.GetMetadata().GetOwnerScope() != apiresource.ApiResourceOwnerScope_platform {
+		t.Errorf("owner scope = %v, want platform", pb.GetMetadata().GetOwnerScope())
+	}
+	if pb.GetSpec().GetDescription() != "Acme platform team" || pb.GetSpec().GetLogoUrl() != "https://acme.example.com/logo.png" {
+		t.Errorf("spec = %v", pb.GetSpec())
+	}
+}
+
+func TestNew_InvalidName(t *testing.T) {
+	tests := []struct {
+		name    string
+		orgName string
+	}{
+		{name: "empty", orgName: ""},
+		{name: "too short", orgName: "a"},
+		{name: "too long", orgName: "acme-platform-engineering"},
+		{name: "leading digit", orgName: "1acme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &mockContext{}
+			if _, err := New(ctx, tt.orgName, nil); err == nil {
+				t.Fatalf("New(%q) succeeded, want error", tt.orgName)
+			} else if tt.orgName != "" && !errors.Is(err, ErrInvalidName) {
+				t.Errorf("New(%q) error = %v, want ErrInvalidName", tt.orgName, err)
+			}
+			if len(ctx.organizations) != 0 {
+				t.Error("invalid organization was registered")
+			}
+		})
+	}
+}
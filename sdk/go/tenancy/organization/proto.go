@@ -0,0 +1,49 @@
+package organization
+
+import (
+	"fmt"
+
+	"buf.build/go/protovalidate"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	organizationv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/tenancy/organization/v1"
+	"github.com/stigmer/stigmer/sdk/go/agent"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// validator is the global protovalidate validator instance.
+var validator protovalidate.Validator
+
+func init() {
+	// Initialize validator once at package load time
+	var err error
+	validator, err = protovalidate.New()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize protovalidate: %v", err))
+	}
+}
+
+// ToProto converts the SDK Organization to a platform Organization proto message.
+func (o *Organization) ToProto() (*organizationv1.Organization, error) {
+	org := &organizationv1.Organization{
+		ApiVersion: "tenancy.stigmer.ai/v1",
+		Kind:       "Organization",
+		Metadata: &apiresource.ApiResourceMetadata{
+			Name:        o.Name,
+			Slug:        o.Slug,
+			Annotations: agent.SDKAnnotations(),
+			OwnerScope:  apiresource.ApiResourceOwnerScope_platform,
+		},
+		Spec: &organizationv1.OrganizationSpec{
+			Description: o.Description,
+			LogoUrl:     o.LogoUrl,
+		},
+	}
+
+	if err := validator.Validate(org); err != nil {
+		return nil, fmt.Errorf("organization validation failed: %w",
+			validation.NewConversionErrorFromViolations("Organization", "", err))
+	}
+
+	return org, nil
+}
@@ -1,11 +1,19 @@
 package workflow
 
-import "github.com/stigmer/stigmer/sdk/go/agent"
+import (
+	"fmt"
 
-// AgentRef represents a reference to an agent (Pulumi-style).
+	"github.com/stigmer/stigmer/sdk/go/agent"
+)
+
+// AgentReference represents a reference to an agent (Pulumi-style).
+//
+// AgentReference enables workflows to reference agents either by direct
+// instance, by slug with optional scope specification, or by organization
+// and name for agents deployed from another project.
 //
-// AgentRef enables workflows to reference agents either by direct instance
-// or by slug with optional scope specification.
+// A reference is an AgentCallOption: pass it to CallAgent to set the agent
+// the task invokes.
 //
 // Example:
 //
@@ -17,15 +25,32 @@ import "github.com/stigmer/stigmer/sdk/go/agent"
 //
 //	// Reference agent by slug with explicit scope
 //	ref := workflow.AgentBySlug("code-reviewer", "platform")
-type AgentRef struct {
+//
+//	// Reference an agent deployed by another project
+//	ref := workflow.AgentRef("my-org", "code-reviewer-prod", workflow.AgentVersion("stable"))
+type AgentReference struct {
 	// Agent slug (name)
 	slug string
 
 	// Scope (platform or organization) - optional
 	// Empty means unspecified, will default to organization at runtime
 	scope string
+
+	// org owns the agent (set via AgentRef); empty means the organization
+	// running the workflow
+	org string
+
+	// version is the agent version tag or hash (set via AgentVersion)
+	version string
+
+	// byOrg marks references created with AgentRef, whose org and name are
+	// validated at synthesis
+	byOrg bool
 }
 
+// AgentRefOption configures a reference created with AgentRef.
+type AgentRefOption func(*AgentReference)
+
 // Agent creates an AgentRef from an agent instance.
 // This is the Pulumi-style reference pattern.
 //
@@ -36,8 +61,8 @@ type AgentRef struct {
 //	    agent.WithInstructions("Review code"),
 //	)
 //	ref := workflow.Agent(reviewer)
-func Agent(a *agent.Agent) AgentRef {
-	return AgentRef{
+func Agent(a *agent.Agent) AgentReference {
+	return AgentReference{
 		slug:  a.Name,
 		scope: determineScope(a),
 	}
@@ -58,25 +83,111 @@ func Agent(a *agent.Agent) AgentRef {
 //
 //	// Platform scope (public agent)
 //	ref := workflow.AgentBySlug("code-reviewer", "platform")
-func AgentBySlug(slug string, scope ...string) AgentRef {
-	ref := AgentRef{slug: slug}
+func AgentBySlug(slug string, scope ...string) AgentReference {
+	ref := AgentReference{slug: slug}
 	if len(scope) > 0 {
 		ref.scope = scope[0]
 	}
 	return ref
 }
 
+// AgentRef references an agent by the organization that owns it and its
+// name, for agents deployed from a different program or repository.
+//
+// stigmer-server resolves the reference when the task runs, so synthesis only
+// checks that org and name are set. Run `stigmer apply --verify-agent-refs`
+// to check that the agent exists when deploying.
+//
+// Example:
+//
+//	reviewer := workflow.AgentRef("my-org", "code-reviewer-prod", workflow.AgentVersion("stable"))
+//	wf.CallAgent("review", &workflow.AgentCallArgs{
+//	    Message: "Review this PR: ${.input.prUrl}",
+//	}, reviewer)
+func AgentRef(org, name string, opts ...AgentRefOption) AgentReference {
+	ref := AgentReference{
+		slug:  name,
+		scope: "organization",
+		org:   org,
+		byOrg: true,
+	}
+	for _, opt := range opts {
+		opt(&ref)
+	}
+	return ref
+}
+
+// AgentVersion pins the agent version: a tag such as "stable", a version
+// hash, or "latest" (the default). The task fails at runtime if the agent
+// doesn't carry the version.
+func AgentVersion(version string) AgentRefOption {
+	return func(r *AgentReference) {
+		r.version = version
+	}
+}
+
 // Slug returns the agent slug.
-func (r AgentRef) Slug() string {
+func (r AgentReference) Slug() string {
 	return r.slug
 }
 
 // Scope returns the agent scope (platform or organization).
 // Empty string means unspecified (defaults to organization at runtime).
-func (r AgentRef) Scope() string {
+func (r AgentReference) Scope() string {
 	return r.scope
 }
 
+// Org returns the organization that owns the agent.
+// Empty string means the organization running the workflow.
+func (r AgentReference) Org() string {
+	return r.org
+}
+
+// Version returns the agent version ("" for the latest version).
+func (r AgentReference) Version() string {
+	return r.version
+}
+
+// applyAgentCall sets the agent invoked by an AGENT_CALL task.
+func (r AgentReference) applyAgentCall(t *Task, cfg *AgentCallTaskConfig) {
+	cfg.Agent = r.slug
+	if r.scope != "" {
+		cfg.Scope = r.scope
+	}
+	cfg.Org = r.org
+	cfg.Version = r.version
+	t.agentRef = &r
+}
+
+// validateAgentRef checks the org and name of an agent referenced with
+// AgentRef. Everything else is resolved by stigmer-server at runtime.
+func (t *Task) validateAgentRef() error {
+	ref := t.agentRef
+	if ref == nil || !ref.byOrg {
+		return nil
+	}
+
+	if ref.org == "" {
+		return NewValidationErrorWithCause(
+			"org",
+			"",
+			"required",
+			fmt.Sprintf("task %q: AgentRef needs the organization that owns agent %q", t.Name, ref.slug),
+			ErrInvalidAgentRef,
+		)
+	}
+	if ref.slug == "" {
+		return NewValidationErrorWithCause(
+			"agent",
+			"",
+			"required",
+			fmt.Sprintf("task %q: AgentRef needs the agent name", t.Name),
+			ErrInvalidAgentRef,
+		)
+	}
+	return nil
+}
+
 // determineScope infers scope from agent configuration.
 //
 // Logic:
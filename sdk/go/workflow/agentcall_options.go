@@ -1,8 +1,65 @@
 package workflow
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+)
+
 // AgentCallArgs is an alias for AgentCallTaskConfig (Pulumi-style args pattern).
 type AgentCallArgs = AgentCallTaskConfig
 
+// AgentCallOption configures an AGENT_CALL task. Agent references created
+// with Agent, AgentBySlug and AgentRef are options that set the agent the
+// task invokes; Timeout sets the execution timeout; StreamToContext and
+// FinalOutputOnly shape the task output.
+type AgentCallOption interface {
+	applyAgentCall(t *Task, cfg *AgentCallTaskConfig)
+}
+
+// agentCallOptionFunc adapts a function to AgentCallOption.
+type agentCallOptionFunc func(t *Task, cfg *AgentCallTaskConfig)
+
+func (f agentCallOptionFunc) applyAgentCall(t *Task, cfg *AgentCallTaskConfig) {
+	f(t, cfg)
+}
+
+// StreamToContext records the agent's output as "final" plus an array of
+// timestamped "chunks", one per agent message, so progress is kept for audit
+// logs. It sets AgentCallArgs.StreamToContext.
+//
+// Example:
+//
+//	research := wf.CallAgent("research", &workflow.AgentCallArgs{
+//	    Agent:   "researcher",
+//	    Message: "Summarize recent papers on ${.input.topic}",
+//	}, workflow.StreamToContext())
+//	research.Field("chunks[3].text") // fourth streamed chunk
+func StreamToContext() AgentCallOption {
+	return agentCallOptionFunc(func(_ *Task, cfg *AgentCallTaskConfig) {
+		cfg.StreamToContext = true
+	})
+}
+
+// FinalOutputOnly records only the agent's final message as "final".
+// Recommended for long-running agents, since task output is stored in
+// Temporal history. It takes precedence over StreamToContext and sets
+// AgentCallArgs.FinalOutputOnly.
+//
+// Example:
+//
+//	summary := wf.CallAgent("summarize", &workflow.AgentCallArgs{
+//	    Agent:   "summarizer",
+//	    Message: "Summarize the incident",
+//	}, workflow.FinalOutputOnly())
+//	summary.Field("final")
+func FinalOutputOnly() AgentCallOption {
+	return agentCallOptionFunc(func(_ *Task, cfg *AgentCallTaskConfig) {
+		cfg.FinalOutputOnly = true
+	})
+}
+
 // AgentCall creates an AGENT_CALL task using struct-based args.
 // This follows the Pulumi Args pattern for resource configuration.
 //
@@ -18,7 +75,65 @@ type AgentCallArgs = AgentCallTaskConfig
 //	        Model: "claude-3-5-sonnet",
 //	    },
 //	})
-func AgentCall(name string, args *AgentCallArgs) *Task {
+//
+// Output shaping:
+//
+// Pass the StreamToContext option to record the agent's output as "final"
+// plus an array of timestamped "chunks", or FinalOutputOnly to keep only
+// "final" (recommended for long-running agents, since task output is stored
+// in Temporal history). FinalOutputOnly takes precedence when both are given:
+//
+//	task := workflow.AgentCall("research", &workflow.AgentCallArgs{
+//	    Agent:   "researcher",
+//	    Message: "Summarize recent papers on ${.input.topic}",
+//	}, workflow.StreamToContext())
+//	task.Field("final")          // final agent message
+//	task.Field("chunks[3].text") // fourth streamed chunk
+//
+// Structured output:
+//
+// When the agent declares an output schema (agent.WithOutputSchema), or the
+// task sets Config.OutputSchema, the task output is the agent's JSON response
+// validated against the schema, and the streaming options above are ignored.
+// Field() paths are checked against the schema at synthesis:
+//
+//	review := wf.CallAgent("review", &workflow.AgentCallArgs{
+//	    Agent:   "code-reviewer",
+//	    Message: "Review this PR: ${.input.prUrl}",
+//	    Config: &types.AgentExecutionConfig{
+//	        OutputSchema: map[string]any{"severity": "string", "issues": "array"},
+//	    },
+//	})
+//	review.Field("severity") // ✅ declared by the schema
+//	review.Field("summary")  // ❌ synthesis error
+//
+// Attachments:
+//
+// Pass files such as a diff fetched by a previous task with Attachment instead
+// of inlining them into the message. The agent runner writes each attachment
+// to /attachments/{name} in the agent's sandbox. Task field references used as
+// attachment content make the agent call depend on the referenced task:
+//
+//	fetchDiff := wf.HttpGet("fetch-diff", diffURL, nil)
+//	review := wf.CallAgent("review", &workflow.AgentCallArgs{
+//	    Agent:   "code-reviewer",
+//	    Message: "Review the attached diff",
+//	    Attachments: []*types.AgentAttachment{
+//	        workflow.AttachmentRef("diff.patch", fetchDiff.Field("body"),
+//	            workflow.MimeType("text/x-patch"),
+//	        ),
+//	    },
+//	})
+//
+// Agents deployed from another program:
+//
+// Pass an AgentRef option instead of setting Agent. stigmer-server resolves
+// the organization, name and version when the task runs:
+//
+//	wf.CallAgent("review", &workflow.AgentCallArgs{
+//	    Message: "Review this PR: ${.input.prUrl}",
+//	}, workflow.AgentRef("my-org", "code-reviewer-prod", workflow.AgentVersion("stable")))
+func AgentCall(name string, args *AgentCallArgs, opts ...AgentCallOption) *Task {
 	if args == nil {
 		args = &AgentCallArgs{}
 	}
@@ -29,10 +144,136 @@ func AgentCall(name string, args *AgentCallArgs) *Task {
 	}
 	// Config is optional and can be nil
 
-	return &Task{
+	task := &Task{
 		Name:   name,
 		Kind:   TaskKindAgentCall,
 		Config: args,
 	}
+	for _, opt := range opts {
+		opt.applyAgentCall(task, args)
+	}
+
+	// Attachment content referencing task output implies a dependency
+	for _, attachment := range args.Attachments {
+		if attachment == nil {
+			continue
+		}
+		if ref, ok := attachment.ContentRef.(TaskFieldRef); ok {
+			task.DependsOn(&Task{Name: ref.TaskName()})
+		}
+	}
+
+	return task
+}
+
+// DefaultAttachmentMaxSize is the size limit of an attachment without MaxSize (1 MiB).
+const DefaultAttachmentMaxSize = 1 << 20
+
+// AttachmentOption configures an agent call attachment.
+type AttachmentOption func(*types.AgentAttachment)
+
+// Attachment creates an agent call attachment named name.
+//
+// content is a string literal or a raw "${ }" expression; use AttachmentRef
+// for references such as a task output field. The name is used as the file
+// name in the agent's sandbox. The MIME type defaults to text/plain.
+//
+// Example:
+//
+//	workflow.Attachment("guidelines.md", "Prefer small functions and table-driven tests.",
+//	    workflow.MimeType("text/markdown"),
+//	)
+func Attachment(name string, content string, opts ...AttachmentOption) *types.AgentAttachment {
+	attachment := &types.AgentAttachment{
+		Name:    name,
+		Content: content,
+	}
+	for _, opt := range opts {
+		opt(attachment)
+	}
+	return attachment
 }
 
+// AttachmentRef creates an agent call attachment whose content is a typed
+// reference, such as a task output field (task.Field("body")) or a StringRef.
+//
+// Example:
+//
+//	workflow.AttachmentRef("diff.patch", fetchDiff.Field("body"),
+//	    workflow.MimeType("text/x-patch"),
+//	    workflow.MaxSize(512*1024),
+//	)
+func AttachmentRef(name string, content StringExpr, opts ...AttachmentOption) *types.AgentAttachment {
+	attachment := &types.AgentAttachment{
+		Name:       name,
+		ContentRef: content,
+	}
+	for _, opt := range opts {
+		opt(attachment)
+	}
+	return attachment
+}
+
+// MimeType sets the MIME type of an attachment.
+func MimeType(mimeType string) AttachmentOption {
+	return func(a *types.AgentAttachment) {
+		a.MimeType = mimeType
+	}
+}
+
+// MaxSize sets the maximum attachment size in bytes (at most 4 MiB).
+// Literal content is checked at synthesis; content resolved from expressions
+// is checked by the workflow runner.
+func MaxSize(bytes int32) AttachmentOption {
+	return func(a *types.AgentAttachment) {
+		a.MaxSizeBytes = bytes
+	}
+}
+
+// validateAttachments checks that attachment names are unique and that
+// literal content fits the attachment's size limit.
+func (t *Task) validateAttachments() error {
+	cfg, ok := t.Config.(*AgentCallTaskConfig)
+	if !ok {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(cfg.Attachments))
+	for _, attachment := range cfg.Attachments {
+		if attachment == nil {
+			continue
+		}
+		if seen[attachment.Name] {
+			return NewValidationErrorWithCause(
+				"attachments",
+				attachment.Name,
+				"unique",
+				fmt.Sprintf("task %q has more than one attachment named %q", t.Name, attachment.Name),
+				ErrInvalidAttachment,
+			)
+		}
+		seen[attachment.Name] = true
+
+		// Expressions are resolved and size-checked by the runner
+		content := attachment.Content
+		if attachment.ContentRef != nil || strings.Contains(content, "${") {
+			continue
+		}
+		limit := int(attachment.MaxSizeBytes)
+		if limit == 0 {
+			limit = DefaultAttachmentMaxSize
+		}
+		if len(content) > limit {
+			return NewValidationErrorWithCause(
+				"attachments",
+				attachment.Name,
+				"max_size",
+				fmt.Sprintf("attachment %q of task %q is %d bytes, exceeding the %d byte limit",
+					attachment.Name, t.Name, len(content), limit),
+				ErrInvalidAttachment,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package workflow
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+)
+
+// ApprovalOption configures an approval task created with Approval.
+type ApprovalOption func(*Task)
+
+// ApprovalTimeoutAction is what an approval task does when no decision
+// arrives before its timeout.
+type ApprovalTimeoutAction string
+
+const (
+	// FailWorkflow fails the approval task, and with it the workflow unless
+	// the task is wrapped in a Try. This is the default.
+	FailWorkflow ApprovalTimeoutAction = "fail"
+
+	// AutoApprove approves the task; its approved_by output is "timeout".
+	AutoApprove ApprovalTimeoutAction = "approve"
+)
+
+// approvalSignalID is the signal an approval task listens to. Decisions are
+// routed to the task by the execution engine, so one ID serves every task.
+const approvalSignalID = "approval"
+
+// Approvers restricts who can decide on the approval to the given identities
+// or groups. Without it, anyone who can edit the execution can decide.
+func Approvers(approvers ...string) ApprovalOption {
+	return func(t *Task) {
+		a := approvalOf(t)
+		a.Approvers = append(a.Approvers, approvers...)
+	}
+}
+
+// ApprovalTimeoutDuration sets how long the task waits for a decision. The
+// default is 24 hours.
+//
+// Like TimeoutDuration, fractional seconds are rounded up, and a positive
+// timeout shorter than one second fails synthesis with ErrInvalidDuration.
+// Zero keeps the default.
+func ApprovalTimeoutDuration(d time.Duration) ApprovalOption {
+	return func(t *Task) {
+		a := approvalOf(t)
+		switch {
+		case d < 0:
+			// Rounded away from zero so validateApproval reports it as negative
+			a.TimeoutSeconds = int32(math.Floor(d.Seconds()))
+		case d == 0:
+			a.TimeoutSeconds = 0
+		default:
+			a.TimeoutSeconds = timeoutSeconds(t, d)
+		}
+	}
+}
+
+// ApprovalTimeout sets how long the task waits for a decision. It behaves
+// exactly like ApprovalTimeoutDuration.
+//
+// Deprecated: Use ApprovalTimeoutDuration, whose name makes the unit explicit.
+func ApprovalTimeout(d time.Duration) ApprovalOption {
+	return ApprovalTimeoutDuration(d)
+}
+
+// OnTimeout sets what happens when no decision arrives before the timeout.
+func OnTimeout(action ApprovalTimeoutAction) ApprovalOption {
+	return func(t *Task) {
+		approvalOf(t).OnTimeout = string(action)
+	}
+}
+
+// approvalOf returns the approval configuration of a task created by Approval.
+func approvalOf(t *Task) *types.ListenApproval {
+	return t.Config.(*ListenTaskConfig).Approval
+}
+
+// Approval creates a task that pauses the workflow until a human approves it.
+//
+// The execution waits in phase EXECUTION_AWAITING_APPROVAL until a decision
+// is sent with `stigmer workflow approve <execution-id> <task>` (or the
+// approve RPC). Once approved, the task output is:
+//
+//	{"approved": true, "approved_by": "...", "approved_at": "...", "comment": "..."}
+//
+// A rejection fails the task with error type "ApprovalRejected", which a Try
+// task can catch.
+//
+// The task is a LISTEN task with an approval configuration.
+//
+// Example:
+//
+//	approval := workflow.Approval("approveDeploy",
+//	    workflow.Approvers("ops-team"),
+//	    workflow.ApprovalTimeoutDuration(48*time.Hour),
+//	    workflow.OnTimeout(workflow.FailWorkflow),
+//	)
+func Approval(name string, opts ...ApprovalOption) *Task {
+	task := &Task{
+		Name: name,
+		Kind: TaskKindListen,
+		Config: &ListenTaskConfig{
+			To: &types.ListenTo{
+				Mode: "one",
+				Signals: []*types.SignalSpec{
+					{Id: approvalSignalID, Type: "signal"},
+				},
+			},
+			Approval: &types.ListenApproval{},
+		},
+	}
+	for _, opt := range opts {
+		opt(task)
+	}
+	return task
+}
+
+// Approval creates an approval task and adds it to the workflow.
+//
+// Example:
+//
+//	approval := wf.Approval("approveDeploy",
+//	    workflow.Approvers("ops-team"),
+//	    workflow.ApprovalTimeoutDuration(48*time.Hour),
+//	)
+//	wf.HttpPost("deploy", deployURL, nil, map[string]interface{}{
+//	    "approvedBy": approval.Field("approved_by"),
+//	})
+func (w *Workflow) Approval(name string, opts ...ApprovalOption) *Task {
+	task := Approval(name, opts...)
+	w.AddTask(task)
+	return task
+}
+
+// validateApproval checks the timeout, timeout action and approvers of an
+// approval task.
+func (t *Task) validateApproval() error {
+	cfg, ok := t.Config.(*ListenTaskConfig)
+	if !ok || cfg.Approval == nil {
+		return nil
+	}
+	approval := cfg.Approval
+
+	if approval.TimeoutSeconds < 0 {
+		return NewValidationErrorWithCause(
+			"approval.timeoutSeconds",
+			fmt.Sprintf("%d", approval.TimeoutSeconds),
+			"min",
+			fmt.Sprintf("task %q: approval timeout must not be negative", t.Name),
+			ErrInvalidApproval,
+		)
+	}
+
+	switch ApprovalTimeoutAction(approval.OnTimeout) {
+	case "", FailWorkflow, AutoApprove:
+	default:
+		return NewValidationErrorWithCause(
+			"approval.onTimeout",
+			approval.OnTimeout,
+			"enum",
+			fmt.Sprintf("task %q: unknown approval timeout action %q (use FailWorkflow or AutoApprove)",
+				t.Name, approval.OnTimeout),
+			ErrInvalidApproval,
+		)
+	}
+
+	for i, approver := range approval.Approvers {
+		if approver == "" {
+			return NewValidationErrorWithCause(
+				fmt.Sprintf("approval.approvers[%d]", i),
+				approver,
+				"required",
+				fmt.Sprintf("task %q: approvers must not be empty", t.Name),
+				ErrInvalidApproval,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestApproval_Synthesis(t *testing.T) {
+	wf, err := New(nil, "ops/deploy", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	approval := wf.Approval("approveDeploy",
+		Approvers("ops-team"),
+		ApprovalTimeoutDuration(48*time.Hour),
+		OnTimeout(FailWorkflow),
+	)
+	wf.Set("record", &SetArgs{Variables: map[string]interface{}{
+		"approver": approval.Field("approved_by").Expression(),
+	}})
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	tasks := pb.GetSpec().GetTasks()
+	config := tasks[0].GetTaskConfig().GetFields()
+	signal := config["to"].GetStructValue().GetFields()["signals"].GetListValue().GetValues()[0].GetStructValue().GetFields()
+	if got := signal["type"].GetStringValue(); got != "signal" {
+		t.Errorf("signal type = %q, want signal", got)
+	}
+
+	approvalConfig := config["approval"].GetStructValue().GetFields()
+	if got := approvalConfig["approvers"].GetListValue().GetValues()[0].GetStringValue(); got != "ops-team" {
+		t.Errorf("approvers[0] = %q, want ops-team", got)
+	}
+	if got := approvalConfig["timeout_seconds"].GetNumberValue(); got != 172800 {
+		t.Errorf("timeout_seconds = %v, want 172800", got)
+	}
+	if got := approvalConfig["on_timeout"].GetStringValue(); got != "fail" {
+		t.Errorf("on_timeout = %q, want fail", got)
+	}
+
+	want := `${ $context["approveDeploy"].approved_by }`
+	if got := tasks[1].GetTaskConfig().GetFields()["variables"].GetStructValue().GetFields()["approver"].GetStringValue(); got != want {
+		t.Errorf("approver = %q, want %q", got, want)
+	}
+}
+
+func TestApproval_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ApprovalOption
+	}{
+		{"negative timeout", []ApprovalOption{ApprovalTimeout(-time.Minute)}},
+		{"unknown timeout action", []ApprovalOption{OnTimeout("escalate")}},
+		{"empty approver", []ApprovalOption{Approvers("ops-team", "")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "ops/deploy", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.Approval("approveDeploy", tt.opts...)
+
+			if _, err := wf.ToProto(); !errors.Is(err, ErrInvalidApproval) {
+				t.Errorf("ToProto() error = %v, want ErrInvalidApproval", err)
+			}
+		})
+	}
+}
+
+func TestApprovalTimeoutDuration_Rounding(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       time.Duration
+		want    int32
+		wantErr bool
+	}{
+		{"whole seconds", 90 * time.Second, 90, false},
+		{"fractional rounds up", 1500 * time.Millisecond, 2, false},
+		{"zero keeps default", 0, 0, false},
+		{"sub-second", 500 * time.Millisecond, 0, true},
+		{"bare number", 3600, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "ops/deploy", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			approval := wf.Approval("approveDeploy", ApprovalTimeoutDuration(tt.d))
+
+			_, err = wf.ToProto()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDuration) {
+					t.Fatalf("ToProto() error = %v, want ErrInvalidDuration", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ToProto() failed: %v", err)
+			}
+			if got := approval.Config.(*ListenTaskConfig).Approval.TimeoutSeconds; got != tt.want {
+				t.Errorf("TimeoutSeconds = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
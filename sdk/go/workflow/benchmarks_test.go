@@ -29,7 +29,7 @@ func BenchmarkWorkflowToProto_Minimal(b *testing.B) {
 				Name: "task1",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{"x": "y"},
+					Variables: map[string]interface{}{"x": "y"},
 				},
 			},
 		},
@@ -56,7 +56,7 @@ func BenchmarkWorkflowToProto_SingleTask(b *testing.B) {
 				Name: "setTask",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"var1": "value1",
 						"var2": "value2",
 						"var3": "value3",
@@ -174,7 +174,7 @@ func BenchmarkWorkflowToProto_MultipleTasks(b *testing.B) {
 					Name: "task" + string(rune('0'+i%10)),
 					Kind: TaskKindSet,
 					Config: &SetTaskConfig{
-						Variables: map[string]string{
+						Variables: map[string]interface{}{
 							"key" + string(rune('0'+i%10)): "value" + string(rune('0'+i%10)),
 						},
 					},
@@ -231,7 +231,7 @@ func BenchmarkWorkflowToProto_WithEnvironmentVariables(b *testing.B) {
 						Name: "task1",
 						Kind: TaskKindSet,
 						Config: &SetTaskConfig{
-							Variables: map[string]string{"x": "y"},
+							Variables: map[string]interface{}{"x": "y"},
 						},
 					},
 				},
@@ -348,7 +348,7 @@ func BenchmarkWorkflowToProto_Allocations(b *testing.B) {
 				Name: "task2",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"key1": "value1",
 						"key2": "value2",
 					},
@@ -387,7 +387,7 @@ func BenchmarkWorkflow_Creation(b *testing.B) {
 					Name: "task1",
 					Kind: TaskKindSet,
 					Config: &SetTaskConfig{
-						Variables: map[string]string{"x": "y"},
+						Variables: map[string]interface{}{"x": "y"},
 					},
 				},
 			},
@@ -412,7 +412,7 @@ func BenchmarkWorkflow_TaskAddition(b *testing.B) {
 		Name: "task1",
 		Kind: TaskKindSet,
 		Config: &SetTaskConfig{
-			Variables: map[string]string{"x": "y"},
+			Variables: map[string]interface{}{"x": "y"},
 		},
 	}
 
@@ -441,7 +441,7 @@ func BenchmarkWorkflowToProto_RealisticAPIWorkflow(b *testing.B) {
 				Name: "validateInput",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"emailValid":    "${user.email matches '^[^@]+@[^@]+$'}",
 						"passwordValid": "${length(user.password) >= 8}",
 					},
@@ -545,7 +545,7 @@ func BenchmarkWorkflowToProto_RealisticDataPipeline(b *testing.B) {
 				Name: "aggregateResults",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"totalRecords":   "${count(processRecords)}",
 						"successCount":   "${count(processRecords.success)}",
 						"errorCount":     "${count(processRecords.errors)}",
@@ -597,7 +597,7 @@ func BenchmarkWorkflowToProto_Parallel(b *testing.B) {
 				Name: "task1",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{"x": "y"},
+					Variables: map[string]interface{}{"x": "y"},
 				},
 			},
 		},
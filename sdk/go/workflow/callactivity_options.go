@@ -6,13 +6,22 @@ type CallActivityArgs = CallActivityTaskConfig
 // CallActivity creates a CALL_ACTIVITY task using struct-based args.
 // This follows the Pulumi Args pattern for resource configuration.
 //
+// The activity is dispatched by name. Without a TaskQueue it runs on the
+// workflow runner's own queue, where it must be registered as a custom
+// activity; otherwise it runs on whichever worker polls TaskQueue.
+//
+// Input values may be task output references (e.g. fetchTask.Field("body")),
+// which are converted to runtime expressions.
+//
 // Example:
 //
-//	task := workflow.CallActivity("processData", &workflow.CallActivityArgs{
-//	    Activity: "dataProcessor",
-//	    Input:    map[string]interface{}{"data": "${.input}"},
+//	task := workflow.CallActivity("normalize", &workflow.CallActivityArgs{
+//	    Activity:       "transform.v1.Normalize",
+//	    Input:          map[string]interface{}{"data": fetchTask.Field("body")},
+//	    TaskQueue:      "custom-q",
+//	    TimeoutSeconds: 120,
 //	})
-func CallActivity(name string, args *CallActivityArgs) *Task {
+func CallActivity(name string, args *CallActivityArgs, opts ...CallActivityOption) *Task {
 	if args == nil {
 		args = &CallActivityArgs{}
 	}
@@ -22,9 +31,19 @@ func CallActivity(name string, args *CallActivityArgs) *Task {
 		args.Input = make(map[string]interface{})
 	}
 
-	return &Task{
+	task := &Task{
 		Name:   name,
 		Kind:   TaskKindCallActivity,
 		Config: args,
 	}
+	for _, opt := range opts {
+		opt.applyCallActivity(task, args)
+	}
+	return task
+}
+
+// CallActivityOption configures a CALL_ACTIVITY task. Timeout is a
+// CallActivityOption.
+type CallActivityOption interface {
+	applyCallActivity(t *Task, cfg *CallActivityTaskConfig)
 }
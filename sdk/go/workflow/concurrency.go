@@ -0,0 +1,138 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+)
+
+// Built-in concurrency keys.
+const (
+	// ConcurrencyKeyOrg serializes executions per organization.
+	ConcurrencyKeyOrg = "org"
+
+	// ConcurrencyKeyWorkflow serializes all executions of the workflow.
+	ConcurrencyKeyWorkflow = "workflow"
+)
+
+// concurrencyKeyRegex matches trigger metadata keys usable as concurrency keys.
+var concurrencyKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ConcurrencyKey identifies what executions of a workflow are serialized on.
+// Create one with SerializeOn.
+type ConcurrencyKey string
+
+// SerializeOn returns the key executions are serialized on.
+//
+// "org" serializes per organization, "workflow" serializes all executions of
+// the workflow, and any other value names a trigger metadata entry:
+//
+//	workflow.SerializeOn("org")       // one execution per org at a time
+//	workflow.SerializeOn("tenant_id") // one execution per trigger_metadata["tenant_id"]
+func SerializeOn(key string) ConcurrencyKey {
+	return ConcurrencyKey(key)
+}
+
+// ConflictAction defines what happens when a new execution conflicts with an
+// in-flight execution that has the same concurrency key.
+type ConflictAction struct {
+	action workflowv1.ConcurrencyConflictAction
+}
+
+// OnConflictQueue waits for in-flight executions to finish before starting.
+func OnConflictQueue() ConflictAction {
+	return ConflictAction{action: workflowv1.ConcurrencyConflictAction_CONCURRENCY_QUEUE}
+}
+
+// OnConflictSkip does not run the new execution.
+func OnConflictSkip() ConflictAction {
+	return ConflictAction{action: workflowv1.ConcurrencyConflictAction_CONCURRENCY_SKIP}
+}
+
+// OnConflictCancelExisting cancels in-flight executions and starts the new one.
+func OnConflictCancelExisting() ConflictAction {
+	return ConflictAction{action: workflowv1.ConcurrencyConflictAction_CONCURRENCY_CANCEL_EXISTING}
+}
+
+// String returns the conflict action name.
+func (a ConflictAction) String() string {
+	switch a.action {
+	case workflowv1.ConcurrencyConflictAction_CONCURRENCY_SKIP:
+		return "skip"
+	case workflowv1.ConcurrencyConflictAction_CONCURRENCY_CANCEL_EXISTING:
+		return "cancel-existing"
+	default:
+		return "queue"
+	}
+}
+
+// ConcurrencyPolicy serializes executions of a workflow that share a key.
+// Set it with Workflow.WithConcurrencyPolicy.
+type ConcurrencyPolicy struct {
+	// Key is what executions are serialized on.
+	Key ConcurrencyKey
+
+	// OnConflict is what happens when an in-flight execution shares the key.
+	OnConflict ConflictAction
+}
+
+// WithConcurrencyPolicy sets the workflow's concurrency policy.
+//
+// The policy is enforced by the server when executions are created: a new
+// execution whose key matches an in-flight execution is queued, skipped, or
+// causes the in-flight execution to be cancelled.
+//
+// Example:
+//
+//	err := wf.WithConcurrencyPolicy(workflow.SerializeOn("org"), workflow.OnConflictQueue())
+func (w *Workflow) WithConcurrencyPolicy(key ConcurrencyKey, onConflict ConflictAction) error {
+	policy := &ConcurrencyPolicy{Key: key, OnConflict: onConflict}
+	if err := policy.validate(); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.ConcurrencyPolicy = policy
+	return nil
+}
+
+// validate checks the concurrency key format.
+func (p *ConcurrencyPolicy) validate() error {
+	key := string(p.Key)
+	if key == "" {
+		return NewValidationErrorWithCause(
+			"concurrency_policy.key",
+			key,
+			"required",
+			"concurrency key is required",
+			ErrInvalidConcurrencyPolicy,
+		)
+	}
+	if !concurrencyKeyRegex.MatchString(key) {
+		return NewValidationErrorWithCause(
+			"concurrency_policy.key",
+			key,
+			"format",
+			fmt.Sprintf("concurrency key %q must contain only letters, digits, '_', '.' or '-'", key),
+			ErrInvalidConcurrencyPolicy,
+		)
+	}
+	return nil
+}
+
+// toProto converts the policy to its proto form. Returns nil for a nil policy.
+func (p *ConcurrencyPolicy) toProto() *workflowv1.ConcurrencyPolicy {
+	if p == nil {
+		return nil
+	}
+	action := p.OnConflict.action
+	if action == workflowv1.ConcurrencyConflictAction_CONCURRENCY_CONFLICT_ACTION_UNSPECIFIED {
+		action = workflowv1.ConcurrencyConflictAction_CONCURRENCY_QUEUE
+	}
+	return &workflowv1.ConcurrencyPolicy{
+		Key:        string(p.Key),
+		OnConflict: action,
+	}
+}
@@ -0,0 +1,253 @@
+package workflow
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	genWorkflow "github.com/stigmer/stigmer/sdk/go/gen/workflow"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// customTaskKindRegex matches custom task kinds: upper snake case, like the
+// built-in kinds (mirrors the WorkflowTask.custom_kind constraint).
+var customTaskKindRegex = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+
+// builtinTaskKinds are the task kinds of the platform. Custom kinds may not
+// reuse them.
+var builtinTaskKinds = []TaskKind{
+	TaskKindSet, TaskKindHttpCall, TaskKindGrpcCall, TaskKindSwitch,
+	TaskKindFor, TaskKindFork, TaskKindTry, TaskKindListen, TaskKindWait,
+	TaskKindCallActivity, TaskKindRaise, TaskKindRun, TaskKindAgentCall,
+}
+
+// reservedCustomTaskKind is the manifest kind that carries custom tasks.
+const reservedCustomTaskKind TaskKind = "CUSTOM"
+
+// customTaskConverter converts the config of a registered custom task kind.
+type customTaskConverter func(config any) (*structpb.Struct, error)
+
+var (
+	customTaskKindsMu sync.RWMutex
+	customTaskKinds   = make(map[TaskKind]customTaskConverter)
+)
+
+// RegisterCustomTaskKind registers a task kind implemented by a workflow
+// runner extension, such as "SNOWFLAKE_QUERY". Tasks of the kind are created
+// with Custom (or wf.Custom) and a config of type C; at synthesis convert
+// turns the config into the task_config written to the manifest.
+//
+// The kind must be upper snake case and must not collide with a built-in
+// kind or an already registered one, or ErrInvalidTaskKind is returned.
+// Register kinds once, typically from an init function of the package that
+// defines the config type.
+//
+// Custom tasks are written as WORKFLOW_TASK_KIND_CUSTOM with the kind in
+// custom_kind; the runner executes them with the extension registered for
+// the kind. Task references in the converted config are tracked like those
+// of built-in tasks. References the converter does not write out as
+// expressions can be declared by implementing CustomTaskRefs on C or with
+// the CustomRefs option.
+//
+// Example:
+//
+//	type SnowflakeQuery struct {
+//	    Warehouse string
+//	    SQL       string
+//	}
+//
+//	func init() {
+//	    err := workflow.RegisterCustomTaskKind("SNOWFLAKE_QUERY",
+//	        func(q SnowflakeQuery) (*structpb.Struct, error) {
+//	            return structpb.NewStruct(map[string]any{
+//	                "warehouse": q.Warehouse,
+//	                "sql":       q.SQL,
+//	            })
+//	        })
+//	    if err != nil {
+//	        panic(err)
+//	    }
+//	}
+func RegisterCustomTaskKind[C any](kind string, convert func(cfg C) (*structpb.Struct, error)) error {
+	if err := validateCustomTaskKind(kind); err != nil {
+		return err
+	}
+	if convert == nil {
+		return validation.NewValidationErrorWithCause(
+			"kind", kind, "required",
+			fmt.Sprintf("custom task kind %s needs a config converter", kind),
+			ErrInvalidTaskKind,
+		)
+	}
+
+	configType := reflect.TypeFor[C]()
+	customTaskKindsMu.Lock()
+	defer customTaskKindsMu.Unlock()
+	if _, exists := customTaskKinds[TaskKind(kind)]; exists {
+		return validation.NewValidationErrorWithCause(
+			"kind", kind, "unique",
+			fmt.Sprintf("custom task kind %s is already registered", kind),
+			ErrInvalidTaskKind,
+		)
+	}
+	customTaskKinds[TaskKind(kind)] = func(config any) (*structpb.Struct, error) {
+		cfg, ok := config.(C)
+		if !ok {
+			return nil, fmt.Errorf("%s tasks take a %s config, got %T", kind, configType, config)
+		}
+		return convert(cfg)
+	}
+	return nil
+}
+
+// validateCustomTaskKind checks the format of a custom task kind and that it
+// does not collide with a built-in kind.
+func validateCustomTaskKind(kind string) error {
+	if !customTaskKindRegex.MatchString(kind) {
+		return validation.NewValidationErrorWithCause(
+			"kind", kind, "format",
+			fmt.Sprintf("custom task kind %q must be upper snake case (e.g. SNOWFLAKE_QUERY)", kind),
+			ErrInvalidTaskKind,
+		)
+	}
+	_, generated := genWorkflow.LookupTaskConfig(kind)
+	if generated || TaskKind(kind) == reservedCustomTaskKind || isBuiltinTaskKind(TaskKind(kind)) {
+		return validation.NewValidationErrorWithCause(
+			"kind", kind, "builtin",
+			fmt.Sprintf("custom task kind %s collides with a built-in task kind", kind),
+			ErrInvalidTaskKind,
+		)
+	}
+	return nil
+}
+
+// isBuiltinTaskKind reports whether kind is a task kind of the platform.
+func isBuiltinTaskKind(kind TaskKind) bool {
+	for _, builtin := range builtinTaskKinds {
+		if kind == builtin {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupCustomTaskKind returns the converter of a registered custom task kind.
+func lookupCustomTaskKind(kind TaskKind) (customTaskConverter, bool) {
+	customTaskKindsMu.RLock()
+	defer customTaskKindsMu.RUnlock()
+	convert, ok := customTaskKinds[kind]
+	return convert, ok
+}
+
+// CustomTaskRefs is implemented by custom task configs that reference other
+// tasks in ways the converted config does not show, for example outputs
+// interpolated into a query text. The returned references count as
+// dependencies of the task, like references in its config.
+type CustomTaskRefs interface {
+	TaskRefs() []Ref
+}
+
+// CustomTaskConfig is the configuration of a task of a custom kind.
+type CustomTaskConfig struct {
+	// Kind is the registered custom task kind.
+	Kind TaskKind
+
+	// Config is the user config passed to the kind's converter.
+	Config any
+
+	// extraRefs are the references declared with CustomRefs.
+	extraRefs []Ref
+}
+
+func (*CustomTaskConfig) IsTaskConfig() {}
+
+// refs returns the task references declared by the config and CustomRefs.
+func (c *CustomTaskConfig) refs() []Ref {
+	var refs []Ref
+	if declared, ok := c.Config.(CustomTaskRefs); ok {
+		refs = append(refs, declared.TaskRefs()...)
+	}
+	refs = append(refs, c.extraRefs...)
+	return refs
+}
+
+// toStruct runs the kind's converter on the config.
+func (c *CustomTaskConfig) toStruct() (*structpb.Struct, error) {
+	if err := validateCustomTaskKind(string(c.Kind)); err != nil {
+		return nil, err
+	}
+	convert, ok := lookupCustomTaskKind(c.Kind)
+	if !ok {
+		return nil, validation.NewValidationErrorWithCause(
+			"kind", string(c.Kind), "registered",
+			fmt.Sprintf("custom task kind %s is not registered (call workflow.RegisterCustomTaskKind)", c.Kind),
+			ErrInvalidTaskKind,
+		)
+	}
+	config, err := convert(c.Config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s config: %w", ErrInvalidTaskConfig, c.Kind, err)
+	}
+	if config == nil {
+		return nil, fmt.Errorf("%w: %s converter returned no config", ErrInvalidTaskConfig, c.Kind)
+	}
+	return config, nil
+}
+
+// Custom creates a task of a custom kind registered with
+// RegisterCustomTaskKind. cfg must have the config type the kind was
+// registered with; it is converted at synthesis.
+//
+// Example:
+//
+//	task := workflow.Custom("loadOrders", "SNOWFLAKE_QUERY", SnowflakeQuery{
+//	    Warehouse: "ANALYTICS",
+//	    SQL:       "SELECT * FROM orders",
+//	})
+func Custom(name string, kind string, cfg any, opts ...CustomOption) *Task {
+	config := &CustomTaskConfig{
+		Kind:   TaskKind(kind),
+		Config: cfg,
+	}
+	task := &Task{
+		Name:   name,
+		Kind:   TaskKind(kind),
+		Config: config,
+	}
+	for _, opt := range opts {
+		opt.applyCustom(task, config)
+	}
+	return task
+}
+
+// CustomOption configures a task of a custom kind.
+type CustomOption interface {
+	applyCustom(t *Task, cfg *CustomTaskConfig)
+}
+
+type customOptionFunc func(t *Task, cfg *CustomTaskConfig)
+
+func (f customOptionFunc) applyCustom(t *Task, cfg *CustomTaskConfig) {
+	f(t, cfg)
+}
+
+// CustomRefs declares task references a custom task depends on, in addition
+// to those in its converted config and its CustomTaskRefs.
+//
+// Example:
+//
+//	wf.Custom("loadOrders", "SNOWFLAKE_QUERY", query,
+//	    workflow.CustomRefs(fetchTask.Field("since")),
+//	)
+func CustomRefs(refs ...Ref) CustomOption {
+	return customOptionFunc(func(t *Task, cfg *CustomTaskConfig) {
+		for _, ref := range refs {
+			if ref != nil {
+				cfg.extraRefs = append(cfg.extraRefs, ref)
+			}
+		}
+	})
+}
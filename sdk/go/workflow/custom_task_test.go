@@ -0,0 +1,201 @@
+package workflow
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+)
+
+// snowflakeQuery is the config of the SNOWFLAKE_QUERY test kind. Since is
+// interpolated into the query text, so it is declared through TaskRefs.
+type snowflakeQuery struct {
+	Warehouse string
+	SQL       string
+	Since     Ref
+}
+
+func (q snowflakeQuery) TaskRefs() []Ref {
+	if q.Since == nil {
+		return nil
+	}
+	return []Ref{q.Since}
+}
+
+func init() {
+	err := RegisterCustomTaskKind("SNOWFLAKE_QUERY", func(q snowflakeQuery) (*structpb.Struct, error) {
+		if q.SQL == "" {
+			return nil, errors.New("sql is required")
+		}
+		return structpb.NewStruct(map[string]any{
+			"warehouse": q.Warehouse,
+			"sql":       q.SQL,
+			"rows":      []any{"id", "total"},
+		})
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestRegisterCustomTaskKind_Validation(t *testing.T) {
+	convert := func(q snowflakeQuery) (*structpb.Struct, error) { return &structpb.Struct{}, nil }
+
+	tests := []struct {
+		name string
+		kind string
+	}{
+		{name: "empty", kind: ""},
+		{name: "lower case", kind: "snowflake_query"},
+		{name: "leading digit", kind: "1_QUERY"},
+		{name: "double underscore", kind: "SNOWFLAKE__QUERY"},
+		{name: "hyphen", kind: "SNOWFLAKE-QUERY"},
+		{name: "built-in kind", kind: string(TaskKindHttpCall)},
+		{name: "manifest custom kind", kind: "CUSTOM"},
+		{name: "already registered", kind: "SNOWFLAKE_QUERY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterCustomTaskKind(tt.kind, convert); !errors.Is(err, ErrInvalidTaskKind) {
+				t.Errorf("RegisterCustomTaskKind(%q) error = %v, want %v", tt.kind, err, ErrInvalidTaskKind)
+			}
+		})
+	}
+
+	if err := RegisterCustomTaskKind[snowflakeQuery]("NIL_CONVERTER", nil); !errors.Is(err, ErrInvalidTaskKind) {
+		t.Errorf("RegisterCustomTaskKind(nil) error = %v, want %v", err, ErrInvalidTaskKind)
+	}
+}
+
+func TestCustom_ManifestRoundTrip(t *testing.T) {
+	wf, err := New(nil, "analytics/orders", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.Custom("loadOrders", "SNOWFLAKE_QUERY", snowflakeQuery{
+		Warehouse: "ANALYTICS",
+		SQL:       "SELECT id, total FROM orders",
+	})
+
+	manifest, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+	task := manifest.GetSpec().GetTasks()[0]
+	if task.GetKind() != apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_CUSTOM {
+		t.Errorf("kind = %v, want WORKFLOW_TASK_KIND_CUSTOM", task.GetKind())
+	}
+	if task.GetCustomKind() != "SNOWFLAKE_QUERY" {
+		t.Errorf("custom_kind = %q, want SNOWFLAKE_QUERY", task.GetCustomKind())
+	}
+	want, _ := structpb.NewStruct(map[string]any{
+		"warehouse": "ANALYTICS",
+		"sql":       "SELECT id, total FROM orders",
+		"rows":      []any{"id", "total"},
+	})
+	if !proto.Equal(task.GetTaskConfig(), want) {
+		t.Errorf("task_config = %v, want %v", task.GetTaskConfig(), want)
+	}
+
+	data, err := proto.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+	decoded := &workflowv1.Workflow{}
+	if err := proto.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %v", err)
+	}
+	if !proto.Equal(decoded, manifest) {
+		t.Errorf("binary round trip changed the manifest:\n got %v\nwant %v", decoded, manifest)
+	}
+
+	jsonData, err := protojson.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("protojson.Marshal() error = %v", err)
+	}
+	decoded = &workflowv1.Workflow{}
+	if err := protojson.Unmarshal(jsonData, decoded); err != nil {
+		t.Fatalf("protojson.Unmarshal() error = %v", err)
+	}
+	if !proto.Equal(decoded, manifest) {
+		t.Errorf("JSON round trip changed the manifest:\n got %v\nwant %v", decoded, manifest)
+	}
+}
+
+func TestCustom_Dependencies(t *testing.T) {
+	wf, err := New(nil, "analytics/orders", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	cursor := wf.Set("cursor", &SetArgs{Variables: map[string]interface{}{"since": "2026-01-01"}})
+	fetch := wf.HttpGet("fetch", "https://api.example.com/limits", nil)
+	wf.Custom("loadOrders", "SNOWFLAKE_QUERY", snowflakeQuery{
+		Warehouse: "ANALYTICS",
+		SQL:       "SELECT id, total FROM orders WHERE created > :since",
+		Since:     cursor.Field("since"),
+	}, CustomRefs(fetch.Field("limit")))
+
+	if _, err := wf.ToProto(); err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+	deps := wf.Dependencies()["loadOrders"]
+	for _, want := range []string{"cursor", "fetch"} {
+		if !slices.Contains(deps, want) {
+			t.Errorf("Dependencies()[loadOrders] = %v, want %s", deps, want)
+		}
+	}
+	// Referenced tasks export their output, as for built-in tasks
+	if cursor.ExportAs == "" || fetch.ExportAs == "" {
+		t.Errorf("referenced tasks not exported: cursor %q, fetch %q", cursor.ExportAs, fetch.ExportAs)
+	}
+}
+
+func TestCustom_SynthesisErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		cfg     any
+		wantErr error
+	}{
+		{name: "unregistered kind", kind: "BIGQUERY_JOB", cfg: snowflakeQuery{SQL: "SELECT 1"}, wantErr: ErrInvalidTaskKind},
+		{name: "malformed kind", kind: "bigquery", cfg: snowflakeQuery{SQL: "SELECT 1"}, wantErr: ErrInvalidTaskKind},
+		{name: "config type mismatch", kind: "SNOWFLAKE_QUERY", cfg: &snowflakeQuery{SQL: "SELECT 1"}, wantErr: ErrInvalidTaskConfig},
+		{name: "converter error", kind: "SNOWFLAKE_QUERY", cfg: snowflakeQuery{}, wantErr: ErrInvalidTaskConfig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "analytics/orders", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.Custom("load", tt.kind, tt.cfg)
+
+			if _, err := wf.ToProto(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToProto() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCustom_ExportYAMLUnsupported(t *testing.T) {
+	wf, err := New(nil, "analytics/orders", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.Custom("loadOrders", "SNOWFLAKE_QUERY", snowflakeQuery{SQL: "SELECT 1"})
+
+	_, err = ExportYAML(wf)
+	if err == nil {
+		t.Fatal("ExportYAML() succeeded, want an error for the custom task")
+	}
+	if want := "SNOWFLAKE_QUERY tasks run on a Stigmer runner extension"; !strings.Contains(err.Error(), want) {
+		t.Errorf("ExportYAML() error = %v, want it to mention %q", err, want)
+	}
+}
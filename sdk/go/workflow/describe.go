@@ -0,0 +1,70 @@
+package workflow
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// MaxDescriptionLength is the maximum length of a task description, in characters.
+const MaxDescriptionLength = 500
+
+// DescribeOption sets the description of a task. It is accepted by the
+// builders that take options (HttpOption, GrpcOption, AgentCallOption and
+// CallActivityOption); other tasks are described with Task.Describe.
+type DescribeOption struct {
+	text string
+}
+
+// Describe sets a human-readable description of what a task does, stored in
+// the workflow manifest for handover notes and generated documentation. The
+// runner does not interpret it. At most MaxDescriptionLength characters.
+//
+// Example:
+//
+//	wf.HttpGet("fetchPRs", endpoint, nil,
+//	    workflow.Describe("Fetches the user's open PRs from GitHub, paginated"))
+func Describe(text string) DescribeOption {
+	return DescribeOption{text: text}
+}
+
+func (o DescribeOption) applyHttp(t *Task, _ *HttpCallTaskConfig) {
+	t.Description = o.text
+}
+
+func (o DescribeOption) applyGrpc(t *Task, _ *GrpcCallTaskConfig) {
+	t.Description = o.text
+}
+
+func (o DescribeOption) applyAgentCall(t *Task, _ *AgentCallTaskConfig) {
+	t.Description = o.text
+}
+
+func (o DescribeOption) applyCallActivity(t *Task, _ *CallActivityTaskConfig) {
+	t.Description = o.text
+}
+
+// Describe sets a human-readable description of what the task does. It is
+// the chained form of the Describe option and works on tasks of every kind.
+//
+// Example:
+//
+//	wf.Switch("route", &workflow.SwitchArgs{...}).
+//	    Describe("Routes orders to the fulfilment center of their region")
+func (t *Task) Describe(text string) *Task {
+	t.Description = text
+	return t
+}
+
+// validateDescription checks that the description fits in MaxDescriptionLength characters.
+func (t *Task) validateDescription() error {
+	if n := utf8.RuneCountInString(t.Description); n > MaxDescriptionLength {
+		return NewValidationErrorWithCause(
+			"description",
+			t.Description,
+			"max_len",
+			fmt.Sprintf("description is %d characters long; the maximum is %d", n, MaxDescriptionLength),
+			ErrInvalidTaskDescription,
+		)
+	}
+	return nil
+}
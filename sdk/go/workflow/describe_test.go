@@ -0,0 +1,123 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+)
+
+func TestDescribe_Synthesis(t *testing.T) {
+	wf, err := New(nil, "github/prs", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	wf.HttpGet("fetchPRs", "https://api.github.com/pulls", nil,
+		Describe("Fetches the user's open PRs from GitHub, paginated"))
+	wf.Set("init", &SetArgs{Variables: map[string]interface{}{"page": "1"}}).
+		Describe("Starts at the first page")
+	wf.Set("plain", &SetArgs{Variables: map[string]interface{}{"done": "false"}})
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	want := []string{"Fetches the user's open PRs from GitHub, paginated", "Starts at the first page", ""}
+	for i, task := range pb.GetSpec().GetTasks() {
+		if got := task.GetDescription(); got != want[i] {
+			t.Errorf("%s description = %q, want %q", task.GetName(), got, want[i])
+		}
+	}
+}
+
+func TestDescribe_ExportYAML(t *testing.T) {
+	wf := &Workflow{
+		Document: Document{DSL: "1.0.0", Namespace: "github", Name: "prs", Version: "1.0.0"},
+		Tasks: []*Task{
+			Set("init", &SetArgs{Variables: map[string]interface{}{"page": "1"}}).
+				Describe("Starts at the first page"),
+		},
+	}
+
+	data, err := ExportYAML(wf)
+	if err != nil {
+		t.Fatalf("ExportYAML() failed: %v", err)
+	}
+	if want := "metadata:\n            description: Starts at the first page"; !strings.Contains(string(data), want) {
+		t.Errorf("ExportYAML() =\n%s\nwant task metadata %q", data, want)
+	}
+}
+
+func TestDescribe_NestedTask(t *testing.T) {
+	wf, err := New(nil, "github/prs", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.ForEach("each", &ForArgs{
+		In: "${ .prs }",
+		Do: LoopBody(func(item LoopVar) []*Task {
+			return []*Task{Set("track", &SetArgs{Variables: map[string]interface{}{"id": item.Field("id")}}).
+				Describe("Records the PR id")}
+		}),
+	})
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+	do := pb.GetSpec().GetTasks()[0].GetTaskConfig().GetFields()["do"].GetListValue().GetValues()
+	if len(do) != 1 {
+		t.Fatalf("loop body has %d tasks, want 1", len(do))
+	}
+	if got := do[0].GetStructValue().GetFields()["description"].GetStringValue(); got != "Records the PR id" {
+		t.Errorf("nested description = %q, want %q", got, "Records the PR id")
+	}
+}
+
+func TestDescribe_TooLong(t *testing.T) {
+	wf, err := New(nil, "github/prs", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	// Multi-byte characters count once
+	wf.Set("fits", &SetArgs{Variables: map[string]interface{}{"a": "1"}}).
+		Describe(strings.Repeat("é", MaxDescriptionLength))
+	if _, err := wf.ToProto(); err != nil {
+		t.Fatalf("ToProto() with %d characters failed: %v", MaxDescriptionLength, err)
+	}
+
+	wf.Set("tooLong", &SetArgs{Variables: map[string]interface{}{"b": "2"}}).
+		Describe(strings.Repeat("x", MaxDescriptionLength+1))
+	if _, err := wf.ToProto(); !errors.Is(err, ErrInvalidTaskDescription) {
+		t.Fatalf("ToProto() error = %v, want %v", err, ErrInvalidTaskDescription)
+	}
+}
+
+func TestMissingDescriptionRule(t *testing.T) {
+	wf, err := New(nil, "github/prs", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.Set("init", &SetArgs{Variables: map[string]interface{}{"page": "1"}})
+	wf.Switch("route", &SwitchArgs{Cases: []*types.SwitchCase{{Name: "default", Then: "init"}}})
+	wf.Switch("described", &SwitchArgs{Cases: []*types.SwitchCase{{Name: "default", Then: "init"}}}).
+		Describe("Loops back to the start")
+
+	for _, finding := range wf.Lint() {
+		if finding.RuleID == LintRuleMissingDescription {
+			t.Errorf("default rules reported %v", finding)
+		}
+	}
+
+	findings := wf.Lint(MissingDescriptionRule())
+	if len(findings) != 1 || findings[0].Task != "route" {
+		t.Fatalf("findings = %v, want one for route", findings)
+	}
+	if findings[0].Severity != LintSeverityWarning {
+		t.Errorf("severity = %v, want %v", findings[0].Severity, LintSeverityWarning)
+	}
+}
@@ -37,7 +37,7 @@
 //	fetchTask := wf.HttpGet("fetchData", endpoint,
 //	    workflow.Header("Content-Type", "application/json"),
 //	    workflow.Header("Authorization", "Bearer ${API_TOKEN}"),
-//	    workflow.Timeout(30),
+//	    workflow.TimeoutDuration(30*time.Second),
 //	)
 //	
 //	// HTTP POST
@@ -54,6 +54,13 @@
 //	// HTTP DELETE
 //	deleteTask := wf.HttpDelete("deleteItem", deleteEndpoint)
 //
+// HEAD and OPTIONS tasks output only the status code and headers, with
+// lowercased header names. They accept no body or response format:
+//
+//	check := wf.HttpHead("check", dataURL)
+//	etag := check.Field("headers.etag")
+//	modified := check.Field(`headers["last-modified"]`)
+//
 // ## Setting Variables
 //
 // Use wf.SetVars() for clean variable assignment:
@@ -65,6 +72,29 @@
 //	    "debug", true,
 //	)
 //
+// Values keep their Go types: numbers and bools are set as JSON numbers and
+// booleans, maps and slices as objects and arrays. This matters because the
+// runner's jq expressions do not coerce types: ${ .retryCount == 3 } is false
+// when retryCount is the string "3", and jq sorts every string after every
+// number, so ${ .retryCount > 2 } holds for any string.
+//
+// CopyFields copies output fields of a task into variables of the same
+// names, and CopyFieldsAs renames them; both work like setting each variable
+// to task.Field(field):
+//
+//	wf.Set("copyUser", workflow.CopyFields(userTask, "id", "name", "email"))
+//	wf.Set("renameUser", workflow.CopyFieldsAs(userTask, map[string]string{"userName": "name"}))
+//
+// Use UnsetVar to remove variables a later phase must not see:
+//
+//	wf.Set("dropToken", workflow.UnsetVar("tempToken"))
+//
+// Map transforms each element of an array output without a ForEach loop,
+// keeping one field with Pick or building objects with Project:
+//
+//	ids := wf.Map("ids", fetchTask.Field("items"), workflow.Pick("id"))
+//	body := map[string]interface{}{"ids": ids.Field("result")}
+//
 // ## Direct Task Output References
 //
 // Reference task outputs directly - dependencies are automatic:
@@ -149,6 +179,33 @@
 //	
 //	// No manual ThenRef() or DependsOn() needed!
 //
+// A reference made with Weak() keeps the expression but adds no edge, for
+// tasks that only use a value when it is available. It resolves to null when
+// the referenced task has not produced output:
+//
+//	logTask := wf.SetVars("log", "label", step1.Field("name").Weak())
+//	// wf.WeakDependencies()["log"] = ["step1"]
+//
+// # Renaming Tasks
+//
+// Field references hold the task, not its name, so a task can be renamed
+// after references to it were created:
+//
+//	fetchTask := wf.HttpGet("fetchData", endpoint, nil)
+//	title := fetchTask.Field("title")
+//	err := fetchTask.Rename("fetchUser")  // title now renders $context["fetchUser"]
+//
+// # Conditional Tasks
+//
+// RunIf skips a task unless a condition on an earlier task's output holds:
+//
+//	escalate := wf.HttpPost("escalate", pagerURL, nil, body).
+//	    RunIf(workflow.Condition(analyze.Field("severity"), workflow.Equals("high")))
+//
+// A skipped task records {"skipped": true} instead of its output, so tasks
+// reading its fields must be guarded too, for example on escalate.Skipped().Not().
+// Synthesis rejects unguarded references.
+//
 // # Task Types
 //
 // The workflow package supports all Zigflow DSL task types:
@@ -166,6 +223,12 @@
 //   - RAISE: Throw errors
 //   - RUN: Execute sub-workflows
 //
+// Runner extensions add their own kinds. RegisterCustomTaskKind registers a
+// kind with the converter of its config type, and wf.Custom adds a task of
+// the kind:
+//
+//	wf.Custom("loadOrders", "SNOWFLAKE_QUERY", SnowflakeQuery{SQL: "SELECT * FROM orders"})
+//
 // # Environment Variables
 //
 // Workflows can declare required environment variables:
@@ -183,6 +246,155 @@
 //	    workflow.WithEnvironmentVariable(apiToken),
 //	)
 //
+// # Schedules
+//
+// Workflows can be triggered on a cron schedule without an external scheduler.
+// The server keeps a schedule for each instance of the workflow:
+//
+//	wf, _ := workflow.New(ctx, "ops/daily-sync", nil,
+//	    workflow.WithSchedule("0 2 * * *",
+//	        workflow.Timezone("UTC"),
+//	        workflow.CatchUpPolicy(workflow.SkipMissed),
+//	    ),
+//	)
+//
+// # Failure Handlers
+//
+// WithOnFailure runs cleanup or notification tasks when the workflow fails.
+// They run after the failure, with its message, type, classification and
+// failed task available through the ErrorRef, and do not run on success. The
+// execution still fails with the original error. They also run when the
+// execution is cancelled, with err.Type() set to ErrorTypeCancelled:
+//
+//	wf, _ := workflow.New(ctx, "billing/charge", nil,
+//	    workflow.WithOnFailure(func(err workflow.ErrorRef) *workflow.Task {
+//	        return workflow.HttpPost("notifyOps", opsWebhook, nil, map[string]interface{}{
+//	            "task":  err.Task(),
+//	            "error": err.Message(),
+//	        })
+//	    }),
+//	)
+//
+// Within a Try task, CatchMatching gives different errors different handlers.
+// Catch blocks are evaluated in declaration order; an unfiltered one can only
+// come last, or synthesis fails with ErrUnreachableCatch:
+//
+//	wf.Try("fetchOrders", &workflow.TryArgs{
+//	    Try: workflow.TryBody(fetch),
+//	    Catches: []*types.CatchBlock{
+//	        workflow.CatchMatching(workflow.StatusCodeIn(429), workflow.CatchBody("error", backoff)),
+//	        workflow.CatchMatching(workflow.ErrorTypeIs("UPSTREAM_4XX"), workflow.CatchBody("error", report)),
+//	        workflow.CatchBody("error", fallback),
+//	    },
+//	})
+//
+// RetryOn runs the try tasks again when they fail with a matching error,
+// such as a task exceeding its ExecutionTimeout, before any catch block sees
+// the error:
+//
+//	wf.Try("review", &workflow.TryArgs{
+//	    Try:   workflow.TryBody(review),
+//	    Retry: workflow.RetryOn(workflow.CatchExecutionTimeout(), 2),
+//	    Catch: workflow.CatchBody("error", fallback),
+//	})
+//
+// # TLS
+//
+// HTTP tasks can present client certificates (mTLS) and trust private CAs,
+// for the whole workflow or per task. Private keys must be runtime secrets,
+// resolved by the workflow runner and never written to the manifest:
+//
+//	wf, _ := workflow.New(ctx, "internal/sync", nil,
+//	    workflow.WithTLS(workflow.CABundle(workflow.RuntimeSecret("INTERNAL_CA"))),
+//	)
+//	wf.HttpGet("fetch", "https://internal.example.com/data", nil).
+//	    WithTLS(workflow.TLSClientCert(
+//	        workflow.RuntimeSecret("CLIENT_CERT"),
+//	        workflow.RuntimeSecret("CLIENT_KEY"),
+//	    ))
+//
+// # Approvals
+//
+// An approval task pauses the execution until someone approves it, with
+// `stigmer workflow approve <execution-id> <task>` or the approve RPC. Later
+// tasks can read who approved it:
+//
+//	approval := wf.Approval("approveDeploy",
+//	    workflow.Approvers("ops-team"),
+//	    workflow.ApprovalTimeoutDuration(48*time.Hour),
+//	    workflow.OnTimeout(workflow.FailWorkflow),
+//	)
+//	wf.Set("record", &workflow.SetArgs{Variables: map[string]interface{}{
+//	    "approver": approval.Field("approved_by").Expression(),
+//	}})
+//
+// # Caching
+//
+// Idempotent GET requests can reuse a response cached by the workflow runner.
+// Requests sending runtime secrets in headers bypass the cache unless
+// CacheIncludesAuth is set:
+//
+//	wf.HttpGet("fetchConfig", "https://api.example.com/config", nil).
+//	    WithCache(workflow.CacheFor(10*time.Minute, workflow.CacheKeyFromURI()))
+//
+// # Proxies
+//
+// HTTP calls go through the proxy configured on the workflow runner
+// (HTTP_PROXY, HTTPS_PROXY and NO_PROXY). A task can use another proxy, or
+// bypass it:
+//
+//	wf.HttpGet("fetchPartner", "https://partner.example.com/data", nil).
+//	    WithProxy(workflow.Proxy("http://proxy.corp:3128"))
+//
+//	wf.HttpGet("fetchInternal", "https://internal.example.com/data", nil).
+//	    WithProxy(workflow.NoProxy())
+//
+// # Response Formats
+//
+// Response bodies that are JSON objects or arrays are parsed; other bodies
+// are stored as a string under "body". ResponseFormat makes the choice
+// explicit: FormatJSON fails the task on a non-JSON body, FormatText always
+// stores a string and FormatBytesBase64 stores binary payloads base64-encoded:
+//
+//	report := wf.HttpGet("report", "https://api.example.com/report.csv", nil,
+//	    workflow.ResponseFormat(workflow.FormatText),
+//	)
+//	wf.SetVars("store", "csv", report.Field("body"))
+//
+// # Response Status
+//
+// HTTP tasks fail on 4xx and 5xx statuses, with an UPSTREAM_4XX or
+// UPSTREAM_5XX classification and a snippet of the response body that Catch
+// handlers can read. ExpectStatus lists the statuses a task succeeds on, and
+// AllowAnyStatus lets every response through; WithHttpStatusCheck sets the
+// default for the workflow:
+//
+//	wf.HttpPost("createUser", "https://api.example.com/users", nil, user,
+//	    workflow.ExpectStatus(200, 201),
+//	)
+//	wf.HttpGet("lookup", "https://api.example.com/users/42", nil,
+//	    workflow.AllowAnyStatus(),
+//	)
+//
+// # Form and Multipart Bodies
+//
+// Request bodies are sent as JSON. BodyForm sends them url-encoded and
+// BodyMultipart as multipart/form-data; the runner encodes the evaluated
+// values and sets the Content-Type header:
+//
+//	wf.HttpPost("token", "https://auth.example.com/oauth/token", nil, nil,
+//	    workflow.BodyForm(map[string]any{
+//	        "grant_type":    "client_credentials",
+//	        "client_secret": workflow.RuntimeSecret("CLIENT_SECRET"),
+//	    }),
+//	)
+//
+//	wf.HttpPost("upload", "https://files.example.com/upload", nil, nil,
+//	    workflow.BodyMultipart(
+//	        workflow.Part("file", report.Field("body"), workflow.Filename("report.csv")),
+//	    ),
+//	)
+//
 // # Type Safety
 //
 // Typed references provide compile-time safety:
@@ -298,12 +510,27 @@
 //
 // Workflows are validated when created and when tasks are added:
 //
-//   - Metadata: namespace, name, and version are required (version must be semver)
+//   - Metadata: namespace, name, and version are required (version must be
+//     semver; "v1.0" is normalized to "1.0.0", see WithVersion)
 //   - Tasks: must have at least one task
 //   - Task names: must be unique within workflow
 //   - Task configs: validated based on task type
 //   - Dependencies: validated to prevent cycles
 //
+// # Inspection
+//
+// Tools built on the SDK (custom linters, documentation generators) can read
+// a workflow through a supported, read-only API that reflects its state
+// before synthesis:
+//
+//	for _, task := range wf.Tasks {          // declaration order
+//	    config, _ := task.ConfigSnapshot()    // deep copy, refs rendered
+//	    fmt.Println(task.Name, task.Kind, config)
+//	}
+//	deps := wf.Dependencies()                // task name → names it depends on
+//	raw := wf.RawExpressions()               // task name → raw JQ expressions (see Expr)
+//	fetch := wf.Task("fetch")                // lookup by name (nil if missing)
+//
 // # Synthesis
 //
 // Workflows are automatically synthesized when stigmer.Run() completes:
@@ -315,6 +542,19 @@
 // 5. Proto manifest generated
 // 6. Manifest written to workflow-manifest.pb
 //
+// # Serverless Workflow Export
+//
+// ExportYAML renders a workflow as a Serverless Workflow 1.0 document, for
+// engines other than the Stigmer runner:
+//
+//	out, err := workflow.ExportYAML(wf)
+//	if errors.Is(err, workflow.ErrNotExportable) {
+//	    log.Fatal(err) // lists every Stigmer-specific task and why
+//	}
+//
+// Agent calls, activities, runtime secrets and other features that only the
+// Stigmer runtime provides are reported rather than dropped.
+//
 // # Documentation
 //
 // For comprehensive documentation:
@@ -1,6 +1,6 @@
 package workflow
 
-import "regexp"
+import "github.com/stigmer/stigmer/sdk/go/internal/semver"
 
 // Document represents workflow metadata.
 // Maps to the `document:` block in Zigflow DSL YAML.
@@ -32,9 +32,6 @@ const (
 	descriptionMaxLength = 500
 )
 
-// Regex for semver validation (simplified).
-var semverRegex = regexp.MustCompile(`^\d+\.\d+\.\d+(-[a-zA-Z0-9.-]+)?(\+[a-zA-Z0-9.-]+)?$`)
-
 // validateDocument validates a workflow document.
 func validateDocument(d *Document) error {
 	// Validate DSL version
@@ -89,15 +86,12 @@ func validateDocument(d *Document) error {
 	}
 
 	// Validate version (if provided, must be semver)
-	// Note: Version is set to "0.1.0" by default in New() if not provided
-	if d.Version != "" && !semverRegex.MatchString(d.Version) {
-		return NewValidationErrorWithCause(
-			"document.version",
-			d.Version,
-			"semver",
-			"version must be valid semver (e.g., 1.0.0)",
-			ErrInvalidVersion,
-		)
+	// Note: Version is set to "0.1.0" by default in New() if not provided,
+	// and a leading "v" or missing patch component is normalized there
+	if d.Version != "" {
+		if _, err := semver.Parse(d.Version); err != nil {
+			return versionError(d.Version, err)
+		}
 	}
 
 	// Validate description (optional)
@@ -30,7 +30,7 @@ func TestWorkflowToProto_NilFields(t *testing.T) {
 					Name:      "test-workflow",
 					Version:   "1.0.0",
 				},
-				Tasks:                []*Task{{Name: "t1", Kind: TaskKindSet, Config: &SetTaskConfig{Variables: map[string]string{"x": "y"}}}},
+				Tasks:                []*Task{{Name: "t1", Kind: TaskKindSet, Config: &SetTaskConfig{Variables: map[string]interface{}{"x": "y"}}}},
 				EnvironmentVariables: nil, // nil slice - this is valid
 			},
 			wantErr: false,
@@ -74,7 +74,7 @@ func TestWorkflowToProto_NilFields(t *testing.T) {
 				},
 				Description: "", // empty
 				Slug:        "", // empty
-				Tasks:       []*Task{{Name: "t1", Kind: TaskKindSet, Config: &SetTaskConfig{Variables: map[string]string{"x": "y"}}}},
+				Tasks:       []*Task{{Name: "t1", Kind: TaskKindSet, Config: &SetTaskConfig{Variables: map[string]interface{}{"x": "y"}}}},
 			},
 			wantErr: true, // Changed: validation requires namespace
 		},
@@ -111,7 +111,7 @@ func TestWorkflowToProto_MaximumFields(t *testing.T) {
 			Name: fmt.Sprintf("task%d", i), // Use unique names
 			Kind: TaskKindSet,
 			Config: &SetTaskConfig{
-				Variables: map[string]string{
+				Variables: map[string]interface{}{
 					fmt.Sprintf("var%d", i): fmt.Sprintf("value%d", i),
 				},
 			},
@@ -170,7 +170,7 @@ func TestWorkflowToProto_SpecialCharacters(t *testing.T) {
 				Name: "task1",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"unicode":  "你好世界",
 						"emoji":    "🚀🎉💻",
 						"special":  "<>&\"'",
@@ -210,7 +210,7 @@ func TestWorkflowToProto_DeepTaskNesting(t *testing.T) {
 						{
 							Name: "trueCase",
 							When: "true",
-							Then: "level2",
+							Then: EndFlow,
 						},
 					},
 				},
@@ -246,7 +246,7 @@ func TestWorkflowToProto_ConcurrentAccess(t *testing.T) {
 				Name: "task1",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{"x": "y"},
+					Variables: map[string]interface{}{"x": "y"},
 				},
 			},
 		},
@@ -298,7 +298,7 @@ func TestWorkflow_ConcurrentTaskAddition(t *testing.T) {
 				Name: "task" + string(rune('0'+idx%10)),
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{"idx": string(rune('0' + idx%10))},
+					Variables: map[string]interface{}{"idx": string(rune('0' + idx%10))},
 				},
 			}
 			wf.AddTask(task)
@@ -336,7 +336,7 @@ func TestWorkflowToProto_EmptyMaps(t *testing.T) {
 				Name: "emptyVars",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{}, // empty map - validation requires at least 1
+					Variables: map[string]interface{}{}, // empty map - validation requires at least 1
 				},
 			},
 			wantErr: true, // SET task must have at least one variable
@@ -87,7 +87,7 @@ func TestWorkflowToProto_InvalidDocumentFields(t *testing.T) {
 						Name: "task1",
 						Kind: TaskKindSet,
 						Config: &SetTaskConfig{
-							Variables: map[string]string{"x": "y"},
+							Variables: map[string]interface{}{"x": "y"},
 						},
 					},
 				},
@@ -289,7 +289,7 @@ func TestWorkflowToProto_InvalidEnvironmentVariables(t *testing.T) {
 						Name: "task1",
 						Kind: TaskKindSet,
 						Config: &SetTaskConfig{
-							Variables: map[string]string{"x": "y"},
+							Variables: map[string]interface{}{"x": "y"},
 						},
 					},
 				},
@@ -330,7 +330,7 @@ func TestWorkflowToProto_TaskKindConfigMismatch(t *testing.T) {
 				Name: "mismatch1",
 				Kind: TaskKindHttpCall,
 				Config: &SetTaskConfig{ // wrong config type
-					Variables: map[string]string{"x": "y"},
+					Variables: map[string]interface{}{"x": "y"},
 				},
 			},
 			errMsg: "type mismatch",
@@ -404,7 +404,7 @@ func TestWorkflowToProto_InvalidFlowControl(t *testing.T) {
 				{
 					Name:     "task1",
 					Kind:     TaskKindSet,
-					Config:   &SetTaskConfig{Variables: map[string]string{"x": "y"}},
+					Config:   &SetTaskConfig{Variables: map[string]interface{}{"x": "y"}},
 					ThenTask: "nonExistentTask", // invalid reference
 				},
 			},
@@ -417,19 +417,35 @@ func TestWorkflowToProto_InvalidFlowControl(t *testing.T) {
 				{
 					Name:     "task1",
 					Kind:     TaskKindSet,
-					Config:   &SetTaskConfig{Variables: map[string]string{"x": "y"}},
+					Config:   &SetTaskConfig{Variables: map[string]interface{}{"x": "y"}},
 					ThenTask: "task2",
 				},
 				{
 					Name:     "task2",
 					Kind:     TaskKindSet,
-					Config:   &SetTaskConfig{Variables: map[string]string{"a": "b"}},
+					Config:   &SetTaskConfig{Variables: map[string]interface{}{"a": "b"}},
 					ThenTask: "task1", // circular reference
 				},
 			},
 			wantErr: false, // May not validate at proto conversion time
 			errMsg:  "circular",
 		},
+		{
+			name: "switch case routes to non-existent task",
+			tasks: []*Task{
+				{
+					Name: "route",
+					Kind: TaskKindSwitch,
+					Config: &SwitchTaskConfig{
+						Cases: []*types.SwitchCase{
+							{Name: "up", When: "${ .up }", Then: "handleUp"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "handleUp",
+		},
 	}
 
 	for _, tt := range tests {
@@ -494,7 +510,7 @@ func TestWorkflowToProto_NestedErrorPropagation(t *testing.T) {
 				Name: "task1",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{"x": "y"},
+					Variables: map[string]interface{}{"x": "y"},
 				},
 			},
 		},
@@ -565,7 +581,7 @@ func TestWorkflowToProto_PartiallyValidWorkflow(t *testing.T) {
 				Name: "validTask",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{"x": "y"},
+					Variables: map[string]interface{}{"x": "y"},
 				},
 			},
 			// Potentially invalid task
@@ -621,7 +637,7 @@ func TestWorkflowToProto_ExcessiveTasks(t *testing.T) {
 			Name: "task_" + strings.Repeat("x", i%10),
 			Kind: TaskKindSet,
 			Config: &SetTaskConfig{
-				Variables: map[string]string{
+				Variables: map[string]interface{}{
 					"key": "value",
 				},
 			},
@@ -713,3 +729,30 @@ func TestWorkflowToProto_DeeplyNestedStructures(t *testing.T) {
 		t.Log("Successfully converted workflow with 10-level deep nesting")
 	}
 }
+
+// TestWorkflowToProto_ConversionErrorPath tests that task config violations
+// name the task and its index.
+func TestWorkflowToProto_ConversionErrorPath(t *testing.T) {
+	wf, err := New(nil, "ops/sync", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.Set("init", &SetArgs{Variables: map[string]interface{}{"page": "1"}})
+	wf.HttpGet("fetch", "", nil)
+
+	_, err = wf.ToProto()
+	if err == nil {
+		t.Fatal("ToProto() succeeded, want conversion error")
+	}
+	if !strings.Contains(err.Error(), "task fetch") {
+		t.Errorf("error = %q, want task name", err)
+	}
+
+	var convErr *ConversionError
+	if !errors.As(err, &convErr) {
+		t.Fatalf("ToProto() error = %v, want ConversionError", err)
+	}
+	if convErr.Field != "spec.tasks[1].task_config.endpoint" {
+		t.Errorf("ConversionError.Field = %q, want %q", convErr.Field, "spec.tasks[1].task_config.endpoint")
+	}
+}
@@ -1,6 +1,13 @@
 package workflow
 
-// ErrorMatcher provides a type-safe, composable way to match error types in CATCH blocks.
+import (
+	"slices"
+
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+)
+
+// ErrorMatcher provides a type-safe, composable way to match error types in
+// CATCH blocks and in the retry policies created by RetryOn.
 //
 // Instead of using raw string slices for error types, ErrorMatcher provides:
 //   - Discoverability: IDE autocomplete shows available error types
@@ -121,6 +128,21 @@ func CatchCommandErrors() *ErrorMatcher {
 	return &ErrorMatcher{types: []string{ErrorTypeCommand}}
 }
 
+// CatchExecutionTimeout catches tasks that exceeded their execution timeout.
+// The timeout is set per task with the ExecutionTimeout option, or with
+// task.ExecutionTimeout(d) on tasks whose builders take no options.
+//
+// Example:
+//
+//	workflow.WithCatchTyped(
+//	    workflow.CatchExecutionTimeout(),
+//	    "timeoutErr",
+//	    workflow.SetTask("handleSlowAgent", ...),
+//	)
+func CatchExecutionTimeout() *ErrorMatcher {
+	return &ErrorMatcher{types: []string{ErrorTypeExecutionTimeout}}
+}
+
 // CatchNetworkErrors catches all network-related errors (HTTP + gRPC).
 // This is a convenience function that combines HTTP and gRPC error matchers.
 //
@@ -188,18 +210,45 @@ func CatchMultiple(errorTypes ...string) *ErrorMatcher {
 	return &ErrorMatcher{types: errorTypes}
 }
 
+// RetryOn creates a retry policy for TryArgs.Retry. When the try tasks fail
+// with an error matched by matcher, they run again, up to maxRetries times,
+// before the catch blocks see the error. Set DelaySeconds on the policy to
+// wait between attempts.
+//
+// Example:
+//
+//	// Give a slow agent call two more chances before falling back
+//	wf.Try("review", &workflow.TryArgs{
+//	    Try:   workflow.TryBody(reviewTask.ExecutionTimeout(2 * time.Minute)),
+//	    Retry: workflow.RetryOn(workflow.CatchExecutionTimeout(), 2),
+//	    Catch: workflow.CatchBody("error", fallback),
+//	})
+func RetryOn(matcher *ErrorMatcher, maxRetries int) *types.RetryPolicy {
+	var errorTypes []string
+	if matcher != nil {
+		errorTypes = slices.Clone(matcher.types)
+	}
+	// Counts outside 0-100 are clamped just outside the valid range, so
+	// synthesis rejects them instead of an int32 overflow wrapping them in
+	maxRetries = max(min(maxRetries, maxTryRetries+1), 0)
+	return &types.RetryPolicy{
+		ErrorTypes: errorTypes,
+		MaxRetries: int32(maxRetries),
+	}
+}
+
 // Note: WithCatchTyped has been removed in favor of struct-based args.
-// Use ErrorMatcher.Types() to get error type strings for TryArgs.Catch field.
+// Pass ErrorMatcher.Types() to ErrorTypeIs to filter a catch block of
+// TryArgs.Catches.
 //
 // Example:
 //
 //	workflow.Try("attemptOperation", &workflow.TryArgs{
-//	    Tasks: []map[string]interface{}{...},
-//	    Catch: []map[string]interface{}{
-//	        {
-//	            "errors": workflow.CatchHTTPErrors().Types(),  // Type-safe!
-//	            "as":     "httpErr",
-//	            "tasks":  []interface{}{...},
-//	        },
+//	    Try: workflow.TryBody(call),
+//	    Catches: []*types.CatchBlock{
+//	        workflow.CatchMatching(
+//	            workflow.ErrorTypeIs(workflow.CatchHTTPErrors().Types()...),
+//	            workflow.CatchBody("httpErr", handleHTTPError),
+//	        ),
 //	    },
 //	})
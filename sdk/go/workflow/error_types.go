@@ -99,6 +99,70 @@ const (
 	//   )
 	ErrorTypeCommand = "command"
 
+	// ErrorTypeExecutionTimeout is raised when a task exceeds its execution timeout.
+	// The bound is set per task with the ExecutionTimeout option or task.ExecutionTimeout(d)
+	// and applies to every task kind.
+	//
+	// Source: Any task with an execution timeout
+	// When raised:
+	//   - Task activity runs longer than its execution timeout
+	//   - Agent call does not complete within its execution timeout
+	//
+	// Example catch block:
+	//   workflow.WithCatch(
+	//       []string{workflow.ErrorTypeExecutionTimeout},
+	//       "timeoutErr",
+	//       // Handle timed-out tasks
+	//   )
+	ErrorTypeExecutionTimeout = "ExecutionTimeout"
+
+	// ErrorTypeCancelled is the type of the failure seen by WithOnFailure
+	// handlers when the execution was cancelled rather than failed.
+	// Cancellation is never caught by TRY/CATCH blocks: the execution stops.
+	//
+	// Source: Cancellation of the execution (stigmer workflow cancel)
+	// When raised:
+	//   - The execution is cancelled while a task is running
+	//
+	// Example on-failure handler:
+	//   workflow.WithOnFailure(func(err workflow.ErrorRef) *workflow.Task {
+	//       // err.Type() is workflow.ErrorTypeCancelled for cancellations
+	//   })
+	ErrorTypeCancelled = "Cancelled"
+
+	// ErrorTypeWorkflowTimeout is the type of the failure seen by
+	// WithOnFailure handlers when the execution ran past the maximum duration
+	// set with WithMaxDuration. The tasks are stopped and the execution fails
+	// with classification WORKFLOW_TIMEOUT.
+	//
+	// Source: Workflow maximum duration (WithMaxDuration)
+	// When raised:
+	//   - The tasks are still running when the maximum duration is reached
+	//
+	// Example on-failure handler:
+	//   workflow.WithOnFailure(func(err workflow.ErrorRef) *workflow.Task {
+	//       // err.Type() is workflow.ErrorTypeWorkflowTimeout for timeouts
+	//   })
+	ErrorTypeWorkflowTimeout = "WorkflowTimeout"
+
+	// ErrorTypeAgentLimitExceeded is raised when the agent called by an
+	// AGENT_CALL task is stopped by one of its limits (agent.WithLimits).
+	// The task fails with classification LIMIT_EXCEEDED and is not retried.
+	//
+	// Source: AGENT_CALL tasks
+	// When raised:
+	//   - The agent execution uses more tokens than MaxTokensPerRun
+	//   - The agent execution makes more tool calls than MaxToolCalls
+	//   - The agent execution runs longer than MaxDuration
+	//
+	// Example catch block:
+	//   workflow.WithCatch(
+	//       []string{workflow.ErrorTypeAgentLimitExceeded},
+	//       "limitErr",
+	//       // Handle agents stopped by a limit
+	//   )
+	ErrorTypeAgentLimitExceeded = "AgentLimitExceeded"
+
 	// ErrorTypeAny is a wildcard that catches ALL error types.
 	// Use this as a fallback catch block to handle any unhandled errors.
 	//
@@ -245,6 +309,56 @@ var ErrorRegistry = map[string]ErrorTypeInfo{
 		},
 	},
 
+	ErrorTypeExecutionTimeout: {
+		Code:      ErrorTypeExecutionTimeout,
+		Category:  "Execution",
+		Source:    "Any task with an execution timeout",
+		Retryable: false,
+		Description: "Task exceeded the wall-clock bound set with ExecutionTimeout(). " +
+			"Unlike HTTP request timeouts, this applies to every task kind.",
+		Examples: []string{
+			"Agent call still running after 10 minutes",
+			"Long-running command exceeded its time budget",
+		},
+	},
+
+	ErrorTypeCancelled: {
+		Code:      ErrorTypeCancelled,
+		Category:  "Execution",
+		Source:    "Execution cancellation",
+		Retryable: false,
+		Description: "The execution was cancelled. Only seen by on-failure handlers; " +
+			"TRY/CATCH blocks never catch a cancellation.",
+		Examples: []string{
+			"Execution cancelled with stigmer workflow cancel",
+		},
+	},
+
+	ErrorTypeWorkflowTimeout: {
+		Code:      ErrorTypeWorkflowTimeout,
+		Category:  "Execution",
+		Source:    "Workflow maximum duration",
+		Retryable: false,
+		Description: "The execution ran past the maximum duration set with WithMaxDuration(). " +
+			"Only seen by on-failure handlers; the tasks are stopped.",
+		Examples: []string{
+			"Workflow still running after its 15 minute maximum",
+		},
+	},
+
+	ErrorTypeAgentLimitExceeded: {
+		Code:      ErrorTypeAgentLimitExceeded,
+		Category:  "Execution",
+		Source:    "AGENT_CALL tasks",
+		Retryable: false,
+		Description: "The called agent was stopped by one of the limits set with agent.WithLimits(). " +
+			"The error message names the exceeded limit.",
+		Examples: []string{
+			"Agent attempted 51 tool calls with MaxToolCalls(50)",
+			"Agent used more than its MaxTokensPerRun token budget",
+		},
+	},
+
 	ErrorTypeAny: {
 		Code:        ErrorTypeAny,
 		Category:    "Wildcard",
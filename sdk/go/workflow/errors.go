@@ -29,6 +29,14 @@ var (
 	// ErrInvalidTaskName is returned when a task name is invalid.
 	ErrInvalidTaskName = errors.New("invalid task name")
 
+	// ErrInvalidOnFailure is returned when a WithOnFailure handler returns
+	// no task.
+	ErrInvalidOnFailure = errors.New("invalid on-failure handler")
+
+	// ErrUnknownSwitchTarget is returned when a Switch case routes to a task
+	// name that does not exist in the workflow.
+	ErrUnknownSwitchTarget = errors.New("switch case routes to unknown task")
+
 	// ErrInvalidTaskKind is returned when a task kind is invalid.
 	ErrInvalidTaskKind = errors.New("invalid task kind")
 
@@ -38,6 +46,175 @@ var (
 	// ErrMissingRequiredField is returned when a required field is missing.
 	ErrMissingRequiredField = errors.New("missing required field")
 
+	// ErrFieldNotExported is returned when Field() references a field that
+	// was narrowed away by Exports().
+	ErrFieldNotExported = errors.New("field not exported by task")
+
+	// ErrEmptyExports is returned when Exports() is called without field names.
+	ErrEmptyExports = errors.New("exports requires at least one field")
+
+	// ErrFieldNotInOutputSchema is returned when Field() references a path
+	// that the called agent's output schema does not declare.
+	ErrFieldNotInOutputSchema = errors.New("field not declared by agent output schema")
+
+	// ErrInvalidOutputSchema is returned when an agent call output schema is invalid.
+	ErrInvalidOutputSchema = errors.New("invalid output schema")
+
+	// ErrInvalidExecutionTimeout is returned when a task execution timeout is invalid.
+	ErrInvalidExecutionTimeout = errors.New("invalid execution timeout")
+
+	// ErrInvalidTaskDescription is returned when a task description is too long.
+	ErrInvalidTaskDescription = errors.New("invalid task description")
+
+	// ErrInvalidSensitiveOutput is returned when a sensitive output field is invalid.
+	ErrInvalidSensitiveOutput = errors.New("invalid sensitive output field")
+
+	// ErrSensitiveOutputExported is returned when a sensitive output field is
+	// re-exported by an expression the runner cannot redact.
+	ErrSensitiveOutputExported = errors.New("sensitive output exported unredacted")
+
+	// ErrInvalidAttachment is returned when an agent call attachment is invalid,
+	// such as a duplicate name or literal content larger than its size limit.
+	ErrInvalidAttachment = errors.New("invalid agent call attachment")
+
+	// ErrInvalidAgentRef is returned when an agent referenced with AgentRef
+	// has no organization or name.
+	ErrInvalidAgentRef = errors.New("invalid agent reference")
+
+	// ErrInvalidConcurrencyPolicy is returned when a workflow concurrency policy is invalid.
+	ErrInvalidConcurrencyPolicy = errors.New("invalid concurrency policy")
+
+	// ErrInvalidSchedule is returned when a workflow schedule has an invalid
+	// cron expression or time zone.
+	ErrInvalidSchedule = errors.New("invalid workflow schedule")
+
+	// ErrInvalidMaxDuration is returned when a workflow maximum duration set
+	// with WithMaxDuration is below the 10 second floor.
+	ErrInvalidMaxDuration = errors.New("invalid workflow maximum duration")
+
+	// ErrInvalidTLS is returned when the TLS configuration of an HTTP call is
+	// invalid, such as a client certificate without a key or a literal private key.
+	ErrInvalidTLS = errors.New("invalid TLS configuration")
+
+	// ErrInvalidCache is returned when the cache directive of an HTTP call is
+	// invalid, such as caching a non-GET request or a TTL under one second.
+	ErrInvalidCache = errors.New("invalid cache directive")
+
+	// ErrInvalidEndpoint is returned when the endpoint of an HTTP call is
+	// invalid, such as a literal URL with an unsupported scheme or an
+	// unterminated ${...} expression.
+	ErrInvalidEndpoint = errors.New("invalid HTTP endpoint")
+
+	// ErrNotExportable is returned by ExportYAML when a workflow uses a
+	// construct that only the Stigmer runtime supports, such as CALL_AGENT.
+	ErrNotExportable = errors.New("not exportable to Serverless Workflow")
+
+	// ErrInvalidProxy is returned when the proxy of an HTTP call is invalid,
+	// such as a URL without a host or with an unsupported scheme.
+	ErrInvalidProxy = errors.New("invalid proxy configuration")
+
+	// ErrInvalidResponseFormat is returned when an HTTP call sets a response
+	// format the runner does not support.
+	ErrInvalidResponseFormat = errors.New("invalid response format")
+
+	// ErrInvalidExpectStatus is returned when an HTTP call expects no status
+	// code or one outside 100-599, or both expects statuses and allows any.
+	ErrInvalidExpectStatus = errors.New("invalid expected status")
+
+	// ErrBodyNotAllowed is returned when a GET, HEAD or DELETE request has a
+	// body without AllowBodyOnGet.
+	ErrBodyNotAllowed = errors.New("request body not allowed for HTTP method")
+
+	// ErrHeaderOnlyResponse is returned when a HEAD or OPTIONS task sets a
+	// response format or Field() references output other than its status
+	// code and headers.
+	ErrHeaderOnlyResponse = errors.New("HTTP response has no body")
+
+	// ErrConflictingBody is returned when the request body of an HTTP call is
+	// set more than once, such as WithBody together with BodyForm.
+	ErrConflictingBody = errors.New("conflicting request bodies")
+
+	// ErrInvalidBodyEncoding is returned when an HTTP call sets a body
+	// encoding the runner does not support, or misconfigures multipart parts.
+	ErrInvalidBodyEncoding = errors.New("invalid body encoding")
+
+	// ErrInvalidApproval is returned when an approval task is misconfigured,
+	// such as a negative timeout or an unknown timeout action.
+	ErrInvalidApproval = errors.New("invalid approval configuration")
+
+	// ErrInvalidRepeat is returned when a Repeat loop is misconfigured, such
+	// as a zero step or a range longer than its iteration cap.
+	ErrInvalidRepeat = errors.New("invalid repeat loop")
+
+	// ErrInvalidMap is returned when a Map task has an invalid projection or
+	// its source field is declared as something other than an array.
+	ErrInvalidMap = errors.New("invalid map task")
+
+	// ErrInvalidCatchFilter is returned when a catch block of a Try task has
+	// an invalid filter, such as an empty error type or a status code outside
+	// 100-599, or no tasks.
+	ErrInvalidCatchFilter = errors.New("invalid catch filter")
+
+	// ErrUnreachableCatch is returned when a catch block of a Try task follows
+	// an unfiltered one, which catches every error before it.
+	ErrUnreachableCatch = errors.New("unreachable catch block")
+
+	// ErrInvalidRetryPolicy is returned when the retry policy of a Try task
+	// has no error types, an empty one, a retry count outside 1-100 or a
+	// negative delay.
+	ErrInvalidRetryPolicy = errors.New("invalid retry policy")
+
+	// ErrVariableUnset is returned when a task references, through Field(),
+	// a variable that an earlier task removed with UnsetVar.
+	ErrVariableUnset = errors.New("variable referenced after unset")
+
+	// ErrInvalidSetVars is returned when SetVars is given an odd number of
+	// arguments or a variable name that is not a string.
+	ErrInvalidSetVars = errors.New("invalid SetVars arguments")
+
+	// ErrInvalidDuration is returned when a timeout or wait duration cannot be
+	// stored in whole seconds, such as TimeoutDuration(30), which is 30
+	// nanoseconds. Generated SetXDuration setters return the same error.
+	ErrInvalidDuration = validation.ErrInvalidDuration
+
+	// ErrMutuallyExclusive is returned when more than one member of a oneof
+	// group is set, such as both Message and MessageRef of an agent call.
+	ErrMutuallyExclusive = validation.ErrMutuallyExclusive
+
+	// ErrInvalidRunIf is returned when a RunIf guard references a task that
+	// does not run before the guarded task.
+	ErrInvalidRunIf = errors.New("invalid RunIf guard")
+
+	// ErrUnguardedReference is returned when a task without a RunIf guard
+	// references, through Field(), the output of a task that may be skipped.
+	ErrUnguardedReference = errors.New("unguarded reference to conditionally skipped task")
+
+	// ErrUnknownTaskReference is returned when a weak field reference
+	// names a task that is not part of the workflow.
+	ErrUnknownTaskReference = errors.New("reference to unknown task")
+
+	// ErrUnknownForkBranch is returned when Branch names a branch the fork
+	// task does not have.
+	ErrUnknownForkBranch = errors.New("reference to unknown fork branch")
+
+	// ErrNilTaskReference is returned when a task references a field of a nil
+	// *Task, such as one from a builder whose error was ignored.
+	ErrNilTaskReference = errors.New("reference to a field of a nil task")
+
+	// ErrInvalidExpression is returned when a raw JQ expression created with
+	// Expr does not parse.
+	ErrInvalidExpression = errors.New("invalid JQ expression")
+
+	// ErrInvalidGrpcRequest is returned when a GRPC_CALL request built with
+	// GrpcRequestFromProto does not match the declared method or GrpcField
+	// names an unknown field.
+	ErrInvalidGrpcRequest = errors.New("invalid gRPC request")
+
+	// ErrRuntimeValueInLiteral is returned when a value resolved only at
+	// runtime, such as a TaskFieldRef inside an ObjectRef, is used in a field
+	// that accepts literals only.
+	ErrRuntimeValueInLiteral = errors.New("runtime value where only literals are allowed")
+
 	// ErrConversion is returned when proto conversion fails.
 	ErrConversion = errors.New("proto conversion failed")
 )
@@ -0,0 +1,91 @@
+package workflow
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ExecutionTimeoutOption bounds the wall-clock execution time of a task. It
+// is accepted by the builders that take options (HttpOption, GrpcOption,
+// AgentCallOption, CallActivityOption and CustomOption); other tasks are
+// bounded with Task.ExecutionTimeout.
+type ExecutionTimeoutOption struct {
+	d time.Duration
+}
+
+// ExecutionTimeout bounds the wall-clock execution time of a task. Unlike
+// TimeoutDuration, which only bounds the HTTP request or agent execution,
+// this caps the whole task. Durations are rounded up to whole seconds and
+// must fit in an int32 number of seconds.
+//
+// When exceeded, the task fails with ErrorTypeExecutionTimeout, which can be
+// handled in a TRY block with CatchExecutionTimeout().
+//
+// Example:
+//
+//	wf.CallAgent("review", &workflow.AgentCallArgs{
+//	    Message: "Review this PR: ${.input.prUrl}",
+//	}, workflow.AgentBySlug("code-reviewer"), workflow.ExecutionTimeout(10*time.Minute))
+func ExecutionTimeout(d time.Duration) ExecutionTimeoutOption {
+	return ExecutionTimeoutOption{d: d}
+}
+
+func (o ExecutionTimeoutOption) applyHttp(t *Task, _ *HttpCallTaskConfig) {
+	t.ExecutionTimeoutAfter = o.d
+}
+
+func (o ExecutionTimeoutOption) applyGrpc(t *Task, _ *GrpcCallTaskConfig) {
+	t.ExecutionTimeoutAfter = o.d
+}
+
+func (o ExecutionTimeoutOption) applyAgentCall(t *Task, _ *AgentCallTaskConfig) {
+	t.ExecutionTimeoutAfter = o.d
+}
+
+func (o ExecutionTimeoutOption) applyCallActivity(t *Task, _ *CallActivityTaskConfig) {
+	t.ExecutionTimeoutAfter = o.d
+}
+
+func (o ExecutionTimeoutOption) applyCustom(t *Task, _ *CustomTaskConfig) {
+	t.ExecutionTimeoutAfter = o.d
+}
+
+// ExecutionTimeout bounds the wall-clock execution time of the task. It is
+// the chained form of the ExecutionTimeout option and works on tasks of every
+// kind, including those whose builders take no options.
+//
+// Example:
+//
+//	wf.ForEach("poll", &workflow.ForArgs{...}).
+//	    ExecutionTimeout(10 * time.Minute)
+func (t *Task) ExecutionTimeout(d time.Duration) *Task {
+	t.ExecutionTimeoutAfter = d
+	return t
+}
+
+// executionTimeoutSeconds returns the execution timeout in whole seconds,
+// rounded up. Negative timeouts and timeouts that overflow the int32 proto
+// field are rejected.
+func (t *Task) executionTimeoutSeconds() (int32, error) {
+	if t.ExecutionTimeoutAfter < 0 {
+		return 0, NewValidationErrorWithCause(
+			"execution_timeout",
+			t.ExecutionTimeoutAfter.String(),
+			"gte",
+			"execution timeout must not be negative",
+			ErrInvalidExecutionTimeout,
+		)
+	}
+	seconds := math.Ceil(t.ExecutionTimeoutAfter.Seconds())
+	if seconds > math.MaxInt32 {
+		return 0, NewValidationErrorWithCause(
+			"execution_timeout",
+			t.ExecutionTimeoutAfter.String(),
+			"lte",
+			fmt.Sprintf("execution timeout must be at most %d seconds", math.MaxInt32),
+			ErrInvalidExecutionTimeout,
+		)
+	}
+	return int32(seconds), nil
+}
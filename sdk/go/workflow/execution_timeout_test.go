@@ -0,0 +1,53 @@
+package workflow
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestExecutionTimeout_Option(t *testing.T) {
+	tasks := []*Task{
+		HttpGet("fetch", "https://api.example.com/data", nil, ExecutionTimeout(time.Minute)),
+		GrpcCall("lookup", &GrpcCallArgs{Service: "users.v1.Users", Method: "Get"}, ExecutionTimeout(time.Minute)),
+		AgentCall("review", &AgentCallArgs{Message: "Review"},
+			AgentBySlug("code-reviewer"), ExecutionTimeout(time.Minute)),
+		CallActivity("normalize", &CallActivityArgs{Activity: "transform.v1.Normalize"}, ExecutionTimeout(time.Minute)),
+		Custom("query", "SNOWFLAKE_QUERY", nil, ExecutionTimeout(time.Minute)),
+		Set("init", &SetArgs{Variables: map[string]interface{}{"page": "1"}}).ExecutionTimeout(time.Minute),
+	}
+	for _, task := range tasks {
+		if task.ExecutionTimeoutAfter != time.Minute {
+			t.Errorf("task %q ExecutionTimeoutAfter = %v, want 1m", task.Name, task.ExecutionTimeoutAfter)
+		}
+	}
+}
+
+func TestExecutionTimeout_Overflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       time.Duration
+		want    int32
+		wantErr bool
+	}{
+		{name: "max", d: math.MaxInt32 * time.Second, want: math.MaxInt32},
+		{name: "rounded up past max", d: math.MaxInt32*time.Second + time.Millisecond, wantErr: true},
+		{name: "max duration", d: math.MaxInt64, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := HttpGet("fetch", "https://api.example.com/data", nil, ExecutionTimeout(tt.d))
+			got, err := task.executionTimeoutSeconds()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidExecutionTimeout) {
+					t.Errorf("executionTimeoutSeconds() error = %v, want ErrInvalidExecutionTimeout", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("executionTimeoutSeconds() = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
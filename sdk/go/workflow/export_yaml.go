@@ -0,0 +1,435 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	tasksv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// raiseErrorType is the error type URI of exported RAISE tasks. It matches
+// the type the workflow runner gives raised errors, so the error name (the
+// title) survives a round trip.
+const raiseErrorType = "https://stigmer.ai/errors/raise"
+
+// ExportYAML renders a workflow as a Serverless Workflow 1.0 document
+// (document, schedule and do), for engines such as SonataFlow that run the
+// standard DSL.
+//
+// The workflow is synthesized first, so the export matches what would be
+// deployed. Constructs that only the Stigmer runtime understands have no
+// standard equivalent and fail the export with ErrNotExportable, naming each
+// offending task:
+//
+//   - CALL_AGENT, CALL_ACTIVITY, RUN and GRPC_CALL tasks
+//   - runtime placeholders (RuntimeSecret, RuntimeEnv) and environment variables
+//   - HTTP TLS, proxy and cache settings, approvals, signal filters,
+//     sensitive outputs, loop caps and early exits, unset variables,
+//     concurrency policies and on-failure tasks
+//
+// Task timeouts (ExecutionTimeout, or the request timeout of HTTP calls) are
+// exported as the standard task timeout, and task descriptions (Describe) as
+// the description entry of the task metadata.
+//
+// Example:
+//
+//	data, err := workflow.ExportYAML(wf)
+//	if err != nil {
+//	    return err
+//	}
+//	os.WriteFile("order-sync.sw.yaml", data, 0o644)
+func ExportYAML(w *Workflow) ([]byte, error) {
+	pb, err := w.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	doc, err := exportSpec(pb.GetSpec())
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+// exportSpec converts a synthesized workflow spec to a Serverless Workflow
+// document. All constructs that cannot be exported are reported together.
+func exportSpec(spec *workflowv1.WorkflowSpec) (yamlMap, error) {
+	e := &exporter{}
+
+	document := yamlMap{
+		{"dsl", spec.GetDocument().GetDsl()},
+		{"namespace", spec.GetDocument().GetNamespace()},
+		{"name", spec.GetDocument().GetName()},
+		{"version", spec.GetDocument().GetVersion()},
+	}
+	description := spec.GetDocument().GetDescription()
+	if description == "" {
+		description = spec.GetDescription()
+	}
+	if description != "" {
+		document = append(document, yamlEntry{"summary", description})
+	}
+	doc := yamlMap{{"document", document}}
+
+	if len(spec.GetEnvSpec().GetData()) > 0 {
+		e.fail("", "environment variables are resolved by the Stigmer runtime; pass the values as workflow input instead")
+	}
+	if spec.GetConcurrencyPolicy() != nil {
+		e.fail("", "concurrency policies are enforced by the Stigmer runtime; configure concurrency on the target engine")
+	}
+	if schedule := spec.GetSchedule(); schedule != nil {
+		if schedule.GetTimezone() != "" || schedule.GetCatchUpPolicy() == workflowv1.ScheduleCatchUpPolicy_SCHEDULE_RUN_MISSED {
+			e.fail("", "schedule time zones and catch-up policies have no Serverless Workflow equivalent; use a UTC cron expression")
+		}
+		doc = append(doc, yamlEntry{"schedule", yamlMap{{"cron", schedule.GetCron()}}})
+	}
+
+	if len(spec.GetOnFailure()) > 0 {
+		e.fail("", "on-failure tasks are run by the Stigmer runtime; wrap the tasks in a Try task instead")
+	}
+
+	doc = append(doc, yamlEntry{"do", e.tasks("", spec.GetTasks())})
+	if err := errors.Join(e.errs...); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// exporter collects the constructs that cannot be exported while a workflow
+// is converted.
+type exporter struct {
+	errs []error
+}
+
+// fail records a construct of the task at path that cannot be exported.
+// An empty path refers to the workflow itself.
+func (e *exporter) fail(path, format string, args ...interface{}) {
+	where := "workflow"
+	if path != "" {
+		where = fmt.Sprintf("task %q", path)
+	}
+	e.errs = append(e.errs, fmt.Errorf("%w: %s: %s", ErrNotExportable, where, fmt.Sprintf(format, args...)))
+}
+
+// tasks converts a task list to a "do" list of single-entry maps. Nested
+// tasks are named by their path from the workflow, such as "retry/fetch".
+func (e *exporter) tasks(parent string, tasks []*workflowv1.WorkflowTask) []yamlMap {
+	do := make([]yamlMap, 0, len(tasks))
+	for _, task := range tasks {
+		path := task.GetName()
+		if parent != "" {
+			path = parent + "/" + path
+		}
+		do = append(do, yamlMap{{task.GetName(), e.task(path, task)}})
+	}
+	return do
+}
+
+// task converts one task, with its run guard, flow directive, export,
+// timeout and description.
+func (e *exporter) task(path string, task *workflowv1.WorkflowTask) yamlMap {
+	if len(task.GetSensitiveOutputFields()) > 0 {
+		e.fail(path, "sensitive outputs are redacted by the Stigmer runtime and would be exported in clear")
+	}
+	e.checkPlaceholders(path, task.GetTaskConfig().AsMap())
+
+	var m yamlMap
+	if task.GetIf() != "" {
+		m = append(m, yamlEntry{"if", task.GetIf()})
+	}
+
+	if task.GetKind() == apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_CUSTOM {
+		e.fail(path, "%s tasks run on a Stigmer runner extension and have no Serverless Workflow equivalent", task.GetCustomKind())
+		return m
+	}
+
+	timeout := task.GetExecutionTimeoutSeconds()
+	config, err := unmarshalTaskConfig(task)
+	if err != nil {
+		e.fail(path, "%v", err)
+		return m
+	}
+	switch cfg := config.(type) {
+	case *tasksv1.SetTaskConfig:
+		m = append(m, e.set(path, cfg)...)
+	case *tasksv1.HttpCallTaskConfig:
+		m = append(m, e.httpCall(path, cfg)...)
+		if timeout == 0 {
+			timeout = cfg.GetTimeoutSeconds()
+		}
+	case *tasksv1.SwitchTaskConfig:
+		m = append(m, e.switchCases(cfg)...)
+	case *tasksv1.ForTaskConfig:
+		m = append(m, e.forEach(path, cfg)...)
+	case *tasksv1.ForkTaskConfig:
+		m = append(m, e.fork(path, cfg)...)
+	case *tasksv1.TryTaskConfig:
+		m = append(m, e.try(path, cfg)...)
+	case *tasksv1.ListenTaskConfig:
+		m = append(m, e.listen(path, cfg)...)
+		if timeout == 0 {
+			timeout = cfg.GetTimeoutSeconds()
+		}
+	case *tasksv1.WaitTaskConfig:
+		m = append(m, yamlEntry{"wait", yamlMap{{"seconds", cfg.GetSeconds()}}})
+	case *tasksv1.RaiseTaskConfig:
+		m = append(m, e.raise(cfg)...)
+	case *tasksv1.AgentCallTaskConfig:
+		e.fail(path, "CALL_AGENT tasks run Stigmer agents; call the agent through an HTTP API instead, "+
+			"or keep this workflow on Stigmer")
+	case *tasksv1.CallActivityTaskConfig:
+		e.fail(path, "CALL_ACTIVITY tasks run Temporal activities of the Stigmer runner; expose the activity "+
+			"as an HTTP endpoint instead")
+	case *tasksv1.RunTaskConfig:
+		e.fail(path, "RUN tasks reference workflows deployed to Stigmer; export the sub-workflow separately "+
+			"and reference it on the target engine")
+	case *tasksv1.GrpcCallTaskConfig:
+		e.fail(path, "GRPC_CALL tasks rely on server reflection; the Serverless Workflow gRPC call requires "+
+			"a proto file and host")
+	}
+
+	if then := task.GetFlow().GetThen(); then != "" {
+		m = append(m, yamlEntry{"then", then})
+	}
+	if as := task.GetExport().GetAs(); as != "" {
+		m = append(m, yamlEntry{"export", yamlMap{{"as", as}}})
+	}
+	if timeout > 0 {
+		m = append(m, yamlEntry{"timeout", yamlMap{{"after", yamlMap{{"seconds", timeout}}}}})
+	}
+	if description := task.GetDescription(); description != "" {
+		m = append(m, yamlEntry{"metadata", yamlMap{{"description", description}}})
+	}
+	return m
+}
+
+func (e *exporter) set(path string, cfg *tasksv1.SetTaskConfig) yamlMap {
+	if len(cfg.GetUnset()) > 0 {
+		e.fail(path, "unset variables have no Serverless Workflow equivalent")
+	}
+	return yamlMap{{"set", cfg.GetVariables().AsMap()}}
+}
+
+func (e *exporter) httpCall(path string, cfg *tasksv1.HttpCallTaskConfig) yamlMap {
+	if cfg.GetTls() != nil {
+		e.fail(path, "TLS settings are applied by the Stigmer runner; configure client certificates and CAs on the target engine")
+	}
+	if cfg.GetProxy() != nil {
+		e.fail(path, "proxy overrides are applied by the Stigmer runner; configure the proxy on the target engine")
+	}
+	if cfg.GetCache() != nil {
+		e.fail(path, "response caching is provided by the Stigmer runner; remove WithCache to export")
+	}
+	if cfg.GetResponseFormat() != "" {
+		e.fail(path, "response formats are applied by the Stigmer runner; remove ResponseFormat to export")
+	}
+	if encoding := cfg.GetBodyEncoding(); encoding != "" && encoding != bodyEncodingJSON {
+		e.fail(path, "form and multipart bodies are encoded by the Stigmer runner; remove BodyForm and BodyMultipart to export")
+	}
+	if len(cfg.GetExpectStatus()) > 0 || cfg.GetAllowAnyStatus() {
+		e.fail(path, "response status checks are applied by the Stigmer runner; remove ExpectStatus and AllowAnyStatus to export")
+	}
+
+	with := yamlMap{
+		{"method", cfg.GetMethod()},
+		{"endpoint", yamlMap{{"uri", cfg.GetEndpoint().GetUri()}}},
+	}
+	if len(cfg.GetHeaders()) > 0 {
+		with = append(with, yamlEntry{"headers", cfg.GetHeaders()})
+	}
+	if body := cfg.GetBody().AsMap(); len(body) > 0 {
+		with = append(with, yamlEntry{"body", body})
+	}
+	return yamlMap{{"call", "http"}, {"with", with}}
+}
+
+// switchCases converts switch cases to the named case list of the DSL.
+func (e *exporter) switchCases(cfg *tasksv1.SwitchTaskConfig) yamlMap {
+	cases := make([]yamlMap, 0, len(cfg.GetCases()))
+	for _, c := range cfg.GetCases() {
+		var def yamlMap
+		if c.GetWhen() != "" {
+			def = append(def, yamlEntry{"when", c.GetWhen()})
+		}
+		def = append(def, yamlEntry{"then", c.GetThen()})
+		cases = append(cases, yamlMap{{c.GetName(), def}})
+	}
+	return yamlMap{{"switch", cases}}
+}
+
+func (e *exporter) forEach(path string, cfg *tasksv1.ForTaskConfig) yamlMap {
+	if cfg.GetUntil() != "" {
+		e.fail(path, "until is evaluated after each iteration by the Stigmer runner; "+
+			"the Serverless Workflow while condition is evaluated before")
+	}
+	if cfg.GetMaxIterations() > 0 || cfg.GetReportIterations() {
+		e.fail(path, "iteration caps and counts have no Serverless Workflow equivalent")
+	}
+	return yamlMap{
+		{"for", yamlMap{{"each", cfg.GetEach()}, {"in", cfg.GetIn()}}},
+		{"do", e.tasks(path, cfg.GetDo())},
+	}
+}
+
+// fork converts each branch to a task running the branch's tasks.
+func (e *exporter) fork(path string, cfg *tasksv1.ForkTaskConfig) yamlMap {
+	if cfg.GetContinueOnBranchError() {
+		e.fail(path, "continuing after a failed branch has no Serverless Workflow equivalent; "+
+			"wrap the branch tasks in a try task instead")
+	}
+	branches := make([]yamlMap, 0, len(cfg.GetBranches()))
+	for _, branch := range cfg.GetBranches() {
+		branchPath := path + "/" + branch.GetName()
+		branches = append(branches, yamlMap{{branch.GetName(), yamlMap{{"do", e.tasks(branchPath, branch.GetDo())}}}})
+	}
+	fork := yamlMap{{"branches", branches}}
+	if cfg.GetCompete() {
+		fork = append(fork, yamlEntry{"compete", true})
+	}
+	return yamlMap{{"fork", fork}}
+}
+
+func (e *exporter) try(path string, cfg *tasksv1.TryTaskConfig) yamlMap {
+	if len(cfg.GetCatches()) > 0 {
+		e.fail(path, "filtered catch blocks match errors classified by the Stigmer runner; "+
+			"use a single Catch to export")
+	}
+	if cfg.GetRetry() != nil {
+		e.fail(path, "retry policies match errors classified by the Stigmer runner; "+
+			"remove Retry to export")
+	}
+	m := yamlMap{{"try", e.tasks(path, cfg.GetTry())}}
+	if catch := cfg.GetCatch(); catch != nil {
+		var c yamlMap
+		if catch.GetAs() != "" {
+			c = append(c, yamlEntry{"as", catch.GetAs()})
+		}
+		c = append(c, yamlEntry{"do", e.tasks(path, catch.GetDo())})
+		m = append(m, yamlEntry{"catch", c})
+	}
+	return m
+}
+
+// listen converts signals to event filters on the CloudEvent id and type.
+// As on the runner, "all" waits for every signal, a single signal is a
+// "one" and otherwise the first signal received completes the task.
+func (e *exporter) listen(path string, cfg *tasksv1.ListenTaskConfig) yamlMap {
+	if cfg.GetApproval() != nil {
+		e.fail(path, "approval gates are decided through the Stigmer server; model the approval as an event instead")
+	}
+
+	filters := make([]yamlMap, 0, len(cfg.GetTo().GetSignals()))
+	for _, signal := range cfg.GetTo().GetSignals() {
+		if signal.GetAcceptIf() != "" {
+			e.fail(path, "signal %q: acceptIf has no Serverless Workflow equivalent", signal.GetId())
+		}
+		filters = append(filters, yamlMap{{"with", yamlMap{{"id", signal.GetId()}, {"type", signal.GetType()}}}})
+	}
+
+	var to yamlMap
+	switch {
+	case cfg.GetTo().GetMode() == "all":
+		to = yamlMap{{"all", filters}}
+	case len(filters) == 1:
+		to = yamlMap{{"one", filters[0]}}
+	default:
+		to = yamlMap{{"any", filters}}
+	}
+	return yamlMap{{"listen", yamlMap{{"to", to}}}}
+}
+
+func (e *exporter) raise(cfg *tasksv1.RaiseTaskConfig) yamlMap {
+	def := yamlMap{
+		{"type", raiseErrorType},
+		{"status", 500},
+		{"title", cfg.GetError()},
+	}
+	if cfg.GetMessage() != "" {
+		def = append(def, yamlEntry{"detail", cfg.GetMessage()})
+	}
+	return yamlMap{{"raise", yamlMap{{"error", def}}}}
+}
+
+// checkPlaceholders reports runtime placeholders, which only the Stigmer
+// runtime resolves. Nested tasks are checked when they are converted.
+func (e *exporter) checkPlaceholders(path string, v interface{}) {
+	refs := map[string]bool{}
+	collectRuntimeRefs(v, refs)
+	if len(refs) == 0 {
+		return
+	}
+	names := make([]string, 0, len(refs))
+	for ref := range refs {
+		names = append(names, ref)
+	}
+	sort.Strings(names)
+	e.fail(path, "runtime placeholders %s are resolved by the Stigmer runtime; pass the values as workflow input "+
+		"or use the target engine's secrets", strings.Join(names, ", "))
+}
+
+// collectRuntimeRefs adds the runtime placeholders found in the strings of v
+// to refs, skipping nested task lists.
+func collectRuntimeRefs(v interface{}, refs map[string]bool) {
+	switch val := v.(type) {
+	case string:
+		for _, ref := range ExtractRuntimeRefs(val) {
+			refs[ref] = true
+		}
+	case map[string]interface{}:
+		if _, ok := val["taskConfig"]; ok {
+			return
+		}
+		for _, item := range val {
+			collectRuntimeRefs(item, refs)
+		}
+	case []interface{}:
+		for _, item := range val {
+			collectRuntimeRefs(item, refs)
+		}
+	}
+}
+
+// unmarshalTaskConfig converts the task_config Struct of a task to its typed
+// config message.
+func unmarshalTaskConfig(task *workflowv1.WorkflowTask) (proto.Message, error) {
+	msg, err := newTaskConfigMessage(task.GetKind())
+	if err != nil {
+		return nil, err
+	}
+	data, err := task.GetTaskConfig().MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task config: %w", err)
+	}
+	if err := protojson.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task config: %w", err)
+	}
+	return msg, nil
+}
+
+// yamlMap is a YAML mapping that keeps its keys in insertion order, so
+// exported documents read like hand-written ones.
+type yamlMap []yamlEntry
+
+type yamlEntry struct {
+	Key   string
+	Value interface{}
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (m yamlMap) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, entry := range m {
+		value := &yaml.Node{}
+		if err := value.Encode(entry.Value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: entry.Key}, value)
+	}
+	return node, nil
+}
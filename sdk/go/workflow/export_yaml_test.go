@@ -0,0 +1,272 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/serverlessworkflow/sdk-go/v3/parser"
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExportYAML(t *testing.T) {
+	fetch := HttpGet("fetch", "https://api.example.com/orders", map[string]string{
+		"Accept": "application/json",
+	}).ExecutionTimeout(30 * time.Second).Export("${ .body }")
+
+	wf := &Workflow{
+		Document: Document{DSL: "1.0.0", Namespace: "shop", Name: "orders", Version: "1.0.0"},
+		Tasks: []*Task{
+			fetch,
+			Set("init", &SetArgs{Variables: map[string]interface{}{"count": "0"}}),
+			Switch("route", &SwitchArgs{Cases: []*types.SwitchCase{
+				{Name: "empty", When: "${ .orders | length == 0 }", Then: "fail"},
+				{Name: "default", Then: "process"},
+			}}),
+			For("process", &ForArgs{
+				Each: "order",
+				In:   "${ .orders }",
+				Do: LoopBody(func(item LoopVar) []*Task {
+					return []*Task{Set("track", &SetArgs{Variables: map[string]interface{}{"id": item.Field("id")}})}
+				}),
+			}),
+			Try("attempt", &TryArgs{
+				Try:   TryBody(HttpGet("notify", "https://hooks.example.com/done", nil)),
+				Catch: CatchBody("err", Set("logError", &SetArgs{Variables: map[string]interface{}{"failed": "true"}})),
+			}),
+			Fork("fanout", &ForkArgs{Branches: ForkBranches(
+				ForkBranch("left", Wait("pause", &WaitArgs{Seconds: 5})),
+				ForkBranch("right", Set("mark", &SetArgs{Variables: map[string]interface{}{"forked": "true"}})),
+			)}),
+			Raise("fail", &RaiseArgs{Error: "NoOrders", Message: "no orders to process"}),
+		},
+	}
+
+	out, err := ExportYAML(wf)
+	if err != nil {
+		t.Fatalf("ExportYAML() failed: %v", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("exported YAML does not parse: %v\n%s", err, out)
+	}
+	root := doc.Content[0]
+	if got := root.Content[0].Value; got != "document" {
+		t.Errorf("first key = %q, want document", got)
+	}
+
+	var parsed struct {
+		Document map[string]interface{}   `yaml:"document"`
+		Do       []map[string]interface{} `yaml:"do"`
+	}
+	if err := yaml.Unmarshal(out, &parsed); err != nil {
+		t.Fatalf("exported YAML does not parse: %v", err)
+	}
+	if parsed.Document["dsl"] != "1.0.0" || parsed.Document["namespace"] != "shop" || parsed.Document["name"] != "orders" {
+		t.Errorf("document = %v", parsed.Document)
+	}
+
+	wantOrder := []string{"fetch", "init", "route", "process", "attempt", "fanout", "fail"}
+	if len(parsed.Do) != len(wantOrder) {
+		t.Fatalf("do has %d tasks, want %d:\n%s", len(parsed.Do), len(wantOrder), out)
+	}
+	tasks := make(map[string]map[string]interface{})
+	for i, entry := range parsed.Do {
+		if _, ok := entry[wantOrder[i]]; !ok {
+			t.Errorf("do[%d] = %v, want task %q", i, entry, wantOrder[i])
+		}
+		for name, body := range entry {
+			tasks[name], _ = body.(map[string]interface{})
+		}
+	}
+
+	text := string(out)
+	for _, want := range []string{
+		"call: http",
+		"uri: https://api.example.com/orders",
+		"as: ${ .body }",
+		"after:\n",
+		"seconds: 30",
+		"- empty:\n",
+		"- default:\n",
+		"each: order",
+		"in: ${ .orders }",
+		"as: err",
+		"- left:\n",
+		"- right:\n",
+		"type: " + raiseErrorType,
+		"title: NoOrders",
+		"detail: no orders to process",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("exported YAML missing %q:\n%s", want, text)
+		}
+	}
+
+	if _, ok := tasks["process"]["do"]; !ok {
+		t.Errorf("for task has no do block: %v", tasks["process"])
+	}
+	if _, ok := tasks["attempt"]["catch"]; !ok {
+		t.Errorf("try task has no catch block: %v", tasks["attempt"])
+	}
+	if _, ok := tasks["fanout"]["fork"]; !ok {
+		t.Errorf("fork task has no fork block: %v", tasks["fanout"])
+	}
+}
+
+// TestExportYAML_ServerlessWorkflowModel parses the export with the
+// Serverless Workflow model the workflow runner uses, so YAML that merely
+// looks right but does not load as the standard DSL fails here.
+func TestExportYAML_ServerlessWorkflowModel(t *testing.T) {
+	wf := &Workflow{
+		Document: Document{DSL: "1.0.0", Namespace: "shop", Name: "orders", Version: "1.0.0"},
+		Tasks: []*Task{
+			HttpPost("fetch", "https://api.example.com/orders", map[string]string{
+				"Accept": "application/json",
+			}, map[string]interface{}{"status": "open"}),
+			Set("init", &SetArgs{Variables: map[string]interface{}{"count": "0"}}),
+			Switch("route", &SwitchArgs{Cases: []*types.SwitchCase{
+				{Name: "empty", When: "${ .orders | length == 0 }", Then: "end"},
+				{Name: "default", Then: "process"},
+			}}),
+			For("process", &ForArgs{
+				Each: "order",
+				In:   "${ .orders }",
+				Do: LoopBody(func(item LoopVar) []*Task {
+					return []*Task{Set("track", &SetArgs{Variables: map[string]interface{}{"id": item.Field("id")}})}
+				}),
+			}),
+			Try("attempt", &TryArgs{
+				Try:   TryBody(HttpGet("notify", "https://hooks.example.com/done", nil)),
+				Catch: CatchBody("err", Set("logError", &SetArgs{Variables: map[string]interface{}{"failed": "true"}})),
+			}),
+		},
+	}
+
+	out, err := ExportYAML(wf)
+	if err != nil {
+		t.Fatalf("ExportYAML() failed: %v", err)
+	}
+	parsed, err := parser.FromYAMLSource(out)
+	if err != nil {
+		t.Fatalf("exported YAML does not load as a Serverless Workflow: %v\n%s", err, out)
+	}
+
+	doc := parsed.Document
+	if doc.DSL != "1.0.0" || doc.Namespace != "shop" || doc.Name != "orders" || doc.Version != "1.0.0" {
+		t.Errorf("document = %+v", doc)
+	}
+
+	wantOrder := []string{"fetch", "init", "route", "process", "attempt"}
+	if parsed.Do == nil || len(*parsed.Do) != len(wantOrder) {
+		t.Fatalf("do = %v, want tasks %v", parsed.Do, wantOrder)
+	}
+	tasks := make(map[string]*model.TaskItem)
+	for i, item := range *parsed.Do {
+		if item.Key != wantOrder[i] {
+			t.Errorf("do[%d] = %q, want %q", i, item.Key, wantOrder[i])
+		}
+		tasks[item.Key] = item
+	}
+
+	if call := tasks["fetch"].AsCallHTTPTask(); call == nil {
+		t.Errorf("fetch is %T, want an HTTP call", tasks["fetch"].Task)
+	} else {
+		if call.With.Method != "POST" || call.With.Endpoint.String() != "https://api.example.com/orders" {
+			t.Errorf("fetch = %s %s", call.With.Method, call.With.Endpoint)
+		}
+		if call.With.Headers["Accept"] != "application/json" {
+			t.Errorf("fetch headers = %v", call.With.Headers)
+		}
+		if body := string(call.With.Body); !strings.Contains(body, `"status":"open"`) {
+			t.Errorf("fetch body = %s", body)
+		}
+	}
+
+	if set := tasks["init"].AsSetTask(); set == nil || set.Set["count"] != "0" {
+		t.Errorf("init = %#v, want set count", tasks["init"].Task)
+	}
+
+	if sw := tasks["route"].AsSwitchTask(); sw == nil || len(sw.Switch) != 2 {
+		t.Errorf("route = %#v, want a switch with 2 cases", tasks["route"].Task)
+	} else {
+		empty, ok := sw.Switch[0]["empty"]
+		if !ok || empty.When == nil || empty.When.Value != "${ .orders | length == 0 }" || empty.Then.Value != "end" {
+			t.Errorf("route case 0 = %+v", sw.Switch[0])
+		}
+		def, ok := sw.Switch[1]["default"]
+		if !ok || def.When != nil || def.Then.Value != "process" {
+			t.Errorf("route case 1 = %+v", sw.Switch[1])
+		}
+	}
+
+	if loop := tasks["process"].AsForTask(); loop == nil {
+		t.Errorf("process is %T, want a for task", tasks["process"].Task)
+	} else {
+		if loop.For.Each != "order" || loop.For.In != "${ .orders }" {
+			t.Errorf("process for = %+v", loop.For)
+		}
+		if loop.Do == nil || len(*loop.Do) != 1 || (*loop.Do)[0].Key != "track" || (*loop.Do)[0].AsSetTask() == nil {
+			t.Errorf("process do = %v, want set task track", loop.Do)
+		}
+	}
+
+	if try := tasks["attempt"].AsTryTask(); try == nil {
+		t.Errorf("attempt is %T, want a try task", tasks["attempt"].Task)
+	} else {
+		if try.Try == nil || len(*try.Try) != 1 || (*try.Try)[0].Key != "notify" || (*try.Try)[0].AsCallHTTPTask() == nil {
+			t.Errorf("attempt try = %v, want HTTP call notify", try.Try)
+		}
+		if try.Catch == nil || try.Catch.As != "err" || try.Catch.Do == nil ||
+			len(*try.Catch.Do) != 1 || (*try.Catch.Do)[0].Key != "logError" {
+			t.Errorf("attempt catch = %+v, want set task logError as err", try.Catch)
+		}
+	}
+}
+
+func TestExportYAML_NotExportable(t *testing.T) {
+	wf := &Workflow{
+		Document: Document{DSL: "1.0.0", Namespace: "shop", Name: "orders", Version: "1.0.0"},
+		Tasks: []*Task{
+			AgentCall("review", &AgentCallArgs{Agent: "reviewer", Message: "Review the order"}),
+			HttpGet("fetch", "https://api.example.com/orders", map[string]string{
+				"Authorization": "Bearer " + RuntimeSecret("API_TOKEN"),
+			}),
+			HttpGet("rates", "https://api.example.com/rates", nil).
+				WithCache(&types.HttpCache{TtlSeconds: 60}),
+			For("loop", &ForArgs{
+				In: "${ .items }",
+				Do: LoopBody(func(LoopVar) []*Task {
+					return []*Task{AgentCall("inner", &AgentCallArgs{Agent: "reviewer", Message: "Review"})}
+				}),
+			}),
+		},
+	}
+
+	_, err := ExportYAML(wf)
+	if err == nil {
+		t.Fatal("ExportYAML() succeeded, want error")
+	}
+	if !errors.Is(err, ErrNotExportable) {
+		t.Errorf("error = %v, want ErrNotExportable", err)
+	}
+	msg := err.Error()
+	for _, want := range []string{
+		`task "review"`,
+		"CALL_AGENT",
+		"HTTP API",
+		`task "fetch"`,
+		"API_TOKEN",
+		`task "rates"`,
+		"WithCache",
+		`task "loop/inner"`,
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error missing %q: %v", want, msg)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/itchyny/gojq"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// RawExpr is a raw JQ expression created with Expr, for expressions the
+// typed references cannot build.
+//
+// It implements Ref, so it is accepted everywhere references are.
+type RawExpr struct {
+	jq   string
+	deps []*Task
+}
+
+// rawExprs records the expressions created with Expr by their rendered form.
+// Task configs hold rendered strings, so synthesis and the inspection API
+// find raw expressions (to validate them and add the tasks they read as
+// dependencies) by looking up the strings of each task.
+var rawExprs sync.Map // rendered expression -> *rawExprInfo
+
+// rawExprInfo is what rawExprs records for one rendered expression.
+type rawExprInfo struct {
+	mu   sync.Mutex
+	jq   string
+	err  error
+	deps []*Task
+}
+
+// Expr creates a raw JQ expression, the escape hatch for expressions the
+// typed references cannot build.
+//
+// jq is the expression with or without the "${ }" wrapper. deps are the
+// tasks whose output the expression reads: a task using the expression
+// depends on them, as it would through Field(). Expressions reading
+// $context["task"] directly are also picked up, like any other reference.
+//
+// The syntax is validated at synthesis: an invalid expression fails
+// synthesis with the parse error and its position. The raw-expression lint
+// rule reports each task using one, so reviewers can spot them.
+//
+// Example:
+//
+//	fetch := wf.HttpGet("fetch", endpoint, nil)
+//	wf.SetVars("ids",
+//	    "ids", workflow.Expr(`[$context["fetch"].items[].id] | join(",")`, fetch),
+//	)
+func Expr(jq string, deps ...*Task) RawExpr {
+	e := RawExpr{jq: unwrapExpression(jq), deps: slices.Clone(deps)}
+
+	info, _ := rawExprs.LoadOrStore(e.Expression(), &rawExprInfo{jq: e.jq, err: parseJQ(e.jq)})
+	entry := info.(*rawExprInfo)
+	entry.mu.Lock()
+	for _, dep := range e.deps {
+		if dep != nil && !slices.Contains(entry.deps, dep) {
+			entry.deps = append(entry.deps, dep)
+		}
+	}
+	entry.mu.Unlock()
+
+	return e
+}
+
+// Expression returns the expression wrapped in "${ }".
+// Implements the Ref interface.
+func (e RawExpr) Expression() string {
+	return fmt.Sprintf("${ %s }", e.jq)
+}
+
+// Name returns the JQ expression.
+// Implements the Ref interface.
+func (e RawExpr) Name() string {
+	return e.jq
+}
+
+// IsComputed reports that the expression is evaluated at runtime.
+func (e RawExpr) IsComputed() bool {
+	return true
+}
+
+// JQ returns the expression without the "${ }" wrapper.
+func (e RawExpr) JQ() string {
+	return e.jq
+}
+
+// Deps returns the tasks the expression was declared to read.
+func (e RawExpr) Deps() []*Task {
+	return slices.Clone(e.deps)
+}
+
+// unwrapExpression strips the "${ }" wrapper from an expression.
+func unwrapExpression(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if inner, ok := strings.CutPrefix(expr, "${"); ok {
+		if inner, ok := strings.CutSuffix(inner, "}"); ok {
+			return strings.TrimSpace(inner)
+		}
+	}
+	return expr
+}
+
+// parseJQ checks the syntax of a JQ expression. Parse errors report the
+// 1-based column at which parsing failed.
+func parseJQ(jq string) error {
+	_, err := gojq.Parse(jq)
+	if err == nil {
+		return nil
+	}
+	var parseErr *gojq.ParseError
+	if errors.As(err, &parseErr) {
+		// Offset is the end of the offending token
+		return fmt.Errorf("%w at column %d", err, parseErr.Offset-len(parseErr.Token)+1)
+	}
+	return err
+}
+
+// rawExpressionsIn returns the raw expressions contained in rendered,
+// sorted by expression.
+func rawExpressionsIn(rendered string) []*rawExprInfo {
+	if !strings.Contains(rendered, "${") {
+		return nil
+	}
+	var found []*rawExprInfo
+	rawExprs.Range(func(key, value any) bool {
+		if strings.Contains(rendered, key.(string)) {
+			found = append(found, value.(*rawExprInfo))
+		}
+		return true
+	})
+	slices.SortFunc(found, func(a, b *rawExprInfo) int { return strings.Compare(a.jq, b.jq) })
+	return found
+}
+
+// rawExprDeps returns the names of the tasks of w the raw expressions
+// contained in rendered were declared to read. The same expression may be
+// created for several workflows, so tasks of other workflows are skipped.
+func rawExprDeps(w *Workflow, rendered string) []string {
+	var names []string
+	for _, info := range rawExpressionsIn(rendered) {
+		info.mu.Lock()
+		for _, dep := range info.deps {
+			if dep.workflow == w {
+				names = append(names, dep.Name)
+			}
+		}
+		info.mu.Unlock()
+	}
+	return names
+}
+
+// validateRawExpressions checks that the raw expressions used by tasks parse.
+func validateRawExpressions(tasks []*Task) error {
+	for i, task := range tasks {
+		rendered, err := task.renderedConfig()
+		if err != nil {
+			// Conversion errors are reported by synthesis itself
+			continue
+		}
+
+		for _, info := range rawExpressionsIn(rendered) {
+			if info.err != nil {
+				return validation.NewValidationErrorWithCause(
+					validation.FieldPath("tasks", i),
+					info.jq,
+					"jq",
+					fmt.Sprintf("task %q uses an invalid JQ expression %q: %v", task.Name, info.jq, info.err),
+					ErrInvalidExpression,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// checkRawExpression reports tasks using raw JQ expressions created with Expr.
+func checkRawExpression(w *Workflow) []LintFinding {
+	w.mu.Lock()
+	tasks := slices.Clone(w.Tasks)
+	w.mu.Unlock()
+
+	exprs := w.RawExpressions()
+	var findings []LintFinding
+	for _, task := range tasks {
+		for _, jq := range exprs[task.Name] {
+			findings = append(findings, LintFinding{
+				Task:    task.Name,
+				Message: fmt.Sprintf("uses raw JQ expression %q; check it by hand, it is not type-checked", jq),
+			})
+		}
+	}
+	return findings
+}
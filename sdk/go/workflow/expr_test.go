@@ -0,0 +1,114 @@
+package workflow
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpr(t *testing.T) {
+	wf, err := New(nil, "ops/sync", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	fetch := wf.HttpGet("fetch", "https://api.example.com/items", nil)
+	ids := Expr(`${ [.items[].id] | join(",") }`, fetch)
+	wf.SetVars("ids", "ids", ids)
+	wf.HttpPost("notify", "https://hooks.example.com", nil, map[string]interface{}{"ids": ids})
+
+	if got := ids.Expression(); got != `${ [.items[].id] | join(",") }` {
+		t.Errorf("Expression() = %q", got)
+	}
+	if got := Expr(`[.items[].id] | join(",")`).Expression(); got != ids.Expression() {
+		t.Errorf("unwrapped expression renders as %q, want %q", got, ids.Expression())
+	}
+
+	deps := wf.Dependencies()
+	if !reflect.DeepEqual(deps["ids"], []string{"fetch"}) || !reflect.DeepEqual(deps["notify"], []string{"fetch"}) {
+		t.Errorf("Dependencies() = %v, want ids and notify to depend on fetch", deps)
+	}
+
+	// The edge follows Rename
+	if err := fetch.Rename("fetchItems"); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if got := wf.Dependencies()["ids"]; !reflect.DeepEqual(got, []string{"fetchItems"}) {
+		t.Errorf("Dependencies()[ids] after rename = %v", got)
+	}
+
+	raw := wf.RawExpressions()
+	want := map[string][]string{
+		"ids":    {`[.items[].id] | join(",")`},
+		"notify": {`[.items[].id] | join(",")`},
+	}
+	if !reflect.DeepEqual(raw, want) {
+		t.Errorf("RawExpressions() = %v, want %v", raw, want)
+	}
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	set := proto.GetSpec().GetTasks()[1].GetTaskConfig().AsMap()
+	if got := set["variables"].(map[string]any)["ids"]; got != ids.Expression() {
+		t.Errorf("ids variable = %v, want %q", got, ids.Expression())
+	}
+}
+
+func TestExpr_DepsScopedToWorkflow(t *testing.T) {
+	other, err := New(nil, "ops/other", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	otherFetch := other.HttpGet("fetch", "https://api.example.com/items", nil)
+	Expr(`.count + 1`, otherFetch)
+
+	wf, err := New(nil, "ops/sync", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.HttpGet("fetch", "https://api.example.com/items", nil)
+	wf.SetVars("next", "count", Expr(`.count + 1`))
+
+	if got := wf.Dependencies()["next"]; len(got) != 0 {
+		t.Errorf("Dependencies()[next] = %v, want none", got)
+	}
+}
+
+func TestExpr_InvalidSyntax(t *testing.T) {
+	wf, err := New(nil, "ops/sync", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.SetVars("ids", "ids", Expr(`.items[] | | length`))
+
+	_, err = wf.ToProto()
+	if !errors.Is(err, ErrInvalidExpression) {
+		t.Fatalf("ToProto() error = %v, want ErrInvalidExpression", err)
+	}
+	for _, want := range []string{`task "ids"`, `unexpected token "|" at column 12`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
+
+func TestExpr_Lint(t *testing.T) {
+	wf, err := New(nil, "ops/sync", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	fetch := wf.HttpGet("fetch", "https://api.example.com/items", nil)
+	wf.SetVars("ids", "ids", Expr(`[.items[].id] | length`, fetch))
+
+	var findings []LintFinding
+	for _, f := range wf.Lint() {
+		if f.RuleID == LintRuleRawExpression {
+			findings = append(findings, f)
+		}
+	}
+	if len(findings) != 1 || findings[0].Task != "ids" || !strings.Contains(findings[0].Message, "[.items[].id] | length") {
+		t.Errorf("raw-expression findings = %v, want one for task ids", findings)
+	}
+}
@@ -20,7 +20,7 @@ func TestLoopBody_DefaultVariable(t *testing.T) {
 				Name: "processItem",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"id":   item.Field("id"),
 						"name": item.Field("name"),
 					},
@@ -81,7 +81,7 @@ func TestLoopBody_CustomVariable(t *testing.T) {
 				Name: "processUser",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"userId": user.Field("id"),
 					},
 				},
@@ -120,7 +120,7 @@ func TestLoopBody_NestedFieldAccess(t *testing.T) {
 				Name: "processNested",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						// Nested access using string concatenation
 						"nestedId": "${.item.user.id}",
 						"deepPath": "${.item.data.attributes.value}",
@@ -159,7 +159,7 @@ func TestLoopBody_ItemValue(t *testing.T) {
 				Name: "useWholeItem",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"currentItem": item.Value(),
 					},
 				},
@@ -192,7 +192,7 @@ func TestLoopBody_MultipleTasks(t *testing.T) {
 				Name: "task1",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"step": "1",
 						"id":   item.Field("id"),
 					},
@@ -209,7 +209,7 @@ func TestLoopBody_MultipleTasks(t *testing.T) {
 				Name: "task3",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"step":   "3",
 						"result": item.Field("result"),
 					},
@@ -260,7 +260,7 @@ func TestLoopBody_WithComplexTaskTypes(t *testing.T) {
 				Name: "setTask",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"processedId": item.Field("id"),
 					},
 				},
@@ -304,13 +304,7 @@ func TestSmartTypeConversion_ForTaskConfig_String(t *testing.T) {
 		In: "$.data.items", // Plain string
 	}
 
-	// Verify type is correct (interface{} can hold string)
-	if config.In == nil {
-		t.Fatal("In field should not be nil")
-	}
-
-	// Verify CoerceToString handles it correctly
-	result := CoerceToString(config.In)
+	result := config.InExpression()
 	if result != "$.data.items" {
 		t.Errorf("Expected '$.data.items', got %q", result)
 	}
@@ -325,58 +319,41 @@ func TestSmartTypeConversion_ForTaskConfig_TaskFieldRef(t *testing.T) {
 	}
 
 	config := &ForTaskConfig{
-		In: taskRef, // TaskFieldRef (implements Expression() string)
-	}
-
-	// Verify type is correct (interface{} can hold TaskFieldRef)
-	if config.In == nil {
-		t.Fatal("In field should not be nil")
+		InRef: taskRef, // TaskFieldRef (implements StringExpr)
 	}
 
-	// Verify CoerceToString handles it correctly
-	result := CoerceToString(config.In)
+	result := config.InExpression()
 	expected := `${ $context["fetchTask"].items }`
 	if result != expected {
 		t.Errorf("Expected %q, got %q", expected, result)
 	}
 }
 
-// TestSmartTypeConversion_HttpCallTaskConfig tests URI field accepts both string and TaskFieldRef.
+// TestSmartTypeConversion_HttpCallTaskConfig tests the URI accepts both a string and a TaskFieldRef.
 func TestSmartTypeConversion_HttpCallTaskConfig(t *testing.T) {
 	tests := []struct {
 		name     string
-		uri      interface{}
+		endpoint *types.HttpEndpoint
 		expected string
 	}{
 		{
 			name:     "plain string URI",
-			uri:      "https://api.example.com/data",
+			endpoint: &types.HttpEndpoint{Uri: "https://api.example.com/data"},
 			expected: "https://api.example.com/data",
 		},
 		{
 			name: "TaskFieldRef URI",
-			uri: TaskFieldRef{
+			endpoint: &types.HttpEndpoint{UriRef: TaskFieldRef{
 				taskName:  "baseTask",
 				fieldName: "endpoint",
-			},
+			}},
 			expected: `${ $context["baseTask"].endpoint }`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create endpoint with URI (interface{} field)
-			endpoint := &types.HttpEndpoint{
-				Uri: tt.uri,
-			}
-
-			// Verify the URI field accepts both types
-			if endpoint.Uri == nil {
-				t.Fatal("URI should not be nil")
-			}
-
-			// Verify CoerceToString handles conversion correctly
-			result := CoerceToString(endpoint.Uri)
+			result := tt.endpoint.UriExpression()
 			if result != tt.expected {
 				t.Errorf("Expected %q, got %q", tt.expected, result)
 			}
@@ -387,9 +364,10 @@ func TestSmartTypeConversion_HttpCallTaskConfig(t *testing.T) {
 // TestSmartTypeConversion_AgentCallTaskConfig tests Message field conversion.
 func TestSmartTypeConversion_AgentCallTaskConfig(t *testing.T) {
 	tests := []struct {
-		name     string
-		message  interface{}
-		expected string
+		name       string
+		message    string
+		messageRef StringExpr
+		expected   string
 	}{
 		{
 			name:     "plain string message",
@@ -398,7 +376,7 @@ func TestSmartTypeConversion_AgentCallTaskConfig(t *testing.T) {
 		},
 		{
 			name: "TaskFieldRef message",
-			message: TaskFieldRef{
+			messageRef: TaskFieldRef{
 				taskName:  "inputTask",
 				fieldName: "prompt",
 			},
@@ -409,8 +387,9 @@ func TestSmartTypeConversion_AgentCallTaskConfig(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			config := &AgentCallTaskConfig{
-				Agent:   "test-agent",
-				Message: tt.message,
+				Agent:      "test-agent",
+				Message:    tt.message,
+				MessageRef: tt.messageRef,
 			}
 
 			proto, err := config.ToProto()
@@ -440,8 +419,8 @@ func TestSmartTypeConversion_RaiseTaskConfig(t *testing.T) {
 	}
 
 	config := &RaiseTaskConfig{
-		Error:   errorRef, // TaskFieldRef
-		Message: "Custom error message",
+		ErrorRef: errorRef, // TaskFieldRef
+		Message:  "Custom error message",
 	}
 
 	proto, err := config.ToProto()
@@ -523,68 +502,14 @@ func TestLoopBody_NilTasks(t *testing.T) {
 	}
 }
 
-// TestCoerceToString_VariousTypes tests the CoerceToString helper with different types.
-func TestCoerceToString_VariousTypes(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    interface{}
-		expected string
-	}{
-		{
-			name:     "string input",
-			input:    "hello world",
-			expected: "hello world",
-		},
-		{
-			name: "TaskFieldRef input",
-			input: TaskFieldRef{
-				taskName:  "task1",
-				fieldName: "output",
-			},
-			expected: `${ $context["task1"].output }`,
-		},
-		{
-			name:     "number input",
-			input:    42,
-			expected: "42",
-		},
-		{
-			name:     "boolean input",
-			input:    true,
-			expected: "true",
-		},
-		{
-			name:     "float input",
-			input:    3.14,
-			expected: "3.14",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := CoerceToString(tt.input)
-			if result != tt.expected {
-				t.Errorf("Expected %q, got %q", tt.expected, result)
-			}
-		})
-	}
-}
-
-// TestForTaskConfig_NilIn tests ForTaskConfig with nil In field.
+// TestForTaskConfig_NilIn tests ForTaskConfig with neither In nor InRef set.
 func TestForTaskConfig_NilIn(t *testing.T) {
 	config := &ForTaskConfig{
-		In: nil, // Nil input
-	}
-
-	// Verify nil is handled correctly
-	if config.In != nil {
-		t.Error("In field should be nil")
+		InRef: nil, // Nil input
 	}
 
-	// Verify CoerceToString handles nil (though this shouldn't happen in practice)
-	result := CoerceToString(config.In)
-	if result != "<nil>" {
-		t.Logf("CoerceToString(nil) returned: %q", result)
+	if result := config.InExpression(); result != "" {
+		t.Errorf("Expected empty string, got %q", result)
 	}
 }
 
@@ -599,8 +524,7 @@ func TestForTaskConfig_EmptyString(t *testing.T) {
 		t.Error("In field should be empty string")
 	}
 
-	// Verify CoerceToString handles empty string
-	result := CoerceToString(config.In)
+	result := config.InExpression()
 	if result != "" {
 		t.Errorf("Expected empty string, got %q", result)
 	}
@@ -620,8 +544,8 @@ func TestForTaskIntegration(t *testing.T) {
 
 	// Create FOR task with LoopBody
 	forConfig := &ForTaskConfig{
-		Each: "item",
-		In:   fetchTask, // Using TaskFieldRef - smart conversion
+		Each:  "item",
+		InRef: fetchTask, // Using TaskFieldRef
 		Do: LoopBody(func(item LoopVar) []*Task {
 			return []*Task{
 				{
@@ -647,12 +571,9 @@ func TestForTaskIntegration(t *testing.T) {
 		t.Errorf("Expected each='item', got %q", forConfig.Each)
 	}
 
-	// Verify In field accepts TaskFieldRef (smart conversion)
-	if forConfig.In == nil {
-		t.Fatal("In field should not be nil")
-	}
+	// Verify InRef accepts TaskFieldRef
 	expectedIn := `${ $context["fetchItems"].data }`
-	actualIn := CoerceToString(forConfig.In)
+	actualIn := forConfig.InExpression()
 	if actualIn != expectedIn {
 		t.Errorf("Expected in=%q, got %q", expectedIn, actualIn)
 	}
@@ -712,25 +633,20 @@ func TestBackwardCompatibility_ExpressionStillWorks(t *testing.T) {
 		In: taskRef.Expression(), // Explicit .Expression() call returns string
 	}
 
-	// Verify the In field accepts string (backward compatibility)
-	if config.In == nil {
-		t.Fatal("In field should not be nil")
-	}
-
 	// Verify the expression is correct
 	expected := `${ $context["fetchTask"].items }`
-	actual := CoerceToString(config.In)
+	actual := config.InExpression()
 	if actual != expected {
 		t.Errorf("Expected %q, got %q", expected, actual)
 	}
 
 	// Also verify both approaches produce the same result
 	config2 := &ForTaskConfig{
-		In: taskRef, // New way: without .Expression()
+		InRef: taskRef, // New way: without .Expression()
 	}
 
-	result1 := CoerceToString(config.In)  // Old way (string)
-	result2 := CoerceToString(config2.In) // New way (TaskFieldRef)
+	result1 := config.InExpression()  // Old way (string)
+	result2 := config2.InExpression() // New way (TaskFieldRef)
 
 	if result1 != result2 {
 		t.Errorf("Old and new approaches should produce same result. Got %q vs %q", result1, result2)
@@ -886,7 +802,7 @@ func TestLoopBody_PanicOnError(t *testing.T) {
 				Name: "normalTask",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{"x": "y"},
+					Variables: map[string]interface{}{"x": "y"},
 				},
 			},
 		}
@@ -911,7 +827,7 @@ func TestLoopBody_LargeTaskList(t *testing.T) {
 				Name: "task_" + strings.Repeat("x", i%10),
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"index": item.Field("id"),
 					},
 				},
@@ -83,7 +83,7 @@ func (v LoopVar) Value() string {
 // Example:
 //
 //	wf.ForEach("processItems", &workflow.ForArgs{
-//	    In: fetchTask.Field("items"),
+//	    InRef: fetchTask.Field("items"),
 //	    Do: workflow.LoopBody(func(item workflow.LoopVar) []*workflow.Task {
 //	        return []*workflow.Task{
 //	            wf.HttpPost("processItem",
@@ -113,11 +113,11 @@ func (v LoopVar) Value() string {
 //
 //	wf.ForEach("processUsers", &workflow.ForArgs{
 //	    Each: "user",  // Custom variable name
-//	    In: fetchTask.Field("users"),
+//	    InRef: fetchTask.Field("users"),
 //	    Do: workflow.LoopBody(func(user workflow.LoopVar) []*workflow.Task {
 //	        return []*workflow.Task{
 //	            wf.Set("processUser", &workflow.SetArgs{
-//	                Variables: map[string]string{
+//	                Variables: map[string]interface{}{
 //	                    "userId": user.Field("id"),  // References ${.user.id}
 //	                },
 //	            }),
@@ -129,9 +129,11 @@ func LoopBody(fn func(LoopVar) []*Task) []*types.WorkflowTask {
 	loopVar := LoopVar{varName: "item"}
 
 	// Call user's function to get typed tasks
-	tasks := fn(loopVar)
+	return loopTasks(fn(loopVar))
+}
 
-	// Convert SDK tasks to types.WorkflowTask format
+// loopTasks converts SDK tasks to the types.WorkflowTask format of a loop body.
+func loopTasks(tasks []*Task) []*types.WorkflowTask {
 	workflowTasks := make([]*types.WorkflowTask, 0, len(tasks))
 	for _, task := range tasks {
 		taskMap, err := taskToMap(task)
@@ -164,6 +166,21 @@ func LoopBody(fn func(LoopVar) []*Task) []*types.WorkflowTask {
 			wfTask.Flow = &types.FlowControl{Then: thenVal}
 		}
 
+		// Extract execution timeout if present
+		if timeoutSeconds, ok := taskMap["executionTimeoutSeconds"].(int32); ok {
+			wfTask.ExecutionTimeoutSeconds = timeoutSeconds
+		}
+
+		// Extract sensitive output fields if present
+		if fields, ok := taskMap["sensitiveOutputFields"].([]string); ok {
+			wfTask.SensitiveOutputFields = fields
+		}
+
+		// Extract description if present
+		if description, ok := taskMap["description"].(string); ok {
+			wfTask.Description = description
+		}
+
 		workflowTasks = append(workflowTasks, wfTask)
 	}
 
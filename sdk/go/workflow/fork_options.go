@@ -1,6 +1,10 @@
 package workflow
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
 )
 
@@ -24,6 +28,22 @@ type ForkArgs = ForkTaskConfig
 //	        },
 //	    },
 //	})
+//
+// Branch error handling:
+//
+// By default a failing branch fails the whole fork. Set ContinueOnBranchError
+// to record the failure in the branch output instead, then check each outcome
+// with Branch(name).Succeeded() and Branch(name).Error():
+//
+//	fork := wf.Fork("gather", &workflow.ForkArgs{
+//	    Branches:              workflow.ForkBranches(users, posts),
+//	    ContinueOnBranchError: true,
+//	})
+//	wf.Switch("merge", &workflow.SwitchArgs{
+//	    Cases: []*types.SwitchCase{
+//	        {Name: "postsFailed", When: fork.Branch("posts").Succeeded().Not().Expression(), Then: "usersOnly"},
+//	    },
+//	})
 func Fork(name string, args *ForkArgs) *Task {
 	if args == nil {
 		args = &ForkArgs{}
@@ -79,11 +99,156 @@ func (b BranchResult) Value() string {
 	return "${." + b.taskName + ".branches." + b.branchName + "}"
 }
 
+// Succeeded returns a condition that is true unless the branch failed.
+// Only meaningful on forks with ContinueOnBranchError, where a failing
+// branch records {"succeeded": false, "error": {...}} as its output.
+//
+// Example:
+//
+//	forkTask.Branch("fetchUsers").Succeeded()
+//	// -> "${ ($context["forkTask"].fetchUsers.succeeded != false) }"
+func (b BranchResult) Succeeded() BranchStatusRef {
+	return BranchStatusRef{taskName: b.taskName, branchName: b.branchName}
+}
+
+// Error returns a reference to the error recorded by a failed branch
+// ({"message": ...}), or null when the branch succeeded.
+// Only meaningful on forks with ContinueOnBranchError.
+//
+// Example:
+//
+//	forkTask.Branch("fetchUsers").Error()
+//	// -> "${ $context["forkTask"].fetchUsers.error }"
+func (b BranchResult) Error() TaskFieldRef {
+	return TaskFieldRef{
+		taskName:  b.taskName,
+		fieldName: b.branchName + ".error",
+	}
+}
+
+// BranchStatusRef is a boolean reference to the outcome of a fork branch.
+// It implements Ref and ConditionMatcher, so it can be used directly as a
+// switch case condition.
+type BranchStatusRef struct {
+	taskName   string
+	branchName string
+	negated    bool
+}
+
+// Expression returns the JQ condition for the branch outcome.
+func (r BranchStatusRef) Expression() string {
+	op := "!="
+	if r.negated {
+		op = "=="
+	}
+	return fmt.Sprintf("${ ($context[\"%s\"].%s.succeeded %s false) }", r.taskName, r.branchName, op)
+}
+
+// Name returns a human-readable name for this reference.
+func (r BranchStatusRef) Name() string {
+	return fmt.Sprintf("%s.%s.succeeded", r.taskName, r.branchName)
+}
+
+// TaskName returns the name of the fork task.
+func (r BranchStatusRef) TaskName() string {
+	return r.taskName
+}
+
+// Not returns the inverse condition (true when the branch failed).
+func (r BranchStatusRef) Not() BranchStatusRef {
+	r.negated = !r.negated
+	return r
+}
+
 // Branch returns a reference to a specific branch's result.
+// Like Field(), this exports the fork task's output to the workflow context
+// so the branch outcome refs can be evaluated by later tasks.
+//
+// The branch name is checked against the fork's branches during synthesis,
+// so branches may still be added after Branch is called. A name that does
+// not match fails synthesis with ErrUnknownForkBranch.
 //
 // Example:
 //
 //	forkTask.Branch("fetchUsers").Field("data")
 func (t *Task) Branch(branchName string) BranchResult {
+	if t.ExportAs == "" {
+		t.ExportAs = "${.}"
+	}
+	if !slices.Contains(t.referencedBranches, branchName) {
+		t.referencedBranches = append(t.referencedBranches, branchName)
+	}
 	return NewBranchResult(t.Name, branchName)
 }
+
+// validateBranchRefs reports branch names passed to Branch that the task
+// does not have, suggesting the closest existing branch.
+func (t *Task) validateBranchRefs() error {
+	if len(t.referencedBranches) == 0 {
+		return nil
+	}
+
+	config, ok := t.Config.(*ForkTaskConfig)
+	if t.Kind != TaskKindFork || !ok {
+		return NewValidationErrorWithCause(
+			"branch",
+			t.referencedBranches[0],
+			"fork",
+			fmt.Sprintf("task %q is not a fork task and has no branches", t.Name),
+			ErrUnknownForkBranch,
+		)
+	}
+
+	available := make([]string, 0, len(config.Branches))
+	for _, branch := range config.Branches {
+		if branch != nil {
+			available = append(available, branch.Name)
+		}
+	}
+
+	for _, name := range t.referencedBranches {
+		if slices.Contains(available, name) {
+			continue
+		}
+		message := fmt.Sprintf("fork %q has no branch %q", t.Name, name)
+		if suggestion := closestName(name, available); suggestion != "" {
+			message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+		}
+		message += fmt.Sprintf("; available: [%s]", strings.Join(available, ", "))
+		return NewValidationErrorWithCause("branch", name, "exists", message, ErrUnknownForkBranch)
+	}
+	return nil
+}
+
+// closestName returns the candidate with the smallest edit distance to name,
+// or "" when none is close enough to be a likely typo.
+func closestName(name string, candidates []string) string {
+	best, bestDistance := "", len(name)/2+1
+	for _, candidate := range candidates {
+		if d := editDistance(name, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,88 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newFetchFork() *Task {
+	return Fork("parallel-fetch", &ForkArgs{
+		Branches: ForkBranches(
+			ForkBranch("fetch-posts", HttpGet("getPosts", "https://api.example.com/posts", nil)),
+			ForkBranch("fetch-todos", HttpGet("getTodos", "https://api.example.com/todos", nil)),
+			ForkBranch("fetch-albums", HttpGet("getAlbums", "https://api.example.com/albums", nil)),
+		),
+	})
+}
+
+func TestTaskBranch_Valid(t *testing.T) {
+	fork := newFetchFork()
+	fork.Branch("fetch-posts")
+	fork.Branch("fetch-albums")
+
+	if _, err := convertTasks([]*Task{fork}); err != nil {
+		t.Errorf("convertTasks() error = %v", err)
+	}
+}
+
+func TestTaskBranch_Typo(t *testing.T) {
+	fork := newFetchFork()
+	fork.Branch("fetch-post")
+
+	_, err := convertTasks([]*Task{fork})
+	if !errors.Is(err, ErrUnknownForkBranch) {
+		t.Fatalf("convertTasks() error = %v, want ErrUnknownForkBranch", err)
+	}
+	for _, want := range []string{
+		`fork "parallel-fetch" has no branch "fetch-post"`,
+		`did you mean "fetch-posts"?`,
+		"available: [fetch-posts, fetch-todos, fetch-albums]",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
+
+func TestTaskBranch_NoSuggestion(t *testing.T) {
+	fork := newFetchFork()
+	fork.Branch("comments")
+
+	_, err := convertTasks([]*Task{fork})
+	if !errors.Is(err, ErrUnknownForkBranch) {
+		t.Fatalf("convertTasks() error = %v, want ErrUnknownForkBranch", err)
+	}
+	if strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("error %q suggests a branch for an unrelated name", err)
+	}
+}
+
+func TestTaskBranch_DynamicBranches(t *testing.T) {
+	fork := Fork("per-region", &ForkArgs{})
+	refs := []BranchResult{}
+	for _, region := range []string{"us", "eu", "ap"} {
+		name := "region-" + region
+		// Branch is called before the branch is added
+		refs = append(refs, fork.Branch(name))
+		config := fork.Config.(*ForkTaskConfig)
+		config.Branches = append(config.Branches,
+			ForkBranch(name, HttpGet("fetch-"+region, "https://"+region+".example.com/status", nil)))
+	}
+
+	if got, want := refs[1].Value(), "${.per-region.branches.region-eu}"; got != want {
+		t.Errorf("Value() = %q, want %q", got, want)
+	}
+	if _, err := convertTasks([]*Task{fork}); err != nil {
+		t.Errorf("convertTasks() error = %v", err)
+	}
+}
+
+func TestTaskBranch_NotAFork(t *testing.T) {
+	task := HttpGet("fetch", "https://api.example.com/posts", nil)
+	task.Branch("posts")
+
+	if _, err := convertTasks([]*Task{task}); !errors.Is(err, ErrUnknownForkBranch) {
+		t.Errorf("convertTasks() error = %v, want ErrUnknownForkBranch", err)
+	}
+}
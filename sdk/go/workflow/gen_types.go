@@ -4,7 +4,10 @@
 package workflow
 
 import (
+	"fmt"
+
 	genWorkflow "github.com/stigmer/stigmer/sdk/go/gen/workflow"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // Type aliases for generated task configs
@@ -23,3 +26,24 @@ type (
 	TryTaskConfig          = genWorkflow.TryTaskConfig
 	WaitTaskConfig         = genWorkflow.WaitTaskConfig
 )
+
+// NewTaskConfigForKind returns a zero-valued config for the given task kind,
+// using the generated kind registry.
+//
+// Example:
+//
+//	config, err := workflow.NewTaskConfigForKind(workflow.TaskKindHttpCall)
+//	// config is *HttpCallTaskConfig
+func NewTaskConfigForKind(kind TaskKind) (TaskConfig, error) {
+	factory, ok := genWorkflow.LookupTaskConfig(string(kind))
+	if !ok {
+		return nil, fmt.Errorf("unknown task kind: %s", kind)
+	}
+	return factory(), nil
+}
+
+// TaskConfigFromStruct decodes a manifest task config (google.protobuf.Struct)
+// into the typed config registered for the given task kind.
+func TaskConfigFromStruct(kind TaskKind, s *structpb.Struct) (TaskConfig, error) {
+	return genWorkflow.TaskConfigFromProto(string(kind), s)
+}
@@ -1,5 +1,16 @@
 package workflow
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
 // GrpcCallArgs is an alias for GrpcCallTaskConfig (Pulumi-style args pattern).
 type GrpcCallArgs = GrpcCallTaskConfig
 
@@ -13,7 +24,18 @@ type GrpcCallArgs = GrpcCallTaskConfig
 //	    Method:  "GetUser",
 //	    Request: map[string]interface{}{"id": "123"},
 //	})
-func GrpcCall(name string, args *GrpcCallArgs) *Task {
+//
+// Options are applied after the args. GrpcField overrides are applied last,
+// after the request is built:
+//
+//	task := workflow.GrpcCall("getUser", &workflow.GrpcCallArgs{
+//	    Service: "acme.user.v1.UserService",
+//	    Method:  "GetUser",
+//	},
+//	    workflow.GrpcRequestFromProto(&userv1.GetUserRequest{IncludeProfile: true}),
+//	    workflow.GrpcField("user_id", lookupTask.Field("id")),
+//	)
+func GrpcCall(name string, args *GrpcCallArgs, opts ...GrpcOption) *Task {
 	if args == nil {
 		args = &GrpcCallArgs{}
 	}
@@ -23,9 +45,212 @@ func GrpcCall(name string, args *GrpcCallArgs) *Task {
 		args.Request = make(map[string]interface{})
 	}
 
-	return &Task{
+	task := &Task{
 		Name:   name,
 		Kind:   TaskKindGrpcCall,
 		Config: args,
 	}
+	for _, opt := range opts {
+		opt.applyGrpc(task, args)
+	}
+	for _, field := range task.grpcFields {
+		setRequestField(args.Request, field.path, field.value)
+	}
+	return task
+}
+
+// GrpcOption configures a GRPC_CALL task. GrpcRequestFromProto and
+// GrpcField are GrpcOptions.
+type GrpcOption interface {
+	applyGrpc(t *Task, cfg *GrpcCallTaskConfig)
+}
+
+// grpcOptionFunc adapts a function to GrpcOption.
+type grpcOptionFunc func(t *Task, cfg *GrpcCallTaskConfig)
+
+func (f grpcOptionFunc) applyGrpc(t *Task, cfg *GrpcCallTaskConfig) {
+	f(t, cfg)
+}
+
+// grpcFieldOverride is a request field set with GrpcField.
+type grpcFieldOverride struct {
+	path  string
+	value interface{}
+}
+
+// GrpcRequestFromProto sets the request of a GRPC_CALL task from a generated
+// request message, replacing the request given in the args.
+//
+// The message is serialized with protojson using the field names of the
+// .proto file (e.g. "user_id", not "userId"). Unset fields are omitted.
+//
+// At synthesis, when the service is registered in this process (its
+// generated Go package is linked in), the message type must be the input
+// type of the declared method.
+//
+// Example:
+//
+//	workflow.GrpcRequestFromProto(&userv1.GetUserRequest{UserId: "42"})
+func GrpcRequestFromProto(msg proto.Message) GrpcOption {
+	return grpcOptionFunc(func(t *Task, cfg *GrpcCallTaskConfig) {
+		t.grpcRequestType = ""
+		t.grpcRequestErr = ""
+		if msg == nil {
+			t.grpcRequestErr = "request message is nil"
+			return
+		}
+
+		data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+		if err != nil {
+			t.grpcRequestErr = fmt.Sprintf("failed to serialize %s: %v", msg.ProtoReflect().Descriptor().FullName(), err)
+			return
+		}
+		request := make(map[string]interface{})
+		if err := json.Unmarshal(data, &request); err != nil {
+			t.grpcRequestErr = fmt.Sprintf("failed to serialize %s: %v", msg.ProtoReflect().Descriptor().FullName(), err)
+			return
+		}
+
+		cfg.Request = request
+		t.grpcRequestType = msg.ProtoReflect().Descriptor().FullName()
+	})
+}
+
+// GrpcField sets a field of the request of a GRPC_CALL task, typically to a
+// task output reference. Fields are set after the request is built, so they
+// override fields of GrpcRequestFromProto messages.
+//
+// path is the field name as written in the .proto file; nested fields are
+// separated by dots ("filter.owner_id"). With GrpcRequestFromProto, the path
+// is checked against the message type at synthesis.
+//
+// Example:
+//
+//	workflow.GrpcField("user_id", lookupTask.Field("id"))
+func GrpcField(path string, value interface{}) GrpcOption {
+	return grpcOptionFunc(func(t *Task, _ *GrpcCallTaskConfig) {
+		t.grpcFields = append(t.grpcFields, grpcFieldOverride{path: path, value: value})
+	})
+}
+
+// setRequestField sets the value at a dotted path of request, creating
+// intermediate objects as needed.
+func setRequestField(request map[string]interface{}, path string, value interface{}) {
+	parts := strings.Split(path, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := request[part].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			request[part] = next
+		}
+		request = next
+	}
+	request[parts[len(parts)-1]] = value
+}
+
+// validateGrpcRequest checks a request built with GrpcRequestFromProto: it
+// must have been serialized, its type must be the input type of the declared
+// method when the service is registered locally, and GrpcField paths must
+// name fields of the message.
+func (t *Task) validateGrpcRequest() error {
+	cfg, ok := t.Config.(*GrpcCallTaskConfig)
+	if !ok {
+		return nil
+	}
+
+	if t.grpcRequestErr != "" {
+		return NewValidationErrorWithCause(
+			"request",
+			"",
+			"proto",
+			fmt.Sprintf("task %q: %s", t.Name, t.grpcRequestErr),
+			ErrInvalidGrpcRequest,
+		)
+	}
+
+	for _, field := range t.grpcFields {
+		if field.path == "" || strings.Contains(field.path, "..") ||
+			strings.HasPrefix(field.path, ".") || strings.HasSuffix(field.path, ".") {
+			return NewValidationErrorWithCause(
+				"request",
+				field.path,
+				"field_path",
+				fmt.Sprintf("task %q: invalid request field path %q", t.Name, field.path),
+				ErrInvalidGrpcRequest,
+			)
+		}
+	}
+
+	if t.grpcRequestType == "" {
+		return nil
+	}
+
+	if input, ok := grpcMethodInput(cfg.Service, cfg.Method); ok && input.FullName() != t.grpcRequestType {
+		return NewValidationErrorWithCause(
+			"request",
+			string(t.grpcRequestType),
+			"input_type",
+			fmt.Sprintf("task %q: request is a %s, but %s/%s takes a %s",
+				t.Name, t.grpcRequestType, cfg.Service, cfg.Method, input.FullName()),
+			ErrInvalidGrpcRequest,
+		)
+	}
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(t.grpcRequestType)
+	if err != nil {
+		return nil
+	}
+	md, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil
+	}
+	for _, field := range t.grpcFields {
+		if err := checkFieldPath(md, field.path); err != nil {
+			return NewValidationErrorWithCause(
+				"request",
+				field.path,
+				"field_path",
+				fmt.Sprintf("task %q: GrpcField %q: %v", t.Name, field.path, err),
+				ErrInvalidGrpcRequest,
+			)
+		}
+	}
+	return nil
+}
+
+// grpcMethodInput returns the input type of a method of a service registered
+// in this process.
+func grpcMethodInput(service, method string) (protoreflect.MessageDescriptor, bool) {
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, false
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, false
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, false
+	}
+	return md.Input(), true
+}
+
+// checkFieldPath checks that a dotted path names a field of md, descending
+// into message fields.
+func checkFieldPath(md protoreflect.MessageDescriptor, path string) error {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		fd := md.Fields().ByName(protoreflect.Name(part))
+		if fd == nil {
+			return fmt.Errorf("%s has no field %q", md.FullName(), part)
+		}
+		if i < len(parts)-1 {
+			if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+				return fmt.Errorf("field %q of %s is not a message", part, md.FullName())
+			}
+			md = fd.Message()
+		}
+	}
+	return nil
 }
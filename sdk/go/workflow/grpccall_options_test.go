@@ -0,0 +1,177 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+)
+
+// Services of the stigmer API are registered by the stubs linked into the test.
+const (
+	testGrpcService = "ai.stigmer.agentic.workflow.v1.WorkflowQueryController"
+	testGrpcMethod  = "getByReference"
+)
+
+func TestGrpcRequestFromProto_SerializesWithProtoNames(t *testing.T) {
+	wf, err := New(nil, "users/lookup", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	wf.GrpcCall("getWorkflow", &GrpcCallArgs{
+		Service: testGrpcService,
+		Method:  testGrpcMethod,
+		Request: map[string]interface{}{"replaced": true},
+	}, GrpcRequestFromProto(&apiresource.ApiResourceReference{
+		Org:  "acme",
+		Slug: "nightly-sync",
+	}))
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	request := pb.GetSpec().GetTasks()[0].GetTaskConfig().GetFields()["request"].GetStructValue().GetFields()
+	if got := request["org"].GetStringValue(); got != "acme" {
+		t.Errorf("request.org = %q, want %q", got, "acme")
+	}
+	if got := request["slug"].GetStringValue(); got != "nightly-sync" {
+		t.Errorf("request.slug = %q, want %q", got, "nightly-sync")
+	}
+	if _, ok := request["replaced"]; ok {
+		t.Errorf("request kept the args request: %v", request)
+	}
+	if _, ok := request["version"]; ok {
+		t.Errorf("request has unset field version: %v", request)
+	}
+}
+
+func TestGrpcField_OverridesSerializedRequest(t *testing.T) {
+	wf, err := New(nil, "users/lookup", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	lookup := wf.HttpGet("lookup", "https://api.example.com/lookup", nil)
+	wf.GrpcCall("getWorkflow", &GrpcCallArgs{
+		Service: testGrpcService,
+		Method:  testGrpcMethod,
+	},
+		GrpcField("slug", lookup.Field("slug")),
+		GrpcRequestFromProto(&apiresource.ApiResourceReference{Org: "acme", Slug: "placeholder"}),
+	)
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	request := pb.GetSpec().GetTasks()[1].GetTaskConfig().GetFields()["request"].GetStructValue().GetFields()
+	if got := request["slug"].GetStringValue(); got != `${ $context["lookup"].slug }` {
+		t.Errorf("request.slug = %q, want the lookup reference", got)
+	}
+	if got := request["org"].GetStringValue(); got != "acme" {
+		t.Errorf("request.org = %q, want %q", got, "acme")
+	}
+}
+
+func TestGrpcField_NestedPath(t *testing.T) {
+	task := GrpcCall("call", &GrpcCallArgs{Service: "acme.Svc", Method: "Do"},
+		GrpcField("filter.owner_id", "u-1"),
+	)
+
+	filter, ok := task.Config.(*GrpcCallTaskConfig).Request["filter"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("request.filter is not an object: %v", task.Config.(*GrpcCallTaskConfig).Request)
+	}
+	if got := filter["owner_id"]; got != "u-1" {
+		t.Errorf("request.filter.owner_id = %v, want u-1", got)
+	}
+}
+
+func TestGrpcRequestFromProto_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *GrpcCallArgs
+		opts    []GrpcOption
+		wantErr string
+	}{
+		{
+			name: "input type mismatch",
+			args: &GrpcCallArgs{Service: testGrpcService, Method: testGrpcMethod},
+			opts: []GrpcOption{
+				GrpcRequestFromProto(&workflowv1.WorkflowId{Value: "wfl-1"}),
+			},
+			wantErr: "request is a ai.stigmer.agentic.workflow.v1.WorkflowId, but " +
+				testGrpcService + "/" + testGrpcMethod + " takes a ai.stigmer.commons.apiresource.ApiResourceReference",
+		},
+		{
+			name: "unknown field",
+			args: &GrpcCallArgs{Service: testGrpcService, Method: testGrpcMethod},
+			opts: []GrpcOption{
+				GrpcRequestFromProto(&apiresource.ApiResourceReference{}),
+				GrpcField("user_id", "u-1"),
+			},
+			wantErr: `ai.stigmer.commons.apiresource.ApiResourceReference has no field "user_id"`,
+		},
+		{
+			name: "nested path into scalar",
+			args: &GrpcCallArgs{Service: testGrpcService, Method: testGrpcMethod},
+			opts: []GrpcOption{
+				GrpcRequestFromProto(&apiresource.ApiResourceReference{}),
+				GrpcField("slug.value", "x"),
+			},
+			wantErr: `field "slug" of ai.stigmer.commons.apiresource.ApiResourceReference is not a message`,
+		},
+		{
+			name:    "nil message",
+			args:    &GrpcCallArgs{Service: testGrpcService, Method: testGrpcMethod},
+			opts:    []GrpcOption{GrpcRequestFromProto(nil)},
+			wantErr: "request message is nil",
+		},
+		{
+			name:    "empty field path",
+			args:    &GrpcCallArgs{Service: "acme.Svc", Method: "Do"},
+			opts:    []GrpcOption{GrpcField("", "x")},
+			wantErr: `invalid request field path ""`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "users/lookup", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.GrpcCall("call", tt.args, tt.opts...)
+
+			_, err = wf.ToProto()
+			if !errors.Is(err, ErrInvalidGrpcRequest) {
+				t.Fatalf("ToProto() error = %v, want ErrInvalidGrpcRequest", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ToProto() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGrpcRequestFromProto_UnknownServiceSkipsTypeCheck(t *testing.T) {
+	wf, err := New(nil, "users/lookup", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	// The service is not registered locally, so only the serialization is checked
+	wf.GrpcCall("call", &GrpcCallArgs{Service: "acme.user.v1.UserService", Method: "GetUser"},
+		GrpcRequestFromProto(&workflowv1.WorkflowId{Value: "wfl-1"}),
+	)
+
+	if _, err := wf.ToProto(); err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+}
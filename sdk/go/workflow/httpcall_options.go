@@ -1,6 +1,14 @@
 package workflow
 
 import (
+	"fmt"
+	"maps"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
 )
 
@@ -20,7 +28,7 @@ type HttpCallArgs = HttpCallTaskConfig
 //	    },
 //	    TimeoutSeconds: 30,
 //	})
-func HttpCall(name string, args *HttpCallArgs) *Task {
+func HttpCall(name string, args *HttpCallArgs, opts ...HttpOption) *Task {
 	if args == nil {
 		args = &HttpCallArgs{}
 	}
@@ -33,11 +41,52 @@ func HttpCall(name string, args *HttpCallArgs) *Task {
 		args.Body = make(map[string]interface{})
 	}
 
-	return &Task{
+	task := &Task{
 		Name:   name,
 		Kind:   TaskKindHttpCall,
 		Config: args,
 	}
+	for _, opt := range opts {
+		opt.applyHttp(task, args)
+	}
+	return task
+}
+
+// HttpOption configures an HTTP_CALL task. Options are applied after the
+// args, so they override them.
+//
+// Header is an HttpOption; Timeout is accepted by several builders. Passing
+// an option to a builder it does not apply to fails to compile, e.g.
+// "HttpOption does not implement AgentCallOption".
+type HttpOption interface {
+	applyHttp(t *Task, cfg *HttpCallTaskConfig)
+}
+
+// httpOptionFunc adapts a function to HttpOption.
+type httpOptionFunc func(t *Task, cfg *HttpCallTaskConfig)
+
+func (f httpOptionFunc) applyHttp(t *Task, cfg *HttpCallTaskConfig) {
+	f(t, cfg)
+}
+
+// Header sets a request header of an HTTP_CALL task.
+//
+// Example:
+//
+//	wf.HttpGet("fetch", endpoint, nil,
+//	    workflow.Header("Accept", "application/vnd.github.v3+json"),
+//	    workflow.TimeoutDuration(10*time.Second),
+//	)
+func Header(name, value string) HttpOption {
+	return httpOptionFunc(func(_ *Task, cfg *HttpCallTaskConfig) {
+		// Copy so a headers map shared between tasks is left untouched
+		headers := maps.Clone(cfg.Headers)
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		headers[name] = value
+		cfg.Headers = headers
+	})
 }
 
 // ============================================================================
@@ -46,21 +95,25 @@ func HttpCall(name string, args *HttpCallArgs) *Task {
 
 // HttpGet creates an HTTP GET task with a default 30-second timeout.
 //
+// uri is a literal or a raw "${ }" expression. A string reference is passed
+// through its StringExpression method, so an IntRef or BoolRef fails to
+// compile rather than producing a junk URI.
+//
 // Example:
 //
 //	task := workflow.HttpGet("fetch", "https://api.example.com/data", map[string]string{
 //	    "Authorization": "Bearer ${.token}",
 //	})
 //
-//	// Or with TaskFieldRef:
-//	task := workflow.HttpGet("fetch", apiBase.Concat("/data"), nil)
-func HttpGet(name string, uri interface{}, headers map[string]string) *Task {
+//	// Or with a StringRef:
+//	task := workflow.HttpGet("fetch", apiBase.Concat("/data").StringExpression(), nil)
+func HttpGet(name string, uri string, headers map[string]string, opts ...HttpOption) *Task {
 	return HttpCall(name, &HttpCallArgs{
 		Method:         "GET",
-		Endpoint:       &types.HttpEndpoint{Uri: CoerceToString(uri)},
+		Endpoint:       &types.HttpEndpoint{Uri: uri},
 		Headers:        headers,
 		TimeoutSeconds: 30,
-	})
+	}, opts...)
 }
 
 // HttpPost creates an HTTP POST task with a default 30-second timeout.
@@ -72,46 +125,1107 @@ func HttpGet(name string, uri interface{}, headers map[string]string) *Task {
 //	    map[string]interface{}{"name": "John", "email": "john@example.com"},
 //	)
 //
-//	// Or with TaskFieldRef:
-//	task := workflow.HttpPost("create", apiBase.Concat("/users"), nil, body)
-func HttpPost(name string, uri interface{}, headers map[string]string, body map[string]interface{}) *Task {
+//	// Or with a StringRef:
+//	task := workflow.HttpPost("create", apiBase.Concat("/users").StringExpression(), nil, body)
+func HttpPost(name string, uri string, headers map[string]string, body map[string]interface{}, opts ...HttpOption) *Task {
 	return HttpCall(name, &HttpCallArgs{
 		Method:         "POST",
-		Endpoint:       &types.HttpEndpoint{Uri: CoerceToString(uri)},
+		Endpoint:       &types.HttpEndpoint{Uri: uri},
 		Headers:        headers,
 		Body:           body,
 		TimeoutSeconds: 30,
-	})
+	}, opts...)
 }
 
 // HttpPut creates an HTTP PUT task with a default 30-second timeout.
-func HttpPut(name string, uri interface{}, headers map[string]string, body map[string]interface{}) *Task {
+func HttpPut(name string, uri string, headers map[string]string, body map[string]interface{}, opts ...HttpOption) *Task {
 	return HttpCall(name, &HttpCallArgs{
 		Method:         "PUT",
-		Endpoint:       &types.HttpEndpoint{Uri: CoerceToString(uri)},
+		Endpoint:       &types.HttpEndpoint{Uri: uri},
 		Headers:        headers,
 		Body:           body,
 		TimeoutSeconds: 30,
-	})
+	}, opts...)
 }
 
 // HttpPatch creates an HTTP PATCH task with a default 30-second timeout.
-func HttpPatch(name string, uri interface{}, headers map[string]string, body map[string]interface{}) *Task {
+func HttpPatch(name string, uri string, headers map[string]string, body map[string]interface{}, opts ...HttpOption) *Task {
 	return HttpCall(name, &HttpCallArgs{
 		Method:         "PATCH",
-		Endpoint:       &types.HttpEndpoint{Uri: CoerceToString(uri)},
+		Endpoint:       &types.HttpEndpoint{Uri: uri},
 		Headers:        headers,
 		Body:           body,
 		TimeoutSeconds: 30,
-	})
+	}, opts...)
 }
 
 // HttpDelete creates an HTTP DELETE task with a default 30-second timeout.
-func HttpDelete(name string, uri interface{}, headers map[string]string) *Task {
+func HttpDelete(name string, uri string, headers map[string]string, opts ...HttpOption) *Task {
 	return HttpCall(name, &HttpCallArgs{
 		Method:         "DELETE",
-		Endpoint:       &types.HttpEndpoint{Uri: CoerceToString(uri)},
+		Endpoint:       &types.HttpEndpoint{Uri: uri},
 		Headers:        headers,
 		TimeoutSeconds: 30,
+	}, opts...)
+}
+
+// HttpHead creates an HTTP HEAD task with a default 30-second timeout.
+//
+// The task output holds only the response status code and headers, with
+// lowercased header names, so a resource can be checked without fetching it:
+//
+//	{"statusCode": 200, "headers": {"etag": "\"33a64df5\"", "last-modified": "..."}}
+//
+// Request bodies and ResponseFormat fail validation with ErrBodyNotAllowed
+// and ErrHeaderOnlyResponse. Read header names containing hyphens with
+// bracket notation:
+//
+//	check := workflow.HttpHead("check", "https://cdn.example.com/data.json")
+//	check.Field("headers.etag")
+//	check.Field(`headers["last-modified"]`)
+func HttpHead(name string, uri string, opts ...HttpOption) *Task {
+	return HttpCall(name, &HttpCallArgs{
+		Method:         "HEAD",
+		Endpoint:       &types.HttpEndpoint{Uri: uri},
+		TimeoutSeconds: 30,
+	}, opts...)
+}
+
+// HttpOptions creates an HTTP OPTIONS task with a default 30-second timeout,
+// for discovering the methods or CORS policy of an endpoint. Like HttpHead,
+// the task output holds only the response status code and headers:
+//
+//	caps := workflow.HttpOptions("caps", "https://api.example.com/items")
+//	caps.Field("headers.allow") // "GET, POST, OPTIONS"
+func HttpOptions(name string, uri string, opts ...HttpOption) *Task {
+	return HttpCall(name, &HttpCallArgs{
+		Method:         "OPTIONS",
+		Endpoint:       &types.HttpEndpoint{Uri: uri},
+		TimeoutSeconds: 30,
+	}, opts...)
+}
+
+// headerOnlyMethods lists the HTTP methods whose task output holds only the
+// response status code and headers (see HttpHead).
+var headerOnlyMethods = map[string]bool{"HEAD": true, "OPTIONS": true}
+
+// headerOnlyFields are the output fields of HEAD and OPTIONS tasks.
+var headerOnlyFields = map[string]bool{"statusCode": true, "headers": true}
+
+// validateHeaderOnlyResponse checks that HEAD and OPTIONS tasks set no
+// response format and that Field() references only their status code and
+// headers.
+func (t *Task) validateHeaderOnlyResponse() error {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok {
+		return nil
+	}
+	method := strings.ToUpper(cfg.Method)
+	if !headerOnlyMethods[method] {
+		return nil
+	}
+
+	if cfg.ResponseFormat != "" {
+		return NewValidationErrorWithCause(
+			"responseFormat",
+			cfg.ResponseFormat,
+			"no_body",
+			fmt.Sprintf("task %q: %s responses have no body to format; remove ResponseFormat", t.Name, method),
+			ErrHeaderOnlyResponse,
+		)
+	}
+	for _, path := range t.referencedPaths {
+		if headerOnlyFields[rootFieldName(path)] {
+			continue
+		}
+		return NewValidationErrorWithCause(
+			"field",
+			path,
+			"header_only",
+			fmt.Sprintf("task %q: Field(%q) is not part of the output of %s tasks, which holds only statusCode and headers",
+				t.Name, path, method),
+			ErrHeaderOnlyResponse,
+		)
+	}
+	return nil
+}
+
+// ============================================================================
+// Endpoint
+// ============================================================================
+
+// endpointSchemes are the schemes an HTTP_CALL endpoint can use.
+var endpointSchemes = map[string]bool{"http": true, "https": true}
+
+// validateEndpoint checks the endpoint URI of an HTTP_CALL task at synthesis.
+//
+// A literal URI (including a StringRef resolved at synthesis, such as a
+// Concat of literals) must be an absolute http or https URL without
+// whitespace. A URI containing ${...} expressions is only resolved at
+// execution, so only the expression syntax is checked.
+func (t *Task) validateEndpoint() error {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok || cfg.Endpoint == nil {
+		return nil
+	}
+	uri := cfg.Endpoint.UriExpression()
+	if uri == "" {
+		return nil
+	}
+
+	invalid := func(rule string, pos int, format string, args ...interface{}) error {
+		return NewValidationErrorWithCause(
+			"endpoint.uri",
+			uri,
+			rule,
+			fmt.Sprintf("task %q: invalid endpoint %q: %s at position %d", t.Name, uri, fmt.Sprintf(format, args...), pos),
+			ErrInvalidEndpoint,
+		)
+	}
+
+	if strings.Contains(uri, "${") {
+		if pos, reason := checkExpressionSyntax(uri); pos >= 0 {
+			return invalid("expression", pos, "%s", reason)
+		}
+		return nil
+	}
+
+	schemeEnd := strings.Index(uri, "://")
+	if schemeEnd < 0 {
+		return invalid("scheme", 0, "missing scheme (expected http:// or https://)")
+	}
+	if scheme := uri[:schemeEnd]; !endpointSchemes[strings.ToLower(scheme)] {
+		return invalid("scheme", 0, "unsupported scheme %q (expected http or https)", scheme)
+	}
+	if pos := strings.IndexFunc(uri, unicode.IsSpace); pos >= 0 {
+		return invalid("whitespace", pos, "unescaped whitespace %q", uri[pos:pos+1])
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return invalid("url", 0, "not a valid URL")
+	}
+	if u.Host == "" {
+		return invalid("host", schemeEnd+len("://"), "missing host")
+	}
+	return nil
+}
+
+// checkExpressionSyntax checks that every ${ in s is closed by a matching }
+// and is not empty. Braces inside jq string literals are ignored. It returns
+// the position of the offending ${ and the reason, or -1 if s is well-formed.
+func checkExpressionSyntax(s string) (int, string) {
+	for i := 0; i < len(s); i++ {
+		if !strings.HasPrefix(s[i:], "${") {
+			continue
+		}
+		start := i
+		depth := 1
+		inString := false
+		for i += 2; i < len(s) && depth > 0; i++ {
+			switch c := s[i]; {
+			case inString && c == '\\':
+				i++
+			case c == '"':
+				inString = !inString
+			case inString:
+			case c == '{':
+				depth++
+			case c == '}':
+				depth--
+			}
+		}
+		if depth > 0 {
+			return start, "unterminated ${ expression"
+		}
+		// i is just past the closing brace
+		if strings.TrimSpace(s[start+2:i-1]) == "" {
+			return start, "empty ${} expression"
+		}
+		i--
+	}
+	return -1, ""
+}
+
+// ============================================================================
+// Request Body
+// ============================================================================
+
+// bodylessMethods lists the HTTP methods whose request body has no defined
+// semantics. Many servers and proxies ignore or reject such bodies.
+var bodylessMethods = map[string]bool{"GET": true, "HEAD": true, "DELETE": true}
+
+// BodyOption configures WithBody.
+type BodyOption func(*Task)
+
+// AllowBodyOnGet lets WithBody set a request body on a GET or DELETE task,
+// for the rare APIs that expect one (such as search endpoints taking a query
+// document). The runner sends the body as is. HEAD and OPTIONS tasks never
+// accept a body.
+func AllowBodyOnGet() BodyOption {
+	return func(t *Task) {
+		t.allowBody = true
+	}
+}
+
+// WithBody sets the request body of an HTTP_CALL task. It has no effect on
+// other task kinds.
+//
+// GET and DELETE requests with a body fail validation with ErrBodyNotAllowed
+// unless AllowBodyOnGet is passed; HEAD and OPTIONS requests always do.
+//
+// Example:
+//
+//	wf.HttpGet("search", "https://search.example.com/_search", nil).
+//	    WithBody(map[string]interface{}{"query": query}, workflow.AllowBodyOnGet())
+func (t *Task) WithBody(body map[string]interface{}, opts ...BodyOption) *Task {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok {
+		return t
+	}
+	if t.bodySetBy != "" && t.bodyErr == "" {
+		t.bodyErr = fmt.Sprintf("WithBody replaces the body set by %s; pass the values to %s instead", t.bodySetBy, t.bodySetBy)
+	}
+	cfg.Body = body
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// validateBody checks that the request body is set once, that its encoding
+// is supported, that HEAD and OPTIONS requests carry no body, and that GET
+// and DELETE requests carry none unless it was allowed with AllowBodyOnGet.
+func (t *Task) validateBody() error {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok {
+		return nil
+	}
+
+	if t.bodyErr != "" {
+		return NewValidationErrorWithCause(
+			"body",
+			t.bodySetBy,
+			"single_body",
+			fmt.Sprintf("task %q: %s", t.Name, t.bodyErr),
+			ErrConflictingBody,
+		)
+	}
+	if err := t.validateBodyEncoding(cfg); err != nil {
+		return err
+	}
+
+	if len(cfg.Body) == 0 {
+		return nil
+	}
+	method := strings.ToUpper(cfg.Method)
+	if headerOnlyMethods[method] {
+		return NewValidationErrorWithCause(
+			"body",
+			method,
+			"method_allows_body",
+			fmt.Sprintf("task %q: %s requests must not have a body", t.Name, method),
+			ErrBodyNotAllowed,
+		)
+	}
+	if t.allowBody || !bodylessMethods[method] {
+		return nil
+	}
+	return NewValidationErrorWithCause(
+		"body",
+		method,
+		"method_allows_body",
+		fmt.Sprintf("task %q: %s requests must not have a body; use POST, PUT or PATCH, "+
+			"or pass workflow.AllowBodyOnGet() to WithBody if the API requires it", t.Name, method),
+		ErrBodyNotAllowed,
+	)
+}
+
+// ============================================================================
+// Form and Multipart Bodies
+// ============================================================================
+
+// Body encodings of HTTP_CALL tasks, see HttpCallTaskConfig.BodyEncoding.
+const (
+	bodyEncodingJSON      = "json"
+	bodyEncodingForm      = "form"
+	bodyEncodingMultipart = "multipart"
+)
+
+// BodyForm sends the request body of an HTTP_CALL task url-encoded
+// (application/x-www-form-urlencoded), as expected by OAuth token endpoints
+// and many payment APIs.
+//
+// Values can be literals, refs or expressions. The runner evaluates them and
+// flattens nested maps and slices with bracket notation
+// (card[number]=4242&items[0]=a). It sets the Content-Type header, replacing
+// one set on the task.
+//
+// The body is set once: combining BodyForm with a body passed to HttpPost,
+// WithBody or BodyMultipart fails validation with ErrConflictingBody.
+//
+// Example:
+//
+//	wf.HttpPost("charge", "https://api.stripe.com/v1/charges", nil, nil,
+//	    workflow.BodyForm(map[string]any{
+//	        "amount":   order.Field("amount"),
+//	        "currency": "usd",
+//	        "metadata": map[string]any{"order_id": order.Field("id")},
+//	    }),
+//	)
+func BodyForm(values map[string]any) HttpOption {
+	return httpOptionFunc(func(t *Task, cfg *HttpCallTaskConfig) {
+		setEncodedBody(t, cfg, "BodyForm", bodyEncodingForm, maps.Clone(values), nil)
+	})
+}
+
+// MultipartPart is a part of a multipart/form-data request body, created
+// with Part.
+type MultipartPart struct {
+	name     string
+	value    any
+	settings types.HttpMultipartPart
+}
+
+// PartOption configures a part of a multipart body.
+type PartOption func(*types.HttpMultipartPart)
+
+// Part creates a part of a multipart body for BodyMultipart.
+//
+// The value can be a literal, a ref or an expression. Strings are sent
+// as-is; other values are sent as JSON with the "application/json" content
+// type.
+//
+// Example:
+//
+//	workflow.Part("file", report.Field("body"), workflow.Filename("report.csv"))
+func Part(name string, value any, opts ...PartOption) MultipartPart {
+	part := MultipartPart{name: name, value: value}
+	part.settings.Name = name
+	for _, opt := range opts {
+		opt(&part.settings)
+	}
+	return part
+}
+
+// Filename sends a part as a file upload with the given file name. Without
+// PartContentType, its content type is "application/octet-stream".
+func Filename(name string) PartOption {
+	return func(p *types.HttpMultipartPart) {
+		p.Filename = name
+	}
+}
+
+// PartContentType sets the content type of a part, such as "text/csv".
+func PartContentType(contentType string) PartOption {
+	return func(p *types.HttpMultipartPart) {
+		p.ContentType = contentType
+	}
+}
+
+// Base64Content marks the value of a part as base64-encoded binary content,
+// which the runner decodes before sending. Use it to upload the response of
+// an HTTP_CALL with ResponseFormat(FormatBytesBase64).
+func Base64Content() PartOption {
+	return func(p *types.HttpMultipartPart) {
+		p.Base64 = true
+	}
+}
+
+// BodyMultipart sends the request body of an HTTP_CALL task as
+// multipart/form-data, for file uploads. Parts are sent in order.
+//
+// The runner evaluates the part values and sets the Content-Type header with
+// the multipart boundary, replacing one set on the task.
+//
+// The body is set once: combining BodyMultipart with a body passed to
+// HttpPost, WithBody or BodyForm fails validation with ErrConflictingBody.
+//
+// Example:
+//
+//	report := wf.HttpGet("export", "https://reports.example.com/daily.csv", nil,
+//	    workflow.ResponseFormat(workflow.FormatText),
+//	)
+//	wf.HttpPost("upload", "https://files.example.com/upload", nil, nil,
+//	    workflow.BodyMultipart(
+//	        workflow.Part("file", report.Field("body"), workflow.Filename("report.csv")),
+//	        workflow.Part("meta", map[string]any{"source": "daily-export"}),
+//	    ),
+//	)
+func BodyMultipart(parts ...MultipartPart) HttpOption {
+	return httpOptionFunc(func(t *Task, cfg *HttpCallTaskConfig) {
+		body := make(map[string]interface{}, len(parts))
+		settings := make([]*types.HttpMultipartPart, 0, len(parts))
+		for _, part := range parts {
+			body[part.name] = part.value
+			partSettings := part.settings
+			settings = append(settings, &partSettings)
+		}
+		setEncodedBody(t, cfg, "BodyMultipart", bodyEncodingMultipart, body, settings)
 	})
 }
+
+// setEncodedBody sets the body of an HTTP_CALL task for BodyForm and
+// BodyMultipart, recording a conflict with a body set before.
+func setEncodedBody(t *Task, cfg *HttpCallTaskConfig, option, encoding string, body map[string]interface{}, parts []*types.HttpMultipartPart) {
+	if t.bodyErr == "" {
+		switch {
+		case t.bodySetBy != "":
+			t.bodyErr = fmt.Sprintf("%s replaces the body set by %s; a request has a single body", option, t.bodySetBy)
+		case len(cfg.Body) > 0:
+			t.bodyErr = fmt.Sprintf("%s replaces the body passed to the task; pass all values to %s", option, option)
+		}
+	}
+	t.bodySetBy = option
+	cfg.Body = body
+	cfg.BodyEncoding = encoding
+	cfg.MultipartParts = parts
+}
+
+// validateBodyEncoding checks the body encoding of an HTTP_CALL task and the
+// parts of a multipart body.
+func (t *Task) validateBodyEncoding(cfg *HttpCallTaskConfig) error {
+	invalid := func(value, rule, msg string) error {
+		return NewValidationErrorWithCause("bodyEncoding", value, rule,
+			fmt.Sprintf("task %q: %s", t.Name, msg), ErrInvalidBodyEncoding)
+	}
+
+	switch cfg.BodyEncoding {
+	case "", bodyEncodingJSON, bodyEncodingForm:
+		if len(cfg.MultipartParts) > 0 {
+			return invalid(cfg.BodyEncoding, "multipart_parts",
+				"multipart parts require the multipart body encoding; use BodyMultipart")
+		}
+		return nil
+	case bodyEncodingMultipart:
+	default:
+		return invalid(cfg.BodyEncoding, "enum", fmt.Sprintf("unknown body encoding %q (expected %q, %q or %q)",
+			cfg.BodyEncoding, bodyEncodingJSON, bodyEncodingForm, bodyEncodingMultipart))
+	}
+
+	seen := make(map[string]bool, len(cfg.MultipartParts))
+	for _, part := range cfg.MultipartParts {
+		switch {
+		case part == nil || part.Name == "":
+			return invalid(cfg.BodyEncoding, "part_name", "multipart part has no name")
+		case seen[part.Name]:
+			return invalid(part.Name, "part_name", fmt.Sprintf("multipart part %q is set more than once", part.Name))
+		}
+		seen[part.Name] = true
+		if _, ok := cfg.Body[part.Name]; !ok {
+			return invalid(part.Name, "part_value", fmt.Sprintf("multipart part %q has no value in the body", part.Name))
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// TLS
+// ============================================================================
+
+// TLSOption configures the TLS settings of HTTP_CALL tasks.
+type TLSOption func(*types.HttpTls)
+
+// TLS creates a TLS configuration for HttpCallArgs.
+//
+// Example:
+//
+//	wf.AddTask(workflow.HttpCall("fetch", &workflow.HttpCallArgs{
+//	    Method:   "GET",
+//	    Endpoint: &types.HttpEndpoint{Uri: "https://internal.example.com/data"},
+//	    Tls: workflow.TLS(
+//	        workflow.TLSClientCert(workflow.RuntimeSecret("CLIENT_CERT"), workflow.RuntimeSecret("CLIENT_KEY")),
+//	    ),
+//	}))
+func TLS(opts ...TLSOption) *types.HttpTls {
+	tls := &types.HttpTls{}
+	for _, opt := range opts {
+		opt(tls)
+	}
+	return tls
+}
+
+// TLSClientCert presents a client certificate to the server (mTLS).
+//
+// keyRef must be a runtime secret reference (RuntimeSecret("CLIENT_KEY")) so
+// that key material never appears in the manifest; it is resolved by the
+// workflow runner just-in-time. certRef is a runtime reference or literal PEM.
+func TLSClientCert(certRef, keyRef string) TLSOption {
+	return func(t *types.HttpTls) {
+		t.ClientCert = certRef
+		t.ClientKey = keyRef
+	}
+}
+
+// CABundle verifies the server against the given CA certificates instead of
+// the system roots.
+//
+// ref is either a runtime reference (RuntimeSecret("INTERNAL_CA")) holding
+// PEM certificates, or the absolute path of a PEM file on the workflow runner.
+func CABundle(ref string) TLSOption {
+	return func(t *types.HttpTls) {
+		if IsRuntimeRef(ref) {
+			t.CaBundle = ref
+			t.CaBundleFile = ""
+		} else {
+			t.CaBundle = ""
+			t.CaBundleFile = ref
+		}
+	}
+}
+
+// InsecureSkipVerify disables verification of the server certificate.
+//
+// Only use it for testing: the connection is open to interception. The
+// insecure-skip-verify lint rule reports tasks using it.
+func InsecureSkipVerify() TLSOption {
+	return func(t *types.HttpTls) {
+		t.InsecureSkipVerify = true
+	}
+}
+
+// WithTLS sets the default TLS configuration of the workflow's HTTP_CALL tasks.
+//
+// The default is copied into HTTP_CALL tasks when they are added to the
+// workflow, unless they already configure TLS. Task.WithTLS adds to or
+// overrides the default per task.
+//
+// Example:
+//
+//	wf, err := workflow.New(ctx, "internal/sync", nil,
+//	    workflow.WithTLS(workflow.CABundle("/etc/stigmer/internal-ca.pem")),
+//	)
+func WithTLS(opts ...TLSOption) WorkflowOption {
+	return func(w *Workflow) {
+		w.TLS = TLS(opts...)
+	}
+}
+
+// WithTLS configures TLS for an HTTP_CALL task, on top of the workflow
+// default set with the WithTLS workflow option. It has no effect on other
+// task kinds.
+//
+// Example:
+//
+//	wf.HttpGet("fetch", "https://internal.example.com/data", nil).
+//	    WithTLS(workflow.TLSClientCert(
+//	        workflow.RuntimeSecret("CLIENT_CERT"),
+//	        workflow.RuntimeSecret("CLIENT_KEY"),
+//	    ))
+func (t *Task) WithTLS(opts ...TLSOption) *Task {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok {
+		return t
+	}
+	tls := &types.HttpTls{}
+	if cfg.Tls != nil {
+		*tls = *cfg.Tls
+	}
+	for _, opt := range opts {
+		opt(tls)
+	}
+	cfg.Tls = tls
+	return t
+}
+
+// applyDefaultTLS copies the workflow's default TLS configuration into an
+// HTTP_CALL task that does not configure TLS itself.
+func (t *Task) applyDefaultTLS(defaults *types.HttpTls) {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok || defaults == nil || cfg.Tls != nil {
+		return
+	}
+	tls := *defaults
+	cfg.Tls = &tls
+}
+
+// validateTLS checks that a client certificate comes with a key, that the
+// key is a runtime secret reference, and that at most one CA bundle is set.
+func (t *Task) validateTLS() error {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok || cfg.Tls == nil {
+		return nil
+	}
+	tls := cfg.Tls
+
+	switch {
+	case tls.ClientCert != "" && tls.ClientKey == "":
+		return NewValidationErrorWithCause(
+			"tls.clientKey",
+			"",
+			"required",
+			fmt.Sprintf("task %q sets a TLS client certificate without a key", t.Name),
+			ErrInvalidTLS,
+		)
+	case tls.ClientKey != "" && tls.ClientCert == "":
+		return NewValidationErrorWithCause(
+			"tls.clientCert",
+			"",
+			"required",
+			fmt.Sprintf("task %q sets a TLS client key without a certificate", t.Name),
+			ErrInvalidTLS,
+		)
+	}
+
+	// Never echo the key value: it may be literal key material
+	if tls.ClientKey != "" && !isRuntimeSecretRef(tls.ClientKey) {
+		return NewValidationErrorWithCause(
+			"tls.clientKey",
+			"<redacted>",
+			"runtime_secret",
+			fmt.Sprintf("task %q: the TLS client key must be a runtime secret reference "+
+				"(workflow.RuntimeSecret(\"KEY\")), not a literal value", t.Name),
+			ErrInvalidTLS,
+		)
+	}
+
+	if tls.CaBundle != "" && tls.CaBundleFile != "" {
+		return NewValidationErrorWithCause(
+			"tls.caBundleFile",
+			tls.CaBundleFile,
+			"exclusive",
+			fmt.Sprintf("task %q sets both a CA bundle and a CA bundle file", t.Name),
+			ErrInvalidTLS,
+		)
+	}
+	if tls.CaBundleFile != "" && !filepath.IsAbs(tls.CaBundleFile) {
+		return NewValidationErrorWithCause(
+			"tls.caBundleFile",
+			tls.CaBundleFile,
+			"absolute_path",
+			fmt.Sprintf("task %q: CA bundle file %q must be an absolute path on the workflow runner "+
+				"or a runtime reference", t.Name, tls.CaBundleFile),
+			ErrInvalidTLS,
+		)
+	}
+
+	return nil
+}
+
+// isRuntimeSecretRef reports whether s is exactly one ${.secrets.KEY} reference.
+func isRuntimeSecretRef(s string) bool {
+	return IsRuntimeRef(s) && strings.HasPrefix(s, "${.secrets.")
+}
+
+// ============================================================================
+// Caching
+// ============================================================================
+
+// Cache key modes of an HTTP_CALL task, see HttpCache.Key.
+const (
+	cacheKeyURI     = "uri"
+	cacheKeyRequest = "request"
+)
+
+// CacheOption configures the response cache of an HTTP GET task.
+type CacheOption func(*types.HttpCache)
+
+// CacheFor creates a cache directive that lets the workflow runner reuse a
+// successful response for ttl instead of calling the endpoint again.
+//
+// The TTL is rounded down to whole seconds. Responses are keyed by the
+// resolved method and URI unless CacheKeyFromRequest is set.
+//
+// Example:
+//
+//	wf.HttpGet("fetchConfig", "https://api.example.com/config", nil).
+//	    WithCache(workflow.CacheFor(10*time.Minute, workflow.CacheKeyFromURI()))
+func CacheFor(ttl time.Duration, opts ...CacheOption) *types.HttpCache {
+	cache := &types.HttpCache{TtlSeconds: int32(ttl / time.Second)}
+	for _, opt := range opts {
+		opt(cache)
+	}
+	return cache
+}
+
+// CacheKeyFromURI keys cached responses by the resolved method and URI,
+// including the query string. This is the default.
+func CacheKeyFromURI() CacheOption {
+	return func(c *types.HttpCache) {
+		c.Key = cacheKeyURI
+	}
+}
+
+// CacheKeyFromRequest keys cached responses by the resolved method, URI and
+// request headers, for endpoints whose response depends on headers such as
+// Accept or Accept-Language.
+func CacheKeyFromRequest() CacheOption {
+	return func(c *types.HttpCache) {
+		c.Key = cacheKeyRequest
+	}
+}
+
+// CacheIncludesAuth caches requests whose headers reference runtime secrets.
+//
+// By default the runner bypasses the cache for such requests, so a response
+// fetched with one caller's credentials is never served to another. Only opt
+// in when the response does not depend on who is asking.
+func CacheIncludesAuth() CacheOption {
+	return func(c *types.HttpCache) {
+		c.IncludeAuth = true
+	}
+}
+
+// WithCache marks an HTTP GET task as cacheable. It has no effect on other
+// task kinds; non-GET HTTP tasks fail validation.
+//
+// Example:
+//
+//	wf.HttpGet("fetchRates", "https://api.example.com/rates", map[string]string{
+//	    "Authorization": "Bearer " + workflow.RuntimeSecret("RATES_TOKEN"),
+//	}).WithCache(workflow.CacheFor(time.Hour, workflow.CacheIncludesAuth()))
+func (t *Task) WithCache(cache *types.HttpCache) *Task {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok {
+		return t
+	}
+	cfg.Cache = cache
+	return t
+}
+
+// validateCache checks that only GET requests are cached, with a positive
+// TTL and a known cache key mode.
+func (t *Task) validateCache() error {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok || cfg.Cache == nil {
+		return nil
+	}
+	cache := cfg.Cache
+
+	if method := strings.ToUpper(cfg.Method); method != "GET" {
+		return NewValidationErrorWithCause(
+			"cache",
+			method,
+			"idempotent",
+			fmt.Sprintf("task %q: only GET requests can be cached, got %s", t.Name, method),
+			ErrInvalidCache,
+		)
+	}
+	if cache.TtlSeconds < 1 {
+		return NewValidationErrorWithCause(
+			"cache.ttlSeconds",
+			fmt.Sprintf("%d", cache.TtlSeconds),
+			"min",
+			fmt.Sprintf("task %q: cache TTL must be at least one second", t.Name),
+			ErrInvalidCache,
+		)
+	}
+	switch cache.Key {
+	case "", cacheKeyURI, cacheKeyRequest:
+	default:
+		return NewValidationErrorWithCause(
+			"cache.key",
+			cache.Key,
+			"enum",
+			fmt.Sprintf("task %q: unknown cache key %q (expected %q or %q)", t.Name, cache.Key, cacheKeyURI, cacheKeyRequest),
+			ErrInvalidCache,
+		)
+	}
+
+	return nil
+}
+
+// ============================================================================
+// Proxy
+// ============================================================================
+
+// proxySchemes are the proxy URL schemes supported by the workflow runner.
+var proxySchemes = map[string]bool{"http": true, "https": true, "socks5": true}
+
+// Proxy routes an HTTP_CALL task through the given proxy instead of the one
+// configured on the workflow runner (HTTP_PROXY, HTTPS_PROXY and NO_PROXY).
+//
+// proxyURL uses the http, https or socks5 scheme. It is stored in the
+// manifest, so it must not embed a password; configure authenticated proxies
+// on the runner instead.
+//
+// Example:
+//
+//	wf.HttpGet("fetchPartner", "https://partner.example.com/data", nil).
+//	    WithProxy(workflow.Proxy("http://proxy.corp:3128"))
+func Proxy(proxyURL string) *types.HttpProxy {
+	return &types.HttpProxy{Url: proxyURL}
+}
+
+// NoProxy connects an HTTP_CALL task directly, bypassing the proxy configured
+// on the workflow runner.
+func NoProxy() *types.HttpProxy {
+	return &types.HttpProxy{Disabled: true}
+}
+
+// WithProxy sets the proxy of an HTTP_CALL task, created with Proxy or
+// NoProxy. It has no effect on other task kinds.
+func (t *Task) WithProxy(proxy *types.HttpProxy) *Task {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok {
+		return t
+	}
+	cfg.Proxy = proxy
+	return t
+}
+
+// validateProxy checks that a proxy URL is absolute, uses a supported scheme
+// and embeds no password, and that it is not combined with NoProxy.
+func (t *Task) validateProxy() error {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok || cfg.Proxy == nil {
+		return nil
+	}
+	proxy := cfg.Proxy
+
+	if proxy.Disabled {
+		if proxy.Url != "" {
+			return NewValidationErrorWithCause(
+				"proxy.url",
+				proxy.Url,
+				"exclusive",
+				fmt.Sprintf("task %q sets both a proxy URL and NoProxy", t.Name),
+				ErrInvalidProxy,
+			)
+		}
+		return nil
+	}
+
+	u, err := url.Parse(proxy.Url)
+	if err != nil || u.Host == "" {
+		return NewValidationErrorWithCause(
+			"proxy.url",
+			proxy.Url,
+			"url",
+			fmt.Sprintf("task %q: proxy URL %q must be an absolute URL such as http://proxy.corp:3128", t.Name, proxy.Url),
+			ErrInvalidProxy,
+		)
+	}
+	if !proxySchemes[u.Scheme] {
+		return NewValidationErrorWithCause(
+			"proxy.url",
+			proxy.Url,
+			"scheme",
+			fmt.Sprintf("task %q: unsupported proxy scheme %q (expected http, https or socks5)", t.Name, u.Scheme),
+			ErrInvalidProxy,
+		)
+	}
+	// Never echo the URL: it embeds a password
+	if _, hasPassword := u.User.Password(); hasPassword {
+		return NewValidationErrorWithCause(
+			"proxy.url",
+			"<redacted>",
+			"credentials",
+			fmt.Sprintf("task %q: the proxy URL must not embed a password; configure authenticated "+
+				"proxies on the workflow runner", t.Name),
+			ErrInvalidProxy,
+		)
+	}
+
+	return nil
+}
+
+// ============================================================================
+// Response Format
+// ============================================================================
+
+// HttpResponseFormat is how the runner stores the response body of an
+// HTTP_CALL task in the task output, see ResponseFormat.
+type HttpResponseFormat string
+
+const (
+	// FormatJSON parses the body as JSON; the task fails if it is not JSON.
+	FormatJSON HttpResponseFormat = "json"
+
+	// FormatText stores the body as a string, such as CSV or HTML.
+	FormatText HttpResponseFormat = "text"
+
+	// FormatBytesBase64 stores the body base64-encoded, for binary payloads.
+	// Bodies over 1 MiB fail the task.
+	FormatBytesBase64 HttpResponseFormat = "bytes_base64"
+)
+
+// ResponseFormat sets how the response body of an HTTP_CALL task is stored
+// in the task output.
+//
+// Without it, the body is parsed as JSON when it is a JSON object or array
+// and stored as a string otherwise. The format used is recorded in the task
+// output metadata ("responseFormat").
+//
+// Example:
+//
+//	report := wf.HttpGet("report", "https://api.example.com/report.csv", nil,
+//	    workflow.ResponseFormat(workflow.FormatText),
+//	)
+//	wf.SetVars("store", "csv", report.Field("body"))
+func ResponseFormat(format HttpResponseFormat) HttpOption {
+	return httpOptionFunc(func(_ *Task, cfg *HttpCallTaskConfig) {
+		cfg.ResponseFormat = string(format)
+	})
+}
+
+// validateResponseFormat checks that the response format of an HTTP_CALL
+// task is one the runner supports.
+func (t *Task) validateResponseFormat() error {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok {
+		return nil
+	}
+
+	switch HttpResponseFormat(cfg.ResponseFormat) {
+	case "", FormatJSON, FormatText, FormatBytesBase64:
+		return nil
+	}
+	return NewValidationErrorWithCause(
+		"responseFormat",
+		cfg.ResponseFormat,
+		"enum",
+		fmt.Sprintf("task %q: unknown response format %q (expected %q, %q or %q)",
+			t.Name, cfg.ResponseFormat, FormatJSON, FormatText, FormatBytesBase64),
+		ErrInvalidResponseFormat,
+	)
+}
+
+// ============================================================================
+// Response Status
+// ============================================================================
+
+// HttpStatusCheck is how the runner checks the response status of an
+// HTTP_CALL task: ExpectStatus, FailOnErrorStatus or AllowAnyStatus.
+//
+// It is an HttpOption for a single task, and the workflow default when
+// passed to WithHttpStatusCheck.
+type HttpStatusCheck struct {
+	expect   []int
+	allowAny bool
+	// empty marks an ExpectStatus call without status codes.
+	empty bool
+}
+
+// ExpectStatus makes an HTTP_CALL task succeed only on the given status
+// codes. Any other status, 2xx included, fails the task with the status and
+// a snippet of the response body; a 5xx status is retried, others are not.
+//
+// Listing an error status lets it through: ExpectStatus(200, 404) hands a
+// 404 response body to later tasks instead of failing.
+//
+// Example:
+//
+//	wf.HttpPost("createUser", "https://api.example.com/users", nil, user,
+//	    workflow.ExpectStatus(200, 201),
+//	)
+func ExpectStatus(codes ...int) HttpStatusCheck {
+	return HttpStatusCheck{expect: append([]int(nil), codes...), empty: len(codes) == 0}
+}
+
+// FailOnErrorStatus makes an HTTP_CALL task fail on 4xx and 5xx statuses,
+// and on redirects the runner does not follow. This is the default; use it
+// to restore the check on a task of a workflow whose default is
+// AllowAnyStatus.
+//
+// Failures are classified UPSTREAM_4XX or UPSTREAM_5XX, and carry the status
+// and a snippet of the response body, so Catch handlers can act on them.
+func FailOnErrorStatus() HttpStatusCheck {
+	return HttpStatusCheck{}
+}
+
+// AllowAnyStatus makes an HTTP_CALL task succeed whatever the response
+// status, for tasks that branch on the status themselves. The response body
+// becomes the task output as for a 2xx status.
+//
+// Example:
+//
+//	lookup := wf.HttpGet("lookup", "https://api.example.com/users/42", nil,
+//	    workflow.AllowAnyStatus(),
+//	)
+func AllowAnyStatus() HttpStatusCheck {
+	return HttpStatusCheck{allowAny: true}
+}
+
+func (c HttpStatusCheck) applyHttp(t *Task, cfg *HttpCallTaskConfig) {
+	t.httpStatusSet = true
+	t.httpStatusErr = ""
+	if c.empty {
+		t.httpStatusErr = "ExpectStatus requires at least one status code"
+	}
+	c.apply(cfg)
+}
+
+// apply sets the status check fields of an HTTP_CALL task configuration.
+func (c HttpStatusCheck) apply(cfg *HttpCallTaskConfig) {
+	cfg.ExpectStatus = nil
+	for _, code := range c.expect {
+		cfg.ExpectStatus = append(cfg.ExpectStatus, int32(code))
+	}
+	cfg.AllowAnyStatus = c.allowAny
+}
+
+// WithHttpStatusCheck sets the default response status check of the
+// workflow's HTTP_CALL tasks. Without it, tasks fail on 4xx and 5xx statuses
+// (FailOnErrorStatus).
+//
+// The default is copied into HTTP_CALL tasks when they are added to the
+// workflow, unless they set a status check themselves.
+//
+// Example:
+//
+//	wf, err := workflow.New(ctx, "ops/probe", nil,
+//	    workflow.WithHttpStatusCheck(workflow.AllowAnyStatus()),
+//	)
+//	wf.HttpGet("health", "https://api.example.com/health", nil)
+//	wf.HttpPost("report", reportURL, nil, payload, workflow.FailOnErrorStatus())
+func WithHttpStatusCheck(check HttpStatusCheck) WorkflowOption {
+	return func(w *Workflow) {
+		w.HttpStatusCheck = &check
+	}
+}
+
+// applyDefaultHttpStatusCheck copies the workflow's default status check into
+// an HTTP_CALL task that does not set one itself.
+func (t *Task) applyDefaultHttpStatusCheck(check *HttpStatusCheck) {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok || check == nil || t.httpStatusSet || len(cfg.ExpectStatus) > 0 || cfg.AllowAnyStatus {
+		return
+	}
+	if check.empty {
+		t.httpStatusErr = "WithHttpStatusCheck: ExpectStatus requires at least one status code"
+	}
+	check.apply(cfg)
+}
+
+// validateExpectStatus checks that the expected statuses of an HTTP_CALL task
+// are HTTP status codes, and that the task does not also allow any status.
+func (t *Task) validateExpectStatus() error {
+	cfg, ok := t.Config.(*HttpCallTaskConfig)
+	if !ok {
+		return nil
+	}
+
+	if t.httpStatusErr != "" {
+		return NewValidationErrorWithCause(
+			"expectStatus", "", "required",
+			fmt.Sprintf("task %q: %s", t.Name, t.httpStatusErr),
+			ErrInvalidExpectStatus,
+		)
+	}
+	if len(cfg.ExpectStatus) > 0 && cfg.AllowAnyStatus {
+		return NewValidationErrorWithCause(
+			"expectStatus", fmt.Sprint(cfg.ExpectStatus), "exclusive",
+			fmt.Sprintf("task %q: ExpectStatus and AllowAnyStatus cannot be combined", t.Name),
+			ErrInvalidExpectStatus,
+		)
+	}
+	seen := make(map[int32]bool, len(cfg.ExpectStatus))
+	for _, code := range cfg.ExpectStatus {
+		if code < 100 || code > 599 {
+			return NewValidationErrorWithCause(
+				"expectStatus", fmt.Sprint(code), "range",
+				fmt.Sprintf("task %q: expected status %d is not an HTTP status code (100-599)", t.Name, code),
+				ErrInvalidExpectStatus,
+			)
+		}
+		if seen[code] {
+			return NewValidationErrorWithCause(
+				"expectStatus", fmt.Sprint(code), "unique",
+				fmt.Sprintf("task %q: expected status %d is listed twice", t.Name, code),
+				ErrInvalidExpectStatus,
+			)
+		}
+		seen[code] = true
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Inspection API
+//
+// The methods in this file are a supported, read-only surface for tools built
+// on top of the SDK (custom linters, documentation generators). They reflect
+// the workflow after all options and builder calls were applied, before
+// synthesis, and are kept stable across internal refactors.
+//
+// Tasks in declaration order, each task's kind and its description are
+// available through the exported Workflow.Tasks, Task.Kind and
+// Task.Description fields, which are covered by the same guarantee.
+
+// Task returns the task with the given name, or nil if the workflow has no
+// such task.
+func (w *Workflow) Task(name string) *Task {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, task := range w.Tasks {
+		if task.Name == name {
+			return task
+		}
+	}
+	return nil
+}
+
+// Dependencies returns, for each task, the names of the tasks it depends on.
+//
+// Dependencies are computed from the task configs as synthesis renders them:
+// a task depends on every task whose output it references (via Field() or a
+// task reference), the tasks read by the raw expressions it uses (see Expr),
+// plus the tasks added explicitly with DependsOn().
+// Weak references (see TaskFieldRef.Weak) are not dependencies; they are
+// reported by WeakDependencies.
+// Every task has an entry; names are sorted.
+//
+// Example:
+//
+//	deps := wf.Dependencies()
+//	// deps["process"] = ["fetch"]
+func (w *Workflow) Dependencies() map[string][]string {
+	return w.referencedTasks(func(task, other *Task, rendered string) bool {
+		return containsStrongRef(rendered, other.Name)
+	}, true)
+}
+
+// WeakDependencies returns, for each task, the names of the tasks it
+// references only through weak references (see TaskFieldRef.Weak). These
+// edges do not order tasks; the referenced value is null when the task has
+// not produced output.
+// Every task has an entry; names are sorted.
+//
+// Example:
+//
+//	weak := wf.WeakDependencies()
+//	// weak["log"] = ["fetch"]
+func (w *Workflow) WeakDependencies() map[string][]string {
+	deps := w.Dependencies()
+	return w.referencedTasks(func(task, other *Task, rendered string) bool {
+		return !slices.Contains(deps[task.Name], other.Name) &&
+			strings.Contains(rendered, contextRef(other.Name)+"?")
+	}, false)
+}
+
+// referencedTasks maps each task to the sorted names of the other tasks for
+// which references reports true given the task's rendered config. With
+// explicit, tasks added with DependsOn() are included as well.
+func (w *Workflow) referencedTasks(references func(task, other *Task, rendered string) bool, explicit bool) map[string][]string {
+	w.mu.Lock()
+	tasks := slices.Clone(w.Tasks)
+	w.mu.Unlock()
+
+	deps := make(map[string][]string, len(tasks))
+	for _, task := range tasks {
+		var names []string
+		if explicit {
+			names = slices.Clone(task.Dependencies)
+		}
+
+		// Configs that cannot be rendered have no data dependencies;
+		// synthesis reports the conversion error itself.
+		rendered, _ := task.renderedConfig()
+		for _, other := range tasks {
+			if other != task && references(task, other, rendered) {
+				names = append(names, other.Name)
+			}
+		}
+		if explicit {
+			names = append(names, rawExprDeps(w, rendered)...)
+		}
+
+		slices.Sort(names)
+		deps[task.Name] = slices.Compact(names)
+	}
+	return deps
+}
+
+// RawExpressions returns, for each task using raw JQ expressions created
+// with Expr, the expressions it uses (without the "${ }" wrapper). Tasks
+// without raw expressions have no entry; expressions are sorted.
+//
+// Example:
+//
+//	raw := wf.RawExpressions()
+//	// raw["ids"] = [`[$context["fetch"].items[].id] | join(",")`]
+func (w *Workflow) RawExpressions() map[string][]string {
+	w.mu.Lock()
+	tasks := slices.Clone(w.Tasks)
+	w.mu.Unlock()
+
+	result := make(map[string][]string)
+	for _, task := range tasks {
+		rendered, _ := task.renderedConfig()
+		for _, info := range rawExpressionsIn(rendered) {
+			result[task.Name] = append(result[task.Name], info.jq)
+		}
+	}
+	return result
+}
+
+// ConfigSnapshot returns a deep copy of the task's configuration in the form
+// written to the manifest: a map keyed by proto field names, with references
+// rendered as their expression strings.
+//
+// Modifying the returned map does not affect the task.
+//
+// Example:
+//
+//	config, err := task.ConfigSnapshot()
+//	// config["endpoint"] = map[string]any{"uri": "https://api.example.com"}
+func (t *Task) ConfigSnapshot() (map[string]any, error) {
+	config, err := convertTaskConfig(t.Config)
+	if err != nil {
+		return nil, fmt.Errorf("task %s: %w", t.Name, err)
+	}
+
+	// Expressions rendered before a Rename point at the current task names,
+	// as in the synthesized manifest.
+	if t.workflow != nil {
+		if renames, err := t.workflow.taskRenames(); err == nil && len(renames) > 0 {
+			replacer := renameReplacer(renames)
+			for _, value := range config.GetFields() {
+				rewriteValue(value, replacer)
+			}
+		}
+	}
+
+	return config.AsMap(), nil
+}
+
+// renderedConfig returns every string in the task's config snapshot, one per
+// line, followed by the expressions of the references a custom task declares
+// outside its config. Dependency checks search it for task references.
+func (t *Task) renderedConfig() (string, error) {
+	config, err := t.ConfigSnapshot()
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	collectStrings(config, &b)
+
+	if custom, ok := t.Config.(*CustomTaskConfig); ok {
+		var replacer *strings.Replacer
+		if t.workflow != nil {
+			if renames, err := t.workflow.taskRenames(); err == nil && len(renames) > 0 {
+				replacer = renameReplacer(renames)
+			}
+		}
+		for _, ref := range custom.refs() {
+			if ref == nil {
+				continue
+			}
+			expression := ref.Expression()
+			if replacer != nil {
+				expression = replacer.Replace(expression)
+			}
+			collectStrings(expression, &b)
+		}
+	}
+	return b.String(), nil
+}
+
+// collectStrings writes every string in a config snapshot to b, one per line.
+func collectStrings(value any, b *strings.Builder) {
+	switch v := value.(type) {
+	case string:
+		b.WriteString(v)
+		b.WriteByte('\n')
+	case map[string]any:
+		for _, item := range v {
+			collectStrings(item, b)
+		}
+	case []any:
+		for _, item := range v {
+			collectStrings(item, b)
+		}
+	}
+}
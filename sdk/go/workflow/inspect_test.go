@@ -0,0 +1,84 @@
+package workflow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWorkflowInspection(t *testing.T) {
+	wf, err := New(nil, "ops/inspect", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	fetchTask := wf.HttpGet("fetch", "https://api.example.com/data", map[string]string{"Accept": "application/json"})
+	configTask := wf.Set("config", &SetArgs{Variables: map[string]interface{}{"region": "eu"}})
+	wf.HttpPost("post", "https://api.example.com/posts", nil, map[string]interface{}{
+		"title": fetchTask.Field("title"),
+	}).DependsOn(configTask)
+	wf.Set("summary", &SetArgs{Variables: map[string]interface{}{
+		"id": fetchTask.Field("id").Expression(),
+	}})
+
+	if got := wf.Task("post"); got == nil || got.Kind != TaskKindHttpCall {
+		t.Errorf("Task(post) = %v, want the HTTP_CALL task", got)
+	}
+	if got := wf.Task("missing"); got != nil {
+		t.Errorf("Task(missing) = %v, want nil", got)
+	}
+
+	want := map[string][]string{
+		"fetch":   nil,
+		"config":  nil,
+		"post":    {"config", "fetch"},
+		"summary": {"fetch"},
+	}
+	got := wf.Dependencies()
+	for name, deps := range want {
+		if len(deps) == 0 && len(got[name]) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got[name], deps) {
+			t.Errorf("Dependencies()[%s] = %v, want %v", name, got[name], deps)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("Dependencies() has %d entries, want %d", len(got), len(want))
+	}
+}
+
+func TestTaskConfigSnapshot(t *testing.T) {
+	wf, err := New(nil, "ops/inspect", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	fetchTask := wf.HttpGet("fetch", "https://api.example.com/data", nil)
+	postTask := wf.HttpPost("post", "https://api.example.com/posts", nil, map[string]interface{}{
+		"title": fetchTask.Field("title"),
+	})
+
+	snapshot, err := postTask.ConfigSnapshot()
+	if err != nil {
+		t.Fatalf("ConfigSnapshot() failed: %v", err)
+	}
+	body := snapshot["body"].(map[string]any)
+	if got := body["title"]; got != `${ $context["fetch"].title }` {
+		t.Errorf("body.title = %v, want the rendered expression", got)
+	}
+
+	// The snapshot is a copy
+	body["title"] = "changed"
+	again, _ := postTask.ConfigSnapshot()
+	if got := again["body"].(map[string]any)["title"]; got == "changed" {
+		t.Error("modifying the snapshot changed the task config")
+	}
+
+	// Renames are reflected
+	if err := fetchTask.Rename("fetchUser"); err != nil {
+		t.Fatalf("Rename() failed: %v", err)
+	}
+	again, _ = postTask.ConfigSnapshot()
+	if got := again["body"].(map[string]any)["title"]; got != `${ $context["fetchUser"].title }` {
+		t.Errorf("body.title after rename = %v", got)
+	}
+}
@@ -0,0 +1,213 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TestNewTaskConfigForKind tests that every task kind has a registered config.
+func TestNewTaskConfigForKind(t *testing.T) {
+	kinds := map[TaskKind]TaskConfig{
+		TaskKindSet:          &SetTaskConfig{},
+		TaskKindHttpCall:     &HttpCallTaskConfig{},
+		TaskKindGrpcCall:     &GrpcCallTaskConfig{},
+		TaskKindSwitch:       &SwitchTaskConfig{},
+		TaskKindFor:          &ForTaskConfig{},
+		TaskKindFork:         &ForkTaskConfig{},
+		TaskKindTry:          &TryTaskConfig{},
+		TaskKindListen:       &ListenTaskConfig{},
+		TaskKindWait:         &WaitTaskConfig{},
+		TaskKindCallActivity: &CallActivityTaskConfig{},
+		TaskKindRaise:        &RaiseTaskConfig{},
+		TaskKindRun:          &RunTaskConfig{},
+		TaskKindAgentCall:    &AgentCallTaskConfig{},
+	}
+
+	for kind, want := range kinds {
+		t.Run(string(kind), func(t *testing.T) {
+			got, err := NewTaskConfigForKind(kind)
+			if err != nil {
+				t.Fatalf("NewTaskConfigForKind(%s) failed: %v", kind, err)
+			}
+			if gotType, wantType := typeName(got), typeName(want); gotType != wantType {
+				t.Errorf("NewTaskConfigForKind(%s) = %s, want %s", kind, gotType, wantType)
+			}
+		})
+	}
+
+	if _, err := NewTaskConfigForKind("UNKNOWN"); err == nil {
+		t.Error("Expected error for unknown task kind")
+	}
+}
+
+// TestNewTaskConfigMessage tests that every proto task kind resolves to its
+// proto config message through the generated kind registry.
+func TestNewTaskConfigMessage(t *testing.T) {
+	for name, value := range apiresource.WorkflowTaskKind_value {
+		kind := apiresource.WorkflowTaskKind(value)
+		if kind == apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_UNSPECIFIED ||
+			kind == apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_CUSTOM {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			msg, err := newTaskConfigMessage(kind)
+			if err != nil {
+				t.Fatalf("newTaskConfigMessage(%s) failed: %v", name, err)
+			}
+			config, err := NewTaskConfigForKind(TaskKind(strings.TrimPrefix(name, "WORKFLOW_TASK_KIND_")))
+			if err != nil {
+				t.Fatalf("NewTaskConfigForKind(%s) failed: %v", name, err)
+			}
+			got := string(msg.ProtoReflect().Descriptor().Name())
+			if want := strings.TrimPrefix(typeName(config), "workflow."); got != want {
+				t.Errorf("newTaskConfigMessage(%s) = %s, want %s", name, got, want)
+			}
+		})
+	}
+
+	if _, err := newTaskConfigMessage(apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_CUSTOM); err == nil {
+		t.Error("Expected error for custom task kind")
+	}
+}
+
+// TestTaskConfigFromStruct tests decoding a manifest config by kind.
+func TestTaskConfigFromStruct(t *testing.T) {
+	s, err := structpb.NewStruct(map[string]interface{}{
+		"agent":           "code-reviewer",
+		"message":         "Review ${.input.pr}",
+		"finalOutputOnly": true,
+	})
+	if err != nil {
+		t.Fatalf("NewStruct failed: %v", err)
+	}
+
+	config, err := TaskConfigFromStruct(TaskKindAgentCall, s)
+	if err != nil {
+		t.Fatalf("TaskConfigFromStruct failed: %v", err)
+	}
+
+	agentCall, ok := config.(*AgentCallTaskConfig)
+	if !ok {
+		t.Fatalf("Expected *AgentCallTaskConfig, got %T", config)
+	}
+	if agentCall.Agent != "code-reviewer" || !agentCall.FinalOutputOnly {
+		t.Errorf("Unexpected decoded config: %+v", agentCall)
+	}
+}
+
+// TestTaskConfigFromStruct_UnknownFields tests that fields added by a newer
+// SDK survive reading a manifest config, changing it and writing it back.
+func TestTaskConfigFromStruct_UnknownFields(t *testing.T) {
+	s, err := structpb.NewStruct(map[string]interface{}{
+		"method": "GET",
+		"endpoint": map[string]interface{}{
+			"uri":        "https://api.example.com/items",
+			"resolveVia": "internal-dns",
+		},
+		"retryBudget": map[string]interface{}{"maxAttempts": 3.0},
+	})
+	if err != nil {
+		t.Fatalf("NewStruct failed: %v", err)
+	}
+
+	config, err := TaskConfigFromStruct(TaskKindHttpCall, s)
+	if err != nil {
+		t.Fatalf("TaskConfigFromStruct failed: %v", err)
+	}
+	httpCall := config.(*HttpCallTaskConfig)
+	httpCall.Method = "POST"
+	httpCall.Endpoint.Uri = "https://api.example.com/v2/items"
+
+	got, err := httpCall.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto failed: %v", err)
+	}
+	fields := got.GetFields()
+	if method := fields["method"].GetStringValue(); method != "POST" {
+		t.Errorf("method = %q, want POST", method)
+	}
+	endpoint := fields["endpoint"].GetStructValue().GetFields()
+	if uri := endpoint["uri"].GetStringValue(); uri != "https://api.example.com/v2/items" {
+		t.Errorf("endpoint.uri = %q, want the updated URI", uri)
+	}
+	if via := endpoint["resolveVia"].GetStringValue(); via != "internal-dns" {
+		t.Errorf("endpoint.resolveVia = %q, want internal-dns", via)
+	}
+	want := s.GetFields()["retryBudget"]
+	if !proto.Equal(fields["retryBudget"], want) {
+		t.Errorf("retryBudget = %v, want %v", fields["retryBudget"], want)
+	}
+
+	// The decoded config doesn't alias the manifest it was read from
+	want.GetStructValue().GetFields()["maxAttempts"] = structpb.NewNumberValue(5)
+	again, err := httpCall.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto failed: %v", err)
+	}
+	if n := again.GetFields()["retryBudget"].GetStructValue().GetFields()["maxAttempts"].GetNumberValue(); n != 3 {
+		t.Errorf("retryBudget.maxAttempts = %v after changing the source manifest, want 3", n)
+	}
+}
+
+// TestUnknownFields_NestedByValue tests that a shared type held by value,
+// not through a pointer, keeps its unknown fields when encoded.
+func TestUnknownFields_NestedByValue(t *testing.T) {
+	s, err := structpb.NewStruct(map[string]interface{}{
+		"uri":        "https://api.example.com/items",
+		"resolveVia": "internal-dns",
+	})
+	if err != nil {
+		t.Fatalf("NewStruct failed: %v", err)
+	}
+	var endpoint types.HttpEndpoint
+	if err := endpoint.FromProto(s); err != nil {
+		t.Fatalf("FromProto failed: %v", err)
+	}
+
+	data, err := json.Marshal(struct {
+		Endpoint types.HttpEndpoint `json:"endpoint"`
+	}{Endpoint: endpoint})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"resolveVia":"internal-dns"`) {
+		t.Errorf("Marshal = %s, want the resolveVia field kept", data)
+	}
+}
+
+// TestTaskConfigString tests that String() summaries redact sensitive values.
+func TestTaskConfigString(t *testing.T) {
+	config := &HttpCallTaskConfig{
+		Method:   "POST",
+		Endpoint: &types.HttpEndpoint{Uri: "https://api.example.com"},
+		Headers: map[string]string{
+			"Authorization": "Bearer secret-token",
+		},
+		TimeoutSeconds: 30,
+	}
+
+	got := config.String()
+	want := "HTTP_CALL{method=POST endpoint=<set> headers=<1 entries> timeoutSeconds=30}"
+	if got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	agentCall := &AgentCallTaskConfig{
+		Agent:   "code-reviewer",
+		Message: "Token is ${.secrets.TOKEN}",
+	}
+	if s := agentCall.String(); strings.Contains(s, "TOKEN") {
+		t.Errorf("String() leaked expression field: %q", s)
+	}
+}
+
+func typeName(v interface{}) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", v), "*")
+}
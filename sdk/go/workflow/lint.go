@@ -0,0 +1,483 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+)
+
+// LintSeverity is the severity of a lint finding.
+type LintSeverity string
+
+const (
+	// LintSeverityWarning marks findings that are reported but never fail synthesis.
+	LintSeverityWarning LintSeverity = "warning"
+
+	// LintSeverityError marks findings that fail synthesis when linting
+	// runs with stigmer.LintError.
+	LintSeverityError LintSeverity = "error"
+)
+
+// Default lint rule IDs.
+const (
+	LintRuleMissingTimeout     = "missing-timeout"
+	LintRuleNoDefaultCase      = "no-default-case"
+	LintRuleTryWithoutCatch    = "try-without-catch"
+	LintRuleInsecureHTTP       = "insecure-http"
+	LintRuleInsecureSkipVerify = "insecure-skip-verify"
+	LintRuleVersionNotBumped   = "version-not-bumped"
+	LintRuleUnusedOutput       = "unused-output"
+	LintRuleUnusedVariable     = "unused-variable"
+	LintRuleRawExpression      = "raw-expression"
+
+	LintRuleTimeoutExceedsMaxDuration = "timeout-exceeds-max-duration"
+)
+
+// Opt-in lint rule IDs, for rules that are not part of DefaultLintRules.
+const (
+	LintRuleMissingDescription = "missing-description"
+)
+
+// LintFinding is a single issue reported by a lint rule.
+type LintFinding struct {
+	// RuleID is the ID of the rule that reported the finding.
+	RuleID string
+
+	// Severity is the severity of the rule that reported the finding.
+	Severity LintSeverity
+
+	// Workflow is the name of the workflow the finding belongs to.
+	Workflow string
+
+	// Task is the name of the offending task ("" for workflow-level findings).
+	Task string
+
+	// Agent is the name of the agent the finding belongs to, for findings
+	// stigmer.WithLint reports on agents. Workflow is then empty.
+	Agent string
+
+	// Variable is the name of the context variable the finding belongs to,
+	// for findings stigmer.WithLint reports on context variables. Workflow
+	// is then empty.
+	Variable string
+
+	// Message describes the issue.
+	Message string
+}
+
+// String formats the finding for display, e.g.
+// `warning: workflow "pr-review" task "fetch": no timeout set [missing-timeout]`.
+func (f LintFinding) String() string {
+	location := fmt.Sprintf("workflow %q", f.Workflow)
+	if f.Agent != "" {
+		location = fmt.Sprintf("agent %q", f.Agent)
+	}
+	if f.Variable != "" {
+		location = fmt.Sprintf("context variable %q", f.Variable)
+	}
+	if f.Task != "" {
+		location += fmt.Sprintf(" task %q", f.Task)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", f.Severity, location, f.Message, f.RuleID)
+}
+
+// LintRule is an opinionated check run against a workflow.
+//
+// Unlike validation errors, rule findings do not make a workflow invalid;
+// stigmer.WithLint controls whether they are reported or fail synthesis.
+type LintRule interface {
+	// ID returns the rule identifier used in findings and suppressions.
+	ID() string
+
+	// Severity returns the severity of the rule's findings.
+	Severity() LintSeverity
+
+	// Check returns the rule's findings for the workflow.
+	// RuleID and Severity of the returned findings are filled in by Lint.
+	Check(w *Workflow) []LintFinding
+}
+
+// NewLintRule creates a LintRule from a check function.
+//
+// Example:
+//
+//	rule := workflow.NewLintRule("no-wait", workflow.LintSeverityWarning,
+//	    func(wf *workflow.Workflow) []workflow.LintFinding {
+//	        var findings []workflow.LintFinding
+//	        for _, task := range wf.Tasks {
+//	            if task.Kind == workflow.TaskKindWait {
+//	                findings = append(findings, workflow.LintFinding{Task: task.Name, Message: "avoid fixed waits"})
+//	            }
+//	        }
+//	        return findings
+//	    })
+func NewLintRule(id string, severity LintSeverity, check func(w *Workflow) []LintFinding) LintRule {
+	return &funcLintRule{id: id, severity: severity, check: check}
+}
+
+// funcLintRule is the LintRule returned by NewLintRule.
+type funcLintRule struct {
+	id       string
+	severity LintSeverity
+	check    func(w *Workflow) []LintFinding
+}
+
+func (r *funcLintRule) ID() string                      { return r.id }
+func (r *funcLintRule) Severity() LintSeverity          { return r.severity }
+func (r *funcLintRule) Check(w *Workflow) []LintFinding { return r.check(w) }
+
+// DefaultLintRules returns the default rule set:
+//
+//   - missing-timeout (warning): HTTP, gRPC, activity, agent and sub-workflow
+//     calls without a timeout
+//   - no-default-case (warning): Switch tasks without a default case
+//   - try-without-catch (warning): Try tasks without a Catch block
+//   - insecure-http (error): HTTP calls to http:// endpoints
+//   - insecure-skip-verify (warning): HTTP calls that skip verification of
+//     the server certificate
+//   - version-not-bumped (error): workflows whose definition changed since
+//     one of the previous manifests without a version change
+//   - unused-output (warning): tasks whose output no other task references,
+//     for tasks that only produce output (HTTP GET) or export it
+//   - unused-variable (warning): Set task variables no other task reads
+//   - raw-expression (warning): tasks using raw JQ expressions created with
+//     Expr, which are not type-checked
+//   - timeout-exceeds-max-duration (warning): tasks whose ExecutionTimeout is
+//     longer than the workflow maximum duration (WithMaxDuration)
+//
+// previous holds previously synthesized workflow manifests for the
+// version-not-bumped rule; without them the rule reports nothing.
+func DefaultLintRules(previous ...*workflowv1.Workflow) []LintRule {
+	return []LintRule{
+		NewLintRule(LintRuleMissingTimeout, LintSeverityWarning, checkMissingTimeout),
+		NewLintRule(LintRuleNoDefaultCase, LintSeverityWarning, checkNoDefaultCase),
+		NewLintRule(LintRuleTryWithoutCatch, LintSeverityWarning, checkTryWithoutCatch),
+		NewLintRule(LintRuleInsecureHTTP, LintSeverityError, checkInsecureHTTP),
+		NewLintRule(LintRuleInsecureSkipVerify, LintSeverityWarning, checkInsecureSkipVerify),
+		NewLintRule(LintRuleUnusedOutput, LintSeverityWarning, checkUnusedOutput),
+		NewLintRule(LintRuleUnusedVariable, LintSeverityWarning, checkUnusedVariable),
+		NewLintRule(LintRuleRawExpression, LintSeverityWarning, checkRawExpression),
+		NewLintRule(LintRuleTimeoutExceedsMaxDuration, LintSeverityWarning, checkTimeoutExceedsMaxDuration),
+		VersionBumpRule(previous...),
+	}
+}
+
+// VersionBumpRule returns the version-not-bumped rule, comparing workflows
+// against previously synthesized manifests with the same namespace and name.
+func VersionBumpRule(previous ...*workflowv1.Workflow) LintRule {
+	return NewLintRule(LintRuleVersionNotBumped, LintSeverityError, func(w *Workflow) []LintFinding {
+		return checkVersionNotBumped(w, previous)
+	})
+}
+
+// MissingDescriptionRule returns the missing-description rule (warning),
+// reporting Switch, Try and For tasks without a description (see Describe).
+// It is not part of DefaultLintRules; enable it alongside them:
+//
+//	stigmer.WithLintRules(append(workflow.DefaultLintRules(), workflow.MissingDescriptionRule())...)
+func MissingDescriptionRule() LintRule {
+	return NewLintRule(LintRuleMissingDescription, LintSeverityWarning, checkMissingDescription)
+}
+
+// SuppressLint disables lint rules for the whole workflow.
+//
+// Example:
+//
+//	wf.SuppressLint(workflow.LintRuleNoDefaultCase, "missing-timeout")
+func (w *Workflow) SuppressLint(ruleIDs ...string) *Workflow {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.suppressedLintRules = append(w.suppressedLintRules, ruleIDs...)
+	return w
+}
+
+// SuppressLint disables lint rules for this task only.
+//
+// Example:
+//
+//	wf.HttpGet("health", "http://localhost:8080/health", nil).
+//	    SuppressLint(workflow.LintRuleInsecureHTTP)
+func (t *Task) SuppressLint(ruleIDs ...string) *Task {
+	t.suppressedLintRules = append(t.suppressedLintRules, ruleIDs...)
+	return t
+}
+
+// Lint runs the given rules against the workflow and returns their findings,
+// excluding suppressed rules. DefaultLintRules() is used when no rules are given.
+func (w *Workflow) Lint(rules ...LintRule) []LintFinding {
+	if len(rules) == 0 {
+		rules = DefaultLintRules()
+	}
+
+	w.mu.Lock()
+	suppressed := slices.Clone(w.suppressedLintRules)
+	suppressedByTask := make(map[string][]string)
+	for _, task := range w.Tasks {
+		suppressedByTask[task.Name] = task.suppressedLintRules
+	}
+	w.mu.Unlock()
+
+	var findings []LintFinding
+	for _, rule := range rules {
+		if slices.Contains(suppressed, rule.ID()) {
+			continue
+		}
+		for _, finding := range rule.Check(w) {
+			if finding.Task != "" && slices.Contains(suppressedByTask[finding.Task], rule.ID()) {
+				continue
+			}
+			finding.RuleID = rule.ID()
+			finding.Severity = rule.Severity()
+			finding.Workflow = w.Document.Name
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// checkMissingTimeout reports call tasks without a timeout.
+func checkMissingTimeout(w *Workflow) []LintFinding {
+	var findings []LintFinding
+	for _, task := range w.Tasks {
+		if task.ExecutionTimeoutAfter > 0 {
+			continue
+		}
+
+		hasTimeout := true
+		switch cfg := task.Config.(type) {
+		case *HttpCallTaskConfig:
+			hasTimeout = cfg.TimeoutSeconds > 0
+		case *CallActivityTaskConfig:
+			hasTimeout = cfg.TimeoutSeconds > 0
+		case *AgentCallTaskConfig:
+			hasTimeout = cfg.Config != nil && cfg.Config.Timeout > 0
+		case *GrpcCallTaskConfig, *RunTaskConfig:
+			hasTimeout = false
+		}
+		if !hasTimeout {
+			findings = append(findings, LintFinding{
+				Task:    task.Name,
+				Message: fmt.Sprintf("%s task has no timeout; set one with ExecutionTimeout()", task.Kind),
+			})
+		}
+	}
+	return findings
+}
+
+// checkMissingDescription reports control-flow tasks without a description.
+func checkMissingDescription(w *Workflow) []LintFinding {
+	var findings []LintFinding
+	for _, task := range w.Tasks {
+		switch task.Kind {
+		case TaskKindSwitch, TaskKindTry, TaskKindFor:
+		default:
+			continue
+		}
+		if strings.TrimSpace(task.Description) == "" {
+			findings = append(findings, LintFinding{
+				Task:    task.Name,
+				Message: fmt.Sprintf("%s task has no description; explain what it does with Describe()", task.Kind),
+			})
+		}
+	}
+	return findings
+}
+
+// checkNoDefaultCase reports Switch tasks without a default case.
+func checkNoDefaultCase(w *Workflow) []LintFinding {
+	var findings []LintFinding
+	for _, task := range w.Tasks {
+		cfg, ok := task.Config.(*SwitchTaskConfig)
+		if !ok {
+			continue
+		}
+		hasDefault := false
+		for _, c := range cfg.Cases {
+			if c != nil && c.When == "" {
+				hasDefault = true
+				break
+			}
+		}
+		if !hasDefault {
+			findings = append(findings, LintFinding{
+				Task:    task.Name,
+				Message: "switch has no default case; unmatched values fall through silently",
+			})
+		}
+	}
+	return findings
+}
+
+// checkTryWithoutCatch reports Try tasks without a Catch block.
+func checkTryWithoutCatch(w *Workflow) []LintFinding {
+	var findings []LintFinding
+	for _, task := range w.Tasks {
+		cfg, ok := task.Config.(*TryTaskConfig)
+		if ok && cfg.Catch == nil && len(cfg.Catches) == 0 {
+			findings = append(findings, LintFinding{
+				Task:    task.Name,
+				Message: "try has no catch block; errors propagate as if there were no try",
+			})
+		}
+	}
+	return findings
+}
+
+// checkInsecureHTTP reports HTTP calls to plain http:// endpoints.
+func checkInsecureHTTP(w *Workflow) []LintFinding {
+	var findings []LintFinding
+	for _, task := range w.Tasks {
+		cfg, ok := task.Config.(*HttpCallTaskConfig)
+		if !ok || cfg.Endpoint == nil {
+			continue
+		}
+		uri := cfg.Endpoint.UriExpression()
+		if strings.HasPrefix(strings.ToLower(uri), "http://") {
+			findings = append(findings, LintFinding{
+				Task:    task.Name,
+				Message: fmt.Sprintf("endpoint %q uses http://; use https://", uri),
+			})
+		}
+	}
+	return findings
+}
+
+// checkInsecureSkipVerify reports HTTP calls that skip verification of the
+// server certificate.
+func checkInsecureSkipVerify(w *Workflow) []LintFinding {
+	var findings []LintFinding
+	for _, task := range w.Tasks {
+		cfg, ok := task.Config.(*HttpCallTaskConfig)
+		if ok && cfg.Tls != nil && cfg.Tls.InsecureSkipVerify {
+			findings = append(findings, LintFinding{
+				Task:    task.Name,
+				Message: "server certificate verification is disabled; use CABundle() for private CAs",
+			})
+		}
+	}
+	return findings
+}
+
+// checkUnusedOutput reports tasks whose output is never referenced.
+//
+// Only tasks run for their output are considered: HTTP GET calls and tasks
+// exporting their output. The last task and tasks ending the flow are
+// terminal by intent; their output is the workflow's result.
+func checkUnusedOutput(w *Workflow) []LintFinding {
+	referenced := make(map[string]bool)
+	for _, graph := range []map[string][]string{w.Dependencies(), w.WeakDependencies()} {
+		for _, names := range graph {
+			for _, name := range names {
+				referenced[name] = true
+			}
+		}
+	}
+
+	w.mu.Lock()
+	tasks := slices.Clone(w.Tasks)
+	w.mu.Unlock()
+
+	var findings []LintFinding
+	for i, task := range tasks {
+		if referenced[task.Name] || isTerminalTask(tasks, i) {
+			continue
+		}
+
+		var message string
+		if cfg, ok := task.Config.(*HttpCallTaskConfig); ok && strings.EqualFold(cfg.Method, "GET") {
+			message = "output of HTTP GET is never referenced by another task; remove the task or use its output"
+		} else if task.ExportAs != "" && task.Kind != TaskKindSet {
+			message = "task exports its output but no task references it"
+		} else {
+			continue
+		}
+		findings = append(findings, LintFinding{Task: task.Name, Message: message})
+	}
+	return findings
+}
+
+// checkUnusedVariable reports Set task variables that no other task reads,
+// through Field() or an expression (.name, $context["task"].name). Like
+// checkUnusedOutput, it skips terminal tasks.
+func checkUnusedVariable(w *Workflow) []LintFinding {
+	w.mu.Lock()
+	tasks := slices.Clone(w.Tasks)
+	w.mu.Unlock()
+
+	// Expressions of every task, to search for reads
+	rendered := make(map[string]string, len(tasks))
+	for _, task := range tasks {
+		rendered[task.Name], _ = task.renderedConfig()
+	}
+
+	var findings []LintFinding
+	for i, task := range tasks {
+		cfg, ok := task.Config.(*SetTaskConfig)
+		if !ok || isTerminalTask(tasks, i) {
+			continue
+		}
+
+		names := slices.Sorted(maps.Keys(cfg.Variables))
+		for _, name := range names {
+			if slices.Contains(task.referencedFields, name) {
+				continue
+			}
+			read := regexp.MustCompile(`(\.|\[")` + regexp.QuoteMeta(name) + `($|[^A-Za-z0-9_])`)
+			used := false
+			for _, other := range tasks {
+				if other != task && read.MatchString(rendered[other.Name]) {
+					used = true
+					break
+				}
+			}
+			if !used {
+				findings = append(findings, LintFinding{
+					Task:    task.Name,
+					Message: fmt.Sprintf("variable %q is set but never read", name),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// isTerminalTask reports whether tasks[i] ends the workflow by intent: it is
+// the last task or ends the flow with End().
+func isTerminalTask(tasks []*Task, i int) bool {
+	return i == len(tasks)-1 || tasks[i].ThenTask == EndFlow
+}
+
+// checkVersionNotBumped reports a workflow whose spec differs from a previous
+// manifest with the same namespace, name and version.
+func checkVersionNotBumped(w *Workflow, previous []*workflowv1.Workflow) []LintFinding {
+	if len(previous) == 0 {
+		return nil
+	}
+
+	current, err := w.ToProto()
+	if err != nil {
+		// Conversion errors are reported by synthesis itself
+		return nil
+	}
+
+	doc := current.GetSpec().GetDocument()
+	for _, prev := range previous {
+		prevDoc := prev.GetSpec().GetDocument()
+		if prevDoc.GetNamespace() != doc.GetNamespace() || prevDoc.GetName() != doc.GetName() ||
+			prevDoc.GetVersion() != doc.GetVersion() {
+			continue
+		}
+		if !proto.Equal(prev.GetSpec(), current.GetSpec()) {
+			return []LintFinding{{
+				Message: fmt.Sprintf("definition changed but version is still %q; bump the version", doc.GetVersion()),
+			}}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+)
+
+func TestWorkflowLint_DefaultRules(t *testing.T) {
+	wf, err := New(nil, "ops/health-check", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.HttpGet("fetch", "http://api.example.com/status", nil)
+	wf.CallAgent("summarize", &AgentCallArgs{Agent: "summarizer", Message: "Summarize"})
+	wf.CallAgent("summarizeBounded", &AgentCallArgs{Agent: "summarizer", Message: "Summarize"}).
+		ExecutionTimeout(5 * time.Minute)
+	wf.Switch("route", &SwitchArgs{Cases: []*types.SwitchCase{
+		{Name: "up", When: "${ .status == \"up\" }", Then: "fetch"},
+	}})
+	wf.Try("attempt", &TryArgs{})
+
+	got := make(map[string]string)
+	for _, f := range wf.Lint() {
+		got[f.Task+"/"+f.RuleID] = string(f.Severity)
+		if f.Workflow != "health-check" {
+			t.Errorf("finding workflow = %q, want health-check", f.Workflow)
+		}
+	}
+	want := map[string]string{
+		"summarize/" + LintRuleMissingTimeout: "warning",
+		"fetch/" + LintRuleInsecureHTTP:       "error",
+		"route/" + LintRuleNoDefaultCase:      "warning",
+		"attempt/" + LintRuleTryWithoutCatch:  "warning",
+		"fetch/" + LintRuleUnusedOutput:       "warning",
+	}
+	if len(got) != len(want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+	for key, severity := range want {
+		if got[key] != severity {
+			t.Errorf("finding %s severity = %q, want %q", key, got[key], severity)
+		}
+	}
+}
+
+func TestWorkflowLint_Suppression(t *testing.T) {
+	wf, err := New(nil, "ops/health-check", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.HttpGet("fetch", "http://localhost:8080/health", nil).SuppressLint(LintRuleInsecureHTTP)
+	wf.HttpGet("fetchOther", "http://localhost:8081/health", nil)
+	wf.CallAgent("summarize", &AgentCallArgs{Agent: "summarizer", Message: "Summarize"})
+	wf.SuppressLint(LintRuleMissingTimeout, LintRuleUnusedOutput)
+
+	findings := wf.Lint()
+	if len(findings) != 1 {
+		t.Fatalf("findings = %v, want 1", findings)
+	}
+	if findings[0].Task != "fetchOther" || findings[0].RuleID != LintRuleInsecureHTTP {
+		t.Errorf("finding = %v, want insecure-http on fetchOther", findings[0])
+	}
+	want := `error: workflow "health-check" task "fetchOther": endpoint "http://localhost:8081/health" uses http://; use https:// [insecure-http]`
+	if findings[0].String() != want {
+		t.Errorf("String() = %q, want %q", findings[0].String(), want)
+	}
+}
+
+func TestWorkflowLint_VersionNotBumped(t *testing.T) {
+	newWorkflow := func(uri string) *Workflow {
+		wf, err := New(nil, "ops/health-check", &WorkflowArgs{Version: "1.0.0"})
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		wf.HttpGet("fetch", uri, nil)
+		return wf
+	}
+
+	previous, err := newWorkflow("https://api.example.com/v1/status").ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+	rule := VersionBumpRule(previous)
+
+	if findings := newWorkflow("https://api.example.com/v1/status").Lint(rule); len(findings) != 0 {
+		t.Errorf("unchanged workflow findings = %v, want none", findings)
+	}
+	findings := newWorkflow("https://api.example.com/v2/status").Lint(rule)
+	if len(findings) != 1 || findings[0].RuleID != LintRuleVersionNotBumped {
+		t.Errorf("changed workflow findings = %v, want version-not-bumped", findings)
+	}
+}
+
+func TestWorkflowLint_UnusedOutputAndVariables(t *testing.T) {
+	wf, err := New(nil, "ops/report", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.HttpGet("fetchStale", "https://api.example.com/legacy", nil)
+	wf.HttpGet("fetchUsers", "https://api.example.com/users", nil)
+	wf.HttpPost("notify", "https://hooks.example.com/ping", nil, nil)
+	vars := wf.Set("init", &SetArgs{Variables: map[string]interface{}{
+		"limit":  "10",
+		"unused": "x",
+		"region": "eu",
+	}})
+	wf.Set("summary", &SetArgs{Variables: map[string]interface{}{
+		"count":  "${ $context[\"fetchUsers\"].total }",
+		"region": vars.Field("region").Expression(),
+		"limit":  "${ .limit }",
+	}})
+
+	got := make(map[string]string)
+	for _, f := range wf.Lint(DefaultLintRules()...) {
+		if f.RuleID == LintRuleUnusedOutput || f.RuleID == LintRuleUnusedVariable {
+			got[f.Task+"/"+f.RuleID] = f.Message
+		}
+	}
+	want := map[string]string{
+		"fetchStale/" + LintRuleUnusedOutput: "output of HTTP GET is never referenced by another task; remove the task or use its output",
+		"init/" + LintRuleUnusedVariable:     `variable "unused" is set but never read`,
+	}
+	if len(got) != len(want) {
+		t.Errorf("findings = %v, want %v", got, want)
+	}
+	for key, message := range want {
+		if got[key] != message {
+			t.Errorf("finding %s = %q, want %q", key, got[key], message)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/outputschema"
+)
+
+// Projection transforms each element of the array passed to Map.
+// Create one with Pick or Project.
+type Projection struct {
+	jq  string
+	err string
+}
+
+// Pick keeps a single field of each element. The path may be nested, such
+// as "attributes.name".
+//
+// Example:
+//
+//	workflow.Pick("id") // [{"id": 1, ...}, {"id": 2, ...}] -> [1, 2]
+func Pick(path string) Projection {
+	jq, err := elementPath(path)
+	if err != "" {
+		return Projection{err: fmt.Sprintf("Pick: %s", err)}
+	}
+	return Projection{jq: jq}
+}
+
+// Project builds an object from each element, with each key of fields set to
+// the element field given as its value. Paths may be nested.
+//
+// Example:
+//
+//	workflow.Project(map[string]string{"id": "id", "title": "attributes.name"})
+//	// [{"id": 1, "attributes": {"name": "a"}}] -> [{"id": 1, "title": "a"}]
+func Project(fields map[string]string) Projection {
+	if len(fields) == 0 {
+		return Projection{err: "Project needs at least one field"}
+	}
+
+	pairs := make([]string, 0, len(fields))
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		if key == "" {
+			return Projection{err: "Project: output key must not be empty"}
+		}
+		jq, err := elementPath(fields[key])
+		if err != "" {
+			return Projection{err: fmt.Sprintf("Project: field %q: %s", key, err)}
+		}
+		pairs = append(pairs, fmt.Sprintf("%q: %s", key, jq))
+	}
+	return Projection{jq: fmt.Sprintf("{%s}", strings.Join(pairs, ", "))}
+}
+
+// jqIdentifier matches path segments that can follow a dot in JQ.
+var jqIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// elementPath returns the JQ path of a dot-separated field path relative to
+// the current element, or a description of why the path is invalid.
+// Segments that are not identifiers use bracket notation.
+func elementPath(path string) (string, string) {
+	if path == "" {
+		return "", "field path must not be empty"
+	}
+
+	var b strings.Builder
+	for _, segment := range strings.Split(path, ".") {
+		switch {
+		case segment == "":
+			return "", fmt.Sprintf("field path %q has an empty segment", path)
+		case jqIdentifier.MatchString(segment):
+			b.WriteString("." + segment)
+		case b.Len() == 0:
+			fmt.Fprintf(&b, ".[%q]", segment)
+		default:
+			fmt.Fprintf(&b, "[%q]", segment)
+		}
+	}
+	return b.String(), ""
+}
+
+// mapping is the source and projection of a task created with Map, kept on
+// the task for validation.
+type mapping struct {
+	source     TaskFieldRef
+	projection Projection
+}
+
+// Map creates a SET task that transforms each element of an array output of
+// another task, without a ForEach loop. The result is a single expression
+// evaluated by the runner, stored in the task's "result" output:
+//
+//	{"result": [...]}
+//
+// The task depends on the source task. When the source task declares its
+// output, such as an agent call with an output schema, synthesis fails with
+// ErrInvalidMap unless the source field is an array.
+//
+// Example:
+//
+//	fetch := wf.HttpGet("fetch", itemsURL, nil)
+//	ids := wf.Map("ids", fetch.Field("items"), workflow.Pick("id"))
+//	wf.HttpPost("tag", tagURL, nil, map[string]interface{}{
+//	    "ids": ids.Field("result"),
+//	})
+func Map(name string, source TaskFieldRef, projection Projection) *Task {
+	task := Set(name, &SetArgs{Variables: map[string]interface{}{
+		"result": fmt.Sprintf("${ %s | map(%s) }", source.path(), projection.jq),
+	}})
+	task.mapping = &mapping{source: source, projection: projection}
+	return task
+}
+
+// Map creates a task transforming an array output and adds it to the
+// workflow.
+//
+// Example:
+//
+//	titles := wf.Map("titles", fetch.Field("items"), workflow.Project(map[string]string{
+//	    "id":    "id",
+//	    "title": "attributes.name",
+//	}))
+func (w *Workflow) Map(name string, source TaskFieldRef, projection Projection) *Task {
+	task := Map(name, source, projection)
+	w.AddTask(task)
+	return task
+}
+
+// validateMap checks the projection of a task created with Map, and that the
+// source field is an array when the source task's output schema is known.
+func (t *Task) validateMap() error {
+	m := t.mapping
+	if m == nil {
+		return nil
+	}
+
+	if m.projection.err != "" {
+		return NewValidationErrorWithCause(
+			"map.projection",
+			"",
+			"valid",
+			fmt.Sprintf("task %q: %s", t.Name, m.projection.err),
+			ErrInvalidMap,
+		)
+	}
+	if m.projection.jq == "" {
+		return NewValidationErrorWithCause(
+			"map.projection",
+			"",
+			"required",
+			fmt.Sprintf("task %q: Map needs a projection (Pick or Project)", t.Name),
+			ErrInvalidMap,
+		)
+	}
+	if m.source.FieldName() == "" {
+		return NewValidationErrorWithCause(
+			"map.source",
+			"",
+			"required",
+			fmt.Sprintf("task %q: Map needs a source field", t.Name),
+			ErrInvalidMap,
+		)
+	}
+
+	if cfg, ok := m.source.task.agentCallConfig(); ok && cfg.Config != nil && len(cfg.Config.OutputSchema) > 0 {
+		schema, err := outputschema.Normalize(normalizeMapForProto(cfg.Config.OutputSchema))
+		if err != nil {
+			// Invalid schemas are reported by the source task
+			return nil
+		}
+		return t.validateMapSource(schema)
+	}
+	return nil
+}
+
+// validateMapSource checks that the source field of a Map task is an array
+// according to the normalized output schema of the source task. Fields the
+// schema does not describe are accepted.
+func (t *Task) validateMapSource(schema map[string]any) error {
+	field, err := outputschema.Lookup(schema, t.mapping.source.FieldName())
+	if err != nil {
+		// Unknown fields are reported by the output schema check of the source
+		return nil
+	}
+	if typ := outputschema.Type(field); typ != "" && typ != "array" {
+		return NewValidationErrorWithCause(
+			"map.source",
+			t.mapping.source.Name(),
+			"array",
+			fmt.Sprintf("task %q: Map source %s is declared as %s, not an array",
+				t.Name, t.mapping.source.Name(), typ),
+			ErrInvalidMap,
+		)
+	}
+	return nil
+}
+
+// agentCallConfig returns the config of an AGENT_CALL task.
+func (t *Task) agentCallConfig() (*AgentCallTaskConfig, bool) {
+	if t == nil || t.Kind != TaskKindAgentCall {
+		return nil, false
+	}
+	cfg, ok := t.Config.(*AgentCallTaskConfig)
+	return cfg, ok
+}
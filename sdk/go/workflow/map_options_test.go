@@ -0,0 +1,130 @@
+package workflow
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+)
+
+func TestMap_Synthesis(t *testing.T) {
+	wf, err := New(nil, "ops/tag-items", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	fetch := wf.HttpGet("fetch", "https://api.example.com/items", nil)
+	ids := wf.Map("ids", fetch.Field("items"), Pick("id"))
+	titles := wf.Map("titles", fetch.Field("data.items"), Project(map[string]string{
+		"title": "attributes.name",
+		"id":    "id",
+		"ref":   "meta.external-id",
+	}))
+	wf.Set("report", &SetArgs{Variables: map[string]interface{}{
+		"ids":    ids.Field("result").Expression(),
+		"titles": titles.Field("result").Expression(),
+	}})
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	tasks := pb.GetSpec().GetTasks()
+	result := func(i int) string {
+		return tasks[i].GetTaskConfig().GetFields()["variables"].GetStructValue().GetFields()["result"].GetStringValue()
+	}
+	if got, want := result(1), `${ $context["fetch"].items | map(.id) }`; got != want {
+		t.Errorf("ids result = %q, want %q", got, want)
+	}
+	want := `${ $context["fetch"].data.items | map({"id": .id, "ref": .meta["external-id"], "title": .attributes.name}) }`
+	if got := result(2); got != want {
+		t.Errorf("titles result = %q, want %q", got, want)
+	}
+
+	deps := wf.Dependencies()
+	if !slices.Equal(deps["ids"], []string{"fetch"}) || !slices.Equal(deps["titles"], []string{"fetch"}) {
+		t.Errorf("Dependencies() = %v, want ids and titles to depend on fetch", deps)
+	}
+}
+
+func TestMap_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		projection Projection
+	}{
+		{"empty pick", Pick("")},
+		{"empty path segment", Pick("attributes..name")},
+		{"empty project", Project(nil)},
+		{"empty project key", Project(map[string]string{"": "id"})},
+		{"empty project path", Project(map[string]string{"id": ""})},
+		{"zero projection", Projection{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "ops/map", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			fetch := wf.HttpGet("fetch", "https://api.example.com/items", nil)
+			wf.Map("ids", fetch.Field("items"), tt.projection)
+
+			if _, err := wf.ToProto(); !errors.Is(err, ErrInvalidMap) {
+				t.Errorf("ToProto() error = %v, want ErrInvalidMap", err)
+			}
+		})
+	}
+}
+
+func TestMap_SourceMustBeArray(t *testing.T) {
+	newWorkflow := func(field string) *Workflow {
+		wf, err := New(nil, "review/pr-review", &WorkflowArgs{Version: "1.0.0"})
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		review := wf.CallAgent("review", &AgentCallArgs{
+			Agent:   "code-reviewer",
+			Message: "Review the PR",
+			Config: &types.AgentExecutionConfig{
+				Timeout: 300,
+				OutputSchema: map[string]any{
+					"severity": "string",
+					"issues":   []any{map[string]any{"title": "string"}},
+				},
+			},
+		})
+		wf.Map("titles", review.Field(field), Pick("title"))
+		return wf
+	}
+
+	if _, err := newWorkflow("issues").ToProto(); err != nil {
+		t.Errorf("ToProto() with an array source failed: %v", err)
+	}
+	if _, err := newWorkflow("severity").ToProto(); !errors.Is(err, ErrInvalidMap) {
+		t.Errorf("ToProto() error = %v, want ErrInvalidMap for a string source", err)
+	}
+}
+
+func TestMap_SourceMustBeArray_AgentSchema(t *testing.T) {
+	schemas := map[string]map[string]any{
+		"code-reviewer": {
+			"type": "object",
+			"properties": map[string]any{
+				"severity": map[string]any{"type": "string"},
+			},
+		},
+	}
+
+	wf, err := New(nil, "review/pr-review", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	review := wf.CallAgent("review", &AgentCallArgs{Agent: "code-reviewer", Message: "Review the PR"})
+	wf.Map("severities", review.Field("severity"), Pick("level"))
+
+	if err := wf.CheckAgentOutputSchemas(schemas); !errors.Is(err, ErrInvalidMap) {
+		t.Errorf("CheckAgentOutputSchemas() error = %v, want ErrInvalidMap", err)
+	}
+}
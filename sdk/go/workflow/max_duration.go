@@ -0,0 +1,81 @@
+package workflow
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// minMaxDuration is the shortest maximum duration a workflow can have
+// (mirrors the WorkflowSpec.max_duration_seconds constraint).
+const minMaxDuration = 10 * time.Second
+
+// WithMaxDuration bounds the wall-clock duration of each execution of the
+// workflow. Durations are rounded up to whole seconds.
+//
+// When an execution runs past d, the runner stops its tasks and the execution
+// fails with classification WORKFLOW_TIMEOUT. Handlers set with WithOnFailure
+// still run, with err.Type() set to ErrorTypeWorkflowTimeout, within a grace
+// period after the maximum.
+//
+// New fails with ErrInvalidMaxDuration if d is shorter than 10 seconds. The
+// timeout-exceeds-max-duration lint rule warns about tasks whose
+// ExecutionTimeout is longer than d, since the maximum stops them first.
+//
+// Example:
+//
+//	wf, err := workflow.New(ctx, "ops/nightly-report", nil,
+//	    workflow.WithMaxDuration(15*time.Minute),
+//	    workflow.WithOnFailure(func(err workflow.ErrorRef) *workflow.Task {
+//	        return workflow.HttpPost("notifyOps", opsWebhook, nil, map[string]interface{}{
+//	            "type":           err.Type(),
+//	            "classification": err.Classification(),
+//	        })
+//	    }),
+//	)
+func WithMaxDuration(d time.Duration) WorkflowOption {
+	return func(w *Workflow) {
+		w.MaxDuration = d
+	}
+}
+
+// validateMaxDuration checks the maximum duration against the 10 second floor.
+func (w *Workflow) validateMaxDuration() error {
+	if w.MaxDuration == 0 || w.MaxDuration >= minMaxDuration {
+		return nil
+	}
+	return NewValidationErrorWithCause(
+		"max_duration",
+		w.MaxDuration.String(),
+		"gte",
+		fmt.Sprintf("maximum duration %s is shorter than %s", w.MaxDuration, minMaxDuration),
+		ErrInvalidMaxDuration,
+	)
+}
+
+// maxDurationSeconds returns the maximum duration in whole seconds, rounded up.
+func (w *Workflow) maxDurationSeconds() (int32, error) {
+	if err := w.validateMaxDuration(); err != nil {
+		return 0, err
+	}
+	return int32(math.Ceil(w.MaxDuration.Seconds())), nil
+}
+
+// checkTimeoutExceedsMaxDuration reports tasks whose execution timeout is
+// longer than the workflow maximum duration.
+func checkTimeoutExceedsMaxDuration(w *Workflow) []LintFinding {
+	if w.MaxDuration <= 0 {
+		return nil
+	}
+	var findings []LintFinding
+	for _, task := range w.Tasks {
+		if task.ExecutionTimeoutAfter > w.MaxDuration {
+			findings = append(findings, LintFinding{
+				Task: task.Name,
+				Message: fmt.Sprintf("execution timeout %s exceeds the workflow maximum duration %s, which stops the task first",
+					task.ExecutionTimeoutAfter, w.MaxDuration),
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,86 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithMaxDuration_Synthesis(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxDuration time.Duration
+		wantSeconds int32
+	}{
+		{name: "unset", maxDuration: 0, wantSeconds: 0},
+		{name: "floor", maxDuration: 10 * time.Second, wantSeconds: 10},
+		{name: "minutes", maxDuration: 15 * time.Minute, wantSeconds: 900},
+		{name: "rounded up", maxDuration: 90*time.Second + time.Millisecond, wantSeconds: 91},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "ops/nightly-report", &WorkflowArgs{Version: "1.0.0"}, WithMaxDuration(tt.maxDuration))
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.HttpGet("fetch", "https://api.example.com/report", nil)
+
+			pb, err := wf.ToProto()
+			if err != nil {
+				t.Fatalf("ToProto() failed: %v", err)
+			}
+			if got := pb.GetSpec().GetMaxDurationSeconds(); got != tt.wantSeconds {
+				t.Errorf("max_duration_seconds = %d, want %d", got, tt.wantSeconds)
+			}
+		})
+	}
+}
+
+func TestWithMaxDuration_Floor(t *testing.T) {
+	for _, d := range []time.Duration{time.Second, 9*time.Second + 999*time.Millisecond, -time.Minute} {
+		if _, err := New(nil, "ops/nightly-report", nil, WithMaxDuration(d)); !errors.Is(err, ErrInvalidMaxDuration) {
+			t.Errorf("New(WithMaxDuration(%s)) error = %v, want %v", d, err, ErrInvalidMaxDuration)
+		}
+	}
+
+	// The field can also be changed after New
+	wf, err := New(nil, "ops/nightly-report", nil)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.HttpGet("fetch", "https://api.example.com/report", nil)
+	wf.MaxDuration = 5 * time.Second
+	if _, err := wf.ToProto(); !errors.Is(err, ErrInvalidMaxDuration) {
+		t.Errorf("ToProto() error = %v, want %v", err, ErrInvalidMaxDuration)
+	}
+}
+
+func TestLint_TimeoutExceedsMaxDuration(t *testing.T) {
+	wf, err := New(nil, "ops/nightly-report", nil, WithMaxDuration(15*time.Minute))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.HttpGet("fetch", "https://api.example.com/report", nil).ExecutionTimeout(time.Minute)
+	wf.HttpGet("export", "https://api.example.com/export", nil).ExecutionTimeout(time.Hour)
+
+	var found []LintFinding
+	for _, f := range wf.Lint() {
+		if f.RuleID == LintRuleTimeoutExceedsMaxDuration {
+			found = append(found, f)
+		}
+	}
+	if len(found) != 1 || found[0].Task != "export" {
+		t.Fatalf("findings = %v, want one for task export", found)
+	}
+	if found[0].Severity != LintSeverityWarning {
+		t.Errorf("severity = %s, want warning", found[0].Severity)
+	}
+
+	// Without a maximum, no task is reported
+	wf.MaxDuration = 0
+	for _, f := range wf.Lint() {
+		if f.RuleID == LintRuleTimeoutExceedsMaxDuration {
+			t.Errorf("unexpected finding without a maximum duration: %v", f)
+		}
+	}
+}
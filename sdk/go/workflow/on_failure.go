@@ -0,0 +1,88 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// onFailureError references the workflow failure, which the runner stores in
+// the "error" variable of the workflow data for the handler tasks of
+// WithOnFailure.
+var onFailureError = ErrorRef{varName: "error", root: "$data."}
+
+// WithOnFailure runs cleanup or notification tasks when the workflow fails.
+//
+// Each handler is called once by New with a reference to the failure and
+// returns a task built with the standalone constructors (HttpPost, SetVars,
+// ...); the tasks run in order after a task of the workflow fails and the
+// failure is not caught by a Try. The reference exposes the error message,
+// type, classification (such as "UPSTREAM_5XX") and the name of the failed
+// task.
+//
+// The handler tasks do not run when the workflow succeeds. If a handler task
+// fails, the remaining handler tasks are skipped and the execution still fails
+// with the original error. Handler task names must be distinct from the names
+// of the workflow tasks.
+//
+// The handler tasks also run when the execution is cancelled, which stays
+// cancelled afterwards; err.Type() is then ErrorTypeCancelled and
+// err.Classification() is "CANCELLED". When the execution runs past the
+// maximum set with WithMaxDuration, err.Type() is ErrorTypeWorkflowTimeout
+// and err.Classification() is "WORKFLOW_TIMEOUT".
+//
+// Example:
+//
+//	wf, err := workflow.New(ctx, "billing/charge", nil,
+//	    workflow.WithOnFailure(
+//	        func(err workflow.ErrorRef) *workflow.Task {
+//	            return workflow.HttpPost("notifyOps", opsWebhook, nil, map[string]interface{}{
+//	                "task":           err.Task(),
+//	                "error":          err.Message(),
+//	                "classification": err.Classification(),
+//	            })
+//	        },
+//	    ),
+//	)
+func WithOnFailure(handlers ...func(err ErrorRef) *Task) WorkflowOption {
+	return func(w *Workflow) {
+		for _, handler := range handlers {
+			w.OnFailure = append(w.OnFailure, handler(onFailureError))
+		}
+	}
+}
+
+// validateOnFailure checks the handler tasks set with WithOnFailure: each is
+// non-nil and has a valid name, unique among the workflow and handler tasks.
+func (w *Workflow) validateOnFailure() error {
+	names := make(map[string]bool, len(w.Tasks)+len(w.OnFailure))
+	for _, task := range w.Tasks {
+		names[task.Name] = true
+	}
+
+	for i, task := range w.OnFailure {
+		if task == nil {
+			return validation.NewValidationErrorWithCause(
+				validation.FieldPath("onFailure", i),
+				"",
+				"required",
+				fmt.Sprintf("on-failure handler %d returned no task", i),
+				ErrInvalidOnFailure,
+			)
+		}
+		if err := validateTaskName(task.Name); err != nil {
+			return fmt.Errorf("onFailure[%d]: %w", i, err)
+		}
+		if names[task.Name] {
+			return validation.NewValidationErrorWithCause(
+				validation.FieldPath("onFailure", i, "name"),
+				task.Name,
+				"unique",
+				fmt.Sprintf("duplicate task name: %q (on-failure task names must be distinct from the workflow task names)", task.Name),
+				ErrDuplicateTaskName,
+			)
+		}
+		names[task.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew_WithOnFailure(t *testing.T) {
+	var ref ErrorRef
+	wf, err := New(nil, "billing/charge", &WorkflowArgs{Version: "1.0.0"},
+		WithOnFailure(
+			func(err ErrorRef) *Task {
+				ref = err
+				return HttpPost("notifyOps", "https://ops.example.com/hooks", nil, map[string]interface{}{
+					"task":           err.Task(),
+					"error":          err.Message(),
+					"classification": err.Classification(),
+				})
+			},
+			func(err ErrorRef) *Task {
+				return SetVars("markFailed", "failed", true)
+			},
+		),
+	)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.HttpPost("charge", "https://payments.example.com/charges", nil, map[string]interface{}{"amount": 1250})
+
+	if got := ref.Message(); got != "${$data.error.message}" {
+		t.Errorf("Message() = %q, want ${$data.error.message}", got)
+	}
+	if got := ref.Classification(); got != "${$data.error.classification}" {
+		t.Errorf("Classification() = %q, want ${$data.error.classification}", got)
+	}
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+	spec := pb.GetSpec()
+	if len(spec.GetTasks()) != 1 || spec.GetTasks()[0].GetName() != "charge" {
+		t.Fatalf("tasks = %v, want only charge", spec.GetTasks())
+	}
+	onFailure := spec.GetOnFailure()
+	if len(onFailure) != 2 || onFailure[0].GetName() != "notifyOps" || onFailure[1].GetName() != "markFailed" {
+		t.Fatalf("on_failure = %v, want notifyOps then markFailed", onFailure)
+	}
+	body := onFailure[0].GetTaskConfig().GetFields()["body"].GetStructValue().GetFields()
+	if got := body["task"].GetStringValue(); got != "${$data.error.task}" {
+		t.Errorf("body.task = %q, want ${$data.error.task}", got)
+	}
+}
+
+func TestNew_WithOnFailureValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(err ErrorRef) *Task
+		wantErr error
+	}{
+		{
+			name:    "nil task",
+			handler: func(err ErrorRef) *Task { return nil },
+			wantErr: ErrInvalidOnFailure,
+		},
+		{
+			name:    "name of a workflow task",
+			handler: func(err ErrorRef) *Task { return SetVars("charge", "failed", true) },
+			wantErr: ErrDuplicateTaskName,
+		},
+		{
+			name:    "invalid name",
+			handler: func(err ErrorRef) *Task { return SetVars("mark failed", "failed", true) },
+			wantErr: ErrInvalidTaskName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "billing/charge", &WorkflowArgs{Version: "1.0.0"}, WithOnFailure(tt.handler))
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.HttpPost("charge", "https://payments.example.com/charges", nil, nil)
+
+			if _, err := wf.ToProto(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToProto() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportYAML_OnFailure(t *testing.T) {
+	wf, err := New(nil, "billing/charge", &WorkflowArgs{Version: "1.0.0"},
+		WithOnFailure(func(err ErrorRef) *Task { return SetVars("markFailed", "failed", true) }),
+	)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.HttpPost("charge", "https://payments.example.com/charges", nil, nil)
+
+	if _, err := ExportYAML(wf); !errors.Is(err, ErrNotExportable) {
+		t.Errorf("ExportYAML() error = %v, want ErrNotExportable", err)
+	}
+}
@@ -0,0 +1,122 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/outputschema"
+)
+
+// CheckAgentOutputSchemas validates Field() references on AGENT_CALL tasks
+// against the output schemas of the agents they call, and that the sources
+// of Map tasks reading those tasks are arrays.
+//
+// schemas maps agent slugs to output schemas (as set by agent.WithOutputSchema).
+// Tasks that declare their own schema in Config.OutputSchema are checked
+// against that schema instead, during ToProto. Tasks calling agents without a
+// known schema are skipped.
+//
+// stigmer.Context calls this during synthesis with the agents registered in
+// the same program.
+func (w *Workflow) CheckAgentOutputSchemas(schemas map[string]map[string]any) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, task := range w.Tasks {
+		if err := task.checkMapSourceSchema(schemas); err != nil {
+			return err
+		}
+
+		cfg, ok := task.Config.(*AgentCallTaskConfig)
+		if !ok || task.Kind != TaskKindAgentCall {
+			continue
+		}
+		if cfg.Config != nil && len(cfg.Config.OutputSchema) > 0 {
+			continue
+		}
+
+		schema, ok := schemas[cfg.Agent]
+		if !ok {
+			continue
+		}
+		if err := task.validateOutputSchemaReferences(schema); err != nil {
+			return fmt.Errorf("task %s: %w", task.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// prepareAgentOutputSchema normalizes a per-task output schema override and
+// checks the task's Field() references against it. Context variables in the
+// schema, such as an ObjectRef, are expanded into their values.
+func (t *Task) prepareAgentOutputSchema() error {
+	cfg, ok := t.Config.(*AgentCallTaskConfig)
+	if !ok || cfg.Config == nil || len(cfg.Config.OutputSchema) == 0 {
+		return nil
+	}
+
+	// The schema is stored with the task: context variables known at
+	// synthesis are expanded, runtime references cannot be
+	if path, ok := runtimeRefPath("output_schema", cfg.Config.OutputSchema); ok {
+		return NewValidationErrorWithCause(
+			path,
+			"",
+			"literal",
+			fmt.Sprintf("task %q: %s is only resolved at runtime; the output schema accepts literals and "+
+				"context variables known at synthesis", t.Name, path),
+			ErrRuntimeValueInLiteral,
+		)
+	}
+
+	schema, err := outputschema.Normalize(normalizeMapForProto(cfg.Config.OutputSchema))
+	if err != nil {
+		return NewValidationErrorWithCause(
+			"output_schema",
+			"",
+			"schema",
+			err.Error(),
+			ErrInvalidOutputSchema,
+		)
+	}
+	cfg.Config.OutputSchema = schema
+
+	return t.validateOutputSchemaReferences(schema)
+}
+
+// validateOutputSchemaReferences checks that every path referenced via
+// Field() is declared by the agent output schema.
+func (t *Task) validateOutputSchemaReferences(schema map[string]any) error {
+	for _, path := range t.referencedPaths {
+		if err := outputschema.Resolve(schema, path); err != nil {
+			return NewValidationErrorWithCause(
+				"field",
+				path,
+				"output_schema",
+				fmt.Sprintf("task %q: %v", t.Name, err),
+				ErrFieldNotInOutputSchema,
+			)
+		}
+	}
+	return nil
+}
+
+// checkMapSourceSchema checks the source field of a Map task reading an
+// AGENT_CALL task against the output schema of the called agent. Tasks that
+// declare their own schema are checked during ToProto instead.
+func (t *Task) checkMapSourceSchema(schemas map[string]map[string]any) error {
+	if t.mapping == nil {
+		return nil
+	}
+	cfg, ok := t.mapping.source.task.agentCallConfig()
+	if !ok || (cfg.Config != nil && len(cfg.Config.OutputSchema) > 0) {
+		return nil
+	}
+	schema, ok := schemas[cfg.Agent]
+	if !ok {
+		return nil
+	}
+	if err := t.validateMapSource(schema); err != nil {
+		return fmt.Errorf("task %s: %w", t.Name, err)
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package workflow
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 
 	"buf.build/go/protovalidate"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -10,10 +12,11 @@ import (
 
 	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
 	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
-	tasksv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
 	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
 	"github.com/stigmer/stigmer/sdk/go/environment"
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
+	genWorkflow "github.com/stigmer/stigmer/sdk/go/gen/workflow"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
 )
 
 // validator is the global protovalidate validator instance.
@@ -37,11 +40,9 @@ func init() {
 //
 // Example:
 //
-//	wf, _ := workflow.New(ctx,
-//	    workflow.WithNamespace("data-processing"),
-//	    workflow.WithName("daily-sync"),
-//	    workflow.WithVersion("1.0.0"),
-//	)
+//	wf, _ := workflow.New(ctx, "data-processing/daily-sync", &workflow.WorkflowArgs{
+//	    Version: "1.0.0",
+//	})
 //	proto, err := wf.ToProto()
 func (w *Workflow) ToProto() (*workflowv1.Workflow, error) {
 	// Convert environment variables
@@ -50,16 +51,69 @@ func (w *Workflow) ToProto() (*workflowv1.Workflow, error) {
 		return nil, fmt.Errorf("failed to convert environment variables: %w", err)
 	}
 
+	if err := validateNilTaskReferences(w.Tasks); err != nil {
+		return nil, err
+	}
+	if err := validateSwitchTargets(w.Tasks); err != nil {
+		return nil, err
+	}
+	if err := validateUnsetReferences(w.Tasks); err != nil {
+		return nil, err
+	}
+	if err := validateRunIfGuards(w.Tasks); err != nil {
+		return nil, err
+	}
+	if err := validateWeakReferences(w.Tasks); err != nil {
+		return nil, err
+	}
+	if err := validateRawExpressions(w.Tasks); err != nil {
+		return nil, err
+	}
+	if err := w.validateOnFailure(); err != nil {
+		return nil, err
+	}
+	if err := validateNilTaskReferences(w.OnFailure); err != nil {
+		return nil, err
+	}
+	if err := validateSwitchTargets(w.OnFailure); err != nil {
+		return nil, err
+	}
+	if err := validateRunIfGuards(w.OnFailure); err != nil {
+		return nil, err
+	}
+	if err := validateRawExpressions(w.OnFailure); err != nil {
+		return nil, err
+	}
+
 	// Convert tasks
 	tasks, err := convertTasks(w.Tasks)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert tasks: %w", err)
 	}
 
+	// Point expressions rendered before a Rename at the current task names
+	renames, err := w.taskRenames()
+	if err != nil {
+		return nil, err
+	}
+	rewriteRenamedReferences(tasks, renames)
+
+	onFailure, err := convertTasks(w.OnFailure)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert on-failure tasks: %w", err)
+	}
+	rewriteRenamedReferences(onFailure, renames)
+
+	maxDurationSeconds, err := w.maxDurationSeconds()
+	if err != nil {
+		return nil, err
+	}
+
 	// Build metadata
 	metadata := &apiresource.ApiResourceMetadata{
 		Name:        w.Document.Name,
 		Slug:        w.Slug, // Include slug for backend resolution
+		Labels:      w.Labels,
 		Annotations: SDKAnnotations(),
 		// Default to organization scope for SDK-created workflows
 		// This satisfies the CEL validation: owner_scope must be platform (1) or organization (2)
@@ -81,16 +135,21 @@ func (w *Workflow) ToProto() (*workflowv1.Workflow, error) {
 		Kind:       "Workflow",
 		Metadata:   metadata,
 		Spec: &workflowv1.WorkflowSpec{
-			Description: w.Description,
-			Document:    document,
-			Tasks:       tasks,
-			EnvSpec:     envSpec,
+			Description:        w.Description,
+			Document:           document,
+			Tasks:              tasks,
+			EnvSpec:            envSpec,
+			ConcurrencyPolicy:  w.ConcurrencyPolicy.toProto(),
+			Schedule:           w.Schedule.ToProto(),
+			OnFailure:          onFailure,
+			MaxDurationSeconds: maxDurationSeconds,
 		},
 	}
 
 	// Validate the proto message against buf.validate rules
 	if err := validator.Validate(workflow); err != nil {
-		return nil, fmt.Errorf("workflow validation failed: %w", err)
+		return nil, fmt.Errorf("workflow validation failed: %w",
+			validation.NewConversionErrorFromViolations("Workflow", "", err))
 	}
 
 	return workflow, nil
@@ -109,6 +168,7 @@ func convertEnvironmentVariables(envVars []environment.Variable) (*environmentv1
 			Value:       v.DefaultValue,
 			IsSecret:    v.IsSecret,
 			Description: v.Description,
+			Type:        v.Type.ToProto(),
 		}
 	}
 
@@ -125,8 +185,81 @@ func convertTasks(tasks []*Task) ([]*workflowv1.WorkflowTask, error) {
 
 	protoTasks := make([]*workflowv1.WorkflowTask, 0, len(tasks))
 
-	for _, task := range tasks {
-		protoTask, err := convertTask(task)
+	for i, task := range tasks {
+		if err := task.validateExpressionRefs(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateFieldReferences(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateSensitiveOutputs(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateDescription(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.prepareAgentOutputSchema(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateAttachments(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateAgentRef(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateTLS(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateCache(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateProxy(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateResponseFormat(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateExpectStatus(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateHeaderOnlyResponse(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateGrpcRequest(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateBody(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateEndpoint(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateApproval(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateRepeat(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateMap(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateCatches(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateRetry(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateSetVars(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateDuration(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+		if err := task.validateBranchRefs(); err != nil {
+			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
+		}
+
+		protoTask, err := convertTask(i, task)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert task %s: %w", task.Name, err)
 		}
@@ -149,38 +282,9 @@ func validateTaskConfigStruct(kind apiresource.WorkflowTaskKind, config *structp
 		return fmt.Errorf("failed to marshal Struct to JSON: %w", err)
 	}
 
-	// Create appropriate proto message based on kind
-	var protoMsg proto.Message
-
-	switch kind {
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SET:
-		protoMsg = &tasksv1.SetTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_HTTP_CALL:
-		protoMsg = &tasksv1.HttpCallTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_GRPC_CALL:
-		protoMsg = &tasksv1.GrpcCallTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_SWITCH:
-		protoMsg = &tasksv1.SwitchTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_FOR:
-		protoMsg = &tasksv1.ForTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_FORK:
-		protoMsg = &tasksv1.ForkTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_TRY:
-		protoMsg = &tasksv1.TryTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_LISTEN:
-		protoMsg = &tasksv1.ListenTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_WAIT:
-		protoMsg = &tasksv1.WaitTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_CALL_ACTIVITY:
-		protoMsg = &tasksv1.CallActivityTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_RAISE:
-		protoMsg = &tasksv1.RaiseTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_RUN:
-		protoMsg = &tasksv1.RunTaskConfig{}
-	case apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_AGENT_CALL:
-		protoMsg = &tasksv1.AgentCallTaskConfig{}
-	default:
-		return fmt.Errorf("unsupported task kind: %v", kind)
+	protoMsg, err := newTaskConfigMessage(kind)
+	if err != nil {
+		return err
 	}
 
 	// Unmarshal JSON to proto message
@@ -197,12 +301,26 @@ func validateTaskConfigStruct(kind apiresource.WorkflowTaskKind, config *structp
 	return nil
 }
 
+// newTaskConfigMessage returns an empty typed config message for a task kind,
+// as registered in the generated kind registry.
+func newTaskConfigMessage(kind apiresource.WorkflowTaskKind) (proto.Message, error) {
+	return genWorkflow.NewTaskConfigMessage(strings.TrimPrefix(kind.String(), "WORKFLOW_TASK_KIND_"))
+}
+
 // convertTask converts a single SDK Task to a proto WorkflowTask.
-func convertTask(task *Task) (*workflowv1.WorkflowTask, error) {
+// index is the position of the task in the workflow, used in conversion errors.
+func convertTask(index int, task *Task) (*workflowv1.WorkflowTask, error) {
+	// Custom tasks carry their registered kind in custom_kind
+	custom, isCustom := task.Config.(*CustomTaskConfig)
+
 	// Convert task kind to proto enum
-	kind, err := convertTaskKind(task.Kind)
-	if err != nil {
-		return nil, fmt.Errorf("invalid task kind %s: %w", task.Kind, err)
+	kind := apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_CUSTOM
+	if !isCustom {
+		var err error
+		kind, err = convertTaskKind(task.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task kind %s: %w", task.Kind, err)
+		}
 	}
 
 	// Convert task config to google.protobuf.Struct
@@ -211,9 +329,13 @@ func convertTask(task *Task) (*workflowv1.WorkflowTask, error) {
 		return nil, fmt.Errorf("failed to convert task config: %w", err)
 	}
 
-	// Validate task config by unmarshaling to typed proto and running buf.validate rules
-	if err := validateTaskConfigStruct(kind, taskConfig); err != nil {
-		return nil, err
+	// Validate task config by unmarshaling to typed proto and running buf.validate rules.
+	// Custom configs have no typed proto; the runner extension validates them.
+	if !isCustom {
+		if err := validateTaskConfigStruct(kind, taskConfig); err != nil {
+			return nil, validation.NewConversionErrorFromViolations(
+				"Workflow", validation.FieldPath("spec", "tasks", index, "task_config"), err)
+		}
 	}
 
 	// Build proto task
@@ -222,6 +344,9 @@ func convertTask(task *Task) (*workflowv1.WorkflowTask, error) {
 		Kind:       kind,
 		TaskConfig: taskConfig,
 	}
+	if isCustom {
+		protoTask.CustomKind = string(custom.Kind)
+	}
 
 	// Add export if set
 	if task.ExportAs != "" {
@@ -237,6 +362,24 @@ func convertTask(task *Task) (*workflowv1.WorkflowTask, error) {
 		}
 	}
 
+	// Add execution timeout if set
+	timeoutSeconds, err := task.executionTimeoutSeconds()
+	if err != nil {
+		return nil, err
+	}
+	protoTask.ExecutionTimeoutSeconds = timeoutSeconds
+
+	// Add sensitive output fields if set
+	if len(task.SensitiveOutputs) > 0 {
+		protoTask.SensitiveOutputFields = append([]string(nil), task.SensitiveOutputs...)
+	}
+
+	// Add run guard if set
+	protoTask.If = task.guardExpression()
+
+	// Add description if set
+	protoTask.Description = task.Description
+
 	return protoTask, nil
 }
 
@@ -314,8 +457,15 @@ func normalizeMapForProto(m map[string]interface{}) map[string]interface{} {
 
 // normalizeValueForProto normalizes a value for protobuf compatibility.
 func normalizeValueForProto(v interface{}) interface{} {
-	// Check if it's a Ref type (TaskFieldRef, StringRef, etc.)
-	// These need to be converted to their expression string
+	// Context variables known at synthesis (ObjectRef, ListRef, ...) are
+	// expanded into their literal values, so objects and lists keep their
+	// structure
+	if known, ok := knownRefValue(v); ok {
+		return normalizeValueForProto(known)
+	}
+
+	// Other Ref types (TaskFieldRef, computed refs, etc.) are resolved at
+	// runtime and need to be converted to their expression string
 	if ref, ok := v.(Ref); ok {
 		return ref.Expression()
 	}
@@ -337,6 +487,46 @@ func normalizeValueForProto(v interface{}) interface{} {
 			result[i] = normalizeValueForProto(item)
 		}
 		return result
+	default:
+		return normalizeReflectValueForProto(v)
+	}
+}
+
+// normalizeReflectValueForProto converts values structpb does not accept
+// directly, such as []string, map[string]int and named numeric types, to
+// their generic form. Other values are returned unchanged.
+func normalizeReflectValueForProto(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return v // []byte is encoded as base64 by structpb
+		}
+		result := make([]interface{}, rv.Len())
+		for i := range result {
+			result[i] = normalizeValueForProto(rv.Index(i).Interface())
+		}
+		return result
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return v
+		}
+		result := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			result[iter.Key().String()] = normalizeValueForProto(iter.Value().Interface())
+		}
+		return result
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
 	default:
 		return v
 	}
@@ -371,6 +561,36 @@ func taskToMap(task *Task) (map[string]interface{}, error) {
 		m["then"] = task.ThenTask
 	}
 
+	// Add execution timeout if set
+	timeoutSeconds, err := task.executionTimeoutSeconds()
+	if err != nil {
+		return nil, err
+	}
+	if timeoutSeconds > 0 {
+		m["executionTimeoutSeconds"] = timeoutSeconds
+	}
+
+	// Add sensitive output fields if set
+	if len(task.SensitiveOutputs) > 0 {
+		if err := task.validateSensitiveOutputs(); err != nil {
+			return nil, err
+		}
+		m["sensitiveOutputFields"] = append([]string(nil), task.SensitiveOutputs...)
+	}
+
+	// Add run guard if set
+	if guard := task.guardExpression(); guard != "" {
+		m["if"] = guard
+	}
+
+	// Add description if set
+	if task.Description != "" {
+		if err := task.validateDescription(); err != nil {
+			return nil, err
+		}
+		m["description"] = task.Description
+	}
+
 	return m, nil
 }
 
@@ -406,6 +626,12 @@ func taskConfigToMap(config TaskConfig) (map[string]interface{}, error) {
 		return forkTaskConfigToMap(c), nil
 	case *TryTaskConfig:
 		return tryTaskConfigToMap(c), nil
+	case *CustomTaskConfig:
+		config, err := c.toStruct()
+		if err != nil {
+			return nil, err
+		}
+		return config.AsMap(), nil
 	default:
 		return nil, fmt.Errorf("unsupported task config type: %T", config)
 	}
@@ -415,12 +641,15 @@ func taskConfigToMap(config TaskConfig) (map[string]interface{}, error) {
 func setTaskConfigToMap(c *SetTaskConfig) map[string]interface{} {
 	m := make(map[string]interface{})
 	if c.Variables != nil && len(c.Variables) > 0 {
-		// Convert map[string]string to map[string]interface{} for structpb compatibility
-		vars := make(map[string]interface{})
-		for k, v := range c.Variables {
-			vars[k] = v
+		// Values keep their types; references become expression strings
+		m["variables"] = normalizeMapForProto(c.Variables)
+	}
+	if len(c.Unset) > 0 {
+		unset := make([]interface{}, len(c.Unset))
+		for i, name := range c.Unset {
+			unset[i] = name
 		}
-		m["variables"] = vars
+		m["unset"] = unset
 	}
 	return m
 }
@@ -434,8 +663,8 @@ func httpCallTaskConfigToMap(c *HttpCallTaskConfig) map[string]interface{} {
 	}
 
 	// Build endpoint struct
-	if c.Endpoint != nil && c.Endpoint.Uri != nil {
-		uriStr := CoerceToString(c.Endpoint.Uri)
+	if c.Endpoint != nil {
+		uriStr := c.Endpoint.UriExpression()
 		if uriStr != "" {
 			endpoint := map[string]interface{}{
 				"uri": uriStr,
@@ -461,6 +690,88 @@ func httpCallTaskConfigToMap(c *HttpCallTaskConfig) map[string]interface{} {
 		m["timeout_seconds"] = c.TimeoutSeconds
 	}
 
+	if c.Tls != nil {
+		tls := make(map[string]interface{})
+		if c.Tls.ClientCert != "" {
+			tls["client_cert"] = c.Tls.ClientCert
+		}
+		if c.Tls.ClientKey != "" {
+			tls["client_key"] = c.Tls.ClientKey
+		}
+		if c.Tls.CaBundle != "" {
+			tls["ca_bundle"] = c.Tls.CaBundle
+		}
+		if c.Tls.CaBundleFile != "" {
+			tls["ca_bundle_file"] = c.Tls.CaBundleFile
+		}
+		if c.Tls.InsecureSkipVerify {
+			tls["insecure_skip_verify"] = true
+		}
+		if len(tls) > 0 {
+			m["tls"] = tls
+		}
+	}
+
+	if c.Cache != nil {
+		cache := map[string]interface{}{
+			"ttl_seconds": c.Cache.TtlSeconds,
+		}
+		if c.Cache.Key != "" {
+			cache["key"] = c.Cache.Key
+		}
+		if c.Cache.IncludeAuth {
+			cache["include_auth"] = true
+		}
+		m["cache"] = cache
+	}
+
+	if c.Proxy != nil {
+		switch {
+		case c.Proxy.Disabled:
+			m["proxy"] = map[string]interface{}{"disabled": true}
+		case c.Proxy.Url != "":
+			m["proxy"] = map[string]interface{}{"url": c.Proxy.Url}
+		}
+	}
+
+	if c.ResponseFormat != "" {
+		m["response_format"] = c.ResponseFormat
+	}
+
+	if c.BodyEncoding != "" {
+		m["body_encoding"] = c.BodyEncoding
+	}
+
+	if len(c.ExpectStatus) > 0 {
+		codes := make([]interface{}, 0, len(c.ExpectStatus))
+		for _, code := range c.ExpectStatus {
+			codes = append(codes, code)
+		}
+		m["expect_status"] = codes
+	}
+
+	if c.AllowAnyStatus {
+		m["allow_any_status"] = true
+	}
+
+	if len(c.MultipartParts) > 0 {
+		parts := make([]interface{}, 0, len(c.MultipartParts))
+		for _, p := range c.MultipartParts {
+			part := map[string]interface{}{"name": p.Name}
+			if p.Filename != "" {
+				part["filename"] = p.Filename
+			}
+			if p.ContentType != "" {
+				part["content_type"] = p.ContentType
+			}
+			if p.Base64 {
+				part["base64"] = true
+			}
+			parts = append(parts, part)
+		}
+		m["multipart_parts"] = parts
+	}
+
 	return m
 }
 
@@ -491,8 +802,8 @@ func agentCallTaskConfigToMap(c *AgentCallTaskConfig) map[string]interface{} {
 		m["agent"] = c.Agent
 	}
 
-	if c.Message != "" {
-		m["message"] = c.Message
+	if message := c.MessageExpression(); message != "" {
+		m["message"] = message
 	}
 
 	if c.Env != nil && len(c.Env) > 0 {
@@ -516,11 +827,51 @@ func agentCallTaskConfigToMap(c *AgentCallTaskConfig) map[string]interface{} {
 		if c.Config.Temperature > 0 {
 			configMap["temperature"] = c.Config.Temperature
 		}
+		if len(c.Config.OutputSchema) > 0 {
+			configMap["output_schema"] = c.Config.OutputSchema
+		}
 		if len(configMap) > 0 {
 			m["config"] = configMap
 		}
 	}
 
+	if c.StreamToContext {
+		m["stream_to_context"] = c.StreamToContext
+	}
+
+	if c.FinalOutputOnly {
+		m["final_output_only"] = c.FinalOutputOnly
+	}
+
+	if len(c.Attachments) > 0 {
+		attachments := make([]interface{}, 0, len(c.Attachments))
+		for _, a := range c.Attachments {
+			if a == nil {
+				continue
+			}
+			attachment := map[string]interface{}{
+				"name":    a.Name,
+				"content": a.ContentExpression(),
+			}
+			if a.MimeType != "" {
+				attachment["mime_type"] = a.MimeType
+			}
+			if a.MaxSizeBytes > 0 {
+				attachment["max_size_bytes"] = a.MaxSizeBytes
+			}
+			attachments = append(attachments, attachment)
+		}
+		m["attachments"] = attachments
+	}
+
+	if c.Org != "" {
+		m["org"] = c.Org
+	}
+
+	if c.Version != "" {
+		m["version"] = c.Version
+	}
+
 	return m
 }
 
@@ -552,12 +903,35 @@ func listenTaskConfigToMap(c *ListenTaskConfig) map[string]interface{} {
 				if sig.Type != "" {
 					sigMap["type"] = sig.Type
 				}
+				if acceptIf := sig.AcceptIfExpression(); acceptIf != "" {
+					sigMap["accept_if"] = acceptIf
+				}
 				signals[i] = sigMap
 			}
 			toMap["signals"] = signals
 		}
 		m["to"] = toMap
 	}
+	if c.Approval != nil {
+		approvalMap := make(map[string]interface{})
+		if len(c.Approval.Approvers) > 0 {
+			approvers := make([]interface{}, len(c.Approval.Approvers))
+			for i, approver := range c.Approval.Approvers {
+				approvers[i] = approver
+			}
+			approvalMap["approvers"] = approvers
+		}
+		if c.Approval.TimeoutSeconds != 0 {
+			approvalMap["timeout_seconds"] = c.Approval.TimeoutSeconds
+		}
+		if c.Approval.OnTimeout != "" {
+			approvalMap["on_timeout"] = c.Approval.OnTimeout
+		}
+		m["approval"] = approvalMap
+	}
+	if c.TimeoutSeconds != 0 {
+		m["timeout_seconds"] = c.TimeoutSeconds
+	}
 	return m
 }
 
@@ -568,7 +942,13 @@ func callActivityTaskConfigToMap(c *CallActivityTaskConfig) map[string]interface
 		m["activity"] = c.Activity
 	}
 	if c.Input != nil && len(c.Input) > 0 {
-		m["input"] = c.Input
+		m["input"] = normalizeMapForProto(c.Input)
+	}
+	if c.TaskQueue != "" {
+		m["task_queue"] = c.TaskQueue
+	}
+	if c.TimeoutSeconds > 0 {
+		m["timeout_seconds"] = c.TimeoutSeconds
 	}
 	return m
 }
@@ -576,8 +956,11 @@ func callActivityTaskConfigToMap(c *CallActivityTaskConfig) map[string]interface
 // raiseTaskConfigToMap converts RaiseTaskConfig to map.
 func raiseTaskConfigToMap(c *RaiseTaskConfig) map[string]interface{} {
 	m := make(map[string]interface{})
-	if c.Error != "" {
-		m["error"] = c.Error
+	if raised := c.ErrorExpression(); raised != "" {
+		m["error"] = raised
+	}
+	if message := c.MessageExpression(); message != "" {
+		m["message"] = message
 	}
 	return m
 }
@@ -589,7 +972,7 @@ func runTaskConfigToMap(c *RunTaskConfig) map[string]interface{} {
 		m["workflow"] = c.Workflow
 	}
 	if c.Input != nil && len(c.Input) > 0 {
-		m["input"] = c.Input
+		m["input"] = normalizeMapForProto(c.Input)
 	}
 	return m
 }
@@ -624,7 +1007,11 @@ func workflowTaskToMap(task *types.WorkflowTask) map[string]interface{} {
 	if task.Name != "" {
 		m["name"] = task.Name
 	}
-	if task.Kind != "" {
+	if _, isCustom := lookupCustomTaskKind(TaskKind(task.Kind)); isCustom {
+		// Custom tasks carry their registered kind in customKind
+		m["kind"] = convertTaskKindStringToProtoEnumName(string(reservedCustomTaskKind))
+		m["customKind"] = task.Kind
+	} else if task.Kind != "" {
 		// Convert SDK TaskKind string to proto enum constant name
 		// e.g., "SET" -> "WORKFLOW_TASK_KIND_SET"
 		m["kind"] = convertTaskKindStringToProtoEnumName(task.Kind)
@@ -642,6 +1029,9 @@ func workflowTaskToMap(task *types.WorkflowTask) map[string]interface{} {
 			"then": task.Flow.Then,
 		}
 	}
+	if task.Description != "" {
+		m["description"] = task.Description
+	}
 	return m
 }
 
@@ -675,12 +1065,8 @@ func forTaskConfigToMap(c *ForTaskConfig) map[string]interface{} {
 	if c.Each != "" {
 		m["each"] = c.Each
 	}
-	if c.In != nil {
-		// Use CoerceToString to handle Task references, strings, and expressions
-		inStr := CoerceToString(c.In)
-		if inStr != "" {
-			m["in"] = inStr
-		}
+	if in := c.InExpression(); in != "" {
+		m["in"] = in
 	}
 	if c.Do != nil && len(c.Do) > 0 {
 		// Convert []*types.WorkflowTask to []interface{} for structpb
@@ -690,6 +1076,15 @@ func forTaskConfigToMap(c *ForTaskConfig) map[string]interface{} {
 		}
 		m["do"] = do
 	}
+	if until := c.UntilExpression(); until != "" {
+		m["until"] = until
+	}
+	if c.MaxIterations != 0 {
+		m["max_iterations"] = c.MaxIterations
+	}
+	if c.ReportIterations {
+		m["report_iterations"] = c.ReportIterations
+	}
 	return m
 }
 
@@ -704,6 +1099,12 @@ func forkTaskConfigToMap(c *ForkTaskConfig) map[string]interface{} {
 		}
 		m["branches"] = branches
 	}
+	if c.Compete {
+		m["compete"] = c.Compete
+	}
+	if c.ContinueOnBranchError {
+		m["continue_on_branch_error"] = c.ContinueOnBranchError
+	}
 	return m
 }
 
@@ -719,19 +1120,66 @@ func tryTaskConfigToMap(c *TryTaskConfig) map[string]interface{} {
 		m["try"] = tryTasks
 	}
 	if c.Catch != nil {
-		// Convert *types.CatchBlock to map
-		catchMap := make(map[string]interface{})
-		if c.Catch.As != "" {
-			catchMap["as"] = c.Catch.As
-		}
-		if c.Catch.Do != nil && len(c.Catch.Do) > 0 {
-			doTasks := make([]interface{}, len(c.Catch.Do))
-			for i, task := range c.Catch.Do {
-				doTasks[i] = workflowTaskToMap(task)
-			}
-			catchMap["do"] = doTasks
+		m["catch"] = catchBlockToMap(c.Catch)
+	}
+	if len(c.Catches) > 0 {
+		catches := make([]interface{}, len(c.Catches))
+		for i, catch := range c.Catches {
+			catches[i] = catchBlockToMap(catch)
 		}
-		m["catch"] = catchMap
+		m["catches"] = catches
+	}
+	if c.Retry != nil {
+		m["retry"] = retryPolicyToMap(c.Retry)
 	}
 	return m
 }
+
+// retryPolicyToMap converts a RetryPolicy to map.
+func retryPolicyToMap(c *types.RetryPolicy) map[string]interface{} {
+	retryMap := make(map[string]interface{})
+	if len(c.ErrorTypes) > 0 {
+		errorTypes := make([]interface{}, len(c.ErrorTypes))
+		for i, errorType := range c.ErrorTypes {
+			errorTypes[i] = errorType
+		}
+		retryMap["error_types"] = errorTypes
+	}
+	if c.MaxRetries != 0 {
+		retryMap["max_retries"] = c.MaxRetries
+	}
+	if c.DelaySeconds != 0 {
+		retryMap["delay_seconds"] = c.DelaySeconds
+	}
+	return retryMap
+}
+
+// catchBlockToMap converts a CatchBlock, including its filters, to map.
+func catchBlockToMap(c *types.CatchBlock) map[string]interface{} {
+	catchMap := make(map[string]interface{})
+	if c.As != "" {
+		catchMap["as"] = c.As
+	}
+	if len(c.Do) > 0 {
+		doTasks := make([]interface{}, len(c.Do))
+		for i, task := range c.Do {
+			doTasks[i] = workflowTaskToMap(task)
+		}
+		catchMap["do"] = doTasks
+	}
+	if len(c.ErrorTypes) > 0 {
+		errorTypes := make([]interface{}, len(c.ErrorTypes))
+		for i, errorType := range c.ErrorTypes {
+			errorTypes[i] = errorType
+		}
+		catchMap["error_types"] = errorTypes
+	}
+	if len(c.StatusCodes) > 0 {
+		statusCodes := make([]interface{}, len(c.StatusCodes))
+		for i, code := range c.StatusCodes {
+			statusCodes[i] = code
+		}
+		catchMap["status_codes"] = statusCodes
+	}
+	return catchMap
+}
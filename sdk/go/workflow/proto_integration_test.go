@@ -1,8 +1,11 @@
 package workflow
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
 	"github.com/stigmer/stigmer/sdk/go/environment"
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
 )
@@ -56,7 +59,7 @@ func TestWorkflowToProto_Complete(t *testing.T) {
 		Name: "processData",
 		Kind: TaskKindSet,
 		Config: &SetTaskConfig{
-			Variables: map[string]string{
+			Variables: map[string]interface{}{
 				"status":  "completed",
 				"count":   "10",
 				"success": "true",
@@ -177,7 +180,7 @@ func TestWorkflowToProto_Minimal(t *testing.T) {
 				Name: "task1",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{
+					Variables: map[string]interface{}{
 						"x": "y",
 					},
 				},
@@ -213,7 +216,7 @@ func TestWorkflowToProto_AllTaskTypes(t *testing.T) {
 			Name: "setTask",
 			Kind: TaskKindSet,
 			Config: &SetTaskConfig{
-				Variables: map[string]string{"x": "y"},
+				Variables: map[string]interface{}{"x": "y"},
 			},
 		},
 		// HTTP_CALL
@@ -250,7 +253,7 @@ func TestWorkflowToProto_AllTaskTypes(t *testing.T) {
 			Kind: TaskKindSwitch,
 			Config: &SwitchTaskConfig{
 				Cases: []*types.SwitchCase{
-					{Name: "case1", When: "true", Then: "task1"},
+					{Name: "case1", When: "true", Then: "listenTask"},
 				},
 			},
 		},
@@ -363,6 +366,255 @@ func TestWorkflowToProto_TaskExport(t *testing.T) {
 	}
 }
 
+// TestWorkflowToProto_ArgsEnvironmentAndLabels tests that New carries env vars and labels from args.
+func TestWorkflowToProto_ArgsEnvironmentAndLabels(t *testing.T) {
+	ctx := &mockEnvContext{}
+
+	token, err := environment.New(ctx, "API_TOKEN", &environment.VariableArgs{IsSecret: true})
+	if err != nil {
+		t.Fatalf("Failed to create env var: %v", err)
+	}
+
+	args := &WorkflowArgs{
+		Version:              "1.0.0",
+		Description:          "Nightly export",
+		EnvironmentVariables: []environment.Variable{*token},
+		Labels:               map[string]string{"team": "data"},
+	}
+
+	wf, err := New(nil, "exports/nightly", args)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.Set("init", &SetArgs{Variables: map[string]interface{}{"status": "started"}})
+
+	// Mutating args after New must not affect the workflow
+	args.Labels["team"] = "changed"
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	if got := proto.Metadata.Labels["team"]; got != "data" {
+		t.Errorf("Labels[team] = %q, want %q", got, "data")
+	}
+	if _, ok := proto.Spec.EnvSpec.GetData()["API_TOKEN"]; !ok {
+		t.Errorf("EnvSpec missing API_TOKEN: %v", proto.Spec.EnvSpec.GetData())
+	}
+}
+
+// TestWorkflowToProto_AgentCallOutputOptions tests streaming output options on agent calls.
+func TestWorkflowToProto_AgentCallOutputOptions(t *testing.T) {
+	wf := &Workflow{
+		Document: Document{
+			DSL:       "1.0.0",
+			Namespace: "test",
+			Name:      "agent-workflow",
+			Version:   "1.0.0",
+		},
+		Tasks: []*Task{
+			AgentCall("research", &AgentCallArgs{
+				Agent:           "researcher",
+				Message:         "Summarize ${.input.topic}",
+				StreamToContext: true,
+			}),
+			AgentCall("summarize", &AgentCallArgs{
+				Agent:           "summarizer",
+				Message:         "Summarize again",
+				FinalOutputOnly: true,
+			}),
+		},
+	}
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	research := proto.Spec.Tasks[0].TaskConfig.GetFields()
+	if !research["stream_to_context"].GetBoolValue() {
+		t.Errorf("stream_to_context = %v, want true", research["stream_to_context"])
+	}
+	if _, ok := research["final_output_only"]; ok {
+		t.Error("final_output_only should be omitted when false")
+	}
+
+	summarize := proto.Spec.Tasks[1].TaskConfig.GetFields()
+	if !summarize["final_output_only"].GetBoolValue() {
+		t.Errorf("final_output_only = %v, want true", summarize["final_output_only"])
+	}
+}
+
+// TestWorkflowToProto_AgentCallOutputShapingOptions tests the StreamToContext and FinalOutputOnly options.
+func TestWorkflowToProto_AgentCallOutputShapingOptions(t *testing.T) {
+	wf := &Workflow{
+		Document: Document{
+			DSL:       "1.0.0",
+			Namespace: "research",
+			Name:      "papers",
+			Version:   "1.0.0",
+		},
+		Tasks: []*Task{
+			AgentCall("research", &AgentCallArgs{
+				Agent:   "researcher",
+				Message: "Summarize recent papers",
+			}, StreamToContext()),
+			AgentCall("summarize", &AgentCallArgs{
+				Agent:   "summarizer",
+				Message: "Summarize again",
+			}, FinalOutputOnly()),
+		},
+	}
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	research := proto.Spec.Tasks[0].TaskConfig.GetFields()
+	if !research["stream_to_context"].GetBoolValue() {
+		t.Errorf("stream_to_context = %v, want true", research["stream_to_context"])
+	}
+	summarize := proto.Spec.Tasks[1].TaskConfig.GetFields()
+	if !summarize["final_output_only"].GetBoolValue() {
+		t.Errorf("final_output_only = %v, want true", summarize["final_output_only"])
+	}
+}
+
+// TestWorkflowToProto_ForkContinueOnBranchError tests per-branch error handling on forks.
+func TestWorkflowToProto_ForkContinueOnBranchError(t *testing.T) {
+	fork := Fork("gather", &ForkArgs{
+		Branches: ForkBranches(
+			ForkBranch("users", HttpGet("fetchUsers", "https://api.example.com/users", nil)),
+			ForkBranch("posts", HttpGet("fetchPosts", "https://api.example.com/posts", nil)),
+		),
+		ContinueOnBranchError: true,
+	})
+
+	posts := fork.Branch("posts")
+	if got, want := posts.Succeeded().Expression(), `${ ($context["gather"].posts.succeeded != false) }`; got != want {
+		t.Errorf("Succeeded() = %q, want %q", got, want)
+	}
+	if got, want := posts.Succeeded().Not().Expression(), `${ ($context["gather"].posts.succeeded == false) }`; got != want {
+		t.Errorf("Succeeded().Not() = %q, want %q", got, want)
+	}
+	if got, want := posts.Error().Expression(), `${ $context["gather"].posts.error }`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	wf := &Workflow{
+		Document: Document{
+			DSL:       "1.0.0",
+			Namespace: "test",
+			Name:      "fork-workflow",
+			Version:   "1.0.0",
+		},
+		Tasks: []*Task{fork},
+	}
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	task := proto.Spec.Tasks[0]
+	if !task.TaskConfig.GetFields()["continue_on_branch_error"].GetBoolValue() {
+		t.Errorf("continue_on_branch_error = %v, want true", task.TaskConfig.GetFields()["continue_on_branch_error"])
+	}
+	// Branch refs read the fork output from context, so the fork must be exported
+	if task.Export == nil || task.Export.As != "${.}" {
+		t.Errorf("Export = %v, want ${.}", task.Export)
+	}
+}
+
+// TestWorkflowToProto_TaskExecutionTimeout tests the task-level execution timeout.
+func TestWorkflowToProto_TaskExecutionTimeout(t *testing.T) {
+	wf := &Workflow{
+		Document: Document{
+			DSL:       "1.0.0",
+			Namespace: "test",
+			Name:      "timeout-workflow",
+			Version:   "1.0.0",
+		},
+		Tasks: []*Task{
+			AgentCall("review", &AgentCallArgs{
+				Agent:   "code-reviewer",
+				Message: "Review the PR",
+			}).ExecutionTimeout(90*time.Second + 500*time.Millisecond),
+			Set("init", &SetArgs{
+				Variables: map[string]interface{}{"status": "ok"},
+			}),
+		},
+	}
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	// Rounded up to whole seconds
+	if got := proto.Spec.Tasks[0].ExecutionTimeoutSeconds; got != 91 {
+		t.Errorf("ExecutionTimeoutSeconds = %d, want 91", got)
+	}
+	if got := proto.Spec.Tasks[1].ExecutionTimeoutSeconds; got != 0 {
+		t.Errorf("ExecutionTimeoutSeconds = %d, want 0 (unset)", got)
+	}
+
+	wf.Tasks[1].ExecutionTimeout(-time.Second)
+	if _, err := wf.ToProto(); !errors.Is(err, ErrInvalidExecutionTimeout) {
+		t.Errorf("Expected ErrInvalidExecutionTimeout, got %v", err)
+	}
+}
+
+func TestWorkflowToProto_TaskSensitiveOutput(t *testing.T) {
+	login := HttpPost("login", "https://auth.example.com/token", nil, map[string]interface{}{
+		"grant_type": "client_credentials",
+	}).SensitiveOutput("access_token", "refresh_token")
+
+	wf := &Workflow{
+		Document: Document{
+			DSL:       "1.0.0",
+			Namespace: "test",
+			Name:      "sensitive-workflow",
+			Version:   "1.0.0",
+		},
+		Tasks: []*Task{
+			login,
+			Set("init", &SetArgs{
+				Variables: map[string]interface{}{"token": login.Field("access_token").Expression()},
+			}),
+		},
+	}
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	got := proto.Spec.Tasks[0].SensitiveOutputFields
+	if len(got) != 2 || got[0] != "access_token" || got[1] != "refresh_token" {
+		t.Errorf("SensitiveOutputFields = %v, want [access_token refresh_token]", got)
+	}
+	if got := proto.Spec.Tasks[1].SensitiveOutputFields; len(got) != 0 {
+		t.Errorf("SensitiveOutputFields = %v, want empty", got)
+	}
+
+	// Narrowed exports keep field names, so the runner can still redact them
+	login.Exports("access_token", "expires_in")
+	if _, err := wf.ToProto(); err != nil {
+		t.Errorf("ToProto() with narrowed export failed: %v", err)
+	}
+
+	// A custom export that copies the field would bypass redaction
+	wf.Tasks[0] = HttpPost("login", "https://auth.example.com/token", nil, nil).
+		SensitiveOutput("access_token").
+		Export("${ {token: .access_token} }")
+	if _, err := wf.ToProto(); !errors.Is(err, ErrSensitiveOutputExported) {
+		t.Errorf("Expected ErrSensitiveOutputExported, got %v", err)
+	}
+}
+
 // TestWorkflowToProto_TaskFlow tests task flow control.
 func TestWorkflowToProto_TaskFlow(t *testing.T) {
 	wf := &Workflow{
@@ -377,7 +629,7 @@ func TestWorkflowToProto_TaskFlow(t *testing.T) {
 				Name: "task1",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{"x": "y"},
+					Variables: map[string]interface{}{"x": "y"},
 				},
 				ThenTask: "task2", // Jump to task2
 			},
@@ -385,7 +637,7 @@ func TestWorkflowToProto_TaskFlow(t *testing.T) {
 				Name: "task2",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{"a": "b"},
+					Variables: map[string]interface{}{"a": "b"},
 				},
 			},
 		},
@@ -419,7 +671,7 @@ func TestWorkflowToProto_SlugAutoGeneration(t *testing.T) {
 				Name: "task1",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{"x": "y"},
+					Variables: map[string]interface{}{"x": "y"},
 				},
 			},
 		},
@@ -467,7 +719,7 @@ func TestWorkflowToProto_MultipleEnvVars(t *testing.T) {
 				Name: "task1",
 				Kind: TaskKindSet,
 				Config: &SetTaskConfig{
-					Variables: map[string]string{"x": "y"},
+					Variables: map[string]interface{}{"x": "y"},
 				},
 			},
 		},
@@ -532,3 +784,326 @@ func TestWorkflowToProto_EmptyTasks(t *testing.T) {
 		t.Logf("Empty tasks validation: %v", err)
 	}
 }
+
+// TestWorkflowToProto_ConcurrencyPolicy tests concurrency policy serialization.
+func TestWorkflowToProto_ConcurrencyPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        ConcurrencyKey
+		onConflict ConflictAction
+		want       workflowv1.ConcurrencyConflictAction
+	}{
+		{"queue", SerializeOn("org"), OnConflictQueue(), workflowv1.ConcurrencyConflictAction_CONCURRENCY_QUEUE},
+		{"skip", SerializeOn("tenant_id"), OnConflictSkip(), workflowv1.ConcurrencyConflictAction_CONCURRENCY_SKIP},
+		{"cancel existing", SerializeOn("workflow"), OnConflictCancelExisting(), workflowv1.ConcurrencyConflictAction_CONCURRENCY_CANCEL_EXISTING},
+		{"zero action defaults to queue", SerializeOn("org"), ConflictAction{}, workflowv1.ConcurrencyConflictAction_CONCURRENCY_QUEUE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "sync/org-sync", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.AddTask(Set("init", &SetArgs{Variables: map[string]interface{}{"status": "ok"}}))
+
+			if err := wf.WithConcurrencyPolicy(tt.key, tt.onConflict); err != nil {
+				t.Fatalf("WithConcurrencyPolicy() failed: %v", err)
+			}
+
+			proto, err := wf.ToProto()
+			if err != nil {
+				t.Fatalf("ToProto() failed: %v", err)
+			}
+			policy := proto.Spec.ConcurrencyPolicy
+			if policy == nil {
+				t.Fatal("Spec.ConcurrencyPolicy is nil")
+			}
+			if policy.Key != string(tt.key) {
+				t.Errorf("Key = %q, want %q", policy.Key, tt.key)
+			}
+			if policy.OnConflict != tt.want {
+				t.Errorf("OnConflict = %v, want %v", policy.OnConflict, tt.want)
+			}
+		})
+	}
+}
+
+// TestWorkflowWithConcurrencyPolicy_Invalid tests concurrency key validation.
+func TestWorkflowWithConcurrencyPolicy_Invalid(t *testing.T) {
+	wf, err := New(nil, "sync/org-sync", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	for _, key := range []string{"", "tenant id", "${.input.org}"} {
+		if err := wf.WithConcurrencyPolicy(SerializeOn(key), OnConflictQueue()); !errors.Is(err, ErrInvalidConcurrencyPolicy) {
+			t.Errorf("WithConcurrencyPolicy(%q) error = %v, want ErrInvalidConcurrencyPolicy", key, err)
+		}
+	}
+	if wf.ConcurrencyPolicy != nil {
+		t.Errorf("ConcurrencyPolicy = %v, want nil after failed validation", wf.ConcurrencyPolicy)
+	}
+}
+
+// TestWorkflowToProto_AgentCallOutputSchema tests per-task output schema
+// normalization and Field() reference checks.
+func TestWorkflowToProto_AgentCallOutputSchema(t *testing.T) {
+	newWorkflow := func(field string) *Workflow {
+		wf, err := New(nil, "review/pr-review", &WorkflowArgs{Version: "1.0.0"})
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		review := wf.CallAgent("review", &AgentCallArgs{
+			Agent:   "code-reviewer",
+			Message: "Review the PR",
+			Config: &types.AgentExecutionConfig{
+				Timeout: 300,
+				OutputSchema: map[string]any{
+					"severity": "string",
+					"issues":   []any{map[string]any{"title": "string"}},
+				},
+			},
+		})
+		wf.Set("report", &SetArgs{Variables: map[string]interface{}{
+			"value": review.Field(field).Expression(),
+		}})
+		return wf
+	}
+
+	proto, err := newWorkflow("issues[0].title").ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+	config := proto.Spec.Tasks[0].TaskConfig.AsMap()["config"].(map[string]any)
+	schema, _ := config["output_schema"].(map[string]any)
+	if schema["type"] != "object" {
+		t.Errorf("output_schema = %v, want normalized object schema", config["output_schema"])
+	}
+
+	_, err = newWorkflow("issues[0].line").ToProto()
+	if !errors.Is(err, ErrFieldNotInOutputSchema) {
+		t.Errorf("ToProto() error = %v, want ErrFieldNotInOutputSchema", err)
+	}
+}
+
+// TestWorkflowCheckAgentOutputSchemas tests Field() reference checks against
+// agent-level output schemas.
+func TestWorkflowCheckAgentOutputSchemas(t *testing.T) {
+	schemas := map[string]map[string]any{
+		"code-reviewer": {
+			"type": "object",
+			"properties": map[string]any{
+				"severity": map[string]any{"type": "string"},
+			},
+		},
+	}
+
+	wf, err := New(nil, "review/pr-review", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	review := wf.CallAgent("review", &AgentCallArgs{Agent: "code-reviewer", Message: "Review the PR"})
+	other := wf.CallAgent("summarize", &AgentCallArgs{Agent: "summarizer", Message: "Summarize"})
+	review.Field("severity")
+	other.Field("anything")
+
+	if err := wf.CheckAgentOutputSchemas(schemas); err != nil {
+		t.Fatalf("CheckAgentOutputSchemas() error = %v", err)
+	}
+
+	review.Field("issues")
+	if err := wf.CheckAgentOutputSchemas(schemas); !errors.Is(err, ErrFieldNotInOutputSchema) {
+		t.Errorf("CheckAgentOutputSchemas() error = %v, want ErrFieldNotInOutputSchema", err)
+	}
+}
+
+// TestWorkflowToProto_CallActivity tests CALL_ACTIVITY serialization with
+// task references, a custom task queue and a timeout.
+func TestWorkflowToProto_CallActivity(t *testing.T) {
+	wf, err := New(nil, "etl/normalize", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	fetch := wf.HttpGet("fetch", "https://api.example.com/data", nil)
+	wf.CallActivity("normalize", &CallActivityArgs{
+		Activity:       "transform.v1.Normalize",
+		Input:          map[string]interface{}{"data": fetch.Field("body"), "mode": "strict"},
+		TaskQueue:      "custom-q",
+		TimeoutSeconds: 120,
+	})
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+	config := proto.Spec.Tasks[1].TaskConfig.AsMap()
+	if config["activity"] != "transform.v1.Normalize" {
+		t.Errorf("activity = %v, want transform.v1.Normalize", config["activity"])
+	}
+	if config["task_queue"] != "custom-q" {
+		t.Errorf("task_queue = %v, want custom-q", config["task_queue"])
+	}
+	if config["timeout_seconds"] != float64(120) {
+		t.Errorf("timeout_seconds = %v, want 120", config["timeout_seconds"])
+	}
+	input := config["input"].(map[string]any)
+	if input["data"] != fetch.Field("body").Expression() {
+		t.Errorf("input.data = %v, want %s", input["data"], fetch.Field("body").Expression())
+	}
+	if input["mode"] != "strict" {
+		t.Errorf("input.mode = %v, want strict", input["mode"])
+	}
+}
+
+func TestWorkflowToProto_ListenCorrelationAndTimeout(t *testing.T) {
+	wf, err := New(nil, "shop/checkout", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.AddTask(Listen("waitForPayment", &ListenArgs{
+		To: &types.ListenTo{
+			Mode: "one",
+			Signals: []*types.SignalSpec{{
+				Id:       "orderPaid",
+				Type:     "signal",
+				AcceptIf: "${ .orderId == $context.createOrder.id }",
+			}},
+		},
+		TimeoutSeconds: 3600,
+	}))
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+	config := proto.Spec.Tasks[0].TaskConfig.AsMap()
+	if config["timeout_seconds"] != float64(3600) {
+		t.Errorf("timeout_seconds = %v, want 3600", config["timeout_seconds"])
+	}
+	signal := config["to"].(map[string]any)["signals"].([]any)[0].(map[string]any)
+	if signal["accept_if"] != "${ .orderId == $context.createOrder.id }" {
+		t.Errorf("accept_if = %v", signal["accept_if"])
+	}
+}
+
+// TestWorkflowToProto_AgentCallAttachments tests attachment serialization,
+// implicit dependencies and size validation.
+func TestWorkflowToProto_AgentCallAttachments(t *testing.T) {
+	wf, err := New(nil, "review/pr-review", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	fetchDiff := wf.HttpGet("fetch-diff", "https://api.example.com/diff", nil)
+	review := wf.CallAgent("review", &AgentCallArgs{
+		Agent:   "code-reviewer",
+		Message: "Review the attached diff",
+		Attachments: []*types.AgentAttachment{
+			AttachmentRef("diff.patch", fetchDiff.Field("body"), MimeType("text/x-patch")),
+			Attachment("guidelines.md", "Keep functions short", MaxSize(1024)),
+		},
+	})
+
+	if len(review.Dependencies) != 1 || review.Dependencies[0] != "fetch-diff" {
+		t.Errorf("Dependencies = %v, want [fetch-diff]", review.Dependencies)
+	}
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+	attachments := proto.Spec.Tasks[1].TaskConfig.AsMap()["attachments"].([]any)
+	if len(attachments) != 2 {
+		t.Fatalf("attachments = %v, want 2 entries", attachments)
+	}
+	diff := attachments[0].(map[string]any)
+	if diff["name"] != "diff.patch" || diff["mime_type"] != "text/x-patch" {
+		t.Errorf("attachments[0] = %v", diff)
+	}
+	if diff["content"] != fetchDiff.Field("body").Expression() {
+		t.Errorf("attachments[0].content = %v, want %s", diff["content"], fetchDiff.Field("body").Expression())
+	}
+	if guidelines := attachments[1].(map[string]any); guidelines["max_size_bytes"] != float64(1024) {
+		t.Errorf("attachments[1].max_size_bytes = %v, want 1024", guidelines["max_size_bytes"])
+	}
+
+	tests := []struct {
+		name        string
+		attachments []*types.AgentAttachment
+	}{
+		{
+			name: "duplicate name",
+			attachments: []*types.AgentAttachment{
+				Attachment("notes.md", "a"),
+				Attachment("notes.md", "b"),
+			},
+		},
+		{
+			name: "literal content over limit",
+			attachments: []*types.AgentAttachment{
+				Attachment("notes.md", "too long", MaxSize(4)),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "review/pr-review", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.CallAgent("review", &AgentCallArgs{
+				Agent:       "code-reviewer",
+				Message:     "Review",
+				Attachments: tt.attachments,
+			})
+			if _, err := wf.ToProto(); !errors.Is(err, ErrInvalidAttachment) {
+				t.Errorf("ToProto() error = %v, want ErrInvalidAttachment", err)
+			}
+		})
+	}
+}
+
+func TestWorkflowToProto_AgentRef(t *testing.T) {
+	wf, err := New(nil, "review/pr-review", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.CallAgent("review", &AgentCallArgs{
+		Message: "Review this PR",
+	}, AgentRef("my-org", "code-reviewer-prod", AgentVersion("stable")))
+
+	proto, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+	config := proto.Spec.Tasks[0].TaskConfig.AsMap()
+	for key, want := range map[string]string{
+		"agent":   "code-reviewer-prod",
+		"org":     "my-org",
+		"version": "stable",
+	} {
+		if config[key] != want {
+			t.Errorf("%s = %v, want %q", key, config[key], want)
+		}
+	}
+
+	tests := []struct {
+		name string
+		ref  AgentReference
+	}{
+		{"missing org", AgentRef("", "code-reviewer-prod")},
+		{"missing name", AgentRef("my-org", "")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "review/pr-review", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.CallAgent("review", &AgentCallArgs{Message: "Review"}, tt.ref)
+			if _, err := wf.ToProto(); !errors.Is(err, ErrInvalidAgentRef) {
+				t.Errorf("ToProto() error = %v, want ErrInvalidAgentRef", err)
+			}
+		})
+	}
+}
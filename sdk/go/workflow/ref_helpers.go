@@ -2,6 +2,8 @@ package workflow
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 )
 
 // Ref is a minimal interface that represents a typed reference to a value.
@@ -33,76 +35,24 @@ type StringValue interface {
 	Value() string
 }
 
-// toExpression converts various input types to expression strings.
-// 
-// SMART RESOLUTION: If the value is a known constant (StringValue, IntValue, BoolValue),
-// returns the actual value. If it's a runtime expression (Ref without value), returns
-// the JQ expression.
-//
-// This enables the SDK to resolve values at synthesis time when possible, avoiding
-// unnecessary runtime JQ evaluation for static configuration.
-//
-// Supported types:
-//   - string: returned as-is
-//   - int, int32, int64: converted to string representation
-//   - bool: converted to string "true" or "false"
-//   - float32, float64: converted to string representation
-//   - StringValue: returns the known value (synthesis-time resolution)
-//   - IntValue: returns the known value as string
-//   - BoolValue: returns the known value as string
-//   - Ref: calls Expression() to get JQ expression (runtime resolution)
-//
-// Examples (synthesis-time resolution):
-//
-//	apiBase := ctx.SetString("apiBase", "https://api.example.com")
-//	endpoint := apiBase.Concat("/users")
-//	toExpression(endpoint)  // "https://api.example.com/users" (resolved!)
-//
-// Examples (runtime resolution):
-//
-//	fetchTask := wf.HttpGet("fetch", "https://api.example.com")
-//	title := fetchTask.Field("title")
-//	toExpression(title)  // "${ $context.fetch.title }" (runtime JQ)
-func toExpression(value interface{}) string {
-	switch v := value.(type) {
-	case string:
-		return v
-	case int:
-		return fmt.Sprintf("%d", v)
-	case int32:
-		return fmt.Sprintf("%d", v)
-	case int64:
-		return fmt.Sprintf("%d", v)
-	case bool:
-		return fmt.Sprintf("%t", v)
-	case float32:
-		return fmt.Sprintf("%f", v)
-	case float64:
-		return fmt.Sprintf("%f", v)
-	
-	// IMPORTANT: Check value interfaces BEFORE Ref interface
-	// This allows resolved StringRef/IntRef/BoolRef to return their values
-	// instead of empty expressions from Expression()
-	case StringValue:
-		// This is a known string value - return it directly (compile-time resolution)
-		return v.Value()
-	case IntValue:
-		// This is a known int value - convert to string (compile-time resolution)
-		return fmt.Sprintf("%d", v.Value())
-	case BoolValue:
-		// This is a known bool value - convert to string (compile-time resolution)
-		return fmt.Sprintf("%t", v.Value())
-	
-	// Check Ref last (for runtime expressions like task field outputs)
-	case Ref:
-		// Use Expression() for context variables and computed expressions
-		// This handles TaskFieldRef and other runtime references
-		return v.Expression()
-	
-	default:
-		// Fallback: convert to string
-		return fmt.Sprintf("%v", value)
-	}
+// ObjectValue represents an object-valued reference that can provide its value.
+// This is used for map-shaped values such as request bodies.
+type ObjectValue interface {
+	Value() map[string]interface{}
+}
+
+// ListValue represents a list-valued reference that can provide its value.
+// This is used for list-shaped values such as request bodies.
+type ListValue interface {
+	Value() []interface{}
+}
+
+// computedRef is implemented by references that can report whether they are
+// runtime expressions (e.g. IntRef.Add with a task output) rather than values
+// known at synthesis time.
+type computedRef interface {
+	Ref
+	IsComputed() bool
 }
 
 // toInt32 converts various input types to int32.
@@ -154,3 +104,78 @@ func toBool(value interface{}) bool {
 		return false
 	}
 }
+
+// knownRefValue returns the value of a context reference known at synthesis
+// time, such as a stigmer.ObjectRef from ctx.SetObject. Computed references
+// and references without a synthesis value (TaskFieldRef, ErrorRef, ...) are
+// only resolved at runtime and return false.
+func knownRefValue(value interface{}) (interface{}, bool) {
+	ref, ok := value.(computedRef)
+	if !ok || ref.IsComputed() {
+		return nil, false
+	}
+
+	switch v := value.(type) {
+	case ObjectValue:
+		return v.Value(), true
+	case ListValue:
+		return v.Value(), true
+	case StringValue:
+		return v.Value(), true
+	case IntValue:
+		return v.Value(), true
+	case BoolValue:
+		return v.Value(), true
+	default:
+		return nil, false
+	}
+}
+
+// runtimeRefPath returns the path of the first reference in value that is
+// only resolved at runtime, looking through maps, slices and the values of
+// known references. path is the path of value itself.
+//
+// Examples:
+//
+//	runtimeRefPath("body", ctx.SetObject("cfg", map[string]interface{}{"id": 1}))
+//	// "", false
+//	runtimeRefPath("body", map[string]interface{}{"id": fetchTask.Field("id")})
+//	// "body.id", true
+func runtimeRefPath(path string, value interface{}) (string, bool) {
+	if known, ok := knownRefValue(value); ok {
+		return runtimeRefPath(path, known)
+	}
+	if _, ok := value.(Ref); ok {
+		return path, true
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return "", false
+		}
+		elems := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			elems[iter.Key().String()] = iter.Value().Interface()
+		}
+		keys := make([]string, 0, len(elems))
+		for key := range elems {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys) // report the same member on every run
+		for _, key := range keys {
+			if p, ok := runtimeRefPath(path+"."+key, elems[key]); ok {
+				return p, true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if p, ok := runtimeRefPath(fmt.Sprintf("%s[%d]", path, i), rv.Index(i).Interface()); ok {
+				return p, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,162 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// Rename changes the task's name.
+//
+// The new name must follow the task naming rules and be unique within the
+// workflow the task was added to. References to the task are updated:
+//
+//   - TaskFieldRefs created with Field() resolve the new name when rendered
+//   - Flow control (Then, switch cases) and explicit dependencies of other tasks
+//   - Expressions already rendered into task configs are rewritten during synthesis
+//
+// Example:
+//
+//	fetchTask := wf.HttpGet("fetchData", endpoint, nil)
+//	title := fetchTask.Field("title")
+//	wf.Set("process", &workflow.SetArgs{Variables: map[string]interface{}{
+//	    "title": title.Expression(),
+//	}})
+//	err := fetchTask.Rename("fetchUser")  // Manifest now references "fetchUser"
+func (t *Task) Rename(newName string) error {
+	if err := validateTaskName(newName); err != nil {
+		return err
+	}
+	if newName == t.Name {
+		return nil
+	}
+
+	w := t.workflow
+	if w != nil {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		for i, other := range w.Tasks {
+			if other != t && other.Name == newName {
+				return validation.NewValidationErrorWithCause(
+					validation.FieldPath("tasks", i, "name"),
+					newName,
+					"unique",
+					fmt.Sprintf("cannot rename task %q: a task named %q already exists", t.Name, newName),
+					ErrDuplicateTaskName,
+				)
+			}
+		}
+	}
+
+	oldName := t.Name
+	t.previousNames = append(t.previousNames, oldName)
+	t.Name = newName
+
+	if w != nil {
+		for _, other := range w.Tasks {
+			other.renameTaskReferences(oldName, newName)
+		}
+	}
+	return nil
+}
+
+// renameTaskReferences updates name-based links from this task to a renamed task.
+func (t *Task) renameTaskReferences(oldName, newName string) {
+	if t.ThenTask == oldName {
+		t.ThenTask = newName
+	}
+	for i, dep := range t.Dependencies {
+		if dep == oldName {
+			t.Dependencies[i] = newName
+		}
+	}
+	if switchConfig, ok := t.Config.(*SwitchTaskConfig); ok {
+		for _, switchCase := range switchConfig.Cases {
+			if switchCase != nil && switchCase.Then == oldName {
+				switchCase.Then = newName
+			}
+		}
+	}
+}
+
+// taskRenames maps every previous name of a renamed task to its current name.
+//
+// Returns an error if a previous name has been taken by another task, since
+// expressions rendered with that name can no longer be attributed.
+func (w *Workflow) taskRenames() (map[string]string, error) {
+	current := make(map[string]bool, len(w.Tasks))
+	for _, task := range w.Tasks {
+		current[task.Name] = true
+	}
+
+	renames := make(map[string]string)
+	for _, task := range w.Tasks {
+		for _, oldName := range task.previousNames {
+			if current[oldName] {
+				return nil, validation.NewValidationErrorWithCause(
+					"tasks",
+					oldName,
+					"unique",
+					fmt.Sprintf("task %q was renamed to %q and its old name is now used by another task; "+
+						"expressions created before the rename are ambiguous", oldName, task.Name),
+					ErrDuplicateTaskName,
+				)
+			}
+			renames[oldName] = task.Name
+		}
+	}
+	return renames, nil
+}
+
+// rewriteRenamedReferences rewrites context references to renamed tasks
+// (${ $context["oldName"]... }) in the task configs to use the current names.
+func rewriteRenamedReferences(tasks []*workflowv1.WorkflowTask, renames map[string]string) {
+	if len(renames) == 0 {
+		return
+	}
+
+	replacer := renameReplacer(renames)
+	for _, task := range tasks {
+		if task.GetTaskConfig() == nil {
+			continue
+		}
+		for _, value := range task.GetTaskConfig().GetFields() {
+			rewriteValue(value, replacer)
+		}
+	}
+}
+
+// renameReplacer replaces context references to previous task names.
+func renameReplacer(renames map[string]string) *strings.Replacer {
+	pairs := make([]string, 0, len(renames)*2)
+	for oldName, newName := range renames {
+		pairs = append(pairs, contextRef(oldName), contextRef(newName))
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// contextRef returns the context accessor used in expressions for a task name.
+func contextRef(taskName string) string {
+	return fmt.Sprintf("$context[%q]", taskName)
+}
+
+// rewriteValue applies the replacer to every string in a struct value.
+func rewriteValue(value *structpb.Value, replacer *strings.Replacer) {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		kind.StringValue = replacer.Replace(kind.StringValue)
+	case *structpb.Value_StructValue:
+		for _, field := range kind.StructValue.GetFields() {
+			rewriteValue(field, replacer)
+		}
+	case *structpb.Value_ListValue:
+		for _, item := range kind.ListValue.GetValues() {
+			rewriteValue(item, replacer)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTaskRename_UpdatesReferences(t *testing.T) {
+	wf, err := New(nil, "ops/rename", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	fetchTask := wf.HttpGet("fetchData", "https://api.example.com/data", nil)
+	title := fetchTask.Field("title")
+
+	// Rendered eagerly into a string config
+	wf.Set("process", &SetArgs{Variables: map[string]interface{}{
+		"title": title.Expression(),
+	}})
+	// Kept as a reference and rendered at synthesis
+	postTask := wf.HttpPost("post", "https://api.example.com/posts", nil, map[string]interface{}{
+		"title": title,
+	})
+	postTask.DependsOn(fetchTask)
+	retryTask := wf.Set("retry", &SetArgs{Variables: map[string]interface{}{"attempt": "2"}}).Then("fetchData")
+
+	if err := fetchTask.Rename("fetchUser"); err != nil {
+		t.Fatalf("Rename() failed: %v", err)
+	}
+
+	if got := title.TaskName(); got != "fetchUser" {
+		t.Errorf("TaskFieldRef.TaskName() = %q, want fetchUser", got)
+	}
+	if retryTask.ThenTask != "fetchUser" {
+		t.Errorf("ThenTask = %q, want fetchUser", retryTask.ThenTask)
+	}
+	if got := postTask.Dependencies; len(got) != 1 || got[0] != "fetchUser" {
+		t.Errorf("Dependencies = %v, want [fetchUser]", got)
+	}
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	want := `${ $context["fetchUser"].title }`
+	for _, task := range pb.GetSpec().GetTasks() {
+		config, _ := task.GetTaskConfig().MarshalJSON()
+		if strings.Contains(string(config), "fetchData") {
+			t.Errorf("task %s config still references fetchData: %s", task.GetName(), config)
+		}
+	}
+	tasks := pb.GetSpec().GetTasks()
+	if tasks[0].GetName() != "fetchUser" {
+		t.Errorf("task[0].name = %q, want fetchUser", tasks[0].GetName())
+	}
+	if got := tasks[1].GetTaskConfig().GetFields()["variables"].GetStructValue().GetFields()["title"].GetStringValue(); got != want {
+		t.Errorf("process title = %q, want %q", got, want)
+	}
+	if got := tasks[2].GetTaskConfig().GetFields()["body"].GetStructValue().GetFields()["title"].GetStringValue(); got != want {
+		t.Errorf("post body title = %q, want %q", got, want)
+	}
+}
+
+func TestTaskRename_Validation(t *testing.T) {
+	wf, err := New(nil, "ops/rename", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	fetchTask := wf.HttpGet("fetch", "https://api.example.com/data", nil)
+	wf.Set("process", &SetArgs{Variables: map[string]interface{}{"ok": "true"}})
+
+	if err := fetchTask.Rename("process"); !errors.Is(err, ErrDuplicateTaskName) {
+		t.Errorf("Rename() to an existing name error = %v, want ErrDuplicateTaskName", err)
+	}
+	if err := fetchTask.Rename("not valid"); !errors.Is(err, ErrInvalidTaskName) {
+		t.Errorf("Rename() to an invalid name error = %v, want ErrInvalidTaskName", err)
+	}
+	if fetchTask.Name != "fetch" {
+		t.Errorf("Name = %q after failed renames, want fetch", fetchTask.Name)
+	}
+}
+
+func TestTaskRename_OldNameReused(t *testing.T) {
+	wf, err := New(nil, "ops/rename", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	fetchTask := wf.HttpGet("fetch", "https://api.example.com/data", nil)
+	if err := fetchTask.Rename("fetchUser"); err != nil {
+		t.Fatalf("Rename() failed: %v", err)
+	}
+	wf.Set("fetch", &SetArgs{Variables: map[string]interface{}{"ok": "true"}})
+
+	if _, err := wf.ToProto(); !errors.Is(err, ErrDuplicateTaskName) {
+		t.Errorf("ToProto() error = %v, want ErrDuplicateTaskName", err)
+	}
+}
@@ -0,0 +1,236 @@
+package workflow
+
+import (
+	"fmt"
+)
+
+// DefaultMaxIterations is the iteration cap of a Repeat loop created without
+// MaxIterations.
+const DefaultMaxIterations = 1000
+
+// RepeatOption configures a loop created with Repeat.
+type RepeatOption func(*repeatLoop)
+
+// repeatLoop holds the settings collected from RepeatOption values. It is kept
+// on the task so the range can be validated at synthesis time.
+type repeatLoop struct {
+	start, end, step int
+	ranged           bool
+	until            *TaskCondition
+	maxIterations    int
+	body             []*Task
+}
+
+// iterations returns the number of values in the range.
+func (r *repeatLoop) iterations() int {
+	switch {
+	case r.step > 0 && r.end > r.start:
+		return (r.end - r.start + r.step - 1) / r.step
+	case r.step < 0 && r.end < r.start:
+		return (r.start - r.end - r.step - 1) / -r.step
+	default:
+		return 0
+	}
+}
+
+// Times runs the loop body n times, with RepeatIndex counting from 0 to n-1.
+func Times(n int) RepeatOption {
+	return RangeLoop(0, n, 1)
+}
+
+// RangeLoop runs the loop body once for each value from start up to, but not
+// including, end, moving by step. A negative step counts down.
+//
+// Example:
+//
+//	workflow.RangeLoop(10, 0, -2) // RepeatIndex: 10, 8, 6, 4, 2
+func RangeLoop(start, end, step int) RepeatOption {
+	return func(r *repeatLoop) {
+		r.start, r.end, r.step = start, end, step
+		r.ranged = true
+	}
+}
+
+// Until stops the loop after the first iteration in which cond holds.
+// The condition is evaluated after each iteration and can reference the
+// output of the body tasks.
+//
+// Example:
+//
+//	workflow.Until(workflow.Condition(poll.Field("status"), workflow.Equals("done")))
+func Until(cond TaskCondition) RepeatOption {
+	return func(r *repeatLoop) {
+		r.until = &cond
+	}
+}
+
+// MaxIterations caps the number of iterations the runner executes. The
+// default is DefaultMaxIterations; synthesis fails if the range is longer
+// than the cap.
+func MaxIterations(n int) RepeatOption {
+	return func(r *repeatLoop) {
+		r.maxIterations = n
+	}
+}
+
+// RepeatDo sets the tasks run in each iteration.
+// Build them with the package-level constructors (workflow.HttpGet,
+// workflow.Set, ...) so they are not added to the workflow itself.
+func RepeatDo(tasks ...*Task) RepeatOption {
+	return func(r *repeatLoop) {
+		r.body = append(r.body, tasks...)
+	}
+}
+
+// LoopIndex refers to the counter of the enclosing Repeat loop.
+type LoopIndex struct{}
+
+// RepeatIndex is the counter of the enclosing Repeat loop, for use in the
+// tasks passed to RepeatDo.
+var RepeatIndex LoopIndex
+
+// Value returns a reference to the current counter value.
+//
+// Example:
+//
+//	workflow.RepeatIndex.Value() -> "${.item}"
+func (LoopIndex) Value() string {
+	return "${.item}"
+}
+
+// Iteration returns a reference to the zero-based number of the current
+// iteration. It equals Value for loops created with Times.
+//
+// Example:
+//
+//	workflow.RepeatIndex.Iteration() -> "${.index}"
+func (LoopIndex) Iteration() string {
+	return "${.index}"
+}
+
+// Repeat creates a FOR task that runs its body a fixed number of times,
+// without building a collection to iterate over.
+//
+// The loop stops early after an iteration in which the Until condition
+// holds, and the runner fails the task rather than exceed the iteration cap
+// (DefaultMaxIterations unless set with MaxIterations). The task output is:
+//
+//	{"iterations": 3, "results": [...]}
+//
+// where results holds the output of each iteration; reference the count with
+// Field("iterations").
+//
+// Example:
+//
+//	poll := workflow.HttpGet("poll", statusURL, nil)
+//	wait := workflow.Repeat("waitUntilReady",
+//	    workflow.Times(30),
+//	    workflow.RepeatDo(poll, workflow.WaitFor("backoff", 10*time.Second)),
+//	    workflow.Until(workflow.Condition(poll.Field("status"), workflow.Equals("ready"))),
+//	)
+//	attempts := wait.Field("iterations")
+func Repeat(name string, opts ...RepeatOption) *Task {
+	loop := &repeatLoop{}
+	for _, opt := range opts {
+		opt(loop)
+	}
+
+	maxIterations := loop.maxIterations
+	if maxIterations == 0 {
+		maxIterations = DefaultMaxIterations
+	}
+
+	config := &ForTaskConfig{
+		Each:             "item",
+		In:               fmt.Sprintf("${ [range(%d; %d; %d)] }", loop.start, loop.end, loop.step),
+		Do:               loopTasks(loop.body),
+		MaxIterations:    int32(maxIterations),
+		ReportIterations: true,
+	}
+	if loop.until != nil {
+		config.Until = loop.until.Expression()
+	}
+
+	return &Task{
+		Name:   name,
+		Kind:   TaskKindFor,
+		Config: config,
+		repeat: loop,
+	}
+}
+
+// Repeat creates a counted loop and adds it to the workflow.
+//
+// Example:
+//
+//	wf.Repeat("warmCache",
+//	    workflow.Times(5),
+//	    workflow.RepeatDo(workflow.HttpGet("warm", cacheURL, nil)),
+//	)
+func (w *Workflow) Repeat(name string, opts ...RepeatOption) *Task {
+	task := Repeat(name, opts...)
+	w.AddTask(task)
+	return task
+}
+
+// validateRepeat checks the range and iteration cap of a loop created with
+// Repeat.
+func (t *Task) validateRepeat() error {
+	loop := t.repeat
+	if loop == nil {
+		return nil
+	}
+
+	if !loop.ranged {
+		return NewValidationErrorWithCause(
+			"repeat.range",
+			"",
+			"required",
+			fmt.Sprintf("task %q: Repeat needs Times or RangeLoop", t.Name),
+			ErrInvalidRepeat,
+		)
+	}
+	if loop.step == 0 {
+		return NewValidationErrorWithCause(
+			"repeat.step",
+			"0",
+			"non_zero",
+			fmt.Sprintf("task %q: RangeLoop step must not be zero", t.Name),
+			ErrInvalidRepeat,
+		)
+	}
+	if loop.maxIterations < 0 {
+		return NewValidationErrorWithCause(
+			"repeat.maxIterations",
+			fmt.Sprintf("%d", loop.maxIterations),
+			"min",
+			fmt.Sprintf("task %q: MaxIterations must not be negative", t.Name),
+			ErrInvalidRepeat,
+		)
+	}
+	if len(loop.body) == 0 {
+		return NewValidationErrorWithCause(
+			"repeat.do",
+			"",
+			"required",
+			fmt.Sprintf("task %q: Repeat needs at least one task in RepeatDo", t.Name),
+			ErrInvalidRepeat,
+		)
+	}
+
+	maxIterations := loop.maxIterations
+	if maxIterations == 0 {
+		maxIterations = DefaultMaxIterations
+	}
+	if n := loop.iterations(); n > maxIterations {
+		return NewValidationErrorWithCause(
+			"repeat.maxIterations",
+			fmt.Sprintf("%d", n),
+			"max",
+			fmt.Sprintf("task %q: range has %d iterations, more than the cap of %d; raise it with MaxIterations",
+				t.Name, n, maxIterations),
+			ErrInvalidRepeat,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRepeat_Synthesis(t *testing.T) {
+	wf, err := New(nil, "ops/wait-ready", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	poll := HttpGet("poll", "https://api.example.com/status", nil)
+	record := Set("record", &SetArgs{Variables: map[string]interface{}{"attempt": RepeatIndex.Value()}})
+	wait := wf.Repeat("waitUntilReady",
+		Times(5),
+		RepeatDo(poll, record),
+		Until(Condition(poll.Field("status"), Equals("ready"))),
+	)
+	wf.Set("report", &SetArgs{Variables: map[string]interface{}{
+		"attempts": wait.Field("iterations").Expression(),
+	}})
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	tasks := pb.GetSpec().GetTasks()
+	config := tasks[0].GetTaskConfig().GetFields()
+	if got, want := config["in"].GetStringValue(), "${ [range(0; 5; 1)] }"; got != want {
+		t.Errorf("in = %q, want %q", got, want)
+	}
+	if got, want := config["until"].GetStringValue(), `${ $context["poll"].status | . == "ready" }`; got != want {
+		t.Errorf("until = %q, want %q", got, want)
+	}
+	if got := config["max_iterations"].GetNumberValue(); got != DefaultMaxIterations {
+		t.Errorf("max_iterations = %v, want %d", got, DefaultMaxIterations)
+	}
+	if !config["report_iterations"].GetBoolValue() {
+		t.Error("report_iterations = false, want true")
+	}
+
+	body := config["do"].GetListValue().GetValues()
+	if len(body) != 2 {
+		t.Fatalf("do has %d tasks, want 2", len(body))
+	}
+	// The Until condition exports the body task it reads
+	if got := body[0].GetStructValue().GetFields()["export"].GetStructValue().GetFields()["as"].GetStringValue(); got != "${.}" {
+		t.Errorf("poll export = %q, want ${.}", got)
+	}
+
+	want := `${ $context["waitUntilReady"].iterations }`
+	if got := tasks[1].GetTaskConfig().GetFields()["variables"].GetStructValue().GetFields()["attempts"].GetStringValue(); got != want {
+		t.Errorf("attempts = %q, want %q", got, want)
+	}
+}
+
+func TestRangeLoop(t *testing.T) {
+	tests := []struct {
+		name           string
+		start, end     int
+		step           int
+		wantIn         string
+		wantIterations int
+	}{
+		{"ascending", 0, 10, 3, "${ [range(0; 10; 3)] }", 4},
+		{"descending", 10, 0, -2, "${ [range(10; 0; -2)] }", 5},
+		{"empty", 5, 5, 1, "${ [range(5; 5; 1)] }", 0},
+		{"wrong direction", 0, 10, -1, "${ [range(0; 10; -1)] }", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := Repeat("loop", RangeLoop(tt.start, tt.end, tt.step), RepeatDo(Set("noop", &SetArgs{})))
+			if got := task.Config.(*ForTaskConfig).In; got != tt.wantIn {
+				t.Errorf("In = %q, want %q", got, tt.wantIn)
+			}
+			if got := task.repeat.iterations(); got != tt.wantIterations {
+				t.Errorf("iterations() = %d, want %d", got, tt.wantIterations)
+			}
+		})
+	}
+}
+
+func TestRepeat_Validation(t *testing.T) {
+	body := RepeatDo(Set("noop", &SetArgs{Variables: map[string]interface{}{"i": RepeatIndex.Value()}}))
+
+	tests := []struct {
+		name string
+		opts []RepeatOption
+	}{
+		{"missing range", []RepeatOption{body}},
+		{"zero step", []RepeatOption{RangeLoop(0, 10, 0), body}},
+		{"negative cap", []RepeatOption{Times(3), MaxIterations(-1), body}},
+		{"missing body", []RepeatOption{Times(3)}},
+		{"range over default cap", []RepeatOption{Times(DefaultMaxIterations + 1), body}},
+		{"range over cap", []RepeatOption{Times(20), MaxIterations(10), body}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "ops/loop", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.Repeat("loop", tt.opts...)
+
+			if _, err := wf.ToProto(); !errors.Is(err, ErrInvalidRepeat) {
+				t.Errorf("ToProto() error = %v, want ErrInvalidRepeat", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+)
+
+// TaskCondition is a guard for RunIf: a matcher applied to a task output field.
+// Build one with Condition, or with Skipped for the outcome of a guarded task.
+type TaskCondition struct {
+	ref     TaskFieldRef
+	matcher ConditionMatcher
+	negated bool
+}
+
+// Condition builds a guard that applies matcher to a task output field.
+//
+// Example:
+//
+//	workflow.Condition(analyzeTask.Field("severity"), workflow.Equals("high"))
+//	// -> "${ $context["analyzeTask"].severity | . == "high" }"
+func Condition(ref TaskFieldRef, matcher ConditionMatcher) TaskCondition {
+	return TaskCondition{ref: ref, matcher: matcher}
+}
+
+// Expression returns the JQ guard expression.
+// The field is piped into the matcher, so the matcher's "." is the field value.
+func (c TaskCondition) Expression() string {
+	expr := fmt.Sprintf("%s | %s", c.ref.path(), matcherBody(c.matcher))
+	if c.negated {
+		expr = fmt.Sprintf("(%s) | not", expr)
+	}
+	return fmt.Sprintf("${ %s }", expr)
+}
+
+// Not returns the inverse condition.
+func (c TaskCondition) Not() TaskCondition {
+	c.negated = !c.negated
+	return c
+}
+
+// matcherBody returns the matcher expression without its ${ } wrapper.
+func matcherBody(m ConditionMatcher) string {
+	expr := strings.TrimSpace(m.Expression())
+	if strings.HasPrefix(expr, "${") && strings.HasSuffix(expr, "}") {
+		expr = strings.TrimSpace(expr[2 : len(expr)-1])
+	}
+	return expr
+}
+
+// RunIf runs this task only when cond holds; otherwise the task is skipped
+// and the workflow continues with the next task. Valid on every task kind.
+//
+// The task depends on the task cond references, which must come earlier in
+// the workflow.
+//
+// A skipped task has no output. If it exports, the runner records
+// {"skipped": true} under its name instead, so tasks referencing its fields
+// through Field() must be guarded with RunIf themselves; synthesis rejects
+// unguarded references. Use Skipped to guard on the outcome directly.
+//
+// Example:
+//
+//	analyze := wf.CallAgent("analyze", &workflow.AgentCallArgs{...})
+//	escalate := wf.HttpPost("escalate", pagerURL, nil, body).
+//	    RunIf(workflow.Condition(analyze.Field("severity"), workflow.Equals("high")))
+//	wf.Set("recordIncident", &workflow.SetArgs{
+//	    Variables: map[string]interface{}{"incident": escalate.Field("id").Expression()},
+//	}).RunIf(escalate.Skipped().Not())
+func (t *Task) RunIf(cond TaskCondition) *Task {
+	t.runIf = &cond
+
+	if cond.ref.task != nil {
+		t.DependsOn(cond.ref.task)
+	} else if name := cond.ref.TaskName(); name != "" && !slices.Contains(t.Dependencies, name) {
+		t.Dependencies = append(t.Dependencies, name)
+	}
+	return t
+}
+
+// Skipped returns a condition that holds when this task was skipped by its
+// RunIf guard. Like Field(), it exports the task's output so the skipped
+// status is recorded in the workflow context.
+//
+// Example:
+//
+//	notify.RunIf(escalate.Skipped().Not()) // run only if escalate ran
+func (t *Task) Skipped() TaskCondition {
+	if t.ExportAs == "" {
+		t.ExportAs = "${.}"
+	}
+	return TaskCondition{
+		ref:     TaskFieldRef{task: t, taskName: t.Name, fieldName: "skipped"},
+		matcher: Equals(true),
+	}
+}
+
+// guardExpression returns the rendered RunIf guard, or "" for unguarded tasks.
+func (t *Task) guardExpression() string {
+	if t.runIf == nil {
+		return ""
+	}
+	return t.runIf.Expression()
+}
+
+// validateRunIfGuards checks that guards reference earlier tasks and that no
+// unguarded task references, through Field(), the output of a guarded task,
+// which is missing whenever that task is skipped. Weak references tolerate
+// the missing output and are allowed.
+func validateRunIfGuards(tasks []*Task) error {
+	index := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		index[task.Name] = i
+	}
+
+	for i, task := range tasks {
+		if task.runIf == nil {
+			continue
+		}
+
+		source := task.runIf.ref.TaskName()
+		if j, ok := index[source]; !ok || j >= i {
+			return validation.NewValidationErrorWithCause(
+				validation.FieldPath("tasks", i, "if"),
+				task.guardExpression(),
+				"earlier_task",
+				fmt.Sprintf("task %q is guarded on task %q, which does not run before it", task.Name, source),
+				ErrInvalidRunIf,
+			)
+		}
+
+		names := append([]string{task.Name}, task.previousNames...)
+		for _, later := range tasks[i+1:] {
+			if later.runIf != nil {
+				continue
+			}
+			rendered, err := later.renderedConfig()
+			if err != nil {
+				// Conversion errors are reported by synthesis itself
+				continue
+			}
+
+			for _, name := range names {
+				if !containsStrongRef(rendered, name) {
+					continue
+				}
+				return validation.NewValidationErrorWithCause(
+					validation.FieldPath("tasks", i, "if"),
+					task.guardExpression(),
+					"guarded",
+					fmt.Sprintf("task %q references the output of task %q, which is skipped when its guard "+
+						"does not hold; guard %q with RunIf as well", later.Name, task.Name, later.Name),
+					ErrUnguardedReference,
+				)
+			}
+		}
+	}
+	return nil
+}
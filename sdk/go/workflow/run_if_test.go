@@ -0,0 +1,104 @@
+package workflow
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// newGuardedWorkflow returns a workflow whose "escalate" task only runs when
+// the "analyze" task reports a high severity.
+func newGuardedWorkflow(t *testing.T) (*Workflow, *Task) {
+	t.Helper()
+
+	wf, err := New(nil, "ops/triage", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	analyze := wf.Set("analyze", &SetArgs{Variables: map[string]interface{}{"severity": "${ .input.severity }"}})
+	escalate := wf.HttpGet("escalate", "https://pager.example.com/page", nil).
+		RunIf(Condition(analyze.Field("severity"), Equals("high")))
+	return wf, escalate
+}
+
+func TestRunIf_Synthesis(t *testing.T) {
+	wf, escalate := newGuardedWorkflow(t)
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	tasks := pb.GetSpec().GetTasks()
+	if got := tasks[0].GetIf(); got != "" {
+		t.Errorf("analyze if = %q, want none", got)
+	}
+	want := `${ $context["analyze"].severity | . == "high" }`
+	if got := tasks[1].GetIf(); got != want {
+		t.Errorf("escalate if = %q, want %q", got, want)
+	}
+	if !slices.Contains(escalate.Dependencies, "analyze") {
+		t.Errorf("Dependencies = %v, want analyze", escalate.Dependencies)
+	}
+}
+
+func TestRunIf_ReferenceToSkippableTask(t *testing.T) {
+	t.Run("unguarded", func(t *testing.T) {
+		wf, escalate := newGuardedWorkflow(t)
+		wf.Set("record", &SetArgs{Variables: map[string]interface{}{"incident": escalate.Field("id").Expression()}})
+
+		if _, err := wf.ToProto(); !errors.Is(err, ErrUnguardedReference) {
+			t.Fatalf("ToProto() error = %v, want %v", err, ErrUnguardedReference)
+		}
+	})
+
+	t.Run("guarded on skipped status", func(t *testing.T) {
+		wf, escalate := newGuardedWorkflow(t)
+		wf.Set("record", &SetArgs{Variables: map[string]interface{}{"incident": escalate.Field("id").Expression()}}).
+			RunIf(escalate.Skipped().Not())
+
+		pb, err := wf.ToProto()
+		if err != nil {
+			t.Fatalf("ToProto() failed: %v", err)
+		}
+		want := `${ ($context["escalate"].skipped | . == true) | not }`
+		if got := pb.GetSpec().GetTasks()[2].GetIf(); got != want {
+			t.Errorf("record if = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestRunIf_GuardOnLaterTask(t *testing.T) {
+	wf, err := New(nil, "ops/triage", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	notify := wf.HttpGet("notify", "https://chat.example.com/hook", nil)
+	analyze := wf.Set("analyze", &SetArgs{Variables: map[string]interface{}{"severity": "${ .input.severity }"}})
+	notify.RunIf(Condition(analyze.Field("severity"), Equals("high")))
+
+	if _, err := wf.ToProto(); !errors.Is(err, ErrInvalidRunIf) {
+		t.Fatalf("ToProto() error = %v, want %v", err, ErrInvalidRunIf)
+	}
+}
+
+func TestTaskCondition_Matchers(t *testing.T) {
+	analyze := &Task{Name: "analyze"}
+	score := analyze.Field("score")
+
+	tests := []struct {
+		cond TaskCondition
+		want string
+	}{
+		{Condition(score, GreaterThan(7)), `${ $context["analyze"].score | . > 7 }`},
+		{Condition(score, LessThan(3)).Not(), `${ ($context["analyze"].score | . < 3) | not }`},
+		{Condition(score, CustomCondition("${ . >= 4 and . <= 6 }")), `${ $context["analyze"].score | . >= 4 and . <= 6 }`},
+	}
+	for _, tt := range tests {
+		if got := tt.cond.Expression(); got != tt.want {
+			t.Errorf("Expression() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -92,12 +92,13 @@ func RuntimeEnv(varName string) string {
 // This is used internally during synthesis to catch malformed references early.
 //
 // Valid formats:
-//   - ${.secrets.KEY_NAME}  (uppercase, underscores allowed)
-//   - ${.env_vars.VAR_NAME} (uppercase, underscores allowed)
+//   - ${.secrets.KEY_NAME}  (letters, digits and underscores)
+//   - ${.env_vars.VAR_NAME} (letters, digits and underscores)
+//   - ${.env_vars.apiBase}  (mixed case, as exported by ctx.ExportToRuntime)
 //
 // Invalid formats:
 //   - ${secrets.KEY}        (missing leading dot)
-//   - ${.secrets.keyName}   (lowercase not allowed)
+//   - ${.secrets.1KEY}      (leading digit not allowed)
 //   - ${.secrets.KEY-NAME}  (hyphens not allowed)
 //   - ${.other.KEY}         (only secrets and env_vars supported)
 //
@@ -109,8 +110,8 @@ func RuntimeEnv(varName string) string {
 //	}
 func ValidateRuntimeRef(ref string) error {
 	// Pattern: ${.secrets.KEY} or ${.env_vars.VAR}
-	// Key/Var names must be UPPERCASE with underscores
-	pattern := regexp.MustCompile(`^\$\{\.(?:secrets|env_vars)\.[A-Z_][A-Z0-9_]*\}$`)
+	// Key/Var names are identifiers: letters, digits and underscores
+	pattern := regexp.MustCompile(`^\$\{\.(?:secrets|env_vars)\.[A-Za-z_][A-Za-z0-9_]*\}$`)
 	if !pattern.MatchString(ref) {
 		return fmt.Errorf("invalid runtime reference format: %s (expected ${.secrets.KEY} or ${.env_vars.VAR})", ref)
 	}
@@ -127,7 +128,7 @@ func ValidateRuntimeRef(ref string) error {
 //	workflow.IsRuntimeRef("https://api.example.com") // false
 //	workflow.IsRuntimeRef("${ $context.apiURL }") // false
 func IsRuntimeRef(s string) bool {
-	pattern := regexp.MustCompile(`^\$\{\.(?:secrets|env_vars)\.[A-Z_][A-Z0-9_]*\}$`)
+	pattern := regexp.MustCompile(`^\$\{\.(?:secrets|env_vars)\.[A-Za-z_][A-Za-z0-9_]*\}$`)
 	return pattern.MatchString(s)
 }
 
@@ -141,7 +142,7 @@ func IsRuntimeRef(s string) bool {
 //	refs := workflow.ExtractRuntimeRefs(s)
 //	// refs = ["${.secrets.TOKEN}", "${.env_vars.ENVIRONMENT}"]
 func ExtractRuntimeRefs(s string) []string {
-	pattern := regexp.MustCompile(`\$\{\.(?:secrets|env_vars)\.[A-Z_][A-Z0-9_]*\}`)
+	pattern := regexp.MustCompile(`\$\{\.(?:secrets|env_vars)\.[A-Za-z_][A-Za-z0-9_]*\}`)
 	return pattern.FindAllString(s, -1)
 }
 
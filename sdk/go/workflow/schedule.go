@@ -0,0 +1,231 @@
+package workflow
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+)
+
+// WorkflowOption configures a Workflow created with New.
+type WorkflowOption func(*Workflow)
+
+// CatchUp defines what happens to schedule fire times missed while the
+// scheduler was unavailable (for example, while the server was down).
+type CatchUp struct {
+	policy workflowv1.ScheduleCatchUpPolicy
+}
+
+var (
+	// SkipMissed drops missed fire times (the default).
+	SkipMissed = CatchUp{policy: workflowv1.ScheduleCatchUpPolicy_SCHEDULE_SKIP_MISSED}
+
+	// RunMissed runs missed fire times once the scheduler is available again.
+	RunMissed = CatchUp{policy: workflowv1.ScheduleCatchUpPolicy_SCHEDULE_RUN_MISSED}
+)
+
+// String returns the catch-up policy name.
+func (c CatchUp) String() string {
+	if c.policy == workflowv1.ScheduleCatchUpPolicy_SCHEDULE_RUN_MISSED {
+		return "run-missed"
+	}
+	return "skip-missed"
+}
+
+// Schedule triggers executions of a workflow on a cron schedule.
+// Set it with WithSchedule.
+type Schedule struct {
+	// Cron is a 5-field cron expression or a descriptor such as "@daily".
+	Cron string
+
+	// Timezone is the IANA time zone the expression is evaluated in ("" means UTC).
+	Timezone string
+
+	// CatchUp controls what happens to missed fire times.
+	CatchUp CatchUp
+}
+
+// ScheduleOption configures a Schedule passed to WithSchedule.
+type ScheduleOption func(*Schedule)
+
+// Timezone sets the IANA time zone the cron expression is evaluated in.
+// Defaults to "UTC".
+func Timezone(name string) ScheduleOption {
+	return func(s *Schedule) {
+		s.Timezone = name
+	}
+}
+
+// CatchUpPolicy sets what happens to fire times missed while the scheduler
+// was unavailable. Defaults to SkipMissed.
+func CatchUpPolicy(c CatchUp) ScheduleOption {
+	return func(s *Schedule) {
+		s.CatchUp = c
+	}
+}
+
+// WithSchedule triggers executions of the workflow on a cron schedule,
+// without an external scheduler.
+//
+// The cron expression has five fields (minute, hour, day of month, month,
+// day of week) or is one of the descriptors @yearly, @annually, @monthly,
+// @weekly, @daily, @midnight and @hourly. The expression and time zone are
+// validated by New.
+//
+// The server maintains a schedule for each instance of the workflow: it is
+// created when the instance is applied, updated when the workflow spec
+// changes, and removed with the instance. Instances can override the
+// schedule with workflowinstance.Args.Schedule.
+//
+// Example:
+//
+//	wf, err := workflow.New(ctx, "ops/daily-sync", nil,
+//	    workflow.WithSchedule("0 2 * * *",
+//	        workflow.Timezone("UTC"),
+//	        workflow.CatchUpPolicy(workflow.SkipMissed),
+//	    ),
+//	)
+func WithSchedule(cron string, opts ...ScheduleOption) WorkflowOption {
+	return func(w *Workflow) {
+		s := &Schedule{Cron: cron}
+		for _, opt := range opts {
+			opt(s)
+		}
+		w.Schedule = s
+	}
+}
+
+// Validate checks the cron expression and time zone. New calls it for the
+// schedule set with WithSchedule.
+func (s *Schedule) Validate() error {
+	if err := validateCron(s.Cron); err != nil {
+		return NewValidationErrorWithCause(
+			"schedule.cron",
+			s.Cron,
+			"format",
+			fmt.Sprintf("invalid cron expression %q: %v", s.Cron, err),
+			ErrInvalidSchedule,
+		)
+	}
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil || s.Timezone == "Local" {
+			return NewValidationErrorWithCause(
+				"schedule.timezone",
+				s.Timezone,
+				"format",
+				fmt.Sprintf("unknown time zone %q (use an IANA name such as \"Europe/Berlin\")", s.Timezone),
+				ErrInvalidSchedule,
+			)
+		}
+	}
+	return nil
+}
+
+// ToProto converts the schedule to its proto form. Returns nil for a nil schedule.
+func (s *Schedule) ToProto() *workflowv1.WorkflowSchedule {
+	if s == nil {
+		return nil
+	}
+	policy := s.CatchUp.policy
+	if policy == workflowv1.ScheduleCatchUpPolicy_SCHEDULE_CATCH_UP_POLICY_UNSPECIFIED {
+		policy = workflowv1.ScheduleCatchUpPolicy_SCHEDULE_SKIP_MISSED
+	}
+	return &workflowv1.WorkflowSchedule{
+		Cron:          s.Cron,
+		Timezone:      s.Timezone,
+		CatchUpPolicy: policy,
+	}
+}
+
+// cronDescriptors lists the supported cron descriptors.
+var cronDescriptors = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// cronField describes the allowed values of one cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names[i] stands for min+i (months and weekdays)
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// validateCron checks a 5-field cron expression or descriptor.
+func validateCron(expr string) error {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return fmt.Errorf("expression is empty")
+	}
+	if strings.HasPrefix(expr, "@") {
+		if !cronDescriptors[expr] {
+			return fmt.Errorf("unknown descriptor %s", expr)
+		}
+		return nil
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+	for i, field := range fields {
+		if err := cronFields[i].validate(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks one field: a comma-separated list of "*", values or
+// ranges, each optionally followed by "/step".
+func (f cronField) validate(field string) error {
+	for _, item := range strings.Split(field, ",") {
+		base, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n <= 0 {
+				return fmt.Errorf("%s: invalid step %q", f.name, step)
+			}
+		}
+		if base == "*" || base == "?" {
+			continue
+		}
+		low, high, isRange := strings.Cut(base, "-")
+		from, err := f.value(low)
+		if err != nil {
+			return err
+		}
+		if isRange {
+			to, err := f.value(high)
+			if err != nil {
+				return err
+			}
+			if from > to {
+				return fmt.Errorf("%s: range %s is inverted", f.name, base)
+			}
+		}
+	}
+	return nil
+}
+
+// value parses a single field value, accepting names where the field has them.
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s: %q is not a value between %d and %d", f.name, s, f.min, f.max)
+	}
+	return n, nil
+}
@@ -0,0 +1,89 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+)
+
+func TestNew_WithSchedule(t *testing.T) {
+	wf, err := New(nil, "ops/daily-sync", &WorkflowArgs{Version: "1.0.0"},
+		WithSchedule("0 2 * * *", Timezone("UTC"), CatchUpPolicy(RunMissed)),
+	)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.Set("init", &SetArgs{Variables: map[string]interface{}{"ok": "true"}})
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+	schedule := pb.GetSpec().GetSchedule()
+	if schedule.GetCron() != "0 2 * * *" || schedule.GetTimezone() != "UTC" {
+		t.Errorf("schedule = %v, want cron 0 2 * * * in UTC", schedule)
+	}
+	if schedule.GetCatchUpPolicy() != workflowv1.ScheduleCatchUpPolicy_SCHEDULE_RUN_MISSED {
+		t.Errorf("catch_up_policy = %v, want SCHEDULE_RUN_MISSED", schedule.GetCatchUpPolicy())
+	}
+}
+
+func TestNew_WithScheduleDefaults(t *testing.T) {
+	wf, err := New(nil, "ops/hourly-sync", nil, WithSchedule("@hourly"))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	got := wf.Schedule.ToProto()
+	if got.GetCatchUpPolicy() != workflowv1.ScheduleCatchUpPolicy_SCHEDULE_SKIP_MISSED {
+		t.Errorf("catch_up_policy = %v, want SCHEDULE_SKIP_MISSED", got.GetCatchUpPolicy())
+	}
+
+	wf, err = New(nil, "ops/manual", nil)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if wf.Schedule.ToProto() != nil {
+		t.Error("workflow without WithSchedule should have no schedule")
+	}
+}
+
+func TestNew_WithScheduleValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cron    string
+		opts    []ScheduleOption
+		wantErr bool
+	}{
+		{name: "daily", cron: "0 2 * * *"},
+		{name: "lists ranges and steps", cron: "*/15 9-17 1,15 * MON-FRI"},
+		{name: "month names", cron: "0 0 1 jan,jul *"},
+		{name: "descriptor", cron: "@weekly"},
+		{name: "iana timezone", cron: "0 2 * * *", opts: []ScheduleOption{Timezone("Europe/Berlin")}},
+		{name: "empty", cron: "", wantErr: true},
+		{name: "too few fields", cron: "0 2 * *", wantErr: true},
+		{name: "six fields", cron: "0 0 2 * * *", wantErr: true},
+		{name: "minute out of range", cron: "60 2 * * *", wantErr: true},
+		{name: "hour out of range", cron: "0 24 * * *", wantErr: true},
+		{name: "inverted range", cron: "0 17-9 * * *", wantErr: true},
+		{name: "zero step", cron: "*/0 * * * *", wantErr: true},
+		{name: "unknown descriptor", cron: "@sometimes", wantErr: true},
+		{name: "unknown timezone", cron: "0 2 * * *", opts: []ScheduleOption{Timezone("Mars/Olympus")}, wantErr: true},
+		{name: "local timezone", cron: "0 2 * * *", opts: []ScheduleOption{Timezone("Local")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(nil, "ops/sync", nil, WithSchedule(tt.cron, tt.opts...))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSchedule) {
+					t.Errorf("New() error = %v, want ErrInvalidSchedule", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("New() unexpected error = %v", err)
+			}
+		})
+	}
+}
@@ -1,5 +1,11 @@
 package workflow
 
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
 // SetArgs is an alias for SetTaskConfig (Pulumi-style args pattern).
 type SetArgs = SetTaskConfig
 
@@ -9,8 +15,8 @@ type SetArgs = SetTaskConfig
 // Example:
 //
 //	task := workflow.Set("init", &workflow.SetArgs{
-//	    Variables: map[string]string{
-//	        "x": "1",
+//	    Variables: map[string]interface{}{
+//	        "x": 1,
 //	        "y": "${.input.value}",
 //	        "computed": "${.a + .b}",
 //	    },
@@ -22,7 +28,7 @@ func Set(name string, args *SetArgs) *Task {
 
 	// Initialize maps if nil
 	if args.Variables == nil {
-		args.Variables = make(map[string]string)
+		args.Variables = make(map[string]interface{})
 	}
 
 	return &Task{
@@ -31,3 +37,106 @@ func Set(name string, args *SetArgs) *Task {
 		Config: args,
 	}
 }
+
+// UnsetVar returns SetArgs that remove the named variables from workflow
+// state, for use with Set and wf.Set. The runner deletes the keys rather
+// than setting them to empty values, so later tasks cannot see them.
+//
+// Use it to drop credentials or bulky intermediate data before a later
+// phase of the workflow:
+//
+//	wf.Set("dropToken", workflow.UnsetVar("tempToken"))
+//
+// Field() references to an unset variable from tasks after the unsetting
+// task fail synthesis with ErrVariableUnset.
+func UnsetVar(names ...string) *SetArgs {
+	return &SetArgs{Unset: names}
+}
+
+// CopyFields returns SetArgs that copy output fields of a task into variables
+// of the same names, for use with Set and wf.Set:
+//
+//	wf.Set("copyUser", workflow.CopyFields(fetchTask, "id", "name", "email"))
+//
+// This is the same as setting each variable to fetchTask.Field(field): the
+// SET task depends on fetchTask, and fields outside the task's declared
+// outputs (narrowed with Exports, or an agent's output schema) fail
+// synthesis. Use CopyFieldsAs to name the variables differently, for example
+// for nested fields.
+func CopyFields(task *Task, fields ...string) *SetArgs {
+	variables := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		variables[field] = task.Field(field)
+	}
+	return &SetArgs{Variables: variables}
+}
+
+// CopyFieldsAs is like CopyFields, with each variable named by a key of
+// fields and set to the output field given as its value:
+//
+//	wf.Set("copyUser", workflow.CopyFieldsAs(fetchTask, map[string]string{
+//	    "userName": "name",
+//	    "city":     "address.city",
+//	}))
+func CopyFieldsAs(task *Task, fields map[string]string) *SetArgs {
+	variables := make(map[string]interface{}, len(fields))
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		variables[name] = task.Field(fields[name])
+	}
+	return &SetArgs{Variables: variables}
+}
+
+// SetVars creates a SET task from alternating variable names and values.
+//
+// Values keep their Go types in the manifest: integers and floats are set as
+// numbers, bools as booleans, and maps and slices as objects and arrays.
+// Context variables known at synthesis, such as an ObjectRef from
+// ctx.SetObject, are set as their values. References such as TaskFieldRef are
+// set as their expression strings, and make the task depend on the referenced
+// task. A nil value removes the
+// variable; prefer UnsetVar to make that explicit.
+//
+// Names must be strings and every name needs a value; synthesis fails with
+// ErrInvalidSetVars otherwise.
+//
+// Example:
+//
+//	task := workflow.SetVars("init",
+//	    "retryCount", 3,
+//	    "debug", true,
+//	    "title", fetchTask.Field("title"),
+//	)
+func SetVars(name string, keyValues ...interface{}) *Task {
+	task := Set(name, nil)
+	args := task.Config.(*SetArgs)
+
+	if len(keyValues)%2 != 0 {
+		task.setVarsErr = fmt.Sprintf("variable %v has no value", keyValues[len(keyValues)-1])
+		keyValues = keyValues[:len(keyValues)-1]
+	}
+	for i := 0; i < len(keyValues); i += 2 {
+		key, ok := keyValues[i].(string)
+		if !ok {
+			if task.setVarsErr == "" {
+				task.setVarsErr = fmt.Sprintf("variable name at argument %d is %T, not a string", i+1, keyValues[i])
+			}
+			continue
+		}
+		args.Variables[key] = keyValues[i+1]
+	}
+	return task
+}
+
+// validateSetVars reports invalid arguments passed to SetVars.
+func (t *Task) validateSetVars() error {
+	if t.setVarsErr == "" {
+		return nil
+	}
+	return NewValidationErrorWithCause(
+		"variables",
+		"",
+		"key_value_pairs",
+		fmt.Sprintf("task %q: SetVars %s", t.Name, t.setVarsErr),
+		ErrInvalidSetVars,
+	)
+}
@@ -0,0 +1,249 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestUnsetVar_Synthesis(t *testing.T) {
+	wf, err := New(nil, "ops/rotate", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	wf.Set("login", &SetArgs{Variables: map[string]interface{}{"tempToken": "${ .input.token }"}})
+	wf.Set("dropToken", UnsetVar("tempToken"))
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	config := pb.GetSpec().GetTasks()[1].GetTaskConfig().GetFields()
+	unset := config["unset"].GetListValue().GetValues()
+	if len(unset) != 1 || unset[0].GetStringValue() != "tempToken" {
+		t.Errorf("unset = %v, want [tempToken]", unset)
+	}
+	if _, ok := config["variables"]; ok {
+		t.Errorf("variables = %v, want none for an unset-only task", config["variables"])
+	}
+}
+
+func TestUnsetVar_FieldReferenceAfterUnset(t *testing.T) {
+	wf, err := New(nil, "ops/rotate", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	login := wf.Set("login", &SetArgs{Variables: map[string]interface{}{"tempToken": "${ .input.token }"}})
+	token := login.Field("tempToken")
+	wf.Set("useToken", &SetArgs{Variables: map[string]interface{}{"header": token.Expression()}})
+	wf.Set("dropToken", UnsetVar("tempToken"))
+	wf.Set("publish", &SetArgs{Variables: map[string]interface{}{"header": token.Expression()}})
+
+	_, err = wf.ToProto()
+	if !errors.Is(err, ErrVariableUnset) {
+		t.Fatalf("ToProto() error = %v, want %v", err, ErrVariableUnset)
+	}
+}
+
+func TestUnsetVar_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		args *SetArgs
+	}{
+		{"set and unset the same variable", &SetArgs{
+			Variables: map[string]interface{}{"tempToken": "x"},
+			Unset:     []string{"tempToken"},
+		}},
+		{"empty variable name", UnsetVar("")},
+		{"nothing to set or unset", &SetArgs{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "ops/rotate", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.Set("dropToken", tt.args)
+
+			if _, err := wf.ToProto(); err == nil {
+				t.Error("ToProto() succeeded, want a validation error")
+			}
+		})
+	}
+}
+
+func TestSetVars_PreservesTypes(t *testing.T) {
+	wf, err := New(nil, "ops/retry", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	type level int
+	fetch := wf.HttpGet("fetch", "https://api.example.com/items", nil)
+	wf.SetVars("init",
+		"retryCount", 3,
+		"ratio", 0.5,
+		"debug", true,
+		"label", "nightly",
+		"level", level(2),
+		"limits", map[string]interface{}{"max": 10, "strict": false},
+		"regions", []string{"eu", "us"},
+		"title", fetch.Field("title"),
+	)
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	vars := pb.GetSpec().GetTasks()[1].GetTaskConfig().GetFields()["variables"].GetStructValue().GetFields()
+	assertNumber := func(name string, want float64) {
+		t.Helper()
+		if _, ok := vars[name].GetKind().(*structpb.Value_NumberValue); !ok || vars[name].GetNumberValue() != want {
+			t.Errorf("%s = %v, want number %v", name, vars[name], want)
+		}
+	}
+	assertNumber("retryCount", 3)
+	assertNumber("ratio", 0.5)
+	assertNumber("level", 2)
+
+	if _, ok := vars["debug"].GetKind().(*structpb.Value_BoolValue); !ok || !vars["debug"].GetBoolValue() {
+		t.Errorf("debug = %v, want bool true", vars["debug"])
+	}
+	if got := vars["label"].GetStringValue(); got != "nightly" {
+		t.Errorf("label = %v, want string nightly", vars["label"])
+	}
+
+	limits := vars["limits"].GetStructValue().GetFields()
+	if _, ok := limits["max"].GetKind().(*structpb.Value_NumberValue); !ok || limits["max"].GetNumberValue() != 10 {
+		t.Errorf("limits.max = %v, want number 10", limits["max"])
+	}
+	if _, ok := limits["strict"].GetKind().(*structpb.Value_BoolValue); !ok || limits["strict"].GetBoolValue() {
+		t.Errorf("limits.strict = %v, want bool false", limits["strict"])
+	}
+
+	regions := vars["regions"].GetListValue().GetValues()
+	if len(regions) != 2 || regions[0].GetStringValue() != "eu" || regions[1].GetStringValue() != "us" {
+		t.Errorf("regions = %v, want [eu us]", vars["regions"])
+	}
+
+	if got, want := vars["title"].GetStringValue(), fetch.Field("title").Expression(); got != want {
+		t.Errorf("title = %q, want expression %q", got, want)
+	}
+	if deps := wf.Dependencies()["init"]; len(deps) != 1 || deps[0] != "fetch" {
+		t.Errorf("Dependencies()[init] = %v, want [fetch]", deps)
+	}
+}
+
+func TestSetVars_InvalidArguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyValues []interface{}
+		want      string
+	}{
+		{"missing value", []interface{}{"retryCount", 3, "debug"}, `variable debug has no value`},
+		{"non-string name", []interface{}{42, "x"}, `variable name at argument 1 is int, not a string`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "ops/retry", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.SetVars("init", tt.keyValues...)
+
+			_, err = wf.ToProto()
+			if !errors.Is(err, ErrInvalidSetVars) {
+				t.Fatalf("ToProto() error = %v, want %v", err, ErrInvalidSetVars)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ToProto() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyFields_MatchesHandWrittenSet(t *testing.T) {
+	build := func(copyUser func(fetch *Task) *SetArgs) *Workflow {
+		wf, err := New(nil, "crm/sync", &WorkflowArgs{Version: "1.0.0"})
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		fetch := wf.HttpGet("fetchUser", "https://api.example.com/users/1", nil)
+		wf.Set("copyUser", copyUser(fetch))
+		return wf
+	}
+
+	tests := []struct {
+		name        string
+		copied      func(fetch *Task) *SetArgs
+		handWritten func(fetch *Task) *SetArgs
+	}{
+		{
+			name: "CopyFields",
+			copied: func(fetch *Task) *SetArgs {
+				return CopyFields(fetch, "id", "name", "email")
+			},
+			handWritten: func(fetch *Task) *SetArgs {
+				return &SetArgs{Variables: map[string]interface{}{
+					"id":    fetch.Field("id"),
+					"name":  fetch.Field("name"),
+					"email": fetch.Field("email"),
+				}}
+			},
+		},
+		{
+			name: "CopyFieldsAs",
+			copied: func(fetch *Task) *SetArgs {
+				return CopyFieldsAs(fetch, map[string]string{"userName": "name", "city": "address.city"})
+			},
+			handWritten: func(fetch *Task) *SetArgs {
+				return &SetArgs{Variables: map[string]interface{}{
+					"userName": fetch.Field("name"),
+					"city":     fetch.Field("address.city"),
+				}}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copied, err := build(tt.copied).ToProto()
+			if err != nil {
+				t.Fatalf("ToProto() failed: %v", err)
+			}
+			handWritten, err := build(tt.handWritten).ToProto()
+			if err != nil {
+				t.Fatalf("ToProto() failed: %v", err)
+			}
+
+			if !proto.Equal(copied.GetSpec(), handWritten.GetSpec()) {
+				t.Errorf("spec = %v, want %v", copied.GetSpec(), handWritten.GetSpec())
+			}
+			fetch := copied.GetSpec().GetTasks()[0]
+			if fetch.GetExport().GetAs() != "${.}" {
+				t.Errorf("fetchUser export = %q, want ${.}", fetch.GetExport().GetAs())
+			}
+		})
+	}
+}
+
+func TestCopyFields_DeclaredOutputs(t *testing.T) {
+	wf, err := New(nil, "crm/sync", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	fetch := wf.HttpGet("fetchUser", "https://api.example.com/users/1", nil).Exports("id", "name")
+	wf.Set("copyUser", CopyFields(fetch, "id", "email"))
+
+	if _, err := wf.ToProto(); !errors.Is(err, ErrFieldNotExported) {
+		t.Fatalf("ToProto() error = %v, want ErrFieldNotExported", err)
+	}
+}
@@ -0,0 +1,80 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+)
+
+// StringExpr is accepted by the Ref companion of each expression field, such
+// as AgentCallArgs.MessageRef or ForArgs.InRef.
+//
+// It is implemented by stigmer.StringRef, TaskFieldRef (task.Field), RawExpr
+// (Expr) and *Task (the task's whole output). IntRef and BoolRef do not
+// implement it, so passing one where a string is expected fails to compile.
+// The plain field (Message, In, ...) is a string holding a literal or a
+// hand-written "${ }" expression; Expr is the checked escape hatch for raw
+// expressions in the Ref companion. Setting both fields fails synthesis with
+// ErrMutuallyExclusive.
+//
+// Example:
+//
+//	wf.AgentCall("review", &workflow.AgentCallArgs{
+//	    Agent:      workflow.AgentBySlug("code-reviewer"),
+//	    MessageRef: fetch.Field("diff"),
+//	})
+type StringExpr = types.StringExpr
+
+// StringExpression returns the expression for this field reference.
+// Implements StringExpr.
+func (r TaskFieldRef) StringExpression() string {
+	return r.Expression()
+}
+
+// StringExpression returns the expression wrapped in "${ }".
+// Implements StringExpr.
+func (e RawExpr) StringExpression() string {
+	return e.Expression()
+}
+
+// StringExpression returns an expression for the task's whole output.
+// Implements StringExpr, so a task can be iterated directly:
+// ForArgs{InRef: fetchTask}.
+func (t *Task) StringExpression() string {
+	if t == nil {
+		return fmt.Sprintf("${ $context[%q] }", "<nil task at unknown location>")
+	}
+	return fmt.Sprintf("${ $context[\"%s\"] }", t.Name)
+}
+
+// validateExpressionRefs rejects expression fields set both in the plain
+// field and through their Ref companion.
+func (t *Task) validateExpressionRefs() error {
+	var err error
+	switch cfg := t.Config.(type) {
+	case *AgentCallTaskConfig:
+		err = cfg.ValidateOneofs()
+		for _, attachment := range cfg.Attachments {
+			if err == nil && attachment != nil {
+				err = attachment.ValidateOneofs()
+			}
+		}
+	case *HttpCallTaskConfig:
+		if cfg.Endpoint != nil {
+			err = cfg.Endpoint.ValidateOneofs()
+		}
+	case *ForTaskConfig:
+		err = cfg.ValidateOneofs()
+	case *RaiseTaskConfig:
+		err = cfg.ValidateOneofs()
+	case *ListenTaskConfig:
+		if cfg.To != nil {
+			for _, signal := range cfg.To.Signals {
+				if err == nil && signal != nil {
+					err = signal.ValidateOneofs()
+				}
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,117 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+)
+
+func TestStringExpr_Refs(t *testing.T) {
+	fetch := HttpGet("fetch", "https://api.example.com/items", nil)
+
+	tasks := []*Task{
+		fetch,
+		For("loop", &ForArgs{
+			InRef: fetch,
+			Do: LoopBody(func(item LoopVar) []*Task {
+				return []*Task{Set("record", &SetArgs{Variables: map[string]interface{}{"id": item.Field("id")}})}
+			}),
+		}),
+		AgentCall("review", &AgentCallArgs{
+			Agent:      "reviewer",
+			MessageRef: fetch.Field("body"),
+		}),
+		Raise("fail", &RaiseArgs{
+			ErrorRef: Expr(".code"),
+			Message:  "lookup failed",
+		}),
+	}
+	protoTasks, err := convertTasks(tasks)
+	if err != nil {
+		t.Fatalf("convertTasks() error = %v", err)
+	}
+
+	tests := []struct {
+		task  int
+		field string
+		want  string
+	}{
+		{1, "in", `${ $context["fetch"] }`},
+		{2, "message", `${ $context["fetch"].body }`},
+		{3, "error", "${ .code }"},
+		{3, "message", "lookup failed"},
+	}
+	for _, tt := range tests {
+		got := protoTasks[tt.task].TaskConfig.GetFields()[tt.field].GetStringValue()
+		if got != tt.want {
+			t.Errorf("task %q %s = %q, want %q", tasks[tt.task].Name, tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestStringExpr_BothSet(t *testing.T) {
+	fetch := HttpGet("fetch", "https://api.example.com/items", nil)
+
+	tests := []struct {
+		name string
+		task *Task
+	}{
+		{"agent call message", AgentCall("review", &AgentCallArgs{
+			Agent:      "reviewer",
+			Message:    "Review this",
+			MessageRef: fetch.Field("body"),
+		})},
+		{"for in", For("loop", &ForArgs{In: "${ .items }", InRef: fetch})},
+		{"attachment content", AgentCall("review", &AgentCallArgs{
+			Agent: "reviewer",
+			Attachments: []*types.AgentAttachment{
+				{Name: "diff", Content: "literal", ContentRef: fetch.Field("body")},
+			},
+		})},
+		{"endpoint uri", HttpCall("call", &HttpCallArgs{
+			Method:   "GET",
+			Endpoint: &types.HttpEndpoint{Uri: "https://api.example.com", UriRef: fetch.Field("next")},
+		})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := convertTasks([]*Task{fetch, tt.task})
+			if !errors.Is(err, ErrMutuallyExclusive) {
+				t.Errorf("convertTasks() error = %v, want ErrMutuallyExclusive", err)
+			}
+		})
+	}
+}
+
+func TestStringExpr_PlainFields(t *testing.T) {
+	// The plain fields hold literals and hand-written raw expressions
+	tasks := []*Task{
+		For("loop", &ForArgs{
+			In:    `${ $context["fetch"].items }`,
+			Until: "${ .done == true }",
+			Do: LoopBody(func(item LoopVar) []*Task {
+				return []*Task{Set("record", &SetArgs{Variables: map[string]interface{}{"id": item.Field("id")}})}
+			}),
+		}),
+		AgentCall("review", &AgentCallArgs{
+			Agent:   "reviewer",
+			Message: "Review the latest items",
+		}),
+	}
+	protoTasks, err := convertTasks(tasks)
+	if err != nil {
+		t.Fatalf("convertTasks() error = %v", err)
+	}
+
+	fields := protoTasks[0].TaskConfig.GetFields()
+	if got := fields["in"].GetStringValue(); got != `${ $context["fetch"].items }` {
+		t.Errorf("in = %q", got)
+	}
+	if got := fields["until"].GetStringValue(); got != "${ .done == true }" {
+		t.Errorf("until = %q", got)
+	}
+	if got := protoTasks[1].TaskConfig.GetFields()["message"].GetStringValue(); got != "Review the latest items" {
+		t.Errorf("message = %q", got)
+	}
+}
@@ -57,7 +57,7 @@ type equalsMatcher struct {
 }
 
 func (m *equalsMatcher) Expression() string {
-	return fmt.Sprintf("${. == %v}", m.value)
+	return fmt.Sprintf("${. == %s}", formatValue(m.value))
 }
 
 // Equals creates a matcher that checks equality.
@@ -76,7 +76,7 @@ type greaterThanMatcher struct {
 }
 
 func (m *greaterThanMatcher) Expression() string {
-	return fmt.Sprintf("${. > %v}", m.value)
+	return fmt.Sprintf("${. > %s}", formatValue(m.value))
 }
 
 // GreaterThan creates a matcher that checks if value is greater than threshold.
@@ -94,7 +94,7 @@ type lessThanMatcher struct {
 }
 
 func (m *lessThanMatcher) Expression() string {
-	return fmt.Sprintf("${. < %v}", m.value)
+	return fmt.Sprintf("${. < %s}", formatValue(m.value))
 }
 
 // LessThan creates a matcher that checks if value is less than threshold.
@@ -2,6 +2,10 @@ package workflow
 
 import (
 	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // TaskKind represents the type of workflow task.
@@ -48,9 +52,94 @@ type Task struct {
 	// Flow control (which task executes next)
 	ThenTask string
 
+	// Wall-clock execution bound for this task (0 = runner default).
+	// Enforced by the runner as the activity timeout, or with a workflow
+	// timer for tasks that run in workflow code (set, for, switch, ...).
+	ExecutionTimeoutAfter time.Duration
+
+	// Top-level output fields redacted from history and exported context.
+	// Values stay available to later tasks in the same execution.
+	SensitiveOutputs []string
+
+	// Human-readable description for handover notes and generated docs (set via Describe).
+	// Stored in the manifest; not interpreted by the runner.
+	Description string
+
 	// Explicit dependencies (optional, for cases where field references don't capture it)
 	// This is tracked automatically when using TaskFieldRef but can be set explicitly
 	Dependencies []string
+
+	// exportedFields narrows the export to these top-level output fields (set via Exports).
+	// Empty means the export is not narrowed.
+	exportedFields []string
+
+	// emptyExports records an Exports() call without field names; it fails synthesis.
+	emptyExports bool
+
+	// referencedFields records the top-level output fields accessed via Field().
+	// Used to verify references against a narrowed export during synthesis.
+	referencedFields []string
+
+	// referencedPaths records the full field paths accessed via Field().
+	// Used to verify references against an agent's output schema.
+	referencedPaths []string
+
+	// referencedBranches records the branch names accessed via Branch().
+	// Used to verify them against the fork's branches during synthesis.
+	referencedBranches []string
+
+	// suppressedLintRules lists lint rule IDs disabled via SuppressLint
+	suppressedLintRules []string
+
+	// runIf guards the task; the task is skipped when it does not hold (set via RunIf).
+	runIf *TaskCondition
+
+	// repeat holds the range of a loop created with Repeat, for validation.
+	repeat *repeatLoop
+
+	// mapping holds the source and projection of a task created with Map, for validation.
+	mapping *mapping
+
+	// setVarsErr records invalid arguments passed to SetVars, for validation.
+	setVarsErr string
+
+	// durationErr records a timeout or wait duration that cannot be stored in
+	// whole seconds (set via TimeoutDuration, WaitFor or ApprovalTimeoutDuration), for validation.
+	durationErr string
+
+	// allowBody permits a body on GET and DELETE requests (set via AllowBodyOnGet).
+	allowBody bool
+
+	// bodySetBy names the option that encoded the request body (BodyForm or
+	// BodyMultipart), and bodyErr records a conflicting body, for validation.
+	bodySetBy string
+	bodyErr   string
+
+	// httpStatusSet marks an HTTP call whose status check was set by an
+	// option, so the workflow default is not applied; httpStatusErr records
+	// an ExpectStatus call without status codes, for validation.
+	httpStatusSet bool
+	httpStatusErr string
+
+	// agentRef is the agent reference passed to an AGENT_CALL task, for validation.
+	agentRef *AgentReference
+
+	// grpcRequestType is the message type passed to GrpcRequestFromProto, for validation.
+	grpcRequestType protoreflect.FullName
+
+	// grpcRequestErr records a GrpcRequestFromProto serialization failure, for validation.
+	grpcRequestErr string
+
+	// grpcFields lists the request fields set via GrpcField, in order.
+	grpcFields []grpcFieldOverride
+
+	// workflow is the workflow this task was added to (set by AddTask).
+	// Used by Rename to check uniqueness and update references.
+	workflow *Workflow
+
+	// previousNames lists the names this task had before Rename, oldest first.
+	// Expressions rendered with an old name are rewritten during synthesis.
+	previousNames []string
 }
 
 // TaskConfig is a marker interface for task configurations.
@@ -75,9 +164,14 @@ type TaskConfig interface {
 //
 //	workflow.FieldRef("title")  // ❌ Where does "title" come from? Unclear!
 //	fetchTask.Field("title")    // ✅ Clear origin - from fetchTask
+//
+// The reference holds the task itself, so the task name is resolved when the
+// expression is rendered and follows Rename.
 type TaskFieldRef struct {
-	taskName  string // Name of the task this field comes from
+	task      *Task  // Task this field comes from (nil for name-only references)
+	taskName  string // Name of the task, used when task is nil
 	fieldName string // Name of the field in the task output
+	weak      bool   // Referencing the field does not make a task depend on the source task
 }
 
 // Expression returns the JQ expression for this field reference.
@@ -86,18 +180,60 @@ func (r TaskFieldRef) Expression() string {
 	// Use bracket notation for task name to support hyphens and special characters
 	// Reference format: ${ $context["task-name"].fieldName }
 	// This allows task names to contain hyphens without breaking jq parsing
-	return fmt.Sprintf("${ $context[\"%s\"].%s }", r.taskName, r.fieldName)
+	return fmt.Sprintf("${ %s }", r.path())
+}
+
+// path returns the bare JQ path of this field, without the ${ } wrapper.
+// Weak references mark the task lookup with ? (see Weak).
+func (r TaskFieldRef) path() string {
+	if r.weak {
+		return fmt.Sprintf("%s?.%s", contextRef(r.TaskName()), r.fieldName)
+	}
+	return fmt.Sprintf("$context[\"%s\"].%s", r.TaskName(), r.fieldName)
+}
+
+// Weak returns a reference to the same field that does not make the
+// referencing task depend on the source task.
+//
+// Use it when a task only needs the value opportunistically, such as a
+// logging task labeling its output with a field of another task. The
+// expression is rendered as usual, but Dependencies() omits the edge and
+// reports it through WeakDependencies() instead, and the lint and RunIf
+// checks treat the source task as optional.
+//
+// At runtime the value resolves to null when the source task has not
+// produced output, because it runs later, runs in another Fork branch, or
+// was skipped. Synthesis still checks that the source task is part of the
+// workflow.
+//
+// Example:
+//
+//	fetchTask := wf.HttpGet("fetch", endpoint)
+//	logTask := wf.SetVars("log",
+//	    "label", fetchTask.Field("name").Weak(), // null if fetch has not run
+//	)
+func (r TaskFieldRef) Weak() TaskFieldRef {
+	r.weak = true
+	return r
+}
+
+// IsWeak reports whether this is a weak reference created with Weak.
+func (r TaskFieldRef) IsWeak() bool {
+	return r.weak
 }
 
 // Name returns a human-readable name for this reference.
 // Implements the Ref interface.
 func (r TaskFieldRef) Name() string {
-	return fmt.Sprintf("%s.%s", r.taskName, r.fieldName)
+	return fmt.Sprintf("%s.%s", r.TaskName(), r.fieldName)
 }
 
 // TaskName returns the name of the source task.
 // This is used for dependency tracking.
 func (r TaskFieldRef) TaskName() string {
+	if r.task != nil {
+		return r.task.Name
+	}
 	return r.taskName
 }
 
@@ -251,7 +387,15 @@ func formatValue(value interface{}) string {
 //
 //	workflow.FieldRef("title")  // ❌ Magic string - where's it from?
 //	fetchTask.Field("title")    // ✅ Clear origin!
+//
+// Calling Field on a nil task, such as one from a builder whose error was
+// ignored, does not panic: synthesis fails with ErrNilTaskReference, naming
+// the line that called Field.
 func (t *Task) Field(fieldName string) TaskFieldRef {
+	if t == nil {
+		return TaskFieldRef{taskName: nilTaskName(), fieldName: fieldName}
+	}
+
 	// Auto-export: When a task's field is referenced, automatically export the task
 	// This matches Pulumi's implicit dependency pattern where accessing an output
 	// automatically makes it available in the workflow context.
@@ -261,7 +405,13 @@ func (t *Task) Field(fieldName string) TaskFieldRef {
 		t.ExportAs = "${.}"
 	}
 
+	// Remember the referenced root field so synthesis can reject references
+	// to fields that were narrowed away by Exports().
+	t.referencedFields = append(t.referencedFields, rootFieldName(fieldName))
+	t.referencedPaths = append(t.referencedPaths, fieldName)
+
 	return TaskFieldRef{
+		task:      t,
 		taskName:  t.Name,
 		fieldName: fieldName,
 	}
@@ -338,6 +488,90 @@ func (t *Task) ExportFields(fieldNames ...string) *Task {
 	return t
 }
 
+// Exports narrows the exported output of this task to the given top-level fields.
+// Only these fields are carried forward in the workflow context, which keeps
+// large payloads (full HTTP bodies, agent transcripts) out of workflow history.
+//
+// Field() keeps working for exported paths. Referencing a field that is not
+// exported fails synthesis with ErrFieldNotExported. Calling Exports without
+// field names fails synthesis with ErrEmptyExports.
+//
+// Example:
+//
+//	fetchTask := wf.HttpGet("fetch", endpoint, nil).Exports("id", "title")
+//	title := fetchTask.Field("title")  // ✅ Exported
+//	body := fetchTask.Field("body")    // ❌ Synthesis error: "body" is not exported
+func (t *Task) Exports(fieldNames ...string) *Task {
+	t.exportedFields = append([]string(nil), fieldNames...)
+	t.emptyExports = len(fieldNames) == 0
+
+	pairs := make([]string, len(fieldNames))
+	for i, field := range fieldNames {
+		pairs[i] = fmt.Sprintf("%q: .[%q]", field, field)
+	}
+	t.ExportAs = fmt.Sprintf("${ {%s} }", strings.Join(pairs, ", "))
+	return t
+}
+
+// ExportedFields returns the fields this task's export is narrowed to.
+// Returns nil if the task exports its full output (or nothing).
+func (t *Task) ExportedFields() []string {
+	return t.exportedFields
+}
+
+// ExportsFullOutput reports whether this task exports its entire output
+// to the workflow context (ExportAs = "${.}").
+func (t *Task) ExportsFullOutput() bool {
+	return t.ExportAs == "${.}"
+}
+
+// validateFieldReferences checks that every field referenced via Field()
+// is part of the narrowed export, if the export was narrowed with Exports().
+func (t *Task) validateFieldReferences() error {
+	if t.emptyExports {
+		return NewValidationErrorWithCause(
+			"exports",
+			"",
+			"required",
+			fmt.Sprintf("task %q: Exports() needs at least one field name", t.Name),
+			ErrEmptyExports,
+		)
+	}
+
+	if len(t.exportedFields) == 0 {
+		return nil
+	}
+
+	exported := make(map[string]bool, len(t.exportedFields))
+	for _, field := range t.exportedFields {
+		exported[field] = true
+	}
+
+	for _, field := range t.referencedFields {
+		if !exported[field] {
+			return NewValidationErrorWithCause(
+				"field",
+				field,
+				"exported",
+				fmt.Sprintf("task %q does not export field %q (exported: %s)",
+					t.Name, field, strings.Join(t.exportedFields, ", ")),
+				ErrFieldNotExported,
+			)
+		}
+	}
+
+	return nil
+}
+
+// rootFieldName returns the top-level field of a field path.
+// Example: "chunks[3].text" -> "chunks", "user.name" -> "user".
+func rootFieldName(path string) string {
+	if i := strings.IndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
+
 // Then sets the flow control directive for this task using a task name string.
 // Example: task.Then("nextTask") jumps to task named "nextTask".
 //
@@ -359,6 +593,56 @@ func (t *Task) ThenRef(task *Task) *Task {
 	return t
 }
 
+// SensitiveOutput marks top-level output fields as sensitive (tokens, credentials).
+// The runner replaces these values with a redaction marker in exported context,
+// task output and execution history, while Field() references from later tasks
+// in the same execution still resolve to the real values.
+//
+// Sensitive fields must not be copied into a custom Export() expression, since
+// the runner can only redact them under their original names.
+//
+// Example:
+//
+//	login := wf.HttpPost("login", authEndpoint, nil, credentials).
+//	    SensitiveOutput("access_token", "refresh_token")
+//	wf.HttpGet("profile", profileEndpoint, map[string]string{
+//	    "Authorization": workflow.Interpolate("Bearer ", login.Field("access_token")),
+//	})
+func (t *Task) SensitiveOutput(fields ...string) *Task {
+	t.SensitiveOutputs = append(t.SensitiveOutputs, fields...)
+	return t
+}
+
+// validateSensitiveOutputs checks that sensitive fields are well-formed and
+// are not re-exported under another name by a custom export expression.
+func (t *Task) validateSensitiveOutputs() error {
+	customExport := t.ExportAs != "" && !t.ExportsFullOutput() && len(t.exportedFields) == 0
+
+	for _, field := range t.SensitiveOutputs {
+		if field == "" {
+			return NewValidationErrorWithCause(
+				"sensitive_output",
+				field,
+				"required",
+				"sensitive output field name must not be empty",
+				ErrInvalidSensitiveOutput,
+			)
+		}
+		if customExport && strings.Contains(t.ExportAs, field) {
+			return NewValidationErrorWithCause(
+				"sensitive_output",
+				field,
+				"redacted",
+				fmt.Sprintf("task %q exports sensitive field %q through custom expression %q; "+
+					"use ExportAll() or Exports() so the runner can redact it", t.Name, field, t.ExportAs),
+				ErrSensitiveOutputExported,
+			)
+		}
+	}
+
+	return nil
+}
+
 // End terminates the workflow after this task.
 // This is equivalent to task.Then(workflow.EndFlow) but more explicit.
 func (t *Task) End() *Task {
@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -122,3 +124,162 @@ func TestFormatValue(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskExports(t *testing.T) {
+	task := HttpGet("fetch", "https://api.example.com/posts/1", nil).Exports("id", "title")
+
+	expected := `${ {"id": .["id"], "title": .["title"]} }`
+	if task.ExportAs != expected {
+		t.Errorf("ExportAs = %s, expected %s", task.ExportAs, expected)
+	}
+	if task.ExportsFullOutput() {
+		t.Error("narrowed task should not report a full export")
+	}
+
+	// Field() must not widen a narrowed export
+	task.Field("title")
+	if task.ExportAs != expected {
+		t.Errorf("Field() overwrote narrowed export: %s", task.ExportAs)
+	}
+}
+
+func TestTaskExports_FieldReferenceValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		wantErr bool
+	}{
+		{"exported field", "title", false},
+		{"nested path under exported field", "id.value", false},
+		{"indexed path under exported field", "title[0]", false},
+		{"non-exported field", "body", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := HttpGet("fetch", "https://api.example.com/posts/1", nil).Exports("id", "title")
+			task.Field(tt.field)
+
+			_, err := convertTasks([]*Task{task})
+			if tt.wantErr {
+				if !errors.Is(err, ErrFieldNotExported) {
+					t.Errorf("expected ErrFieldNotExported, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTaskExports_NoFields(t *testing.T) {
+	task := HttpGet("fetch", "https://api.example.com/posts/1", nil).Exports()
+
+	_, err := convertTasks([]*Task{task})
+	if !errors.Is(err, ErrEmptyExports) {
+		t.Errorf("expected ErrEmptyExports, got %v", err)
+	}
+}
+
+func TestWorkflowExportSummary(t *testing.T) {
+	wf, err := New(nil, "test/export-summary", nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	full := wf.HttpGet("full", "https://api.example.com/a", nil)
+	narrowed := wf.HttpGet("narrowed", "https://api.example.com/b", nil).Exports("id")
+	wf.HttpGet("unexported", "https://api.example.com/c", nil)
+	full.Field("data")
+	narrowed.Field("id")
+
+	summary := wf.ExportSummary()
+	if summary.TotalTasks != 3 {
+		t.Errorf("TotalTasks = %d, expected 3", summary.TotalTasks)
+	}
+	if len(summary.FullExports) != 1 || summary.FullExports[0] != "full" {
+		t.Errorf("FullExports = %v, expected [full]", summary.FullExports)
+	}
+	if len(summary.NarrowedExports) != 1 || summary.NarrowedExports[0] != "narrowed" {
+		t.Errorf("NarrowedExports = %v, expected [narrowed]", summary.NarrowedExports)
+	}
+}
+
+func TestTaskFieldRef_Weak(t *testing.T) {
+	wf, err := New(nil, "ops/sync", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	fetch := wf.HttpGet("fetch", "https://api.example.com/items", nil)
+	wf.Set("log", &SetArgs{Variables: map[string]interface{}{"label": fetch.Field("name").Weak().Expression()}})
+
+	if got, want := fetch.Field("name").Weak().Expression(), `${ $context["fetch"]?.name }`; got != want {
+		t.Errorf("Expression() = %q, want %q", got, want)
+	}
+	if deps := wf.Dependencies()["log"]; len(deps) != 0 {
+		t.Errorf("Dependencies()[log] = %v, want none", deps)
+	}
+	if weak := wf.WeakDependencies()["log"]; len(weak) != 1 || weak[0] != "fetch" {
+		t.Errorf("WeakDependencies()[log] = %v, want [fetch]", weak)
+	}
+
+	// A strong reference to the same task takes precedence
+	wf.Set("store", &SetArgs{Variables: map[string]interface{}{
+		"label": fetch.Field("name").Weak().Expression(),
+		"id":    fetch.Field("id").Expression(),
+	}})
+	if deps := wf.Dependencies()["store"]; len(deps) != 1 || deps[0] != "fetch" {
+		t.Errorf("Dependencies()[store] = %v, want [fetch]", deps)
+	}
+	if weak := wf.WeakDependencies()["store"]; len(weak) != 0 {
+		t.Errorf("WeakDependencies()[store] = %v, want none", weak)
+	}
+
+	if _, err := wf.ToProto(); err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+}
+
+func TestTaskFieldRef_WeakToUnknownTask(t *testing.T) {
+	wf, err := New(nil, "ops/sync", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	other := &Task{Name: "fetchElsewhere"}
+	wf.Set("log", &SetArgs{Variables: map[string]interface{}{"label": other.Field("name").Weak().Expression()}})
+
+	if _, err := wf.ToProto(); !errors.Is(err, ErrUnknownTaskReference) {
+		t.Fatalf("ToProto() error = %v, want %v", err, ErrUnknownTaskReference)
+	}
+}
+
+func TestTaskFieldRef_WeakToSkippableTask(t *testing.T) {
+	wf, escalate := newGuardedWorkflow(t)
+	wf.Set("log", &SetArgs{Variables: map[string]interface{}{"incident": escalate.Field("id").Weak().Expression()}})
+
+	if _, err := wf.ToProto(); err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+}
+
+func TestTaskField_NilTask(t *testing.T) {
+	wf, err := New(nil, "ops/nil-task", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var fetch *Task // e.g. from a builder whose error was ignored
+	wf.SetVars("process", "title", fetch.Field("title"))
+
+	_, err = wf.ToProto()
+	if !errors.Is(err, ErrNilTaskReference) {
+		t.Fatalf("ToProto() error = %v, want ErrNilTaskReference", err)
+	}
+	if !strings.Contains(err.Error(), "task_field_ref_test.go:") {
+		t.Errorf("error %q does not name the line that called Field", err)
+	}
+}
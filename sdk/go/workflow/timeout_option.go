@@ -0,0 +1,97 @@
+package workflow
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+)
+
+// TimeoutOption sets the timeout of a task. It is accepted by the builders
+// of the task kinds that have a timeout: HTTP_CALL (HttpOption), AGENT_CALL
+// (AgentCallOption) and CALL_ACTIVITY (CallActivityOption).
+type TimeoutOption struct {
+	d time.Duration
+}
+
+// TimeoutDuration sets the timeout of an HTTP_CALL, AGENT_CALL or
+// CALL_ACTIVITY task.
+//
+// The runner enforces timeouts in whole seconds: fractional seconds are
+// rounded up, so the task never times out earlier than asked. Timeouts
+// shorter than one second fail synthesis with ErrInvalidDuration, which also
+// catches bare numbers: TimeoutDuration(30) compiles but means 30 nanoseconds.
+// So do timeouts longer than math.MaxInt32 seconds, which the runner cannot
+// store.
+//
+// Example:
+//
+//	wf.HttpGet("fetch", endpoint, nil, workflow.TimeoutDuration(10*time.Second))
+//	wf.CallAgent("review", &workflow.AgentCallArgs{
+//	    Message: "Review this PR: ${.input.prUrl}",
+//	}, workflow.AgentBySlug("code-reviewer"), workflow.TimeoutDuration(10*time.Minute))
+func TimeoutDuration(d time.Duration) TimeoutOption {
+	return TimeoutOption{d: d}
+}
+
+// Timeout sets the timeout of an HTTP_CALL, AGENT_CALL or CALL_ACTIVITY
+// task. It behaves exactly like TimeoutDuration.
+//
+// Deprecated: Use TimeoutDuration. Timeout used to take int seconds, and
+// calls such as Timeout(30) still compile as 30 nanoseconds.
+func Timeout(d time.Duration) TimeoutOption {
+	return TimeoutDuration(d)
+}
+
+// seconds returns the timeout in whole seconds, rounded up. A timeout shorter
+// than one second is recorded on the task for validation.
+func (o TimeoutOption) seconds(t *Task) int32 {
+	return timeoutSeconds(t, o.d)
+}
+
+func (o TimeoutOption) applyHttp(t *Task, cfg *HttpCallTaskConfig) {
+	cfg.TimeoutSeconds = o.seconds(t)
+}
+
+func (o TimeoutOption) applyAgentCall(t *Task, cfg *AgentCallTaskConfig) {
+	if cfg.Config == nil {
+		cfg.Config = &types.AgentExecutionConfig{}
+	}
+	cfg.Config.Timeout = o.seconds(t)
+}
+
+func (o TimeoutOption) applyCallActivity(t *Task, cfg *CallActivityTaskConfig) {
+	cfg.TimeoutSeconds = o.seconds(t)
+}
+
+// timeoutSeconds converts a timeout to whole seconds, rounded up. Timeouts
+// shorter than one second, or too long for the int32 seconds field, are
+// recorded on the task for validation.
+func timeoutSeconds(t *Task, d time.Duration) int32 {
+	if d < time.Second {
+		t.durationErr = fmt.Sprintf("timeout %s is shorter than one second (did you mean n*time.Second?)", d)
+		return 0
+	}
+	seconds := math.Ceil(d.Seconds())
+	if seconds > math.MaxInt32 {
+		t.durationErr = fmt.Sprintf("timeout %s is longer than %d seconds", d, math.MaxInt32)
+		return 0
+	}
+	return int32(seconds)
+}
+
+// validateDuration reports a timeout or wait duration that cannot be stored
+// in whole seconds.
+func (t *Task) validateDuration() error {
+	if t.durationErr == "" {
+		return nil
+	}
+	return NewValidationErrorWithCause(
+		"duration",
+		"",
+		"duration",
+		fmt.Sprintf("task %q: %s", t.Name, t.durationErr),
+		ErrInvalidDuration,
+	)
+}
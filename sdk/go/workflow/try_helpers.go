@@ -1,6 +1,10 @@
 package workflow
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/stigmer/stigmer/sdk/go/gen/types"
 )
 
@@ -38,6 +42,21 @@ func TryBody(tasks ...*Task) []*types.WorkflowTask {
 			}
 		}
 
+		// Extract execution timeout if present
+		if timeoutSeconds, ok := taskMap["executionTimeoutSeconds"].(int32); ok {
+			wfTask.ExecutionTimeoutSeconds = timeoutSeconds
+		}
+
+		// Extract sensitive output fields if present
+		if fields, ok := taskMap["sensitiveOutputFields"].([]string); ok {
+			wfTask.SensitiveOutputFields = fields
+		}
+
+		// Extract description if present
+		if description, ok := taskMap["description"].(string); ok {
+			wfTask.Description = description
+		}
+
 		workflowTasks = append(workflowTasks, wfTask)
 	}
 	return workflowTasks
@@ -50,7 +69,7 @@ func TryBody(tasks ...*Task) []*types.WorkflowTask {
 //
 //	Catch: workflow.CatchBody("error",
 //	    wf.Set("logError", &workflow.SetArgs{
-//	        Variables: map[string]string{
+//	        Variables: map[string]interface{}{
 //	            "message": "${.error.message}",
 //	        },
 //	    }),
@@ -61,3 +80,198 @@ func CatchBody(errorVar string, tasks ...*Task) *types.CatchBlock {
 		Do: TryBody(tasks...),
 	}
 }
+
+// maxTryRetries is the most retries a retry policy allows, as enforced by
+// the TryTaskConfig proto.
+const maxTryRetries = 100
+
+// CatchFilter selects the errors a catch block handles. Create one with
+// ErrorTypeIs or StatusCodeIn and apply it with CatchMatching.
+type CatchFilter struct {
+	errorTypes  []string
+	statusCodes []int32
+}
+
+// ErrorTypeIs matches errors whose type or classification is one of
+// errorTypes, such as "UPSTREAM_4XX", "UPSTREAM_5XX", "TIMEOUT" or the type
+// of a raised error.
+func ErrorTypeIs(errorTypes ...string) CatchFilter {
+	if len(errorTypes) == 0 {
+		// Rejected at synthesis rather than silently catching every error
+		errorTypes = []string{""}
+	}
+	return CatchFilter{errorTypes: errorTypes}
+}
+
+// StatusCodeIn matches HTTP calls that failed with one of the given response
+// status codes, such as 429.
+func StatusCodeIn(codes ...int) CatchFilter {
+	if len(codes) == 0 {
+		// Rejected at synthesis rather than silently catching every error
+		codes = []int{0}
+	}
+	statusCodes := make([]int32, len(codes))
+	for i, code := range codes {
+		statusCodes[i] = int32(code)
+	}
+	return CatchFilter{statusCodes: statusCodes}
+}
+
+// CatchMatching restricts a catch block to the errors matching filter, for
+// use in TryArgs.Catches. Applying several filters to the same block requires
+// an error to match all of them.
+//
+// Catches are evaluated in declaration order and the first matching block
+// handles the error. An unfiltered block, such as one created with CatchBody
+// alone, matches every error and can only come last.
+//
+// Example:
+//
+//	wf.Try("fetchOrders", &workflow.TryArgs{
+//	    Try: workflow.TryBody(fetch),
+//	    Catches: []*types.CatchBlock{
+//	        workflow.CatchMatching(workflow.StatusCodeIn(429), workflow.CatchBody("error", backoff)),
+//	        workflow.CatchMatching(workflow.ErrorTypeIs("UPSTREAM_4XX"), workflow.CatchBody("error", report)),
+//	        workflow.CatchBody("error", fallback),
+//	    },
+//	})
+func CatchMatching(filter CatchFilter, handler *types.CatchBlock) *types.CatchBlock {
+	block := &types.CatchBlock{}
+	if handler != nil {
+		*block = *handler
+	}
+	block.ErrorTypes = append(slices.Clone(block.ErrorTypes), filter.errorTypes...)
+	block.StatusCodes = append(slices.Clone(block.StatusCodes), filter.statusCodes...)
+	return block
+}
+
+// validateCatches checks the catch blocks of a TRY task: their filters must
+// be valid, and every block must be reachable. An unfiltered block catches
+// every error, so no block may follow it, and Catch is unreachable after one.
+func (t *Task) validateCatches() error {
+	cfg, ok := t.Config.(*TryTaskConfig)
+	if !ok {
+		return nil
+	}
+
+	if cfg.Catch != nil && isFilteredCatch(cfg.Catch) {
+		return NewValidationErrorWithCause(
+			"catch", "", "unfiltered",
+			fmt.Sprintf("task %q: Catch handles the errors no filtered catch matched and cannot have filters; use Catches", t.Name),
+			ErrInvalidCatchFilter,
+		)
+	}
+
+	for i, catch := range cfg.Catches {
+		field := fmt.Sprintf("catches[%d]", i)
+		if catch == nil || len(catch.Do) == 0 {
+			return NewValidationErrorWithCause(
+				field, "", "required",
+				fmt.Sprintf("task %q: catch block %d has no tasks", t.Name, i),
+				ErrInvalidCatchFilter,
+			)
+		}
+		if err := validateCatchFilter(t.Name, field, catch); err != nil {
+			return err
+		}
+		if isFilteredCatch(catch) {
+			continue
+		}
+		if i < len(cfg.Catches)-1 {
+			return NewValidationErrorWithCause(
+				fmt.Sprintf("catches[%d]", i+1), "", "reachable",
+				fmt.Sprintf("task %q: catch block %d is unreachable: catch block %d has no filter and catches every error",
+					t.Name, i+1, i),
+				ErrUnreachableCatch,
+			)
+		}
+		if cfg.Catch != nil {
+			return NewValidationErrorWithCause(
+				"catch", "", "reachable",
+				fmt.Sprintf("task %q: Catch is unreachable: catch block %d has no filter and catches every error", t.Name, i),
+				ErrUnreachableCatch,
+			)
+		}
+	}
+	return nil
+}
+
+// validateCatchFilter checks the error types and status codes of a catch
+// block.
+func validateCatchFilter(taskName, field string, catch *types.CatchBlock) error {
+	for _, errorType := range catch.ErrorTypes {
+		if strings.TrimSpace(errorType) == "" {
+			return NewValidationErrorWithCause(
+				field+".errorTypes", errorType, "required",
+				fmt.Sprintf("task %q: ErrorTypeIs needs non-empty error types", taskName),
+				ErrInvalidCatchFilter,
+			)
+		}
+	}
+	seen := make(map[int32]bool, len(catch.StatusCodes))
+	for _, code := range catch.StatusCodes {
+		if code < 100 || code > 599 {
+			return NewValidationErrorWithCause(
+				field+".statusCodes", fmt.Sprint(code), "range",
+				fmt.Sprintf("task %q: status %d is not an HTTP status code (100-599)", taskName, code),
+				ErrInvalidCatchFilter,
+			)
+		}
+		if seen[code] {
+			return NewValidationErrorWithCause(
+				field+".statusCodes", fmt.Sprint(code), "unique",
+				fmt.Sprintf("task %q: status %d is listed twice", taskName, code),
+				ErrInvalidCatchFilter,
+			)
+		}
+		seen[code] = true
+	}
+	return nil
+}
+
+// validateRetry checks the retry policy of a TRY task: it needs non-empty
+// error types, 1-100 retries and a delay that is not negative.
+func (t *Task) validateRetry() error {
+	cfg, ok := t.Config.(*TryTaskConfig)
+	if !ok || cfg.Retry == nil {
+		return nil
+	}
+
+	retry := cfg.Retry
+	if len(retry.ErrorTypes) == 0 {
+		return NewValidationErrorWithCause(
+			"retry.errorTypes", "", "required",
+			fmt.Sprintf("task %q: the retry policy needs at least one error type", t.Name),
+			ErrInvalidRetryPolicy,
+		)
+	}
+	for _, errorType := range retry.ErrorTypes {
+		if strings.TrimSpace(errorType) == "" {
+			return NewValidationErrorWithCause(
+				"retry.errorTypes", errorType, "required",
+				fmt.Sprintf("task %q: the retry policy needs non-empty error types", t.Name),
+				ErrInvalidRetryPolicy,
+			)
+		}
+	}
+	if retry.MaxRetries < 1 || retry.MaxRetries > maxTryRetries {
+		return NewValidationErrorWithCause(
+			"retry.maxRetries", fmt.Sprint(retry.MaxRetries), "range",
+			fmt.Sprintf("task %q: the retry policy allows %d retries, want 1-%d", t.Name, retry.MaxRetries, maxTryRetries),
+			ErrInvalidRetryPolicy,
+		)
+	}
+	if retry.DelaySeconds < 0 {
+		return NewValidationErrorWithCause(
+			"retry.delaySeconds", fmt.Sprint(retry.DelaySeconds), "min",
+			fmt.Sprintf("task %q: the retry delay cannot be negative", t.Name),
+			ErrInvalidRetryPolicy,
+		)
+	}
+	return nil
+}
+
+// isFilteredCatch reports whether a catch block only handles some errors.
+func isFilteredCatch(catch *types.CatchBlock) bool {
+	return len(catch.ErrorTypes) > 0 || len(catch.StatusCodes) > 0
+}
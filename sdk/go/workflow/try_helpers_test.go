@@ -0,0 +1,307 @@
+package workflow
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
+)
+
+func TestCatchMatching_Synthesis(t *testing.T) {
+	wf, err := New(nil, "ops/fetch-orders", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	wf.Try("fetchOrders", &TryArgs{
+		Try: TryBody(HttpGet("fetch", "https://api.example.com/orders", nil)),
+		Catches: []*types.CatchBlock{
+			CatchMatching(StatusCodeIn(429, 503), CatchBody("error", Wait("backoff", &WaitArgs{Seconds: 30}))),
+			CatchMatching(ErrorTypeIs("UPSTREAM_4XX"), CatchBody("clientError",
+				Set("report", &SetArgs{Variables: map[string]interface{}{"rejected": "true"}}))),
+			CatchBody("error", Set("fallback", &SetArgs{Variables: map[string]interface{}{"failed": "true"}})),
+		},
+	})
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	catches := pb.GetSpec().GetTasks()[0].GetTaskConfig().GetFields()["catches"].GetListValue().GetValues()
+	if len(catches) != 3 {
+		t.Fatalf("got %d catch blocks, want 3", len(catches))
+	}
+	block := func(i int) map[string]interface{} {
+		return catches[i].GetStructValue().AsMap()
+	}
+	firstTask := func(i int) string {
+		return block(i)["do"].([]interface{})[0].(map[string]interface{})["name"].(string)
+	}
+
+	if got := block(0)["status_codes"]; !slices.Equal(got.([]interface{}), []interface{}{429.0, 503.0}) {
+		t.Errorf("catches[0].status_codes = %v, want [429 503]", got)
+	}
+	if got := firstTask(0); got != "backoff" {
+		t.Errorf("catches[0] runs %q, want backoff", got)
+	}
+	if got := block(1)["error_types"]; !slices.Equal(got.([]interface{}), []interface{}{"UPSTREAM_4XX"}) {
+		t.Errorf("catches[1].error_types = %v, want [UPSTREAM_4XX]", got)
+	}
+	if got := block(1)["as"]; got != "clientError" {
+		t.Errorf("catches[1].as = %v, want clientError", got)
+	}
+	if _, ok := block(2)["error_types"]; ok {
+		t.Errorf("catches[2] has error types, want an unfiltered block")
+	}
+	if got := firstTask(2); got != "fallback" {
+		t.Errorf("catches[2] runs %q, want fallback", got)
+	}
+}
+
+func TestCatchMatching_ComposesWithoutMutatingHandler(t *testing.T) {
+	handler := CatchBody("error", Set("report", &SetArgs{Variables: map[string]interface{}{"failed": "true"}}))
+
+	block := CatchMatching(StatusCodeIn(404), CatchMatching(ErrorTypeIs("UPSTREAM_4XX"), handler))
+
+	if !slices.Equal(block.ErrorTypes, []string{"UPSTREAM_4XX"}) || !slices.Equal(block.StatusCodes, []int32{404}) {
+		t.Errorf("CatchMatching() = types %v, codes %v, want both filters", block.ErrorTypes, block.StatusCodes)
+	}
+	if block.As != "error" || len(block.Do) != 1 {
+		t.Errorf("CatchMatching() = as %q with %d tasks, want the handler's", block.As, len(block.Do))
+	}
+	if len(handler.ErrorTypes) != 0 || len(handler.StatusCodes) != 0 {
+		t.Errorf("CatchMatching() modified the handler: %+v", handler)
+	}
+}
+
+func TestCatchMatching_Validation(t *testing.T) {
+	handler := func() *types.CatchBlock {
+		return CatchBody("error", Set("handle", &SetArgs{Variables: map[string]interface{}{"handled": "true"}}))
+	}
+
+	tests := []struct {
+		name    string
+		args    func() *TryArgs
+		wantErr error
+	}{
+		{
+			name: "unfiltered catch before filtered ones",
+			args: func() *TryArgs {
+				return &TryArgs{Catches: []*types.CatchBlock{
+					handler(),
+					CatchMatching(StatusCodeIn(429), handler()),
+				}}
+			},
+			wantErr: ErrUnreachableCatch,
+		},
+		{
+			name: "unfiltered catch before Catch",
+			args: func() *TryArgs {
+				return &TryArgs{Catches: []*types.CatchBlock{handler()}, Catch: handler()}
+			},
+			wantErr: ErrUnreachableCatch,
+		},
+		{
+			name: "filtered Catch",
+			args: func() *TryArgs {
+				return &TryArgs{Catch: CatchMatching(ErrorTypeIs("TIMEOUT"), handler())}
+			},
+			wantErr: ErrInvalidCatchFilter,
+		},
+		{
+			name: "no error types",
+			args: func() *TryArgs {
+				return &TryArgs{Catches: []*types.CatchBlock{CatchMatching(ErrorTypeIs(), handler())}}
+			},
+			wantErr: ErrInvalidCatchFilter,
+		},
+		{
+			name: "no status codes",
+			args: func() *TryArgs {
+				return &TryArgs{Catches: []*types.CatchBlock{CatchMatching(StatusCodeIn(), handler())}}
+			},
+			wantErr: ErrInvalidCatchFilter,
+		},
+		{
+			name: "status code out of range",
+			args: func() *TryArgs {
+				return &TryArgs{Catches: []*types.CatchBlock{CatchMatching(StatusCodeIn(42), handler())}}
+			},
+			wantErr: ErrInvalidCatchFilter,
+		},
+		{
+			name: "duplicate status code",
+			args: func() *TryArgs {
+				return &TryArgs{Catches: []*types.CatchBlock{CatchMatching(StatusCodeIn(429, 429), handler())}}
+			},
+			wantErr: ErrInvalidCatchFilter,
+		},
+		{
+			name: "no tasks",
+			args: func() *TryArgs {
+				return &TryArgs{Catches: []*types.CatchBlock{CatchMatching(StatusCodeIn(429), nil)}}
+			},
+			wantErr: ErrInvalidCatchFilter,
+		},
+		{
+			name: "filtered catches with a final unfiltered one and Catch",
+			args: func() *TryArgs {
+				return &TryArgs{
+					Catches: []*types.CatchBlock{CatchMatching(StatusCodeIn(429), handler())},
+					Catch:   handler(),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "ops/fetch-orders", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			args := tt.args()
+			args.Try = TryBody(HttpGet("fetch", "https://api.example.com/orders", nil))
+			wf.Try("fetchOrders", args)
+
+			_, err = wf.ToProto()
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("ToProto() failed: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToProto() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCatchMatching_NotExportable(t *testing.T) {
+	wf := &Workflow{
+		Document: Document{DSL: "1.0.0", Namespace: "shop", Name: "orders", Version: "1.0.0"},
+		Tasks: []*Task{
+			Try("fetchOrders", &TryArgs{
+				Try: TryBody(HttpGet("fetch", "https://api.example.com/orders", nil)),
+				Catches: []*types.CatchBlock{
+					CatchMatching(StatusCodeIn(429), CatchBody("error", Wait("backoff", &WaitArgs{Seconds: 30}))),
+				},
+			}),
+		},
+	}
+
+	if _, err := ExportYAML(wf); !errors.Is(err, ErrNotExportable) {
+		t.Errorf("ExportYAML() error = %v, want ErrNotExportable", err)
+	}
+}
+
+func TestRetryOn_Synthesis(t *testing.T) {
+	wf, err := New(nil, "ops/review", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	retry := RetryOn(CatchExecutionTimeout().Or(CatchHTTPErrors()), 2)
+	retry.DelaySeconds = 10
+	wf.Try("review", &TryArgs{
+		Try:   TryBody(HttpGet("fetch", "https://api.example.com/orders", nil).ExecutionTimeout(30 * time.Second)),
+		Retry: retry,
+		Catch: CatchBody("error", Set("fallback", &SetArgs{Variables: map[string]interface{}{"failed": "true"}})),
+	})
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	got := pb.GetSpec().GetTasks()[0].GetTaskConfig().GetFields()["retry"].GetStructValue().AsMap()
+	want := map[string]interface{}{
+		"error_types":   []interface{}{ErrorTypeExecutionTimeout, ErrorTypeHTTPCall},
+		"max_retries":   2.0,
+		"delay_seconds": 10.0,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("retry = %v, want %v", got, want)
+	}
+}
+
+func TestRetryOn_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		retry   func() *types.RetryPolicy
+		wantErr error
+	}{
+		{
+			name:  "execution timeout",
+			retry: func() *types.RetryPolicy { return RetryOn(CatchExecutionTimeout(), 3) },
+		},
+		{
+			name:    "no matcher",
+			retry:   func() *types.RetryPolicy { return RetryOn(nil, 3) },
+			wantErr: ErrInvalidRetryPolicy,
+		},
+		{
+			name:    "empty error type",
+			retry:   func() *types.RetryPolicy { return RetryOn(CatchCustom(" "), 3) },
+			wantErr: ErrInvalidRetryPolicy,
+		},
+		{
+			name:    "no retries",
+			retry:   func() *types.RetryPolicy { return RetryOn(CatchExecutionTimeout(), 0) },
+			wantErr: ErrInvalidRetryPolicy,
+		},
+		{
+			name:    "too many retries",
+			retry:   func() *types.RetryPolicy { return RetryOn(CatchExecutionTimeout(), 1<<32+1) },
+			wantErr: ErrInvalidRetryPolicy,
+		},
+		{
+			name: "negative delay",
+			retry: func() *types.RetryPolicy {
+				retry := RetryOn(CatchExecutionTimeout(), 3)
+				retry.DelaySeconds = -1
+				return retry
+			},
+			wantErr: ErrInvalidRetryPolicy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "ops/review", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.Try("review", &TryArgs{
+				Try:   TryBody(HttpGet("fetch", "https://api.example.com/orders", nil)),
+				Retry: tt.retry(),
+			})
+
+			_, err = wf.ToProto()
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("ToProto() failed: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ToProto() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryOn_NotExportable(t *testing.T) {
+	wf := &Workflow{
+		Document: Document{DSL: "1.0.0", Namespace: "shop", Name: "orders", Version: "1.0.0"},
+		Tasks: []*Task{
+			Try("fetchOrders", &TryArgs{
+				Try:   TryBody(HttpGet("fetch", "https://api.example.com/orders", nil)),
+				Retry: RetryOn(CatchExecutionTimeout(), 2),
+			}),
+		},
+	}
+
+	if _, err := ExportYAML(wf); !errors.Is(err, ErrNotExportable) {
+		t.Errorf("ExportYAML() error = %v, want ErrNotExportable", err)
+	}
+}
@@ -46,6 +46,10 @@ func Try(name string, args *TryArgs) *Task {
 type ErrorRef struct {
 	// varName is the variable name for the error
 	varName string
+
+	// root is what the variable is read from in expressions: "." (the task
+	// input) by default, "$data." for the handler tasks of WithOnFailure
+	root string
 }
 
 // NewErrorRef creates a new ErrorRef with the given variable name.
@@ -53,7 +57,15 @@ func NewErrorRef(varName string) ErrorRef {
 	if varName == "" {
 		varName = "error"
 	}
-	return ErrorRef{varName: varName}
+	return ErrorRef{varName: varName, root: "."}
+}
+
+// path returns the expression path of the error, such as ".error".
+func (e ErrorRef) path() string {
+	if e.root == "" {
+		return "." + e.varName
+	}
+	return e.root + e.varName
 }
 
 // Message returns a reference to the error message.
@@ -62,7 +74,7 @@ func NewErrorRef(varName string) ErrorRef {
 //
 //	err.Message() -> "${.error.message}"
 func (e ErrorRef) Message() string {
-	return "${." + e.varName + ".message}"
+	return "${" + e.path() + ".message}"
 }
 
 // Type returns a reference to the error type.
@@ -71,7 +83,7 @@ func (e ErrorRef) Message() string {
 //
 //	err.Type() -> "${.error.type}"
 func (e ErrorRef) Type() string {
-	return "${." + e.varName + ".type}"
+	return "${" + e.path() + ".type}"
 }
 
 // Timestamp returns a reference to when the error occurred.
@@ -80,7 +92,7 @@ func (e ErrorRef) Type() string {
 //
 //	err.Timestamp() -> "${.error.timestamp}"
 func (e ErrorRef) Timestamp() string {
-	return "${." + e.varName + ".timestamp}"
+	return "${" + e.path() + ".timestamp}"
 }
 
 // StackTrace returns a reference to the error stack trace.
@@ -89,7 +101,28 @@ func (e ErrorRef) Timestamp() string {
 //
 //	err.StackTrace() -> "${.error.stackTrace}"
 func (e ErrorRef) StackTrace() string {
-	return "${." + e.varName + ".stackTrace}"
+	return "${" + e.path() + ".stackTrace}"
+}
+
+// Classification returns a reference to the classification of the error,
+// such as "USER_ERROR" or "TIMEOUT". Only set for the handler tasks of
+// WithOnFailure.
+//
+// Example:
+//
+//	err.Classification() -> "${.error.classification}"
+func (e ErrorRef) Classification() string {
+	return "${" + e.path() + ".classification}"
+}
+
+// Task returns a reference to the name of the task that failed. Only set for
+// the handler tasks of WithOnFailure.
+//
+// Example:
+//
+//	err.Task() -> "${.error.task}"
+func (e ErrorRef) Task() string {
+	return "${" + e.path() + ".task}"
 }
 
 // Field returns a reference to a custom field in the error.
@@ -98,5 +131,5 @@ func (e ErrorRef) StackTrace() string {
 //
 //	err.Field("statusCode") -> "${.error.statusCode}"
 func (e ErrorRef) Field(fieldName string) string {
-	return "${." + e.varName + "." + fieldName + "}"
+	return "${" + e.path() + "." + fieldName + "}"
 }
@@ -2,7 +2,10 @@ package workflow
 
 import (
 	"fmt"
+	"path/filepath"
 	"regexp"
+	"runtime"
+	"strings"
 
 	"github.com/stigmer/stigmer/sdk/go/internal/validation"
 )
@@ -106,3 +109,192 @@ func validateTaskName(name string) error {
 
 	return nil
 }
+
+// flowDirectives are the flow control targets that are not task names.
+var flowDirectives = map[string]bool{
+	EndFlow:    true,
+	"continue": true,
+	"exit":     true,
+}
+
+// validateSwitchTargets checks that every Switch case routes to a task of the
+// workflow or to a flow directive. A case routing to an unknown task would
+// only fail when the workflow runs and takes that branch.
+func validateSwitchTargets(tasks []*Task) error {
+	names := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		names[task.Name] = true
+	}
+
+	for i, task := range tasks {
+		cfg, ok := task.Config.(*SwitchTaskConfig)
+		if !ok {
+			continue
+		}
+		for j, c := range cfg.Cases {
+			if c == nil || c.Then == "" || names[c.Then] || flowDirectives[c.Then] {
+				continue
+			}
+			return validation.NewValidationErrorWithCause(
+				validation.FieldPath("tasks", i, "cases", j, "then"),
+				c.Then,
+				"task_exists",
+				fmt.Sprintf("switch task %q routes case %q to unknown task %q", task.Name, c.Name, c.Then),
+				ErrUnknownSwitchTarget,
+			)
+		}
+	}
+	return nil
+}
+
+// validateUnsetReferences checks that no task references, through Field(), a
+// variable that an earlier Set task unset. The runner removes unset variables
+// from the data and from every task export, so such a reference always fails
+// at runtime.
+func validateUnsetReferences(tasks []*Task) error {
+	for i, task := range tasks {
+		cfg, ok := task.Config.(*SetTaskConfig)
+		if !ok || len(cfg.Unset) == 0 {
+			continue
+		}
+
+		for _, later := range tasks[i+1:] {
+			rendered, err := later.renderedConfig()
+			if err != nil {
+				// Conversion errors are reported by synthesis itself
+				continue
+			}
+
+			for _, earlier := range tasks[:i] {
+				for _, name := range cfg.Unset {
+					ref := fmt.Sprintf(`$context[%q].%s`, earlier.Name, name)
+					if !containsFieldRef(rendered, ref) {
+						continue
+					}
+					return validation.NewValidationErrorWithCause(
+						validation.FieldPath("tasks", i, "unset"),
+						name,
+						"not_unset",
+						fmt.Sprintf("task %q references %s.%s, which task %q unsets",
+							later.Name, earlier.Name, name, task.Name),
+						ErrVariableUnset,
+					)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// weakRefPattern matches the task lookup of weak field references and
+// captures the task name.
+var weakRefPattern = regexp.MustCompile(`\$context\["([^"]+)"\]\?`)
+
+// validateWeakReferences checks that every weak field reference names a task
+// of the workflow. Weak references add no dependency, so a reference to a
+// task that is never added would otherwise go unnoticed and always be null.
+func validateWeakReferences(tasks []*Task) error {
+	names := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		names[task.Name] = true
+	}
+
+	for i, task := range tasks {
+		rendered, err := task.renderedConfig()
+		if err != nil {
+			// Conversion errors are reported by synthesis itself
+			continue
+		}
+
+		for _, match := range weakRefPattern.FindAllStringSubmatch(rendered, -1) {
+			if names[match[1]] {
+				continue
+			}
+			return validation.NewValidationErrorWithCause(
+				validation.FieldPath("tasks", i),
+				match[1],
+				"task_exists",
+				fmt.Sprintf("task %q has a weak reference to unknown task %q", task.Name, match[1]),
+				ErrUnknownTaskReference,
+			)
+		}
+	}
+	return nil
+}
+
+// nilTaskPattern matches the task lookup of references created by Field on a
+// nil task and captures the call site of Field.
+var nilTaskPattern = regexp.MustCompile(`\$context\["<nil task at ([^"]+)>"\]`)
+
+// nilTaskName returns the task name of a reference created by Field on a nil
+// task: a name no task can have that records the call site of Field, so
+// synthesis can report it (see validateNilTaskReferences).
+func nilTaskName() string {
+	location := "unknown location"
+	if _, file, line, ok := runtime.Caller(2); ok {
+		location = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	}
+	return fmt.Sprintf("<nil task at %s>", location)
+}
+
+// validateNilTaskReferences fails synthesis for references to fields of a
+// nil task, which usually come from ignoring the error of the builder that
+// should have created the task.
+func validateNilTaskReferences(tasks []*Task) error {
+	for i, task := range tasks {
+		rendered, err := task.renderedConfig()
+		if err != nil {
+			// Conversion errors are reported by synthesis itself
+			continue
+		}
+		rendered += task.guardExpression()
+
+		if match := nilTaskPattern.FindStringSubmatch(rendered); match != nil {
+			return validation.NewValidationErrorWithCause(
+				validation.FieldPath("tasks", i),
+				match[1],
+				"task_exists",
+				fmt.Sprintf("task %q references a field of a nil *Task (Field called at %s); "+
+					"check the error of the call that should have created the task", task.Name, match[1]),
+				ErrNilTaskReference,
+			)
+		}
+	}
+	return nil
+}
+
+// containsStrongRef reports whether s references the output of the task
+// other than through a weak reference (see TaskFieldRef.Weak).
+func containsStrongRef(s, taskName string) bool {
+	ref := contextRef(taskName)
+	for {
+		i := strings.Index(s, ref)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(ref):]
+		if !strings.HasPrefix(s, "?") {
+			return true
+		}
+	}
+}
+
+// containsFieldRef reports whether s contains ref as a whole field reference,
+// so that ".token" does not match ".tokenType".
+func containsFieldRef(s, ref string) bool {
+	for {
+		i := strings.Index(s, ref)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(ref):]
+		if s == "" || !isIdentChar(s[0]) {
+			return true
+		}
+	}
+}
+
+// isIdentChar reports whether c can continue a field name.
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,75 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/semver"
+)
+
+// SemVer is a parsed semantic version, as returned by Workflow.Version.
+// Compare orders versions by semver precedence.
+type SemVer = semver.Version
+
+// WithVersion sets the workflow version, overriding WorkflowArgs.Version.
+//
+// The version must be semver (MAJOR.MINOR.PATCH). A leading "v" and a
+// missing patch component are accepted and normalized ("v1.0" becomes
+// "1.0.0"); synthesis prints a note when that happens. Other invalid versions
+// make New fail with ErrInvalidVersion and a suggested fix.
+//
+// Example:
+//
+//	wf, err := workflow.New(ctx, "ops/deploy", nil, workflow.WithVersion("1.2.0"))
+func WithVersion(version string) WorkflowOption {
+	return func(w *Workflow) {
+		w.Document.Version = version
+	}
+}
+
+// Version returns the parsed workflow version, so tooling can compare
+// versions with SemVer.Compare. It returns the zero SemVer if
+// Document.Version was changed to an invalid value after New.
+//
+// Example:
+//
+//	if wf.Version().Compare(deployed) <= 0 {
+//	    return fmt.Errorf("bump the version of %s", wf.Document.Name)
+//	}
+func (w *Workflow) Version() SemVer {
+	v, _ := semver.Parse(w.Document.Version)
+	return v
+}
+
+// VersionNote describes how New normalized the version it was given, e.g.
+// `version "v1.0" normalized to "1.0.0"`, or returns "" if the version was
+// used as given.
+func (w *Workflow) VersionNote() string {
+	return w.versionNote
+}
+
+// normalizeVersion rewrites the document version to strict semver, recording
+// a note when a leading "v" or missing patch component was fixed up.
+func (w *Workflow) normalizeVersion() error {
+	given := w.Document.Version
+	v, normalized, err := semver.Normalize(given)
+	if err != nil {
+		return versionError(given, err)
+	}
+	if normalized {
+		w.Document.Version = v.String()
+		w.versionNote = fmt.Sprintf("version %q normalized to %q", given, w.Document.Version)
+	}
+	return nil
+}
+
+// versionError reports an invalid workflow version with the expected format
+// and a suggested fix.
+func versionError(version string, err error) error {
+	return NewValidationErrorWithCause(
+		"document.version",
+		version,
+		"semver",
+		fmt.Sprintf("%v, e.g. workflow.WithVersion(%q)", err, semver.Suggest(version)),
+		ErrInvalidVersion,
+	)
+}
@@ -0,0 +1,105 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithVersion_Normalization(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *WorkflowArgs
+		opts     []WorkflowOption
+		want     string
+		wantNote string
+	}{
+		{
+			name: "valid version",
+			opts: []WorkflowOption{WithVersion("1.2.3")},
+			want: "1.2.3",
+		},
+		{
+			name:     "leading v and missing patch",
+			opts:     []WorkflowOption{WithVersion("v1.0")},
+			want:     "1.0.0",
+			wantNote: `version "v1.0" normalized to "1.0.0"`,
+		},
+		{
+			name:     "args version is normalized too",
+			args:     &WorkflowArgs{Version: "v2.1.0-rc.1"},
+			want:     "2.1.0-rc.1",
+			wantNote: `version "v2.1.0-rc.1" normalized to "2.1.0-rc.1"`,
+		},
+		{
+			name: "option overrides args",
+			args: &WorkflowArgs{Version: "1.0.0"},
+			opts: []WorkflowOption{WithVersion("2.0.0")},
+			want: "2.0.0",
+		},
+		{
+			name: "default version",
+			want: "0.1.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "ops/deploy", tt.args, tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if wf.Document.Version != tt.want {
+				t.Errorf("Document.Version = %q, want %q", wf.Document.Version, tt.want)
+			}
+			if got := wf.Version().String(); got != tt.want {
+				t.Errorf("Version() = %q, want %q", got, tt.want)
+			}
+			if got := wf.VersionNote(); got != tt.wantNote {
+				t.Errorf("VersionNote() = %q, want %q", got, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestWithVersion_Invalid(t *testing.T) {
+	tests := []struct {
+		version string
+		fix     string
+	}{
+		{"1.0.0.1", `workflow.WithVersion("1.0.0")`},
+		{"1", `workflow.WithVersion("1.0.0")`},
+		{"latest", `workflow.WithVersion("1.0.0")`},
+		{"2.01.3", `workflow.WithVersion("2.1.3")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			_, err := New(nil, "ops/deploy", nil, WithVersion(tt.version))
+			if !errors.Is(err, ErrInvalidVersion) {
+				t.Fatalf("New() error = %v, want ErrInvalidVersion", err)
+			}
+			if !strings.Contains(err.Error(), "MAJOR.MINOR.PATCH") || !strings.Contains(err.Error(), tt.fix) {
+				t.Errorf("New() error = %q, want expected format and %s", err, tt.fix)
+			}
+		})
+	}
+}
+
+func TestWorkflow_VersionCompare(t *testing.T) {
+	older, err := New(nil, "ops/deploy", nil, WithVersion("1.2.0-rc.1"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	newer, err := New(nil, "ops/deploy", nil, WithVersion("v1.2"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if got := older.Version().Compare(newer.Version()); got != -1 {
+		t.Errorf("Compare(1.2.0-rc.1, 1.2.0) = %d, want -1", got)
+	}
+	if newer.Version().Major != 1 || newer.Version().Minor != 2 {
+		t.Errorf("Version() = %+v, want 1.2.0", newer.Version())
+	}
+}
@@ -1,5 +1,10 @@
 package workflow
 
+import (
+	"fmt"
+	"time"
+)
+
 // WaitArgs is an alias for WaitTaskConfig (Pulumi-style args pattern).
 type WaitArgs = WaitTaskConfig
 
@@ -11,6 +16,8 @@ type WaitArgs = WaitTaskConfig
 //	task := workflow.Wait("pause", &workflow.WaitArgs{
 //	    Seconds: 5,
 //	})
+//
+// Deprecated: Use WaitFor, which takes a time.Duration instead of bare seconds.
 func Wait(name string, args *WaitArgs) *Task {
 	if args == nil {
 		args = &WaitArgs{}
@@ -22,3 +29,21 @@ func Wait(name string, args *WaitArgs) *Task {
 		Config: args,
 	}
 }
+
+// WaitFor creates a WAIT task that pauses the workflow for d.
+//
+// The runner waits in whole seconds. Unlike timeouts, waits are not rounded:
+// d must be a positive whole number of seconds, and synthesis fails with
+// ErrInvalidDuration otherwise, since a rounded wait silently changes the
+// schedule.
+//
+// Example:
+//
+//	wf.AddTask(workflow.WaitFor("cooldown", 45*time.Second))
+func WaitFor(name string, d time.Duration) *Task {
+	task := Wait(name, nil)
+	if err := task.Config.(*WaitArgs).SetDuration(d); err != nil || d == 0 {
+		task.durationErr = fmt.Sprintf("wait %s is not a positive whole number of seconds (did you mean n*time.Second?)", d)
+	}
+	return task
+}
@@ -0,0 +1,68 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitFor_Synthesis(t *testing.T) {
+	wf, err := New(nil, "ops/cooldown", &WorkflowArgs{Version: "1.0.0"})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	wf.AddTask(WaitFor("cooldown", 45*time.Second))
+
+	pb, err := wf.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() failed: %v", err)
+	}
+
+	task := pb.GetSpec().GetTasks()[0]
+	if got := task.GetTaskConfig().GetFields()["seconds"].GetNumberValue(); got != 45 {
+		t.Errorf("seconds = %v, want 45", got)
+	}
+	if got := wf.Task("cooldown").Config.(*WaitArgs).Duration(); got != 45*time.Second {
+		t.Errorf("Duration() = %v, want 45s", got)
+	}
+}
+
+func TestWaitFor_InvalidDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+	}{
+		{"zero", 0},
+		{"negative", -5 * time.Second},
+		{"sub-second", 500 * time.Millisecond},
+		{"fractional seconds", 1500 * time.Millisecond},
+		{"bare number", 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := New(nil, "ops/cooldown", &WorkflowArgs{Version: "1.0.0"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			wf.AddTask(WaitFor("cooldown", tt.d))
+
+			if _, err := wf.ToProto(); !errors.Is(err, ErrInvalidDuration) {
+				t.Errorf("ToProto() error = %v, want ErrInvalidDuration", err)
+			}
+		})
+	}
+}
+
+func TestTimeoutDuration_GeneratedSetterSentinel(t *testing.T) {
+	cfg := &HttpCallTaskConfig{}
+	if err := cfg.SetTimeoutDuration(1500 * time.Millisecond); !errors.Is(err, ErrInvalidDuration) {
+		t.Errorf("SetTimeoutDuration() error = %v, want ErrInvalidDuration", err)
+	}
+	if err := cfg.SetTimeoutDuration(time.Minute); err != nil {
+		t.Fatalf("SetTimeoutDuration() failed: %v", err)
+	}
+	if cfg.TimeoutSeconds != 60 {
+		t.Errorf("TimeoutSeconds = %d, want 60", cfg.TimeoutSeconds)
+	}
+}
@@ -1,9 +1,12 @@
 package workflow
 
 import (
+	"maps"
 	"sync"
+	"time"
 
 	"github.com/stigmer/stigmer/sdk/go/environment"
+	"github.com/stigmer/stigmer/sdk/go/gen/types"
 	"github.com/stigmer/stigmer/sdk/go/stigmer/naming"
 )
 
@@ -16,11 +19,25 @@ type Context interface {
 	RegisterWorkflow(*Workflow)
 }
 
+// openChecker is implemented by contexts that are closed once they can no
+// longer synthesize resources, such as stigmer.Context after Run returns.
+type openChecker interface {
+	CheckOpen() error
+}
+
 // WorkflowArgs contains the configuration arguments for creating a Workflow.
 //
 // This struct follows the Pulumi Args pattern for resource configuration.
 // Required fields: Namespace
-// Optional fields: Version (defaults to "0.1.0"), Description, Org, Slug
+// Optional fields: Version (defaults to "0.1.0"), Description, Org, Slug,
+// EnvironmentVariables, Labels
+//
+// Because args is a plain struct, it can be built in a helper and tweaked
+// per workflow before calling New:
+//
+//	args := defaultArgs()
+//	args.Description = "Nightly export"
+//	wf, err := workflow.New(ctx, "exports/nightly", args)
 type WorkflowArgs struct {
 	// Namespace is the workflow namespace for organization/categorization.
 	// This is a required field.
@@ -39,6 +56,13 @@ type WorkflowArgs struct {
 	// Slug is a custom URL-friendly identifier.
 	// If not provided, auto-generated from the name.
 	Slug string
+
+	// EnvironmentVariables are environment variables required by the workflow.
+	// More can be added after creation with AddEnvironmentVariable().
+	EnvironmentVariables []environment.Variable
+
+	// Labels are key-value labels for organization and filtering.
+	Labels map[string]string
 }
 
 // Workflow represents a workflow orchestration definition.
@@ -75,9 +99,43 @@ type Workflow struct {
 	// Organization that owns this workflow (optional)
 	Org string
 
+	// Key-value labels for organization and filtering (optional)
+	Labels map[string]string
+
+	// Concurrency policy for executions (optional).
+	// Use WithConcurrencyPolicy() to set it.
+	ConcurrencyPolicy *ConcurrencyPolicy
+
+	// Cron schedule that triggers executions (optional).
+	// Use WithSchedule() on New to set it.
+	Schedule *Schedule
+
+	// Tasks run when the workflow fails (optional).
+	// Use WithOnFailure() on New to set them.
+	OnFailure []*Task
+
+	// Maximum duration of an execution (optional, 0 means no maximum).
+	// Use WithMaxDuration() on New to set it.
+	MaxDuration time.Duration
+
+	// Default TLS configuration for HTTP_CALL tasks (optional).
+	// Use WithTLS() on New to set it; it applies to tasks added afterwards.
+	TLS *types.HttpTls
+
+	// Default response status check for HTTP_CALL tasks (optional, failing
+	// on 4xx and 5xx statuses if unset).
+	// Use WithHttpStatusCheck() on New to set it; it applies to tasks added afterwards.
+	HttpStatusCheck *HttpStatusCheck
+
 	// Context reference (optional, used for typed variable management)
 	ctx Context
 
+	// suppressedLintRules lists lint rule IDs disabled via SuppressLint
+	suppressedLintRules []string
+
+	// versionNote describes how New normalized the given version ("" if unchanged)
+	versionNote string
+
 	// mu protects concurrent access to Tasks and EnvironmentVariables slices
 	mu sync.Mutex
 }
@@ -100,6 +158,14 @@ type Workflow struct {
 //   - Description: human-readable description
 //   - Org: organization identifier
 //   - Slug: custom slug (overrides auto-generation from name)
+//   - EnvironmentVariables: environment variables required by the workflow
+//   - Labels: key-value labels for organization and filtering
+//
+// Options:
+//   - WithSchedule: triggers executions on a cron schedule
+//   - WithTLS: default TLS configuration for HTTP_CALL tasks
+//   - WithHttpStatusCheck: default response status check for HTTP_CALL tasks
+//   - WithMaxDuration: bounds the duration of each execution
 //
 // Example:
 //
@@ -122,7 +188,19 @@ type Workflow struct {
 // Example with nil args (uses defaults):
 //
 //	wf, err := workflow.New(ctx, "data-processing/daily-sync", nil)
-func New(ctx Context, name string, args *WorkflowArgs) (*Workflow, error) {
+//
+// Example with a schedule:
+//
+//	wf, err := workflow.New(ctx, "data-processing/daily-sync", nil,
+//	    workflow.WithSchedule("0 2 * * *", workflow.Timezone("UTC")),
+//	)
+func New(ctx Context, name string, args *WorkflowArgs, opts ...WorkflowOption) (*Workflow, error) {
+	if oc, ok := ctx.(openChecker); ok {
+		if err := oc.CheckOpen(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Nil-safety: if args is nil, create empty args
 	if args == nil {
 		args = &WorkflowArgs{}
@@ -148,10 +226,19 @@ func New(ctx Context, name string, args *WorkflowArgs) (*Workflow, error) {
 		Org:                  args.Org,
 		Slug:                 args.Slug,
 		Tasks:                []*Task{},
-		EnvironmentVariables: []environment.Variable{},
+		EnvironmentVariables: append([]environment.Variable{}, args.EnvironmentVariables...),
 		ctx:                  ctx,
 	}
 
+	// Copy labels so later changes to args don't leak into the workflow
+	if len(args.Labels) > 0 {
+		w.Labels = maps.Clone(args.Labels)
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
 	// Auto-generate slug from name if not provided
 	if w.Slug == "" && w.Document.Name != "" {
 		w.Slug = naming.GenerateSlug(w.Document.Name)
@@ -166,6 +253,9 @@ func New(ctx Context, name string, args *WorkflowArgs) (*Workflow, error) {
 	if w.Document.Version == "" {
 		w.Document.Version = "0.1.0" // Default version for development
 	}
+	if err := w.normalizeVersion(); err != nil {
+		return nil, err
+	}
 
 	// Validate the workflow
 	if err := validate(w); err != nil {
@@ -179,6 +269,16 @@ func New(ctx Context, name string, args *WorkflowArgs) (*Workflow, error) {
 		}
 	}
 
+	if w.Schedule != nil {
+		if err := w.Schedule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.validateMaxDuration(); err != nil {
+		return nil, err
+	}
+
 	// Register with context (if provided)
 	if ctx != nil {
 		ctx.RegisterWorkflow(w)
@@ -210,6 +310,9 @@ func parseName(name string) (string, string) {
 func (w *Workflow) AddTask(task *Task) *Workflow {
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	task.workflow = w
+	task.applyDefaultTLS(w.TLS)
+	task.applyDefaultHttpStatusCheck(w.HttpStatusCheck)
 	w.Tasks = append(w.Tasks, task)
 	return w
 }
@@ -227,6 +330,11 @@ func (w *Workflow) AddTask(task *Task) *Workflow {
 func (w *Workflow) AddTasks(tasks ...*Task) *Workflow {
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	for _, task := range tasks {
+		task.workflow = w
+		task.applyDefaultTLS(w.TLS)
+		task.applyDefaultHttpStatusCheck(w.HttpStatusCheck)
+	}
 	w.Tasks = append(w.Tasks, tasks...)
 	return w
 }
@@ -260,6 +368,44 @@ func (w *Workflow) AddEnvironmentVariables(variables ...environment.Variable) *W
 	return w
 }
 
+// ExportSummary describes how much task output a workflow carries forward
+// in its context. Synthesis cannot know runtime payload sizes, but it can
+// tell how many tasks export their full output and whether any are narrowed.
+type ExportSummary struct {
+	// TotalTasks is the number of top-level tasks in the workflow.
+	TotalTasks int
+
+	// FullExports lists tasks exporting their entire output (${.}).
+	FullExports []string
+
+	// NarrowedExports lists tasks whose export was narrowed with Exports().
+	NarrowedExports []string
+}
+
+// ExportSummary returns the export accounting for this workflow.
+//
+// Example:
+//
+//	summary := wf.ExportSummary()
+//	if len(summary.FullExports) > 0 && len(summary.NarrowedExports) == 0 {
+//	    // Consider narrowing large outputs with task.Exports("id", "title")
+//	}
+func (w *Workflow) ExportSummary() ExportSummary {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	summary := ExportSummary{TotalTasks: len(w.Tasks)}
+	for _, task := range w.Tasks {
+		switch {
+		case len(task.exportedFields) > 0:
+			summary.NarrowedExports = append(summary.NarrowedExports, task.Name)
+		case task.ExportsFullOutput():
+			summary.FullExports = append(summary.FullExports, task.Name)
+		}
+	}
+	return summary
+}
+
 // ============================================================================
 // Convenience Methods (Pulumi-Style Task Builders)
 // ============================================================================
@@ -275,17 +421,17 @@ func (w *Workflow) AddEnvironmentVariables(variables ...environment.Variable) *W
 //	wf := workflow.New(ctx, ...)
 //
 //	// Clean, one-line GET request
-//	fetchTask := wf.HttpGet("fetch", "https://api.example.com/posts/1",
-//	    Header("Content-Type", "application/json"),
-//	    Timeout(30),
+//	fetchTask := wf.HttpGet("fetch", "https://api.example.com/posts/1", nil,
+//	    workflow.Header("Accept", "application/json"),
+//	    workflow.TimeoutDuration(10*time.Second),
 //	)
 //
 //	// Use task outputs with clear origin
-//	processTask := wf.Set("process",
-//	    SetVar("title", fetchTask.Field("title")),  // Implicit dependency!
+//	processTask := wf.SetVars("process",
+//	    "title", fetchTask.Field("title"), // Implicit dependency!
 //	)
-func (w *Workflow) HttpGet(name string, uri interface{}, headers map[string]string) *Task {
-	task := HttpGet(name, uri, headers)
+func (w *Workflow) HttpGet(name string, uri string, headers map[string]string, opts ...HttpOption) *Task {
+	task := HttpGet(name, uri, headers, opts...)
 	w.AddTask(task)
 	return task
 }
@@ -296,15 +442,15 @@ func (w *Workflow) HttpGet(name string, uri interface{}, headers map[string]stri
 // Example:
 //
 //	wf := workflow.New(ctx, ...)
-//	createTask := wf.HttpPost("createUser", "https://api.example.com/users",
-//	    Body(map[string]any{
-//	        "name": "John Doe",
+//	createTask := wf.HttpPost("createUser", "https://api.example.com/users", nil,
+//	    map[string]any{
+//	        "name":  "John Doe",
 //	        "email": "john@example.com",
-//	    }),
-//	    Header("Authorization", "Bearer token"),
+//	    },
+//	    workflow.Header("Authorization", "Bearer ${.secrets.API_TOKEN}"),
 //	)
-func (w *Workflow) HttpPost(name string, uri interface{}, headers map[string]string, body map[string]interface{}) *Task {
-	task := HttpPost(name, uri, headers, body)
+func (w *Workflow) HttpPost(name string, uri string, headers map[string]string, body map[string]interface{}, opts ...HttpOption) *Task {
+	task := HttpPost(name, uri, headers, body, opts...)
 	w.AddTask(task)
 	return task
 }
@@ -314,11 +460,11 @@ func (w *Workflow) HttpPost(name string, uri interface{}, headers map[string]str
 //
 // Example:
 //
-//	updateTask := wf.HttpPut("updateUser", "https://api.example.com/users/123",
-//	    Body(map[string]any{"status": "active"}),
+//	updateTask := wf.HttpPut("updateUser", "https://api.example.com/users/123", nil,
+//	    map[string]any{"status": "active"},
 //	)
-func (w *Workflow) HttpPut(name string, uri string, headers map[string]string, body map[string]interface{}) *Task {
-	task := HttpPut(name, uri, headers, body)
+func (w *Workflow) HttpPut(name string, uri string, headers map[string]string, body map[string]interface{}, opts ...HttpOption) *Task {
+	task := HttpPut(name, uri, headers, body, opts...)
 	w.AddTask(task)
 	return task
 }
@@ -328,11 +474,11 @@ func (w *Workflow) HttpPut(name string, uri string, headers map[string]string, b
 //
 // Example:
 //
-//	patchTask := wf.HttpPatch("patchUser", "https://api.example.com/users/123",
-//	    Body(map[string]any{"email": "newemail@example.com"}),
+//	patchTask := wf.HttpPatch("patchUser", "https://api.example.com/users/123", nil,
+//	    map[string]any{"email": "newemail@example.com"},
 //	)
-func (w *Workflow) HttpPatch(name string, uri interface{}, headers map[string]string, body map[string]interface{}) *Task {
-	task := HttpPatch(name, uri, headers, body)
+func (w *Workflow) HttpPatch(name string, uri string, headers map[string]string, body map[string]interface{}, opts ...HttpOption) *Task {
+	task := HttpPatch(name, uri, headers, body, opts...)
 	w.AddTask(task)
 	return task
 }
@@ -342,11 +488,38 @@ func (w *Workflow) HttpPatch(name string, uri interface{}, headers map[string]st
 //
 // Example:
 //
-//	deleteTask := wf.HttpDelete("deleteUser", "https://api.example.com/users/123",
-//	    Header("Authorization", "Bearer token"),
+//	deleteTask := wf.HttpDelete("deleteUser", "https://api.example.com/users/123", nil,
+//	    workflow.Header("Authorization", "Bearer ${.secrets.API_TOKEN}"),
 //	)
-func (w *Workflow) HttpDelete(name string, uri interface{}, headers map[string]string) *Task {
-	task := HttpDelete(name, uri, headers)
+func (w *Workflow) HttpDelete(name string, uri string, headers map[string]string, opts ...HttpOption) *Task {
+	task := HttpDelete(name, uri, headers, opts...)
+	w.AddTask(task)
+	return task
+}
+
+// HttpHead creates an HTTP HEAD task and adds it to the workflow. The task
+// output holds only the response status code and headers (see HttpHead).
+//
+// Example:
+//
+//	check := wf.HttpHead("check", "https://cdn.example.com/data.json")
+//	wf.Switch("route", &workflow.SwitchArgs{...}) // on check.Field("headers.etag")
+func (w *Workflow) HttpHead(name string, uri string, opts ...HttpOption) *Task {
+	task := HttpHead(name, uri, opts...)
+	w.AddTask(task)
+	return task
+}
+
+// HttpOptions creates an HTTP OPTIONS task and adds it to the workflow. The
+// task output holds only the response status code and headers (see
+// HttpOptions).
+//
+// Example:
+//
+//	caps := wf.HttpOptions("caps", "https://api.example.com/items")
+//	wf.SetVars("record", "allowed", caps.Field("headers.allow"))
+func (w *Workflow) HttpOptions(name string, uri string, opts ...HttpOption) *Task {
+	task := HttpOptions(name, uri, opts...)
 	w.AddTask(task)
 	return task
 }
@@ -371,6 +544,18 @@ func (w *Workflow) Set(name string, args *SetArgs) *Task {
 	return task
 }
 
+// SetVars creates a SET task from alternating variable names and values and
+// adds it to the workflow. Values keep their Go types; see SetVars.
+//
+// Example:
+//
+//	wf.SetVars("init", "retryCount", 3, "debug", true)
+func (w *Workflow) SetVars(name string, keyValues ...interface{}) *Task {
+	task := SetVars(name, keyValues...)
+	w.AddTask(task)
+	return task
+}
+
 // CallAgent creates an agent call task and adds it to the workflow.
 //
 // This is a convenience method combining task creation and workflow registration.
@@ -379,16 +564,74 @@ func (w *Workflow) Set(name string, args *SetArgs) *Task {
 // Example:
 //
 //	wf := workflow.New(ctx, ...)
-//	reviewTask := wf.CallAgent("review",
-//	    AgentOption(AgentBySlug("code-reviewer")),
-//	    Message("Review PR: ${.input.prUrl}"),
-//	    WithAgentEnv(map[string]string{
+//	reviewTask := wf.CallAgent("review", &workflow.AgentCallArgs{
+//	    Message: "Review PR: ${.input.prUrl}",
+//	    Env: map[string]string{
 //	        "GITHUB_TOKEN": "${.secrets.GITHUB_TOKEN}",
-//	    }),
-//	)
+//	    },
+//	}, workflow.AgentBySlug("code-reviewer"))
 //	reviewTask.ExportAll()
-func (w *Workflow) CallAgent(name string, args *AgentCallArgs) *Task {
-	task := AgentCall(name, args)
+//
+// Agent references (Agent, AgentBySlug, AgentRef) passed as options set the
+// agent the task invokes.
+func (w *Workflow) CallAgent(name string, args *AgentCallArgs, opts ...AgentCallOption) *Task {
+	task := AgentCall(name, args, opts...)
+	w.AddTask(task)
+	return task
+}
+
+// GrpcCall creates a GRPC_CALL task and adds it to the workflow.
+//
+// Example:
+//
+//	wf := workflow.New(ctx, ...)
+//	lookupTask := wf.HttpGet("lookup", endpoint, nil)
+//	getUser := wf.GrpcCall("getUser", &workflow.GrpcCallArgs{
+//	    Service: "acme.user.v1.UserService",
+//	    Method:  "GetUser",
+//	},
+//	    workflow.GrpcRequestFromProto(&userv1.GetUserRequest{IncludeProfile: true}),
+//	    workflow.GrpcField("user_id", lookupTask.Field("id")),
+//	)
+func (w *Workflow) GrpcCall(name string, args *GrpcCallArgs, opts ...GrpcOption) *Task {
+	task := GrpcCall(name, args, opts...)
+	w.AddTask(task)
+	return task
+}
+
+// CallActivity creates a CALL_ACTIVITY task and adds it to the workflow.
+// This is a clean, Pulumi-style builder for invoking custom Temporal activities.
+//
+// Example:
+//
+//	wf := workflow.New(ctx, ...)
+//	fetchTask := wf.HttpGet("fetch", endpoint, nil)
+//	normalizeTask := wf.CallActivity("normalize", &workflow.CallActivityArgs{
+//	    Activity:       "transform.v1.Normalize",
+//	    Input:          map[string]interface{}{"data": fetchTask.Field("body")},
+//	    TaskQueue:      "custom-q",
+//	    TimeoutSeconds: 120,
+//	})
+func (w *Workflow) CallActivity(name string, args *CallActivityArgs, opts ...CallActivityOption) *Task {
+	task := CallActivity(name, args, opts...)
+	w.AddTask(task)
+	return task
+}
+
+// Custom creates a task of a custom kind registered with
+// RegisterCustomTaskKind and adds it to the workflow. The task runs on the
+// runner extension registered for the kind.
+//
+// Example:
+//
+//	wf := workflow.New(ctx, ...)
+//	fetchTask := wf.HttpGet("fetch", endpoint, nil)
+//	loadTask := wf.Custom("loadOrders", "SNOWFLAKE_QUERY", SnowflakeQuery{
+//	    Warehouse: "ANALYTICS",
+//	    SQL:       "SELECT * FROM orders",
+//	}, workflow.CustomRefs(fetchTask.Field("since")))
+func (w *Workflow) Custom(name string, kind string, cfg any, opts ...CustomOption) *Task {
+	task := Custom(name, kind, cfg, opts...)
 	w.AddTask(task)
 	return task
 }
@@ -0,0 +1,46 @@
+// Package workflowinstance provides the WorkflowInstance builder for
+// deploying workflow templates with their environment variables bound and,
+// optionally, their own schedule.
+//
+// A Workflow declares the environment variables it needs; a WorkflowInstance
+// supplies them for one deployment, so a template, its production instance
+// and that instance's schedule can live in one program:
+//
+//	wf, _ := workflow.New(ctx, "ops/daily-sync", &workflow.WorkflowArgs{
+//	    EnvironmentVariables: []environment.Variable{*slackToken, *apiBase},
+//	})
+//
+//	_, err := workflowinstance.New(ctx, "daily-sync-prod", &workflowinstance.Args{
+//	    Workflow: wf,
+//	    Env: map[string]workflowinstance.Value{
+//	        "SLACK_TOKEN": workflowinstance.SecretFromEnv("SLACK_TOKEN_PROD"),
+//	        "API_BASE":    workflowinstance.Literal("https://api.example.com"),
+//	    },
+//	    Schedule: "0 2 * * *",
+//	})
+//
+// # Validation
+//
+// Bindings are checked against the workflow's variables when the instance
+// is created and again at synthesis, with the same rules as agent instances:
+//   - Required variables without a default must be bound
+//   - Bindings must name variables the workflow declares
+//   - Secret variables must use SecretFromEnv, plain variables Literal
+//   - Literals must match the variable's declared type
+//
+// The schedule is checked like workflow.WithSchedule.
+//
+// # Schedules
+//
+// An instance's Schedule overrides the schedule of its workflow, so
+// instances of one workflow can run at different times. Instances without a
+// Schedule run on the workflow's schedule, if it has one.
+//
+// # Synthesis
+//
+// Each instance is synthesized into two manifests: an Environment named
+// "{instance}-env" holding the bindings, and the WorkflowInstance
+// referencing it. `stigmer apply` deploys them after the workflows, filling
+// in the workflow ID and reading SecretFromEnv values from its own
+// environment, so secrets are never written to the manifests.
+package workflowinstance
@@ -0,0 +1,37 @@
+package workflowinstance
+
+import (
+	"errors"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/envbinding"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// Common errors that can occur when working with workflow instances.
+var (
+	// ErrInvalidName is returned when a workflow instance name is invalid.
+	ErrInvalidName = errors.New("invalid workflow instance name")
+
+	// ErrMissingWorkflow is returned when no workflow is given.
+	ErrMissingWorkflow = errors.New("missing workflow")
+
+	// ErrMissingBinding is returned when a required workflow environment
+	// variable without a default value is not bound.
+	ErrMissingBinding = envbinding.ErrMissingBinding
+
+	// ErrUnknownBinding is returned when a binding names a variable the
+	// workflow does not declare.
+	ErrUnknownBinding = envbinding.ErrUnknownBinding
+
+	// ErrSecretMismatch is returned when a secret variable is bound to a
+	// literal, or a plain variable to a secret.
+	ErrSecretMismatch = envbinding.ErrSecretMismatch
+
+	// ErrInvalidBinding is returned when a literal does not match the
+	// variable's declared type, or a secret names an invalid variable.
+	ErrInvalidBinding = envbinding.ErrInvalidBinding
+
+	// ErrInvalidSchedule is returned when the instance's cron expression or
+	// time zone is invalid. It is the same error as workflow.ErrInvalidSchedule.
+	ErrInvalidSchedule = workflow.ErrInvalidSchedule
+)
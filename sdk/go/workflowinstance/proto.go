@@ -0,0 +1,90 @@
+package workflowinstance
+
+import (
+	"fmt"
+
+	"buf.build/go/protovalidate"
+
+	environmentv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/environment/v1"
+	workflowinstancev1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflowinstance/v1"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+	"github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource/apiresourcekind"
+	"github.com/stigmer/stigmer/sdk/go/internal/envbinding"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// validator is the global protovalidate validator instance.
+var validator protovalidate.Validator
+
+func init() {
+	// Initialize validator once at package load time
+	var err error
+	validator, err = protovalidate.New()
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize protovalidate: %v", err))
+	}
+}
+
+// ToProto converts the SDK WorkflowInstance to platform WorkflowInstance and
+// Environment proto messages.
+//
+// The Environment holds the bindings and is nil when there are none. Secret
+// values are left empty and recorded as "stigmer.ai/secret-from-env."
+// annotations instead.
+//
+// The workflow is not deployed yet at synthesis, so spec.workflow_id holds
+// the workflow's slug. The CLI replaces it with the deployed workflow's ID.
+func (i *WorkflowInstance) ToProto() (*workflowinstancev1.WorkflowInstance, *environmentv1.Environment, error) {
+	// Bindings are checked again for variables added to the workflow after New
+	if err := i.validateBindings(); err != nil {
+		return nil, nil, err
+	}
+
+	instance := &workflowinstancev1.WorkflowInstance{
+		ApiVersion: "agentic.stigmer.ai/v1",
+		Kind:       "WorkflowInstance",
+		Metadata: &apiresource.ApiResourceMetadata{
+			Name:        i.Name,
+			Slug:        i.Slug,
+			Annotations: workflow.SDKAnnotations(),
+			OwnerScope:  apiresource.ApiResourceOwnerScope_organization,
+		},
+		Spec: &workflowinstancev1.WorkflowInstanceSpec{
+			WorkflowId:  i.WorkflowSlug(),
+			Description: i.Description,
+			Schedule:    i.Schedule.ToProto(),
+		},
+	}
+
+	var env *environmentv1.Environment
+	if len(i.Env) > 0 {
+		env = i.environmentProto()
+		instance.Spec.EnvRefs = []*apiresource.ApiResourceReference{
+			{
+				Scope: apiresource.ApiResourceOwnerScope_organization,
+				Kind:  apiresourcekind.ApiResourceKind_environment,
+				Slug:  env.Metadata.Slug,
+			},
+		}
+		if err := validator.Validate(env); err != nil {
+			return nil, nil, fmt.Errorf("environment validation failed: %w", err)
+		}
+	}
+
+	if err := validator.Validate(instance); err != nil {
+		return nil, nil, fmt.Errorf("workflow instance validation failed: %w", err)
+	}
+
+	return instance, env, nil
+}
+
+// environmentProto builds the Environment holding the instance's bindings.
+func (i *WorkflowInstance) environmentProto() *environmentv1.Environment {
+	return envbinding.Environment(
+		i.EnvironmentSlug(),
+		fmt.Sprintf("Environment bindings for workflow instance %s", i.Name),
+		i.Workflow.EnvironmentVariables,
+		i.Env,
+		workflow.SDKAnnotations(),
+	)
+}
@@ -0,0 +1,31 @@
+package workflowinstance
+
+import "github.com/stigmer/stigmer/sdk/go/internal/envbinding"
+
+// Value is the value bound to a workflow environment variable.
+//
+// Use Literal for plain configuration and SecretFromEnv for secrets:
+//
+//	Env: map[string]workflowinstance.Value{
+//	    "SLACK_TOKEN": workflowinstance.SecretFromEnv("SLACK_TOKEN_PROD"),
+//	    "API_BASE":    workflowinstance.Literal("https://api.example.com"),
+//	}
+//
+// It is the same type as agentinstance.Value.
+type Value = envbinding.Value
+
+// Literal binds a plain value. Literals are written to the synthesized
+// manifest as-is, so they must not be used for secret variables.
+func Literal(value string) Value {
+	return envbinding.Literal(value)
+}
+
+// SecretFromEnv binds a secret read from the environment variable name of
+// the process that deploys the instance.
+//
+// The secret value never appears in the synthesized manifest: the manifest
+// records only the variable name, and `stigmer apply` reads the value when it
+// creates the instance's environment.
+func SecretFromEnv(name string) Value {
+	return envbinding.SecretFromEnv(name)
+}
@@ -0,0 +1,194 @@
+package workflowinstance
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/stigmer/stigmer/sdk/go/internal/envbinding"
+	"github.com/stigmer/stigmer/sdk/go/internal/validation"
+	"github.com/stigmer/stigmer/sdk/go/stigmer/naming"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// Context is a minimal interface that represents a stigmer context.
+// This allows the workflowinstance package to work with contexts without
+// importing the stigmer package (avoiding import cycles).
+//
+// The stigmer.Context type implements this interface.
+type Context interface {
+	RegisterWorkflowInstance(*WorkflowInstance)
+}
+
+// openChecker is implemented by contexts that are closed once they can no
+// longer synthesize resources, such as stigmer.Context after Run returns.
+type openChecker interface {
+	CheckOpen() error
+}
+
+// Args contains the configuration arguments for creating a WorkflowInstance.
+//
+// This struct follows the Pulumi Args pattern for resource configuration.
+type Args struct {
+	// Workflow is the workflow template this instance deploys (required).
+	Workflow *workflow.Workflow
+
+	// Description is a human-readable description of the instance.
+	Description string
+
+	// Env binds the workflow's environment variables by name.
+	Env map[string]Value
+
+	// Schedule is a cron expression triggering executions of this instance,
+	// in the format accepted by workflow.WithSchedule. It overrides the
+	// workflow's schedule. Empty means the workflow's schedule applies.
+	Schedule string
+
+	// ScheduleOptions configure Schedule, e.g. workflow.Timezone.
+	ScheduleOptions []workflow.ScheduleOption
+}
+
+// WorkflowInstance is a deployment of a Workflow template with its
+// environment variables bound.
+//
+// The bindings are synthesized into an Environment resource named
+// "{instance}-env", which the instance references.
+type WorkflowInstance struct {
+	// Name is the instance name (lowercase alphanumeric with hyphens).
+	Name string
+
+	// Slug is the URL-friendly identifier (generated from the name).
+	Slug string
+
+	// Workflow is the workflow template this instance deploys.
+	Workflow *workflow.Workflow
+
+	// Description is a human-readable description of the instance.
+	Description string
+
+	// Env binds the workflow's environment variables by name.
+	Env map[string]Value
+
+	// Schedule triggers executions of this instance, overriding the
+	// workflow's schedule. Nil means the workflow's schedule applies.
+	Schedule *workflow.Schedule
+
+	// Org is the organization that owns this instance (optional).
+	Org string
+}
+
+// nameRegex matches valid instance names (lowercase alphanumeric with hyphens).
+var nameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// New creates a new WorkflowInstance with struct-based args (Pulumi pattern).
+//
+// The instance is automatically registered with the provided context for
+// synthesis. Its bindings are checked against the environment variables the
+// workflow declares, with the same rules as agentinstance.New, and its
+// schedule is checked like workflow.WithSchedule.
+//
+// Example:
+//
+//	prod, err := workflowinstance.New(ctx, "daily-sync-prod", &workflowinstance.Args{
+//	    Workflow: dailySync,
+//	    Env: map[string]workflowinstance.Value{
+//	        "SLACK_TOKEN": workflowinstance.SecretFromEnv("SLACK_TOKEN_PROD"),
+//	        "API_BASE":    workflowinstance.Literal("https://api.example.com"),
+//	    },
+//	    Schedule: "0 2 * * *",
+//	})
+func New(ctx Context, name string, args *Args) (*WorkflowInstance, error) {
+	if oc, ok := ctx.(openChecker); ok {
+		if err := oc.CheckOpen(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Nil-safety: if args is nil, create empty args
+	if args == nil {
+		args = &Args{}
+	}
+
+	i := &WorkflowInstance{
+		Name:        name,
+		Slug:        naming.GenerateSlug(name),
+		Workflow:    args.Workflow,
+		Description: args.Description,
+		Env:         args.Env,
+	}
+
+	if args.Schedule != "" {
+		i.Schedule = &workflow.Schedule{Cron: args.Schedule}
+		for _, opt := range args.ScheduleOptions {
+			opt(i.Schedule)
+		}
+	}
+
+	if err := i.validate(); err != nil {
+		return nil, err
+	}
+
+	// Register with context (if provided)
+	if ctx != nil {
+		ctx.RegisterWorkflowInstance(i)
+	}
+
+	return i, nil
+}
+
+// EnvironmentSlug returns the slug of the Environment resource holding the
+// instance's bindings.
+func (i *WorkflowInstance) EnvironmentSlug() string {
+	return i.Slug + "-env"
+}
+
+// WorkflowSlug returns the slug of the instance's workflow.
+func (i *WorkflowInstance) WorkflowSlug() string {
+	if i.Workflow.Slug != "" {
+		return i.Workflow.Slug
+	}
+	return naming.GenerateSlug(i.Workflow.Document.Name)
+}
+
+// String returns a string representation of the WorkflowInstance.
+func (i *WorkflowInstance) String() string {
+	return fmt.Sprintf("WorkflowInstance(name=%s)", i.Name)
+}
+
+// validate checks the instance name, its bindings and its schedule.
+func (i *WorkflowInstance) validate() error {
+	if err := validation.RequiredWithMessage("name", i.Name, "workflow instance name is required"); err != nil {
+		return err
+	}
+	if !nameRegex.MatchString(i.Name) || len(i.Name) > 63 {
+		return validation.NewValidationErrorWithCause(
+			"name",
+			i.Name,
+			"format",
+			"workflow instance name must be lowercase alphanumeric with hyphens, max 63 characters",
+			ErrInvalidName,
+		)
+	}
+	if i.Workflow == nil {
+		return validation.NewValidationErrorWithCause(
+			"workflow",
+			"",
+			"required",
+			fmt.Sprintf("workflow instance %q requires a workflow", i.Name),
+			ErrMissingWorkflow,
+		)
+	}
+	if err := i.validateBindings(); err != nil {
+		return err
+	}
+	if i.Schedule != nil {
+		return i.Schedule.Validate()
+	}
+	return nil
+}
+
+// validateBindings checks the bindings against the workflow's environment
+// variables. Variables added to the workflow after New are checked again at
+// synthesis.
+func (i *WorkflowInstance) validateBindings() error {
+	return envbinding.Validate(fmt.Sprintf("workflow %q", i.Workflow.Document.Name), i.Workflow.EnvironmentVariables, i.Env)
+}
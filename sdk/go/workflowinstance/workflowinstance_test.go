@@ -0,0 +1,243 @@
+package workflowinstance
+
+import (
+	"errors"
+	"testing"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	"github.com/stigmer/stigmer/sdk/go/environment"
+	"github.com/stigmer/stigmer/sdk/go/workflow"
+)
+
+// mockContext records registered workflow instances.
+type mockContext struct {
+	instances []*WorkflowInstance
+}
+
+func (m *mockContext) RegisterWorkflowInstance(i *WorkflowInstance) {
+	m.instances = append(m.instances, i)
+}
+
+// newTestWorkflow returns a workflow declaring a required secret
+// SLACK_TOKEN and an optional API_BASE.
+func newTestWorkflow(t *testing.T, opts ...workflow.WorkflowOption) *workflow.Workflow {
+	t.Helper()
+
+	slackToken, err := environment.New(nil, "SLACK_TOKEN", &environment.VariableArgs{
+		IsSecret:    true,
+		Description: "Slack bot token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiBase, err := environment.New(nil, "API_BASE", &environment.VariableArgs{
+		DefaultValue: "https://api.example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wf, err := workflow.New(nil, "ops/daily-sync", &workflow.WorkflowArgs{
+		EnvironmentVariables: []environment.Variable{*slackToken, *apiBase},
+	}, opts...)
+	if err != nil {
+		t.Fatalf("workflow.New() error = %v", err)
+	}
+	return wf
+}
+
+func TestNew(t *testing.T) {
+	ctx := &mockContext{}
+	wf := newTestWorkflow(t)
+
+	inst, err := New(ctx, "daily-sync-prod", &Args{
+		Workflow: wf,
+		Env: map[string]Value{
+			"SLACK_TOKEN": SecretFromEnv("SLACK_TOKEN_PROD"),
+			"API_BASE":    Literal("https://api.prod.example.com"),
+		},
+		Schedule:        "0 2 * * *",
+		ScheduleOptions: []workflow.ScheduleOption{workflow.Timezone("Europe/Berlin")},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if len(ctx.instances) != 1 || ctx.instances[0] != inst {
+		t.Fatalf("instance was not registered with the context")
+	}
+	if inst.Slug != "daily-sync-prod" || inst.EnvironmentSlug() != "daily-sync-prod-env" {
+		t.Errorf("unexpected slugs %q, %q", inst.Slug, inst.EnvironmentSlug())
+	}
+	if inst.Schedule == nil || inst.Schedule.Cron != "0 2 * * *" || inst.Schedule.Timezone != "Europe/Berlin" {
+		t.Errorf("unexpected schedule %+v", inst.Schedule)
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance string
+		args     func(wf *workflow.Workflow) *Args
+		wantErr  error
+	}{
+		{
+			name:     "invalid name",
+			instance: "Daily_Sync",
+			args: func(wf *workflow.Workflow) *Args {
+				return &Args{Workflow: wf, Env: map[string]Value{"SLACK_TOKEN": SecretFromEnv("T")}}
+			},
+			wantErr: ErrInvalidName,
+		},
+		{
+			name:     "missing workflow",
+			instance: "daily-sync-prod",
+			args:     func(*workflow.Workflow) *Args { return &Args{} },
+			wantErr:  ErrMissingWorkflow,
+		},
+		{
+			name:     "missing binding",
+			instance: "daily-sync-prod",
+			args:     func(wf *workflow.Workflow) *Args { return &Args{Workflow: wf} },
+			wantErr:  ErrMissingBinding,
+		},
+		{
+			name:     "unknown binding",
+			instance: "daily-sync-prod",
+			args: func(wf *workflow.Workflow) *Args {
+				return &Args{Workflow: wf, Env: map[string]Value{
+					"SLACK_TOKEN": SecretFromEnv("T"),
+					"REGION":      Literal("eu"),
+				}}
+			},
+			wantErr: ErrUnknownBinding,
+		},
+		{
+			name:     "secret bound to literal",
+			instance: "daily-sync-prod",
+			args: func(wf *workflow.Workflow) *Args {
+				return &Args{Workflow: wf, Env: map[string]Value{"SLACK_TOKEN": Literal("xoxb")}}
+			},
+			wantErr: ErrSecretMismatch,
+		},
+		{
+			name:     "invalid schedule",
+			instance: "daily-sync-prod",
+			args: func(wf *workflow.Workflow) *Args {
+				return &Args{Workflow: wf, Env: map[string]Value{"SLACK_TOKEN": SecretFromEnv("T")}, Schedule: "0 25 * * *"}
+			},
+			wantErr: ErrInvalidSchedule,
+		},
+		{
+			name:     "invalid time zone",
+			instance: "daily-sync-prod",
+			args: func(wf *workflow.Workflow) *Args {
+				return &Args{
+					Workflow:        wf,
+					Env:             map[string]Value{"SLACK_TOKEN": SecretFromEnv("T")},
+					Schedule:        "@daily",
+					ScheduleOptions: []workflow.ScheduleOption{workflow.Timezone("Mars/Olympus")},
+				}
+			},
+			wantErr: ErrInvalidSchedule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &mockContext{}
+			_, err := New(ctx, tt.instance, tt.args(newTestWorkflow(t)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("New() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(ctx.instances) != 0 {
+				t.Error("invalid instance was registered with the context")
+			}
+		})
+	}
+}
+
+func TestToProto(t *testing.T) {
+	wf := newTestWorkflow(t, workflow.WithSchedule("@hourly"))
+
+	inst, err := New(nil, "daily-sync-prod", &Args{
+		Workflow:    wf,
+		Description: "Production sync",
+		Env: map[string]Value{
+			"SLACK_TOKEN": SecretFromEnv("SLACK_TOKEN_PROD"),
+			"API_BASE":    Literal("https://api.prod.example.com"),
+		},
+		Schedule: "0 2 * * *",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	instance, env, err := inst.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+
+	spec := instance.GetSpec()
+	if spec.GetWorkflowId() != "daily-sync" {
+		t.Errorf("workflow_id = %q, want the workflow slug", spec.GetWorkflowId())
+	}
+	if spec.GetDescription() != "Production sync" {
+		t.Errorf("description = %q", spec.GetDescription())
+	}
+	if len(spec.GetEnvRefs()) != 1 || spec.GetEnvRefs()[0].GetSlug() != "daily-sync-prod-env" {
+		t.Errorf("unexpected env refs %v", spec.GetEnvRefs())
+	}
+	if got := spec.GetSchedule(); got.GetCron() != "0 2 * * *" ||
+		got.GetCatchUpPolicy() != workflowv1.ScheduleCatchUpPolicy_SCHEDULE_SKIP_MISSED {
+		t.Errorf("unexpected schedule %v", got)
+	}
+
+	token := env.GetSpec().GetData()["SLACK_TOKEN"]
+	if !token.GetIsSecret() || token.GetValue() != "" || token.GetDescription() != "Slack bot token" {
+		t.Errorf("unexpected secret value %v", token)
+	}
+	if got := env.GetMetadata().GetAnnotations()["stigmer.ai/secret-from-env.SLACK_TOKEN"]; got != "SLACK_TOKEN_PROD" {
+		t.Errorf("secret source annotation = %q", got)
+	}
+	if got := env.GetSpec().GetData()["API_BASE"].GetValue(); got != "https://api.prod.example.com" {
+		t.Errorf("API_BASE = %q", got)
+	}
+}
+
+func TestToProto_WorkflowSchedule(t *testing.T) {
+	wf := newTestWorkflow(t, workflow.WithSchedule("@hourly"))
+
+	// Without a schedule of its own, the instance runs on the workflow's
+	inst, err := New(nil, "daily-sync-prod", &Args{
+		Workflow: wf,
+		Env:      map[string]Value{"SLACK_TOKEN": SecretFromEnv("SLACK_TOKEN_PROD")},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	instance, _, err := inst.ToProto()
+	if err != nil {
+		t.Fatalf("ToProto() error = %v", err)
+	}
+	if instance.GetSpec().GetSchedule() != nil {
+		t.Errorf("schedule = %v, want none", instance.GetSpec().GetSchedule())
+	}
+}
+
+func TestToProto_RevalidatesBindings(t *testing.T) {
+	wf := newTestWorkflow(t)
+	inst, err := New(nil, "daily-sync-prod", &Args{
+		Workflow: wf,
+		Env:      map[string]Value{"SLACK_TOKEN": SecretFromEnv("SLACK_TOKEN_PROD")},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	wf.AddEnvironmentVariable(environment.Variable{Name: "WEBHOOK_URL", Required: true})
+	if _, _, err := inst.ToProto(); !errors.Is(err, ErrMissingBinding) {
+		t.Errorf("ToProto() error = %v, want ErrMissingBinding", err)
+	}
+}
@@ -23,6 +23,7 @@ const (
 	// Full agent optional fields (from SDK example)
 	FullAgentDescription = "Professional code reviewer with security focus"
 	FullAgentIconURL     = "https://example.com/icons/code-reviewer.png"
+	FullAgentMemoryTurns = 20 // MemoryWindow(20)
 
 	// Test messages
 	BasicAgentTestMessage = "Hello, test agent!"
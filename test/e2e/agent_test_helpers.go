@@ -153,8 +153,14 @@ func VerifyFullAgentOptionalFields(t *testing.T, agent *agentv1.Agent) {
 	require.Equal(t, LocalOrg, agent.Metadata.Org,
 		"Full agent org should be 'local' in local backend mode")
 
-	t.Logf("✓ Optional fields verified: description=%s, iconUrl=%s",
-		agent.Spec.Description, agent.Spec.IconUrl)
+	require.NotNil(t, agent.Spec.Memory, "Full agent should have memory config from SDK example")
+	require.Equal(t, agentv1.MemoryStrategy_MEMORY_WINDOW, agent.Spec.Memory.Strategy,
+		"Full agent memory should use a window strategy")
+	require.Equal(t, int32(FullAgentMemoryTurns), agent.Spec.Memory.WindowTurns,
+		"Full agent memory window should round-trip from SDK example")
+
+	t.Logf("✓ Optional fields verified: description=%s, iconUrl=%s, memory=%s",
+		agent.Spec.Description, agent.Spec.IconUrl, agent.Spec.Memory.Strategy)
 }
 
 // VerifyAgentDefaultInstance verifies that an agent has a default instance created
@@ -295,6 +301,10 @@ func WaitForAgentExecutionCompletion(t *testing.T, serverPort int, executionID s
 				t.Logf("   ⚠️  Execution was cancelled after %d polls", pollCount)
 				require.FailNow(t, "execution was cancelled")
 
+			case agentexecutionv1.ExecutionPhase_EXECUTION_LIMIT_EXCEEDED:
+				t.Logf("   ⛔ Execution stopped by an agent limit after %d polls", pollCount)
+				require.FailNow(t, fmt.Sprintf("execution exceeded an agent limit: %s", execution.Status.Error))
+
 			default:
 				// Still in progress (PENDING or IN_PROGRESS), continue polling
 				continue
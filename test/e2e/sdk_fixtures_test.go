@@ -200,6 +200,11 @@ func CopyAllSDKExamples() error {
 			TestDataDir:    "examples/19-workflow-agent-execution-config",
 			TargetFileName: "main.go",
 		},
+		{
+			SDKFileName:    "20_workflow_agent_with_attachments.go",
+			TestDataDir:    "examples/20-workflow-agent-with-attachments",
+			TargetFileName: "main.go",
+		},
 	}
 	
 	for _, example := range examples {
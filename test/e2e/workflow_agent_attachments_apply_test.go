@@ -0,0 +1,61 @@
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"path/filepath"
+
+	workflowv1 "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1"
+	apiresource "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/commons/apiresource"
+)
+
+// TestApplyWorkflowAgentAttachments verifies agent call attachments are deployed
+// with their expression values
+//
+// Example: sdk/go/examples/20_workflow_agent_with_attachments.go demonstrates:
+// - Passing a previous task's output to an agent as an attachment
+// - Setting the MIME type and size limit of an attachment
+//
+// Test Fixture: test/e2e/testdata/examples/20-workflow-agent-with-attachments/
+func (s *E2ESuite) TestApplyWorkflowAgentAttachments() {
+	s.T().Logf("=== Testing Agent Call Attachments ===")
+
+	// STEP 1: Apply from SDK example
+	absTestdataDir, err := filepath.Abs(AttachmentsTestDataDir)
+	s.Require().NoError(err, "Failed to get absolute path to attachments directory")
+
+	output, err := RunCLIWithServerAddr(s.Harness.ServerPort, "apply", "--config", absTestdataDir)
+	s.Require().NoError(err, "Apply command should succeed")
+	s.T().Logf("Apply command output:\n%s", output)
+
+	workflow, err := GetWorkflowBySlug(s.Harness.ServerPort, AttachmentsWorkflowName, LocalOrg)
+	s.Require().NoError(err, "Should be able to query workflow by slug via API")
+	s.Require().NotNil(workflow, "Workflow should exist")
+
+	// STEP 2: Find the agent call task
+	var review *workflowv1.WorkflowTask
+	for _, task := range workflow.Spec.Tasks {
+		if task.Name == AttachmentsReviewTaskName {
+			review = task
+		}
+	}
+	s.Require().NotNil(review, "Workflow should have the reviewDiff task")
+	s.Equal(apiresource.WorkflowTaskKind_WORKFLOW_TASK_KIND_AGENT_CALL, review.Kind,
+		"Task should be of type AGENT_CALL")
+	fields := review.TaskConfig.AsMap()
+
+	// STEP 3: Verify attachment entries carry the expression, not a resolved value
+	attachments, ok := fields["attachments"].([]interface{})
+	s.Require().True(ok, "Task config should contain attachments")
+	s.Len(attachments, AttachmentsCount, "Task should have the attachments from the SDK example")
+
+	diff, ok := attachments[0].(map[string]interface{})
+	s.Require().True(ok, "Attachment should be an object")
+	s.Equal(AttachmentsDiffName, diff["name"])
+	s.Equal(AttachmentsDiffMimeType, diff["mime_type"])
+	s.Equal(`${ $context["`+AttachmentsFetchTaskName+`"].body }`, diff["content"],
+		"Attachment content should reference the fetch task output")
+
+	s.T().Logf("✅ Attachments test passed: %d attachments deployed", len(attachments))
+}
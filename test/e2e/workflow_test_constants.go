@@ -56,3 +56,24 @@ const (
 	// Test fixture path
 	WorkflowCallingAgentTestDataDir = "testdata/examples/15-workflow-calling-simple-agent"
 )
+
+// ============================================================================
+// Agent-Call-Attachments test constants - matches SDK example 20_workflow_agent_with_attachments.go
+// ============================================================================
+const (
+	// Workflow from SDK example 20
+	AttachmentsWorkflowName = "diff-review"
+	AttachmentsAgentName    = "diff-reviewer"
+
+	// Task names from SDK example 20
+	AttachmentsFetchTaskName  = "fetchDiff"
+	AttachmentsReviewTaskName = "reviewDiff"
+
+	// Attachments from SDK example 20
+	AttachmentsDiffName     = "pr.diff"
+	AttachmentsDiffMimeType = "text/x-patch"
+	AttachmentsCount        = 2
+
+	// Test fixture path
+	AttachmentsTestDataDir = "testdata/examples/20-workflow-agent-with-attachments"
+)
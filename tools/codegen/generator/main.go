@@ -4,12 +4,20 @@
 // - Config structs for workflow tasks
 // - Builder functions for creating tasks
 // - ToProto/FromProto conversion methods
+// - ValidateOneofs methods for mutually exclusive (oneof) fields
 //
 // Usage:
 //   go run tools/codegen/generator/main.go \
 //     --schema-dir tools/codegen/schemas \
 //     --output-dir sdk/go/workflow/gen \
 //     --package gen
+//
+// Resource args of namespaces outside ai.stigmer.agentic (iam, tenancy) are
+// written to the directories mapped with --namespace-dir namespace=dir.
+//
+// Output is deterministic: regenerating from unchanged schemas produces
+// byte-identical files. Pass --stamp to record the generation time in the
+// file headers.
 
 package main
 
@@ -22,6 +30,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -38,6 +47,7 @@ type TaskConfigSchema struct {
 	ProtoType   string         `json:"protoType"`
 	ProtoFile   string         `json:"protoFile"`
 	Fields      []*FieldSchema `json:"fields"`
+	Oneofs      []*OneofSchema `json:"oneofs,omitempty"`
 }
 
 // TypeSchema represents a shared type (e.g., HttpEndpoint)
@@ -47,9 +57,18 @@ type TypeSchema struct {
 	ProtoType   string         `json:"protoType"`
 	ProtoFile   string         `json:"protoFile"`
 	Fields      []*FieldSchema `json:"fields"`
+	Oneofs      []*OneofSchema `json:"oneofs,omitempty"`
 	Domain      string         // Extracted from proto namespace (e.g., "commons", "agentic")
 }
 
+// OneofSchema represents a group of mutually exclusive fields (a proto oneof)
+type OneofSchema struct {
+	Name        string   `json:"name"`
+	ProtoOneof  string   `json:"protoOneof"`
+	Description string   `json:"description,omitempty"`
+	Fields      []string `json:"fields"` // Member field names (FieldSchema.Name)
+}
+
 // FieldSchema represents a field in a config or type
 type FieldSchema struct {
 	Name         string      `json:"name"`
@@ -60,6 +79,7 @@ type FieldSchema struct {
 	Required     bool        `json:"required"`
 	IsExpression bool        `json:"isExpression,omitempty"`
 	Validation   *Validation `json:"validation,omitempty"`
+	Semantic     string      `json:"semantic,omitempty"` // "duration" for integer fields holding whole seconds
 }
 
 // TypeSpec describes the type of a field
@@ -95,19 +115,34 @@ type Generator struct {
 	packageName string
 	fileSuffix  string
 
+	// stamp adds a "// Generated: <time>" line to file headers (--stamp)
+	stamp bool
+
+	// namespaceDirs maps proto namespaces (e.g. "iam") to the output
+	// directory of their resource args (defaultNamespaceDirs and --namespace-dir)
+	namespaceDirs map[string]string
+
 	// Loaded schemas
 	taskConfigs   []*TaskConfigSchema
 	sharedTypes   []*TypeSchema
 	resourceSpecs []*TaskConfigSchema // SDK resource specs (Agent, Skill, etc.) - reuses TaskConfigSchema
 }
 
-// NewGenerator creates a new code generator
-func NewGenerator(schemaDir, outputDir, packageName, fileSuffix string) (*Generator, error) {
+// NewGenerator creates a new code generator. namespaceDirs overrides and
+// extends defaultNamespaceDirs (nil keeps the defaults).
+func NewGenerator(schemaDir, outputDir, packageName, fileSuffix string, namespaceDirs map[string]string) (*Generator, error) {
 	g := &Generator{
-		schemaDir:   schemaDir,
-		outputDir:   outputDir,
-		packageName: packageName,
-		fileSuffix:  fileSuffix,
+		schemaDir:     schemaDir,
+		outputDir:     outputDir,
+		packageName:   packageName,
+		fileSuffix:    fileSuffix,
+		namespaceDirs: make(map[string]string, len(defaultNamespaceDirs)+len(namespaceDirs)),
+	}
+	for namespace, dir := range defaultNamespaceDirs {
+		g.namespaceDirs[namespace] = dir
+	}
+	for namespace, dir := range namespaceDirs {
+		g.namespaceDirs[namespace] = dir
 	}
 
 	// Load schemas
@@ -115,6 +150,11 @@ func NewGenerator(schemaDir, outputDir, packageName, fileSuffix string) (*Genera
 		return nil, fmt.Errorf("failed to load schemas: %w", err)
 	}
 
+	// Fail before writing any file if two schemas map to the same Go identifier
+	if err := g.checkNameCollisions(); err != nil {
+		return nil, err
+	}
+
 	return g, nil
 }
 
@@ -131,6 +171,11 @@ func (g *Generator) Generate() error {
 		return fmt.Errorf("failed to generate helpers: %w", err)
 	}
 
+	// Generate the shared StringExpr interface used by expression fields
+	if err := g.generateSharedHelpers(); err != nil {
+		return fmt.Errorf("failed to generate shared helpers: %w", err)
+	}
+
 	// Generate shared types
 	if len(g.sharedTypes) > 0 {
 		fmt.Printf("\nGenerating shared types...\n")
@@ -147,6 +192,12 @@ func (g *Generator) Generate() error {
 		}
 	}
 
+	// Generate kind registry (TaskKind -> config factory)
+	fmt.Printf("\nGenerating kind registry...\n")
+	if err := g.generateKindRegistry(); err != nil {
+		return fmt.Errorf("failed to generate kind registry: %w", err)
+	}
+
 	// Generate SDK resource args structs (Agent, Skill, etc.)
 	if len(g.resourceSpecs) > 0 {
 		fmt.Printf("\nGenerating SDK resource args structs...\n")
@@ -197,6 +248,14 @@ func extractSubdomainFromProtoFile(protoFile string) string {
 	return ""
 }
 
+// defaultNamespaceDirs maps the proto namespaces outside ai.stigmer.agentic
+// to the output directory of their resource args. Override or extend it
+// with --namespace-dir.
+var defaultNamespaceDirs = map[string]string{
+	"iam":     "sdk/go/iam/gen",
+	"tenancy": "sdk/go/tenancy/gen",
+}
+
 // getOutputDir returns the appropriate output directory for a given schema
 func (g *Generator) getOutputDir(schema *TaskConfigSchema) string {
 	// Extract subdomain from proto file path (data-driven)
@@ -207,6 +266,12 @@ func (g *Generator) getOutputDir(schema *TaskConfigSchema) string {
 		return filepath.Join("sdk", "go", "gen", subdomain)
 	}
 
+	// Other namespaces generate to their mapped directory
+	// (e.g., ai.stigmer.iam -> sdk/go/iam/gen/)
+	if dir, ok := g.namespaceDirs[extractDomainFromProtoType(schema.ProtoType)]; ok {
+		return dir
+	}
+
 	// Default: use configured output directory (gen/workflow for tasks)
 	return g.outputDir
 }
@@ -272,8 +337,10 @@ func (g *Generator) loadSchemas() error {
 		}
 	}
 
-	// Track loaded types to avoid duplicates
-	loadedTypes := make(map[string]bool)
+	// Track loaded types to avoid duplicates (type name -> proto message).
+	// Types with the same name but a different proto message are kept so
+	// checkNameCollisions can report them.
+	loadedTypes := make(map[string]string)
 
 	// Load shared types from types/ directory (workflow task types)
 	typesDir := filepath.Join(g.schemaDir, "types")
@@ -295,10 +362,9 @@ func (g *Generator) loadSchemas() error {
 			}
 
 			// Skip duplicates
-			if loadedTypes[schema.Name] {
+			if isDuplicateType(loadedTypes, schema) {
 				continue
 			}
-			loadedTypes[schema.Name] = true
 
 			// Extract domain from proto namespace (data-driven, no hard-coding)
 			schema.Domain = extractDomainFromProtoType(schema.ProtoType)
@@ -328,10 +394,9 @@ func (g *Generator) loadSchemas() error {
 			}
 
 			// Skip duplicates
-			if loadedTypes[schema.Name] {
+			if isDuplicateType(loadedTypes, schema) {
 				continue
 			}
-			loadedTypes[schema.Name] = true
 
 			// Extract domain from proto namespace (data-driven, no hard-coding)
 			schema.Domain = extractDomainFromProtoType(schema.ProtoType)
@@ -358,72 +423,86 @@ func (g *Generator) loadSchemas() error {
 			}
 
 			// Load specs from this namespace directory
-			namespaceDir := filepath.Join(g.schemaDir, dirName)
-			entries, err := os.ReadDir(namespaceDir)
+			g.loadNamespaceDir(filepath.Join(g.schemaDir, dirName), dirName, loadedTypes, true)
+		}
+	}
+
+	if len(g.taskConfigs) == 0 && len(g.sharedTypes) == 0 && len(g.resourceSpecs) == 0 {
+		return fmt.Errorf("no schemas found in %s", g.schemaDir)
+	}
+
+	return nil
+}
+
+// loadNamespaceDir loads the resource specs of a namespace directory and the
+// shared types of its types/ subdirectory. Namespaces grouping several
+// resources (iam/apikey/, tenancy/organization/) keep each resource in its
+// own subdirectory, which is loaded when nested is set.
+func (g *Generator) loadNamespaceDir(namespaceDir, label string, loadedTypes map[string]string, nested bool) {
+	entries, err := os.ReadDir(namespaceDir)
+	if err != nil {
+		return // Skip directories we can't read
+	}
+
+	for _, entry := range entries {
+		// Check if this is a types/ subdirectory
+		if entry.IsDir() && entry.Name() == "types" {
+			// Load types from <namespace>/types/ directory
+			namespaceTypesDir := filepath.Join(namespaceDir, "types")
+			typeEntries, err := os.ReadDir(namespaceTypesDir)
 			if err != nil {
-				continue // Skip directories we can't read
+				continue
 			}
 
-			for _, entry := range entries {
-				// Check if this is a types/ subdirectory
-				if entry.IsDir() && entry.Name() == "types" {
-					// Load types from <namespace>/types/ directory
-					namespaceTypesDir := filepath.Join(namespaceDir, "types")
-					typeEntries, err := os.ReadDir(namespaceTypesDir)
-					if err != nil {
-						continue
-					}
-
-					for _, typeEntry := range typeEntries {
-						if typeEntry.IsDir() || !strings.HasSuffix(typeEntry.Name(), ".json") {
-							continue
-						}
-
-						path := filepath.Join(namespaceTypesDir, typeEntry.Name())
-						schema, err := loadTypeSchema(path)
-						if err != nil {
-							fmt.Printf("  Warning: failed to load type %s: %v\n", typeEntry.Name(), err)
-							continue
-						}
-
-						// Skip duplicates
-						if loadedTypes[schema.Name] {
-							continue
-						}
-						loadedTypes[schema.Name] = true
-
-						// Extract domain from proto namespace (data-driven, no hard-coding)
-						schema.Domain = extractDomainFromProtoType(schema.ProtoType)
-						fmt.Printf("  Loaded type: %s (domain: %s, from %s/types/)\n", schema.Name, schema.Domain, dirName)
-
-						g.sharedTypes = append(g.sharedTypes, schema)
-					}
+			for _, typeEntry := range typeEntries {
+				if typeEntry.IsDir() || !strings.HasSuffix(typeEntry.Name(), ".json") {
 					continue
 				}
 
-				// Skip other subdirectories and non-JSON files
-				if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+				path := filepath.Join(namespaceTypesDir, typeEntry.Name())
+				schema, err := loadTypeSchema(path)
+				if err != nil {
+					fmt.Printf("  Warning: failed to load type %s: %v\n", typeEntry.Name(), err)
 					continue
 				}
 
-				path := filepath.Join(namespaceDir, entry.Name())
-				schema, err := loadTaskConfigSchema(path)
-				if err != nil {
-					fmt.Printf("  Warning: failed to load spec %s: %v\n", entry.Name(), err)
+				// Skip duplicates
+				if isDuplicateType(loadedTypes, schema) {
 					continue
 				}
 
-				g.resourceSpecs = append(g.resourceSpecs, schema)
-				fmt.Printf("  Loaded spec: %s (from %s/)\n", schema.Name, dirName)
+				// Extract domain from proto namespace (data-driven, no hard-coding)
+				schema.Domain = extractDomainFromProtoType(schema.ProtoType)
+				fmt.Printf("  Loaded type: %s (domain: %s, from %s/types/)\n", schema.Name, schema.Domain, label)
+
+				g.sharedTypes = append(g.sharedTypes, schema)
 			}
+			continue
 		}
-	}
 
-	if len(g.taskConfigs) == 0 && len(g.sharedTypes) == 0 && len(g.resourceSpecs) == 0 {
-		return fmt.Errorf("no schemas found in %s", g.schemaDir)
-	}
+		// Load resources grouped in a subdirectory of the namespace
+		if entry.IsDir() {
+			if nested {
+				g.loadNamespaceDir(filepath.Join(namespaceDir, entry.Name()), label+"/"+entry.Name(), loadedTypes, false)
+			}
+			continue
+		}
 
-	return nil
+		// Skip non-JSON files
+		if !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		path := filepath.Join(namespaceDir, entry.Name())
+		schema, err := loadTaskConfigSchema(path)
+		if err != nil {
+			fmt.Printf("  Warning: failed to load spec %s: %v\n", entry.Name(), err)
+			continue
+		}
+
+		g.resourceSpecs = append(g.resourceSpecs, schema)
+		fmt.Printf("  Loaded spec: %s (from %s/)\n", schema.Name, label)
+	}
 }
 
 // loadTaskConfigSchema loads a task config schema from a JSON file
@@ -456,19 +535,122 @@ func loadTypeSchema(path string) (*TypeSchema, error) {
 	return &schema, nil
 }
 
+// isDuplicateType reports whether a type with the same name and proto message
+// was already loaded, and records the type otherwise.
+func isDuplicateType(loaded map[string]string, schema *TypeSchema) bool {
+	protoType, ok := loaded[schema.Name]
+	if ok && protoType == schema.ProtoType {
+		return true
+	}
+	if !ok {
+		loaded[schema.Name] = schema.ProtoType
+	}
+	return false
+}
+
+// sharedTypesOutputDir is the directory of the shared types package.
+const sharedTypesOutputDir = "sdk/go/gen/types"
+
+// stubsImportPrefix is the import path of the Go stubs generated from the
+// protos under apis/.
+const stubsImportPrefix = "github.com/stigmer/stigmer/apis/stubs/go/"
+
+// generatedHelperNames lists the identifiers declared by helpers.go and
+// kind_registry.go in the task config package.
+var generatedHelperNames = []string{
+	"isEmpty",
+	"summaryField",
+	"summarizeConfig",
+	"TaskConfig",
+	"TaskConfigFactory",
+	"taskConfigRegistry",
+	"RegisterTaskConfig",
+	"LookupTaskConfig",
+	"TaskConfigFromProto",
+	"RegisteredTaskKinds",
+	"taskConfigMessages",
+	"NewTaskConfigMessage",
+}
+
+// checkNameCollisions reports schemas that generate the same identifier in
+// the same Go package, which would not compile.
+//
+// Schemas for the same proto message (e.g. agent_call.json and agentcall.json)
+// are duplicates, not collisions: the last one loaded wins. Collisions must be
+// resolved by renaming one of the schemas.
+func (g *Generator) checkNameCollisions() error {
+	// output directory (one Go package) -> identifier -> source
+	declared := make(map[string]map[string]string)
+	var collisions []string
+
+	declare := func(dir, name, source string) {
+		if declared[dir] == nil {
+			declared[dir] = make(map[string]string)
+		}
+		existing, ok := declared[dir][name]
+		if !ok {
+			declared[dir][name] = source
+			return
+		}
+		if existing != source {
+			collisions = append(collisions,
+				fmt.Sprintf("%s: %s is declared by both %s and %s", dir, name, existing, source))
+		}
+	}
+
+	if len(g.taskConfigs) > 0 {
+		for _, name := range generatedHelperNames {
+			declare(g.outputDir, name, "generated helpers")
+		}
+	}
+	declare(sharedTypesOutputDir, stringExprType, "generated helpers")
+	for _, taskConfig := range g.taskConfigs {
+		declare(g.outputDir, taskConfig.Name, taskConfig.ProtoType)
+	}
+	for _, typeSchema := range g.sharedTypes {
+		declare(sharedTypesOutputDir, typeSchema.Name, typeSchema.ProtoType)
+	}
+	for _, resourceSpec := range g.resourceSpecs {
+		argsName := strings.TrimSuffix(resourceSpec.Name, "Spec") + "Args"
+		declare(g.getOutputDir(resourceSpec), argsName, resourceSpec.ProtoType)
+	}
+
+	if len(collisions) == 0 {
+		return nil
+	}
+	sort.Strings(collisions)
+	return fmt.Errorf("generated name collisions (rename one of the schemas):\n  %s",
+		strings.Join(collisions, "\n  "))
+}
+
+// writeFileHeader writes the "Code generated" header of a generated file.
+// source is the file name recorded in the header ("" to omit it). The
+// generation time is only recorded with --stamp, so regenerating unchanged
+// schemas doesn't touch every file.
+func (g *Generator) writeFileHeader(w *bytes.Buffer, source string) {
+	fmt.Fprintf(w, "// Code generated by stigmer-codegen. DO NOT EDIT.\n")
+	if source != "" {
+		fmt.Fprintf(w, "// Source: %s\n", source)
+	}
+	if g.stamp {
+		fmt.Fprintf(w, "// Generated: %s\n", time.Now().Format(time.RFC3339))
+	}
+	fmt.Fprintf(w, "\n")
+}
+
 // generateHelpers generates a helpers.go file with utility functions
 func (g *Generator) generateHelpers() error {
 	var buf bytes.Buffer
 
 	// File header
-	fmt.Fprintf(&buf, "// Code generated by stigmer-codegen. DO NOT EDIT.\n")
-	fmt.Fprintf(&buf, "// Generated: %s\n\n", time.Now().Format(time.RFC3339))
+	g.writeFileHeader(&buf, "")
 	fmt.Fprintf(&buf, "package %s\n\n", g.packageName)
 
-	// Import reflect and fmt
+	// Import fmt, reflect and strings
 	fmt.Fprintf(&buf, "import (\n")
 	fmt.Fprintf(&buf, "\t\"fmt\"\n")
 	fmt.Fprintf(&buf, "\t\"reflect\"\n")
+	fmt.Fprintf(&buf, "\t\"strings\"\n")
 	fmt.Fprintf(&buf, ")\n\n")
 
 	// isEmpty function
@@ -482,29 +664,38 @@ func (g *Generator) generateHelpers() error {
 	fmt.Fprintf(&buf, "\treturn val.IsZero()\n")
 	fmt.Fprintf(&buf, "}\n\n")
 
-	// coerceToString function for expression support
-	fmt.Fprintf(&buf, "// coerceToString converts various types to strings for expression support.\n")
-	fmt.Fprintf(&buf, "// Used by option functions to accept both string literals and expressions.\n")
-	fmt.Fprintf(&buf, "func coerceToString(value interface{}) string {\n")
-	fmt.Fprintf(&buf, "\tif s, ok := value.(string); ok {\n")
-	fmt.Fprintf(&buf, "\t\treturn s\n")
+	// summaryField function for String() methods
+	fmt.Fprintf(&buf, "// summaryField formats a single field for a config's String() summary.\n")
+	fmt.Fprintf(&buf, "// Empty fields are dropped; maps, lists and nested messages are reduced to\n")
+	fmt.Fprintf(&buf, "// their size or presence so values (which may hold secrets) are never printed.\n")
+	fmt.Fprintf(&buf, "func summaryField(name string, value interface{}) string {\n")
+	fmt.Fprintf(&buf, "\tif isEmpty(value) {\n")
+	fmt.Fprintf(&buf, "\t\treturn \"\"\n")
 	fmt.Fprintf(&buf, "\t}\n")
-	fmt.Fprintf(&buf, "\t// NOTE: *Task handling omitted to avoid circular dependency between gen/workflow and workflow packages.\n")
-	fmt.Fprintf(&buf, "\t// Task-to-expression conversion is handled in the hand-written workflow package helpers.\n")
-	fmt.Fprintf(&buf, "\t// Handle StringRef - use Value() for resolved literals, Expression() for computed\n")
-	fmt.Fprintf(&buf, "\tif sr, ok := value.(interface{ Value() string; Expression() string }); ok {\n")
-	fmt.Fprintf(&buf, "\t\t// Try Value() first (for resolved StringRef from Concat, etc.)\n")
-	fmt.Fprintf(&buf, "\t\tif v := sr.Value(); v != \"\" {\n")
-	fmt.Fprintf(&buf, "\t\t\treturn v\n")
-	fmt.Fprintf(&buf, "\t\t}\n")
-	fmt.Fprintf(&buf, "\t\t// Fall back to Expression() for computed/context refs\n")
-	fmt.Fprintf(&buf, "\t\treturn sr.Expression()\n")
+	fmt.Fprintf(&buf, "\tval := reflect.ValueOf(value)\n")
+	fmt.Fprintf(&buf, "\tswitch val.Kind() {\n")
+	fmt.Fprintf(&buf, "\tcase reflect.Map:\n")
+	fmt.Fprintf(&buf, "\t\treturn fmt.Sprintf(\"%%s=<%%d entries>\", name, val.Len())\n")
+	fmt.Fprintf(&buf, "\tcase reflect.Slice, reflect.Array:\n")
+	fmt.Fprintf(&buf, "\t\treturn fmt.Sprintf(\"%%s=<%%d items>\", name, val.Len())\n")
+	fmt.Fprintf(&buf, "\tcase reflect.Ptr, reflect.Struct, reflect.Interface:\n")
+	fmt.Fprintf(&buf, "\t\treturn fmt.Sprintf(\"%%s=<set>\", name)\n")
+	fmt.Fprintf(&buf, "\tdefault:\n")
+	fmt.Fprintf(&buf, "\t\treturn fmt.Sprintf(\"%%s=%%v\", name, value)\n")
 	fmt.Fprintf(&buf, "\t}\n")
-	fmt.Fprintf(&buf, "\t// Handle TaskFieldRef and other expression types\n")
-	fmt.Fprintf(&buf, "\tif expr, ok := value.(interface{ Expression() string }); ok {\n")
-	fmt.Fprintf(&buf, "\t\treturn expr.Expression()\n")
+	fmt.Fprintf(&buf, "}\n\n")
+
+	// summarizeConfig function for String() methods
+	fmt.Fprintf(&buf, "// summarizeConfig joins non-empty summary fields into a one-line summary.\n")
+	fmt.Fprintf(&buf, "// Example: HTTP_CALL{method=GET endpoint=<set> timeoutSeconds=30}\n")
+	fmt.Fprintf(&buf, "func summarizeConfig(kind string, fields ...string) string {\n")
+	fmt.Fprintf(&buf, "\tparts := make([]string, 0, len(fields))\n")
+	fmt.Fprintf(&buf, "\tfor _, f := range fields {\n")
+	fmt.Fprintf(&buf, "\t\tif f != \"\" {\n")
+	fmt.Fprintf(&buf, "\t\t\tparts = append(parts, f)\n")
+	fmt.Fprintf(&buf, "\t\t}\n")
 	fmt.Fprintf(&buf, "\t}\n")
-	fmt.Fprintf(&buf, "\treturn fmt.Sprintf(\"%%v\", value)\n")
+	fmt.Fprintf(&buf, "\treturn kind + \"{\" + strings.Join(parts, \" \") + \"}\"\n")
 	fmt.Fprintf(&buf, "}\n")
 
 	// Format and write
@@ -512,6 +703,36 @@ func (g *Generator) generateHelpers() error {
 	return g.writeFormattedFile("helpers.go", buf.Bytes())
 }
 
+// stringExprType is the interface accepted by the Ref companion of
+// expression fields, declared in the shared types package.
+const stringExprType = "StringExpr"
+
+// generateSharedHelpers generates expr.go in the shared types package,
+// declaring the StringExpr interface. Task configs and shared types both use
+// it, so it lives in the package they share.
+func (g *Generator) generateSharedHelpers() error {
+	var buf bytes.Buffer
+
+	g.writeFileHeader(&buf, "")
+	fmt.Fprintf(&buf, "package types\n\n")
+
+	fmt.Fprintf(&buf, "// %s is a typed value for an expression field, set through the\n", stringExprType)
+	fmt.Fprintf(&buf, "// field's Ref companion (e.g. MessageRef for Message).\n")
+	fmt.Fprintf(&buf, "//\n")
+	fmt.Fprintf(&buf, "// It is implemented by the SDK's string references, task field references,\n")
+	fmt.Fprintf(&buf, "// raw expressions and tasks. Int and bool references do not implement it,\n")
+	fmt.Fprintf(&buf, "// so passing one where a string is expected fails to compile. Literals and\n")
+	fmt.Fprintf(&buf, "// hand-written \"${ }\" expressions go in the plain field instead.\n")
+	fmt.Fprintf(&buf, "type %s interface {\n", stringExprType)
+	fmt.Fprintf(&buf, "\t// StringExpression returns the value known at synthesis time, or the\n")
+	fmt.Fprintf(&buf, "\t// \"${ }\" expression that computes it at runtime.\n")
+	fmt.Fprintf(&buf, "\tStringExpression() string\n")
+	fmt.Fprintf(&buf, "}\n")
+
+	fmt.Printf("  Generating %s/expr.go...\n", sharedTypesOutputDir)
+	return g.writeFormattedFileToDir(sharedTypesOutputDir, "expr.go", buf.Bytes())
+}
+
 // generateSharedTypes generates a types.go file with all shared types
 func (g *Generator) generateSharedTypes() error {
 	// Group types by domain
@@ -524,9 +745,15 @@ func (g *Generator) generateSharedTypes() error {
 		typesByDomain[domain] = append(typesByDomain[domain], typeSchema)
 	}
 
+	domains := make([]string, 0, len(typesByDomain))
+	for domain := range typesByDomain {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+
 	// Generate a separate file for each domain
-	for domain, types := range typesByDomain {
-		if err := g.generateTypesForDomain(domain, types); err != nil {
+	for _, domain := range domains {
+		if err := g.generateTypesForDomain(domain, typesByDomain[domain]); err != nil {
 			return fmt.Errorf("failed to generate %s types: %w", domain, err)
 		}
 	}
@@ -549,18 +776,33 @@ func (g *Generator) generateTypesForDomain(domain string, types []*TypeSchema) e
 			return err
 		}
 
+		// Generate ValidateOneofs method (mutually exclusive fields)
+		fields, oneofs := withExpressionRefOneofs(typeSchema.Fields, typeSchema.Oneofs)
+		if err := ctx.genValidateOneofsMethod(&buf, typeSchema.Name, fields, oneofs); err != nil {
+			return err
+		}
+
+		// Generate accessors resolving expression fields and their Ref companions
+		ctx.genExpressionMethods(&buf, typeSchema.Name, typeSchema.Fields)
+
+		// Generate time.Duration accessors for whole-seconds fields
+		if err := ctx.genDurationMethods(&buf, typeSchema.Name, typeSchema.Fields); err != nil {
+			return err
+		}
+
 		// Generate FromProto method for shared types
 		if err := ctx.genTypeFromProtoMethod(&buf, typeSchema); err != nil {
 			return err
 		}
+
+		// Generate MarshalJSON method writing back unknown fields
+		ctx.genTypeMarshalJSONMethod(&buf, typeSchema)
 	}
 
 	// Add imports at the beginning
 	var finalBuf bytes.Buffer
 	filename := fmt.Sprintf("%s_types.go", domain)
-	finalBuf.WriteString(fmt.Sprintf("// Code generated by stigmer-codegen. DO NOT EDIT.\n"))
-	finalBuf.WriteString(fmt.Sprintf("// Source: %s\n", filename))
-	finalBuf.WriteString(fmt.Sprintf("// Generated: %s\n\n", time.Now().Format(time.RFC3339)))
+	g.writeFileHeader(&finalBuf, filename)
 	finalBuf.WriteString(fmt.Sprintf("package types\n\n"))
 
 	// Add imports if any were used
@@ -572,7 +814,7 @@ func (g *Generator) generateTypesForDomain(domain string, types []*TypeSchema) e
 	finalBuf.Write(buf.Bytes()[len("package types\n\n"):])
 
 	// Write to sdk/go/gen/types/ directory
-	typesOutputDir := "sdk/go/gen/types"
+	typesOutputDir := sharedTypesOutputDir
 	if err := os.MkdirAll(typesOutputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create types directory: %w", err)
 	}
@@ -603,6 +845,11 @@ func (g *Generator) generateTaskFile(taskConfig *TaskConfigSchema) error {
 	}
 
 	ctx := newGenContextWithSharedTypes(g.packageName, sharedTypeNames)
+	for _, t := range g.sharedTypes {
+		if len(t.Oneofs) > 0 || hasStringExpressions(t.Fields) {
+			ctx.oneofTypes[t.Name] = struct{}{}
+		}
+	}
 
 	var buf bytes.Buffer
 
@@ -614,6 +861,20 @@ func (g *Generator) generateTaskFile(taskConfig *TaskConfigSchema) error {
 		return err
 	}
 
+	// Generate ValidateOneofs method (mutually exclusive fields)
+	fields, oneofs := withExpressionRefOneofs(taskConfig.Fields, taskConfig.Oneofs)
+	if err := ctx.genValidateOneofsMethod(&buf, taskConfig.Name, fields, oneofs); err != nil {
+		return err
+	}
+
+	// Generate accessors resolving expression fields and their Ref companions
+	ctx.genExpressionMethods(&buf, taskConfig.Name, taskConfig.Fields)
+
+	// Generate time.Duration accessors for whole-seconds fields
+	if err := ctx.genDurationMethods(&buf, taskConfig.Name, taskConfig.Fields); err != nil {
+		return err
+	}
+
 	// Generate ToProto method
 	if err := ctx.genToProtoMethod(&buf, taskConfig); err != nil {
 		return err
@@ -624,6 +885,13 @@ func (g *Generator) generateTaskFile(taskConfig *TaskConfigSchema) error {
 		return err
 	}
 
+	// Generate String method (redacted one-line summary)
+	if strings.HasSuffix(taskConfig.Name, "TaskConfig") {
+		if err := ctx.genStringMethod(&buf, taskConfig); err != nil {
+			return err
+		}
+	}
+
 	// TODO: Generate Args structs for workflow tasks (after SDK resources are stable)
 
 	// Add imports at the beginning (after package declaration)
@@ -631,9 +899,7 @@ func (g *Generator) generateTaskFile(taskConfig *TaskConfigSchema) error {
 	baseName := strings.ToLower(strings.ReplaceAll(taskConfig.Name, "Spec", "spec"))
 	baseName = strings.ToLower(strings.ReplaceAll(baseName, "Config", "config"))
 	filename := fmt.Sprintf("%s%s.go", toSnakeCase(baseName), g.fileSuffix)
-	finalBuf.WriteString(fmt.Sprintf("// Code generated by stigmer-codegen. DO NOT EDIT.\n"))
-	finalBuf.WriteString(fmt.Sprintf("// Source: %s\n", filename))
-	finalBuf.WriteString(fmt.Sprintf("// Generated: %s\n\n", time.Now().Format(time.RFC3339)))
+	g.writeFileHeader(&finalBuf, filename)
 	finalBuf.WriteString(fmt.Sprintf("package %s\n\n", g.packageName))
 
 	// Add imports if any were used
@@ -649,6 +915,154 @@ func (g *Generator) generateTaskFile(taskConfig *TaskConfigSchema) error {
 	return g.writeFormattedFile(filename, finalBuf.Bytes())
 }
 
+// generateKindRegistry generates kind_registry.go, mapping each task kind to a
+// factory for its config type so callers can decode manifests without a
+// hand-maintained switch.
+func (g *Generator) generateKindRegistry() error {
+	// Schemas may be duplicated across files (e.g. agent_call.json and
+	// agentcall.json); the last one loaded wins, matching file generation.
+	configsByKind := make(map[string]*TaskConfigSchema)
+	for _, taskConfig := range g.taskConfigs {
+		if taskConfig.Kind == "" || !strings.HasSuffix(taskConfig.Name, "TaskConfig") {
+			continue
+		}
+		configsByKind[taskConfig.Kind] = taskConfig
+	}
+
+	kinds := make([]string, 0, len(configsByKind))
+	for kind := range configsByKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	// The stub packages holding the config messages are imported for their
+	// side effect of registering the messages with protoregistry
+	stubImports := make(map[string]bool)
+	for _, kind := range kinds {
+		if importPath := stubImportPath(configsByKind[kind].ProtoFile); importPath != "" {
+			stubImports[importPath] = true
+		}
+	}
+	stubImportPaths := make([]string, 0, len(stubImports))
+	for importPath := range stubImports {
+		stubImportPaths = append(stubImportPaths, importPath)
+	}
+	sort.Strings(stubImportPaths)
+
+	var buf bytes.Buffer
+
+	// File header
+	g.writeFileHeader(&buf, "kind_registry.go")
+	fmt.Fprintf(&buf, "package %s\n\n", g.packageName)
+
+	fmt.Fprintf(&buf, "import (\n")
+	fmt.Fprintf(&buf, "\t\"fmt\"\n")
+	fmt.Fprintf(&buf, "\t\"sort\"\n\n")
+	for _, importPath := range stubImportPaths {
+		fmt.Fprintf(&buf, "\t_ %q\n", importPath)
+	}
+	fmt.Fprintf(&buf, "\t\"google.golang.org/protobuf/proto\"\n")
+	fmt.Fprintf(&buf, "\t\"google.golang.org/protobuf/reflect/protoreflect\"\n")
+	fmt.Fprintf(&buf, "\t\"google.golang.org/protobuf/reflect/protoregistry\"\n")
+	fmt.Fprintf(&buf, "\t\"google.golang.org/protobuf/types/known/structpb\"\n")
+	fmt.Fprintf(&buf, ")\n\n")
+
+	// TaskConfig interface
+	fmt.Fprintf(&buf, "// TaskConfig is implemented by every generated task config.\n")
+	fmt.Fprintf(&buf, "type TaskConfig interface {\n")
+	fmt.Fprintf(&buf, "\tIsTaskConfig()\n")
+	fmt.Fprintf(&buf, "\tToProto() (*structpb.Struct, error)\n")
+	fmt.Fprintf(&buf, "\tFromProto(s *structpb.Struct) error\n")
+	fmt.Fprintf(&buf, "\tString() string\n")
+	fmt.Fprintf(&buf, "}\n\n")
+
+	// Factory type and registry
+	fmt.Fprintf(&buf, "// TaskConfigFactory creates a zero-valued task config.\n")
+	fmt.Fprintf(&buf, "type TaskConfigFactory func() TaskConfig\n\n")
+	fmt.Fprintf(&buf, "// taskConfigRegistry maps task kinds (e.g. \"HTTP_CALL\") to config factories.\n")
+	fmt.Fprintf(&buf, "var taskConfigRegistry = make(map[string]TaskConfigFactory)\n\n")
+
+	fmt.Fprintf(&buf, "// RegisterTaskConfig registers the config factory for a task kind.\n")
+	fmt.Fprintf(&buf, "// Registering a kind again replaces its factory.\n")
+	fmt.Fprintf(&buf, "func RegisterTaskConfig(kind string, factory TaskConfigFactory) {\n")
+	fmt.Fprintf(&buf, "\ttaskConfigRegistry[kind] = factory\n")
+	fmt.Fprintf(&buf, "}\n\n")
+
+	fmt.Fprintf(&buf, "// LookupTaskConfig returns the config factory registered for a task kind.\n")
+	fmt.Fprintf(&buf, "func LookupTaskConfig(kind string) (TaskConfigFactory, bool) {\n")
+	fmt.Fprintf(&buf, "\tfactory, ok := taskConfigRegistry[kind]\n")
+	fmt.Fprintf(&buf, "\treturn factory, ok\n")
+	fmt.Fprintf(&buf, "}\n\n")
+
+	fmt.Fprintf(&buf, "// TaskConfigFromProto decodes s into a new config of the given task kind.\n")
+	fmt.Fprintf(&buf, "func TaskConfigFromProto(kind string, s *structpb.Struct) (TaskConfig, error) {\n")
+	fmt.Fprintf(&buf, "\tfactory, ok := LookupTaskConfig(kind)\n")
+	fmt.Fprintf(&buf, "\tif !ok {\n")
+	fmt.Fprintf(&buf, "\t\treturn nil, fmt.Errorf(\"unknown task kind: %%s\", kind)\n")
+	fmt.Fprintf(&buf, "\t}\n")
+	fmt.Fprintf(&buf, "\tconfig := factory()\n")
+	fmt.Fprintf(&buf, "\tif err := config.FromProto(s); err != nil {\n")
+	fmt.Fprintf(&buf, "\t\treturn nil, fmt.Errorf(\"failed to decode %%s task config: %%w\", kind, err)\n")
+	fmt.Fprintf(&buf, "\t}\n")
+	fmt.Fprintf(&buf, "\treturn config, nil\n")
+	fmt.Fprintf(&buf, "}\n\n")
+
+	fmt.Fprintf(&buf, "// RegisteredTaskKinds returns all registered task kinds in sorted order.\n")
+	fmt.Fprintf(&buf, "func RegisteredTaskKinds() []string {\n")
+	fmt.Fprintf(&buf, "\tkinds := make([]string, 0, len(taskConfigRegistry))\n")
+	fmt.Fprintf(&buf, "\tfor kind := range taskConfigRegistry {\n")
+	fmt.Fprintf(&buf, "\t\tkinds = append(kinds, kind)\n")
+	fmt.Fprintf(&buf, "\t}\n")
+	fmt.Fprintf(&buf, "\tsort.Strings(kinds)\n")
+	fmt.Fprintf(&buf, "\treturn kinds\n")
+	fmt.Fprintf(&buf, "}\n\n")
+
+	// Proto config messages
+	fmt.Fprintf(&buf, "// taskConfigMessages maps task kinds to the full name of their proto config\n")
+	fmt.Fprintf(&buf, "// message, which the buf.validate rules are declared on.\n")
+	fmt.Fprintf(&buf, "var taskConfigMessages = map[string]protoreflect.FullName{\n")
+	for _, kind := range kinds {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", kind, configsByKind[kind].ProtoType)
+	}
+	fmt.Fprintf(&buf, "}\n\n")
+
+	fmt.Fprintf(&buf, "// NewTaskConfigMessage returns an empty proto config message for a task kind,\n")
+	fmt.Fprintf(&buf, "// such as tasks.HttpCallTaskConfig for \"HTTP_CALL\".\n")
+	fmt.Fprintf(&buf, "func NewTaskConfigMessage(kind string) (proto.Message, error) {\n")
+	fmt.Fprintf(&buf, "\tname, ok := taskConfigMessages[kind]\n")
+	fmt.Fprintf(&buf, "\tif !ok {\n")
+	fmt.Fprintf(&buf, "\t\treturn nil, fmt.Errorf(\"unsupported task kind: %%s\", kind)\n")
+	fmt.Fprintf(&buf, "\t}\n")
+	fmt.Fprintf(&buf, "\tmessageType, err := protoregistry.GlobalTypes.FindMessageByName(name)\n")
+	fmt.Fprintf(&buf, "\tif err != nil {\n")
+	fmt.Fprintf(&buf, "\t\treturn nil, fmt.Errorf(\"config message of task kind %%s: %%w\", kind, err)\n")
+	fmt.Fprintf(&buf, "\t}\n")
+	fmt.Fprintf(&buf, "\treturn messageType.New().Interface(), nil\n")
+	fmt.Fprintf(&buf, "}\n\n")
+
+	// Registrations
+	fmt.Fprintf(&buf, "func init() {\n")
+	for _, kind := range kinds {
+		fmt.Fprintf(&buf, "\tRegisterTaskConfig(%q, func() TaskConfig { return &%s{} })\n", kind, configsByKind[kind].Name)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	fmt.Printf("  Generating kind_registry.go...\n")
+	return g.writeFormattedFile("kind_registry.go", buf.Bytes())
+}
+
+// stubImportPath returns the import path of the Go stubs generated for a
+// proto file, such as
+// "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks"
+// for "apis/ai/stigmer/agentic/workflow/v1/tasks/try.proto".
+func stubImportPath(protoFile string) string {
+	dir, ok := strings.CutPrefix(filepath.ToSlash(filepath.Dir(protoFile)), "apis/")
+	if !ok {
+		return ""
+	}
+	return stubsImportPrefix + dir
+}
+
 // generateResourceArgsFile generates Args struct for an SDK resource spec (Pulumi pattern)
 func (g *Generator) generateResourceArgsFile(resourceSpec *TaskConfigSchema) error {
 	// Collect shared type names
@@ -677,9 +1091,7 @@ func (g *Generator) generateResourceArgsFile(resourceSpec *TaskConfigSchema) err
 	var finalBuf bytes.Buffer
 	baseName := strings.ToLower(strings.ReplaceAll(resourceSpec.Name, "Spec", "spec"))
 	filename := fmt.Sprintf("%s_args.go", toSnakeCase(baseName))
-	finalBuf.WriteString(fmt.Sprintf("// Code generated by stigmer-codegen. DO NOT EDIT.\n"))
-	finalBuf.WriteString(fmt.Sprintf("// Source: %s\n", filename))
-	finalBuf.WriteString(fmt.Sprintf("// Generated: %s\n\n", time.Now().Format(time.RFC3339)))
+	g.writeFileHeader(&finalBuf, filename)
 	finalBuf.WriteString(fmt.Sprintf("package %s\n\n", packageName))
 
 	// Add imports if any were used
@@ -735,6 +1147,7 @@ type genContext struct {
 	imports     map[string]struct{}
 	generated   map[string]struct{}
 	sharedTypes map[string]struct{} // Set of shared type names (from types package)
+	oneofTypes  map[string]struct{} // Set of shared type names that declare oneof groups
 }
 
 // newGenContext creates a new generation context
@@ -744,6 +1157,7 @@ func newGenContext(packageName string) *genContext {
 		imports:     make(map[string]struct{}),
 		generated:   make(map[string]struct{}),
 		sharedTypes: make(map[string]struct{}),
+		oneofTypes:  make(map[string]struct{}),
 	}
 }
 
@@ -798,18 +1212,20 @@ func (c *genContext) genConfigStruct(w *bytes.Buffer, config *TaskConfigSchema)
 		if field.Description != "" {
 			c.writeFieldComment(w, field.Description)
 		}
+		c.writeDurationDeprecation(w, field)
 
 		// Field declaration
 		goType := c.goType(field.Type)
 
-		// Use interface{} for expression fields (smart type conversion)
-		if field.IsExpression && field.Type.Kind == "string" {
-			goType = "interface{}"
-		}
-
 		jsonTag := fmt.Sprintf("`json:\"%s,omitempty\"`", field.JsonName)
 		fmt.Fprintf(w, "\t%s %s %s\n", field.Name, goType, jsonTag)
+
+		// Expression fields get a typed reference companion
+		if isStringExpression(field) {
+			c.writeExpressionRefField(w, field)
+		}
 	}
+	c.writeUnknownFieldsField(w)
 
 	fmt.Fprintf(w, "}\n\n")
 
@@ -838,23 +1254,36 @@ func (c *genContext) genTypeStruct(w *bytes.Buffer, typeSchema *TypeSchema) erro
 		if field.Description != "" {
 			c.writeFieldComment(w, field.Description)
 		}
+		c.writeDurationDeprecation(w, field)
 
 		// Field declaration
 		goType := c.goType(field.Type)
 
-		// Use interface{} for expression fields (smart type conversion)
-		if field.IsExpression && field.Type.Kind == "string" {
-			goType = "interface{}"
-		}
-
 		jsonTag := fmt.Sprintf("`json:\"%s,omitempty\"`", field.JsonName)
 		fmt.Fprintf(w, "\t%s %s %s\n", field.Name, goType, jsonTag)
+
+		// Expression fields get a typed reference companion
+		if isStringExpression(field) {
+			c.writeExpressionRefField(w, field)
+		}
 	}
+	c.writeUnknownFieldsField(w)
 
 	fmt.Fprintf(w, "}\n\n")
 	return nil
 }
 
+// writeUnknownFieldsField writes the field keeping the proto fields FromProto
+// did not recognize. Without it, reading a manifest written by a newer SDK and
+// writing it back (diff/apply) would silently drop the fields added since.
+func (c *genContext) writeUnknownFieldsField(w *bytes.Buffer) {
+	c.addImport("google.golang.org/protobuf/types/known/structpb")
+
+	fmt.Fprintf(w, "\t// unknownFields holds the fields FromProto did not recognize, such as\n")
+	fmt.Fprintf(w, "\t// fields added by a newer SDK, so they are written back unchanged.\n")
+	fmt.Fprintf(w, "\tunknownFields map[string]*structpb.Value\n")
+}
+
 // genArgsStruct generates an Args struct for SDK resources (Pulumi pattern)
 // Example: AgentSpec -> AgentArgs
 func (c *genContext) genArgsStruct(w *bytes.Buffer, config *TaskConfigSchema) error {
@@ -955,13 +1384,47 @@ func (c *genContext) genToProtoMethod(w *bytes.Buffer, config *TaskConfigSchema)
 
 	fmt.Fprintf(w, "// ToProto converts %s to google.protobuf.Struct for proto marshaling.\n", config.Name)
 	fmt.Fprintf(w, "func (c *%s) ToProto() (*structpb.Struct, error) {\n", config.Name)
+
+	// Reject mutually exclusive options before building the struct
+	if len(config.Oneofs) > 0 || hasStringExpressions(config.Fields) {
+		fmt.Fprintf(w, "\tif err := c.ValidateOneofs(); err != nil {\n")
+		fmt.Fprintf(w, "\t\treturn nil, err\n")
+		fmt.Fprintf(w, "\t}\n\n")
+	}
+	for _, field := range config.Fields {
+		if field.Type.Kind != "message" {
+			continue
+		}
+		if _, ok := c.oneofTypes[field.Type.MessageType]; !ok {
+			continue
+		}
+		fmt.Fprintf(w, "\tif c.%s != nil {\n", field.Name)
+		fmt.Fprintf(w, "\t\tif err := c.%s.ValidateOneofs(); err != nil {\n", field.Name)
+		fmt.Fprintf(w, "\t\t\treturn nil, err\n")
+		fmt.Fprintf(w, "\t\t}\n")
+		fmt.Fprintf(w, "\t}\n\n")
+	}
+	for _, field := range config.Fields {
+		if field.Type.Kind != "array" || field.Type.ElementType == nil || field.Type.ElementType.Kind != "message" {
+			continue
+		}
+		if _, ok := c.oneofTypes[field.Type.ElementType.MessageType]; !ok {
+			continue
+		}
+		fmt.Fprintf(w, "\tfor _, item := range c.%s {\n", field.Name)
+		fmt.Fprintf(w, "\t\tif item == nil {\n")
+		fmt.Fprintf(w, "\t\t\tcontinue\n")
+		fmt.Fprintf(w, "\t\t}\n")
+		fmt.Fprintf(w, "\t\tif err := item.ValidateOneofs(); err != nil {\n")
+		fmt.Fprintf(w, "\t\t\treturn nil, err\n")
+		fmt.Fprintf(w, "\t\t}\n")
+		fmt.Fprintf(w, "\t}\n\n")
+	}
+
 	fmt.Fprintf(w, "\tdata := make(map[string]interface{})\n\n")
 
 	// Marshal each field
 	for _, field := range config.Fields {
-		// Determine if we need smart conversion for expression fields
-		needsConversion := field.IsExpression && field.Type.Kind == "string"
-
 		// Special handling for array of message types (e.g., []*types.WorkflowTask)
 		if field.Type.Kind == "array" && field.Type.ElementType != nil && field.Type.ElementType.Kind == "message" {
 			c.addImport("encoding/json")
@@ -976,6 +1439,7 @@ func (c *genContext) genToProtoMethod(w *bytes.Buffer, config *TaskConfigSchema)
 				fmt.Fprintf(w, "\t\tif err := json.Unmarshal(jsonBytes, &%sArray); err != nil {\n", field.Name)
 				fmt.Fprintf(w, "\t\t\treturn nil, err\n")
 				fmt.Fprintf(w, "\t\t}\n")
+				c.generateArrayElementFieldConversion(w, field, field.Name+"Array")
 				fmt.Fprintf(w, "\t\tdata[\"%s\"] = %sArray\n", field.JsonName, field.Name)
 				fmt.Fprintf(w, "\t}\n")
 			} else {
@@ -989,12 +1453,32 @@ func (c *genContext) genToProtoMethod(w *bytes.Buffer, config *TaskConfigSchema)
 				fmt.Fprintf(w, "\t\tif err := json.Unmarshal(jsonBytes, &%sArray); err != nil {\n", field.Name)
 				fmt.Fprintf(w, "\t\t\treturn nil, err\n")
 				fmt.Fprintf(w, "\t\t}\n")
+				c.generateArrayElementFieldConversion(w, field, field.Name+"Array")
 				fmt.Fprintf(w, "\t\tdata[\"%s\"] = %sArray\n", field.JsonName, field.Name)
 				fmt.Fprintf(w, "\t}\n")
 			}
 			continue
 		}
 
+		// Arrays of scalars are copied to []interface{}, the only list type
+		// structpb accepts
+		if field.Type.Kind == "array" && field.Type.ElementType != nil && field.Type.ElementType.Kind != "map" {
+			indent := "\t"
+			if !field.Required {
+				fmt.Fprintf(w, "\tif !isEmpty(c.%s) {\n", field.Name)
+				indent = "\t\t"
+			}
+			fmt.Fprintf(w, "%s%sList := make([]interface{}, 0, len(c.%s))\n", indent, field.Name, field.Name)
+			fmt.Fprintf(w, "%sfor _, v := range c.%s {\n", indent, field.Name)
+			fmt.Fprintf(w, "%s\t%sList = append(%sList, v)\n", indent, field.Name, field.Name)
+			fmt.Fprintf(w, "%s}\n", indent)
+			fmt.Fprintf(w, "%sdata[\"%s\"] = %sList\n", indent, field.JsonName, field.Name)
+			if !field.Required {
+				fmt.Fprintf(w, "\t}\n")
+			}
+			continue
+		}
+
 		// Special handling for message types (e.g., *types.HttpEndpoint)
 		if field.Type.Kind == "message" {
 			c.addImport("encoding/json")
@@ -1032,41 +1516,326 @@ func (c *genContext) genToProtoMethod(w *bytes.Buffer, config *TaskConfigSchema)
 			continue
 		}
 
-		valueExpr := "c." + field.Name
-		if needsConversion {
-			valueExpr = "coerceToString(c." + field.Name + ")"
+		// Expression fields resolve their Ref companion (ValidateOneofs
+		// rejected setting both)
+		if isStringExpression(field) {
+			if field.Required {
+				fmt.Fprintf(w, "\tdata[\"%s\"] = c.%s()\n", field.JsonName, expressionMethodName(field))
+			} else {
+				fmt.Fprintf(w, "\tif %s := c.%s(); %s != \"\" {\n", field.JsonName, expressionMethodName(field), field.JsonName)
+				fmt.Fprintf(w, "\t\tdata[\"%s\"] = %s\n", field.JsonName, field.JsonName)
+				fmt.Fprintf(w, "\t}\n")
+			}
+			continue
 		}
 
 		if field.Required {
-			fmt.Fprintf(w, "\tdata[\"%s\"] = %s\n", field.JsonName, valueExpr)
+			fmt.Fprintf(w, "\tdata[\"%s\"] = c.%s\n", field.JsonName, field.Name)
 		} else {
 			// Optional field - only include if not zero value
 			fmt.Fprintf(w, "\tif !isEmpty(c.%s) {\n", field.Name)
-			if needsConversion {
-				fmt.Fprintf(w, "\t\t// Smart conversion: accepts string or TaskFieldRef\n")
-			}
-			fmt.Fprintf(w, "\t\tdata[\"%s\"] = %s\n", field.JsonName, valueExpr)
+			fmt.Fprintf(w, "\t\tdata[\"%s\"] = c.%s\n", field.JsonName, field.Name)
 			fmt.Fprintf(w, "\t}\n")
 		}
 	}
 
-	fmt.Fprintf(w, "\n\treturn structpb.NewStruct(data)\n")
+	c.addImport("google.golang.org/protobuf/proto")
+	fmt.Fprintf(w, "\n\ts, err := structpb.NewStruct(data)\n")
+	fmt.Fprintf(w, "\tif err != nil {\n")
+	fmt.Fprintf(w, "\t\treturn nil, err\n")
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "\tfor key, val := range c.unknownFields {\n")
+	fmt.Fprintf(w, "\t\ts.Fields[key] = proto.Clone(val).(*structpb.Value)\n")
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "\treturn s, nil\n")
 	fmt.Fprintf(w, "}\n\n")
 
 	return nil
 }
 
-// generateMessageFieldConversion generates code to apply smart conversion to expression fields within a message
+// genValidateOneofsMethod generates a ValidateOneofs() method rejecting values
+// that set more than one member of a oneof group. Proto flattens oneof members
+// into independent Go fields, so without this check both could be set and the
+// server would only reject the manifest later.
+func (c *genContext) genValidateOneofsMethod(w *bytes.Buffer, typeName string, fields []*FieldSchema, oneofs []*OneofSchema) error {
+	if len(oneofs) == 0 {
+		return nil
+	}
+
+	byName := make(map[string]*FieldSchema, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+
+	c.addImport("fmt")
+	c.addImport("strings")
+	c.addImport("github.com/stigmer/stigmer/sdk/go/internal/validation")
+
+	fmt.Fprintf(w, "// ValidateOneofs checks that at most one member of each oneof group is set.\n")
+	fmt.Fprintf(w, "func (c *%s) ValidateOneofs() error {\n", typeName)
+
+	for _, oneof := range oneofs {
+		members := make([]*FieldSchema, 0, len(oneof.Fields))
+		memberNames := make([]string, 0, len(oneof.Fields))
+		for _, name := range oneof.Fields {
+			field, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("oneof %s of %s references unknown field %s", oneof.Name, typeName, name)
+			}
+			members = append(members, field)
+			memberNames = append(memberNames, field.JsonName)
+		}
+
+		fmt.Fprintf(w, "\t// oneof %s: %s\n", oneof.ProtoOneof, strings.Join(memberNames, ", "))
+		fmt.Fprintf(w, "\t{\n")
+		fmt.Fprintf(w, "\t\tvar set []string\n")
+		for _, field := range members {
+			fmt.Fprintf(w, "\t\tif %s {\n", c.isSetExpr(field))
+			fmt.Fprintf(w, "\t\t\tset = append(set, %q)\n", field.JsonName)
+			fmt.Fprintf(w, "\t\t}\n")
+		}
+		fmt.Fprintf(w, "\t\tif len(set) > 1 {\n")
+		fmt.Fprintf(w, "\t\t\treturn validation.NewValidationErrorWithCause(\n")
+		fmt.Fprintf(w, "\t\t\t\t%q,\n", oneof.ProtoOneof)
+		fmt.Fprintf(w, "\t\t\t\tstrings.Join(set, \", \"),\n")
+		fmt.Fprintf(w, "\t\t\t\t\"oneof\",\n")
+		fmt.Fprintf(w, "\t\t\t\tfmt.Sprintf(\"only one of %%s may be set, got %%s\", %q, strings.Join(set, \" and \")),\n", strings.Join(memberNames, ", "))
+		fmt.Fprintf(w, "\t\t\t\tvalidation.ErrMutuallyExclusive,\n")
+		fmt.Fprintf(w, "\t\t\t)\n")
+		fmt.Fprintf(w, "\t\t}\n")
+		fmt.Fprintf(w, "\t}\n\n")
+	}
+
+	fmt.Fprintf(w, "\treturn nil\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	return nil
+}
+
+// genDurationMethods generates time.Duration accessors for integer fields
+// with the "duration" semantic hint, which hold a number of seconds. The raw
+// field stays for proto compatibility; the setter rejects durations that do
+// not fit it (negative, sub-second or overflowing) rather than silently
+// truncating them.
+//
+// Example: TimeoutSeconds int32 -> TimeoutDuration() and SetTimeoutDuration(d).
+func (c *genContext) genDurationMethods(w *bytes.Buffer, typeName string, fields []*FieldSchema) error {
+	for _, field := range fields {
+		if field.Semantic != "duration" {
+			continue
+		}
+		if field.Type.Kind != "int32" && field.Type.Kind != "int64" {
+			return fmt.Errorf("field %s of %s has duration semantic but type %s", field.Name, typeName, field.Type.Kind)
+		}
+
+		c.addImport("time")
+		c.addImport("github.com/stigmer/stigmer/sdk/go/internal/validation")
+
+		method := durationMethodName(field.Name)
+		goType := c.goType(field.Type)
+
+		fmt.Fprintf(w, "// %s returns %s as a time.Duration.\n", method, field.Name)
+		fmt.Fprintf(w, "func (c *%s) %s() time.Duration {\n", typeName, method)
+		fmt.Fprintf(w, "\treturn time.Duration(c.%s) * time.Second\n", field.Name)
+		fmt.Fprintf(w, "}\n\n")
+
+		outOfRange := "d < 0 || d%time.Second != 0"
+		if field.Type.Kind == "int32" {
+			c.addImport("math")
+			outOfRange += " || d/time.Second > math.MaxInt32"
+		}
+
+		fmt.Fprintf(w, "// Set%s sets %s from d, which must be a non-negative\n", method, field.Name)
+		fmt.Fprintf(w, "// whole number of seconds.\n")
+		fmt.Fprintf(w, "func (c *%s) Set%s(d time.Duration) error {\n", typeName, method)
+		fmt.Fprintf(w, "\tif %s {\n", outOfRange)
+		fmt.Fprintf(w, "\t\treturn validation.NewValidationErrorWithCause(\n")
+		fmt.Fprintf(w, "\t\t\t%q,\n", field.JsonName)
+		fmt.Fprintf(w, "\t\t\td.String(),\n")
+		fmt.Fprintf(w, "\t\t\t\"duration\",\n")
+		fmt.Fprintf(w, "\t\t\t\"must be a non-negative whole number of seconds\",\n")
+		fmt.Fprintf(w, "\t\t\tvalidation.ErrInvalidDuration,\n")
+		fmt.Fprintf(w, "\t\t)\n")
+		fmt.Fprintf(w, "\t}\n")
+		fmt.Fprintf(w, "\tc.%s = %s(d / time.Second)\n", field.Name, goType)
+		fmt.Fprintf(w, "\treturn nil\n")
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	return nil
+}
+
+// durationMethodName names the duration accessor of a whole-seconds field.
+// Example: "TimeoutSeconds" -> "TimeoutDuration", "Seconds" -> "Duration".
+func durationMethodName(fieldName string) string {
+	return strings.TrimSuffix(fieldName, "Seconds") + "Duration"
+}
+
+// writeDurationDeprecation marks a whole-seconds field deprecated in favor of
+// its time.Duration setter, whose unit cannot be mistaken at call sites.
+func (c *genContext) writeDurationDeprecation(w *bytes.Buffer, field *FieldSchema) {
+	if field.Semantic != "duration" {
+		return
+	}
+	if field.Description != "" {
+		fmt.Fprintf(w, "\t//\n")
+	}
+	fmt.Fprintf(w, "\t// Deprecated: Use Set%s, which takes a time.Duration.\n", durationMethodName(field.Name))
+}
+
+// isSetExpr returns a Go expression reporting whether a field holds a non-zero value.
+// It avoids the isEmpty helper, which is not generated into the shared types package.
+func (c *genContext) isSetExpr(field *FieldSchema) string {
+	ref := "c." + field.Name
+	switch field.Type.Kind {
+	case "string":
+		return ref + ` != ""`
+	case "bool":
+		return ref
+	case "int32", "int64", "float", "double":
+		return ref + " != 0"
+	case "map", "struct", "array", "bytes":
+		return "len(" + ref + ") > 0"
+	default:
+		return ref + " != nil"
+	}
+}
+
+// generateMessageFieldConversion generates code converting expression fields within a message.
+// JSON marshaling skips the Ref companions and cannot encode references held by the plain
+// fields, so the conversion reads the original message.
 func (c *genContext) generateMessageFieldConversion(w *bytes.Buffer, field *FieldSchema, mapVarName string) {
 	// Check if this is HttpEndpoint which has Uri as an expression field
 	if field.Type.MessageType == "HttpEndpoint" {
-		fmt.Fprintf(w, "\t\tif uri, ok := %s[\"uri\"]; ok {\n", mapVarName)
-		fmt.Fprintf(w, "\t\t\t%s[\"uri\"] = coerceToString(uri)\n", mapVarName)
+		fmt.Fprintf(w, "\t\tif uri := c.%s.UriExpression(); uri != \"\" {\n", field.Name)
+		fmt.Fprintf(w, "\t\t\t%s[\"uri\"] = uri\n", mapVarName)
 		fmt.Fprintf(w, "\t\t}\n")
 	}
 	// Add more message types here as needed
 }
 
+// generateArrayElementFieldConversion generates code converting expression fields within each
+// element of an array of messages. JSON marshaling skips the Ref companions and drops the
+// unexported fields of references, so the conversion reads the original elements.
+func (c *genContext) generateArrayElementFieldConversion(w *bytes.Buffer, field *FieldSchema, arrayVarName string) {
+	// Check if this is AgentAttachment which has Content as an expression field
+	if field.Type.ElementType.MessageType == "AgentAttachment" {
+		fmt.Fprintf(w, "\t\t// Convert expression fields within each element\n")
+		fmt.Fprintf(w, "\t\tfor i, item := range c.%s {\n", field.Name)
+		fmt.Fprintf(w, "\t\t\tif item == nil || (item.Content == \"\" && item.ContentRef == nil) {\n")
+		fmt.Fprintf(w, "\t\t\t\tcontinue\n")
+		fmt.Fprintf(w, "\t\t\t}\n")
+		fmt.Fprintf(w, "\t\t\tif m, ok := %s[i].(map[string]interface{}); ok {\n", arrayVarName)
+		fmt.Fprintf(w, "\t\t\t\tm[\"content\"] = item.ContentExpression()\n")
+		fmt.Fprintf(w, "\t\t\t}\n")
+		fmt.Fprintf(w, "\t\t}\n")
+	}
+	// Add more message types here as needed
+}
+
+// isStringExpression reports whether field is a string field that accepts
+// runtime expressions. Such fields stay strings, holding a literal or a raw
+// "${ }" expression, and get a typed Ref companion for references.
+func isStringExpression(field *FieldSchema) bool {
+	return field.IsExpression && field.Type.Kind == "string"
+}
+
+// hasStringExpressions reports whether any of fields is an expression field.
+func hasStringExpressions(fields []*FieldSchema) bool {
+	for _, field := range fields {
+		if isStringExpression(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// expressionRefName names the typed reference companion of an expression field.
+// Example: "Message" -> "MessageRef".
+func expressionRefName(field *FieldSchema) string {
+	return field.Name + "Ref"
+}
+
+// expressionMethodName names the accessor resolving an expression field.
+// Example: "Message" -> "MessageExpression".
+func expressionMethodName(field *FieldSchema) string {
+	return field.Name + "Expression"
+}
+
+// writeExpressionRefField writes the Ref companion of an expression field.
+// The plain field stays for literals, raw expressions and existing callers;
+// the companion only accepts StringExpr values, so passing an int or bool
+// reference fails to compile instead of producing a junk string.
+func (c *genContext) writeExpressionRefField(w *bytes.Buffer, field *FieldSchema) {
+	exprType := c.sharedName(stringExprType)
+	fmt.Fprintf(w, "\t// %s sets %s from a typed string reference, such as a\n", expressionRefName(field), field.Name)
+	fmt.Fprintf(w, "\t// StringRef or a task's Field(). Only one of %s and %s may be set.\n", field.Name, expressionRefName(field))
+	fmt.Fprintf(w, "\t%s %s `json:\"-\"`\n", expressionRefName(field), exprType)
+}
+
+// sharedName qualifies a name declared by generateSharedHelpers for the
+// package being generated, importing the shared types package if needed.
+func (c *genContext) sharedName(name string) string {
+	if c.packageName == "types" {
+		return name
+	}
+	c.addImport("github.com/stigmer/stigmer/sdk/go/gen/types")
+	return "types." + name
+}
+
+// withExpressionRefOneofs adds each expression field's Ref companion to
+// fields, paired with the field in a oneof group, so ValidateOneofs rejects
+// setting both.
+func withExpressionRefOneofs(fields []*FieldSchema, oneofs []*OneofSchema) ([]*FieldSchema, []*OneofSchema) {
+	if !hasStringExpressions(fields) {
+		return fields, oneofs
+	}
+
+	allFields := append([]*FieldSchema(nil), fields...)
+	allOneofs := append([]*OneofSchema(nil), oneofs...)
+	for _, field := range fields {
+		if !isStringExpression(field) {
+			continue
+		}
+		ref := &FieldSchema{
+			Name:     expressionRefName(field),
+			JsonName: field.JsonName + "Ref",
+			Type:     TypeSpec{Kind: "message", MessageType: stringExprType},
+		}
+		allFields = append(allFields, ref)
+		allOneofs = append(allOneofs, &OneofSchema{
+			Name:       field.Name,
+			ProtoOneof: field.JsonName,
+			Fields:     []string{field.Name, ref.Name},
+		})
+	}
+	return allFields, allOneofs
+}
+
+// genExpressionMethods generates an accessor for each expression field,
+// returning the expression of its Ref companion when set and the converted
+// plain field otherwise.
+//
+// Example: Message + MessageRef -> MessageExpression().
+func (c *genContext) genExpressionMethods(w *bytes.Buffer, typeName string, fields []*FieldSchema) {
+	for _, field := range fields {
+		if !isStringExpression(field) {
+			continue
+		}
+		method := expressionMethodName(field)
+		ref := expressionRefName(field)
+
+		fmt.Fprintf(w, "// %s returns the expression of %s when set, otherwise the literal\n", method, ref)
+		fmt.Fprintf(w, "// or raw expression held by %s.\n", field.Name)
+		fmt.Fprintf(w, "func (c *%s) %s() string {\n", typeName, method)
+		fmt.Fprintf(w, "\tif c.%s != nil {\n", ref)
+		fmt.Fprintf(w, "\t\treturn c.%s.StringExpression()\n", ref)
+		fmt.Fprintf(w, "\t}\n")
+		fmt.Fprintf(w, "\treturn c.%s\n", field.Name)
+		fmt.Fprintf(w, "}\n\n")
+	}
+}
+
 // genTypeFromProtoMethod generates FromProto() method for a shared type
 func (c *genContext) genTypeFromProtoMethod(w *bytes.Buffer, typeSchema *TypeSchema) error {
 	c.addImport("google.golang.org/protobuf/types/known/structpb")
@@ -1079,6 +1848,7 @@ func (c *genContext) genTypeFromProtoMethod(w *bytes.Buffer, typeSchema *TypeSch
 	for _, field := range typeSchema.Fields {
 		c.genFromProtoField(w, field)
 	}
+	c.genUnknownFieldsCapture(w, typeSchema.Fields)
 
 	fmt.Fprintf(w, "\treturn nil\n")
 	fmt.Fprintf(w, "}\n\n")
@@ -1098,6 +1868,7 @@ func (c *genContext) genFromProtoMethod(w *bytes.Buffer, config *TaskConfigSchem
 	for _, field := range config.Fields {
 		c.genFromProtoField(w, field)
 	}
+	c.genUnknownFieldsCapture(w, config.Fields)
 
 	fmt.Fprintf(w, "\treturn nil\n")
 	fmt.Fprintf(w, "}\n\n")
@@ -1105,6 +1876,80 @@ func (c *genContext) genFromProtoMethod(w *bytes.Buffer, config *TaskConfigSchem
 	return nil
 }
 
+// genUnknownFieldsCapture generates the FromProto code keeping the fields
+// that are not in the schema, for ToProto (or MarshalJSON) to write back.
+func (c *genContext) genUnknownFieldsCapture(w *bytes.Buffer, fields []*FieldSchema) {
+	c.addImport("google.golang.org/protobuf/proto")
+
+	known := make([]string, 0, len(fields))
+	for _, field := range fields {
+		known = append(known, strconv.Quote(field.JsonName))
+	}
+
+	fmt.Fprintf(w, "\tc.unknownFields = nil\n")
+	fmt.Fprintf(w, "\tfor key, val := range fields {\n")
+	if len(known) > 0 {
+		fmt.Fprintf(w, "\t\tswitch key {\n")
+		fmt.Fprintf(w, "\t\tcase %s:\n", strings.Join(known, ", "))
+		fmt.Fprintf(w, "\t\t\tcontinue\n")
+		fmt.Fprintf(w, "\t\t}\n")
+	}
+	fmt.Fprintf(w, "\t\tif c.unknownFields == nil {\n")
+	fmt.Fprintf(w, "\t\t\tc.unknownFields = make(map[string]*structpb.Value)\n")
+	fmt.Fprintf(w, "\t\t}\n")
+	fmt.Fprintf(w, "\t\tc.unknownFields[key] = proto.Clone(val).(*structpb.Value)\n")
+	fmt.Fprintf(w, "\t}\n\n")
+}
+
+// genTypeMarshalJSONMethod generates a MarshalJSON() method for a shared type
+// that adds back the fields FromProto did not recognize. Task configs convert
+// nested messages to proto through JSON, so this is what keeps unknown fields
+// of nested messages. The receiver is a value so that messages held by value,
+// not only through a pointer, keep them too.
+func (c *genContext) genTypeMarshalJSONMethod(w *bytes.Buffer, typeSchema *TypeSchema) {
+	c.addImport("encoding/json")
+
+	fmt.Fprintf(w, "// MarshalJSON encodes %s, including the fields FromProto did not recognize.\n", typeSchema.Name)
+	fmt.Fprintf(w, "func (c %s) MarshalJSON() ([]byte, error) {\n", typeSchema.Name)
+	fmt.Fprintf(w, "\ttype plain %s\n", typeSchema.Name)
+	fmt.Fprintf(w, "\tdata, err := json.Marshal(plain(c))\n")
+	fmt.Fprintf(w, "\tif err != nil || len(c.unknownFields) == 0 {\n")
+	fmt.Fprintf(w, "\t\treturn data, err\n")
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "\tvar m map[string]interface{}\n")
+	fmt.Fprintf(w, "\tif err := json.Unmarshal(data, &m); err != nil {\n")
+	fmt.Fprintf(w, "\t\treturn nil, err\n")
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "\tfor key, val := range c.unknownFields {\n")
+	fmt.Fprintf(w, "\t\tm[key] = val.AsInterface()\n")
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "\treturn json.Marshal(m)\n")
+	fmt.Fprintf(w, "}\n\n")
+}
+
+// genStringMethod generates a String() method printing a redacted one-line summary.
+// Expression fields are omitted since they may interpolate secrets at runtime.
+func (c *genContext) genStringMethod(w *bytes.Buffer, config *TaskConfigSchema) error {
+	kind := config.Kind
+	if kind == "" {
+		kind = config.Name
+	}
+
+	fmt.Fprintf(w, "// String returns a redacted one-line summary of %s.\n", config.Name)
+	fmt.Fprintf(w, "func (c *%s) String() string {\n", config.Name)
+	fmt.Fprintf(w, "\treturn summarizeConfig(%q,\n", kind)
+	for _, field := range config.Fields {
+		if field.IsExpression {
+			continue
+		}
+		fmt.Fprintf(w, "\t\tsummaryField(%q, c.%s),\n", field.JsonName, field.Name)
+	}
+	fmt.Fprintf(w, "\t)\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	return nil
+}
+
 // genFromProtoField generates FromProto conversion code for a single field
 func (c *genContext) genFromProtoField(w *bytes.Buffer, field *FieldSchema) {
 	fmt.Fprintf(w, "\tif val, ok := fields[\"%s\"]; ok {\n", field.JsonName)
@@ -1300,27 +2145,6 @@ func (c *genContext) pluralize(singular string) string {
 	return singular + "s"
 }
 
-// needsCoercion determines if a value type needs coerceToString() conversion.
-// Returns true for string types (which support expressions), false for structured types.
-func (c *genContext) needsCoercion(typeSpec *TypeSpec) bool {
-	if typeSpec == nil {
-		return false
-	}
-
-	switch typeSpec.Kind {
-	case "string":
-		return true
-	case "map":
-		// For maps, check if value type is string
-		if typeSpec.ValueType != nil && typeSpec.ValueType.Kind == "string" {
-			return true
-		}
-		return false
-	default:
-		return false
-	}
-}
-
 // ============================================================================
 // Type Conversion
 // ============================================================================
@@ -1365,6 +2189,11 @@ func (c *genContext) goType(typeSpec TypeSpec) string {
 			}
 			return "*types." + typeSpec.MessageType
 		}
+		// Well-known protobuf types use their Go packages
+		if wellKnown, ok := wellKnownMessageTypes[typeSpec.MessageType]; ok {
+			c.addImport(wellKnown.importPath)
+			return "*" + wellKnown.goType
+		}
 		// Pointer for proto compatibility
 		return "*" + typeSpec.MessageType
 
@@ -1377,6 +2206,15 @@ func (c *genContext) goType(typeSpec TypeSpec) string {
 	}
 }
 
+// wellKnownMessageTypes maps google.protobuf message types, which have no
+// schema of their own, to their Go types.
+var wellKnownMessageTypes = map[string]struct {
+	importPath string
+	goType     string
+}{
+	"Timestamp": {"google.golang.org/protobuf/types/known/timestamppb", "timestamppb.Timestamp"},
+}
+
 // paramName converts a field name to a parameter name (lowercase first letter)
 func (c *genContext) paramName(fieldName string) string {
 	if fieldName == "" {
@@ -1447,11 +2285,35 @@ func toSnakeCase(s string) string {
 // Main
 // ============================================================================
 
+// namespaceDirsFlag collects --namespace-dir namespace=dir values.
+type namespaceDirsFlag map[string]string
+
+func (f namespaceDirsFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for namespace, dir := range f {
+		pairs = append(pairs, namespace+"="+dir)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (f namespaceDirsFlag) Set(value string) error {
+	namespace, dir, ok := strings.Cut(value, "=")
+	if !ok || namespace == "" || dir == "" {
+		return fmt.Errorf("expected namespace=dir, got %q", value)
+	}
+	f[namespace] = dir
+	return nil
+}
+
 func main() {
 	schemaDir := flag.String("schema-dir", "tools/codegen/schemas", "Directory containing JSON schemas")
 	outputDir := flag.String("output-dir", "sdk/go/workflow/gen", "Output directory for generated Go code")
 	packageName := flag.String("package", "gen", "Go package name for generated code")
 	fileSuffix := flag.String("file-suffix", "", "Suffix for generated files (e.g., '_task', '_spec', or empty)")
+	stamp := flag.Bool("stamp", false, "Record the generation time in file headers (makes output non-reproducible)")
+	namespaceDirs := namespaceDirsFlag{}
+	flag.Var(namespaceDirs, "namespace-dir", "Output directory of a proto namespace's resource args, as namespace=dir (repeatable, e.g. iam=sdk/go/iam/gen)")
 	flag.Parse()
 
 	if *schemaDir == "" || *outputDir == "" {
@@ -1464,11 +2326,12 @@ func main() {
 	fmt.Printf("Package name: %s\n", *packageName)
 
 	// Create generator
-	gen, err := NewGenerator(*schemaDir, *outputDir, *packageName, *fileSuffix)
+	gen, err := NewGenerator(*schemaDir, *outputDir, *packageName, *fileSuffix, namespaceDirs)
 	if err != nil {
 		fmt.Printf("Error creating generator: %v\n", err)
 		os.Exit(1)
 	}
+	gen.stamp = *stamp
 
 	// Generate code
 	if err := gen.Generate(); err != nil {
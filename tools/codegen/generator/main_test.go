@@ -0,0 +1,504 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// endpointSchema mirrors the EndpointTaskConfig fixture in
+// proto2schema/testdata/fixture/v1/oneof.proto.
+func endpointSchema() *TaskConfigSchema {
+	return &TaskConfigSchema{
+		Name:      "EndpointTaskConfig",
+		Kind:      "ENDPOINT",
+		ProtoType: "fixture.v1.EndpointTaskConfig",
+		Fields: []*FieldSchema{
+			{Name: "Uri", JsonName: "uri", ProtoField: "uri", Type: TypeSpec{Kind: "string"}},
+			{Name: "Service", JsonName: "service", ProtoField: "service", Type: TypeSpec{Kind: "message", MessageType: "ServiceRef"}},
+			{Name: "TimeoutSeconds", JsonName: "timeoutSeconds", ProtoField: "timeout_seconds", Type: TypeSpec{Kind: "int32"}},
+		},
+		Oneofs: []*OneofSchema{
+			{Name: "Target", ProtoOneof: "target", Fields: []string{"Uri", "Service"}},
+		},
+	}
+}
+
+func TestGenValidateOneofsMethod(t *testing.T) {
+	schema := endpointSchema()
+	ctx := newGenContextWithSharedTypes("workflow", []string{"ServiceRef"})
+	ctx.oneofTypes["ServiceRef"] = struct{}{}
+
+	var buf bytes.Buffer
+	buf.WriteString("package workflow\n\n")
+	if err := ctx.genValidateOneofsMethod(&buf, schema.Name, schema.Fields, schema.Oneofs); err != nil {
+		t.Fatalf("genValidateOneofsMethod() failed: %v", err)
+	}
+	if err := ctx.genToProtoMethod(&buf, schema); err != nil {
+		t.Fatalf("genToProtoMethod() failed: %v", err)
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, buf.String())
+	}
+	src := string(code)
+
+	for _, want := range []string{
+		"func (c *EndpointTaskConfig) ValidateOneofs() error {",
+		`if c.Uri != "" {`,
+		"if c.Service != nil {",
+		`"only one of %s may be set, got %s", "uri, service"`,
+		"validation.ErrMutuallyExclusive",
+		// ToProto rejects conflicting options before building the struct
+		"if err := c.ValidateOneofs(); err != nil {",
+		// Nested shared types with oneofs are validated too
+		"if err := c.Service.ValidateOneofs(); err != nil {",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code missing %q\n%s", want, src)
+		}
+	}
+
+	if _, ok := ctx.imports["github.com/stigmer/stigmer/sdk/go/internal/validation"]; !ok {
+		t.Error("validation package was not imported")
+	}
+}
+
+func TestGenValidateOneofsMethod_NoOneofs(t *testing.T) {
+	schema := endpointSchema()
+	schema.Oneofs = nil
+	ctx := newGenContext("workflow")
+
+	var buf bytes.Buffer
+	if err := ctx.genValidateOneofsMethod(&buf, schema.Name, schema.Fields, schema.Oneofs); err != nil {
+		t.Fatalf("genValidateOneofsMethod() failed: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no code for a schema without oneofs, got:\n%s", buf.String())
+	}
+}
+
+func TestGenValidateOneofsMethod_UnknownField(t *testing.T) {
+	schema := endpointSchema()
+	schema.Oneofs[0].Fields = append(schema.Oneofs[0].Fields, "Missing")
+	ctx := newGenContext("workflow")
+
+	var buf bytes.Buffer
+	if err := ctx.genValidateOneofsMethod(&buf, schema.Name, schema.Fields, schema.Oneofs); err == nil {
+		t.Error("expected error for oneof referencing an unknown field")
+	}
+}
+
+func TestGenExpressionRefFields(t *testing.T) {
+	schema := endpointSchema()
+	schema.Oneofs = nil
+	schema.Fields[0].IsExpression = true
+	ctx := newGenContextWithSharedTypes("workflow", []string{"ServiceRef"})
+
+	var buf bytes.Buffer
+	buf.WriteString("package workflow\n\n")
+	if err := ctx.genConfigStruct(&buf, schema); err != nil {
+		t.Fatalf("genConfigStruct() failed: %v", err)
+	}
+	fields, oneofs := withExpressionRefOneofs(schema.Fields, schema.Oneofs)
+	if err := ctx.genValidateOneofsMethod(&buf, schema.Name, fields, oneofs); err != nil {
+		t.Fatalf("genValidateOneofsMethod() failed: %v", err)
+	}
+	ctx.genExpressionMethods(&buf, schema.Name, schema.Fields)
+	if err := ctx.genToProtoMethod(&buf, schema); err != nil {
+		t.Fatalf("genToProtoMethod() failed: %v", err)
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, buf.String())
+	}
+	src := string(code)
+
+	for _, want := range []string{
+		// The plain field holds literals and raw expressions; the companion is typed
+		"\tUri string `json:\"uri,omitempty\"`",
+		"UriRef         types.StringExpr  `json:\"-\"`",
+		"if c.Uri != \"\" {",
+		"if c.UriRef != nil {",
+		`"only one of %s may be set, got %s", "uri, uriRef"`,
+		"func (c *EndpointTaskConfig) UriExpression() string {",
+		"return c.UriRef.StringExpression()",
+		"return c.Uri\n",
+		"if err := c.ValidateOneofs(); err != nil {",
+		"if uri := c.UriExpression(); uri != \"\" {",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code missing %q\n%s", want, src)
+		}
+	}
+	// Values are never coerced at runtime: wrong types fail to compile
+	for _, unwanted := range []string{"interface{} `json:\"uri", "coerceToString", "ExpressionString", `"%v"`} {
+		if strings.Contains(src, unwanted) {
+			t.Errorf("generated code contains %q\n%s", unwanted, src)
+		}
+	}
+}
+
+func TestGenUnknownFields(t *testing.T) {
+	schema := endpointSchema()
+	schema.Oneofs = nil
+	ctx := newGenContextWithSharedTypes("workflow", []string{"ServiceRef"})
+
+	var buf bytes.Buffer
+	buf.WriteString("package workflow\n\n")
+	if err := ctx.genConfigStruct(&buf, schema); err != nil {
+		t.Fatalf("genConfigStruct() failed: %v", err)
+	}
+	if err := ctx.genToProtoMethod(&buf, schema); err != nil {
+		t.Fatalf("genToProtoMethod() failed: %v", err)
+	}
+	if err := ctx.genFromProtoMethod(&buf, schema); err != nil {
+		t.Fatalf("genFromProtoMethod() failed: %v", err)
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, buf.String())
+	}
+	src := string(code)
+
+	for _, want := range []string{
+		"\tunknownFields map[string]*structpb.Value\n",
+		// FromProto keeps every key that is not a schema field
+		`case "uri", "service", "timeoutSeconds":`,
+		"c.unknownFields[key] = proto.Clone(val).(*structpb.Value)",
+		// ToProto writes them back
+		"for key, val := range c.unknownFields {\n\t\ts.Fields[key] = proto.Clone(val).(*structpb.Value)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code missing %q\n%s", want, src)
+		}
+	}
+	if _, ok := ctx.imports["google.golang.org/protobuf/proto"]; !ok {
+		t.Error("google.golang.org/protobuf/proto was not imported")
+	}
+}
+
+// TestGenTypeMarshalJSONMethod tests that shared types encode their unknown
+// fields with a value receiver, so they are kept when held by value too.
+func TestGenTypeMarshalJSONMethod(t *testing.T) {
+	ctx := newGenContextWithSharedTypes("types", nil)
+
+	var buf bytes.Buffer
+	buf.WriteString("package types\n\n")
+	ctx.genTypeMarshalJSONMethod(&buf, &TypeSchema{Name: "ServiceRef"})
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, buf.String())
+	}
+	src := string(code)
+
+	for _, want := range []string{
+		"func (c ServiceRef) MarshalJSON() ([]byte, error) {",
+		"json.Marshal(plain(c))",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code missing %q\n%s", want, src)
+		}
+	}
+}
+
+func TestGenDurationMethods(t *testing.T) {
+	schema := endpointSchema()
+	schema.Fields[2].Semantic = "duration"
+	schema.Fields[2].Description = "Request timeout in seconds."
+	ctx := newGenContextWithSharedTypes("workflow", []string{"ServiceRef"})
+
+	var buf bytes.Buffer
+	buf.WriteString("package workflow\n\n")
+	if err := ctx.genConfigStruct(&buf, schema); err != nil {
+		t.Fatalf("genConfigStruct() failed: %v", err)
+	}
+	if err := ctx.genDurationMethods(&buf, schema.Name, schema.Fields); err != nil {
+		t.Fatalf("genDurationMethods() failed: %v", err)
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, buf.String())
+	}
+	src := string(code)
+
+	for _, want := range []string{
+		// The raw field is kept but deprecated in favor of the setter
+		"\t// Request timeout in seconds.\n\t//\n\t// Deprecated: Use SetTimeoutDuration, which takes a time.Duration.\n\tTimeoutSeconds int32",
+		"func (c *EndpointTaskConfig) TimeoutDuration() time.Duration {",
+		"return time.Duration(c.TimeoutSeconds) * time.Second",
+		"func (c *EndpointTaskConfig) SetTimeoutDuration(d time.Duration) error {",
+		"if d < 0 || d%time.Second != 0 || d/time.Second > math.MaxInt32 {",
+		"validation.ErrInvalidDuration",
+		"c.TimeoutSeconds = int32(d / time.Second)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code missing %q\n%s", want, src)
+		}
+	}
+	// Fields without the hint get neither accessors nor a deprecation
+	if strings.Contains(src, "UriDuration") || strings.Count(src, "Deprecated:") != 1 {
+		t.Errorf("duration code generated for fields without the hint\n%s", src)
+	}
+
+	for _, imp := range []string{"time", "math", "github.com/stigmer/stigmer/sdk/go/internal/validation"} {
+		if _, ok := ctx.imports[imp]; !ok {
+			t.Errorf("%s was not imported", imp)
+		}
+	}
+}
+
+func TestGenDurationMethods_Int64(t *testing.T) {
+	fields := []*FieldSchema{
+		{Name: "TtlSeconds", JsonName: "ttlSeconds", Type: TypeSpec{Kind: "int64"}, Semantic: "duration"},
+	}
+	ctx := newGenContext("types")
+
+	var buf bytes.Buffer
+	if err := ctx.genDurationMethods(&buf, "HttpCache", fields); err != nil {
+		t.Fatalf("genDurationMethods() failed: %v", err)
+	}
+	src := buf.String()
+
+	if !strings.Contains(src, "if d < 0 || d%time.Second != 0 {") || strings.Contains(src, "math.MaxInt32") {
+		t.Errorf("int64 field should only reject negative and sub-second durations\n%s", src)
+	}
+	if _, ok := ctx.imports["math"]; ok {
+		t.Error("math imported for an int64 field")
+	}
+}
+
+func TestGenDurationMethods_NonIntegerField(t *testing.T) {
+	fields := []*FieldSchema{
+		{Name: "Delay", JsonName: "delay", Type: TypeSpec{Kind: "string"}, Semantic: "duration"},
+	}
+	ctx := newGenContext("workflow")
+
+	var buf bytes.Buffer
+	if err := ctx.genDurationMethods(&buf, "WaitTaskConfig", fields); err == nil {
+		t.Error("expected error for a duration hint on a string field")
+	}
+}
+
+func TestDurationMethodName(t *testing.T) {
+	tests := map[string]string{
+		"TimeoutSeconds":          "TimeoutDuration",
+		"ExecutionTimeoutSeconds": "ExecutionTimeoutDuration",
+		"Seconds":                 "Duration",
+		"Timeout":                 "TimeoutDuration",
+	}
+	for field, want := range tests {
+		if got := durationMethodName(field); got != want {
+			t.Errorf("durationMethodName(%q) = %q, want %q", field, got, want)
+		}
+	}
+}
+
+func TestStubImportPath(t *testing.T) {
+	tests := map[string]string{
+		"apis/ai/stigmer/agentic/workflow/v1/tasks/try.proto": "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/workflow/v1/tasks",
+		"apis/ai/stigmer/agentic/agent/v1/spec.proto":         "github.com/stigmer/stigmer/apis/stubs/go/ai/stigmer/agentic/agent/v1",
+		"testdata/try.proto":                                  "",
+	}
+	for protoFile, want := range tests {
+		if got := stubImportPath(protoFile); got != want {
+			t.Errorf("stubImportPath(%q) = %q, want %q", protoFile, got, want)
+		}
+	}
+}
+
+func TestCheckNameCollisions(t *testing.T) {
+	timeoutConfig := func(name, protoType string) *TaskConfigSchema {
+		return &TaskConfigSchema{
+			Name:      name,
+			ProtoType: protoType,
+			Fields: []*FieldSchema{
+				{Name: "Timeout", JsonName: "timeout", ProtoField: "timeout", Type: TypeSpec{Kind: "int32"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		g       *Generator
+		wantErr []string
+	}{
+		{
+			name: "same field name in different configs",
+			g: &Generator{
+				outputDir: "sdk/go/gen/workflow",
+				taskConfigs: []*TaskConfigSchema{
+					timeoutConfig("HttpCallTaskConfig", "fixture.v1.HttpCallTaskConfig"),
+					timeoutConfig("GrpcCallTaskConfig", "fixture.v1.GrpcCallTaskConfig"),
+				},
+			},
+		},
+		{
+			name: "duplicate schema files for one message",
+			g: &Generator{
+				outputDir: "sdk/go/gen/workflow",
+				taskConfigs: []*TaskConfigSchema{
+					timeoutConfig("AgentCallTaskConfig", "fixture.v1.AgentCallTaskConfig"),
+					timeoutConfig("AgentCallTaskConfig", "fixture.v1.AgentCallTaskConfig"),
+				},
+			},
+		},
+		{
+			name: "shared types from different messages",
+			g: &Generator{
+				outputDir: "sdk/go/gen/workflow",
+				sharedTypes: []*TypeSchema{
+					{Name: "RetryPolicy", ProtoType: "fixture.v1.tasks.RetryPolicy"},
+					{Name: "RetryPolicy", ProtoType: "fixture.v1.agent.RetryPolicy"},
+				},
+			},
+			wantErr: []string{"sdk/go/gen/types: RetryPolicy", "fixture.v1.tasks.RetryPolicy", "fixture.v1.agent.RetryPolicy"},
+		},
+		{
+			name: "config named like a generated helper",
+			g: &Generator{
+				outputDir:   "sdk/go/gen/workflow",
+				taskConfigs: []*TaskConfigSchema{timeoutConfig("TaskConfig", "fixture.v1.TaskConfig")},
+			},
+			wantErr: []string{"TaskConfig is declared by both generated helpers and fixture.v1.TaskConfig"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.g.checkNameCollisions()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("checkNameCollisions() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("checkNameCollisions() succeeded, want collision error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q missing %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadSchemas_NoCollisions(t *testing.T) {
+	// The published schemas must keep generating a package that compiles
+	if _, err := NewGenerator("../schemas", "sdk/go/gen/workflow", "workflow", "", nil); err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+}
+
+// generateInto runs the generator with the schemas in schemaDir in dir and
+// returns the generated files by path relative to dir.
+func generateInto(t *testing.T, schemaDir, dir string) map[string][]byte {
+	t.Helper()
+
+	// Resource args and shared types are written relative to the working
+	// directory
+	t.Chdir(dir)
+
+	g, err := NewGenerator(schemaDir, "sdk/go/gen/workflow", "workflow", "", nil)
+	if err != nil {
+		t.Fatalf("NewGenerator() failed: %v", err)
+	}
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	files := make(map[string][]byte)
+	err = filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		files[path] = data
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	schemaDir, err := filepath.Abs("../schemas")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := generateInto(t, schemaDir, t.TempDir())
+	second := generateInto(t, schemaDir, t.TempDir())
+
+	if len(first) == 0 {
+		t.Fatal("no files generated")
+	}
+	if len(first) != len(second) {
+		t.Fatalf("generated %d files, then %d", len(first), len(second))
+	}
+	for path, data := range first {
+		if !bytes.Equal(data, second[path]) {
+			t.Errorf("%s differs between runs", path)
+		}
+		if bytes.Contains(data, []byte("// Generated: ")) {
+			t.Errorf("%s records the generation time without --stamp", path)
+		}
+	}
+}
+
+func TestGenerate_NamespaceDirs(t *testing.T) {
+	schemaDir, err := filepath.Abs("../schemas")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := generateInto(t, schemaDir, t.TempDir())
+	for _, path := range []string{
+		"sdk/go/iam/gen/apikeyspec_args.go",
+		"sdk/go/iam/gen/iampolicyspec_args.go",
+		"sdk/go/tenancy/gen/organizationspec_args.go",
+	} {
+		data, ok := files[path]
+		if !ok {
+			t.Errorf("%s was not generated", path)
+			continue
+		}
+		if !bytes.Contains(data, []byte("package gen\n")) {
+			t.Errorf("%s does not declare package gen", path)
+		}
+	}
+
+	g := &Generator{namespaceDirs: map[string]string{"tenancy": "sdk/go/org/gen"}}
+	schema := &TaskConfigSchema{
+		ProtoType: "ai.stigmer.tenancy.organization.v1.OrganizationSpec",
+		ProtoFile: "apis/ai/stigmer/tenancy/organization/v1/spec.proto",
+	}
+	if got := g.getOutputDir(schema); got != "sdk/go/org/gen" {
+		t.Errorf("getOutputDir() = %q, want the --namespace-dir override", got)
+	}
+}
+
+func TestNamespaceDirsFlag(t *testing.T) {
+	f := namespaceDirsFlag{}
+	if err := f.Set("iam=sdk/go/iam/gen"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if f["iam"] != "sdk/go/iam/gen" {
+		t.Errorf("namespace dirs = %v", f)
+	}
+	if err := f.Set("iam"); err == nil {
+		t.Error("Set() accepted a value without a directory")
+	}
+}
@@ -5,6 +5,8 @@
 // - Field names, types, and metadata
 // - Comments and documentation
 // - buf.validate validation rules
+// - oneof groups (mutually exclusive fields)
+// - semantic hints (integer fields holding a number of seconds)
 //
 // Output is JSON schema files used by the code generator.
 //
@@ -21,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
@@ -48,6 +51,7 @@ type TaskConfigSchema struct {
 	ProtoType   string         `json:"protoType"`
 	ProtoFile   string         `json:"protoFile"`
 	Fields      []*FieldSchema `json:"fields"`
+	Oneofs      []*OneofSchema `json:"oneofs,omitempty"`
 }
 
 type TypeSchema struct {
@@ -56,6 +60,16 @@ type TypeSchema struct {
 	ProtoType   string         `json:"protoType"`
 	ProtoFile   string         `json:"protoFile"`
 	Fields      []*FieldSchema `json:"fields"`
+	Oneofs      []*OneofSchema `json:"oneofs,omitempty"`
+}
+
+// OneofSchema groups fields that are mutually exclusive (a proto oneof).
+// Fields lists the member field names as they appear in FieldSchema.Name.
+type OneofSchema struct {
+	Name        string   `json:"name"`
+	ProtoOneof  string   `json:"protoOneof"`
+	Description string   `json:"description,omitempty"`
+	Fields      []string `json:"fields"`
 }
 
 type FieldSchema struct {
@@ -67,6 +81,7 @@ type FieldSchema struct {
 	Required     bool        `json:"required"`
 	IsExpression bool        `json:"isExpression,omitempty"`
 	Validation   *Validation `json:"validation,omitempty"`
+	Semantic     string      `json:"semantic,omitempty"` // "duration" for integer fields holding whole seconds
 }
 
 type TypeSpec struct {
@@ -542,6 +557,8 @@ func parseSharedType(msg *desc.MessageDescriptor, fd *desc.FileDescriptor) *Type
 		schema.Fields = append(schema.Fields, fieldSchema)
 	}
 
+	schema.Oneofs = extractOneofs(msg)
+
 	return schema
 }
 
@@ -577,9 +594,42 @@ func parseTaskConfig(msg *desc.MessageDescriptor, fd *desc.FileDescriptor) (*Tas
 		schema.Fields = append(schema.Fields, fieldSchema)
 	}
 
+	schema.Oneofs = extractOneofs(msg)
+
 	return schema, nil
 }
 
+// extractOneofs extracts oneof groups from a message descriptor.
+// Synthetic oneofs (generated for proto3 optional fields) are skipped since
+// they hold a single field and carry no exclusivity constraint.
+func extractOneofs(msg *desc.MessageDescriptor) []*OneofSchema {
+	var oneofs []*OneofSchema
+
+	for _, oneof := range msg.GetOneOfs() {
+		if oneof.IsSynthetic() {
+			continue
+		}
+
+		oneofSchema := &OneofSchema{
+			Name:       toCamelCase(oneof.GetName(), true),
+			ProtoOneof: oneof.GetName(),
+			Fields:     make([]string, 0, len(oneof.GetChoices())),
+		}
+
+		if sourceInfo := oneof.GetSourceInfo(); sourceInfo != nil {
+			oneofSchema.Description = strings.TrimSpace(sourceInfo.GetLeadingComments())
+		}
+
+		for _, field := range oneof.GetChoices() {
+			oneofSchema.Fields = append(oneofSchema.Fields, toCamelCase(field.GetName(), true))
+		}
+
+		oneofs = append(oneofs, oneofSchema)
+	}
+
+	return oneofs
+}
+
 // extractFieldSchema extracts field schema from a proto field descriptor
 func extractFieldSchema(field *desc.FieldDescriptor) (*FieldSchema, error) {
 	// Extract field description from comments
@@ -605,6 +655,8 @@ func extractFieldSchema(field *desc.FieldDescriptor) (*FieldSchema, error) {
 		fieldSchema.Required = true
 	}
 
+	fieldSchema.Semantic = extractSemantic(field.GetName(), fieldSchema.Type, description)
+
 	return fieldSchema, nil
 }
 
@@ -836,6 +888,22 @@ func extractValidation(field *desc.FieldDescriptor) *Validation {
 	return validation
 }
 
+// secondsComment matches field comments that state the value is in seconds.
+var secondsComment = regexp.MustCompile(`(?i)\b(in|of) seconds\b`)
+
+// extractSemantic returns the semantic hint for a field. Integer fields named
+// "*_seconds" or documented as "in seconds" are marked "duration", so the
+// generator can offer time.Duration accessors instead of a bare int.
+func extractSemantic(name string, typeSpec TypeSpec, description string) string {
+	if typeSpec.Kind != "int32" && typeSpec.Kind != "int64" {
+		return ""
+	}
+	if name == "seconds" || strings.HasSuffix(name, "_seconds") || secondsComment.MatchString(description) {
+		return "duration"
+	}
+	return ""
+}
+
 // extractIsExpression extracts the is_expression field option
 func extractIsExpression(field *desc.FieldDescriptor) bool {
 	opts := field.GetFieldOptions()
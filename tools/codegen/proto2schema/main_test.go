@@ -0,0 +1,124 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+)
+
+// parseFixture parses a proto file from testdata.
+func parseFixture(t *testing.T, path string) *desc.FileDescriptor {
+	t.Helper()
+
+	parser := &protoparse.Parser{
+		ImportPaths:           []string{"testdata"},
+		IncludeSourceCodeInfo: true,
+	}
+	fds, err := parser.ParseFiles(path)
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", path, err)
+	}
+	return fds[0]
+}
+
+func TestParseTaskConfig_Oneofs(t *testing.T) {
+	fd := parseFixture(t, "fixture/v1/oneof.proto")
+	msg := fd.FindMessage("fixture.v1.EndpointTaskConfig")
+	if msg == nil {
+		t.Fatal("EndpointTaskConfig not found in fixture")
+	}
+
+	schema, err := parseTaskConfig(msg, fd)
+	if err != nil {
+		t.Fatalf("parseTaskConfig() failed: %v", err)
+	}
+
+	// Oneof members are still emitted as regular fields
+	if got := len(schema.Fields); got != 4 {
+		t.Fatalf("len(Fields) = %d, want 4", got)
+	}
+
+	// The synthetic oneof behind "optional timeout_seconds" is not a group
+	if got := len(schema.Oneofs); got != 1 {
+		t.Fatalf("len(Oneofs) = %d, want 1", got)
+	}
+
+	oneof := schema.Oneofs[0]
+	if oneof.Name != "Target" || oneof.ProtoOneof != "target" {
+		t.Errorf("oneof name = %s/%s, want Target/target", oneof.Name, oneof.ProtoOneof)
+	}
+	if oneof.Description != "How the endpoint is addressed." {
+		t.Errorf("oneof description = %q", oneof.Description)
+	}
+	if want := []string{"Uri", "Service"}; !reflect.DeepEqual(oneof.Fields, want) {
+		t.Errorf("oneof fields = %v, want %v", oneof.Fields, want)
+	}
+}
+
+func TestParseSharedType_Oneofs(t *testing.T) {
+	fd := parseFixture(t, "fixture/v1/oneof.proto")
+
+	sharedTypes := make(map[string]*TypeSchema)
+	collectNestedTypes(fd.FindMessage("fixture.v1.EndpointTaskConfig"), fd, sharedTypes)
+
+	serviceRef, ok := sharedTypes["ServiceRef"]
+	if !ok {
+		t.Fatal("ServiceRef not collected as shared type")
+	}
+	if got := len(serviceRef.Oneofs); got != 1 {
+		t.Fatalf("len(Oneofs) = %d, want 1", got)
+	}
+	if want := []string{"Port", "PortName"}; !reflect.DeepEqual(serviceRef.Oneofs[0].Fields, want) {
+		t.Errorf("oneof fields = %v, want %v", serviceRef.Oneofs[0].Fields, want)
+	}
+}
+
+func TestParseTaskConfig_DurationSemantic(t *testing.T) {
+	fd := parseFixture(t, "fixture/v1/oneof.proto")
+	schema, err := parseTaskConfig(fd.FindMessage("fixture.v1.EndpointTaskConfig"), fd)
+	if err != nil {
+		t.Fatalf("parseTaskConfig() failed: %v", err)
+	}
+
+	for _, field := range schema.Fields {
+		want := ""
+		if field.Name == "TimeoutSeconds" {
+			want = "duration"
+		}
+		if field.Semantic != want {
+			t.Errorf("%s semantic = %q, want %q", field.Name, field.Semantic, want)
+		}
+	}
+}
+
+func TestExtractSemantic(t *testing.T) {
+	int32Type := TypeSpec{Kind: "int32"}
+	tests := []struct {
+		name        string
+		field       string
+		typeSpec    TypeSpec
+		description string
+		want        string
+	}{
+		{"seconds suffix", "timeout_seconds", int32Type, "Request timeout.", "duration"},
+		{"bare seconds field", "seconds", int32Type, "", "duration"},
+		{"in seconds comment", "timeout", int32Type, "Timeout for agent execution in seconds.\n Default: 300", "duration"},
+		{"of seconds comment", "delay", TypeSpec{Kind: "int64"}, "Number of seconds to wait.", "duration"},
+		{"comment is case-insensitive", "ttl", int32Type, "Cache lifetime, In Seconds.", "duration"},
+		{"non-integer field", "timeout_seconds", TypeSpec{Kind: "string"}, "Timeout in seconds.", ""},
+		{"milliseconds", "timeout_ms", int32Type, "Timeout in milliseconds.", ""},
+		{"seconds not as unit", "max_retries", int32Type, "Retries before giving up; backoff doubles the seconds.", ""},
+		{"seconds as a word prefix", "window", int32Type, "Window in secondsish units.", ""},
+		{"plain count", "count", int32Type, "Number of items.", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractSemantic(tt.field, tt.typeSpec, tt.description); got != tt.want {
+				t.Errorf("extractSemantic(%q) = %q, want %q", tt.field, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,5 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: agentexecutionspec_args.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package agentexecution
 
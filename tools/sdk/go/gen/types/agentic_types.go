@@ -1,6 +1,5 @@
 // Code generated by stigmer-codegen. DO NOT EDIT.
 // Source: agentic_types.go
-// Generated: 2026-01-26T04:50:42+05:30
 
 package types
 